@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// logFunc writes a crasher log line.
+type logFunc func(format string, args ...any)
+
+// growMemory allocates and touches rateMiB MiB per second until maxMiB is
+// held (0 = no cap), so the resident memory grows like a leaking service.
+func growMemory(rateMiB, maxMiB int, log logFunc) {
+	var held [][]byte
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		if maxMiB > 0 && len(held)*rateMiB >= maxMiB {
+			continue
+		}
+		block := make([]byte, rateMiB<<20)
+		// Touch every page so the memory is resident, not just reserved.
+		for i := 0; i < len(block); i += os.Getpagesize() {
+			block[i] = 1
+		}
+		held = append(held, block)
+		log("holding %d MiB", len(held)*rateMiB)
+	}
+}
+
+// leakFDs opens rate descriptors per second and never closes them.
+func leakFDs(rate int, log logFunc) {
+	var leaked []*os.File
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		for range rate {
+			f, err := os.Open(os.DevNull)
+			if err != nil {
+				log("fd leak stopped: %v", err)
+				return
+			}
+			leaked = append(leaked, f)
+		}
+		log("leaked %d descriptors", len(leaked))
+	}
+}
+
+// notifyReady sends READY=1 to the sd_notify socket after delay.
+func notifyReady(delay time.Duration, log logFunc) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		log("NOTIFY_SOCKET not set, readiness not sent")
+		return
+	}
+	time.Sleep(delay)
+	// A leading @ names an abstract socket.
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		log("sd_notify failed: %v", err)
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("READY=1\nSTATUS=crasher ready\n")); err != nil {
+		log("sd_notify failed: %v", err)
+		return
+	}
+	log("sent READY=1")
+}
+
+// serveUDP echoes every datagram received on port.
+func serveUDP(port int, log logFunc) {
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		log("failed to listen on udp port %d: %v", port, err)
+		os.Exit(1)
+	}
+	log("starting UDP listener on port %d", port)
+	buf := make([]byte, 64*1024)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		_, _ = conn.WriteTo(buf[:n], addr)
+	}
+}
+
+// floodLogs writes rate lines of size bytes per second to out.
+func floodLogs(out io.Writer, rate, size int) {
+	line := []byte(strings.Repeat("x", max(size-1, 0)) + "\n")
+	// Spread the lines over the second in batches of about 10 ms.
+	batches := min(rate, 100)
+	perBatch := rate / batches
+	ticker := time.NewTicker(time.Second / time.Duration(batches))
+	defer ticker.Stop()
+	for range ticker.C {
+		for range perBatch {
+			if _, err := out.Write(line); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -11,20 +11,30 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 )
 
 var (
-	exitCode   = flag.Int("exit", 0, "Exit code to return")
-	delay      = flag.Duration("delay", 0, "Delay before exit (0 = immediate)")
-	port       = flag.Int("port", 0, "TCP port to listen on (0 = no listener)")
-	httpHealth = flag.Bool("http", false, "Serve HTTP /health endpoint")
+	exitCode    = flag.Int("exit", 0, "Exit code to return")
+	delay       = flag.Duration("delay", 0, "Delay before exit (0 = immediate)")
+	port        = flag.Int("port", 0, "TCP port to listen on (0 = no listener)")
+	httpHealth  = flag.Bool("http", false, "Serve HTTP /health endpoint")
 	spawnOrphan = flag.Bool("orphan", false, "Spawn an orphan process before exit")
-	ignoreTerm = flag.Bool("ignore-term", false, "Ignore SIGTERM signal")
-	logFile    = flag.String("log", "", "Log file path (empty = stdout)")
-	crashAfter = flag.Int("crash-after", 0, "Crash after N seconds (0 = use delay)")
-	healthy    = flag.Bool("healthy", true, "Health endpoint returns 200 (false = 503)")
+	orphanSleep = flag.Duration("orphan-sleep", 60*time.Second, "Lifetime of the spawned orphan process")
+	ignoreTerm  = flag.Bool("ignore-term", false, "Ignore SIGTERM signal")
+	logFile     = flag.String("log", "", "Log file path (empty = stdout)")
+	crashAfter  = flag.Int("crash-after", 0, "Crash after N seconds (0 = use delay)")
+	healthy     = flag.Bool("healthy", true, "Health endpoint returns 200 (false = 503)")
+	termDelay   = flag.Duration("term-delay", 0, "Keep running this long after SIGTERM, then exit 0 (slow shutdown)")
+	memGrowth   = flag.Int("mem-growth", 0, "Allocate N MiB per second (0 = no growth)")
+	memMax      = flag.Int("mem-max", 0, "Stop growing at N MiB (0 = no cap)")
+	fdLeak      = flag.Int("fd-leak", 0, "Open N descriptors per second, never closed (0 = no leak)")
+	notify      = flag.Duration("notify-ready", -1, "Send sd_notify READY=1 after this delay (negative = never)")
+	udpPort     = flag.Int("udp-port", 0, "UDP echo port (0 = no listener)")
+	flood       = flag.Int("flood", 0, "Write N log lines per second (0 = no flooding)")
+	floodSize   = flag.Int("flood-size", 128, "Size in bytes of each flooded line")
 )
 
 func main() {
@@ -46,8 +56,8 @@ func main() {
 		fmt.Fprintf(logOutput, "[crasher] %s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
 	}
 
-	log("starting with exit=%d delay=%v port=%d http=%v orphan=%v ignore-term=%v crash-after=%d healthy=%v",
-		*exitCode, *delay, *port, *httpHealth, *spawnOrphan, *ignoreTerm, *crashAfter, *healthy)
+	log("starting with exit=%d delay=%v port=%d http=%v orphan=%v ignore-term=%v crash-after=%d healthy=%v term-delay=%v",
+		*exitCode, *delay, *port, *httpHealth, *spawnOrphan, *ignoreTerm, *crashAfter, *healthy, *termDelay)
 
 	// Setup signal handling
 	sigCh := make(chan os.Signal, 1)
@@ -58,6 +68,10 @@ func main() {
 		signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
 		go func() {
 			sig := <-sigCh
+			if *termDelay > 0 {
+				log("received signal: %v, exiting in %v", sig, *termDelay)
+				time.Sleep(*termDelay)
+			}
 			log("received signal: %v, exiting gracefully", sig)
 			os.Exit(0)
 		}()
@@ -113,10 +127,27 @@ func main() {
 		}()
 	}
 
+	// Start the resource and output behaviors
+	if *memGrowth > 0 {
+		go growMemory(*memGrowth, *memMax, log)
+	}
+	if *fdLeak > 0 {
+		go leakFDs(*fdLeak, log)
+	}
+	if *notify >= 0 {
+		go notifyReady(*notify, log)
+	}
+	if *udpPort > 0 {
+		go serveUDP(*udpPort, log)
+	}
+	if *flood > 0 {
+		go floodLogs(logOutput, *flood, *floodSize)
+	}
+
 	// Spawn orphan process if requested
 	if *spawnOrphan {
 		log("spawning orphan process")
-		cmd := exec.Command("sleep", "60")
+		cmd := exec.Command("sleep", strconv.Itoa(int(orphanSleep.Seconds())))
 		if err := cmd.Start(); err != nil {
 			log("failed to spawn orphan: %v", err)
 		} else {
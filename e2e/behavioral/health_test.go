@@ -58,6 +58,35 @@ func TestTCPHealthProbe(t *testing.T) {
 	assert.Equal(t, 0, code, "TCP connection to port 9090 should succeed")
 }
 
+// TestUDPHealthProbe verifies that the supervisor can perform UDP health probes.
+func TestUDPHealthProbe(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	// No need to expose ports - we test from inside the container
+	tc := startContainer(t, "health-udp.yaml")
+
+	// Wait for crasher to start
+	require.True(t, tc.waitForProcess("crasher", 10*time.Second),
+		"crasher should start")
+
+	// Give the UDP listener time to start
+	time.Sleep(2 * time.Second)
+
+	// Verify the listener echoes the probe payload using netcat inside the container
+	code, output, err := tc.exec("sh", "-c", "echo PING | nc -u -w 2 localhost 9091")
+	require.NoError(t, err, "netcat should execute")
+	require.Equal(t, 0, code, "netcat should succeed")
+	assert.Contains(t, output, "PING", "UDP listener should echo the payload")
+
+	// Several probe rounds later the service must still be the same process
+	pid := tc.getProcessPID("crasher")
+	time.Sleep(6 * time.Second)
+	assert.Equal(t, pid, tc.getProcessPID("crasher"),
+		"passing UDP probes should not restart the service")
+}
+
 // TestHealthProbeFailureTriggersRestart verifies that when a health probe
 // fails repeatedly, the service is restarted.
 func TestHealthProbeFailureTriggersRestart(t *testing.T) {
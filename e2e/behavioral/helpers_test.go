@@ -1,7 +1,9 @@
 package behavioral_test
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -34,13 +36,23 @@ type testContainer struct {
 // startContainer creates and starts a container with the specified config file.
 func startContainer(t *testing.T, configFile string) *testContainer {
 	t.Helper()
-	ctx := context.Background()
 
 	// Get absolute paths for the build context
 	absPath, err := filepath.Abs("../..")
 	require.NoError(t, err, "failed to get absolute path")
 
 	configPath := fmt.Sprintf("e2e/behavioral/testdata/%s", configFile)
+	return startContainerFromFile(t, filepath.Join(absPath, configPath))
+}
+
+// startContainerFromFile creates and starts a container with a config file
+// anywhere on the host, such as one generated by the test.
+func startContainerFromFile(t *testing.T, hostConfigPath string) *testContainer {
+	t.Helper()
+	ctx := context.Background()
+
+	absPath, err := filepath.Abs("../..")
+	require.NoError(t, err, "failed to get absolute path")
 
 	req := testcontainers.ContainerRequest{
 		FromDockerfile: testcontainers.FromDockerfile{
@@ -50,7 +62,7 @@ func startContainer(t *testing.T, configFile string) *testContainer {
 		Cmd: []string{"--config", "/etc/daemon/config.yaml"},
 		Files: []testcontainers.ContainerFile{
 			{
-				HostFilePath:      filepath.Join(absPath, configPath),
+				HostFilePath:      hostConfigPath,
 				ContainerFilePath: "/etc/daemon/config.yaml",
 				FileMode:          0644,
 			},
@@ -235,6 +247,14 @@ func (tc *testContainer) getZombieCount() int {
 	return count
 }
 
+// getRSSKiB returns the resident memory of a process in KiB.
+func (tc *testContainer) getRSSKiB(pid int) int {
+	tc.t.Helper()
+	_, output, _ := tc.exec("sh", "-c", fmt.Sprintf("awk '/^VmRSS:/ {print $2}' /proc/%d/status", pid))
+	rss, _ := strconv.Atoi(strings.TrimSpace(output))
+	return rss
+}
+
 // getLogs returns the container logs.
 func (tc *testContainer) getLogs() string {
 	tc.t.Helper()
@@ -308,3 +328,112 @@ func (tc *testContainer) waitForLogPattern(pattern string, timeout time.Duration
 	}
 	return false
 }
+
+// daemonEvent is one line of the daemon JSON event log.
+type daemonEvent struct {
+	// TS is the event time, to the second.
+	TS time.Time `json:"ts"`
+	// Level is the log level.
+	Level string `json:"level"`
+	// Service is the service name, empty for daemon events.
+	Service string `json:"service"`
+	// Event is the event name.
+	Event string `json:"event"`
+	// Message is the human-readable message.
+	Message string `json:"message"`
+	// Fields holds every field of the line, metadata included.
+	Fields map[string]any `json:"-"`
+}
+
+// readFile returns the content of a file in the container.
+// It also works once the container has stopped.
+func (tc *testContainer) readFile(path string) (string, error) {
+	tc.t.Helper()
+	reader, err := tc.container.CopyFileFromContainer(tc.ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	return string(data), err
+}
+
+// readEvents returns the events of a daemon JSON log, in write order.
+func (tc *testContainer) readEvents(path string) []daemonEvent {
+	tc.t.Helper()
+	content, err := tc.readFile(path)
+	if err != nil {
+		return nil
+	}
+	var events []daemonEvent
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		var event daemonEvent
+		if json.Unmarshal(scanner.Bytes(), &event) != nil {
+			// Skip a line still being written
+			continue
+		}
+		_ = json.Unmarshal(scanner.Bytes(), &event.Fields)
+		events = append(events, event)
+	}
+	return events
+}
+
+// waitForEvents polls a daemon JSON log until the events satisfy match.
+func (tc *testContainer) waitForEvents(path string, timeout time.Duration, match func([]daemonEvent) bool) bool {
+	tc.t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if match(tc.readEvents(path)) {
+			return true
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return false
+}
+
+// waitForContainerExit waits for the container to stop and returns its exit code.
+func (tc *testContainer) waitForContainerExit(timeout time.Duration) (int, bool) {
+	tc.t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		state, err := tc.container.State(tc.ctx)
+		if err == nil && !state.Running {
+			return state.ExitCode, true
+		}
+		time.Sleep(defaultPollInterval)
+	}
+	return 0, false
+}
+
+// countEvents returns the number of events of a service with the given name.
+func countEvents(events []daemonEvent, service, name string) int {
+	count := 0
+	for _, event := range events {
+		if event.Service == service && event.Event == name {
+			count++
+		}
+	}
+	return count
+}
+
+// indexOfEvent returns the position of the first event matching the
+// service, name and fields, or -1.
+func indexOfEvent(events []daemonEvent, service, name string, fields map[string]any) int {
+	for i, event := range events {
+		if event.Service != service || event.Event != name {
+			continue
+		}
+		matched := true
+		for key, want := range fields {
+			if event.Fields[key] != want {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,110 @@
+package behavioral_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pid1EventsPath is the daemon JSON event log written by pid1-events.yaml.
+const pid1EventsPath string = "/var/log/supervizio/events.json"
+
+// TestPID1ReapsRestartsAndAdoptedOrphans verifies, through the emitted
+// events, that supervizio as PID 1 restarts an exiting service, adopts the
+// orphans it leaves behind and reaps them once they exit.
+func TestPID1ReapsRestartsAndAdoptedOrphans(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	tc := startContainer(t, "pid1-events.yaml")
+
+	// Orphans outlive the spawner by one second, adopted by PID 1
+	adopted := false
+	deadline := time.Now().Add(10 * time.Second)
+	for !adopted && time.Now().Before(deadline) {
+		_, output, _ := tc.exec("sh", "-c", "ps -o ppid= -C sleep")
+		for _, ppid := range strings.Fields(output) {
+			adopted = adopted || ppid == "1"
+		}
+		time.Sleep(defaultPollInterval)
+	}
+	assert.True(t, adopted, "orphan should be adopted by PID 1")
+
+	// Initial run plus two restarts, each a clean exit
+	require.True(t, tc.waitForEvents(pid1EventsPath, 20*time.Second, func(events []daemonEvent) bool {
+		return countEvents(events, "spawner", "stopped") >= 3
+	}), "spawner should stop three times")
+
+	events := tc.readEvents(pid1EventsPath)
+	assert.GreaterOrEqual(t, countEvents(events, "spawner", "started"), 3,
+		"spawner should be started once and restarted twice")
+	assert.GreaterOrEqual(t, countEvents(events, "spawner", "restarting"), 2,
+		"each restart should be announced")
+
+	// Every run got its own process
+	pids := make(map[any]bool)
+	for _, event := range events {
+		if event.Service == "spawner" && event.Event == "started" {
+			pids[event.Fields["pid"]] = true
+		}
+		if event.Service == "spawner" && event.Event == "stopped" {
+			assert.Equal(t, float64(0), event.Fields["exit_code"], "spawner should exit cleanly")
+		}
+	}
+	assert.GreaterOrEqual(t, len(pids), 3, "each run should have its own PID")
+
+	// Let the last orphan exit, then nothing must be left unreaped
+	time.Sleep(3 * time.Second)
+	assert.False(t, tc.isProcessRunning("sleep"), "orphans should have exited")
+	assert.Equal(t, 0, tc.getZombieCount(), "PID 1 should reap adopted orphans")
+	assert.True(t, tc.isProcessRunning("crasher"), "worker should still run")
+}
+
+// TestPID1ForwardsTermAndShutsDownInOrder verifies that SIGTERM sent to
+// PID 1 reaches the services, and that the daemon reports stopping, waits
+// for the slow service and only then reports stopped and exits.
+func TestPID1ForwardsTermAndShutsDownInOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	tc := startContainer(t, "pid1-events.yaml")
+
+	require.True(t, tc.waitForEvents(pid1EventsPath, 10*time.Second, func(events []daemonEvent) bool {
+		return countEvents(events, "worker", "started") > 0
+	}), "worker should start")
+
+	_, _, err := tc.exec("kill", "-TERM", "1")
+	require.NoError(t, err, "should be able to send SIGTERM to PID 1")
+
+	exitCode, exited := tc.waitForContainerExit(15 * time.Second)
+	require.True(t, exited, "container should stop after SIGTERM to PID 1")
+	assert.Equal(t, 0, exitCode, "supervizio should exit cleanly")
+
+	// The service received the forwarded signal and took its time to stop
+	workerLog, err := tc.readFile("/var/log/supervizio/worker.log")
+	require.NoError(t, err)
+	assert.Contains(t, workerLog, "received signal: terminated, exiting in 1s")
+	assert.Contains(t, workerLog, "exiting gracefully")
+
+	events := tc.readEvents(pid1EventsPath)
+	started := indexOfEvent(events, "", "daemon_started", nil)
+	worker := indexOfEvent(events, "worker", "started", nil)
+	stopping := indexOfEvent(events, "", "supervisor_state", map[string]any{"to": "stopping"})
+	stopped := indexOfEvent(events, "", "supervisor_state", map[string]any{"to": "stopped"})
+	require.NotEqual(t, -1, started, "daemon_started should be emitted")
+	require.NotEqual(t, -1, worker, "worker start should be emitted")
+	require.NotEqual(t, -1, stopping, "stopping state should be emitted")
+	require.NotEqual(t, -1, stopped, "stopped state should be emitted")
+
+	assert.Less(t, worker, stopping, "services start before the shutdown")
+	assert.Less(t, stopping, stopped, "stopping comes before stopped")
+	assert.Equal(t, -1, indexOfEvent(events[stopped+1:], "", "supervisor_state", nil),
+		"stopped should be the last state")
+	assert.GreaterOrEqual(t, events[stopped].TS.Sub(events[stopping].TS), time.Second,
+		"stopped should wait for the slow worker")
+}
@@ -0,0 +1,81 @@
+package behavioral_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stress enables the stress profile, too long and heavy for every run.
+var stress = flag.Bool("stress", false, "Run the stress profile (hundreds of crashing services)")
+
+const (
+	// stressServices is the number of crashing services.
+	stressServices int = 200
+	// stressDuration is how long the services keep crashing.
+	stressDuration time.Duration = 60 * time.Second
+	// stressSampleInterval is the interval between budget checks.
+	stressSampleInterval time.Duration = 5 * time.Second
+	// stressMaxRSSKiB is the resident memory budget of the daemon.
+	stressMaxRSSKiB int = 256 * 1024
+	// stressMaxZombies is the number of unreaped exits tolerated at a sample.
+	stressMaxZombies int = stressServices / 10
+)
+
+// writeStressConfig writes a configuration with N crasher services, each
+// crashing one second after it starts and restarted without limit.
+func writeStressConfig(t *testing.T, services int) string {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString("version: \"1\"\n\nlogging:\n  base_dir: /var/log/supervizio\n\nservices:\n")
+	for i := range services {
+		fmt.Fprintf(&b, `  - name: crasher-%d
+    command: /usr/local/bin/crasher
+    args: ["--delay=1s", "--exit=1"]
+    restart:
+      policy: always
+      max_retries: 1000000
+      delay: 1s
+`, i)
+	}
+	path := filepath.Join(t.TempDir(), "stress.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(b.String()), 0o644))
+	return path
+}
+
+// TestStressCrashingServices verifies the daemon stays within its budget
+// while hundreds of services crash and restart continuously.
+func TestStressCrashingServices(t *testing.T) {
+	if !*stress {
+		t.Skip("stress profile disabled, run with -stress")
+	}
+
+	tc := startContainerFromFile(t, writeStressConfig(t, stressServices))
+	require.True(t, tc.waitForProcess("crasher", 30*time.Second),
+		"crashers should start")
+
+	pid := tc.getProcessPID("supervizio")
+	require.Positive(t, pid, "supervizio should be running")
+
+	maxRSS := 0
+	deadline := time.Now().Add(stressDuration)
+	for time.Now().Before(deadline) {
+		time.Sleep(stressSampleInterval)
+
+		require.True(t, tc.isRunning(), "supervizio should survive the crash loop")
+		rss := tc.getRSSKiB(pid)
+		maxRSS = max(maxRSS, rss)
+		assert.LessOrEqual(t, rss, stressMaxRSSKiB, "daemon resident memory over budget")
+		assert.LessOrEqual(t, tc.getZombieCount(), stressMaxZombies, "exits not reaped in time")
+	}
+
+	t.Logf("peak daemon RSS: %d KiB for %d crashing services", maxRSS, stressServices)
+	assert.Positive(t, tc.countProcesses("crasher"), "crashers should keep restarting")
+}
@@ -37,6 +37,8 @@ const (
 	ProcessState_PROCESS_STATE_RUNNING     ProcessState = 3
 	ProcessState_PROCESS_STATE_STOPPING    ProcessState = 4
 	ProcessState_PROCESS_STATE_FAILED      ProcessState = 5
+	// Not started: the host does not meet the start conditions of the service.
+	ProcessState_PROCESS_STATE_SKIPPED ProcessState = 6
 )
 
 // Enum value maps for ProcessState.
@@ -48,6 +50,7 @@ var (
 		3: "PROCESS_STATE_RUNNING",
 		4: "PROCESS_STATE_STOPPING",
 		5: "PROCESS_STATE_FAILED",
+		6: "PROCESS_STATE_SKIPPED",
 	}
 	ProcessState_value = map[string]int32{
 		"PROCESS_STATE_UNSPECIFIED": 0,
@@ -56,6 +59,7 @@ var (
 		"PROCESS_STATE_RUNNING":     3,
 		"PROCESS_STATE_STOPPING":    4,
 		"PROCESS_STATE_FAILED":      5,
+		"PROCESS_STATE_SKIPPED":     6,
 	}
 )
 
@@ -86,6 +90,169 @@ func (ProcessState) EnumDescriptor() ([]byte, []int) {
 	return file_daemon_proto_rawDescGZIP(), []int{0}
 }
 
+// BootStatus is the outcome of one service during the initial startup.
+type BootStatus int32
+
+const (
+	BootStatus_BOOT_STATUS_UNSPECIFIED BootStatus = 0
+	BootStatus_BOOT_STATUS_PENDING     BootStatus = 1
+	BootStatus_BOOT_STATUS_READY       BootStatus = 2
+	BootStatus_BOOT_STATUS_FAILED      BootStatus = 3
+	// Not started: the host does not meet the start conditions of the service.
+	BootStatus_BOOT_STATUS_SKIPPED BootStatus = 4
+)
+
+// Enum value maps for BootStatus.
+var (
+	BootStatus_name = map[int32]string{
+		0: "BOOT_STATUS_UNSPECIFIED",
+		1: "BOOT_STATUS_PENDING",
+		2: "BOOT_STATUS_READY",
+		3: "BOOT_STATUS_FAILED",
+		4: "BOOT_STATUS_SKIPPED",
+	}
+	BootStatus_value = map[string]int32{
+		"BOOT_STATUS_UNSPECIFIED": 0,
+		"BOOT_STATUS_PENDING":     1,
+		"BOOT_STATUS_READY":       2,
+		"BOOT_STATUS_FAILED":      3,
+		"BOOT_STATUS_SKIPPED":     4,
+	}
+)
+
+func (x BootStatus) Enum() *BootStatus {
+	p := new(BootStatus)
+	*p = x
+	return p
+}
+
+func (x BootStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BootStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_enumTypes[1].Descriptor()
+}
+
+func (BootStatus) Type() protoreflect.EnumType {
+	return &file_daemon_proto_enumTypes[1]
+}
+
+func (x BootStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BootStatus.Descriptor instead.
+func (BootStatus) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{1}
+}
+
+// ReloadAction is what a configuration reload does to one service.
+type ReloadAction int32
+
+const (
+	ReloadAction_RELOAD_ACTION_UNSPECIFIED ReloadAction = 0
+	ReloadAction_RELOAD_ACTION_ADD         ReloadAction = 1
+	ReloadAction_RELOAD_ACTION_REMOVE      ReloadAction = 2
+	ReloadAction_RELOAD_ACTION_RESTART     ReloadAction = 3
+)
+
+// Enum value maps for ReloadAction.
+var (
+	ReloadAction_name = map[int32]string{
+		0: "RELOAD_ACTION_UNSPECIFIED",
+		1: "RELOAD_ACTION_ADD",
+		2: "RELOAD_ACTION_REMOVE",
+		3: "RELOAD_ACTION_RESTART",
+	}
+	ReloadAction_value = map[string]int32{
+		"RELOAD_ACTION_UNSPECIFIED": 0,
+		"RELOAD_ACTION_ADD":         1,
+		"RELOAD_ACTION_REMOVE":      2,
+		"RELOAD_ACTION_RESTART":     3,
+	}
+)
+
+func (x ReloadAction) Enum() *ReloadAction {
+	p := new(ReloadAction)
+	*p = x
+	return p
+}
+
+func (x ReloadAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReloadAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_enumTypes[2].Descriptor()
+}
+
+func (ReloadAction) Type() protoreflect.EnumType {
+	return &file_daemon_proto_enumTypes[2]
+}
+
+func (x ReloadAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReloadAction.Descriptor instead.
+func (ReloadAction) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{2}
+}
+
+// ServiceAction is a lifecycle action applied to services.
+type ServiceAction int32
+
+const (
+	ServiceAction_SERVICE_ACTION_UNSPECIFIED ServiceAction = 0
+	ServiceAction_SERVICE_ACTION_START       ServiceAction = 1
+	ServiceAction_SERVICE_ACTION_STOP        ServiceAction = 2
+	ServiceAction_SERVICE_ACTION_RESTART     ServiceAction = 3
+)
+
+// Enum value maps for ServiceAction.
+var (
+	ServiceAction_name = map[int32]string{
+		0: "SERVICE_ACTION_UNSPECIFIED",
+		1: "SERVICE_ACTION_START",
+		2: "SERVICE_ACTION_STOP",
+		3: "SERVICE_ACTION_RESTART",
+	}
+	ServiceAction_value = map[string]int32{
+		"SERVICE_ACTION_UNSPECIFIED": 0,
+		"SERVICE_ACTION_START":       1,
+		"SERVICE_ACTION_STOP":        2,
+		"SERVICE_ACTION_RESTART":     3,
+	}
+)
+
+func (x ServiceAction) Enum() *ServiceAction {
+	p := new(ServiceAction)
+	*p = x
+	return p
+}
+
+func (x ServiceAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_enumTypes[3].Descriptor()
+}
+
+func (ServiceAction) Type() protoreflect.EnumType {
+	return &file_daemon_proto_enumTypes[3]
+}
+
+func (x ServiceAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceAction.Descriptor instead.
+func (ServiceAction) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{3}
+}
+
 // StreamStateRequest configures state streaming.
 type StreamStateRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -279,18 +446,117 @@ func (x *GetProcessRequest) GetServiceName() string {
 	return ""
 }
 
-// ListProcessesResponse contains all process metrics.
+// ListProcessesRequest filters, sorts and pages the listed processes. An
+// empty request lists every process, sorted by service name.
+type ListProcessesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Labels a service must carry, each with the given value; empty matches all.
+	LabelSelector map[string]string `protobuf:"bytes,1,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// States a process must be in; empty matches all.
+	States []ProcessState `protobuf:"varint,2,rep,packed,name=states,proto3,enum=daemon.v1.ProcessState" json:"states,omitempty"`
+	// Sort key (name, state, cpu, memory, restarts, uptime), followed by
+	// " desc" to reverse the order; empty sorts by name.
+	OrderBy string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Largest number of processes to return; zero returns them all.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token of the page to return, from a previous next_page_token; empty
+	// returns the first page.
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// ProcessMetrics fields to fill, by proto name; empty fills them all.
+	// service_name is always filled.
+	Fields        []string `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProcessesRequest) Reset() {
+	*x = ListProcessesRequest{}
+	mi := &file_daemon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProcessesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProcessesRequest) ProtoMessage() {}
+
+func (x *ListProcessesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProcessesRequest.ProtoReflect.Descriptor instead.
+func (*ListProcessesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListProcessesRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
+func (x *ListProcessesRequest) GetStates() []ProcessState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListProcessesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListProcessesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProcessesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProcessesRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// ListProcessesResponse contains a page of process metrics.
 type ListProcessesResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// All supervised process metrics.
-	Processes     []*ProcessMetrics `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	// Supervised process metrics of the page.
+	Processes []*ProcessMetrics `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	// Token of the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of processes matching the filters, across all pages.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListProcessesResponse) Reset() {
 	*x = ListProcessesResponse{}
-	mi := &file_daemon_proto_msgTypes[4]
+	mi := &file_daemon_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -302,7 +568,7 @@ func (x *ListProcessesResponse) String() string {
 func (*ListProcessesResponse) ProtoMessage() {}
 
 func (x *ListProcessesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[4]
+	mi := &file_daemon_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,7 +581,7 @@ func (x *ListProcessesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProcessesResponse.ProtoReflect.Descriptor instead.
 func (*ListProcessesResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{4}
+	return file_daemon_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListProcessesResponse) GetProcesses() []*ProcessMetrics {
@@ -325,6 +591,20 @@ func (x *ListProcessesResponse) GetProcesses() []*ProcessMetrics {
 	return nil
 }
 
+func (x *ListProcessesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListProcessesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
 // DaemonState represents the complete daemon state.
 type DaemonState struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -343,14 +623,17 @@ type DaemonState struct {
 	// Host information.
 	Host *HostInfo `protobuf:"bytes,7,opt,name=host,proto3" json:"host,omitempty"`
 	// Kubernetes information (if running in k8s).
-	Kubernetes    *KubernetesInfo `protobuf:"bytes,8,opt,name=kubernetes,proto3" json:"kubernetes,omitempty"`
+	Kubernetes *KubernetesInfo `protobuf:"bytes,8,opt,name=kubernetes,proto3" json:"kubernetes,omitempty"`
+	// Number of service events applied when the state was taken. A state
+	// whose sequence is at least that of a ServiceSnapshot reflects it.
+	Sequence      uint64 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DaemonState) Reset() {
 	*x = DaemonState{}
-	mi := &file_daemon_proto_msgTypes[5]
+	mi := &file_daemon_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -362,7 +645,7 @@ func (x *DaemonState) String() string {
 func (*DaemonState) ProtoMessage() {}
 
 func (x *DaemonState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[5]
+	mi := &file_daemon_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -375,7 +658,7 @@ func (x *DaemonState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DaemonState.ProtoReflect.Descriptor instead.
 func (*DaemonState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{5}
+	return file_daemon_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DaemonState) GetVersion() string {
@@ -434,6 +717,13 @@ func (x *DaemonState) GetKubernetes() *KubernetesInfo {
 	return nil
 }
 
+func (x *DaemonState) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 // HostInfo contains host system information.
 type HostInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -451,7 +741,7 @@ type HostInfo struct {
 
 func (x *HostInfo) Reset() {
 	*x = HostInfo{}
-	mi := &file_daemon_proto_msgTypes[6]
+	mi := &file_daemon_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -463,7 +753,7 @@ func (x *HostInfo) String() string {
 func (*HostInfo) ProtoMessage() {}
 
 func (x *HostInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[6]
+	mi := &file_daemon_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +766,7 @@ func (x *HostInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HostInfo.ProtoReflect.Descriptor instead.
 func (*HostInfo) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{6}
+	return file_daemon_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *HostInfo) GetHostname() string {
@@ -524,7 +814,7 @@ type KubernetesInfo struct {
 
 func (x *KubernetesInfo) Reset() {
 	*x = KubernetesInfo{}
-	mi := &file_daemon_proto_msgTypes[7]
+	mi := &file_daemon_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -536,7 +826,7 @@ func (x *KubernetesInfo) String() string {
 func (*KubernetesInfo) ProtoMessage() {}
 
 func (x *KubernetesInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[7]
+	mi := &file_daemon_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -549,7 +839,7 @@ func (x *KubernetesInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KubernetesInfo.ProtoReflect.Descriptor instead.
 func (*KubernetesInfo) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{7}
+	return file_daemon_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *KubernetesInfo) GetPodName() string {
@@ -604,14 +894,24 @@ type ProcessMetrics struct {
 	// Last error message (if failed).
 	LastError string `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
 	// Metrics collection timestamp.
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Share of wall time the cgroup was CPU-throttled (0-100).
+	ThrottledPercent float64 `protobuf:"fixed64,12,opt,name=throttled_percent,json=throttledPercent,proto3" json:"throttled_percent,omitempty"`
+	// Cgroup pressure stall information (cgroup v2 only).
+	Pressure *ResourcePressure `protobuf:"bytes,13,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	// Service availability over 1d, 7d and 30d (unset without statistics).
+	Availability *ServiceAvailability `protobuf:"bytes,14,opt,name=availability,proto3" json:"availability,omitempty"`
+	// Labels of the service from its configuration.
+	Labels map[string]string `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Number of open file descriptors.
+	NumFds        uint32 `protobuf:"varint,16,opt,name=num_fds,json=numFds,proto3" json:"num_fds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProcessMetrics) Reset() {
 	*x = ProcessMetrics{}
-	mi := &file_daemon_proto_msgTypes[8]
+	mi := &file_daemon_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -623,7 +923,7 @@ func (x *ProcessMetrics) String() string {
 func (*ProcessMetrics) ProtoMessage() {}
 
 func (x *ProcessMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[8]
+	mi := &file_daemon_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -636,7 +936,7 @@ func (x *ProcessMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessMetrics.ProtoReflect.Descriptor instead.
 func (*ProcessMetrics) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{8}
+	return file_daemon_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ProcessMetrics) GetServiceName() string {
@@ -716,6 +1016,41 @@ func (x *ProcessMetrics) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ProcessMetrics) GetThrottledPercent() float64 {
+	if x != nil {
+		return x.ThrottledPercent
+	}
+	return 0
+}
+
+func (x *ProcessMetrics) GetPressure() *ResourcePressure {
+	if x != nil {
+		return x.Pressure
+	}
+	return nil
+}
+
+func (x *ProcessMetrics) GetAvailability() *ServiceAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *ProcessMetrics) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ProcessMetrics) GetNumFds() uint32 {
+	if x != nil {
+		return x.NumFds
+	}
+	return 0
+}
+
 // ProcessCPU contains CPU metrics for a process.
 type ProcessCPU struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -725,15 +1060,20 @@ type ProcessCPU struct {
 	SystemTimeNs uint64 `protobuf:"varint,2,opt,name=system_time_ns,json=systemTimeNs,proto3" json:"system_time_ns,omitempty"`
 	// Total CPU time in nanoseconds.
 	TotalTimeNs uint64 `protobuf:"varint,3,opt,name=total_time_ns,json=totalTimeNs,proto3" json:"total_time_ns,omitempty"`
-	// CPU usage percentage (0-100 per core).
-	UsagePercent  float64 `protobuf:"fixed64,4,opt,name=usage_percent,json=usagePercent,proto3" json:"usage_percent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// CPU usage percentage in the configured percent mode: per_core_percent
+	// or normalized_percent.
+	UsagePercent float64 `protobuf:"fixed64,4,opt,name=usage_percent,json=usagePercent,proto3" json:"usage_percent,omitempty"`
+	// CPU usage where a fully busy CPU is 100% (0 to N*100 on N CPUs).
+	PerCorePercent float64 `protobuf:"fixed64,5,opt,name=per_core_percent,json=perCorePercent,proto3" json:"per_core_percent,omitempty"`
+	// CPU usage as a share of every CPU (0-100).
+	NormalizedPercent float64 `protobuf:"fixed64,6,opt,name=normalized_percent,json=normalizedPercent,proto3" json:"normalized_percent,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ProcessCPU) Reset() {
 	*x = ProcessCPU{}
-	mi := &file_daemon_proto_msgTypes[9]
+	mi := &file_daemon_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -745,7 +1085,7 @@ func (x *ProcessCPU) String() string {
 func (*ProcessCPU) ProtoMessage() {}
 
 func (x *ProcessCPU) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[9]
+	mi := &file_daemon_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -758,7 +1098,7 @@ func (x *ProcessCPU) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessCPU.ProtoReflect.Descriptor instead.
 func (*ProcessCPU) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{9}
+	return file_daemon_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ProcessCPU) GetUserTimeNs() uint64 {
@@ -789,6 +1129,20 @@ func (x *ProcessCPU) GetUsagePercent() float64 {
 	return 0
 }
 
+func (x *ProcessCPU) GetPerCorePercent() float64 {
+	if x != nil {
+		return x.PerCorePercent
+	}
+	return 0
+}
+
+func (x *ProcessCPU) GetNormalizedPercent() float64 {
+	if x != nil {
+		return x.NormalizedPercent
+	}
+	return 0
+}
+
 // ProcessMemory contains memory metrics for a process.
 type ProcessMemory struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -803,14 +1157,29 @@ type ProcessMemory struct {
 	// Data segment size in bytes.
 	DataBytes uint64 `protobuf:"varint,5,opt,name=data_bytes,json=dataBytes,proto3" json:"data_bytes,omitempty"`
 	// Stack size in bytes.
-	StackBytes    uint64 `protobuf:"varint,6,opt,name=stack_bytes,json=stackBytes,proto3" json:"stack_bytes,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	StackBytes uint64 `protobuf:"varint,6,opt,name=stack_bytes,json=stackBytes,proto3" json:"stack_bytes,omitempty"`
+	// Proportional set size in bytes, shared pages split between their
+	// mappers. Zero unless metrics.memory.smaps is enabled.
+	PssBytes uint64 `protobuf:"varint,7,opt,name=pss_bytes,json=pssBytes,proto3" json:"pss_bytes,omitempty"`
+	// Unique set size in bytes, pages no other process maps. Zero unless
+	// metrics.memory.smaps is enabled.
+	UssBytes uint64 `protobuf:"varint,8,opt,name=uss_bytes,json=ussBytes,proto3" json:"uss_bytes,omitempty"`
+	// Proportional share of the swap usage in bytes. Zero unless
+	// metrics.memory.smaps is enabled.
+	SwapPssBytes uint64 `protobuf:"varint,9,opt,name=swap_pss_bytes,json=swapPssBytes,proto3" json:"swap_pss_bytes,omitempty"`
+	// Page faults that required disk I/O since the process started. Zero
+	// unless metrics.memory.smaps is enabled.
+	MajorFaults uint64 `protobuf:"varint,10,opt,name=major_faults,json=majorFaults,proto3" json:"major_faults,omitempty"`
+	// Major page faults per second since the previous sample. Zero unless
+	// metrics.memory.smaps is enabled.
+	MajorFaultRate float64 `protobuf:"fixed64,11,opt,name=major_fault_rate,json=majorFaultRate,proto3" json:"major_fault_rate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ProcessMemory) Reset() {
 	*x = ProcessMemory{}
-	mi := &file_daemon_proto_msgTypes[10]
+	mi := &file_daemon_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -822,7 +1191,7 @@ func (x *ProcessMemory) String() string {
 func (*ProcessMemory) ProtoMessage() {}
 
 func (x *ProcessMemory) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[10]
+	mi := &file_daemon_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,7 +1204,7 @@ func (x *ProcessMemory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessMemory.ProtoReflect.Descriptor instead.
 func (*ProcessMemory) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{10}
+	return file_daemon_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ProcessMemory) GetRssBytes() uint64 {
@@ -880,26 +1249,218 @@ func (x *ProcessMemory) GetStackBytes() uint64 {
 	return 0
 }
 
-// SystemMetrics contains system-wide metrics.
-type SystemMetrics struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// System CPU metrics.
-	Cpu *SystemCPU `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	// System memory metrics.
-	Memory *SystemMemory `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	// Load average.
-	Load *LoadAverage `protobuf:"bytes,3,opt,name=load,proto3" json:"load,omitempty"`
-	// Collection timestamp.
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ProcessMemory) GetPssBytes() uint64 {
+	if x != nil {
+		return x.PssBytes
+	}
+	return 0
 }
 
-func (x *SystemMetrics) Reset() {
-	*x = SystemMetrics{}
-	mi := &file_daemon_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ProcessMemory) GetUssBytes() uint64 {
+	if x != nil {
+		return x.UssBytes
+	}
+	return 0
+}
+
+func (x *ProcessMemory) GetSwapPssBytes() uint64 {
+	if x != nil {
+		return x.SwapPssBytes
+	}
+	return 0
+}
+
+func (x *ProcessMemory) GetMajorFaults() uint64 {
+	if x != nil {
+		return x.MajorFaults
+	}
+	return 0
+}
+
+func (x *ProcessMemory) GetMajorFaultRate() float64 {
+	if x != nil {
+		return x.MajorFaultRate
+	}
+	return 0
+}
+
+// ResourcePressure contains PSI for CPU, memory, and I/O.
+type ResourcePressure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CPU pressure.
+	Cpu *Pressure `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// Memory pressure.
+	Memory *Pressure `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// I/O pressure.
+	Io            *Pressure `protobuf:"bytes,3,opt,name=io,proto3" json:"io,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourcePressure) Reset() {
+	*x = ResourcePressure{}
+	mi := &file_daemon_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourcePressure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourcePressure) ProtoMessage() {}
+
+func (x *ResourcePressure) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourcePressure.ProtoReflect.Descriptor instead.
+func (*ResourcePressure) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResourcePressure) GetCpu() *Pressure {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *ResourcePressure) GetMemory() *Pressure {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *ResourcePressure) GetIo() *Pressure {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+// Pressure contains PSI stall percentages for one resource.
+type Pressure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Share of time some tasks were stalled (10s average).
+	SomeAvg10 float64 `protobuf:"fixed64,1,opt,name=some_avg10,json=someAvg10,proto3" json:"some_avg10,omitempty"`
+	// Share of time some tasks were stalled (60s average).
+	SomeAvg60 float64 `protobuf:"fixed64,2,opt,name=some_avg60,json=someAvg60,proto3" json:"some_avg60,omitempty"`
+	// Share of time some tasks were stalled (300s average).
+	SomeAvg300 float64 `protobuf:"fixed64,3,opt,name=some_avg300,json=someAvg300,proto3" json:"some_avg300,omitempty"`
+	// Share of time all tasks were stalled (10s average).
+	FullAvg10 float64 `protobuf:"fixed64,4,opt,name=full_avg10,json=fullAvg10,proto3" json:"full_avg10,omitempty"`
+	// Share of time all tasks were stalled (60s average).
+	FullAvg60 float64 `protobuf:"fixed64,5,opt,name=full_avg60,json=fullAvg60,proto3" json:"full_avg60,omitempty"`
+	// Share of time all tasks were stalled (300s average).
+	FullAvg300    float64 `protobuf:"fixed64,6,opt,name=full_avg300,json=fullAvg300,proto3" json:"full_avg300,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pressure) Reset() {
+	*x = Pressure{}
+	mi := &file_daemon_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pressure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pressure) ProtoMessage() {}
+
+func (x *Pressure) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pressure.ProtoReflect.Descriptor instead.
+func (*Pressure) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Pressure) GetSomeAvg10() float64 {
+	if x != nil {
+		return x.SomeAvg10
+	}
+	return 0
+}
+
+func (x *Pressure) GetSomeAvg60() float64 {
+	if x != nil {
+		return x.SomeAvg60
+	}
+	return 0
+}
+
+func (x *Pressure) GetSomeAvg300() float64 {
+	if x != nil {
+		return x.SomeAvg300
+	}
+	return 0
+}
+
+func (x *Pressure) GetFullAvg10() float64 {
+	if x != nil {
+		return x.FullAvg10
+	}
+	return 0
+}
+
+func (x *Pressure) GetFullAvg60() float64 {
+	if x != nil {
+		return x.FullAvg60
+	}
+	return 0
+}
+
+func (x *Pressure) GetFullAvg300() float64 {
+	if x != nil {
+		return x.FullAvg300
+	}
+	return 0
+}
+
+// SystemMetrics contains system-wide metrics.
+type SystemMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// System CPU metrics.
+	Cpu *SystemCPU `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// System memory metrics.
+	Memory *SystemMemory `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Load average.
+	Load *LoadAverage `protobuf:"bytes,3,opt,name=load,proto3" json:"load,omitempty"`
+	// Collection timestamp.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Host pressure stall information.
+	Pressure      *ResourcePressure `protobuf:"bytes,5,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemMetrics) Reset() {
+	*x = SystemMetrics{}
+	mi := &file_daemon_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *SystemMetrics) String() string {
@@ -909,7 +1470,7 @@ func (x *SystemMetrics) String() string {
 func (*SystemMetrics) ProtoMessage() {}
 
 func (x *SystemMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[11]
+	mi := &file_daemon_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -922,7 +1483,7 @@ func (x *SystemMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemMetrics.ProtoReflect.Descriptor instead.
 func (*SystemMetrics) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{11}
+	return file_daemon_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *SystemMetrics) GetCpu() *SystemCPU {
@@ -953,6 +1514,13 @@ func (x *SystemMetrics) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *SystemMetrics) GetPressure() *ResourcePressure {
+	if x != nil {
+		return x.Pressure
+	}
+	return nil
+}
+
 // SystemCPU contains system-wide CPU metrics.
 type SystemCPU struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -980,7 +1548,7 @@ type SystemCPU struct {
 
 func (x *SystemCPU) Reset() {
 	*x = SystemCPU{}
-	mi := &file_daemon_proto_msgTypes[12]
+	mi := &file_daemon_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -992,7 +1560,7 @@ func (x *SystemCPU) String() string {
 func (*SystemCPU) ProtoMessage() {}
 
 func (x *SystemCPU) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[12]
+	mi := &file_daemon_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,7 +1573,7 @@ func (x *SystemCPU) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemCPU.ProtoReflect.Descriptor instead.
 func (*SystemCPU) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{12}
+	return file_daemon_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SystemCPU) GetUserNs() uint64 {
@@ -1102,7 +1670,7 @@ type SystemMemory struct {
 
 func (x *SystemMemory) Reset() {
 	*x = SystemMemory{}
-	mi := &file_daemon_proto_msgTypes[13]
+	mi := &file_daemon_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1114,7 +1682,7 @@ func (x *SystemMemory) String() string {
 func (*SystemMemory) ProtoMessage() {}
 
 func (x *SystemMemory) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[13]
+	mi := &file_daemon_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1127,7 +1695,7 @@ func (x *SystemMemory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemMemory.ProtoReflect.Descriptor instead.
 func (*SystemMemory) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{13}
+	return file_daemon_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SystemMemory) GetTotalBytes() uint64 {
@@ -1222,7 +1790,7 @@ type LoadAverage struct {
 
 func (x *LoadAverage) Reset() {
 	*x = LoadAverage{}
-	mi := &file_daemon_proto_msgTypes[14]
+	mi := &file_daemon_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1234,7 +1802,7 @@ func (x *LoadAverage) String() string {
 func (*LoadAverage) ProtoMessage() {}
 
 func (x *LoadAverage) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[14]
+	mi := &file_daemon_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1247,7 +1815,7 @@ func (x *LoadAverage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadAverage.ProtoReflect.Descriptor instead.
 func (*LoadAverage) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{14}
+	return file_daemon_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *LoadAverage) GetLoad1() float64 {
@@ -1271,129 +1839,5409 @@ func (x *LoadAverage) GetLoad15() float64 {
 	return 0
 }
 
-var File_daemon_proto protoreflect.FileDescriptor
+// StreamLogsRequest selects the service output to stream.
+type StreamLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Services to stream; empty streams all services.
+	Services []string `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	// Keep the stream open and send new lines as they are captured.
+	Follow bool `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`
+	// Number of recent lines to send first.
+	Tail int32 `protobuf:"varint,3,opt,name=tail,proto3" json:"tail,omitempty"`
+	// Lowest level to send (debug, info, warn, error); empty sends all.
+	MinLevel string `protobuf:"bytes,4,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	// Regular expression lines must match; empty matches all.
+	Pattern       string `protobuf:"bytes,5,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_daemon_proto_rawDesc = "" +
-	"\n" +
-	"\fdaemon.proto\x12\tdaemon.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\"K\n" +
-	"\x12StreamStateRequest\x125\n" +
-	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\"M\n" +
-	"\x14StreamMetricsRequest\x125\n" +
-	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\"w\n" +
-	"\x1bStreamProcessMetricsRequest\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x125\n" +
-	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\"6\n" +
-	"\x11GetProcessRequest\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"P\n" +
-	"\x15ListProcessesResponse\x127\n" +
-	"\tprocesses\x18\x01 \x03(\v2\x19.daemon.v1.ProcessMetricsR\tprocesses\"\xfe\x02\n" +
-	"\vDaemonState\x12\x18\n" +
-	"\aversion\x18\x01 \x01(\tR\aversion\x129\n" +
-	"\n" +
-	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x121\n" +
-	"\x06uptime\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12\x18\n" +
-	"\ahealthy\x18\x04 \x01(\bR\ahealthy\x127\n" +
-	"\tprocesses\x18\x05 \x03(\v2\x19.daemon.v1.ProcessMetricsR\tprocesses\x120\n" +
-	"\x06system\x18\x06 \x01(\v2\x18.daemon.v1.SystemMetricsR\x06system\x12'\n" +
-	"\x04host\x18\a \x01(\v2\x13.daemon.v1.HostInfoR\x04host\x129\n" +
-	"\n" +
-	"kubernetes\x18\b \x01(\v2\x19.daemon.v1.KubernetesInfoR\n" +
-	"kubernetes\"e\n" +
-	"\bHostInfo\x12\x1a\n" +
-	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x0e\n" +
-	"\x02os\x18\x02 \x01(\tR\x02os\x12\x12\n" +
-	"\x04arch\x18\x03 \x01(\tR\x04arch\x12\x19\n" +
-	"\bnum_cpus\x18\x04 \x01(\x05R\anumCpus\"\xe0\x01\n" +
-	"\x0eKubernetesInfo\x12\x19\n" +
-	"\bpod_name\x18\x01 \x01(\tR\apodName\x12\x1c\n" +
-	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1b\n" +
-	"\tnode_name\x18\x03 \x01(\tR\bnodeName\x12=\n" +
-	"\x06labels\x18\x04 \x03(\v2%.daemon.v1.KubernetesInfo.LabelsEntryR\x06labels\x1a9\n" +
-	"\vLabelsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd5\x03\n" +
-	"\x0eProcessMetrics\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x10\n" +
-	"\x03pid\x18\x02 \x01(\x05R\x03pid\x12-\n" +
-	"\x05state\x18\x03 \x01(\x0e2\x17.daemon.v1.ProcessStateR\x05state\x12\x18\n" +
-	"\ahealthy\x18\x04 \x01(\bR\ahealthy\x12'\n" +
-	"\x03cpu\x18\x05 \x01(\v2\x15.daemon.v1.ProcessCPUR\x03cpu\x120\n" +
-	"\x06memory\x18\x06 \x01(\v2\x18.daemon.v1.ProcessMemoryR\x06memory\x129\n" +
-	"\n" +
-	"start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x121\n" +
-	"\x06uptime\x18\b \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12#\n" +
-	"\rrestart_count\x18\t \x01(\x05R\frestartCount\x12\x1d\n" +
-	"\n" +
-	"last_error\x18\n" +
-	" \x01(\tR\tlastError\x128\n" +
-	"\ttimestamp\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x9d\x01\n" +
-	"\n" +
-	"ProcessCPU\x12 \n" +
-	"\fuser_time_ns\x18\x01 \x01(\x04R\n" +
-	"userTimeNs\x12$\n" +
-	"\x0esystem_time_ns\x18\x02 \x01(\x04R\fsystemTimeNs\x12\"\n" +
-	"\rtotal_time_ns\x18\x03 \x01(\x04R\vtotalTimeNs\x12#\n" +
-	"\rusage_percent\x18\x04 \x01(\x01R\fusagePercent\"\xcb\x01\n" +
-	"\rProcessMemory\x12\x1b\n" +
-	"\trss_bytes\x18\x01 \x01(\x04R\brssBytes\x12\x1b\n" +
-	"\tvms_bytes\x18\x02 \x01(\x04R\bvmsBytes\x12\x1d\n" +
-	"\n" +
-	"swap_bytes\x18\x03 \x01(\x04R\tswapBytes\x12!\n" +
-	"\fshared_bytes\x18\x04 \x01(\x04R\vsharedBytes\x12\x1d\n" +
-	"\n" +
-	"data_bytes\x18\x05 \x01(\x04R\tdataBytes\x12\x1f\n" +
-	"\vstack_bytes\x18\x06 \x01(\x04R\n" +
-	"stackBytes\"\xce\x01\n" +
-	"\rSystemMetrics\x12&\n" +
-	"\x03cpu\x18\x01 \x01(\v2\x14.daemon.v1.SystemCPUR\x03cpu\x12/\n" +
-	"\x06memory\x18\x02 \x01(\v2\x17.daemon.v1.SystemMemoryR\x06memory\x12*\n" +
-	"\x04load\x18\x03 \x01(\v2\x16.daemon.v1.LoadAverageR\x04load\x128\n" +
-	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x86\x02\n" +
-	"\tSystemCPU\x12\x17\n" +
-	"\auser_ns\x18\x01 \x01(\x04R\x06userNs\x12\x17\n" +
-	"\anice_ns\x18\x02 \x01(\x04R\x06niceNs\x12\x1b\n" +
-	"\tsystem_ns\x18\x03 \x01(\x04R\bsystemNs\x12\x17\n" +
-	"\aidle_ns\x18\x04 \x01(\x04R\x06idleNs\x12\x1b\n" +
-	"\tiowait_ns\x18\x05 \x01(\x04R\biowaitNs\x12\x15\n" +
-	"\x06irq_ns\x18\x06 \x01(\x04R\x05irqNs\x12\x1d\n" +
-	"\n" +
-	"softirq_ns\x18\a \x01(\x04R\tsoftirqNs\x12\x19\n" +
-	"\bsteal_ns\x18\b \x01(\x04R\astealNs\x12#\n" +
-	"\rusage_percent\x18\t \x01(\x01R\fusagePercent\"\xa0\x03\n" +
-	"\fSystemMemory\x12\x1f\n" +
-	"\vtotal_bytes\x18\x01 \x01(\x04R\n" +
-	"totalBytes\x12'\n" +
-	"\x0favailable_bytes\x18\x02 \x01(\x04R\x0eavailableBytes\x12\x1d\n" +
-	"\n" +
-	"used_bytes\x18\x03 \x01(\x04R\tusedBytes\x12\x1d\n" +
-	"\n" +
-	"free_bytes\x18\x04 \x01(\x04R\tfreeBytes\x12#\n" +
-	"\rbuffers_bytes\x18\x05 \x01(\x04R\fbuffersBytes\x12!\n" +
-	"\fcached_bytes\x18\x06 \x01(\x04R\vcachedBytes\x12!\n" +
-	"\fshared_bytes\x18\a \x01(\x04R\vsharedBytes\x12(\n" +
-	"\x10swap_total_bytes\x18\b \x01(\x04R\x0eswapTotalBytes\x12&\n" +
-	"\x0fswap_used_bytes\x18\t \x01(\x04R\rswapUsedBytes\x12&\n" +
-	"\x0fswap_free_bytes\x18\n" +
-	" \x01(\x04R\rswapFreeBytes\x12#\n" +
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_daemon_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamLogsRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *StreamLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+func (x *StreamLogsRequest) GetTail() int32 {
+	if x != nil {
+		return x.Tail
+	}
+	return 0
+}
+
+func (x *StreamLogsRequest) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// LogLine is one line captured from a service output.
+type LogLine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Output stream (stdout or stderr).
+	Stream string `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
+	// Capture time.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Level detected from the line content.
+	Level string `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	// Line content without trailing newline.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// Lines dropped since the previous message because the client read too slowly.
+	Dropped       uint64 `protobuf:"varint,6,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	mi := &file_daemon_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LogLine) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *LogLine) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *LogLine) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *LogLine) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogLine) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LogLine) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// ReadLogsRequest selects the lines read from the log files of a service.
+type ReadLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service whose log files are read.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Oldest line to send; unset sends every line.
+	Since *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// Lowest level to send (debug, info, warn, error); empty sends all.
+	MinLevel string `protobuf:"bytes,3,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	// Regular expression lines must match; empty matches all.
+	Pattern       string `protobuf:"bytes,4,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadLogsRequest) Reset() {
+	*x = ReadLogsRequest{}
+	mi := &file_daemon_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadLogsRequest) ProtoMessage() {}
+
+func (x *ReadLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadLogsRequest.ProtoReflect.Descriptor instead.
+func (*ReadLogsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReadLogsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ReadLogsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ReadLogsRequest) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+func (x *ReadLogsRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// DaemonParameters are the daemon settings changed at runtime, without
+// editing the configuration file. Unset fields keep the configured settings.
+type DaemonParameters struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Level of every daemon log writer (debug, info, warn, error); empty keeps
+	// the level configured for each writer.
+	LogLevel string `protobuf:"bytes,1,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
+	// Interval of the probes that configure none; unset keeps the default (10s).
+	ProbeInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=probe_interval,json=probeInterval,proto3" json:"probe_interval,omitempty"`
+	// Timeout of the probes that configure none; unset keeps the default (5s).
+	ProbeTimeout *durationpb.Duration `protobuf:"bytes,3,opt,name=probe_timeout,json=probeTimeout,proto3" json:"probe_timeout,omitempty"`
+	// Whether events are no longer delivered to notification channels.
+	NotificationsMuted bool `protobuf:"varint,4,opt,name=notifications_muted,json=notificationsMuted,proto3" json:"notifications_muted,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DaemonParameters) Reset() {
+	*x = DaemonParameters{}
+	mi := &file_daemon_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonParameters) ProtoMessage() {}
+
+func (x *DaemonParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonParameters.ProtoReflect.Descriptor instead.
+func (*DaemonParameters) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DaemonParameters) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+func (x *DaemonParameters) GetProbeInterval() *durationpb.Duration {
+	if x != nil {
+		return x.ProbeInterval
+	}
+	return nil
+}
+
+func (x *DaemonParameters) GetProbeTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ProbeTimeout
+	}
+	return nil
+}
+
+func (x *DaemonParameters) GetNotificationsMuted() bool {
+	if x != nil {
+		return x.NotificationsMuted
+	}
+	return false
+}
+
+// BuildInfo identifies the build of the daemon binary.
+type BuildInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Release version, "dev" for local builds.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Revision the binary was built from; empty when unknown.
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// When the binary was built; unset when unknown.
+	BuildDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	// Go toolchain version.
+	GoVersion string `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	// Optional capabilities compiled in, sorted.
+	Features      []string `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildInfo) Reset() {
+	*x = BuildInfo{}
+	mi := &file_daemon_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildInfo) ProtoMessage() {}
+
+func (x *BuildInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildInfo.ProtoReflect.Descriptor instead.
+func (*BuildInfo) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BuildInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetBuildDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildDate
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// GetServiceSpecRequest identifies the service to inspect.
+type GetServiceSpecRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceSpecRequest) Reset() {
+	*x = GetServiceSpecRequest{}
+	mi := &file_daemon_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceSpecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceSpecRequest) ProtoMessage() {}
+
+func (x *GetServiceSpecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceSpecRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceSpecRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetServiceSpecRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ServiceSpec is the specification a service was actually launched with.
+type ServiceSpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Process ID.
+	Pid int32 `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Launch time.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Executable path or command.
+	Command string `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	// Command-line arguments.
+	Args []string `protobuf:"bytes,5,rep,name=args,proto3" json:"args,omitempty"`
+	// Working directory.
+	WorkingDirectory string `protobuf:"bytes,6,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	// Environment variables; secret-looking values are "[REDACTED]".
+	Env map[string]string `protobuf:"bytes,7,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// User the process runs as.
+	User string `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`
+	// Group the process runs as.
+	Group string `protobuf:"bytes,9,opt,name=group,proto3" json:"group,omitempty"`
+	// Cgroup of the process; empty when unknown.
+	CgroupPath string `protobuf:"bytes,10,opt,name=cgroup_path,json=cgroupPath,proto3" json:"cgroup_path,omitempty"`
+	// Resource limits applied to the process.
+	Limits []*ResourceLimit `protobuf:"bytes,11,rep,name=limits,proto3" json:"limits,omitempty"`
+	// Configured listeners.
+	Listeners     []*ListenerSpec `protobuf:"bytes,12,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceSpec) Reset() {
+	*x = ServiceSpec{}
+	mi := &file_daemon_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSpec) ProtoMessage() {}
+
+func (x *ServiceSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSpec.ProtoReflect.Descriptor instead.
+func (*ServiceSpec) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ServiceSpec) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceSpec) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ServiceSpec) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ServiceSpec) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ServiceSpec) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ServiceSpec) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *ServiceSpec) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ServiceSpec) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ServiceSpec) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ServiceSpec) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *ServiceSpec) GetLimits() []*ResourceLimit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *ServiceSpec) GetListeners() []*ListenerSpec {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+// ResourceLimit is one resource limit applied to a process.
+type ResourceLimit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limit name (e.g. "Max open files").
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Soft limit, "unlimited" when not bounded.
+	Soft string `protobuf:"bytes,2,opt,name=soft,proto3" json:"soft,omitempty"`
+	// Hard limit, "unlimited" when not bounded.
+	Hard string `protobuf:"bytes,3,opt,name=hard,proto3" json:"hard,omitempty"`
+	// Limit unit; empty when none.
+	Unit          string `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceLimit) Reset() {
+	*x = ResourceLimit{}
+	mi := &file_daemon_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceLimit) ProtoMessage() {}
+
+func (x *ResourceLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceLimit.ProtoReflect.Descriptor instead.
+func (*ResourceLimit) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResourceLimit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceLimit) GetSoft() string {
+	if x != nil {
+		return x.Soft
+	}
+	return ""
+}
+
+func (x *ResourceLimit) GetHard() string {
+	if x != nil {
+		return x.Hard
+	}
+	return ""
+}
+
+func (x *ResourceLimit) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// ListenerSpec is a configured listener of a service.
+type ListenerSpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Listener name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Network protocol.
+	Protocol string `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Bind address; empty binds all interfaces.
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// Port number.
+	Port int32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	// Whether the port should be publicly reachable.
+	Exposed       bool `protobuf:"varint,5,opt,name=exposed,proto3" json:"exposed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenerSpec) Reset() {
+	*x = ListenerSpec{}
+	mi := &file_daemon_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerSpec) ProtoMessage() {}
+
+func (x *ListenerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerSpec.ProtoReflect.Descriptor instead.
+func (*ListenerSpec) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListenerSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListenerSpec) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ListenerSpec) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListenerSpec) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ListenerSpec) GetExposed() bool {
+	if x != nil {
+		return x.Exposed
+	}
+	return false
+}
+
+// BootReport summarizes the initial startup of the supervised services.
+type BootReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the supervisor began starting services.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// When every service was resolved; unset while booting.
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Whether a critical service failed under on_boot_failure: shutdown.
+	Aborted bool `protobuf:"varint,3,opt,name=aborted,proto3" json:"aborted,omitempty"`
+	// Outcome of each service, in configuration order.
+	Services      []*ServiceBoot `protobuf:"bytes,4,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootReport) Reset() {
+	*x = BootReport{}
+	mi := &file_daemon_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootReport) ProtoMessage() {}
+
+func (x *BootReport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootReport.ProtoReflect.Descriptor instead.
+func (*BootReport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BootReport) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *BootReport) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *BootReport) GetAborted() bool {
+	if x != nil {
+		return x.Aborted
+	}
+	return false
+}
+
+func (x *BootReport) GetServices() []*ServiceBoot {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// ServiceBoot is the boot outcome of one service.
+type ServiceBoot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Whether the service is required for a successful boot.
+	Critical bool `protobuf:"varint,2,opt,name=critical,proto3" json:"critical,omitempty"`
+	// Boot outcome.
+	Status BootStatus `protobuf:"varint,3,opt,name=status,proto3,enum=daemon.v1.BootStatus" json:"status,omitempty"`
+	// Time from boot start to readiness or failure.
+	Duration *durationpb.Duration `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Failure description or unmet conditions; empty unless status is
+	// BOOT_STATUS_FAILED or BOOT_STATUS_SKIPPED.
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceBoot) Reset() {
+	*x = ServiceBoot{}
+	mi := &file_daemon_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceBoot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceBoot) ProtoMessage() {}
+
+func (x *ServiceBoot) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceBoot.ProtoReflect.Descriptor instead.
+func (*ServiceBoot) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ServiceBoot) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceBoot) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+func (x *ServiceBoot) GetStatus() BootStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BootStatus_BOOT_STATUS_UNSPECIFIED
+}
+
+func (x *ServiceBoot) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ServiceBoot) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ReloadStatus is the progress and last result of configuration reloads.
+// Reloads run one at a time; requests arriving while one is queued are merged
+// into it. Runs are numbered from 1 in start order.
+type ReloadStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether a reload is being applied.
+	Running bool `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	// Whether a reload is queued behind the running one.
+	Pending bool `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	// Reload requests accepted.
+	Requested uint64 `protobuf:"varint,3,opt,name=requested,proto3" json:"requested,omitempty"`
+	// Requests merged into an already queued reload.
+	Coalesced uint64 `protobuf:"varint,4,opt,name=coalesced,proto3" json:"coalesced,omitempty"`
+	// Reload runs started.
+	Started uint64 `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`
+	// Reload runs finished, applied or refused.
+	Completed uint64 `protobuf:"varint,6,opt,name=completed,proto3" json:"completed,omitempty"`
+	// When the last reload run started; unset before the first run.
+	LastStartedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_started_at,json=lastStartedAt,proto3" json:"last_started_at,omitempty"`
+	// When the last reload run finished; unset before the first run ends.
+	LastFinishedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_finished_at,json=lastFinishedAt,proto3" json:"last_finished_at,omitempty"`
+	// Why the last reload failed; empty on success.
+	LastError string `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// Code of the last reload error, such as CFG_INVALID; empty on success.
+	LastErrorCode string `protobuf:"bytes,10,opt,name=last_error_code,json=lastErrorCode,proto3" json:"last_error_code,omitempty"`
+	// Run serving this request; set by RequestReload only. The reload is done
+	// once completed reaches it.
+	Run           uint64 `protobuf:"varint,11,opt,name=run,proto3" json:"run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadStatus) Reset() {
+	*x = ReloadStatus{}
+	mi := &file_daemon_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadStatus) ProtoMessage() {}
+
+func (x *ReloadStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadStatus.ProtoReflect.Descriptor instead.
+func (*ReloadStatus) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReloadStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *ReloadStatus) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *ReloadStatus) GetRequested() uint64 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *ReloadStatus) GetCoalesced() uint64 {
+	if x != nil {
+		return x.Coalesced
+	}
+	return 0
+}
+
+func (x *ReloadStatus) GetStarted() uint64 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+func (x *ReloadStatus) GetCompleted() uint64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *ReloadStatus) GetLastStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastStartedAt
+	}
+	return nil
+}
+
+func (x *ReloadStatus) GetLastFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFinishedAt
+	}
+	return nil
+}
+
+func (x *ReloadStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ReloadStatus) GetLastErrorCode() string {
+	if x != nil {
+		return x.LastErrorCode
+	}
+	return ""
+}
+
+func (x *ReloadStatus) GetRun() uint64 {
+	if x != nil {
+		return x.Run
+	}
+	return 0
+}
+
+// GetProbeTraceRequest identifies the service whose probe trace to return.
+type GetProbeTraceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProbeTraceRequest) Reset() {
+	*x = GetProbeTraceRequest{}
+	mi := &file_daemon_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProbeTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProbeTraceRequest) ProtoMessage() {}
+
+func (x *GetProbeTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProbeTraceRequest.ProtoReflect.Descriptor instead.
+func (*GetProbeTraceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetProbeTraceRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ProbeTrace lists the recent attempts of the probes with debug enabled.
+type ProbeTrace struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Attempts, oldest first; the last 100 per listener are kept.
+	Attempts      []*ProbeAttempt `protobuf:"bytes,2,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbeTrace) Reset() {
+	*x = ProbeTrace{}
+	mi := &file_daemon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbeTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeTrace) ProtoMessage() {}
+
+func (x *ProbeTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeTrace.ProtoReflect.Descriptor instead.
+func (*ProbeTrace) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ProbeTrace) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProbeTrace) GetAttempts() []*ProbeAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+// ProbeAttempt is one execution of a traced probe.
+type ProbeAttempt struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the probed listener.
+	ListenerName string `protobuf:"bytes,1,opt,name=listener_name,json=listenerName,proto3" json:"listener_name,omitempty"`
+	// Probe type (tcp, http, grpc, ...).
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Probed address, or command line for exec probes.
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// When the probe completed.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// How long the probe took.
+	Latency *durationpb.Duration `protobuf:"bytes,5,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Whether the probe succeeded.
+	Success bool `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	// Probe output.
+	Output string `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
+	// Failure description; empty on success.
+	Error         string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbeAttempt) Reset() {
+	*x = ProbeAttempt{}
+	mi := &file_daemon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbeAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeAttempt) ProtoMessage() {}
+
+func (x *ProbeAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeAttempt.ProtoReflect.Descriptor instead.
+func (*ProbeAttempt) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ProbeAttempt) GetListenerName() string {
+	if x != nil {
+		return x.ListenerName
+	}
+	return ""
+}
+
+func (x *ProbeAttempt) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProbeAttempt) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ProbeAttempt) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ProbeAttempt) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *ProbeAttempt) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ProbeAttempt) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *ProbeAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetDependenciesRequest identifies the service whose dependencies to return.
+type GetDependenciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDependenciesRequest) Reset() {
+	*x = GetDependenciesRequest{}
+	mi := &file_daemon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDependenciesRequest) ProtoMessage() {}
+
+func (x *GetDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*GetDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDependenciesRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ServiceDependencies lists the external dependencies of a service.
+type ServiceDependencies struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Dependencies, in configuration order.
+	Dependencies  []*DependencyStatus `protobuf:"bytes,2,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceDependencies) Reset() {
+	*x = ServiceDependencies{}
+	mi := &file_daemon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceDependencies) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceDependencies) ProtoMessage() {}
+
+func (x *ServiceDependencies) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceDependencies.ProtoReflect.Descriptor instead.
+func (*ServiceDependencies) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ServiceDependencies) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceDependencies) GetDependencies() []*DependencyStatus {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+// DependencyStatus is the probed state of an external dependency.
+type DependencyStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Dependency name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Probe type (tcp, http, grpc, ...).
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Probed address.
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// Whether health-check restarts of the service wait for this dependency.
+	GateRestart bool `protobuf:"varint,4,opt,name=gate_restart,json=gateRestart,proto3" json:"gate_restart,omitempty"`
+	// Whether the dependency passes its probe thresholds.
+	Healthy bool `protobuf:"varint,5,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// When the dependency was last probed; unset if never.
+	LastCheck *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_check,json=lastCheck,proto3" json:"last_check,omitempty"`
+	// How long the last probe took.
+	Latency *durationpb.Duration `protobuf:"bytes,7,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Failure of the last probe; empty when it passed.
+	Error         string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyStatus) Reset() {
+	*x = DependencyStatus{}
+	mi := &file_daemon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyStatus) ProtoMessage() {}
+
+func (x *DependencyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyStatus.ProtoReflect.Descriptor instead.
+func (*DependencyStatus) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DependencyStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DependencyStatus) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DependencyStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DependencyStatus) GetGateRestart() bool {
+	if x != nil {
+		return x.GateRestart
+	}
+	return false
+}
+
+func (x *DependencyStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *DependencyStatus) GetLastCheck() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheck
+	}
+	return nil
+}
+
+func (x *DependencyStatus) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *DependencyStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// SignalServiceRequest names the service and the signal to send.
+type SignalServiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Signal name, with or without the SIG prefix (SIGHUP, usr1).
+	Signal        string `protobuf:"bytes,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalServiceRequest) Reset() {
+	*x = SignalServiceRequest{}
+	mi := &file_daemon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalServiceRequest) ProtoMessage() {}
+
+func (x *SignalServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalServiceRequest.ProtoReflect.Descriptor instead.
+func (*SignalServiceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SignalServiceRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *SignalServiceRequest) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+// SpawnDebugServiceRequest describes a transient diagnostic run.
+type SpawnDebugServiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Executable to run.
+	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// Arguments of the command.
+	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// Environment variables, set over those of the sandbox service.
+	Env map[string]string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Lifetime of the run, stopped once it elapses. Unset uses 5 minutes;
+	// at most 1 hour.
+	Ttl *durationpb.Duration `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Service whose user, working directory, environment, confinement and
+	// egress rules the run reuses. Empty runs with the daemon defaults.
+	SandboxFrom   string `protobuf:"bytes,5,opt,name=sandbox_from,json=sandboxFrom,proto3" json:"sandbox_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpawnDebugServiceRequest) Reset() {
+	*x = SpawnDebugServiceRequest{}
+	mi := &file_daemon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpawnDebugServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpawnDebugServiceRequest) ProtoMessage() {}
+
+func (x *SpawnDebugServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpawnDebugServiceRequest.ProtoReflect.Descriptor instead.
+func (*SpawnDebugServiceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SpawnDebugServiceRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *SpawnDebugServiceRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *SpawnDebugServiceRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *SpawnDebugServiceRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *SpawnDebugServiceRequest) GetSandboxFrom() string {
+	if x != nil {
+		return x.SandboxFrom
+	}
+	return ""
+}
+
+// DebugService identifies a spawned transient service.
+type DebugService struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Generated service name, usable with the other service calls until the
+	// run ends.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// When the run is stopped if still running.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugService) Reset() {
+	*x = DebugService{}
+	mi := &file_daemon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugService) ProtoMessage() {}
+
+func (x *DebugService) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugService.ProtoReflect.Descriptor instead.
+func (*DebugService) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DebugService) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DebugService) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// GetCertificatesRequest names the service whose certificates to report.
+type GetCertificatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCertificatesRequest) Reset() {
+	*x = GetCertificatesRequest{}
+	mi := &file_daemon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCertificatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCertificatesRequest) ProtoMessage() {}
+
+func (x *GetCertificatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCertificatesRequest.ProtoReflect.Descriptor instead.
+func (*GetCertificatesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetCertificatesRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ServiceCertificates lists the checked certificates of a service.
+type ServiceCertificates struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Certificates in listener order; empty without tls listeners.
+	Certificates  []*CertificateStatus `protobuf:"bytes,2,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceCertificates) Reset() {
+	*x = ServiceCertificates{}
+	mi := &file_daemon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceCertificates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceCertificates) ProtoMessage() {}
+
+func (x *ServiceCertificates) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceCertificates.ProtoReflect.Descriptor instead.
+func (*ServiceCertificates) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ServiceCertificates) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceCertificates) GetCertificates() []*CertificateStatus {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+// CertificateStatus is the last check of the certificate a listener serves.
+type CertificateStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Listener name.
+	Listener string `protobuf:"bytes,1,opt,name=listener,proto3" json:"listener,omitempty"`
+	// Checked address.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Subject distinguished name. Empty until a check succeeded.
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Issuer distinguished name.
+	Issuer string `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// Subject alternative DNS names.
+	DnsNames []string `protobuf:"bytes,5,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// Serial number, in decimal.
+	SerialNumber string `protobuf:"bytes,6,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// Start of validity.
+	NotBefore *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// End of validity.
+	NotAfter *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// SHA-256 fingerprint of the certificate, in hex.
+	Fingerprint string `protobuf:"bytes,9,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// Time of the last check, unset before the first one.
+	LastCheck *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_check,json=lastCheck,proto3" json:"last_check,omitempty"`
+	// Error of the last check, empty when it succeeded.
+	Error string `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	// Time of the last successful renewal, unset if none.
+	RenewedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=renewed_at,json=renewedAt,proto3" json:"renewed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CertificateStatus) Reset() {
+	*x = CertificateStatus{}
+	mi := &file_daemon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CertificateStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertificateStatus) ProtoMessage() {}
+
+func (x *CertificateStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertificateStatus.ProtoReflect.Descriptor instead.
+func (*CertificateStatus) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CertificateStatus) GetListener() string {
+	if x != nil {
+		return x.Listener
+	}
+	return ""
+}
+
+func (x *CertificateStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CertificateStatus) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *CertificateStatus) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *CertificateStatus) GetDnsNames() []string {
+	if x != nil {
+		return x.DnsNames
+	}
+	return nil
+}
+
+func (x *CertificateStatus) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *CertificateStatus) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *CertificateStatus) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+func (x *CertificateStatus) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *CertificateStatus) GetLastCheck() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheck
+	}
+	return nil
+}
+
+func (x *CertificateStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CertificateStatus) GetRenewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RenewedAt
+	}
+	return nil
+}
+
+// PlanReloadRequest carries the configuration to plan.
+type PlanReloadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Configuration YAML, with includes merged as the reload loader would.
+	Config        []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanReloadRequest) Reset() {
+	*x = PlanReloadRequest{}
+	mi := &file_daemon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanReloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanReloadRequest) ProtoMessage() {}
+
+func (x *PlanReloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanReloadRequest.ProtoReflect.Descriptor instead.
+func (*PlanReloadRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PlanReloadRequest) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// ReloadPlan is what a reload of a configuration would do.
+type ReloadPlan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Affected services: kept and added ones in configuration order, then
+	// removed ones by name.
+	Services      []*ServicePlan `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadPlan) Reset() {
+	*x = ReloadPlan{}
+	mi := &file_daemon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadPlan) ProtoMessage() {}
+
+func (x *ReloadPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadPlan.ProtoReflect.Descriptor instead.
+func (*ReloadPlan) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReloadPlan) GetServices() []*ServicePlan {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// ServicePlan is what a reload would do to one service, and why.
+type ServicePlan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// What the reload does to the service.
+	Action ReloadAction `protobuf:"varint,2,opt,name=action,proto3,enum=daemon.v1.ReloadAction" json:"action,omitempty"`
+	// Changed settings of a restarted service, by configuration key; empty
+	// when it is restarted unchanged.
+	Changes       []string `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServicePlan) Reset() {
+	*x = ServicePlan{}
+	mi := &file_daemon_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServicePlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServicePlan) ProtoMessage() {}
+
+func (x *ServicePlan) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServicePlan.ProtoReflect.Descriptor instead.
+func (*ServicePlan) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ServicePlan) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServicePlan) GetAction() ReloadAction {
+	if x != nil {
+		return x.Action
+	}
+	return ReloadAction_RELOAD_ACTION_UNSPECIFIED
+}
+
+func (x *ServicePlan) GetChanges() []string {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// ReloadServiceRequest names the service to reload in place.
+type ReloadServiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadServiceRequest) Reset() {
+	*x = ReloadServiceRequest{}
+	mi := &file_daemon_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadServiceRequest) ProtoMessage() {}
+
+func (x *ReloadServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadServiceRequest.ProtoReflect.Descriptor instead.
+func (*ReloadServiceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReloadServiceRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// BatchServicesRequest selects the services of a batch action.
+type BatchServicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Action applied to every selected service.
+	Action ServiceAction `protobuf:"varint,1,opt,name=action,proto3,enum=daemon.v1.ServiceAction" json:"action,omitempty"`
+	// Names of the target services.
+	ServiceNames []string `protobuf:"bytes,2,rep,name=service_names,json=serviceNames,proto3" json:"service_names,omitempty"`
+	// Adds the services carrying each label with the given value.
+	LabelSelector map[string]string `protobuf:"bytes,3,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Largest number of services acted on at once; zero uses the default (4).
+	MaxParallel   int32 `protobuf:"varint,4,opt,name=max_parallel,json=maxParallel,proto3" json:"max_parallel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchServicesRequest) Reset() {
+	*x = BatchServicesRequest{}
+	mi := &file_daemon_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchServicesRequest) ProtoMessage() {}
+
+func (x *BatchServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchServicesRequest.ProtoReflect.Descriptor instead.
+func (*BatchServicesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *BatchServicesRequest) GetAction() ServiceAction {
+	if x != nil {
+		return x.Action
+	}
+	return ServiceAction_SERVICE_ACTION_UNSPECIFIED
+}
+
+func (x *BatchServicesRequest) GetServiceNames() []string {
+	if x != nil {
+		return x.ServiceNames
+	}
+	return nil
+}
+
+func (x *BatchServicesRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
+func (x *BatchServicesRequest) GetMaxParallel() int32 {
+	if x != nil {
+		return x.MaxParallel
+	}
+	return 0
+}
+
+// BatchServicesResponse reports the outcome of a batch action.
+type BatchServicesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per service: named services in request order, then
+	// selected ones in configuration order.
+	Results []*ServiceActionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Number of results with an error.
+	Failed        int32 `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchServicesResponse) Reset() {
+	*x = BatchServicesResponse{}
+	mi := &file_daemon_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchServicesResponse) ProtoMessage() {}
+
+func (x *BatchServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchServicesResponse.ProtoReflect.Descriptor instead.
+func (*BatchServicesResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BatchServicesResponse) GetResults() []*ServiceActionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchServicesResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// ServiceActionResult is the outcome of an action on one service.
+type ServiceActionResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Error message; empty on success.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Error code (SVC_NOT_FOUND...); empty on success.
+	ErrorCode string `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// State of the service once the action completed.
+	Snapshot      *ServiceSnapshot `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceActionResult) Reset() {
+	*x = ServiceActionResult{}
+	mi := &file_daemon_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceActionResult) ProtoMessage() {}
+
+func (x *ServiceActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceActionResult.ProtoReflect.Descriptor instead.
+func (*ServiceActionResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ServiceActionResult) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceActionResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ServiceActionResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ServiceActionResult) GetSnapshot() *ServiceSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// ServiceSnapshot is the state of a service after a control operation.
+type ServiceSnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Process state.
+	State ProcessState `protobuf:"varint,2,opt,name=state,proto3,enum=daemon.v1.ProcessState" json:"state,omitempty"`
+	// Process ID; zero when not running.
+	Pid int32 `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Lifecycle operation that produced the state; empty when none.
+	OperationId string `protobuf:"bytes,4,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Number of service events applied when the snapshot was taken.
+	Sequence      uint64 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceSnapshot) Reset() {
+	*x = ServiceSnapshot{}
+	mi := &file_daemon_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSnapshot) ProtoMessage() {}
+
+func (x *ServiceSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSnapshot.ProtoReflect.Descriptor instead.
+func (*ServiceSnapshot) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ServiceSnapshot) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceSnapshot) GetState() ProcessState {
+	if x != nil {
+		return x.State
+	}
+	return ProcessState_PROCESS_STATE_UNSPECIFIED
+}
+
+func (x *ServiceSnapshot) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ServiceSnapshot) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *ServiceSnapshot) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// GetServiceStatsRequest names the service to report.
+type GetServiceStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceStatsRequest) Reset() {
+	*x = GetServiceStatsRequest{}
+	mi := &file_daemon_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceStatsRequest) ProtoMessage() {}
+
+func (x *GetServiceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetServiceStatsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ServiceStats contains the lifetime statistics of a service.
+type ServiceStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Number of starts.
+	StartCount int64 `protobuf:"varint,2,opt,name=start_count,json=startCount,proto3" json:"start_count,omitempty"`
+	// Number of clean stops.
+	StopCount int64 `protobuf:"varint,3,opt,name=stop_count,json=stopCount,proto3" json:"stop_count,omitempty"`
+	// Number of failures.
+	FailCount int64 `protobuf:"varint,4,opt,name=fail_count,json=failCount,proto3" json:"fail_count,omitempty"`
+	// Number of automatic restarts.
+	RestartCount int64 `protobuf:"varint,5,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	// Cumulative time the service was running.
+	Uptime *durationpb.Duration `protobuf:"bytes,6,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	// Cumulative time the service was supervised but not running.
+	Downtime *durationpb.Duration `protobuf:"bytes,7,opt,name=downtime,proto3" json:"downtime,omitempty"`
+	// Availability over 1d, 7d and 30d.
+	Availability *ServiceAvailability `protobuf:"bytes,8,opt,name=availability,proto3" json:"availability,omitempty"`
+	// Compliance with the availability objective (unset without slo).
+	Slo *SLOStatus `protobuf:"bytes,9,opt,name=slo,proto3" json:"slo,omitempty"`
+	// Most frequent failure modes of the last 30 days, at most 5, most
+	// frequent first.
+	TopExits      []*ExitCount `protobuf:"bytes,10,rep,name=top_exits,json=topExits,proto3" json:"top_exits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceStats) Reset() {
+	*x = ServiceStats{}
+	mi := &file_daemon_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceStats) ProtoMessage() {}
+
+func (x *ServiceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceStats.ProtoReflect.Descriptor instead.
+func (*ServiceStats) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ServiceStats) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceStats) GetStartCount() int64 {
+	if x != nil {
+		return x.StartCount
+	}
+	return 0
+}
+
+func (x *ServiceStats) GetStopCount() int64 {
+	if x != nil {
+		return x.StopCount
+	}
+	return 0
+}
+
+func (x *ServiceStats) GetFailCount() int64 {
+	if x != nil {
+		return x.FailCount
+	}
+	return 0
+}
+
+func (x *ServiceStats) GetRestartCount() int64 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *ServiceStats) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *ServiceStats) GetDowntime() *durationpb.Duration {
+	if x != nil {
+		return x.Downtime
+	}
+	return nil
+}
+
+func (x *ServiceStats) GetAvailability() *ServiceAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *ServiceStats) GetSlo() *SLOStatus {
+	if x != nil {
+		return x.Slo
+	}
+	return nil
+}
+
+func (x *ServiceStats) GetTopExits() []*ExitCount {
+	if x != nil {
+		return x.TopExits
+	}
+	return nil
+}
+
+// ExitCount is the number of failures of a service with one exit cause.
+type ExitCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Exit code; zero when killed by a signal.
+	ExitCode int32 `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// POSIX name of the signal that killed the process; empty for an exit code.
+	Signal string `protobuf:"bytes,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	// Number of failures.
+	Count int64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// When the last of them occurred.
+	Last          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last,proto3" json:"last,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitCount) Reset() {
+	*x = ExitCount{}
+	mi := &file_daemon_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitCount) ProtoMessage() {}
+
+func (x *ExitCount) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitCount.ProtoReflect.Descriptor instead.
+func (*ExitCount) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ExitCount) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ExitCount) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *ExitCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ExitCount) GetLast() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
+// SLOStatus is the compliance of a service with its availability objective.
+type SLOStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Objective, in percent.
+	Target float64 `protobuf:"fixed64,1,opt,name=target,proto3" json:"target,omitempty"`
+	// Compliance window.
+	Window *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	// Share of observed time the service was available over the window, in percent.
+	Availability float64 `protobuf:"fixed64,3,opt,name=availability,proto3" json:"availability,omitempty"`
+	// Share of the error budget left, in percent; negative once exhausted.
+	BudgetRemaining float64 `protobuf:"fixed64,4,opt,name=budget_remaining,json=budgetRemaining,proto3" json:"budget_remaining,omitempty"`
+	// Measured burn rate of each burn alert.
+	BurnRates     []*BurnRate `protobuf:"bytes,5,rep,name=burn_rates,json=burnRates,proto3" json:"burn_rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOStatus) Reset() {
+	*x = SLOStatus{}
+	mi := &file_daemon_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOStatus) ProtoMessage() {}
+
+func (x *SLOStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOStatus.ProtoReflect.Descriptor instead.
+func (*SLOStatus) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SLOStatus) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *SLOStatus) GetAvailability() float64 {
+	if x != nil {
+		return x.Availability
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetBudgetRemaining() float64 {
+	if x != nil {
+		return x.BudgetRemaining
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetBurnRates() []*BurnRate {
+	if x != nil {
+		return x.BurnRates
+	}
+	return nil
+}
+
+// BurnRate is the error budget burn of a service over a lookback window.
+type BurnRate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lookback over which the rate is measured.
+	Window *durationpb.Duration `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	// Rate at which the alert fires.
+	Threshold float64 `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Measured burn rate; 1 consumes the budget in exactly the SLO window.
+	Rate float64 `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Whether the rate reaches the threshold.
+	Alerting      bool `protobuf:"varint,4,opt,name=alerting,proto3" json:"alerting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BurnRate) Reset() {
+	*x = BurnRate{}
+	mi := &file_daemon_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BurnRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BurnRate) ProtoMessage() {}
+
+func (x *BurnRate) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BurnRate.ProtoReflect.Descriptor instead.
+func (*BurnRate) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *BurnRate) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *BurnRate) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *BurnRate) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *BurnRate) GetAlerting() bool {
+	if x != nil {
+		return x.Alerting
+	}
+	return false
+}
+
+// ServiceAvailability is the share of time a service was up, in percent.
+// A window without observed downtime reports 100.
+type ServiceAvailability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Availability over the last day.
+	Day float64 `protobuf:"fixed64,1,opt,name=day,proto3" json:"day,omitempty"`
+	// Availability over the last 7 days.
+	Week float64 `protobuf:"fixed64,2,opt,name=week,proto3" json:"week,omitempty"`
+	// Availability over the last 30 days.
+	Month         float64 `protobuf:"fixed64,3,opt,name=month,proto3" json:"month,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceAvailability) Reset() {
+	*x = ServiceAvailability{}
+	mi := &file_daemon_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceAvailability) ProtoMessage() {}
+
+func (x *ServiceAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceAvailability.ProtoReflect.Descriptor instead.
+func (*ServiceAvailability) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ServiceAvailability) GetDay() float64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *ServiceAvailability) GetWeek() float64 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *ServiceAvailability) GetMonth() float64 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+// GetJobHistoryRequest identifies the oneshot service whose runs to return.
+type GetJobHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobHistoryRequest) Reset() {
+	*x = GetJobHistoryRequest{}
+	mi := &file_daemon_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobHistoryRequest) ProtoMessage() {}
+
+func (x *GetJobHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetJobHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetJobHistoryRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// JobHistory lists the last runs of a oneshot service.
+type JobHistory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Runs, most recent first; job_history runs are kept (default 10).
+	Runs          []*JobRun `protobuf:"bytes,2,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobHistory) Reset() {
+	*x = JobHistory{}
+	mi := &file_daemon_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobHistory) ProtoMessage() {}
+
+func (x *JobHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobHistory.ProtoReflect.Descriptor instead.
+func (*JobHistory) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *JobHistory) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *JobHistory) GetRuns() []*JobRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+// JobRun is the outcome of one run of a oneshot service.
+type JobRun struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the run started, or failed to start.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// How long the process ran.
+	Duration *durationpb.Duration `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Exit code of the process.
+	ExitCode int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// Number of the signal that terminated the process, zero if none.
+	Signal int32 `protobuf:"varint,4,opt,name=signal,proto3" json:"signal,omitempty"`
+	// Whether the process exited with code 0.
+	Succeeded bool `protobuf:"varint,5,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// Failure description; empty on success.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Last output lines of the run, oldest first.
+	Output        []string `protobuf:"bytes,7,rep,name=output,proto3" json:"output,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobRun) Reset() {
+	*x = JobRun{}
+	mi := &file_daemon_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRun) ProtoMessage() {}
+
+func (x *JobRun) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRun.ProtoReflect.Descriptor instead.
+func (*JobRun) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *JobRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *JobRun) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *JobRun) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *JobRun) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+func (x *JobRun) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *JobRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *JobRun) GetOutput() []string {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+// VerifyServicesRequest selects the services to verify.
+type VerifyServicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service names; empty verifies every service.
+	ServiceNames  []string `protobuf:"bytes,1,rep,name=service_names,json=serviceNames,proto3" json:"service_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyServicesRequest) Reset() {
+	*x = VerifyServicesRequest{}
+	mi := &file_daemon_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyServicesRequest) ProtoMessage() {}
+
+func (x *VerifyServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyServicesRequest.ProtoReflect.Descriptor instead.
+func (*VerifyServicesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *VerifyServicesRequest) GetServiceNames() []string {
+	if x != nil {
+		return x.ServiceNames
+	}
+	return nil
+}
+
+// VerifyReport is the outcome of a service verification.
+type VerifyReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether every check passed.
+	Passed bool `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Failed checks, in discovery order.
+	Failures      []*PreflightFailure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyReport) Reset() {
+	*x = VerifyReport{}
+	mi := &file_daemon_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyReport) ProtoMessage() {}
+
+func (x *VerifyReport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyReport.ProtoReflect.Descriptor instead.
+func (*VerifyReport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *VerifyReport) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *VerifyReport) GetFailures() []*PreflightFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// PreflightFailure is one failed pre-flight check.
+type PreflightFailure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Check name: binary, user, group, working_directory, security_label,
+	// egress or verify.
+	Check string `protobuf:"bytes,2,opt,name=check,proto3" json:"check,omitempty"`
+	// Why the check failed; for verify, the command output tail.
+	Detail        string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightFailure) Reset() {
+	*x = PreflightFailure{}
+	mi := &file_daemon_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightFailure) ProtoMessage() {}
+
+func (x *PreflightFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightFailure.ProtoReflect.Descriptor instead.
+func (*PreflightFailure) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PreflightFailure) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *PreflightFailure) GetCheck() string {
+	if x != nil {
+		return x.Check
+	}
+	return ""
+}
+
+func (x *PreflightFailure) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// DaemonInfo is the resource usage of the daemon process, to detect the
+// supervisor itself misbehaving.
+type DaemonInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resident set size in bytes; zero when procfs is unavailable.
+	RssBytes uint64 `protobuf:"varint,1,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	// Open file descriptors; zero when procfs is unavailable.
+	OpenFds uint32 `protobuf:"varint,2,opt,name=open_fds,json=openFds,proto3" json:"open_fds,omitempty"`
+	// Live goroutines.
+	Goroutines uint32 `protobuf:"varint,3,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	// Bytes of allocated heap objects.
+	HeapAllocBytes uint64 `protobuf:"varint,4,opt,name=heap_alloc_bytes,json=heapAllocBytes,proto3" json:"heap_alloc_bytes,omitempty"`
+	// Completed garbage collections.
+	GcCycles uint32 `protobuf:"varint,5,opt,name=gc_cycles,json=gcCycles,proto3" json:"gc_cycles,omitempty"`
+	// Cumulative garbage collection pause time.
+	GcPauseTotal *durationpb.Duration `protobuf:"bytes,6,opt,name=gc_pause_total,json=gcPauseTotal,proto3" json:"gc_pause_total,omitempty"`
+	// Pause of the last garbage collection.
+	LastGcPause *durationpb.Duration `protobuf:"bytes,7,opt,name=last_gc_pause,json=lastGcPause,proto3" json:"last_gc_pause,omitempty"`
+	// Internal event queues, sorted by name.
+	Queues []*QueueDepth `protobuf:"bytes,8,rep,name=queues,proto3" json:"queues,omitempty"`
+	// Periodic loops, sorted by name.
+	Loops         []*LoopLatency `protobuf:"bytes,9,rep,name=loops,proto3" json:"loops,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonInfo) Reset() {
+	*x = DaemonInfo{}
+	mi := &file_daemon_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonInfo) ProtoMessage() {}
+
+func (x *DaemonInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonInfo.ProtoReflect.Descriptor instead.
+func (*DaemonInfo) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DaemonInfo) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetOpenFds() uint32 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetGoroutines() uint32 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetHeapAllocBytes() uint64 {
+	if x != nil {
+		return x.HeapAllocBytes
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetGcCycles() uint32 {
+	if x != nil {
+		return x.GcCycles
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetGcPauseTotal() *durationpb.Duration {
+	if x != nil {
+		return x.GcPauseTotal
+	}
+	return nil
+}
+
+func (x *DaemonInfo) GetLastGcPause() *durationpb.Duration {
+	if x != nil {
+		return x.LastGcPause
+	}
+	return nil
+}
+
+func (x *DaemonInfo) GetQueues() []*QueueDepth {
+	if x != nil {
+		return x.Queues
+	}
+	return nil
+}
+
+func (x *DaemonInfo) GetLoops() []*LoopLatency {
+	if x != nil {
+		return x.Loops
+	}
+	return nil
+}
+
+// QueueDepth is the number of pending items of an internal queue.
+type QueueDepth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Queue name: events/<service> or reload.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Pending items.
+	Depth uint32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Items the queue holds before blocking.
+	Capacity      uint32 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueDepth) Reset() {
+	*x = QueueDepth{}
+	mi := &file_daemon_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueDepth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueDepth) ProtoMessage() {}
+
+func (x *QueueDepth) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueDepth.ProtoReflect.Descriptor instead.
+func (*QueueDepth) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *QueueDepth) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueueDepth) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *QueueDepth) GetCapacity() uint32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+// LoopLatency is how long the last iteration of a periodic loop took.
+type LoopLatency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Loop name: metrics or health/<service>.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Duration of the last iteration.
+	Latency       *durationpb.Duration `protobuf:"bytes,2,opt,name=latency,proto3" json:"latency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoopLatency) Reset() {
+	*x = LoopLatency{}
+	mi := &file_daemon_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoopLatency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoopLatency) ProtoMessage() {}
+
+func (x *LoopLatency) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoopLatency.ProtoReflect.Descriptor instead.
+func (*LoopLatency) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *LoopLatency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LoopLatency) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+// GetProcessTreeRequest identifies the service whose processes to return.
+type GetProcessTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name; empty for every running service.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProcessTreeRequest) Reset() {
+	*x = GetProcessTreeRequest{}
+	mi := &file_daemon_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProcessTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProcessTreeRequest) ProtoMessage() {}
+
+func (x *GetProcessTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProcessTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetProcessTreeRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetProcessTreeRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ProcessTrees lists the process tree of running services.
+type ProcessTrees struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Trees, sorted by service name.
+	Services      []*ServiceProcessTree `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessTrees) Reset() {
+	*x = ProcessTrees{}
+	mi := &file_daemon_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessTrees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessTrees) ProtoMessage() {}
+
+func (x *ProcessTrees) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessTrees.ProtoReflect.Descriptor instead.
+func (*ProcessTrees) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ProcessTrees) GetServices() []*ServiceProcessTree {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// ServiceProcessTree is the process tree under the main process of a service.
+type ServiceProcessTree struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Main process of the service.
+	Root          *ProcessNode `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceProcessTree) Reset() {
+	*x = ServiceProcessTree{}
+	mi := &file_daemon_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceProcessTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceProcessTree) ProtoMessage() {}
+
+func (x *ServiceProcessTree) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceProcessTree.ProtoReflect.Descriptor instead.
+func (*ServiceProcessTree) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ServiceProcessTree) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceProcessTree) GetRoot() *ProcessNode {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+// ProcessNode is a process and the processes it spawned, linked by parent PID.
+type ProcessNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Process ID.
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Command name reported by the kernel.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Command line; empty for zombies.
+	Cmdline string `protobuf:"bytes,3,opt,name=cmdline,proto3" json:"cmdline,omitempty"`
+	// Kernel state letter (R, S, D, Z, ...).
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// Resident set size in bytes.
+	RssBytes uint64 `protobuf:"varint,5,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	// User and system CPU time consumed.
+	CpuTime *durationpb.Duration `protobuf:"bytes,6,opt,name=cpu_time,json=cpuTime,proto3" json:"cpu_time,omitempty"`
+	// CPU time over the process lifetime, as a percentage of one core (like ps).
+	CpuPercent float64 `protobuf:"fixed64,7,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	// Child processes, by PID.
+	Children      []*ProcessNode `protobuf:"bytes,8,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessNode) Reset() {
+	*x = ProcessNode{}
+	mi := &file_daemon_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessNode) ProtoMessage() {}
+
+func (x *ProcessNode) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessNode.ProtoReflect.Descriptor instead.
+func (*ProcessNode) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ProcessNode) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcessNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProcessNode) GetCmdline() string {
+	if x != nil {
+		return x.Cmdline
+	}
+	return ""
+}
+
+func (x *ProcessNode) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ProcessNode) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+func (x *ProcessNode) GetCpuTime() *durationpb.Duration {
+	if x != nil {
+		return x.CpuTime
+	}
+	return nil
+}
+
+func (x *ProcessNode) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ProcessNode) GetChildren() []*ProcessNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// GetServiceTimelineRequest selects a service and a time range.
+type GetServiceTimelineRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Start of the range; unset for the oldest retained event.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// End of the range, excluded; unset for now.
+	To *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Maximum number of entries; zero for the server default (1000).
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceTimelineRequest) Reset() {
+	*x = GetServiceTimelineRequest{}
+	mi := &file_daemon_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceTimelineRequest) ProtoMessage() {}
+
+func (x *GetServiceTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetServiceTimelineRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *GetServiceTimelineRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetServiceTimelineRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetServiceTimelineRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ServiceTimeline lists the events of a service over a time range.
+type ServiceTimeline struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Entries, oldest first.
+	Entries []*TimelineEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Whether more events of the range were left out by the limit.
+	Truncated     bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceTimeline) Reset() {
+	*x = ServiceTimeline{}
+	mi := &file_daemon_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTimeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTimeline) ProtoMessage() {}
+
+func (x *ServiceTimeline) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTimeline.ProtoReflect.Descriptor instead.
+func (*ServiceTimeline) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ServiceTimeline) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceTimeline) GetEntries() []*TimelineEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ServiceTimeline) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// TimelineEntry is one event of a service timeline.
+type TimelineEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the event occurred.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Event name (started, unhealthy, reloaded, or a custom event name).
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Event category: lifecycle, health, reload or alert.
+	Category string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	// Process ID at the time of the event, zero if none.
+	Pid int32 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Exit code, for exit events.
+	ExitCode int32 `protobuf:"varint,5,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// Error or detail attached to the event; empty if none.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Store sequence number of the event.
+	Seq           uint64 `protobuf:"varint,7,opt,name=seq,proto3" json:"seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineEntry) Reset() {
+	*x = TimelineEntry{}
+	mi := &file_daemon_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEntry) ProtoMessage() {}
+
+func (x *TimelineEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEntry.ProtoReflect.Descriptor instead.
+func (*TimelineEntry) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *TimelineEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TimelineEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// PauseProbesRequest names the probes to pause and for how long.
+type PauseProbesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Listener whose probe pauses; empty pauses every listener of the service.
+	Listener string `protobuf:"bytes,2,opt,name=listener,proto3" json:"listener,omitempty"`
+	// Length of the pause, after which probes resume. Unset uses 15 minutes;
+	// at most 4 hours.
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseProbesRequest) Reset() {
+	*x = PauseProbesRequest{}
+	mi := &file_daemon_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseProbesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseProbesRequest) ProtoMessage() {}
+
+func (x *PauseProbesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseProbesRequest.ProtoReflect.Descriptor instead.
+func (*PauseProbesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *PauseProbesRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *PauseProbesRequest) GetListener() string {
+	if x != nil {
+		return x.Listener
+	}
+	return ""
+}
+
+func (x *PauseProbesRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+// ResumeProbesRequest names the probes to resume.
+type ResumeProbesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Listener whose pause to lift; empty lifts every pause of the service.
+	Listener      string `protobuf:"bytes,2,opt,name=listener,proto3" json:"listener,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeProbesRequest) Reset() {
+	*x = ResumeProbesRequest{}
+	mi := &file_daemon_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeProbesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeProbesRequest) ProtoMessage() {}
+
+func (x *ResumeProbesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeProbesRequest.ProtoReflect.Descriptor instead.
+func (*ResumeProbesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ResumeProbesRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ResumeProbesRequest) GetListener() string {
+	if x != nil {
+		return x.Listener
+	}
+	return ""
+}
+
+// ProbePauses lists the active probe pauses of a service.
+type ProbePauses struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Pauses, the pause of every listener first, then by listener name.
+	Pauses        []*ProbePause `protobuf:"bytes,2,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbePauses) Reset() {
+	*x = ProbePauses{}
+	mi := &file_daemon_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbePauses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbePauses) ProtoMessage() {}
+
+func (x *ProbePauses) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbePauses.ProtoReflect.Descriptor instead.
+func (*ProbePauses) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ProbePauses) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProbePauses) GetPauses() []*ProbePause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+// ProbePause is an active pause of the probes of a service.
+type ProbePause struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Paused listener; empty when every listener is paused.
+	Listener string `protobuf:"bytes,1,opt,name=listener,proto3" json:"listener,omitempty"`
+	// When the probes resume.
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbePause) Reset() {
+	*x = ProbePause{}
+	mi := &file_daemon_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbePause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbePause) ProtoMessage() {}
+
+func (x *ProbePause) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbePause.ProtoReflect.Descriptor instead.
+func (*ProbePause) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ProbePause) GetListener() string {
+	if x != nil {
+		return x.Listener
+	}
+	return ""
+}
+
+func (x *ProbePause) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+// HostInventory is the platform capabilities of the host running the daemon.
+type HostInventory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operating system the daemon was built for, such as "linux".
+	Os string `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	// Architecture the daemon was built for, such as "amd64".
+	Architecture string `protobuf:"bytes,2,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	// Kernel release; empty when unknown.
+	KernelVersion string `protobuf:"bytes,3,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	// Version of the cgroup hierarchy: 1, 2, or 0 without one.
+	CgroupVersion int32 `protobuf:"varint,4,opt,name=cgroup_version,json=cgroupVersion,proto3" json:"cgroup_version,omitempty"`
+	// Whether the kernel exposes pressure stall information.
+	PressureStall bool `protobuf:"varint,5,opt,name=pressure_stall,json=pressureStall,proto3" json:"pressure_stall,omitempty"`
+	// Active Linux security modules among "apparmor" and "selinux".
+	SecurityModules []string `protobuf:"bytes,6,rep,name=security_modules,json=securityModules,proto3" json:"security_modules,omitempty"`
+	// Whether the nft command is installed.
+	Nftables bool `protobuf:"varint,7,opt,name=nftables,proto3" json:"nftables,omitempty"`
+	// Whether systemd is the init system of the host.
+	Systemd bool `protobuf:"varint,8,opt,name=systemd,proto3" json:"systemd,omitempty"`
+	// Container runtime sockets found.
+	ContainerSockets []*ContainerSocket `protobuf:"bytes,9,rep,name=container_sockets,json=containerSockets,proto3" json:"container_sockets,omitempty"`
+	// Whether the daemon runs as PID 1.
+	Pid1 bool `protobuf:"varint,10,opt,name=pid1,proto3" json:"pid1,omitempty"`
+	// Whether the daemon runs as root.
+	Privileged bool `protobuf:"varint,11,opt,name=privileged,proto3" json:"privileged,omitempty"`
+	// Soft limit of open file descriptors of the daemon; 0 when unknown.
+	MaxFds uint64 `protobuf:"varint,12,opt,name=max_fds,json=maxFds,proto3" json:"max_fds,omitempty"`
+	// Hard limit of open file descriptors of the daemon; 0 when unknown.
+	MaxFdsHard uint64 `protobuf:"varint,13,opt,name=max_fds_hard,json=maxFdsHard,proto3" json:"max_fds_hard,omitempty"`
+	// Support of each optional feature, in a fixed order.
+	Features      []*FeatureSupport `protobuf:"bytes,14,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostInventory) Reset() {
+	*x = HostInventory{}
+	mi := &file_daemon_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostInventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostInventory) ProtoMessage() {}
+
+func (x *HostInventory) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostInventory.ProtoReflect.Descriptor instead.
+func (*HostInventory) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *HostInventory) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *HostInventory) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *HostInventory) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *HostInventory) GetCgroupVersion() int32 {
+	if x != nil {
+		return x.CgroupVersion
+	}
+	return 0
+}
+
+func (x *HostInventory) GetPressureStall() bool {
+	if x != nil {
+		return x.PressureStall
+	}
+	return false
+}
+
+func (x *HostInventory) GetSecurityModules() []string {
+	if x != nil {
+		return x.SecurityModules
+	}
+	return nil
+}
+
+func (x *HostInventory) GetNftables() bool {
+	if x != nil {
+		return x.Nftables
+	}
+	return false
+}
+
+func (x *HostInventory) GetSystemd() bool {
+	if x != nil {
+		return x.Systemd
+	}
+	return false
+}
+
+func (x *HostInventory) GetContainerSockets() []*ContainerSocket {
+	if x != nil {
+		return x.ContainerSockets
+	}
+	return nil
+}
+
+func (x *HostInventory) GetPid1() bool {
+	if x != nil {
+		return x.Pid1
+	}
+	return false
+}
+
+func (x *HostInventory) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+func (x *HostInventory) GetMaxFds() uint64 {
+	if x != nil {
+		return x.MaxFds
+	}
+	return 0
+}
+
+func (x *HostInventory) GetMaxFdsHard() uint64 {
+	if x != nil {
+		return x.MaxFdsHard
+	}
+	return 0
+}
+
+func (x *HostInventory) GetFeatures() []*FeatureSupport {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// ContainerSocket is the API socket of a container runtime.
+type ContainerSocket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container runtime, such as "docker" or "podman".
+	Runtime string `protobuf:"bytes,1,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	// Path of the socket.
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerSocket) Reset() {
+	*x = ContainerSocket{}
+	mi := &file_daemon_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerSocket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerSocket) ProtoMessage() {}
+
+func (x *ContainerSocket) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerSocket.ProtoReflect.Descriptor instead.
+func (*ContainerSocket) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ContainerSocket) GetRuntime() string {
+	if x != nil {
+		return x.Runtime
+	}
+	return ""
+}
+
+func (x *ContainerSocket) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// FeatureSupport tells whether an optional feature can run on the host.
+type FeatureSupport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Feature name, such as "egress" or "shedding".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether the feature can run.
+	Supported bool `protobuf:"varint,2,opt,name=supported,proto3" json:"supported,omitempty"`
+	// Why the feature is disabled; empty when supported.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureSupport) Reset() {
+	*x = FeatureSupport{}
+	mi := &file_daemon_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureSupport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureSupport) ProtoMessage() {}
+
+func (x *FeatureSupport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureSupport.ProtoReflect.Descriptor instead.
+func (*FeatureSupport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *FeatureSupport) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureSupport) GetSupported() bool {
+	if x != nil {
+		return x.Supported
+	}
+	return false
+}
+
+func (x *FeatureSupport) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// GetDependencyMapRequest identifies the service whose connections to return.
+type GetDependencyMapRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service name; empty for every service.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Whether to return only the edges depends_on and
+	// external_dependencies do not declare.
+	UndeclaredOnly bool `protobuf:"varint,2,opt,name=undeclared_only,json=undeclaredOnly,proto3" json:"undeclared_only,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDependencyMapRequest) Reset() {
+	*x = GetDependencyMapRequest{}
+	mi := &file_daemon_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDependencyMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDependencyMapRequest) ProtoMessage() {}
+
+func (x *GetDependencyMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDependencyMapRequest.ProtoReflect.Descriptor instead.
+func (*GetDependencyMapRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetDependencyMapRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *GetDependencyMapRequest) GetUndeclaredOnly() bool {
+	if x != nil {
+		return x.UndeclaredOnly
+	}
+	return false
+}
+
+// DependencyMap lists the connections sampled from the services.
+type DependencyMap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Edges, sorted by service, supervised peers first.
+	Edges         []*TrafficEdge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyMap) Reset() {
+	*x = DependencyMap{}
+	mi := &file_daemon_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyMap) ProtoMessage() {}
+
+func (x *DependencyMap) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyMap.ProtoReflect.Descriptor instead.
+func (*DependencyMap) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DependencyMap) GetEdges() []*TrafficEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+// TrafficEdge is traffic sampled from a service to a supervised service or
+// an endpoint outside the daemon.
+type TrafficEdge struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service opening the connections.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Supervised service reached; empty for endpoints outside the daemon.
+	Peer string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	// Address and port reached; the last seen for a supervised service.
+	Endpoint string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// Whether depends_on or external_dependencies declares the edge.
+	Declared bool `protobuf:"varint,4,opt,name=declared,proto3" json:"declared,omitempty"`
+	// Number of samples that saw a connection.
+	Samples int64 `protobuf:"varint,5,opt,name=samples,proto3" json:"samples,omitempty"`
+	// When a connection was first seen.
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// When a connection was last seen.
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficEdge) Reset() {
+	*x = TrafficEdge{}
+	mi := &file_daemon_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficEdge) ProtoMessage() {}
+
+func (x *TrafficEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficEdge.ProtoReflect.Descriptor instead.
+func (*TrafficEdge) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *TrafficEdge) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TrafficEdge) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *TrafficEdge) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *TrafficEdge) GetDeclared() bool {
+	if x != nil {
+		return x.Declared
+	}
+	return false
+}
+
+func (x *TrafficEdge) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *TrafficEdge) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *TrafficEdge) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// SetSafeModeRequest enters or exits safe mode.
+type SetSafeModeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether to enter safe mode; false exits it.
+	Enabled       bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSafeModeRequest) Reset() {
+	*x = SetSafeModeRequest{}
+	mi := &file_daemon_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSafeModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSafeModeRequest) ProtoMessage() {}
+
+func (x *SetSafeModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSafeModeRequest.ProtoReflect.Descriptor instead.
+func (*SetSafeModeRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *SetSafeModeRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// SafeModeState is the safe mode of the daemon.
+type SafeModeState struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether restarts of non-critical services are held.
+	Active bool `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	// Whether safe mode was entered on request rather than by a restart storm.
+	Manual bool `protobuf:"varint,2,opt,name=manual,proto3" json:"manual,omitempty"`
+	// When safe mode was entered; unset when inactive.
+	Since *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	// Restarts counted within the window when the storm entered safe mode.
+	Restarts int32 `protobuf:"varint,4,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	// Window the restarts were counted over.
+	Window *durationpb.Duration `protobuf:"bytes,5,opt,name=window,proto3" json:"window,omitempty"`
+	// Services whose restart is held, sorted.
+	Held          []string `protobuf:"bytes,6,rep,name=held,proto3" json:"held,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SafeModeState) Reset() {
+	*x = SafeModeState{}
+	mi := &file_daemon_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SafeModeState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SafeModeState) ProtoMessage() {}
+
+func (x *SafeModeState) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SafeModeState.ProtoReflect.Descriptor instead.
+func (*SafeModeState) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *SafeModeState) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *SafeModeState) GetManual() bool {
+	if x != nil {
+		return x.Manual
+	}
+	return false
+}
+
+func (x *SafeModeState) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *SafeModeState) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *SafeModeState) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *SafeModeState) GetHeld() []string {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+var File_daemon_proto protoreflect.FileDescriptor
+
+const file_daemon_proto_rawDesc = "" +
+	"\n" +
+	"\fdaemon.proto\x12\tdaemon.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\"K\n" +
+	"\x12StreamStateRequest\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\"M\n" +
+	"\x14StreamMetricsRequest\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\"w\n" +
+	"\x1bStreamProcessMetricsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\"6\n" +
+	"\x11GetProcessRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"\xd3\x02\n" +
+	"\x14ListProcessesRequest\x12Y\n" +
+	"\x0elabel_selector\x18\x01 \x03(\v22.daemon.v1.ListProcessesRequest.LabelSelectorEntryR\rlabelSelector\x12/\n" +
+	"\x06states\x18\x02 \x03(\x0e2\x17.daemon.v1.ProcessStateR\x06states\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06fields\x18\x06 \x03(\tR\x06fields\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x97\x01\n" +
+	"\x15ListProcessesResponse\x127\n" +
+	"\tprocesses\x18\x01 \x03(\v2\x19.daemon.v1.ProcessMetricsR\tprocesses\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\x9a\x03\n" +
+	"\vDaemonState\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x121\n" +
+	"\x06uptime\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12\x18\n" +
+	"\ahealthy\x18\x04 \x01(\bR\ahealthy\x127\n" +
+	"\tprocesses\x18\x05 \x03(\v2\x19.daemon.v1.ProcessMetricsR\tprocesses\x120\n" +
+	"\x06system\x18\x06 \x01(\v2\x18.daemon.v1.SystemMetricsR\x06system\x12'\n" +
+	"\x04host\x18\a \x01(\v2\x13.daemon.v1.HostInfoR\x04host\x129\n" +
+	"\n" +
+	"kubernetes\x18\b \x01(\v2\x19.daemon.v1.KubernetesInfoR\n" +
+	"kubernetes\x12\x1a\n" +
+	"\bsequence\x18\t \x01(\x04R\bsequence\"e\n" +
+	"\bHostInfo\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x0e\n" +
+	"\x02os\x18\x02 \x01(\tR\x02os\x12\x12\n" +
+	"\x04arch\x18\x03 \x01(\tR\x04arch\x12\x19\n" +
+	"\bnum_cpus\x18\x04 \x01(\x05R\anumCpus\"\xe0\x01\n" +
+	"\x0eKubernetesInfo\x12\x19\n" +
+	"\bpod_name\x18\x01 \x01(\tR\apodName\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tnode_name\x18\x03 \x01(\tR\bnodeName\x12=\n" +
+	"\x06labels\x18\x04 \x03(\v2%.daemon.v1.KubernetesInfo.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x92\x06\n" +
+	"\x0eProcessMetrics\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x02 \x01(\x05R\x03pid\x12-\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x17.daemon.v1.ProcessStateR\x05state\x12\x18\n" +
+	"\ahealthy\x18\x04 \x01(\bR\ahealthy\x12'\n" +
+	"\x03cpu\x18\x05 \x01(\v2\x15.daemon.v1.ProcessCPUR\x03cpu\x120\n" +
+	"\x06memory\x18\x06 \x01(\v2\x18.daemon.v1.ProcessMemoryR\x06memory\x129\n" +
+	"\n" +
+	"start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x121\n" +
+	"\x06uptime\x18\b \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12#\n" +
+	"\rrestart_count\x18\t \x01(\x05R\frestartCount\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tR\tlastError\x128\n" +
+	"\ttimestamp\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12+\n" +
+	"\x11throttled_percent\x18\f \x01(\x01R\x10throttledPercent\x127\n" +
+	"\bpressure\x18\r \x01(\v2\x1b.daemon.v1.ResourcePressureR\bpressure\x12B\n" +
+	"\favailability\x18\x0e \x01(\v2\x1e.daemon.v1.ServiceAvailabilityR\favailability\x12=\n" +
+	"\x06labels\x18\x0f \x03(\v2%.daemon.v1.ProcessMetrics.LabelsEntryR\x06labels\x12\x17\n" +
+	"\anum_fds\x18\x10 \x01(\rR\x06numFds\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf6\x01\n" +
+	"\n" +
+	"ProcessCPU\x12 \n" +
+	"\fuser_time_ns\x18\x01 \x01(\x04R\n" +
+	"userTimeNs\x12$\n" +
+	"\x0esystem_time_ns\x18\x02 \x01(\x04R\fsystemTimeNs\x12\"\n" +
+	"\rtotal_time_ns\x18\x03 \x01(\x04R\vtotalTimeNs\x12#\n" +
+	"\rusage_percent\x18\x04 \x01(\x01R\fusagePercent\x12(\n" +
+	"\x10per_core_percent\x18\x05 \x01(\x01R\x0eperCorePercent\x12-\n" +
+	"\x12normalized_percent\x18\x06 \x01(\x01R\x11normalizedPercent\"\xf8\x02\n" +
+	"\rProcessMemory\x12\x1b\n" +
+	"\trss_bytes\x18\x01 \x01(\x04R\brssBytes\x12\x1b\n" +
+	"\tvms_bytes\x18\x02 \x01(\x04R\bvmsBytes\x12\x1d\n" +
+	"\n" +
+	"swap_bytes\x18\x03 \x01(\x04R\tswapBytes\x12!\n" +
+	"\fshared_bytes\x18\x04 \x01(\x04R\vsharedBytes\x12\x1d\n" +
+	"\n" +
+	"data_bytes\x18\x05 \x01(\x04R\tdataBytes\x12\x1f\n" +
+	"\vstack_bytes\x18\x06 \x01(\x04R\n" +
+	"stackBytes\x12\x1b\n" +
+	"\tpss_bytes\x18\a \x01(\x04R\bpssBytes\x12\x1b\n" +
+	"\tuss_bytes\x18\b \x01(\x04R\bussBytes\x12$\n" +
+	"\x0eswap_pss_bytes\x18\t \x01(\x04R\fswapPssBytes\x12!\n" +
+	"\fmajor_faults\x18\n" +
+	" \x01(\x04R\vmajorFaults\x12(\n" +
+	"\x10major_fault_rate\x18\v \x01(\x01R\x0emajorFaultRate\"\x8b\x01\n" +
+	"\x10ResourcePressure\x12%\n" +
+	"\x03cpu\x18\x01 \x01(\v2\x13.daemon.v1.PressureR\x03cpu\x12+\n" +
+	"\x06memory\x18\x02 \x01(\v2\x13.daemon.v1.PressureR\x06memory\x12#\n" +
+	"\x02io\x18\x03 \x01(\v2\x13.daemon.v1.PressureR\x02io\"\xc8\x01\n" +
+	"\bPressure\x12\x1d\n" +
+	"\n" +
+	"some_avg10\x18\x01 \x01(\x01R\tsomeAvg10\x12\x1d\n" +
+	"\n" +
+	"some_avg60\x18\x02 \x01(\x01R\tsomeAvg60\x12\x1f\n" +
+	"\vsome_avg300\x18\x03 \x01(\x01R\n" +
+	"someAvg300\x12\x1d\n" +
+	"\n" +
+	"full_avg10\x18\x04 \x01(\x01R\tfullAvg10\x12\x1d\n" +
+	"\n" +
+	"full_avg60\x18\x05 \x01(\x01R\tfullAvg60\x12\x1f\n" +
+	"\vfull_avg300\x18\x06 \x01(\x01R\n" +
+	"fullAvg300\"\x87\x02\n" +
+	"\rSystemMetrics\x12&\n" +
+	"\x03cpu\x18\x01 \x01(\v2\x14.daemon.v1.SystemCPUR\x03cpu\x12/\n" +
+	"\x06memory\x18\x02 \x01(\v2\x17.daemon.v1.SystemMemoryR\x06memory\x12*\n" +
+	"\x04load\x18\x03 \x01(\v2\x16.daemon.v1.LoadAverageR\x04load\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x127\n" +
+	"\bpressure\x18\x05 \x01(\v2\x1b.daemon.v1.ResourcePressureR\bpressure\"\x86\x02\n" +
+	"\tSystemCPU\x12\x17\n" +
+	"\auser_ns\x18\x01 \x01(\x04R\x06userNs\x12\x17\n" +
+	"\anice_ns\x18\x02 \x01(\x04R\x06niceNs\x12\x1b\n" +
+	"\tsystem_ns\x18\x03 \x01(\x04R\bsystemNs\x12\x17\n" +
+	"\aidle_ns\x18\x04 \x01(\x04R\x06idleNs\x12\x1b\n" +
+	"\tiowait_ns\x18\x05 \x01(\x04R\biowaitNs\x12\x15\n" +
+	"\x06irq_ns\x18\x06 \x01(\x04R\x05irqNs\x12\x1d\n" +
+	"\n" +
+	"softirq_ns\x18\a \x01(\x04R\tsoftirqNs\x12\x19\n" +
+	"\bsteal_ns\x18\b \x01(\x04R\astealNs\x12#\n" +
+	"\rusage_percent\x18\t \x01(\x01R\fusagePercent\"\xa0\x03\n" +
+	"\fSystemMemory\x12\x1f\n" +
+	"\vtotal_bytes\x18\x01 \x01(\x04R\n" +
+	"totalBytes\x12'\n" +
+	"\x0favailable_bytes\x18\x02 \x01(\x04R\x0eavailableBytes\x12\x1d\n" +
+	"\n" +
+	"used_bytes\x18\x03 \x01(\x04R\tusedBytes\x12\x1d\n" +
+	"\n" +
+	"free_bytes\x18\x04 \x01(\x04R\tfreeBytes\x12#\n" +
+	"\rbuffers_bytes\x18\x05 \x01(\x04R\fbuffersBytes\x12!\n" +
+	"\fcached_bytes\x18\x06 \x01(\x04R\vcachedBytes\x12!\n" +
+	"\fshared_bytes\x18\a \x01(\x04R\vsharedBytes\x12(\n" +
+	"\x10swap_total_bytes\x18\b \x01(\x04R\x0eswapTotalBytes\x12&\n" +
+	"\x0fswap_used_bytes\x18\t \x01(\x04R\rswapUsedBytes\x12&\n" +
+	"\x0fswap_free_bytes\x18\n" +
+	" \x01(\x04R\rswapFreeBytes\x12#\n" +
 	"\rusage_percent\x18\v \x01(\x01R\fusagePercent\"Q\n" +
 	"\vLoadAverage\x12\x14\n" +
 	"\x05load1\x18\x01 \x01(\x01R\x05load1\x12\x14\n" +
 	"\x05load5\x18\x02 \x01(\x01R\x05load5\x12\x16\n" +
-	"\x06load15\x18\x03 \x01(\x01R\x06load15*\xb5\x01\n" +
+	"\x06load15\x18\x03 \x01(\x01R\x06load15\"\x92\x01\n" +
+	"\x11StreamLogsRequest\x12\x1a\n" +
+	"\bservices\x18\x01 \x03(\tR\bservices\x12\x16\n" +
+	"\x06follow\x18\x02 \x01(\bR\x06follow\x12\x12\n" +
+	"\x04tail\x18\x03 \x01(\x05R\x04tail\x12\x1b\n" +
+	"\tmin_level\x18\x04 \x01(\tR\bminLevel\x12\x18\n" +
+	"\apattern\x18\x05 \x01(\tR\apattern\"\xbf\x01\n" +
+	"\aLogLine\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x16\n" +
+	"\x06stream\x18\x02 \x01(\tR\x06stream\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x18\n" +
+	"\adropped\x18\x06 \x01(\x04R\adropped\"\x9d\x01\n" +
+	"\x0fReadLogsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x1b\n" +
+	"\tmin_level\x18\x03 \x01(\tR\bminLevel\x12\x18\n" +
+	"\apattern\x18\x04 \x01(\tR\apattern\"\xe2\x01\n" +
+	"\x10DaemonParameters\x12\x1b\n" +
+	"\tlog_level\x18\x01 \x01(\tR\blogLevel\x12@\n" +
+	"\x0eprobe_interval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\rprobeInterval\x12>\n" +
+	"\rprobe_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fprobeTimeout\x12/\n" +
+	"\x13notifications_muted\x18\x04 \x01(\bR\x12notificationsMuted\"\xb3\x01\n" +
+	"\tBuildInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x129\n" +
+	"\n" +
+	"build_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tbuildDate\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x04 \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures\":\n" +
+	"\x15GetServiceSpecRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"\xf7\x03\n" +
+	"\vServiceSpec\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x02 \x01(\x05R\x03pid\x129\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x18\n" +
+	"\acommand\x18\x04 \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x05 \x03(\tR\x04args\x12+\n" +
+	"\x11working_directory\x18\x06 \x01(\tR\x10workingDirectory\x121\n" +
+	"\x03env\x18\a \x03(\v2\x1f.daemon.v1.ServiceSpec.EnvEntryR\x03env\x12\x12\n" +
+	"\x04user\x18\b \x01(\tR\x04user\x12\x14\n" +
+	"\x05group\x18\t \x01(\tR\x05group\x12\x1f\n" +
+	"\vcgroup_path\x18\n" +
+	" \x01(\tR\n" +
+	"cgroupPath\x120\n" +
+	"\x06limits\x18\v \x03(\v2\x18.daemon.v1.ResourceLimitR\x06limits\x125\n" +
+	"\tlisteners\x18\f \x03(\v2\x17.daemon.v1.ListenerSpecR\tlisteners\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"_\n" +
+	"\rResourceLimit\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04soft\x18\x02 \x01(\tR\x04soft\x12\x12\n" +
+	"\x04hard\x18\x03 \x01(\tR\x04hard\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\"\x86\x01\n" +
+	"\fListenerSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x18\n" +
+	"\aexposed\x18\x05 \x01(\bR\aexposed\"\xd4\x01\n" +
+	"\n" +
+	"BootReport\x129\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x18\n" +
+	"\aaborted\x18\x03 \x01(\bR\aaborted\x122\n" +
+	"\bservices\x18\x04 \x03(\v2\x16.daemon.v1.ServiceBootR\bservices\"\xc8\x01\n" +
+	"\vServiceBoot\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1a\n" +
+	"\bcritical\x18\x02 \x01(\bR\bcritical\x12-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.daemon.v1.BootStatusR\x06status\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x99\x03\n" +
+	"\fReloadStatus\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\bR\arunning\x12\x18\n" +
+	"\apending\x18\x02 \x01(\bR\apending\x12\x1c\n" +
+	"\trequested\x18\x03 \x01(\x04R\trequested\x12\x1c\n" +
+	"\tcoalesced\x18\x04 \x01(\x04R\tcoalesced\x12\x18\n" +
+	"\astarted\x18\x05 \x01(\x04R\astarted\x12\x1c\n" +
+	"\tcompleted\x18\x06 \x01(\x04R\tcompleted\x12B\n" +
+	"\x0flast_started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rlastStartedAt\x12D\n" +
+	"\x10last_finished_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0elastFinishedAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12&\n" +
+	"\x0flast_error_code\x18\n" +
+	" \x01(\tR\rlastErrorCode\x12\x10\n" +
+	"\x03run\x18\v \x01(\x04R\x03run\"9\n" +
+	"\x14GetProbeTraceRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"d\n" +
+	"\n" +
+	"ProbeTrace\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x123\n" +
+	"\battempts\x18\x02 \x03(\v2\x17.daemon.v1.ProbeAttemptR\battempts\"\x96\x02\n" +
+	"\fProbeAttempt\x12#\n" +
+	"\rlistener_name\x18\x01 \x01(\tR\flistenerName\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x123\n" +
+	"\alatency\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\alatency\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06output\x18\a \x01(\tR\x06output\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\";\n" +
+	"\x16GetDependenciesRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"y\n" +
+	"\x13ServiceDependencies\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12?\n" +
+	"\fdependencies\x18\x02 \x03(\v2\x1b.daemon.v1.DependencyStatusR\fdependencies\"\x97\x02\n" +
+	"\x10DependencyStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12!\n" +
+	"\fgate_restart\x18\x04 \x01(\bR\vgateRestart\x12\x18\n" +
+	"\ahealthy\x18\x05 \x01(\bR\ahealthy\x129\n" +
+	"\n" +
+	"last_check\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tlastCheck\x123\n" +
+	"\alatency\x18\a \x01(\v2\x19.google.protobuf.DurationR\alatency\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"Q\n" +
+	"\x14SignalServiceRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x16\n" +
+	"\x06signal\x18\x02 \x01(\tR\x06signal\"\x90\x02\n" +
+	"\x18SpawnDebugServiceRequest\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x02 \x03(\tR\x04args\x12>\n" +
+	"\x03env\x18\x03 \x03(\v2,.daemon.v1.SpawnDebugServiceRequest.EnvEntryR\x03env\x12+\n" +
+	"\x03ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12!\n" +
+	"\fsandbox_from\x18\x05 \x01(\tR\vsandboxFrom\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"l\n" +
+	"\fDebugService\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\";\n" +
+	"\x16GetCertificatesRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"z\n" +
+	"\x13ServiceCertificates\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12@\n" +
+	"\fcertificates\x18\x02 \x03(\v2\x1c.daemon.v1.CertificateStatusR\fcertificates\"\xdf\x03\n" +
+	"\x11CertificateStatus\x12\x1a\n" +
+	"\blistener\x18\x01 \x01(\tR\blistener\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x16\n" +
+	"\x06issuer\x18\x04 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tdns_names\x18\x05 \x03(\tR\bdnsNames\x12#\n" +
+	"\rserial_number\x18\x06 \x01(\tR\fserialNumber\x129\n" +
+	"\n" +
+	"not_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x127\n" +
+	"\tnot_after\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bnotAfter\x12 \n" +
+	"\vfingerprint\x18\t \x01(\tR\vfingerprint\x129\n" +
+	"\n" +
+	"last_check\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tlastCheck\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"renewed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\trenewedAt\"+\n" +
+	"\x11PlanReloadRequest\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\fR\x06config\"@\n" +
+	"\n" +
+	"ReloadPlan\x122\n" +
+	"\bservices\x18\x01 \x03(\v2\x16.daemon.v1.ServicePlanR\bservices\"{\n" +
+	"\vServicePlan\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12/\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x17.daemon.v1.ReloadActionR\x06action\x12\x18\n" +
+	"\achanges\x18\x03 \x03(\tR\achanges\"9\n" +
+	"\x14ReloadServiceRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"\xad\x02\n" +
+	"\x14BatchServicesRequest\x120\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x18.daemon.v1.ServiceActionR\x06action\x12#\n" +
+	"\rservice_names\x18\x02 \x03(\tR\fserviceNames\x12Y\n" +
+	"\x0elabel_selector\x18\x03 \x03(\v22.daemon.v1.BatchServicesRequest.LabelSelectorEntryR\rlabelSelector\x12!\n" +
+	"\fmax_parallel\x18\x04 \x01(\x05R\vmaxParallel\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
+	"\x15BatchServicesResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.daemon.v1.ServiceActionResultR\aresults\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\"\xa5\x01\n" +
+	"\x13ServiceActionResult\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x126\n" +
+	"\bsnapshot\x18\x04 \x01(\v2\x1a.daemon.v1.ServiceSnapshotR\bsnapshot\"\xb4\x01\n" +
+	"\x0fServiceSnapshot\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12-\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x17.daemon.v1.ProcessStateR\x05state\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12!\n" +
+	"\foperation_id\x18\x04 \x01(\tR\voperationId\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x04R\bsequence\";\n" +
+	"\x16GetServiceStatsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"\xbe\x03\n" +
+	"\fServiceStats\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1f\n" +
+	"\vstart_count\x18\x02 \x01(\x03R\n" +
+	"startCount\x12\x1d\n" +
+	"\n" +
+	"stop_count\x18\x03 \x01(\x03R\tstopCount\x12\x1d\n" +
+	"\n" +
+	"fail_count\x18\x04 \x01(\x03R\tfailCount\x12#\n" +
+	"\rrestart_count\x18\x05 \x01(\x03R\frestartCount\x121\n" +
+	"\x06uptime\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x125\n" +
+	"\bdowntime\x18\a \x01(\v2\x19.google.protobuf.DurationR\bdowntime\x12B\n" +
+	"\favailability\x18\b \x01(\v2\x1e.daemon.v1.ServiceAvailabilityR\favailability\x12&\n" +
+	"\x03slo\x18\t \x01(\v2\x14.daemon.v1.SLOStatusR\x03slo\x121\n" +
+	"\ttop_exits\x18\n" +
+	" \x03(\v2\x14.daemon.v1.ExitCountR\btopExits\"\x86\x01\n" +
+	"\tExitCount\x12\x1b\n" +
+	"\texit_code\x18\x01 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06signal\x18\x02 \x01(\tR\x06signal\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\x12.\n" +
+	"\x04last\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04last\"\xd9\x01\n" +
+	"\tSLOStatus\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\x01R\x06target\x121\n" +
+	"\x06window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\"\n" +
+	"\favailability\x18\x03 \x01(\x01R\favailability\x12)\n" +
+	"\x10budget_remaining\x18\x04 \x01(\x01R\x0fbudgetRemaining\x122\n" +
+	"\n" +
+	"burn_rates\x18\x05 \x03(\v2\x13.daemon.v1.BurnRateR\tburnRates\"\x8b\x01\n" +
+	"\bBurnRate\x121\n" +
+	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x01R\tthreshold\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\x12\x1a\n" +
+	"\balerting\x18\x04 \x01(\bR\balerting\"Q\n" +
+	"\x13ServiceAvailability\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x01R\x03day\x12\x12\n" +
+	"\x04week\x18\x02 \x01(\x01R\x04week\x12\x14\n" +
+	"\x05month\x18\x03 \x01(\x01R\x05month\"9\n" +
+	"\x14GetJobHistoryRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"V\n" +
+	"\n" +
+	"JobHistory\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12%\n" +
+	"\x04runs\x18\x02 \x03(\v2\x11.daemon.v1.JobRunR\x04runs\"\xfb\x01\n" +
+	"\x06JobRun\x129\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06signal\x18\x04 \x01(\x05R\x06signal\x12\x1c\n" +
+	"\tsucceeded\x18\x05 \x01(\bR\tsucceeded\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x16\n" +
+	"\x06output\x18\a \x03(\tR\x06output\"<\n" +
+	"\x15VerifyServicesRequest\x12#\n" +
+	"\rservice_names\x18\x01 \x03(\tR\fserviceNames\"_\n" +
+	"\fVerifyReport\x12\x16\n" +
+	"\x06passed\x18\x01 \x01(\bR\x06passed\x127\n" +
+	"\bfailures\x18\x02 \x03(\v2\x1b.daemon.v1.PreflightFailureR\bfailures\"c\n" +
+	"\x10PreflightFailure\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05check\x18\x02 \x01(\tR\x05check\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\x88\x03\n" +
+	"\n" +
+	"DaemonInfo\x12\x1b\n" +
+	"\trss_bytes\x18\x01 \x01(\x04R\brssBytes\x12\x19\n" +
+	"\bopen_fds\x18\x02 \x01(\rR\aopenFds\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\x03 \x01(\rR\n" +
+	"goroutines\x12(\n" +
+	"\x10heap_alloc_bytes\x18\x04 \x01(\x04R\x0eheapAllocBytes\x12\x1b\n" +
+	"\tgc_cycles\x18\x05 \x01(\rR\bgcCycles\x12?\n" +
+	"\x0egc_pause_total\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fgcPauseTotal\x12=\n" +
+	"\rlast_gc_pause\x18\a \x01(\v2\x19.google.protobuf.DurationR\vlastGcPause\x12-\n" +
+	"\x06queues\x18\b \x03(\v2\x15.daemon.v1.QueueDepthR\x06queues\x12,\n" +
+	"\x05loops\x18\t \x03(\v2\x16.daemon.v1.LoopLatencyR\x05loops\"R\n" +
+	"\n" +
+	"QueueDepth\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\rR\x05depth\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\rR\bcapacity\"V\n" +
+	"\vLoopLatency\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x123\n" +
+	"\alatency\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\alatency\":\n" +
+	"\x15GetProcessTreeRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"I\n" +
+	"\fProcessTrees\x129\n" +
+	"\bservices\x18\x01 \x03(\v2\x1d.daemon.v1.ServiceProcessTreeR\bservices\"c\n" +
+	"\x12ServiceProcessTree\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12*\n" +
+	"\x04root\x18\x02 \x01(\v2\x16.daemon.v1.ProcessNodeR\x04root\"\x8b\x02\n" +
+	"\vProcessNode\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acmdline\x18\x03 \x01(\tR\acmdline\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x1b\n" +
+	"\trss_bytes\x18\x05 \x01(\x04R\brssBytes\x124\n" +
+	"\bcpu_time\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\acpuTime\x12\x1f\n" +
+	"\vcpu_percent\x18\a \x01(\x01R\n" +
+	"cpuPercent\x122\n" +
+	"\bchildren\x18\b \x03(\v2\x16.daemon.v1.ProcessNodeR\bchildren\"\xb0\x01\n" +
+	"\x19GetServiceTimelineRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x86\x01\n" +
+	"\x0fServiceTimeline\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x122\n" +
+	"\aentries\x18\x02 \x03(\v2\x18.daemon.v1.TimelineEntryR\aentries\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\"\xd0\x01\n" +
+	"\rTimelineEntry\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x10\n" +
+	"\x03pid\x18\x04 \x01(\x05R\x03pid\x12\x1b\n" +
+	"\texit_code\x18\x05 \x01(\x05R\bexitCode\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x10\n" +
+	"\x03seq\x18\a \x01(\x04R\x03seq\"\x80\x01\n" +
+	"\x12PauseProbesRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1a\n" +
+	"\blistener\x18\x02 \x01(\tR\blistener\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"T\n" +
+	"\x13ResumeProbesRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1a\n" +
+	"\blistener\x18\x02 \x01(\tR\blistener\"_\n" +
+	"\vProbePauses\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12-\n" +
+	"\x06pauses\x18\x02 \x03(\v2\x15.daemon.v1.ProbePauseR\x06pauses\"Z\n" +
+	"\n" +
+	"ProbePause\x12\x1a\n" +
+	"\blistener\x18\x01 \x01(\tR\blistener\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"\x88\x04\n" +
+	"\rHostInventory\x12\x0e\n" +
+	"\x02os\x18\x01 \x01(\tR\x02os\x12\"\n" +
+	"\farchitecture\x18\x02 \x01(\tR\farchitecture\x12%\n" +
+	"\x0ekernel_version\x18\x03 \x01(\tR\rkernelVersion\x12%\n" +
+	"\x0ecgroup_version\x18\x04 \x01(\x05R\rcgroupVersion\x12%\n" +
+	"\x0epressure_stall\x18\x05 \x01(\bR\rpressureStall\x12)\n" +
+	"\x10security_modules\x18\x06 \x03(\tR\x0fsecurityModules\x12\x1a\n" +
+	"\bnftables\x18\a \x01(\bR\bnftables\x12\x18\n" +
+	"\asystemd\x18\b \x01(\bR\asystemd\x12G\n" +
+	"\x11container_sockets\x18\t \x03(\v2\x1a.daemon.v1.ContainerSocketR\x10containerSockets\x12\x12\n" +
+	"\x04pid1\x18\n" +
+	" \x01(\bR\x04pid1\x12\x1e\n" +
+	"\n" +
+	"privileged\x18\v \x01(\bR\n" +
+	"privileged\x12\x17\n" +
+	"\amax_fds\x18\f \x01(\x04R\x06maxFds\x12 \n" +
+	"\fmax_fds_hard\x18\r \x01(\x04R\n" +
+	"maxFdsHard\x125\n" +
+	"\bfeatures\x18\x0e \x03(\v2\x19.daemon.v1.FeatureSupportR\bfeatures\"?\n" +
+	"\x0fContainerSocket\x12\x18\n" +
+	"\aruntime\x18\x01 \x01(\tR\aruntime\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"Z\n" +
+	"\x0eFeatureSupport\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tsupported\x18\x02 \x01(\bR\tsupported\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"e\n" +
+	"\x17GetDependencyMapRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12'\n" +
+	"\x0fundeclared_only\x18\x02 \x01(\bR\x0eundeclaredOnly\"=\n" +
+	"\rDependencyMap\x12,\n" +
+	"\x05edges\x18\x01 \x03(\v2\x16.daemon.v1.TrafficEdgeR\x05edges\"\x8a\x02\n" +
+	"\vTrafficEdge\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\bdeclared\x18\x04 \x01(\bR\bdeclared\x12\x18\n" +
+	"\asamples\x18\x05 \x01(\x03R\asamples\x129\n" +
+	"\n" +
+	"first_seen\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\".\n" +
+	"\x12SetSafeModeRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"\xd4\x01\n" +
+	"\rSafeModeState\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x12\x16\n" +
+	"\x06manual\x18\x02 \x01(\bR\x06manual\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x1a\n" +
+	"\brestarts\x18\x04 \x01(\x05R\brestarts\x121\n" +
+	"\x06window\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x12\n" +
+	"\x04held\x18\x06 \x03(\tR\x04held*\xd0\x01\n" +
 	"\fProcessState\x12\x1d\n" +
 	"\x19PROCESS_STATE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15PROCESS_STATE_STOPPED\x10\x01\x12\x1a\n" +
 	"\x16PROCESS_STATE_STARTING\x10\x02\x12\x19\n" +
 	"\x15PROCESS_STATE_RUNNING\x10\x03\x12\x1a\n" +
 	"\x16PROCESS_STATE_STOPPING\x10\x04\x12\x18\n" +
-	"\x14PROCESS_STATE_FAILED\x10\x052\x82\x03\n" +
+	"\x14PROCESS_STATE_FAILED\x10\x05\x12\x19\n" +
+	"\x15PROCESS_STATE_SKIPPED\x10\x06*\x8a\x01\n" +
+	"\n" +
+	"BootStatus\x12\x1b\n" +
+	"\x17BOOT_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13BOOT_STATUS_PENDING\x10\x01\x12\x15\n" +
+	"\x11BOOT_STATUS_READY\x10\x02\x12\x16\n" +
+	"\x12BOOT_STATUS_FAILED\x10\x03\x12\x17\n" +
+	"\x13BOOT_STATUS_SKIPPED\x10\x04*y\n" +
+	"\fReloadAction\x12\x1d\n" +
+	"\x19RELOAD_ACTION_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11RELOAD_ACTION_ADD\x10\x01\x12\x18\n" +
+	"\x14RELOAD_ACTION_REMOVE\x10\x02\x12\x19\n" +
+	"\x15RELOAD_ACTION_RESTART\x10\x03*~\n" +
+	"\rServiceAction\x12\x1e\n" +
+	"\x1aSERVICE_ACTION_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SERVICE_ACTION_START\x10\x01\x12\x17\n" +
+	"\x13SERVICE_ACTION_STOP\x10\x02\x12\x1a\n" +
+	"\x16SERVICE_ACTION_RESTART\x10\x032\xec\x13\n" +
 	"\rDaemonService\x12:\n" +
 	"\bGetState\x12\x16.google.protobuf.Empty\x1a\x16.daemon.v1.DaemonState\x12F\n" +
-	"\vStreamState\x12\x1d.daemon.v1.StreamStateRequest\x1a\x16.daemon.v1.DaemonState0\x01\x12I\n" +
-	"\rListProcesses\x12\x16.google.protobuf.Empty\x1a .daemon.v1.ListProcessesResponse\x12E\n" +
+	"\vStreamState\x12\x1d.daemon.v1.StreamStateRequest\x1a\x16.daemon.v1.DaemonState0\x01\x12R\n" +
+	"\rListProcesses\x12\x1f.daemon.v1.ListProcessesRequest\x1a .daemon.v1.ListProcessesResponse\x12E\n" +
 	"\n" +
 	"GetProcess\x12\x1c.daemon.v1.GetProcessRequest\x1a\x19.daemon.v1.ProcessMetrics\x12[\n" +
-	"\x14StreamProcessMetrics\x12&.daemon.v1.StreamProcessMetricsRequest\x1a\x19.daemon.v1.ProcessMetrics0\x012\xe0\x02\n" +
+	"\x14StreamProcessMetrics\x12&.daemon.v1.StreamProcessMetricsRequest\x1a\x19.daemon.v1.ProcessMetrics0\x01\x12@\n" +
+	"\n" +
+	"StreamLogs\x12\x1c.daemon.v1.StreamLogsRequest\x1a\x12.daemon.v1.LogLine0\x01\x12J\n" +
+	"\x0eGetServiceSpec\x12 .daemon.v1.GetServiceSpecRequest\x1a\x16.daemon.v1.ServiceSpec\x12>\n" +
+	"\rGetBootReport\x12\x16.google.protobuf.Empty\x1a\x15.daemon.v1.BootReport\x12@\n" +
+	"\rRequestReload\x12\x16.google.protobuf.Empty\x1a\x17.daemon.v1.ReloadStatus\x12B\n" +
+	"\x0fGetReloadStatus\x12\x16.google.protobuf.Empty\x1a\x17.daemon.v1.ReloadStatus\x12G\n" +
+	"\rGetProbeTrace\x12\x1f.daemon.v1.GetProbeTraceRequest\x1a\x15.daemon.v1.ProbeTrace\x12T\n" +
+	"\x0fGetDependencies\x12!.daemon.v1.GetDependenciesRequest\x1a\x1e.daemon.v1.ServiceDependencies\x12L\n" +
+	"\rSignalService\x12\x1f.daemon.v1.SignalServiceRequest\x1a\x1a.daemon.v1.ServiceSnapshot\x12L\n" +
+	"\rReloadService\x12\x1f.daemon.v1.ReloadServiceRequest\x1a\x1a.daemon.v1.ServiceSnapshot\x12R\n" +
+	"\rBatchServices\x12\x1f.daemon.v1.BatchServicesRequest\x1a .daemon.v1.BatchServicesResponse\x12M\n" +
+	"\x0fGetServiceStats\x12!.daemon.v1.GetServiceStatsRequest\x1a\x17.daemon.v1.ServiceStats\x12G\n" +
+	"\rGetJobHistory\x12\x1f.daemon.v1.GetJobHistoryRequest\x1a\x15.daemon.v1.JobHistory\x12K\n" +
+	"\x0eVerifyServices\x12 .daemon.v1.VerifyServicesRequest\x1a\x17.daemon.v1.VerifyReport\x12>\n" +
+	"\rGetDaemonInfo\x12\x16.google.protobuf.Empty\x1a\x15.daemon.v1.DaemonInfo\x12Q\n" +
+	"\x11SpawnDebugService\x12#.daemon.v1.SpawnDebugServiceRequest\x1a\x17.daemon.v1.DebugService\x12T\n" +
+	"\x0fGetCertificates\x12!.daemon.v1.GetCertificatesRequest\x1a\x1e.daemon.v1.ServiceCertificates\x12A\n" +
+	"\n" +
+	"PlanReload\x12\x1c.daemon.v1.PlanReloadRequest\x1a\x15.daemon.v1.ReloadPlan\x12<\n" +
+	"\bReadLogs\x12\x1a.daemon.v1.ReadLogsRequest\x1a\x12.daemon.v1.LogLine0\x01\x12J\n" +
+	"\x13GetDaemonParameters\x12\x16.google.protobuf.Empty\x1a\x1b.daemon.v1.DaemonParameters\x12O\n" +
+	"\x13SetDaemonParameters\x12\x1b.daemon.v1.DaemonParameters\x1a\x1b.daemon.v1.DaemonParameters\x12<\n" +
+	"\fGetBuildInfo\x12\x16.google.protobuf.Empty\x1a\x14.daemon.v1.BuildInfo\x12K\n" +
+	"\x0eGetProcessTree\x12 .daemon.v1.GetProcessTreeRequest\x1a\x17.daemon.v1.ProcessTrees\x12V\n" +
+	"\x12GetServiceTimeline\x12$.daemon.v1.GetServiceTimelineRequest\x1a\x1a.daemon.v1.ServiceTimeline\x12D\n" +
+	"\vPauseProbes\x12\x1d.daemon.v1.PauseProbesRequest\x1a\x16.daemon.v1.ProbePauses\x12F\n" +
+	"\fResumeProbes\x12\x1e.daemon.v1.ResumeProbesRequest\x1a\x16.daemon.v1.ProbePauses\x12D\n" +
+	"\x10GetHostInventory\x12\x16.google.protobuf.Empty\x1a\x18.daemon.v1.HostInventory\x12P\n" +
+	"\x10GetDependencyMap\x12\".daemon.v1.GetDependencyMapRequest\x1a\x18.daemon.v1.DependencyMap\x12?\n" +
+	"\vGetSafeMode\x12\x16.google.protobuf.Empty\x1a\x18.daemon.v1.SafeModeState\x12F\n" +
+	"\vSetSafeMode\x12\x1d.daemon.v1.SetSafeModeRequest\x1a\x18.daemon.v1.SafeModeState2\xe0\x02\n" +
 	"\x0eMetricsService\x12D\n" +
 	"\x10GetSystemMetrics\x12\x16.google.protobuf.Empty\x1a\x18.daemon.v1.SystemMetrics\x12R\n" +
 	"\x13StreamSystemMetrics\x12\x1f.daemon.v1.StreamMetricsRequest\x1a\x18.daemon.v1.SystemMetrics0\x01\x12[\n" +
@@ -1412,75 +7260,292 @@ func file_daemon_proto_rawDescGZIP() []byte {
 	return file_daemon_proto_rawDescData
 }
 
-var file_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 90)
 var file_daemon_proto_goTypes = []any{
 	(ProcessState)(0),                   // 0: daemon.v1.ProcessState
-	(*StreamStateRequest)(nil),          // 1: daemon.v1.StreamStateRequest
-	(*StreamMetricsRequest)(nil),        // 2: daemon.v1.StreamMetricsRequest
-	(*StreamProcessMetricsRequest)(nil), // 3: daemon.v1.StreamProcessMetricsRequest
-	(*GetProcessRequest)(nil),           // 4: daemon.v1.GetProcessRequest
-	(*ListProcessesResponse)(nil),       // 5: daemon.v1.ListProcessesResponse
-	(*DaemonState)(nil),                 // 6: daemon.v1.DaemonState
-	(*HostInfo)(nil),                    // 7: daemon.v1.HostInfo
-	(*KubernetesInfo)(nil),              // 8: daemon.v1.KubernetesInfo
-	(*ProcessMetrics)(nil),              // 9: daemon.v1.ProcessMetrics
-	(*ProcessCPU)(nil),                  // 10: daemon.v1.ProcessCPU
-	(*ProcessMemory)(nil),               // 11: daemon.v1.ProcessMemory
-	(*SystemMetrics)(nil),               // 12: daemon.v1.SystemMetrics
-	(*SystemCPU)(nil),                   // 13: daemon.v1.SystemCPU
-	(*SystemMemory)(nil),                // 14: daemon.v1.SystemMemory
-	(*LoadAverage)(nil),                 // 15: daemon.v1.LoadAverage
-	nil,                                 // 16: daemon.v1.KubernetesInfo.LabelsEntry
-	(*durationpb.Duration)(nil),         // 17: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),       // 18: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),               // 19: google.protobuf.Empty
+	(BootStatus)(0),                     // 1: daemon.v1.BootStatus
+	(ReloadAction)(0),                   // 2: daemon.v1.ReloadAction
+	(ServiceAction)(0),                  // 3: daemon.v1.ServiceAction
+	(*StreamStateRequest)(nil),          // 4: daemon.v1.StreamStateRequest
+	(*StreamMetricsRequest)(nil),        // 5: daemon.v1.StreamMetricsRequest
+	(*StreamProcessMetricsRequest)(nil), // 6: daemon.v1.StreamProcessMetricsRequest
+	(*GetProcessRequest)(nil),           // 7: daemon.v1.GetProcessRequest
+	(*ListProcessesRequest)(nil),        // 8: daemon.v1.ListProcessesRequest
+	(*ListProcessesResponse)(nil),       // 9: daemon.v1.ListProcessesResponse
+	(*DaemonState)(nil),                 // 10: daemon.v1.DaemonState
+	(*HostInfo)(nil),                    // 11: daemon.v1.HostInfo
+	(*KubernetesInfo)(nil),              // 12: daemon.v1.KubernetesInfo
+	(*ProcessMetrics)(nil),              // 13: daemon.v1.ProcessMetrics
+	(*ProcessCPU)(nil),                  // 14: daemon.v1.ProcessCPU
+	(*ProcessMemory)(nil),               // 15: daemon.v1.ProcessMemory
+	(*ResourcePressure)(nil),            // 16: daemon.v1.ResourcePressure
+	(*Pressure)(nil),                    // 17: daemon.v1.Pressure
+	(*SystemMetrics)(nil),               // 18: daemon.v1.SystemMetrics
+	(*SystemCPU)(nil),                   // 19: daemon.v1.SystemCPU
+	(*SystemMemory)(nil),                // 20: daemon.v1.SystemMemory
+	(*LoadAverage)(nil),                 // 21: daemon.v1.LoadAverage
+	(*StreamLogsRequest)(nil),           // 22: daemon.v1.StreamLogsRequest
+	(*LogLine)(nil),                     // 23: daemon.v1.LogLine
+	(*ReadLogsRequest)(nil),             // 24: daemon.v1.ReadLogsRequest
+	(*DaemonParameters)(nil),            // 25: daemon.v1.DaemonParameters
+	(*BuildInfo)(nil),                   // 26: daemon.v1.BuildInfo
+	(*GetServiceSpecRequest)(nil),       // 27: daemon.v1.GetServiceSpecRequest
+	(*ServiceSpec)(nil),                 // 28: daemon.v1.ServiceSpec
+	(*ResourceLimit)(nil),               // 29: daemon.v1.ResourceLimit
+	(*ListenerSpec)(nil),                // 30: daemon.v1.ListenerSpec
+	(*BootReport)(nil),                  // 31: daemon.v1.BootReport
+	(*ServiceBoot)(nil),                 // 32: daemon.v1.ServiceBoot
+	(*ReloadStatus)(nil),                // 33: daemon.v1.ReloadStatus
+	(*GetProbeTraceRequest)(nil),        // 34: daemon.v1.GetProbeTraceRequest
+	(*ProbeTrace)(nil),                  // 35: daemon.v1.ProbeTrace
+	(*ProbeAttempt)(nil),                // 36: daemon.v1.ProbeAttempt
+	(*GetDependenciesRequest)(nil),      // 37: daemon.v1.GetDependenciesRequest
+	(*ServiceDependencies)(nil),         // 38: daemon.v1.ServiceDependencies
+	(*DependencyStatus)(nil),            // 39: daemon.v1.DependencyStatus
+	(*SignalServiceRequest)(nil),        // 40: daemon.v1.SignalServiceRequest
+	(*SpawnDebugServiceRequest)(nil),    // 41: daemon.v1.SpawnDebugServiceRequest
+	(*DebugService)(nil),                // 42: daemon.v1.DebugService
+	(*GetCertificatesRequest)(nil),      // 43: daemon.v1.GetCertificatesRequest
+	(*ServiceCertificates)(nil),         // 44: daemon.v1.ServiceCertificates
+	(*CertificateStatus)(nil),           // 45: daemon.v1.CertificateStatus
+	(*PlanReloadRequest)(nil),           // 46: daemon.v1.PlanReloadRequest
+	(*ReloadPlan)(nil),                  // 47: daemon.v1.ReloadPlan
+	(*ServicePlan)(nil),                 // 48: daemon.v1.ServicePlan
+	(*ReloadServiceRequest)(nil),        // 49: daemon.v1.ReloadServiceRequest
+	(*BatchServicesRequest)(nil),        // 50: daemon.v1.BatchServicesRequest
+	(*BatchServicesResponse)(nil),       // 51: daemon.v1.BatchServicesResponse
+	(*ServiceActionResult)(nil),         // 52: daemon.v1.ServiceActionResult
+	(*ServiceSnapshot)(nil),             // 53: daemon.v1.ServiceSnapshot
+	(*GetServiceStatsRequest)(nil),      // 54: daemon.v1.GetServiceStatsRequest
+	(*ServiceStats)(nil),                // 55: daemon.v1.ServiceStats
+	(*ExitCount)(nil),                   // 56: daemon.v1.ExitCount
+	(*SLOStatus)(nil),                   // 57: daemon.v1.SLOStatus
+	(*BurnRate)(nil),                    // 58: daemon.v1.BurnRate
+	(*ServiceAvailability)(nil),         // 59: daemon.v1.ServiceAvailability
+	(*GetJobHistoryRequest)(nil),        // 60: daemon.v1.GetJobHistoryRequest
+	(*JobHistory)(nil),                  // 61: daemon.v1.JobHistory
+	(*JobRun)(nil),                      // 62: daemon.v1.JobRun
+	(*VerifyServicesRequest)(nil),       // 63: daemon.v1.VerifyServicesRequest
+	(*VerifyReport)(nil),                // 64: daemon.v1.VerifyReport
+	(*PreflightFailure)(nil),            // 65: daemon.v1.PreflightFailure
+	(*DaemonInfo)(nil),                  // 66: daemon.v1.DaemonInfo
+	(*QueueDepth)(nil),                  // 67: daemon.v1.QueueDepth
+	(*LoopLatency)(nil),                 // 68: daemon.v1.LoopLatency
+	(*GetProcessTreeRequest)(nil),       // 69: daemon.v1.GetProcessTreeRequest
+	(*ProcessTrees)(nil),                // 70: daemon.v1.ProcessTrees
+	(*ServiceProcessTree)(nil),          // 71: daemon.v1.ServiceProcessTree
+	(*ProcessNode)(nil),                 // 72: daemon.v1.ProcessNode
+	(*GetServiceTimelineRequest)(nil),   // 73: daemon.v1.GetServiceTimelineRequest
+	(*ServiceTimeline)(nil),             // 74: daemon.v1.ServiceTimeline
+	(*TimelineEntry)(nil),               // 75: daemon.v1.TimelineEntry
+	(*PauseProbesRequest)(nil),          // 76: daemon.v1.PauseProbesRequest
+	(*ResumeProbesRequest)(nil),         // 77: daemon.v1.ResumeProbesRequest
+	(*ProbePauses)(nil),                 // 78: daemon.v1.ProbePauses
+	(*ProbePause)(nil),                  // 79: daemon.v1.ProbePause
+	(*HostInventory)(nil),               // 80: daemon.v1.HostInventory
+	(*ContainerSocket)(nil),             // 81: daemon.v1.ContainerSocket
+	(*FeatureSupport)(nil),              // 82: daemon.v1.FeatureSupport
+	(*GetDependencyMapRequest)(nil),     // 83: daemon.v1.GetDependencyMapRequest
+	(*DependencyMap)(nil),               // 84: daemon.v1.DependencyMap
+	(*TrafficEdge)(nil),                 // 85: daemon.v1.TrafficEdge
+	(*SetSafeModeRequest)(nil),          // 86: daemon.v1.SetSafeModeRequest
+	(*SafeModeState)(nil),               // 87: daemon.v1.SafeModeState
+	nil,                                 // 88: daemon.v1.ListProcessesRequest.LabelSelectorEntry
+	nil,                                 // 89: daemon.v1.KubernetesInfo.LabelsEntry
+	nil,                                 // 90: daemon.v1.ProcessMetrics.LabelsEntry
+	nil,                                 // 91: daemon.v1.ServiceSpec.EnvEntry
+	nil,                                 // 92: daemon.v1.SpawnDebugServiceRequest.EnvEntry
+	nil,                                 // 93: daemon.v1.BatchServicesRequest.LabelSelectorEntry
+	(*durationpb.Duration)(nil),         // 94: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),       // 95: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),               // 96: google.protobuf.Empty
 }
 var file_daemon_proto_depIdxs = []int32{
-	17, // 0: daemon.v1.StreamStateRequest.interval:type_name -> google.protobuf.Duration
-	17, // 1: daemon.v1.StreamMetricsRequest.interval:type_name -> google.protobuf.Duration
-	17, // 2: daemon.v1.StreamProcessMetricsRequest.interval:type_name -> google.protobuf.Duration
-	9,  // 3: daemon.v1.ListProcessesResponse.processes:type_name -> daemon.v1.ProcessMetrics
-	18, // 4: daemon.v1.DaemonState.start_time:type_name -> google.protobuf.Timestamp
-	17, // 5: daemon.v1.DaemonState.uptime:type_name -> google.protobuf.Duration
-	9,  // 6: daemon.v1.DaemonState.processes:type_name -> daemon.v1.ProcessMetrics
-	12, // 7: daemon.v1.DaemonState.system:type_name -> daemon.v1.SystemMetrics
-	7,  // 8: daemon.v1.DaemonState.host:type_name -> daemon.v1.HostInfo
-	8,  // 9: daemon.v1.DaemonState.kubernetes:type_name -> daemon.v1.KubernetesInfo
-	16, // 10: daemon.v1.KubernetesInfo.labels:type_name -> daemon.v1.KubernetesInfo.LabelsEntry
-	0,  // 11: daemon.v1.ProcessMetrics.state:type_name -> daemon.v1.ProcessState
-	10, // 12: daemon.v1.ProcessMetrics.cpu:type_name -> daemon.v1.ProcessCPU
-	11, // 13: daemon.v1.ProcessMetrics.memory:type_name -> daemon.v1.ProcessMemory
-	18, // 14: daemon.v1.ProcessMetrics.start_time:type_name -> google.protobuf.Timestamp
-	17, // 15: daemon.v1.ProcessMetrics.uptime:type_name -> google.protobuf.Duration
-	18, // 16: daemon.v1.ProcessMetrics.timestamp:type_name -> google.protobuf.Timestamp
-	13, // 17: daemon.v1.SystemMetrics.cpu:type_name -> daemon.v1.SystemCPU
-	14, // 18: daemon.v1.SystemMetrics.memory:type_name -> daemon.v1.SystemMemory
-	15, // 19: daemon.v1.SystemMetrics.load:type_name -> daemon.v1.LoadAverage
-	18, // 20: daemon.v1.SystemMetrics.timestamp:type_name -> google.protobuf.Timestamp
-	19, // 21: daemon.v1.DaemonService.GetState:input_type -> google.protobuf.Empty
-	1,  // 22: daemon.v1.DaemonService.StreamState:input_type -> daemon.v1.StreamStateRequest
-	19, // 23: daemon.v1.DaemonService.ListProcesses:input_type -> google.protobuf.Empty
-	4,  // 24: daemon.v1.DaemonService.GetProcess:input_type -> daemon.v1.GetProcessRequest
-	3,  // 25: daemon.v1.DaemonService.StreamProcessMetrics:input_type -> daemon.v1.StreamProcessMetricsRequest
-	19, // 26: daemon.v1.MetricsService.GetSystemMetrics:input_type -> google.protobuf.Empty
-	2,  // 27: daemon.v1.MetricsService.StreamSystemMetrics:input_type -> daemon.v1.StreamMetricsRequest
-	3,  // 28: daemon.v1.MetricsService.StreamProcessMetrics:input_type -> daemon.v1.StreamProcessMetricsRequest
-	2,  // 29: daemon.v1.MetricsService.StreamAllProcessMetrics:input_type -> daemon.v1.StreamMetricsRequest
-	6,  // 30: daemon.v1.DaemonService.GetState:output_type -> daemon.v1.DaemonState
-	6,  // 31: daemon.v1.DaemonService.StreamState:output_type -> daemon.v1.DaemonState
-	5,  // 32: daemon.v1.DaemonService.ListProcesses:output_type -> daemon.v1.ListProcessesResponse
-	9,  // 33: daemon.v1.DaemonService.GetProcess:output_type -> daemon.v1.ProcessMetrics
-	9,  // 34: daemon.v1.DaemonService.StreamProcessMetrics:output_type -> daemon.v1.ProcessMetrics
-	12, // 35: daemon.v1.MetricsService.GetSystemMetrics:output_type -> daemon.v1.SystemMetrics
-	12, // 36: daemon.v1.MetricsService.StreamSystemMetrics:output_type -> daemon.v1.SystemMetrics
-	9,  // 37: daemon.v1.MetricsService.StreamProcessMetrics:output_type -> daemon.v1.ProcessMetrics
-	9,  // 38: daemon.v1.MetricsService.StreamAllProcessMetrics:output_type -> daemon.v1.ProcessMetrics
-	30, // [30:39] is the sub-list for method output_type
-	21, // [21:30] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	94,  // 0: daemon.v1.StreamStateRequest.interval:type_name -> google.protobuf.Duration
+	94,  // 1: daemon.v1.StreamMetricsRequest.interval:type_name -> google.protobuf.Duration
+	94,  // 2: daemon.v1.StreamProcessMetricsRequest.interval:type_name -> google.protobuf.Duration
+	88,  // 3: daemon.v1.ListProcessesRequest.label_selector:type_name -> daemon.v1.ListProcessesRequest.LabelSelectorEntry
+	0,   // 4: daemon.v1.ListProcessesRequest.states:type_name -> daemon.v1.ProcessState
+	13,  // 5: daemon.v1.ListProcessesResponse.processes:type_name -> daemon.v1.ProcessMetrics
+	95,  // 6: daemon.v1.DaemonState.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 7: daemon.v1.DaemonState.uptime:type_name -> google.protobuf.Duration
+	13,  // 8: daemon.v1.DaemonState.processes:type_name -> daemon.v1.ProcessMetrics
+	18,  // 9: daemon.v1.DaemonState.system:type_name -> daemon.v1.SystemMetrics
+	11,  // 10: daemon.v1.DaemonState.host:type_name -> daemon.v1.HostInfo
+	12,  // 11: daemon.v1.DaemonState.kubernetes:type_name -> daemon.v1.KubernetesInfo
+	89,  // 12: daemon.v1.KubernetesInfo.labels:type_name -> daemon.v1.KubernetesInfo.LabelsEntry
+	0,   // 13: daemon.v1.ProcessMetrics.state:type_name -> daemon.v1.ProcessState
+	14,  // 14: daemon.v1.ProcessMetrics.cpu:type_name -> daemon.v1.ProcessCPU
+	15,  // 15: daemon.v1.ProcessMetrics.memory:type_name -> daemon.v1.ProcessMemory
+	95,  // 16: daemon.v1.ProcessMetrics.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 17: daemon.v1.ProcessMetrics.uptime:type_name -> google.protobuf.Duration
+	95,  // 18: daemon.v1.ProcessMetrics.timestamp:type_name -> google.protobuf.Timestamp
+	16,  // 19: daemon.v1.ProcessMetrics.pressure:type_name -> daemon.v1.ResourcePressure
+	59,  // 20: daemon.v1.ProcessMetrics.availability:type_name -> daemon.v1.ServiceAvailability
+	90,  // 21: daemon.v1.ProcessMetrics.labels:type_name -> daemon.v1.ProcessMetrics.LabelsEntry
+	17,  // 22: daemon.v1.ResourcePressure.cpu:type_name -> daemon.v1.Pressure
+	17,  // 23: daemon.v1.ResourcePressure.memory:type_name -> daemon.v1.Pressure
+	17,  // 24: daemon.v1.ResourcePressure.io:type_name -> daemon.v1.Pressure
+	19,  // 25: daemon.v1.SystemMetrics.cpu:type_name -> daemon.v1.SystemCPU
+	20,  // 26: daemon.v1.SystemMetrics.memory:type_name -> daemon.v1.SystemMemory
+	21,  // 27: daemon.v1.SystemMetrics.load:type_name -> daemon.v1.LoadAverage
+	95,  // 28: daemon.v1.SystemMetrics.timestamp:type_name -> google.protobuf.Timestamp
+	16,  // 29: daemon.v1.SystemMetrics.pressure:type_name -> daemon.v1.ResourcePressure
+	95,  // 30: daemon.v1.LogLine.timestamp:type_name -> google.protobuf.Timestamp
+	95,  // 31: daemon.v1.ReadLogsRequest.since:type_name -> google.protobuf.Timestamp
+	94,  // 32: daemon.v1.DaemonParameters.probe_interval:type_name -> google.protobuf.Duration
+	94,  // 33: daemon.v1.DaemonParameters.probe_timeout:type_name -> google.protobuf.Duration
+	95,  // 34: daemon.v1.BuildInfo.build_date:type_name -> google.protobuf.Timestamp
+	95,  // 35: daemon.v1.ServiceSpec.started_at:type_name -> google.protobuf.Timestamp
+	91,  // 36: daemon.v1.ServiceSpec.env:type_name -> daemon.v1.ServiceSpec.EnvEntry
+	29,  // 37: daemon.v1.ServiceSpec.limits:type_name -> daemon.v1.ResourceLimit
+	30,  // 38: daemon.v1.ServiceSpec.listeners:type_name -> daemon.v1.ListenerSpec
+	95,  // 39: daemon.v1.BootReport.started_at:type_name -> google.protobuf.Timestamp
+	95,  // 40: daemon.v1.BootReport.completed_at:type_name -> google.protobuf.Timestamp
+	32,  // 41: daemon.v1.BootReport.services:type_name -> daemon.v1.ServiceBoot
+	1,   // 42: daemon.v1.ServiceBoot.status:type_name -> daemon.v1.BootStatus
+	94,  // 43: daemon.v1.ServiceBoot.duration:type_name -> google.protobuf.Duration
+	95,  // 44: daemon.v1.ReloadStatus.last_started_at:type_name -> google.protobuf.Timestamp
+	95,  // 45: daemon.v1.ReloadStatus.last_finished_at:type_name -> google.protobuf.Timestamp
+	36,  // 46: daemon.v1.ProbeTrace.attempts:type_name -> daemon.v1.ProbeAttempt
+	95,  // 47: daemon.v1.ProbeAttempt.timestamp:type_name -> google.protobuf.Timestamp
+	94,  // 48: daemon.v1.ProbeAttempt.latency:type_name -> google.protobuf.Duration
+	39,  // 49: daemon.v1.ServiceDependencies.dependencies:type_name -> daemon.v1.DependencyStatus
+	95,  // 50: daemon.v1.DependencyStatus.last_check:type_name -> google.protobuf.Timestamp
+	94,  // 51: daemon.v1.DependencyStatus.latency:type_name -> google.protobuf.Duration
+	92,  // 52: daemon.v1.SpawnDebugServiceRequest.env:type_name -> daemon.v1.SpawnDebugServiceRequest.EnvEntry
+	94,  // 53: daemon.v1.SpawnDebugServiceRequest.ttl:type_name -> google.protobuf.Duration
+	95,  // 54: daemon.v1.DebugService.expires_at:type_name -> google.protobuf.Timestamp
+	45,  // 55: daemon.v1.ServiceCertificates.certificates:type_name -> daemon.v1.CertificateStatus
+	95,  // 56: daemon.v1.CertificateStatus.not_before:type_name -> google.protobuf.Timestamp
+	95,  // 57: daemon.v1.CertificateStatus.not_after:type_name -> google.protobuf.Timestamp
+	95,  // 58: daemon.v1.CertificateStatus.last_check:type_name -> google.protobuf.Timestamp
+	95,  // 59: daemon.v1.CertificateStatus.renewed_at:type_name -> google.protobuf.Timestamp
+	48,  // 60: daemon.v1.ReloadPlan.services:type_name -> daemon.v1.ServicePlan
+	2,   // 61: daemon.v1.ServicePlan.action:type_name -> daemon.v1.ReloadAction
+	3,   // 62: daemon.v1.BatchServicesRequest.action:type_name -> daemon.v1.ServiceAction
+	93,  // 63: daemon.v1.BatchServicesRequest.label_selector:type_name -> daemon.v1.BatchServicesRequest.LabelSelectorEntry
+	52,  // 64: daemon.v1.BatchServicesResponse.results:type_name -> daemon.v1.ServiceActionResult
+	53,  // 65: daemon.v1.ServiceActionResult.snapshot:type_name -> daemon.v1.ServiceSnapshot
+	0,   // 66: daemon.v1.ServiceSnapshot.state:type_name -> daemon.v1.ProcessState
+	94,  // 67: daemon.v1.ServiceStats.uptime:type_name -> google.protobuf.Duration
+	94,  // 68: daemon.v1.ServiceStats.downtime:type_name -> google.protobuf.Duration
+	59,  // 69: daemon.v1.ServiceStats.availability:type_name -> daemon.v1.ServiceAvailability
+	57,  // 70: daemon.v1.ServiceStats.slo:type_name -> daemon.v1.SLOStatus
+	56,  // 71: daemon.v1.ServiceStats.top_exits:type_name -> daemon.v1.ExitCount
+	95,  // 72: daemon.v1.ExitCount.last:type_name -> google.protobuf.Timestamp
+	94,  // 73: daemon.v1.SLOStatus.window:type_name -> google.protobuf.Duration
+	58,  // 74: daemon.v1.SLOStatus.burn_rates:type_name -> daemon.v1.BurnRate
+	94,  // 75: daemon.v1.BurnRate.window:type_name -> google.protobuf.Duration
+	62,  // 76: daemon.v1.JobHistory.runs:type_name -> daemon.v1.JobRun
+	95,  // 77: daemon.v1.JobRun.started_at:type_name -> google.protobuf.Timestamp
+	94,  // 78: daemon.v1.JobRun.duration:type_name -> google.protobuf.Duration
+	65,  // 79: daemon.v1.VerifyReport.failures:type_name -> daemon.v1.PreflightFailure
+	94,  // 80: daemon.v1.DaemonInfo.gc_pause_total:type_name -> google.protobuf.Duration
+	94,  // 81: daemon.v1.DaemonInfo.last_gc_pause:type_name -> google.protobuf.Duration
+	67,  // 82: daemon.v1.DaemonInfo.queues:type_name -> daemon.v1.QueueDepth
+	68,  // 83: daemon.v1.DaemonInfo.loops:type_name -> daemon.v1.LoopLatency
+	94,  // 84: daemon.v1.LoopLatency.latency:type_name -> google.protobuf.Duration
+	71,  // 85: daemon.v1.ProcessTrees.services:type_name -> daemon.v1.ServiceProcessTree
+	72,  // 86: daemon.v1.ServiceProcessTree.root:type_name -> daemon.v1.ProcessNode
+	94,  // 87: daemon.v1.ProcessNode.cpu_time:type_name -> google.protobuf.Duration
+	72,  // 88: daemon.v1.ProcessNode.children:type_name -> daemon.v1.ProcessNode
+	95,  // 89: daemon.v1.GetServiceTimelineRequest.from:type_name -> google.protobuf.Timestamp
+	95,  // 90: daemon.v1.GetServiceTimelineRequest.to:type_name -> google.protobuf.Timestamp
+	75,  // 91: daemon.v1.ServiceTimeline.entries:type_name -> daemon.v1.TimelineEntry
+	95,  // 92: daemon.v1.TimelineEntry.timestamp:type_name -> google.protobuf.Timestamp
+	94,  // 93: daemon.v1.PauseProbesRequest.ttl:type_name -> google.protobuf.Duration
+	79,  // 94: daemon.v1.ProbePauses.pauses:type_name -> daemon.v1.ProbePause
+	95,  // 95: daemon.v1.ProbePause.until:type_name -> google.protobuf.Timestamp
+	81,  // 96: daemon.v1.HostInventory.container_sockets:type_name -> daemon.v1.ContainerSocket
+	82,  // 97: daemon.v1.HostInventory.features:type_name -> daemon.v1.FeatureSupport
+	85,  // 98: daemon.v1.DependencyMap.edges:type_name -> daemon.v1.TrafficEdge
+	95,  // 99: daemon.v1.TrafficEdge.first_seen:type_name -> google.protobuf.Timestamp
+	95,  // 100: daemon.v1.TrafficEdge.last_seen:type_name -> google.protobuf.Timestamp
+	95,  // 101: daemon.v1.SafeModeState.since:type_name -> google.protobuf.Timestamp
+	94,  // 102: daemon.v1.SafeModeState.window:type_name -> google.protobuf.Duration
+	96,  // 103: daemon.v1.DaemonService.GetState:input_type -> google.protobuf.Empty
+	4,   // 104: daemon.v1.DaemonService.StreamState:input_type -> daemon.v1.StreamStateRequest
+	8,   // 105: daemon.v1.DaemonService.ListProcesses:input_type -> daemon.v1.ListProcessesRequest
+	7,   // 106: daemon.v1.DaemonService.GetProcess:input_type -> daemon.v1.GetProcessRequest
+	6,   // 107: daemon.v1.DaemonService.StreamProcessMetrics:input_type -> daemon.v1.StreamProcessMetricsRequest
+	22,  // 108: daemon.v1.DaemonService.StreamLogs:input_type -> daemon.v1.StreamLogsRequest
+	27,  // 109: daemon.v1.DaemonService.GetServiceSpec:input_type -> daemon.v1.GetServiceSpecRequest
+	96,  // 110: daemon.v1.DaemonService.GetBootReport:input_type -> google.protobuf.Empty
+	96,  // 111: daemon.v1.DaemonService.RequestReload:input_type -> google.protobuf.Empty
+	96,  // 112: daemon.v1.DaemonService.GetReloadStatus:input_type -> google.protobuf.Empty
+	34,  // 113: daemon.v1.DaemonService.GetProbeTrace:input_type -> daemon.v1.GetProbeTraceRequest
+	37,  // 114: daemon.v1.DaemonService.GetDependencies:input_type -> daemon.v1.GetDependenciesRequest
+	40,  // 115: daemon.v1.DaemonService.SignalService:input_type -> daemon.v1.SignalServiceRequest
+	49,  // 116: daemon.v1.DaemonService.ReloadService:input_type -> daemon.v1.ReloadServiceRequest
+	50,  // 117: daemon.v1.DaemonService.BatchServices:input_type -> daemon.v1.BatchServicesRequest
+	54,  // 118: daemon.v1.DaemonService.GetServiceStats:input_type -> daemon.v1.GetServiceStatsRequest
+	60,  // 119: daemon.v1.DaemonService.GetJobHistory:input_type -> daemon.v1.GetJobHistoryRequest
+	63,  // 120: daemon.v1.DaemonService.VerifyServices:input_type -> daemon.v1.VerifyServicesRequest
+	96,  // 121: daemon.v1.DaemonService.GetDaemonInfo:input_type -> google.protobuf.Empty
+	41,  // 122: daemon.v1.DaemonService.SpawnDebugService:input_type -> daemon.v1.SpawnDebugServiceRequest
+	43,  // 123: daemon.v1.DaemonService.GetCertificates:input_type -> daemon.v1.GetCertificatesRequest
+	46,  // 124: daemon.v1.DaemonService.PlanReload:input_type -> daemon.v1.PlanReloadRequest
+	24,  // 125: daemon.v1.DaemonService.ReadLogs:input_type -> daemon.v1.ReadLogsRequest
+	96,  // 126: daemon.v1.DaemonService.GetDaemonParameters:input_type -> google.protobuf.Empty
+	25,  // 127: daemon.v1.DaemonService.SetDaemonParameters:input_type -> daemon.v1.DaemonParameters
+	96,  // 128: daemon.v1.DaemonService.GetBuildInfo:input_type -> google.protobuf.Empty
+	69,  // 129: daemon.v1.DaemonService.GetProcessTree:input_type -> daemon.v1.GetProcessTreeRequest
+	73,  // 130: daemon.v1.DaemonService.GetServiceTimeline:input_type -> daemon.v1.GetServiceTimelineRequest
+	76,  // 131: daemon.v1.DaemonService.PauseProbes:input_type -> daemon.v1.PauseProbesRequest
+	77,  // 132: daemon.v1.DaemonService.ResumeProbes:input_type -> daemon.v1.ResumeProbesRequest
+	96,  // 133: daemon.v1.DaemonService.GetHostInventory:input_type -> google.protobuf.Empty
+	83,  // 134: daemon.v1.DaemonService.GetDependencyMap:input_type -> daemon.v1.GetDependencyMapRequest
+	96,  // 135: daemon.v1.DaemonService.GetSafeMode:input_type -> google.protobuf.Empty
+	86,  // 136: daemon.v1.DaemonService.SetSafeMode:input_type -> daemon.v1.SetSafeModeRequest
+	96,  // 137: daemon.v1.MetricsService.GetSystemMetrics:input_type -> google.protobuf.Empty
+	5,   // 138: daemon.v1.MetricsService.StreamSystemMetrics:input_type -> daemon.v1.StreamMetricsRequest
+	6,   // 139: daemon.v1.MetricsService.StreamProcessMetrics:input_type -> daemon.v1.StreamProcessMetricsRequest
+	5,   // 140: daemon.v1.MetricsService.StreamAllProcessMetrics:input_type -> daemon.v1.StreamMetricsRequest
+	10,  // 141: daemon.v1.DaemonService.GetState:output_type -> daemon.v1.DaemonState
+	10,  // 142: daemon.v1.DaemonService.StreamState:output_type -> daemon.v1.DaemonState
+	9,   // 143: daemon.v1.DaemonService.ListProcesses:output_type -> daemon.v1.ListProcessesResponse
+	13,  // 144: daemon.v1.DaemonService.GetProcess:output_type -> daemon.v1.ProcessMetrics
+	13,  // 145: daemon.v1.DaemonService.StreamProcessMetrics:output_type -> daemon.v1.ProcessMetrics
+	23,  // 146: daemon.v1.DaemonService.StreamLogs:output_type -> daemon.v1.LogLine
+	28,  // 147: daemon.v1.DaemonService.GetServiceSpec:output_type -> daemon.v1.ServiceSpec
+	31,  // 148: daemon.v1.DaemonService.GetBootReport:output_type -> daemon.v1.BootReport
+	33,  // 149: daemon.v1.DaemonService.RequestReload:output_type -> daemon.v1.ReloadStatus
+	33,  // 150: daemon.v1.DaemonService.GetReloadStatus:output_type -> daemon.v1.ReloadStatus
+	35,  // 151: daemon.v1.DaemonService.GetProbeTrace:output_type -> daemon.v1.ProbeTrace
+	38,  // 152: daemon.v1.DaemonService.GetDependencies:output_type -> daemon.v1.ServiceDependencies
+	53,  // 153: daemon.v1.DaemonService.SignalService:output_type -> daemon.v1.ServiceSnapshot
+	53,  // 154: daemon.v1.DaemonService.ReloadService:output_type -> daemon.v1.ServiceSnapshot
+	51,  // 155: daemon.v1.DaemonService.BatchServices:output_type -> daemon.v1.BatchServicesResponse
+	55,  // 156: daemon.v1.DaemonService.GetServiceStats:output_type -> daemon.v1.ServiceStats
+	61,  // 157: daemon.v1.DaemonService.GetJobHistory:output_type -> daemon.v1.JobHistory
+	64,  // 158: daemon.v1.DaemonService.VerifyServices:output_type -> daemon.v1.VerifyReport
+	66,  // 159: daemon.v1.DaemonService.GetDaemonInfo:output_type -> daemon.v1.DaemonInfo
+	42,  // 160: daemon.v1.DaemonService.SpawnDebugService:output_type -> daemon.v1.DebugService
+	44,  // 161: daemon.v1.DaemonService.GetCertificates:output_type -> daemon.v1.ServiceCertificates
+	47,  // 162: daemon.v1.DaemonService.PlanReload:output_type -> daemon.v1.ReloadPlan
+	23,  // 163: daemon.v1.DaemonService.ReadLogs:output_type -> daemon.v1.LogLine
+	25,  // 164: daemon.v1.DaemonService.GetDaemonParameters:output_type -> daemon.v1.DaemonParameters
+	25,  // 165: daemon.v1.DaemonService.SetDaemonParameters:output_type -> daemon.v1.DaemonParameters
+	26,  // 166: daemon.v1.DaemonService.GetBuildInfo:output_type -> daemon.v1.BuildInfo
+	70,  // 167: daemon.v1.DaemonService.GetProcessTree:output_type -> daemon.v1.ProcessTrees
+	74,  // 168: daemon.v1.DaemonService.GetServiceTimeline:output_type -> daemon.v1.ServiceTimeline
+	78,  // 169: daemon.v1.DaemonService.PauseProbes:output_type -> daemon.v1.ProbePauses
+	78,  // 170: daemon.v1.DaemonService.ResumeProbes:output_type -> daemon.v1.ProbePauses
+	80,  // 171: daemon.v1.DaemonService.GetHostInventory:output_type -> daemon.v1.HostInventory
+	84,  // 172: daemon.v1.DaemonService.GetDependencyMap:output_type -> daemon.v1.DependencyMap
+	87,  // 173: daemon.v1.DaemonService.GetSafeMode:output_type -> daemon.v1.SafeModeState
+	87,  // 174: daemon.v1.DaemonService.SetSafeMode:output_type -> daemon.v1.SafeModeState
+	18,  // 175: daemon.v1.MetricsService.GetSystemMetrics:output_type -> daemon.v1.SystemMetrics
+	18,  // 176: daemon.v1.MetricsService.StreamSystemMetrics:output_type -> daemon.v1.SystemMetrics
+	13,  // 177: daemon.v1.MetricsService.StreamProcessMetrics:output_type -> daemon.v1.ProcessMetrics
+	13,  // 178: daemon.v1.MetricsService.StreamAllProcessMetrics:output_type -> daemon.v1.ProcessMetrics
+	141, // [141:179] is the sub-list for method output_type
+	103, // [103:141] is the sub-list for method input_type
+	103, // [103:103] is the sub-list for extension type_name
+	103, // [103:103] is the sub-list for extension extendee
+	0,   // [0:103] is the sub-list for field type_name
 }
 
 func init() { file_daemon_proto_init() }
@@ -1493,8 +7558,8 @@ func file_daemon_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_daemon_proto_rawDesc), len(file_daemon_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   16,
+			NumEnums:      4,
+			NumMessages:   90,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
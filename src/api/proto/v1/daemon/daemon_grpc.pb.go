@@ -28,6 +28,35 @@ const (
 	DaemonService_ListProcesses_FullMethodName        = "/daemon.v1.DaemonService/ListProcesses"
 	DaemonService_GetProcess_FullMethodName           = "/daemon.v1.DaemonService/GetProcess"
 	DaemonService_StreamProcessMetrics_FullMethodName = "/daemon.v1.DaemonService/StreamProcessMetrics"
+	DaemonService_StreamLogs_FullMethodName           = "/daemon.v1.DaemonService/StreamLogs"
+	DaemonService_GetServiceSpec_FullMethodName       = "/daemon.v1.DaemonService/GetServiceSpec"
+	DaemonService_GetBootReport_FullMethodName        = "/daemon.v1.DaemonService/GetBootReport"
+	DaemonService_RequestReload_FullMethodName        = "/daemon.v1.DaemonService/RequestReload"
+	DaemonService_GetReloadStatus_FullMethodName      = "/daemon.v1.DaemonService/GetReloadStatus"
+	DaemonService_GetProbeTrace_FullMethodName        = "/daemon.v1.DaemonService/GetProbeTrace"
+	DaemonService_GetDependencies_FullMethodName      = "/daemon.v1.DaemonService/GetDependencies"
+	DaemonService_SignalService_FullMethodName        = "/daemon.v1.DaemonService/SignalService"
+	DaemonService_ReloadService_FullMethodName        = "/daemon.v1.DaemonService/ReloadService"
+	DaemonService_BatchServices_FullMethodName        = "/daemon.v1.DaemonService/BatchServices"
+	DaemonService_GetServiceStats_FullMethodName      = "/daemon.v1.DaemonService/GetServiceStats"
+	DaemonService_GetJobHistory_FullMethodName        = "/daemon.v1.DaemonService/GetJobHistory"
+	DaemonService_VerifyServices_FullMethodName       = "/daemon.v1.DaemonService/VerifyServices"
+	DaemonService_GetDaemonInfo_FullMethodName        = "/daemon.v1.DaemonService/GetDaemonInfo"
+	DaemonService_SpawnDebugService_FullMethodName    = "/daemon.v1.DaemonService/SpawnDebugService"
+	DaemonService_GetCertificates_FullMethodName      = "/daemon.v1.DaemonService/GetCertificates"
+	DaemonService_PlanReload_FullMethodName           = "/daemon.v1.DaemonService/PlanReload"
+	DaemonService_ReadLogs_FullMethodName             = "/daemon.v1.DaemonService/ReadLogs"
+	DaemonService_GetDaemonParameters_FullMethodName  = "/daemon.v1.DaemonService/GetDaemonParameters"
+	DaemonService_SetDaemonParameters_FullMethodName  = "/daemon.v1.DaemonService/SetDaemonParameters"
+	DaemonService_GetBuildInfo_FullMethodName         = "/daemon.v1.DaemonService/GetBuildInfo"
+	DaemonService_GetProcessTree_FullMethodName       = "/daemon.v1.DaemonService/GetProcessTree"
+	DaemonService_GetServiceTimeline_FullMethodName   = "/daemon.v1.DaemonService/GetServiceTimeline"
+	DaemonService_PauseProbes_FullMethodName          = "/daemon.v1.DaemonService/PauseProbes"
+	DaemonService_ResumeProbes_FullMethodName         = "/daemon.v1.DaemonService/ResumeProbes"
+	DaemonService_GetHostInventory_FullMethodName     = "/daemon.v1.DaemonService/GetHostInventory"
+	DaemonService_GetDependencyMap_FullMethodName     = "/daemon.v1.DaemonService/GetDependencyMap"
+	DaemonService_GetSafeMode_FullMethodName          = "/daemon.v1.DaemonService/GetSafeMode"
+	DaemonService_SetSafeMode_FullMethodName          = "/daemon.v1.DaemonService/SetSafeMode"
 )
 
 // DaemonServiceClient is the client API for DaemonService service.
@@ -40,12 +69,99 @@ type DaemonServiceClient interface {
 	GetState(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DaemonState, error)
 	// StreamState streams daemon state updates.
 	StreamState(ctx context.Context, in *StreamStateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DaemonState], error)
-	// ListProcesses returns all supervised processes.
-	ListProcesses(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListProcessesResponse, error)
+	// ListProcesses returns the supervised processes, filtered, sorted and paged.
+	ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error)
 	// GetProcess returns a specific process by name.
 	GetProcess(ctx context.Context, in *GetProcessRequest, opts ...grpc.CallOption) (*ProcessMetrics, error)
 	// StreamProcessMetrics streams metrics for a specific process.
 	StreamProcessMetrics(ctx context.Context, in *StreamProcessMetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProcessMetrics], error)
+	// StreamLogs streams captured stdout/stderr lines of services.
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error)
+	// GetServiceSpec returns the resolved specification a running service was launched with.
+	GetServiceSpec(ctx context.Context, in *GetServiceSpecRequest, opts ...grpc.CallOption) (*ServiceSpec, error)
+	// GetBootReport returns the outcome of the initial startup of services.
+	GetBootReport(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BootReport, error)
+	// RequestReload queues a configuration reload and returns without waiting for it.
+	RequestReload(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReloadStatus, error)
+	// GetReloadStatus returns the progress and last result of configuration reloads.
+	GetReloadStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReloadStatus, error)
+	// GetProbeTrace returns the recent attempts of the debug probes of a service.
+	GetProbeTrace(ctx context.Context, in *GetProbeTraceRequest, opts ...grpc.CallOption) (*ProbeTrace, error)
+	// GetDependencies returns the probed state of the external dependencies of a service.
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*ServiceDependencies, error)
+	// SignalService sends a signal to the process of a service, within the
+	// signals allowed for that service. It returns the state of the service
+	// once the events reported so far are applied.
+	SignalService(ctx context.Context, in *SignalServiceRequest, opts ...grpc.CallOption) (*ServiceSnapshot, error)
+	// ReloadService makes a service reload its own configuration, through its
+	// reload command or signal, and waits until its probes pass again. It
+	// returns the state of the service after the reload.
+	ReloadService(ctx context.Context, in *ReloadServiceRequest, opts ...grpc.CallOption) (*ServiceSnapshot, error)
+	// BatchServices starts, stops or restarts several services in one call:
+	// the named ones and those matching a label selector. It returns once
+	// every service was acted on, with the outcome and resulting state of
+	// each.
+	BatchServices(ctx context.Context, in *BatchServicesRequest, opts ...grpc.CallOption) (*BatchServicesResponse, error)
+	// GetServiceStats returns the lifetime counters and availability of a
+	// service, kept across daemon restarts when statistics are persisted.
+	GetServiceStats(ctx context.Context, in *GetServiceStatsRequest, opts ...grpc.CallOption) (*ServiceStats, error)
+	// GetJobHistory returns the last runs of a oneshot service, with their
+	// exit code, duration and output tail.
+	GetJobHistory(ctx context.Context, in *GetJobHistoryRequest, opts ...grpc.CallOption) (*JobHistory, error)
+	// VerifyServices runs the pre-flight checks of services and dry-runs their
+	// binary under their user and group, without starting them.
+	VerifyServices(ctx context.Context, in *VerifyServicesRequest, opts ...grpc.CallOption) (*VerifyReport, error)
+	// GetDaemonInfo returns the resource usage of the daemon itself: memory,
+	// goroutines, GC, open descriptors, event queue depths and loop latencies.
+	GetDaemonInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DaemonInfo, error)
+	// SpawnDebugService runs a command once as a transient service, supervised
+	// like the configured ones and removed after it exits or its TTL elapses.
+	SpawnDebugService(ctx context.Context, in *SpawnDebugServiceRequest, opts ...grpc.CallOption) (*DebugService, error)
+	// GetCertificates returns the certificates served by the TLS listeners of
+	// a service, as last checked, with their expiry and last renewal.
+	GetCertificates(ctx context.Context, in *GetCertificatesRequest, opts ...grpc.CallOption) (*ServiceCertificates, error)
+	// PlanReload reports what reloading a configuration would do to the
+	// running services, through the diff of the reload path, without
+	// applying it.
+	PlanReload(ctx context.Context, in *PlanReloadRequest, opts ...grpc.CallOption) (*ReloadPlan, error)
+	// ReadLogs streams the lines of a service read from its log files,
+	// rotated and compressed files included, oldest first.
+	ReadLogs(ctx context.Context, in *ReadLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error)
+	// GetDaemonParameters returns the daemon settings changed at runtime.
+	GetDaemonParameters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DaemonParameters, error)
+	// SetDaemonParameters replaces the daemon settings changed at runtime.
+	// They apply at once and are saved, surviving restarts.
+	SetDaemonParameters(ctx context.Context, in *DaemonParameters, opts ...grpc.CallOption) (*DaemonParameters, error)
+	// GetBuildInfo returns the version, commit, build date, Go version and
+	// compiled-in features of the daemon binary.
+	GetBuildInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BuildInfo, error)
+	// GetProcessTree returns the processes spawned by a running service, or
+	// by every running service, with their memory and CPU usage.
+	GetProcessTree(ctx context.Context, in *GetProcessTreeRequest, opts ...grpc.CallOption) (*ProcessTrees, error)
+	// GetServiceTimeline returns what happened to a service over a time range,
+	// read from the persisted event store, oldest first.
+	GetServiceTimeline(ctx context.Context, in *GetServiceTimelineRequest, opts ...grpc.CallOption) (*ServiceTimeline, error)
+	// PauseProbes stops probing a listener of a service, or all its listeners,
+	// until a TTL elapses: health failures then neither change the health of
+	// the service nor run its unhealthy actions, restarts included.
+	PauseProbes(ctx context.Context, in *PauseProbesRequest, opts ...grpc.CallOption) (*ProbePauses, error)
+	// ResumeProbes lifts the probe pause of a listener of a service, or every
+	// probe pause of the service.
+	ResumeProbes(ctx context.Context, in *ResumeProbesRequest, opts ...grpc.CallOption) (*ProbePauses, error)
+	// GetHostInventory returns the platform capabilities detected on the host
+	// and the support of each optional feature, with the reason of every
+	// feature the daemon disables.
+	GetHostInventory(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HostInventory, error)
+	// GetDependencyMap returns the connections sampled from a service, or
+	// from every service, to the supervised services and external endpoints
+	// they reach, each flagged as declared by the configuration or not.
+	GetDependencyMap(ctx context.Context, in *GetDependencyMapRequest, opts ...grpc.CallOption) (*DependencyMap, error)
+	// GetSafeMode returns whether the daemon is in safe mode, holding the
+	// restarts of non-critical services, and which services wait.
+	GetSafeMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SafeModeState, error)
+	// SetSafeMode enters or exits safe mode. Exiting releases the held
+	// restarts.
+	SetSafeMode(ctx context.Context, in *SetSafeModeRequest, opts ...grpc.CallOption) (*SafeModeState, error)
 }
 
 type daemonServiceClient struct {
@@ -85,7 +201,7 @@ func (c *daemonServiceClient) StreamState(ctx context.Context, in *StreamStateRe
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DaemonService_StreamStateClient = grpc.ServerStreamingClient[DaemonState]
 
-func (c *daemonServiceClient) ListProcesses(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListProcessesResponse, error) {
+func (c *daemonServiceClient) ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListProcessesResponse)
 	err := c.cc.Invoke(ctx, DaemonService_ListProcesses_FullMethodName, in, out, cOpts...)
@@ -124,6 +240,314 @@ func (c *daemonServiceClient) StreamProcessMetrics(ctx context.Context, in *Stre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DaemonService_StreamProcessMetricsClient = grpc.ServerStreamingClient[ProcessMetrics]
 
+func (c *daemonServiceClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[2], DaemonService_StreamLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamLogsRequest, LogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_StreamLogsClient = grpc.ServerStreamingClient[LogLine]
+
+func (c *daemonServiceClient) GetServiceSpec(ctx context.Context, in *GetServiceSpecRequest, opts ...grpc.CallOption) (*ServiceSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceSpec)
+	err := c.cc.Invoke(ctx, DaemonService_GetServiceSpec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetBootReport(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BootReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BootReport)
+	err := c.cc.Invoke(ctx, DaemonService_GetBootReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) RequestReload(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReloadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadStatus)
+	err := c.cc.Invoke(ctx, DaemonService_RequestReload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetReloadStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReloadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadStatus)
+	err := c.cc.Invoke(ctx, DaemonService_GetReloadStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetProbeTrace(ctx context.Context, in *GetProbeTraceRequest, opts ...grpc.CallOption) (*ProbeTrace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProbeTrace)
+	err := c.cc.Invoke(ctx, DaemonService_GetProbeTrace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*ServiceDependencies, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceDependencies)
+	err := c.cc.Invoke(ctx, DaemonService_GetDependencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) SignalService(ctx context.Context, in *SignalServiceRequest, opts ...grpc.CallOption) (*ServiceSnapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceSnapshot)
+	err := c.cc.Invoke(ctx, DaemonService_SignalService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ReloadService(ctx context.Context, in *ReloadServiceRequest, opts ...grpc.CallOption) (*ServiceSnapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceSnapshot)
+	err := c.cc.Invoke(ctx, DaemonService_ReloadService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) BatchServices(ctx context.Context, in *BatchServicesRequest, opts ...grpc.CallOption) (*BatchServicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchServicesResponse)
+	err := c.cc.Invoke(ctx, DaemonService_BatchServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetServiceStats(ctx context.Context, in *GetServiceStatsRequest, opts ...grpc.CallOption) (*ServiceStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceStats)
+	err := c.cc.Invoke(ctx, DaemonService_GetServiceStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetJobHistory(ctx context.Context, in *GetJobHistoryRequest, opts ...grpc.CallOption) (*JobHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobHistory)
+	err := c.cc.Invoke(ctx, DaemonService_GetJobHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) VerifyServices(ctx context.Context, in *VerifyServicesRequest, opts ...grpc.CallOption) (*VerifyReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyReport)
+	err := c.cc.Invoke(ctx, DaemonService_VerifyServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetDaemonInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DaemonInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonInfo)
+	err := c.cc.Invoke(ctx, DaemonService_GetDaemonInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) SpawnDebugService(ctx context.Context, in *SpawnDebugServiceRequest, opts ...grpc.CallOption) (*DebugService, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DebugService)
+	err := c.cc.Invoke(ctx, DaemonService_SpawnDebugService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetCertificates(ctx context.Context, in *GetCertificatesRequest, opts ...grpc.CallOption) (*ServiceCertificates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceCertificates)
+	err := c.cc.Invoke(ctx, DaemonService_GetCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) PlanReload(ctx context.Context, in *PlanReloadRequest, opts ...grpc.CallOption) (*ReloadPlan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadPlan)
+	err := c.cc.Invoke(ctx, DaemonService_PlanReload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ReadLogs(ctx context.Context, in *ReadLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[3], DaemonService_ReadLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReadLogsRequest, LogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_ReadLogsClient = grpc.ServerStreamingClient[LogLine]
+
+func (c *daemonServiceClient) GetDaemonParameters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DaemonParameters, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonParameters)
+	err := c.cc.Invoke(ctx, DaemonService_GetDaemonParameters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) SetDaemonParameters(ctx context.Context, in *DaemonParameters, opts ...grpc.CallOption) (*DaemonParameters, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonParameters)
+	err := c.cc.Invoke(ctx, DaemonService_SetDaemonParameters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetBuildInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BuildInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuildInfo)
+	err := c.cc.Invoke(ctx, DaemonService_GetBuildInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetProcessTree(ctx context.Context, in *GetProcessTreeRequest, opts ...grpc.CallOption) (*ProcessTrees, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessTrees)
+	err := c.cc.Invoke(ctx, DaemonService_GetProcessTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetServiceTimeline(ctx context.Context, in *GetServiceTimelineRequest, opts ...grpc.CallOption) (*ServiceTimeline, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceTimeline)
+	err := c.cc.Invoke(ctx, DaemonService_GetServiceTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) PauseProbes(ctx context.Context, in *PauseProbesRequest, opts ...grpc.CallOption) (*ProbePauses, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProbePauses)
+	err := c.cc.Invoke(ctx, DaemonService_PauseProbes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ResumeProbes(ctx context.Context, in *ResumeProbesRequest, opts ...grpc.CallOption) (*ProbePauses, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProbePauses)
+	err := c.cc.Invoke(ctx, DaemonService_ResumeProbes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetHostInventory(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HostInventory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostInventory)
+	err := c.cc.Invoke(ctx, DaemonService_GetHostInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetDependencyMap(ctx context.Context, in *GetDependencyMapRequest, opts ...grpc.CallOption) (*DependencyMap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DependencyMap)
+	err := c.cc.Invoke(ctx, DaemonService_GetDependencyMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetSafeMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SafeModeState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SafeModeState)
+	err := c.cc.Invoke(ctx, DaemonService_GetSafeMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) SetSafeMode(ctx context.Context, in *SetSafeModeRequest, opts ...grpc.CallOption) (*SafeModeState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SafeModeState)
+	err := c.cc.Invoke(ctx, DaemonService_SetSafeMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DaemonServiceServer is the server API for DaemonService service.
 // All implementations must embed UnimplementedDaemonServiceServer
 // for forward compatibility.
@@ -134,12 +558,99 @@ type DaemonServiceServer interface {
 	GetState(context.Context, *emptypb.Empty) (*DaemonState, error)
 	// StreamState streams daemon state updates.
 	StreamState(*StreamStateRequest, grpc.ServerStreamingServer[DaemonState]) error
-	// ListProcesses returns all supervised processes.
-	ListProcesses(context.Context, *emptypb.Empty) (*ListProcessesResponse, error)
+	// ListProcesses returns the supervised processes, filtered, sorted and paged.
+	ListProcesses(context.Context, *ListProcessesRequest) (*ListProcessesResponse, error)
 	// GetProcess returns a specific process by name.
 	GetProcess(context.Context, *GetProcessRequest) (*ProcessMetrics, error)
 	// StreamProcessMetrics streams metrics for a specific process.
 	StreamProcessMetrics(*StreamProcessMetricsRequest, grpc.ServerStreamingServer[ProcessMetrics]) error
+	// StreamLogs streams captured stdout/stderr lines of services.
+	StreamLogs(*StreamLogsRequest, grpc.ServerStreamingServer[LogLine]) error
+	// GetServiceSpec returns the resolved specification a running service was launched with.
+	GetServiceSpec(context.Context, *GetServiceSpecRequest) (*ServiceSpec, error)
+	// GetBootReport returns the outcome of the initial startup of services.
+	GetBootReport(context.Context, *emptypb.Empty) (*BootReport, error)
+	// RequestReload queues a configuration reload and returns without waiting for it.
+	RequestReload(context.Context, *emptypb.Empty) (*ReloadStatus, error)
+	// GetReloadStatus returns the progress and last result of configuration reloads.
+	GetReloadStatus(context.Context, *emptypb.Empty) (*ReloadStatus, error)
+	// GetProbeTrace returns the recent attempts of the debug probes of a service.
+	GetProbeTrace(context.Context, *GetProbeTraceRequest) (*ProbeTrace, error)
+	// GetDependencies returns the probed state of the external dependencies of a service.
+	GetDependencies(context.Context, *GetDependenciesRequest) (*ServiceDependencies, error)
+	// SignalService sends a signal to the process of a service, within the
+	// signals allowed for that service. It returns the state of the service
+	// once the events reported so far are applied.
+	SignalService(context.Context, *SignalServiceRequest) (*ServiceSnapshot, error)
+	// ReloadService makes a service reload its own configuration, through its
+	// reload command or signal, and waits until its probes pass again. It
+	// returns the state of the service after the reload.
+	ReloadService(context.Context, *ReloadServiceRequest) (*ServiceSnapshot, error)
+	// BatchServices starts, stops or restarts several services in one call:
+	// the named ones and those matching a label selector. It returns once
+	// every service was acted on, with the outcome and resulting state of
+	// each.
+	BatchServices(context.Context, *BatchServicesRequest) (*BatchServicesResponse, error)
+	// GetServiceStats returns the lifetime counters and availability of a
+	// service, kept across daemon restarts when statistics are persisted.
+	GetServiceStats(context.Context, *GetServiceStatsRequest) (*ServiceStats, error)
+	// GetJobHistory returns the last runs of a oneshot service, with their
+	// exit code, duration and output tail.
+	GetJobHistory(context.Context, *GetJobHistoryRequest) (*JobHistory, error)
+	// VerifyServices runs the pre-flight checks of services and dry-runs their
+	// binary under their user and group, without starting them.
+	VerifyServices(context.Context, *VerifyServicesRequest) (*VerifyReport, error)
+	// GetDaemonInfo returns the resource usage of the daemon itself: memory,
+	// goroutines, GC, open descriptors, event queue depths and loop latencies.
+	GetDaemonInfo(context.Context, *emptypb.Empty) (*DaemonInfo, error)
+	// SpawnDebugService runs a command once as a transient service, supervised
+	// like the configured ones and removed after it exits or its TTL elapses.
+	SpawnDebugService(context.Context, *SpawnDebugServiceRequest) (*DebugService, error)
+	// GetCertificates returns the certificates served by the TLS listeners of
+	// a service, as last checked, with their expiry and last renewal.
+	GetCertificates(context.Context, *GetCertificatesRequest) (*ServiceCertificates, error)
+	// PlanReload reports what reloading a configuration would do to the
+	// running services, through the diff of the reload path, without
+	// applying it.
+	PlanReload(context.Context, *PlanReloadRequest) (*ReloadPlan, error)
+	// ReadLogs streams the lines of a service read from its log files,
+	// rotated and compressed files included, oldest first.
+	ReadLogs(*ReadLogsRequest, grpc.ServerStreamingServer[LogLine]) error
+	// GetDaemonParameters returns the daemon settings changed at runtime.
+	GetDaemonParameters(context.Context, *emptypb.Empty) (*DaemonParameters, error)
+	// SetDaemonParameters replaces the daemon settings changed at runtime.
+	// They apply at once and are saved, surviving restarts.
+	SetDaemonParameters(context.Context, *DaemonParameters) (*DaemonParameters, error)
+	// GetBuildInfo returns the version, commit, build date, Go version and
+	// compiled-in features of the daemon binary.
+	GetBuildInfo(context.Context, *emptypb.Empty) (*BuildInfo, error)
+	// GetProcessTree returns the processes spawned by a running service, or
+	// by every running service, with their memory and CPU usage.
+	GetProcessTree(context.Context, *GetProcessTreeRequest) (*ProcessTrees, error)
+	// GetServiceTimeline returns what happened to a service over a time range,
+	// read from the persisted event store, oldest first.
+	GetServiceTimeline(context.Context, *GetServiceTimelineRequest) (*ServiceTimeline, error)
+	// PauseProbes stops probing a listener of a service, or all its listeners,
+	// until a TTL elapses: health failures then neither change the health of
+	// the service nor run its unhealthy actions, restarts included.
+	PauseProbes(context.Context, *PauseProbesRequest) (*ProbePauses, error)
+	// ResumeProbes lifts the probe pause of a listener of a service, or every
+	// probe pause of the service.
+	ResumeProbes(context.Context, *ResumeProbesRequest) (*ProbePauses, error)
+	// GetHostInventory returns the platform capabilities detected on the host
+	// and the support of each optional feature, with the reason of every
+	// feature the daemon disables.
+	GetHostInventory(context.Context, *emptypb.Empty) (*HostInventory, error)
+	// GetDependencyMap returns the connections sampled from a service, or
+	// from every service, to the supervised services and external endpoints
+	// they reach, each flagged as declared by the configuration or not.
+	GetDependencyMap(context.Context, *GetDependencyMapRequest) (*DependencyMap, error)
+	// GetSafeMode returns whether the daemon is in safe mode, holding the
+	// restarts of non-critical services, and which services wait.
+	GetSafeMode(context.Context, *emptypb.Empty) (*SafeModeState, error)
+	// SetSafeMode enters or exits safe mode. Exiting releases the held
+	// restarts.
+	SetSafeMode(context.Context, *SetSafeModeRequest) (*SafeModeState, error)
 	mustEmbedUnimplementedDaemonServiceServer()
 }
 
@@ -156,7 +667,7 @@ func (UnimplementedDaemonServiceServer) GetState(context.Context, *emptypb.Empty
 func (UnimplementedDaemonServiceServer) StreamState(*StreamStateRequest, grpc.ServerStreamingServer[DaemonState]) error {
 	return status.Error(codes.Unimplemented, "method StreamState not implemented")
 }
-func (UnimplementedDaemonServiceServer) ListProcesses(context.Context, *emptypb.Empty) (*ListProcessesResponse, error) {
+func (UnimplementedDaemonServiceServer) ListProcesses(context.Context, *ListProcessesRequest) (*ListProcessesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListProcesses not implemented")
 }
 func (UnimplementedDaemonServiceServer) GetProcess(context.Context, *GetProcessRequest) (*ProcessMetrics, error) {
@@ -165,6 +676,93 @@ func (UnimplementedDaemonServiceServer) GetProcess(context.Context, *GetProcessR
 func (UnimplementedDaemonServiceServer) StreamProcessMetrics(*StreamProcessMetricsRequest, grpc.ServerStreamingServer[ProcessMetrics]) error {
 	return status.Error(codes.Unimplemented, "method StreamProcessMetrics not implemented")
 }
+func (UnimplementedDaemonServiceServer) StreamLogs(*StreamLogsRequest, grpc.ServerStreamingServer[LogLine]) error {
+	return status.Error(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetServiceSpec(context.Context, *GetServiceSpecRequest) (*ServiceSpec, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServiceSpec not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetBootReport(context.Context, *emptypb.Empty) (*BootReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBootReport not implemented")
+}
+func (UnimplementedDaemonServiceServer) RequestReload(context.Context, *emptypb.Empty) (*ReloadStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestReload not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetReloadStatus(context.Context, *emptypb.Empty) (*ReloadStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReloadStatus not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetProbeTrace(context.Context, *GetProbeTraceRequest) (*ProbeTrace, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProbeTrace not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetDependencies(context.Context, *GetDependenciesRequest) (*ServiceDependencies, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDependencies not implemented")
+}
+func (UnimplementedDaemonServiceServer) SignalService(context.Context, *SignalServiceRequest) (*ServiceSnapshot, error) {
+	return nil, status.Error(codes.Unimplemented, "method SignalService not implemented")
+}
+func (UnimplementedDaemonServiceServer) ReloadService(context.Context, *ReloadServiceRequest) (*ServiceSnapshot, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReloadService not implemented")
+}
+func (UnimplementedDaemonServiceServer) BatchServices(context.Context, *BatchServicesRequest) (*BatchServicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchServices not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetServiceStats(context.Context, *GetServiceStatsRequest) (*ServiceStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServiceStats not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetJobHistory(context.Context, *GetJobHistoryRequest) (*JobHistory, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJobHistory not implemented")
+}
+func (UnimplementedDaemonServiceServer) VerifyServices(context.Context, *VerifyServicesRequest) (*VerifyReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyServices not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetDaemonInfo(context.Context, *emptypb.Empty) (*DaemonInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDaemonInfo not implemented")
+}
+func (UnimplementedDaemonServiceServer) SpawnDebugService(context.Context, *SpawnDebugServiceRequest) (*DebugService, error) {
+	return nil, status.Error(codes.Unimplemented, "method SpawnDebugService not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetCertificates(context.Context, *GetCertificatesRequest) (*ServiceCertificates, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCertificates not implemented")
+}
+func (UnimplementedDaemonServiceServer) PlanReload(context.Context, *PlanReloadRequest) (*ReloadPlan, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlanReload not implemented")
+}
+func (UnimplementedDaemonServiceServer) ReadLogs(*ReadLogsRequest, grpc.ServerStreamingServer[LogLine]) error {
+	return status.Error(codes.Unimplemented, "method ReadLogs not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetDaemonParameters(context.Context, *emptypb.Empty) (*DaemonParameters, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDaemonParameters not implemented")
+}
+func (UnimplementedDaemonServiceServer) SetDaemonParameters(context.Context, *DaemonParameters) (*DaemonParameters, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDaemonParameters not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetBuildInfo(context.Context, *emptypb.Empty) (*BuildInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBuildInfo not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetProcessTree(context.Context, *GetProcessTreeRequest) (*ProcessTrees, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProcessTree not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetServiceTimeline(context.Context, *GetServiceTimelineRequest) (*ServiceTimeline, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServiceTimeline not implemented")
+}
+func (UnimplementedDaemonServiceServer) PauseProbes(context.Context, *PauseProbesRequest) (*ProbePauses, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseProbes not implemented")
+}
+func (UnimplementedDaemonServiceServer) ResumeProbes(context.Context, *ResumeProbesRequest) (*ProbePauses, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeProbes not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetHostInventory(context.Context, *emptypb.Empty) (*HostInventory, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHostInventory not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetDependencyMap(context.Context, *GetDependencyMapRequest) (*DependencyMap, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDependencyMap not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetSafeMode(context.Context, *emptypb.Empty) (*SafeModeState, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSafeMode not implemented")
+}
+func (UnimplementedDaemonServiceServer) SetSafeMode(context.Context, *SetSafeModeRequest) (*SafeModeState, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetSafeMode not implemented")
+}
 func (UnimplementedDaemonServiceServer) mustEmbedUnimplementedDaemonServiceServer() {}
 func (UnimplementedDaemonServiceServer) testEmbeddedByValue()                       {}
 
@@ -216,7 +814,7 @@ func _DaemonService_StreamState_Handler(srv interface{}, stream grpc.ServerStrea
 type DaemonService_StreamStateServer = grpc.ServerStreamingServer[DaemonState]
 
 func _DaemonService_ListProcesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ListProcessesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -228,7 +826,7 @@ func _DaemonService_ListProcesses_Handler(srv interface{}, ctx context.Context,
 		FullMethod: DaemonService_ListProcesses_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServiceServer).ListProcesses(ctx, req.(*emptypb.Empty))
+		return srv.(DaemonServiceServer).ListProcesses(ctx, req.(*ListProcessesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -262,13 +860,521 @@ func _DaemonService_StreamProcessMetrics_Handler(srv interface{}, stream grpc.Se
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DaemonService_StreamProcessMetricsServer = grpc.ServerStreamingServer[ProcessMetrics]
 
-// DaemonService_ServiceDesc is the grpc.ServiceDesc for DaemonService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var DaemonService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "daemon.v1.DaemonService",
-	HandlerType: (*DaemonServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
+func _DaemonService_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).StreamLogs(m, &grpc.GenericServerStream[StreamLogsRequest, LogLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_StreamLogsServer = grpc.ServerStreamingServer[LogLine]
+
+func _DaemonService_GetServiceSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceSpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetServiceSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetServiceSpec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetServiceSpec(ctx, req.(*GetServiceSpecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetBootReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetBootReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetBootReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetBootReport(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_RequestReload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RequestReload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_RequestReload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RequestReload(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetReloadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetReloadStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetReloadStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetReloadStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetProbeTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProbeTraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetProbeTrace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetProbeTrace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetProbeTrace(ctx, req.(*GetProbeTraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_SignalService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).SignalService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_SignalService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).SignalService(ctx, req.(*SignalServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ReloadService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ReloadService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_ReloadService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ReloadService(ctx, req.(*ReloadServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_BatchServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).BatchServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_BatchServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).BatchServices(ctx, req.(*BatchServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetServiceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetServiceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetServiceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetServiceStats(ctx, req.(*GetServiceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetJobHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetJobHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetJobHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetJobHistory(ctx, req.(*GetJobHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_VerifyServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).VerifyServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_VerifyServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).VerifyServices(ctx, req.(*VerifyServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetDaemonInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetDaemonInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetDaemonInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetDaemonInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_SpawnDebugService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpawnDebugServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).SpawnDebugService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_SpawnDebugService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).SpawnDebugService(ctx, req.(*SpawnDebugServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetCertificates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetCertificates(ctx, req.(*GetCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_PlanReload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanReloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).PlanReload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_PlanReload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).PlanReload(ctx, req.(*PlanReloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ReadLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).ReadLogs(m, &grpc.GenericServerStream[ReadLogsRequest, LogLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_ReadLogsServer = grpc.ServerStreamingServer[LogLine]
+
+func _DaemonService_GetDaemonParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetDaemonParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetDaemonParameters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetDaemonParameters(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_SetDaemonParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DaemonParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).SetDaemonParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_SetDaemonParameters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).SetDaemonParameters(ctx, req.(*DaemonParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetBuildInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetBuildInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetBuildInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetBuildInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetProcessTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetProcessTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetProcessTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetProcessTree(ctx, req.(*GetProcessTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetServiceTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetServiceTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetServiceTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetServiceTimeline(ctx, req.(*GetServiceTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_PauseProbes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseProbesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).PauseProbes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_PauseProbes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).PauseProbes(ctx, req.(*PauseProbesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ResumeProbes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeProbesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ResumeProbes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_ResumeProbes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ResumeProbes(ctx, req.(*ResumeProbesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetHostInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetHostInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetHostInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetHostInventory(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetDependencyMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependencyMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetDependencyMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetDependencyMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetDependencyMap(ctx, req.(*GetDependencyMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetSafeMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetSafeMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetSafeMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetSafeMode(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_SetSafeMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSafeModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).SetSafeMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_SetSafeMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).SetSafeMode(ctx, req.(*SetSafeModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DaemonService_ServiceDesc is the grpc.ServiceDesc for DaemonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DaemonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "daemon.v1.DaemonService",
+	HandlerType: (*DaemonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "GetState",
 			Handler:    _DaemonService_GetState_Handler,
@@ -281,6 +1387,114 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProcess",
 			Handler:    _DaemonService_GetProcess_Handler,
 		},
+		{
+			MethodName: "GetServiceSpec",
+			Handler:    _DaemonService_GetServiceSpec_Handler,
+		},
+		{
+			MethodName: "GetBootReport",
+			Handler:    _DaemonService_GetBootReport_Handler,
+		},
+		{
+			MethodName: "RequestReload",
+			Handler:    _DaemonService_RequestReload_Handler,
+		},
+		{
+			MethodName: "GetReloadStatus",
+			Handler:    _DaemonService_GetReloadStatus_Handler,
+		},
+		{
+			MethodName: "GetProbeTrace",
+			Handler:    _DaemonService_GetProbeTrace_Handler,
+		},
+		{
+			MethodName: "GetDependencies",
+			Handler:    _DaemonService_GetDependencies_Handler,
+		},
+		{
+			MethodName: "SignalService",
+			Handler:    _DaemonService_SignalService_Handler,
+		},
+		{
+			MethodName: "ReloadService",
+			Handler:    _DaemonService_ReloadService_Handler,
+		},
+		{
+			MethodName: "BatchServices",
+			Handler:    _DaemonService_BatchServices_Handler,
+		},
+		{
+			MethodName: "GetServiceStats",
+			Handler:    _DaemonService_GetServiceStats_Handler,
+		},
+		{
+			MethodName: "GetJobHistory",
+			Handler:    _DaemonService_GetJobHistory_Handler,
+		},
+		{
+			MethodName: "VerifyServices",
+			Handler:    _DaemonService_VerifyServices_Handler,
+		},
+		{
+			MethodName: "GetDaemonInfo",
+			Handler:    _DaemonService_GetDaemonInfo_Handler,
+		},
+		{
+			MethodName: "SpawnDebugService",
+			Handler:    _DaemonService_SpawnDebugService_Handler,
+		},
+		{
+			MethodName: "GetCertificates",
+			Handler:    _DaemonService_GetCertificates_Handler,
+		},
+		{
+			MethodName: "PlanReload",
+			Handler:    _DaemonService_PlanReload_Handler,
+		},
+		{
+			MethodName: "GetDaemonParameters",
+			Handler:    _DaemonService_GetDaemonParameters_Handler,
+		},
+		{
+			MethodName: "SetDaemonParameters",
+			Handler:    _DaemonService_SetDaemonParameters_Handler,
+		},
+		{
+			MethodName: "GetBuildInfo",
+			Handler:    _DaemonService_GetBuildInfo_Handler,
+		},
+		{
+			MethodName: "GetProcessTree",
+			Handler:    _DaemonService_GetProcessTree_Handler,
+		},
+		{
+			MethodName: "GetServiceTimeline",
+			Handler:    _DaemonService_GetServiceTimeline_Handler,
+		},
+		{
+			MethodName: "PauseProbes",
+			Handler:    _DaemonService_PauseProbes_Handler,
+		},
+		{
+			MethodName: "ResumeProbes",
+			Handler:    _DaemonService_ResumeProbes_Handler,
+		},
+		{
+			MethodName: "GetHostInventory",
+			Handler:    _DaemonService_GetHostInventory_Handler,
+		},
+		{
+			MethodName: "GetDependencyMap",
+			Handler:    _DaemonService_GetDependencyMap_Handler,
+		},
+		{
+			MethodName: "GetSafeMode",
+			Handler:    _DaemonService_GetSafeMode_Handler,
+		},
+		{
+			MethodName: "SetSafeMode",
+			Handler:    _DaemonService_SetSafeMode_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -293,6 +1507,16 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DaemonService_StreamProcessMetrics_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _DaemonService_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadLogs",
+			Handler:       _DaemonService_ReadLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "daemon.proto",
 }
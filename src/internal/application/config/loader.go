@@ -15,3 +15,19 @@ type Reloader interface {
 	// Reload reloads configuration from its original source.
 	Reload() (*config.Config, error)
 }
+
+// Preflighter validates a configuration against the host before it is applied.
+type Preflighter interface {
+	// Preflight checks that the configuration can be applied.
+	// It returns a *config.PreflightError listing every failed check.
+	Preflight(cfg *config.Config) error
+}
+
+// Verifier dry-runs service binaries before a deploy.
+// Pre-flight checkers implementing it enable on-demand verification.
+type Verifier interface {
+	// Verify runs the per-service pre-flight checks and the verify command
+	// of the named services, all when none is named. It returns a
+	// *config.PreflightError listing every failed check.
+	Verify(cfg *config.Config, names []string) error
+}
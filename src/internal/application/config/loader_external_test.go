@@ -572,3 +572,58 @@ func TestLoaderReloader_TypeAssertions(t *testing.T) {
 		})
 	}
 }
+
+// mockPreflighter is a mock implementation of Preflighter for testing.
+type mockPreflighter struct {
+	err error
+}
+
+// Preflight returns the pre-configured error.
+//
+// Params:
+//   - cfg: configuration to check (ignored in mock).
+//
+// Returns:
+//   - error: pre-configured error.
+func (m *mockPreflighter) Preflight(_ *config.Config) error {
+	// Return pre-configured result for testing.
+	return m.err
+}
+
+// TestPreflighter_Preflight tests the Preflighter.Preflight interface method contract.
+//
+// Params:
+//   - t: testing context for assertions and error reporting.
+func TestPreflighter_Preflight(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{
+			name: "checks_pass",
+		},
+		{
+			name: "checks_fail",
+			err: &config.PreflightError{Failures: []config.PreflightFailure{
+				{Service: "api", Check: config.PreflightCheckBinary, Detail: "not found"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var preflighter appconfig.Preflighter = &mockPreflighter{err: tt.err}
+
+			err := preflighter.Preflight(&config.Config{})
+
+			// Verify the report is surfaced as a pre-flight error.
+			if tt.wantErr {
+				assert.ErrorIs(t, err, config.ErrPreflightFailed)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -17,4 +17,8 @@ const (
 	ProbeExec ProbeType = "exec"
 	// ProbeICMP is an ICMP ping probe.
 	ProbeICMP ProbeType = "icmp"
+	// ProbeScenario is a multi-step HTTP probe.
+	ProbeScenario ProbeType = "scenario"
+	// ProbeThreshold is a numeric value probe compared with warn and fail thresholds.
+	ProbeThreshold ProbeType = "threshold"
 )
@@ -97,6 +97,7 @@ func (lp *ListenerProbe) ProbeTarget() domain.Target {
 	}
 	// Return full target with all binding configuration fields.
 	return domain.Target{
+		Network:    lp.Binding.Target.Network,
 		Address:    lp.ProbeAddress(),
 		Path:       lp.Binding.Target.Path,
 		Service:    lp.Binding.Target.Service,
@@ -104,6 +105,8 @@ func (lp *ListenerProbe) ProbeTarget() domain.Target {
 		StatusCode: lp.Binding.Target.StatusCode,
 		Command:    lp.Binding.Target.Command,
 		Args:       lp.Binding.Target.Args,
+		Steps:      lp.Binding.Target.Steps,
+		Threshold:  lp.Binding.Target.Threshold,
 	}
 }
 
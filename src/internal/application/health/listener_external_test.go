@@ -303,6 +303,20 @@ func TestListenerProbe_ProbeTarget(t *testing.T) {
 				Service: "grpc.health.v1.Health",
 			},
 		},
+		{
+			name: "with_ipv6_tcp_binding",
+			lp: apphealth.NewListenerProbeWithBinding(
+				listener.NewListener("v6-test", "tcp6", "::1", 8080),
+				apphealth.NewProbeBinding("v6-test", apphealth.ProbeTCP, apphealth.ProbeTarget{
+					Network: "tcp6",
+					Address: "[::1]:8080",
+				}),
+			),
+			expected: health.Target{
+				Network: "tcp6",
+				Address: "[::1]:8080",
+			},
+		},
 		{
 			name: "with_exec_binding",
 			lp: apphealth.NewListenerProbeWithBinding(
@@ -318,6 +332,20 @@ func TestListenerProbe_ProbeTarget(t *testing.T) {
 				Args:    []string{"--status"},
 			},
 		},
+		{
+			name: "with_scenario_binding",
+			lp: apphealth.NewListenerProbeWithBinding(
+				listener.NewListener("scenario-test", "tcp", "localhost", 8080),
+				apphealth.NewProbeBinding("scenario-test", apphealth.ProbeScenario, apphealth.ProbeTarget{
+					Address: "localhost:8080",
+					Steps:   []health.ScenarioStep{{Name: "login", Path: "/login"}},
+				}),
+			),
+			expected: health.Target{
+				Address: "localhost:8080",
+				Steps:   []health.ScenarioStep{{Name: "login", Path: "/login"}},
+			},
+		},
 	}
 
 	// Iterate through all test cases.
@@ -326,6 +354,7 @@ func TestListenerProbe_ProbeTarget(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Verify ProbeTarget returns expected value.
 			target := tt.lp.ProbeTarget()
+			assert.Equal(t, tt.expected.Network, target.Network)
 			assert.Equal(t, tt.expected.Address, target.Address)
 			assert.Equal(t, tt.expected.Path, target.Path)
 			assert.Equal(t, tt.expected.Service, target.Service)
@@ -333,6 +362,7 @@ func TestListenerProbe_ProbeTarget(t *testing.T) {
 			assert.Equal(t, tt.expected.StatusCode, target.StatusCode)
 			assert.Equal(t, tt.expected.Command, target.Command)
 			assert.Equal(t, tt.expected.Args, target.Args)
+			assert.Equal(t, tt.expected.Steps, target.Steps)
 		})
 	}
 }
@@ -11,6 +11,7 @@ import (
 	domain "github.com/kodflow/daemon/internal/domain/health"
 	"github.com/kodflow/daemon/internal/domain/listener"
 	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
 )
 
 // subjectStatus defines the interface for subject status operations.
@@ -59,6 +60,15 @@ type ProbeMonitor struct {
 	onUnhealthy UnhealthyCallback
 	// onHealthy is called when a service becomes healthy.
 	onHealthy HealthyCallback
+	// onProbeAttempt is called after each traced probe attempt.
+	onProbeAttempt ProbeAttemptCallback
+	// traces holds the recent attempts of debug probes, by listener name.
+	traces map[string]*probeTrace
+	// pauses holds the end of the probe pauses, by listener name; the
+	// empty name pauses every listener.
+	pauses map[string]time.Time
+	// clock times the probe intervals and timestamps the events.
+	clock shared.Clock
 }
 
 // NewProbeMonitor creates a new probe-based health monitor.
@@ -81,6 +91,12 @@ func NewProbeMonitor(config ProbeMonitorConfig) *ProbeMonitor {
 		defaultInterval = domain.DefaultInterval
 	}
 
+	clock := config.Clock
+	// Use the default clock when none is injected.
+	if clock == nil {
+		clock = shared.DefaultClock
+	}
+
 	// construct monitor with all config values
 	return &ProbeMonitor{
 		listeners:       nil,
@@ -94,6 +110,8 @@ func NewProbeMonitor(config ProbeMonitorConfig) *ProbeMonitor {
 		onStateChange:   config.OnStateChange,
 		onUnhealthy:     config.OnUnhealthy,
 		onHealthy:       config.OnHealthy,
+		onProbeAttempt:  config.OnProbeAttempt,
+		clock:           clock,
 	}
 }
 
@@ -119,6 +137,10 @@ func listenerStateToSubjectState(state listener.State) domain.SubjectState {
 	case listener.StateClosed:
 		// return closed state
 		return domain.SubjectClosed
+	// Listener port is held by another process.
+	case listener.StateConflicted:
+		// return conflicted state
+		return domain.SubjectConflicted
 	// Unrecognized or invalid listener state.
 	default:
 		// return unknown state for invalid input
@@ -243,6 +265,113 @@ func (m *ProbeMonitor) SetCustomStatus(status string) {
 	m.health.SetCustomStatus(status)
 }
 
+// SetListenerConflicted marks a listener as conflicted while its port is
+// bound by a process outside the service, or clears the mark.
+// Probe results are ignored while a listener is conflicted; once cleared
+// the listener returns to listening until probes mark it ready again.
+//
+// Params:
+//   - name: the listener name.
+//   - conflicted: whether the port is held by another process.
+//
+// Returns:
+//   - bool: true if the listener state changed.
+func (m *ProbeMonitor) SetListenerConflicted(name string, conflicted bool) bool {
+	// Lock for thread-safe update.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Find the listener by name.
+	for _, lp := range m.listeners {
+		// Skip other listeners.
+		if lp.Listener.Name != name {
+			continue
+		}
+
+		// Skip when the conflict state is unchanged.
+		if lp.Listener.State.IsConflicted() == conflicted {
+			// No transition.
+			return false
+		}
+
+		var changed bool
+		// Enter or leave the conflicted state.
+		if conflicted {
+			changed = lp.Listener.MarkConflicted()
+		} else {
+			changed = lp.Listener.MarkListening()
+		}
+		ls := m.findOrCreateSubjectStatus(lp)
+		ls.SetState(listenerStateToSubjectState(lp.Listener.State))
+		ls.ResetCounters()
+		// Return whether the listener accepted the transition.
+		return changed
+	}
+
+	// Listener not monitored.
+	return false
+}
+
+// ListenerState returns the current state of a monitored listener.
+//
+// Params:
+//   - name: the listener name.
+//
+// Returns:
+//   - listener.State: the listener state.
+//   - bool: true if the listener is monitored.
+func (m *ProbeMonitor) ListenerState(name string) (listener.State, bool) {
+	// Lock for thread-safe read.
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Find the listener by name.
+	for _, lp := range m.listeners {
+		// Return the state of the matching listener.
+		if lp.Listener.Name == name {
+			// Listener found.
+			return lp.Listener.State, true
+		}
+	}
+
+	// Listener not monitored.
+	return listener.StateClosed, false
+}
+
+// ProbeNow probes a listener at once, outside its interval, such as when
+// its unix socket appeared. The result applies as a periodic one.
+//
+// Params:
+//   - ctx: context bounding the probe.
+//   - name: the listener name.
+//
+// Returns:
+//   - bool: false if the monitor is stopped or the listener has no prober.
+func (m *ProbeMonitor) ProbeNow(ctx context.Context, name string) bool {
+	m.mu.RLock()
+	running := m.running
+	var target *ListenerProbe
+	// Find the probed listener by name.
+	for _, lp := range m.listeners {
+		// Skip other listeners and listeners without prober.
+		if lp.Listener.Name != name || lp.Prober == nil {
+			continue
+		}
+		target = lp
+		break
+	}
+	m.mu.RUnlock()
+
+	// Only a running monitor probes.
+	if !running || target == nil {
+		// Nothing probed.
+		return false
+	}
+	m.performProbe(ctx, target)
+	// Listener probed.
+	return true
+}
+
 // Start starts the probe monitor.
 // This method spawns goroutines for each listener with a prober configured.
 // Each goroutine runs a probe loop that terminates when the context is cancelled
@@ -313,14 +442,9 @@ func (m *ProbeMonitor) Stop() {
 //   - stopCh: channel to signal stop (passed as param to avoid race on restart).
 //   - lp: the listener probe to run.
 func (m *ProbeMonitor) runProber(ctx context.Context, stopCh <-chan struct{}, lp *ListenerProbe) {
-	config := lp.ProbeConfig()
-	interval := config.Interval
-	// Use default interval when not specified in config.
-	if interval == 0 {
-		interval = m.defaultInterval
-	}
+	interval := m.probeInterval(lp)
 
-	ticker := time.NewTicker(interval)
+	ticker := m.clock.NewTicker(interval)
 	defer ticker.Stop()
 
 	// Perform initial probe immediately (unless already stopped/cancelled).
@@ -345,41 +469,129 @@ func (m *ProbeMonitor) runProber(ctx context.Context, stopCh <-chan struct{}, lp
 		case <-ctx.Done():
 			// Context cancelled.
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			// Perform periodic healthcheck.
 			m.performProbe(ctx, lp)
+			// Follow a changed default interval from the next period.
+			if next := m.probeInterval(lp); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
 		}
 	}
 }
 
+// SetDefaults changes the interval and timeout of the probes that configure
+// none. Running probes use the new interval from their next period, and
+// their probers are recreated with the new timeout.
+//
+// Params:
+//   - interval: the default interval; zero uses the probe default.
+//   - timeout: the default timeout; zero uses the probe default.
+func (m *ProbeMonitor) SetDefaults(interval, timeout time.Duration) {
+	// Use probe defaults when not set.
+	if interval == 0 {
+		interval = domain.DefaultInterval
+	}
+	// Use probe defaults when not set.
+	if timeout == 0 {
+		timeout = domain.DefaultTimeout
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultInterval = interval
+	// Probers keep the timeout they were created with.
+	if timeout == m.defaultTimeout {
+		// Timeout unchanged.
+		return
+	}
+	m.defaultTimeout = timeout
+	// Recreate the probers of the probes inheriting the timeout.
+	for _, lp := range m.listeners {
+		// Skip probes with their own timeout.
+		if lp.Prober == nil || lp.Binding == nil || lp.Binding.Config.Timeout != 0 {
+			continue
+		}
+		// Keep the current prober if creation fails.
+		if prober, err := m.createProberFromBinding(lp.Binding); err == nil {
+			lp.Prober = prober
+		}
+	}
+}
+
+// probeInterval returns the interval of a probe, the default interval when
+// it configures none.
+//
+// Params:
+//   - lp: the listener probe.
+//
+// Returns:
+//   - time.Duration: the effective interval.
+func (m *ProbeMonitor) probeInterval(lp *ListenerProbe) time.Duration {
+	// Use the configured interval when specified.
+	if interval := lp.ProbeConfig().Interval; interval != 0 {
+		// return configured interval
+		return interval
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// return default interval
+	return m.defaultInterval
+}
+
+// probeTimeout returns the timeout of a probe, the default timeout when it
+// configures none.
+//
+// Params:
+//   - lp: the listener probe.
+//
+// Returns:
+//   - time.Duration: the effective timeout.
+func (m *ProbeMonitor) probeTimeout(lp *ListenerProbe) time.Duration {
+	// Use the configured timeout when specified.
+	if timeout := lp.ProbeConfig().Timeout; timeout != 0 {
+		// return configured timeout
+		return timeout
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// return default timeout
+	return m.defaultTimeout
+}
+
 // performProbe performs a single healthcheck.
 //
 // Params:
 //   - ctx: parent context.
 //   - lp: the listener probe to use.
 func (m *ProbeMonitor) performProbe(ctx context.Context, lp *ListenerProbe) {
+	m.mu.RLock()
+	prober := lp.Prober
+	paused := m.probesPaused(lp.Listener.Name)
+	m.mu.RUnlock()
 	// Guard against nil prober to prevent panic.
-	if lp.Prober == nil {
+	if prober == nil {
 		// Skip probe execution when prober is not configured.
 		return
 	}
-
-	config := lp.ProbeConfig()
-	timeout := config.Timeout
-	// Use default timeout when not specified in config.
-	if timeout == 0 {
-		timeout = m.defaultTimeout
+	// Leave paused listeners alone.
+	if paused {
+		// Skip probe execution until the pause ends.
+		return
 	}
 
-	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	probeCtx, cancel := context.WithTimeout(ctx, m.probeTimeout(lp))
 	defer cancel()
 
 	target := lp.ProbeTarget()
 
 	// Execute the healthcheck.
-	result := lp.Prober.Probe(probeCtx, target)
+	result := prober.Probe(probeCtx, target)
 
 	m.updateProbeResult(lp, result)
+
+	// Record the attempt when the probe is traced.
+	m.traceAttempt(lp, target, result)
 }
 
 // updateProbeResult updates the listener status based on probe result.
@@ -392,6 +604,18 @@ func (m *ProbeMonitor) updateProbeResult(lp *ListenerProbe, result domain.CheckR
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// Ignore results while another process holds the port: it answers the probe.
+	if lp.Listener.State.IsConflicted() {
+		// Skip probe evaluation until the conflict clears.
+		return
+	}
+
+	// Drop the result of a probe that was running when the pause started.
+	if m.probesPaused(lp.Listener.Name) {
+		// Skip probe evaluation until the pause ends.
+		return
+	}
+
 	// Find or create listener status in health.
 	ls := m.findOrCreateSubjectStatus(lp)
 
@@ -511,7 +735,7 @@ func (m *ProbeMonitor) attemptStateTransition(lp *ListenerProbe, targetState dom
 		// attempt transition to listening
 		return lp.Listener.MarkListening()
 	// Handle invalid transition targets for listeners.
-	case domain.SubjectUnknown, domain.SubjectClosed, domain.SubjectRunning, domain.SubjectStopped, domain.SubjectFailed:
+	case domain.SubjectUnknown, domain.SubjectClosed, domain.SubjectConflicted, domain.SubjectRunning, domain.SubjectStopped, domain.SubjectFailed:
 		// return false for invalid targets
 		return false
 	// return false for any unhandled state
@@ -532,7 +756,7 @@ func (m *ProbeMonitor) storeProbeResult(ls *domain.SubjectStatus, result domain.
 		Status:    m.resultToStatus(result),
 		Message:   result.Output,
 		Duration:  result.Latency,
-		Timestamp: time.Now(),
+		Timestamp: m.clock.Now(),
 		Error:     result.Error,
 	}
 
@@ -771,6 +995,11 @@ func (m *ProbeMonitor) getFailureThreshold(lp *ListenerProbe) int {
 // Returns:
 //   - domain.Status: the corresponding health status.
 func (m *ProbeMonitor) resultToStatus(result domain.CheckResult) domain.Status {
+	// Map success past a warn threshold to degraded.
+	if result.Success && result.Warning {
+		// Return degraded status for successful healthcheck with warning.
+		return domain.StatusDegraded
+	}
 	// Map success to healthy, failure to unhealthy.
 	if result.Success {
 		// Return healthy status for successful healthcheck.
@@ -0,0 +1,118 @@
+//go:build !race
+
+package health_test
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	domain "github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/listener"
+)
+
+// probesPerService is the number of listeners probed per service.
+const probesPerService int = 5
+
+// benchCreator creates probers counting their probes.
+type benchCreator struct {
+	probes atomic.Int64
+}
+
+func (c *benchCreator) Create(proberType string, _ time.Duration) (domain.Prober, error) {
+	return &benchProber{probeType: proberType, probes: &c.probes}, nil
+}
+
+// benchProber succeeds immediately.
+type benchProber struct {
+	probeType string
+	probes    *atomic.Int64
+}
+
+func (p *benchProber) Probe(_ context.Context, _ domain.Target) domain.CheckResult {
+	p.probes.Add(1)
+	return domain.CheckResult{Success: true}
+}
+
+func (p *benchProber) Type() string {
+	return p.probeType
+}
+
+// newBenchMonitors creates one monitor per service, each with five probes.
+func newBenchMonitors(b *testing.B, creator *benchCreator, services int) []*apphealth.ProbeMonitor {
+	b.Helper()
+	monitors := make([]*apphealth.ProbeMonitor, services)
+	for i := range monitors {
+		monitors[i] = apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{Factory: creator})
+		for j := range probesPerService {
+			name := "listener-" + strconv.Itoa(j)
+			binding := &apphealth.ProbeBinding{ListenerName: name, Type: apphealth.ProbeTCP}
+			if err := monitors[i].AddListenerWithBinding(listener.NewListener(name, "tcp", "localhost", 8000+j), binding); err != nil {
+				b.Fatalf("AddListenerWithBinding failed: %v", err)
+			}
+		}
+	}
+	return monitors
+}
+
+// BenchmarkProbeMonitorRound measures scheduling one round of every probe:
+// starting the probe goroutines, running the first probe of each and
+// stopping them.
+func BenchmarkProbeMonitorRound(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		services int
+	}{
+		{"100Probes", 20},
+		{"1000Probes", 200},
+		{"5000Probes", 1000},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			creator := &benchCreator{}
+			monitors := newBenchMonitors(b, creator, bm.services)
+			ctx := context.Background()
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for range b.N {
+				target := creator.probes.Load() + int64(bm.services*probesPerService)
+				for _, m := range monitors {
+					m.Start(ctx)
+				}
+				for creator.probes.Load() < target {
+					time.Sleep(10 * time.Microsecond)
+				}
+				for _, m := range monitors {
+					m.Stop()
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkProbeMonitorHealth measures the aggregated health read polled by
+// the API while probes run.
+func BenchmarkProbeMonitorHealth(b *testing.B) {
+	creator := &benchCreator{}
+	monitors := newBenchMonitors(b, creator, 1000)
+	ctx := context.Background()
+	for _, m := range monitors {
+		m.Start(ctx)
+		defer m.Stop()
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for range b.N {
+		for _, m := range monitors {
+			_ = m.Health()
+		}
+	}
+}
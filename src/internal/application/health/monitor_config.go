@@ -5,6 +5,7 @@ import (
 	"time"
 
 	domain "github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/shared"
 )
 
 // HealthStateLogger is called when a health state transition occurs.
@@ -19,6 +20,10 @@ type UnhealthyCallback func(listenerName string, reason string)
 // This enables the supervisor to emit healthy events for observability.
 type HealthyCallback func(listenerName string)
 
+// ProbeAttemptCallback is called after each attempt of a probe with debug enabled.
+// This enables logging probe attempts without raising the global log level.
+type ProbeAttemptCallback func(attempt domain.ProbeAttempt)
+
 // ProbeMonitorConfig contains configuration for ProbeMonitor.
 // It provides all necessary dependencies for creating a new ProbeMonitor.
 type ProbeMonitorConfig struct {
@@ -40,6 +45,12 @@ type ProbeMonitorConfig struct {
 	// OnHealthy is called when a service becomes healthy (optional).
 	// This callback enables the supervisor to emit healthy events for observability.
 	OnHealthy HealthyCallback
+	// OnProbeAttempt is called after each traced probe attempt (optional).
+	// It runs on the probing goroutine and must not block.
+	OnProbeAttempt ProbeAttemptCallback
+	// Clock times the probe intervals and timestamps the events (optional).
+	// shared.DefaultClock is used when nil.
+	Clock shared.Clock
 }
 
 // NewProbeMonitorConfig creates a new ProbeMonitorConfig with the given factory.
@@ -197,6 +197,83 @@ func TestProbeMonitor_AddListener(t *testing.T) {
 	}
 }
 
+// TestProbeMonitor_SetListenerConflicted tests marking a listener as conflicted.
+func TestProbeMonitor_SetListenerConflicted(t *testing.T) {
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})
+	l := listener.NewListener("http", "tcp", "", 8080)
+	l.State = listener.StateReady
+	require.NoError(t, monitor.AddListener(l))
+
+	// Unknown listener: no change.
+	assert.False(t, monitor.SetListenerConflicted("admin", true))
+
+	// Port taken by another process: listener becomes conflicted.
+	assert.True(t, monitor.SetListenerConflicted("http", true))
+	assert.Equal(t, listener.StateConflicted, l.State)
+	health := monitor.Health()
+	require.Len(t, health.Subjects, 1)
+	assert.Equal(t, domain.SubjectConflicted, health.Subjects[0].State)
+
+	// Repeated detection: no change.
+	assert.False(t, monitor.SetListenerConflicted("http", true))
+
+	// Conflict cleared: listener back to listening until probes succeed.
+	assert.True(t, monitor.SetListenerConflicted("http", false))
+	assert.Equal(t, listener.StateListening, l.State)
+	assert.Equal(t, domain.SubjectListening, monitor.Health().Subjects[0].State)
+}
+
+// TestProbeMonitor_ListenerState tests listener state lookup by name.
+func TestProbeMonitor_ListenerState(t *testing.T) {
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})
+	l := listener.NewListener("http", "tcp", "", 8080)
+	l.State = listener.StateReady
+	require.NoError(t, monitor.AddListener(l))
+
+	// Monitored listener reports its state.
+	state, ok := monitor.ListenerState("http")
+	assert.True(t, ok)
+	assert.Equal(t, listener.StateReady, state)
+
+	// Unknown listener is not found.
+	_, ok = monitor.ListenerState("admin")
+	assert.False(t, ok)
+}
+
+// TestProbeMonitor_ProbeNow tests a listener is probed outside its interval.
+func TestProbeMonitor_ProbeNow(t *testing.T) {
+	healthy := make(chan string, 1)
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{
+		Factory:   &mockCreator{},
+		OnHealthy: func(name string) { healthy <- name },
+	})
+	monitor.SetProcessState(process.StateRunning)
+	sock := listener.NewListener("sock", "unix", "/run/api.sock", 0)
+	sock.MarkListening()
+	require.NoError(t, monitor.AddListenerWithBinding(sock, &apphealth.ProbeBinding{
+		ListenerName: "sock",
+		Type:         apphealth.ProbeTCP,
+		Target:       apphealth.ProbeTarget{Network: "unix", Address: "/run/api.sock"},
+		Config:       apphealth.ProbeConfig{Interval: time.Hour, SuccessThreshold: 1, FailureThreshold: 1},
+	}))
+
+	// A stopped monitor does not probe.
+	assert.False(t, monitor.ProbeNow(context.Background(), "sock"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	monitor.Start(ctx)
+	defer monitor.Stop()
+	// The initial probe marks the listener ready.
+	assert.Equal(t, "sock", <-healthy)
+
+	// Unknown listeners are not probed.
+	assert.False(t, monitor.ProbeNow(ctx, "admin"))
+	assert.True(t, monitor.ProbeNow(ctx, "sock"))
+	state, _ := monitor.ListenerState("sock")
+	assert.Equal(t, listener.StateReady, state)
+}
+
 // TestProbeMonitor_AddListenerWithBinding tests listener with binding addition.
 func TestProbeMonitor_AddListenerWithBinding(t *testing.T) {
 	tests := []struct {
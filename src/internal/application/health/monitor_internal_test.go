@@ -13,6 +13,7 @@ import (
 	domain "github.com/kodflow/daemon/internal/domain/health"
 	"github.com/kodflow/daemon/internal/domain/listener"
 	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
 )
 
 // internalTestProber is a mock prober for internal testing.
@@ -23,6 +24,8 @@ type internalTestProber struct {
 	probeType  string
 	result     domain.CheckResult
 	probeCount int
+	// probed receives a value after each probe when set.
+	probed chan struct{}
 }
 
 // Probe returns the configured test result and increments probe count.
@@ -35,6 +38,9 @@ type internalTestProber struct {
 //   - domain.CheckResult: the configured test result.
 func (p *internalTestProber) Probe(_ context.Context, _ domain.Target) domain.CheckResult {
 	p.probeCount++
+	if p.probed != nil {
+		p.probed <- struct{}{}
+	}
 	return p.result
 }
 
@@ -113,6 +119,11 @@ func Test_ProbeMonitor_resultToStatus(t *testing.T) {
 			},
 			expected: domain.StatusHealthy,
 		},
+		{
+			name:     "success_with_warning_maps_to_degraded",
+			result:   domain.CheckResult{Success: true, Warning: true},
+			expected: domain.StatusDegraded,
+		},
 		{
 			name: "failure_with_error_maps_to_unhealthy",
 			result: domain.CheckResult{
@@ -409,6 +420,29 @@ func Test_ProbeMonitor_updateProbeResult(t *testing.T) {
 	}
 }
 
+// Test_ProbeMonitor_updateProbeResult_conflicted tests that probe results are ignored
+// while another process holds the listener port.
+//
+// Params:
+//   - t: the testing context.
+func Test_ProbeMonitor_updateProbeResult_conflicted(t *testing.T) {
+	monitor := NewProbeMonitor(ProbeMonitorConfig{})
+	l := listener.NewListener("test", "tcp", "localhost", 8080)
+	l.State = listener.StateConflicted
+	lp := &ListenerProbe{
+		Listener: l,
+		Prober:   &internalTestProber{probeType: "tcp"},
+		Binding:  &ProbeBinding{ListenerName: "test", Type: ProbeTCP},
+	}
+
+	// A successful probe answered by the foreign process.
+	monitor.updateProbeResult(lp, domain.CheckResult{Success: true})
+
+	// Verify neither the listener nor health subjects changed.
+	assert.Equal(t, listener.StateConflicted, l.State)
+	assert.Empty(t, monitor.health.Subjects)
+}
+
 // Test_ProbeMonitor_sendEventIfChanged tests the sendEventIfChanged method.
 //
 // Params:
@@ -978,6 +1012,11 @@ func Test_listenerStateToSubjectState(t *testing.T) {
 			input:    listener.StateClosed,
 			expected: domain.SubjectClosed,
 		},
+		{
+			name:     "conflicted_to_subject_conflicted",
+			input:    listener.StateConflicted,
+			expected: domain.SubjectConflicted,
+		},
 		{
 			name:     "unknown_to_subject_unknown",
 			input:    listener.State(99),
@@ -1896,8 +1935,10 @@ func Test_ProbeMonitor_updateListenerState_invalidTargetStates(t *testing.T) {
 //
 // Goroutine lifecycle:
 //   - Started: runs monitor.runProber in background
-//   - Synchronized: via done channel and stopCh
+//   - Synchronized: via the probed channel, done channel and stopCh
 //   - Terminated: when stopCh is closed or test timeout
+//
+// The ticker runs on a fake clock advanced one interval at a time.
 func Test_ProbeMonitor_runProber_tickerCase(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1912,22 +1953,24 @@ func Test_ProbeMonitor_runProber_tickerCase(t *testing.T) {
 			// Create monitor.
 			factory := &internalTestCreator{}
 			config := NewProbeMonitorConfig(factory)
+			clock := shared.NewFakeClock(time.Now())
+			config.Clock = clock
 			monitor := NewProbeMonitor(config)
 
-			// Create listener probe with very short interval.
+			// Create listener probe.
 			l := listener.NewListener("test", "tcp", "localhost", 8080)
 			lp := NewListenerProbe(l)
 			prober := &internalTestProber{
 				probeType: "tcp",
 				result:    domain.CheckResult{Success: true},
+				probed:    make(chan struct{}),
 			}
 			lp.Prober = prober
-			// Set very short interval to ensure ticker fires.
 			binding := &ProbeBinding{
 				ListenerName: "test",
 				Type:         ProbeTCP,
 				Config: ProbeConfig{
-					Interval: 10 * time.Millisecond,
+					Interval: 10 * time.Second,
 				},
 			}
 			lp.Binding = binding
@@ -1943,8 +1986,13 @@ func Test_ProbeMonitor_runProber_tickerCase(t *testing.T) {
 				close(done)
 			}()
 
-			// Wait for multiple ticker intervals (initial + at least 2 ticks).
-			time.Sleep(35 * time.Millisecond)
+			// Initial probe, then one probe per interval.
+			<-prober.probed
+			clock.BlockUntil(1)
+			for range 2 {
+				clock.Advance(binding.Config.Interval)
+				<-prober.probed
+			}
 
 			// Close stop channel.
 			close(stopCh)
@@ -1957,10 +2005,9 @@ func Test_ProbeMonitor_runProber_tickerCase(t *testing.T) {
 				t.Fatal("runProber did not exit")
 			}
 
-			// Verify multiple probes occurred (initial + ticker ticks).
-			// With 10ms interval and 35ms wait, expect at least 3 probes.
-			assert.GreaterOrEqual(t, prober.probeCount, 3,
-				"expected at least 3 probes (initial + 2 ticks), got %d", prober.probeCount)
+			// Verify the initial probe and one probe per tick.
+			assert.Equal(t, 3, prober.probeCount,
+				"expected 3 probes (initial + 2 ticks), got %d", prober.probeCount)
 		})
 	}
 }
@@ -2603,3 +2650,117 @@ func Test_ProbeMonitor_attemptStateTransition(t *testing.T) {
 		})
 	}
 }
+
+// Test_ProbeMonitor_SetDefaults tests that changed defaults apply to the
+// probes configuring no interval or timeout only.
+func Test_ProbeMonitor_SetDefaults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		interval        time.Duration
+		timeout         time.Duration
+		binding         ProbeConfig
+		expectedPeriod  time.Duration
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "inheriting_probe_follows_defaults",
+			interval:        30 * time.Second,
+			timeout:         2 * time.Second,
+			expectedPeriod:  30 * time.Second,
+			expectedTimeout: 2 * time.Second,
+		},
+		{
+			name:            "configured_probe_keeps_its_timing",
+			interval:        30 * time.Second,
+			timeout:         2 * time.Second,
+			binding:         ProbeConfig{Interval: time.Second, Timeout: time.Second},
+			expectedPeriod:  time.Second,
+			expectedTimeout: time.Second,
+		},
+		{
+			name:            "zero_restores_probe_defaults",
+			expectedPeriod:  domain.DefaultInterval,
+			expectedTimeout: domain.DefaultTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			monitor := NewProbeMonitor(ProbeMonitorConfig{DefaultInterval: time.Minute, DefaultTimeout: time.Minute})
+			lp := NewListenerProbeWithBinding(listener.NewListener("test", "tcp", "localhost", 8080), &ProbeBinding{Config: tt.binding})
+
+			monitor.SetDefaults(tt.interval, tt.timeout)
+
+			assert.Equal(t, tt.expectedPeriod, monitor.probeInterval(lp))
+			assert.Equal(t, tt.expectedTimeout, monitor.probeTimeout(lp))
+		})
+	}
+}
+
+// timeoutRecordingCreator records the timeout of each created prober.
+type timeoutRecordingCreator struct {
+	timeouts []time.Duration
+}
+
+// Create records the timeout and returns a successful prober.
+//
+// Params:
+//   - proberType: the type of prober to create.
+//   - timeout: the timeout for the prober.
+//
+// Returns:
+//   - domain.Prober: the created prober.
+//   - error: always nil.
+func (c *timeoutRecordingCreator) Create(proberType string, timeout time.Duration) (domain.Prober, error) {
+	c.timeouts = append(c.timeouts, timeout)
+	return &internalTestProber{probeType: proberType, result: domain.CheckResult{Success: true}}, nil
+}
+
+// Test_ProbeMonitor_SetDefaults_recreatesProbers tests that a changed
+// default timeout recreates the probers of the probes inheriting it.
+func Test_ProbeMonitor_SetDefaults_recreatesProbers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		binding  ProbeConfig
+		expected []time.Duration
+	}{
+		{
+			name:     "inheriting_prober_recreated",
+			timeout:  2 * time.Second,
+			expected: []time.Duration{domain.DefaultTimeout, 2 * time.Second},
+		},
+		{
+			name:     "unchanged_timeout_keeps_prober",
+			timeout:  domain.DefaultTimeout,
+			expected: []time.Duration{domain.DefaultTimeout},
+		},
+		{
+			name:     "configured_timeout_keeps_prober",
+			timeout:  2 * time.Second,
+			binding:  ProbeConfig{Timeout: time.Second},
+			expected: []time.Duration{time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			creator := &timeoutRecordingCreator{}
+			monitor := NewProbeMonitor(NewProbeMonitorConfig(creator))
+			l := listener.NewListener("test", "tcp", "localhost", 8080)
+			require.NoError(t, monitor.AddListenerWithBinding(l, &ProbeBinding{ListenerName: "test", Type: ProbeTCP, Config: tt.binding}))
+
+			monitor.SetDefaults(0, tt.timeout)
+
+			assert.Equal(t, tt.expected, creator.timeouts)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Package health provides the application service for health monitoring.
+package health
+
+import "time"
+
+// allListeners keys the pause covering every listener of the monitor.
+const allListeners string = ""
+
+// PauseProbes stops probing a listener, or every listener when name is
+// empty, until the given time. Paused listeners keep their state: no probe
+// runs, results of probes already running are dropped, and no health event
+// or unhealthy callback fires. Pausing a paused listener moves its end.
+//
+// Params:
+//   - name: the listener name, empty for every listener.
+//   - until: when probing resumes on its own.
+//
+// Returns:
+//   - bool: false if no probed listener matches the name.
+func (m *ProbeMonitor) PauseProbes(name string, until time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Only probed listeners can pause.
+	if !m.hasProber(name) {
+		// Nothing to pause.
+		return false
+	}
+	// create pause map on first use
+	if m.pauses == nil {
+		m.pauses = make(map[string]time.Time)
+	}
+	m.pauses[name] = until
+	// Pause applied.
+	return true
+}
+
+// ResumeProbes lifts the pause of a listener, or the pause of every
+// listener when name is empty; pauses of single listeners are kept.
+// The resumed listeners count their successes and failures afresh.
+//
+// Params:
+//   - name: the listener name, empty for the pause of every listener.
+//
+// Returns:
+//   - bool: false if no such pause was set.
+func (m *ProbeMonitor) ResumeProbes(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Skip listeners that are not paused.
+	if _, ok := m.pauses[name]; !ok {
+		// Nothing to resume.
+		return false
+	}
+	delete(m.pauses, name)
+	// Restart the thresholds of the resumed listeners.
+	for _, lp := range m.listeners {
+		// Skip listeners outside the lifted pause.
+		if name != allListeners && lp.Listener.Name != name {
+			continue
+		}
+		m.findOrCreateSubjectStatus(lp).ResetCounters()
+	}
+	// Pause lifted.
+	return true
+}
+
+// hasProber reports whether a listener, or any listener when name is
+// empty, is probed. Must be called with m.mu held.
+//
+// Params:
+//   - name: the listener name, empty for any listener.
+//
+// Returns:
+//   - bool: true if a matching listener has a prober.
+func (m *ProbeMonitor) hasProber(name string) bool {
+	// Look for a matching probed listener.
+	for _, lp := range m.listeners {
+		// Match any listener or the named one.
+		if lp.Prober != nil && (name == allListeners || lp.Listener.Name == name) {
+			// Probed listener found.
+			return true
+		}
+	}
+	// No probed listener matches.
+	return false
+}
+
+// probesPaused reports whether the probe of a listener is paused, by its
+// own pause or by the pause of every listener. Pauses past their end are
+// ignored. Must be called with m.mu held.
+//
+// Params:
+//   - name: the listener name.
+//
+// Returns:
+//   - bool: true while the listener is paused.
+func (m *ProbeMonitor) probesPaused(name string) bool {
+	// Most monitors are never paused.
+	if len(m.pauses) == 0 {
+		// Not paused.
+		return false
+	}
+	now := m.clock.Now()
+	// Check the pause of every listener first.
+	if until, ok := m.pauses[allListeners]; ok && now.Before(until) {
+		// Paused with every listener.
+		return true
+	}
+	until, ok := m.pauses[name]
+	// Return whether the own pause still runs.
+	return ok && now.Before(until)
+}
@@ -0,0 +1,79 @@
+// Package health_test provides black-box tests for the health package.
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	domain "github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/listener"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestProbeMonitor_PauseProbes tests that paused probes neither run nor
+// report failures until their pause ends.
+func TestProbeMonitor_PauseProbes(t *testing.T) {
+	clock := shared.NewFakeClock(time.Now())
+	unhealthy := make(chan string, 4)
+	factory := &mockCreator{probers: map[string]*mockProber{
+		"tcp": {probeType: "tcp", result: domain.NewFailureCheckResult(0, "", errors.New("connection refused"))},
+	}}
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{
+		Factory:     factory,
+		Clock:       clock,
+		OnUnhealthy: func(name, _ string) { unhealthy <- name },
+	})
+	monitor.SetProcessState(process.StateRunning)
+	l := listener.NewListener("http", "tcp", "127.0.0.1", 8080)
+	l.State = listener.StateReady
+	require.NoError(t, monitor.AddListenerWithBinding(l, &apphealth.ProbeBinding{
+		ListenerName: "http",
+		Type:         apphealth.ProbeTCP,
+		Config:       apphealth.ProbeConfig{Interval: time.Hour, SuccessThreshold: 1, FailureThreshold: 1},
+	}))
+
+	// Only probed listeners pause.
+	assert.False(t, monitor.PauseProbes("admin", clock.Now().Add(time.Minute)))
+	assert.True(t, monitor.PauseProbes("", clock.Now().Add(time.Minute)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	monitor.Start(ctx)
+	defer monitor.Stop()
+
+	// The failing probe is skipped while paused.
+	assert.True(t, monitor.ProbeNow(ctx, "http"))
+	state, _ := monitor.ListenerState("http")
+	assert.Equal(t, listener.StateReady, state)
+	assert.Empty(t, unhealthy)
+
+	// Once the pause ends, failures count again.
+	clock.Advance(time.Minute)
+	assert.True(t, monitor.ProbeNow(ctx, "http"))
+	assert.Equal(t, "http", <-unhealthy)
+}
+
+// TestProbeMonitor_ResumeProbes tests lifting probe pauses.
+func TestProbeMonitor_ResumeProbes(t *testing.T) {
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{Factory: &mockCreator{}})
+	require.NoError(t, monitor.AddListenerWithBinding(listener.NewListener("http", "tcp", "127.0.0.1", 8080), &apphealth.ProbeBinding{
+		ListenerName: "http",
+		Type:         apphealth.ProbeTCP,
+	}))
+	until := time.Now().Add(time.Hour)
+	require.True(t, monitor.PauseProbes("", until))
+	require.True(t, monitor.PauseProbes("http", until))
+
+	// Lifting the pause of every listener keeps the listener pause.
+	assert.True(t, monitor.ResumeProbes(""))
+	assert.False(t, monitor.ResumeProbes(""))
+	assert.True(t, monitor.ResumeProbes("http"))
+	assert.False(t, monitor.ResumeProbes("http"))
+}
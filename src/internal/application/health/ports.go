@@ -2,6 +2,7 @@
 package health
 
 import (
+	"context"
 	"time"
 
 	"github.com/kodflow/daemon/internal/domain/health"
@@ -21,3 +22,20 @@ type Creator interface {
 	//   - error: if creation fails.
 	Create(proberType string, timeout time.Duration) (health.Prober, error)
 }
+
+// CertificateInspector retrieves the certificate served by a TLS endpoint.
+// It is the port that infrastructure adapters implement for certificate checks.
+type CertificateInspector interface {
+	// Inspect connects to a TLS endpoint and returns its leaf certificate,
+	// without verifying it.
+	//
+	// Params:
+	//   - ctx: the context bounding the connection and handshake.
+	//   - address: the host:port to connect to.
+	//   - serverName: the SNI to send, empty for none.
+	//
+	// Returns:
+	//   - health.Certificate: the leaf certificate.
+	//   - error: if the connection or handshake fails.
+	Inspect(ctx context.Context, address, serverName string) (health.Certificate, error)
+}
@@ -27,6 +27,8 @@ type ProbeConfig struct {
 	SuccessThreshold int
 	// FailureThreshold is the number of consecutive failures to mark unhealthy.
 	FailureThreshold int
+	// Debug records every probe attempt in the monitor trace.
+	Debug bool
 }
 
 // DefaultProbeConfig returns a ProbeConfig with sensible defaults.
@@ -1,9 +1,13 @@
 // Package health provides health monitoring for services.
 package health
 
+import domain "github.com/kodflow/daemon/internal/domain/health"
+
 // ProbeTarget defines the target for a health probe.
 // It contains all necessary information to execute different types of probes (HTTP, gRPC, exec, etc.).
 type ProbeTarget struct {
+	// Network is the network to dial (tcp, tcp4, tcp6, udp, udp4, udp6).
+	Network string
 	// Address is the target address (host:port).
 	Address string
 	// Path is the HTTP path (for HTTP probes).
@@ -18,4 +22,8 @@ type ProbeTarget struct {
 	Command string
 	// Args are the command arguments (for exec probes).
 	Args []string
+	// Steps are the HTTP requests (for scenario probes).
+	Steps []domain.ScenarioStep
+	// Threshold is the value check (for threshold probes).
+	Threshold *domain.Threshold
 }
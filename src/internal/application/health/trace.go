@@ -0,0 +1,144 @@
+// Package health provides the application service for health monitoring.
+package health
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	domain "github.com/kodflow/daemon/internal/domain/health"
+)
+
+// probeTraceSize is the number of attempts kept per traced listener.
+const probeTraceSize int = 100
+
+// probeTrace is a fixed-size ring of the latest probe attempts of a listener.
+type probeTrace struct {
+	// attempts holds up to probeTraceSize attempts.
+	attempts []domain.ProbeAttempt
+	// next is the slot overwritten by the next attempt once full.
+	next int
+}
+
+// record appends an attempt, overwriting the oldest one once full.
+//
+// Params:
+//   - attempt: the probe attempt.
+func (t *probeTrace) record(attempt domain.ProbeAttempt) {
+	// grow until the ring is full
+	if len(t.attempts) < probeTraceSize {
+		t.attempts = append(t.attempts, attempt)
+		// attempt stored
+		return
+	}
+	t.attempts[t.next] = attempt
+	t.next = (t.next + 1) % probeTraceSize
+}
+
+// snapshot returns the attempts from oldest to newest.
+//
+// Returns:
+//   - []domain.ProbeAttempt: a copy of the attempts.
+func (t *probeTrace) snapshot() []domain.ProbeAttempt {
+	// unroll the ring from its oldest slot
+	return append(slices.Clone(t.attempts[t.next:]), t.attempts[:t.next]...)
+}
+
+// traceAttempt records a probe attempt when debug is enabled for the probe
+// and hands it to the attempt callback.
+//
+// Params:
+//   - lp: the listener probe that was executed.
+//   - target: the probed target.
+//   - result: the probe result.
+func (m *ProbeMonitor) traceAttempt(lp *ListenerProbe, target domain.Target, result domain.CheckResult) {
+	// Only probes with debug enabled are traced.
+	if lp.Binding == nil || !lp.Binding.Config.Debug {
+		// Not traced.
+		return
+	}
+	attempt := domain.NewProbeAttempt(lp.Listener.Name, string(lp.Binding.Type), describeTarget(lp.Binding.Type, target), result, m.clock.Now())
+
+	// Lock for thread-safe update.
+	m.mu.Lock()
+	// Create the trace on first attempt.
+	if m.traces == nil {
+		m.traces = make(map[string]*probeTrace)
+	}
+	trace, ok := m.traces[attempt.Listener]
+	// Create the listener trace on first attempt.
+	if !ok {
+		trace = &probeTrace{}
+		m.traces[attempt.Listener] = trace
+	}
+	trace.record(attempt)
+	callback := m.onProbeAttempt
+	m.mu.Unlock()
+
+	// Notify outside the lock.
+	if callback != nil {
+		callback(attempt)
+	}
+}
+
+// ProbeTrace returns the recent attempts of probes with debug enabled,
+// oldest first, across all listeners.
+//
+// Returns:
+//   - []domain.ProbeAttempt: the traced attempts, empty when none is traced.
+func (m *ProbeMonitor) ProbeTrace() []domain.ProbeAttempt {
+	// Lock for thread-safe read.
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var attempts []domain.ProbeAttempt
+	// Gather every listener trace.
+	for _, trace := range m.traces {
+		attempts = append(attempts, trace.snapshot()...)
+	}
+	// Interleave listeners in time order.
+	slices.SortStableFunc(attempts, func(a, b domain.ProbeAttempt) int { return a.Timestamp.Compare(b.Timestamp) })
+	// Return merged attempts.
+	return attempts
+}
+
+// describeTarget returns a readable form of the probed target.
+//
+// Params:
+//   - probeType: the probe type.
+//   - target: the probe target.
+//
+// Returns:
+//   - string: the command line for exec probes, the URL path for HTTP probes,
+//     the address otherwise.
+func describeTarget(probeType ProbeType, target domain.Target) string {
+	// Describe the target by probe type.
+	//exhaustive:ignore
+	switch probeType {
+	// Exec probes run a command.
+	case ProbeExec:
+		// Return the command line.
+		return strings.Join(append([]string{target.Command}, target.Args...), " ")
+	// HTTP probes request a path.
+	case ProbeHTTP:
+		// Return address and path.
+		return target.Address + target.Path
+	// Scenario probes request several paths.
+	case ProbeScenario:
+		// Return address and step count.
+		return fmt.Sprintf("%s (%d steps)", target.Address, len(target.Steps))
+	// Threshold probes run a command or request a path.
+	case ProbeThreshold:
+		// Return the command line when set.
+		if target.Command != "" {
+			// Return the command line.
+			return strings.Join(append([]string{target.Command}, target.Args...), " ")
+		}
+		// Return address and path.
+		return target.Address + target.Path
+	// Other probes dial the address.
+	default:
+		// Return the address.
+		return target.Address
+	}
+}
@@ -0,0 +1,154 @@
+// Package health provides internal tests for trace.go.
+// It tests probe attempt tracing using white-box testing.
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/listener"
+)
+
+// Test_probeTrace_record tests that the ring keeps the latest attempts in order.
+//
+// Params:
+//   - t: the testing context.
+func Test_probeTrace_record(t *testing.T) {
+	tests := []struct {
+		name      string
+		recorded  int
+		wantLen   int
+		wantFirst int
+	}{
+		{name: "empty", recorded: 0, wantLen: 0},
+		{name: "partial", recorded: 3, wantLen: 3, wantFirst: 0},
+		{name: "full", recorded: probeTraceSize, wantLen: probeTraceSize, wantFirst: 0},
+		{name: "wrapped", recorded: probeTraceSize + 7, wantLen: probeTraceSize, wantFirst: 7},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := &probeTrace{}
+			// Tag each attempt with its sequence in the latency field.
+			for i := range tt.recorded {
+				trace.record(domain.ProbeAttempt{Latency: time.Duration(i)})
+			}
+
+			got := trace.snapshot()
+			require.Len(t, got, tt.wantLen)
+			// Attempts come out oldest first without gaps.
+			for i, attempt := range got {
+				assert.Equal(t, time.Duration(tt.wantFirst+i), attempt.Latency)
+			}
+		})
+	}
+}
+
+// Test_ProbeMonitor_traceAttempt tests that only debug probes are traced.
+//
+// Params:
+//   - t: the testing context.
+func Test_ProbeMonitor_traceAttempt(t *testing.T) {
+	tests := []struct {
+		name       string
+		debug      bool
+		result     domain.CheckResult
+		wantTraced bool
+	}{
+		{name: "debug success", debug: true, result: domain.CheckResult{Success: true, Latency: time.Millisecond}, wantTraced: true},
+		{name: "debug failure", debug: true, result: domain.CheckResult{Error: errors.New("connection refused")}, wantTraced: true},
+		{name: "not traced", debug: false, result: domain.CheckResult{Success: true}},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var notified []domain.ProbeAttempt
+			monitor := NewProbeMonitor(ProbeMonitorConfig{
+				Factory:        &internalTestCreator{},
+				OnProbeAttempt: func(attempt domain.ProbeAttempt) { notified = append(notified, attempt) },
+			})
+			lp := &ListenerProbe{
+				Listener: listener.NewListener("http", "tcp", "localhost", 8080),
+				Prober:   &internalTestProber{probeType: "tcp", result: tt.result},
+				Binding: &ProbeBinding{
+					ListenerName: "http",
+					Type:         ProbeTCP,
+					Target:       ProbeTarget{Address: "127.0.0.1:8080"},
+					Config:       ProbeConfig{Timeout: time.Second, Debug: tt.debug},
+				},
+			}
+			monitor.listeners = append(monitor.listeners, lp)
+
+			monitor.performProbe(context.Background(), lp)
+
+			got := monitor.ProbeTrace()
+			// Untraced probes leave no trace and call no callback.
+			if !tt.wantTraced {
+				assert.Empty(t, got)
+				assert.Empty(t, notified)
+				return
+			}
+			require.Len(t, got, 1)
+			assert.Equal(t, got, notified)
+			assert.Equal(t, "http", got[0].Listener)
+			assert.Equal(t, "tcp", got[0].Type)
+			assert.Equal(t, "127.0.0.1:8080", got[0].Target)
+			assert.Equal(t, tt.result.Success, got[0].Success)
+			assert.Equal(t, tt.result.Latency, got[0].Latency)
+			assert.Equal(t, tt.result.Error != nil, got[0].Error != "")
+		})
+	}
+}
+
+// Test_ProbeMonitor_ProbeTrace_order tests that listener traces are merged in time order.
+//
+// Params:
+//   - t: the testing context.
+func Test_ProbeMonitor_ProbeTrace_order(t *testing.T) {
+	base := time.Now()
+	monitor := NewProbeMonitor(ProbeMonitorConfig{})
+	monitor.traces = map[string]*probeTrace{
+		"http": {attempts: []domain.ProbeAttempt{{Listener: "http", Timestamp: base}, {Listener: "http", Timestamp: base.Add(2 * time.Second)}}},
+		"grpc": {attempts: []domain.ProbeAttempt{{Listener: "grpc", Timestamp: base.Add(time.Second)}}},
+	}
+
+	got := monitor.ProbeTrace()
+
+	require.Len(t, got, 3)
+	assert.Equal(t, []string{"http", "grpc", "http"}, []string{got[0].Listener, got[1].Listener, got[2].Listener})
+}
+
+// Test_describeTarget tests the readable target of each probe type.
+//
+// Params:
+//   - t: the testing context.
+func Test_describeTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		probeType ProbeType
+		target    domain.Target
+		want      string
+	}{
+		{name: "tcp", probeType: ProbeTCP, target: domain.Target{Address: "127.0.0.1:5432"}, want: "127.0.0.1:5432"},
+		{name: "http", probeType: ProbeHTTP, target: domain.Target{Address: "127.0.0.1:8080", Path: "/health"}, want: "127.0.0.1:8080/health"},
+		{name: "scenario", probeType: ProbeScenario, target: domain.Target{Address: "127.0.0.1:8080", Steps: make([]domain.ScenarioStep, 2)}, want: "127.0.0.1:8080 (2 steps)"},
+		{name: "threshold http", probeType: ProbeThreshold, target: domain.Target{Address: "127.0.0.1:9090", Path: "/lag"}, want: "127.0.0.1:9090/lag"},
+		{name: "threshold command", probeType: ProbeThreshold, target: domain.Target{Command: "/bin/queue-lag", Args: []string{"orders"}}, want: "/bin/queue-lag orders"},
+		{name: "exec", probeType: ProbeExec, target: domain.Target{Command: "/bin/check", Args: []string{"--fast"}}, want: "/bin/check --fast"},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, describeTarget(tt.probeType, tt.target))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Package hook provides the port interface for running hook commands.
+package hook
+
+import (
+	"context"
+	"time"
+)
+
+// Command is a hook command run by the daemon on behalf of a service.
+type Command struct {
+	// Path is the executable, absolute or looked up in PATH.
+	Path string
+	// Args are the command arguments.
+	Args []string
+	// Env is added to the environment of the daemon, as KEY=value.
+	Env []string
+	// Timeout bounds the run; the command is killed once it expires.
+	Timeout time.Duration
+	// Event is written as JSON to the standard input, nil for none.
+	Event *Event
+}
+
+// Event is the event handed to an event hook.
+type Event struct {
+	// Time is when the event occurred.
+	Time time.Time
+	// Service is the service name, empty for daemon-wide events.
+	Service string
+	// Type is the event type name (started, exhausted, config_reloaded, ...).
+	Type string
+	// PID is the process ID, zero when none.
+	PID int
+	// ExitCode is the exit code of exit events.
+	ExitCode int
+	// Signal is the number of the signal that terminated the process, zero if none.
+	Signal int
+	// Error is the error attached to the event, if any.
+	Error string
+	// Labels are the labels of the service, if any.
+	Labels map[string]string
+}
+
+// Runner runs hook commands.
+type Runner interface {
+	// Run runs a command until it exits.
+	//
+	// Params:
+	//   - ctx: kills the command when cancelled.
+	//   - cmd: the command.
+	//
+	// Returns:
+	//   - error: if the command cannot start, fails or times out, with its output.
+	Run(ctx context.Context, cmd Command) error
+
+	// Output runs a command until it exits and returns its standard output.
+	//
+	// Params:
+	//   - ctx: kills the command when cancelled.
+	//   - cmd: the command.
+	//
+	// Returns:
+	//   - string: the standard output, also when the command failed.
+	//   - error: if the command cannot start, fails or times out, with its output.
+	Output(ctx context.Context, cmd Command) (string, error)
+}
@@ -0,0 +1,50 @@
+// Package integrity provides the port interface for file integrity watches.
+package integrity
+
+import (
+	"context"
+	"time"
+)
+
+// Target describes the files of one watch.
+type Target struct {
+	// Paths are the watched files, or glob patterns with wildcards in
+	// the file name only.
+	Paths []string
+	// Interval is the periodic re-hash period.
+	Interval time.Duration
+	// Debounce is the quiet period after a change notification before
+	// the changes are reported together.
+	Debounce time.Duration
+}
+
+// Watcher detects content changes of files, and the appearance of files.
+type Watcher interface {
+	// Watch hashes the files, then reports content changes until the
+	// context is cancelled. Files missing at start are watched from their
+	// first appearance; files appearing in or vanishing from a glob
+	// pattern are changes.
+	//
+	// Params:
+	//   - ctx: stops the watch when cancelled.
+	//   - target: the watched files.
+	//   - onChange: called with the files changed within one debounce
+	//     window, literal paths as given.
+	//
+	// Returns:
+	//   - error: if the watch cannot be set up, nil when ctx is cancelled.
+	Watch(ctx context.Context, target Target, onChange func(paths []string)) error
+
+	// AwaitFile returns once the file exists, such as the unix socket a
+	// service creates when it starts listening.
+	//
+	// Params:
+	//   - ctx: stops the wait when cancelled.
+	//   - path: the absolute file path.
+	//   - poll: how often the path is checked, on top of notifications.
+	//
+	// Returns:
+	//   - error: the context error when cancelled first, or the error of
+	//     the notification setup.
+	AwaitFile(ctx context.Context, path string, poll time.Duration) error
+}
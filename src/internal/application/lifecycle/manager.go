@@ -4,11 +4,15 @@ package lifecycle
 import (
 	"context"
 	"fmt"
+	"maps"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kodflow/daemon/internal/domain/config"
 	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
 )
 
 // Manager configuration constants.
@@ -28,19 +32,44 @@ type Manager struct {
 	mu       sync.RWMutex
 	config   *config.ServiceConfig
 	executor domain.Executor
-	tracker  *domain.RestartTracker
+	tracker  domain.RestartDecider
 	events   chan domain.Event
 	ctx      context.Context
 	cancel   context.CancelFunc
 	running  bool
+	// done is closed when the lifecycle goroutine exits.
+	done chan struct{}
+	// limiter holds restarts back across managers, nil when unbounded.
+	limiter RestartLimiter
+	// guard surrounds the runs of a oneshot service, nil when none.
+	guard JobGuard
+	// jobEnv is added to the environment of the current oneshot run.
+	jobEnv map[string]string
+	// clock times the restart backoff and the uptime.
+	clock shared.Clock
+	// sequence numbers the sent events, shared with other managers.
+	sequence *atomic.Uint64
+	// lastSeq is the sequence of the last event sent.
+	lastSeq uint64
 
 	// Current process state
 	pid       int
 	state     domain.State
 	exitCode  int
+	signal    int
 	startTime time.Time
 	restarts  int
 	waitCh    <-chan domain.ExitResult
+	spec      domain.Spec
+	launched  bool
+	// expired marks the current process as stopped at its max runtime.
+	expired bool
+	// reason explains the state, set while the service is skipped.
+	reason string
+	// backingOff marks the wait before a restart.
+	backingOff bool
+	// retry cuts the current restart backoff short.
+	retry chan struct{}
 }
 
 // NewManager creates a new process lifecycle manager.
@@ -59,9 +88,59 @@ func NewManager(cfg *config.ServiceConfig, executor domain.Executor) *Manager {
 		tracker:  domain.NewRestartTracker(&cfg.Restart),
 		events:   make(chan domain.Event, eventBufferSize),
 		state:    domain.StateStopped,
+		clock:    shared.DefaultClock,
+		retry:    make(chan struct{}, 1),
+		sequence: new(atomic.Uint64),
 	}
 }
 
+// SetClock replaces the clock timing the restart backoff and the uptime,
+// by default shared.DefaultClock. It must be called before Start.
+//
+// Params:
+//   - clock: the clock.
+func (m *Manager) SetClock(clock shared.Clock) {
+	m.clock = clock
+}
+
+// SetRestartDecider replaces the restart decisions of the manager, by
+// default the RestartTracker of the service restart settings. It must be
+// called before Start.
+//
+// Params:
+//   - decider: decides whether and when the process restarts.
+func (m *Manager) SetRestartDecider(decider domain.RestartDecider) {
+	m.tracker = decider
+}
+
+// SetEventSequence numbers the events of the manager from a counter shared
+// with other managers, so sequences keep growing when a manager replaces
+// another. It must be called before Start.
+//
+// Params:
+//   - sequence: the shared event counter.
+func (m *Manager) SetEventSequence(sequence *atomic.Uint64) {
+	m.sequence = sequence
+}
+
+// SetRestartLimiter bounds the restarts of the manager together with other
+// managers. It must be called before Start.
+//
+// Params:
+//   - limiter: the shared restart limiter, nil for unbounded restarts.
+func (m *Manager) SetRestartLimiter(limiter RestartLimiter) {
+	m.limiter = limiter
+}
+
+// SetJobGuard surrounds each run of a oneshot service with the guard. It
+// must be called before Start.
+//
+// Params:
+//   - guard: the job guard, nil for none.
+func (m *Manager) SetJobGuard(guard JobGuard) {
+	m.guard = guard
+}
+
 // Events returns the event channel for monitoring.
 //
 // Returns:
@@ -118,7 +197,20 @@ func (m *Manager) Uptime() int64 {
 		return 0
 	}
 	// Calculate and return uptime in seconds.
-	return int64(time.Since(m.startTime).Seconds())
+	return int64(m.clock.Now().Sub(m.startTime).Seconds())
+}
+
+// Supervised reports whether the lifecycle goroutine is active: the process
+// runs, starts, or waits for a restart.
+//
+// Returns:
+//   - bool: true between Start and Stop or the end of the lifecycle.
+func (m *Manager) Supervised() bool {
+	// lock for thread-safe read
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// Return the running flag under read lock.
+	return m.running
 }
 
 // Start starts the managed process with automatic restart handling.
@@ -145,7 +237,12 @@ func (m *Manager) Start(ctx context.Context) error {
 	}
 	// set running state
 	m.running = true
+	// A start clears the skip.
+	if m.state == domain.StateSkipped {
+		m.state, m.reason = domain.StateStopped, ""
+	}
 	m.ctx, m.cancel = context.WithCancel(ctx)
+	m.done = make(chan struct{})
 	m.mu.Unlock()
 
 	// start main lifecycle goroutine
@@ -154,13 +251,41 @@ func (m *Manager) Start(ctx context.Context) error {
 	return nil
 }
 
+// Skip leaves a stopped manager in StateSkipped, for a service whose start
+// conditions are unmet. The next Start clears the skip.
+//
+// Params:
+//   - reason: the unmet conditions, reported in Status.
+//
+// Returns:
+//   - bool: false if the manager is running and was left as is.
+func (m *Manager) Skip(reason string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Only a stopped manager can be skipped.
+	if m.running {
+		// Leave the running process alone.
+		return false
+	}
+	m.state = domain.StateSkipped
+	m.reason = reason
+	m.pid = 0
+	// Skip recorded.
+	return true
+}
+
 // run is the main loop that manages the process lifecycle.
 func (m *Manager) run() {
 	// ensure running flag is cleared on exit
 	defer func() {
 		m.mu.Lock()
 		m.running = false
+		done := m.done
 		m.mu.Unlock()
+		// release Stop callers waiting for the goroutine
+		if done != nil {
+			close(done)
+		}
 	}()
 
 	// Check if service is configured as oneshot.
@@ -175,8 +300,25 @@ func (m *Manager) run() {
 	m.runWithRestart()
 }
 
-// runOnce runs the process once without restart.
+// runOnce runs the process once without restart, within the job guard.
 func (m *Manager) runOnce() {
+	// Prepare the run with the job guard.
+	if m.guard != nil {
+		env, err := m.guard.BeforeJob(m.ctx, m.config.Name)
+		// The guard refused the run.
+		if err != nil {
+			// send failed event
+			m.sendEvent(domain.EventFailed, err)
+			// Return without starting.
+			return
+		}
+		m.jobEnv = env
+		defer func() {
+			m.jobEnv = nil
+			m.guard.AfterJob(m.ctx, m.config.Name)
+		}()
+	}
+
 	// Attempt to start the process.
 	if err := m.startProcess(); err != nil {
 		// send failed event
@@ -209,16 +351,37 @@ func (m *Manager) runOnce() {
 	case result = <-m.waitCh:
 	}
 
-	// Check if process exited with non-zero code.
-	if result.Code != 0 {
-		// send failed event with exit code
-		m.sendEvent(domain.EventFailed, fmt.Errorf("exit code %d: %w", result.Code, domain.ErrProcessFailed))
-		// Return after reporting failure.
+	// record exit code and signal for the exit event
+	m.updateStateAfterExit(result)
+	// A run stopped at its max runtime is complete.
+	if m.completeExpiredRun() {
+		// Return after reporting the completed run.
 		return
 	}
+	// send stopped or failed event
+	m.sendExitEvent(result)
+}
 
-	// send stopped event
+// completeExpiredRun marks a oneshot run stopped at its max runtime as
+// stopped, whatever its exit code.
+//
+// Returns:
+//   - bool: true if the run was stopped at its max runtime.
+func (m *Manager) completeExpiredRun() bool {
+	m.mu.Lock()
+	expired := m.expired
+	// The run ended on its own.
+	if !expired {
+		m.mu.Unlock()
+		// Report the exit as is.
+		return false
+	}
+	m.state = domain.StateStopped
+	m.mu.Unlock()
+	// send stopped event for the completed run
 	m.sendEvent(domain.EventStopped, nil)
+	// Return true when the run is complete.
+	return true
 }
 
 // runWithRestart runs the process with automatic restart based on policy.
@@ -236,6 +399,10 @@ func (m *Manager) runWithRestart() {
 			// Return when start fails and no restart.
 			return
 		}
+		// Start again at once when the failed start was followed by its backoff.
+		if m.State() != domain.StateRunning {
+			continue
+		}
 
 		// Wait for process exit or shutdown.
 		if m.waitForProcessOrShutdown() {
@@ -302,13 +469,45 @@ func (m *Manager) startProcess() error {
 	m.state = domain.StateStarting
 	m.mu.Unlock()
 
+	env, err := m.config.ProcessEnv(shared.DefaultFileSystem)
+	// an unreadable secret fails the start
+	if err != nil {
+		// update state to failed
+		m.mu.Lock()
+		m.state = domain.StateFailed
+		m.mu.Unlock()
+		// return read error
+		return err
+	}
+	// add the variables of the job guard, leaving the configuration untouched
+	if len(m.jobEnv) > 0 {
+		env = maps.Clone(env)
+		// create the map of a service without variables
+		if env == nil {
+			env = make(map[string]string, len(m.jobEnv))
+		}
+		maps.Copy(env, m.jobEnv)
+	}
+
 	spec := domain.NewSpec(domain.SpecParams{
 		Command: m.config.Command,
 		Args:    m.config.Args,
 		Dir:     m.config.WorkingDirectory,
-		Env:     m.config.Environment,
+		Env:     env,
 		User:    m.config.User,
 		Group:   m.config.Group,
+
+		SELinuxContext:  m.config.SELinuxContext,
+		AppArmorProfile: m.config.AppArmorProfile,
+
+		ReadOnlyPaths: m.config.ReadOnlyPaths,
+		MaskedPaths:   m.config.MaskedPaths,
+		TmpfsPaths:    m.config.TmpfsPaths,
+		PIDNamespace:  m.config.PIDNamespace,
+
+		Name:   m.config.Name,
+		Egress: m.config.Egress,
+		DNS:    m.config.DNS,
 	})
 
 	pid, wait, err := m.executor.Start(m.ctx, spec)
@@ -325,9 +524,13 @@ func (m *Manager) startProcess() error {
 	// update process state to running
 	m.mu.Lock()
 	m.pid = pid
+	m.signal = 0
 	m.waitCh = wait
-	m.startTime = time.Now()
+	m.startTime = m.clock.Now()
 	m.state = domain.StateRunning
+	m.spec = spec
+	m.launched = true
+	m.expired = false
 	m.mu.Unlock()
 
 	// Return nil on successful process start.
@@ -406,8 +609,9 @@ func (m *Manager) updateStateAfterExit(result domain.ExitResult) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// update exit code and clear PID
+	// update exit code and signal, clear PID
 	m.exitCode = result.Code
+	m.signal = result.Signal
 	m.pid = 0
 
 	// Check if process exited successfully.
@@ -444,7 +648,7 @@ func (m *Manager) calculateUptime() time.Duration {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	// Calculate uptime based on start time.
-	return time.Since(m.startTime)
+	return m.clock.Now().Sub(m.startTime)
 }
 
 // handleExhaustedRestarts checks if restarts are exhausted and emits event if needed.
@@ -507,6 +711,9 @@ func (m *Manager) shouldEmitExhaustedEvent(exitCode int) bool {
 // Returns:
 //   - bool: true if restart should proceed, false if cancelled.
 func (m *Manager) waitAndRestart() bool {
+	// Accept retries from the restart event on.
+	m.setBackingOff(true)
+	defer m.setBackingOff(false)
 	// record restart attempt
 	m.tracker.RecordAttempt()
 	// increment restart count
@@ -521,23 +728,77 @@ func (m *Manager) waitAndRestart() bool {
 
 	// Use NewTimer instead of time.After to allow proper cleanup.
 	// time.After creates a timer that won't be GC'd until it fires.
-	timer := time.NewTimer(delay)
+	timer := m.clock.NewTimer(delay)
 	defer timer.Stop()
 
-	// wait for either context cancellation or delay
+	// wait for either context cancellation, delay or retry
 	select {
 	// Handle context cancellation during delay.
 	case <-m.ctx.Done():
 		// Return false to cancel restart.
 		return false
 	// Wait for delay duration.
-	case <-timer.C:
+	case <-timer.C():
+	// Restart before the end of the delay.
+	case <-m.retry:
+	}
+
+	// Skip the budget when restarts are unbounded.
+	if m.limiter == nil {
 		// Return true to proceed with restart.
 		return true
 	}
+	// Proceed once the shared restart budget allows it.
+	return m.limiter.WaitRestart(m.ctx, m.config.Name) == nil
+}
+
+// setBackingOff marks the start or end of the wait before a restart. At the
+// end, a retry requested too late for the wait is dropped.
+//
+// Params:
+//   - on: true when the wait starts.
+func (m *Manager) setBackingOff(on bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backingOff = on
+	// Drop a retry the wait did not consume.
+	if !on {
+		select {
+		// drop the pending retry
+		case <-m.retry:
+		// no pending retry
+		default:
+		}
+	}
 }
 
-// Stop stops the managed process.
+// RetryNow ends the wait before a restart, so the process restarts at once
+// instead of at the end of its backoff delay. The restart still waits for
+// the restart limiter.
+//
+// Returns:
+//   - bool: false if the manager is not waiting to restart.
+func (m *Manager) RetryNow() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Only a backoff wait can be cut short.
+	if !m.backingOff {
+		// Nothing to retry.
+		return false
+	}
+	// Wake the wait, once.
+	select {
+	// signal the waiting restart
+	case m.retry <- struct{}{}:
+	// a retry is already pending
+	default:
+	}
+	// Retry requested.
+	return true
+}
+
+// Stop stops the managed process. It returns once the lifecycle goroutine
+// exited, so the manager can be started again right away.
 //
 // Returns:
 //   - error: nil on success, error from executor on failure.
@@ -551,6 +812,7 @@ func (m *Manager) Stop() error {
 		return nil
 	}
 	pid := m.pid
+	done := m.done
 	m.mu.Unlock()
 
 	// Cancel the context if set.
@@ -559,13 +821,18 @@ func (m *Manager) Stop() error {
 		m.cancel()
 	}
 
+	var err error
 	// Stop the process if PID is valid.
 	if pid > 0 {
 		// stop the process with timeout
-		return m.executor.Stop(pid, defaultStopTimeout)
+		err = m.executor.Stop(pid, defaultStopTimeout)
 	}
-	// return success when no process to stop
-	return nil
+	// wait for the lifecycle goroutine to observe the cancellation
+	if done != nil {
+		<-done
+	}
+	// return the executor stop result
+	return err
 }
 
 // Reload reloads the process (sends SIGHUP).
@@ -588,26 +855,71 @@ func (m *Manager) Reload() error {
 	return m.executor.Signal(pid, signalHUP)
 }
 
+// Signal sends a signal to the process.
+//
+// Params:
+//   - name: the signal name (SIGHUP, usr1).
+//
+// Returns:
+//   - error: config.ErrUnknownSignal, ErrNotRunning if no process, error from signal otherwise.
+func (m *Manager) Signal(name string) error {
+	posix, err := config.NormalizeSignal(name)
+	// signal not sendable
+	if err != nil {
+		// propagate unknown signal
+		return err
+	}
+	// lock for reading PID
+	m.mu.RLock()
+	pid := m.pid
+	m.mu.RUnlock()
+
+	// Check if process is not running.
+	if pid == 0 {
+		// Return error when not running.
+		return domain.ErrNotRunning
+	}
+
+	// Send the signal to the process.
+	return m.executor.Signal(pid, signalsByName[posix])
+}
+
 // sendEvent sends a lifecycle event.
 //
 // Params:
 //   - eventType: the type of lifecycle event.
 //   - err: optional error associated with the event.
 func (m *Manager) sendEvent(eventType domain.EventType, err error) {
-	// lock for reading state fields
-	m.mu.RLock()
+	// lock for state fields and the sequence of sent events
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	event := domain.NewEvent(eventType, m.config.Name, m.pid, m.exitCode, err)
-	m.mu.RUnlock()
+	event.Signal = m.signal
+	event.Seq = m.sequence.Add(1)
 
 	// attempt non-blocking send to events channel
 	select {
 	// Attempt to send event to channel.
 	case m.events <- event:
+		m.lastSeq = event.Seq
 	// Drop event if channel is full.
 	default:
 	}
 }
 
+// EventSeq returns the sequence of the last event sent by the manager.
+// Once the supervisor applied that event, it reflects every change the
+// manager reported so far.
+//
+// Returns:
+//   - uint64: the sequence, zero before the first event.
+func (m *Manager) EventSeq() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// return last sent sequence
+	return m.lastSeq
+}
+
 // Status returns the current status of the process.
 //
 // Returns:
@@ -622,12 +934,90 @@ func (m *Manager) Status() domain.Status {
 		Name:     m.config.Name,
 		State:    m.state,
 		PID:      m.pid,
-		Uptime:   time.Since(m.startTime),
+		Uptime:   m.clock.Now().Sub(m.startTime),
 		Restarts: m.restarts,
 		ExitCode: m.exitCode,
+		Reason:   m.reason,
 	}
 }
 
+// LaunchedSpec returns the specification of the last launched process.
+//
+// Returns:
+//   - domain.ResolvedSpec: the launched spec with redacted environment.
+//   - bool: false if no process has been launched yet.
+func (m *Manager) LaunchedSpec() (domain.ResolvedSpec, bool) {
+	// lock for thread-safe read
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Check if a process was ever launched.
+	if !m.launched {
+		// Return empty spec.
+		return domain.ResolvedSpec{}, false
+	}
+	resolved := domain.NewResolvedSpec(m.config.Name, m.pid, m.startTime, &m.spec, m.config.RedactedEnv()...)
+	resolved.Listeners = m.config.Listeners
+	// Return resolved spec.
+	return resolved, true
+}
+
+// AbortStart kills a process that did not become ready within its start timeout.
+// The kill is reported as a failure, so the restart policy decides what happens next.
+//
+// Params:
+//   - pid: the process ID the deadline was armed for.
+//
+// Returns:
+//   - error: ErrNotRunning if the process already exited or was replaced, error from executor on kill failure.
+func (m *Manager) AbortStart(pid int) error {
+	// lock for reading state
+	m.mu.RLock()
+	current := m.pid
+	running := m.running
+	m.mu.RUnlock()
+
+	// Check if the deadline still targets the live process.
+	if !running || pid == 0 || current != pid {
+		// Return error when the process is gone.
+		return domain.ErrNotRunning
+	}
+
+	// Send start timeout event before killing the process.
+	m.sendEvent(domain.EventStartTimeout, fmt.Errorf("not ready after %s: %w", m.config.StartTimeout.Duration(), domain.ErrStartTimeout))
+
+	// Kill the hung process; restart loop will handle restart based on policy.
+	return m.executor.Signal(pid, os.Kill)
+}
+
+// ExpireRuntime stops a process that ran for the max runtime of its service.
+// The restart policy decides what happens next, and a oneshot run is
+// reported as stopped.
+//
+// Params:
+//   - pid: the process ID the limit was armed for.
+//
+// Returns:
+//   - error: ErrNotRunning if the process already exited or was replaced, error from executor on stop failure.
+func (m *Manager) ExpireRuntime(pid int) error {
+	// lock for reading and marking state
+	m.mu.Lock()
+	// Check if the limit still targets the live process.
+	if !m.running || pid == 0 || m.pid != pid {
+		m.mu.Unlock()
+		// Return error when the process is gone.
+		return domain.ErrNotRunning
+	}
+	m.expired = true
+	m.mu.Unlock()
+
+	// Send max runtime event before stopping the process.
+	m.sendEvent(domain.EventRuntimeExceeded, fmt.Errorf("ran for %s: %w", m.config.MaxRuntime.Duration(), domain.ErrMaxRuntime))
+
+	// Stop the process; the lifecycle loop handles the exit.
+	return m.executor.Stop(pid, defaultStopTimeout)
+}
+
 // RestartOnHealthFailure triggers a process restart due to health probe failure.
 // This implements the Kubernetes liveness probe pattern: when health probes
 // fail consecutively beyond the failure threshold, the process is killed
@@ -5,6 +5,10 @@ package lifecycle_test
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -240,6 +244,47 @@ func TestManager_Uptime(t *testing.T) {
 	}
 }
 
+// TestManager_Supervised tests the Supervised method.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_Supervised(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// start indicates whether to start the manager.
+		start bool
+		// stop indicates whether to stop the manager after starting it.
+		stop bool
+		// expected is the expected Supervised result.
+		expected bool
+	}{
+		{name: "not_started", expected: false},
+		{name: "started", start: true, expected: true},
+		{name: "stopped", start: true, stop: true, expected: false},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := lifecycle.NewManager(createTestConfig("test-service", "/bin/echo"), &mockExecutor{})
+			defer func() { _ = mgr.Stop() }()
+
+			// Start the manager if requested.
+			if tt.start {
+				require.NoError(t, mgr.Start(context.Background()))
+			}
+			// Stop the manager if requested.
+			if tt.stop {
+				require.NoError(t, mgr.Stop())
+			}
+
+			assert.Equal(t, tt.expected, mgr.Supervised())
+		})
+	}
+}
+
 // TestManager_Events tests the Events method.
 //
 // Params:
@@ -364,6 +409,42 @@ func TestManager_Stop(t *testing.T) {
 	}
 }
 
+// TestManager_Stop_restart tests that a stopped manager can be started right away.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_Stop_restart(t *testing.T) {
+	cfg := createTestConfig("test-service", "/bin/echo")
+	mgr := lifecycle.NewManager(cfg, &mockExecutor{})
+
+	// Each stop returns once the manager is no longer running.
+	for range 3 {
+		require.NoError(t, mgr.Start(context.Background()))
+		require.NoError(t, mgr.Stop())
+	}
+}
+
+// TestManager_Skip tests that a stopped manager is skipped until its next start.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_Skip(t *testing.T) {
+	cfg := createTestConfig("test-service", "/bin/echo")
+	mgr := lifecycle.NewManager(cfg, &mockExecutor{})
+
+	require.True(t, mgr.Skip("path /dev/kvm does not exist"))
+	status := mgr.Status()
+	assert.Equal(t, domain.StateSkipped, status.State)
+	assert.Equal(t, "path /dev/kvm does not exist", status.Reason)
+
+	// A start clears the skip; a running manager is not skipped.
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+	assert.False(t, mgr.Skip("later"))
+	assert.Empty(t, mgr.Status().Reason)
+	assert.NotEqual(t, domain.StateSkipped, mgr.State())
+}
+
 // TestManager_Reload tests the Reload method.
 //
 // Params:
@@ -404,6 +485,59 @@ func TestManager_Reload(t *testing.T) {
 	}
 }
 
+// TestManager_Signal tests the Signal method.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_Signal(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// signal is the signal name.
+		signal string
+		// start indicates whether the process is started first.
+		start bool
+		// expected is the signal delivered to the process.
+		expected os.Signal
+		// errTarget is the expected error.
+		errTarget error
+	}{
+		{name: "sends_normalized_signal", signal: "usr1", start: true, expected: syscall.SIGUSR1},
+		{name: "unknown_signal", signal: "SIGFOO", start: true, errTarget: config.ErrUnknownSignal},
+		{name: "not_running", signal: "SIGHUP", errTarget: domain.ErrNotRunning},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make(chan os.Signal, 1)
+			executor := &mockExecutor{signalFunc: func(_ int, sig os.Signal) error {
+				sent <- sig
+				return nil
+			}}
+			mgr := lifecycle.NewManager(createTestConfig("test-service", "/bin/sleep"), executor)
+			// Start the process when required.
+			if tt.start {
+				require.NoError(t, mgr.Start(context.Background()))
+				require.Eventually(t, func() bool { return mgr.PID() != 0 }, time.Second, time.Millisecond)
+				defer func() { _ = mgr.Stop() }()
+			}
+
+			err := mgr.Signal(tt.signal)
+
+			// Check if error is expected.
+			if tt.errTarget != nil {
+				assert.ErrorIs(t, err, tt.errTarget)
+				assert.Empty(t, sent)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, <-sent)
+		})
+	}
+}
+
 // TestManager_Status tests the Status method.
 //
 // Params:
@@ -541,3 +675,336 @@ func TestManager_RestartOnHealthFailure(t *testing.T) {
 		})
 	}
 }
+
+// TestManager_LaunchedSpec tests the LaunchedSpec method.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_LaunchedSpec(t *testing.T) {
+	cfg := createTestConfig("test-service", "/bin/echo")
+	cfg.Args = []string{"hello"}
+	cfg.Environment = map[string]string{"DB_PASSWORD": "hunter2", "MODE": "prod"}
+	cfg.Listeners = []config.ListenerConfig{{Name: "http", Port: 8080}}
+	mgr := lifecycle.NewManager(cfg, &mockExecutor{})
+
+	_, ok := mgr.LaunchedSpec()
+	assert.False(t, ok)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+
+	// Wait for the process to be launched.
+	require.Eventually(t, func() bool {
+		_, ok := mgr.LaunchedSpec()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	spec, _ := mgr.LaunchedSpec()
+	assert.Equal(t, "test-service", spec.Service)
+	assert.Equal(t, 1234, spec.PID)
+	assert.Equal(t, "/bin/echo", spec.Command)
+	assert.Equal(t, []string{"hello"}, spec.Args)
+	assert.Equal(t, domain.RedactedValue, spec.Env["DB_PASSWORD"])
+	assert.Equal(t, "prod", spec.Env["MODE"])
+	assert.Len(t, spec.Listeners, 1)
+}
+
+// TestManager_secrets tests the secret files are read into the environment
+// at each start, and redacted from the launched spec.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_secrets(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "upstream")
+	require.NoError(t, os.WriteFile(file, []byte("s3cr3t\n"), 0o600))
+	cfg := createTestConfig("test-service", "/bin/echo")
+	cfg.Environment = map[string]string{"MODE": "prod"}
+	cfg.Secrets = []config.SecretConfig{{Env: "UPSTREAM", File: file}}
+	envs := make(chan map[string]string, 1)
+	mgr := lifecycle.NewManager(cfg, &mockExecutor{
+		startFunc: func(_ context.Context, spec domain.Spec) (int, <-chan domain.ExitResult, error) {
+			envs <- spec.Env
+			return 1234, make(chan domain.ExitResult, 1), nil
+		},
+	})
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+
+	env := <-envs
+	assert.Equal(t, map[string]string{"MODE": "prod", "UPSTREAM": "s3cr3t"}, env)
+	assert.Equal(t, map[string]string{"MODE": "prod"}, cfg.Environment)
+	require.Eventually(t, func() bool {
+		_, ok := mgr.LaunchedSpec()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	spec, _ := mgr.LaunchedSpec()
+	assert.Equal(t, domain.RedactedValue, spec.Env["UPSTREAM"])
+	assert.Equal(t, "prod", spec.Env["MODE"])
+}
+
+// TestManager_secrets_unreadable tests a missing secret file fails the start.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_secrets_unreadable(t *testing.T) {
+	cfg := createTestConfig("test-service", "/bin/echo")
+	cfg.Restart.Policy = config.RestartNever
+	cfg.Secrets = []config.SecretConfig{{Env: "UPSTREAM", File: filepath.Join(t.TempDir(), "missing")}}
+	var starts atomic.Int32
+	mgr := lifecycle.NewManager(cfg, &mockExecutor{
+		startFunc: func(context.Context, domain.Spec) (int, <-chan domain.ExitResult, error) {
+			starts.Add(1)
+			return 1234, make(chan domain.ExitResult, 1), nil
+		},
+	})
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+
+	require.Eventually(t, func() bool {
+		return mgr.State() == domain.StateFailed
+	}, time.Second, 10*time.Millisecond)
+	assert.Zero(t, starts.Load())
+}
+
+// TestManager_AbortStart tests the AbortStart method.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_AbortStart(t *testing.T) {
+	var killed []int
+	executor := &mockExecutor{
+		startFunc: func(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+			return 1234, make(chan domain.ExitResult), nil
+		},
+		signalFunc: func(pid int, sig os.Signal) error {
+			assert.Equal(t, os.Kill, sig)
+			killed = append(killed, pid)
+			return nil
+		},
+	}
+	mgr := lifecycle.NewManager(createTestConfig("test-service", "/bin/echo"), executor)
+
+	// Nothing to abort before start.
+	require.ErrorIs(t, mgr.AbortStart(1234), domain.ErrNotRunning)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+	require.Eventually(t, func() bool { return mgr.PID() == 1234 }, time.Second, 10*time.Millisecond)
+
+	// A deadline armed for a previous process is ignored.
+	require.ErrorIs(t, mgr.AbortStart(999), domain.ErrNotRunning)
+
+	require.NoError(t, mgr.AbortStart(1234))
+	assert.Equal(t, []int{1234}, killed)
+}
+
+// TestManager_ExpireRuntime tests that a oneshot run stopped at its max runtime is complete.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_ExpireRuntime(t *testing.T) {
+	exits := make(chan domain.ExitResult, 1)
+	executor := &mockExecutor{
+		startFunc: func(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+			return 1234, exits, nil
+		},
+		stopFunc: func(_ int, _ time.Duration) error {
+			// The process dies of the termination signal.
+			exits <- domain.ExitResult{Code: -1, Signal: 15}
+			return nil
+		},
+	}
+	cfg := createTestConfig("backup", "/bin/backup")
+	cfg.Oneshot = true
+	mgr := lifecycle.NewManager(cfg, executor)
+
+	// Nothing to stop before start.
+	require.ErrorIs(t, mgr.ExpireRuntime(1234), domain.ErrNotRunning)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+	require.Eventually(t, func() bool { return mgr.PID() == 1234 }, time.Second, 10*time.Millisecond)
+
+	// A limit armed for a previous process is ignored.
+	require.ErrorIs(t, mgr.ExpireRuntime(999), domain.ErrNotRunning)
+
+	require.NoError(t, mgr.ExpireRuntime(1234))
+
+	var types []domain.EventType
+	var exceeded domain.Event
+	require.Eventually(t, func() bool {
+		select {
+		case event := <-mgr.Events():
+			types = append(types, event.Type)
+			if event.Type == domain.EventRuntimeExceeded {
+				exceeded = event
+			}
+		default:
+		}
+		return len(types) > 0 && types[len(types)-1] == domain.EventStopped
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []domain.EventType{domain.EventStarted, domain.EventRuntimeExceeded, domain.EventStopped}, types)
+	assert.ErrorIs(t, exceeded.Error, domain.ErrMaxRuntime)
+	assert.Equal(t, domain.StateStopped, mgr.Status().State)
+}
+
+// gateLimiter holds each restart until released.
+type gateLimiter struct {
+	// waits receives the name of each restart waiting.
+	waits chan string
+	// release lets one waiting restart proceed.
+	release chan struct{}
+}
+
+// WaitRestart blocks until the restart is released or ctx is cancelled.
+//
+// Params:
+//   - ctx: abandons the wait when cancelled.
+//   - name: the restarting service.
+//
+// Returns:
+//   - error: the context error when abandoned.
+func (g *gateLimiter) WaitRestart(ctx context.Context, name string) error {
+	g.waits <- name
+	select {
+	case <-g.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TestManager_SetRestartLimiter tests that restarts wait for the restart limiter.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_SetRestartLimiter(t *testing.T) {
+	var starts atomic.Int32
+	executor := &mockExecutor{
+		startFunc: func(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+			starts.Add(1)
+			exits := make(chan domain.ExitResult, 1)
+			// The process crashes at once.
+			exits <- domain.ExitResult{Code: 1}
+			return 1234, exits, nil
+		},
+	}
+	cfg := createTestConfig("worker", "/bin/worker")
+	cfg.Restart.Delay = shared.Duration(time.Millisecond)
+	limiter := &gateLimiter{waits: make(chan string, 4), release: make(chan struct{})}
+	mgr := lifecycle.NewManager(cfg, executor)
+	mgr.SetRestartLimiter(limiter)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+
+	// The restart waits for the limiter.
+	assert.Equal(t, "worker", <-limiter.waits)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), starts.Load())
+
+	limiter.release <- struct{}{}
+	assert.Equal(t, "worker", <-limiter.waits)
+	assert.Equal(t, int32(2), starts.Load())
+}
+
+// scriptedDecider restarts a fixed number of times without delay.
+type scriptedDecider struct {
+	// mu protects the fields.
+	mu sync.Mutex
+	// restarts is the number of restarts allowed.
+	restarts int
+	// attempts counts the recorded attempts.
+	attempts int
+	// exitCodes records the exit codes asked about.
+	exitCodes []int
+}
+
+// ShouldRestart allows restarts until the script is spent.
+//
+// Params:
+//   - exitCode: the process exit code.
+//
+// Returns:
+//   - bool: true while restarts are left.
+func (d *scriptedDecider) ShouldRestart(exitCode int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.exitCodes = append(d.exitCodes, exitCode)
+	return d.attempts < d.restarts
+}
+
+// RecordAttempt counts an attempt.
+func (d *scriptedDecider) RecordAttempt() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.attempts++
+}
+
+// MaybeReset never resets.
+func (d *scriptedDecider) MaybeReset(time.Duration) {}
+
+// NextDelay restarts at once.
+//
+// Returns:
+//   - time.Duration: always zero.
+func (d *scriptedDecider) NextDelay() time.Duration { return 0 }
+
+// IsExhausted reports whether the script is spent.
+//
+// Returns:
+//   - bool: true once every restart ran.
+func (d *scriptedDecider) IsExhausted() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.attempts >= d.restarts
+}
+
+// Attempts returns the recorded attempts.
+//
+// Returns:
+//   - int: the attempts.
+func (d *scriptedDecider) Attempts() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.attempts
+}
+
+// recorded returns the exit codes asked about.
+//
+// Returns:
+//   - []int: the exit codes.
+func (d *scriptedDecider) recorded() []int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]int(nil), d.exitCodes...)
+}
+
+// TestManager_SetRestartDecider tests that restarts follow the restart decider.
+//
+// Params:
+//   - t: the testing context.
+func TestManager_SetRestartDecider(t *testing.T) {
+	var starts atomic.Int32
+	executor := &mockExecutor{
+		startFunc: func(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+			exits := make(chan domain.ExitResult, 1)
+			// Each process crashes at once with its start number.
+			exits <- domain.ExitResult{Code: int(starts.Add(1))}
+			return 1234, exits, nil
+		},
+	}
+	mgr := lifecycle.NewManager(createTestConfig("worker", "/bin/worker"), executor)
+	decider := &scriptedDecider{restarts: 2}
+	mgr.SetRestartDecider(decider)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+
+	require.Eventually(t, func() bool { return !mgr.Supervised() }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(3), starts.Load())
+	assert.Equal(t, []int{1, 2, 3}, decider.recorded())
+}
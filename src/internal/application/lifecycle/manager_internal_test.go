@@ -5,6 +5,7 @@ package lifecycle
 import (
 	"context"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -331,9 +332,8 @@ func Test_Manager_handleProcessExit(t *testing.T) {
 
 // Test_Manager_waitAndRestart tests the waitAndRestart method.
 //
-// This test spawns a goroutine in the cancel_during_wait case to simulate
-// context cancellation during the restart delay. The goroutine terminates
-// after calling mgr.cancel() and has no resources to release.
+// The restart delay runs on a fake clock: the test either advances it past
+// the delay or cancels the context once the manager waits on its timer.
 //
 // Params:
 //   - t: the testing context.
@@ -343,6 +343,8 @@ func Test_Manager_waitAndRestart(t *testing.T) {
 		name string
 		// cancelDuringWait indicates whether to cancel during wait.
 		cancelDuringWait bool
+		// retryDuringWait indicates whether to cut the wait short.
+		retryDuringWait bool
 		// initialRestarts sets the initial restart count.
 		initialRestarts int
 		// expected is the expected result.
@@ -364,6 +366,13 @@ func Test_Manager_waitAndRestart(t *testing.T) {
 			expected:         false,
 			expectedRestarts: 1, // restarts is incremented before waiting
 		},
+		{
+			name:             "restarts_before_delay_on_retry",
+			retryDuringWait:  true,
+			initialRestarts:  0,
+			expected:         true,
+			expectedRestarts: 1,
+		},
 		{
 			name:             "increments_restart_count_on_success",
 			cancelDuringWait: false,
@@ -378,25 +387,41 @@ func Test_Manager_waitAndRestart(t *testing.T) {
 		// Run each test case as a subtest.
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := createInternalTestConfig("test-service", "/bin/echo")
-			cfg.Restart.Delay = shared.FromTimeDuration(10 * time.Millisecond)
+			cfg.Restart.Delay = shared.Seconds(30)
 			executor := &testExecutor{}
+			clock := shared.NewFakeClock(time.Now())
 
 			mgr := NewManager(cfg, executor)
+			mgr.SetClock(clock)
 			mgr.ctx, mgr.cancel = context.WithCancel(context.Background())
 			mgr.restarts = tt.initialRestarts
 
-			// Cancel context during wait if requested.
-			// The goroutine terminates after calling cancel().
+			result := make(chan bool, 1)
+			go func() { result <- mgr.waitAndRestart() }()
+
+			// Act once the manager waits on its restart timer.
+			clock.BlockUntil(1)
+			assert.Eventually(t, func() bool {
+				mgr.mu.RLock()
+				defer mgr.mu.RUnlock()
+				return mgr.backingOff
+			}, time.Second, time.Millisecond)
 			if tt.cancelDuringWait {
-				go func() {
-					time.Sleep(5 * time.Millisecond)
-					mgr.cancel()
-				}()
+				mgr.cancel()
+			} else if tt.retryDuringWait {
+				assert.True(t, mgr.RetryNow())
+			} else {
+				// Go past the backoff, whatever the attempt.
+				clock.Advance(time.Hour)
 			}
 
-			result := mgr.waitAndRestart()
-			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expected, <-result)
+			mgr.mu.RLock()
 			assert.Equal(t, tt.expectedRestarts, mgr.restarts)
+			mgr.mu.RUnlock()
+			// A retry outside a wait is refused and not kept for the next one.
+			assert.False(t, mgr.RetryNow())
+			assert.Empty(t, mgr.retry)
 		})
 	}
 }
@@ -509,24 +534,29 @@ func Test_Manager_runOnce(t *testing.T) {
 		exitCode int
 		// expectedEventType is the expected final event type.
 		expectedEventType domain.EventType
+		// expectedState is the expected state after the run.
+		expectedState domain.State
 	}{
 		{
 			name:              "sends_stopped_on_success",
 			startError:        nil,
 			exitCode:          0,
 			expectedEventType: domain.EventStopped,
+			expectedState:     domain.StateStopped,
 		},
 		{
 			name:              "sends_failed_on_start_error",
 			startError:        shared.ErrEmptyCommand,
 			exitCode:          0,
 			expectedEventType: domain.EventFailed,
+			expectedState:     domain.StateFailed,
 		},
 		{
 			name:              "sends_failed_on_nonzero_exit",
 			startError:        nil,
 			exitCode:          1,
 			expectedEventType: domain.EventFailed,
+			expectedState:     domain.StateFailed,
 		},
 	}
 
@@ -574,6 +604,96 @@ func Test_Manager_runOnce(t *testing.T) {
 			}
 			require.True(t, eventFound, "expected at least one event")
 			assert.Equal(t, tt.expectedEventType, lastEvent.Type)
+			assert.Equal(t, tt.exitCode, lastEvent.ExitCode)
+			assert.Zero(t, lastEvent.PID)
+			assert.Equal(t, tt.expectedState, mgr.State())
+		})
+	}
+}
+
+// testJobGuard records the runs it surrounds.
+type testJobGuard struct {
+	// err refuses the runs when set.
+	err error
+	// calls lists "before" and "after" in call order.
+	calls []string
+}
+
+// BeforeJob records the call.
+//
+// Params:
+//   - ctx: the lifecycle context (unused).
+//   - name: the oneshot service (unused).
+//
+// Returns:
+//   - map[string]string: the variable of the run.
+//   - error: the configured error.
+func (g *testJobGuard) BeforeJob(_ context.Context, _ string) (map[string]string, error) {
+	g.calls = append(g.calls, "before")
+	// Return the variable of the run.
+	return map[string]string{"SUPERVIZIO_STATE_DIR": "/var/lib/db"}, g.err
+}
+
+// AfterJob records the call.
+//
+// Params:
+//   - ctx: the lifecycle context (unused).
+//   - name: the oneshot service (unused).
+func (g *testJobGuard) AfterJob(_ context.Context, _ string) {
+	g.calls = append(g.calls, "after")
+}
+
+// Test_Manager_runOnce_guard tests that the job guard surrounds a run and
+// can refuse it.
+//
+// Params:
+//   - t: the testing context.
+func Test_Manager_runOnce_guard(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// guardErr is the error of the guard.
+		guardErr error
+		// wantCalls is the expected guard calls.
+		wantCalls []string
+		// wantStarted indicates whether the process is expected to start.
+		wantStarted bool
+	}{
+		{name: "surrounds_run", wantCalls: []string{"before", "after"}, wantStarted: true},
+		{name: "refuses_run", guardErr: assert.AnError, wantCalls: []string{"before"}},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createInternalTestConfig("backup", "/bin/backup")
+			cfg.Oneshot = true
+			cfg.Environment = map[string]string{"MODE": "full"}
+			exitCh := make(chan domain.ExitResult, 1)
+			exitCh <- domain.ExitResult{Code: 0}
+			var started *domain.Spec
+			executor := &testExecutor{
+				startFunc: func(_ context.Context, spec domain.Spec) (int, <-chan domain.ExitResult, error) {
+					started = &spec
+					// Return success with exit channel.
+					return 1234, exitCh, nil
+				},
+			}
+			guard := &testJobGuard{err: tt.guardErr}
+			mgr := NewManager(cfg, executor)
+			mgr.SetJobGuard(guard)
+			mgr.ctx, mgr.cancel = context.WithCancel(context.Background())
+
+			mgr.runOnce()
+
+			assert.Equal(t, tt.wantCalls, guard.calls)
+			assert.Equal(t, tt.wantStarted, started != nil)
+			assert.Equal(t, map[string]string{"MODE": "full"}, cfg.Environment)
+			// Check the variable of the run.
+			if started != nil {
+				assert.Equal(t, map[string]string{"MODE": "full", "SUPERVIZIO_STATE_DIR": "/var/lib/db"}, started.Env)
+			}
 		})
 	}
 }
@@ -592,6 +712,8 @@ func Test_Manager_runWithRestart(t *testing.T) {
 		startError error
 		// exitCode is the exit code of the process.
 		exitCode int
+		// maxRetries enables restarts on failure when positive.
+		maxRetries int
 		// expectedRestarts is the expected number of restarts.
 		expectedRestarts int
 	}{
@@ -616,6 +738,12 @@ func Test_Manager_runWithRestart(t *testing.T) {
 			exitCode:          0,
 			expectedRestarts:  0,
 		},
+		{
+			name:             "retries_failed_starts_until_exhausted",
+			startError:       shared.ErrEmptyCommand,
+			maxRetries:       2,
+			expectedRestarts: 2,
+		},
 	}
 
 	// Iterate through all test cases.
@@ -624,6 +752,12 @@ func Test_Manager_runWithRestart(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := createInternalTestConfig("test-service", "/bin/echo")
 			cfg.Restart.Policy = config.RestartNever
+			// Restart failures when retries are allowed.
+			if tt.maxRetries > 0 {
+				cfg.Restart.Policy = config.RestartOnFailure
+				cfg.Restart.MaxRetries = tt.maxRetries
+				cfg.Restart.Delay = shared.FromTimeDuration(time.Millisecond)
+			}
 			exitCh := make(chan domain.ExitResult, 1)
 			exitCh <- domain.ExitResult{Code: tt.exitCode}
 
@@ -1266,6 +1400,35 @@ func Test_Manager_sendEvent_channel_full(t *testing.T) {
 	}
 }
 
+// Test_Manager_EventSeq tests event numbering from a shared counter.
+//
+// Params:
+//   - t: the testing context.
+func Test_Manager_EventSeq(t *testing.T) {
+	counter := new(atomic.Uint64)
+	first := NewManager(createInternalTestConfig("first", "/bin/echo"), &testExecutor{})
+	second := NewManager(createInternalTestConfig("second", "/bin/echo"), &testExecutor{})
+	first.SetEventSequence(counter)
+	second.SetEventSequence(counter)
+	assert.Zero(t, first.EventSeq())
+
+	first.sendEvent(domain.EventStarted, nil)
+	second.sendEvent(domain.EventStarted, nil)
+	first.sendEvent(domain.EventStopped, nil)
+
+	assert.Equal(t, uint64(3), first.EventSeq())
+	assert.Equal(t, uint64(2), second.EventSeq())
+	assert.Equal(t, uint64(1), (<-first.events).Seq)
+	assert.Equal(t, uint64(3), (<-first.events).Seq)
+
+	// Dropped events are not waited for.
+	for len(second.events) < eventBufferSize {
+		second.events <- domain.Event{}
+	}
+	second.sendEvent(domain.EventStopped, nil)
+	assert.Equal(t, uint64(2), second.EventSeq())
+}
+
 // Test_Manager_updateStateAfterExit tests the updateStateAfterExit method.
 //
 // Params:
@@ -1276,6 +1439,8 @@ func Test_Manager_updateStateAfterExit(t *testing.T) {
 		name string
 		// exitCode is the exit code to test.
 		exitCode int
+		// signal is the terminating signal to test.
+		signal int
 		// initialPID is the initial process PID.
 		initialPID int
 		// expectedState is the expected state after exit.
@@ -1304,6 +1469,14 @@ func Test_Manager_updateStateAfterExit(t *testing.T) {
 			expectedState: domain.StateFailed,
 			expectedPID:   0,
 		},
+		{
+			name:          "killed_by_signal_records_signal",
+			exitCode:      -1,
+			signal:        9,
+			initialPID:    5678,
+			expectedState: domain.StateFailed,
+			expectedPID:   0,
+		},
 	}
 
 	// Iterate through all test cases.
@@ -1316,12 +1489,13 @@ func Test_Manager_updateStateAfterExit(t *testing.T) {
 			mgr := NewManager(cfg, executor)
 			mgr.pid = tt.initialPID
 
-			result := domain.ExitResult{Code: tt.exitCode}
+			result := domain.ExitResult{Code: tt.exitCode, Signal: tt.signal}
 			mgr.updateStateAfterExit(result)
 
 			assert.Equal(t, tt.expectedState, mgr.state)
 			assert.Equal(t, tt.expectedPID, mgr.pid)
 			assert.Equal(t, tt.exitCode, mgr.exitCode)
+			assert.Equal(t, tt.signal, mgr.signal)
 		})
 	}
 }
@@ -1387,20 +1561,16 @@ func Test_Manager_calculateUptime(t *testing.T) {
 	tests := []struct {
 		// name is the test case name.
 		name string
-		// startTime is the process start time.
-		startTime time.Time
-		// minExpectedUptime is the minimum expected uptime.
-		minExpectedUptime time.Duration
+		// elapsed is the time elapsed since the process start.
+		elapsed time.Duration
 	}{
 		{
-			name:              "calculates_uptime_for_running_process",
-			startTime:         time.Now().Add(-5 * time.Second),
-			minExpectedUptime: 4 * time.Second,
+			name:    "calculates_uptime_for_running_process",
+			elapsed: 5 * time.Second,
 		},
 		{
-			name:              "calculates_uptime_for_recent_start",
-			startTime:         time.Now().Add(-100 * time.Millisecond),
-			minExpectedUptime: 50 * time.Millisecond,
+			name:    "calculates_uptime_for_recent_start",
+			elapsed: 100 * time.Millisecond,
 		},
 	}
 
@@ -1411,12 +1581,16 @@ func Test_Manager_calculateUptime(t *testing.T) {
 			cfg := createInternalTestConfig("test-service", "/bin/echo")
 			executor := &testExecutor{}
 
+			clock := shared.NewFakeClock(time.Now())
+
 			mgr := NewManager(cfg, executor)
-			mgr.startTime = tt.startTime
+			mgr.SetClock(clock)
+			mgr.startTime = clock.Now()
+			clock.Advance(tt.elapsed)
 
 			uptime := mgr.calculateUptime()
 
-			assert.GreaterOrEqual(t, uptime, tt.minExpectedUptime)
+			assert.Equal(t, tt.elapsed, uptime)
 		})
 	}
 }
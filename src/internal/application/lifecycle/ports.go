@@ -0,0 +1,38 @@
+// Package lifecycle provides the application service for managing process lifecycle.
+package lifecycle
+
+import "context"
+
+// RestartLimiter bounds the restarts performed across several managers.
+type RestartLimiter interface {
+	// WaitRestart blocks until the service may restart.
+	//
+	// Params:
+	//   - ctx: abandons the wait when cancelled.
+	//   - name: the restarting service.
+	//
+	// Returns:
+	//   - error: the context error when the wait was abandoned.
+	WaitRestart(ctx context.Context, name string) error
+}
+
+// JobGuard surrounds each run of a oneshot service, such as a backup
+// quiescing the service it operates on.
+type JobGuard interface {
+	// BeforeJob prepares a run before its process starts.
+	//
+	// Params:
+	//   - ctx: the lifecycle context of the job.
+	//   - name: the oneshot service.
+	//
+	// Returns:
+	//   - map[string]string: variables added to the environment of the run.
+	//   - error: why the run must not start; the run then fails.
+	BeforeJob(ctx context.Context, name string) (map[string]string, error)
+	// AfterJob ends a run prepared by BeforeJob, once its process exited.
+	//
+	// Params:
+	//   - ctx: the lifecycle context of the job, cancelled on shutdown.
+	//   - name: the oneshot service.
+	AfterJob(ctx context.Context, name string)
+}
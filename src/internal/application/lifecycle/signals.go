@@ -8,3 +8,18 @@ import (
 
 // signalHUP is the SIGHUP signal for reload operations.
 var signalHUP os.Signal = syscall.SIGHUP
+
+// signalsByName maps the POSIX names accepted by config.NormalizeSignal
+// to the signals of the platform.
+var signalsByName map[string]os.Signal = map[string]os.Signal{
+	"SIGHUP":   syscall.SIGHUP,
+	"SIGINT":   syscall.SIGINT,
+	"SIGQUIT":  syscall.SIGQUIT,
+	"SIGKILL":  syscall.SIGKILL,
+	"SIGUSR1":  syscall.SIGUSR1,
+	"SIGUSR2":  syscall.SIGUSR2,
+	"SIGTERM":  syscall.SIGTERM,
+	"SIGCONT":  syscall.SIGCONT,
+	"SIGSTOP":  syscall.SIGSTOP,
+	"SIGWINCH": syscall.SIGWINCH,
+}
@@ -3,7 +3,9 @@ package metrics
 
 import (
 	"context"
+	"time"
 
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
 	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
 )
 
@@ -22,6 +24,8 @@ type ProcessTracker interface {
 	Subscribe() <-chan domainmetrics.ProcessMetrics
 	// Unsubscribe removes a subscription channel.
 	Unsubscribe(ch <-chan domainmetrics.ProcessMetrics)
+	// CollectDuration returns how long the last collection of all processes took.
+	CollectDuration() time.Duration
 }
 
 // Collector abstracts the collection of process metrics.
@@ -32,3 +36,72 @@ type Collector interface {
 	// CollectMemory collects memory metrics for a process.
 	CollectMemory(ctx context.Context, pid int) (domainmetrics.ProcessMemory, error)
 }
+
+// CPUCollector abstracts the reading of the CPU times of a process from
+// the kernel, with its start time and clock tick rate.
+// It is implemented by infrastructure adapters reading procfs.
+type CPUCollector interface {
+	// CollectCPU collects the CPU times of a process.
+	CollectCPU(ctx context.Context, pid int) (domainmetrics.ProcessCPU, error)
+}
+
+// MemoryDetailCollector abstracts the reading of the memory attribution
+// of a process (PSS, USS, swap and major page faults).
+// It is implemented by infrastructure adapters reading procfs.
+type MemoryDetailCollector interface {
+	// CollectMemoryDetail collects the memory attribution of a process.
+	CollectMemoryDetail(ctx context.Context, pid int) (domainmetrics.MemoryDetail, error)
+}
+
+// ThrottlingCollector abstracts the collection of cgroup CPU throttling counters.
+// It is implemented by infrastructure adapters reading cgroup cpu.stat files.
+type ThrottlingCollector interface {
+	// CollectThrottling collects CPU throttling counters for the cgroup of a process.
+	CollectThrottling(ctx context.Context, pid int) (domainmetrics.CPUThrottling, error)
+}
+
+// PressureCollector abstracts the collection of per-cgroup PSI (Pressure Stall Information).
+// It is implemented by infrastructure adapters reading cgroup v2 pressure files.
+type PressureCollector interface {
+	// CollectPressure collects CPU, memory, and I/O pressure for the cgroup of a process.
+	CollectPressure(ctx context.Context, pid int) (domainmetrics.ResourcePressure, error)
+}
+
+// HostPressureCollector abstracts the collection of host-wide PSI (Pressure Stall Information).
+// It is implemented by infrastructure adapters reading /proc/pressure.
+type HostPressureCollector interface {
+	// CollectHostPressure collects host CPU, memory, and I/O pressure.
+	CollectHostPressure(ctx context.Context) (domainmetrics.ResourcePressure, error)
+}
+
+// CapacityCollector abstracts the measurement of the resources available to services.
+// It is implemented by infrastructure adapters reading procfs and the daemon cgroup.
+type CapacityCollector interface {
+	// CollectCapacity measures the host memory and CPUs, bounded by the daemon cgroup limits.
+	CollectCapacity(ctx context.Context) (domainmetrics.HostCapacity, error)
+}
+
+// HostFactsCollector abstracts the reading of the host facts checked by
+// service start conditions. It is implemented by infrastructure adapters
+// reading procfs and the cgroup filesystem.
+type HostFactsCollector interface {
+	// CollectHostFacts reads the kernel, architecture, cgroup version and
+	// available memory of the host, and whether each path exists.
+	CollectHostFacts(ctx context.Context, paths []string) (domainconfig.HostFacts, error)
+}
+
+// SelfCollector abstracts the measurement of the daemon's own process.
+// It is implemented by infrastructure adapters reading procfs.
+type SelfCollector interface {
+	// CollectSelf measures the resident memory and open file descriptors of the daemon.
+	CollectSelf(ctx context.Context) (domainmetrics.SelfUsage, error)
+}
+
+// HostInventoryCollector abstracts the detection of the platform
+// capabilities of the host. It is implemented by infrastructure adapters
+// reading procfs, sysfs and /run.
+type HostInventoryCollector interface {
+	// CollectHostInventory detects the cgroup version, security modules,
+	// init system, container runtime sockets and limits of the host.
+	CollectHostInventory(ctx context.Context) (domainmetrics.HostInventory, error)
+}
@@ -22,4 +22,12 @@ type trackedProcess struct {
 	prevCPU domainmetrics.ProcessCPU
 	// prevCPUTime stores when the previous CPU sample was taken.
 	prevCPUTime time.Time
+	// prevThrottling stores the previous cgroup throttling sample.
+	prevThrottling domainmetrics.CPUThrottling
+	// prevMemDetail stores the previous memory attribution sample for the major fault rate.
+	prevMemDetail domainmetrics.MemoryDetail
+	// throttledPercent is the last computed throttled share of wall time.
+	throttledPercent float64
+	// pressure is the last collected cgroup PSI sample.
+	pressure domainmetrics.ResourcePressure
 }
@@ -4,11 +4,13 @@ package metrics
 import (
 	"context"
 	"maps"
+	"runtime"
 	"slices"
 	"sync"
 	"time"
 	"unsafe"
 
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
 	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
 	"github.com/kodflow/daemon/internal/domain/process"
 )
@@ -35,13 +37,23 @@ const (
 // maintains process state, and publishes updates to subscribers.
 // The collection loop runs in a background goroutine started by Start().
 type Tracker struct {
-	mu          sync.RWMutex
-	collector   Collector
-	processes   map[string]*trackedProcess
-	interval    time.Duration
-	ctx         context.Context
-	cancel      context.CancelFunc
-	running     bool
+	mu         sync.RWMutex
+	collector  Collector
+	cpuTimes   CPUCollector
+	memDetail  MemoryDetailCollector
+	throttling ThrottlingCollector
+	pressure   PressureCollector
+	processes  map[string]*trackedProcess
+	interval   time.Duration
+	ctx        context.Context
+	cancel     context.CancelFunc
+	running    bool
+	// percentMode selects the figure reported as CPU usage.
+	percentMode domainconfig.CPUPercentMode
+	// cpus is the number of CPUs the normalized usage is relative to.
+	cpus int
+	// lastCollect is how long the last collection of all processes took.
+	lastCollect time.Duration
 	subsMu      sync.RWMutex
 	subscribers map[chan domainmetrics.ProcessMetrics]struct{}
 }
@@ -66,6 +78,92 @@ func WithCollectionInterval(d time.Duration) TrackerOption {
 	}
 }
 
+// WithCPUCollector reads the CPU times of processes from the kernel
+// instead of the process collector. Samples it fails to read fall back to
+// the process collector.
+//
+// Params:
+//   - c: CPU times collector (ignored if nil)
+//
+// Returns:
+//   - TrackerOption: option that sets the CPU collector
+func WithCPUCollector(c CPUCollector) TrackerOption {
+	// Return option that sets CPU collector if provided.
+	return func(t *Tracker) {
+		// Only set collector if non-nil.
+		if c != nil {
+			t.cpuTimes = c
+		}
+	}
+}
+
+// WithCPUPercentMode selects the CPU usage figure reported for each process.
+//
+// Params:
+//   - mode: per_core or normalized (per_core when empty)
+//
+// Returns:
+//   - TrackerOption: option that sets the percent mode
+func WithCPUPercentMode(mode domainconfig.CPUPercentMode) TrackerOption {
+	// Return option that sets the mode.
+	return func(t *Tracker) {
+		t.percentMode = mode
+	}
+}
+
+// WithMemoryDetailCollector enables the collection of PSS, USS, swap and
+// major page faults. Samples it fails to read keep the process collector
+// figures.
+//
+// Params:
+//   - c: memory attribution collector (ignored if nil)
+//
+// Returns:
+//   - TrackerOption: option that sets the memory attribution collector
+func WithMemoryDetailCollector(c MemoryDetailCollector) TrackerOption {
+	// Return option that sets memory attribution collector if provided.
+	return func(t *Tracker) {
+		// Only set collector if non-nil.
+		if c != nil {
+			t.memDetail = c
+		}
+	}
+}
+
+// WithThrottlingCollector enables cgroup CPU throttling collection.
+//
+// Params:
+//   - c: throttling collector (ignored if nil)
+//
+// Returns:
+//   - TrackerOption: option that sets the throttling collector
+func WithThrottlingCollector(c ThrottlingCollector) TrackerOption {
+	// Return option that sets throttling collector if provided.
+	return func(t *Tracker) {
+		// Only set collector if non-nil.
+		if c != nil {
+			t.throttling = c
+		}
+	}
+}
+
+// WithPressureCollector enables cgroup PSI collection.
+//
+// Params:
+//   - c: pressure collector (ignored if nil)
+//
+// Returns:
+//   - TrackerOption: option that sets the pressure collector
+func WithPressureCollector(c PressureCollector) TrackerOption {
+	// Return option that sets pressure collector if provided.
+	return func(t *Tracker) {
+		// Only set collector if non-nil.
+		if c != nil {
+			t.pressure = c
+		}
+	}
+}
+
 // NewTracker creates a new process metrics tracker.
 //
 // Params:
@@ -79,6 +177,7 @@ func NewTracker(collector Collector, opts ...TrackerOption) *Tracker {
 		collector:   collector,
 		processes:   make(map[string]*trackedProcess, defaultProcessMapCap),
 		interval:    defaultCollectionInterval,
+		cpus:        runtime.NumCPU(),
 		subscribers: make(map[chan domainmetrics.ProcessMetrics]struct{}, defaultSubscriberMapCap),
 	}
 
@@ -157,6 +256,14 @@ func (t *Tracker) Track(serviceName string, pid int) error {
 		existing.startTime = now
 		existing.restartCount++
 		existing.lastError = ""
+		// Reset CPU baseline since the counters belong to the old process.
+		existing.prevCPU = domainmetrics.ProcessCPU{}
+		existing.prevCPUTime = time.Time{}
+		existing.prevMemDetail = domainmetrics.MemoryDetail{}
+		// Reset throttling baseline since the process may live in a new cgroup.
+		existing.prevThrottling = domainmetrics.CPUThrottling{}
+		existing.throttledPercent = 0
+		existing.pressure = domainmetrics.ResourcePressure{}
 		// Update lastMetrics with new state
 		existing.lastMetrics = t.buildMetrics(existing, now)
 	} else {
@@ -189,16 +296,18 @@ func (t *Tracker) Track(serviceName string, pid int) error {
 //   - ProcessMetrics: snapshot of process metrics
 func (t *Tracker) buildMetrics(proc *trackedProcess, now time.Time) domainmetrics.ProcessMetrics {
 	m := domainmetrics.ProcessMetrics{
-		ServiceName:  proc.serviceName,
-		PID:          proc.pid,
-		State:        proc.state,
-		Healthy:      proc.healthy,
-		CPU:          proc.lastMetrics.CPU,
-		Memory:       proc.lastMetrics.Memory,
-		StartTime:    proc.startTime,
-		RestartCount: proc.restartCount,
-		LastError:    proc.lastError,
-		Timestamp:    now,
+		ServiceName:      proc.serviceName,
+		PID:              proc.pid,
+		State:            proc.state,
+		Healthy:          proc.healthy,
+		CPU:              proc.lastMetrics.CPU,
+		Memory:           proc.lastMetrics.Memory,
+		ThrottledPercent: proc.throttledPercent,
+		Pressure:         proc.pressure,
+		StartTime:        proc.startTime,
+		RestartCount:     proc.restartCount,
+		LastError:        proc.lastError,
+		Timestamp:        now,
 	}
 
 	// Calculate uptime if process is running.
@@ -392,10 +501,27 @@ func (t *Tracker) collectAll() {
 	processes := slices.Collect(maps.Values(t.processes))
 	t.mu.Unlock()
 
+	start := time.Now()
 	// Collect metrics for each process.
 	for _, proc := range processes {
 		t.collectProcess(proc)
 	}
+
+	t.mu.Lock()
+	t.lastCollect = time.Since(start)
+	t.mu.Unlock()
+}
+
+// CollectDuration returns how long the last collection of all tracked
+// processes took, to detect a collection loop falling behind its interval.
+//
+// Returns:
+//   - time.Duration: the last collection duration, zero before the first one.
+func (t *Tracker) CollectDuration() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	// return last loop duration
+	return t.lastCollect
 }
 
 // collectProcess collects metrics for a single process.
@@ -413,7 +539,7 @@ func (t *Tracker) collectProcess(proc *trackedProcess) {
 	ctx, cancel := context.WithTimeout(t.ctx, t.interval/time.Duration(collectionTimeoutDivisor))
 	defer cancel()
 
-	cpu, cpuErr := t.collector.CollectCPU(ctx, proc.pid)
+	cpu, cpuErr := t.collectCPU(ctx, proc.pid)
 	mem, memErr := t.collector.CollectMemory(ctx, proc.pid)
 
 	// If both fail, process may have exited
@@ -426,9 +552,9 @@ func (t *Tracker) collectProcess(proc *trackedProcess) {
 
 	// Calculate CPU percentage using delta between snapshots.
 	now := time.Now()
-	// Calculate CPU percentage if previous snapshot exists.
-	if cpuErr == nil && !proc.prevCPUTime.IsZero() {
-		cpu.UsagePercent = t.calculateCPUPercent(proc.prevCPU, cpu, proc.prevCPUTime, now)
+	// Calculate CPU percentage if a previous snapshot of the same process exists.
+	if cpuErr == nil && !proc.prevCPUTime.IsZero() && proc.prevCPU.SameProcess(&cpu) {
+		t.applyCPUPercent(&cpu, t.calculateCPUPercent(proc.prevCPU, cpu, proc.prevCPUTime, now))
 	}
 
 	// Store current CPU snapshot for next calculation.
@@ -437,11 +563,140 @@ func (t *Tracker) collectProcess(proc *trackedProcess) {
 		proc.prevCPUTime = now
 	}
 
+	// Attribute shared memory when the process is readable.
+	if memErr == nil {
+		t.collectMemoryDetail(ctx, proc, &mem)
+	}
+
+	t.collectThrottling(ctx, proc)
+	t.collectPressure(ctx, proc)
 	t.updateProcessMetrics(proc, cpu, mem)
 }
 
+// collectCPU reads the CPU times of a process, from the kernel when a CPU
+// collector is set and can read them, from the process collector otherwise.
+//
+// Params:
+//   - ctx: context for collection timeout
+//   - pid: process ID
+//
+// Returns:
+//   - domainmetrics.ProcessCPU: the CPU sample
+//   - error: if no collector could read the process
+func (t *Tracker) collectCPU(ctx context.Context, pid int) (domainmetrics.ProcessCPU, error) {
+	// Prefer the kernel times.
+	if t.cpuTimes != nil {
+		// Use them when readable.
+		if cpu, err := t.cpuTimes.CollectCPU(ctx, pid); err == nil {
+			// Return kernel sample.
+			return cpu, nil
+		}
+	}
+	// Return process collector sample.
+	return t.collector.CollectCPU(ctx, pid)
+}
+
+// applyCPUPercent sets the CPU usage figures of a sample from its per-core
+// usage. The normalized usage divides it by the CPUs; both are clamped to
+// the capacity, which timer jitter can slightly exceed.
+//
+// Params:
+//   - cpu: the sample to update
+//   - perCore: the usage where a fully busy CPU is 100%
+func (t *Tracker) applyCPUPercent(cpu *domainmetrics.ProcessCPU, perCore float64) {
+	cpus := float64(max(t.cpus, 1))
+	cpu.PerCorePercent = min(perCore, cpus*percentMultiplier)
+	cpu.NormalizedPercent = cpu.PerCorePercent / cpus
+	cpu.UsagePercent = cpu.PerCorePercent
+	// Report the share of the capacity when configured.
+	if t.percentMode == domainconfig.CPUPercentNormalized {
+		cpu.UsagePercent = cpu.NormalizedPercent
+	}
+}
+
+// collectMemoryDetail overlays the memory attribution of a process onto its
+// memory sample. Collection errors keep the process collector figures.
+//
+// Params:
+//   - ctx: context for collection timeout
+//   - proc: process to collect the attribution for
+//   - mem: the memory sample to update
+func (t *Tracker) collectMemoryDetail(ctx context.Context, proc *trackedProcess, mem *domainmetrics.ProcessMemory) {
+	// Skip if smaps collection is disabled.
+	if t.memDetail == nil {
+		// No collector configured.
+		return
+	}
+
+	sample, err := t.memDetail.CollectMemoryDetail(ctx, proc.pid)
+	// Skip if the attribution is unavailable.
+	if err != nil {
+		// Keep collector figures.
+		return
+	}
+
+	sample.ApplyTo(mem, &proc.prevMemDetail)
+	proc.prevMemDetail = sample
+}
+
+// collectThrottling samples cgroup throttling counters and updates the throttled share.
+// Collection errors keep the previous value since cgroup stats are optional.
+//
+// Params:
+//   - ctx: context for collection timeout
+//   - proc: process to collect throttling for
+func (t *Tracker) collectThrottling(ctx context.Context, proc *trackedProcess) {
+	// Skip if throttling collection is disabled.
+	if t.throttling == nil {
+		// No collector configured.
+		return
+	}
+
+	sample, err := t.throttling.CollectThrottling(ctx, proc.pid)
+	// Skip if cgroup stats are unavailable.
+	if err != nil {
+		// Keep previous value.
+		return
+	}
+
+	// Calculate throttled share if previous sample exists.
+	if !proc.prevThrottling.Timestamp.IsZero() {
+		percent := sample.ThrottledPercent(proc.prevThrottling)
+		t.mu.Lock()
+		proc.throttledPercent = percent
+		t.mu.Unlock()
+	}
+	proc.prevThrottling = sample
+}
+
+// collectPressure samples cgroup PSI for a process.
+// Collection errors keep the previous value since PSI requires cgroup v2.
+//
+// Params:
+//   - ctx: context for collection timeout
+//   - proc: process to collect pressure for
+func (t *Tracker) collectPressure(ctx context.Context, proc *trackedProcess) {
+	// Skip if pressure collection is disabled.
+	if t.pressure == nil {
+		// No collector configured.
+		return
+	}
+
+	sample, err := t.pressure.CollectPressure(ctx, proc.pid)
+	// Skip if PSI is unavailable.
+	if err != nil {
+		// Keep previous value.
+		return
+	}
+
+	t.mu.Lock()
+	proc.pressure = sample
+	t.mu.Unlock()
+}
+
 // calculateCPUPercent calculates CPU usage percentage from two snapshots.
-// The formula compares the change in CPU jiffies over time.
+// The formula compares the change in CPU jiffies over time, converted with
+// the clock tick rate of the current snapshot.
 //
 // Params:
 //   - prev: previous CPU snapshot
@@ -473,10 +728,8 @@ func (t *Tracker) calculateCPUPercent(prev, curr domainmetrics.ProcessCPU, prevT
 	// Calculate jiffies delta.
 	delta := currTotal - prevTotal
 
-	// Convert jiffies to seconds (assuming 100 Hz = 100 jiffies per second).
-	// This is the standard USER_HZ on Linux.
-	const jiffiesPerSecond float64 = 100.0
-	cpuSeconds := float64(delta) / jiffiesPerSecond
+	// Convert jiffies to seconds at the detected rate (USER_HZ when unknown).
+	cpuSeconds := curr.TicksToDuration(delta).Seconds()
 
 	// Calculate percentage relative to elapsed wall time.
 	// Result can exceed 100% for multi-threaded processes using multiple cores.
@@ -503,16 +756,18 @@ func (t *Tracker) updateProcessMetrics(proc *trackedProcess, cpu domainmetrics.P
 	now := time.Now()
 
 	m := domainmetrics.ProcessMetrics{
-		ServiceName:  proc.serviceName,
-		PID:          proc.pid,
-		State:        proc.state,
-		Healthy:      proc.healthy,
-		CPU:          cpu,
-		Memory:       mem,
-		StartTime:    proc.startTime,
-		RestartCount: proc.restartCount,
-		LastError:    proc.lastError,
-		Timestamp:    now,
+		ServiceName:      proc.serviceName,
+		PID:              proc.pid,
+		State:            proc.state,
+		Healthy:          proc.healthy,
+		CPU:              cpu,
+		Memory:           mem,
+		ThrottledPercent: proc.throttledPercent,
+		Pressure:         proc.pressure,
+		StartTime:        proc.startTime,
+		RestartCount:     proc.restartCount,
+		LastError:        proc.lastError,
+		Timestamp:        now,
 	}
 
 	// Calculate uptime if process is running.
@@ -847,3 +847,90 @@ func TestTracker_Publish(t *testing.T) {
 		})
 	}
 }
+
+// mockThrottlingCollector implements ThrottlingCollector for testing.
+type mockThrottlingCollector struct {
+	mu      sync.Mutex
+	elapsed time.Duration
+	start   time.Time
+}
+
+func (m *mockThrottlingCollector) CollectThrottling(_ context.Context, _ int) (domainmetrics.CPUThrottling, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Report the cgroup as throttled for the whole elapsed wall time.
+	now := time.Now()
+	if m.start.IsZero() {
+		m.start = now
+	}
+	m.elapsed = now.Sub(m.start)
+	return domainmetrics.CPUThrottling{ThrottledTime: m.elapsed, ThrottledPeriods: 1, Timestamp: now}, nil
+}
+
+func TestWithThrottlingCollector(t *testing.T) {
+	t.Parallel()
+
+	collector := &mockCollector{}
+	throttling := &mockThrottlingCollector{}
+	tracker := appmetrics.NewTracker(collector,
+		appmetrics.WithCollectionInterval(testCollectionInterval),
+		appmetrics.WithThrottlingCollector(throttling),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, tracker.Start(ctx))
+	defer tracker.Stop()
+
+	require.NoError(t, tracker.Track("throttled", testPID))
+
+	// Wait until at least two samples produce a throttled percentage.
+	assert.Eventually(t, func() bool {
+		m, ok := tracker.Get("throttled")
+		return ok && m.ThrottledPercent > 50
+	}, testTimeout, testCollectionInterval/2)
+}
+
+func TestWithThrottlingCollector_Nil(t *testing.T) {
+	t.Parallel()
+
+	// A nil collector must not panic during collection.
+	tracker := appmetrics.NewTracker(&mockCollector{}, appmetrics.WithThrottlingCollector(nil))
+	require.NoError(t, tracker.Track("svc", testPID))
+
+	m, ok := tracker.Get("svc")
+	require.True(t, ok)
+	assert.Zero(t, m.ThrottledPercent)
+}
+
+// mockPressureCollector implements PressureCollector for testing.
+type mockPressureCollector struct{}
+
+func (m *mockPressureCollector) CollectPressure(_ context.Context, _ int) (domainmetrics.ResourcePressure, error) {
+	return domainmetrics.ResourcePressure{
+		Timestamp: time.Now(),
+		Memory:    domainmetrics.Pressure{FullAvg10: 15},
+	}, nil
+}
+
+func TestWithPressureCollector(t *testing.T) {
+	t.Parallel()
+
+	tracker := appmetrics.NewTracker(&mockCollector{},
+		appmetrics.WithCollectionInterval(testCollectionInterval),
+		appmetrics.WithPressureCollector(&mockPressureCollector{}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, tracker.Start(ctx))
+	defer tracker.Stop()
+
+	require.NoError(t, tracker.Track("pressured", testPID))
+
+	// Wait until a collection cycle publishes the pressure sample.
+	assert.Eventually(t, func() bool {
+		m, ok := tracker.Get("pressured")
+		return ok && m.Pressure.Memory.FullAvg10 == 15
+	}, testTimeout, testCollectionInterval/2)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
 	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
 	domain "github.com/kodflow/daemon/internal/domain/process"
 )
@@ -187,6 +188,74 @@ func Test_Tracker_calculateCPUPercent(t *testing.T) {
 	}
 }
 
+// Test_Tracker_collectProcess_cpuPercent tests the CPU usage figures of
+// successive samples, the percent modes and PID reuse.
+//
+// Params:
+//   - t: the testing context.
+func Test_Tracker_collectProcess_cpuPercent(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// mode is the configured percent mode.
+		mode domainconfig.CPUPercentMode
+		// startTime is the start time of the current sample.
+		startTime uint64
+		// wantPerCore is the expected per-core usage.
+		wantPerCore float64
+		// wantUsage is the expected reported usage.
+		wantUsage float64
+	}{
+		{name: "per_core", startTime: 1000, wantPerCore: 200, wantUsage: 200},
+		{name: "normalized", mode: domainconfig.CPUPercentNormalized, startTime: 1000, wantPerCore: 200, wantUsage: 50},
+		{name: "reused_pid", startTime: 2000},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			// Two busy CPUs out of four over one second at 1000 Hz.
+			kernel := &mockCollectorInternal{cpu: domainmetrics.ProcessCPU{User: 3000, System: 1000, StartTime: tt.startTime, ClockTicks: 1000}}
+			tracker := NewTracker(&mockCollectorInternal{}, WithCPUCollector(kernel), WithCPUPercentMode(tt.mode))
+			tracker.ctx = context.Background()
+			tracker.cpus = 4
+			proc := &trackedProcess{
+				serviceName: "api",
+				pid:         42,
+				prevCPU:     domainmetrics.ProcessCPU{PID: 42, User: 1500, System: 500, StartTime: 1000, ClockTicks: 1000},
+				prevCPUTime: time.Now().Add(-time.Second),
+			}
+
+			tracker.collectProcess(proc)
+
+			cpu := proc.lastMetrics.CPU
+			assert.InDelta(t, tt.wantPerCore, cpu.PerCorePercent, 1)
+			assert.InDelta(t, tt.wantPerCore/4, cpu.NormalizedPercent, 0.25)
+			assert.InDelta(t, tt.wantUsage, cpu.UsagePercent, 1)
+			// The sample becomes the baseline of the next one.
+			assert.Equal(t, tt.startTime, proc.prevCPU.StartTime)
+		})
+	}
+}
+
+// Test_Tracker_collectCPU tests the fallback to the process collector.
+//
+// Params:
+//   - t: the testing context.
+func Test_Tracker_collectCPU(t *testing.T) {
+	collector := &mockCollectorInternal{cpu: domainmetrics.ProcessCPU{UsagePercent: 12}}
+	kernel := &mockCollectorInternal{cpuErr: fmt.Errorf("procfs unavailable")}
+	tracker := NewTracker(collector, WithCPUCollector(kernel))
+
+	cpu, err := tracker.collectCPU(context.Background(), 42)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 12.0, cpu.UsagePercent, 0.001)
+	assert.Equal(t, 1, kernel.cpuCalls)
+	assert.Equal(t, 1, collector.cpuCalls)
+}
+
 // Test_Tracker_buildMetrics tests the buildMetrics method.
 //
 // Params:
@@ -363,6 +432,8 @@ func Test_Tracker_collectAll(t *testing.T) {
 			// Verify collector was called for each process with valid PID.
 			assert.Equal(t, tt.numProcesses, collector.cpuCalls)
 			assert.Equal(t, tt.numProcesses, collector.memCalls)
+			// Verify the loop duration was recorded.
+			assert.Positive(t, tracker.CollectDuration())
 		})
 	}
 }
@@ -468,3 +539,225 @@ func Test_Tracker_updateProcessMetrics(t *testing.T) {
 		})
 	}
 }
+
+// mockThrottlingCollectorInternal implements ThrottlingCollector for internal testing.
+type mockThrottlingCollectorInternal struct {
+	samples []domainmetrics.CPUThrottling
+	err     error
+	calls   int
+}
+
+// CollectThrottling returns the next configured throttling sample.
+//
+// Params:
+//   - ctx: the context for the collection
+//   - pid: the process ID
+//
+// Returns:
+//   - CPUThrottling: the throttling sample
+//   - error: any error that occurred
+func (m *mockThrottlingCollectorInternal) CollectThrottling(_ context.Context, _ int) (domainmetrics.CPUThrottling, error) {
+	if m.err != nil {
+		return domainmetrics.CPUThrottling{}, m.err
+	}
+	sample := m.samples[m.calls%len(m.samples)]
+	m.calls++
+	return sample, nil
+}
+
+// Test_Tracker_collectThrottling tests the collectThrottling method.
+//
+// Params:
+//   - t: the testing context.
+func Test_Tracker_collectThrottling(t *testing.T) {
+	base := time.Now()
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// collector is the throttling collector.
+		collector *mockThrottlingCollectorInternal
+		// wantPercent is the expected throttled percentage after two samples.
+		wantPercent float64
+	}{
+		{
+			name: "computes_percent_from_two_samples",
+			collector: &mockThrottlingCollectorInternal{samples: []domainmetrics.CPUThrottling{
+				{ThrottledTime: 0, Timestamp: base},
+				{ThrottledTime: 500 * time.Millisecond, Timestamp: base.Add(time.Second)},
+			}},
+			wantPercent: 50.0,
+		},
+		{
+			name:        "keeps_zero_on_error",
+			collector:   &mockThrottlingCollectorInternal{err: fmt.Errorf("no cgroup")},
+			wantPercent: 0,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTracker(nil, WithThrottlingCollector(tt.collector))
+			proc := &trackedProcess{serviceName: "test-service", pid: 1234}
+
+			tracker.collectThrottling(context.Background(), proc)
+			tracker.collectThrottling(context.Background(), proc)
+
+			assert.InDelta(t, tt.wantPercent, proc.throttledPercent, 0.001)
+		})
+	}
+}
+
+// mockMemoryDetailCollectorInternal implements MemoryDetailCollector for internal testing.
+type mockMemoryDetailCollectorInternal struct {
+	samples []domainmetrics.MemoryDetail
+	err     error
+	calls   int
+}
+
+// CollectMemoryDetail returns the next configured memory attribution sample.
+//
+// Params:
+//   - ctx: the context for the collection
+//   - pid: the process ID
+//
+// Returns:
+//   - MemoryDetail: the memory attribution sample
+//   - error: any error that occurred
+func (m *mockMemoryDetailCollectorInternal) CollectMemoryDetail(_ context.Context, _ int) (domainmetrics.MemoryDetail, error) {
+	if m.err != nil {
+		return domainmetrics.MemoryDetail{}, m.err
+	}
+	sample := m.samples[m.calls%len(m.samples)]
+	m.calls++
+	return sample, nil
+}
+
+// Test_Tracker_collectMemoryDetail tests the collectMemoryDetail method.
+//
+// Params:
+//   - t: the testing context.
+func Test_Tracker_collectMemoryDetail(t *testing.T) {
+	base := time.Now()
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// collector is the memory attribution collector.
+		collector *mockMemoryDetailCollectorInternal
+		// wantPSS is the expected PSS after two samples.
+		wantPSS uint64
+		// wantSwap is the expected swap after two samples.
+		wantSwap uint64
+		// wantRate is the expected major fault rate after two samples.
+		wantRate float64
+	}{
+		{
+			name: "computes_rate_from_two_samples",
+			collector: &mockMemoryDetailCollectorInternal{samples: []domainmetrics.MemoryDetail{
+				{Timestamp: base, PID: 1234, StartTime: 10, PSS: 100, Swap: 8, MajorFaults: 5},
+				{Timestamp: base.Add(time.Second), PID: 1234, StartTime: 10, PSS: 200, Swap: 16, MajorFaults: 25},
+			}},
+			wantPSS:  200,
+			wantSwap: 16,
+			wantRate: 20,
+		},
+		{
+			name: "ignores_reused_pid",
+			collector: &mockMemoryDetailCollectorInternal{samples: []domainmetrics.MemoryDetail{
+				{Timestamp: base, PID: 1234, StartTime: 10, MajorFaults: 5},
+				{Timestamp: base.Add(time.Second), PID: 1234, StartTime: 90, PSS: 50, Swap: 4, MajorFaults: 25},
+			}},
+			wantPSS:  50,
+			wantSwap: 4,
+		},
+		{
+			name:      "keeps_collector_figures_on_error",
+			collector: &mockMemoryDetailCollectorInternal{err: fmt.Errorf("no smaps_rollup")},
+			wantSwap:  1,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTracker(nil, WithMemoryDetailCollector(tt.collector))
+			proc := &trackedProcess{serviceName: "test-service", pid: 1234}
+			mem := domainmetrics.ProcessMemory{RSS: 300, Swap: 1}
+
+			tracker.collectMemoryDetail(context.Background(), proc, &mem)
+			tracker.collectMemoryDetail(context.Background(), proc, &mem)
+
+			assert.Equal(t, uint64(300), mem.RSS)
+			assert.Equal(t, tt.wantPSS, mem.PSS)
+			assert.Equal(t, tt.wantSwap, mem.Swap)
+			assert.InDelta(t, tt.wantRate, mem.MajorFaultRate, 0.001)
+		})
+	}
+}
+
+// mockPressureCollectorInternal implements PressureCollector for internal testing.
+type mockPressureCollectorInternal struct {
+	sample domainmetrics.ResourcePressure
+	err    error
+}
+
+// CollectPressure returns the configured pressure sample.
+//
+// Params:
+//   - ctx: the context for the collection
+//   - pid: the process ID
+//
+// Returns:
+//   - ResourcePressure: the pressure sample
+//   - error: any error that occurred
+func (m *mockPressureCollectorInternal) CollectPressure(_ context.Context, _ int) (domainmetrics.ResourcePressure, error) {
+	return m.sample, m.err
+}
+
+// Test_Tracker_collectPressure tests the collectPressure method.
+//
+// Params:
+//   - t: the testing context.
+func Test_Tracker_collectPressure(t *testing.T) {
+	sample := domainmetrics.ResourcePressure{
+		Timestamp: time.Now(),
+		IO:        domainmetrics.Pressure{SomeAvg10: 42},
+	}
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// collector is the pressure collector.
+		collector *mockPressureCollectorInternal
+		// wantIO is the expected I/O some avg10 after collection.
+		wantIO float64
+	}{
+		{
+			name:      "stores_sample",
+			collector: &mockPressureCollectorInternal{sample: sample},
+			wantIO:    42,
+		},
+		{
+			name:      "keeps_previous_on_error",
+			collector: &mockPressureCollectorInternal{err: fmt.Errorf("cgroup v1")},
+			wantIO:    0,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTracker(nil, WithPressureCollector(tt.collector))
+			proc := &trackedProcess{serviceName: "test-service", pid: 1234}
+
+			tracker.collectPressure(context.Background(), proc)
+
+			assert.InDelta(t, tt.wantIO, proc.pressure.IO.SomeAvg10, 0.001)
+		})
+	}
+}
@@ -0,0 +1,367 @@
+// Package notification provides the application service delivering lifecycle
+// events to external channels.
+package notification
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/target"
+)
+
+// defaultRateLimitPeriod is the rate limit window when none is configured.
+const defaultRateLimitPeriod time.Duration = time.Minute
+
+// Dispatcher routes lifecycle events to notification channels.
+// Channels with a digest batch their events into one message per period,
+// a global rate limit drops messages during floods, and escalation rules
+// send repeated events straight to a channel.
+// Deliveries run in the background; Notify never blocks on a channel.
+type Dispatcher struct {
+	// cfg is the notification configuration.
+	cfg domainconfig.NotificationsConfig
+	// sender delivers messages.
+	sender Sender
+	// onError is called when a delivery fails, may be nil.
+	onError ErrorHandler
+	// mu protects the fields below.
+	mu sync.Mutex
+	// pending holds, per channel, the events waiting for the digest.
+	pending map[string][]Entry
+	// timers holds, per channel, the pending digest flush.
+	timers map[string]*time.Timer
+	// occurrences holds, per escalation rule and service, recent event times.
+	occurrences map[string][]time.Time
+	// windowStart is when the current rate limit window began.
+	windowStart time.Time
+	// sent is the number of messages sent in the current window.
+	sent int
+	// suppressed is the number of messages dropped since the last one sent.
+	suppressed int
+	// closed reports that Close was called.
+	closed bool
+	// muted drops the events notified while set.
+	muted bool
+	// wg tracks in-flight deliveries.
+	wg sync.WaitGroup
+}
+
+// NewDispatcher creates a new notification dispatcher.
+//
+// Params:
+//   - cfg: the notification configuration.
+//   - sender: the adapter delivering messages.
+//   - onError: called when a delivery fails, may be nil.
+//
+// Returns:
+//   - *Dispatcher: the dispatcher.
+func NewDispatcher(cfg domainconfig.NotificationsConfig, sender Sender, onError ErrorHandler) *Dispatcher {
+	// return dispatcher without pending events
+	return &Dispatcher{
+		cfg:         cfg,
+		sender:      sender,
+		onError:     onError,
+		pending:     make(map[string][]Entry, len(cfg.Channels)),
+		timers:      make(map[string]*time.Timer, len(cfg.Channels)),
+		occurrences: make(map[string][]time.Time),
+	}
+}
+
+// Notify routes an event to the channels accepting it and applies the
+// escalation rules.
+//
+// Params:
+//   - service: the service name, empty for daemon-wide events.
+//   - event: the process event.
+func (d *Dispatcher) Notify(service string, event *domain.Event) {
+	d.route(NewEntry(service, event))
+}
+
+// NotifyTarget routes a health change of a monitored target like a service
+// event, with the target labels. Other target events are ignored.
+//
+// Params:
+//   - event: the target event.
+func (d *Dispatcher) NotifyTarget(event *target.Event) {
+	// only health changes are notified
+	if event.Type != target.EventHealthChanged {
+		// nothing to deliver
+		return
+	}
+	d.route(NewTargetEntry(event))
+}
+
+// route sends an entry to the channels accepting it and applies the
+// escalation rules.
+//
+// Params:
+//   - entry: the event to deliver.
+func (d *Dispatcher) route(entry Entry) {
+	var out []delivery
+
+	d.mu.Lock()
+	// ignore events after close and while muted
+	if d.closed || d.muted {
+		d.mu.Unlock()
+		// nothing to deliver
+		return
+	}
+	// route to each accepting channel
+	for i := range d.cfg.Channels {
+		ch := &d.cfg.Channels[i]
+		// skip channels not interested in this event
+		if len(ch.Events) > 0 && !slices.Contains(ch.Events, entry.Type) {
+			continue
+		}
+		// skip channels selecting other labels
+		if !ch.Selects(entry.Labels) {
+			continue
+		}
+		// batch when a digest is configured
+		if ch.Digest > 0 {
+			d.queue(ch, entry)
+			continue
+		}
+		// send immediately within the rate limit
+		if suppressed, ok := d.allow(); ok {
+			out = append(out, delivery{channel: ch, msg: &Message{Channel: ch.Name, Events: []Entry{entry}, Suppressed: suppressed}})
+		}
+	}
+	out = append(out, d.escalate(entry)...)
+	d.deliver(out)
+	d.mu.Unlock()
+}
+
+// SetMuted stops or resumes delivering events. Events notified while muted
+// are dropped, not delivered on unmute; pending digests are still sent.
+//
+// Params:
+//   - muted: whether to drop the events notified from now on.
+func (d *Dispatcher) SetMuted(muted bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// store mute state
+	d.muted = muted
+}
+
+// Close flushes pending digests and waits for in-flight deliveries.
+// Events notified after Close are dropped.
+func (d *Dispatcher) Close() {
+	var out []delivery
+
+	d.mu.Lock()
+	d.closed = true
+	// flush every pending digest
+	for name, timer := range d.timers {
+		timer.Stop()
+		delete(d.timers, name)
+		// keep the digest when within the rate limit
+		if msg := d.takeDigest(name); msg != nil {
+			out = append(out, delivery{channel: d.channel(name), msg: msg})
+		}
+	}
+	d.deliver(out)
+	d.mu.Unlock()
+
+	// wait for the background deliveries
+	d.wg.Wait()
+}
+
+// queue adds an event to a channel digest, arming its flush.
+// Must be called with d.mu held.
+//
+// Params:
+//   - ch: the channel.
+//   - entry: the event.
+func (d *Dispatcher) queue(ch *domainconfig.NotificationChannel, entry Entry) {
+	d.pending[ch.Name] = append(d.pending[ch.Name], entry)
+	// the first event of a period arms the flush
+	if _, armed := d.timers[ch.Name]; !armed {
+		name := ch.Name
+		d.timers[name] = time.AfterFunc(ch.Digest.Duration(), func() {
+			// Send the digest once the period is over.
+			d.flush(name)
+		})
+	}
+}
+
+// flush sends the digest of a channel.
+//
+// Params:
+//   - name: the channel name.
+func (d *Dispatcher) flush(name string) {
+	d.mu.Lock()
+	// Close already flushed this digest
+	if _, armed := d.timers[name]; !armed {
+		d.mu.Unlock()
+		// nothing to flush
+		return
+	}
+	delete(d.timers, name)
+	defer d.mu.Unlock()
+
+	// deliver within the rate limit
+	if msg := d.takeDigest(name); msg != nil {
+		d.deliver([]delivery{{channel: d.channel(name), msg: msg}})
+	}
+}
+
+// takeDigest removes the pending events of a channel as one message.
+// Must be called with d.mu held.
+//
+// Params:
+//   - name: the channel name.
+//
+// Returns:
+//   - *Message: the digest, nil when empty or over the rate limit.
+func (d *Dispatcher) takeDigest(name string) *Message {
+	entries := d.pending[name]
+	delete(d.pending, name)
+	// nothing batched
+	if len(entries) == 0 {
+		// no message
+		return nil
+	}
+	suppressed, ok := d.allow()
+	// dropped by the rate limit
+	if !ok {
+		// no message
+		return nil
+	}
+	// return the digest
+	return &Message{Channel: name, Events: entries, Suppressed: suppressed}
+}
+
+// allow applies the global rate limit to one message.
+// Must be called with d.mu held.
+//
+// Returns:
+//   - int: messages dropped since the previous allowed one.
+//   - bool: true if the message may be sent.
+func (d *Dispatcher) allow() (int, bool) {
+	limit := d.cfg.RateLimit
+	// no rate limit configured
+	if limit.Max <= 0 {
+		// always allowed
+		return 0, true
+	}
+	period := limit.Period.Duration()
+	// use default window if not specified
+	if period <= 0 {
+		period = defaultRateLimitPeriod
+	}
+	now := time.Now()
+	// start a new window
+	if now.Sub(d.windowStart) >= period {
+		d.windowStart = now
+		d.sent = 0
+	}
+	// over the limit: drop and count
+	if d.sent >= limit.Max {
+		d.suppressed++
+		// dropped
+		return 0, false
+	}
+	d.sent++
+	suppressed := d.suppressed
+	d.suppressed = 0
+	// allowed
+	return suppressed, true
+}
+
+// escalate records an event against the escalation rules.
+// Must be called with d.mu held.
+//
+// Params:
+//   - entry: the event.
+//
+// Returns:
+//   - []delivery: the escalations triggered by the event.
+func (d *Dispatcher) escalate(entry Entry) []delivery {
+	var out []delivery
+	// check each rule
+	for i := range d.cfg.Escalations {
+		rule := &d.cfg.Escalations[i]
+		// skip rules counting another event
+		if rule.Event != entry.Type {
+			continue
+		}
+		key := fmt.Sprintf("%d/%s/%s", i, entry.Service, entry.Target)
+		window := rule.Within.Duration()
+		// keep occurrences within the window
+		times := slices.DeleteFunc(d.occurrences[key], func(t time.Time) bool {
+			// too old to count
+			return entry.Time.Sub(t) > window
+		})
+		times = append(times, entry.Time)
+		// tolerate up to Count occurrences
+		if len(times) <= rule.Count {
+			d.occurrences[key] = times
+			continue
+		}
+		// escalate and start counting again
+		delete(d.occurrences, key)
+		out = append(out, delivery{
+			channel: d.channel(rule.Channel),
+			msg:     &Message{Channel: rule.Channel, Escalation: true, Events: []Entry{entry}},
+		})
+	}
+	// return triggered escalations
+	return out
+}
+
+// channel returns the configuration of a channel.
+//
+// Params:
+//   - name: the channel name.
+//
+// Returns:
+//   - *domainconfig.NotificationChannel: the channel, nil if unknown.
+func (d *Dispatcher) channel(name string) *domainconfig.NotificationChannel {
+	// search channels by name
+	for i := range d.cfg.Channels {
+		// matching channel
+		if d.cfg.Channels[i].Name == name {
+			// return channel
+			return &d.cfg.Channels[i]
+		}
+	}
+	// unknown channel
+	return nil
+}
+
+// delivery is a message bound for a channel.
+type delivery struct {
+	// channel is the destination.
+	channel *domainconfig.NotificationChannel
+	// msg is the message.
+	msg *Message
+}
+
+// deliver sends messages in the background.
+// Must be called with d.mu held, so no delivery starts once Close waits.
+//
+// Params:
+//   - out: the messages to send.
+func (d *Dispatcher) deliver(out []delivery) {
+	// send each message
+	for _, dl := range out {
+		// skip channels missing from the configuration
+		if dl.channel == nil {
+			continue
+		}
+		d.wg.Add(1)
+		go func(dl delivery) {
+			defer d.wg.Done()
+			// Report failed deliveries.
+			if err := d.sender.Send(context.Background(), dl.channel, dl.msg); err != nil && d.onError != nil {
+				d.onError(dl.channel.Name, err)
+			}
+		}(dl)
+	}
+}
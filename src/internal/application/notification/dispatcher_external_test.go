@@ -0,0 +1,324 @@
+// Package notification_test provides black-box tests for the notification package.
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/notification"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/target"
+)
+
+// recordingSender records the delivered messages.
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []notification.Message
+	err      error
+}
+
+// Send records the message.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - channel: the destination channel (unused).
+//   - msg: the message.
+//
+// Returns:
+//   - error: the configured error.
+func (s *recordingSender) Send(_ context.Context, _ *domainconfig.NotificationChannel, msg *notification.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, *msg)
+	return s.err
+}
+
+// sent returns the delivered messages.
+//
+// Returns:
+//   - []notification.Message: the messages.
+func (s *recordingSender) sent() []notification.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]notification.Message(nil), s.messages...)
+}
+
+// failed creates a failed event of a service.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - *domain.Event: the event.
+func failed(service string) *domain.Event {
+	event := domain.NewEvent(domain.EventFailed, service, 0, 1, errors.New("exit code 1"))
+	return &event
+}
+
+// TestDispatcher_Notify tests routing, digests and the rate limit.
+func TestDispatcher_Notify(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       domainconfig.NotificationsConfig
+		events    int
+		wantSizes []int
+	}{
+		{
+			name:      "each_event_sent",
+			cfg:       domainconfig.NotificationsConfig{Channels: []domainconfig.NotificationChannel{{Name: "ops"}}},
+			events:    3,
+			wantSizes: []int{1, 1, 1},
+		},
+		{
+			name: "digest_batches_events",
+			cfg: domainconfig.NotificationsConfig{Channels: []domainconfig.NotificationChannel{
+				{Name: "ops", Digest: shared.FromTimeDuration(time.Hour)},
+			}},
+			events:    3,
+			wantSizes: []int{3},
+		},
+		{
+			name: "event_filter",
+			cfg: domainconfig.NotificationsConfig{Channels: []domainconfig.NotificationChannel{
+				{Name: "ops", Events: []string{"unhealthy"}},
+			}},
+			events: 3,
+		},
+		{
+			name: "rate_limit_drops_flood",
+			cfg: domainconfig.NotificationsConfig{
+				Channels:  []domainconfig.NotificationChannel{{Name: "ops"}},
+				RateLimit: domainconfig.NotificationRateLimit{Max: 2},
+			},
+			events:    5,
+			wantSizes: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &recordingSender{}
+			d := notification.NewDispatcher(tt.cfg, sender, nil)
+
+			// notify the events
+			for range tt.events {
+				d.Notify("api", failed("api"))
+			}
+			// Close flushes pending digests.
+			d.Close()
+
+			sizes := make([]int, 0)
+			// collect message sizes
+			for _, msg := range sender.sent() {
+				assert.Equal(t, "ops", msg.Channel)
+				assert.False(t, msg.Escalation)
+				sizes = append(sizes, len(msg.Events))
+			}
+			assert.ElementsMatch(t, tt.wantSizes, sizes)
+		})
+	}
+}
+
+// TestDispatcher_SetMuted tests that events notified while muted are dropped.
+func TestDispatcher_SetMuted(t *testing.T) {
+	tests := []struct {
+		name     string
+		unmute   bool
+		wantSent int
+	}{
+		{name: "muted_drops_events"},
+		{name: "unmuted_delivers_later_events", unmute: true, wantSent: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &recordingSender{}
+			d := notification.NewDispatcher(domainconfig.NotificationsConfig{
+				Channels: []domainconfig.NotificationChannel{{Name: "ops"}},
+			}, sender, nil)
+
+			d.SetMuted(true)
+			d.Notify("api", failed("api"))
+			// resume before the next event
+			if tt.unmute {
+				d.SetMuted(false)
+			}
+			d.Notify("api", failed("api"))
+			d.Close()
+
+			assert.Len(t, sender.sent(), tt.wantSent)
+		})
+	}
+}
+
+// TestDispatcher_Notify_digestPeriod tests that a digest is sent once its period is over.
+func TestDispatcher_Notify_digestPeriod(t *testing.T) {
+	sender := &recordingSender{}
+	d := notification.NewDispatcher(domainconfig.NotificationsConfig{Channels: []domainconfig.NotificationChannel{
+		{Name: "ops", Digest: shared.FromTimeDuration(20 * time.Millisecond)},
+	}}, sender, nil)
+	defer d.Close()
+
+	d.Notify("api", failed("api"))
+	d.Notify("worker", failed("worker"))
+
+	require.Eventually(t, func() bool { return len(sender.sent()) == 1 }, time.Second, 5*time.Millisecond)
+	msg := sender.sent()[0]
+	require.Len(t, msg.Events, 2)
+	assert.Equal(t, "api", msg.Events[0].Service)
+	assert.Equal(t, "failed", msg.Events[0].Type)
+	assert.Equal(t, "exit code 1", msg.Events[0].Error)
+	assert.Equal(t, "worker", msg.Events[1].Service)
+}
+
+// TestDispatcher_Notify_rateLimitReportsSuppressed tests that the first
+// message of a new window counts the dropped ones.
+func TestDispatcher_Notify_rateLimitReportsSuppressed(t *testing.T) {
+	sender := &recordingSender{}
+	d := notification.NewDispatcher(domainconfig.NotificationsConfig{
+		Channels:  []domainconfig.NotificationChannel{{Name: "ops"}},
+		RateLimit: domainconfig.NotificationRateLimit{Max: 1, Period: shared.FromTimeDuration(30 * time.Millisecond)},
+	}, sender, nil)
+
+	d.Notify("api", failed("api"))
+	d.Notify("api", failed("api"))
+	d.Notify("api", failed("api"))
+	time.Sleep(40 * time.Millisecond)
+	d.Notify("api", failed("api"))
+	d.Close()
+
+	sent := sender.sent()
+	require.Len(t, sent, 2)
+	suppressed := []int{sent[0].Suppressed, sent[1].Suppressed}
+	assert.ElementsMatch(t, []int{0, 2}, suppressed)
+}
+
+// TestDispatcher_Notify_escalation tests that repeated events go straight
+// to the escalation channel.
+func TestDispatcher_Notify_escalation(t *testing.T) {
+	sender := &recordingSender{}
+	d := notification.NewDispatcher(domainconfig.NotificationsConfig{
+		Channels: []domainconfig.NotificationChannel{
+			{Name: "ops", Digest: shared.FromTimeDuration(time.Hour)},
+			{Name: "pager", Events: []string{"exhausted"}},
+		},
+		RateLimit:   domainconfig.NotificationRateLimit{Max: 1},
+		Escalations: []domainconfig.EscalationRule{{Event: "failed", Count: 2, Within: shared.Minutes(5), Channel: "pager"}},
+	}, sender, nil)
+
+	// Two failures are tolerated, the third escalates.
+	d.Notify("api", failed("api"))
+	d.Notify("api", failed("api"))
+	d.Notify("worker", failed("worker"))
+	d.Notify("api", failed("api"))
+	require.Eventually(t, func() bool { return len(sender.sent()) == 1 }, time.Second, 5*time.Millisecond)
+	page := sender.sent()[0]
+	assert.Equal(t, "pager", page.Channel)
+	assert.True(t, page.Escalation)
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, "api", page.Events[0].Service)
+
+	// Counting starts again after an escalation.
+	d.Notify("api", failed("api"))
+	d.Close()
+
+	var pages, digests int
+	// classify delivered messages
+	for _, msg := range sender.sent() {
+		// count per kind
+		if msg.Escalation {
+			pages++
+		} else {
+			digests++
+		}
+	}
+	assert.Equal(t, 1, pages)
+	// The ops digest is sent on close, within the rate limit.
+	assert.Equal(t, 1, digests)
+}
+
+// TestDispatcher_Notify_selector tests that channels receive the events matching their label selector.
+func TestDispatcher_Notify_selector(t *testing.T) {
+	sender := &recordingSender{}
+	d := notification.NewDispatcher(domainconfig.NotificationsConfig{
+		Channels: []domainconfig.NotificationChannel{
+			{Name: "payments", Selector: map[string]string{"team": "payments"}},
+			{Name: "frontend", Selector: map[string]string{"team": "payments", "tier": "frontend"}},
+			{Name: "ops"},
+		},
+	}, sender, nil)
+
+	api := failed("api")
+	api.Labels = map[string]string{"team": "payments", "tier": "backend"}
+	d.Notify("api", api)
+	d.Notify("worker", failed("worker"))
+	d.Close()
+
+	received := make(map[string][]string)
+	// group delivered services by channel
+	for _, msg := range sender.sent() {
+		for _, e := range msg.Events {
+			received[msg.Channel] = append(received[msg.Channel], e.Service)
+		}
+	}
+	assert.ElementsMatch(t, []string{"api"}, received["payments"])
+	assert.Empty(t, received["frontend"])
+	assert.ElementsMatch(t, []string{"api", "worker"}, received["ops"])
+}
+
+// TestDispatcher_NotifyTarget tests that target health changes are routed with their labels.
+func TestDispatcher_NotifyTarget(t *testing.T) {
+	sender := &recordingSender{}
+	d := notification.NewDispatcher(domainconfig.NotificationsConfig{
+		Channels: []domainconfig.NotificationChannel{
+			{Name: "payments", Events: []string{"unhealthy"}, Selector: map[string]string{"team": "payments"}},
+		},
+	}, sender, nil)
+
+	db := &target.ExternalTarget{ID: "remote:db", Name: "db", Labels: map[string]string{"team": "payments"}}
+	// Only health changes are notified.
+	d.NotifyTarget(&target.Event{Type: target.EventUpdated, Target: *db})
+	d.NotifyTarget(&target.Event{Type: target.EventHealthChanged, Target: *db, PreviousState: target.StateHealthy, NewState: target.StateUnhealthy})
+	d.Close()
+
+	msgs := sender.sent()
+	require.Len(t, msgs, 1)
+	require.Len(t, msgs[0].Events, 1)
+	entry := msgs[0].Events[0]
+	assert.Equal(t, "remote:db", entry.Target)
+	assert.Empty(t, entry.Service)
+	assert.Equal(t, "unhealthy", entry.Type)
+	assert.Equal(t, map[string]string{"team": "payments"}, entry.Labels)
+}
+
+// TestDispatcher_deliveryError tests that failed deliveries are reported.
+func TestDispatcher_deliveryError(t *testing.T) {
+	sender := &recordingSender{err: errors.New("connection refused")}
+	var mu sync.Mutex
+	var reported []string
+	d := notification.NewDispatcher(domainconfig.NotificationsConfig{
+		Channels: []domainconfig.NotificationChannel{{Name: "ops"}},
+	}, sender, func(channel string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, channel+": "+err.Error())
+	})
+
+	d.Notify("api", failed("api"))
+	d.Close()
+	// Events after close are dropped.
+	d.Notify("api", failed("api"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"ops: connection refused"}, reported)
+	assert.Len(t, sender.sent(), 1)
+}
@@ -0,0 +1,122 @@
+// Package notification provides the application service delivering lifecycle
+// events to external channels.
+package notification
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// Heartbeat pings dead man's switch endpoints at their interval while the
+// daemon and the selected services are healthy. No ping is sent otherwise,
+// so the endpoint raises its alert, as it does when the host is down.
+type Heartbeat struct {
+	// endpoints are the heartbeats to ping.
+	endpoints []domainconfig.HeartbeatConfig
+	// health reports the daemon and service health.
+	health HealthSource
+	// pinger pings the endpoints.
+	pinger Pinger
+	// onError is called when a ping fails, may be nil.
+	onError ErrorHandler
+}
+
+// NewHeartbeat creates a new heartbeat notifier.
+//
+// Params:
+//   - endpoints: the heartbeats to ping.
+//   - health: the daemon and service health source.
+//   - pinger: the adapter pinging endpoints.
+//   - onError: called with the endpoint host when a ping fails, may be nil.
+//
+// Returns:
+//   - *Heartbeat: the heartbeat notifier.
+func NewHeartbeat(endpoints []domainconfig.HeartbeatConfig, health HealthSource, pinger Pinger, onError ErrorHandler) *Heartbeat {
+	// return notifier
+	return &Heartbeat{
+		endpoints: endpoints,
+		health:    health,
+		pinger:    pinger,
+		onError:   onError,
+	}
+}
+
+// Run pings every endpoint at its interval until the context is cancelled.
+//
+// Params:
+//   - ctx: context for cancellation.
+func (h *Heartbeat) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	// one loop per endpoint
+	for i := range h.endpoints {
+		wg.Add(1)
+		go func(hb *domainconfig.HeartbeatConfig) {
+			defer wg.Done()
+			// Ping until cancelled.
+			h.loop(ctx, hb)
+		}(&h.endpoints[i])
+	}
+	wg.Wait()
+}
+
+// loop pings one endpoint at its interval until the context is cancelled.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - hb: the heartbeat endpoint.
+func (h *Heartbeat) loop(ctx context.Context, hb *domainconfig.HeartbeatConfig) {
+	ticker := time.NewTicker(hb.Interval.Duration())
+	defer ticker.Stop()
+
+	// wait for each tick
+	for {
+		select {
+		case <-ctx.Done():
+			// stopped
+			return
+		case <-ticker.C:
+			h.beat(ctx, hb)
+		}
+	}
+}
+
+// beat pings one endpoint when healthy.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - hb: the heartbeat endpoint.
+func (h *Heartbeat) beat(ctx context.Context, hb *domainconfig.HeartbeatConfig) {
+	// stay silent so the endpoint alerts
+	if !h.health.Healthy(hb.Services) {
+		// skip unhealthy tick
+		return
+	}
+	err := h.pinger.Ping(ctx, hb.URL)
+	// report failures unless stopping
+	if err != nil && ctx.Err() == nil && h.onError != nil {
+		h.onError(endpointHost(hb.URL), err)
+	}
+}
+
+// endpointHost returns the host of an endpoint, keeping the secret path
+// of dead man's switch URLs out of logs.
+//
+// Params:
+//   - raw: the endpoint URL.
+//
+// Returns:
+//   - string: the host, or the URL if it cannot be parsed.
+func endpointHost(raw string) string {
+	u, err := url.Parse(raw)
+	// fall back on the raw value
+	if err != nil || u.Host == "" {
+		// unparsable URL
+		return raw
+	}
+	// return host only
+	return u.Host
+}
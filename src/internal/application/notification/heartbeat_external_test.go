@@ -0,0 +1,115 @@
+// Package notification_test provides black-box tests for the notification package.
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/notification"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// switchHealth is a health source toggled by tests.
+type switchHealth struct {
+	healthy  atomic.Bool
+	mu       sync.Mutex
+	services [][]string
+}
+
+// Healthy records the checked services and returns the switch.
+//
+// Params:
+//   - services: the checked services.
+//
+// Returns:
+//   - bool: the switch value.
+func (h *switchHealth) Healthy(services []string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.services = append(h.services, services)
+	return h.healthy.Load()
+}
+
+// countingPinger counts pings.
+type countingPinger struct {
+	pings atomic.Int32
+	err   error
+}
+
+// Ping counts the ping.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - url: the endpoint (unused).
+//
+// Returns:
+//   - error: the configured error.
+func (p *countingPinger) Ping(_ context.Context, _ string) error {
+	p.pings.Add(1)
+	return p.err
+}
+
+// TestHeartbeat_Run tests that endpoints are pinged only while healthy.
+func TestHeartbeat_Run(t *testing.T) {
+	health := &switchHealth{}
+	pinger := &countingPinger{}
+	hb := notification.NewHeartbeat([]domainconfig.HeartbeatConfig{
+		{URL: "https://hc-ping.com/uuid", Interval: shared.FromTimeDuration(5 * time.Millisecond), Services: []string{"api"}},
+	}, health, pinger, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		hb.Run(ctx)
+		close(done)
+	}()
+
+	// Unhealthy: no ping.
+	time.Sleep(30 * time.Millisecond)
+	assert.Zero(t, pinger.pings.Load())
+
+	// Healthy: pings flow.
+	health.healthy.Store(true)
+	require.Eventually(t, func() bool { return pinger.pings.Load() >= 2 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+	health.mu.Lock()
+	defer health.mu.Unlock()
+	assert.Equal(t, []string{"api"}, health.services[0])
+}
+
+// TestHeartbeat_Run_pingError tests that failed pings are reported by host.
+func TestHeartbeat_Run_pingError(t *testing.T) {
+	health := &switchHealth{}
+	health.healthy.Store(true)
+	pinger := &countingPinger{err: errors.New("connection refused")}
+	reported := make(chan string, 10)
+	hb := notification.NewHeartbeat([]domainconfig.HeartbeatConfig{
+		{URL: "https://hc-ping.com/secret-uuid", Interval: shared.FromTimeDuration(5 * time.Millisecond)},
+	}, health, pinger, func(endpoint string, _ error) {
+		select {
+		case reported <- endpoint:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hb.Run(ctx)
+
+	select {
+	case endpoint := <-reported:
+		assert.Equal(t, "hc-ping.com", endpoint)
+	case <-time.After(time.Second):
+		t.Fatal("ping error not reported")
+	}
+}
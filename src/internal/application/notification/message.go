@@ -0,0 +1,85 @@
+// Package notification provides the application service delivering lifecycle
+// events to external channels.
+package notification
+
+import (
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/target"
+)
+
+// Message is one delivery to a channel: a single event, a digest of the
+// events of a period, or an escalation.
+type Message struct {
+	// Channel is the destination channel name.
+	Channel string
+	// Escalation reports that an escalation rule triggered the message.
+	Escalation bool
+	// Events are the delivered events, oldest first.
+	Events []Entry
+	// Suppressed is the number of messages dropped by the rate limit
+	// since the previous message was sent.
+	Suppressed int
+}
+
+// Entry is an event as delivered to channels.
+type Entry struct {
+	// Time is when the event occurred.
+	Time time.Time
+	// Service is the service name, empty for daemon-wide and target events.
+	Service string
+	// Target is the monitored target ID, set on target events only.
+	Target string
+	// Type is the event type name (failed, unhealthy, ...).
+	Type string
+	// Error is the error attached to the event, if any.
+	Error string
+	// Labels are the labels of the service or target, if any.
+	Labels map[string]string
+}
+
+// NewEntry converts a process event for delivery.
+//
+// Params:
+//   - service: the service name.
+//   - event: the process event.
+//
+// Returns:
+//   - Entry: the entry.
+func NewEntry(service string, event *domain.Event) Entry {
+	entry := Entry{
+		Time:    event.Timestamp,
+		Service: service,
+		Type:    event.Name(),
+		Labels:  event.Labels,
+	}
+	// stamp events created without a time
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	// keep the error text only
+	if event.Error != nil {
+		entry.Error = event.Error.Error()
+	}
+	// return converted entry
+	return entry
+}
+
+// NewTargetEntry converts a target health change for delivery. The entry
+// type is the new health state (healthy, unhealthy, ...).
+//
+// Params:
+//   - event: the target event.
+//
+// Returns:
+//   - Entry: the entry.
+func NewTargetEntry(event *target.Event) Entry {
+	// return converted entry
+	return Entry{
+		Time:   time.Now(),
+		Target: event.Target.ID,
+		Type:   string(event.NewState),
+		Labels: event.Target.Labels,
+	}
+}
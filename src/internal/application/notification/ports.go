@@ -0,0 +1,53 @@
+// Package notification provides the application service delivering lifecycle
+// events to external channels.
+package notification
+
+import (
+	"context"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// Sender delivers a message to a notification channel.
+// Infrastructure adapters implement this for each transport.
+type Sender interface {
+	// Send delivers the message to the channel.
+	//
+	// Params:
+	//   - ctx: context for cancellation.
+	//   - channel: the destination channel.
+	//   - msg: the message to deliver.
+	//
+	// Returns:
+	//   - error: if the message could not be delivered.
+	Send(ctx context.Context, channel *domainconfig.NotificationChannel, msg *Message) error
+}
+
+// ErrorHandler is called when a message could not be delivered.
+type ErrorHandler func(channel string, err error)
+
+// Pinger pings a heartbeat endpoint.
+// Infrastructure adapters implement this for each transport.
+type Pinger interface {
+	// Ping signals the endpoint that the daemon is alive.
+	//
+	// Params:
+	//   - ctx: context for cancellation.
+	//   - url: the endpoint URL.
+	//
+	// Returns:
+	//   - error: if the endpoint could not be pinged.
+	Ping(ctx context.Context, url string) error
+}
+
+// HealthSource reports whether the daemon and services are healthy.
+type HealthSource interface {
+	// Healthy reports whether the daemon and the given services are healthy.
+	//
+	// Params:
+	//   - services: the service names; empty checks the daemon only.
+	//
+	// Returns:
+	//   - bool: true if everything is healthy.
+	Healthy(services []string) bool
+}
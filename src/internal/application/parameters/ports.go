@@ -0,0 +1,36 @@
+// Package parameters provides the application service changing daemon
+// settings at runtime, without editing the configuration file.
+package parameters
+
+import (
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// Store persists the daemon parameters across restarts.
+type Store interface {
+	// Load returns the saved parameters, the zero value when none were saved.
+	Load() (domainconfig.DaemonParameters, error)
+	// Save replaces the saved parameters.
+	Save(params *domainconfig.DaemonParameters) error
+}
+
+// LevelSetter replaces the level of the daemon log writers.
+type LevelSetter interface {
+	// SetLevelOverride replaces the writer levels, nil restores the configured levels.
+	SetLevelOverride(level *domainlogging.Level)
+}
+
+// ProbeDefaulter changes the timing of the probes that configure none.
+type ProbeDefaulter interface {
+	// SetProbeDefaults changes the default interval and timeout, zero restores the built-in ones.
+	SetProbeDefaults(interval, timeout time.Duration)
+}
+
+// Muter stops and resumes notification delivery.
+type Muter interface {
+	// SetMuted stops delivering events while set.
+	SetMuted(muted bool)
+}
@@ -0,0 +1,131 @@
+// Package parameters provides the application service changing daemon
+// settings at runtime, without editing the configuration file.
+package parameters
+
+import (
+	"fmt"
+	"sync"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// Service holds the daemon parameters, saves them and applies them to the
+// daemon components. Components left nil are not configured.
+type Service struct {
+	// store persists the parameters.
+	store Store
+	// logger receives the log level, may be nil.
+	logger LevelSetter
+	// probes receive the default probe timing, may be nil.
+	probes ProbeDefaulter
+	// notifier receives the notification mute, may be nil.
+	notifier Muter
+	// mu serializes changes and protects current.
+	mu sync.Mutex
+	// current is the applied parameters.
+	current domainconfig.DaemonParameters
+}
+
+// NewService creates a parameters service.
+//
+// Params:
+//   - store: the parameters store.
+//   - logger: the daemon logger, nil when not configured.
+//   - probes: the owner of the probes, nil when not configured.
+//   - notifier: the notification dispatcher, nil when not configured.
+//
+// Returns:
+//   - *Service: the service, holding the zero parameters until Load.
+func NewService(store Store, logger LevelSetter, probes ProbeDefaulter, notifier Muter) *Service {
+	// return service with its components
+	return &Service{
+		store:    store,
+		logger:   logger,
+		probes:   probes,
+		notifier: notifier,
+	}
+}
+
+// Load reads the saved parameters and applies them, so changes made
+// before a restart still hold.
+//
+// Returns:
+//   - error: if the saved parameters cannot be read or are invalid.
+func (s *Service) Load() error {
+	params, err := s.store.Load()
+	// saved parameters unreadable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("loading daemon parameters: %w", err)
+	}
+	// refuse saved parameters edited into invalid values
+	if err := params.Validate(); err != nil {
+		// return error with context
+		return fmt.Errorf("loading daemon parameters: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apply(&params)
+	// parameters applied
+	return nil
+}
+
+// Parameters returns the applied parameters.
+//
+// Returns:
+//   - domainconfig.DaemonParameters: the current parameters.
+func (s *Service) Parameters() domainconfig.DaemonParameters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// return a copy of the current parameters
+	return s.current
+}
+
+// SetParameters replaces the parameters, saves them, then applies them.
+// Nothing changes when they are invalid or cannot be saved.
+//
+// Params:
+//   - params: the new parameters.
+//
+// Returns:
+//   - error: the validation or save error.
+func (s *Service) SetParameters(params *domainconfig.DaemonParameters) error {
+	// refuse invalid parameters
+	if err := params.Validate(); err != nil {
+		// return validation error
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// keep the change only once saved, to survive restarts
+	if err := s.store.Save(params); err != nil {
+		// return error with context
+		return fmt.Errorf("saving daemon parameters: %w", err)
+	}
+	s.apply(params)
+	// parameters changed
+	return nil
+}
+
+// apply hands the parameters to the components.
+// Must be called with s.mu held.
+//
+// Params:
+//   - params: the parameters to apply.
+func (s *Service) apply(params *domainconfig.DaemonParameters) {
+	s.current = *params
+	// replace the daemon log level
+	if s.logger != nil {
+		s.logger.SetLevelOverride(params.Level())
+	}
+	// change the timing of probes configuring none
+	if s.probes != nil {
+		s.probes.SetProbeDefaults(params.ProbeInterval.Duration(), params.ProbeTimeout.Duration())
+	}
+	// mute or resume notifications
+	if s.notifier != nil {
+		s.notifier.SetMuted(params.NotificationsMuted)
+	}
+}
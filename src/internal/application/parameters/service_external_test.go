@@ -0,0 +1,214 @@
+// Package parameters_test provides black-box tests for the parameters package.
+package parameters_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/parameters"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// errStore is returned by the failing store.
+var errStore error = errors.New("disk full")
+
+// memoryStore keeps the parameters in memory.
+type memoryStore struct {
+	saved domainconfig.DaemonParameters
+	err   error
+}
+
+// Load returns the saved parameters.
+//
+// Returns:
+//   - domainconfig.DaemonParameters: the saved parameters.
+//   - error: the configured error.
+func (m *memoryStore) Load() (domainconfig.DaemonParameters, error) {
+	return m.saved, m.err
+}
+
+// Save stores the parameters unless an error is configured.
+//
+// Params:
+//   - params: the parameters.
+//
+// Returns:
+//   - error: the configured error.
+func (m *memoryStore) Save(params *domainconfig.DaemonParameters) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.saved = *params
+	return nil
+}
+
+// components records what the service applied.
+type components struct {
+	level    *domainlogging.Level
+	interval time.Duration
+	timeout  time.Duration
+	muted    bool
+}
+
+// SetLevelOverride records the level.
+//
+// Params:
+//   - level: the level override.
+func (c *components) SetLevelOverride(level *domainlogging.Level) {
+	c.level = level
+}
+
+// SetProbeDefaults records the probe timing.
+//
+// Params:
+//   - interval: the default interval.
+//   - timeout: the default timeout.
+func (c *components) SetProbeDefaults(interval, timeout time.Duration) {
+	c.interval, c.timeout = interval, timeout
+}
+
+// SetMuted records the mute.
+//
+// Params:
+//   - muted: the mute state.
+func (c *components) SetMuted(muted bool) {
+	c.muted = muted
+}
+
+// TestService_SetParameters tests that valid parameters are saved and
+// applied, and that nothing changes otherwise.
+func TestService_SetParameters(t *testing.T) {
+	debug := domainlogging.LevelDebug
+	tests := []struct {
+		name      string
+		params    domainconfig.DaemonParameters
+		storeErr  error
+		wantErr   error
+		wantApply *components
+	}{
+		{
+			name: "applied and saved",
+			params: domainconfig.DaemonParameters{
+				LogLevel:           "debug",
+				ProbeInterval:      shared.Seconds(30),
+				ProbeTimeout:       shared.Seconds(2),
+				NotificationsMuted: true,
+			},
+			wantApply: &components{level: &debug, interval: 30 * time.Second, timeout: 2 * time.Second, muted: true},
+		},
+		{
+			name:    "invalid level",
+			params:  domainconfig.DaemonParameters{LogLevel: "verbose"},
+			wantErr: domainconfig.ErrInvalidParameterLevel,
+		},
+		{
+			name:    "negative timeout",
+			params:  domainconfig.DaemonParameters{ProbeTimeout: -1},
+			wantErr: domainconfig.ErrInvalidParameterProbe,
+		},
+		{
+			name:     "store failure",
+			params:   domainconfig.DaemonParameters{NotificationsMuted: true},
+			storeErr: errStore,
+			wantErr:  errStore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memoryStore{err: tt.storeErr}
+			applied := &components{}
+			service := parameters.NewService(store, applied, applied, applied)
+
+			err := service.SetParameters(&tt.params)
+
+			// rejected changes leave everything untouched
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, &components{}, applied)
+				assert.Equal(t, domainconfig.DaemonParameters{}, service.Parameters())
+				assert.Equal(t, domainconfig.DaemonParameters{}, store.saved)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantApply, applied)
+			assert.Equal(t, tt.params, service.Parameters())
+			assert.Equal(t, tt.params, store.saved)
+		})
+	}
+}
+
+// TestService_Load tests that saved parameters are applied at start.
+func TestService_Load(t *testing.T) {
+	tests := []struct {
+		name      string
+		saved     domainconfig.DaemonParameters
+		storeErr  error
+		wantErr   error
+		wantMuted bool
+	}{
+		{
+			name:      "saved parameters applied",
+			saved:     domainconfig.DaemonParameters{NotificationsMuted: true},
+			wantMuted: true,
+		},
+		{
+			name: "nothing saved",
+		},
+		{
+			name:     "unreadable store",
+			storeErr: errStore,
+			wantErr:  errStore,
+		},
+		{
+			name:    "invalid saved parameters",
+			saved:   domainconfig.DaemonParameters{LogLevel: "loud", NotificationsMuted: true},
+			wantErr: domainconfig.ErrInvalidParameterLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied := &components{}
+			service := parameters.NewService(&memoryStore{saved: tt.saved, err: tt.storeErr}, applied, applied, applied)
+
+			err := service.Load()
+
+			// failed loads apply nothing
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, &components{}, applied)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMuted, applied.muted)
+			assert.Nil(t, applied.level)
+			assert.Equal(t, tt.saved, service.Parameters())
+		})
+	}
+}
+
+// TestService_nilComponents tests that parameters are kept without components.
+func TestService_nilComponents(t *testing.T) {
+	tests := []struct {
+		name   string
+		params domainconfig.DaemonParameters
+	}{
+		{name: "every parameter", params: domainconfig.DaemonParameters{LogLevel: "warn", ProbeInterval: shared.Seconds(5), NotificationsMuted: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := parameters.NewService(&memoryStore{}, nil, nil, nil)
+
+			require.NoError(t, service.SetParameters(&tt.params))
+			assert.Equal(t, tt.params, service.Parameters())
+		})
+	}
+}
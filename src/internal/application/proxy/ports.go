@@ -0,0 +1,38 @@
+// Package proxy provides the application port for supervisor-owned listener proxies.
+package proxy
+
+// Opener binds the public endpoint of proxied listeners.
+// It is the port that infrastructure adapters implement for TCP forwarding.
+type Opener interface {
+	// Open binds the route public endpoint and starts forwarding connections.
+	//
+	// Params:
+	//   - route: the proxied listener.
+	//
+	// Returns:
+	//   - Relay: the running relay.
+	//   - error: if the public endpoint cannot be bound.
+	Open(route Route) (Relay, error)
+}
+
+// Relay is a bound public endpoint forwarding connections to a service.
+type Relay interface {
+	// Addr returns the bound public address.
+	//
+	// Returns:
+	//   - string: the address in host:port format.
+	Addr() string
+
+	// Drain refuses new connections and waits for in-flight ones to finish.
+	// Connections still open when the route drain timeout elapses are closed.
+	Drain()
+
+	// Resume admits new connections again after Drain.
+	Resume()
+
+	// Close stops accepting connections and closes forwarded connections.
+	//
+	// Returns:
+	//   - error: if the endpoint cannot be closed.
+	Close() error
+}
@@ -0,0 +1,35 @@
+// Package proxy provides the application port for supervisor-owned listener proxies.
+package proxy
+
+import "time"
+
+// Gate reports whether a new connection may be forwarded.
+type Gate func() bool
+
+// Route describes a proxied listener: the public endpoint bound by the
+// supervisor and the local endpoint of the service.
+type Route struct {
+	// Service is the owning service name.
+	Service string
+	// Listener is the proxied listener name.
+	Listener string
+	// Network is the stream network ("tcp", "tcp4" or "tcp6").
+	Network string
+	// ListenAddress is the public endpoint in host:port format.
+	ListenAddress string
+	// TargetAddress is the service endpoint in host:port format.
+	TargetAddress string
+	// Gate admits new connections; nil admits every connection.
+	Gate Gate
+	// DrainTimeout bounds how long in-flight connections may finish on Drain.
+	DrainTimeout time.Duration
+}
+
+// Admits reports whether a new connection may be forwarded now.
+//
+// Returns:
+//   - bool: true when the route has no gate or the gate is open.
+func (r *Route) Admits() bool {
+	// ungated routes always forward
+	return r.Gate == nil || r.Gate()
+}
@@ -0,0 +1,39 @@
+// Package proxy_test provides black-box tests for the proxy package.
+package proxy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/application/proxy"
+)
+
+// TestRoute_Admits tests connection admission through the route gate.
+//
+// Params:
+//   - t: the testing context.
+func TestRoute_Admits(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// gate is the route gate.
+		gate proxy.Gate
+		// want is the expected admission.
+		want bool
+	}{
+		{name: "no_gate", gate: nil, want: true},
+		{name: "open_gate", gate: func() bool { return true }, want: true},
+		{name: "closed_gate", gate: func() bool { return false }, want: false},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			route := proxy.Route{Service: "api", Listener: "http", Gate: tt.gate}
+
+			assert.Equal(t, tt.want, route.Admits())
+		})
+	}
+}
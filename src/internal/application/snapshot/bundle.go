@@ -0,0 +1,26 @@
+// Package snapshot provides the application service saving and restoring
+// the configuration and state of the daemon as a single bundle.
+package snapshot
+
+import (
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// FormatVersion is the version of the bundle layout written by Save.
+const FormatVersion int = 1
+
+// Bundle is the saved configuration and state of a daemon.
+type Bundle struct {
+	// Format is the version of the bundle layout.
+	Format int
+	// DaemonVersion is the version of the daemon that saved the bundle.
+	DaemonVersion string
+	// CreatedAt is when the bundle was saved.
+	CreatedAt time.Time
+	// Config is the effective configuration.
+	Config []byte
+	// Stats are the persisted service statistics, ordered by service name.
+	Stats []storage.ServiceStatsRecord
+}
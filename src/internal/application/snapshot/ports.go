@@ -0,0 +1,32 @@
+// Package snapshot provides the application service saving and restoring
+// the configuration and state of the daemon as a single bundle.
+package snapshot
+
+import (
+	"io"
+
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// ConfigStore renders and replaces configuration files.
+type ConfigStore interface {
+	// Render returns the effective configuration of a file as one document,
+	// with includes, templates and defaults resolved.
+	Render(path string) ([]byte, error)
+	// Replace validates a configuration and writes it over a file.
+	Replace(path string, data []byte) error
+}
+
+// StatsStore persists service statistics and lists them.
+type StatsStore interface {
+	storage.StatsStore
+	storage.StatsLister
+}
+
+// Archive encodes bundles.
+type Archive interface {
+	// Write encodes a bundle.
+	Write(w io.Writer, bundle *Bundle) error
+	// Read decodes a bundle, refusing unknown formats.
+	Read(r io.Reader) (*Bundle, error)
+}
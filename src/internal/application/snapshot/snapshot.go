@@ -0,0 +1,125 @@
+// Package snapshot provides the application service saving and restoring
+// the configuration and state of the daemon as a single bundle.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Service saves and restores daemon bundles, to migrate a host or clone an
+// environment.
+type Service struct {
+	// configs renders and replaces the configuration file.
+	configs ConfigStore
+	// archive encodes bundles.
+	archive Archive
+	// stats holds the persisted service statistics, nil when none are kept.
+	stats StatsStore
+	// version is the daemon version recorded in saved bundles.
+	version string
+}
+
+// NewService creates a snapshot service.
+//
+// Params:
+//   - configs: the configuration store.
+//   - archive: the bundle encoding.
+//   - stats: the statistics store, nil when statistics are not persisted.
+//   - version: the daemon version recorded in saved bundles.
+//
+// Returns:
+//   - *Service: the snapshot service.
+func NewService(configs ConfigStore, archive Archive, stats StatsStore, version string) *Service {
+	// return service with its adapters
+	return &Service{
+		configs: configs,
+		archive: archive,
+		stats:   stats,
+		version: version,
+	}
+}
+
+// Save writes a bundle of the effective configuration of a file and of the
+// persisted service statistics.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - configPath: the configuration file.
+//   - w: the bundle destination.
+//
+// Returns:
+//   - *Bundle: the saved bundle.
+//   - error: if the configuration is invalid, or reading or writing fails.
+func (s *Service) Save(ctx context.Context, configPath string, w io.Writer) (*Bundle, error) {
+	cfg, err := s.configs.Render(configPath)
+	// configuration unreadable or invalid
+	if err != nil {
+		// return configuration error
+		return nil, err
+	}
+	bundle := &Bundle{
+		Format:        FormatVersion,
+		DaemonVersion: s.version,
+		CreatedAt:     time.Now().UTC(),
+		Config:        cfg,
+	}
+	// add the persisted statistics
+	if s.stats != nil {
+		// propagate store read error
+		if bundle.Stats, err = s.stats.ListServiceStats(ctx); err != nil {
+			// return error with context
+			return nil, fmt.Errorf("reading service statistics: %w", err)
+		}
+	}
+	// propagate encoding error
+	if err := s.archive.Write(w, bundle); err != nil {
+		// return error with context
+		return nil, fmt.Errorf("writing snapshot: %w", err)
+	}
+	// return saved bundle
+	return bundle, nil
+}
+
+// Restore reads a bundle, replaces the configuration file with its
+// configuration, then restores its service statistics. Nothing is written
+// when the bundle or its configuration is invalid.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - r: the bundle source.
+//   - configPath: the configuration file to replace.
+//
+// Returns:
+//   - *Bundle: the restored bundle.
+//   - error: if the bundle is invalid, or writing fails.
+func (s *Service) Restore(ctx context.Context, r io.Reader, configPath string) (*Bundle, error) {
+	bundle, err := s.archive.Read(r)
+	// bundle unreadable
+	if err != nil {
+		// return error with context
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	// propagate invalid configuration or write error
+	if err := s.configs.Replace(configPath, bundle.Config); err != nil {
+		// return configuration error
+		return nil, err
+	}
+	// Skip statistics without store.
+	if s.stats == nil {
+		// Configuration restored only.
+		return bundle, nil
+	}
+	// restore each service record
+	for i := range bundle.Stats {
+		// propagate store write error
+		if err := s.stats.SaveServiceStats(ctx, &bundle.Stats[i]); err != nil {
+			// return error naming the service
+			return nil, fmt.Errorf("restoring statistics of %s: %w", bundle.Stats[i].Service, err)
+		}
+	}
+	// return restored bundle
+	return bundle, nil
+}
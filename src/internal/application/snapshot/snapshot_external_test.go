@@ -0,0 +1,207 @@
+// Package snapshot_test provides black-box tests for the snapshot service.
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/snapshot"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// errInvalidConfig is returned by fakeConfigStore for invalid configurations.
+var errInvalidConfig error = errors.New("invalid config")
+
+// fakeConfigStore keeps configuration files in memory.
+type fakeConfigStore struct {
+	// files are the configuration files, by path.
+	files map[string][]byte
+}
+
+// Render returns the stored file.
+//
+// Params:
+//   - path: the file path.
+//
+// Returns:
+//   - []byte: the file content.
+//   - error: errInvalidConfig for unknown files.
+func (c *fakeConfigStore) Render(path string) ([]byte, error) {
+	data, ok := c.files[path]
+	if !ok {
+		return nil, errInvalidConfig
+	}
+	return data, nil
+}
+
+// Replace stores non-empty files.
+//
+// Params:
+//   - path: the file path.
+//   - data: the file content.
+//
+// Returns:
+//   - error: errInvalidConfig for empty content.
+func (c *fakeConfigStore) Replace(path string, data []byte) error {
+	if len(data) == 0 {
+		return errInvalidConfig
+	}
+	c.files[path] = data
+	return nil
+}
+
+// fakeArchive keeps the last written bundle.
+type fakeArchive struct {
+	// bundle is the last written bundle.
+	bundle *snapshot.Bundle
+}
+
+// Write keeps the bundle.
+//
+// Params:
+//   - w: the destination (unused).
+//   - bundle: the bundle.
+//
+// Returns:
+//   - error: always nil.
+func (a *fakeArchive) Write(_ io.Writer, bundle *snapshot.Bundle) error {
+	a.bundle = bundle
+	return nil
+}
+
+// Read returns the kept bundle.
+//
+// Params:
+//   - r: the source (unused).
+//
+// Returns:
+//   - *snapshot.Bundle: the kept bundle.
+//   - error: always nil.
+func (a *fakeArchive) Read(_ io.Reader) (*snapshot.Bundle, error) {
+	return a.bundle, nil
+}
+
+// memoryStatsStore keeps statistics in memory.
+type memoryStatsStore struct {
+	// records are the saved records, by service.
+	records map[string]storage.ServiceStatsRecord
+}
+
+// SaveServiceStats stores the record.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - rec: the record.
+//
+// Returns:
+//   - error: always nil.
+func (m *memoryStatsStore) SaveServiceStats(_ context.Context, rec *storage.ServiceStatsRecord) error {
+	m.records[rec.Service] = *rec
+	return nil
+}
+
+// LoadServiceStats returns the record of a service.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - service: the service name.
+//
+// Returns:
+//   - storage.ServiceStatsRecord: the record.
+//   - bool: whether it was saved.
+//   - error: always nil.
+func (m *memoryStatsStore) LoadServiceStats(_ context.Context, service string) (storage.ServiceStatsRecord, bool, error) {
+	rec, ok := m.records[service]
+	return rec, ok, nil
+}
+
+// ListServiceStats returns every record by service name.
+//
+// Params:
+//   - ctx: the context (unused).
+//
+// Returns:
+//   - []storage.ServiceStatsRecord: the records.
+//   - error: always nil.
+func (m *memoryStatsStore) ListServiceStats(_ context.Context) ([]storage.ServiceStatsRecord, error) {
+	var records []storage.ServiceStatsRecord
+	for _, name := range slices.Sorted(maps.Keys(m.records)) {
+		records = append(records, m.records[name])
+	}
+	return records, nil
+}
+
+// TestService_SaveRestore tests that a saved bundle restores the configuration and statistics.
+//
+// Params:
+//   - t: the testing context.
+func TestService_SaveRestore(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// withStats enables the statistics store.
+		withStats bool
+		// wantStats is the expected number of restored records.
+		wantStats int
+	}{
+		{name: "configuration and statistics", withStats: true, wantStats: 2},
+		{name: "configuration only", withStats: false, wantStats: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			source := &fakeConfigStore{files: map[string][]byte{"/etc/daemon/config.yaml": []byte("services: []\n")}}
+			archive := &fakeArchive{}
+			var stats snapshot.StatsStore
+			if tt.withStats {
+				stats = &memoryStatsStore{records: map[string]storage.ServiceStatsRecord{
+					"web": {Service: "web", StartCount: 2},
+					"api": {Service: "api", StartCount: 5},
+				}}
+			}
+
+			saved, err := snapshot.NewService(source, archive, stats, "1.2.3").Save(ctx, "/etc/daemon/config.yaml", &bytes.Buffer{})
+			require.NoError(t, err)
+			assert.Equal(t, snapshot.FormatVersion, saved.Format)
+			assert.Equal(t, "1.2.3", saved.DaemonVersion)
+			require.Len(t, saved.Stats, tt.wantStats)
+
+			target := &fakeConfigStore{files: map[string][]byte{}}
+			restoredStats := &memoryStatsStore{records: map[string]storage.ServiceStatsRecord{}}
+			var targetStats snapshot.StatsStore
+			if tt.withStats {
+				targetStats = restoredStats
+			}
+			_, err = snapshot.NewService(target, archive, targetStats, "1.2.3").Restore(ctx, &bytes.Buffer{}, "/srv/config.yaml")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("services: []\n"), target.files["/srv/config.yaml"])
+			assert.Len(t, restoredStats.records, tt.wantStats)
+		})
+	}
+}
+
+// TestService_Restore_invalidConfig tests that invalid configurations restore nothing.
+//
+// Params:
+//   - t: the testing context.
+func TestService_Restore_invalidConfig(t *testing.T) {
+	archive := &fakeArchive{bundle: &snapshot.Bundle{
+		Format: snapshot.FormatVersion,
+		Stats:  []storage.ServiceStatsRecord{{Service: "api"}},
+	}}
+	stats := &memoryStatsStore{records: map[string]storage.ServiceStatsRecord{}}
+
+	_, err := snapshot.NewService(&fakeConfigStore{files: map[string][]byte{}}, archive, stats, "dev").
+		Restore(context.Background(), &bytes.Buffer{}, "/srv/config.yaml")
+	require.ErrorIs(t, err, errInvalidConfig)
+	assert.Empty(t, stats.records)
+}
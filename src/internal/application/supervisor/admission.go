@@ -0,0 +1,147 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file admits service starts against the host capacity.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+var (
+	// ErrAdmissionRefused is returned when a start would reserve more than the admitted host capacity.
+	ErrAdmissionRefused error = shared.NewCodedError(shared.CodeAdmissionRefused, "start refused: reservation exceeds admitted host capacity")
+	// ErrOvercommitted is attached to overcommitted events of services started under the warn action.
+	ErrOvercommitted error = fmt.Errorf("reservation exceeds admitted host capacity")
+)
+
+// SetCapacityCollector sets the collector measuring the host capacity that
+// service reservations are admitted against. Without it, every start is admitted.
+//
+// Params:
+//   - collector: the host capacity collector.
+func (s *Supervisor) SetCapacityCollector(collector appmetrics.CapacityCollector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store capacity collector
+	s.capacityCollector = collector
+}
+
+// refreshCapacity measures the host capacity before services are started.
+// On failure, admission is disabled until the next measure.
+//
+// Params:
+//   - ctx: context for cancellation.
+func (s *Supervisor) refreshCapacity(ctx context.Context) {
+	s.mu.RLock()
+	collector := s.capacityCollector
+	s.mu.RUnlock()
+	// Admission is disabled without collector.
+	if collector == nil {
+		// Nothing to measure.
+		return
+	}
+	capacity, err := collector.CollectCapacity(ctx)
+	// Report the failure and admit every start.
+	if err != nil {
+		s.handleRecoveryError("collect-capacity", "", err)
+		capacity = domainmetrics.HostCapacity{}
+	}
+	s.mu.Lock()
+	s.capacity = capacity
+	s.mu.Unlock()
+}
+
+// admit checks the reservation of a service against the admitted host
+// capacity, counting the services of cfg whose manager is supervised.
+// Must be called with s.mu held.
+//
+// Params:
+//   - cfg: the configuration holding the reservations.
+//   - name: the service about to start.
+//
+// Returns:
+//   - *domain.Event: the overcommitted event to emit, nil when admitted.
+//   - bool: true if the start must be refused.
+func (s *Supervisor) admit(cfg *domainconfig.Config, name string) (*domain.Event, bool) {
+	// Admission is disabled without configuration or capacity.
+	if cfg == nil || s.capacity.IsZero() {
+		// Admitted.
+		return nil, false
+	}
+	svc := cfg.FindService(name)
+	// Only services declaring a reservation are checked.
+	if svc == nil || svc.Reservation.IsZero() {
+		// Admitted.
+		return nil, false
+	}
+	memory, cpu := svc.Reservation.MemoryBytes(), svc.Reservation.CPU
+	// Add the reservations of the other supervised services.
+	for i := range cfg.Services {
+		other := &cfg.Services[i]
+		mgr := s.managers[other.Name]
+		// Stopped services reserve nothing.
+		if other.Name == name || mgr == nil || !mgr.Supervised() {
+			continue
+		}
+		memory += other.Reservation.MemoryBytes()
+		cpu += other.Reservation.CPU
+	}
+
+	ratio := cfg.Admission.EffectiveOvercommit()
+	var over []string
+	// Compare reserved memory with the admitted memory.
+	if admitted := float64(s.capacity.Memory) * ratio; s.capacity.Memory > 0 && float64(memory) > admitted {
+		over = append(over, fmt.Sprintf("memory %s of %s", shared.FormatSize(memory), shared.FormatSize(int64(admitted))))
+	}
+	// Compare reserved CPUs with the admitted CPUs.
+	if admitted := s.capacity.CPUs * ratio; s.capacity.CPUs > 0 && cpu > admitted {
+		over = append(over, fmt.Sprintf("cpu %.2f of %.2f", cpu, admitted))
+	}
+	// Within capacity.
+	if len(over) == 0 {
+		// Admitted.
+		return nil, false
+	}
+
+	refused := cfg.Admission.Refuses()
+	cause := ErrOvercommitted
+	// Refused starts carry the coded error.
+	if refused {
+		cause = ErrAdmissionRefused
+	}
+	event := domain.NewEvent(domain.EventOvercommitted, name, 0, 0, fmt.Errorf("%w: %s", cause, strings.Join(over, ", ")))
+	// return over-committing start
+	return &event, refused
+}
+
+// emitGated hands the skipped and overcommitted events of gated starts to
+// the event pipeline. Must be called without s.mu held.
+//
+// Params:
+//   - events: the events returned by gateStart.
+func (s *Supervisor) emitGated(events []*domain.Event) {
+	// Emit each event in order.
+	for _, event := range events {
+		s.handleEvent(event.Process, event)
+	}
+}
+
+// admissionRefused reports whether an event records a refused start.
+//
+// Params:
+//   - event: the process event.
+//
+// Returns:
+//   - bool: true for overcommitted events of refused starts.
+func admissionRefused(event *domain.Event) bool {
+	// only refused starts carry the coded error
+	return event.Type == domain.EventOvercommitted && errors.Is(event.Error, ErrAdmissionRefused)
+}
@@ -0,0 +1,279 @@
+// Package supervisor provides internal tests for admission.go.
+// It tests the admission of service reservations using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// capacityTestCollector returns a fixed host capacity.
+type capacityTestCollector struct {
+	// capacity is the returned capacity.
+	capacity domainmetrics.HostCapacity
+	// err is the returned error.
+	err error
+}
+
+// CollectCapacity returns the fixed capacity.
+//
+// Returns:
+//   - domainmetrics.HostCapacity: the configured capacity.
+//   - error: the configured error.
+func (c *capacityTestCollector) CollectCapacity(_ context.Context) (domainmetrics.HostCapacity, error) {
+	return c.capacity, c.err
+}
+
+// newAdmissionTestSupervisor creates a supervisor with "db" reserving 1GB
+// and 1 CPU, and "api" reserving 512MB and 0.5 CPU, on a 1.5GB, 2-CPU host.
+//
+// Params:
+//   - t: the testing context.
+//   - admission: the admission settings.
+//
+// Returns:
+//   - *Supervisor: the supervisor under test.
+//   - *phaseTestExecutor: the executor recording launches.
+func newAdmissionTestSupervisor(t *testing.T, admission domainconfig.AdmissionConfig) (*Supervisor, *phaseTestExecutor) {
+	t.Helper()
+	cfg := &domainconfig.Config{Admission: admission, Services: []domainconfig.ServiceConfig{
+		{Name: "db", Command: "/bin/db", Reservation: domainconfig.ReservationConfig{Memory: "1GB", CPU: 1}},
+		{Name: "api", Command: "/bin/api", Reservation: domainconfig.ReservationConfig{Memory: "512MB", CPU: 0.5}},
+		{Name: "cron", Command: "/bin/cron"},
+	}}
+
+	executor := &phaseTestExecutor{}
+	managers := make(map[string]*applifecycle.Manager, len(cfg.Services))
+	for i := range cfg.Services {
+		managers[cfg.Services[i].Name] = applifecycle.NewManager(&cfg.Services[i], executor)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := &Supervisor{
+		config:   cfg,
+		managers: managers,
+		stats:    make(map[string]*ServiceStats),
+		ctx:      ctx,
+		capacity: domainmetrics.HostCapacity{Memory: 1536 * 1024 * 1024, CPUs: 2},
+	}
+	t.Cleanup(func() {
+		for _, mgr := range managers {
+			_ = mgr.Stop()
+		}
+	})
+	return s, executor
+}
+
+// Test_Supervisor_admit tests the admission of a reservation against the capacity.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_admit(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// admission is the admission settings.
+		admission domainconfig.AdmissionConfig
+		// capacity overrides the host capacity when set.
+		capacity *domainmetrics.HostCapacity
+		// running lists the services started before the check.
+		running []string
+		// service is the service about to start.
+		service string
+		// wantEvent indicates whether an overcommitted event is expected.
+		wantEvent bool
+		// wantRefused indicates whether the start must be refused.
+		wantRefused bool
+		// wantDetail is a substring of the event error.
+		wantDetail string
+	}{
+		{
+			name:    "within_capacity",
+			running: []string{"db"},
+			service: "api",
+		},
+		{
+			name:    "stopped_services_reserve_nothing",
+			service: "api",
+		},
+		{
+			name:    "without_reservation",
+			running: []string{"db", "api"},
+			service: "cron",
+		},
+		{
+			name:     "unknown_capacity_disables_admission",
+			capacity: &domainmetrics.HostCapacity{},
+			running:  []string{"db", "api"},
+			service:  "db",
+		},
+		{
+			name:        "memory_over_capacity_refused",
+			admission:   domainconfig.AdmissionConfig{},
+			capacity:    &domainmetrics.HostCapacity{Memory: 1024 * 1024 * 1024, CPUs: 8},
+			running:     []string{"db"},
+			service:     "api",
+			wantEvent:   true,
+			wantRefused: true,
+			wantDetail:  "memory",
+		},
+		{
+			name:       "cpu_over_capacity_warned",
+			admission:  domainconfig.AdmissionConfig{Action: domainconfig.AdmissionWarn},
+			capacity:   &domainmetrics.HostCapacity{CPUs: 1},
+			running:    []string{"db"},
+			service:    "api",
+			wantEvent:  true,
+			wantDetail: "cpu 1.50 of 1.00",
+		},
+		{
+			name:      "overcommit_ratio_admits",
+			admission: domainconfig.AdmissionConfig{Overcommit: 2},
+			capacity:  &domainmetrics.HostCapacity{Memory: 1024 * 1024 * 1024, CPUs: 1},
+			running:   []string{"db"},
+			service:   "api",
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newAdmissionTestSupervisor(t, tt.admission)
+			// Override the host capacity.
+			if tt.capacity != nil {
+				s.capacity = *tt.capacity
+			}
+			// Start the services counted against the capacity.
+			for _, name := range tt.running {
+				require.NoError(t, s.managers[name].Start(s.ctx))
+			}
+
+			s.mu.RLock()
+			event, refused := s.admit(s.config, tt.service)
+			s.mu.RUnlock()
+
+			assert.Equal(t, tt.wantRefused, refused)
+			// Admitted starts carry no event.
+			if !tt.wantEvent {
+				assert.Nil(t, event)
+				return
+			}
+			require.NotNil(t, event)
+			assert.Equal(t, domain.EventOvercommitted, event.Type)
+			assert.Equal(t, tt.service, event.Process)
+			assert.Contains(t, event.Error.Error(), tt.wantDetail)
+			assert.Equal(t, tt.wantRefused, errors.Is(event.Error, ErrAdmissionRefused))
+			assert.Equal(t, !tt.wantRefused, errors.Is(event.Error, ErrOvercommitted))
+		})
+	}
+}
+
+// Test_Supervisor_StartService_admission tests that a refused start leaves the service stopped.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_StartService_admission(t *testing.T) {
+	s, executor := newAdmissionTestSupervisor(t, domainconfig.AdmissionConfig{})
+	s.capacity = domainmetrics.HostCapacity{Memory: 1024 * 1024 * 1024}
+	var events []domain.EventType
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		events = append(events, event.Type)
+	}
+
+	require.NoError(t, s.StartService("db"))
+	err := s.StartService("api")
+
+	require.ErrorIs(t, err, ErrAdmissionRefused)
+	assert.Equal(t, shared.CodeAdmissionRefused, shared.CodeOf(err))
+	assert.False(t, s.managers["api"].Supervised())
+	assert.Equal(t, []domain.EventType{domain.EventOvercommitted}, events)
+	assert.Eventually(t, func() bool { return len(executor.launched()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"db"}, executor.launched())
+}
+
+// Test_Supervisor_updateBoot_admission tests that refused starts fail the boot.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_updateBoot_admission(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// cause is the error wrapped by the overcommitted event.
+		cause error
+		// want is the expected boot status of the service.
+		want domainlifecycle.BootStatus
+	}{
+		{name: "refused_fails", cause: ErrAdmissionRefused, want: domainlifecycle.BootFailed},
+		{name: "warned_unchanged", cause: ErrOvercommitted, want: domainlifecycle.BootPending},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newAdmissionTestSupervisor(t, domainconfig.AdmissionConfig{})
+			s.beginBoot()
+			event := domain.NewEvent(domain.EventOvercommitted, "api", 0, 0, tt.cause)
+
+			s.mu.Lock()
+			s.updateBoot("api", &event)
+			s.mu.Unlock()
+
+			report := s.BootReport()
+			assert.Equal(t, tt.want, report.Services[1].Status)
+		})
+	}
+}
+
+// Test_Supervisor_refreshCapacity tests that a collector failure disables admission.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_refreshCapacity(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// collector is the capacity collector.
+		collector *capacityTestCollector
+		// want is the expected capacity.
+		want domainmetrics.HostCapacity
+	}{
+		{
+			name:      "measured",
+			collector: &capacityTestCollector{capacity: domainmetrics.HostCapacity{Memory: 1024, CPUs: 4}},
+			want:      domainmetrics.HostCapacity{Memory: 1024, CPUs: 4},
+		},
+		{
+			name:      "failure_disables_admission",
+			collector: &capacityTestCollector{capacity: domainmetrics.HostCapacity{Memory: 1024}, err: errors.New("no meminfo")},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Supervisor{capacity: domainmetrics.HostCapacity{Memory: 1, CPUs: 1}}
+			s.SetCapacityCollector(tt.collector)
+
+			s.refreshCapacity(context.Background())
+
+			assert.Equal(t, tt.want, s.capacity)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file reloads services in place, through their own reload mechanism.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// reloadPollInterval is how often probe results are checked after a reload.
+const reloadPollInterval time.Duration = 250 * time.Millisecond
+
+// In-place reload errors.
+var (
+	// ErrReloadUnverified is returned when the probes did not pass after a reload.
+	ErrReloadUnverified error = fmt.Errorf("probes did not pass after reload")
+	// ErrReloadProcessExited is returned when the process exited during a reload.
+	ErrReloadProcessExited error = fmt.Errorf("process exited during reload")
+)
+
+// ReloadService makes a running service reload its own configuration,
+// unlike RestartService: the reload_command is run, or the reload_signal
+// is sent. Services with health probes are reloaded once every probe
+// passed again after the trigger. The outcome is reported as a reloaded
+// or reload_failed event.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - error: ErrServiceNotFound, the not running error, or why the reload
+//     could not be triggered or verified.
+func (s *Supervisor) ReloadService(name string) error {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	svc := s.config.FindService(name)
+	monitor := s.healthMonitors[name]
+	ctx := s.ctx
+	s.mu.RUnlock()
+
+	// validate service exists
+	if !ok || svc == nil {
+		// Return error for missing service.
+		return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	pid := mgr.PID()
+	// Only a running process can reload.
+	if pid == 0 || !mgr.State().IsRunning() {
+		// Return not running error.
+		return domain.ErrNotRunning
+	}
+	// Use context from supervisor or fallback to Background
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, svc.AppReloadTimeout())
+	defer cancel()
+
+	triggered := time.Now()
+	err := s.triggerAppReload(ctx, svc, mgr, pid)
+	// Verify the reload through the probes.
+	if err == nil {
+		err = awaitAppReload(ctx, mgr, pid, monitor, triggered)
+	}
+	s.reportAppReload(name, pid, err)
+	// return reload outcome
+	return err
+}
+
+// triggerAppReload runs the reload command or sends the reload signal.
+//
+// Params:
+//   - ctx: the reload context, bounding the command.
+//   - svc: the service configuration.
+//   - mgr: the service manager.
+//   - pid: the running process ID.
+//
+// Returns:
+//   - error: the command or signal error.
+func (s *Supervisor) triggerAppReload(ctx context.Context, svc *domainconfig.ServiceConfig, mgr *applifecycle.Manager, pid int) error {
+	program, args := svc.AppReloadCommand()
+	// reload by signal
+	if program == "" {
+		// send the reload signal
+		return mgr.Signal(svc.AppReloadSignal())
+	}
+
+	s.mu.RLock()
+	runner := s.hookRunner
+	s.mu.RUnlock()
+
+	// Reload commands need a runner.
+	if runner == nil {
+		// Nothing to run with.
+		return ErrNoHookRunner
+	}
+	// run the reload command
+	return runner.Run(ctx, apphook.Command{
+		Path: program,
+		Args: args,
+		Env: []string{
+			"SUPERVIZIO_SERVICE=" + svc.Name,
+			"SUPERVIZIO_PID=" + strconv.Itoa(pid),
+		},
+		Timeout: svc.AppReloadTimeout(),
+	})
+}
+
+// awaitAppReload waits until every probe of the service passed after the
+// reload was triggered. Services without probes are reloaded at once.
+//
+// Params:
+//   - ctx: the reload context, bounding the wait.
+//   - mgr: the service manager.
+//   - pid: the process ID the reload was sent to.
+//   - monitor: the health monitor of the service, nil without probes.
+//   - triggered: when the reload was triggered.
+//
+// Returns:
+//   - error: ErrReloadProcessExited or ErrReloadUnverified.
+func awaitAppReload(ctx context.Context, mgr *applifecycle.Manager, pid int, monitor *apphealth.ProbeMonitor, triggered time.Time) error {
+	// Nothing to verify without probes.
+	if monitor == nil {
+		// Reload done.
+		return nil
+	}
+	ticker := time.NewTicker(reloadPollInterval)
+	defer ticker.Stop()
+	// poll until the probes confirm the reload
+	for {
+		// A reload must not replace the process.
+		if mgr.PID() != pid {
+			// Return exit error.
+			return ErrReloadProcessExited
+		}
+		// Done once every probe passed since the trigger.
+		if probesPassedSince(monitor.Health(), triggered) {
+			// Reload verified.
+			return nil
+		}
+		select {
+		// timeout or shutdown
+		case <-ctx.Done():
+			// Return unverified error with the cause.
+			return fmt.Errorf("%w: %w", ErrReloadUnverified, ctx.Err())
+		// check again
+		case <-ticker.C:
+		}
+	}
+}
+
+// probesPassedSince reports whether the last result of every probe is a
+// success obtained after the given time.
+//
+// Params:
+//   - health: the aggregated health of the service.
+//   - since: the earliest accepted probe time.
+//
+// Returns:
+//   - bool: true if every probe passed since then.
+func probesPassedSince(health *domainhealth.AggregatedHealth, since time.Time) bool {
+	// check each probed subject
+	for i := range health.Subjects {
+		result := health.Subjects[i].LastProbeResult
+		// probe not run since the trigger, or failed
+		if result == nil || result.Timestamp.Before(since) || result.Status != domainhealth.StatusHealthy {
+			// Not verified yet.
+			return false
+		}
+	}
+	// every probe passed
+	return true
+}
+
+// reportAppReload emits the reloaded or reload_failed event of a service.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the process ID the reload was sent to.
+//   - err: the reload error, nil on success.
+func (s *Supervisor) reportAppReload(name string, pid int, err error) {
+	s.mu.RLock()
+	snap := s.getStatsSnapshot(s.stats[name])
+	s.mu.RUnlock()
+
+	eventType := domain.EventReloaded
+	// failed reload
+	if err != nil {
+		eventType = domain.EventReloadFailed
+	}
+	event := domain.NewEvent(eventType, name, pid, 0, err)
+	s.callEventHandler(name, &event, snap)
+}
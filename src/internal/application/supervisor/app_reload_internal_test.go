@@ -0,0 +1,169 @@
+// Package supervisor provides internal tests for app_reload.go.
+// It tests in-place reloads using white-box testing.
+package supervisor
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_Supervisor_ReloadService tests in-place reloads by signal and by command.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ReloadService(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// svc is the service configuration.
+		svc domainconfig.ServiceConfig
+		// withRunner indicates whether a hook runner is set.
+		withRunner bool
+		// errIs is the expected error.
+		errIs error
+		// check verifies the reload trigger.
+		check func(t *testing.T, executor *countingExecutor, runner *fakeHookRunner)
+	}{
+		{
+			name: "default_signal",
+			svc:  domainconfig.ServiceConfig{Name: "nginx", Command: "/usr/sbin/nginx"},
+			check: func(t *testing.T, executor *countingExecutor, _ *fakeHookRunner) {
+				assert.Equal(t, syscall.SIGHUP, <-executor.signals)
+			},
+		},
+		{
+			name: "configured_signal",
+			svc:  domainconfig.ServiceConfig{Name: "nginx", Command: "/usr/sbin/nginx", ReloadSignal: "usr2"},
+			check: func(t *testing.T, executor *countingExecutor, _ *fakeHookRunner) {
+				assert.Equal(t, syscall.SIGUSR2, <-executor.signals)
+			},
+		},
+		{
+			name:       "command",
+			svc:        domainconfig.ServiceConfig{Name: "nginx", Command: "/usr/sbin/nginx", ReloadCommand: "/usr/sbin/nginx -s reload"},
+			withRunner: true,
+			check: func(t *testing.T, executor *countingExecutor, runner *fakeHookRunner) {
+				assert.Empty(t, executor.signals)
+				require.Len(t, runner.commands, 1)
+				assert.Equal(t, apphook.Command{
+					Path:    "/usr/sbin/nginx",
+					Args:    []string{"-s", "reload"},
+					Env:     []string{"SUPERVIZIO_SERVICE=nginx", "SUPERVIZIO_PID=4242"},
+					Timeout: domainconfig.DefaultReloadTimeout,
+				}, runner.commands[0])
+			},
+		},
+		{
+			name:  "command_without_runner",
+			svc:   domainconfig.ServiceConfig{Name: "nginx", Command: "/usr/sbin/nginx", ReloadCommand: "/usr/sbin/nginx -s reload"},
+			errIs: ErrNoHookRunner,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{tt.svc}}
+			s, executor, _, events := newWatchTestSupervisor(t, cfg)
+			runner := &fakeHookRunner{}
+			// Install the runner when required.
+			if tt.withRunner {
+				s.SetHookRunner(runner)
+			}
+
+			err := s.ReloadService("nginx")
+
+			delivered := events()
+			require.Len(t, delivered, 1)
+			assert.Equal(t, 4242, delivered[0].PID)
+			// Check if error is expected.
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.Equal(t, domain.EventReloadFailed, delivered[0].Type)
+				assert.ErrorIs(t, delivered[0].Error, tt.errIs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domain.EventReloaded, delivered[0].Type)
+			// The service is not restarted.
+			assert.Equal(t, int32(1), executor.starts.Load())
+			tt.check(t, executor, runner)
+		})
+	}
+}
+
+// Test_Supervisor_ReloadService_errors tests reloads refused before any trigger.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ReloadService_errors(t *testing.T) {
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{{Name: "api", Command: "/bin/api"}}}
+	executor := &countingExecutor{signals: make(chan os.Signal, 1)}
+	s := &Supervisor{
+		config:   cfg,
+		managers: map[string]*applifecycle.Manager{"api": applifecycle.NewManager(&cfg.Services[0], executor)},
+	}
+	var delivered []domain.Event
+	s.SetEventHandler(func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		delivered = append(delivered, *event)
+	})
+
+	// Unknown services are not found.
+	assert.ErrorIs(t, s.ReloadService("missing"), ErrServiceNotFound)
+
+	// Stopped services cannot reload.
+	assert.ErrorIs(t, s.ReloadService("api"), domain.ErrNotRunning)
+	assert.Empty(t, executor.signals)
+	assert.Empty(t, delivered)
+}
+
+// Test_probesPassedSince tests the probe check confirming a reload.
+//
+// Params:
+//   - t: the testing context.
+func Test_probesPassedSince(t *testing.T) {
+	triggered := time.Now()
+	passed := &domainhealth.Result{Status: domainhealth.StatusHealthy, Timestamp: triggered.Add(time.Second)}
+	stale := &domainhealth.Result{Status: domainhealth.StatusHealthy, Timestamp: triggered.Add(-time.Second)}
+	failed := &domainhealth.Result{Status: domainhealth.StatusUnhealthy, Timestamp: triggered.Add(time.Second)}
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// results are the last probe results of each subject.
+		results []*domainhealth.Result
+		// expected is the expected result.
+		expected bool
+	}{
+		{name: "no_probes", results: nil, expected: true},
+		{name: "all_passed", results: []*domainhealth.Result{passed, passed}, expected: true},
+		{name: "not_probed", results: []*domainhealth.Result{passed, nil}, expected: false},
+		{name: "probed_before_trigger", results: []*domainhealth.Result{stale}, expected: false},
+		{name: "failed", results: []*domainhealth.Result{passed, failed}, expected: false},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			health := &domainhealth.AggregatedHealth{}
+			// Build one subject per result.
+			for _, result := range tt.results {
+				health.Subjects = append(health.Subjects, domainhealth.SubjectStatus{LastProbeResult: result})
+			}
+			assert.Equal(t, tt.expected, probesPassedSince(health, triggered))
+		})
+	}
+}
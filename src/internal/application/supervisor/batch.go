@@ -0,0 +1,128 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file applies one lifecycle action to several services at once.
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// ErrEmptyBatch is returned for a batch naming no service and without selector.
+var ErrEmptyBatch error = shared.NewCodedError(shared.CodeInvalidArgument, "batch names no service and has no label selector")
+
+// RunBatch applies a lifecycle action to the named services and to those
+// matching the label selector, at most req.Parallelism() at a time, and
+// waits for all of them. A service failing does not stop the others: each
+// outcome is reported in its own result, with the state of the service once
+// the events of the action were applied.
+//
+// Params:
+//   - req: the action and its target services.
+//
+// Returns:
+//   - []domainlifecycle.BatchResult: one result per target, named services
+//     first in request order, then selected ones in configuration order.
+//   - error: ErrUnknownOperation for unsupported actions, ErrEmptyBatch
+//     when the request has no target.
+func (s *Supervisor) RunBatch(req *domainlifecycle.BatchRequest) ([]domainlifecycle.BatchResult, error) {
+	run, err := s.batchFunc(req.Action)
+	// reject unsupported actions
+	if err != nil {
+		// propagate action error
+		return nil, err
+	}
+	// a batch needs targets
+	if len(req.Services) == 0 && len(req.Selector) == 0 {
+		// return empty batch error
+		return nil, ErrEmptyBatch
+	}
+
+	targets := s.batchTargets(req)
+	results := make([]domainlifecycle.BatchResult, len(targets))
+	slots := make(chan struct{}, req.Parallelism())
+	var wg sync.WaitGroup
+	// act on each target within the parallelism bound
+	for i, name := range targets {
+		results[i].Service = name
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			before := s.serviceEventSeq(name)
+			results[i].Err = run(name)
+			results[i].Snapshot = s.settle(name, req.Action != domainlifecycle.ActionStop, before, results[i].Err)
+		})
+	}
+	wg.Wait()
+	// return results in target order
+	return results, nil
+}
+
+// batchTargets resolves the services of a batch, without duplicates.
+//
+// Params:
+//   - req: the batch request.
+//
+// Returns:
+//   - []string: the named services, then the selected ones.
+func (s *Supervisor) batchTargets(req *domainlifecycle.BatchRequest) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	targets := make([]string, 0, len(req.Services))
+	// keep named services in request order
+	for _, name := range req.Services {
+		// Skip names given twice.
+		if slices.Contains(targets, name) {
+			continue
+		}
+		targets = append(targets, name)
+	}
+	// Skip selection when no configuration is loaded.
+	if s.config == nil {
+		// named services only
+		return targets
+	}
+	// add selected services in configuration order
+	for i := range s.config.Services {
+		name := s.config.Services[i].Name
+		// Skip unselected or already named services.
+		if !req.Selects(s.config.Services[i].Labels) || slices.Contains(targets, name) {
+			continue
+		}
+		targets = append(targets, name)
+	}
+	// return resolved targets
+	return targets
+}
+
+// batchFunc returns the supervisor method running a batch action.
+//
+// Params:
+//   - action: the lifecycle action.
+//
+// Returns:
+//   - func(string) error: the method to run.
+//   - error: ErrUnknownOperation for unsupported actions.
+func (s *Supervisor) batchFunc(action domainlifecycle.ServiceAction) (func(string) error, error) {
+	// select lifecycle method
+	switch action {
+	// start action
+	case domainlifecycle.ActionStart:
+		// start method
+		return s.StartService, nil
+	// stop action
+	case domainlifecycle.ActionStop:
+		// stop method
+		return s.StopService, nil
+	// restart action
+	case domainlifecycle.ActionRestart:
+		// restart method
+		return s.RestartService, nil
+	}
+	// unsupported action
+	return nil, fmt.Errorf("%w: %s", ErrUnknownOperation, action)
+}
@@ -0,0 +1,113 @@
+// Package supervisor_test provides black-box tests for batch.go.
+// It tests lifecycle actions applied to several services at once.
+package supervisor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/supervisor"
+	"github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+// TestSupervisor_RunBatch tests batch target resolution and per-item results.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_RunBatch(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// req is the batch request.
+		req domainlifecycle.BatchRequest
+		// wantServices are the expected result services, in order.
+		wantServices []string
+		// wantFailed are the services expected to fail.
+		wantFailed []string
+		// errIs is the expected sentinel error of the whole batch.
+		errIs error
+	}{
+		{
+			name:         "named_services",
+			req:          domainlifecycle.BatchRequest{Action: domainlifecycle.ActionStart, Services: []string{"worker", "web", "worker"}},
+			wantServices: []string{"worker", "web"},
+		},
+		{
+			name:         "label_selector",
+			req:          domainlifecycle.BatchRequest{Action: domainlifecycle.ActionRestart, Selector: map[string]string{"team": "payments"}},
+			wantServices: []string{"web", "worker"},
+		},
+		{
+			name: "names_then_selected",
+			req: domainlifecycle.BatchRequest{Action: domainlifecycle.ActionStop, Services: []string{"cron"},
+				Selector: map[string]string{"team": "payments"}, MaxParallel: 1},
+			wantServices: []string{"cron", "web", "worker"},
+		},
+		{
+			name:         "partial_failure",
+			req:          domainlifecycle.BatchRequest{Action: domainlifecycle.ActionStart, Services: []string{"web", "missing"}},
+			wantServices: []string{"web", "missing"},
+			wantFailed:   []string{"missing"},
+		},
+		{
+			name:         "selector_without_match",
+			req:          domainlifecycle.BatchRequest{Action: domainlifecycle.ActionStart, Selector: map[string]string{"team": "search"}},
+			wantServices: []string{},
+		},
+		{
+			name:  "no_target",
+			req:   domainlifecycle.BatchRequest{Action: domainlifecycle.ActionStart},
+			errIs: supervisor.ErrEmptyBatch,
+		},
+		{
+			name:  "unknown_action",
+			req:   domainlifecycle.BatchRequest{Action: "reload", Services: []string{"web"}},
+			errIs: supervisor.ErrUnknownOperation,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				ConfigPath: "/test/config.yaml",
+				Services: []config.ServiceConfig{
+					{Name: "web", Command: "/bin/echo", Labels: map[string]string{"team": "payments"}},
+					{Name: "cron", Command: "/bin/echo"},
+					{Name: "worker", Command: "/bin/echo", Labels: map[string]string{"team": "payments", "tier": "batch"}},
+				},
+			}
+			sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+			require.NoError(t, err)
+
+			results, err := sup.RunBatch(&tt.req)
+
+			// Check rejected batches.
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.Nil(t, results)
+				return
+			}
+			require.NoError(t, err)
+			services := make([]string, 0, len(results))
+			var failed []string
+			// Collect the outcome of each item.
+			for _, result := range results {
+				services = append(services, result.Service)
+				assert.Equal(t, result.Service, result.Snapshot.Name)
+				// Record failed items.
+				if result.Err != nil {
+					failed = append(failed, result.Service)
+					assert.ErrorIs(t, result.Err, supervisor.ErrServiceNotFound)
+				}
+			}
+			assert.Equal(t, tt.wantServices, services)
+			assert.Equal(t, tt.wantFailed, failed)
+			assert.Equal(t, len(tt.wantFailed), domainlifecycle.BatchFailures(results))
+		})
+	}
+}
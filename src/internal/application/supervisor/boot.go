@@ -0,0 +1,222 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file tracks the outcome of the initial startup.
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// BootHandler is a callback invoked once when the initial startup is over.
+// An aborted report means a critical service failed and the boot failure
+// policy asks for a shutdown; the supervisor itself keeps running.
+type BootHandler func(report domainlifecycle.BootReport)
+
+// SetBootHandler sets the callback for the completed boot report.
+//
+// Params:
+//   - handler: the callback function to invoke when the boot is over.
+func (s *Supervisor) SetBootHandler(handler BootHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store boot handler
+	s.bootHandler = handler
+}
+
+// BootReport returns the outcome of the initial startup so far.
+// Services still pending are listed with BootPending.
+//
+// Returns:
+//   - domainlifecycle.BootReport: a copy of the boot report.
+func (s *Supervisor) BootReport() domainlifecycle.BootReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// return copy safe for the caller
+	return s.bootSnapshot()
+}
+
+// beginBoot resets the boot report with every configured service pending.
+// When no service is marked critical, every service is.
+func (s *Supervisor) beginBoot() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	anyCritical := slices.ContainsFunc(s.config.Services, func(svc domainconfig.ServiceConfig) bool { return svc.Critical })
+	services := make([]domainlifecycle.ServiceBoot, 0, len(s.config.Services))
+	// list services in configuration order
+	for i := range s.config.Services {
+		svc := &s.config.Services[i]
+		services = append(services, domainlifecycle.ServiceBoot{
+			Name:     svc.Name,
+			Critical: svc.Critical || !anyCritical,
+			Status:   domainlifecycle.BootPending,
+		})
+	}
+	s.boot = domainlifecycle.BootReport{StartedAt: time.Now(), Services: services}
+}
+
+// updateBoot resolves the boot status of a service from a process event.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+//
+// Returns:
+//   - domainlifecycle.BootReport: the completed report, when the boot just ended.
+//   - bool: true if the boot just ended and the report must be handed out.
+func (s *Supervisor) updateBoot(name string, event *domain.Event) (domainlifecycle.BootReport, bool) {
+	// Skip events outside the boot and from the shutdown itself.
+	if s.boot.StartedAt.IsZero() || s.boot.Complete() || s.state == StateStopping {
+		// Boot unchanged.
+		return domainlifecycle.BootReport{}, false
+	}
+	svc := s.config.FindService(name)
+	oneshot := svc != nil && svc.Oneshot
+
+	// resolve based on event
+	switch event.Type {
+	// Running services without probes are ready once launched.
+	case domain.EventStarted:
+		// Readiness of probed and one-shot services comes later.
+		if oneshot || s.readinessGated(name) {
+			// Still pending.
+			return domainlifecycle.BootReport{}, false
+		}
+		// resolve as ready
+		return s.resolveBoot(name, domainlifecycle.BootReady, "")
+	// Clean exit: one-shot done, others exited before being ready.
+	case domain.EventStopped:
+		// One-shot services succeed by exiting cleanly.
+		if oneshot {
+			// resolve as ready
+			return s.resolveBoot(name, domainlifecycle.BootReady, "")
+		}
+		// resolve as failed
+		return s.resolveBoot(name, domainlifecycle.BootFailed, "exited before becoming ready")
+	// The process did not make it.
+	case domain.EventFailed, domain.EventExhausted, domain.EventStartTimeout:
+		reason := event.Type.String()
+		// Prefer the event error when present.
+		if event.Error != nil {
+			reason = event.Error.Error()
+		} else if event.ExitCode != 0 {
+			reason = fmt.Sprintf("exited with code %d", event.ExitCode)
+		}
+		// resolve as failed
+		return s.resolveBoot(name, domainlifecycle.BootFailed, reason)
+	// Refused starts fail the boot; warned ones start anyway.
+	case domain.EventOvercommitted:
+		// Warned starts resolve with their lifecycle events.
+		if !admissionRefused(event) {
+			// Boot unchanged.
+			return domainlifecycle.BootReport{}, false
+		}
+		// resolve as failed
+		return s.resolveBoot(name, domainlifecycle.BootFailed, event.Error.Error())
+	// Unmet conditions resolve the service without failing the boot.
+	case domain.EventSkipped:
+		// resolve as skipped
+		return s.resolveBoot(name, domainlifecycle.BootSkipped, event.Error.Error())
+	// No boot change for these events.
+	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy,
+		domain.EventThrottled, domain.EventUnthrottled, domain.EventPressureAlert, domain.EventPressureCleared,
+		domain.EventListenerConflict, domain.EventListenerConflictCleared,
+		domain.EventDependencyDown, domain.EventDependencyUp, domain.EventIncident, domain.EventFileChanged, domain.EventSecretChanged,
+		domain.EventProbesPaused, domain.EventProbesResumed,
+		domain.EventQuiesced, domain.EventUnquiesced, domain.EventQuiesceFailed, domain.EventSafeModeEntered, domain.EventSafeModeExited, domain.EventConfigReloaded,
+		domain.EventReloaded, domain.EventReloadFailed,
+		domain.EventSLOBurn, domain.EventSLOBurnCleared, domain.EventShed, domain.EventShedResumed,
+		domain.EventResourceLeaked, domain.EventRuntimeWarning, domain.EventRuntimeExceeded, domain.EventRestartDeferred,
+		domain.EventCertificateExpiring, domain.EventCertificateChanged,
+		domain.EventClockJump, domain.EventCustom:
+		// Boot unchanged.
+		return domainlifecycle.BootReport{}, false
+	default:
+		// Unknown event type, ignore.
+		return domainlifecycle.BootReport{}, false
+	}
+}
+
+// markBootReady resolves a readiness-gated service once its probes pass.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - domainlifecycle.BootReport: the completed report, when the boot just ended.
+//   - bool: true if the boot just ended and the report must be handed out.
+func (s *Supervisor) markBootReady(name string) (domainlifecycle.BootReport, bool) {
+	// resolve as ready
+	return s.resolveBoot(name, domainlifecycle.BootReady, "")
+}
+
+// resolveBoot records the outcome of a pending service and ends the boot
+// when every service is resolved, or at once when a critical service fails
+// under the shutdown policy.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - status: the boot outcome.
+//   - reason: the failure description, empty on success.
+//
+// Returns:
+//   - domainlifecycle.BootReport: the completed report, when the boot just ended.
+//   - bool: true if the boot just ended and the report must be handed out.
+func (s *Supervisor) resolveBoot(name string, status domainlifecycle.BootStatus, reason string) (domainlifecycle.BootReport, bool) {
+	idx := slices.IndexFunc(s.boot.Services, func(svc domainlifecycle.ServiceBoot) bool { return svc.Name == name })
+	// Only the first outcome of a service during the boot counts.
+	if s.boot.Complete() || idx < 0 || s.boot.Services[idx].Status != domainlifecycle.BootPending {
+		// Boot unchanged.
+		return domainlifecycle.BootReport{}, false
+	}
+	now := time.Now()
+	entry := &s.boot.Services[idx]
+	entry.Status = status
+	entry.Duration = now.Sub(s.boot.StartedAt)
+	entry.Error = reason
+
+	abort := s.boot.CriticalFailed() && s.config.OnBootFailure == domainconfig.BootFailureShutdown
+	// Wait for the remaining services unless the boot is aborted.
+	if !abort && s.boot.Pending() {
+		// Still booting.
+		return domainlifecycle.BootReport{}, false
+	}
+	s.boot.Aborted = abort
+	s.boot.CompletedAt = now
+	// hand out completed report
+	return s.bootSnapshot(), true
+}
+
+// completeBoot hands the completed boot report to the boot handler.
+//
+// Params:
+//   - report: the completed boot report.
+func (s *Supervisor) completeBoot(report domainlifecycle.BootReport) {
+	s.mu.RLock()
+	handler := s.bootHandler
+	s.mu.RUnlock()
+	// call handler if registered
+	if handler != nil {
+		handler(report)
+	}
+}
+
+// bootSnapshot returns a copy of the boot report.
+// Must be called with s.mu held.
+//
+// Returns:
+//   - domainlifecycle.BootReport: the copy.
+func (s *Supervisor) bootSnapshot() domainlifecycle.BootReport {
+	report := s.boot
+	report.Services = slices.Clone(s.boot.Services)
+	// return copy
+	return report
+}
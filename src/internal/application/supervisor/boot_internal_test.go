@@ -0,0 +1,203 @@
+// Package supervisor provides internal tests for boot.go.
+// It tests boot report tracking using white-box testing.
+package supervisor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// bootStep is one input of a boot report test: a process event, or the
+// service becoming healthy when event is nil.
+type bootStep struct {
+	// service is the service name.
+	service string
+	// event is the process event, nil for readiness.
+	event *domain.Event
+}
+
+// Test_Supervisor_bootReport tests boot outcomes and the failure policy.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_bootReport(t *testing.T) {
+	probed := []domainconfig.ListenerConfig{{Name: "http", Port: 8080, Probe: &domainconfig.ProbeConfig{Type: "tcp"}}}
+
+	tests := []struct {
+		name         string
+		policy       domainconfig.BootFailurePolicy
+		services     []domainconfig.ServiceConfig
+		steps        []bootStep
+		wantStatus   map[string]domainlifecycle.BootStatus
+		wantCritical map[string]bool
+		wantComplete bool
+		wantAborted  bool
+	}{
+		{
+			name: "all services ready",
+			services: []domainconfig.ServiceConfig{
+				{Name: "api", Command: "/bin/api"},
+				{Name: "db", Command: "/bin/db", Listeners: probed},
+			},
+			steps: []bootStep{
+				{service: "api", event: &domain.Event{Type: domain.EventStarted}},
+				{service: "db", event: &domain.Event{Type: domain.EventStarted}},
+				{service: "db"},
+			},
+			wantStatus:   map[string]domainlifecycle.BootStatus{"api": domainlifecycle.BootReady, "db": domainlifecycle.BootReady},
+			wantCritical: map[string]bool{"api": true, "db": true},
+			wantComplete: true,
+		},
+		{
+			name: "probed service waits for readiness",
+			services: []domainconfig.ServiceConfig{
+				{Name: "db", Command: "/bin/db", Listeners: probed},
+			},
+			steps: []bootStep{
+				{service: "db", event: &domain.Event{Type: domain.EventStarted}},
+			},
+			wantStatus:   map[string]domainlifecycle.BootStatus{"db": domainlifecycle.BootPending},
+			wantCritical: map[string]bool{"db": true},
+		},
+		{
+			name: "oneshot ready on clean exit",
+			services: []domainconfig.ServiceConfig{
+				{Name: "migrate", Command: "/bin/migrate", Oneshot: true},
+			},
+			steps: []bootStep{
+				{service: "migrate", event: &domain.Event{Type: domain.EventStarted}},
+				{service: "migrate", event: &domain.Event{Type: domain.EventStopped}},
+			},
+			wantStatus:   map[string]domainlifecycle.BootStatus{"migrate": domainlifecycle.BootReady},
+			wantCritical: map[string]bool{"migrate": true},
+			wantComplete: true,
+		},
+		{
+			name:   "non-critical failure keeps booting",
+			policy: domainconfig.BootFailureShutdown,
+			services: []domainconfig.ServiceConfig{
+				{Name: "api", Command: "/bin/api", Critical: true},
+				{Name: "cron", Command: "/bin/cron"},
+			},
+			steps: []bootStep{
+				{service: "cron", event: &domain.Event{Type: domain.EventFailed, ExitCode: 2}},
+				{service: "api", event: &domain.Event{Type: domain.EventStarted}},
+			},
+			wantStatus:   map[string]domainlifecycle.BootStatus{"api": domainlifecycle.BootReady, "cron": domainlifecycle.BootFailed},
+			wantCritical: map[string]bool{"api": true, "cron": false},
+			wantComplete: true,
+		},
+		{
+			name:   "critical failure aborts under shutdown",
+			policy: domainconfig.BootFailureShutdown,
+			services: []domainconfig.ServiceConfig{
+				{Name: "api", Command: "/bin/api", Critical: true},
+				{Name: "db", Command: "/bin/db", Listeners: probed},
+			},
+			steps: []bootStep{
+				{service: "api", event: &domain.Event{Type: domain.EventFailed, Error: errors.New("exec: not found")}},
+			},
+			wantStatus:   map[string]domainlifecycle.BootStatus{"api": domainlifecycle.BootFailed, "db": domainlifecycle.BootPending},
+			wantCritical: map[string]bool{"api": true, "db": false},
+			wantComplete: true,
+			wantAborted:  true,
+		},
+		{
+			name:   "critical failure continues by default",
+			policy: domainconfig.BootFailureContinue,
+			services: []domainconfig.ServiceConfig{
+				{Name: "api", Command: "/bin/api"},
+				{Name: "db", Command: "/bin/db"},
+			},
+			steps: []bootStep{
+				{service: "api", event: &domain.Event{Type: domain.EventFailed}},
+				{service: "api", event: &domain.Event{Type: domain.EventStarted}},
+				{service: "db", event: &domain.Event{Type: domain.EventStarted}},
+			},
+			wantStatus:   map[string]domainlifecycle.BootStatus{"api": domainlifecycle.BootFailed, "db": domainlifecycle.BootReady},
+			wantCritical: map[string]bool{"api": true, "db": true},
+			wantComplete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Supervisor{
+				config:        &domainconfig.Config{OnBootFailure: tt.policy, Services: tt.services},
+				proberFactory: &mockProberFactory{},
+			}
+			var reports []domainlifecycle.BootReport
+			s.SetBootHandler(func(report domainlifecycle.BootReport) { reports = append(reports, report) })
+			s.beginBoot()
+
+			// Feed each step the way handleEvent and OnHealthy do.
+			for _, step := range tt.steps {
+				s.mu.Lock()
+				var report domainlifecycle.BootReport
+				var booted bool
+				if step.event == nil {
+					report, booted = s.markBootReady(step.service)
+				} else {
+					report, booted = s.updateBoot(step.service, step.event)
+				}
+				s.mu.Unlock()
+				if booted {
+					s.completeBoot(report)
+				}
+			}
+
+			got := s.BootReport()
+			assert.Equal(t, tt.wantComplete, got.Complete())
+			assert.Equal(t, tt.wantAborted, got.Aborted)
+			for _, svc := range got.Services {
+				assert.Equal(t, tt.wantStatus[svc.Name], svc.Status, svc.Name)
+				assert.Equal(t, tt.wantCritical[svc.Name], svc.Critical, svc.Name)
+			}
+
+			// The handler runs exactly once, when the boot is over.
+			if tt.wantComplete {
+				require.Len(t, reports, 1)
+				assert.Equal(t, got, reports[0])
+			} else {
+				assert.Empty(t, reports)
+			}
+		})
+	}
+}
+
+// Test_Supervisor_bootReport_FailureReason tests the recorded failure reason.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_bootReport_FailureReason(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *domain.Event
+		want  string
+	}{
+		{name: "event error", event: &domain.Event{Type: domain.EventFailed, Error: errors.New("boom")}, want: "boom"},
+		{name: "exit code", event: &domain.Event{Type: domain.EventFailed, ExitCode: 3}, want: "exited with code 3"},
+		{name: "event type", event: &domain.Event{Type: domain.EventExhausted}, want: "exhausted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Supervisor{config: &domainconfig.Config{Services: []domainconfig.ServiceConfig{{Name: "api", Command: "/bin/api"}}}}
+			s.beginBoot()
+
+			s.mu.Lock()
+			_, booted := s.updateBoot("api", tt.event)
+			s.mu.Unlock()
+
+			assert.True(t, booted)
+			assert.Equal(t, tt.want, s.BootReport().Services[0].Error)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file checks the certificates served by TLS listeners.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// certificateCheckTimeout bounds the connection and handshake of a check.
+const certificateCheckTimeout time.Duration = 10 * time.Second
+
+// Certificate errors.
+var (
+	// ErrCertificateExpiring is attached to EventCertificateExpiring.
+	ErrCertificateExpiring error = errors.New("certificate expiring")
+	// ErrCertificateChanged is attached to EventCertificateChanged.
+	ErrCertificateChanged error = errors.New("certificate changed")
+)
+
+// certificateCheck is the checked state of a listener certificate.
+type certificateCheck struct {
+	// status is the state reported by Certificates.
+	status domainhealth.CertificateStatus
+	// warned is the fingerprint the expiry was reported for.
+	warned string
+	// renewed is the fingerprint the renewal succeeded for.
+	renewed string
+}
+
+// SetCertificateInspector sets the adapter retrieving the certificates of
+// listeners with a tls block. Without an inspector, no certificate is checked.
+//
+// Params:
+//   - inspector: the certificate inspector.
+func (s *Supervisor) SetCertificateInspector(inspector apphealth.CertificateInspector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store certificate inspector
+	s.certInspector = inspector
+}
+
+// Certificates returns the checked certificates of the TLS listeners of a
+// service, in listener order.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - []domainhealth.CertificateStatus: the certificates, empty without tls listeners.
+//   - error: ErrServiceNotFound if the service does not exist.
+func (s *Supervisor) Certificates(name string) ([]domainhealth.CertificateStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	svc := s.config.FindService(name)
+	// validate service exists
+	if svc == nil {
+		// Return error for missing service.
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	var statuses []domainhealth.CertificateStatus
+	// collect checked listeners in order
+	for i := range svc.Listeners {
+		// skip listeners without certificate checks
+		if check, ok := s.certificates[name][svc.Listeners[i].Name]; ok {
+			statuses = append(statuses, check.status)
+		}
+	}
+	// return certificates
+	return statuses, nil
+}
+
+// startCertificateChecks starts the certificate checks of the configuration.
+func (s *Supervisor) startCertificateChecks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkCertificates(s.config)
+}
+
+// checkCertificates replaces the running certificate checks with those of
+// cfg. The state of listeners kept by a reload is carried over, so a
+// certificate replaced across the reload is still reported. Checks end with
+// the supervisor context. Must be called with s.mu held.
+//
+// Params:
+//   - cfg: the configuration to check.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine per tls listener.
+//   - Goroutines exit on the next call or when the supervisor context is cancelled.
+func (s *Supervisor) checkCertificates(cfg *domainconfig.Config) {
+	// stop the checks of the previous configuration
+	if s.certCancel != nil {
+		s.certCancel()
+		s.certCancel = nil
+	}
+	checks := make(map[string]map[string]*certificateCheck)
+	var listeners []certificateTarget
+	// collect the tls listeners of each service
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		// check each listener with a tls block
+		for j := range svc.Listeners {
+			lc := svc.Listeners[j]
+			// skip listeners without certificate checks
+			if lc.TLS == nil {
+				continue
+			}
+			check := s.certificates[svc.Name][lc.Name]
+			// start from scratch for new listeners
+			if check == nil {
+				check = &certificateCheck{status: domainhealth.CertificateStatus{Listener: lc.Name}}
+			}
+			check.status.Address = lc.DialAddress()
+			// create the service map on first listener
+			if checks[svc.Name] == nil {
+				checks[svc.Name] = make(map[string]*certificateCheck)
+			}
+			checks[svc.Name][lc.Name] = check
+			listeners = append(listeners, certificateTarget{service: svc.Name, listener: lc})
+		}
+	}
+	s.certificates = checks
+
+	// Skip when not started, nothing to check or nothing to check with.
+	if s.ctx == nil || len(listeners) == 0 || s.certInspector == nil {
+		// Nothing to run.
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.certCancel = cancel
+	inspector := s.certInspector
+	// start each check
+	for i := range listeners {
+		target := listeners[i]
+		s.wg.Go(func() {
+			// Run until replaced or shutdown.
+			s.watchCertificate(ctx, inspector, &target)
+		})
+	}
+}
+
+// certificateTarget is a tls listener of a service.
+type certificateTarget struct {
+	// service is the service name.
+	service string
+	// listener is the listener configuration.
+	listener domainconfig.ListenerConfig
+}
+
+// watchCertificate checks a listener at once, then on each interval until
+// ctx is cancelled.
+//
+// Params:
+//   - ctx: the check context.
+//   - inspector: the certificate inspector.
+//   - target: the checked listener.
+func (s *Supervisor) watchCertificate(ctx context.Context, inspector apphealth.CertificateInspector, target *certificateTarget) {
+	s.checkCertificate(ctx, inspector, target)
+	ticker := time.NewTicker(target.listener.TLS.CheckInterval())
+	defer ticker.Stop()
+	// Loop until context is cancelled.
+	for {
+		select {
+		case <-ctx.Done():
+			// Return when context is cancelled.
+			return
+		case <-ticker.C:
+			s.checkCertificate(ctx, inspector, target)
+		}
+	}
+}
+
+// checkCertificate retrieves the certificate of a listener, records it, emits
+// the changed and expiring events, and renews it when due.
+//
+// Params:
+//   - ctx: the check context.
+//   - inspector: the certificate inspector.
+//   - target: the checked listener.
+func (s *Supervisor) checkCertificate(ctx context.Context, inspector apphealth.CertificateInspector, target *certificateTarget) {
+	lc := &target.listener
+	checkCtx, cancel := context.WithTimeout(ctx, certificateCheckTimeout)
+	cert, err := inspector.Inspect(checkCtx, lc.DialAddress(), lc.TLS.ServerName)
+	cancel()
+	// A check cut by a reload or shutdown is no outcome.
+	if ctx.Err() != nil {
+		// Keep the previous state.
+		return
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	check := s.certificates[target.service][lc.Name]
+	// Skip listeners removed meanwhile.
+	if check == nil {
+		s.mu.Unlock()
+		// Listener gone.
+		return
+	}
+	check.status.LastCheck = now
+	// Keep the last certificate when the listener is unreachable.
+	if err != nil {
+		check.status.Error = err.Error()
+		s.mu.Unlock()
+		// Nothing else to report.
+		return
+	}
+	events := certificateEvents(target.service, lc, check, &cert, now)
+	check.status.Certificate = cert
+	check.status.Error = ""
+	renew := lc.TLS.RenewCommand != "" && cert.Remaining(now) <= lc.TLS.RenewDelay() && check.renewed != cert.Fingerprint
+	runner := s.hookRunner
+	snap := s.getStatsSnapshot(s.stats[target.service])
+	s.mu.Unlock()
+
+	// deliver each event
+	for i := range events {
+		s.callEventHandler(target.service, &events[i], snap)
+	}
+	// Renew the certificate before it expires.
+	if renew {
+		s.renewCertificate(ctx, runner, target, &cert)
+	}
+}
+
+// certificateEvents returns the events of a retrieved certificate and
+// records the reported expiry. The changed event is emitted when the
+// fingerprint differs from the previous check, the expiring event once per
+// certificate when it expires within the warning delay.
+//
+// Params:
+//   - service: the service name.
+//   - lc: the listener configuration.
+//   - check: the checked state, updated.
+//   - cert: the retrieved certificate.
+//   - now: the check time.
+//
+// Returns:
+//   - []domain.Event: the events to emit, in changed, expiring order.
+func certificateEvents(service string, lc *domainconfig.ListenerConfig, check *certificateCheck, cert *domainhealth.Certificate, now time.Time) []domain.Event {
+	var events []domain.Event
+	previous := check.status.Certificate.Fingerprint
+	// certificate replaced since the previous check
+	if previous != "" && previous != cert.Fingerprint {
+		events = append(events, domain.NewEvent(domain.EventCertificateChanged, service, 0, 0,
+			fmt.Errorf("%w: listener %s: issuer %s, expires %s", ErrCertificateChanged,
+				lc.Name, cert.Issuer, cert.NotAfter.UTC().Format(time.RFC3339))))
+	}
+	// certificate expires within the warning delay
+	if cert.Remaining(now) <= lc.TLS.WarnDelay() && check.warned != cert.Fingerprint {
+		check.warned = cert.Fingerprint
+		events = append(events, domain.NewEvent(domain.EventCertificateExpiring, service, 0, 0,
+			fmt.Errorf("%w: listener %s: expires %s (in %s)", ErrCertificateExpiring,
+				lc.Name, cert.NotAfter.UTC().Format(time.RFC3339), cert.Remaining(now).Round(time.Minute))))
+	}
+	// return events to emit
+	return events
+}
+
+// renewCertificate runs the renewal command of a listener, then restarts the
+// service so it serves the renewed certificate. The command receives the
+// service, the listener and the expiry in its environment. Failures are
+// reported through the error handler and retried on the next check.
+//
+// Params:
+//   - ctx: the check context.
+//   - runner: the hook runner, nil when none is set.
+//   - target: the checked listener.
+//   - cert: the expiring certificate.
+func (s *Supervisor) renewCertificate(ctx context.Context, runner apphook.Runner, target *certificateTarget, cert *domainhealth.Certificate) {
+	tls := target.listener.TLS
+	// Renewal commands need a runner.
+	if runner == nil {
+		s.handleRecoveryError("certificate-renewal", target.service, ErrNoHookRunner)
+		// Nothing to run with.
+		return
+	}
+	err := runner.Run(ctx, apphook.Command{
+		Path: tls.RenewCommand,
+		Args: tls.RenewArgs,
+		Env: []string{
+			"SUPERVIZIO_SERVICE=" + target.service,
+			"SUPERVIZIO_LISTENER=" + target.listener.Name,
+			"SUPERVIZIO_CERT_NOT_AFTER=" + cert.NotAfter.UTC().Format(time.RFC3339),
+		},
+		Timeout: tls.RenewLimit(),
+	})
+	// Report failed renewals; the next check tries again.
+	if err != nil {
+		s.handleRecoveryError("certificate-renewal", target.service, err)
+		// Keep the certificate due for renewal.
+		return
+	}
+
+	s.mu.Lock()
+	// Record the renewal unless the listener was removed meanwhile.
+	if check := s.certificates[target.service][target.listener.Name]; check != nil {
+		check.renewed = cert.Fingerprint
+		check.status.RenewedAt = time.Now()
+	}
+	s.mu.Unlock()
+	// Restart on the renewed certificate (best-effort).
+	if err := s.RestartService(target.service); err != nil {
+		s.handleRecoveryError("certificate-renewal", target.service, err)
+	}
+}
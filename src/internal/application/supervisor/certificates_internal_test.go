@@ -0,0 +1,235 @@
+// Package supervisor provides internal tests for certificates.go.
+// It tests listener certificate checks using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// scriptedInspector returns scripted certificates, repeating the last one.
+type scriptedInspector struct {
+	// mu guards next.
+	mu sync.Mutex
+	// certs are the certificates, in order.
+	certs []domainhealth.Certificate
+	// err is returned instead of a certificate when set.
+	err error
+	// next is the index of the next certificate.
+	next int
+}
+
+// Inspect returns the next certificate.
+//
+// Returns:
+//   - domainhealth.Certificate: the scripted certificate.
+//   - error: the scripted error.
+func (i *scriptedInspector) Inspect(_ context.Context, _, _ string) (domainhealth.Certificate, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	// fail when scripted
+	if i.err != nil {
+		return domainhealth.Certificate{}, i.err
+	}
+	cert := i.certs[min(i.next, len(i.certs)-1)]
+	i.next++
+	return cert, nil
+}
+
+// newCertificateTestSupervisor builds a running supervisor with a started
+// service serving tls on port 8443.
+//
+// Params:
+//   - t: the testing context.
+//   - tls: the tls settings of the listener.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+//   - *ephemeralTestExecutor: the executor.
+//   - *certificateTarget: the checked listener.
+func newCertificateTestSupervisor(t *testing.T, tls *domainconfig.ListenerTLSConfig) (*Supervisor, *ephemeralTestExecutor, *certificateTarget) {
+	t.Helper()
+	lc := domainconfig.ListenerConfig{Name: "https", Port: 8443, TLS: tls}
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{{
+		Name:      "api",
+		Command:   "/bin/api",
+		Listeners: []domainconfig.ListenerConfig{lc},
+	}}}
+	executor := &ephemeralTestExecutor{}
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := applifecycle.NewManager(&cfg.Services[0], executor)
+	s := &Supervisor{
+		config:   cfg,
+		executor: executor,
+		managers: map[string]*applifecycle.Manager{"api": mgr},
+		stats:    map[string]*ServiceStats{"api": NewServiceStats()},
+		state:    StateRunning,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	t.Cleanup(func() {
+		cancel()
+		s.wg.Wait()
+	})
+	require.NoError(t, mgr.Start(ctx))
+	require.Eventually(t, func() bool { return len(executor.started()) == 1 }, time.Second, 5*time.Millisecond)
+	s.mu.Lock()
+	s.checkCertificates(cfg)
+	s.mu.Unlock()
+	return s, executor, &certificateTarget{service: "api", listener: lc}
+}
+
+// Test_certificateEvents tests the events of successive certificates.
+//
+// Params:
+//   - t: the testing context.
+func Test_certificateEvents(t *testing.T) {
+	now := time.Now()
+	far := domainhealth.Certificate{Fingerprint: "aa", Issuer: "CN=CA", NotAfter: now.Add(90 * 24 * time.Hour)}
+	near := domainhealth.Certificate{Fingerprint: "aa", Issuer: "CN=CA", NotAfter: now.Add(24 * time.Hour)}
+	renewed := domainhealth.Certificate{Fingerprint: "bb", Issuer: "CN=CA", NotAfter: now.Add(24 * time.Hour)}
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// certs are the successive certificates.
+		certs []domainhealth.Certificate
+		// want is the expected event types, per certificate.
+		want [][]domain.EventType
+	}{
+		{
+			name:  "first certificate is the baseline",
+			certs: []domainhealth.Certificate{far, far},
+			want:  [][]domain.EventType{nil, nil},
+		},
+		{
+			name:  "expiry reported once",
+			certs: []domainhealth.Certificate{near, near},
+			want:  [][]domain.EventType{{domain.EventCertificateExpiring}, nil},
+		},
+		{
+			name:  "replaced certificate",
+			certs: []domainhealth.Certificate{near, renewed},
+			want:  [][]domain.EventType{{domain.EventCertificateExpiring}, {domain.EventCertificateChanged, domain.EventCertificateExpiring}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &domainconfig.ListenerConfig{Name: "https", TLS: &domainconfig.ListenerTLSConfig{}}
+			check := &certificateCheck{}
+			// check each certificate in turn
+			for i := range tt.certs {
+				var got []domain.EventType
+				// collect event types
+				for _, event := range certificateEvents("api", lc, check, &tt.certs[i], now) {
+					got = append(got, event.Type)
+				}
+				check.status.Certificate = tt.certs[i]
+				assert.Equal(t, tt.want[i], got, "certificate %d", i)
+			}
+		})
+	}
+}
+
+// Test_Supervisor_checkCertificate_Renewal tests that an expiring certificate
+// is renewed once and the service restarted.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkCertificate_Renewal(t *testing.T) {
+	s, executor, target := newCertificateTestSupervisor(t, &domainconfig.ListenerTLSConfig{
+		WarnBefore:   shared.Duration(7 * 24 * time.Hour),
+		RenewCommand: "/usr/local/bin/renew",
+		RenewArgs:    []string{"--force"},
+		RenewBefore:  shared.Duration(2 * 24 * time.Hour),
+	})
+	runner := &fakeHookRunner{}
+	s.hookRunner = runner
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	inspector := &scriptedInspector{certs: []domainhealth.Certificate{{Fingerprint: "aa", NotAfter: notAfter}}}
+	var mu sync.Mutex
+	var events []domain.EventType
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event.Type)
+	}
+
+	// Far from expiry: recorded, nothing reported.
+	s.checkCertificate(s.ctx, inspector, target)
+	statuses, err := s.Certificates("api")
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "https", statuses[0].Listener)
+	assert.Equal(t, "aa", statuses[0].Certificate.Fingerprint)
+	assert.True(t, statuses[0].RenewedAt.IsZero())
+	assert.Empty(t, runner.commands)
+
+	// Within the renewal delay: reported, renewed and restarted once.
+	inspector.certs = []domainhealth.Certificate{{Fingerprint: "aa", NotAfter: time.Now().Add(24 * time.Hour)}}
+	s.checkCertificate(s.ctx, inspector, target)
+	s.checkCertificate(s.ctx, inspector, target)
+	mu.Lock()
+	assert.Equal(t, []domain.EventType{domain.EventCertificateExpiring}, events)
+	mu.Unlock()
+	runner.mu.Lock()
+	require.Len(t, runner.commands, 1)
+	cmd := runner.commands[0]
+	runner.mu.Unlock()
+	assert.Equal(t, "/usr/local/bin/renew", cmd.Path)
+	assert.Equal(t, []string{"--force"}, cmd.Args)
+	assert.Contains(t, cmd.Env, "SUPERVIZIO_SERVICE=api")
+	assert.Contains(t, cmd.Env, "SUPERVIZIO_LISTENER=https")
+	assert.Equal(t, domainconfig.DefaultTLSRenewTimeout, cmd.Timeout)
+	require.Eventually(t, func() bool { return len(executor.started()) == 2 }, time.Second, 5*time.Millisecond)
+	statuses, err = s.Certificates("api")
+	require.NoError(t, err)
+	assert.False(t, statuses[0].RenewedAt.IsZero())
+}
+
+// Test_Supervisor_checkCertificate_Errors tests failed checks and renewals.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkCertificate_Errors(t *testing.T) {
+	s, executor, target := newCertificateTestSupervisor(t, &domainconfig.ListenerTLSConfig{RenewCommand: "/usr/local/bin/renew"})
+	var mu sync.Mutex
+	var errs []error
+	s.errorHandler = func(_, _ string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+	inspector := &scriptedInspector{certs: []domainhealth.Certificate{{Fingerprint: "aa", NotAfter: time.Now().Add(time.Hour)}}}
+
+	// Renewal without runner is reported and the service left running.
+	s.checkCertificate(s.ctx, inspector, target)
+	mu.Lock()
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrNoHookRunner)
+	mu.Unlock()
+	assert.Len(t, executor.started(), 1)
+
+	// An unreachable listener keeps the last certificate.
+	inspector.err = errors.New("connection refused")
+	s.checkCertificate(s.ctx, inspector, target)
+	statuses, err := s.Certificates("api")
+	require.NoError(t, err)
+	assert.Equal(t, "aa", statuses[0].Certificate.Fingerprint)
+	assert.Equal(t, "connection refused", statuses[0].Error)
+
+	_, err = s.Certificates("db")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
@@ -0,0 +1,91 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file detects jumps of the wall clock.
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+const (
+	// clockCheckInterval is the period between clock samples.
+	clockCheckInterval time.Duration = 5 * time.Second
+	// clockJumpThreshold is the drift between the wall and monotonic clocks
+	// reported as a jump.
+	clockJumpThreshold time.Duration = 30 * time.Second
+)
+
+// ErrClockJump is the error of clock_jump events.
+var ErrClockJump error = errors.New("wall clock jumped")
+
+// startClockWatcher samples the wall and monotonic clocks and reports wall
+// clock jumps, such as a resume from suspend or an NTP step. Timers of the
+// daemon (probe intervals, restart backoff, restart budget, start timeouts,
+// max runtimes, watchers) run on the monotonic clock: they keep their
+// remaining delay across a jump instead of firing overdue, so the jump is
+// only reported.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine sampling on a ticker.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startClockWatcher() {
+	// Sample until shutdown.
+	s.wg.Go(func() {
+		ticker := time.NewTicker(clockCheckInterval)
+		defer ticker.Stop()
+		previous := time.Now()
+		// Loop until context is cancelled.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case <-ticker.C:
+				now := time.Now()
+				// Report the drift accumulated since the last sample.
+				if jump := clockJump(now.Round(0).Sub(previous.Round(0)), now.Sub(previous)); jump != 0 {
+					s.reportClockJump(jump)
+				}
+				previous = now
+			}
+		}
+	})
+}
+
+// clockJump returns how far the wall clock moved beyond the monotonic clock
+// between two samples.
+//
+// Params:
+//   - wall: the elapsed wall clock time.
+//   - monotonic: the elapsed monotonic time.
+//
+// Returns:
+//   - time.Duration: the jump, positive forward, zero below clockJumpThreshold.
+func clockJump(wall, monotonic time.Duration) time.Duration {
+	jump := wall - monotonic
+	// ignore the drift of clock slewing
+	if jump.Abs() < clockJumpThreshold {
+		// no jump
+		return 0
+	}
+	// return the jump
+	return jump
+}
+
+// reportClockJump emits a daemon-wide clock_jump event.
+//
+// Params:
+//   - jump: the jump, positive forward.
+func (s *Supervisor) reportClockJump(jump time.Duration) {
+	direction := "forward"
+	// a negative jump moved the clock back
+	if jump < 0 {
+		direction = "backward"
+	}
+	event := domain.NewEvent(domain.EventClockJump, "", 0, 0,
+		fmt.Errorf("%w %s by %s", ErrClockJump, direction, jump.Abs().Round(time.Second)))
+	s.callEventHandler("", &event, nil)
+}
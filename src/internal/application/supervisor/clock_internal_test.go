@@ -0,0 +1,64 @@
+// Package supervisor provides internal tests for clock.go.
+// It tests wall clock jump detection using white-box testing.
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_clockJump tests the drift reported as a jump.
+//
+// Params:
+//   - t: the testing context.
+func Test_clockJump(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// wall is the elapsed wall clock time.
+		wall time.Duration
+		// monotonic is the elapsed monotonic time.
+		monotonic time.Duration
+		// want is the expected jump.
+		want time.Duration
+	}{
+		{name: "in sync", wall: 5 * time.Second, monotonic: 5 * time.Second, want: 0},
+		{name: "slew below threshold", wall: 5*time.Second + 29*time.Second, monotonic: 5 * time.Second, want: 0},
+		{name: "resume from suspend", wall: 8 * time.Hour, monotonic: 5 * time.Second, want: 8*time.Hour - 5*time.Second},
+		{name: "backward step", wall: 5*time.Second - time.Minute, monotonic: 5 * time.Second, want: -time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clockJump(tt.wall, tt.monotonic))
+		})
+	}
+}
+
+// Test_Supervisor_reportClockJump tests the daemon-wide clock_jump event.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_reportClockJump(t *testing.T) {
+	var events []*domain.Event
+	var services []string
+	s := &Supervisor{
+		eventHandler: func(service string, event *domain.Event, _ *ServiceStatsSnapshot) {
+			services = append(services, service)
+			events = append(events, event)
+		},
+	}
+
+	s.reportClockJump(-90 * time.Second)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, []string{""}, services)
+	assert.Equal(t, domain.EventClockJump, events[0].Type)
+	assert.ErrorIs(t, events[0].Error, ErrClockJump)
+	assert.Contains(t, events[0].Error.Error(), "backward by 1m30s")
+}
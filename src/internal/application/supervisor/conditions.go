@@ -0,0 +1,131 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file skips the services whose start conditions are unmet.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// ErrConditionsUnmet is attached to skipped events of services whose start conditions are unmet.
+var ErrConditionsUnmet error = shared.NewCodedError(shared.CodeConditionsUnmet, "start conditions unmet")
+
+// SetHostFactsCollector sets the collector reading the host facts that
+// service start conditions are checked against. Without it, conditions are
+// not checked and every service starts.
+//
+// Params:
+//   - collector: the host facts collector.
+func (s *Supervisor) SetHostFactsCollector(collector appmetrics.HostFactsCollector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store host facts collector
+	s.hostFactsCollector = collector
+}
+
+// refreshHostFacts reads the host facts before the services of cfg are
+// started. The facts are only read when a service declares conditions.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - cfg: the configuration whose services are about to start.
+func (s *Supervisor) refreshHostFacts(ctx context.Context, cfg *domainconfig.Config) {
+	s.mu.RLock()
+	collector := s.hostFactsCollector
+	s.mu.RUnlock()
+	// Nothing starts without configuration.
+	if cfg == nil {
+		// Nothing to read.
+		return
+	}
+	var paths []string
+	conditional := false
+	// Collect the paths the conditions check.
+	for i := range cfg.Services {
+		conditions := &cfg.Services[i].Conditions
+		conditional = conditional || !conditions.IsZero()
+		paths = append(paths, conditions.Paths()...)
+	}
+	// Conditions are not checked without collector or conditions.
+	if collector == nil || !conditional {
+		// Nothing to read.
+		return
+	}
+	facts, err := collector.CollectHostFacts(ctx, paths)
+	// Report the failure and start every service.
+	if err != nil {
+		s.handleRecoveryError("collect-host-facts", "", err)
+		s.mu.Lock()
+		s.hostFacts = nil
+		s.mu.Unlock()
+		// Facts unknown.
+		return
+	}
+	s.mu.Lock()
+	s.hostFacts = &facts
+	s.mu.Unlock()
+}
+
+// checkConditions checks the start conditions of a service against the
+// host facts and leaves the manager of a service with unmet conditions
+// skipped. Must be called with s.mu held.
+//
+// Params:
+//   - cfg: the configuration holding the conditions.
+//   - name: the service about to start.
+//
+// Returns:
+//   - *domain.Event: the skipped event to emit, nil when the conditions are met.
+func (s *Supervisor) checkConditions(cfg *domainconfig.Config, name string) *domain.Event {
+	// Conditions are not checked without configuration or facts.
+	if cfg == nil || s.hostFacts == nil {
+		// Conditions met.
+		return nil
+	}
+	svc := cfg.FindService(name)
+	// Only services declaring conditions are checked.
+	if svc == nil || svc.Conditions.IsZero() {
+		// Conditions met.
+		return nil
+	}
+	unmet := svc.Conditions.Unmet(s.hostFacts)
+	// Every condition holds.
+	if len(unmet) == 0 {
+		// Conditions met.
+		return nil
+	}
+	reason := strings.Join(unmet, "; ")
+	// Report the reason in the service status.
+	if mgr := s.managers[name]; mgr != nil {
+		mgr.Skip(reason)
+	}
+	event := domain.NewEvent(domain.EventSkipped, name, 0, 0, fmt.Errorf("%w: %s", ErrConditionsUnmet, reason))
+	// return skipped start
+	return &event
+}
+
+// gateStart checks a service start against its conditions, then against
+// the admitted host capacity. Must be called with s.mu held.
+//
+// Params:
+//   - cfg: the configuration of the service.
+//   - name: the service about to start.
+//
+// Returns:
+//   - *domain.Event: the skipped or overcommitted event to emit, nil when none.
+//   - bool: true if the start must not happen.
+func (s *Supervisor) gateStart(cfg *domainconfig.Config, name string) (*domain.Event, bool) {
+	// Unmet conditions skip the service before its reservation counts.
+	if event := s.checkConditions(cfg, name); event != nil {
+		// Skipped.
+		return event, true
+	}
+	// Admit the reservation.
+	return s.admit(cfg, name)
+}
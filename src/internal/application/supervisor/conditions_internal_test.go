@@ -0,0 +1,219 @@
+// Package supervisor provides internal tests for conditions.go.
+// It tests the skipping of services whose start conditions are unmet using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// hostFactsTestCollector returns fixed host facts.
+type hostFactsTestCollector struct {
+	// facts is the returned facts.
+	facts domainconfig.HostFacts
+	// err is the returned error.
+	err error
+	// paths records the paths of the last collection.
+	paths []string
+}
+
+// CollectHostFacts returns the fixed facts.
+//
+// Params:
+//   - paths: the paths whose existence is checked.
+//
+// Returns:
+//   - domainconfig.HostFacts: the configured facts.
+//   - error: the configured error.
+func (c *hostFactsTestCollector) CollectHostFacts(_ context.Context, paths []string) (domainconfig.HostFacts, error) {
+	c.paths = paths
+	return c.facts, c.err
+}
+
+// newConditionsTestSupervisor creates a supervisor with "gpu" requiring
+// /dev/nvidia0, "kvm" requiring kernel 5.10 on amd64, and "cron" without
+// conditions, on an amd64 host running kernel 6.1 without GPU.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *Supervisor: the supervisor under test.
+//   - *phaseTestExecutor: the executor recording launches.
+func newConditionsTestSupervisor(t *testing.T) (*Supervisor, *phaseTestExecutor) {
+	t.Helper()
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "gpu", Command: "/bin/gpu", Conditions: domainconfig.ConditionConfig{PathExists: []string{"/dev/nvidia0"}}},
+		{Name: "kvm", Command: "/bin/kvm", Conditions: domainconfig.ConditionConfig{MinKernelVersion: "5.10", Architectures: []string{"x86_64"}}},
+		{Name: "cron", Command: "/bin/cron"},
+	}}
+
+	executor := &phaseTestExecutor{}
+	managers := make(map[string]*applifecycle.Manager, len(cfg.Services))
+	for i := range cfg.Services {
+		managers[cfg.Services[i].Name] = applifecycle.NewManager(&cfg.Services[i], executor)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := &Supervisor{
+		config:   cfg,
+		managers: managers,
+		stats:    make(map[string]*ServiceStats),
+		ctx:      ctx,
+		hostFactsCollector: &hostFactsTestCollector{facts: domainconfig.HostFacts{
+			KernelVersion: "6.1.0-18-amd64",
+			Architecture:  "amd64",
+			Paths:         map[string]bool{"/dev/nvidia0": false},
+		}},
+	}
+	t.Cleanup(func() {
+		for _, mgr := range managers {
+			_ = mgr.Stop()
+		}
+	})
+	return s, executor
+}
+
+// Test_Supervisor_checkConditions tests the conditions of each service against the host facts.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkConditions(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// service is the service about to start.
+		service string
+		// wantReason is the expected skip reason, empty when the service starts.
+		wantReason string
+	}{
+		{name: "missing_path_skips", service: "gpu", wantReason: "path /dev/nvidia0 does not exist"},
+		{name: "met_conditions_start", service: "kvm"},
+		{name: "without_conditions", service: "cron"},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newConditionsTestSupervisor(t)
+			s.refreshHostFacts(s.ctx, s.config)
+
+			s.mu.RLock()
+			event := s.checkConditions(s.config, tt.service)
+			s.mu.RUnlock()
+
+			// Met conditions carry no event.
+			if tt.wantReason == "" {
+				assert.Nil(t, event)
+				assert.Equal(t, domain.StateStopped, s.managers[tt.service].State())
+				return
+			}
+			require.NotNil(t, event)
+			assert.Equal(t, domain.EventSkipped, event.Type)
+			assert.ErrorIs(t, event.Error, ErrConditionsUnmet)
+			assert.Contains(t, event.Error.Error(), tt.wantReason)
+			status := s.managers[tt.service].Status()
+			assert.Equal(t, domain.StateSkipped, status.State)
+			assert.Equal(t, tt.wantReason, status.Reason)
+		})
+	}
+}
+
+// Test_Supervisor_StartService_conditions tests that a skipped start leaves the service stopped.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_StartService_conditions(t *testing.T) {
+	s, executor := newConditionsTestSupervisor(t)
+	var events []domain.EventType
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		events = append(events, event.Type)
+	}
+
+	err := s.StartService("gpu")
+
+	require.ErrorIs(t, err, ErrConditionsUnmet)
+	assert.Equal(t, shared.CodeConditionsUnmet, shared.CodeOf(err))
+	assert.False(t, s.managers["gpu"].Supervised())
+	assert.Equal(t, []domain.EventType{domain.EventSkipped}, events)
+	assert.Equal(t, []string{"/dev/nvidia0"}, s.hostFactsCollector.(*hostFactsTestCollector).paths)
+
+	require.NoError(t, s.StartService("cron"))
+	assert.Eventually(t, func() bool { return len(executor.launched()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"cron"}, executor.launched())
+}
+
+// Test_Supervisor_updateBoot_conditions tests that skipped services do not fail the boot.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_updateBoot_conditions(t *testing.T) {
+	s, _ := newConditionsTestSupervisor(t)
+	s.beginBoot()
+	event := domain.NewEvent(domain.EventSkipped, "gpu", 0, 0, ErrConditionsUnmet)
+
+	s.mu.Lock()
+	s.updateBoot("gpu", &event)
+	s.mu.Unlock()
+
+	report := s.BootReport()
+	assert.Equal(t, domainlifecycle.BootSkipped, report.Services[0].Status)
+	assert.Equal(t, ErrConditionsUnmet.Error(), report.Services[0].Error)
+}
+
+// Test_Supervisor_refreshHostFacts tests when the host facts are read.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_refreshHostFacts(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// collector is the host facts collector, nil when unset.
+		collector *hostFactsTestCollector
+		// unconditional drops the conditions of every service.
+		unconditional bool
+		// wantFacts indicates whether facts are expected.
+		wantFacts bool
+	}{
+		{name: "collected", collector: &hostFactsTestCollector{}, wantFacts: true},
+		{name: "failure_starts_every_service", collector: &hostFactsTestCollector{err: errors.New("no procfs")}},
+		{name: "without_collector"},
+		{name: "without_conditions", collector: &hostFactsTestCollector{}, unconditional: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newConditionsTestSupervisor(t)
+			s.hostFactsCollector = nil
+			// Install the collector under test.
+			if tt.collector != nil {
+				s.SetHostFactsCollector(tt.collector)
+			}
+			cfg := s.config
+			// Keep only the service without conditions.
+			if tt.unconditional {
+				cfg = &domainconfig.Config{Services: s.config.Services[2:]}
+			}
+
+			s.refreshHostFacts(context.Background(), cfg)
+
+			assert.Equal(t, tt.wantFacts, s.hostFacts != nil)
+		})
+	}
+}
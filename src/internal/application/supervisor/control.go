@@ -0,0 +1,29 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file reports whether the control plane accepts changes.
+package supervisor
+
+// SetLockdown keeps the control plane read-only whatever control.read_only
+// says, so reloading a configuration cannot reopen it.
+//
+// Params:
+//   - on: true to lock the control plane down.
+func (s *Supervisor) SetLockdown(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store lockdown
+	s.lockdown = on
+}
+
+// ReadOnly reports whether mutating API requests and CLI commands are
+// refused, from the lockdown and the current configuration. Signals sent
+// to the daemon are not affected: a reload through SIGHUP applies the
+// configuration file, which may turn read-only mode off.
+//
+// Returns:
+//   - bool: true if changes are refused.
+func (s *Supervisor) ReadOnly() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// the lockdown prevails over the configuration
+	return s.lockdown || (s.config != nil && s.config.Control.ReadOnly)
+}
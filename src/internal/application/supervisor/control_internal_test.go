@@ -0,0 +1,59 @@
+// Package supervisor provides internal tests for control.go.
+// It tests the read-only control plane using white-box testing.
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// Test_Supervisor_ReadOnly tests read-only mode from the configuration and the lockdown.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ReadOnly(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// config is the current configuration.
+		config *domainconfig.Config
+		// lockdown is the lockdown setting.
+		lockdown bool
+		// want is the expected read-only mode.
+		want bool
+	}{
+		{name: "writable", config: &domainconfig.Config{}},
+		{name: "no_config"},
+		{name: "configured", config: &domainconfig.Config{Control: domainconfig.ControlConfig{ReadOnly: true}}, want: true},
+		{name: "lockdown", config: &domainconfig.Config{}, lockdown: true, want: true},
+		{name: "lockdown_without_config", lockdown: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Supervisor{config: tt.config}
+			s.SetLockdown(tt.lockdown)
+			assert.Equal(t, tt.want, s.ReadOnly())
+		})
+	}
+}
+
+// Test_Supervisor_ReadOnly_reload tests that a reloaded configuration cannot lift the lockdown.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ReadOnly_reload(t *testing.T) {
+	s := &Supervisor{config: &domainconfig.Config{Control: domainconfig.ControlConfig{ReadOnly: true}}}
+	assert.True(t, s.ReadOnly())
+
+	// a reload applying a writable configuration reopens the control plane
+	s.config = &domainconfig.Config{}
+	assert.False(t, s.ReadOnly())
+
+	// unless the daemon was locked down
+	s.SetLockdown(true)
+	assert.True(t, s.ReadOnly())
+}
@@ -0,0 +1,102 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file reports the resource usage of the daemon itself.
+package supervisor
+
+import (
+	"cmp"
+	"context"
+	"runtime"
+	"slices"
+	"time"
+
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// SetSelfCollector sets the collector measuring the resident memory and open
+// file descriptors of the daemon. Without it, DaemonUsage reports them as zero.
+//
+// Params:
+//   - collector: the daemon process collector.
+func (s *Supervisor) SetSelfCollector(collector appmetrics.SelfCollector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store self collector
+	s.selfCollector = collector
+}
+
+// DaemonUsage returns the resource usage of the daemon: its process usage,
+// Go runtime statistics, the depth of the event queues of each service and
+// of the reload queue, and the duration of the last metrics collection and
+// health probe of each service.
+//
+// Params:
+//   - ctx: context for cancellation.
+//
+// Returns:
+//   - domainmetrics.DaemonUsage: the daemon usage; process usage is zero when it cannot be read.
+func (s *Supervisor) DaemonUsage(ctx context.Context) domainmetrics.DaemonUsage {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	usage := domainmetrics.DaemonUsage{
+		Goroutines:   runtime.NumGoroutine(),
+		HeapAlloc:    mem.HeapAlloc,
+		GCCycles:     mem.NumGC,
+		GCPauseTotal: time.Duration(mem.PauseTotalNs),
+	}
+	// The last pause is only recorded after a collection.
+	if mem.NumGC > 0 {
+		usage.LastGCPause = time.Duration(mem.PauseNs[(mem.NumGC+255)%256])
+	}
+
+	s.mu.RLock()
+	collector := s.selfCollector
+	tracker := s.metricsTracker
+	// Report the event queue of each service.
+	for name, mgr := range s.managers {
+		events := mgr.Events()
+		usage.Queues = append(usage.Queues, domainmetrics.QueueDepth{Name: "events/" + name, Depth: len(events), Capacity: cap(events)})
+	}
+	// Report the last probe of each health monitor.
+	for name, monitor := range s.healthMonitors {
+		usage.Loops = append(usage.Loops, domainmetrics.LoopLatency{Name: "health/" + name, Latency: monitor.Latency()})
+	}
+	s.mu.RUnlock()
+
+	usage.Queues = append(usage.Queues, s.reloadQueueDepth())
+	// Report the metrics collection loop when metrics are tracked.
+	if tracker != nil {
+		usage.Loops = append(usage.Loops, domainmetrics.LoopLatency{Name: "metrics", Latency: tracker.CollectDuration()})
+	}
+	slices.SortFunc(usage.Queues, func(a, b domainmetrics.QueueDepth) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(usage.Loops, func(a, b domainmetrics.LoopLatency) int { return cmp.Compare(a.Name, b.Name) })
+
+	// Measure the process when a collector is set.
+	if collector != nil {
+		self, err := collector.CollectSelf(ctx)
+		// Report the failure and keep the runtime statistics.
+		if err != nil {
+			s.handleRecoveryError("collect-self", "", err)
+		}
+		usage.SelfUsage = self
+	}
+	// return daemon usage
+	return usage
+}
+
+// reloadQueueDepth returns the depth of the configuration reload queue,
+// which holds at most one reload behind the running one.
+//
+// Returns:
+//   - domainmetrics.QueueDepth: the reload queue depth.
+func (s *Supervisor) reloadQueueDepth() domainmetrics.QueueDepth {
+	s.reloads.mu.Lock()
+	defer s.reloads.mu.Unlock()
+	depth := domainmetrics.QueueDepth{Name: "reload", Capacity: 1}
+	// A queued reload waits behind the running one.
+	if s.reloads.queued {
+		depth.Depth = 1
+	}
+	// return reload queue depth
+	return depth
+}
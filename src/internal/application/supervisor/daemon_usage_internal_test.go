@@ -0,0 +1,95 @@
+// Package supervisor provides internal tests for daemon_usage.go.
+// It tests the report of the daemon's own resource usage using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// selfTestCollector returns a fixed daemon process usage.
+type selfTestCollector struct {
+	// usage is the returned usage.
+	usage domainmetrics.SelfUsage
+	// err is the returned error.
+	err error
+}
+
+// CollectSelf returns the fixed usage.
+//
+// Returns:
+//   - domainmetrics.SelfUsage: the configured usage.
+//   - error: the configured error.
+func (c *selfTestCollector) CollectSelf(_ context.Context) (domainmetrics.SelfUsage, error) {
+	return c.usage, c.err
+}
+
+// Test_Supervisor_DaemonUsage tests the daemon usage report.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_DaemonUsage(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// collector is the self collector, nil when unset.
+		collector *selfTestCollector
+		// wantSelf is the expected process usage.
+		wantSelf domainmetrics.SelfUsage
+	}{
+		{
+			name:      "measured",
+			collector: &selfTestCollector{usage: domainmetrics.SelfUsage{RSS: 20 << 20, OpenFDs: 12}},
+			wantSelf:  domainmetrics.SelfUsage{RSS: 20 << 20, OpenFDs: 12},
+		},
+		{
+			name:      "collector_failure",
+			collector: &selfTestCollector{err: errors.New("no procfs")},
+		},
+		{
+			name: "without_collector",
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &phaseTestExecutor{}
+			s := &Supervisor{
+				managers: map[string]*applifecycle.Manager{
+					"db":  applifecycle.NewManager(&domainconfig.ServiceConfig{Name: "db", Command: "/bin/db"}, executor),
+					"api": applifecycle.NewManager(&domainconfig.ServiceConfig{Name: "api", Command: "/bin/api"}, executor),
+				},
+				healthMonitors: map[string]*apphealth.ProbeMonitor{"api": apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})},
+			}
+			s.SetMetricsTracker(appmetrics.NewTracker(nil))
+			s.reloads.queued = true
+			// Set the collector when configured.
+			if tt.collector != nil {
+				s.SetSelfCollector(tt.collector)
+			}
+
+			usage := s.DaemonUsage(context.Background())
+
+			assert.Equal(t, tt.wantSelf, usage.SelfUsage)
+			assert.Positive(t, usage.Goroutines)
+			assert.Positive(t, usage.HeapAlloc)
+			assert.Equal(t, []domainmetrics.QueueDepth{
+				{Name: "events/api", Capacity: 16},
+				{Name: "events/db", Capacity: 16},
+				{Name: "reload", Depth: 1, Capacity: 1},
+			}, usage.Queues)
+			assert.Equal(t, []domainmetrics.LoopLatency{{Name: "health/api"}, {Name: "metrics"}}, usage.Loops)
+		})
+	}
+}
@@ -0,0 +1,357 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file probes the external dependencies declared by services.
+package supervisor
+
+import (
+	"fmt"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/listener"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// startDependencyMonitors creates and starts a probe monitor for each service
+// declaring external dependencies. Dependency monitors are separate from
+// service health monitors: a failing dependency never restarts the service.
+func (s *Supervisor) startDependencyMonitors() {
+	// start one monitor per service with dependencies
+	for i := range s.config.Services {
+		svc := &s.config.Services[i]
+		monitor := s.createDependencyMonitor(svc)
+		// Skip services without dependencies.
+		if monitor == nil {
+			continue
+		}
+		// Store and start the monitor.
+		s.mu.Lock()
+		monitor.SetDefaults(s.probeInterval, s.probeTimeout)
+		s.dependencyMonitors[svc.Name] = monitor
+		s.markDependenciesNotReady(svc)
+		s.mu.Unlock()
+		monitor.Start(s.ctx)
+	}
+}
+
+// stopDependencyMonitors stops every dependency monitor.
+func (s *Supervisor) stopDependencyMonitors() {
+	s.mu.RLock()
+	monitors := make([]*apphealth.ProbeMonitor, 0, len(s.dependencyMonitors))
+	// collect monitors to stop outside the lock
+	for _, monitor := range s.dependencyMonitors {
+		monitors = append(monitors, monitor)
+	}
+	s.mu.RUnlock()
+
+	// stop each monitor
+	for _, monitor := range monitors {
+		monitor.Stop()
+	}
+}
+
+// createDependencyMonitor creates the probe monitor for the external
+// dependencies of a service.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - *apphealth.ProbeMonitor: the monitor, nil without dependencies or prober factory.
+func (s *Supervisor) createDependencyMonitor(svc *domainconfig.ServiceConfig) *apphealth.ProbeMonitor {
+	// return nil if no dependencies configured or factory unavailable
+	if len(svc.ExternalDependencies) == 0 || s.proberFactory == nil {
+		// Nothing to probe.
+		return nil
+	}
+	serviceName := svc.Name
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{
+		Factory: s.proberFactory,
+		Clock:   s.clock,
+		OnStateChange: func(name string, from, to domainhealth.SubjectState, result domainhealth.CheckResult) {
+			// Report the dependency transition next to the service.
+			s.dependencyStateChanged(serviceName, name, from, to, result)
+			// Retry a service that failed on its dependencies once they are back.
+			s.dependencyRecovered(serviceName, name, to)
+		},
+		OnProbeAttempt: func(attempt domainhealth.ProbeAttempt) {
+			// Forward attempts of debug probes.
+			s.notifyProbeAttempt(serviceName, attempt)
+		},
+	})
+	// Dependencies live outside the service process.
+	monitor.SetProcessState(domain.StateRunning)
+
+	// Add each dependency as a probed subject.
+	for i := range svc.ExternalDependencies {
+		dep := &svc.ExternalDependencies[i]
+		subject := listener.NewListener(dep.Name, dependencyNetwork(dep.Probe.Type), dep.Address, 0)
+		subject.MarkListening()
+		// Errors are silently ignored - dependency monitoring is best-effort.
+		_ = monitor.AddListenerWithBinding(subject, createDependencyBinding(dep))
+	}
+	// return configured monitor
+	return monitor
+}
+
+// createDependencyBinding creates the probe binding of an external dependency.
+//
+// Params:
+//   - dep: the dependency configuration.
+//
+// Returns:
+//   - *apphealth.ProbeBinding: the probe binding.
+func createDependencyBinding(dep *domainconfig.DependencyConfig) *apphealth.ProbeBinding {
+	// return probe binding configuration
+	return &apphealth.ProbeBinding{
+		ListenerName: dep.Name,
+		Type:         apphealth.ProbeType(dep.Probe.Type),
+		Target: apphealth.ProbeTarget{
+			Network:    dependencyNetwork(dep.Probe.Type),
+			Address:    dep.Address,
+			Path:       dep.Probe.Path,
+			Service:    dep.Probe.Service,
+			Method:     dep.Probe.Method,
+			StatusCode: dep.Probe.StatusCode,
+			Command:    dep.Probe.Command,
+			Args:       dep.Probe.Args,
+			Steps:      scenarioSteps(dep.Probe.Steps),
+			Threshold:  probeThreshold(dep.Probe.Threshold),
+		},
+		Config: apphealth.ProbeConfig{
+			Timeout:          probeTimeout(&dep.Probe),
+			Interval:         probeInterval(&dep.Probe),
+			SuccessThreshold: dep.Probe.SuccessThreshold,
+			FailureThreshold: dep.Probe.FailureThreshold,
+			Debug:            dep.Probe.Debug,
+		},
+	}
+}
+
+// dependencyNetwork returns the network dialed by a dependency probe.
+//
+// Params:
+//   - probeType: the probe type.
+//
+// Returns:
+//   - string: udp for udp probes, tcp otherwise.
+func dependencyNetwork(probeType string) string {
+	// only udp probes dial datagram sockets
+	if probeType == domainconfig.ProbeTypeUDP {
+		// datagram network
+		return "udp"
+	}
+	// stream network
+	return "tcp"
+}
+
+// dependencyStateChanged emits an event when a dependency goes down, or
+// comes back after being reported down.
+//
+// Params:
+//   - serviceName: the service declaring the dependency.
+//   - name: the dependency name.
+//   - from: the previous subject state.
+//   - to: the new subject state.
+//   - result: the probe result causing the change.
+func (s *Supervisor) dependencyStateChanged(serviceName, name string, from, to domainhealth.SubjectState, result domainhealth.CheckResult) {
+	var event domain.Event
+	s.mu.Lock()
+	// classify the transition
+	switch {
+	// ready to not ready: the dependency went down
+	case from == domainhealth.SubjectReady && to != domainhealth.SubjectReady:
+		// remember the outage for the recovery event
+		if s.dependenciesDown[serviceName] == nil {
+			s.dependenciesDown[serviceName] = make(map[string]bool)
+		}
+		s.dependenciesDown[serviceName][name] = true
+		event = domain.NewEvent(domain.EventDependencyDown, serviceName, 0, 0,
+			fmt.Errorf("%w: %q: %s", ErrDependencyDown, name, dependencyFailure(result)))
+		event.Dependency = s.dependencyAddress(serviceName, name)
+	// back to ready after a reported outage
+	case to == domainhealth.SubjectReady && s.dependenciesDown[serviceName][name]:
+		delete(s.dependenciesDown[serviceName], name)
+		event = domain.NewEvent(domain.EventDependencyUp, serviceName, 0, 0, nil)
+		event.Dependency = s.dependencyAddress(serviceName, name)
+	// first success or other change: nothing to report
+	default:
+		s.mu.Unlock()
+		// no event
+		return
+	}
+	snap := s.getStatsSnapshot(s.stats[serviceName])
+	s.mu.Unlock()
+
+	// notify outside the lock
+	s.callEventHandler(serviceName, &event, snap)
+}
+
+// dependencyAddress returns the address of a declared external dependency.
+// Must be called with s.mu held.
+//
+// Params:
+//   - serviceName: the service declaring the dependency.
+//   - name: the dependency name.
+//
+// Returns:
+//   - string: the dependency address, empty if unknown.
+func (s *Supervisor) dependencyAddress(serviceName, name string) string {
+	deps := s.declaredDependencies(serviceName)
+	// find the dependency by name
+	for i := range deps {
+		// matching dependency
+		if deps[i].Name == name {
+			// return its address
+			return deps[i].Address
+		}
+	}
+	// unknown dependency
+	return ""
+}
+
+// dependencyFailure describes why a dependency probe failed.
+//
+// Params:
+//   - result: the probe result.
+//
+// Returns:
+//   - string: the error, or the probe output when no error is set.
+func dependencyFailure(result domainhealth.CheckResult) string {
+	// prefer the probe error
+	if result.Error != nil {
+		// error message
+		return result.Error.Error()
+	}
+	// fall back to the probe output
+	return result.Output
+}
+
+// Dependencies returns the probed state of the external dependencies of a
+// service, in configuration order.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - []domainhealth.DependencyStatus: the dependency states, nil without dependencies.
+//   - error: ErrServiceNotFound if the service is unknown.
+func (s *Supervisor) Dependencies(name string) ([]domainhealth.DependencyStatus, error) {
+	s.mu.RLock()
+	_, ok := s.managers[name]
+	monitor := s.dependencyMonitors[name]
+	deps := s.declaredDependencies(name)
+	s.mu.RUnlock()
+
+	// validate service exists
+	if !ok {
+		// return not found error
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	// return statuses merged with probe results
+	return dependencyStatuses(deps, monitor), nil
+}
+
+// declaredDependencies returns the external dependencies configured for a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - []domainconfig.DependencyConfig: the dependencies, nil when none.
+func (s *Supervisor) declaredDependencies(name string) []domainconfig.DependencyConfig {
+	// no configuration loaded
+	if s.config == nil {
+		// no dependencies
+		return nil
+	}
+	svc := s.config.FindService(name)
+	// unknown service
+	if svc == nil {
+		// no dependencies
+		return nil
+	}
+	// return configured dependencies
+	return svc.ExternalDependencies
+}
+
+// dependencyStatuses builds the status of each dependency from the last probe results.
+// Must be called without s.mu held.
+//
+// Params:
+//   - deps: the declared dependencies.
+//   - monitor: the dependency monitor, nil when not started.
+//
+// Returns:
+//   - []domainhealth.DependencyStatus: the dependency states.
+func dependencyStatuses(deps []domainconfig.DependencyConfig, monitor *apphealth.ProbeMonitor) []domainhealth.DependencyStatus {
+	// skip services without dependencies
+	if len(deps) == 0 {
+		// no dependencies
+		return nil
+	}
+	subjects := make(map[string]domainhealth.SubjectStatus)
+	// index probe results by dependency name
+	if monitor != nil {
+		for _, subject := range monitor.Health().Subjects {
+			subjects[subject.Name] = subject
+		}
+	}
+
+	statuses := make([]domainhealth.DependencyStatus, 0, len(deps))
+	// report each declared dependency
+	for i := range deps {
+		dep := &deps[i]
+		status := domainhealth.DependencyStatus{
+			Name:        dep.Name,
+			Type:        dep.Probe.Type,
+			Address:     dep.Address,
+			GateRestart: dep.GateRestart,
+		}
+		// attach the last probe result when known
+		if subject, ok := subjects[dep.Name]; ok && subject.LastProbeResult != nil {
+			result := subject.LastProbeResult
+			status.Healthy = subject.State == domainhealth.SubjectReady
+			status.LastCheck = result.Timestamp
+			status.Latency = result.Duration
+			// keep the failure of the last probe
+			if result.Status != domainhealth.StatusHealthy {
+				status.Error = result.Message
+				// prefer the probe error
+				if result.Error != nil {
+					status.Error = result.Error.Error()
+				}
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	// return dependency states
+	return statuses
+}
+
+// restartGate returns the first gating dependency of a service that is down.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - string: the down dependency name.
+//   - bool: true if a restart must be held back.
+func (s *Supervisor) restartGate(name string) (string, bool) {
+	s.mu.RLock()
+	monitor := s.dependencyMonitors[name]
+	deps := s.declaredDependencies(name)
+	s.mu.RUnlock()
+
+	// look for a gating dependency that is down
+	for _, status := range dependencyStatuses(deps, monitor) {
+		// only gating dependencies hold back restarts
+		if status.GateRestart && status.Down() {
+			// restart gated
+			return status.Name, true
+		}
+	}
+	// restart allowed
+	return "", false
+}
@@ -0,0 +1,185 @@
+// Package supervisor provides internal tests for dependencies.go.
+// It tests external dependency probing using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// switchProber succeeds or fails depending on a shared flag.
+type switchProber struct {
+	// up reports whether probes succeed.
+	up *atomic.Bool
+}
+
+// Probe returns a result following the flag.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - target: the probe target (unused).
+//
+// Returns:
+//   - domainhealth.CheckResult: the probe result.
+func (p *switchProber) Probe(_ context.Context, _ domainhealth.Target) domainhealth.CheckResult {
+	// fail while the flag is down
+	if !p.up.Load() {
+		return domainhealth.NewFailureCheckResult(time.Millisecond, "", errors.New("connection refused"))
+	}
+	return domainhealth.NewSuccessCheckResult(time.Millisecond, "ok")
+}
+
+// Type returns the prober type.
+//
+// Returns:
+//   - string: the prober type.
+func (p *switchProber) Type() string {
+	return "tcp"
+}
+
+// switchFactory creates switchProber instances sharing one flag.
+type switchFactory struct {
+	// up reports whether probes succeed.
+	up atomic.Bool
+}
+
+// Create returns a switchProber.
+//
+// Params:
+//   - proberType: the prober type (unused).
+//   - timeout: the probe timeout (unused).
+//
+// Returns:
+//   - domainhealth.Prober: the prober.
+//   - error: always nil.
+func (f *switchFactory) Create(_ string, _ time.Duration) (domainhealth.Prober, error) {
+	return &switchProber{up: &f.up}, nil
+}
+
+// Test_Supervisor_Dependencies tests dependency status, events and restart gating.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_Dependencies(t *testing.T) {
+	probe := domainconfig.ProbeConfig{
+		Type:             "tcp",
+		Interval:         shared.FromTimeDuration(5 * time.Millisecond),
+		SuccessThreshold: 1,
+		FailureThreshold: 1,
+	}
+	svc := domainconfig.ServiceConfig{
+		Name:    "api",
+		Command: "/bin/api",
+		ExternalDependencies: []domainconfig.DependencyConfig{
+			{Name: "db", Address: "db.internal:5432", Probe: probe, GateRestart: true},
+			{Name: "billing", Address: "billing.example.com:443", Probe: probe},
+		},
+	}
+	factory := &switchFactory{}
+	factory.up.Store(true)
+	s := &Supervisor{
+		config:             &domainconfig.Config{Services: []domainconfig.ServiceConfig{svc}},
+		managers:           map[string]*applifecycle.Manager{"api": applifecycle.NewManager(&svc, &proxyTestExecutor{})},
+		stats:              map[string]*ServiceStats{"api": NewServiceStats()},
+		dependencyMonitors: map[string]*apphealth.ProbeMonitor{},
+		dependenciesDown:   map[string]map[string]bool{},
+		proberFactory:      factory,
+		ctx:                context.Background(),
+	}
+	var mu sync.Mutex
+	var events []domain.EventType
+	var addresses []string
+	s.SetEventHandler(func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event.Type)
+		addresses = append(addresses, event.Dependency)
+	})
+	eventCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events)
+	}
+	healthy := func(want bool) func() bool {
+		return func() bool {
+			deps, err := s.Dependencies("api")
+			return err == nil && len(deps) == 2 && deps[0].Checked() && deps[0].Healthy == want
+		}
+	}
+
+	s.startDependencyMonitors()
+	defer s.stopDependencyMonitors()
+
+	// Reachable dependencies do not gate restarts.
+	require.Eventually(t, healthy(true), time.Second, 2*time.Millisecond)
+	deps, _ := s.Dependencies("api")
+	assert.Equal(t, "db", deps[0].Name)
+	assert.True(t, deps[0].GateRestart)
+	assert.Equal(t, "billing", deps[1].Name)
+	_, gated := s.restartGate("api")
+	assert.False(t, gated)
+	// The first success is not reported as a recovery.
+	assert.Zero(t, eventCount())
+
+	// An outage is reported and holds back health-check restarts.
+	factory.up.Store(false)
+	require.Eventually(t, healthy(false), time.Second, 2*time.Millisecond)
+	deps, _ = s.Dependencies("api")
+	assert.True(t, deps[0].Down())
+	assert.Equal(t, "connection refused", deps[0].Error)
+	dep, gated := s.restartGate("api")
+	assert.True(t, gated)
+	assert.Equal(t, "db", dep)
+	assert.ErrorIs(t, s.RestartOnHealthFailure("api", "probe failed"), ErrRestartGated)
+
+	// Recovery is reported once the dependency answers again.
+	factory.up.Store(true)
+	require.Eventually(t, healthy(true), time.Second, 2*time.Millisecond)
+	require.Eventually(t, func() bool { return eventCount() == 4 }, time.Second, 2*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.ElementsMatch(t, []domain.EventType{
+		domain.EventDependencyDown, domain.EventDependencyDown,
+		domain.EventDependencyUp, domain.EventDependencyUp,
+	}, events)
+	// Events name the dependency address for correlation.
+	assert.ElementsMatch(t, []string{
+		"db.internal:5432", "billing.example.com:443",
+		"db.internal:5432", "billing.example.com:443",
+	}, addresses)
+}
+
+// Test_Supervisor_Dependencies_lookup tests dependency lookup of unknown
+// services and services without dependencies.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_Dependencies_lookup(t *testing.T) {
+	svc := domainconfig.ServiceConfig{Name: "worker", Command: "/bin/worker"}
+	s := &Supervisor{
+		config:   &domainconfig.Config{Services: []domainconfig.ServiceConfig{svc}},
+		managers: map[string]*applifecycle.Manager{"worker": applifecycle.NewManager(&svc, &proxyTestExecutor{})},
+	}
+
+	_, err := s.Dependencies("missing")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+
+	deps, err := s.Dependencies("worker")
+	require.NoError(t, err)
+	assert.Empty(t, deps)
+	assert.Nil(t, s.createDependencyMonitor(&svc))
+}
@@ -0,0 +1,133 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file retries services that failed while an external dependency was down.
+package supervisor
+
+import (
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// markDependenciesNotReady records every external dependency of a service
+// as not ready until its first successful probe. Must be called with s.mu held.
+//
+// Params:
+//   - svc: the service configuration.
+func (s *Supervisor) markDependenciesNotReady(svc *domainconfig.ServiceConfig) {
+	// create the map on first use
+	if s.dependenciesNotReady == nil {
+		s.dependenciesNotReady = make(map[string]map[string]bool)
+	}
+	pending := make(map[string]bool, len(svc.ExternalDependencies))
+	// every dependency waits for its first success
+	for i := range svc.ExternalDependencies {
+		pending[svc.ExternalDependencies[i].Name] = true
+	}
+	s.dependenciesNotReady[svc.Name] = pending
+}
+
+// dependencyDown reports whether an external dependency of a service is
+// not ready: never probed healthy yet, or failing its probe.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - bool: true if a dependency is down.
+func (s *Supervisor) dependencyDown(name string) bool {
+	// return whether any dependency is not ready
+	return len(s.dependenciesNotReady[name]) > 0
+}
+
+// updateDependencyFailure records the failures of a service seen while one
+// of its external dependencies was down, and forgets them once the service
+// starts, stops cleanly or fails with every dependency up.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) updateDependencyFailure(name string, event *domain.Event) {
+	failure := event.Type == domain.EventFailed || event.Type == domain.EventExhausted
+	// remember the failure until the dependencies recover
+	if failure && s.dependencyDown(name) {
+		// create the map on first use
+		if s.dependencyFailures == nil {
+			s.dependencyFailures = make(map[string]domain.EventType)
+		}
+		s.dependencyFailures[name] = event.Type
+		// failure recorded
+		return
+	}
+	// another failure, a start or a clean stop ends the retry
+	if failure || event.Type == domain.EventStarted || event.Type == domain.EventStopped {
+		delete(s.dependencyFailures, name)
+	}
+}
+
+// forgetDependencyFailure drops the pending retry of a service, for a
+// manual stop.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) forgetDependencyFailure(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.dependencyFailures, name)
+}
+
+// dependencyRecovered tracks the probe state of an external dependency and
+// restarts a service that failed while its dependencies were down, once
+// they are all up again. A service waiting to restart skips the rest of its
+// backoff; a service whose restarts were exhausted is started once more, as
+// a manual start would.
+//
+// Params:
+//   - serviceName: the service declaring the dependency.
+//   - name: the dependency name.
+//   - to: the new subject state.
+func (s *Supervisor) dependencyRecovered(serviceName, name string, to domainhealth.SubjectState) {
+	s.mu.Lock()
+	// track the dependencies not ready
+	if to != domainhealth.SubjectReady {
+		// create the maps on first use
+		if s.dependenciesNotReady == nil {
+			s.dependenciesNotReady = make(map[string]map[string]bool)
+		}
+		if s.dependenciesNotReady[serviceName] == nil {
+			s.dependenciesNotReady[serviceName] = make(map[string]bool)
+		}
+		s.dependenciesNotReady[serviceName][name] = true
+		s.mu.Unlock()
+		// not a recovery
+		return
+	}
+	delete(s.dependenciesNotReady[serviceName], name)
+	failure, failed := s.dependencyFailures[serviceName]
+	// wait for the last dependency down
+	if !failed || s.dependencyDown(serviceName) {
+		s.mu.Unlock()
+		// nothing to retry yet
+		return
+	}
+	delete(s.dependencyFailures, serviceName)
+	mgr := s.managers[serviceName]
+	s.mu.Unlock()
+
+	// the service was removed
+	if mgr == nil {
+		// no retry
+		return
+	}
+	// cut the backoff short
+	if mgr.RetryNow() {
+		// restart under way
+		return
+	}
+	// exhausted services are started again
+	if failure == domain.EventExhausted && !mgr.Supervised() {
+		// Errors are reported by events - the retry is best-effort.
+		_ = s.StartService(serviceName)
+	}
+}
@@ -0,0 +1,186 @@
+// Package supervisor provides internal tests for dependency_retry.go.
+// It tests the retry of services on dependency recovery using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// retryTestExecutor fails every start, as a service unable to reach its database.
+type retryTestExecutor struct {
+	// starts counts the start attempts.
+	starts atomic.Int32
+}
+
+// Start counts the attempt and fails.
+//
+// Returns:
+//   - int: always 0.
+//   - <-chan domain.ExitResult: always nil.
+//   - error: always a connection error.
+func (e *retryTestExecutor) Start(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+	e.starts.Add(1)
+	return 0, nil, errors.New("connection refused")
+}
+
+// Stop is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *retryTestExecutor) Stop(_ int, _ time.Duration) error { return nil }
+
+// Signal is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *retryTestExecutor) Signal(_ int, _ os.Signal) error { return nil }
+
+// Test_Supervisor_dependencyRecovered tests that services failing
+// during a dependency outage are retried once the dependency is back.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_dependencyRecovered(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// maxRetries is the restart limit of the service.
+		maxRetries int
+		// delay is the restart backoff of the service.
+		delay time.Duration
+		// failures is the number of failed starts before the recovery.
+		failures int32
+	}{
+		{name: "backoff_cut_short", maxRetries: 3, delay: time.Hour, failures: 1},
+		{name: "exhausted_started_again", maxRetries: 1, delay: time.Millisecond, failures: 2},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			svc := domainconfig.ServiceConfig{
+				Name:    "api",
+				Command: "/bin/api",
+				Restart: domainconfig.RestartConfig{
+					Policy:     domainconfig.RestartAlways,
+					MaxRetries: tt.maxRetries,
+					Delay:      shared.FromTimeDuration(tt.delay),
+				},
+				ExternalDependencies: []domainconfig.DependencyConfig{{Name: "db", Address: "db.internal:5432", Probe: domainconfig.ProbeConfig{
+					Type:             "tcp",
+					Interval:         shared.FromTimeDuration(5 * time.Millisecond),
+					SuccessThreshold: 1,
+					FailureThreshold: 1,
+				}}},
+			}
+			executor := &retryTestExecutor{}
+			mgr := applifecycle.NewManager(&svc, executor)
+			ctx, cancel := context.WithCancel(context.Background())
+			s := &Supervisor{
+				config:             &domainconfig.Config{Services: []domainconfig.ServiceConfig{svc}},
+				managers:           map[string]*applifecycle.Manager{"api": mgr},
+				stats:              map[string]*ServiceStats{"api": NewServiceStats()},
+				dependencyMonitors: map[string]*apphealth.ProbeMonitor{},
+				dependenciesDown:   map[string]map[string]bool{},
+				proberFactory:      &switchFactory{},
+				ctx:                ctx,
+			}
+			s.wg.Add(1)
+			go s.monitorService("api", mgr)
+			t.Cleanup(func() {
+				_ = mgr.Stop()
+				cancel()
+				s.wg.Wait()
+			})
+
+			// The service fails while its database is down.
+			s.startDependencyMonitors()
+			defer s.stopDependencyMonitors()
+			require.Eventually(t, func() bool {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				return s.dependencyDown("api")
+			}, time.Second, 2*time.Millisecond)
+			require.NoError(t, mgr.Start(ctx))
+			require.Eventually(t, func() bool {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				_, failed := s.dependencyFailures["api"]
+				return failed && executor.starts.Load() == tt.failures
+			}, time.Second, 2*time.Millisecond)
+
+			// The recovery restarts it without waiting for the backoff.
+			s.proberFactory.(*switchFactory).up.Store(true)
+			require.Eventually(t, func() bool { return executor.starts.Load() == tt.failures+1 }, time.Second, 2*time.Millisecond)
+		})
+	}
+}
+
+// Test_Supervisor_updateDependencyFailure tests which events record or
+// clear the retry of a service.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_updateDependencyFailure(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// recorded is the failures already recorded.
+		recorded map[string]domain.EventType
+		// event is the event type.
+		event domain.EventType
+		// down is the dependencies failing their probe.
+		down map[string]map[string]bool
+		// wantRetry is whether a retry is recorded afterwards.
+		wantRetry bool
+		// want is the failure recorded afterwards.
+		want domain.EventType
+	}{
+		{name: "failure_on_dependency", event: domain.EventFailed, down: map[string]map[string]bool{"api": {"db": true}}, wantRetry: true, want: domain.EventFailed},
+		{
+			name:     "exhausted_on_dependency",
+			recorded: map[string]domain.EventType{"api": domain.EventFailed},
+			event:    domain.EventExhausted, down: map[string]map[string]bool{"api": {"db": true}}, wantRetry: true, want: domain.EventExhausted,
+		},
+		{name: "failure_with_dependencies_up", recorded: map[string]domain.EventType{"api": domain.EventFailed}, event: domain.EventFailed},
+		{name: "started", recorded: map[string]domain.EventType{"api": domain.EventFailed}, event: domain.EventStarted},
+		{name: "stopped", recorded: map[string]domain.EventType{"api": domain.EventExhausted}, event: domain.EventStopped},
+		{
+			name:     "restarting",
+			recorded: map[string]domain.EventType{"api": domain.EventFailed},
+			event:    domain.EventRestarting, wantRetry: true, want: domain.EventFailed,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Supervisor{dependencyFailures: tt.recorded, dependenciesNotReady: tt.down}
+
+			s.updateDependencyFailure("api", &domain.Event{Type: tt.event})
+
+			got, retry := s.dependencyFailures["api"]
+			assert.Equal(t, tt.wantRetry, retry)
+			assert.Equal(t, tt.want, got)
+			// A manual stop drops the retry.
+			s.forgetDependencyFailure("api")
+			assert.Empty(t, s.dependencyFailures)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file spawns the ephemeral services run for remote diagnostics.
+package supervisor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// ephemeralPrefix starts the generated names of ephemeral services.
+	ephemeralPrefix string = "debug-"
+	// ephemeralIDBytes is the number of random bytes in ephemeral names.
+	ephemeralIDBytes int = 4
+)
+
+// ephemeral is the state of a running ephemeral service.
+type ephemeral struct {
+	// expiry stops the run once its TTL elapses.
+	expiry shared.Timer
+	// cancel ends the monitoring of the service.
+	cancel context.CancelFunc
+}
+
+// SpawnEphemeral runs a command once as an unnamed service, supervised like
+// the configured ones: its events are reported, its statistics kept and it
+// is listed among the services. The service is removed once the process
+// exits or its TTL elapses, and configuration reloads leave it alone.
+//
+// Params:
+//   - spec: the command, environment, TTL and sandbox of the run.
+//
+// Returns:
+//   - domain.EphemeralService: the generated name and the expiry of the run.
+//   - error: domain.ErrInvalidEphemeral, ErrServiceNotFound for an unknown sandbox
+//     service, ErrNotRunning, or the start error.
+//
+// Goroutine lifecycle:
+//   - Spawns a goroutine monitoring the service until it is removed or the
+//     supervisor stops.
+func (s *Supervisor) SpawnEphemeral(spec *domain.EphemeralSpec) (domain.EphemeralService, error) {
+	ttl, err := spec.EffectiveTTL()
+	// reject specs that cannot run
+	if err != nil {
+		// return validation error
+		return domain.EphemeralService{}, err
+	}
+
+	s.mu.Lock()
+	// refuse spawning outside a running supervisor
+	if s.state != StateRunning {
+		s.mu.Unlock()
+		// return not running error
+		return domain.EphemeralService{}, ErrNotRunning
+	}
+	svc, err := s.ephemeralConfig(spec)
+	// sandbox service unknown
+	if err != nil {
+		s.mu.Unlock()
+		// return lookup error
+		return domain.EphemeralService{}, err
+	}
+	mgr := applifecycle.NewManager(svc, s.executor)
+	mgr.SetClock(s.clock)
+	mgr.SetEventSequence(&s.eventSeq)
+	ctx, cancel := context.WithCancel(s.ctx)
+	// create ephemeral map on first use
+	if s.ephemerals == nil {
+		s.ephemerals = make(map[string]*ephemeral)
+	}
+	s.managers[svc.Name] = mgr
+	s.stats[svc.Name] = NewServiceStats()
+	s.ephemerals[svc.Name] = &ephemeral{
+		expiry: s.clock.AfterFunc(ttl, func() { s.expireEphemeral(svc.Name, mgr) }),
+		cancel: cancel,
+	}
+	s.wg.Go(func() {
+		// Report events until the service is removed.
+		s.monitorEphemeral(ctx, svc.Name, mgr)
+	})
+	s.publishStatus()
+	s.mu.Unlock()
+
+	// Start the run; a failed start leaves nothing behind.
+	if err := mgr.Start(ctx); err != nil {
+		s.removeEphemeral(svc.Name)
+		// return start error with the name
+		return domain.EphemeralService{}, fmt.Errorf("starting %s: %w", svc.Name, err)
+	}
+	// return the spawned service
+	return domain.EphemeralService{Name: svc.Name, ExpiresAt: s.clock.Now().Add(ttl)}, nil
+}
+
+// ephemeralConfig builds the service configuration of a spec: a oneshot
+// service under a fresh name, sandboxed like spec.SandboxFrom when set.
+// Must be called with s.mu held.
+//
+// Params:
+//   - spec: the ephemeral service spec.
+//
+// Returns:
+//   - *domainconfig.ServiceConfig: the service configuration.
+//   - error: ErrServiceNotFound for an unknown sandbox service.
+func (s *Supervisor) ephemeralConfig(spec *domain.EphemeralSpec) (*domainconfig.ServiceConfig, error) {
+	svc := domainconfig.NewServiceConfig(s.ephemeralName(), spec.Command)
+	svc.Args = slices.Clone(spec.Args)
+	svc.Oneshot = true
+	svc.Restart = domainconfig.RestartConfig{Policy: domainconfig.RestartNever}
+	// reuse the sandbox of a configured service
+	if spec.SandboxFrom != "" {
+		from := s.config.FindService(spec.SandboxFrom)
+		// sandbox service must exist
+		if from == nil {
+			// return error with the name
+			return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, spec.SandboxFrom)
+		}
+		svc.User = from.User
+		svc.Group = from.Group
+		svc.WorkingDirectory = from.WorkingDirectory
+		svc.Environment = maps.Clone(from.Environment)
+		svc.SecretEnv = slices.Clone(from.SecretEnv)
+		svc.Secrets = slices.Clone(from.Secrets)
+		svc.SELinuxContext = from.SELinuxContext
+		svc.AppArmorProfile = from.AppArmorProfile
+		svc.ReadOnlyPaths = slices.Clone(from.ReadOnlyPaths)
+		svc.MaskedPaths = slices.Clone(from.MaskedPaths)
+		svc.TmpfsPaths = slices.Clone(from.TmpfsPaths)
+		svc.PIDNamespace = from.PIDNamespace
+		svc.Egress = slices.Clone(from.Egress)
+		svc.DNS = from.DNS
+	}
+	// set the requested variables over the sandbox ones
+	if len(spec.Env) > 0 {
+		// create environment when the sandbox has none
+		if svc.Environment == nil {
+			svc.Environment = make(map[string]string, len(spec.Env))
+		}
+		maps.Copy(svc.Environment, spec.Env)
+		// requested variables replace the secret files
+		svc.Secrets = slices.DeleteFunc(svc.Secrets, func(secret domainconfig.SecretConfig) bool {
+			_, ok := spec.Env[secret.Env]
+			// drop the secret set by the spec
+			return ok
+		})
+	}
+	// return service configuration
+	return &svc, nil
+}
+
+// ephemeralName generates a service name not used by any manager.
+// Must be called with s.mu held.
+//
+// Returns:
+//   - string: the generated name.
+func (s *Supervisor) ephemeralName() string {
+	buf := make([]byte, ephemeralIDBytes)
+	// draw until the name is free
+	for {
+		// crypto/rand.Read never returns an error
+		_, _ = rand.Read(buf)
+		name := ephemeralPrefix + hex.EncodeToString(buf)
+		// keep names unused by configured and ephemeral services
+		if _, taken := s.managers[name]; !taken && s.config.FindService(name) == nil {
+			// return free name
+			return name
+		}
+	}
+}
+
+// monitorEphemeral reports the events of an ephemeral service and removes
+// the service once its run ends.
+//
+// Params:
+//   - ctx: the monitoring context, cancelled on removal.
+//   - name: the service name.
+//   - mgr: the service manager.
+func (s *Supervisor) monitorEphemeral(ctx context.Context, name string, mgr Eventser) {
+	events := mgr.Events()
+	// Loop until the service is removed or the supervisor stops.
+	for {
+		select {
+		case <-ctx.Done():
+			// Return when monitoring ends.
+			return
+		case event := <-events:
+			s.handleEvent(name, &event)
+			// The run is over.
+			if event.Type == domain.EventStopped || event.Type == domain.EventFailed {
+				s.removeEphemeral(name)
+				// Return once removed.
+				return
+			}
+		}
+	}
+}
+
+// expireEphemeral stops an ephemeral service whose TTL elapsed. Its stopped
+// event then removes it. Stop failures are reported through the error handler.
+//
+// Params:
+//   - name: the service name.
+//   - mgr: the manager the TTL was armed for.
+func (s *Supervisor) expireEphemeral(name string, mgr *applifecycle.Manager) {
+	s.mu.RLock()
+	current := s.managers[name]
+	s.mu.RUnlock()
+	// Skip services removed since the timer fired.
+	if current != mgr {
+		// Stale expiry.
+		return
+	}
+	// Stop the run (best-effort).
+	if err := mgr.Stop(); err != nil {
+		s.handleRecoveryError("expire-ephemeral", name, err)
+	}
+}
+
+// removeEphemeral forgets an ephemeral service and stops its monitoring.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) removeEphemeral(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropEphemeral(name)
+}
+
+// dropEphemeral forgets an ephemeral service and stops its monitoring.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) dropEphemeral(name string) {
+	e, ok := s.ephemerals[name]
+	// Skip services already removed.
+	if !ok {
+		// Nothing to remove.
+		return
+	}
+	e.expiry.Stop()
+	e.cancel()
+	delete(s.ephemerals, name)
+	delete(s.managers, name)
+	delete(s.stats, name)
+	s.publishStatus()
+}
+
+// dropEphemerals forgets every ephemeral service, which the supervisor
+// stopped. Must be called with s.mu held.
+func (s *Supervisor) dropEphemerals() {
+	// forget each ephemeral service
+	for name := range s.ephemerals {
+		s.dropEphemeral(name)
+	}
+}
+
+// isEphemeral reports whether a service was spawned by SpawnEphemeral.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - bool: true for ephemeral services.
+func (s *Supervisor) isEphemeral(name string) bool {
+	_, ok := s.ephemerals[name]
+	// return membership
+	return ok
+}
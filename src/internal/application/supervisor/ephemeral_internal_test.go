@@ -0,0 +1,256 @@
+// Package supervisor provides internal tests for ephemeral.go.
+// It tests ephemeral services using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// ephemeralTestExecutor records started specs and lets tests end the processes.
+type ephemeralTestExecutor struct {
+	// mu protects specs, exits and stops.
+	mu sync.Mutex
+	// specs records the started specs.
+	specs []domain.Spec
+	// exits holds the exit channel of each started process.
+	exits []chan domain.ExitResult
+	// stops counts the stopped processes.
+	stops int
+}
+
+// Start records the spec and returns a process exiting on demand.
+//
+// Params:
+//   - spec: the process spec.
+//
+// Returns:
+//   - int: a fake pid.
+//   - <-chan domain.ExitResult: the exit channel.
+//   - error: always nil.
+func (e *ephemeralTestExecutor) Start(_ context.Context, spec domain.Spec) (int, <-chan domain.ExitResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	exit := make(chan domain.ExitResult, 1)
+	e.specs = append(e.specs, spec)
+	e.exits = append(e.exits, exit)
+	return 4242, exit, nil
+}
+
+// Stop counts the stopped process.
+//
+// Returns:
+//   - error: always nil.
+func (e *ephemeralTestExecutor) Stop(_ int, _ time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stops++
+	return nil
+}
+
+// Signal is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *ephemeralTestExecutor) Signal(_ int, _ os.Signal) error { return nil }
+
+// started returns the started specs.
+//
+// Returns:
+//   - []domain.Spec: the recorded specs.
+func (e *ephemeralTestExecutor) started() []domain.Spec {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]domain.Spec(nil), e.specs...)
+}
+
+// exit ends the first started process.
+//
+// Params:
+//   - code: the exit code.
+func (e *ephemeralTestExecutor) exit(code int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.exits[0] <- domain.ExitResult{Code: code}
+}
+
+// stopped returns the number of stopped processes.
+//
+// Returns:
+//   - int: the stop count.
+func (e *ephemeralTestExecutor) stopped() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.stops
+}
+
+// newEphemeralTestSupervisor builds a running supervisor with one sandboxed service.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+//   - *ephemeralTestExecutor: the executor.
+//   - *shared.FakeClock: the clock of the TTLs.
+func newEphemeralTestSupervisor(t *testing.T) (*Supervisor, *ephemeralTestExecutor, *shared.FakeClock) {
+	t.Helper()
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{{
+		Name:             "api",
+		Command:          "/bin/api",
+		User:             "www",
+		WorkingDirectory: "/srv/api",
+		Environment:      map[string]string{"MODE": "prod", "REGION": "eu"},
+		ReadOnlyPaths:    []string{"/etc"},
+	}}}
+	executor := &ephemeralTestExecutor{}
+	ctx, cancel := context.WithCancel(context.Background())
+	clock := shared.NewFakeClock(time.Now())
+	s := &Supervisor{
+		config:   cfg,
+		clock:    clock,
+		executor: executor,
+		managers: make(map[string]*applifecycle.Manager),
+		stats:    make(map[string]*ServiceStats),
+		state:    StateRunning,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	t.Cleanup(func() {
+		cancel()
+		s.wg.Wait()
+	})
+	return s, executor, clock
+}
+
+// Test_Supervisor_SpawnEphemeral_Sandbox tests that a run reuses the sandbox
+// of a service and is removed once it exits.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_SpawnEphemeral_Sandbox(t *testing.T) {
+	s, executor, clock := newEphemeralTestSupervisor(t)
+	var mu sync.Mutex
+	var events []domain.EventType
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event.Type)
+	}
+
+	spawned, err := s.SpawnEphemeral(&domain.EphemeralSpec{
+		Command:     "/usr/bin/ss",
+		Args:        []string{"-tlnp"},
+		Env:         map[string]string{"MODE": "debug"},
+		TTL:         time.Minute,
+		SandboxFrom: "api",
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(spawned.Name, ephemeralPrefix))
+	assert.Equal(t, clock.Now().Add(time.Minute), spawned.ExpiresAt)
+
+	// The run is listed and runs in the sandbox of api.
+	require.Eventually(t, func() bool { return len(executor.started()) == 1 }, time.Second, 5*time.Millisecond)
+	spec := executor.started()[0]
+	assert.Equal(t, "/usr/bin/ss", spec.Command)
+	assert.Equal(t, []string{"-tlnp"}, spec.Args)
+	assert.Equal(t, "www", spec.User)
+	assert.Equal(t, "/srv/api", spec.Dir)
+	assert.Equal(t, []string{"/etc"}, spec.ReadOnlyPaths)
+	assert.Equal(t, map[string]string{"MODE": "debug", "REGION": "eu"}, spec.Env)
+	assert.Equal(t, map[string]string{"MODE": "prod", "REGION": "eu"}, s.config.Services[0].Environment)
+	s.mu.RLock()
+	assert.Contains(t, s.managers, spawned.Name)
+	s.mu.RUnlock()
+
+	// A reload leaves the run alone.
+	s.mu.Lock()
+	s.removeDeletedServices(s.config)
+	assert.Contains(t, s.managers, spawned.Name)
+	s.mu.Unlock()
+
+	// The exit removes the service.
+	executor.exit(0)
+	require.Eventually(t, func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return len(s.managers) == 0 && len(s.ephemerals) == 0 && len(s.stats) == 0
+	}, time.Second, 5*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []domain.EventType{domain.EventStarted, domain.EventStopped}, events)
+	mu.Unlock()
+}
+
+// Test_Supervisor_SpawnEphemeral_TTL tests that a run is stopped and removed
+// once its TTL elapses.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_SpawnEphemeral_TTL(t *testing.T) {
+	s, executor, clock := newEphemeralTestSupervisor(t)
+
+	_, err := s.SpawnEphemeral(&domain.EphemeralSpec{Command: "/bin/sleep", Args: []string{"3600"}, TTL: 30 * time.Second})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(executor.started()) == 1 }, time.Second, 5*time.Millisecond)
+	clock.Advance(30 * time.Second)
+
+	require.Eventually(t, func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return executor.stopped() > 0 && len(s.managers) == 0 && len(s.ephemerals) == 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+// Test_Supervisor_SpawnEphemeral_Errors tests refused spawns.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_SpawnEphemeral_Errors(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// spec is the spawned spec.
+		spec domain.EphemeralSpec
+		// stopped runs the spawn on a stopped supervisor.
+		stopped bool
+		// wantErr is the expected error.
+		wantErr error
+		// wantCode is the expected error code.
+		wantCode shared.Code
+	}{
+		{name: "no command", wantErr: domain.ErrInvalidEphemeral, wantCode: shared.CodeInvalidArgument},
+		{name: "negative ttl", spec: domain.EphemeralSpec{Command: "/bin/true", TTL: -time.Second}, wantErr: domain.ErrInvalidEphemeral, wantCode: shared.CodeInvalidArgument},
+		{name: "ttl too long", spec: domain.EphemeralSpec{Command: "/bin/true", TTL: 2 * domain.MaxEphemeralTTL}, wantErr: domain.ErrInvalidEphemeral, wantCode: shared.CodeInvalidArgument},
+		{name: "unknown sandbox", spec: domain.EphemeralSpec{Command: "/bin/true", SandboxFrom: "db"}, wantErr: ErrServiceNotFound, wantCode: shared.CodeServiceNotFound},
+		{name: "not running", spec: domain.EphemeralSpec{Command: "/bin/true"}, stopped: true, wantErr: ErrNotRunning, wantCode: shared.CodeSupervisorNotRunning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, executor, _ := newEphemeralTestSupervisor(t)
+			// Stop the supervisor first.
+			if tt.stopped {
+				s.state = StateStopped
+			}
+
+			_, err := s.SpawnEphemeral(&tt.spec)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCode, shared.CodeOf(err))
+			assert.Empty(t, executor.started())
+			assert.Empty(t, s.managers)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file runs the event hooks: commands handed each event as JSON.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// maxQueuedEventHooks bounds the event hooks waiting for a slot; further
+// ones are dropped so an event flood cannot pile up goroutines.
+const maxQueuedEventHooks int64 = 256
+
+// ErrEventHookDropped is reported when an event hook is not run because
+// too many hooks wait for a slot.
+var ErrEventHookDropped error = errors.New("event hook dropped, too many hooks waiting")
+
+// configureEventHooks sizes the slots of the event hooks. Hooks running
+// or waiting keep the slots they were started with. Must be called with
+// s.mu held.
+//
+// Params:
+//   - cfg: the event hooks settings.
+func (s *Supervisor) configureEventHooks(cfg *domainconfig.EventHooksConfig) {
+	limit := cfg.EffectiveMaxConcurrent()
+	// keep the slots of an unchanged limit
+	if s.eventHookSlots != nil && cap(s.eventHookSlots) == limit {
+		// limit unchanged
+		return
+	}
+	s.eventHookSlots = make(chan struct{}, limit)
+}
+
+// runEventHooks starts the hooks of an event in the background. They run
+// with the supervisor context, at most hooks.max_concurrent at once.
+//
+// Params:
+//   - name: the service name, empty for daemon-wide events.
+//   - event: the event.
+func (s *Supervisor) runEventHooks(name string, event *domain.Event) {
+	s.mu.RLock()
+	var hooks []domainconfig.EventHook
+	// Hooks come from the current configuration.
+	if s.config != nil {
+		hooks = s.config.Hooks.Matching(event.Name())
+	}
+	runner := s.hookRunner
+	slots := s.eventHookSlots
+	ctx := s.ctx
+	s.mu.RUnlock()
+
+	// No hook runs on this event.
+	if len(hooks) == 0 || slots == nil {
+		// Nothing to run.
+		return
+	}
+	// Event hooks need a runner.
+	if runner == nil {
+		s.handleRecoveryError("event-hook", name, ErrNoHookRunner)
+		// Nothing to run with.
+		return
+	}
+	// Use context from supervisor or fallback to Background
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	input := hookEvent(name, event)
+	// Start each matching hook.
+	for i := range hooks {
+		// Drop the hook once the queue is full.
+		if s.eventHooksQueued.Add(1) > maxQueuedEventHooks {
+			s.eventHooksQueued.Add(-1)
+			s.handleRecoveryError("event-hook", name, fmt.Errorf("%w: %s on %s", ErrEventHookDropped, hooks[i].Command, input.Type))
+			continue
+		}
+		go s.runEventHook(ctx, runner, slots, &hooks[i], input)
+	}
+}
+
+// runEventHook waits for a slot, then runs one event hook.
+//
+// Params:
+//   - ctx: the supervisor context, abandoning the hook when cancelled.
+//   - runner: the hook runner.
+//   - slots: the slots bounding the running hooks.
+//   - hook: the hook.
+//   - input: the event handed to the hook.
+func (s *Supervisor) runEventHook(ctx context.Context, runner apphook.Runner, slots chan struct{}, hook *domainconfig.EventHook, input *apphook.Event) {
+	// Wait for a slot.
+	select {
+	// slot taken
+	case slots <- struct{}{}:
+		s.eventHooksQueued.Add(-1)
+	// abandoned while waiting
+	case <-ctx.Done():
+		s.eventHooksQueued.Add(-1)
+		// The supervisor is stopping.
+		return
+	}
+	defer func() { <-slots }()
+
+	err := runner.Run(ctx, apphook.Command{
+		Path: hook.Command,
+		Args: hook.Args,
+		Env: []string{
+			"SUPERVIZIO_EVENT=" + input.Type,
+			"SUPERVIZIO_SERVICE=" + input.Service,
+		},
+		Timeout: hook.EffectiveTimeout(),
+		Event:   input,
+	})
+	// Report failed hooks.
+	if err != nil {
+		s.handleRecoveryError("event-hook", input.Service, err)
+	}
+}
+
+// hookEvent converts an event for the event hooks.
+//
+// Params:
+//   - name: the service name, empty for daemon-wide events.
+//   - event: the event.
+//
+// Returns:
+//   - *apphook.Event: the event handed to the hooks.
+func hookEvent(name string, event *domain.Event) *apphook.Event {
+	input := &apphook.Event{
+		Time:     event.Timestamp,
+		Service:  name,
+		Type:     event.Name(),
+		PID:      event.PID,
+		ExitCode: event.ExitCode,
+		Signal:   event.Signal,
+		Labels:   event.Labels,
+	}
+	// Stamp events created without a time.
+	if input.Time.IsZero() {
+		input.Time = time.Now()
+	}
+	// Keep the error text only.
+	if event.Error != nil {
+		input.Error = event.Error.Error()
+	}
+	// return converted event
+	return input
+}
@@ -0,0 +1,168 @@
+// Package supervisor provides internal tests for event_hooks.go.
+// It tests the commands run on events using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// gatedHookRunner blocks each hook until released.
+type gatedHookRunner struct {
+	// mu guards the fields below.
+	mu sync.Mutex
+	// running counts the hooks running.
+	running int
+	// peak is the most hooks seen running at once.
+	peak int
+	// commands are the hooks run.
+	commands []apphook.Command
+	// release lets one hook finish per value.
+	release chan struct{}
+}
+
+// Run records the command, then waits to be released.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - cmd: the command.
+//
+// Returns:
+//   - error: always nil.
+func (r *gatedHookRunner) Run(_ context.Context, cmd apphook.Command) error {
+	r.mu.Lock()
+	r.running++
+	r.peak = max(r.peak, r.running)
+	r.commands = append(r.commands, cmd)
+	r.mu.Unlock()
+	<-r.release
+	r.mu.Lock()
+	r.running--
+	r.mu.Unlock()
+	return nil
+}
+
+// Output records the command like Run.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - cmd: the command.
+//
+// Returns:
+//   - string: always empty.
+//   - error: always nil.
+func (r *gatedHookRunner) Output(ctx context.Context, cmd apphook.Command) (string, error) {
+	// record like Run
+	return "", r.Run(ctx, cmd)
+}
+
+// started returns the hooks run so far.
+//
+// Returns:
+//   - []apphook.Command: the commands.
+func (r *gatedHookRunner) started() []apphook.Command {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.commands)
+}
+
+// Test_Supervisor_runEventHooks tests that matching hooks receive the event,
+// at most max_concurrent at once.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_runEventHooks(t *testing.T) {
+	runner := &gatedHookRunner{release: make(chan struct{})}
+	s := &Supervisor{
+		hookRunner: runner,
+		config: &domainconfig.Config{Hooks: domainconfig.EventHooksConfig{MaxConcurrent: 1, Commands: []domainconfig.EventHook{
+			{Command: "/bin/page", Args: []string{"--team", "ops"}, Events: []string{"exhausted"}},
+			{Command: "/bin/audit", Events: []string{"exhausted", "config_reloaded"}},
+		}}},
+	}
+	s.configureEventHooks(&s.config.Hooks)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Unmatched events run nothing.
+	s.runEventHooks("api", &domain.Event{Type: domain.EventStarted, Timestamp: at})
+	s.runEventHooks("api", &domain.Event{Type: domain.EventExhausted, ExitCode: 2, Error: errors.New("max restarts reached"), Timestamp: at})
+
+	// The second hook waits for the slot of the first.
+	require.Eventually(t, func() bool { return len(runner.started()) == 1 }, time.Second, time.Millisecond)
+	runner.release <- struct{}{}
+	require.Eventually(t, func() bool { return len(runner.started()) == 2 }, time.Second, time.Millisecond)
+	runner.release <- struct{}{}
+
+	commands := runner.started()
+	// Either hook may take the slot first; order them page first.
+	slices.SortFunc(commands, func(a, b apphook.Command) int { return len(b.Args) - len(a.Args) })
+	assert.Equal(t, "/bin/page", commands[0].Path)
+	assert.Equal(t, []string{"--team", "ops"}, commands[0].Args)
+	assert.Equal(t, domainconfig.DefaultEventHookTimeout, commands[0].Timeout)
+	assert.Equal(t, []string{"SUPERVIZIO_EVENT=exhausted", "SUPERVIZIO_SERVICE=api"}, commands[0].Env)
+	assert.Equal(t, &apphook.Event{Time: at, Service: "api", Type: "exhausted", ExitCode: 2, Error: "max restarts reached"}, commands[0].Event)
+	assert.Equal(t, "/bin/audit", commands[1].Path)
+
+	// Daemon-wide events run the hooks listening to them.
+	s.runEventHooks("", &domain.Event{Type: domain.EventConfigReloaded, Timestamp: at})
+	require.Eventually(t, func() bool { return len(runner.started()) == 3 }, time.Second, time.Millisecond)
+	runner.release <- struct{}{}
+	reloaded := runner.started()[2]
+	assert.Equal(t, "/bin/audit", reloaded.Path)
+	assert.Equal(t, &apphook.Event{Time: at, Type: "config_reloaded"}, reloaded.Event)
+
+	runner.mu.Lock()
+	assert.Equal(t, 1, runner.peak)
+	runner.mu.Unlock()
+}
+
+// Test_Supervisor_runEventHooks_dropped tests that hooks are dropped, and
+// reported, once too many wait for a slot.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_runEventHooks_dropped(t *testing.T) {
+	var reported []error
+	s := &Supervisor{
+		hookRunner:   &fakeHookRunner{},
+		config:       &domainconfig.Config{Hooks: domainconfig.EventHooksConfig{Commands: []domainconfig.EventHook{{Command: "/bin/page"}}}},
+		errorHandler: func(_, _ string, err error) { reported = append(reported, err) },
+	}
+	s.configureEventHooks(&s.config.Hooks)
+	s.eventHooksQueued.Store(maxQueuedEventHooks)
+
+	s.runEventHooks("api", &domain.Event{Type: domain.EventFailed})
+
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], ErrEventHookDropped)
+	assert.Equal(t, maxQueuedEventHooks, s.eventHooksQueued.Load())
+}
+
+// Test_Supervisor_configureEventHooks tests that reloads keep the slots of an unchanged limit.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_configureEventHooks(t *testing.T) {
+	s := &Supervisor{}
+
+	s.configureEventHooks(&domainconfig.EventHooksConfig{})
+	slots := s.eventHookSlots
+	assert.Equal(t, domainconfig.DefaultEventHookConcurrency, cap(slots))
+
+	s.configureEventHooks(&domainconfig.EventHooksConfig{MaxConcurrent: domainconfig.DefaultEventHookConcurrency})
+	assert.Equal(t, slots, s.eventHookSlots)
+
+	s.configureEventHooks(&domainconfig.EventHooksConfig{MaxConcurrent: 8})
+	assert.Equal(t, 8, cap(s.eventHookSlots))
+}
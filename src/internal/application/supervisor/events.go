@@ -0,0 +1,145 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file persists lifecycle events and replays them to clients.
+package supervisor
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// ErrEventStoreUnavailable is returned by replay methods when no event store is configured.
+var ErrEventStoreUnavailable error = shared.NewCodedError(shared.CodeEventStoreUnavailable, "event store not configured")
+
+// SetEventStore sets the store persisting lifecycle events for replay.
+// Without a store, events are only delivered to the event handler.
+//
+// Params:
+//   - store: the event store to use.
+func (s *Supervisor) SetEventStore(store storage.EventStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store event store
+	s.eventStore = store
+}
+
+// ListEvents returns persisted events with a sequence greater than since.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - since: the last sequence already seen, zero for all retained events.
+//   - filter: service, type, and page size restrictions.
+//
+// Returns:
+//   - []storage.EventRecord: matching events, oldest first.
+//   - error: ErrEventStoreUnavailable or a store read error.
+func (s *Supervisor) ListEvents(ctx context.Context, since uint64, filter storage.EventFilter) ([]storage.EventRecord, error) {
+	store := s.getEventStore()
+	// replay requires persistence
+	if store == nil {
+		// no store configured
+		return nil, ErrEventStoreUnavailable
+	}
+	// read from store
+	return store.ListEvents(ctx, since, filter)
+}
+
+// ReadEvents returns the events a subscriber has not acknowledged yet.
+// The subscriber cursor is not moved; call AckEvents once the events are
+// processed, so a client that disconnects mid-batch receives them again.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - subscriber: the subscriber name.
+//   - filter: service, type, and page size restrictions.
+//
+// Returns:
+//   - []storage.EventRecord: events after the subscriber cursor, oldest first.
+//   - error: ErrEventStoreUnavailable or a store error.
+func (s *Supervisor) ReadEvents(ctx context.Context, subscriber string, filter storage.EventFilter) ([]storage.EventRecord, error) {
+	store := s.getEventStore()
+	// replay requires persistence
+	if store == nil {
+		// no store configured
+		return nil, ErrEventStoreUnavailable
+	}
+	since, err := store.LoadCursor(ctx, subscriber)
+	// cursor lookup failed
+	if err != nil {
+		// propagate store error
+		return nil, err
+	}
+	// read after cursor
+	return store.ListEvents(ctx, since, filter)
+}
+
+// AckEvents moves a subscriber cursor to the last processed sequence.
+// Acknowledging an older sequence than the current cursor has no effect.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - subscriber: the subscriber name.
+//   - seq: the last processed sequence.
+//
+// Returns:
+//   - error: ErrEventStoreUnavailable or a store write error.
+func (s *Supervisor) AckEvents(ctx context.Context, subscriber string, seq uint64) error {
+	store := s.getEventStore()
+	// cursors require persistence
+	if store == nil {
+		// no store configured
+		return ErrEventStoreUnavailable
+	}
+	// persist cursor
+	return store.SaveCursor(ctx, subscriber, seq)
+}
+
+// recordEvent persists a lifecycle event when an event store is configured.
+// Write failures are reported through the error handler.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) recordEvent(name string, event *domain.Event) {
+	store := s.getEventStore()
+	// persistence disabled
+	if store == nil {
+		// nothing to record
+		return
+	}
+
+	rec := storage.EventRecord{
+		Timestamp: event.Timestamp,
+		Service:   name,
+		Type:      event.Name(),
+		PID:       event.PID,
+		ExitCode:  event.ExitCode,
+		Labels:    event.Labels,
+	}
+	// stamp events created without a time
+	if rec.Timestamp.IsZero() {
+		rec.Timestamp = time.Now()
+	}
+	// keep the error text only
+	if event.Error != nil {
+		rec.Error = event.Error.Error()
+	}
+	// Report write failure (best-effort persistence).
+	if _, err := store.AppendEvent(context.Background(), &rec); err != nil {
+		s.handleRecoveryError("event-store", name, err)
+	}
+}
+
+// getEventStore returns the configured event store.
+//
+// Returns:
+//   - storage.EventStore: the store, or nil when persistence is disabled.
+func (s *Supervisor) getEventStore() storage.EventStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// return event store
+	return s.eventStore
+}
@@ -0,0 +1,182 @@
+// Package supervisor provides internal tests for events.go.
+// It tests event persistence and replay using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// memoryEventStore is an in-memory storage.EventStore.
+type memoryEventStore struct {
+	// mu protects the fields.
+	mu sync.Mutex
+	// events are the appended events.
+	events []storage.EventRecord
+	// cursors are the subscriber cursors.
+	cursors map[string]uint64
+	// appendErr is returned by AppendEvent when set.
+	appendErr error
+}
+
+// AppendEvent stores the event with the next sequence.
+//
+// Params:
+//   - rec: the event to store.
+//
+// Returns:
+//   - uint64: the assigned sequence.
+//   - error: appendErr when set.
+func (m *memoryEventStore) AppendEvent(_ context.Context, rec *storage.EventRecord) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.appendErr != nil {
+		return 0, m.appendErr
+	}
+	rec.Seq = uint64(len(m.events) + 1)
+	m.events = append(m.events, *rec)
+	return rec.Seq, nil
+}
+
+// ListEvents returns matching events after since.
+//
+// Params:
+//   - since: the last sequence seen.
+//   - filter: the event filter.
+//
+// Returns:
+//   - []storage.EventRecord: the matching events.
+//   - error: always nil.
+func (m *memoryEventStore) ListEvents(_ context.Context, since uint64, filter storage.EventFilter) ([]storage.EventRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []storage.EventRecord
+	for i := range m.events {
+		if m.events[i].Seq > since && filter.Matches(&m.events[i]) {
+			result = append(result, m.events[i])
+		}
+	}
+	return result, nil
+}
+
+// SaveCursor stores the cursor if it moves forward.
+//
+// Params:
+//   - subscriber: the subscriber name.
+//   - seq: the cursor.
+//
+// Returns:
+//   - error: always nil.
+func (m *memoryEventStore) SaveCursor(_ context.Context, subscriber string, seq uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cursors == nil {
+		m.cursors = make(map[string]uint64)
+	}
+	m.cursors[subscriber] = max(m.cursors[subscriber], seq)
+	return nil
+}
+
+// LoadCursor returns the stored cursor.
+//
+// Params:
+//   - subscriber: the subscriber name.
+//
+// Returns:
+//   - uint64: the cursor.
+//   - error: always nil.
+func (m *memoryEventStore) LoadCursor(_ context.Context, subscriber string) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursors[subscriber], nil
+}
+
+// Test_Supervisor_recordEvent tests that delivered events are persisted.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_recordEvent(t *testing.T) {
+	store := &memoryEventStore{}
+	s := &Supervisor{eventStore: store}
+
+	s.callEventHandler("api", &domain.Event{Type: domain.EventStarted, PID: 42}, nil)
+	s.callEventHandler("api", &domain.Event{Type: domain.EventFailed, ExitCode: 1, Error: errors.New("boom")}, nil)
+
+	require.Len(t, store.events, 2)
+	assert.Equal(t, "started", store.events[0].Type)
+	assert.Equal(t, 42, store.events[0].PID)
+	assert.False(t, store.events[0].Timestamp.IsZero())
+	assert.Equal(t, "failed", store.events[1].Type)
+	assert.Equal(t, "boom", store.events[1].Error)
+}
+
+// Test_Supervisor_recordEvent_Error tests that write failures are reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_recordEvent_Error(t *testing.T) {
+	failure := errors.New("disk full")
+	var reported error
+	s := &Supervisor{
+		eventStore:   &memoryEventStore{appendErr: failure},
+		errorHandler: func(_, _ string, err error) { reported = err },
+	}
+
+	s.recordEvent("api", &domain.Event{Type: domain.EventStopped})
+
+	assert.ErrorIs(t, reported, failure)
+}
+
+// Test_Supervisor_ReadEvents tests replay from a subscriber cursor.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ReadEvents(t *testing.T) {
+	ctx := context.Background()
+	s := &Supervisor{eventStore: &memoryEventStore{}}
+	for _, typ := range []domain.EventType{domain.EventStarted, domain.EventFailed, domain.EventRestarting} {
+		s.recordEvent("api", &domain.Event{Type: typ})
+	}
+
+	events, err := s.ReadEvents(ctx, "cli", storage.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	// Unacknowledged events are delivered again.
+	require.NoError(t, s.AckEvents(ctx, "cli", events[1].Seq))
+	events, err = s.ReadEvents(ctx, "cli", storage.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "restarting", events[0].Type)
+
+	// ListEvents ignores subscriber cursors.
+	events, err = s.ListEvents(ctx, 0, storage.EventFilter{Types: []string{"failed"}})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+// Test_Supervisor_Events_NoStore tests replay without a configured store.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_Events_NoStore(t *testing.T) {
+	ctx := context.Background()
+	s := &Supervisor{}
+
+	_, err := s.ListEvents(ctx, 0, storage.EventFilter{})
+	require.ErrorIs(t, err, ErrEventStoreUnavailable)
+	_, err = s.ReadEvents(ctx, "cli", storage.EventFilter{})
+	require.ErrorIs(t, err, ErrEventStoreUnavailable)
+	require.ErrorIs(t, s.AckEvents(ctx, "cli", 1), ErrEventStoreUnavailable)
+
+	// Events are still delivered without persistence.
+	s.recordEvent("api", &domain.Event{Type: domain.EventStarted})
+}
@@ -0,0 +1,82 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file classifies the failures of services by exit code or signal.
+package supervisor
+
+import (
+	"errors"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// signalNames maps the signals of the platform that commonly end a
+// process to their POSIX names.
+var signalNames map[syscall.Signal]string = map[syscall.Signal]string{
+	syscall.SIGHUP:  "SIGHUP",
+	syscall.SIGINT:  "SIGINT",
+	syscall.SIGQUIT: "SIGQUIT",
+	syscall.SIGILL:  "SIGILL",
+	syscall.SIGTRAP: "SIGTRAP",
+	syscall.SIGABRT: "SIGABRT",
+	syscall.SIGBUS:  "SIGBUS",
+	syscall.SIGFPE:  "SIGFPE",
+	syscall.SIGKILL: "SIGKILL",
+	syscall.SIGUSR1: "SIGUSR1",
+	syscall.SIGSEGV: "SIGSEGV",
+	syscall.SIGUSR2: "SIGUSR2",
+	syscall.SIGPIPE: "SIGPIPE",
+	syscall.SIGALRM: "SIGALRM",
+	syscall.SIGTERM: "SIGTERM",
+	syscall.SIGXCPU: "SIGXCPU",
+	syscall.SIGXFSZ: "SIGXFSZ",
+	syscall.SIGSYS:  "SIGSYS",
+}
+
+// exitCause returns how the process of a failed event ended. Failures to
+// start the process have no exit cause.
+//
+// Params:
+//   - event: the failed event.
+//
+// Returns:
+//   - metrics.ExitCause: the exit code or signal.
+//   - bool: false when the process did not exit.
+func exitCause(event *domain.Event) (metrics.ExitCause, bool) {
+	// the process never ran
+	if !errors.Is(event.Error, domain.ErrProcessFailed) {
+		// nothing to classify
+		return metrics.ExitCause{}, false
+	}
+	// the process exited with a code
+	if event.Signal == 0 {
+		// return the exit code
+		return metrics.ExitCause{Code: event.ExitCode}, true
+	}
+	name, ok := signalNames[syscall.Signal(event.Signal)]
+	// name signals outside the table by number
+	if !ok {
+		name = "SIG" + strconv.Itoa(event.Signal)
+	}
+	// return the signal
+	return metrics.ExitCause{Signal: name}, true
+}
+
+// ServiceExits returns the failures of each service over the last 30
+// days, by exit code or signal.
+//
+// Returns:
+//   - map[string][]metrics.ExitCount: the causes of each service, most frequent first.
+func (s *Supervisor) ServiceExits() map[string][]metrics.ExitCount {
+	now := time.Now()
+	status := s.loadStatus()
+	exits := make(map[string][]metrics.ExitCount, len(status.stats))
+	// count the causes of each service
+	for name, stats := range status.stats {
+		exits[name] = stats.Exits(now)
+	}
+	// return the causes
+	return exits
+}
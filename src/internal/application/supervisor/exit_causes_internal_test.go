@@ -0,0 +1,77 @@
+// Package supervisor provides internal tests for exit_causes.go.
+// It tests the classification of failures using white-box testing.
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_exitCause tests that failed events are classified by exit code or signal.
+//
+// Params:
+//   - t: the testing context.
+func Test_exitCause(t *testing.T) {
+	exited := fmt.Errorf("exit code 2: %w", domain.ErrProcessFailed)
+	tests := []struct {
+		name   string
+		event  domain.Event
+		want   metrics.ExitCause
+		wantOK bool
+	}{
+		{
+			name:   "exit code",
+			event:  domain.Event{ExitCode: 2, Error: exited},
+			want:   metrics.ExitCause{Code: 2},
+			wantOK: true,
+		},
+		{
+			name:   "crash",
+			event:  domain.Event{ExitCode: -1, Signal: int(syscall.SIGSEGV), Error: exited},
+			want:   metrics.ExitCause{Signal: "SIGSEGV"},
+			wantOK: true,
+		},
+		{
+			name:   "unnamed signal",
+			event:  domain.Event{ExitCode: -1, Signal: 40, Error: exited},
+			want:   metrics.ExitCause{Signal: "SIG40"},
+			wantOK: true,
+		},
+		{
+			name:  "start failure",
+			event: domain.Event{Error: errors.New("executable file not found")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cause, ok := exitCause(&tt.event)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, cause)
+		})
+	}
+}
+
+// Test_Supervisor_ServiceExits tests that the failures of every service are counted by cause.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ServiceExits(t *testing.T) {
+	s := &Supervisor{stats: map[string]*ServiceStats{"api": NewServiceStats(), "web": NewServiceStats()}}
+	s.publishStatus()
+	failed := domain.NewEvent(domain.EventFailed, "api", 0, 2, domain.ErrProcessFailed)
+	s.updateStatsForEvent(s.stats["api"], &failed)
+	s.updateStatsForEvent(s.stats["api"], &failed)
+
+	exits := s.ServiceExits()
+	assert.Len(t, exits, 2)
+	assert.Equal(t, []metrics.ExitCount{{Cause: metrics.ExitCause{Code: 2}, Count: 2, Last: failed.Timestamp}}, exits["api"])
+	assert.Empty(t, exits["web"])
+}
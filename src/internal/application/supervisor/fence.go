@@ -0,0 +1,247 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file makes the effects of control operations visible to later reads.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// operationFenceTimeout bounds the wait for the events of an operation.
+// A process that is not launched in time is reported as it stands.
+const operationFenceTimeout time.Duration = 10 * time.Second
+
+// eventFence tracks the manager events applied by the supervisor, so a
+// control operation can wait until its own events are visible.
+type eventFence struct {
+	// mu protects the fields below.
+	mu sync.Mutex
+	// applied counts the events applied.
+	applied uint64
+	// handled is, per service, the sequence of the last manager event applied.
+	handled map[string]uint64
+	// launched is, per service, the sequence of the last launch outcome
+	// applied: started, failed or exhausted.
+	launched map[string]uint64
+	// changed is closed and replaced whenever an event is applied.
+	changed chan struct{}
+}
+
+// apply records an applied event and wakes the waiters.
+//
+// Params:
+//   - name: the service name.
+//   - event: the applied event.
+func (f *eventFence) apply(name string, event *domain.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.applied++
+	// Only manager events carry a sequence.
+	if event.Seq > 0 {
+		// create maps on first use
+		if f.handled == nil {
+			f.handled = make(map[string]uint64)
+			f.launched = make(map[string]uint64)
+		}
+		f.handled[name] = max(f.handled[name], event.Seq)
+		// record launch outcomes
+		switch event.Type {
+		// the process ran or will not run
+		case domain.EventStarted, domain.EventFailed, domain.EventExhausted:
+			f.launched[name] = max(f.launched[name], event.Seq)
+		// Other events do not end a launch.
+		default:
+		}
+	}
+	// wake waiters
+	if f.changed != nil {
+		close(f.changed)
+		f.changed = nil
+	}
+}
+
+// wait blocks until reached holds or the context is done.
+//
+// Params:
+//   - ctx: bounds the wait.
+//   - reached: the condition, called with f.mu held.
+//
+// Returns:
+//   - error: the context error when the condition was not reached.
+func (f *eventFence) wait(ctx context.Context, reached func(f *eventFence) bool) error {
+	// re-check after each applied event
+	for {
+		f.mu.Lock()
+		// condition met
+		if reached(f) {
+			f.mu.Unlock()
+			// done waiting
+			return nil
+		}
+		// create channel on first wait
+		if f.changed == nil {
+			f.changed = make(chan struct{})
+		}
+		changed := f.changed
+		f.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			// Return when context is cancelled.
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// sequence returns the number of events applied.
+//
+// Returns:
+//   - uint64: the applied event count.
+func (f *eventFence) sequence() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// return applied count
+	return f.applied
+}
+
+// EventSequence returns the number of events applied by the supervisor.
+// A state read after the sequence of a control snapshot reflects at least
+// that operation.
+//
+// Returns:
+//   - uint64: the applied event count.
+func (s *Supervisor) EventSequence() uint64 {
+	// return applied count
+	return s.fence.sequence()
+}
+
+// ServiceSnapshot waits until the supervisor applied every event the
+// service reported so far, then returns its state. A supervisor that is
+// not running applies no event: the state is returned as it stands.
+//
+// Params:
+//   - ctx: bounds the wait.
+//   - name: the service name.
+//
+// Returns:
+//   - domain.ServiceSnapshot: the state of the service.
+//   - error: ErrServiceNotFound, or the context error when the events were
+//     not applied in time.
+func (s *Supervisor) ServiceSnapshot(ctx context.Context, name string) (domain.ServiceSnapshot, error) {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	running := s.state == StateRunning
+	s.mu.RUnlock()
+	// validate service exists
+	if !ok {
+		// Return error for missing service.
+		return domain.ServiceSnapshot{}, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	// Events are applied only while the supervisor runs.
+	if !running {
+		// return the state as it stands
+		return s.currentSnapshot(name), nil
+	}
+
+	target := mgr.EventSeq()
+	// wait for the reported events
+	if err := s.fence.wait(ctx, func(f *eventFence) bool { return f.handled[name] >= target }); err != nil {
+		// return wait error
+		return domain.ServiceSnapshot{}, err
+	}
+	// return the state as applied
+	return domain.ServiceSnapshot{Name: name, State: mgr.State(), PID: mgr.PID(), Sequence: s.fence.sequence()}, nil
+}
+
+// settleOperation waits until the events of a completed operation are
+// applied and returns the resulting snapshot, tagged with the operation ID.
+//
+// Params:
+//   - op: the completed operation.
+//   - before: the event sequence of the service when the operation began.
+//   - err: the operation result.
+//
+// Returns:
+//   - domain.ServiceSnapshot: the state of the service after the operation.
+func (s *Supervisor) settleOperation(op *Operation, before uint64, err error) domain.ServiceSnapshot {
+	launches := op.Kind == OperationStart || op.Kind == OperationRestart
+	snapshot := s.settle(op.Service, launches, before, err)
+	snapshot.OperationID = op.ID
+	// return settled snapshot
+	return snapshot
+}
+
+// settle waits until the events of a completed action are applied: the
+// launch outcome of a start or restart, then every event the service
+// reported. It returns the resulting snapshot.
+//
+// Params:
+//   - name: the service name.
+//   - launches: whether the action launches the process.
+//   - before: the event sequence of the service when the action began.
+//   - err: the action result.
+//
+// Returns:
+//   - domain.ServiceSnapshot: the state of the service after the action.
+func (s *Supervisor) settle(name string, launches bool, before uint64, err error) domain.ServiceSnapshot {
+	ctx, cancel := context.WithTimeout(context.Background(), operationFenceTimeout)
+	defer cancel()
+
+	// a launch is settled by its outcome, reported asynchronously
+	if launches && err == nil && s.State() == StateRunning {
+		// Report the state as it stands when the launch is late.
+		_ = s.fence.wait(ctx, func(f *eventFence) bool { return f.launched[name] > before })
+	}
+	snapshot, waitErr := s.ServiceSnapshot(ctx, name)
+	// report the state as it stands when the events are late
+	if waitErr != nil {
+		snapshot = s.currentSnapshot(name)
+	}
+	// return settled snapshot
+	return snapshot
+}
+
+// serviceEventSeq returns the event sequence of a service.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - uint64: the sequence of its last event, zero for unknown services.
+func (s *Supervisor) serviceEventSeq(name string) uint64 {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	s.mu.RUnlock()
+	// Unknown services report no event.
+	if !ok {
+		// no sequence
+		return 0
+	}
+	// return manager sequence
+	return mgr.EventSeq()
+}
+
+// currentSnapshot returns the state of a service without waiting.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - domain.ServiceSnapshot: the state, only the name for unknown services.
+func (s *Supervisor) currentSnapshot(name string) domain.ServiceSnapshot {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	s.mu.RUnlock()
+	snapshot := domain.ServiceSnapshot{Name: name, Sequence: s.fence.sequence()}
+	// Fill the state of known services.
+	if ok {
+		snapshot.State, snapshot.PID = mgr.State(), mgr.PID()
+	}
+	// return snapshot
+	return snapshot
+}
@@ -0,0 +1,68 @@
+// Package supervisor_test provides black-box tests for fence.go.
+// It tests that control operations are visible to the reads that follow them.
+package supervisor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/supervisor"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestSupervisor_OperationSnapshot tests that a completed operation reports
+// the state it produced, and that later reads reflect at least that state.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_OperationSnapshot(t *testing.T) {
+	cfg := createValidConfig()
+	sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, sup.Start(context.Background()))
+	defer func() { _ = sup.Stop() }()
+
+	op, err := sup.Submit(supervisor.OperationRestart, "test-service", "")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, op.Wait(ctx))
+
+	snapshot := op.Snapshot()
+	assert.Equal(t, "test-service", snapshot.Name)
+	assert.Equal(t, domain.StateRunning, snapshot.State)
+	assert.Equal(t, 1234, snapshot.PID)
+	assert.Equal(t, op.ID, snapshot.OperationID)
+	assert.Positive(t, snapshot.Sequence)
+
+	// Reads after the operation see its events without polling.
+	assert.GreaterOrEqual(t, sup.EventSequence(), snapshot.Sequence)
+	assert.Equal(t, domain.StateRunning, sup.Services()["test-service"].State)
+	current, err := sup.ServiceSnapshot(ctx, "test-service")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StateRunning, current.State)
+	assert.Empty(t, current.OperationID)
+}
+
+// TestSupervisor_ServiceSnapshot tests snapshots of a supervisor that is not
+// running and of unknown services.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_ServiceSnapshot(t *testing.T) {
+	cfg := createValidConfig()
+	sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+	require.NoError(t, err)
+
+	// Nothing to wait for before the supervisor starts.
+	snapshot, err := sup.ServiceSnapshot(context.Background(), "test-service")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ServiceSnapshot{Name: "test-service", State: domain.StateStopped}, snapshot)
+
+	_, err = sup.ServiceSnapshot(context.Background(), "missing")
+	assert.ErrorIs(t, err, supervisor.ErrServiceNotFound)
+}
@@ -0,0 +1,72 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+package supervisor
+
+import (
+	"fmt"
+
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Healthy reports whether the daemon and the given services are healthy:
+// the supervisor is running (or reloading), and each service is running with
+// its health checks, if any, passing.
+//
+// Params:
+//   - services: the service names to check; empty checks the daemon only.
+//
+// Returns:
+//   - bool: true if the daemon and every service are healthy.
+func (s *Supervisor) Healthy(services []string) bool {
+	status := s.loadStatus()
+
+	// the daemon must be serving
+	if status.state != StateRunning && status.state != StateReloading {
+		// daemon not serving
+		return false
+	}
+	// check each service
+	for _, name := range services {
+		entry, ok := status.services[name]
+		// unknown or not running service
+		if !ok || entry.status.Load().state != domain.StateRunning {
+			// service down
+			return false
+		}
+		// services with health checks must pass them
+		if monitor, ok := status.monitors[name]; ok && !monitor.IsHealthy() {
+			// service unhealthy
+			return false
+		}
+	}
+	// everything healthy
+	return true
+}
+
+// ServiceHealth returns the aggregated health of a service: its process state
+// and, when it has health checks, the state of each probed subject whatever
+// the probe type. Services without health checks report their process only.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - *domainhealth.AggregatedHealth: a copy of the service health.
+//   - error: ErrServiceNotFound if the service does not exist.
+func (s *Supervisor) ServiceHealth(name string) (*domainhealth.AggregatedHealth, error) {
+	status := s.loadStatus()
+	entry, ok := status.services[name]
+	// validate service exists
+	if !ok {
+		// Return error for missing service.
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	monitor, ok := status.monitors[name]
+	// report the process only without health checks
+	if !ok {
+		// return process health
+		return domainhealth.NewAggregatedHealth(entry.status.Load().state), nil
+	}
+	// return probed health, as judged by Healthy
+	return monitor.Health(), nil
+}
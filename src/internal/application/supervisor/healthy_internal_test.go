@@ -0,0 +1,75 @@
+// Package supervisor provides internal tests for healthy.go.
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_Supervisor_Healthy tests the daemon and service health report.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_Healthy(t *testing.T) {
+	cfg := newProxyTestConfig()
+	api := applifecycle.NewManager(&cfg.Services[0], &proxyTestExecutor{})
+	worker := applifecycle.NewManager(&cfg.Services[0], &proxyTestExecutor{})
+	s := &Supervisor{
+		config:         cfg,
+		state:          StateStarting,
+		managers:       map[string]*applifecycle.Manager{"api": api, "worker": worker},
+		healthMonitors: map[string]*apphealth.ProbeMonitor{"worker": apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})},
+	}
+
+	s.publishStatus()
+
+	// The daemon must be running.
+	assert.False(t, s.Healthy(nil))
+	s.state = StateRunning
+	s.publishStatus()
+	assert.True(t, s.Healthy(nil))
+
+	// Stopped and unknown services are not healthy.
+	assert.False(t, s.Healthy([]string{"api"}))
+	assert.False(t, s.Healthy([]string{"db"}))
+
+	require.NoError(t, api.Start(context.Background()))
+	defer func() { _ = api.Stop() }()
+	require.NoError(t, worker.Start(context.Background()))
+	defer func() { _ = worker.Stop() }()
+	require.Eventually(t, func() bool {
+		return api.State() == domain.StateRunning && worker.State() == domain.StateRunning
+	}, time.Second, 10*time.Millisecond)
+	// The started events would publish the running services.
+	s.publishStatus()
+
+	// Running services without health checks are healthy.
+	assert.True(t, s.Healthy([]string{"api"}))
+	// Health checks must pass.
+	assert.False(t, s.Healthy([]string{"api", "worker"}))
+
+	// Reloading keeps the daemon healthy.
+	s.state = StateReloading
+	s.publishStatus()
+	assert.True(t, s.Healthy([]string{"api"}))
+
+	// Service health follows the process and the health checks.
+	health, err := s.ServiceHealth("api")
+	require.NoError(t, err)
+	assert.Equal(t, domainhealth.StatusHealthy, health.Status())
+	assert.Empty(t, health.Subjects)
+	health, err = s.ServiceHealth("worker")
+	require.NoError(t, err)
+	assert.False(t, health.IsHealthy())
+	_, err = s.ServiceHealth("db")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
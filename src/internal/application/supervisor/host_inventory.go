@@ -0,0 +1,96 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file detects the platform capabilities of the host and disables the
+// features it cannot run.
+package supervisor
+
+import (
+	"context"
+	"errors"
+
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// ErrHostInventoryUnavailable indicates a host inventory requested without collector.
+var ErrHostInventoryUnavailable error = errors.New("host inventory not configured")
+
+// SetHostInventoryCollector sets the collector detecting the platform
+// capabilities of the host. Without it, the inventory is unavailable and
+// every feature is assumed supported.
+//
+// Params:
+//   - collector: the host inventory collector.
+func (s *Supervisor) SetHostInventoryCollector(collector appmetrics.HostInventoryCollector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store host inventory collector
+	s.inventoryCollector = collector
+}
+
+// HostInventory detects the platform capabilities of the host again and
+// returns them, with the support of each optional feature derived by
+// domainmetrics.HostInventory.Features.
+//
+// Params:
+//   - ctx: context for cancellation.
+//
+// Returns:
+//   - domainmetrics.HostInventory: the host inventory.
+//   - error: ErrHostInventoryUnavailable without collector, or the collection error.
+func (s *Supervisor) HostInventory(ctx context.Context) (domainmetrics.HostInventory, error) {
+	s.mu.RLock()
+	collector := s.inventoryCollector
+	s.mu.RUnlock()
+	// The inventory needs a collector.
+	if collector == nil {
+		// return unavailable inventory
+		return domainmetrics.HostInventory{}, ErrHostInventoryUnavailable
+	}
+	// return detected inventory
+	return collector.CollectHostInventory(ctx)
+}
+
+// refreshHostInventory detects the platform capabilities of the host before
+// the services and watchers are started. A failed detection leaves the
+// inventory unknown, and every feature enabled.
+//
+// Params:
+//   - ctx: context for cancellation.
+func (s *Supervisor) refreshHostInventory(ctx context.Context) {
+	inventory, err := s.HostInventory(ctx)
+	// Keep every feature enabled when the host is unknown.
+	if err != nil {
+		// Report a failed detection, not a missing collector.
+		if !errors.Is(err, ErrHostInventoryUnavailable) {
+			s.handleRecoveryError("collect-host-inventory", "", err)
+		}
+		s.mu.Lock()
+		s.inventory = nil
+		s.mu.Unlock()
+		// Inventory unknown.
+		return
+	}
+	s.mu.Lock()
+	s.inventory = &inventory
+	s.mu.Unlock()
+}
+
+// featureSupport returns whether the host detected at start can run a
+// feature. Features are supported while the host is unknown.
+//
+// Params:
+//   - name: the feature name, one of the domainmetrics Feature constants.
+//
+// Returns:
+//   - domainmetrics.FeatureSupport: the support of the feature.
+func (s *Supervisor) featureSupport(name string) domainmetrics.FeatureSupport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// Assume support without inventory.
+	if s.inventory == nil {
+		// return supported
+		return domainmetrics.FeatureSupport{Name: name, Supported: true}
+	}
+	// return detected support
+	return s.inventory.Supports(name)
+}
@@ -0,0 +1,89 @@
+// Package supervisor provides internal tests for host_inventory.go.
+// It tests the detection of the host capabilities using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// hostInventoryTestCollector returns a settable host inventory.
+type hostInventoryTestCollector struct {
+	// inventory is the returned inventory.
+	inventory domainmetrics.HostInventory
+	// err is the returned error.
+	err error
+}
+
+// CollectHostInventory returns the configured inventory.
+//
+// Returns:
+//   - domainmetrics.HostInventory: the inventory.
+//   - error: the configured error.
+func (c *hostInventoryTestCollector) CollectHostInventory(_ context.Context) (domainmetrics.HostInventory, error) {
+	return c.inventory, c.err
+}
+
+// Test_Supervisor_HostInventory tests the inventory returned to the API.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_HostInventory(t *testing.T) {
+	s := &Supervisor{}
+	_, err := s.HostInventory(context.Background())
+	require.ErrorIs(t, err, ErrHostInventoryUnavailable)
+
+	s.SetHostInventoryCollector(&hostInventoryTestCollector{inventory: domainmetrics.HostInventory{OS: "linux", CgroupVersion: 2}})
+	inventory, err := s.HostInventory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, inventory.CgroupVersion)
+}
+
+// Test_Supervisor_refreshHostInventory tests the features disabled by the
+// inventory detected at start.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_refreshHostInventory(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// collector is the host inventory collector, nil when unset.
+		collector *hostInventoryTestCollector
+		// wantShedding is the expected support of shedding.
+		wantShedding bool
+		// wantReported indicates whether a recovery error is expected.
+		wantReported bool
+	}{
+		{name: "without_collector", wantShedding: true},
+		{name: "with_psi", collector: &hostInventoryTestCollector{inventory: domainmetrics.HostInventory{PressureStall: true}}, wantShedding: true},
+		{name: "without_psi", collector: &hostInventoryTestCollector{}},
+		{name: "failure_keeps_features", collector: &hostInventoryTestCollector{err: errors.New("no procfs")}, wantShedding: true, wantReported: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []string
+			s := &Supervisor{errorHandler: func(operation, _ string, _ error) { reported = append(reported, operation) }}
+			// Install the collector under test.
+			if tt.collector != nil {
+				s.SetHostInventoryCollector(tt.collector)
+			}
+
+			s.refreshHostInventory(context.Background())
+
+			support := s.featureSupport(domainmetrics.FeatureShedding)
+			assert.Equal(t, tt.wantShedding, support.Supported)
+			assert.Equal(t, tt.wantShedding, support.Reason == "")
+			assert.Equal(t, tt.wantReported, len(reported) == 1)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file correlates close failures of several services into incidents.
+package supervisor
+
+import (
+	"fmt"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// configureIncidents sets up failure correlation from the configuration.
+// The correlator is kept when the settings are unchanged, so a reload does
+// not split an ongoing incident.
+//
+// Params:
+//   - cfg: the incident correlation settings.
+func (s *Supervisor) configureIncidents(cfg domainconfig.IncidentConfig) {
+	s.incidentMu.Lock()
+	defer s.incidentMu.Unlock()
+
+	// keep the running correlator
+	if s.incidents != nil && cfg == s.incidentConfig {
+		// settings unchanged
+		return
+	}
+	s.incidentConfig = cfg
+	s.incidents = nil
+	// correlation disabled
+	if !cfg.Enabled() {
+		// nothing to correlate
+		return
+	}
+	s.incidents = domain.NewIncidentCorrelator(cfg.Window.Duration(), cfg.MinServices)
+}
+
+// correlateIncident feeds an event to the incident correlator.
+// Failures joining an open incident get it attached.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event, updated in place.
+//
+// Returns:
+//   - *domain.Event: the incident event to deliver, nil if no incident opened.
+func (s *Supervisor) correlateIncident(name string, event *domain.Event) *domain.Event {
+	s.incidentMu.Lock()
+	// correlation disabled
+	if s.incidents == nil {
+		s.incidentMu.Unlock()
+		// no incident
+		return nil
+	}
+	incident := s.incidents.Observe(name, event)
+	s.incidentMu.Unlock()
+
+	// no incident opened by this event
+	if incident == nil {
+		// no incident
+		return nil
+	}
+	// build the daemon-wide incident event
+	incidentEvent := domain.NewEvent(domain.EventIncident, "", 0, 0,
+		fmt.Errorf("%w: %s", ErrCorrelatedIncident, incident))
+	incidentEvent.Timestamp = incident.OpenedAt
+	incidentEvent.Incident = incident
+	// return event to deliver
+	return &incidentEvent
+}
@@ -0,0 +1,118 @@
+// Package supervisor provides internal tests for incidents.go.
+// It tests failure correlation using white-box testing.
+package supervisor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// deliveredEvent is an event received by the event handler.
+type deliveredEvent struct {
+	service string
+	event   domain.Event
+}
+
+// Test_Supervisor_callEventHandler_incidents tests that close failures of
+// several services are reported once as an incident.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_callEventHandler_incidents(t *testing.T) {
+	tests := []struct {
+		name          string
+		incidents     domainconfig.IncidentConfig
+		wantIncidents int
+	}{
+		{name: "correlation enabled", incidents: domainconfig.IncidentConfig{Window: shared.Seconds(30)}, wantIncidents: 1},
+		{name: "correlation disabled", wantIncidents: 0},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Supervisor{}
+			s.configureIncidents(tt.incidents)
+			var mu sync.Mutex
+			var delivered []deliveredEvent
+			s.eventHandler = func(name string, event *domain.Event, _ *ServiceStatsSnapshot) {
+				mu.Lock()
+				defer mu.Unlock()
+				delivered = append(delivered, deliveredEvent{service: name, event: *event})
+			}
+
+			// Three services killed by the same signal.
+			for _, name := range []string{"api", "worker", "cron"} {
+				event := domain.NewEvent(domain.EventFailed, name, 0, -1, nil)
+				event.Signal = 9
+				s.callEventHandler(name, &event, nil)
+			}
+
+			var incidents []deliveredEvent
+			// collect incident events
+			for _, d := range delivered {
+				// keep incidents only
+				if d.event.Type == domain.EventIncident {
+					incidents = append(incidents, d)
+				}
+			}
+			require.Len(t, incidents, tt.wantIncidents)
+			// Per-service failures are always delivered.
+			assert.Len(t, delivered, 3+tt.wantIncidents)
+			// no further checks without correlation
+			if tt.wantIncidents == 0 {
+				return
+			}
+
+			// The incident follows the failure opening it.
+			assert.Equal(t, domain.EventIncident, delivered[2].event.Type)
+			incident := incidents[0]
+			assert.Empty(t, incident.service)
+			require.NotNil(t, incident.event.Incident)
+			assert.Equal(t, domain.IncidentSameSignal, incident.event.Incident.Cause)
+			assert.Equal(t, []string{"api", "worker"}, incident.event.Incident.Services)
+			require.Error(t, incident.event.Error)
+			assert.ErrorIs(t, incident.event.Error, ErrCorrelatedIncident)
+			assert.Contains(t, incident.event.Error.Error(), "same_signal signal 9: api, worker")
+
+			// The later failure joins the incident.
+			last := delivered[3].event
+			require.NotNil(t, last.Incident)
+			assert.Equal(t, []string{"api", "worker", "cron"}, last.Incident.Services)
+		})
+	}
+}
+
+// Test_Supervisor_configureIncidents tests that reloads keep the correlator
+// unless the settings change.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_configureIncidents(t *testing.T) {
+	s := &Supervisor{}
+	cfg := domainconfig.IncidentConfig{Window: shared.Seconds(30)}
+
+	s.configureIncidents(cfg)
+	correlator := s.incidents
+	require.NotNil(t, correlator)
+
+	// Unchanged settings keep the ongoing correlation.
+	s.configureIncidents(cfg)
+	assert.Same(t, correlator, s.incidents)
+
+	// Changed settings rebuild it.
+	s.configureIncidents(domainconfig.IncidentConfig{Window: shared.FromTimeDuration(time.Minute)})
+	assert.NotSame(t, correlator, s.incidents)
+
+	// No window disables correlation.
+	s.configureIncidents(domainconfig.IncidentConfig{})
+	assert.Nil(t, s.incidents)
+}
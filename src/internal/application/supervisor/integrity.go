@@ -0,0 +1,298 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file watches service binaries and files, and runs the actions of file watches.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// File watch errors.
+var (
+	// ErrFileChanged is attached to file changed events.
+	ErrFileChanged error = fmt.Errorf("file content changed on disk")
+	// ErrNoHookRunner is reported when an exec action runs without hook runner.
+	ErrNoHookRunner error = fmt.Errorf("no hook runner configured")
+)
+
+// SetFileWatcher sets the adapter watching service files for modification.
+// Without a watcher, integrity settings and file watches are ignored.
+//
+// Params:
+//   - watcher: the file watcher to use.
+func (s *Supervisor) SetFileWatcher(watcher appintegrity.Watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store file watcher
+	s.fileWatcher = watcher
+}
+
+// SetHookRunner sets the adapter running the exec actions of file watches.
+//
+// Params:
+//   - runner: the hook runner to use.
+func (s *Supervisor) SetHookRunner(runner apphook.Runner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store hook runner
+	s.hookRunner = runner
+}
+
+// startFileWatches starts the file watches of the configuration.
+func (s *Supervisor) startFileWatches() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchFiles(s.config)
+}
+
+// watchFiles replaces the running file watches with those of cfg: the
+// integrity watch of each service enabling it, each file watch, and the
+// secret files of each service.
+// Watches end with the supervisor context. Must be called with s.mu held.
+//
+// Params:
+//   - cfg: the configuration to watch.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine per watch.
+//   - Goroutines exit on the next call or when the supervisor context is cancelled.
+func (s *Supervisor) watchFiles(cfg *domainconfig.Config) {
+	// stop the watches of the previous configuration
+	if s.watchCancel != nil {
+		s.watchCancel()
+		s.watchCancel = nil
+	}
+	// Skip when no adapter is configured.
+	if s.fileWatcher == nil || s.ctx == nil {
+		// Nothing to watch with.
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.watchCancel = cancel
+	// start the watches of each service
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		name := svc.Name
+		// watch the binary and integrity paths
+		if svc.WatchesIntegrity() {
+			binary := integrityBinary(svc)
+			restart := svc.RestartOnBinaryChange
+			s.startFileWatch(ctx, name, appintegrity.Target{
+				Paths:    append([]string{binary}, svc.Integrity.Paths...),
+				Interval: svc.Integrity.IntegrityInterval(),
+				Debounce: domainconfig.DefaultWatchDebounce,
+			}, func(paths []string) {
+				// Report the changes, restarting on a new binary.
+				s.binaryChanged(name, binary, restart, paths)
+			})
+		}
+		// watch the files of each watch
+		for j := range svc.Watches {
+			watch := svc.Watches[j]
+			s.startFileWatch(ctx, name, appintegrity.Target{
+				Paths:    watch.Paths,
+				Interval: watch.WatchInterval(),
+				Debounce: watch.WatchDebounce(),
+			}, func(paths []string) {
+				// Report the changes and run the action.
+				s.watchTriggered(ctx, name, &watch, paths)
+			})
+		}
+		s.watchSecrets(ctx, svc)
+	}
+}
+
+// startFileWatch runs one watch until ctx is cancelled.
+// Watches that cannot be set up are reported through the error handler.
+//
+// Params:
+//   - ctx: the watch context.
+//   - name: the service name.
+//   - target: the watched files.
+//   - onChange: called with the changed files.
+func (s *Supervisor) startFileWatch(ctx context.Context, name string, target appintegrity.Target, onChange func(paths []string)) {
+	watcher := s.fileWatcher
+	s.wg.Go(func() {
+		// Report watches that could not be set up.
+		if err := watcher.Watch(ctx, target, onChange); err != nil {
+			s.handleRecoveryError("watch-files", name, err)
+		}
+	})
+}
+
+// binaryChanged emits file changed events and restarts the service if its
+// binary changed and restart is set.
+//
+// Params:
+//   - name: the service name.
+//   - binary: the binary path.
+//   - restart: whether a new binary restarts the service.
+//   - paths: the changed files.
+func (s *Supervisor) binaryChanged(name, binary string, restart bool, paths []string) {
+	mgr := s.filesChanged(name, paths)
+	// Skip without restart or binary change.
+	if mgr == nil || !restart || !slices.Contains(paths, binary) {
+		// No restart requested.
+		return
+	}
+	s.restartOnChange(name, mgr, "restart-on-binary-change")
+}
+
+// watchTriggered emits file changed events, then runs the watch action once.
+//
+// Params:
+//   - ctx: the watch context, bounding exec actions.
+//   - name: the service name.
+//   - watch: the triggered watch.
+//   - paths: the changed files.
+func (s *Supervisor) watchTriggered(ctx context.Context, name string, watch *domainconfig.WatchConfig, paths []string) {
+	mgr := s.filesChanged(name, paths)
+	// Skip services removed meanwhile.
+	if mgr == nil {
+		// Service gone.
+		return
+	}
+	// run the action
+	switch watch.Action {
+	// restart on the new files
+	case domainconfig.WatchActionRestart:
+		s.restartOnChange(name, mgr, "watch-restart")
+	// let the service reload its files
+	case domainconfig.WatchActionReload:
+		// Only a running process can be signaled.
+		if !mgr.State().IsRunning() {
+			// Nothing to reload.
+			return
+		}
+		// Report signals that could not be sent.
+		if err := mgr.Signal(watch.ReloadSignal()); err != nil {
+			s.handleRecoveryError("watch-reload", name, err)
+		}
+	// run the hook
+	case domainconfig.WatchActionExec:
+		s.runWatchHook(ctx, name, watch, paths)
+	// events only
+	case domainconfig.WatchActionNone:
+		// Events already emitted.
+	default:
+		// Unknown action, rejected by validation.
+	}
+}
+
+// runWatchHook runs the exec action of a watch. The hook receives the
+// service name and the changed files, one per line, in its environment.
+//
+// Params:
+//   - ctx: the watch context.
+//   - name: the service name.
+//   - watch: the triggered watch.
+//   - paths: the changed files.
+func (s *Supervisor) runWatchHook(ctx context.Context, name string, watch *domainconfig.WatchConfig, paths []string) {
+	s.mu.RLock()
+	runner := s.hookRunner
+	s.mu.RUnlock()
+
+	// Exec actions need a runner.
+	if runner == nil {
+		s.handleRecoveryError("watch-exec", name, ErrNoHookRunner)
+		// Nothing to run with.
+		return
+	}
+	err := runner.Run(ctx, apphook.Command{
+		Path: watch.Command,
+		Args: watch.Args,
+		Env: []string{
+			"SUPERVIZIO_SERVICE=" + name,
+			"SUPERVIZIO_CHANGED_FILES=" + strings.Join(paths, "\n"),
+		},
+		Timeout: watch.WatchTimeout(),
+	})
+	// Report failed hooks.
+	if err != nil {
+		s.handleRecoveryError("watch-exec", name, err)
+	}
+}
+
+// filesChanged emits one file changed event per changed file.
+//
+// Params:
+//   - name: the service name.
+//   - paths: the changed files.
+//
+// Returns:
+//   - *applifecycle.Manager: the service manager, nil if the service was removed.
+func (s *Supervisor) filesChanged(name string, paths []string) *applifecycle.Manager {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	snap := s.getStatsSnapshot(s.stats[name])
+	s.mu.RUnlock()
+
+	// Skip services removed meanwhile.
+	if !ok {
+		// Service gone.
+		return nil
+	}
+	// report each file
+	for _, path := range paths {
+		event := domain.NewEvent(domain.EventFileChanged, name, mgr.PID(), 0,
+			fmt.Errorf("%w: %s", ErrFileChanged, path))
+		event.File = path
+		s.callEventHandler(name, &event, snap)
+	}
+	// return manager for the action
+	return mgr
+}
+
+// restartOnChange restarts an active service after a file change.
+// A stopped service is not started by a change.
+//
+// Params:
+//   - name: the service name.
+//   - mgr: the service manager.
+//   - operation: the operation reported on failure.
+func (s *Supervisor) restartOnChange(name string, mgr *applifecycle.Manager, operation string) {
+	// Only an active service has new files to pick up.
+	if !mgr.State().IsActive() {
+		// Stopped service.
+		return
+	}
+	// Restart on the new files (best-effort).
+	if err := s.RestartService(name); err != nil {
+		s.handleRecoveryError(operation, name, err)
+	}
+}
+
+// integrityBinary returns the path of the service binary. Relative paths
+// are resolved against the working directory, as the process sees them;
+// bare names are left to the watcher to look up in PATH.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - string: the binary path.
+func integrityBinary(svc *domainconfig.ServiceConfig) string {
+	fields := strings.Fields(svc.Command)
+	// no command to watch
+	if len(fields) == 0 {
+		// return empty path
+		return ""
+	}
+	binary := fields[0]
+	// relative paths with a directory are resolved by the executor from the working directory
+	if strings.Contains(binary, "/") && !filepath.IsAbs(binary) && svc.WorkingDirectory != "" {
+		// return resolved path
+		return filepath.Join(svc.WorkingDirectory, binary)
+	}
+	// return path as configured
+	return binary
+}
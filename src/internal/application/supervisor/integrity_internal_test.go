@@ -0,0 +1,378 @@
+// Package supervisor provides internal tests for integrity.go.
+// It tests file watches and their actions using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// fakeWatch is a watch started on the fakeFileWatcher.
+type fakeWatch struct {
+	// ctx is the watch context.
+	ctx context.Context
+	// target is the watched files.
+	target appintegrity.Target
+	// onChange reports changes.
+	onChange func(paths []string)
+}
+
+// fakeFileWatcher records watches and blocks until they are cancelled.
+type fakeFileWatcher struct {
+	// mu guards watches.
+	mu sync.Mutex
+	// watches are the started watches.
+	watches []fakeWatch
+	// awaited are the paths awaited so far.
+	awaited []string
+	// created releases the awaited paths, nil blocks until cancelled.
+	created chan struct{}
+}
+
+// Watch records the watch and waits for its cancellation.
+//
+// Params:
+//   - ctx: the watch context.
+//   - target: the watched files.
+//   - onChange: the change callback.
+//
+// Returns:
+//   - error: always nil.
+func (w *fakeFileWatcher) Watch(ctx context.Context, target appintegrity.Target, onChange func(paths []string)) error {
+	w.mu.Lock()
+	w.watches = append(w.watches, fakeWatch{ctx: ctx, target: target, onChange: onChange})
+	w.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+// AwaitFile records the path and waits for its creation or cancellation.
+//
+// Params:
+//   - ctx: the wait context.
+//   - path: the awaited path.
+//   - _: the poll period.
+//
+// Returns:
+//   - error: the context error when cancelled first.
+func (w *fakeFileWatcher) AwaitFile(ctx context.Context, path string, _ time.Duration) error {
+	w.mu.Lock()
+	w.awaited = append(w.awaited, path)
+	w.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.created:
+		return nil
+	}
+}
+
+// awaitedPaths returns the paths awaited so far.
+//
+// Returns:
+//   - []string: the paths.
+func (w *fakeFileWatcher) awaitedPaths() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.awaited...)
+}
+
+// started returns the watches started so far.
+//
+// Returns:
+//   - []fakeWatch: the watches.
+func (w *fakeFileWatcher) started() []fakeWatch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]fakeWatch(nil), w.watches...)
+}
+
+// fakeHookRunner records the hooks run.
+type fakeHookRunner struct {
+	// mu guards commands.
+	mu sync.Mutex
+	// commands are the hooks run.
+	commands []apphook.Command
+}
+
+// Run records the command.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - cmd: the command.
+//
+// Returns:
+//   - error: always nil.
+func (r *fakeHookRunner) Run(_ context.Context, cmd apphook.Command) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands = append(r.commands, cmd)
+	return nil
+}
+
+// Output records the command.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - cmd: the command.
+//
+// Returns:
+//   - string: always empty.
+//   - error: always nil.
+func (r *fakeHookRunner) Output(ctx context.Context, cmd apphook.Command) (string, error) {
+	// record like Run
+	return "", r.Run(ctx, cmd)
+}
+
+// countingExecutor starts processes that never exit, counting starts and signals.
+type countingExecutor struct {
+	// starts counts started processes.
+	starts atomic.Int32
+	// signals records the signals sent.
+	signals chan os.Signal
+}
+
+// Start returns a process that stays running.
+//
+// Returns:
+//   - int: a fake pid.
+//   - <-chan domain.ExitResult: a channel that never fires.
+//   - error: always nil.
+func (e *countingExecutor) Start(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+	e.starts.Add(1)
+	return 4242, make(chan domain.ExitResult), nil
+}
+
+// Stop is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *countingExecutor) Stop(_ int, _ time.Duration) error { return nil }
+
+// Signal records the signal.
+//
+// Params:
+//   - pid: the process ID (unused).
+//   - sig: the signal.
+//
+// Returns:
+//   - error: always nil.
+func (e *countingExecutor) Signal(_ int, sig os.Signal) error {
+	e.signals <- sig
+	return nil
+}
+
+// newWatchTestSupervisor builds a supervisor running the first service of cfg.
+//
+// Params:
+//   - t: the testing context.
+//   - cfg: the configuration.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+//   - *countingExecutor: the executor of the running service.
+//   - *fakeFileWatcher: the file watcher.
+//   - func() []domain.Event: the delivered events.
+func newWatchTestSupervisor(t *testing.T, cfg *domainconfig.Config) (*Supervisor, *countingExecutor, *fakeFileWatcher, func() []domain.Event) {
+	t.Helper()
+	executor := &countingExecutor{signals: make(chan os.Signal, 1)}
+	svc := &cfg.Services[0]
+	mgr := applifecycle.NewManager(svc, executor)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, mgr.Start(ctx))
+	require.Eventually(t, func() bool { return mgr.State().IsRunning() }, time.Second, time.Millisecond)
+
+	watcher := &fakeFileWatcher{}
+	s := &Supervisor{
+		config:   cfg,
+		managers: map[string]*applifecycle.Manager{svc.Name: mgr},
+		stats:    map[string]*ServiceStats{svc.Name: NewServiceStats()},
+		ctx:      ctx,
+	}
+	s.SetFileWatcher(watcher)
+	var mu sync.Mutex
+	var events []domain.Event
+	s.SetEventHandler(func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, *event)
+	})
+	t.Cleanup(func() {
+		cancel()
+		_ = mgr.Stop()
+		s.wg.Wait()
+	})
+	return s, executor, watcher, func() []domain.Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]domain.Event(nil), events...)
+	}
+}
+
+// Test_Supervisor_watchFiles_integrity tests file changed events and restarts on binary change.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_watchFiles_integrity(t *testing.T) {
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "api", Command: "/opt/api/bin/api --port 80", RestartOnBinaryChange: true,
+			Integrity: domainconfig.IntegrityConfig{Paths: []string{"/etc/api.conf"}}},
+		{Name: "worker", Command: "/bin/worker"},
+	}}
+	s, executor, watcher, events := newWatchTestSupervisor(t, cfg)
+
+	s.startFileWatches()
+
+	// Only the service with integrity settings is watched.
+	require.Eventually(t, func() bool { return len(watcher.started()) == 1 }, time.Second, time.Millisecond)
+	watch := watcher.started()[0]
+	assert.Equal(t, []string{"/opt/api/bin/api", "/etc/api.conf"}, watch.target.Paths)
+	assert.Equal(t, domainconfig.DefaultIntegrityInterval, watch.target.Interval)
+	assert.Equal(t, domainconfig.DefaultWatchDebounce, watch.target.Debounce)
+
+	// An extra file change is reported without restart.
+	watch.onChange([]string{"/etc/api.conf"})
+	assert.Equal(t, int32(1), executor.starts.Load())
+
+	// A binary change is reported and restarts the service.
+	watch.onChange([]string{"/opt/api/bin/api"})
+	require.Eventually(t, func() bool { return executor.starts.Load() == 2 }, time.Second, time.Millisecond)
+
+	delivered := events()
+	require.Len(t, delivered, 2)
+	assert.Equal(t, domain.EventFileChanged, delivered[0].Type)
+	assert.Equal(t, "/etc/api.conf", delivered[0].File)
+	assert.ErrorIs(t, delivered[0].Error, ErrFileChanged)
+	assert.Equal(t, "/opt/api/bin/api", delivered[1].File)
+
+	// A new configuration replaces the watches.
+	s.mu.Lock()
+	s.watchFiles(&domainconfig.Config{})
+	s.mu.Unlock()
+	assert.Error(t, watch.ctx.Err())
+}
+
+// Test_Supervisor_watchFiles_actions tests the actions of file watches.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_watchFiles_actions(t *testing.T) {
+	changed := []string{"/etc/app/a.conf", "/etc/app/b.conf"}
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// watch is the file watch.
+		watch domainconfig.WatchConfig
+		// check verifies the action.
+		check func(t *testing.T, executor *countingExecutor, runner *fakeHookRunner)
+	}{
+		{
+			name:  "events_only",
+			watch: domainconfig.WatchConfig{},
+			check: func(t *testing.T, executor *countingExecutor, runner *fakeHookRunner) {
+				assert.Equal(t, int32(1), executor.starts.Load())
+				assert.Empty(t, executor.signals)
+				assert.Empty(t, runner.commands)
+			},
+		},
+		{
+			name:  "restart",
+			watch: domainconfig.WatchConfig{Action: domainconfig.WatchActionRestart},
+			check: func(t *testing.T, executor *countingExecutor, _ *fakeHookRunner) {
+				require.Eventually(t, func() bool { return executor.starts.Load() == 2 }, time.Second, time.Millisecond)
+			},
+		},
+		{
+			name:  "reload",
+			watch: domainconfig.WatchConfig{Action: domainconfig.WatchActionReload, Signal: "usr1"},
+			check: func(t *testing.T, executor *countingExecutor, _ *fakeHookRunner) {
+				assert.Equal(t, syscall.SIGUSR1, <-executor.signals)
+				assert.Equal(t, int32(1), executor.starts.Load())
+			},
+		},
+		{
+			name: "exec",
+			watch: domainconfig.WatchConfig{Action: domainconfig.WatchActionExec,
+				Command: "/usr/local/bin/deploy-hook", Args: []string{"--check"}},
+			check: func(t *testing.T, _ *countingExecutor, runner *fakeHookRunner) {
+				require.Len(t, runner.commands, 1)
+				assert.Equal(t, apphook.Command{
+					Path: "/usr/local/bin/deploy-hook",
+					Args: []string{"--check"},
+					Env: []string{
+						"SUPERVIZIO_SERVICE=api",
+						"SUPERVIZIO_CHANGED_FILES=/etc/app/a.conf\n/etc/app/b.conf",
+					},
+					Timeout: domainconfig.DefaultWatchTimeout,
+				}, runner.commands[0])
+			},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			watch := tt.watch
+			watch.Paths = []string{"/etc/app/*.conf"}
+			cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+				{Name: "api", Command: "/bin/api", Watches: []domainconfig.WatchConfig{watch}},
+			}}
+			s, executor, watcher, events := newWatchTestSupervisor(t, cfg)
+			runner := &fakeHookRunner{}
+			s.SetHookRunner(runner)
+
+			s.startFileWatches()
+			require.Eventually(t, func() bool { return len(watcher.started()) == 1 }, time.Second, time.Millisecond)
+			started := watcher.started()[0]
+			assert.Equal(t, []string{"/etc/app/*.conf"}, started.target.Paths)
+
+			started.onChange(changed)
+
+			// Each changed file is reported, then the action runs once.
+			delivered := events()
+			require.Len(t, delivered, 2)
+			assert.Equal(t, changed[0], delivered[0].File)
+			assert.Equal(t, changed[1], delivered[1].File)
+			tt.check(t, executor, runner)
+		})
+	}
+}
+
+// Test_integrityBinary tests resolution of the watched binary path.
+//
+// Params:
+//   - t: the testing context.
+func Test_integrityBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      domainconfig.ServiceConfig
+		expected string
+	}{
+		{"absolute", domainconfig.ServiceConfig{Command: "/usr/bin/api -v", WorkingDirectory: "/srv"}, "/usr/bin/api"},
+		{"relative", domainconfig.ServiceConfig{Command: "./bin/api", WorkingDirectory: "/srv"}, "/srv/bin/api"},
+		{"relative_without_dir", domainconfig.ServiceConfig{Command: "bin/api"}, "bin/api"},
+		{"bare_name", domainconfig.ServiceConfig{Command: "nginx -g daemon", WorkingDirectory: "/srv"}, "nginx"},
+		{"empty", domainconfig.ServiceConfig{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, integrityBinary(&tt.svc))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file keeps the outcome of the last runs of oneshot services.
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// jobOutputLines is the number of output lines kept with each job run.
+const jobOutputLines int = 20
+
+// SetOutputStreamer sets the source of captured service output, read to keep
+// the output tail of each job run. Without it, job runs carry no output.
+//
+// Params:
+//   - streamer: the captured output source.
+func (s *Supervisor) SetOutputStreamer(streamer domainlogging.OutputStreamer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store output streamer
+	s.outputStreamer = streamer
+}
+
+// JobRuns returns the last runs of a oneshot service, most recent first.
+// The number of runs kept is set by the service job_history.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - []domain.JobRun: the runs; empty for services that are not oneshot.
+//   - error: ErrServiceNotFound if the service does not exist.
+func (s *Supervisor) JobRuns(name string) ([]domain.JobRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// validate service exists
+	if s.config.FindService(name) == nil {
+		// Return error for missing service.
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	runs := slices.Clone(s.jobRuns[name])
+	slices.Reverse(runs)
+	// return most recent first
+	return runs, nil
+}
+
+// updateJobRuns records the run carried by a process event of a oneshot
+// service. Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) updateJobRuns(name string, event *domain.Event) {
+	// No configuration loaded.
+	if s.config == nil {
+		// Nothing to record.
+		return
+	}
+	svc := s.config.FindService(name)
+	// Only oneshot services are jobs.
+	if svc == nil || !svc.Oneshot {
+		// Nothing to record.
+		return
+	}
+
+	// Map lifecycle events to runs.
+	switch event.Type {
+	// A run starts.
+	case domain.EventStarted:
+		// Create the map on first use.
+		if s.jobStarts == nil {
+			s.jobStarts = make(map[string]time.Time)
+		}
+		s.jobStarts[name] = eventTime(event)
+	// A run ends.
+	case domain.EventStopped, domain.EventFailed:
+		started := s.jobStarts[name]
+		delete(s.jobStarts, name)
+		run := domain.NewJobRun(started, event, s.jobOutput(name, started))
+		s.appendJobRun(name, run, svc.EffectiveJobHistory())
+	// Other events do not end runs.
+	default:
+	}
+}
+
+// jobOutput returns the last output lines a run wrote. Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - since: when the run started; zero keeps every recent line.
+//
+// Returns:
+//   - []string: the messages, oldest first; nil without output streamer.
+func (s *Supervisor) jobOutput(name string, since time.Time) []string {
+	// No captured output to read.
+	if s.outputStreamer == nil {
+		// Nothing to keep.
+		return nil
+	}
+	lines := s.outputStreamer.Tail(domainlogging.OutputFilter{Services: []string{name}}, jobOutputLines)
+	output := make([]string, 0, len(lines))
+	// Keep the lines of this run only.
+	for i := range lines {
+		// Skip lines of previous runs.
+		if lines[i].Timestamp.Before(since) {
+			continue
+		}
+		output = append(output, lines[i].Message)
+	}
+	// return run output
+	return output
+}
+
+// appendJobRun appends a run, dropping the oldest beyond the limit.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - run: the run outcome.
+//   - limit: the number of runs kept.
+func (s *Supervisor) appendJobRun(name string, run domain.JobRun, limit int) {
+	// Create the map on first use.
+	if s.jobRuns == nil {
+		s.jobRuns = make(map[string][]domain.JobRun)
+	}
+	runs := append(s.jobRuns[name], run)
+	// Keep the most recent runs only.
+	if len(runs) > limit {
+		runs = slices.Clone(runs[len(runs)-limit:])
+	}
+	s.jobRuns[name] = runs
+}
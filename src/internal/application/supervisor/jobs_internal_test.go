@@ -0,0 +1,129 @@
+// Package supervisor provides internal tests for jobs.go.
+// It tests the history of oneshot service runs using white-box testing.
+package supervisor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// stubOutputStreamer returns fixed lines from Tail.
+type stubOutputStreamer struct {
+	// lines are the captured lines.
+	lines []domainlogging.OutputLine
+}
+
+// Tail returns the last n lines of the filtered services.
+//
+// Params:
+//   - filter: the line filter.
+//   - n: the maximum number of lines.
+//
+// Returns:
+//   - []domainlogging.OutputLine: the matching lines.
+func (s *stubOutputStreamer) Tail(filter domainlogging.OutputFilter, n int) []domainlogging.OutputLine {
+	var result []domainlogging.OutputLine
+	for i := range s.lines {
+		if filter.Matches(&s.lines[i]) {
+			result = append(result, s.lines[i])
+		}
+	}
+	if len(result) > n {
+		result = result[len(result)-n:]
+	}
+	return result
+}
+
+// Subscribe is not used by the supervisor.
+//
+// Returns:
+//   - domainlogging.OutputSubscription: always nil.
+func (s *stubOutputStreamer) Subscribe(domainlogging.OutputFilter, int) domainlogging.OutputSubscription {
+	return nil
+}
+
+// Test_Supervisor_updateJobRuns tests that oneshot runs are kept with their output.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_updateJobRuns(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	s := &Supervisor{config: &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "backup", Oneshot: true, JobHistory: 2},
+		{Name: "api"},
+	}}}
+	s.SetOutputStreamer(&stubOutputStreamer{lines: []domainlogging.OutputLine{
+		{Service: "backup", Timestamp: start.Add(-time.Minute), Message: "previous run"},
+		{Service: "api", Timestamp: start.Add(time.Second), Message: "listening"},
+		{Service: "backup", Timestamp: start.Add(time.Second), Message: "dumping"},
+		{Service: "backup", Timestamp: start.Add(2 * time.Second), Message: "disk full"},
+	}})
+
+	// A failed run with output.
+	s.updateJobRuns("backup", &domain.Event{Type: domain.EventStarted, Process: "backup", Timestamp: start})
+	s.updateJobRuns("api", &domain.Event{Type: domain.EventStarted, Process: "api", Timestamp: start})
+	s.updateJobRuns("backup", &domain.Event{
+		Type: domain.EventFailed, Process: "backup", ExitCode: 3,
+		Timestamp: start.Add(time.Minute), Error: errors.New("exit code 3"),
+	})
+	runs, err := s.JobRuns("backup")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, 3, runs[0].ExitCode)
+	assert.Equal(t, []string{"dumping", "disk full"}, runs[0].Output)
+
+	// A successful run, then a start failure: only the last two are kept.
+	s.updateJobRuns("backup", &domain.Event{Type: domain.EventStarted, Process: "backup", Timestamp: start.Add(2 * time.Minute)})
+	s.updateJobRuns("backup", &domain.Event{Type: domain.EventStopped, Process: "backup", Timestamp: start.Add(3 * time.Minute)})
+	s.updateJobRuns("backup", &domain.Event{Type: domain.EventFailed, Process: "backup", Timestamp: start.Add(4 * time.Minute), Error: errors.New("not found")})
+
+	runs, err = s.JobRuns("backup")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	// Start failure, most recent first.
+	assert.False(t, runs[0].Succeeded)
+	assert.Equal(t, "not found", runs[0].Error)
+	assert.Zero(t, runs[0].Duration)
+
+	// Success after the failure.
+	assert.True(t, runs[1].Succeeded)
+	assert.Equal(t, time.Minute, runs[1].Duration)
+	assert.Empty(t, runs[1].Output)
+
+	// Services that are not oneshot keep no runs.
+	runs, err = s.JobRuns("api")
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	_, err = s.JobRuns("missing")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
+
+// Test_Supervisor_jobOutput tests that only the lines of the run are kept.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_jobOutput(t *testing.T) {
+	start := time.Now()
+	s := &Supervisor{}
+
+	// No output streamer: no output.
+	assert.Nil(t, s.jobOutput("backup", start))
+
+	s.SetOutputStreamer(&stubOutputStreamer{lines: []domainlogging.OutputLine{
+		{Service: "backup", Timestamp: start.Add(-time.Minute), Message: "previous run"},
+		{Service: "api", Timestamp: start.Add(time.Second), Message: "listening"},
+		{Service: "backup", Timestamp: start.Add(time.Second), Message: "dumping"},
+		{Service: "backup", Timestamp: start.Add(2 * time.Second), Message: "disk full"},
+	}})
+	assert.Equal(t, []string{"dumping", "disk full"}, s.jobOutput("backup", start))
+}
@@ -0,0 +1,62 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file attaches service labels to events and metrics.
+package supervisor
+
+import (
+	"maps"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// ServiceLabels returns the labels of a service.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - map[string]string: a copy of the labels, nil for unknown or unlabeled services.
+func (s *Supervisor) ServiceLabels(name string) map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// return the labels of the current configuration
+	return s.serviceLabels(name)
+}
+
+// serviceLabels returns a copy of the labels of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - map[string]string: the labels, nil for unknown or unlabeled services.
+func (s *Supervisor) serviceLabels(name string) map[string]string {
+	// Skip when no configuration is loaded.
+	if s.config == nil {
+		// No labels.
+		return nil
+	}
+	svc := s.config.FindService(name)
+	// Skip unknown and unlabeled services.
+	if svc == nil || len(svc.Labels) == 0 {
+		// No labels.
+		return nil
+	}
+	// return a copy handlers may keep
+	return maps.Clone(svc.Labels)
+}
+
+// labelEvent attaches the labels of its service to an event.
+// Labels already set on the event are kept.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) labelEvent(name string, event *domain.Event) {
+	// Keep labels set by the emitter.
+	if event.Labels != nil {
+		// Already labeled.
+		return
+	}
+	event.Labels = s.ServiceLabels(name)
+}
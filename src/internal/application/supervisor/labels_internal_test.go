@@ -0,0 +1,86 @@
+// Package supervisor provides internal tests for labels.go.
+// It tests service labels using white-box testing.
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// newLabelTestSupervisor builds a supervisor with a labeled and an unlabeled service.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+func newLabelTestSupervisor() *Supervisor {
+	return &Supervisor{
+		config: &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+			{Name: "api", Command: "/bin/api", Labels: map[string]string{"team": "payments", "tier": "frontend"}},
+			{Name: "worker", Command: "/bin/worker"},
+		}},
+		stats: make(map[string]*ServiceStats),
+	}
+}
+
+// Test_Supervisor_ServiceLabels tests the labels returned per service.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ServiceLabels(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// service is the looked up service.
+		service string
+		// want is the expected labels.
+		want map[string]string
+	}{
+		{name: "labeled", service: "api", want: map[string]string{"team": "payments", "tier": "frontend"}},
+		{name: "unlabeled", service: "worker"},
+		{name: "unknown", service: "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newLabelTestSupervisor()
+			assert.Equal(t, tt.want, s.ServiceLabels(tt.service))
+		})
+	}
+}
+
+// Test_Supervisor_ServiceLabels_Copy tests that callers cannot alter the configuration.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ServiceLabels_Copy(t *testing.T) {
+	s := newLabelTestSupervisor()
+	labels := s.ServiceLabels("api")
+	labels["team"] = "changed"
+	assert.Equal(t, "payments", s.ServiceLabels("api")["team"])
+}
+
+// Test_Supervisor_callEventHandler_Labels tests that delivered events carry the service labels.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_callEventHandler_Labels(t *testing.T) {
+	s := newLabelTestSupervisor()
+	var got []*domain.Event
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		got = append(got, event)
+	}
+
+	s.callEventHandler("api", &domain.Event{Type: domain.EventFailed}, nil)
+	s.callEventHandler("worker", &domain.Event{Type: domain.EventFailed}, nil)
+	// Labels set by the emitter are kept.
+	s.callEventHandler("api", &domain.Event{Type: domain.EventFailed, Labels: map[string]string{"team": "override"}}, nil)
+
+	require.Len(t, got, 3)
+	assert.Equal(t, map[string]string{"team": "payments", "tier": "frontend"}, got[0].Labels)
+	assert.Nil(t, got[1].Labels)
+	assert.Equal(t, map[string]string{"team": "override"}, got[2].Labels)
+}
@@ -0,0 +1,162 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file reconciles the resources held by the executor with the services.
+package supervisor
+
+import (
+	"fmt"
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// leakStopTimeout bounds the graceful stop of a reaped process.
+const leakStopTimeout time.Duration = 10 * time.Second
+
+// ErrResourceLeaked is the error of resource_leaked events.
+var ErrResourceLeaked error = fmt.Errorf("resources outlived their service")
+
+// SetResourceLedger sets the ledger of the resources the executor holds per
+// process start. Without it, leaks are not checked.
+//
+// Params:
+//   - ledger: the executor resource ledger.
+func (s *Supervisor) SetResourceLedger(ledger domain.ResourceLedger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store resource ledger
+	s.ledger = ledger
+}
+
+// startLeakWatcher reconciles executor resources with the services
+// periodically. It is skipped without resource ledger.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine reconciling on a ticker.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startLeakWatcher() {
+	s.mu.RLock()
+	ledger := s.ledger
+	interval := s.config.LeakCheck.EffectiveInterval()
+	s.mu.RUnlock()
+
+	// Skip without ledger.
+	if ledger == nil {
+		// Nothing to reconcile.
+		return
+	}
+
+	// Reconcile until shutdown.
+	s.wg.Go(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		// Loop until context is cancelled.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case <-ticker.C:
+				// Follow interval changes of reloads.
+				ticker.Reset(s.checkLeaks(ledger))
+			}
+		}
+	})
+}
+
+// checkLeaks reports the resources held for processes no service owns, and
+// for exited processes whose cgroup is left behind. A resource is leaked
+// once seen on two consecutive checks, so a start in progress is not
+// mistaken for a leak. Each leak emits one resource_leaked event, and is
+// reaped on every check under leak_check.reap.
+//
+// Params:
+//   - ledger: the executor resource ledger.
+//
+// Returns:
+//   - time.Duration: the interval until the next check.
+func (s *Supervisor) checkLeaks(ledger domain.ResourceLedger) time.Duration {
+	s.mu.RLock()
+	cfg := s.config.LeakCheck
+	// The map is replaced, never modified, by each check.
+	previous := s.leakSeen
+	owned := make(map[int]bool, len(s.managers))
+	// Collect the processes owned by services.
+	for _, mgr := range s.managers {
+		// Stopped services own no process.
+		if pid := mgr.PID(); pid > 0 {
+			owned[pid] = true
+		}
+	}
+	s.mu.RUnlock()
+
+	seen := make(map[int]int)
+	var leaks []domain.ProcessResources
+	// Keep the resources nothing accounts for.
+	for _, res := range ledger.Resources() {
+		// Running processes of a service use their resources.
+		if res.Waiting && owned[res.PID] {
+			continue
+		}
+		seen[res.PID] = previous[res.PID] + 1
+		// Leave one check to starts in progress.
+		if seen[res.PID] >= 2 {
+			leaks = append(leaks, res)
+		}
+	}
+	s.mu.Lock()
+	s.leakSeen = seen
+	s.mu.Unlock()
+
+	// Report and reap each leak.
+	for _, res := range leaks {
+		// Report a leak the first time it is confirmed.
+		if previous[res.PID] < 2 {
+			event := domain.NewEvent(domain.EventResourceLeaked, res.Service, res.PID, 0, leakError(res))
+			// The service may be gone, its statistics are left untouched.
+			s.callEventHandler(res.Service, &event, nil)
+		}
+		// Reap leaks when configured.
+		if cfg.Reap {
+			s.reapLeak(ledger, res)
+		}
+	}
+	// Check again after the interval.
+	return cfg.EffectiveInterval()
+}
+
+// reapLeak stops a leaked process, or removes the cgroup of an exited one.
+//
+// Params:
+//   - ledger: the executor resource ledger.
+//   - res: the leaked resources.
+func (s *Supervisor) reapLeak(ledger domain.ResourceLedger, res domain.ProcessResources) {
+	// Stopping the process lets the executor release its resources.
+	if res.Waiting {
+		s.handleRecoveryError("reap-leak", res.Service, s.executor.Stop(res.PID, leakStopTimeout))
+		// Resources released on exit.
+		return
+	}
+	s.handleRecoveryError("reap-leak", res.Service, ledger.Release(res.PID))
+}
+
+// leakError describes leaked resources.
+//
+// Params:
+//   - res: the leaked resources.
+//
+// Returns:
+//   - error: ErrResourceLeaked with the process and resources.
+func leakError(res domain.ProcessResources) error {
+	// An unowned process holds its exit watch, and its cgroup if any.
+	if res.Waiting {
+		detail := fmt.Sprintf("pid %d started %s runs without service", res.PID, res.StartedAt.Format(time.RFC3339))
+		// Name the cgroup held by the process.
+		if res.Cgroup != "" {
+			detail += fmt.Sprintf(" in cgroup %q", res.Cgroup)
+		}
+		// return unowned process
+		return fmt.Errorf("%w: %s", ErrResourceLeaked, detail)
+	}
+	// return left-behind cgroup
+	return fmt.Errorf("%w: cgroup %q of exited pid %d was not removed", ErrResourceLeaked, res.Cgroup, res.PID)
+}
@@ -0,0 +1,151 @@
+// Package supervisor provides internal tests for leak_check.go.
+// It tests the reconciliation of executor resources using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// leakTestLedger returns fixed resources and records releases.
+type leakTestLedger struct {
+	// resources is the returned resources.
+	resources []domain.ProcessResources
+	// released lists the released PIDs.
+	released []int
+}
+
+// Resources returns the fixed resources.
+//
+// Returns:
+//   - []domain.ProcessResources: the configured resources.
+func (l *leakTestLedger) Resources() []domain.ProcessResources {
+	return l.resources
+}
+
+// Release records the PID.
+//
+// Params:
+//   - pid: the process ID.
+//
+// Returns:
+//   - error: always nil.
+func (l *leakTestLedger) Release(pid int) error {
+	l.released = append(l.released, pid)
+	return nil
+}
+
+// leakTestExecutor records the PIDs stopped by the supervisor.
+type leakTestExecutor struct {
+	phaseTestExecutor
+	// stopped lists the stopped PIDs.
+	stopped []int
+}
+
+// Stop records the PID.
+//
+// Params:
+//   - pid: the process ID.
+//
+// Returns:
+//   - error: always nil.
+func (e *leakTestExecutor) Stop(pid int, _ time.Duration) error {
+	e.stopped = append(e.stopped, pid)
+	return nil
+}
+
+// Test_Supervisor_checkLeaks tests that unowned resources are reported once and reaped.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkLeaks(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// reap is the leak_check.reap setting.
+		reap bool
+		// wantStopped is the expected stopped PIDs.
+		wantStopped []int
+		// wantReleased is the expected released PIDs.
+		wantReleased []int
+	}{
+		{name: "report_only"},
+		{name: "reap", reap: true, wantStopped: []int{99, 99}, wantReleased: []int{77, 77}},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &domainconfig.Config{
+				LeakCheck: domainconfig.LeakCheckConfig{Reap: tt.reap},
+				Services:  []domainconfig.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			}
+			executor := &leakTestExecutor{}
+			mgr := applifecycle.NewManager(&cfg.Services[0], &phaseTestExecutor{})
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+			require.NoError(t, mgr.Start(ctx))
+			t.Cleanup(func() { _ = mgr.Stop() })
+			require.Eventually(t, func() bool { return mgr.PID() == 4242 }, time.Second, 5*time.Millisecond)
+
+			s := &Supervisor{config: cfg, executor: executor, managers: map[string]*applifecycle.Manager{"api": mgr}, ctx: ctx}
+			var reported []error
+			s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+				// Keep the leak reports.
+				if event.Type == domain.EventResourceLeaked {
+					reported = append(reported, event.Error)
+				}
+			}
+			ledger := &leakTestLedger{resources: []domain.ProcessResources{
+				{PID: 77, Service: "api", Cgroup: "/cg/svc-api"},
+				{PID: 99, Service: "old", Waiting: true},
+				{PID: 4242, Service: "api", Waiting: true},
+			}}
+
+			// The first check leaves time to starts in progress.
+			assert.Equal(t, domainconfig.DefaultLeakCheckInterval, s.checkLeaks(ledger))
+			assert.Empty(t, reported)
+
+			s.checkLeaks(ledger)
+			require.Len(t, reported, 2)
+			assert.ErrorIs(t, reported[0], ErrResourceLeaked)
+			assert.Contains(t, reported[0].Error(), `cgroup "/cg/svc-api" of exited pid 77`)
+			assert.Contains(t, reported[1].Error(), "pid 99")
+
+			// Confirmed leaks are reported once.
+			s.checkLeaks(ledger)
+			assert.Len(t, reported, 2)
+			assert.Equal(t, tt.wantStopped, executor.stopped)
+			assert.Equal(t, tt.wantReleased, ledger.released)
+		})
+	}
+}
+
+// Test_Supervisor_checkLeaks_recovered tests that resources owned again are forgotten.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkLeaks_recovered(t *testing.T) {
+	s := &Supervisor{config: &domainconfig.Config{}, managers: map[string]*applifecycle.Manager{}}
+	var reported []error
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) { reported = append(reported, event.Error) }
+	ledger := &leakTestLedger{resources: []domain.ProcessResources{{PID: 99, Service: "api", Waiting: true}}}
+
+	s.checkLeaks(ledger)
+	ledger.resources = nil
+	s.checkLeaks(ledger)
+	ledger.resources = []domain.ProcessResources{{PID: 99, Service: "api", Waiting: true}}
+	s.checkLeaks(ledger)
+
+	assert.Empty(t, reported, errors.Join(reported...))
+}
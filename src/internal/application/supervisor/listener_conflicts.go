@@ -0,0 +1,203 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file contains runtime detection of listener ports held by other processes.
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// conflictCheckInterval is the period between listener port conflict scans.
+const conflictCheckInterval time.Duration = 10 * time.Second
+
+// boundSocket is a listening or bound socket found on the host.
+type boundSocket struct {
+	// protocol is the listener protocol ("tcp" or "udp").
+	protocol string
+	// address is the bind address.
+	address string
+	// port is the bound port.
+	port int
+	// inode is the socket inode.
+	inode uint64
+}
+
+// conflictTarget captures what is needed to scan one service outside the lock.
+type conflictTarget struct {
+	// name is the service name.
+	name string
+	// pid is the service process ID, zero when not running.
+	pid int
+	// listeners are the configured listeners of the service.
+	listeners []domainconfig.ListenerConfig
+}
+
+// startConflictWatcher periodically detects listener ports bound by processes
+// outside the owning service. It is skipped when no service declares listeners.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine scanning host sockets on a ticker.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startConflictWatcher() {
+	s.mu.RLock()
+	hasListeners := slices.ContainsFunc(s.config.Services, func(svc domainconfig.ServiceConfig) bool {
+		// service declares at least one listener
+		return len(svc.Listeners) > 0
+	})
+	s.mu.RUnlock()
+
+	// Skip when there is nothing to watch.
+	if !hasListeners {
+		// No listeners configured.
+		return
+	}
+
+	// Scan until shutdown.
+	s.wg.Go(func() {
+		ticker := time.NewTicker(conflictCheckInterval)
+		defer ticker.Stop()
+		// Loop until context is cancelled.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case <-ticker.C:
+				s.checkListenerConflicts(getBoundSockets())
+			}
+		}
+	})
+}
+
+// checkListenerConflicts compares configured listeners against host sockets.
+// On a transition it updates listener health and emits an event.
+//
+// Params:
+//   - sockets: the bound sockets found on the host.
+func (s *Supervisor) checkListenerConflicts(sockets []boundSocket) {
+	targets := s.conflictTargets()
+
+	// Resolve conflicts without holding the lock (procfs I/O).
+	found := make(map[string]map[string]bool, len(targets))
+	for _, t := range targets {
+		owns := func(sock boundSocket) bool {
+			// sockets held by the service process tree are not conflicts
+			return socketOwnedBy(t.pid, sock.inode)
+		}
+		found[t.name] = findForeignListeners(t.listeners, getListeningPorts(t.pid), sockets, owns)
+	}
+
+	var events []domain.Event
+	var snaps []*ServiceStatsSnapshot
+	s.mu.Lock()
+	// Compare against the previous scan.
+	for _, t := range targets {
+		// Inspect each listener of the service.
+		for i := range t.listeners {
+			lc := &t.listeners[i]
+			conflicted := found[t.name][lc.Name]
+			// Skip listeners whose state did not change.
+			if s.conflicts[t.name][lc.Name] == conflicted {
+				continue
+			}
+			// Propagate the conflict to listener health.
+			if monitor, ok := s.healthMonitors[t.name]; ok {
+				monitor.SetListenerConflicted(lc.Name, conflicted)
+			}
+			event := domain.NewEvent(domain.EventListenerConflictCleared, t.name, t.pid, 0, nil)
+			// Attach conflict details when entering the conflicted state.
+			if conflicted {
+				event = domain.NewEvent(domain.EventListenerConflict, t.name, t.pid, 0,
+					fmt.Errorf("%w: listener %q %s/%d", ErrListenerConflict, lc.Name, lc.EffectiveProtocol(), lc.Port))
+			}
+			events = append(events, event)
+			snaps = append(snaps, s.getStatsSnapshot(s.stats[t.name]))
+		}
+	}
+	s.conflicts = found
+	s.mu.Unlock()
+
+	// Notify outside the lock.
+	for i := range events {
+		s.callEventHandler(events[i].Process, &events[i], snaps[i])
+	}
+}
+
+// conflictTargets returns the services with listeners and their current PIDs.
+//
+// Returns:
+//   - []conflictTarget: services to scan.
+func (s *Supervisor) conflictTargets() []conflictTarget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	targets := make([]conflictTarget, 0, len(s.config.Services))
+	// Collect services declaring listeners.
+	for i := range s.config.Services {
+		svc := &s.config.Services[i]
+		// Skip services without listeners.
+		if len(svc.Listeners) == 0 {
+			continue
+		}
+		t := conflictTarget{name: svc.Name, listeners: svc.Listeners}
+		// Attach the running PID when known.
+		if mgr, ok := s.managers[svc.Name]; ok {
+			t.pid = mgr.PID()
+		}
+		targets = append(targets, t)
+	}
+	// Return scan targets.
+	return targets
+}
+
+// findForeignListeners returns the listeners whose port is bound on the host
+// by a socket the service does not own.
+//
+// Params:
+//   - listeners: the configured listeners of the service.
+//   - owned: ports the service process is listening on.
+//   - sockets: the bound sockets found on the host.
+//   - owns: reports whether a socket belongs to the service process tree.
+//
+// Returns:
+//   - map[string]bool: conflicted listener names.
+func findForeignListeners(listeners []domainconfig.ListenerConfig, owned []int, sockets []boundSocket, owns func(boundSocket) bool) map[string]bool {
+	conflicted := make(map[string]bool)
+	// Check each configured listener.
+	for i := range listeners {
+		lc := &listeners[i]
+		// Skip listeners without a port or bound by the service itself.
+		if lc.Port <= 0 || slices.Contains(owned, lc.Port) {
+			continue
+		}
+		// Look for a host socket competing for the listener.
+		for _, sock := range sockets {
+			bound := domainconfig.ListenerConfig{Protocol: sock.protocol, Address: sock.address, Port: sock.port}
+			// Record the first competing socket held outside the service.
+			if lc.ConflictsWith(&bound) && !owns(sock) {
+				conflicted[lc.Name] = true
+				break
+			}
+		}
+	}
+	// Return conflicted listeners.
+	return conflicted
+}
+
+// listenerConflicted reports whether a listener was conflicted at the last scan.
+// Must be called with s.mu held.
+//
+// Params:
+//   - service: the service name.
+//   - name: the listener name.
+//
+// Returns:
+//   - bool: true if another process holds the listener port.
+func (s *Supervisor) listenerConflicted(service, name string) bool {
+	// lookup last scan result
+	return s.conflicts[service][name]
+}
@@ -0,0 +1,158 @@
+// Package supervisor provides internal tests for listener_conflicts.go.
+// It tests runtime listener port conflict detection using white-box testing.
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/listener"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_findForeignListeners tests matching configured listeners against host sockets.
+//
+// Params:
+//   - t: the testing context.
+func Test_findForeignListeners(t *testing.T) {
+	listeners := []domainconfig.ListenerConfig{
+		{Name: "http", Port: 8080},
+		{Name: "admin", Port: 9090, Address: "127.0.0.1"},
+		{Name: "dns", Port: 5353, Protocol: "udp"},
+		{Name: "owned", Port: 7070},
+		{Name: "v6", Port: 6060, Protocol: "tcp6"},
+	}
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// sockets are the host sockets.
+		sockets []boundSocket
+		// want is the expected set of conflicted listeners.
+		want map[string]bool
+	}{
+		{
+			name: "no_sockets",
+			want: map[string]bool{},
+		},
+		{
+			name: "foreign_wildcard_socket",
+			sockets: []boundSocket{
+				{protocol: "tcp", address: "::", port: 9090},
+				{protocol: "tcp", address: "0.0.0.0", port: 7070},
+			},
+			want: map[string]bool{"admin": true},
+		},
+		{
+			name: "foreign_socket_on_other_address",
+			sockets: []boundSocket{
+				{protocol: "tcp", address: "10.0.0.1", port: 9090},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "foreign_socket_other_protocol",
+			sockets: []boundSocket{
+				{protocol: "tcp", address: "0.0.0.0", port: 5353},
+				{protocol: "udp", address: "127.0.0.1", port: 8080},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "foreign_socket_other_family",
+			sockets: []boundSocket{
+				{protocol: "tcp4", address: "0.0.0.0", port: 6060},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "foreign_dual_stack_socket",
+			sockets: []boundSocket{
+				{protocol: "tcp", address: "::", port: 6060},
+			},
+			want: map[string]bool{"v6": true},
+		},
+		{
+			name: "socket_owned_by_service_tree",
+			sockets: []boundSocket{
+				{protocol: "tcp", address: "0.0.0.0", port: 8080, inode: 1},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "foreign_socket_same_protocol",
+			sockets: []boundSocket{
+				{protocol: "udp", address: "127.0.0.1", port: 5353},
+				{protocol: "tcp", address: "127.0.0.1", port: 8080},
+			},
+			want: map[string]bool{"dns": true, "http": true},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			// The service itself listens on port 7070 and owns socket inode 1.
+			owns := func(sock boundSocket) bool { return sock.inode == 1 }
+			got := findForeignListeners(listeners, []int{7070}, tt.sockets, owns)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Test_Supervisor_checkListenerConflicts tests conflict transitions, health, and events.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkListenerConflicts(t *testing.T) {
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "app", Command: "/bin/app", Listeners: []domainconfig.ListenerConfig{{Name: "http", Port: 8080}}},
+		{Name: "worker", Command: "/bin/worker"},
+	}}
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})
+	l := listener.NewListener("http", "tcp", "localhost", 8080)
+	l.MarkListening()
+	require.NoError(t, monitor.AddListener(l))
+
+	events := &[]domain.Event{}
+	s := &Supervisor{
+		config:         cfg,
+		healthMonitors: map[string]*apphealth.ProbeMonitor{"app": monitor},
+		stats:          map[string]*ServiceStats{"app": NewServiceStats()},
+		conflicts:      make(map[string]map[string]bool),
+		eventHandler: func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+			*events = append(*events, *event)
+		},
+	}
+	foreign := []boundSocket{{protocol: "tcp", address: "0.0.0.0", port: 8080}}
+
+	// No foreign socket: no transition.
+	s.checkListenerConflicts(nil)
+	assert.Empty(t, *events)
+
+	// Foreign socket: conflict event and conflicted listener health.
+	s.checkListenerConflicts(foreign)
+	require.Len(t, *events, 1)
+	assert.Equal(t, domain.EventListenerConflict, (*events)[0].Type)
+	assert.Equal(t, "app", (*events)[0].Process)
+	assert.ErrorIs(t, (*events)[0].Error, ErrListenerConflict)
+	assert.Equal(t, listener.StateConflicted, l.State)
+	assert.True(t, s.listenerConflicted("app", "http"))
+
+	// Still conflicted: no duplicate event.
+	s.checkListenerConflicts(foreign)
+	assert.Len(t, *events, 1)
+
+	// Foreign socket gone: cleared event and listener back to listening.
+	s.checkListenerConflicts(nil)
+	require.Len(t, *events, 2)
+	assert.Equal(t, domain.EventListenerConflictCleared, (*events)[1].Type)
+	assert.Equal(t, listener.StateListening, l.State)
+	assert.False(t, s.listenerConflicted("app", "http"))
+}
@@ -15,10 +15,11 @@ const (
 // This struct uses basic types to avoid import cycles with TUI packages.
 // Each listener tracks its configuration and runtime status for visualization.
 type ListenerSnapshotForTUI struct {
-	Name      string
-	Port      int
-	Protocol  string
-	Exposed   bool // Whether the port should be publicly accessible
-	Listening bool // Whether the port is actually listening
-	StatusInt int  // 0=OK (green), 1=Warning (yellow), 2=Error (red)
+	Name       string
+	Port       int
+	Protocol   string
+	Exposed    bool // Whether the port should be publicly accessible
+	Listening  bool // Whether the port is actually listening
+	Conflicted bool // Whether the port is held by a process outside the service
+	StatusInt  int  // 0=OK (green), 1=Warning (yellow), 2=Error (red)
 }
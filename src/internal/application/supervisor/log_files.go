@@ -0,0 +1,65 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file locates the files service output is written to.
+package supervisor
+
+import (
+	"fmt"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// LogFiles returns the files the output of a service is written to, in the
+// current configuration. Streams without file are left out, and a file
+// shared by both streams is listed once.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - []domainlogging.LogFile: the stdout then stderr files.
+//   - error: ErrServiceNotFound if the service does not exist.
+func (s *Supervisor) LogFiles(name string) ([]domainlogging.LogFile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var svc *domainconfig.ServiceConfig
+	// look the service up when a configuration is loaded
+	if s.config != nil {
+		svc = s.config.FindService(name)
+	}
+	// validate service exists
+	if svc == nil {
+		// Return error for missing service.
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+
+	streams := []struct {
+		// name is the output stream name.
+		name string
+		// cfg is the stream logging configuration.
+		cfg *domainconfig.LogStreamConfig
+	}{
+		{domainlogging.StreamStdout, &svc.Logging.Stdout},
+		{domainlogging.StreamStderr, &svc.Logging.Stderr},
+	}
+	var files []domainlogging.LogFile
+	// resolve the file of each stream
+	for _, stream := range streams {
+		// stream passed through to the daemon output
+		if stream.cfg.File() == "" {
+			continue
+		}
+		path := s.config.GetServiceLogPath(name, stream.cfg.File())
+		// stderr written to the stdout file
+		if len(files) > 0 && files[0].Path == path {
+			continue
+		}
+		files = append(files, domainlogging.LogFile{
+			Path:            path,
+			Stream:          stream.name,
+			TimestampFormat: svc.Logging.TimestampFormat(stream.cfg),
+		})
+	}
+	// return resolved files
+	return files, nil
+}
@@ -0,0 +1,85 @@
+// Package supervisor provides internal tests for log_files.go.
+// It tests the location of service log files using white-box testing.
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// Test_Supervisor_LogFiles tests that the stdout and stderr files of a
+// service are resolved under the log directory.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_LogFiles(t *testing.T) {
+	s := &Supervisor{config: &domainconfig.Config{
+		Logging: domainconfig.LoggingConfig{BaseDir: "/var/log/supervizio"},
+		Services: []domainconfig.ServiceConfig{
+			{Name: "api", Logging: domainconfig.ServiceLogging{
+				Stdout: domainconfig.LogStreamConfig{FilePath: "out.log", Format: "iso8601"},
+				Stderr: domainconfig.LogStreamConfig{FilePath: "err.log"},
+			}},
+			{Name: "web", Logging: domainconfig.ServiceLogging{
+				Stdout: domainconfig.LogStreamConfig{FilePath: "web.log"},
+				Stderr: domainconfig.LogStreamConfig{FilePath: "web.log"},
+			}},
+			{Name: "worker"},
+			{Name: "cron", Logging: domainconfig.ServiceLogging{
+				Stdout:              domainconfig.LogStreamConfig{FilePath: "cron.log", Format: "iso8601"},
+				NormalizeTimestamps: true,
+			}},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		service  string
+		expected []domainlogging.LogFile
+	}{
+		{
+			name:    "both streams",
+			service: "api",
+			expected: []domainlogging.LogFile{
+				{Path: "/var/log/supervizio/api/out.log", Stream: domainlogging.StreamStdout, TimestampFormat: "iso8601"},
+				{Path: "/var/log/supervizio/api/err.log", Stream: domainlogging.StreamStderr},
+			},
+		},
+		{
+			name:    "shared file",
+			service: "web",
+			expected: []domainlogging.LogFile{
+				{Path: "/var/log/supervizio/web/web.log", Stream: domainlogging.StreamStdout},
+			},
+		},
+		{
+			name:    "normalized timestamps",
+			service: "cron",
+			expected: []domainlogging.LogFile{
+				{Path: "/var/log/supervizio/cron/cron.log", Stream: domainlogging.StreamStdout, TimestampFormat: domainconfig.NormalizedTimestampFormat},
+			},
+		},
+		{
+			name:    "passthrough streams",
+			service: "worker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := s.LogFiles(tt.service)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, files)
+		})
+	}
+
+	_, err := s.LogFiles("db")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+	_, err = (&Supervisor{}).LogFiles("api")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
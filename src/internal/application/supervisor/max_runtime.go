@@ -0,0 +1,162 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file enforces the max runtime of services.
+package supervisor
+
+import (
+	"fmt"
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// runtimeLimit is the pending max runtime of one launched process.
+type runtimeLimit struct {
+	// pid is the process the limit was armed for.
+	pid int
+	// warning fires when the process approaches the limit.
+	warning shared.Timer
+	// expiry fires when the process reaches the limit.
+	expiry shared.Timer
+}
+
+// updateRuntimeLimit arms or clears the max runtime of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) updateRuntimeLimit(name string, event *domain.Event) {
+	// arm or clear based on event
+	switch event.Type {
+	// process launched: runtime clock starts
+	case domain.EventStarted:
+		s.armRuntimeLimit(name, event.PID)
+	// process gone: nothing left to limit
+	case domain.EventStopped, domain.EventFailed, domain.EventExhausted:
+		s.clearRuntimeLimit(name)
+	// No limit change for these events.
+	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy,
+		domain.EventThrottled, domain.EventUnthrottled, domain.EventPressureAlert, domain.EventPressureCleared,
+		domain.EventListenerConflict, domain.EventListenerConflictCleared, domain.EventStartTimeout,
+		domain.EventDependencyDown, domain.EventDependencyUp, domain.EventIncident, domain.EventFileChanged, domain.EventSecretChanged,
+		domain.EventProbesPaused, domain.EventProbesResumed,
+		domain.EventQuiesced, domain.EventUnquiesced, domain.EventQuiesceFailed, domain.EventSafeModeEntered, domain.EventSafeModeExited, domain.EventConfigReloaded,
+		domain.EventReloaded, domain.EventReloadFailed,
+		domain.EventSLOBurn, domain.EventSLOBurnCleared, domain.EventOvercommitted, domain.EventShed, domain.EventShedResumed,
+		domain.EventResourceLeaked, domain.EventRuntimeWarning, domain.EventRuntimeExceeded, domain.EventRestartDeferred,
+		domain.EventCertificateExpiring, domain.EventCertificateChanged,
+		domain.EventClockJump, domain.EventCustom, domain.EventSkipped:
+		// No limit change needed.
+	default:
+		// Unknown event type, ignore.
+	}
+}
+
+// armRuntimeLimit starts the max runtime of a launched process.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the launched process ID.
+func (s *Supervisor) armRuntimeLimit(name string, pid int) {
+	s.clearRuntimeLimit(name)
+
+	// Skip when no configuration is loaded.
+	if s.config == nil {
+		// No limit.
+		return
+	}
+	svc := s.config.FindService(name)
+	// Skip unknown services, disabled limits and unknown processes.
+	if svc == nil || svc.MaxRuntime <= 0 || pid <= 0 {
+		// No limit.
+		return
+	}
+
+	// create limit map on first use
+	if s.runtimeLimits == nil {
+		s.runtimeLimits = make(map[string]*runtimeLimit)
+	}
+	limit, warning := svc.MaxRuntime.Duration(), svc.MaxRuntimeWarning()
+	s.runtimeLimits[name] = &runtimeLimit{
+		pid:     pid,
+		warning: s.clock.AfterFunc(warning, func() { s.warnRuntime(name, pid, limit-warning) }),
+		expiry:  s.clock.AfterFunc(limit, func() { s.expireRuntime(name, pid) }),
+	}
+}
+
+// clearRuntimeLimit cancels the max runtime of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) clearRuntimeLimit(name string) {
+	limit, ok := s.runtimeLimits[name]
+	// Skip when no limit is pending.
+	if !ok {
+		// Nothing to cancel.
+		return
+	}
+	limit.warning.Stop()
+	limit.expiry.Stop()
+	delete(s.runtimeLimits, name)
+}
+
+// clearRuntimeLimits cancels every pending max runtime.
+// Must be called with s.mu held.
+func (s *Supervisor) clearRuntimeLimits() {
+	// cancel each pending limit
+	for name := range s.runtimeLimits {
+		s.clearRuntimeLimit(name)
+	}
+}
+
+// warnRuntime reports a process approaching its max runtime.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the process ID the limit was armed for.
+//   - left: the time left before the process is stopped.
+func (s *Supervisor) warnRuntime(name string, pid int, left time.Duration) {
+	s.mu.RLock()
+	current, ok := s.runtimeLimits[name]
+	s.mu.RUnlock()
+	// Skip limits cleared or re-armed since the timer fired.
+	if !ok || current.pid != pid {
+		// Stale limit.
+		return
+	}
+	event := domain.NewEvent(domain.EventRuntimeWarning, name, pid, 0, fmt.Errorf("stopping in %s: %w", left, domain.ErrMaxRuntime))
+	s.handleEvent(name, &event)
+}
+
+// expireRuntime stops a process that reached its max runtime.
+// Stop failures are reported through the error handler.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the process ID the limit was armed for.
+func (s *Supervisor) expireRuntime(name string, pid int) {
+	s.mu.Lock()
+	limit, ok := s.runtimeLimits[name]
+	// Skip limits cleared or re-armed since the timer fired.
+	if !ok || limit.pid != pid {
+		s.mu.Unlock()
+		// Stale limit.
+		return
+	}
+	delete(s.runtimeLimits, name)
+	mgr, found := s.managers[name]
+	s.mu.Unlock()
+
+	// Skip services removed by a reload.
+	if !found {
+		// Nothing to stop.
+		return
+	}
+	// Report stop failure (the process may have exited meanwhile).
+	if err := mgr.ExpireRuntime(pid); err != nil {
+		s.handleRecoveryError("max-runtime", name, err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package supervisor provides internal tests for max_runtime.go.
+// It tests max runtimes using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// runtimeTestExecutor starts processes that never exit and records stops.
+type runtimeTestExecutor struct {
+	proxyTestExecutor
+	// mu protects stops.
+	mu sync.Mutex
+	// stops records the stopped pids.
+	stops []int
+}
+
+// Stop records the stopped pid.
+//
+// Params:
+//   - pid: the stopped pid.
+//
+// Returns:
+//   - error: always nil.
+func (e *runtimeTestExecutor) Stop(pid int, _ time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stops = append(e.stops, pid)
+	return nil
+}
+
+// stopped returns the stopped pids.
+//
+// Returns:
+//   - []int: the recorded pids.
+func (e *runtimeTestExecutor) stopped() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]int(nil), e.stops...)
+}
+
+// newRuntimeTestSupervisor builds a supervisor with one running service.
+//
+// Params:
+//   - t: the testing context.
+//   - limit: the service max runtime.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+//   - *applifecycle.Manager: the service manager.
+//   - *runtimeTestExecutor: the executor.
+//   - *shared.FakeClock: the clock of the limits.
+func newRuntimeTestSupervisor(t *testing.T, limit time.Duration) (*Supervisor, *applifecycle.Manager, *runtimeTestExecutor, *shared.FakeClock) {
+	t.Helper()
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "batch", Command: "/bin/batch", MaxRuntime: shared.Duration(limit)},
+	}}
+	executor := &runtimeTestExecutor{}
+	mgr := applifecycle.NewManager(&cfg.Services[0], executor)
+	require.NoError(t, mgr.Start(context.Background()))
+	t.Cleanup(func() { _ = mgr.Stop() })
+	require.Eventually(t, func() bool { return mgr.PID() != 0 }, time.Second, 10*time.Millisecond)
+
+	clock := shared.NewFakeClock(time.Now())
+	s := &Supervisor{
+		config:   cfg,
+		clock:    clock,
+		managers: map[string]*applifecycle.Manager{"batch": mgr},
+		stats:    make(map[string]*ServiceStats),
+	}
+	return s, mgr, executor, clock
+}
+
+// Test_Supervisor_runtimeLimit_Expires tests that a process is warned, then stopped.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_runtimeLimit_Expires(t *testing.T) {
+	s, mgr, executor, clock := newRuntimeTestSupervisor(t, 50*time.Second)
+	var mu sync.Mutex
+	var warnings []*domain.Event
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		mu.Lock()
+		defer mu.Unlock()
+		warnings = append(warnings, event)
+	}
+
+	s.mu.Lock()
+	s.updateRuntimeLimit("batch", &domain.Event{Type: domain.EventStarted, PID: 4242})
+	s.mu.Unlock()
+	clock.Advance(50 * time.Second)
+
+	require.Eventually(t, func() bool { return len(executor.stopped()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int{4242}, executor.stopped())
+
+	// The warning precedes the stop.
+	mu.Lock()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, domain.EventRuntimeWarning, warnings[0].Type)
+	assert.ErrorIs(t, warnings[0].Error, domain.ErrMaxRuntime)
+	assert.Contains(t, warnings[0].Error.Error(), "stopping in 5s")
+	mu.Unlock()
+
+	// The stop is announced by the manager.
+	var exceeded *domain.Event
+	require.Eventually(t, func() bool {
+		select {
+		case event := <-mgr.Events():
+			if event.Type == domain.EventRuntimeExceeded {
+				exceeded = &event
+			}
+		default:
+		}
+		return exceeded != nil
+	}, time.Second, 5*time.Millisecond)
+	assert.ErrorIs(t, exceeded.Error, domain.ErrMaxRuntime)
+}
+
+// Test_Supervisor_runtimeLimit_Cleared tests that exited or unlimited processes are not stopped.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_runtimeLimit_Cleared(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// limit is the service max runtime.
+		limit time.Duration
+		// exit is the event ending the process, nil to keep it running.
+		exit *domain.Event
+	}{
+		{name: "exited", limit: 20 * time.Second, exit: &domain.Event{Type: domain.EventStopped}},
+		{name: "restarted", limit: 20 * time.Second, exit: &domain.Event{Type: domain.EventFailed}},
+		{name: "no limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, executor, clock := newRuntimeTestSupervisor(t, tt.limit)
+
+			s.mu.Lock()
+			s.updateRuntimeLimit("batch", &domain.Event{Type: domain.EventStarted, PID: 4242})
+			// End the process before its limit.
+			if tt.exit != nil {
+				s.updateRuntimeLimit("batch", tt.exit)
+			}
+			s.mu.Unlock()
+
+			clock.Advance(time.Minute)
+			assert.Empty(t, executor.stopped())
+			assert.Empty(t, s.runtimeLimits)
+		})
+	}
+}
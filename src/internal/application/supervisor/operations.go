@@ -0,0 +1,325 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file implements the registry of asynchronous lifecycle operations.
+package supervisor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// operationRetention is how long completed operations stay queryable.
+const operationRetention time.Duration = 15 * time.Minute
+
+// operationIDBytes is the number of random bytes in generated operation IDs.
+const operationIDBytes int = 8
+
+// OperationKind identifies the lifecycle action of an operation.
+type OperationKind string
+
+// Operation kinds.
+const (
+	// OperationStart starts a service.
+	OperationStart OperationKind = "start"
+	// OperationStop stops a service.
+	OperationStop OperationKind = "stop"
+	// OperationRestart restarts a service.
+	OperationRestart OperationKind = "restart"
+	// OperationReload reloads a service in place.
+	OperationReload OperationKind = "reload"
+)
+
+// OperationStatus is the progress of an operation.
+type OperationStatus string
+
+// Operation statuses.
+const (
+	// OperationRunning indicates the operation is in progress.
+	OperationRunning OperationStatus = "running"
+	// OperationSucceeded indicates the operation completed without error.
+	OperationSucceeded OperationStatus = "succeeded"
+	// OperationFailed indicates the operation completed with an error.
+	OperationFailed OperationStatus = "failed"
+)
+
+// Errors for operation submission.
+var (
+	// ErrOperationConflict is returned when an operation ID is reused for a different action.
+	ErrOperationConflict error = shared.NewCodedError(shared.CodeOperationConflict, "operation id already used")
+	// ErrUnknownOperation is returned for an unsupported operation kind.
+	ErrUnknownOperation error = shared.NewCodedError(shared.CodeInvalidArgument, "unknown operation kind")
+)
+
+// Operation is the handle of an asynchronous lifecycle operation.
+// Submitting the same operation ID again returns the same handle, so a
+// client retrying a request does not run the action twice.
+type Operation struct {
+	// ID is the operation identifier, supplied by the client or generated.
+	ID string
+	// Kind is the lifecycle action.
+	Kind OperationKind
+	// Service is the target service name.
+	Service string
+	// Submitted is when the operation was accepted.
+	Submitted time.Time
+
+	// mu protects the completion fields.
+	mu sync.RWMutex
+	// done is closed when the operation completes.
+	done chan struct{}
+	// completed is when the operation finished; zero while running.
+	completed time.Time
+	// err is the operation result.
+	err error
+	// snapshot is the state of the service once the operation completed.
+	snapshot domain.ServiceSnapshot
+}
+
+// Done returns a channel closed when the operation completes.
+//
+// Returns:
+//   - <-chan struct{}: the completion channel.
+func (o *Operation) Done() <-chan struct{} {
+	// expose completion channel
+	return o.done
+}
+
+// Wait blocks until the operation completes or the context is done.
+//
+// Params:
+//   - ctx: the context bounding the wait.
+//
+// Returns:
+//   - error: the operation result, or the context error if the wait was abandoned.
+func (o *Operation) Wait(ctx context.Context) error {
+	select {
+	// operation finished
+	case <-o.done:
+		// return operation result
+		return o.Err()
+	// caller gave up, the operation keeps running
+	case <-ctx.Done():
+		// return context error
+		return ctx.Err()
+	}
+}
+
+// Status returns the progress of the operation.
+//
+// Returns:
+//   - OperationStatus: running, succeeded, or failed.
+func (o *Operation) Status() OperationStatus {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	// still in progress
+	if o.completed.IsZero() {
+		// running
+		return OperationRunning
+	}
+	// completed with error
+	if o.err != nil {
+		// failed
+		return OperationFailed
+	}
+	// completed without error
+	return OperationSucceeded
+}
+
+// Err returns the operation result.
+//
+// Returns:
+//   - error: the error of a failed operation, nil otherwise.
+func (o *Operation) Err() error {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	// return result
+	return o.err
+}
+
+// Completed returns when the operation finished.
+//
+// Returns:
+//   - time.Time: the completion time, zero while running.
+func (o *Operation) Completed() time.Time {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	// return completion time
+	return o.completed
+}
+
+// Snapshot returns the state of the service once the operation completed.
+// It reflects the events of the operation: a start or restart reports the
+// launched process, or its failure.
+//
+// Returns:
+//   - domain.ServiceSnapshot: the snapshot, zero while running.
+func (o *Operation) Snapshot() domain.ServiceSnapshot {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	// return snapshot
+	return o.snapshot
+}
+
+// finish records the result and releases waiters.
+//
+// Params:
+//   - err: the operation result.
+//   - snapshot: the state of the service after the operation.
+func (o *Operation) finish(err error, snapshot domain.ServiceSnapshot) {
+	o.mu.Lock()
+	o.err = err
+	o.snapshot = snapshot
+	o.completed = time.Now()
+	o.mu.Unlock()
+	close(o.done)
+}
+
+// Submit starts a lifecycle operation in the background and returns its handle.
+// When id matches a known operation for the same action and service, that
+// operation is returned instead of starting a new one. An empty id generates one.
+//
+// Params:
+//   - kind: the lifecycle action.
+//   - service: the target service name.
+//   - id: the optional client-supplied operation ID.
+//
+// Returns:
+//   - *Operation: the new or existing operation handle.
+//   - error: if the kind is unknown, the service is not found, or the ID is used by another action.
+func (s *Supervisor) Submit(kind OperationKind, service, id string) (*Operation, error) {
+	run, err := s.operationFunc(kind)
+	// reject unsupported actions
+	if err != nil {
+		// propagate kind error
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.pruneOperations(time.Now())
+	// return existing operation for retried requests
+	if existing, ok := s.operations[id]; ok && id != "" {
+		s.mu.Unlock()
+		// same ID must describe the same action
+		if existing.Kind != kind || existing.Service != service {
+			// reject conflicting reuse
+			return nil, fmt.Errorf("%w: %s", ErrOperationConflict, id)
+		}
+		// idempotent retry
+		return existing, nil
+	}
+	mgr, ok := s.managers[service]
+	// validate service exists
+	if !ok {
+		s.mu.Unlock()
+		// Return error for missing service.
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, service)
+	}
+	before := mgr.EventSeq()
+	// generate an ID for anonymous operations
+	if id == "" {
+		id = newOperationID()
+	}
+	op := &Operation{
+		ID:        id,
+		Kind:      kind,
+		Service:   service,
+		Submitted: time.Now(),
+		done:      make(chan struct{}),
+	}
+	// lazily create the registry
+	if s.operations == nil {
+		s.operations = make(map[string]*Operation)
+	}
+	s.operations[id] = op
+	s.mu.Unlock()
+
+	// run the action outside the lock, then wait for its events
+	go func() {
+		err := run(service)
+		op.finish(err, s.settleOperation(op, before, err))
+	}()
+	// return new operation
+	return op, nil
+}
+
+// Operation returns a known operation by ID.
+//
+// Params:
+//   - id: the operation ID.
+//
+// Returns:
+//   - *Operation: the operation if found.
+//   - bool: true if the operation is known.
+func (s *Supervisor) Operation(id string) (*Operation, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	op, ok := s.operations[id]
+	// return operation and existence flag
+	return op, ok
+}
+
+// operationFunc returns the supervisor method running an operation kind.
+//
+// Params:
+//   - kind: the lifecycle action.
+//
+// Returns:
+//   - func(string) error: the method to run.
+//   - error: ErrUnknownOperation for unsupported kinds.
+func (s *Supervisor) operationFunc(kind OperationKind) (func(string) error, error) {
+	// select lifecycle method
+	switch kind {
+	// start action
+	case OperationStart:
+		// start method
+		return s.StartService, nil
+	// stop action
+	case OperationStop:
+		// stop method
+		return s.StopService, nil
+	// restart action
+	case OperationRestart:
+		// restart method
+		return s.RestartService, nil
+	// in-place reload action
+	case OperationReload:
+		// reload method
+		return s.ReloadService, nil
+	}
+	// unsupported action
+	return nil, fmt.Errorf("%w: %s", ErrUnknownOperation, kind)
+}
+
+// pruneOperations forgets operations completed longer than the retention ago.
+// Must be called with s.mu held.
+//
+// Params:
+//   - now: the current time.
+func (s *Supervisor) pruneOperations(now time.Time) {
+	// Drop expired completed operations.
+	for id, op := range s.operations {
+		completed := op.Completed()
+		// keep running and recent operations
+		if !completed.IsZero() && now.Sub(completed) > operationRetention {
+			delete(s.operations, id)
+		}
+	}
+}
+
+// newOperationID generates a random operation ID.
+//
+// Returns:
+//   - string: the generated ID.
+func newOperationID() string {
+	buf := make([]byte, operationIDBytes)
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(buf)
+	// hex-encode with prefix
+	return "op-" + hex.EncodeToString(buf)
+}
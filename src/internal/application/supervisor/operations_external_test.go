@@ -0,0 +1,130 @@
+// Package supervisor_test provides black-box tests for operations.go.
+// It tests asynchronous lifecycle operations and their idempotency.
+package supervisor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/supervisor"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestSupervisor_Submit tests submitting lifecycle operations.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_Submit(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// kind is the operation kind.
+		kind supervisor.OperationKind
+		// service is the target service.
+		service string
+		// errIs is the expected sentinel error, nil on success.
+		errIs error
+	}{
+		{
+			name:    "start_succeeds",
+			kind:    supervisor.OperationStart,
+			service: "test-service",
+		},
+		{
+			name:    "restart_succeeds",
+			kind:    supervisor.OperationRestart,
+			service: "test-service",
+		},
+		{
+			name:    "unknown_service_is_rejected",
+			kind:    supervisor.OperationStart,
+			service: "nonexistent",
+			errIs:   supervisor.ErrServiceNotFound,
+		},
+		{
+			name:    "unknown_kind_is_rejected",
+			kind:    supervisor.OperationKind("reboot"),
+			service: "test-service",
+			errIs:   supervisor.ErrUnknownOperation,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createValidConfig()
+			sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+			require.NoError(t, err)
+
+			op, err := sup.Submit(tt.kind, tt.service, "")
+
+			// Check rejected submissions.
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.Nil(t, op)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, op.ID)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			require.NoError(t, op.Wait(ctx))
+			assert.Equal(t, supervisor.OperationSucceeded, op.Status())
+			assert.False(t, op.Completed().IsZero())
+
+			// Completed operations stay queryable.
+			found, ok := sup.Operation(op.ID)
+			assert.True(t, ok)
+			assert.Same(t, op, found)
+		})
+	}
+}
+
+// TestSupervisor_Submit_Idempotent tests that a retried operation ID returns
+// the original operation instead of running the action again.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_Submit_Idempotent(t *testing.T) {
+	cfg := createValidConfig()
+	sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+	require.NoError(t, err)
+
+	first, err := sup.Submit(supervisor.OperationRestart, "test-service", "deploy-42")
+	require.NoError(t, err)
+	second, err := sup.Submit(supervisor.OperationRestart, "test-service", "deploy-42")
+	require.NoError(t, err)
+
+	// The retry gets the same handle.
+	assert.Same(t, first, second)
+	assert.Equal(t, "deploy-42", first.ID)
+
+	// Reusing the ID for another action is a conflict.
+	_, err = sup.Submit(supervisor.OperationStop, "test-service", "deploy-42")
+	require.ErrorIs(t, err, supervisor.ErrOperationConflict)
+	assert.Equal(t, shared.CodeOperationConflict, shared.CodeOf(err))
+
+	<-first.Done()
+	assert.NoError(t, first.Err())
+}
+
+// TestSupervisor_Operation_Unknown tests looking up an unknown operation.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_Operation_Unknown(t *testing.T) {
+	cfg := createValidConfig()
+	sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+	require.NoError(t, err)
+
+	op, ok := sup.Operation("missing")
+
+	assert.False(t, ok)
+	assert.Nil(t, op)
+}
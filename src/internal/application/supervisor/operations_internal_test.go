@@ -0,0 +1,58 @@
+// Package supervisor provides internal tests for operations.go.
+// It tests operation retention using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_Supervisor_pruneOperations tests that only expired completed operations are forgotten.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_pruneOperations(t *testing.T) {
+	old := &Operation{ID: "old", done: make(chan struct{})}
+	old.finish(nil, domain.ServiceSnapshot{})
+	recent := &Operation{ID: "recent", done: make(chan struct{})}
+	recent.finish(nil, domain.ServiceSnapshot{})
+	running := &Operation{ID: "running", done: make(chan struct{})}
+	s := &Supervisor{operations: map[string]*Operation{"old": old, "recent": recent, "running": running}}
+
+	old.completed = time.Now().Add(-2 * operationRetention)
+	s.pruneOperations(time.Now())
+
+	assert.NotContains(t, s.operations, "old")
+	assert.Contains(t, s.operations, "recent")
+	assert.Contains(t, s.operations, "running")
+}
+
+// Test_Operation_Wait tests the status of a failed operation and abandoned waits.
+//
+// Params:
+//   - t: the testing context.
+func Test_Operation_Wait(t *testing.T) {
+	op := &Operation{done: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Abandoned waits return the context error.
+	require.ErrorIs(t, op.Wait(ctx), context.Canceled)
+	assert.Equal(t, OperationRunning, op.Status())
+
+	failure := errors.New("boom")
+	snapshot := domain.ServiceSnapshot{Name: "web", State: domain.StateFailed, OperationID: "op-1"}
+	op.finish(failure, snapshot)
+
+	assert.ErrorIs(t, op.Wait(context.Background()), failure)
+	assert.Equal(t, OperationFailed, op.Status())
+	assert.Equal(t, snapshot, op.Snapshot())
+}
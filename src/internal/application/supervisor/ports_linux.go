@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/hex"
 	"maps"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -69,8 +70,29 @@ const (
 
 	// bitSize64 is the bit size for 64-bit integers.
 	bitSize64 int = 64
+
+	// ipWordLen is the number of bytes in each host-endian word of a /proc/net address.
+	ipWordLen int = 4
 )
 
+// netFile describes a /proc/net socket table and the listener protocol it holds.
+type netFile struct {
+	// path is the socket table path.
+	path string
+	// protocol is the listener protocol of sockets in the table.
+	protocol string
+}
+
+// netFiles lists the socket tables scanned for bound ports.
+// IPv4 tables only hold IPv4 sockets; IPv6 tables may hold dual-stack
+// sockets, so their protocol carries no family restriction.
+var netFiles []netFile = []netFile{
+	{path: "/proc/net/tcp", protocol: "tcp4"},
+	{path: "/proc/net/tcp6", protocol: "tcp"},
+	{path: "/proc/net/udp", protocol: "udp4"},
+	{path: "/proc/net/udp6", protocol: "udp"},
+}
+
 // getListeningPorts returns TCP/UDP ports the process is listening on.
 // Reads from /proc/net/tcp, /proc/net/tcp6, /proc/net/udp, /proc/net/udp6.
 // Also detects Docker container port mappings.
@@ -110,12 +132,10 @@ func getListeningPorts(pid int) []int {
 		return nil
 	}
 
-	// Scan /proc/net/* files for listening ports.
-	netFiles := []string{"/proc/net/tcp", "/proc/net/tcp6", "/proc/net/udp", "/proc/net/udp6"}
 	// Check each protocol file for listening ports.
-	for _, netFile := range netFiles {
+	for _, nf := range netFiles {
 		// find ports in this network file
-		findListeningPorts(netFile, inodes, ports)
+		findListeningPorts(nf.path, inodes, ports)
 	}
 
 	// Convert map to sorted slice.
@@ -626,3 +646,194 @@ func parseHexPort(localAddr string) (int, bool) {
 	// Return parsed port.
 	return port, true
 }
+
+// getBoundSockets returns every listening TCP socket and bound UDP socket on the host.
+//
+// Returns:
+//   - []boundSocket: sockets found in /proc/net/*.
+func getBoundSockets() []boundSocket {
+	var sockets []boundSocket
+	// Collect sockets from each protocol file.
+	for _, nf := range netFiles {
+		sockets = append(sockets, readBoundSockets(nf)...)
+	}
+	// Return collected sockets.
+	return sockets
+}
+
+// readBoundSockets reads the bound sockets of a /proc/net/* file.
+//
+// Params:
+//   - nf: the socket table to read.
+//
+// Returns:
+//   - []boundSocket: sockets in listening or bound state.
+func readBoundSockets(nf netFile) []boundSocket {
+	file, err := os.Open(nf.path)
+	// Failed to open file.
+	if err != nil {
+		// Unable to open netfile.
+		return nil
+	}
+	defer func() { _ = file.Close() }()
+
+	isUDP := strings.HasPrefix(nf.protocol, "udp")
+	scanner := bufio.NewScanner(file)
+
+	// Skip header line.
+	if !scanner.Scan() {
+		// No header found.
+		return nil
+	}
+
+	var sockets []boundSocket
+	// Parse each connection line.
+	for scanner.Scan() {
+		// Keep sockets in bound state.
+		if sock, ok := parseBoundSocket(scanner.Text(), nf.protocol, isUDP); ok {
+			sockets = append(sockets, sock)
+		}
+	}
+	// Return parsed sockets.
+	return sockets
+}
+
+// parseBoundSocket parses a /proc/net/* line into a bound socket.
+//
+// Params:
+//   - line: line from /proc/net/* file
+//   - protocol: listener protocol of the table
+//   - isUDP: true if parsing UDP file
+//
+// Returns:
+//   - boundSocket: the parsed socket
+//   - bool: true if the line is a listening or bound socket
+func parseBoundSocket(line, protocol string, isUDP bool) (boundSocket, bool) {
+	fields := strings.Fields(line)
+	// Not enough fields.
+	if len(fields) < minNetFields {
+		// Insufficient fields.
+		return boundSocket{}, false
+	}
+
+	state := fields[netFieldState]
+	// Keep only TCP LISTEN or UDP bound sockets.
+	if (!isUDP && state != tcpListenState) || (isUDP && state != udpBoundState) {
+		// Wrong socket state.
+		return boundSocket{}, false
+	}
+
+	inode, err := strconv.ParseUint(fields[netFieldInode], decimalBase, bitSize64)
+	// Failed to parse inode.
+	if err != nil {
+		// Malformed inode.
+		return boundSocket{}, false
+	}
+
+	hexIP, _, _ := strings.Cut(fields[1], ":")
+	address, ok := parseHexIP(hexIP)
+	// Failed to decode address.
+	if !ok {
+		// Malformed address.
+		return boundSocket{}, false
+	}
+
+	port, ok := parseHexPort(fields[1])
+	// Failed to decode port.
+	if !ok || port == 0 {
+		// Malformed or unbound port.
+		return boundSocket{}, false
+	}
+
+	// Return parsed socket.
+	return boundSocket{protocol: protocol, address: address, port: port, inode: inode}, true
+}
+
+// parseHexIP decodes a /proc/net/* address stored as host-endian 32-bit words.
+//
+// Params:
+//   - hexIP: hex encoded address (8 chars for IPv4, 32 for IPv6)
+//
+// Returns:
+//   - string: the textual IP address
+//   - bool: true if successfully decoded
+func parseHexIP(hexIP string) (string, bool) {
+	raw, err := hex.DecodeString(hexIP)
+	// Failed to decode or unexpected length.
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		// Invalid address.
+		return "", false
+	}
+
+	// Reverse each little-endian word into network order.
+	ip := make(net.IP, len(raw))
+	for w := 0; w < len(raw); w += ipWordLen {
+		// Copy word bytes in reverse.
+		for b := range ipWordLen {
+			ip[w+b] = raw[w+ipWordLen-1-b]
+		}
+	}
+
+	// Return textual form.
+	return ip.String(), true
+}
+
+// socketOwnedBy reports whether a socket is held by a process or one of its descendants.
+//
+// Params:
+//   - pid: root process ID of the service
+//   - inode: socket inode to look up
+//
+// Returns:
+//   - bool: true if the socket belongs to the process tree
+func socketOwnedBy(pid int, inode uint64) bool {
+	// Stopped services own nothing.
+	if pid <= 0 {
+		// No process tree.
+		return false
+	}
+
+	owner, ok := findSocketOwner(inode)
+	// Socket owner could not be resolved.
+	if !ok {
+		// Treat as foreign.
+		return false
+	}
+
+	// Owned by the process itself or a descendant.
+	return owner == pid || isAncestor(pid, owner)
+}
+
+// findSocketOwner scans /proc/{pid}/fd for the process holding a socket inode.
+//
+// Params:
+//   - inode: socket inode to look up
+//
+// Returns:
+//   - int: PID of the owning process
+//   - bool: true if an owner was found
+func findSocketOwner(inode uint64) (int, bool) {
+	entries, err := os.ReadDir(procfsPath)
+	// Failed to read procfs.
+	if err != nil {
+		// Unable to scan processes.
+		return 0, false
+	}
+
+	// Check each process directory.
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		// Skip non-process entries.
+		if err != nil {
+			continue
+		}
+		// Match socket inode.
+		if _, ok := getSocketInodes(pid)[inode]; ok {
+			// Owner found.
+			return pid, true
+		}
+	}
+
+	// No owner visible.
+	return 0, false
+}
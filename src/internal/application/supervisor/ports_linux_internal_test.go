@@ -782,3 +782,79 @@ func Test_matchesContainerPID(t *testing.T) {
 		})
 	}
 }
+
+// Test_parseBoundSocket tests parsing /proc/net/* lines into bound sockets.
+//
+// Params:
+//   - t: the testing context.
+func Test_parseBoundSocket(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// line is the /proc/net/* line to parse.
+		line string
+		// protocol is the table protocol.
+		protocol string
+		// isUDP indicates if parsing UDP file.
+		isUDP bool
+		// expected is the expected socket.
+		expected boundSocket
+		// expectedOK indicates if parsing should succeed.
+		expectedOK bool
+	}{
+		{
+			name:       "tcp4_listen_loopback",
+			line:       "  0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1",
+			protocol:   "tcp",
+			expected:   boundSocket{protocol: "tcp", address: "127.0.0.1", port: 8080, inode: 12345},
+			expectedOK: true,
+		},
+		{
+			name:       "tcp6_listen_wildcard",
+			line:       "  0: 00000000000000000000000000000000:0050 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 777 1",
+			protocol:   "tcp",
+			expected:   boundSocket{protocol: "tcp", address: "::", port: 80, inode: 777},
+			expectedOK: true,
+		},
+		{
+			name:       "udp_bound",
+			line:       "  0: 00000000:14E9 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 42 2",
+			protocol:   "udp",
+			isUDP:      true,
+			expected:   boundSocket{protocol: "udp", address: "0.0.0.0", port: 5353, inode: 42},
+			expectedOK: true,
+		},
+		{
+			name:     "tcp_established_ignored",
+			line:     "  0: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000     0        0 12345 1",
+			protocol: "tcp",
+		},
+		{
+			name:     "malformed_address",
+			line:     "  0: XYZ:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1",
+			protocol: "tcp",
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			sock, ok := parseBoundSocket(tt.line, tt.protocol, tt.isUDP)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expected, sock)
+		})
+	}
+}
+
+// Test_socketOwnedBy tests socket ownership resolution.
+//
+// Params:
+//   - t: the testing context.
+func Test_socketOwnedBy(t *testing.T) {
+	// Stopped services own no sockets.
+	assert.False(t, socketOwnedBy(0, 12345))
+	// Unknown inodes are never owned.
+	assert.False(t, socketOwnedBy(os.Getpid(), ^uint64(0)))
+}
@@ -7,3 +7,15 @@ package supervisor
 func getListeningPorts(_ int) []int {
 	return nil
 }
+
+// getBoundSockets returns every bound socket on the host.
+// Not implemented on non-Linux platforms.
+func getBoundSockets() []boundSocket {
+	return nil
+}
+
+// socketOwnedBy reports whether a socket is held by a process tree.
+// Not implemented on non-Linux platforms.
+func socketOwnedBy(_ int, _ uint64) bool {
+	return false
+}
@@ -0,0 +1,90 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file contains PSI (Pressure Stall Information) alert evaluation for managed services.
+package supervisor
+
+import (
+	"fmt"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// checkPressure evaluates the configured pressure alert rules against a metrics update.
+// An event is emitted for each rule whose alert state changed.
+//
+// Params:
+//   - m: the metrics update.
+func (s *Supervisor) checkPressure(m *domainmetrics.ProcessMetrics) {
+	// Skip samples without PSI (cgroup v1 or collection disabled).
+	if !m.Pressure.IsCollected() {
+		// Nothing to evaluate.
+		return
+	}
+
+	s.mu.Lock()
+	rules := s.config.Monitoring.Metrics.PressureAlerts
+	var events []domain.Event
+	// Evaluate each rule and record transitions.
+	for i := range rules {
+		rule := &rules[i]
+		value, ok := m.Pressure.Value(rule.Resource, rule.Scope, rule.Window)
+		over := ok && value > rule.Threshold
+		active := s.pressureAlerts[m.ServiceName]
+		// Skip when the alert state did not change.
+		if active[i] == over {
+			continue
+		}
+		s.setPressureAlert(m.ServiceName, i, over)
+
+		event := domain.NewEvent(domain.EventPressureCleared, m.ServiceName, m.PID, 0, nil)
+		// Attach rule details when the alert fires.
+		if over {
+			event = domain.NewEvent(domain.EventPressureAlert, m.ServiceName, m.PID, 0,
+				fmt.Errorf("%w: %s %s %s at %.1f%% exceeds %.1f%%",
+					ErrPressureAlert, rule.Resource, rule.Scope, rule.Window, value, rule.Threshold))
+		}
+		events = append(events, event)
+	}
+	statsSnap := s.getStatsSnapshot(s.stats[m.ServiceName])
+	s.mu.Unlock()
+
+	// Emit events outside the lock.
+	for i := range events {
+		s.callEventHandler(m.ServiceName, &events[i], statsSnap)
+	}
+}
+
+// setPressureAlert records the alert state of a rule for a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - rule: the index of the pressure alert rule.
+//   - active: whether the alert is firing.
+func (s *Supervisor) setPressureAlert(name string, rule int, active bool) {
+	// Clear the rule and drop empty service entries.
+	if !active {
+		delete(s.pressureAlerts[name], rule)
+		// Remove service when no alert remains.
+		if len(s.pressureAlerts[name]) == 0 {
+			delete(s.pressureAlerts, name)
+		}
+		// Rule cleared.
+		return
+	}
+
+	// Create the service entry on first alert.
+	if s.pressureAlerts[name] == nil {
+		s.pressureAlerts[name] = make(map[int]bool)
+	}
+	s.pressureAlerts[name][rule] = true
+}
+
+// resetPressureAlerts clears the pressure alert state of a service that stopped.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) resetPressureAlerts(name string) {
+	delete(s.pressureAlerts, name)
+}
@@ -0,0 +1,126 @@
+// Package supervisor provides internal tests for pressure.go.
+// It tests PSI alert rule evaluation using white-box testing.
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// newPressureTestSupervisor creates a supervisor with pressure alert rules.
+//
+// Params:
+//   - rules: the pressure alert rules.
+//
+// Returns:
+//   - *Supervisor: the supervisor under test.
+//   - *[]domain.Event: events received by the event handler.
+func newPressureTestSupervisor(rules ...domainconfig.PressureAlertRule) (*Supervisor, *[]domain.Event) {
+	cfg := &domainconfig.Config{}
+	cfg.Monitoring.Metrics.PressureAlerts = rules
+
+	events := &[]domain.Event{}
+	s := &Supervisor{
+		config:         cfg,
+		stats:          map[string]*ServiceStats{"app": NewServiceStats()},
+		pressureAlerts: make(map[string]map[int]bool),
+		eventHandler: func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+			*events = append(*events, *event)
+		},
+	}
+	// return supervisor and captured events
+	return s, events
+}
+
+// pressureMetrics builds a metrics update with the given I/O and memory some avg10.
+//
+// Params:
+//   - io: I/O some avg10 percentage.
+//   - memory: memory some avg10 percentage.
+//
+// Returns:
+//   - *domainmetrics.ProcessMetrics: the metrics update.
+func pressureMetrics(io, memory float64) *domainmetrics.ProcessMetrics {
+	// return collected pressure sample for the test service
+	return &domainmetrics.ProcessMetrics{
+		ServiceName: "app",
+		PID:         10,
+		Pressure: domainmetrics.ResourcePressure{
+			Timestamp: time.Now(),
+			IO:        domainmetrics.Pressure{SomeAvg10: io},
+			Memory:    domainmetrics.Pressure{SomeAvg10: memory},
+		},
+	}
+}
+
+// Test_Supervisor_checkPressure tests alert transitions per rule.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkPressure(t *testing.T) {
+	s, events := newPressureTestSupervisor(
+		domainconfig.PressureAlertRule{Resource: "io", Scope: "some", Window: "avg10", Threshold: 20},
+		domainconfig.PressureAlertRule{Resource: "memory", Scope: "some", Window: "avg10", Threshold: 10},
+	)
+
+	// Below thresholds: no transition.
+	s.checkPressure(pressureMetrics(5, 5))
+	assert.Empty(t, *events)
+
+	// I/O rule fires.
+	s.checkPressure(pressureMetrics(30, 5))
+	require.Len(t, *events, 1)
+	assert.Equal(t, domain.EventPressureAlert, (*events)[0].Type)
+	assert.ErrorIs(t, (*events)[0].Error, ErrPressureAlert)
+	assert.Contains(t, (*events)[0].Error.Error(), "io some avg10")
+
+	// Memory rule fires while I/O stays active: one new event only.
+	s.checkPressure(pressureMetrics(35, 15))
+	require.Len(t, *events, 2)
+	assert.Equal(t, domain.EventPressureAlert, (*events)[1].Type)
+	assert.Contains(t, (*events)[1].Error.Error(), "memory some avg10")
+
+	// Both recover.
+	s.checkPressure(pressureMetrics(1, 1))
+	require.Len(t, *events, 4)
+	assert.Equal(t, domain.EventPressureCleared, (*events)[2].Type)
+	assert.Equal(t, domain.EventPressureCleared, (*events)[3].Type)
+	assert.Empty(t, s.pressureAlerts)
+}
+
+// Test_Supervisor_checkPressure_NotCollected tests that samples without PSI are ignored.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkPressure_NotCollected(t *testing.T) {
+	s, events := newPressureTestSupervisor(
+		domainconfig.PressureAlertRule{Resource: "cpu", Scope: "some", Window: "avg10", Threshold: 1},
+	)
+
+	s.checkPressure(&domainmetrics.ProcessMetrics{ServiceName: "app"})
+
+	assert.Empty(t, *events)
+}
+
+// Test_Supervisor_resetPressureAlerts tests clearing alert state on service exit.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_resetPressureAlerts(t *testing.T) {
+	s, _ := newPressureTestSupervisor(
+		domainconfig.PressureAlertRule{Resource: "io", Scope: "some", Window: "avg10", Threshold: 20},
+	)
+	s.checkPressure(pressureMetrics(30, 0))
+	require.True(t, s.pressureAlerts["app"][0])
+
+	s.resetPressureAlerts("app")
+
+	assert.Empty(t, s.pressureAlerts)
+}
@@ -0,0 +1,67 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file applies the default timing of probes configuring none.
+package supervisor
+
+import (
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// SetProbeDefaults changes the interval and timeout of the health and
+// dependency probes that configure none. Running probes follow the new
+// interval from their next period.
+//
+// Params:
+//   - interval: the default interval; zero restores the built-in default.
+//   - timeout: the default timeout; zero restores the built-in default.
+func (s *Supervisor) SetProbeDefaults(interval, timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.probeInterval = interval
+	s.probeTimeout = timeout
+	// apply to the running health monitors
+	for _, monitor := range s.healthMonitors {
+		monitor.SetDefaults(interval, timeout)
+	}
+	// apply to the running dependency monitors
+	for _, monitor := range s.dependencyMonitors {
+		monitor.SetDefaults(interval, timeout)
+	}
+}
+
+// probeInterval returns the interval of a probe binding, zero when the
+// probe inherits the default interval of its monitor.
+//
+// Params:
+//   - probe: the probe configuration.
+//
+// Returns:
+//   - time.Duration: the configured interval, zero when inherited.
+func probeInterval(probe *domainconfig.ProbeConfig) time.Duration {
+	// follow the monitor default
+	if probe.InheritsInterval {
+		// inherited interval
+		return 0
+	}
+	// return configured interval
+	return probe.Interval.Duration()
+}
+
+// probeTimeout returns the timeout of a probe binding, zero when the probe
+// inherits the default timeout of its monitor.
+//
+// Params:
+//   - probe: the probe configuration.
+//
+// Returns:
+//   - time.Duration: the configured timeout, zero when inherited.
+func probeTimeout(probe *domainconfig.ProbeConfig) time.Duration {
+	// follow the monitor default
+	if probe.InheritsTimeout {
+		// inherited timeout
+		return 0
+	}
+	// return configured timeout
+	return probe.Timeout.Duration()
+}
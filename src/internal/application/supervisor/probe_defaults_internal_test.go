@@ -0,0 +1,71 @@
+// Package supervisor provides internal tests for probe_defaults.go.
+// It tests the default timing of probes using white-box testing.
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Test_probeTiming tests that probes configuring no interval or timeout
+// leave them to the monitor defaults.
+//
+// Params:
+//   - t: the testing context.
+func Test_probeTiming(t *testing.T) {
+	tests := []struct {
+		name             string
+		probe            domainconfig.ProbeConfig
+		expectedInterval time.Duration
+		expectedTimeout  time.Duration
+	}{
+		{
+			name:             "configured timing",
+			probe:            domainconfig.ProbeConfig{Interval: shared.Seconds(30), Timeout: shared.Seconds(2)},
+			expectedInterval: 30 * time.Second,
+			expectedTimeout:  2 * time.Second,
+		},
+		{
+			name: "inherited timing",
+			probe: domainconfig.ProbeConfig{
+				Interval: shared.Seconds(10), Timeout: shared.Seconds(5),
+				InheritsInterval: true, InheritsTimeout: true,
+			},
+		},
+		{
+			name:             "inherited timeout only",
+			probe:            domainconfig.ProbeConfig{Interval: shared.Seconds(30), Timeout: shared.Seconds(5), InheritsTimeout: true},
+			expectedInterval: 30 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedInterval, probeInterval(&tt.probe))
+			assert.Equal(t, tt.expectedTimeout, probeTimeout(&tt.probe))
+		})
+	}
+}
+
+// Test_Supervisor_SetProbeDefaults tests that the defaults are kept for
+// the monitors created later.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_SetProbeDefaults(t *testing.T) {
+	s := &Supervisor{
+		healthMonitors:     map[string]*apphealth.ProbeMonitor{"api": apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})},
+		dependencyMonitors: map[string]*apphealth.ProbeMonitor{},
+	}
+
+	s.SetProbeDefaults(30*time.Second, 2*time.Second)
+
+	assert.Equal(t, 30*time.Second, s.probeInterval)
+	assert.Equal(t, 2*time.Second, s.probeTimeout)
+}
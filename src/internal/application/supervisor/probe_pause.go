@@ -0,0 +1,242 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file pauses the listener probes of services for debugging sessions.
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+var (
+	// ErrNoProbes indicates a probe pause naming a service or listener without probe.
+	ErrNoProbes error = shared.NewCodedError(shared.CodeInvalidArgument, "no probe to pause")
+	// ErrProbesPaused describes the paused listeners in probes paused events.
+	ErrProbesPaused error = fmt.Errorf("probes paused")
+)
+
+// probePause is the pending end of a probe pause.
+type probePause struct {
+	// until is when the probes resume.
+	until time.Time
+	// expiry resumes the probes once the pause ends.
+	expiry shared.Timer
+}
+
+// PauseProbes stops probing a listener of a service, or all its listeners,
+// for the TTL of the spec. Health failures then change nothing: the service
+// keeps its health and its unhealthy actions, restarts included, do not
+// run, which leaves a misbehaving process in place for inspection. Pausing
+// a paused listener moves the end of its pause. Probes resume on their own
+// once the TTL elapses.
+//
+// Params:
+//   - spec: the service, listener and TTL of the pause.
+//
+// Returns:
+//   - []domainhealth.ProbePause: the active pauses of the service.
+//   - error: domainhealth.ErrInvalidProbePause, ErrServiceNotFound, or
+//     ErrNoProbes for a service or listener without probe.
+func (s *Supervisor) PauseProbes(spec *domainhealth.ProbePauseSpec) ([]domainhealth.ProbePause, error) {
+	ttl, err := spec.EffectiveTTL()
+	// reject specs that cannot apply
+	if err != nil {
+		// return validation error
+		return nil, err
+	}
+	name, listener := spec.Service, spec.Listener
+
+	s.mu.Lock()
+	mgr, ok := s.managers[name]
+	// validate service exists
+	if !ok {
+		s.mu.Unlock()
+		// return not found error
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	until := s.clock.Now().Add(ttl)
+	monitor := s.healthMonitors[name]
+	// only probed listeners pause
+	if monitor == nil || !monitor.PauseProbes(listener, until) {
+		s.mu.Unlock()
+		// return missing probe error
+		return nil, fmt.Errorf("%w: %s of %s", ErrNoProbes, describePausedListener(listener), name)
+	}
+	// create the maps on first use
+	if s.probePauses == nil {
+		s.probePauses = make(map[string]map[string]*probePause)
+	}
+	if s.probePauses[name] == nil {
+		s.probePauses[name] = make(map[string]*probePause)
+	}
+	// a new pause replaces the running one
+	if previous, ok := s.probePauses[name][listener]; ok {
+		previous.expiry.Stop()
+	}
+	pause := &probePause{until: until}
+	pause.expiry = s.clock.AfterFunc(ttl, func() { s.expireProbePause(name, listener, pause) })
+	s.probePauses[name][listener] = pause
+	pauses := s.activeProbePauses(name)
+	pid := mgr.PID()
+	statsSnap := s.getStatsSnapshot(s.stats[name])
+	s.mu.Unlock()
+
+	event := domain.NewEvent(domain.EventProbesPaused, name, pid, 0,
+		fmt.Errorf("%w: %s until %s", ErrProbesPaused, describePausedListener(listener), until.Format(time.RFC3339)))
+	s.callEventHandler(name, &event, statsSnap)
+	// return the active pauses
+	return pauses, nil
+}
+
+// ResumeProbes lifts the probe pause of a listener of a service, or every
+// probe pause of the service when listener is empty. Resuming probes that
+// are not paused changes nothing.
+//
+// Params:
+//   - name: the service name.
+//   - listener: the listener name, empty for every pause of the service.
+//
+// Returns:
+//   - []domainhealth.ProbePause: the pauses of the service still active.
+//   - error: ErrServiceNotFound if the service is unknown.
+func (s *Supervisor) ResumeProbes(name, listener string) ([]domainhealth.ProbePause, error) {
+	s.mu.Lock()
+	mgr, ok := s.managers[name]
+	// validate service exists
+	if !ok {
+		s.mu.Unlock()
+		// return not found error
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	lifted := false
+	// lift the requested pauses
+	for paused := range s.probePauses[name] {
+		// keep the pauses of other listeners
+		if listener != "" && paused != listener {
+			continue
+		}
+		s.liftProbePause(name, paused)
+		lifted = true
+	}
+	pauses := s.activeProbePauses(name)
+	pid := mgr.PID()
+	statsSnap := s.getStatsSnapshot(s.stats[name])
+	s.mu.Unlock()
+
+	// report resumed probes
+	if lifted {
+		event := domain.NewEvent(domain.EventProbesResumed, name, pid, 0, nil)
+		s.callEventHandler(name, &event, statsSnap)
+	}
+	// return the remaining pauses
+	return pauses, nil
+}
+
+// expireProbePause resumes the probes of a pause that reached its end.
+//
+// Params:
+//   - name: the service name.
+//   - listener: the paused listener, empty for every listener.
+//   - pause: the pause the timer was armed for.
+func (s *Supervisor) expireProbePause(name, listener string, pause *probePause) {
+	s.mu.Lock()
+	// Skip pauses lifted or replaced since the timer fired.
+	if s.probePauses[name][listener] != pause {
+		s.mu.Unlock()
+		// Stale expiry.
+		return
+	}
+	s.liftProbePause(name, listener)
+	pid := 0
+	// attach the running process
+	if mgr, ok := s.managers[name]; ok {
+		pid = mgr.PID()
+	}
+	statsSnap := s.getStatsSnapshot(s.stats[name])
+	s.mu.Unlock()
+
+	event := domain.NewEvent(domain.EventProbesResumed, name, pid, 0, nil)
+	s.callEventHandler(name, &event, statsSnap)
+}
+
+// liftProbePause ends a probe pause and resumes its probes.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - listener: the paused listener, empty for every listener.
+func (s *Supervisor) liftProbePause(name, listener string) {
+	pause, ok := s.probePauses[name][listener]
+	// Skip listeners that are not paused.
+	if !ok {
+		// Nothing to lift.
+		return
+	}
+	pause.expiry.Stop()
+	delete(s.probePauses[name], listener)
+	// forget services without pause
+	if len(s.probePauses[name]) == 0 {
+		delete(s.probePauses, name)
+	}
+	// resume the probes of the running monitor
+	if monitor, ok := s.healthMonitors[name]; ok {
+		monitor.ResumeProbes(listener)
+	}
+}
+
+// clearProbePauses cancels the pending ends of every probe pause, for a
+// supervisor stop. Must be called with s.mu held.
+func (s *Supervisor) clearProbePauses() {
+	// cancel each pending pause end
+	for _, pauses := range s.probePauses {
+		// stop the timer of each listener
+		for _, pause := range pauses {
+			pause.expiry.Stop()
+		}
+	}
+	s.probePauses = nil
+}
+
+// activeProbePauses returns the probe pauses of a service, the pause of
+// every listener first, then by listener name. Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - []domainhealth.ProbePause: the active pauses.
+func (s *Supervisor) activeProbePauses(name string) []domainhealth.ProbePause {
+	pauses := make([]domainhealth.ProbePause, 0, len(s.probePauses[name]))
+	// collect each pause
+	for listener, pause := range s.probePauses[name] {
+		pauses = append(pauses, domainhealth.ProbePause{Listener: listener, Until: pause.until})
+	}
+	slices.SortFunc(pauses, func(a, b domainhealth.ProbePause) int {
+		// order by listener name, the empty name first
+		return strings.Compare(a.Listener, b.Listener)
+	})
+	// return sorted pauses
+	return pauses
+}
+
+// describePausedListener names the listeners of a pause in messages.
+//
+// Params:
+//   - listener: the paused listener, empty for every listener.
+//
+// Returns:
+//   - string: the description.
+func describePausedListener(listener string) string {
+	// the empty name covers the whole service
+	if listener == "" {
+		// return every listener
+		return "every listener"
+	}
+	// return the quoted listener
+	return fmt.Sprintf("listener %q", listener)
+}
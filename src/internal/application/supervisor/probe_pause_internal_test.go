@@ -0,0 +1,170 @@
+// Package supervisor provides internal tests for probe_pause.go.
+// It tests the pause and resume of listener probes using white-box testing.
+package supervisor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// pauseTestEvents records the events of a supervisor under test.
+type pauseTestEvents struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+// record stores an event.
+//
+// Params:
+//   - name: the service name (unused).
+//   - event: the event.
+//   - stats: the statistics snapshot (unused).
+func (r *pauseTestEvents) record(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, *event)
+}
+
+// types returns the types of the recorded events.
+//
+// Returns:
+//   - []domain.EventType: the event types, in order.
+func (r *pauseTestEvents) types() []domain.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]domain.EventType, 0, len(r.events))
+	for i := range r.events {
+		types = append(types, r.events[i].Type)
+	}
+	return types
+}
+
+// newPauseTestSupervisor creates a supervisor with a probed service "api"
+// and an unprobed service "worker", on a fake clock.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+//   - *pauseTestEvents: the recorded events.
+//   - *shared.FakeClock: the clock of the pauses.
+func newPauseTestSupervisor(t *testing.T) (*Supervisor, *pauseTestEvents, *shared.FakeClock) {
+	t.Helper()
+	api := &domainconfig.ServiceConfig{
+		Name:    "api",
+		Command: "/bin/api",
+		Listeners: []domainconfig.ListenerConfig{{
+			Name:  "http",
+			Port:  8080,
+			Probe: &domainconfig.ProbeConfig{Type: "tcp"},
+		}},
+	}
+	worker := &domainconfig.ServiceConfig{Name: "worker", Command: "/bin/worker"}
+	clock := shared.NewFakeClock(time.Now())
+	recorder := &pauseTestEvents{}
+	s := &Supervisor{
+		clock: clock,
+		managers: map[string]*applifecycle.Manager{
+			"api":    applifecycle.NewManager(api, &proxyTestExecutor{}),
+			"worker": applifecycle.NewManager(worker, &proxyTestExecutor{}),
+		},
+		healthMonitors: map[string]*apphealth.ProbeMonitor{},
+		proberFactory:  &traceTestFactory{},
+		eventHandler:   recorder.record,
+	}
+	monitor := apphealth.NewProbeMonitor(s.createProbeMonitorConfig("api"))
+	s.addListenersWithProbes(monitor, api)
+	s.healthMonitors["api"] = monitor
+	return s, recorder, clock
+}
+
+// Test_Supervisor_PauseProbes_rejected tests pauses that cannot apply.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_PauseProbes_rejected(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// spec is the pause request.
+		spec domainhealth.ProbePauseSpec
+		// wantErr is the expected error.
+		wantErr error
+	}{
+		{name: "unknown service", spec: domainhealth.ProbePauseSpec{Service: "missing"}, wantErr: ErrServiceNotFound},
+		{name: "service without probe", spec: domainhealth.ProbePauseSpec{Service: "worker"}, wantErr: ErrNoProbes},
+		{name: "unknown listener", spec: domainhealth.ProbePauseSpec{Service: "api", Listener: "admin"}, wantErr: ErrNoProbes},
+		{name: "ttl too long", spec: domainhealth.ProbePauseSpec{Service: "api", TTL: 2 * domainhealth.MaxProbePauseTTL}, wantErr: domainhealth.ErrInvalidProbePause},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, recorder, _ := newPauseTestSupervisor(t)
+			_, err := s.PauseProbes(&tt.spec)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, recorder.types())
+		})
+	}
+}
+
+// Test_Supervisor_PauseProbes tests pausing, resuming and the automatic
+// resume of listener probes.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_PauseProbes(t *testing.T) {
+	s, recorder, clock := newPauseTestSupervisor(t)
+	start := clock.Now()
+
+	pauses, err := s.PauseProbes(&domainhealth.ProbePauseSpec{Service: "api", Listener: "http", TTL: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, []domainhealth.ProbePause{{Listener: "http", Until: start.Add(time.Minute)}}, pauses)
+	pauses, err = s.PauseProbes(&domainhealth.ProbePauseSpec{Service: "api", TTL: 2 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, []domainhealth.ProbePause{
+		{Until: start.Add(2 * time.Minute)},
+		{Listener: "http", Until: start.Add(time.Minute)},
+	}, pauses)
+
+	// The pause event names the listener and the end of the pause.
+	recorder.mu.Lock()
+	require.Len(t, recorder.events, 2)
+	assert.ErrorIs(t, recorder.events[0].Error, ErrProbesPaused)
+	assert.Contains(t, recorder.events[0].Error.Error(), `listener "http" until`)
+	assert.Contains(t, recorder.events[1].Error.Error(), "every listener")
+	recorder.mu.Unlock()
+
+	// Resuming a listener keeps the pause of every listener.
+	pauses, err = s.ResumeProbes("api", "http")
+	require.NoError(t, err)
+	assert.Equal(t, []domainhealth.ProbePause{{Until: start.Add(2 * time.Minute)}}, pauses)
+
+	// The remaining pause ends on its own.
+	clock.Advance(2 * time.Minute)
+	require.Eventually(t, func() bool { return len(recorder.types()) == 4 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []domain.EventType{
+		domain.EventProbesPaused, domain.EventProbesPaused,
+		domain.EventProbesResumed, domain.EventProbesResumed,
+	}, recorder.types())
+
+	// Nothing is left to resume.
+	pauses, err = s.ResumeProbes("api", "")
+	require.NoError(t, err)
+	assert.Empty(t, pauses)
+	assert.Len(t, recorder.types(), 4)
+	_, err = s.ResumeProbes("missing", "")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
@@ -0,0 +1,66 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file exposes the attempts of probes with debug enabled.
+package supervisor
+
+import (
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+)
+
+// ProbeTraceHandler is a callback invoked after each attempt of a probe with
+// debug enabled. It runs on the probing goroutine and must not block.
+type ProbeTraceHandler func(serviceName string, attempt domainhealth.ProbeAttempt)
+
+// SetProbeTraceHandler sets the callback for traced probe attempts.
+//
+// Params:
+//   - handler: the callback function to invoke for each traced attempt.
+func (s *Supervisor) SetProbeTraceHandler(handler ProbeTraceHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store probe trace handler
+	s.probeTraceHandler = handler
+}
+
+// ProbeTrace returns the recent attempts of the debug probes of a service,
+// oldest first. Services without debug probes return an empty trace.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - []domainhealth.ProbeAttempt: the traced attempts.
+//   - error: ErrServiceNotFound if the service is unknown.
+func (s *Supervisor) ProbeTrace(name string) ([]domainhealth.ProbeAttempt, error) {
+	s.mu.RLock()
+	_, ok := s.managers[name]
+	monitor := s.healthMonitors[name]
+	s.mu.RUnlock()
+
+	// validate service exists
+	if !ok {
+		// return not found error
+		return nil, ErrServiceNotFound
+	}
+	// services without probes have no monitor
+	if monitor == nil {
+		// return empty trace
+		return nil, nil
+	}
+	// return monitor trace
+	return monitor.ProbeTrace(), nil
+}
+
+// notifyProbeAttempt hands a traced probe attempt to the trace handler.
+//
+// Params:
+//   - serviceName: the probed service.
+//   - attempt: the probe attempt.
+func (s *Supervisor) notifyProbeAttempt(serviceName string, attempt domainhealth.ProbeAttempt) {
+	s.mu.RLock()
+	handler := s.probeTraceHandler
+	s.mu.RUnlock()
+	// call handler if registered
+	if handler != nil {
+		handler(serviceName, attempt)
+	}
+}
@@ -0,0 +1,144 @@
+// Package supervisor provides internal tests for probe_trace.go.
+// It tests probe attempt tracing using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+)
+
+// traceTestProber always succeeds.
+type traceTestProber struct{}
+
+// Probe returns a successful result.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - target: the probe target (unused).
+//
+// Returns:
+//   - domainhealth.CheckResult: a successful result.
+func (p *traceTestProber) Probe(_ context.Context, _ domainhealth.Target) domainhealth.CheckResult {
+	return domainhealth.NewSuccessCheckResult(time.Millisecond, "ok")
+}
+
+// Type returns the prober type.
+//
+// Returns:
+//   - string: the prober type.
+func (p *traceTestProber) Type() string {
+	return "tcp"
+}
+
+// traceTestFactory creates traceTestProber instances.
+type traceTestFactory struct{}
+
+// Create returns a traceTestProber.
+//
+// Params:
+//   - proberType: the prober type (unused).
+//   - timeout: the probe timeout (unused).
+//
+// Returns:
+//   - domainhealth.Prober: the prober.
+//   - error: always nil.
+func (f *traceTestFactory) Create(_ string, _ time.Duration) (domainhealth.Prober, error) {
+	return &traceTestProber{}, nil
+}
+
+// Test_Supervisor_ProbeTrace tests tracing of debug probes per service.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ProbeTrace(t *testing.T) {
+	tests := []struct {
+		name       string
+		debug      bool
+		wantTraced bool
+	}{
+		{name: "debug probe traced", debug: true, wantTraced: true},
+		{name: "regular probe not traced", debug: false},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &domainconfig.ServiceConfig{
+				Name:    "api",
+				Command: "/bin/api",
+				Listeners: []domainconfig.ListenerConfig{{
+					Name:  "http",
+					Port:  8080,
+					Probe: &domainconfig.ProbeConfig{Type: "tcp", Debug: tt.debug},
+				}},
+			}
+			s := &Supervisor{
+				managers:       map[string]*applifecycle.Manager{"api": applifecycle.NewManager(svc, &proxyTestExecutor{})},
+				healthMonitors: map[string]*apphealth.ProbeMonitor{},
+				proberFactory:  &traceTestFactory{},
+			}
+			var mu sync.Mutex
+			var handled []string
+			s.SetProbeTraceHandler(func(service string, attempt domainhealth.ProbeAttempt) {
+				mu.Lock()
+				defer mu.Unlock()
+				handled = append(handled, service+"/"+attempt.Listener)
+			})
+
+			monitor := apphealth.NewProbeMonitor(s.createProbeMonitorConfig("api"))
+			s.addListenersWithProbes(monitor, svc)
+			s.healthMonitors["api"] = monitor
+			monitor.Start(context.Background())
+			defer monitor.Stop()
+
+			// Untraced probes run but leave no trace.
+			if !tt.wantTraced {
+				time.Sleep(20 * time.Millisecond)
+				trace, err := s.ProbeTrace("api")
+				require.NoError(t, err)
+				assert.Empty(t, trace)
+				return
+			}
+			require.Eventually(t, func() bool {
+				trace, err := s.ProbeTrace("api")
+				return err == nil && len(trace) > 0
+			}, time.Second, 5*time.Millisecond)
+			trace, _ := s.ProbeTrace("api")
+			assert.Equal(t, "http", trace[0].Listener)
+			assert.True(t, trace[0].Success)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Contains(t, handled, "api/http")
+		})
+	}
+}
+
+// Test_Supervisor_ProbeTrace_lookup tests trace lookup of unknown and unprobed services.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ProbeTrace_lookup(t *testing.T) {
+	svc := &domainconfig.ServiceConfig{Name: "worker", Command: "/bin/worker"}
+	s := &Supervisor{
+		managers:       map[string]*applifecycle.Manager{"worker": applifecycle.NewManager(svc, &proxyTestExecutor{})},
+		healthMonitors: map[string]*apphealth.ProbeMonitor{},
+	}
+
+	_, err := s.ProbeTrace("missing")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+
+	trace, err := s.ProbeTrace("worker")
+	require.NoError(t, err)
+	assert.Empty(t, trace)
+}
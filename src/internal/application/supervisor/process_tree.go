@@ -0,0 +1,101 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file exposes the processes spawned by running services.
+package supervisor
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// ErrNoTreeReader is returned by ProcessTrees when no tree reader is configured.
+var ErrNoTreeReader error = fmt.Errorf("no process tree reader configured")
+
+// SetTreeReader sets the adapter reading the processes spawned by running
+// services. Without it, ProcessTrees returns ErrNoTreeReader.
+//
+// Params:
+//   - reader: the process tree reader to use.
+func (s *Supervisor) SetTreeReader(reader domain.TreeReader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store process tree reader
+	s.treeReader = reader
+}
+
+// ProcessTrees returns the process tree under the main process of a
+// running service, or of every running service, sorted by name, when name
+// is empty. Processes are linked by parent PID, so processes that detach
+// from their parent are not listed under the service.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - name: the service name, empty for every running service.
+//
+// Returns:
+//   - []domain.ServiceTree: the process tree of each service.
+//   - error: ErrNoTreeReader, ErrServiceNotFound, domain.ErrNotRunning or the reader error.
+func (s *Supervisor) ProcessTrees(ctx context.Context, name string) ([]domain.ServiceTree, error) {
+	s.mu.RLock()
+	reader := s.treeReader
+	roots := make(map[string]int, len(s.managers))
+	// collect the main process of each running service
+	for svc, mgr := range s.managers {
+		// keep the requested service only
+		if name != "" && svc != name {
+			continue
+		}
+		roots[svc] = mgr.PID()
+	}
+	s.mu.RUnlock()
+
+	// the tree reader is optional
+	if reader == nil {
+		// return disabled feature
+		return nil, ErrNoTreeReader
+	}
+	// validate service exists
+	if name != "" && len(roots) == 0 {
+		// return not found error
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	pids := make([]int, 0, len(roots))
+	// only running services have a tree
+	for svc, pid := range roots {
+		// drop stopped services
+		if pid <= 0 {
+			delete(roots, svc)
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	nodes, err := reader.ProcessTrees(ctx, pids)
+	// reading the process table failed
+	if err != nil {
+		// return wrapped reader error
+		return nil, fmt.Errorf("read process trees: %w", err)
+	}
+
+	trees := make([]domain.ServiceTree, 0, len(nodes))
+	// pair each service with its tree
+	for svc, pid := range roots {
+		// skip processes that exited since
+		if node, ok := nodes[pid]; ok {
+			trees = append(trees, domain.ServiceTree{Service: svc, Root: node})
+		}
+	}
+	// a requested service must be running
+	if name != "" && len(trees) == 0 {
+		// return not running error
+		return nil, domain.ErrNotRunning
+	}
+	slices.SortFunc(trees, func(a, b domain.ServiceTree) int {
+		// order by service name
+		return cmp.Compare(a.Service, b.Service)
+	})
+	// return the trees
+	return trees, nil
+}
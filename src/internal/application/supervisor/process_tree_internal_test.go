@@ -0,0 +1,116 @@
+// Package supervisor provides internal tests for process_tree.go.
+// It tests the process trees of running services using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// treeTestReader returns fixed process trees.
+type treeTestReader struct {
+	// trees is returned by ProcessTrees.
+	trees map[int]domain.ProcessNode
+	// err is returned by ProcessTrees when set.
+	err error
+	// roots records the requested roots.
+	roots []int
+}
+
+// ProcessTrees records the roots and returns the configured trees.
+//
+// Params:
+//   - roots: the requested roots.
+//
+// Returns:
+//   - map[int]domain.ProcessNode: the configured trees.
+//   - error: the configured error.
+func (r *treeTestReader) ProcessTrees(_ context.Context, roots []int) (map[int]domain.ProcessNode, error) {
+	r.roots = roots
+	return r.trees, r.err
+}
+
+// newTreeTestSupervisor builds a supervisor with "api" running as pid 4242
+// and "cron" stopped.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+func newTreeTestSupervisor(t *testing.T) *Supervisor {
+	t.Helper()
+	s := newSpecTestSupervisor(t)
+	s.managers["cron"] = applifecycle.NewManager(&domainconfig.ServiceConfig{Name: "cron", Command: "/bin/cron"}, &proxyTestExecutor{})
+	return s
+}
+
+// Test_Supervisor_ProcessTrees tests the trees returned for each request.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ProcessTrees(t *testing.T) {
+	api := domain.ProcessNode{PID: 4242, Name: "api", Children: []domain.ProcessNode{{PID: 4243, Name: "worker"}}}
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// service is the requested service.
+		service string
+		// reader is the tree reader, nil when unset.
+		reader *treeTestReader
+		// want is the expected trees.
+		want []domain.ServiceTree
+		// wantErr is the expected error.
+		wantErr error
+	}{
+		{
+			name:   "every_running_service",
+			reader: &treeTestReader{trees: map[int]domain.ProcessNode{4242: api}},
+			want:   []domain.ServiceTree{{Service: "api", Root: api}},
+		},
+		{
+			name:    "named_service",
+			service: "api",
+			reader:  &treeTestReader{trees: map[int]domain.ProcessNode{4242: api}},
+			want:    []domain.ServiceTree{{Service: "api", Root: api}},
+		},
+		{name: "stopped_service", service: "cron", reader: &treeTestReader{}, wantErr: domain.ErrNotRunning},
+		{name: "exited_since", service: "api", reader: &treeTestReader{}, wantErr: domain.ErrNotRunning},
+		{name: "unknown_service", service: "db", reader: &treeTestReader{}, wantErr: ErrServiceNotFound},
+		{name: "without_reader", wantErr: ErrNoTreeReader},
+		{name: "reader_failure", reader: &treeTestReader{err: errors.New("no procfs")}, wantErr: errors.New("read process trees: no procfs")},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTreeTestSupervisor(t)
+			// Install the reader under test.
+			if tt.reader != nil {
+				s.SetTreeReader(tt.reader)
+			}
+
+			trees, err := s.ProcessTrees(context.Background(), tt.service)
+
+			// Failures return no tree.
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr.Error())
+				assert.Nil(t, trees)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, trees)
+			assert.Equal(t, []int{4242}, tt.reader.roots)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file binds supervisor-owned public endpoints for proxied listeners.
+package supervisor
+
+import (
+	"sync"
+
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// SetProxyOpener sets the adapter binding public endpoints of proxied listeners.
+// Without an opener, proxy settings are ignored.
+//
+// Params:
+//   - opener: the relay opener to use.
+func (s *Supervisor) SetProxyOpener(opener appproxy.Opener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store relay opener
+	s.proxyOpener = opener
+}
+
+// startProxies binds the public endpoint of every proxied listener.
+// Bind failures are reported through the error handler and do not stop
+// the service, which stays reachable on its own address.
+func (s *Supervisor) startProxies() {
+	s.mu.RLock()
+	opener := s.proxyOpener
+	routes := s.proxyRoutes()
+	s.mu.RUnlock()
+
+	// Skip when no adapter is configured or no listener is proxied.
+	if opener == nil || len(routes) == 0 {
+		// Nothing to bind.
+		return
+	}
+
+	relays := make(map[string][]appproxy.Relay, len(routes))
+	// Bind each public endpoint.
+	for _, route := range routes {
+		relay, err := opener.Open(route)
+		// Report bind failure and keep going.
+		if err != nil {
+			s.handleRecoveryError("proxy", route.Service, err)
+			continue
+		}
+		relays[route.Service] = append(relays[route.Service], relay)
+	}
+
+	s.mu.Lock()
+	s.relays = relays
+	s.mu.Unlock()
+}
+
+// closeProxies closes every public endpoint and its forwarded connections.
+func (s *Supervisor) closeProxies() {
+	s.mu.Lock()
+	relays := s.relays
+	s.relays = nil
+	s.mu.Unlock()
+
+	// Close relays outside the lock.
+	for service, list := range relays {
+		// Close each relay of the service.
+		for _, relay := range list {
+			// Report close failure (best-effort cleanup).
+			if err := relay.Close(); err != nil {
+				s.handleRecoveryError("proxy-close", service, err)
+			}
+		}
+	}
+}
+
+// drainProxies refuses new connections to the public endpoints of a service
+// and waits for in-flight connections to finish, up to each listener drain
+// timeout. It is called before the service is stopped or restarted.
+//
+// Params:
+//   - service: the service name.
+func (s *Supervisor) drainProxies(service string) {
+	s.mu.RLock()
+	relays := s.relays[service]
+	s.mu.RUnlock()
+
+	drainRelays(relays)
+}
+
+// drainAllProxies drains the public endpoints of every service concurrently.
+func (s *Supervisor) drainAllProxies() {
+	s.mu.RLock()
+	var relays []appproxy.Relay
+	// Collect relays of all services.
+	for _, list := range s.relays {
+		relays = append(relays, list...)
+	}
+	s.mu.RUnlock()
+
+	drainRelays(relays)
+}
+
+// resumeProxies admits new connections to the public endpoints of a service.
+// It is called once the service has started again.
+//
+// Params:
+//   - service: the service name.
+func (s *Supervisor) resumeProxies(service string) {
+	s.mu.RLock()
+	relays := s.relays[service]
+	s.mu.RUnlock()
+
+	// Reopen each relay of the service.
+	for _, relay := range relays {
+		relay.Resume()
+	}
+}
+
+// drainRelays drains relays concurrently and waits for all of them.
+//
+// Params:
+//   - relays: the relays to drain.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine per relay, each bounded by its drain timeout.
+//   - Blocks until all goroutines complete via WaitGroup.
+func drainRelays(relays []appproxy.Relay) {
+	var wg sync.WaitGroup
+	// Drain each relay in its own goroutine.
+	for _, relay := range relays {
+		wg.Go(relay.Drain)
+	}
+	wg.Wait()
+}
+
+// restartProxies rebinds public endpoints after a configuration change.
+func (s *Supervisor) restartProxies() {
+	s.closeProxies()
+	s.startProxies()
+}
+
+// proxyRoutes builds the routes of all proxied listeners.
+// Must be called with s.mu held.
+//
+// Returns:
+//   - []appproxy.Route: one route per proxied exposed listener.
+func (s *Supervisor) proxyRoutes() []appproxy.Route {
+	var routes []appproxy.Route
+	// Iterate over configured services.
+	for i := range s.config.Services {
+		svc := &s.config.Services[i]
+		// Iterate over service listeners.
+		for j := range svc.Listeners {
+			lc := &svc.Listeners[j]
+			// Only exposed listeners with a proxy block are bound.
+			if lc.Proxy == nil || !lc.Exposed {
+				continue
+			}
+			route := appproxy.Route{
+				Service:       svc.Name,
+				Listener:      lc.Name,
+				Network:       lc.ProbeNetwork("tcp"),
+				ListenAddress: lc.PublicListener().ListenAddress(),
+				TargetAddress: lc.DialAddress(),
+				DrainTimeout:  lc.Proxy.EffectiveDrainTimeout(),
+			}
+			// Gate connections on service health when requested.
+			if lc.Proxy.HealthGate {
+				route.Gate = s.proxyGate(svc.Name, lc)
+			}
+			routes = append(routes, route)
+		}
+	}
+	// Return collected routes.
+	return routes
+}
+
+// proxyGate returns the gate of a health-gated proxied listener.
+//
+// Params:
+//   - service: the service name.
+//   - lc: the proxied listener configuration.
+//
+// Returns:
+//   - appproxy.Gate: the gate evaluated for each new connection.
+func (s *Supervisor) proxyGate(service string, lc *domainconfig.ListenerConfig) appproxy.Gate {
+	name := lc.Name
+	probed := lc.Probe != nil
+	// Evaluate current health on each connection.
+	return func() bool {
+		// check service and listener health
+		return s.proxyAdmits(service, name, probed)
+	}
+}
+
+// proxyAdmits reports whether a health-gated listener accepts traffic.
+// The service must be running and the listener not conflicted; probed
+// listeners must also be ready.
+//
+// Params:
+//   - service: the service name.
+//   - name: the listener name.
+//   - probed: whether the listener has a probe.
+//
+// Returns:
+//   - bool: true if new connections may be forwarded.
+func (s *Supervisor) proxyAdmits(service, name string, probed bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mgr, ok := s.managers[service]
+	// Refuse traffic while the service is not running.
+	if !ok || mgr.State() != domain.StateRunning {
+		// Service down.
+		return false
+	}
+
+	// Refuse traffic while another process holds the port.
+	if s.listenerConflicted(service, name) {
+		// Foreign process would answer.
+		return false
+	}
+
+	// Listeners without probes only need a running service.
+	if !probed {
+		// Running is enough.
+		return true
+	}
+
+	monitor, ok := s.healthMonitors[service]
+	// Refuse traffic until the health monitor tracks the listener.
+	if !ok {
+		// No health information yet.
+		return false
+	}
+	state, ok := monitor.ListenerState(name)
+	// Probed listeners must be ready.
+	return ok && state.IsReady()
+}
@@ -0,0 +1,276 @@
+// Package supervisor provides internal tests for proxies.go.
+// It tests public endpoint binding and health gating using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/listener"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// errProxyBind is returned by the test opener for failing routes.
+var errProxyBind error = errors.New("address in use")
+
+// proxyTestRelay records Drain, Resume and Close calls.
+type proxyTestRelay struct {
+	// drained counts Drain calls.
+	drained int
+	// resumed counts Resume calls.
+	resumed int
+	// closed indicates Close was called.
+	closed bool
+}
+
+// Addr returns a fixed address.
+//
+// Returns:
+//   - string: the relay address.
+func (r *proxyTestRelay) Addr() string { return "127.0.0.1:0" }
+
+// Drain records the call.
+func (r *proxyTestRelay) Drain() { r.drained++ }
+
+// Resume records the call.
+func (r *proxyTestRelay) Resume() { r.resumed++ }
+
+// Close records the call.
+//
+// Returns:
+//   - error: always nil.
+func (r *proxyTestRelay) Close() error {
+	r.closed = true
+	return nil
+}
+
+// proxyTestOpener records opened routes and fails for one listener.
+type proxyTestOpener struct {
+	// routes are the opened routes.
+	routes []appproxy.Route
+	// relays are the returned relays.
+	relays []*proxyTestRelay
+	// failListener is the listener whose bind fails.
+	failListener string
+}
+
+// Open records the route.
+//
+// Params:
+//   - route: the route to open.
+//
+// Returns:
+//   - appproxy.Relay: the test relay.
+//   - error: errProxyBind for the failing listener.
+func (o *proxyTestOpener) Open(route appproxy.Route) (appproxy.Relay, error) {
+	if route.Listener == o.failListener {
+		return nil, errProxyBind
+	}
+	o.routes = append(o.routes, route)
+	relay := &proxyTestRelay{}
+	o.relays = append(o.relays, relay)
+	return relay, nil
+}
+
+// proxyTestExecutor starts processes that never exit.
+type proxyTestExecutor struct{}
+
+// Start returns a process that stays running.
+//
+// Returns:
+//   - int: a fake pid.
+//   - <-chan domain.ExitResult: a channel that never fires.
+//   - error: always nil.
+func (e *proxyTestExecutor) Start(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+	return 4242, make(chan domain.ExitResult), nil
+}
+
+// Stop is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *proxyTestExecutor) Stop(_ int, _ time.Duration) error { return nil }
+
+// Signal is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *proxyTestExecutor) Signal(_ int, _ os.Signal) error { return nil }
+
+// newProxyTestConfig builds a configuration with proxied and plain listeners.
+//
+// Returns:
+//   - *domainconfig.Config: the configuration.
+func newProxyTestConfig() *domainconfig.Config {
+	return &domainconfig.Config{
+		Services: []domainconfig.ServiceConfig{
+			{Name: "api", Command: "/bin/api", Listeners: []domainconfig.ListenerConfig{
+				{Name: "http", Port: 8080, Address: "127.0.0.1", Exposed: true,
+					Proxy: &domainconfig.ProxyConfig{Port: 80, HealthGate: true}},
+				{Name: "admin", Port: 9090, Address: "127.0.0.1", Exposed: true,
+					Proxy: &domainconfig.ProxyConfig{Address: "::", Port: 9091}},
+				{Name: "internal", Port: 7070, Proxy: &domainconfig.ProxyConfig{Port: 7071}},
+				{Name: "plain", Port: 6060, Exposed: true},
+			}},
+		},
+	}
+}
+
+// Test_Supervisor_proxyRoutes tests route construction for proxied listeners.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_proxyRoutes(t *testing.T) {
+	s := &Supervisor{config: newProxyTestConfig()}
+
+	routes := s.proxyRoutes()
+
+	// Only exposed listeners with a proxy block are routed.
+	require.Len(t, routes, 2)
+	assert.Equal(t, "http", routes[0].Listener)
+	assert.Equal(t, "tcp", routes[0].Network)
+	assert.Equal(t, ":80", routes[0].ListenAddress)
+	assert.Equal(t, "127.0.0.1:8080", routes[0].TargetAddress)
+	assert.Equal(t, 30*time.Second, routes[0].DrainTimeout)
+	assert.NotNil(t, routes[0].Gate)
+	assert.Equal(t, "[::]:9091", routes[1].ListenAddress)
+	assert.Nil(t, routes[1].Gate)
+}
+
+// Test_Supervisor_startProxies tests binding, failure reporting, and closing.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_startProxies(t *testing.T) {
+	opener := &proxyTestOpener{failListener: "admin"}
+	var reported []error
+	s := &Supervisor{
+		config:      newProxyTestConfig(),
+		proxyOpener: opener,
+		errorHandler: func(_, _ string, err error) {
+			reported = append(reported, err)
+		},
+	}
+
+	s.startProxies()
+
+	// One relay bound, one bind failure reported.
+	require.Len(t, opener.relays, 1)
+	assert.Len(t, s.relays["api"], 1)
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], errProxyBind)
+
+	s.closeProxies()
+
+	// Relays are closed and forgotten.
+	assert.True(t, opener.relays[0].closed)
+	assert.Empty(t, s.relays)
+}
+
+// Test_Supervisor_startProxies_NoOpener tests that proxies are skipped without an adapter.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_startProxies_NoOpener(t *testing.T) {
+	s := &Supervisor{config: newProxyTestConfig()}
+
+	s.startProxies()
+
+	assert.Empty(t, s.relays)
+}
+
+// Test_Supervisor_proxyAdmits tests health gating of proxied listeners.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_proxyAdmits(t *testing.T) {
+	cfg := newProxyTestConfig()
+	mgr := applifecycle.NewManager(&cfg.Services[0], &proxyTestExecutor{})
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})
+	http := listener.NewListener("http", "tcp", "127.0.0.1", 8080)
+	require.NoError(t, monitor.AddListener(http))
+	s := &Supervisor{
+		config:         cfg,
+		managers:       map[string]*applifecycle.Manager{"api": mgr},
+		healthMonitors: map[string]*apphealth.ProbeMonitor{"api": monitor},
+		conflicts:      make(map[string]map[string]bool),
+	}
+
+	// Unknown and stopped services refuse traffic.
+	assert.False(t, s.proxyAdmits("web", "http", false))
+	assert.False(t, s.proxyAdmits("api", "http", false))
+
+	require.NoError(t, mgr.Start(context.Background()))
+	defer func() { _ = mgr.Stop() }()
+	require.Eventually(t, func() bool { return mgr.State() == domain.StateRunning }, time.Second, 10*time.Millisecond)
+
+	// Unprobed listeners only need a running service.
+	assert.True(t, s.proxyAdmits("api", "plain", false))
+
+	// Probed listeners need a ready listener.
+	assert.False(t, s.proxyAdmits("api", "http", true))
+	http.MarkListening()
+	http.MarkReady()
+	assert.True(t, s.proxyAdmits("api", "http", true))
+
+	// Conflicted listeners refuse traffic.
+	s.conflicts["api"] = map[string]bool{"http": true}
+	assert.False(t, s.proxyAdmits("api", "http", true))
+}
+
+// Test_Supervisor_drainProxies tests draining and resuming the relays of one service.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_drainProxies(t *testing.T) {
+	api, web := &proxyTestRelay{}, &proxyTestRelay{}
+	s := &Supervisor{relays: map[string][]appproxy.Relay{"api": {api}, "web": {web}}}
+
+	s.drainProxies("api")
+
+	// Only the relays of the service are drained.
+	assert.Equal(t, 1, api.drained)
+	assert.Zero(t, web.drained)
+
+	s.resumeProxies("api")
+	s.drainAllProxies()
+
+	// Resume reopens the service; drainAllProxies covers every service.
+	assert.Equal(t, 1, api.resumed)
+	assert.Equal(t, 2, api.drained)
+	assert.Equal(t, 1, web.drained)
+}
+
+// Test_Supervisor_StopService_DrainsProxies tests that proxies drain before a
+// service stops and resume once it has started again.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_StopService_DrainsProxies(t *testing.T) {
+	cfg := newProxyTestConfig()
+	relay := &proxyTestRelay{}
+	s := &Supervisor{
+		config:    cfg,
+		managers:  map[string]*applifecycle.Manager{"api": applifecycle.NewManager(&cfg.Services[0], &proxyTestExecutor{})},
+		stats:     make(map[string]*ServiceStats),
+		relays:    map[string][]appproxy.Relay{"api": {relay}},
+		conflicts: make(map[string]map[string]bool),
+	}
+
+	require.NoError(t, s.StopService("api"))
+	assert.Equal(t, 1, relay.drained)
+
+	// A started event admits traffic again.
+	s.handleEvent("api", &domain.Event{Type: domain.EventStarted})
+	assert.Equal(t, 1, relay.resumed)
+}
@@ -0,0 +1,212 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file quiesces the services oneshot jobs operate on, around each run.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Job state variables.
+const (
+	// stateServiceEnv names the service a job operates on.
+	stateServiceEnv string = "SUPERVIZIO_STATE_SERVICE"
+	// stateDirEnv is the state directory, the working directory of the service a job operates on.
+	stateDirEnv string = "SUPERVIZIO_STATE_DIR"
+)
+
+// ErrQuiesceUnverified is reported when a service is not healthy within
+// its health timeout after a job operating on it.
+var ErrQuiesceUnverified error = errors.New("service not healthy after job")
+
+// jobQuiesce is a service quiesced, or checked, around a running job.
+type jobQuiesce struct {
+	// op is the operated service declaration of the job.
+	op domainconfig.OperatesOnConfig
+	// acted reports that the service was stopped or signaled, and must be resumed.
+	acted bool
+}
+
+// BeforeJob quiesces the service a oneshot job operates on before the job
+// starts: the service is stopped, or sent its reload signal. A service
+// that is not running is left as is. The job receives the service
+// name and state directory in SUPERVIZIO_STATE_SERVICE and
+// SUPERVIZIO_STATE_DIR.
+//
+// Params:
+//   - ctx: the lifecycle context of the job (unused).
+//   - name: the job.
+//
+// Returns:
+//   - map[string]string: the state variables, nil for other jobs.
+//   - error: why the service could not be quiesced; the run then fails.
+func (s *Supervisor) BeforeJob(_ context.Context, name string) (map[string]string, error) {
+	s.mu.RLock()
+	var op *domainconfig.OperatesOnConfig
+	var target *domainconfig.ServiceConfig
+	var mgr *applifecycle.Manager
+	// read the declaration of the job and the service operated on
+	if svc := s.config.FindService(name); svc != nil && svc.OperatesOn != nil {
+		op = svc.OperatesOn
+		target = s.config.FindService(op.Service)
+		mgr = s.managers[op.Service]
+	}
+	s.mu.RUnlock()
+
+	// the job operates on no service
+	if op == nil || target == nil || mgr == nil {
+		// return no state variables
+		return nil, nil
+	}
+	env := map[string]string{stateServiceEnv: target.Name, stateDirEnv: target.WorkingDirectory}
+	quiesce := jobQuiesce{op: *op}
+	var err error
+	// quiesce the running service
+	switch {
+	// a service not running has nothing to quiesce
+	case !mgr.State().IsRunning():
+	case op.Quiesce == domainconfig.QuiesceStop:
+		err = s.StopService(target.Name)
+		quiesce.acted = err == nil
+	case op.Quiesce == domainconfig.QuiesceReloadSignal:
+		err = mgr.Signal(target.AppReloadSignal())
+		quiesce.acted = err == nil
+	// the service stays running
+	default:
+	}
+	// the service could not be quiesced
+	if err != nil {
+		s.reportQuiesce(domain.EventQuiesceFailed, target.Name, fmt.Errorf("quiesce for job %s: %w", name, err))
+		// return quiesce error
+		return nil, fmt.Errorf("quiesce %s: %w", target.Name, err)
+	}
+	// report the quiesced service
+	if quiesce.acted {
+		s.reportQuiesce(domain.EventQuiesced, target.Name, fmt.Errorf("job %s", name))
+	}
+	s.mu.Lock()
+	// create the map on first use
+	if s.jobQuiesces == nil {
+		s.jobQuiesces = make(map[string]jobQuiesce)
+	}
+	s.jobQuiesces[name] = quiesce
+	s.mu.Unlock()
+	// return the state variables
+	return env, nil
+}
+
+// AfterJob resumes the service quiesced by BeforeJob once the job exited:
+// a stopped service is started again, a signaled one sent its reload
+// signal again. With verify_health, the service must then become healthy
+// within its health timeout. Nothing is resumed on shutdown.
+//
+// Params:
+//   - ctx: the lifecycle context of the job, cancelled on shutdown.
+//   - name: the job.
+func (s *Supervisor) AfterJob(ctx context.Context, name string) {
+	s.mu.Lock()
+	quiesce, ok := s.jobQuiesces[name]
+	delete(s.jobQuiesces, name)
+	mgr := s.managers[quiesce.op.Service]
+	target := s.config.FindService(quiesce.op.Service)
+	s.mu.Unlock()
+
+	// nothing quiesced, or the service went away with a reload
+	if !ok || mgr == nil || target == nil {
+		// nothing to resume
+		return
+	}
+	// the daemon is stopping every service
+	if ctx.Err() != nil {
+		// leave the service to the shutdown
+		return
+	}
+	var err error
+	// resume the quiesced service
+	if quiesce.acted {
+		// resume as quiesced
+		switch quiesce.op.Quiesce {
+		case domainconfig.QuiesceStop:
+			err = s.StartService(target.Name)
+		case domainconfig.QuiesceReloadSignal:
+			err = mgr.Signal(target.AppReloadSignal())
+		// QuiesceNone never acts
+		case domainconfig.QuiesceNone:
+		}
+	}
+	// check the service is back
+	if err == nil && quiesce.op.VerifyHealth {
+		err = s.awaitQuiescedHealth(ctx, target.Name, quiesce.op.EffectiveHealthTimeout())
+	}
+	// report the failed resume
+	if err != nil {
+		s.reportQuiesce(domain.EventQuiesceFailed, target.Name, fmt.Errorf("resume after job %s: %w", name, err))
+		// failure reported
+		return
+	}
+	// report the resumed service
+	if quiesce.acted {
+		s.reportQuiesce(domain.EventUnquiesced, target.Name, fmt.Errorf("job %s", name))
+	}
+}
+
+// awaitQuiescedHealth waits until a service resumed after a job is healthy.
+//
+// Params:
+//   - ctx: the lifecycle context of the job.
+//   - name: the resumed service.
+//   - timeout: the bound of the wait.
+//
+// Returns:
+//   - error: ErrQuiesceUnverified when the timeout elapsed, the context error on shutdown.
+func (s *Supervisor) awaitQuiescedHealth(ctx context.Context, name string, timeout time.Duration) error {
+	timer := s.clock.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(reloadPollInterval)
+	defer ticker.Stop()
+	// poll until the service is healthy
+	for {
+		// service healthy
+		if s.Healthy([]string{name}) {
+			// return verified
+			return nil
+		}
+		select {
+		// shutdown
+		case <-ctx.Done():
+			// return shutdown
+			return ctx.Err()
+		// timeout elapsed
+		case <-timer.C():
+			// return unverified
+			return fmt.Errorf("%w within %s", ErrQuiesceUnverified, timeout)
+		// check again
+		case <-ticker.C:
+		}
+	}
+}
+
+// reportQuiesce reports a quiesce event of the service a job operates on.
+//
+// Params:
+//   - eventType: the quiesce event type.
+//   - name: the service operated on.
+//   - err: the job or failure carried by the event.
+func (s *Supervisor) reportQuiesce(eventType domain.EventType, name string, err error) {
+	s.mu.RLock()
+	mgr := s.managers[name]
+	s.mu.RUnlock()
+	pid := 0
+	// read the process of the service
+	if mgr != nil {
+		pid = mgr.PID()
+	}
+	event := domain.NewEvent(eventType, name, pid, 0, err)
+	s.handleEvent(name, &event)
+}
@@ -0,0 +1,110 @@
+// Package supervisor provides internal tests for quiesce.go.
+// It tests the quiesce of the services jobs operate on using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// newQuiesceTestConfig builds a configuration with a running service "db"
+// and a backup job operating on it.
+//
+// Params:
+//   - op: the operated service declaration of the job.
+//
+// Returns:
+//   - *domainconfig.Config: the configuration.
+func newQuiesceTestConfig(op domainconfig.OperatesOnConfig) *domainconfig.Config {
+	return &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "db", Command: "/usr/bin/postgres", WorkingDirectory: "/var/lib/postgres"},
+		{Name: "backup", Command: "/usr/local/bin/backup", Oneshot: true, OperatesOn: &op},
+		{Name: "report", Command: "/usr/local/bin/report", Oneshot: true},
+	}}
+}
+
+// Test_Supervisor_BeforeJob_reloadSignal tests a job signaling the service
+// it operates on before and after its run.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_BeforeJob_reloadSignal(t *testing.T) {
+	cfg := newQuiesceTestConfig(domainconfig.OperatesOnConfig{Service: "db", Quiesce: domainconfig.QuiesceReloadSignal})
+	s, executor, _, events := newWatchTestSupervisor(t, cfg)
+
+	env, err := s.BeforeJob(context.Background(), "backup")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{stateServiceEnv: "db", stateDirEnv: "/var/lib/postgres"}, env)
+	assert.Equal(t, syscall.SIGHUP, <-executor.signals)
+
+	s.AfterJob(context.Background(), "backup")
+	assert.Equal(t, syscall.SIGHUP, <-executor.signals)
+
+	delivered := events()
+	require.Len(t, delivered, 2)
+	assert.Equal(t, domain.EventQuiesced, delivered[0].Type)
+	assert.Equal(t, "db", delivered[0].Process)
+	assert.Equal(t, domain.EventUnquiesced, delivered[1].Type)
+	assert.Empty(t, s.jobQuiesces)
+}
+
+// Test_Supervisor_BeforeJob_stop tests a job stopping the service it
+// operates on, then starting it again and verifying its health.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_BeforeJob_stop(t *testing.T) {
+	cfg := newQuiesceTestConfig(domainconfig.OperatesOnConfig{Service: "db", Quiesce: domainconfig.QuiesceStop,
+		VerifyHealth: true, HealthTimeout: shared.Duration(20 * time.Millisecond)})
+	s, executor, _, events := newWatchTestSupervisor(t, cfg)
+	s.SetClock(shared.RealClock{})
+
+	_, err := s.BeforeJob(context.Background(), "backup")
+	require.NoError(t, err)
+
+	s.AfterJob(context.Background(), "backup")
+	assert.Equal(t, int32(2), executor.starts.Load())
+
+	// The daemon status is never published, so the service is not healthy.
+	delivered := events()
+	require.NotEmpty(t, delivered)
+	last := delivered[len(delivered)-1]
+	assert.Equal(t, domain.EventQuiesceFailed, last.Type)
+	assert.ErrorIs(t, last.Error, ErrQuiesceUnverified)
+}
+
+// Test_Supervisor_BeforeJob_untouched tests jobs leaving services alone:
+// jobs without operates_on, and resumes on shutdown.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_BeforeJob_untouched(t *testing.T) {
+	cfg := newQuiesceTestConfig(domainconfig.OperatesOnConfig{Service: "db", Quiesce: domainconfig.QuiesceReloadSignal})
+	s, executor, _, events := newWatchTestSupervisor(t, cfg)
+
+	// A job without operates_on gets no state variables.
+	env, err := s.BeforeJob(context.Background(), "report")
+	require.NoError(t, err)
+	assert.Nil(t, env)
+	s.AfterJob(context.Background(), "report")
+	assert.Empty(t, events())
+
+	// Nothing is resumed on shutdown.
+	_, err = s.BeforeJob(context.Background(), "backup")
+	require.NoError(t, err)
+	<-executor.signals
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.AfterJob(ctx, "backup")
+	assert.Empty(t, executor.signals)
+	assert.Len(t, events(), 1)
+}
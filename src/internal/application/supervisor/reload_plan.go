@@ -0,0 +1,111 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file computes what a configuration reload would do, without applying it.
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+// PlanReload reports what reloading a configuration would do to the running
+// services, without applying it. The configuration goes through the checks
+// of a reload first, so a plan is only returned for a reload that would be
+// applied.
+//
+// Params:
+//   - newCfg: the validated configuration to plan.
+//
+// Returns:
+//   - domainlifecycle.ReloadPlan: the services added, removed and restarted.
+//   - error: ErrReloadRefused if the reload would be refused.
+func (s *Supervisor) PlanReload(newCfg *domainconfig.Config) (domainlifecycle.ReloadPlan, error) {
+	// Refuse plans of refused reloads.
+	if err := s.checkReload(newCfg); err != nil {
+		// Return the refusal.
+		return domainlifecycle.ReloadPlan{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// Return the plan against the running services.
+	return s.planServices(newCfg), nil
+}
+
+// checkReload runs the checks refusing a reloaded configuration before any
+// service is touched.
+//
+// Params:
+//   - newCfg: the configuration to check.
+//
+// Returns:
+//   - error: ErrReloadRefused wrapping the failed check, nil if it can be applied.
+func (s *Supervisor) checkReload(newCfg *domainconfig.Config) error {
+	// Refuse restart strategies nothing registered.
+	if err := checkRestartStrategies(newCfg); err != nil {
+		// Return the unknown strategy.
+		return fmt.Errorf("%w: %w", ErrReloadRefused, err)
+	}
+	// Refuse watcher events named like built-in ones.
+	if err := checkWatchers(newCfg); err != nil {
+		// Return the reserved name.
+		return fmt.Errorf("%w: %w", ErrReloadRefused, err)
+	}
+	// Refuse the configuration if it cannot be applied on this host.
+	if err := s.preflight(newCfg); err != nil {
+		// Return the pre-flight report.
+		return fmt.Errorf("%w: %w", ErrReloadRefused, err)
+	}
+	// The configuration can be applied.
+	return nil
+}
+
+// planServices compares the running services with a new configuration: its
+// services are added or restarted, the others removed, except ephemeral
+// services which outlive reloads. Restarted services list their changed
+// settings. Must be called with s.mu held.
+//
+// Params:
+//   - newCfg: the new configuration.
+//
+// Returns:
+//   - domainlifecycle.ReloadPlan: the services added, removed and restarted.
+func (s *Supervisor) planServices(newCfg *domainconfig.Config) domainlifecycle.ReloadPlan {
+	var plan domainlifecycle.ReloadPlan
+	kept := make(map[string]bool, len(newCfg.Services))
+	// plan the services of the new configuration
+	for i := range newCfg.Services {
+		svc := &newCfg.Services[i]
+		kept[svc.Name] = true
+		// start services without a manager
+		if _, exists := s.managers[svc.Name]; !exists {
+			plan.Services = append(plan.Services, domainlifecycle.ServicePlan{Name: svc.Name, Action: domainlifecycle.ReloadAdd})
+			continue
+		}
+		restart := domainlifecycle.ServicePlan{Name: svc.Name, Action: domainlifecycle.ReloadRestart}
+		// name the changed settings of configured services
+		if s.config != nil {
+			if current := s.config.FindService(svc.Name); current != nil {
+				restart.Changes = current.Changes(svc)
+			}
+		}
+		plan.Services = append(plan.Services, restart)
+	}
+
+	var removed []string
+	// stop the services left out, ephemeral ones aside
+	for name := range s.managers {
+		// keep configured and ephemeral services
+		if !kept[name] && !s.isEphemeral(name) {
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(removed)
+	// plan removals in name order
+	for _, name := range removed {
+		plan.Services = append(plan.Services, domainlifecycle.ServicePlan{Name: name, Action: domainlifecycle.ReloadRemove})
+	}
+	// return the plan
+	return plan
+}
@@ -0,0 +1,65 @@
+// Package supervisor provides internal tests for reload_plan.go.
+// It tests reload plans using white-box testing.
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_Supervisor_PlanReload tests the plan of a reloaded configuration.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_PlanReload(t *testing.T) {
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "web", Command: "/bin/web"},
+		{Name: "worker", Command: "/bin/worker"},
+		{Name: "old", Command: "/bin/old"},
+		{Name: "cache", Command: "/bin/cache"},
+	}}
+	executor := &ephemeralTestExecutor{}
+	managers := make(map[string]*applifecycle.Manager, len(cfg.Services)+1)
+	// manage every configured service
+	for i := range cfg.Services {
+		managers[cfg.Services[i].Name] = applifecycle.NewManager(&cfg.Services[i], executor)
+	}
+	debug := domainconfig.ServiceConfig{Name: "debug", Command: "/bin/sh"}
+	managers["debug"] = applifecycle.NewManager(&debug, executor)
+	s := &Supervisor{
+		config:     cfg,
+		managers:   managers,
+		ephemerals: map[string]*ephemeral{"debug": {}},
+	}
+
+	newCfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "web", Command: "/bin/web2", Environment: map[string]string{"PORT": "80"}},
+		{Name: "worker", Command: "/bin/worker"},
+		{Name: "api", Command: "/bin/api"},
+	}}
+	plan, err := s.PlanReload(newCfg)
+	require.NoError(t, err)
+	assert.Equal(t, []domainlifecycle.ServicePlan{
+		{Name: "web", Action: domainlifecycle.ReloadRestart, Changes: []string{"command", "environment"}},
+		{Name: "worker", Action: domainlifecycle.ReloadRestart},
+		{Name: "api", Action: domainlifecycle.ReloadAdd},
+		{Name: "cache", Action: domainlifecycle.ReloadRemove},
+		{Name: "old", Action: domainlifecycle.ReloadRemove},
+	}, plan.Services)
+	// Planning applies nothing.
+	assert.Len(t, s.managers, 5)
+	assert.Same(t, cfg, s.config)
+
+	// Refused reloads have no plan.
+	newCfg.Watchers = []domainconfig.WatcherConfig{{Name: "raid", Command: "/bin/check", OnFailure: "healthy"}}
+	_, err = s.PlanReload(newCfg)
+	require.ErrorIs(t, err, ErrReloadRefused)
+	assert.ErrorIs(t, err, domain.ErrReservedEventName)
+}
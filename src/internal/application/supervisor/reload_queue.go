@@ -0,0 +1,116 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file serializes configuration reloads through a coalescing queue.
+package supervisor
+
+import (
+	"sync"
+	"time"
+
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// reloadQueue holds at most one running and one queued reload.
+// The zero value is an idle queue.
+type reloadQueue struct {
+	// mu protects the queue fields.
+	mu sync.Mutex
+	// queued reports that a reload waits behind the running one.
+	queued bool
+	// waiters receive the result of the queued reload.
+	waiters []chan error
+	// status is the progress and last result.
+	status domainlifecycle.ReloadStatus
+}
+
+// RequestReload queues a configuration reload and returns at once.
+// While a reload is already queued, the request joins it instead of adding
+// another one, so a burst of requests during a long reload runs only once
+// more.
+//
+// Returns:
+//   - uint64: the number of the reload run serving this request.
+//   - <-chan error: receives the result of that run, then is closed.
+//
+// Goroutine lifecycle:
+//   - Spawns a worker when the queue is idle.
+//   - The worker exits once no reload is queued.
+func (s *Supervisor) RequestReload() (uint64, <-chan error) {
+	q := &s.reloads
+	result := make(chan error, 1)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.status.Requested++
+	// join the queued reload
+	if q.queued {
+		q.status.Coalesced++
+	} else {
+		q.queued = true
+		q.status.Pending = q.status.Running
+	}
+	q.waiters = append(q.waiters, result)
+	run := q.status.Started + 1
+
+	// start a worker when none is running
+	if !q.status.Running {
+		q.status.Running = true
+		go s.runReloads()
+	}
+	// return run number and result channel
+	return run, result
+}
+
+// ReloadStatus returns the progress and last result of configuration reloads.
+//
+// Returns:
+//   - domainlifecycle.ReloadStatus: the current status.
+func (s *Supervisor) ReloadStatus() domainlifecycle.ReloadStatus {
+	s.reloads.mu.Lock()
+	defer s.reloads.mu.Unlock()
+	// return status copy
+	return s.reloads.status
+}
+
+// runReloads applies queued reloads one at a time until none is left.
+func (s *Supervisor) runReloads() {
+	q := &s.reloads
+	// run until the queue is empty
+	for {
+		q.mu.Lock()
+		// stop when nothing is queued
+		if !q.queued {
+			q.status.Running = false
+			q.mu.Unlock()
+			// queue idle
+			return
+		}
+		waiters := q.waiters
+		q.waiters = nil
+		q.queued = false
+		q.status.Pending = false
+		q.status.Started++
+		q.status.LastStartedAt = time.Now()
+		q.mu.Unlock()
+
+		err := s.reload()
+
+		q.mu.Lock()
+		q.status.Completed++
+		q.status.LastFinishedAt = time.Now()
+		q.status.LastError = ""
+		q.status.LastErrorCode = ""
+		// record failure
+		if err != nil {
+			q.status.LastError = err.Error()
+			q.status.LastErrorCode = shared.CodeOf(err)
+		}
+		q.mu.Unlock()
+
+		// hand the result to every merged request
+		for _, w := range waiters {
+			w <- err
+			close(w)
+		}
+	}
+}
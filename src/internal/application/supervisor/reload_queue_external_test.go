@@ -0,0 +1,110 @@
+// Package supervisor_test provides black-box tests for reload_queue.go.
+package supervisor_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/supervisor"
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// slowFirstLoader blocks its first Load until released and counts calls.
+type slowFirstLoader struct {
+	// cfg is the configuration to return.
+	cfg *config.Config
+	// err is the error to return.
+	err error
+	// calls counts Load calls.
+	calls atomic.Int32
+	// entered is closed when the first Load starts.
+	entered chan struct{}
+	// release unblocks the first Load.
+	release chan struct{}
+}
+
+// Load blocks on the first call, then returns the configuration.
+//
+// Params:
+//   - path: the configuration path (unused).
+//
+// Returns:
+//   - *config.Config: the configuration.
+//   - error: the configured error.
+func (l *slowFirstLoader) Load(_ string) (*config.Config, error) {
+	// Only the first call blocks.
+	if l.calls.Add(1) == 1 {
+		close(l.entered)
+		<-l.release
+	}
+	return l.cfg, l.err
+}
+
+// TestSupervisor_RequestReload_Coalesces tests that requests during a reload collapse into one run.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_RequestReload_Coalesces(t *testing.T) {
+	tests := []struct {
+		name     string
+		loadErr  error
+		wantCode shared.Code
+	}{
+		{name: "applied"},
+		{name: "failed", loadErr: errors.New("bad yaml"), wantCode: shared.CodeUnknown},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createValidConfig()
+			loader := &slowFirstLoader{cfg: cfg, err: tt.loadErr, entered: make(chan struct{}), release: make(chan struct{})}
+			sup, err := supervisor.NewSupervisor(cfg, loader, &mockExecutor{}, nil)
+			require.NoError(t, err)
+			require.NoError(t, sup.Start(context.Background()))
+			defer func() { _ = sup.Stop() }()
+
+			first, firstResult := sup.RequestReload()
+			assert.Equal(t, uint64(1), first)
+			<-loader.entered
+
+			// A burst during the running reload shares the next run.
+			results := make([]<-chan error, 0, 3)
+			for range 3 {
+				run, result := sup.RequestReload()
+				assert.Equal(t, uint64(2), run)
+				results = append(results, result)
+			}
+			status := sup.ReloadStatus()
+			assert.True(t, status.Running)
+			assert.True(t, status.Pending)
+			assert.Equal(t, uint64(4), status.Requested)
+			assert.Equal(t, uint64(2), status.Coalesced)
+			assert.False(t, status.Done(1))
+
+			close(loader.release)
+			assert.Equal(t, tt.loadErr != nil, <-firstResult != nil)
+			for _, result := range results {
+				assert.Equal(t, tt.loadErr != nil, <-result != nil)
+			}
+
+			require.Eventually(t, func() bool { return !sup.ReloadStatus().Running }, time.Second, 5*time.Millisecond)
+			status = sup.ReloadStatus()
+			assert.Equal(t, int32(2), loader.calls.Load())
+			assert.Equal(t, uint64(2), status.Started)
+			assert.Equal(t, uint64(2), status.Completed)
+			assert.True(t, status.Done(2))
+			assert.False(t, status.Pending)
+			assert.Equal(t, tt.wantCode, status.LastErrorCode)
+			assert.Equal(t, tt.loadErr != nil, status.LastError != "")
+			assert.Equal(t, supervisor.StateRunning, sup.State())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file contains the metrics watcher driving cgroup resource detection.
+package supervisor
+
+// startResourceWatcher subscribes to metrics updates to detect cgroup CPU
+// throttling and resource pressure. It is skipped when no metrics tracker is
+// set or when neither throttling detection nor pressure alerts are configured.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine reading metrics updates.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startResourceWatcher() {
+	s.mu.RLock()
+	tracker := s.metricsTracker
+	metricsCfg := &s.config.Monitoring.Metrics
+	enabled := metricsCfg.CPU.ThrottleThreshold > 0 || len(metricsCfg.PressureAlerts) > 0
+	s.mu.RUnlock()
+
+	// Skip when tracking or detection is disabled.
+	if tracker == nil || !enabled {
+		// Nothing to watch.
+		return
+	}
+
+	updates := tracker.Subscribe()
+	// Skip when subscriber limit is reached.
+	if updates == nil {
+		// Subscription rejected.
+		return
+	}
+
+	// Watch updates until shutdown.
+	s.wg.Go(func() {
+		defer tracker.Unsubscribe(updates)
+		// Loop until context is cancelled or channel is closed.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case m, ok := <-updates:
+				// Check if the updates channel is closed.
+				if !ok {
+					// Return when channel is closed.
+					return
+				}
+				s.checkThrottling(&m)
+				s.checkPressure(&m)
+			}
+		}
+	})
+}
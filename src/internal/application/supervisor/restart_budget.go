@@ -0,0 +1,96 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file bounds the restarts performed across all services.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// ErrRestartBudgetExhausted is reported when a restart waits for the daemon restart budget.
+var ErrRestartBudgetExhausted error = errors.New("restart budget exhausted")
+
+// configureRestartBudget sets up the restart budget from the configuration.
+// The bucket is kept when the settings are unchanged, so a reload does not
+// refill it while services are crashing.
+//
+// Params:
+//   - cfg: the restart budget settings.
+func (s *Supervisor) configureRestartBudget(cfg domainconfig.RestartBudgetConfig) {
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+
+	// keep the running bucket
+	if s.restartBudget != nil && cfg == s.restartBudgetConfig {
+		// settings unchanged
+		return
+	}
+	s.restartBudgetConfig = cfg
+	s.restartBudget = nil
+	// budget disabled
+	if !cfg.Enabled() {
+		// restarts unbounded
+		return
+	}
+	s.restartBudget = domain.NewRestartBudget(cfg.PerMinute, cfg.EffectiveBurst())
+}
+
+// WaitRestart blocks until the restart budget allows the service to restart.
+// Restarts beyond the budget are queued in order and reported with a
+// restart_deferred event. In safe mode, the restarts of non-critical
+// services are held first, until safe mode is exited.
+//
+// Params:
+//   - ctx: abandons the wait when cancelled.
+//   - name: the restarting service.
+//
+// Returns:
+//   - error: the context error when the wait was abandoned.
+func (s *Supervisor) WaitRestart(ctx context.Context, name string) error {
+	// held by safe mode until the manager stops
+	if err := s.holdRestart(ctx, name); err != nil {
+		// return cancellation
+		return err
+	}
+	s.restartMu.Lock()
+	budget := s.restartBudget
+	// budget disabled
+	if budget == nil {
+		s.restartMu.Unlock()
+		// restart at once
+		return nil
+	}
+	wait := budget.Reserve(s.clock.Now())
+	s.restartMu.Unlock()
+
+	// token available
+	if wait <= 0 {
+		// restart at once
+		return nil
+	}
+	event := domain.NewEvent(domain.EventRestartDeferred, name, 0, 0,
+		fmt.Errorf("%w: restart deferred by %s", ErrRestartBudgetExhausted, wait.Round(time.Millisecond)))
+	s.handleEvent(name, &event)
+
+	timer := s.clock.NewTimer(wait)
+	defer timer.Stop()
+	// wait for the token or the end of the manager
+	select {
+	// token refilled
+	case <-timer.C():
+		// restart now
+		return nil
+	// restart abandoned
+	case <-ctx.Done():
+		s.restartMu.Lock()
+		budget.Cancel(s.clock.Now())
+		s.restartMu.Unlock()
+		// return cancellation
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,93 @@
+// Package supervisor provides internal tests for restart_budget.go.
+// It tests the restart budget using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Test_Supervisor_WaitRestart tests that restarts beyond the budget are deferred and reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_WaitRestart(t *testing.T) {
+	clock := shared.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	s := &Supervisor{stats: make(map[string]*ServiceStats), clock: clock}
+	var mu sync.Mutex
+	var deferred []*domain.Event
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		mu.Lock()
+		defer mu.Unlock()
+		deferred = append(deferred, event)
+	}
+	// One restart per 50ms, two at once.
+	s.configureRestartBudget(domainconfig.RestartBudgetConfig{PerMinute: 1200, Burst: 2})
+
+	ctx := context.Background()
+	require.NoError(t, s.WaitRestart(ctx, "api"))
+	require.NoError(t, s.WaitRestart(ctx, "web"))
+	mu.Lock()
+	assert.Empty(t, deferred)
+	mu.Unlock()
+
+	// The third restart waits for a refilled token.
+	done := make(chan error, 1)
+	go func() { done <- s.WaitRestart(ctx, "worker") }()
+	clock.BlockUntil(1)
+	clock.Advance(49 * time.Millisecond)
+	assert.Empty(t, done)
+	clock.Advance(time.Millisecond)
+	require.NoError(t, <-done)
+
+	mu.Lock()
+	require.Len(t, deferred, 1)
+	assert.Equal(t, domain.EventRestartDeferred, deferred[0].Type)
+	assert.Equal(t, "worker", deferred[0].Process)
+	assert.ErrorIs(t, deferred[0].Error, ErrRestartBudgetExhausted)
+	mu.Unlock()
+
+	// An abandoned wait returns its token.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, s.WaitRestart(cancelled, "api"), context.Canceled)
+	go func() { done <- s.WaitRestart(ctx, "api") }()
+	clock.BlockUntil(1)
+	clock.Advance(50 * time.Millisecond)
+	require.NoError(t, <-done)
+}
+
+// Test_Supervisor_configureRestartBudget tests that reloads keep or replace the budget.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_configureRestartBudget(t *testing.T) {
+	s := &Supervisor{}
+	cfg := domainconfig.RestartBudgetConfig{PerMinute: 10}
+
+	s.configureRestartBudget(cfg)
+	budget := s.restartBudget
+	require.NotNil(t, budget)
+
+	// Unchanged settings keep the drained bucket.
+	s.configureRestartBudget(cfg)
+	assert.Same(t, budget, s.restartBudget)
+
+	// Changed settings build a new bucket.
+	s.configureRestartBudget(domainconfig.RestartBudgetConfig{PerMinute: 20})
+	assert.NotSame(t, budget, s.restartBudget)
+
+	// Disabled budgets leave restarts unbounded.
+	s.configureRestartBudget(domainconfig.RestartBudgetConfig{})
+	assert.Nil(t, s.restartBudget)
+	assert.NoError(t, s.WaitRestart(context.Background(), "api"))
+}
@@ -0,0 +1,237 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file holds non-critical restarts in safe mode, entered on restart storms.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Safe mode errors, carried by the safe_mode_entered and safe_mode_exited events.
+var (
+	// ErrRestartStorm is reported when a restart storm enters safe mode.
+	ErrRestartStorm error = errors.New("restart storm")
+	// ErrSafeModeRequested is reported when safe mode is entered or exited on request.
+	ErrSafeModeRequested error = errors.New("requested")
+)
+
+// configureSafeMode sets up the restart storm detection from the
+// configuration. The restarts counted so far are kept when the settings
+// are unchanged. Safe mode itself survives reloads: only a request exits it.
+//
+// Params:
+//   - cfg: the safe mode settings.
+func (s *Supervisor) configureSafeMode(cfg domainconfig.SafeModeConfig) {
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+
+	// keep the running counter
+	if s.restartStorm != nil && cfg == s.safeModeConfig {
+		// settings unchanged
+		return
+	}
+	s.safeModeConfig = cfg
+	s.restartStorm = nil
+	// detection disabled
+	if !cfg.Enabled() {
+		// restarts not counted
+		return
+	}
+	s.restartStorm = domain.NewRestartStorm(cfg.EffectiveWindow())
+}
+
+// holdRestart counts an automatic restart, enters safe mode when the
+// restarts within the window exceed the threshold, and blocks the restart
+// of a non-critical service while the daemon is in safe mode.
+//
+// Params:
+//   - ctx: abandons the wait when cancelled.
+//   - name: the restarting service.
+//
+// Returns:
+//   - error: the context error when the wait was abandoned.
+func (s *Supervisor) holdRestart(ctx context.Context, name string) error {
+	critical := s.isCritical(name)
+	s.restartMu.Lock()
+	storm := s.recordRestart(name)
+	release := s.safeModeRelease
+	held := release != nil && !critical
+	// register the held restart
+	if held {
+		s.safeModeHeld[name]++
+	}
+	s.restartMu.Unlock()
+
+	// raise the single alert of the storm
+	if storm != nil {
+		s.reportSafeMode(domain.EventSafeModeEntered, storm)
+	}
+	// critical services and restarts outside safe mode go on
+	if !held {
+		// restart now
+		return nil
+	}
+	defer s.releaseHeld(name)
+	// wait for the end of safe mode or of the manager
+	select {
+	// safe mode exited
+	case <-release:
+		// restart now
+		return nil
+	// restart abandoned
+	case <-ctx.Done():
+		// return cancellation
+		return ctx.Err()
+	}
+}
+
+// isCritical reports whether a service is required for the daemon to run.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - bool: true for a critical service.
+func (s *Supervisor) isCritical(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// no configuration loaded yet
+	if s.config == nil {
+		// nothing is critical
+		return false
+	}
+	svc := s.config.FindService(name)
+	// return the service setting
+	return svc != nil && svc.Critical
+}
+
+// recordRestart counts a restart and enters safe mode on a storm.
+// The caller holds restartMu.
+//
+// Params:
+//   - name: the restarting service.
+//
+// Returns:
+//   - error: the storm that entered safe mode, nil otherwise.
+func (s *Supervisor) recordRestart(name string) error {
+	// detection disabled, or already in safe mode
+	if s.restartStorm == nil || s.safeModeRelease != nil {
+		// nothing to count
+		return nil
+	}
+	now := s.clock.Now()
+	restarts := s.restartStorm.Record(name, now)
+	// the rate is tolerated
+	if restarts <= s.safeModeConfig.Restarts {
+		// no storm
+		return nil
+	}
+	s.enterSafeMode(domain.SafeMode{Since: now, Restarts: restarts, Window: s.restartStorm.Window()})
+	// return the storm
+	return fmt.Errorf("%w: %d restarts within %s across %s", ErrRestartStorm,
+		restarts, s.restartStorm.Window(), strings.Join(s.restartStorm.Services(), ", "))
+}
+
+// enterSafeMode starts holding restarts. The caller holds restartMu.
+//
+// Params:
+//   - mode: the safe mode entered.
+func (s *Supervisor) enterSafeMode(mode domain.SafeMode) {
+	mode.Active = true
+	s.safeMode = mode
+	s.safeModeRelease = make(chan struct{})
+	s.safeModeHeld = make(map[string]int)
+}
+
+// releaseHeld unregisters a held restart once it proceeds or is abandoned.
+//
+// Params:
+//   - name: the restarting service.
+func (s *Supervisor) releaseHeld(name string) {
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+	s.safeModeHeld[name]--
+	// the service waits no more
+	if s.safeModeHeld[name] <= 0 {
+		delete(s.safeModeHeld, name)
+	}
+}
+
+// SetSafeMode enters or exits safe mode on request. Exiting releases the
+// held restarts, which then draw from the restart budget, and starts
+// counting restarts afresh.
+//
+// Params:
+//   - on: true to enter safe mode, false to exit it.
+//
+// Returns:
+//   - domain.SafeMode: the safe mode after the request.
+func (s *Supervisor) SetSafeMode(on bool) domain.SafeMode {
+	s.restartMu.Lock()
+	var eventType domain.EventType
+	var reason error
+	// change the mode when asked for the other one
+	switch {
+	// enter, holding the next restarts
+	case on && s.safeModeRelease == nil:
+		s.enterSafeMode(domain.SafeMode{Manual: true, Since: s.clock.Now()})
+		eventType, reason = domain.EventSafeModeEntered, ErrSafeModeRequested
+	// exit, releasing the held restarts
+	case !on && s.safeModeRelease != nil:
+		eventType = domain.EventSafeModeExited
+		reason = fmt.Errorf("%w: %d held restarts released", ErrSafeModeRequested, len(s.safeModeHeld))
+		close(s.safeModeRelease)
+		s.safeModeRelease = nil
+		s.safeMode = domain.SafeMode{}
+		// count the next storm from the exit
+		if s.restartStorm != nil {
+			s.restartStorm.Reset()
+		}
+	// already in the requested mode
+	default:
+	}
+	s.restartMu.Unlock()
+
+	// report the change
+	if reason != nil {
+		s.reportSafeMode(eventType, reason)
+	}
+	// return the resulting mode
+	return s.SafeMode()
+}
+
+// SafeMode returns the safe mode and the services whose restart it holds.
+//
+// Returns:
+//   - domain.SafeMode: the safe mode, inactive outside safe mode.
+func (s *Supervisor) SafeMode() domain.SafeMode {
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+
+	mode := s.safeMode
+	mode.Held = make([]string, 0, len(s.safeModeHeld))
+	// list the held services
+	for name := range s.safeModeHeld {
+		mode.Held = append(mode.Held, name)
+	}
+	slices.Sort(mode.Held)
+	// return the mode
+	return mode
+}
+
+// reportSafeMode emits a daemon-wide safe mode event.
+//
+// Params:
+//   - eventType: EventSafeModeEntered or EventSafeModeExited.
+//   - reason: the storm or request carried by the event.
+func (s *Supervisor) reportSafeMode(eventType domain.EventType, reason error) {
+	event := domain.NewEvent(eventType, "", 0, 0, reason)
+	s.callEventHandler("", &event, nil)
+}
@@ -0,0 +1,144 @@
+// Package supervisor provides internal tests for safe_mode.go.
+// It tests the restart storm detection and safe mode using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Test_Supervisor_holdRestart tests that a restart storm enters safe mode
+// and holds non-critical restarts until safe mode is exited.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_holdRestart(t *testing.T) {
+	clock := shared.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	s := &Supervisor{
+		stats: make(map[string]*ServiceStats),
+		clock: clock,
+		config: &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+			{Name: "db", Command: "/bin/db", Critical: true},
+			{Name: "api", Command: "/bin/api"},
+			{Name: "web", Command: "/bin/web"},
+		}},
+	}
+	var mu sync.Mutex
+	var events []*domain.Event
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+	s.configureSafeMode(domainconfig.SafeModeConfig{Restarts: 2})
+
+	ctx := context.Background()
+	require.NoError(t, s.WaitRestart(ctx, "api"))
+	require.NoError(t, s.WaitRestart(ctx, "web"))
+	assert.False(t, s.SafeMode().Active)
+
+	// The third restart within the window enters safe mode and is held.
+	done := make(chan error, 1)
+	go func() { done <- s.WaitRestart(ctx, "api") }()
+	require.Eventually(t, func() bool { return len(s.SafeMode().Held) == 1 }, time.Second, time.Millisecond)
+	mode := s.SafeMode()
+	assert.True(t, mode.Active)
+	assert.False(t, mode.Manual)
+	assert.Equal(t, 3, mode.Restarts)
+	assert.Equal(t, domainconfig.DefaultSafeModeWindow, mode.Window)
+	assert.Equal(t, []string{"api"}, mode.Held)
+
+	// Critical services restart in safe mode, without a second alert.
+	require.NoError(t, s.WaitRestart(ctx, "db"))
+	mu.Lock()
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.EventSafeModeEntered, events[0].Type)
+	assert.Empty(t, events[0].Process)
+	assert.ErrorIs(t, events[0].Error, ErrRestartStorm)
+	assert.Contains(t, events[0].Error.Error(), "3 restarts within 1m0s across api, web")
+	mu.Unlock()
+
+	// A cancelled manager abandons the held restart.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, s.WaitRestart(cancelled, "web"), context.Canceled)
+	assert.Equal(t, []string{"api"}, s.SafeMode().Held)
+
+	// Exiting releases the held restart.
+	assert.False(t, s.SetSafeMode(false).Active)
+	require.NoError(t, <-done)
+	assert.Empty(t, s.SafeMode().Held)
+	mu.Lock()
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.EventSafeModeExited, events[1].Type)
+	assert.ErrorIs(t, events[1].Error, ErrSafeModeRequested)
+	mu.Unlock()
+
+	// Restarts are counted afresh after the exit.
+	require.NoError(t, s.WaitRestart(ctx, "api"))
+	assert.False(t, s.SafeMode().Active)
+}
+
+// Test_Supervisor_SetSafeMode tests entering and exiting safe mode on request.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_SetSafeMode(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := &Supervisor{stats: make(map[string]*ServiceStats), clock: shared.NewFakeClock(now)}
+	var events []domain.EventType
+	s.eventHandler = func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		events = append(events, event.Type)
+	}
+
+	// Safe mode can be entered without storm detection.
+	mode := s.SetSafeMode(true)
+	assert.True(t, mode.Active)
+	assert.True(t, mode.Manual)
+	assert.Equal(t, now, mode.Since)
+	assert.Empty(t, mode.Held)
+
+	// Requests for the current mode change nothing.
+	s.SetSafeMode(true)
+	assert.Equal(t, []domain.EventType{domain.EventSafeModeEntered}, events)
+	s.SetSafeMode(false)
+	s.SetSafeMode(false)
+	assert.Equal(t, []domain.EventType{domain.EventSafeModeEntered, domain.EventSafeModeExited}, events)
+	assert.Equal(t, domain.SafeMode{Held: []string{}}, s.SafeMode())
+}
+
+// Test_Supervisor_configureSafeMode tests that reloads keep or replace the detection.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_configureSafeMode(t *testing.T) {
+	s := &Supervisor{stats: make(map[string]*ServiceStats), clock: shared.DefaultClock}
+	cfg := domainconfig.SafeModeConfig{Restarts: 10}
+
+	s.configureSafeMode(cfg)
+	storm := s.restartStorm
+	require.NotNil(t, storm)
+
+	// Unchanged settings keep the counted restarts.
+	s.configureSafeMode(cfg)
+	assert.Same(t, storm, s.restartStorm)
+
+	// Changed settings build a new counter.
+	s.configureSafeMode(domainconfig.SafeModeConfig{Restarts: 10, Window: shared.Duration(time.Minute * 5)})
+	assert.NotSame(t, storm, s.restartStorm)
+
+	// Reloads never exit safe mode.
+	s.SetSafeMode(true)
+	s.configureSafeMode(domainconfig.SafeModeConfig{})
+	assert.Nil(t, s.restartStorm)
+	assert.True(t, s.SafeMode().Active)
+}
@@ -0,0 +1,98 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file watches the secret files of services and applies their on_secret_change action.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// ErrSecretChanged is attached to secret changed events. The event names
+// the variable and the file, never the value.
+var ErrSecretChanged error = fmt.Errorf("secret rotated")
+
+// watchSecrets starts the watch of the secret files of a service.
+// Must be called with s.mu held, from watchFiles.
+//
+// Params:
+//   - ctx: the watch context of the configuration.
+//   - svc: the service configuration.
+func (s *Supervisor) watchSecrets(ctx context.Context, svc *domainconfig.ServiceConfig) {
+	// Skip services without secrets.
+	if len(svc.Secrets) == 0 {
+		// Nothing to watch.
+		return
+	}
+	name, action := svc.Name, svc.OnSecretChange
+	secrets := svc.Secrets
+	s.startFileWatch(ctx, name, appintegrity.Target{
+		Paths:    svc.SecretFiles(),
+		Interval: domainconfig.DefaultSecretInterval,
+		Debounce: domainconfig.DefaultWatchDebounce,
+	}, func(paths []string) {
+		// Report the rotation and run the action.
+		s.secretsChanged(name, action, secrets, paths)
+	})
+}
+
+// secretsChanged emits one secret changed event per rotated file, then
+// reloads or restarts the service once, as on_secret_change requests.
+// The new values reach the environment at the next start: a reload suits
+// services reading the files themselves.
+//
+// Params:
+//   - name: the service name.
+//   - action: the on_secret_change action.
+//   - secrets: the secrets of the service.
+//   - paths: the changed files.
+func (s *Supervisor) secretsChanged(name string, action domainconfig.SecretChangeAction, secrets []domainconfig.SecretConfig, paths []string) {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	snap := s.getStatsSnapshot(s.stats[name])
+	s.mu.RUnlock()
+
+	// Skip services removed meanwhile.
+	if !ok {
+		// Service gone.
+		return
+	}
+	// report each variable whose file changed
+	for i := range secrets {
+		secret := &secrets[i]
+		// Skip unchanged files.
+		if !slices.Contains(paths, secret.File) {
+			continue
+		}
+		event := domain.NewEvent(domain.EventSecretChanged, name, mgr.PID(), 0,
+			fmt.Errorf("%w: %s", ErrSecretChanged, secret.Env))
+		event.File = secret.File
+		s.callEventHandler(name, &event, snap)
+	}
+	// run the action
+	switch action {
+	// restart with the new values
+	case domainconfig.SecretChangeRestart:
+		s.restartOnChange(name, mgr, "secret-restart")
+	// let the service read its secrets again
+	case domainconfig.SecretChangeReload:
+		// Only a running process can reload.
+		if !mgr.State().IsRunning() {
+			// Nothing to reload.
+			return
+		}
+		// Report reloads that failed.
+		if err := s.ReloadService(name); err != nil {
+			s.handleRecoveryError("secret-reload", name, err)
+		}
+	// events only
+	case domainconfig.SecretChangeNone:
+		// Events already emitted.
+	default:
+		// Unknown action, rejected by validation.
+	}
+}
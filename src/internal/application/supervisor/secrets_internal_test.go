@@ -0,0 +1,94 @@
+// Package supervisor provides internal tests for secrets.go.
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_Supervisor_watchSecrets tests the secret changed events and the
+// on_secret_change actions.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_watchSecrets(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// action is the on_secret_change action.
+		action domainconfig.SecretChangeAction
+		// check verifies the action.
+		check func(t *testing.T, executor *countingExecutor)
+	}{
+		{
+			name: "events_only",
+			check: func(t *testing.T, executor *countingExecutor) {
+				assert.Equal(t, int32(1), executor.starts.Load())
+				assert.Empty(t, executor.signals)
+			},
+		},
+		{
+			name:   "restart",
+			action: domainconfig.SecretChangeRestart,
+			check: func(t *testing.T, executor *countingExecutor) {
+				require.Eventually(t, func() bool { return executor.starts.Load() == 2 }, time.Second, time.Millisecond)
+			},
+		},
+		{
+			name:   "reload",
+			action: domainconfig.SecretChangeReload,
+			check: func(t *testing.T, executor *countingExecutor) {
+				assert.Equal(t, syscall.SIGHUP, <-executor.signals)
+				assert.Equal(t, int32(1), executor.starts.Load())
+			},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			db, upstream := filepath.Join(dir, "db"), filepath.Join(dir, "upstream")
+			require.NoError(t, os.WriteFile(db, []byte("hunter2\n"), 0o600))
+			require.NoError(t, os.WriteFile(upstream, []byte("s3cr3t\n"), 0o600))
+			cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+				{Name: "api", Command: "/bin/api", OnSecretChange: tt.action, Secrets: []domainconfig.SecretConfig{
+					{Env: "DB_PASSWORD", File: db},
+					{Env: "UPSTREAM", File: upstream},
+				}},
+				{Name: "worker", Command: "/bin/worker"},
+			}}
+			s, executor, watcher, events := newWatchTestSupervisor(t, cfg)
+
+			s.startFileWatches()
+
+			// Only the service with secrets is watched.
+			require.Eventually(t, func() bool { return len(watcher.started()) == 1 }, time.Second, time.Millisecond)
+			started := watcher.started()[0]
+			assert.Equal(t, []string{db, upstream}, started.target.Paths)
+			assert.Equal(t, domainconfig.DefaultSecretInterval, started.target.Interval)
+
+			started.onChange([]string{upstream})
+
+			// The rotated variable is reported, without its value.
+			delivered := events()
+			require.NotEmpty(t, delivered)
+			assert.Equal(t, domain.EventSecretChanged, delivered[0].Type)
+			assert.Equal(t, upstream, delivered[0].File)
+			require.ErrorIs(t, delivered[0].Error, ErrSecretChanged)
+			assert.Contains(t, delivered[0].Error.Error(), "UPSTREAM")
+			assert.NotContains(t, delivered[0].Error.Error(), "s3cr3t")
+			tt.check(t, executor)
+		})
+	}
+}
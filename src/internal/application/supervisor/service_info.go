@@ -14,4 +14,6 @@ type ServiceInfo struct {
 	PID int
 	// Uptime is the uptime in seconds.
 	Uptime int64
+	// Reason lists the unmet start conditions of a skipped service.
+	Reason string
 }
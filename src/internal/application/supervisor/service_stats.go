@@ -2,7 +2,15 @@
 // It manages the lifecycle of services including start, stop, restart, and reload operations.
 package supervisor
 
-import "sync/atomic"
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
 
 // ServiceStats holds statistics for a single service using atomic counters.
 // It tracks the number of starts, stops, failures, and restarts that have occurred
@@ -10,17 +18,34 @@ import "sync/atomic"
 // service health and identifying problematic services that may be restarting frequently.
 //
 // All counters use atomic operations for lock-free thread safety.
+// Up and down time are accounted from the first start or stop, and the
+// time the daemon itself is not running is counted as neither.
 //
 // Fields:
 //   - startCount: Number of times the service has been started.
 //   - stopCount: Number of times the service has stopped normally.
 //   - failCount: Number of times the service has failed (non-zero exit or crash).
 //   - restartCount: Number of times the service has been automatically restarted.
+//   - mu: Guards the time accounting below.
+//   - running: Whether the service is up since the since time.
+//   - since: Start of the current up or down interval, zero before the first one.
+//   - uptime: Cumulative time the service was running.
+//   - downtime: Cumulative time the service was not running.
+//   - hours: Hourly up and down time of the last 30 days.
+//   - exits: Daily exit causes of the failures of the last 30 days.
 type ServiceStats struct {
 	startCount   atomic.Int64
 	stopCount    atomic.Int64
 	failCount    atomic.Int64
 	restartCount atomic.Int64
+
+	mu       sync.Mutex
+	running  bool
+	since    time.Time
+	uptime   time.Duration
+	downtime time.Duration
+	hours    metrics.AvailabilityLog
+	exits    metrics.ExitLog
 }
 
 // NewServiceStats creates a new ServiceStats instance with zero values.
@@ -83,6 +108,46 @@ func (s *ServiceStats) FailCount() int {
 	return int(s.failCount.Load())
 }
 
+// RecordExit counts a failure in the exit cause histogram.
+//
+// Params:
+//   - cause: how the process ended.
+//   - at: when it ended.
+func (s *ServiceStats) RecordExit(cause metrics.ExitCause, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// count the cause on its day
+	s.exits = s.exits.Record(cause, at)
+}
+
+// TopExits returns the most frequent failure modes of the last 30 days.
+//
+// Params:
+//   - now: the current time.
+//
+// Returns:
+//   - []metrics.ExitCount: at most metrics.TopExitCauses causes, most frequent first.
+func (s *ServiceStats) TopExits(now time.Time) []metrics.ExitCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// rank the causes of the retention
+	return s.exits.Top(now, metrics.AvailabilityMonth, metrics.TopExitCauses)
+}
+
+// Exits returns the failures of each cause over the last 30 days.
+//
+// Params:
+//   - now: the current time.
+//
+// Returns:
+//   - []metrics.ExitCount: every cause, most frequent first.
+func (s *ServiceStats) Exits(now time.Time) []metrics.ExitCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// count every cause of the retention
+	return s.exits.Top(now, metrics.AvailabilityMonth, 0)
+}
+
 // RestartCount returns the current restart count.
 //
 // Returns:
@@ -92,21 +157,122 @@ func (s *ServiceStats) RestartCount() int {
 	return int(s.restartCount.Load())
 }
 
-// Snapshot returns a copy of all counters for safe reading.
-// This is useful when you need all values at a consistent point in time.
+// MarkUp records that the service is running from the given time.
+//
+// Params:
+//   - at: when the service started.
+func (s *ServiceStats) MarkUp(at time.Time) {
+	// open an up interval
+	s.markState(at, true)
+}
+
+// MarkDown records that the service is not running from the given time.
+//
+// Params:
+//   - at: when the service stopped.
+func (s *ServiceStats) MarkDown(at time.Time) {
+	// open a down interval
+	s.markState(at, false)
+}
+
+// markState closes the current interval and opens a new one.
+//
+// Params:
+//   - at: when the state changed.
+//   - running: the new state.
+func (s *ServiceStats) markState(at time.Time, running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accrue(at)
+	s.running = running
+	s.since = at
+}
+
+// accrue accounts the current interval up to now. The caller holds mu.
+//
+// Params:
+//   - now: the end of the accounted time.
+func (s *ServiceStats) accrue(now time.Time) {
+	// no interval opened yet
+	if s.since.IsZero() {
+		// nothing to account
+		return
+	}
+	// the clock went backwards: restart the interval
+	if now.Before(s.since) {
+		s.since = now
+		// nothing to account
+		return
+	}
+	elapsed := now.Sub(s.since)
+	// account the elapsed time
+	if s.running {
+		s.uptime += elapsed
+	} else {
+		s.downtime += elapsed
+	}
+	// bucket the monotonic elapsed time, ending now, so a wall clock jump
+	// is not accounted as up or down time
+	s.hours = s.hours.Record(now.Add(-elapsed), now, s.running)
+	s.since = now
+}
+
+// Record returns the statistics to persist, accounted up to now.
+//
+// Params:
+//   - name: the service name.
+//   - now: the current time.
 //
 // Returns:
-//   - ServiceStatsSnapshot: a copy of all counter values.
-func (s *ServiceStats) Snapshot() ServiceStatsSnapshot {
-	// construct snapshot with current values
-	return ServiceStatsSnapshot{
+//   - storage.ServiceStatsRecord: the lifetime statistics of the service.
+func (s *ServiceStats) Record(name string, now time.Time) storage.ServiceStatsRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accrue(now)
+	// copy counters and time accounting
+	return storage.ServiceStatsRecord{
+		Service:      name,
 		StartCount:   int(s.startCount.Load()),
 		StopCount:    int(s.stopCount.Load()),
 		FailCount:    int(s.failCount.Load()),
 		RestartCount: int(s.restartCount.Load()),
+		Uptime:       s.uptime,
+		Downtime:     s.downtime,
+		Hours:        slices.Clone(s.hours),
+		Exits:        slices.Clone(s.exits.Trim(now)),
+		UpdatedAt:    now,
 	}
 }
 
+// Restore adds persisted statistics to the current ones, so counters and
+// availability carry on across daemon restarts.
+//
+// Params:
+//   - rec: the persisted statistics.
+func (s *ServiceStats) Restore(rec *storage.ServiceStatsRecord) {
+	s.startCount.Add(int64(rec.StartCount))
+	s.stopCount.Add(int64(rec.StopCount))
+	s.failCount.Add(int64(rec.FailCount))
+	s.restartCount.Add(int64(rec.RestartCount))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uptime += rec.Uptime
+	s.downtime += rec.Downtime
+	s.hours = s.hours.Merge(rec.Hours)
+	s.exits = s.exits.Merge(rec.Exits)
+}
+
+// Snapshot returns a copy of all counters for safe reading.
+// This is useful when you need all values at a consistent point in time.
+//
+// Returns:
+//   - ServiceStatsSnapshot: a copy of all counter values.
+func (s *ServiceStats) Snapshot() ServiceStatsSnapshot {
+	// construct snapshot with current values
+	return s.snapshotAt(time.Now())
+}
+
 // SnapshotPtr returns a pointer to a copy of all counters.
 // Use this instead of &Snapshot() to avoid escape analysis issues
 // where taking address of return value causes heap allocation.
@@ -114,11 +280,30 @@ func (s *ServiceStats) Snapshot() ServiceStatsSnapshot {
 // Returns:
 //   - *ServiceStatsSnapshot: a pointer to a copy of all counter values.
 func (s *ServiceStats) SnapshotPtr() *ServiceStatsSnapshot {
-	// construct snapshot pointer with current values
-	return &ServiceStatsSnapshot{
+	snap := s.snapshotAt(time.Now())
+	// return snapshot pointer with current values
+	return &snap
+}
+
+// snapshotAt copies all counters, with time accounted up to now.
+//
+// Params:
+//   - now: the current time.
+//
+// Returns:
+//   - ServiceStatsSnapshot: a copy of all counter values.
+func (s *ServiceStats) snapshotAt(now time.Time) ServiceStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accrue(now)
+	// construct snapshot with current values
+	return ServiceStatsSnapshot{
 		StartCount:   int(s.startCount.Load()),
 		StopCount:    int(s.stopCount.Load()),
 		FailCount:    int(s.failCount.Load()),
 		RestartCount: int(s.restartCount.Load()),
+		Uptime:       s.uptime,
+		Downtime:     s.downtime,
+		Availability: s.hours.Availability(now),
 	}
 }
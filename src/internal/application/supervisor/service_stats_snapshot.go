@@ -2,6 +2,12 @@
 // It manages the lifecycle of services including start, stop, restart, and reload operations.
 package supervisor
 
+import (
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
 // ServiceStatsSnapshot is an immutable copy of ServiceStats counters.
 // Used for passing stats to callbacks without race conditions.
 type ServiceStatsSnapshot struct {
@@ -9,4 +15,10 @@ type ServiceStatsSnapshot struct {
 	StopCount    int `dto:"out,priv,pub" json:"stopCount"`
 	FailCount    int `dto:"out,priv,pub" json:"failCount"`
 	RestartCount int `dto:"out,priv,pub" json:"restartCount"`
+	// Uptime is the cumulative time the service was running.
+	Uptime time.Duration `dto:"out,priv,pub" json:"uptime"`
+	// Downtime is the cumulative time the service was not running.
+	Downtime time.Duration `dto:"out,priv,pub" json:"downtime"`
+	// Availability is the share of time the service was up over 1d, 7d and 30d.
+	Availability metrics.Availability `dto:"out,priv,pub" json:"availability"`
 }
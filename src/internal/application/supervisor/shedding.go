@@ -0,0 +1,234 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file sheds low-priority services under host memory pressure.
+package supervisor
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// ErrShed is attached to shed events when host memory pressure exceeds the shedding threshold.
+var ErrShed error = fmt.Errorf("stopped to relieve host memory pressure")
+
+// SetHostPressureCollector sets the collector of host memory pressure
+// driving shedding. Without it, services are never shed.
+//
+// Params:
+//   - collector: the host pressure collector.
+func (s *Supervisor) SetHostPressureCollector(collector appmetrics.HostPressureCollector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store host pressure collector
+	s.hostPressure = collector
+}
+
+// startShedWatcher checks host memory pressure periodically, stopping the
+// lowest priority class still running above the threshold and starting the
+// highest shed class again below the resume threshold. It is skipped
+// without host pressure collector, or when the host inventory reports no
+// pressure stall information.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine checking pressure on a ticker.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startShedWatcher() {
+	s.mu.RLock()
+	collector := s.hostPressure
+	interval := s.config.Shedding.EffectiveInterval()
+	s.mu.RUnlock()
+
+	// Skip without pressure source.
+	if collector == nil {
+		// Nothing to watch.
+		return
+	}
+	// Skip on a host without pressure stall information.
+	if !s.featureSupport(domainmetrics.FeatureShedding).Supported {
+		// Nothing to watch.
+		return
+	}
+
+	// Check until shutdown.
+	s.wg.Go(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		// Loop until context is cancelled.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case <-ticker.C:
+				// Follow interval changes of reloads.
+				ticker.Reset(s.checkShedding(collector))
+			}
+		}
+	})
+}
+
+// checkShedding compares host memory pressure with the shedding thresholds
+// of the running configuration, and sheds or resumes one priority class.
+//
+// Params:
+//   - collector: the host pressure collector.
+//
+// Returns:
+//   - time.Duration: the interval until the next check.
+func (s *Supervisor) checkShedding(collector appmetrics.HostPressureCollector) time.Duration {
+	s.mu.RLock()
+	cfg := s.config.Shedding
+	s.mu.RUnlock()
+
+	// Shedding is disabled by the configuration.
+	if !cfg.Enabled() {
+		// Check again later in case a reload enables it.
+		return cfg.EffectiveInterval()
+	}
+	pressure, err := collector.CollectHostPressure(s.ctx)
+	// Report missing PSI and keep services running.
+	if err != nil {
+		s.handleRecoveryError("collect-host-pressure", "", err)
+		// Check again later.
+		return cfg.EffectiveInterval()
+	}
+	value, _ := pressure.Value(domainmetrics.PressureResourceMemory, cfg.EffectiveScope(), cfg.EffectiveWindow())
+
+	// Shed above the threshold, resume below the resume threshold.
+	switch {
+	case value > cfg.Threshold:
+		s.shedClass(fmt.Errorf("%w: memory %s %s at %.1f%% exceeds %.1f%%",
+			ErrShed, cfg.EffectiveScope(), cfg.EffectiveWindow(), value, cfg.Threshold))
+	case value < cfg.EffectiveResume():
+		s.resumeClass()
+	// Between thresholds, nothing changes.
+	default:
+	}
+	// Check again after the interval.
+	return cfg.EffectiveInterval()
+}
+
+// shedClass stops the running services of the lowest sheddable priority class.
+//
+// Params:
+//   - cause: the error attached to the shed events.
+func (s *Supervisor) shedClass(cause error) {
+	s.mu.Lock()
+	names := s.priorityClass(func(svc *domainconfig.ServiceConfig) bool {
+		mgr := s.managers[svc.Name]
+		// Only running, sheddable services not shed yet qualify.
+		return svc.Priority.Sheddable() && !svc.Oneshot && !s.shed[svc.Name] && mgr != nil && mgr.Supervised()
+	}, false)
+	managers := make([]*applifecycle.Manager, 0, len(names))
+	// Record the shed services before stopping them.
+	for _, name := range names {
+		// Create the set on first use.
+		if s.shed == nil {
+			s.shed = make(map[string]bool)
+		}
+		s.shed[name] = true
+		managers = append(managers, s.managers[name])
+	}
+	s.mu.Unlock()
+
+	// Stop each service outside the lock.
+	for i, name := range names {
+		event := domain.NewEvent(domain.EventShed, name, managers[i].PID(), 0, cause)
+		s.handleEvent(name, &event)
+		// Let in-flight proxied connections finish first.
+		s.drainProxies(name)
+		// Stop the service (best-effort).
+		if err := managers[i].Stop(); err != nil {
+			s.handleRecoveryError("shed", name, err)
+		}
+	}
+}
+
+// resumeClass starts again the shed services of the highest priority class.
+func (s *Supervisor) resumeClass() {
+	s.mu.Lock()
+	names := s.priorityClass(func(svc *domainconfig.ServiceConfig) bool {
+		// Only shed services qualify.
+		return s.shed[svc.Name] && s.managers[svc.Name] != nil
+	}, true)
+	managers := make([]*applifecycle.Manager, 0, len(names))
+	// Forget the shed services before starting them.
+	for _, name := range names {
+		delete(s.shed, name)
+		managers = append(managers, s.managers[name])
+	}
+	s.mu.Unlock()
+
+	// Start each service outside the lock.
+	for i, name := range names {
+		event := domain.NewEvent(domain.EventShedResumed, name, 0, 0, nil)
+		s.handleEvent(name, &event)
+		// Start the service (best-effort).
+		if err := managers[i].Start(s.ctx); err != nil {
+			s.handleRecoveryError("resume-shed", name, err)
+		}
+	}
+}
+
+// priorityClass returns the services matching a filter within their lowest,
+// or highest, priority class. Must be called with s.mu held.
+//
+// Params:
+//   - match: the filter of candidate services.
+//   - highest: true to select the highest class instead of the lowest.
+//
+// Returns:
+//   - []string: the services of the selected class, in configuration order.
+func (s *Supervisor) priorityClass(match func(svc *domainconfig.ServiceConfig) bool, highest bool) []string {
+	var names []string
+	rank := -1
+	// Keep the candidates of the best rank seen so far.
+	for i := range s.config.Services {
+		svc := &s.config.Services[i]
+		// Skip services not matching.
+		if !match(svc) {
+			continue
+		}
+		r := svc.Priority.Rank()
+		better := rank < 0 || (highest && r > rank) || (!highest && r < rank)
+		// Start a new class on a better rank.
+		if better {
+			rank, names = r, nil
+		}
+		// Collect services of the selected rank.
+		if r == rank {
+			names = append(names, svc.Name)
+		}
+	}
+	// return selected class
+	return names
+}
+
+// forgetShed forgets that a service was shed, once it is started or
+// stopped by hand, so it is not resumed behind the operator's back.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) forgetShed(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.shed, name)
+}
+
+// Shed returns the services stopped to relieve host memory pressure.
+//
+// Returns:
+//   - []string: the shed services, sorted.
+func (s *Supervisor) Shed() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// return sorted names
+	return slices.Sorted(maps.Keys(s.shed))
+}
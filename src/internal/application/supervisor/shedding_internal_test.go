@@ -0,0 +1,191 @@
+// Package supervisor provides internal tests for shedding.go.
+// It tests the shedding of services under host memory pressure using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// hostPressureTestCollector returns a settable host memory pressure.
+type hostPressureTestCollector struct {
+	// memory is the returned memory some avg10 percentage.
+	memory float64
+	// err is the returned error.
+	err error
+	// calls counts the collections.
+	calls int
+}
+
+// CollectHostPressure returns the configured memory pressure.
+//
+// Returns:
+//   - domainmetrics.ResourcePressure: the pressure sample.
+//   - error: the configured error.
+func (c *hostPressureTestCollector) CollectHostPressure(_ context.Context) (domainmetrics.ResourcePressure, error) {
+	c.calls++
+	return domainmetrics.ResourcePressure{Memory: domainmetrics.Pressure{SomeAvg10: c.memory}}, c.err
+}
+
+// newShedTestSupervisor creates a running supervisor with "batch" (low),
+// "cache" and "worker" (normal), "api" (high) and a oneshot "migrate" (low),
+// shedding above 40% memory pressure and resuming below 20%.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *Supervisor: the supervisor under test.
+//   - *[]string: the delivered events, as type:service.
+func newShedTestSupervisor(t *testing.T) (*Supervisor, *[]string) {
+	t.Helper()
+	cfg := &domainconfig.Config{Shedding: domainconfig.SheddingConfig{Threshold: 40}, Services: []domainconfig.ServiceConfig{
+		{Name: "api", Command: "/bin/api", Priority: domainconfig.PriorityHigh},
+		{Name: "cache", Command: "/bin/cache"},
+		{Name: "batch", Command: "/bin/batch", Priority: domainconfig.PriorityLow},
+		{Name: "worker", Command: "/bin/worker", Priority: domainconfig.PriorityNormal},
+		{Name: "migrate", Command: "/bin/migrate", Priority: domainconfig.PriorityLow, Oneshot: true},
+	}}
+
+	executor := &phaseTestExecutor{}
+	managers := make(map[string]*applifecycle.Manager, len(cfg.Services))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	for i := range cfg.Services {
+		mgr := applifecycle.NewManager(&cfg.Services[i], executor)
+		require.NoError(t, mgr.Start(ctx))
+		managers[cfg.Services[i].Name] = mgr
+	}
+	t.Cleanup(func() {
+		for _, mgr := range managers {
+			_ = mgr.Stop()
+		}
+	})
+
+	var events []string
+	s := &Supervisor{config: cfg, managers: managers, stats: make(map[string]*ServiceStats), ctx: ctx}
+	s.eventHandler = func(name string, event *domain.Event, _ *ServiceStatsSnapshot) {
+		events = append(events, event.Type.String()+":"+name)
+	}
+	return s, &events
+}
+
+// Test_Supervisor_checkShedding tests that classes are shed lowest first and resumed highest first.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkShedding(t *testing.T) {
+	s, events := newShedTestSupervisor(t)
+	collector := &hostPressureTestCollector{}
+
+	steps := []struct {
+		// memory is the host memory pressure of the check.
+		memory float64
+		// wantShed is the expected shed services after the check.
+		wantShed []string
+		// wantEvents is the expected events of the check.
+		wantEvents []string
+	}{
+		{memory: 50, wantShed: []string{"batch"}, wantEvents: []string{"shed:batch"}},
+		{memory: 45, wantShed: []string{"batch", "cache", "worker"}, wantEvents: []string{"shed:cache", "shed:worker"}},
+		{memory: 60, wantShed: []string{"batch", "cache", "worker"}},
+		{memory: 30, wantShed: []string{"batch", "cache", "worker"}},
+		{memory: 10, wantShed: []string{"batch"}, wantEvents: []string{"shed_resumed:cache", "shed_resumed:worker"}},
+		{memory: 10, wantShed: nil, wantEvents: []string{"shed_resumed:batch"}},
+	}
+
+	// Run each check in order.
+	for _, step := range steps {
+		*events = nil
+		collector.memory = step.memory
+
+		s.checkShedding(collector)
+
+		assert.Equal(t, step.wantShed, s.Shed(), "memory %.0f", step.memory)
+		assert.Equal(t, step.wantEvents, filterShedEvents(*events), "memory %.0f", step.memory)
+		// Shed services are stopped, the others keep running.
+		for name, mgr := range s.managers {
+			assert.Equal(t, !s.shed[name], mgr.Supervised(), name)
+		}
+	}
+}
+
+// Test_Supervisor_checkShedding_skipped tests that shedding needs a threshold and PSI.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkShedding_skipped(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// threshold is the shedding threshold.
+		threshold float64
+		// err is the collector error.
+		err error
+		// wantCalls is the expected number of collections.
+		wantCalls int
+	}{
+		{name: "disabled", wantCalls: 0},
+		{name: "no_psi", threshold: 40, err: errors.New("no pressure files"), wantCalls: 1},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newShedTestSupervisor(t)
+			s.config.Shedding.Threshold = tt.threshold
+			collector := &hostPressureTestCollector{memory: 90, err: tt.err}
+
+			s.checkShedding(collector)
+
+			assert.Equal(t, tt.wantCalls, collector.calls)
+			assert.Empty(t, s.Shed())
+		})
+	}
+}
+
+// Test_Supervisor_forgetShed tests that a manual stop keeps a shed service stopped.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_forgetShed(t *testing.T) {
+	s, _ := newShedTestSupervisor(t)
+	collector := &hostPressureTestCollector{memory: 50}
+	s.checkShedding(collector)
+	require.Equal(t, []string{"batch"}, s.Shed())
+
+	require.NoError(t, s.StopService("batch"))
+	collector.memory = 0
+	s.checkShedding(collector)
+
+	assert.Empty(t, s.Shed())
+	assert.False(t, s.managers["batch"].Supervised())
+}
+
+// filterShedEvents keeps the shed and shed_resumed events.
+//
+// Params:
+//   - events: the delivered events, as type:service.
+//
+// Returns:
+//   - []string: the shedding events.
+func filterShedEvents(events []string) []string {
+	var kept []string
+	for _, e := range events {
+		if strings.HasPrefix(e, "shed:") || strings.HasPrefix(e, "shed_resumed:") {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
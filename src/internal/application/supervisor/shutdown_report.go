@@ -0,0 +1,99 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file reports the final statistics and health of the services at shutdown.
+package supervisor
+
+import (
+	"slices"
+	"time"
+
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// ShutdownHandler is a callback invoked once the services are stopped by a
+// graceful shutdown. It runs before Stop returns, so the daemon waits for it.
+type ShutdownHandler func(report domainlifecycle.ShutdownReport)
+
+// SetShutdownHandler sets the callback for the shutdown report.
+// Without a handler, no report is built.
+//
+// Params:
+//   - handler: the callback function to invoke when the services are stopped.
+func (s *Supervisor) SetShutdownHandler(handler ShutdownHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store shutdown handler
+	s.shutdownHandler = handler
+}
+
+// beginShutdownReport records the health of each service when the shutdown
+// begins, before probes and processes are stopped.
+func (s *Supervisor) beginShutdownReport() {
+	s.mu.RLock()
+	handler := s.shutdownHandler
+	startedAt := s.boot.StartedAt
+	names := make([]string, 0, len(s.managers))
+	// list the supervised services
+	for name := range s.managers {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+
+	// Skip when nobody reads the report.
+	if handler == nil {
+		// nothing to record
+		return
+	}
+	slices.Sort(names)
+	report := domainlifecycle.ShutdownReport{
+		StartedAt:  startedAt,
+		StoppingAt: time.Now(),
+		Services:   make([]domainlifecycle.ServiceShutdown, 0, len(names)),
+	}
+	// record the health of each service
+	for _, name := range names {
+		health, err := s.ServiceHealth(name)
+		// skip services removed meanwhile
+		if err != nil {
+			continue
+		}
+		report.Services = append(report.Services, domainlifecycle.ServiceShutdown{
+			Stats:  metrics.ServiceStats{ServiceName: name},
+			Health: *health,
+		})
+	}
+
+	s.mu.Lock()
+	s.shutdown = report
+	s.mu.Unlock()
+}
+
+// completeShutdownReport adds the final statistics of each service to the
+// report and hands it to the shutdown handler.
+func (s *Supervisor) completeShutdownReport() {
+	s.mu.Lock()
+	handler := s.shutdownHandler
+	report := s.shutdown
+	s.shutdown = domainlifecycle.ShutdownReport{}
+	s.mu.Unlock()
+
+	// Skip when nobody reads the report.
+	if handler == nil {
+		// nothing to report
+		return
+	}
+	report.StoppedAt = time.Now()
+	services := report.Services[:0]
+	// add the final statistics of each service
+	for _, svc := range report.Services {
+		stats, err := s.ServiceStats(svc.Stats.ServiceName)
+		// ephemeral services are gone with their statistics
+		if err != nil {
+			continue
+		}
+		svc.Stats = stats
+		services = append(services, svc)
+	}
+	report.Services = services
+	handler(report)
+}
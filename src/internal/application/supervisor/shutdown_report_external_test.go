@@ -0,0 +1,48 @@
+// Package supervisor_test provides black-box tests for shutdown_report.go.
+package supervisor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/supervisor"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+// TestSupervisor_ShutdownReport tests the report handed out once a graceful
+// shutdown stopped every service.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_ShutdownReport(t *testing.T) {
+	cfg := createMultiServiceConfig()
+	sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+	require.NoError(t, err)
+	var reports []domainlifecycle.ShutdownReport
+	sup.SetShutdownHandler(func(report domainlifecycle.ShutdownReport) { reports = append(reports, report) })
+
+	require.NoError(t, sup.Start(context.Background()))
+	require.Eventually(t, func() bool {
+		return sup.Stats("service-1").StartCount > 0 && sup.Stats("service-2").StartCount > 0
+	}, time.Second, time.Millisecond)
+	require.NoError(t, sup.Stop())
+	// A second stop has nothing to report.
+	require.NoError(t, sup.Stop())
+
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.False(t, report.StartedAt.IsZero())
+	assert.False(t, report.StoppedAt.Before(report.StoppingAt))
+	assert.Positive(t, report.Uptime())
+	require.Len(t, report.Services, 2)
+	assert.Equal(t, "service-1", report.Services[0].Stats.ServiceName)
+	assert.Equal(t, "service-2", report.Services[1].Stats.ServiceName)
+	// Each service carries its lifetime statistics.
+	for _, svc := range report.Services {
+		assert.Positive(t, svc.Stats.StartCount)
+	}
+}
@@ -0,0 +1,235 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file tracks availability objectives and their error budget burn.
+package supervisor
+
+import (
+	"fmt"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// sloCheckInterval is how often burn rates are evaluated against their alerts.
+const sloCheckInterval time.Duration = 30 * time.Second
+
+// ErrSLOBurn is attached to burn events when the error budget burns faster than an alert rate.
+var ErrSLOBurn error = fmt.Errorf("error budget burning faster than alert rate")
+
+// SLOStatus returns the compliance of a service with its availability objective.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - *domainmetrics.SLOStatus: the status, nil when the service declares no SLO.
+//   - error: ErrServiceNotFound if the service does not exist.
+func (s *Supervisor) SLOStatus(name string) (*domainmetrics.SLOStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	svc := s.config.FindService(name)
+	// validate service exists
+	if svc == nil {
+		// Return error for missing service.
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	// no objective declared
+	if svc.SLO == nil {
+		// Nothing to report.
+		return nil, nil
+	}
+	status := s.sloStatus(svc, time.Now())
+	// return measured status
+	return &status, nil
+}
+
+// sloStatus measures a service against its objective. Must be called with s.mu held.
+//
+// Params:
+//   - svc: the service configuration, with an SLO.
+//   - now: the end of the windows.
+//
+// Returns:
+//   - domainmetrics.SLOStatus: the measured status.
+func (s *Supervisor) sloStatus(svc *domainconfig.ServiceConfig, now time.Time) domainmetrics.SLOStatus {
+	configured := svc.SLO.EffectiveBurnAlerts()
+	alerts := make([]domainmetrics.BurnRate, 0, len(configured))
+	// convert each burn alert
+	for _, a := range configured {
+		alerts = append(alerts, domainmetrics.BurnRate{Window: a.Window.Duration(), Threshold: a.Rate})
+	}
+	// measure the availability history
+	return s.healthLogs[svc.Name].SLOStatus(svc.SLO.Target, svc.SLO.EffectiveWindow(), alerts, now)
+}
+
+// recordAvailability records that a service with an SLO became available
+// or unavailable. Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - good: true if the service became available.
+//   - at: when the change happened.
+func (s *Supervisor) recordAvailability(name string, good bool, at time.Time) {
+	svc := s.sloService(name)
+	// Only services with an objective are tracked.
+	if svc == nil {
+		// Nothing to record.
+		return
+	}
+	// Create the logs on first use.
+	if s.healthLogs == nil {
+		s.healthLogs = make(map[string]domainmetrics.HealthLog)
+	}
+	s.healthLogs[name] = s.healthLogs[name].Record(at, good, svc.SLO.EffectiveWindow())
+}
+
+// sloService returns the configuration of a service declaring an SLO.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - *domainconfig.ServiceConfig: the service, nil if unknown or without SLO.
+func (s *Supervisor) sloService(name string) *domainconfig.ServiceConfig {
+	// No configuration loaded.
+	if s.config == nil {
+		// Nothing tracked.
+		return nil
+	}
+	svc := s.config.FindService(name)
+	// Only services with an objective are tracked.
+	if svc == nil || svc.SLO == nil {
+		// Not tracked.
+		return nil
+	}
+	// return tracked service
+	return svc
+}
+
+// updateAvailability records the availability change carried by a process
+// event. With health probes, a started service is available once they pass.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) updateAvailability(name string, event *domain.Event) {
+	// Map lifecycle events to availability.
+	switch event.Type {
+	// Running without probes is available.
+	case domain.EventStarted:
+		// Probed services wait for their probes.
+		if svc := s.sloService(name); svc != nil && !s.hasConfiguredProbes(svc) {
+			s.recordAvailability(name, true, eventTime(event))
+		}
+	// Not running is unavailable.
+	case domain.EventStopped, domain.EventFailed, domain.EventExhausted:
+		s.recordAvailability(name, false, eventTime(event))
+	// Other events do not change availability.
+	default:
+	}
+}
+
+// startSLOWatcher evaluates burn rates periodically and emits an event when
+// a burn alert fires or clears.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine evaluating burn rates on a ticker.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startSLOWatcher() {
+	// Evaluate until shutdown.
+	s.wg.Go(func() {
+		ticker := time.NewTicker(sloCheckInterval)
+		defer ticker.Stop()
+		// Loop until context is cancelled.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case now := <-ticker.C:
+				s.checkSLOs(now)
+			}
+		}
+	})
+}
+
+// checkSLOs evaluates the burn alerts of every service with an SLO.
+// An event is emitted for each alert whose state changed.
+//
+// Params:
+//   - now: the evaluation time.
+func (s *Supervisor) checkSLOs(now time.Time) {
+	type sloEvent struct {
+		name  string
+		event domain.Event
+		snap  *ServiceStatsSnapshot
+	}
+
+	s.mu.Lock()
+	var events []sloEvent
+	// Evaluate each service declaring an objective.
+	for i := range s.config.Services {
+		svc := &s.config.Services[i]
+		// Skip services without objective.
+		if svc.SLO == nil {
+			continue
+		}
+		status := s.sloStatus(svc, now)
+		// Record transitions of each burn alert.
+		for j, burn := range status.BurnRates {
+			// Skip when the alert state did not change.
+			if s.burnAlerts[svc.Name][j] == burn.Alerting {
+				continue
+			}
+			s.setBurnAlert(svc.Name, j, burn.Alerting)
+
+			event := domain.NewEvent(domain.EventSLOBurnCleared, svc.Name, 0, 0, nil)
+			// Attach burn details when the alert fires.
+			if burn.Alerting {
+				event = domain.NewEvent(domain.EventSLOBurn, svc.Name, 0, 0,
+					fmt.Errorf("%w: %.1fx over %s reaches %.1fx, %.1f%% of budget left",
+						ErrSLOBurn, burn.Rate, burn.Window, burn.Threshold, status.BudgetRemaining))
+			}
+			events = append(events, sloEvent{name: svc.Name, event: event, snap: s.getStatsSnapshot(s.stats[svc.Name])})
+		}
+	}
+	s.mu.Unlock()
+
+	// Emit events outside the lock.
+	for i := range events {
+		s.callEventHandler(events[i].name, &events[i].event, events[i].snap)
+	}
+}
+
+// setBurnAlert records the state of a burn alert for a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - alert: the index of the burn alert.
+//   - active: whether the alert is firing.
+func (s *Supervisor) setBurnAlert(name string, alert int, active bool) {
+	// Clear the alert and drop empty service entries.
+	if !active {
+		delete(s.burnAlerts[name], alert)
+		// Remove service when no alert remains.
+		if len(s.burnAlerts[name]) == 0 {
+			delete(s.burnAlerts, name)
+		}
+		// Alert cleared.
+		return
+	}
+
+	// Create the maps on first alert.
+	if s.burnAlerts == nil {
+		s.burnAlerts = make(map[string]map[int]bool)
+	}
+	// Create the service entry on first alert.
+	if s.burnAlerts[name] == nil {
+		s.burnAlerts[name] = make(map[int]bool)
+	}
+	s.burnAlerts[name][alert] = true
+}
@@ -0,0 +1,125 @@
+// Package supervisor provides internal tests for slo.go.
+// It tests availability tracking and burn alerts using white-box testing.
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// newSLOTestSupervisor creates a supervisor with an "api" service declaring
+// a 99% objective with a single 1h burn alert at 10x, and a "web" service
+// without objective.
+//
+// Returns:
+//   - *Supervisor: the supervisor under test.
+//   - *[]domain.Event: events received by the event handler.
+func newSLOTestSupervisor() (*Supervisor, *[]domain.Event) {
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{
+			Name: "api",
+			SLO: &domainconfig.SLOConfig{
+				Target:     99,
+				BurnAlerts: []domainconfig.BurnAlertConfig{{Rate: 10, Window: shared.Minutes(60)}},
+			},
+		},
+		{Name: "web"},
+	}}
+
+	events := &[]domain.Event{}
+	s := &Supervisor{
+		config: cfg,
+		stats:  map[string]*ServiceStats{"api": NewServiceStats(), "web": NewServiceStats()},
+		eventHandler: func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+			*events = append(*events, *event)
+		},
+	}
+	// return supervisor and captured events
+	return s, events
+}
+
+// Test_Supervisor_updateAvailability tests which events are tracked.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_updateAvailability(t *testing.T) {
+	s, _ := newSLOTestSupervisor()
+	start := time.Now().Add(-time.Hour)
+
+	s.updateAvailability("api", &domain.Event{Type: domain.EventStarted, Timestamp: start})
+	s.updateAvailability("api", &domain.Event{Type: domain.EventHealthy, Timestamp: start.Add(time.Minute)})
+	s.updateAvailability("api", &domain.Event{Type: domain.EventFailed, Timestamp: start.Add(30 * time.Minute)})
+	s.updateAvailability("web", &domain.Event{Type: domain.EventStarted, Timestamp: start})
+
+	assert.Equal(t, domainmetrics.HealthLog{
+		{At: start, Good: true},
+		{At: start.Add(30 * time.Minute), Good: false},
+	}, s.healthLogs["api"])
+	assert.NotContains(t, s.healthLogs, "web")
+}
+
+// Test_Supervisor_SLOStatus tests the reported objective compliance.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_SLOStatus(t *testing.T) {
+	s, _ := newSLOTestSupervisor()
+	s.recordAvailability("api", true, time.Now().Add(-time.Hour))
+
+	status, err := s.SLOStatus("api")
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.InDelta(t, 99.0, status.Target, 0.0001)
+	assert.Equal(t, domainconfig.DefaultSLOWindow, status.Window)
+	assert.InDelta(t, 100.0, status.Availability, 0.0001)
+	require.Len(t, status.BurnRates, 1)
+	assert.False(t, status.BurnRates[0].Alerting)
+
+	status, err = s.SLOStatus("web")
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	_, err = s.SLOStatus("missing")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
+
+// Test_Supervisor_checkSLOs tests burn alert transitions.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkSLOs(t *testing.T) {
+	s, events := newSLOTestSupervisor()
+	now := time.Now()
+	s.recordAvailability("api", true, now.Add(-2*time.Hour))
+
+	// Fully available: no transition.
+	s.checkSLOs(now)
+	assert.Empty(t, *events)
+
+	// Down for 12 of the last 60 minutes burns at 20x.
+	s.recordAvailability("api", false, now.Add(-12*time.Minute))
+	s.checkSLOs(now)
+	require.Len(t, *events, 1)
+	assert.Equal(t, domain.EventSLOBurn, (*events)[0].Type)
+	assert.Equal(t, "api", (*events)[0].Process)
+	assert.ErrorIs(t, (*events)[0].Error, ErrSLOBurn)
+
+	// Still burning: no new event.
+	s.checkSLOs(now.Add(time.Second))
+	assert.Len(t, *events, 1)
+
+	// Back up long enough for the hour to recover.
+	s.recordAvailability("api", true, now)
+	s.checkSLOs(now.Add(time.Hour))
+	require.Len(t, *events, 2)
+	assert.Equal(t, domain.EventSLOBurnCleared, (*events)[1].Type)
+	assert.Empty(t, s.burnAlerts)
+}
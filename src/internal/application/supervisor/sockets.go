@@ -0,0 +1,222 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file marks services ready once their unix sockets appear.
+package supervisor
+
+import (
+	"context"
+	"sync/atomic"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// socketWait is the pending wait for the unix sockets of one launched process.
+type socketWait struct {
+	// pid is the process the wait was started for.
+	pid int
+	// cancel stops the wait.
+	cancel context.CancelFunc
+}
+
+// updateSocketWait starts or stops the wait for the unix sockets of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) updateSocketWait(name string, event *domain.Event) {
+	// start or stop based on event
+	switch event.Type {
+	// process launched: its sockets are about to be created
+	case domain.EventStarted:
+		s.awaitSockets(name, event.PID)
+	// process gone: its sockets will not come
+	case domain.EventStopped, domain.EventFailed, domain.EventExhausted:
+		s.clearSocketWait(name)
+	// No wait change for these events.
+	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy,
+		domain.EventThrottled, domain.EventUnthrottled, domain.EventPressureAlert, domain.EventPressureCleared,
+		domain.EventListenerConflict, domain.EventListenerConflictCleared, domain.EventStartTimeout,
+		domain.EventDependencyDown, domain.EventDependencyUp, domain.EventIncident, domain.EventFileChanged, domain.EventSecretChanged,
+		domain.EventProbesPaused, domain.EventProbesResumed,
+		domain.EventQuiesced, domain.EventUnquiesced, domain.EventQuiesceFailed, domain.EventSafeModeEntered, domain.EventSafeModeExited, domain.EventConfigReloaded,
+		domain.EventReloaded, domain.EventReloadFailed,
+		domain.EventSLOBurn, domain.EventSLOBurnCleared, domain.EventOvercommitted, domain.EventShed, domain.EventShedResumed,
+		domain.EventResourceLeaked, domain.EventRuntimeWarning, domain.EventRuntimeExceeded, domain.EventRestartDeferred,
+		domain.EventCertificateExpiring, domain.EventCertificateChanged,
+		domain.EventClockJump, domain.EventCustom, domain.EventSkipped:
+		// No wait change needed.
+	default:
+		// Unknown event type, ignore.
+	}
+}
+
+// awaitSockets waits for each unix socket of a launched process. A probed
+// socket is probed as soon as it appears instead of at the next interval;
+// the service is ready once every unprobed socket exists.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the launched process ID.
+func (s *Supervisor) awaitSockets(name string, pid int) {
+	s.clearSocketWait(name)
+	// Skip when sockets cannot be watched.
+	if s.config == nil || s.fileWatcher == nil || s.ctx == nil || pid <= 0 {
+		// No wait.
+		return
+	}
+	svc := s.config.FindService(name)
+	// Skip services without unix listeners.
+	if svc == nil || len(svc.SocketListeners()) == 0 {
+		// No wait.
+		return
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	// create wait map on first use
+	if s.socketWaits == nil {
+		s.socketWaits = make(map[string]*socketWait)
+	}
+	s.socketWaits[name] = &socketWait{pid: pid, cancel: cancel}
+
+	watcher := s.fileWatcher
+	var pending atomic.Int32
+	// count the sockets that decide readiness
+	for _, lc := range svc.SocketListeners() {
+		// Probed sockets are ready once their probe passes.
+		if !lc.HasProbe() {
+			pending.Add(1)
+		}
+	}
+	// watch each socket, copied as a reload replaces the configuration
+	for _, lc := range svc.SocketListeners() {
+		path, listener, probed := lc.SocketPath(), lc.Name, lc.HasProbe()
+		s.wg.Go(func() {
+			// Report watch failures, not cancellations.
+			if err := watcher.AwaitFile(ctx, path, domainconfig.DefaultSocketPoll); err != nil {
+				// Report unless the wait was stopped.
+				if ctx.Err() == nil {
+					s.handleRecoveryError("await-socket", name, err)
+				}
+				// Stop waiting.
+				return
+			}
+			// Probe now rather than at the next interval.
+			if probed {
+				s.probeSocket(ctx, name, listener)
+				// Readiness is reported by the probe.
+				return
+			}
+			// The last socket makes the service ready.
+			if pending.Add(-1) == 0 {
+				s.socketsReady(name, pid)
+			}
+		})
+	}
+}
+
+// probeSocket runs the probe of a listener whose socket just appeared.
+//
+// Params:
+//   - ctx: the socket wait context.
+//   - name: the service name.
+//   - listener: the listener name.
+func (s *Supervisor) probeSocket(ctx context.Context, name, listener string) {
+	s.mu.RLock()
+	monitor := s.healthMonitors[name]
+	s.mu.RUnlock()
+	// Skip when the monitor is not running.
+	if monitor == nil {
+		// Probed at the next interval, if ever.
+		return
+	}
+	monitor.ProbeNow(ctx, listener)
+}
+
+// socketsReady marks a service ready once its unprobed sockets exist. A
+// service with probes stays gated by them.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the process ID the wait was started for.
+func (s *Supervisor) socketsReady(name string, pid int) {
+	s.mu.Lock()
+	wait, ok := s.socketWaits[name]
+	// Skip waits stopped or restarted since the sockets appeared.
+	if !ok || wait.pid != pid {
+		s.mu.Unlock()
+		// Stale wait.
+		return
+	}
+	wait.cancel()
+	delete(s.socketWaits, name)
+	svc := s.config.FindService(name)
+	// Probes decide the readiness of probed services.
+	if svc != nil && s.probesGate(svc) {
+		s.mu.Unlock()
+		// Ready once probes pass.
+		return
+	}
+	s.clearStartDeadline(name)
+	boot, booted := s.markBootReady(name)
+	s.mu.Unlock()
+
+	// Report the boot outcome once every service is resolved.
+	if booted {
+		s.completeBoot(boot)
+	}
+	// Start the next phases once the started services are resolved.
+	s.advanceStartPhases()
+}
+
+// clearSocketWait stops the socket wait of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) clearSocketWait(name string) {
+	wait, ok := s.socketWaits[name]
+	// Skip when no wait is pending.
+	if !ok {
+		// Nothing to stop.
+		return
+	}
+	wait.cancel()
+	delete(s.socketWaits, name)
+}
+
+// clearSocketWaits stops every pending socket wait.
+// Must be called with s.mu held.
+func (s *Supervisor) clearSocketWaits() {
+	// stop each pending wait
+	for name := range s.socketWaits {
+		s.clearSocketWait(name)
+	}
+}
+
+// socketsGate reports whether the sockets of a service decide its readiness.
+// Must be called with s.mu held.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - bool: true if the service has unprobed unix listeners and a file watcher is set.
+func (s *Supervisor) socketsGate(svc *domainconfig.ServiceConfig) bool {
+	// no watcher, no wait
+	if s.fileWatcher == nil {
+		// Not gated.
+		return false
+	}
+	// gated by the first unprobed socket
+	for _, lc := range svc.SocketListeners() {
+		// Probed sockets are gated by their probe.
+		if !lc.HasProbe() {
+			// Gated.
+			return true
+		}
+	}
+	// every socket is probed
+	return false
+}
@@ -0,0 +1,119 @@
+// Package supervisor provides internal tests for sockets.go.
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_Supervisor_awaitSockets tests the readiness of services on unix sockets.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_awaitSockets(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// probe is the probe of the socket listener, nil for none.
+		probe *domainconfig.ProbeConfig
+		// created makes the socket appear.
+		created bool
+		// stopped stops the process before the socket appears.
+		stopped bool
+		// wantStatus is the expected boot status.
+		wantStatus domainlifecycle.BootStatus
+	}{
+		{
+			name:       "ready_on_creation",
+			created:    true,
+			wantStatus: domainlifecycle.BootReady,
+		},
+		{
+			name:       "pending_until_creation",
+			wantStatus: domainlifecycle.BootPending,
+		},
+		{
+			name:       "probed_socket_waits_for_probe",
+			probe:      &domainconfig.ProbeConfig{Type: domainconfig.ProbeTypeTCP},
+			created:    true,
+			wantStatus: domainlifecycle.BootPending,
+		},
+		{
+			name:       "stopped_before_creation",
+			stopped:    true,
+			created:    true,
+			wantStatus: domainlifecycle.BootFailed,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{{
+				Name: "db", Command: "/bin/db",
+				Listeners: []domainconfig.ListenerConfig{
+					{Name: "sock", Protocol: domainconfig.ListenerProtocolUnix, Address: "/run/db.sock", Probe: tt.probe},
+				},
+			}}}
+			ctx, cancel := context.WithCancel(context.Background())
+			watcher := &fakeFileWatcher{created: make(chan struct{})}
+			s := &Supervisor{config: cfg, ctx: ctx, proberFactory: &mockProberFactory{}, stats: map[string]*ServiceStats{}}
+			s.SetFileWatcher(watcher)
+			t.Cleanup(func() {
+				cancel()
+				s.wg.Wait()
+			})
+			s.beginBoot()
+
+			s.handleEvent("db", &domain.Event{Type: domain.EventStarted, PID: 42})
+			require.Eventually(t, func() bool { return len(watcher.awaitedPaths()) == 1 }, time.Second, time.Millisecond)
+			assert.Equal(t, []string{"/run/db.sock"}, watcher.awaitedPaths())
+			// A stopped process no longer waits for its socket.
+			if tt.stopped {
+				s.handleEvent("db", &domain.Event{Type: domain.EventFailed, PID: 42})
+			}
+			// The socket appears.
+			if tt.created {
+				close(watcher.created)
+			}
+
+			// Readiness is reported asynchronously.
+			status := func() domainlifecycle.BootStatus { return s.BootReport().Services[0].Status }
+			if tt.wantStatus == domainlifecycle.BootReady {
+				require.Eventually(t, func() bool { return status() == tt.wantStatus }, time.Second, time.Millisecond)
+			}
+			time.Sleep(20 * time.Millisecond)
+			assert.Equal(t, tt.wantStatus, status())
+		})
+	}
+}
+
+// Test_Supervisor_readinessGated_sockets tests that unprobed sockets gate
+// readiness only when they can be watched.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_readinessGated_sockets(t *testing.T) {
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{{
+		Name: "db", Command: "/bin/db",
+		Listeners: []domainconfig.ListenerConfig{
+			{Name: "sock", Protocol: domainconfig.ListenerProtocolUnix, Address: "/run/db.sock"},
+		},
+	}}}
+	s := &Supervisor{config: cfg}
+
+	// Without a watcher, the service is ready once running.
+	assert.False(t, s.readinessGated("db"))
+
+	s.SetFileWatcher(&fakeFileWatcher{})
+	assert.True(t, s.readinessGated("db"))
+}
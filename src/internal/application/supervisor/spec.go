@@ -0,0 +1,70 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file exposes the resolved launch specification of running services.
+package supervisor
+
+import (
+	"context"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// SetInspector sets the adapter reading the cgroup and resource limits of
+// running processes. Without an inspector, ServiceSpec reports only the
+// launched specification.
+//
+// Params:
+//   - inspector: the process inspector to use.
+func (s *Supervisor) SetInspector(inspector domain.Inspector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store process inspector
+	s.inspector = inspector
+}
+
+// ServiceSpec returns the specification a running service was launched with.
+// Secret-looking environment values are redacted. Inspection failures are
+// reported through the error handler and leave the kernel-side fields empty.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - name: the service name.
+//
+// Returns:
+//   - domain.ResolvedSpec: the resolved specification.
+//   - error: ErrServiceNotFound or domain.ErrNotRunning.
+func (s *Supervisor) ServiceSpec(ctx context.Context, name string) (domain.ResolvedSpec, error) {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	inspector := s.inspector
+	s.mu.RUnlock()
+
+	// validate service exists
+	if !ok {
+		// return not found error
+		return domain.ResolvedSpec{}, ErrServiceNotFound
+	}
+
+	spec, launched := mgr.LaunchedSpec()
+	// only running processes have a meaningful spec
+	if !launched || spec.PID == 0 {
+		// return not running error
+		return domain.ResolvedSpec{}, domain.ErrNotRunning
+	}
+
+	// inspection is optional
+	if inspector == nil {
+		// return launched spec only
+		return spec, nil
+	}
+	details, err := inspector.Inspect(ctx, spec.PID)
+	// Report inspection failure (the launched spec is still useful).
+	if err != nil {
+		s.handleRecoveryError("inspect", name, err)
+		// return launched spec only
+		return spec, nil
+	}
+	spec.CgroupPath = details.CgroupPath
+	spec.Limits = details.Limits
+	// return enriched spec
+	return spec, nil
+}
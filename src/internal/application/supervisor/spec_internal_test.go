@@ -0,0 +1,127 @@
+// Package supervisor provides internal tests for spec.go.
+// It tests resolved spec inspection using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// specTestInspector returns fixed inspection results.
+type specTestInspector struct {
+	// inspection is returned by Inspect.
+	inspection domain.Inspection
+	// err is returned by Inspect when set.
+	err error
+	// pid records the inspected pid.
+	pid int
+}
+
+// Inspect records the pid and returns the configured result.
+//
+// Params:
+//   - pid: the inspected pid.
+//
+// Returns:
+//   - domain.Inspection: the configured inspection.
+//   - error: the configured error.
+func (i *specTestInspector) Inspect(_ context.Context, pid int) (domain.Inspection, error) {
+	i.pid = pid
+	return i.inspection, i.err
+}
+
+// newSpecTestSupervisor builds a supervisor with one launched service.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+func newSpecTestSupervisor(t *testing.T) *Supervisor {
+	t.Helper()
+	cfg := &domainconfig.ServiceConfig{
+		Name:        "api",
+		Command:     "/bin/api",
+		Args:        []string{"--port", "8080"},
+		Environment: map[string]string{"API_TOKEN": "s3cr3t", "MODE": "prod"},
+		User:        "app",
+		Listeners:   []domainconfig.ListenerConfig{{Name: "http", Port: 8080}},
+	}
+	mgr := applifecycle.NewManager(cfg, &proxyTestExecutor{})
+	require.NoError(t, mgr.Start(context.Background()))
+	t.Cleanup(func() { _ = mgr.Stop() })
+	require.Eventually(t, func() bool { return mgr.PID() != 0 }, time.Second, 10*time.Millisecond)
+	return &Supervisor{managers: map[string]*applifecycle.Manager{"api": mgr}}
+}
+
+// Test_Supervisor_ServiceSpec tests the resolved spec with inspection.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ServiceSpec(t *testing.T) {
+	s := newSpecTestSupervisor(t)
+	inspector := &specTestInspector{inspection: domain.Inspection{
+		CgroupPath: "/system.slice/api.service",
+		Limits:     []domain.ResourceLimit{{Name: "Max open files", Soft: "1024", Hard: "4096", Unit: "files"}},
+	}}
+	s.SetInspector(inspector)
+
+	spec, err := s.ServiceSpec(context.Background(), "api")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4242, inspector.pid)
+	assert.Equal(t, 4242, spec.PID)
+	assert.Equal(t, "/bin/api", spec.Command)
+	assert.Equal(t, []string{"--port", "8080"}, spec.Args)
+	assert.Equal(t, domain.RedactedValue, spec.Env["API_TOKEN"])
+	assert.Equal(t, "prod", spec.Env["MODE"])
+	assert.Equal(t, "app", spec.User)
+	assert.Equal(t, "/system.slice/api.service", spec.CgroupPath)
+	assert.Len(t, spec.Limits, 1)
+	assert.Len(t, spec.Listeners, 1)
+}
+
+// Test_Supervisor_ServiceSpec_InspectError tests that inspection failures are reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ServiceSpec_InspectError(t *testing.T) {
+	s := newSpecTestSupervisor(t)
+	failure := errors.New("no procfs")
+	var reported error
+	s.errorHandler = func(_, _ string, err error) { reported = err }
+	s.SetInspector(&specTestInspector{err: failure})
+
+	spec, err := s.ServiceSpec(context.Background(), "api")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, reported, failure)
+	assert.Equal(t, "/bin/api", spec.Command)
+	assert.Empty(t, spec.CgroupPath)
+}
+
+// Test_Supervisor_ServiceSpec_Errors tests unknown and stopped services.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_ServiceSpec_Errors(t *testing.T) {
+	cfg := &domainconfig.ServiceConfig{Name: "idle", Command: "/bin/idle"}
+	s := &Supervisor{managers: map[string]*applifecycle.Manager{
+		"idle": applifecycle.NewManager(cfg, &proxyTestExecutor{}),
+	}}
+
+	_, err := s.ServiceSpec(context.Background(), "missing")
+	require.ErrorIs(t, err, ErrServiceNotFound)
+
+	_, err = s.ServiceSpec(context.Background(), "idle")
+	require.ErrorIs(t, err, domain.ErrNotRunning)
+}
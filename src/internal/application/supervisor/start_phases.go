@@ -0,0 +1,113 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file orders the initial startup in phases separated by readiness barriers.
+package supervisor
+
+import (
+	"maps"
+	"slices"
+
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// planStartPhases records the start phases of the initial startup and
+// returns the services of the first one. The later phases are started by
+// advanceStartPhases.
+//
+// Returns:
+//   - []string: the services to start at once.
+func (s *Supervisor) planStartPhases() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Without configuration, every manager starts at once.
+	if s.config == nil {
+		s.startPhases = nil
+		// Start every service.
+		return slices.Collect(maps.Keys(s.managers))
+	}
+	phases := s.config.StartPhases()
+	// No service configured.
+	if len(phases) == 0 {
+		s.startPhases = nil
+		// Nothing to start.
+		return nil
+	}
+	s.startPhases = phases[1:]
+	// Start the first phase.
+	return phases[0]
+}
+
+// advanceStartPhases starts the next phases once every service of the
+// started phases is resolved in the boot report: ready, or failed. Phases
+// are dropped when the boot is aborted or the supervisor stops.
+func (s *Supervisor) advanceStartPhases() {
+	s.mu.Lock()
+	// Nothing left to start.
+	if len(s.startPhases) == 0 {
+		s.mu.Unlock()
+		// No phase pending.
+		return
+	}
+	// Give up the remaining phases on shutdown or aborted boot.
+	if s.state == StateStopping || s.state == StateStopped || s.boot.Aborted {
+		s.startPhases = nil
+		s.mu.Unlock()
+		// Phases dropped.
+		return
+	}
+	var failed []string
+	var errs []error
+	var gated []*domain.Event
+	// Release each phase whose predecessors are resolved.
+	for len(s.startPhases) > 0 && !s.startedPhasesPending() {
+		phase := s.startPhases[0]
+		s.startPhases = s.startPhases[1:]
+		// Start the services of the phase together.
+		for _, name := range phase {
+			mgr := s.managers[name]
+			event, refused := s.gateStart(s.config, name)
+			// Record skipped and over-committing starts.
+			if event != nil {
+				gated = append(gated, event)
+			}
+			// Skip services without manager, skipped or refused.
+			if mgr == nil || refused {
+				continue
+			}
+			// Record start failures for the error handler.
+			if err := mgr.Start(s.ctx); err != nil {
+				failed = append(failed, name)
+				errs = append(errs, err)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	// Report start failures outside the lock.
+	for i, name := range failed {
+		s.handleRecoveryError("start-phase", name, errs[i])
+	}
+	s.emitGated(gated)
+}
+
+// startedPhasesPending reports whether a service of an already started
+// phase is still pending in the boot report. Must be called with s.mu held.
+//
+// Returns:
+//   - bool: true if the next phase must wait.
+func (s *Supervisor) startedPhasesPending() bool {
+	// Check every service of the boot report.
+	for _, entry := range s.boot.Services {
+		// Resolved services do not hold the barrier.
+		if entry.Status != domainlifecycle.BootPending {
+			continue
+		}
+		// Pending services of later phases are not started yet.
+		if !slices.ContainsFunc(s.startPhases, func(phase []string) bool { return slices.Contains(phase, entry.Name) }) {
+			// A started service is not ready yet.
+			return true
+		}
+	}
+	// every started service is resolved
+	return false
+}
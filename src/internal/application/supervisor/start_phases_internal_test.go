@@ -0,0 +1,163 @@
+// Package supervisor provides internal tests for start_phases.go.
+// It tests phased startup with readiness barriers using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// phaseTestExecutor records the services it launched.
+type phaseTestExecutor struct {
+	// mu protects started.
+	mu sync.Mutex
+	// started lists the launched services, in launch order.
+	started []string
+}
+
+// Start records the service and returns a process that stays running.
+//
+// Params:
+//   - spec: the process specification.
+//
+// Returns:
+//   - int: a fake pid.
+//   - <-chan domain.ExitResult: a channel that never fires.
+//   - error: always nil.
+func (e *phaseTestExecutor) Start(_ context.Context, spec domain.Spec) (int, <-chan domain.ExitResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.started = append(e.started, spec.Name)
+	return 4242, make(chan domain.ExitResult), nil
+}
+
+// Stop is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *phaseTestExecutor) Stop(_ int, _ time.Duration) error { return nil }
+
+// Signal is a no-op.
+//
+// Returns:
+//   - error: always nil.
+func (e *phaseTestExecutor) Signal(_ int, _ os.Signal) error { return nil }
+
+// launched returns the launched services, sorted.
+//
+// Returns:
+//   - []string: the service names.
+func (e *phaseTestExecutor) launched() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := slices.Clone(e.started)
+	slices.Sort(names)
+	return names
+}
+
+// newPhaseTestSupervisor creates a booting supervisor with a probed "db" in
+// phase 1, "api" and "worker" in phase 2 and "proxy" in phase 3.
+//
+// Params:
+//   - t: the testing context.
+//   - policy: the boot failure policy.
+//
+// Returns:
+//   - *Supervisor: the supervisor under test.
+//   - *phaseTestExecutor: the executor recording launches.
+func newPhaseTestSupervisor(t *testing.T, policy domainconfig.BootFailurePolicy) (*Supervisor, *phaseTestExecutor) {
+	t.Helper()
+	probed := []domainconfig.ListenerConfig{{Name: "sql", Port: 5432, Probe: &domainconfig.ProbeConfig{Type: "tcp"}}}
+	cfg := &domainconfig.Config{OnBootFailure: policy, Services: []domainconfig.ServiceConfig{
+		{Name: "proxy", Command: "/bin/proxy", StartPhase: 3},
+		{Name: "api", Command: "/bin/api", StartPhase: 2},
+		{Name: "db", Command: "/bin/db", StartPhase: 1, Listeners: probed, Critical: true},
+		{Name: "worker", Command: "/bin/worker", StartPhase: 2},
+	}}
+
+	executor := &phaseTestExecutor{}
+	managers := make(map[string]*applifecycle.Manager, len(cfg.Services))
+	for i := range cfg.Services {
+		managers[cfg.Services[i].Name] = applifecycle.NewManager(&cfg.Services[i], executor)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := &Supervisor{config: cfg, managers: managers, state: StateStarting, ctx: ctx, proberFactory: &mockProberFactory{}}
+	s.beginBoot()
+	return s, executor
+}
+
+// resolve feeds a boot outcome and advances the phases.
+//
+// Params:
+//   - s: the supervisor under test.
+//   - name: the service name.
+//   - event: the process event, nil for readiness.
+func resolve(s *Supervisor, name string, event *domain.Event) {
+	s.mu.Lock()
+	if event == nil {
+		s.markBootReady(name)
+	} else {
+		s.updateBoot(name, event)
+	}
+	s.mu.Unlock()
+	s.advanceStartPhases()
+}
+
+// Test_Supervisor_startPhases tests that each phase waits for the previous ones.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_startPhases(t *testing.T) {
+	s, executor := newPhaseTestSupervisor(t, domainconfig.BootFailureContinue)
+
+	require.NoError(t, s.startAllServices())
+	require.Eventually(t, func() bool { return len(executor.launched()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"db"}, executor.launched())
+
+	// Running is not ready for a probed service.
+	resolve(s, "db", &domain.Event{Type: domain.EventStarted})
+	assert.Len(t, s.startPhases, 2)
+
+	// Probes pass: the second phase starts together.
+	resolve(s, "db", nil)
+	require.Eventually(t, func() bool { return len(executor.launched()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"api", "db", "worker"}, executor.launched())
+
+	// One ready service is not enough.
+	resolve(s, "api", &domain.Event{Type: domain.EventStarted})
+	assert.Len(t, s.startPhases, 1)
+
+	// A failed service releases the barrier too.
+	resolve(s, "worker", &domain.Event{Type: domain.EventFailed, ExitCode: 1})
+	require.Eventually(t, func() bool { return len(executor.launched()) == 4 }, time.Second, time.Millisecond)
+	assert.Empty(t, s.startPhases)
+}
+
+// Test_Supervisor_startPhases_aborted tests that an aborted boot starts no further phase.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_startPhases_aborted(t *testing.T) {
+	s, executor := newPhaseTestSupervisor(t, domainconfig.BootFailureShutdown)
+
+	require.NoError(t, s.startAllServices())
+	resolve(s, "db", &domain.Event{Type: domain.EventFailed, ExitCode: 1})
+
+	assert.True(t, s.boot.Aborted)
+	assert.Empty(t, s.startPhases)
+	require.Eventually(t, func() bool { return len(executor.launched()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"db"}, executor.launched())
+}
@@ -0,0 +1,193 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file enforces the start timeout of services gated by health probes or sockets.
+package supervisor
+
+import (
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// startDeadline is the pending start timeout of one launched process.
+type startDeadline struct {
+	// pid is the process the deadline was armed for.
+	pid int
+	// timer fires when the deadline expires.
+	timer shared.Timer
+}
+
+// updateStartDeadline arms or clears the start deadline of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - event: the process event.
+func (s *Supervisor) updateStartDeadline(name string, event *domain.Event) {
+	// arm or clear based on event
+	switch event.Type {
+	// process launched: readiness clock starts
+	case domain.EventStarted:
+		s.armStartDeadline(name, event.PID)
+	// process gone: nothing left to time out
+	case domain.EventStopped, domain.EventFailed, domain.EventExhausted:
+		s.clearStartDeadline(name)
+	// No deadline change for these events.
+	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy,
+		domain.EventThrottled, domain.EventUnthrottled, domain.EventPressureAlert, domain.EventPressureCleared,
+		domain.EventListenerConflict, domain.EventListenerConflictCleared, domain.EventStartTimeout,
+		domain.EventDependencyDown, domain.EventDependencyUp, domain.EventIncident, domain.EventFileChanged, domain.EventSecretChanged,
+		domain.EventProbesPaused, domain.EventProbesResumed,
+		domain.EventQuiesced, domain.EventUnquiesced, domain.EventQuiesceFailed, domain.EventSafeModeEntered, domain.EventSafeModeExited, domain.EventConfigReloaded,
+		domain.EventReloaded, domain.EventReloadFailed,
+		domain.EventSLOBurn, domain.EventSLOBurnCleared, domain.EventOvercommitted, domain.EventShed, domain.EventShedResumed,
+		domain.EventResourceLeaked, domain.EventRuntimeWarning, domain.EventRuntimeExceeded, domain.EventRestartDeferred,
+		domain.EventCertificateExpiring, domain.EventCertificateChanged,
+		domain.EventClockJump, domain.EventCustom, domain.EventSkipped:
+		// No deadline change needed.
+	default:
+		// Unknown event type, ignore.
+	}
+}
+
+// armStartDeadline starts the start timeout of a launched process.
+// Services without health probes or awaited sockets are ready as soon as
+// they run, so only readiness-gated services get a deadline.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the launched process ID.
+func (s *Supervisor) armStartDeadline(name string, pid int) {
+	s.clearStartDeadline(name)
+
+	timeout := s.startTimeout(name)
+	// Skip when disabled or readiness is not observable.
+	if timeout <= 0 || !s.readinessGated(name) || pid <= 0 {
+		// No deadline.
+		return
+	}
+
+	// create deadline map on first use
+	if s.startDeadlines == nil {
+		s.startDeadlines = make(map[string]*startDeadline)
+	}
+	s.startDeadlines[name] = &startDeadline{
+		pid:   pid,
+		timer: s.clock.AfterFunc(timeout, func() { s.expireStartDeadline(name, pid) }),
+	}
+}
+
+// clearStartDeadline cancels the start deadline of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) clearStartDeadline(name string) {
+	deadline, ok := s.startDeadlines[name]
+	// Skip when no deadline is pending.
+	if !ok {
+		// Nothing to cancel.
+		return
+	}
+	deadline.timer.Stop()
+	delete(s.startDeadlines, name)
+}
+
+// clearStartDeadlines cancels every pending start deadline.
+// Must be called with s.mu held.
+func (s *Supervisor) clearStartDeadlines() {
+	// cancel each pending deadline
+	for name := range s.startDeadlines {
+		s.clearStartDeadline(name)
+	}
+}
+
+// expireStartDeadline kills a process that is still not ready.
+// Kill failures are reported through the error handler.
+//
+// Params:
+//   - name: the service name.
+//   - pid: the process ID the deadline was armed for.
+func (s *Supervisor) expireStartDeadline(name string, pid int) {
+	s.mu.Lock()
+	deadline, ok := s.startDeadlines[name]
+	// Skip deadlines cleared or re-armed since the timer fired.
+	if !ok || deadline.pid != pid {
+		s.mu.Unlock()
+		// Stale deadline.
+		return
+	}
+	delete(s.startDeadlines, name)
+	mgr, found := s.managers[name]
+	s.mu.Unlock()
+
+	// Skip services removed by a reload.
+	if !found {
+		// Nothing to kill.
+		return
+	}
+	// Report kill failure (the process may have exited meanwhile).
+	if err := mgr.AbortStart(pid); err != nil {
+		s.handleRecoveryError("start-timeout", name, err)
+	}
+}
+
+// startTimeout returns the configured start timeout of a service.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - time.Duration: the timeout, zero when disabled or unknown.
+func (s *Supervisor) startTimeout(name string) time.Duration {
+	// Skip when no configuration is loaded.
+	if s.config == nil {
+		// No timeout.
+		return 0
+	}
+	svc := s.config.FindService(name)
+	// unknown service
+	if svc == nil {
+		// No timeout.
+		return 0
+	}
+	// return configured timeout
+	return svc.StartTimeout.Duration()
+}
+
+// readinessGated reports whether a service is ready only once its probes
+// pass or its unix sockets appear. It is derived from the configuration, so
+// it holds before the health monitor of the service is registered.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - bool: true if probes or socket waits decide the readiness of the service.
+func (s *Supervisor) readinessGated(name string) bool {
+	// Skip when no configuration is loaded.
+	if s.config == nil {
+		// Not gated.
+		return false
+	}
+	svc := s.config.FindService(name)
+	// gated when probes will run for the service or its sockets are awaited
+	return svc != nil && (s.probesGate(svc) || s.socketsGate(svc))
+}
+
+// probesGate reports whether the probes of a service decide its readiness.
+// Must be called with s.mu held.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - bool: true if the service has probed listeners and a prober factory is set.
+func (s *Supervisor) probesGate(svc *domainconfig.ServiceConfig) bool {
+	// gated when probes will run for the service
+	return s.proberFactory != nil && s.hasConfiguredProbes(svc)
+}
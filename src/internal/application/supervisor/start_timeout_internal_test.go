@@ -0,0 +1,157 @@
+// Package supervisor provides internal tests for start_timeout.go.
+// It tests start deadlines using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// startTimeoutTestExecutor starts processes that never exit and records signals.
+type startTimeoutTestExecutor struct {
+	proxyTestExecutor
+	// mu protects signals.
+	mu sync.Mutex
+	// signals records the signalled pids.
+	signals []int
+}
+
+// Signal records the signalled pid.
+//
+// Params:
+//   - pid: the signalled pid.
+//
+// Returns:
+//   - error: always nil.
+func (e *startTimeoutTestExecutor) Signal(pid int, _ os.Signal) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.signals = append(e.signals, pid)
+	return nil
+}
+
+// signalled returns the signalled pids.
+//
+// Returns:
+//   - []int: the recorded pids.
+func (e *startTimeoutTestExecutor) signalled() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]int(nil), e.signals...)
+}
+
+// newStartTimeoutTestSupervisor builds a supervisor with one running service.
+//
+// Params:
+//   - t: the testing context.
+//   - timeout: the service start timeout.
+//   - gated: whether the service has probed listeners.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+//   - *applifecycle.Manager: the service manager.
+//   - *startTimeoutTestExecutor: the executor.
+//   - *shared.FakeClock: the clock of the deadlines.
+func newStartTimeoutTestSupervisor(t *testing.T, timeout time.Duration, gated bool) (*Supervisor, *applifecycle.Manager, *startTimeoutTestExecutor, *shared.FakeClock) {
+	t.Helper()
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+		{Name: "api", Command: "/bin/api", StartTimeout: shared.Duration(timeout)},
+	}}
+	// Probed listeners gate readiness.
+	if gated {
+		cfg.Services[0].Listeners = []domainconfig.ListenerConfig{
+			{Name: "http", Port: 8080, Probe: &domainconfig.ProbeConfig{Type: "tcp"}},
+		}
+	}
+	executor := &startTimeoutTestExecutor{}
+	mgr := applifecycle.NewManager(&cfg.Services[0], executor)
+	require.NoError(t, mgr.Start(context.Background()))
+	t.Cleanup(func() { _ = mgr.Stop() })
+	require.Eventually(t, func() bool { return mgr.PID() != 0 }, time.Second, 10*time.Millisecond)
+
+	clock := shared.NewFakeClock(time.Now())
+	s := &Supervisor{
+		config:        cfg,
+		clock:         clock,
+		managers:      map[string]*applifecycle.Manager{"api": mgr},
+		proberFactory: &mockProberFactory{},
+	}
+	return s, mgr, executor, clock
+}
+
+// Test_Supervisor_startDeadline_Expires tests that a service never ready is killed.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_startDeadline_Expires(t *testing.T) {
+	s, mgr, executor, clock := newStartTimeoutTestSupervisor(t, 20*time.Second, true)
+
+	s.mu.Lock()
+	s.updateStartDeadline("api", &domain.Event{Type: domain.EventStarted, PID: 4242})
+	s.mu.Unlock()
+	clock.Advance(20 * time.Second)
+
+	require.Eventually(t, func() bool { return len(executor.signalled()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int{4242}, executor.signalled())
+
+	// The kill is announced with a start timeout event.
+	var timedOut *domain.Event
+	require.Eventually(t, func() bool {
+		select {
+		case event := <-mgr.Events():
+			if event.Type == domain.EventStartTimeout {
+				timedOut = &event
+			}
+		default:
+		}
+		return timedOut != nil
+	}, time.Second, 5*time.Millisecond)
+	assert.ErrorIs(t, timedOut.Error, domain.ErrStartTimeout)
+}
+
+// Test_Supervisor_startDeadline_Cleared tests that ready or exited services are not killed.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_startDeadline_Cleared(t *testing.T) {
+	tests := []struct {
+		name  string
+		gated bool
+		clear *domain.Event
+	}{
+		{name: "became healthy", gated: true},
+		{name: "exited", gated: true, clear: &domain.Event{Type: domain.EventFailed}},
+		{name: "no probes", gated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, executor, clock := newStartTimeoutTestSupervisor(t, 20*time.Second, tt.gated)
+
+			s.mu.Lock()
+			s.updateStartDeadline("api", &domain.Event{Type: domain.EventStarted, PID: 4242})
+			// Readiness clears the deadline like OnHealthy does.
+			if tt.clear == nil {
+				s.clearStartDeadline("api")
+			} else {
+				s.updateStartDeadline("api", tt.clear)
+			}
+			s.mu.Unlock()
+
+			clock.Advance(time.Minute)
+			assert.Empty(t, executor.signalled())
+			assert.Empty(t, s.startDeadlines)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file defines the supervisor state machine.
+package supervisor
+
+import "slices"
+
+// State represents the supervisor state.
+// It defines the current operational status of the supervisor.
+type State int
+
+// Supervisor state constants.
+// StateReloading comes last so the values of existing states never change.
+const (
+	StateStopped State = iota
+	StateStarting
+	StateRunning
+	StateStopping
+	StateReloading
+)
+
+// StateAny matches every state when registering a transition hook.
+const StateAny State = -1
+
+// stateTransitions lists, per state, the states it may move to.
+// Start, Stop and Reload are serialized, so Stop never interrupts a reload:
+// it waits for the reload to return to StateRunning.
+var stateTransitions map[State][]State = map[State][]State{
+	StateStopped:   {StateStarting},
+	StateStarting:  {StateRunning, StateStopped},
+	StateRunning:   {StateReloading, StateStopping},
+	StateReloading: {StateRunning},
+	StateStopping:  {StateStopped},
+}
+
+// StateHook is a callback invoked after a supervisor state change.
+// Hooks run while Start, Stop or Reload is in progress and must not call them.
+type StateHook func(from, to State)
+
+// stateHook is a registered transition hook.
+type stateHook struct {
+	// from is the source state, or StateAny.
+	from State
+	// to is the target state, or StateAny.
+	to State
+	// fn is the callback.
+	fn StateHook
+}
+
+// String returns the string representation of the state.
+//
+// Returns:
+//   - string: the state name.
+func (s State) String() string {
+	// map state to name
+	switch s {
+	// stopped state
+	case StateStopped:
+		// stopped name
+		return "stopped"
+	// starting state
+	case StateStarting:
+		// starting name
+		return "starting"
+	// running state
+	case StateRunning:
+		// running name
+		return "running"
+	// stopping state
+	case StateStopping:
+		// stopping name
+		return "stopping"
+	// reloading state
+	case StateReloading:
+		// reloading name
+		return "reloading"
+	// wildcard
+	case StateAny:
+		// wildcard name
+		return "any"
+	// unknown state
+	default:
+		// unknown name
+		return "unknown"
+	}
+}
+
+// CanTransitionTo reports whether the supervisor may move from s to the target state.
+//
+// Params:
+//   - to: the target state.
+//
+// Returns:
+//   - bool: true if the transition is allowed.
+func (s State) CanTransitionTo(to State) bool {
+	// look up allowed targets
+	return slices.Contains(stateTransitions[s], to)
+}
+
+// OnTransition registers a hook called after every change from one state to
+// another. StateAny matches every state, so OnTransition(StateAny, StateAny, h)
+// observes all state changes. Hooks run in registration order.
+//
+// Params:
+//   - from: the source state, or StateAny.
+//   - to: the target state, or StateAny.
+//   - hook: the callback.
+func (s *Supervisor) OnTransition(from, to State, hook StateHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// append hook
+	s.stateHooks = append(s.stateHooks, stateHook{from: from, to: to, fn: hook})
+}
+
+// transition moves the supervisor to a new state if the state machine allows it.
+// Must be called with s.mu held; the caller runs the hooks once it is released.
+//
+// Params:
+//   - to: the target state.
+//
+// Returns:
+//   - State: the state before the call.
+//   - bool: true if the transition happened.
+func (s *Supervisor) transition(to State) (State, bool) {
+	from := s.state
+	// refuse transitions not in the state machine
+	if !from.CanTransitionTo(to) {
+		// state unchanged
+		return from, false
+	}
+	s.state = to
+	s.publishStatus()
+	// transition done
+	return from, true
+}
+
+// changeState moves the supervisor to a new state and runs the matching hooks.
+//
+// Params:
+//   - to: the target state.
+//
+// Returns:
+//   - bool: true if the transition happened.
+func (s *Supervisor) changeState(to State) bool {
+	s.mu.Lock()
+	from, ok := s.transition(to)
+	s.mu.Unlock()
+	// run hooks only on an actual change
+	if ok {
+		s.runStateHooks(from, to)
+	}
+	// report outcome
+	return ok
+}
+
+// runStateHooks calls the hooks matching a state change.
+// Must be called without s.mu held.
+//
+// Params:
+//   - from: the previous state.
+//   - to: the new state.
+func (s *Supervisor) runStateHooks(from, to State) {
+	s.mu.RLock()
+	hooks := make([]StateHook, 0, len(s.stateHooks))
+	// select matching hooks
+	for _, h := range s.stateHooks {
+		// keep hooks matching both ends
+		if (h.from == StateAny || h.from == from) && (h.to == StateAny || h.to == to) {
+			hooks = append(hooks, h.fn)
+		}
+	}
+	s.mu.RUnlock()
+
+	// call hooks outside the lock
+	for _, hook := range hooks {
+		hook(from, to)
+	}
+}
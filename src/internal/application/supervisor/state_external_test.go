@@ -0,0 +1,222 @@
+// Package supervisor_test provides black-box tests for state.go.
+package supervisor_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/supervisor"
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// blockingLoader returns a configuration once released.
+type blockingLoader struct {
+	// cfg is the configuration to return.
+	cfg *config.Config
+	// entered is closed when Load is called.
+	entered chan struct{}
+	// release unblocks Load.
+	release chan struct{}
+}
+
+// Load blocks until released, then returns the configuration.
+//
+// Params:
+//   - path: the configuration path (unused).
+//
+// Returns:
+//   - *config.Config: the configuration.
+//   - error: always nil.
+func (bl *blockingLoader) Load(_ string) (*config.Config, error) {
+	close(bl.entered)
+	<-bl.release
+	return bl.cfg, nil
+}
+
+// transitionRecorder records state changes.
+type transitionRecorder struct {
+	// mu protects changes.
+	mu sync.Mutex
+	// changes lists "from->to" entries.
+	changes []string
+}
+
+// record stores a state change.
+//
+// Params:
+//   - from: the previous state.
+//   - to: the new state.
+func (r *transitionRecorder) record(from, to supervisor.State) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, fmt.Sprintf("%s->%s", from, to))
+}
+
+// recorded returns the recorded changes.
+//
+// Returns:
+//   - []string: the changes in order.
+func (r *transitionRecorder) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.changes...)
+}
+
+// TestState_String tests the string representation of supervisor states.
+//
+// Params:
+//   - t: the testing context.
+func TestState_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		state supervisor.State
+		want  string
+	}{
+		{name: "stopped", state: supervisor.StateStopped, want: "stopped"},
+		{name: "starting", state: supervisor.StateStarting, want: "starting"},
+		{name: "running", state: supervisor.StateRunning, want: "running"},
+		{name: "stopping", state: supervisor.StateStopping, want: "stopping"},
+		{name: "reloading", state: supervisor.StateReloading, want: "reloading"},
+		{name: "any", state: supervisor.StateAny, want: "any"},
+		{name: "unknown", state: supervisor.State(42), want: "unknown"},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.state.String())
+		})
+	}
+}
+
+// TestState_CanTransitionTo tests the guarded transitions of the state machine.
+//
+// Params:
+//   - t: the testing context.
+func TestState_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from supervisor.State
+		to   supervisor.State
+		want bool
+	}{
+		{name: "stopped_to_starting", from: supervisor.StateStopped, to: supervisor.StateStarting, want: true},
+		{name: "stopped_to_running", from: supervisor.StateStopped, to: supervisor.StateRunning, want: false},
+		{name: "starting_to_running", from: supervisor.StateStarting, to: supervisor.StateRunning, want: true},
+		{name: "starting_to_stopped", from: supervisor.StateStarting, to: supervisor.StateStopped, want: true},
+		{name: "running_to_reloading", from: supervisor.StateRunning, to: supervisor.StateReloading, want: true},
+		{name: "running_to_stopping", from: supervisor.StateRunning, to: supervisor.StateStopping, want: true},
+		{name: "reloading_to_running", from: supervisor.StateReloading, to: supervisor.StateRunning, want: true},
+		{name: "reloading_to_stopping", from: supervisor.StateReloading, to: supervisor.StateStopping, want: false},
+		{name: "reloading_to_reloading", from: supervisor.StateReloading, to: supervisor.StateReloading, want: false},
+		{name: "stopping_to_stopped", from: supervisor.StateStopping, to: supervisor.StateStopped, want: true},
+		{name: "stopping_to_starting", from: supervisor.StateStopping, to: supervisor.StateStarting, want: false},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+// TestSupervisor_OnTransition tests that hooks observe every state change in order.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_OnTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from supervisor.State
+		to   supervisor.State
+		want []string
+	}{
+		{
+			name: "all_changes",
+			from: supervisor.StateAny,
+			to:   supervisor.StateAny,
+			want: []string{
+				"stopped->starting", "starting->running",
+				"running->reloading", "reloading->running",
+				"running->stopping", "stopping->stopped",
+			},
+		},
+		{
+			name: "into_running",
+			from: supervisor.StateAny,
+			to:   supervisor.StateRunning,
+			want: []string{"starting->running", "reloading->running"},
+		},
+		{
+			name: "single_transition",
+			from: supervisor.StateRunning,
+			to:   supervisor.StateStopping,
+			want: []string{"running->stopping"},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createValidConfig()
+			sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+			require.NoError(t, err)
+			recorder := &transitionRecorder{}
+			sup.OnTransition(tt.from, tt.to, recorder.record)
+
+			require.NoError(t, sup.Start(context.Background()))
+			require.NoError(t, sup.Reload())
+			require.NoError(t, sup.Stop())
+
+			assert.Equal(t, tt.want, recorder.recorded())
+		})
+	}
+}
+
+// TestSupervisor_StopDuringReload tests that Stop waits for an in-flight reload.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_StopDuringReload(t *testing.T) {
+	cfg := createValidConfig()
+	loader := &blockingLoader{cfg: cfg, entered: make(chan struct{}), release: make(chan struct{})}
+	sup, err := supervisor.NewSupervisor(cfg, loader, &mockExecutor{}, nil)
+	require.NoError(t, err)
+	recorder := &transitionRecorder{}
+	sup.OnTransition(supervisor.StateAny, supervisor.StateAny, recorder.record)
+	require.NoError(t, sup.Start(context.Background()))
+
+	reloadErr := make(chan error, 1)
+	go func() { reloadErr <- sup.Reload() }()
+	<-loader.entered
+	assert.Equal(t, supervisor.StateReloading, sup.State())
+
+	stopErr := make(chan error, 1)
+	go func() { stopErr <- sup.Stop() }()
+
+	// Stop must not proceed while the reload is in flight.
+	select {
+	case <-stopErr:
+		t.Fatal("Stop returned during reload")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, supervisor.StateReloading, sup.State())
+
+	close(loader.release)
+	require.NoError(t, <-reloadErr)
+	require.NoError(t, <-stopErr)
+
+	assert.Equal(t, supervisor.StateStopped, sup.State())
+	assert.Equal(t, []string{
+		"stopped->starting", "starting->running",
+		"running->reloading", "reloading->running",
+		"running->stopping", "stopping->stopped",
+	}, recorder.recorded())
+}
@@ -0,0 +1,174 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file persists service statistics across daemon restarts.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// statsPersistInterval is how often service statistics are saved while running.
+const statsPersistInterval time.Duration = time.Minute
+
+// SetStatsStore sets the store persisting service statistics, and restores
+// the statistics saved by previous runs. Call it once, before Start.
+// Without a store, statistics start from zero on each daemon start.
+//
+// Params:
+//   - store: the statistics store to use.
+func (s *Supervisor) SetStatsStore(store storage.StatsStore) {
+	s.mu.Lock()
+	s.statsStore = store
+	stats := make(map[string]*ServiceStats, len(s.stats))
+	// copy the stats to restore outside the lock
+	for name, st := range s.stats {
+		stats[name] = st
+	}
+	s.mu.Unlock()
+
+	// restore each configured service
+	for name, st := range stats {
+		rec, found, err := store.LoadServiceStats(context.Background(), name)
+		// Report read failure (best-effort persistence).
+		if err != nil {
+			s.handleRecoveryError("stats-store", name, err)
+			continue
+		}
+		// restore saved statistics
+		if found {
+			st.Restore(&rec)
+		}
+	}
+}
+
+// ServiceStats returns the lifetime statistics of a service.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - metrics.ServiceStats: counters, up and down time, and availability.
+//   - error: ErrServiceNotFound if the service does not exist.
+func (s *Supervisor) ServiceStats(name string) (metrics.ServiceStats, error) {
+	s.mu.RLock()
+	stats, ok := s.stats[name]
+	var slo *metrics.SLOStatus
+	// measure the objective, if any
+	if svc := s.sloService(name); svc != nil {
+		status := s.sloStatus(svc, time.Now())
+		slo = &status
+	}
+	s.mu.RUnlock()
+
+	// validate service exists
+	if !ok {
+		// Return error for missing service.
+		return metrics.ServiceStats{}, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	snap := stats.Snapshot()
+	topExits := stats.TopExits(time.Now())
+	// convert snapshot
+	return metrics.ServiceStats{
+		ServiceName:  name,
+		StartCount:   snap.StartCount,
+		StopCount:    snap.StopCount,
+		FailCount:    snap.FailCount,
+		RestartCount: snap.RestartCount,
+		Uptime:       snap.Uptime,
+		Downtime:     snap.Downtime,
+		Availability: snap.Availability,
+		SLO:          slo,
+		TopExits:     topExits,
+	}, nil
+}
+
+// startStatsPersister saves service statistics periodically, so a crash of
+// the daemon loses at most one interval. It is skipped without a store.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine saving statistics on a ticker.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startStatsPersister() {
+	// Skip when persistence is disabled.
+	if s.getStatsStore() == nil {
+		// Nothing to save to.
+		return
+	}
+
+	// Save statistics until shutdown.
+	s.wg.Go(func() {
+		ticker := time.NewTicker(statsPersistInterval)
+		defer ticker.Stop()
+		// Loop until context is cancelled.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case <-ticker.C:
+				s.persistStats()
+			}
+		}
+	})
+}
+
+// persistStats saves the statistics of every service when a statistics
+// store is configured. Write failures are reported through the error handler.
+func (s *Supervisor) persistStats() {
+	store := s.getStatsStore()
+	// persistence disabled
+	if store == nil {
+		// nothing to save
+		return
+	}
+
+	now := time.Now()
+	s.mu.RLock()
+	records := make([]storage.ServiceStatsRecord, 0, len(s.stats))
+	// snapshot every service
+	for name, stats := range s.stats {
+		records = append(records, stats.Record(name, now))
+	}
+	s.mu.RUnlock()
+
+	// save each record
+	for i := range records {
+		// Report write failure (best-effort persistence).
+		if err := store.SaveServiceStats(context.Background(), &records[i]); err != nil {
+			s.handleRecoveryError("stats-store", records[i].Service, err)
+		}
+	}
+}
+
+// getStatsStore returns the configured statistics store.
+//
+// Returns:
+//   - storage.StatsStore: the store, or nil when persistence is disabled.
+func (s *Supervisor) getStatsStore() storage.StatsStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// return statistics store
+	return s.statsStore
+}
+
+// eventTime returns when an event occurred, now for events created without a time.
+//
+// Params:
+//   - event: the process event.
+//
+// Returns:
+//   - time.Time: the event time.
+func eventTime(event *domain.Event) time.Time {
+	// stamp events created without a time
+	if event.Timestamp.IsZero() {
+		// use the current time
+		return time.Now()
+	}
+	// use the event time
+	return event.Timestamp
+}
@@ -0,0 +1,127 @@
+// Package supervisor provides internal tests for stats_persistence.go.
+// It tests the persistence of service statistics using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// memoryStatsStore is an in-memory storage.StatsStore.
+type memoryStatsStore struct {
+	// mu protects the fields.
+	mu sync.Mutex
+	// records are the saved records by service.
+	records map[string]storage.ServiceStatsRecord
+	// err is returned by every method when set.
+	err error
+}
+
+// SaveServiceStats stores the record.
+//
+// Params:
+//   - rec: the record to store.
+//
+// Returns:
+//   - error: err when set.
+func (m *memoryStatsStore) SaveServiceStats(_ context.Context, rec *storage.ServiceStatsRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	if m.records == nil {
+		m.records = make(map[string]storage.ServiceStatsRecord)
+	}
+	m.records[rec.Service] = *rec
+	return nil
+}
+
+// LoadServiceStats returns the stored record.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - storage.ServiceStatsRecord: the stored record.
+//   - bool: whether a record was stored.
+//   - error: err when set.
+func (m *memoryStatsStore) LoadServiceStats(_ context.Context, service string) (storage.ServiceStatsRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec, ok := m.records[service]
+	return rec, ok, m.err
+}
+
+// Test_Supervisor_statsPersistence tests that statistics survive a daemon restart.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_statsPersistence(t *testing.T) {
+	store := &memoryStatsStore{}
+	start := time.Now().Add(-time.Hour)
+
+	// First run: the service runs 45 minutes, then fails for 15.
+	first := &Supervisor{stats: map[string]*ServiceStats{"api": NewServiceStats()}}
+	first.SetStatsStore(store)
+	first.updateStatsForEvent(first.stats["api"], &domain.Event{Type: domain.EventStarted, Timestamp: start})
+	first.updateStatsForEvent(first.stats["api"], &domain.Event{
+		Type: domain.EventFailed, ExitCode: 2, Error: domain.ErrProcessFailed, Timestamp: start.Add(45 * time.Minute),
+	})
+	first.updateStatsForEvent(first.stats["api"], &domain.Event{Type: domain.EventRestarting, Timestamp: start.Add(45 * time.Minute)})
+	first.updateStatsForEvent(first.stats["api"], &domain.Event{Type: domain.EventStarted, Timestamp: start.Add(time.Hour)})
+	first.persistStats()
+
+	// Second run: the saved statistics are restored.
+	second := &Supervisor{stats: map[string]*ServiceStats{"api": NewServiceStats(), "web": NewServiceStats()}}
+	second.SetStatsStore(store)
+
+	stats, err := second.ServiceStats("api")
+	require.NoError(t, err)
+	assert.Equal(t, "api", stats.ServiceName)
+	assert.Equal(t, 2, stats.StartCount)
+	assert.Equal(t, 1, stats.FailCount)
+	assert.Equal(t, 1, stats.RestartCount)
+	assert.InDelta(t, 45*time.Minute, stats.Uptime, float64(time.Second))
+	assert.Equal(t, 15*time.Minute, stats.Downtime)
+	assert.InDelta(t, 75.0, stats.Availability.Day, 0.1)
+	assert.Equal(t, []metrics.ExitCount{{Cause: metrics.ExitCause{Code: 2}, Count: 1, Last: start.Add(45 * time.Minute)}}, stats.TopExits)
+
+	stats, err = second.ServiceStats("web")
+	require.NoError(t, err)
+	assert.Zero(t, stats.StartCount)
+	assert.InDelta(t, 100.0, stats.Availability.Month, 0.001)
+
+	_, err = second.ServiceStats("missing")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
+
+// Test_Supervisor_statsPersistence_errors tests that store failures are reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_statsPersistence_errors(t *testing.T) {
+	failure := errors.New("disk full")
+	var reported []error
+	s := &Supervisor{
+		stats:        map[string]*ServiceStats{"api": NewServiceStats()},
+		errorHandler: func(_, _ string, err error) { reported = append(reported, err) },
+	}
+
+	s.SetStatsStore(&memoryStatsStore{err: failure})
+	s.persistStats()
+
+	require.Len(t, reported, 2)
+	assert.ErrorIs(t, reported[0], failure)
+	assert.ErrorIs(t, reported[1], failure)
+}
@@ -0,0 +1,136 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file keeps the copy-on-write status read by the polling methods.
+package supervisor
+
+import (
+	"sync/atomic"
+	"time"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// statusSnapshot is an immutable view of the supervisor and service status.
+// It is rebuilt when the supervisor state or the set of services changes and
+// swapped atomically, so Services, Stats, AllStats, Healthy and ServiceHealth,
+// polled by the API and the TUI, never wait for lifecycle operations holding
+// s.mu. Service events only swap the status of their service.
+type statusSnapshot struct {
+	// state is the supervisor state.
+	state State
+	// services holds the process status of each service.
+	services map[string]*serviceEntry
+	// stats holds the statistics of each service, synchronized on their own.
+	stats map[string]*ServiceStats
+	// monitors holds the health monitors, synchronized on their own.
+	monitors map[string]*apphealth.ProbeMonitor
+}
+
+// serviceEntry holds the last status of a service, swapped on each event.
+type serviceEntry struct {
+	// status is the immutable process status.
+	status atomic.Pointer[serviceStatus]
+}
+
+// serviceStatus is the process status of a service when the snapshot was taken.
+type serviceStatus struct {
+	// state is the process state.
+	state domain.State
+	// pid is the process ID, zero when not running.
+	pid int
+	// startedAt is when the process started, so uptime is computed on read.
+	startedAt time.Time
+	// reason explains a skipped state.
+	reason string
+}
+
+// emptyStatus is the status of a supervisor that published none.
+var emptyStatus *statusSnapshot = &statusSnapshot{}
+
+// publishStatus rebuilds the status snapshot from the current state.
+// Must be called with s.mu held, after every change of the supervisor state,
+// the managed services, their statistics or health monitors.
+func (s *Supervisor) publishStatus() {
+	now := time.Now()
+	services := make(map[string]*serviceEntry, len(s.managers))
+	// capture each process status
+	for name, mgr := range s.managers {
+		entry := &serviceEntry{}
+		entry.status.Store(captureStatus(mgr, now))
+		services[name] = entry
+	}
+	stats := make(map[string]*ServiceStats, len(s.stats))
+	// share the statistics, updated in place
+	for name, st := range s.stats {
+		stats[name] = st
+	}
+	monitors := make(map[string]*apphealth.ProbeMonitor, len(s.healthMonitors))
+	// share the monitors, updated in place
+	for name, monitor := range s.healthMonitors {
+		monitors[name] = monitor
+	}
+	s.status.Store(&statusSnapshot{state: s.state, services: services, stats: stats, monitors: monitors})
+}
+
+// publishServiceStatus swaps the status of one service after its event.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) publishServiceStatus(name string) {
+	entry, ok := s.loadStatus().services[name]
+	mgr, managed := s.managers[name]
+	// rebuild when the set of services changed since the last snapshot
+	if !ok || !managed {
+		s.publishStatus()
+		// snapshot rebuilt
+		return
+	}
+	entry.status.Store(captureStatus(mgr, time.Now()))
+}
+
+// captureStatus reads the process status of a manager.
+//
+// Params:
+//   - mgr: the service manager.
+//   - now: the current time.
+//
+// Returns:
+//   - *serviceStatus: the status.
+func captureStatus(mgr *applifecycle.Manager, now time.Time) *serviceStatus {
+	status := mgr.Status()
+	// return the status with the start time
+	return &serviceStatus{state: status.State, pid: status.PID, startedAt: now.Add(-status.Uptime), reason: status.Reason}
+}
+
+// loadStatus returns the last published status snapshot, without locking.
+//
+// Returns:
+//   - *statusSnapshot: the snapshot; it must not be modified.
+func (s *Supervisor) loadStatus() *statusSnapshot {
+	// use the published snapshot when any
+	if status := s.status.Load(); status != nil {
+		// return the published snapshot
+		return status
+	}
+	// return the empty status
+	return emptyStatus
+}
+
+// uptime returns the seconds the process has been running.
+//
+// Params:
+//   - now: the current time.
+//
+// Returns:
+//   - int64: the uptime in seconds, zero when not running.
+func (st *serviceStatus) uptime(now time.Time) int64 {
+	// stopped processes have no uptime
+	if st.state != domain.StateRunning {
+		// return zero uptime
+		return 0
+	}
+	// return elapsed seconds
+	return int64(now.Sub(st.startedAt).Seconds())
+}
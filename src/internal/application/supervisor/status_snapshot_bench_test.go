@@ -0,0 +1,84 @@
+//go:build !race
+
+package supervisor
+
+import (
+	"strconv"
+	"testing"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// newBenchSupervisor creates a stopped supervisor with N services.
+func newBenchSupervisor(b *testing.B, serviceCount int) *Supervisor {
+	b.Helper()
+	cfg := &domainconfig.Config{Services: make([]domainconfig.ServiceConfig, serviceCount)}
+	for i := range cfg.Services {
+		cfg.Services[i] = domainconfig.ServiceConfig{
+			Name:    "service-" + strconv.Itoa(i),
+			Command: "/bin/sleep",
+			Restart: domainconfig.RestartConfig{Policy: domainconfig.RestartNever},
+		}
+	}
+	s, err := NewSupervisor(cfg, nil, &proxyTestExecutor{}, nil)
+	if err != nil {
+		b.Fatalf("NewSupervisor failed: %v", err)
+	}
+	return s
+}
+
+// BenchmarkSupervisorHandleEvent measures the event pipeline, status
+// snapshot publication included.
+func BenchmarkSupervisorHandleEvent(b *testing.B) {
+	benchmarks := []struct {
+		name         string
+		serviceCount int
+	}{
+		{"10Services", 10},
+		{"1000Services", 1000},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			s := newBenchSupervisor(b, bm.serviceCount)
+			event := domain.NewEvent(domain.EventRestarting, "service-0", 0, 0, nil)
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for range b.N {
+				s.handleEvent("service-0", &event)
+			}
+		})
+	}
+}
+
+// BenchmarkSupervisorPollingUnderEvents measures status polling while
+// events are handled concurrently.
+func BenchmarkSupervisorPollingUnderEvents(b *testing.B) {
+	s := newBenchSupervisor(b, 1000)
+	event := domain.NewEvent(domain.EventRestarting, "service-0", 0, 0, nil)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				s.handleEvent("service-0", &event)
+			}
+		}
+	}()
+	defer close(done)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = s.Stats("service-1")
+			_ = s.Healthy([]string{"service-1"})
+		}
+	})
+}
@@ -0,0 +1,84 @@
+// Package supervisor provides internal tests for status_snapshot.go.
+// It tests the copy-on-write status using white-box testing.
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_Supervisor_loadStatus tests that the polling methods read the last
+// published status without waiting for s.mu.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_loadStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		publish   bool
+		wantCount int
+	}{
+		{name: "published status", publish: true, wantCount: 1},
+		{name: "nothing published"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newProxyTestConfig()
+			s := &Supervisor{
+				config:   cfg,
+				managers: map[string]*applifecycle.Manager{"api": applifecycle.NewManager(&cfg.Services[0], &proxyTestExecutor{})},
+				stats:    map[string]*ServiceStats{"api": NewServiceStats()},
+			}
+			// publish as the event pipeline would
+			if tt.publish {
+				s.publishStatus()
+			}
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			done := make(chan map[string]ServiceInfo)
+			go func() {
+				_ = s.AllStats()
+				_ = s.Stats("api")
+				_ = s.Healthy([]string{"api"})
+				done <- s.Services()
+			}()
+
+			select {
+			case services := <-done:
+				assert.Len(t, services, tt.wantCount)
+			case <-time.After(time.Second):
+				t.Fatal("polling waited for the supervisor lock")
+			}
+		})
+	}
+}
+
+// Test_serviceStatus_uptime tests that uptime is computed on read.
+//
+// Params:
+//   - t: the testing context.
+func Test_serviceStatus_uptime(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		status serviceStatus
+		want   int64
+	}{
+		{name: "running", status: serviceStatus{state: domain.StateRunning, startedAt: now.Add(-90 * time.Second)}, want: 90},
+		{name: "stopped", status: serviceStatus{state: domain.StateStopped, startedAt: now.Add(-90 * time.Second)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.status.uptime(now))
+		})
+	}
+}
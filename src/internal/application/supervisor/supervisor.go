@@ -7,38 +7,53 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	appconfig "github.com/kodflow/daemon/internal/application/config"
 	apphealth "github.com/kodflow/daemon/internal/application/health"
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
 	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
 	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
 	domainconfig "github.com/kodflow/daemon/internal/domain/config"
 	domainhealth "github.com/kodflow/daemon/internal/domain/health"
 	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
 	"github.com/kodflow/daemon/internal/domain/listener"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
 	domain "github.com/kodflow/daemon/internal/domain/process"
-)
-
-// State represents the supervisor state.
-// It defines the current operational status of the supervisor.
-type State int
-
-// Supervisor state constants.
-const (
-	StateStopped State = iota
-	StateStarting
-	StateRunning
-	StateStopping
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/storage"
 )
 
 // Errors for supervisor operations.
 var (
 	// ErrAlreadyRunning is returned when the supervisor is already running.
-	ErrAlreadyRunning error = fmt.Errorf("supervisor already running")
+	ErrAlreadyRunning error = shared.NewCodedError(shared.CodeSupervisorAlreadyRunning, "supervisor already running")
 	// ErrNotRunning is returned when the supervisor is not running.
-	ErrNotRunning error = fmt.Errorf("supervisor not running")
+	ErrNotRunning error = shared.NewCodedError(shared.CodeSupervisorNotRunning, "supervisor not running")
 	// ErrServiceNotFound is returned when a service is not found.
-	ErrServiceNotFound error = fmt.Errorf("service not found")
+	ErrServiceNotFound error = shared.NewCodedError(shared.CodeServiceNotFound, "service not found")
+	// ErrBootFailed is returned when a critical service failed at boot under the shutdown policy.
+	ErrBootFailed error = shared.NewCodedError(shared.CodeBootFailed, "critical service failed at boot")
+	// ErrSignalNotAllowed is returned when a signal is not in the allowlist of the service.
+	ErrSignalNotAllowed error = shared.NewCodedError(shared.CodeSignalNotAllowed, "signal not allowed")
+	// ErrReloadRefused is returned when a reloaded configuration fails pre-flight checks.
+	ErrReloadRefused error = shared.NewCodedError(shared.CodeConfigPreflightFailed, "reload refused")
+	// ErrCPUThrottled is attached to throttling events when a service exceeds the threshold.
+	ErrCPUThrottled error = fmt.Errorf("cpu throttled by cgroup limit")
+	// ErrPressureAlert is attached to pressure alert events when a service exceeds a PSI rule.
+	ErrPressureAlert error = fmt.Errorf("resource pressure above alert threshold")
+	// ErrListenerConflict is attached to conflict events when another process holds a listener port.
+	ErrListenerConflict error = fmt.Errorf("listener port bound by another process")
+	// ErrDependencyDown is attached to dependency events when an external dependency fails its probe.
+	ErrDependencyDown error = fmt.Errorf("external dependency down")
+	// ErrRestartGated is returned when a health-check restart waits for a down external dependency.
+	ErrRestartGated error = fmt.Errorf("restart held back while external dependency is down")
+	// ErrCorrelatedIncident is attached to incident events grouping close failures of several services.
+	ErrCorrelatedIncident error = fmt.Errorf("correlated failures")
 )
 
 // EventHandler is a callback function for process events.
@@ -68,16 +83,38 @@ type AddListenerWithBindinger interface {
 type Supervisor struct {
 	// mu is the mutex for thread-safe access.
 	mu sync.RWMutex
+	// transitionMu serializes Start, Stop and Reload so their state
+	// transitions never interleave.
+	transitionMu sync.Mutex
 	// config is the service configuration.
 	config *domainconfig.Config
 	// loader is the configuration loader.
 	loader appconfig.Loader
+	// preflighter checks a reloaded configuration before it is applied.
+	preflighter appconfig.Preflighter
+	// lockdown keeps the control plane read-only whatever the configuration.
+	lockdown bool
+	// clock times the restart backoff, the probes and the service deadlines.
+	clock shared.Clock
 	// executor is the process execution.
 	executor domain.Executor
 	// managers is the map of service managers.
 	managers map[string]*applifecycle.Manager
 	// healthMonitors is the map of health monitors per service.
 	healthMonitors map[string]*apphealth.ProbeMonitor
+	// dependencyMonitors probes, per service, the declared external dependencies.
+	dependencyMonitors map[string]*apphealth.ProbeMonitor
+	// dependenciesDown records, per service, dependencies reported down.
+	dependenciesDown map[string]map[string]bool
+	// dependenciesNotReady records, per service, dependencies not yet
+	// healthy or failing their probe.
+	dependenciesNotReady map[string]map[string]bool
+	// unhealthyReports counts, per service and listener, the reports of a
+	// failing probe since it was last healthy, to follow on_unhealthy.
+	unhealthyReports map[string]map[string]int
+	// dependencyFailures records, per service, the last failure seen while
+	// a dependency was down, retried once the dependencies recover.
+	dependencyFailures map[string]domain.EventType
 	// proberFactory creates health probers.
 	proberFactory apphealth.Creator
 	// reaper is the zombie process reaper (domain port).
@@ -98,6 +135,141 @@ type Supervisor struct {
 	stats map[string]*ServiceStats
 	// metricsTracker tracks process CPU and memory metrics.
 	metricsTracker appmetrics.ProcessTracker
+	// throttled records services currently above the CPU throttling threshold.
+	throttled map[string]bool
+	// pressureAlerts records, per service, the indexes of active pressure alert rules.
+	pressureAlerts map[string]map[int]bool
+	// conflicts records, per service, listeners whose port is held by another process.
+	conflicts map[string]map[string]bool
+	// proxyOpener binds public endpoints of proxied listeners.
+	proxyOpener appproxy.Opener
+	// relays holds, per service, the bound public endpoints.
+	relays map[string][]appproxy.Relay
+	// operations holds submitted lifecycle operations by ID.
+	operations map[string]*Operation
+	// eventStore persists lifecycle events for replay.
+	eventStore storage.EventStore
+	// statsStore persists service statistics across daemon restarts.
+	statsStore storage.StatsStore
+	// healthLogs holds, per service with an SLO, its availability history.
+	healthLogs map[string]domainmetrics.HealthLog
+	// burnAlerts records, per service, the indexes of firing SLO burn alerts.
+	burnAlerts map[string]map[int]bool
+	// outputStreamer reads captured service output for job run tails.
+	outputStreamer domainlogging.OutputStreamer
+	// startPhases holds the start phases of the initial startup not started yet.
+	startPhases [][]string
+	// jobStarts holds, per oneshot service, when the current run started.
+	jobStarts map[string]time.Time
+	// jobRuns holds, per oneshot service, its last runs, oldest first.
+	jobRuns map[string][]domain.JobRun
+	// jobQuiesces holds, per running job, the service it quiesced.
+	jobQuiesces map[string]jobQuiesce
+	// inspector reads the cgroup and resource limits of running processes.
+	inspector domain.Inspector
+	// treeReader reads the processes spawned by running services.
+	treeReader domain.TreeReader
+	// socketReader reads the sockets of running services for traffic sampling.
+	socketReader domain.SocketReader
+	// traffic holds the sampled dependency map, by service and peer or endpoint.
+	traffic map[trafficKey]*domain.TrafficEdge
+	// hostPressure collects the host memory pressure driving shedding.
+	hostPressure appmetrics.HostPressureCollector
+	// shed records the services stopped to relieve host memory pressure.
+	shed map[string]bool
+	// capacityCollector measures the host capacity admitting reservations.
+	capacityCollector appmetrics.CapacityCollector
+	// ledger accounts for the resources the executor holds per process start.
+	ledger domain.ResourceLedger
+	// leakSeen counts, per PID, the consecutive leak checks its resources were unowned.
+	leakSeen map[int]int
+	// selfCollector measures the resident memory and open descriptors of the daemon.
+	selfCollector appmetrics.SelfCollector
+	// capacity is the host capacity measured at the last start or reload, zero when unknown.
+	capacity domainmetrics.HostCapacity
+	// hostFactsCollector reads the host facts checked by service start conditions.
+	hostFactsCollector appmetrics.HostFactsCollector
+	// hostFacts are the host facts read at the last start or reload, nil when unknown.
+	hostFacts *domainconfig.HostFacts
+	// inventoryCollector detects the platform capabilities of the host.
+	inventoryCollector appmetrics.HostInventoryCollector
+	// inventory is the host inventory detected at the last start, nil when unknown.
+	inventory *domainmetrics.HostInventory
+	// startDeadlines holds, per service, the pending start timeout.
+	startDeadlines map[string]*startDeadline
+	// fence tracks the applied events for read-your-writes snapshots.
+	fence eventFence
+	// eventSeq numbers the events of every manager.
+	eventSeq atomic.Uint64
+	// socketWaits holds, per service, the pending wait for its unix sockets.
+	socketWaits map[string]*socketWait
+	// runtimeLimits holds, per service, the pending max runtime.
+	runtimeLimits map[string]*runtimeLimit
+	// boot tracks the outcome of the initial startup.
+	boot domainlifecycle.BootReport
+	// bootHandler is the optional callback for the completed boot report.
+	bootHandler BootHandler
+	// shutdown is the report of the shutdown in progress.
+	shutdown domainlifecycle.ShutdownReport
+	// shutdownHandler is the optional callback for the shutdown report.
+	shutdownHandler ShutdownHandler
+	// stateHooks are called after supervisor state changes.
+	stateHooks []stateHook
+	// reloads serializes and coalesces configuration reloads.
+	reloads reloadQueue
+	// probeTraceHandler is the optional callback for traced probe attempts.
+	probeTraceHandler ProbeTraceHandler
+	// incidentMu guards the incident correlator, which sees events from every service.
+	incidentMu sync.Mutex
+	// incidents groups close failures into incidents, nil when disabled.
+	incidents *domain.IncidentCorrelator
+	// incidentConfig holds the settings the correlator was built with.
+	incidentConfig domainconfig.IncidentConfig
+	// restartMu guards the restart budget and the safe mode, which every manager draws from.
+	restartMu sync.Mutex
+	// restartBudget bounds the restarts across all services, nil when disabled.
+	restartBudget *domain.RestartBudget
+	// restartBudgetConfig holds the settings the restart budget was built with.
+	restartBudgetConfig domainconfig.RestartBudgetConfig
+	// restartStorm counts the restarts across all services, nil when detection is disabled.
+	restartStorm *domain.RestartStorm
+	// safeModeConfig holds the settings the restart storm detection was built with.
+	safeModeConfig domainconfig.SafeModeConfig
+	// safeMode describes the safe mode while it is active.
+	safeMode domain.SafeMode
+	// safeModeHeld counts the held restarts per service.
+	safeModeHeld map[string]int
+	// safeModeRelease is closed when safe mode exits, nil outside safe mode.
+	safeModeRelease chan struct{}
+	// fileWatcher watches service binaries and files for modification.
+	fileWatcher appintegrity.Watcher
+	// hookRunner runs the exec actions of file watches.
+	hookRunner apphook.Runner
+	// eventHookSlots bounds the event hooks running at once.
+	eventHookSlots chan struct{}
+	// eventHooksQueued counts the event hooks waiting for a slot.
+	eventHooksQueued atomic.Int64
+	// watchCancel stops the file watches of the current configuration.
+	watchCancel context.CancelFunc
+	// watcherCancel stops the watchers of the current configuration.
+	watcherCancel context.CancelFunc
+	// ephemerals holds the running ephemeral services by name.
+	ephemerals map[string]*ephemeral
+	// probePauses holds, per service and listener, the probe pauses; the
+	// empty listener name pauses every listener of the service.
+	probePauses map[string]map[string]*probePause
+	// certInspector retrieves the certificates of tls listeners.
+	certInspector apphealth.CertificateInspector
+	// certificates holds the certificate checks by service and listener.
+	certificates map[string]map[string]*certificateCheck
+	// certCancel stops the certificate checks of the current configuration.
+	certCancel context.CancelFunc
+	// probeInterval is the interval of probes configuring none, zero for the default.
+	probeInterval time.Duration
+	// probeTimeout is the timeout of probes configuring none, zero for the default.
+	probeTimeout time.Duration
+	// status is the copy-on-write status read by the polling methods.
+	status atomic.Pointer[statusSnapshot]
 }
 
 // NewSupervisor creates a new supervisor from configuration.
@@ -117,29 +289,92 @@ func NewSupervisor(cfg *domainconfig.Config, loader appconfig.Loader, executor d
 		// return error if configuration is invalid
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	// Refuse restart strategies nothing registered.
+	if err := checkRestartStrategies(cfg); err != nil {
+		// return error naming the service
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	// Refuse watcher events named like built-in ones.
+	if err := checkWatchers(cfg); err != nil {
+		// return error naming the watcher
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
 
 	s := &Supervisor{
-		config:         cfg,
-		loader:         loader,
-		executor:       executor,
-		managers:       make(map[string]*applifecycle.Manager, len(cfg.Services)),
-		healthMonitors: make(map[string]*apphealth.ProbeMonitor, len(cfg.Services)),
-		reaper:         reaper,
-		state:          StateStopped,
-		stats:          make(map[string]*ServiceStats, len(cfg.Services)),
+		config:             cfg,
+		loader:             loader,
+		clock:              shared.DefaultClock,
+		executor:           executor,
+		managers:           make(map[string]*applifecycle.Manager, len(cfg.Services)),
+		healthMonitors:     make(map[string]*apphealth.ProbeMonitor, len(cfg.Services)),
+		dependencyMonitors: make(map[string]*apphealth.ProbeMonitor, len(cfg.Services)),
+		dependenciesDown:   make(map[string]map[string]bool, len(cfg.Services)),
+		reaper:             reaper,
+		state:              StateStopped,
+		stats:              make(map[string]*ServiceStats, len(cfg.Services)),
+		throttled:          make(map[string]bool, len(cfg.Services)),
+		pressureAlerts:     make(map[string]map[int]bool, len(cfg.Services)),
+		conflicts:          make(map[string]map[string]bool, len(cfg.Services)),
+		healthLogs:         make(map[string]domainmetrics.HealthLog, len(cfg.Services)),
+		burnAlerts:         make(map[string]map[int]bool, len(cfg.Services)),
 	}
 
 	// create managers and stats for each service
 	for i := range cfg.Services {
 		svc := &cfg.Services[i]
-		s.managers[svc.Name] = applifecycle.NewManager(svc, executor)
+		s.managers[svc.Name] = s.newManager(svc)
 		s.stats[svc.Name] = NewServiceStats()
 	}
+	s.configureIncidents(cfg.Incidents)
+	s.configureRestartBudget(cfg.RestartBudget)
+	s.configureSafeMode(cfg.SafeMode)
+	s.configureEventHooks(&cfg.Hooks)
+	s.publishStatus()
 
 	// return initialized supervisor
 	return s, nil
 }
 
+// newManager creates the lifecycle manager of a configured service,
+// sharing the restart budget and the clock of the supervisor.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - *applifecycle.Manager: the manager, not started.
+func (s *Supervisor) newManager(svc *domainconfig.ServiceConfig) *applifecycle.Manager {
+	mgr := applifecycle.NewManager(svc, s.executor)
+	mgr.SetRestartLimiter(s)
+	mgr.SetJobGuard(s)
+	mgr.SetClock(s.clock)
+	mgr.SetEventSequence(&s.eventSeq)
+	// return configured manager
+	return mgr
+}
+
+// checkRestartStrategies verifies that every service selects a registered
+// restart strategy, which configuration validation cannot know about.
+//
+// Params:
+//   - cfg: the service configuration.
+//
+// Returns:
+//   - error: a ServiceError wrapping domain.ErrUnknownRestartStrategy.
+func checkRestartStrategies(cfg *domainconfig.Config) error {
+	// resolve the strategy of each service
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		// strategy must be registered
+		if _, err := domain.NewRestartStrategy(&svc.Restart); err != nil {
+			// return error naming the service
+			return &domainconfig.ServiceError{Index: i, Name: svc.Name, Err: err}
+		}
+	}
+	// every strategy registered
+	return nil
+}
+
 // Start starts all managed services.
 //
 // Params:
@@ -153,15 +388,31 @@ func NewSupervisor(cfg *domainconfig.Config, loader appconfig.Loader, executor d
 //   - Goroutines run until Stop is called or context is cancelled.
 //   - Use Stop() to terminate all monitoring goroutines.
 func (s *Supervisor) Start(ctx context.Context) error {
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
 	// Initialize supervisor state and context.
 	if err := s.initializeStart(ctx); err != nil {
 		// initialize supervisor state and context
 		return err
 	}
+	s.runStateHooks(StateStopped, StateStarting)
+
+	// Track readiness of the initial startup.
+	s.beginBoot()
 
 	// Start zombie reaper if configured.
 	s.startReaper()
 
+	// Measure the host capacity admitting service reservations.
+	s.refreshCapacity(s.ctx)
+
+	// Read the host facts checked by service start conditions.
+	s.refreshHostFacts(s.ctx, s.config)
+
+	// Detect the features the host can run.
+	s.refreshHostInventory(s.ctx)
+
 	// Start all managed services.
 	if err := s.startAllServices(); err != nil {
 		// start all managed services
@@ -173,10 +424,47 @@ func (s *Supervisor) Start(ctx context.Context) error {
 
 	s.startHealthMonitors()
 
+	// Probe the external dependencies declared by services.
+	s.startDependencyMonitors()
+
+	// Bind public endpoints of proxied listeners.
+	s.startProxies()
+
+	// Watch cgroup throttling and pressure reported by the metrics tracker.
+	s.startResourceWatcher()
+
+	// Watch for listener ports held by processes outside their service.
+	s.startConflictWatcher()
+
+	// Watch service binaries and files for modification.
+	s.startFileWatches()
+
+	// Save service statistics periodically.
+	s.startStatsPersister()
+
+	// Evaluate the error budget burn of services with an SLO.
+	s.startSLOWatcher()
+
+	// Shed low-priority services under host memory pressure.
+	s.startShedWatcher()
+
+	// Reconcile executor resources with the services.
+	s.startLeakWatcher()
+
+	// Sample the connections of the services into a dependency map.
+	s.startTrafficSampler()
+
+	// Run the watchers emitting custom events.
+	s.startWatchers()
+
+	// Check the certificates of tls listeners.
+	s.startCertificateChecks()
+
+	// Report jumps of the wall clock.
+	s.startClockWatcher()
+
 	// Mark supervisor as running.
-	s.mu.Lock()
-	s.state = StateRunning
-	s.mu.Unlock()
+	s.changeState(StateRunning)
 
 	// mark supervisor as running
 	return nil
@@ -194,12 +482,11 @@ func (s *Supervisor) initializeStart(ctx context.Context) error {
 	defer s.mu.Unlock()
 
 	// check if already running
-	if s.state != StateStopped {
+	if _, ok := s.transition(StateStarting); !ok {
 		// Return error when supervisor is already running.
 		return ErrAlreadyRunning
 	}
 
-	s.state = StateStarting
 	s.ctx, s.cancel = context.WithCancel(ctx)
 	// return success after initialization
 	return nil
@@ -215,13 +502,29 @@ func (s *Supervisor) startReaper() {
 	s.reaper.Start()
 }
 
-// startAllServices starts all managed services.
+// startAllServices starts the services of the first start phase; the
+// others follow as the phases before them become ready.
 //
 // Returns:
 //   - error: first error encountered, or nil on success.
 func (s *Supervisor) startAllServices() error {
-	// Iterate through all managed services.
-	for name, mgr := range s.managers {
+	var gated []*domain.Event
+	// Report skipped and over-committing starts once the phase is started.
+	defer func() { s.emitGated(gated) }()
+	// Iterate through the services of the first start phase.
+	for _, name := range s.planStartPhases() {
+		s.mu.RLock()
+		mgr := s.managers[name]
+		event, refused := s.gateStart(s.config, name)
+		s.mu.RUnlock()
+		// Record skipped and over-committing starts.
+		if event != nil {
+			gated = append(gated, event)
+		}
+		// Skip services without manager, skipped or refused.
+		if mgr == nil || refused {
+			continue
+		}
 		err := mgr.Start(s.ctx)
 		// Skip successfully started services.
 		if err == nil {
@@ -229,9 +532,7 @@ func (s *Supervisor) startAllServices() error {
 		}
 		// Handle startup failure by stopping all services.
 		s.stopAll()
-		s.mu.Lock()
-		s.state = StateStopped
-		s.mu.Unlock()
+		s.changeState(StateStopped)
 		// Return wrapped start error.
 		return fmt.Errorf("failed to start service %s: %w", name, err)
 	}
@@ -260,7 +561,9 @@ func (s *Supervisor) startHealthMonitors() {
 		}
 		// Store and start the monitor.
 		s.mu.Lock()
+		monitor.SetDefaults(s.probeInterval, s.probeTimeout)
 		s.healthMonitors[svc.Name] = monitor
+		s.publishStatus()
 		s.mu.Unlock()
 		monitor.Start(s.ctx)
 	}
@@ -271,17 +574,32 @@ func (s *Supervisor) startHealthMonitors() {
 // Returns:
 //   - error: always nil, provided for interface compatibility.
 func (s *Supervisor) Stop() error {
-	s.mu.Lock()
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
 	// return nil when not running
-	if s.state != StateRunning {
-		s.mu.Unlock()
+	if !s.changeState(StateStopping) {
 		// Return nil when not running.
 		return nil
 	}
-	s.state = StateStopping
+
+	// Record the health of the services while their probes still run.
+	s.beginShutdownReport()
+
+	// Let in-flight proxied connections finish before the services go away.
+	s.drainAllProxies()
+
+	s.mu.Lock()
 	s.cancel()
+	s.clearStartDeadlines()
+	s.clearSocketWaits()
+	s.clearRuntimeLimits()
+	s.clearProbePauses()
 	s.mu.Unlock()
 
+	// Stop public traffic before the services go away.
+	s.closeProxies()
+
 	// Stop all health monitors first.
 	s.mu.RLock()
 	// Iterate through health monitors and stop each one.
@@ -289,18 +607,28 @@ func (s *Supervisor) Stop() error {
 		monitor.Stop()
 	}
 	s.mu.RUnlock()
+	s.stopDependencyMonitors()
 
 	s.stopAll()
 	s.wg.Wait()
 
+	// Forget the ephemeral services, stopped with the others, and publish
+	// the stops the monitoring goroutines no longer report.
+	s.mu.Lock()
+	s.dropEphemerals()
+	s.publishStatus()
+	s.mu.Unlock()
+
+	// Save the statistics, stop events included.
+	s.persistStats()
+	s.completeShutdownReport()
+
 	// Stop the zombie reaper if available.
 	if s.reaper != nil {
 		s.reaper.Stop()
 	}
 
-	s.mu.Lock()
-	s.state = StateStopped
-	s.mu.Unlock()
+	s.changeState(StateStopped)
 
 	// return success after graceful stop
 	return nil
@@ -331,20 +659,39 @@ func (s *Supervisor) stopAll() {
 }
 
 // Reload reloads the configuration and restarts changed services.
+// The request goes through the reload queue: it waits for the reload in
+// progress, if any, and shares the next run with concurrent requests.
 //
 // Returns:
 //   - error: an error if the reload fails.
 func (s *Supervisor) Reload() error {
+	_, result := s.RequestReload()
+	// wait for the run serving this request
+	return <-result
+}
+
+// reload applies the configuration file to running services.
+// The supervisor is StateReloading meanwhile and returns to StateRunning
+// whatever the outcome; a concurrent Stop waits for the reload to finish.
+// Only the reload queue calls it, so reloads never overlap.
+//
+// Returns:
+//   - error: an error if the reload fails.
+func (s *Supervisor) reload() error {
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
 	s.mu.RLock()
-	state := s.state
 	configPath := s.config.ConfigPath
 	s.mu.RUnlock()
 
 	// return error when not running
-	if state != StateRunning {
+	if !s.changeState(StateReloading) {
 		// Return error when not running.
 		return ErrNotRunning
 	}
+	// Resume running once the reload is over, applied or not.
+	defer s.changeState(StateRunning)
 
 	// Load configuration without holding lock (I/O operation).
 	newCfg, err := s.loader.Load(configPath)
@@ -354,60 +701,118 @@ func (s *Supervisor) Reload() error {
 		return fmt.Errorf("failed to reload config: %w", err)
 	}
 
-	// Acquire write lock for state updates.
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Re-check state after acquiring lock (may have changed).
-	if s.state != StateRunning {
-		// Return error when no longer running.
-		return ErrNotRunning
+	// Refuse the whole reload if the new configuration cannot be applied.
+	if err := s.checkReload(newCfg); err != nil {
+		// Return the refusal without touching running services.
+		return err
 	}
 
-	s.updateServices(newCfg)
-	s.removeDeletedServices(newCfg)
+	// Let in-flight proxied connections finish before services restart.
+	s.drainAllProxies()
+
+	// Measure the host capacity admitting service reservations.
+	s.refreshCapacity(s.ctx)
+
+	// Read the host facts checked by the new start conditions.
+	s.refreshHostFacts(s.ctx, newCfg)
+
+	// Apply the new configuration to running services.
+	s.applyConfig(newCfg)
+
+	// Rebind public endpoints for the new configuration.
+	s.restartProxies()
+
+	// Report the applied configuration daemon-wide.
+	event := domain.NewEvent(domain.EventConfigReloaded, "", 0, 0, nil)
+	s.callEventHandler("", &event, nil)
 
-	s.config = newCfg
 	// return success after reload
 	return nil
 }
 
+// applyConfig restarts changed services and stores the new configuration.
+// It runs while the supervisor is StateReloading, so Stop cannot interleave.
+//
+// Params:
+//   - newCfg: the new configuration.
+func (s *Supervisor) applyConfig(newCfg *domainconfig.Config) {
+	// Acquire write lock for state updates.
+	s.mu.Lock()
+	gated := s.updateServices(newCfg)
+	s.removeDeletedServices(newCfg)
+	s.configureIncidents(newCfg.Incidents)
+	s.configureRestartBudget(newCfg.RestartBudget)
+	s.configureSafeMode(newCfg.SafeMode)
+	s.configureEventHooks(&newCfg.Hooks)
+	s.watchFiles(newCfg)
+	watcherErr := s.runWatchers(newCfg)
+	s.checkCertificates(newCfg)
+
+	s.config = newCfg
+	// Every service was restarted, shed ones included.
+	s.shed = nil
+	s.publishStatus()
+	s.mu.Unlock()
+
+	// Report gated starts and missing runner outside the lock.
+	s.emitGated(gated)
+	s.handleRecoveryError("watcher", "", watcherErr)
+}
+
 // updateServices updates or adds managers for services in the new configuration.
+// Starts are gated by the conditions and reservations of newCfg.
 // Errors during stop/start are reported via handleRecoveryError (best-effort reload).
 //
 // Params:
 //   - newCfg: the new service configuration.
 //
+// Returns:
+//   - []*domain.Event: the skipped and overcommitted events to emit once s.mu is released.
+//
 // Goroutine lifecycle:
 //   - May spawn new goroutines for monitoring newly added services.
 //   - Goroutines run until Stop is called or context is cancelled.
 //   - Use Stop() to terminate all monitoring goroutines.
-func (s *Supervisor) updateServices(newCfg *domainconfig.Config) {
+func (s *Supervisor) updateServices(newCfg *domainconfig.Config) []*domain.Event {
+	var gated []*domain.Event
 	// Iterate through all services in the new configuration.
 	for i := range newCfg.Services {
 		svc := &newCfg.Services[i]
-		// Check if the service already exists.
-		if mgr, exists := s.managers[svc.Name]; exists {
-			// Stop existing manager (best-effort).
+		mgr, exists := s.managers[svc.Name]
+		// Stop existing manager (best-effort).
+		if exists {
 			if err := mgr.Stop(); err != nil {
 				s.handleRecoveryError("stop-for-reload", svc.Name, err)
 			}
-			s.managers[svc.Name] = applifecycle.NewManager(svc, s.executor)
-			// Start new manager (best-effort).
-			if err := s.managers[svc.Name].Start(s.ctx); err != nil {
-				s.handleRecoveryError("start-for-reload", svc.Name, err)
-			}
-		} else {
-			// Create and start a new manager for the new service.
-			s.managers[svc.Name] = applifecycle.NewManager(svc, s.executor)
-			// Start new manager (best-effort).
-			if err := s.managers[svc.Name].Start(s.ctx); err != nil {
-				s.handleRecoveryError("start-new-service", svc.Name, err)
-			}
+		}
+		// Create a new manager for the new or changed service.
+		s.managers[svc.Name] = s.newManager(svc)
+		// Monitor the events of a new service.
+		if !exists {
 			s.wg.Add(1)
 			go s.monitorService(svc.Name, s.managers[svc.Name])
 		}
+		event, refused := s.gateStart(newCfg, svc.Name)
+		// Record skipped and over-committing starts.
+		if event != nil {
+			gated = append(gated, event)
+		}
+		// Leave skipped and refused services stopped.
+		if refused {
+			continue
+		}
+		// Start new manager (best-effort).
+		if err := s.managers[svc.Name].Start(s.ctx); err != nil {
+			operation := "start-for-reload"
+			// Distinguish services added by the reload.
+			if !exists {
+				operation = "start-new-service"
+			}
+			s.handleRecoveryError(operation, svc.Name, err)
+		}
 	}
+	// return the events to emit once the lock is released
+	return gated
 }
 
 // removeDeletedServices removes managers for services no longer in configuration.
@@ -416,21 +821,21 @@ func (s *Supervisor) updateServices(newCfg *domainconfig.Config) {
 // Params:
 //   - newCfg: the new service configuration.
 func (s *Supervisor) removeDeletedServices(newCfg *domainconfig.Config) {
-	newServices := make(map[string]bool, len(newCfg.Services))
-	// iterate to find removed services
-	for i := range newCfg.Services {
-		newServices[newCfg.Services[i].Name] = true
-	}
+	plan := s.planServices(newCfg)
 	// Remove services that are no longer in the configuration.
-	for name, mgr := range s.managers {
-		// Check if the service should be removed.
-		if !newServices[name] {
-			// Stop removed service (best-effort).
-			if err := mgr.Stop(); err != nil {
-				s.handleRecoveryError("stop-removed-service", name, err)
-			}
-			delete(s.managers, name)
+	for i := range plan.Services {
+		name := plan.Services[i].Name
+		// Skip kept and added services.
+		if plan.Services[i].Action != domainlifecycle.ReloadRemove {
+			continue
 		}
+		// Stop removed service (best-effort).
+		if err := s.managers[name].Stop(); err != nil {
+			s.handleRecoveryError("stop-removed-service", name, err)
+		}
+		delete(s.managers, name)
+		delete(s.dependencyFailures, name)
+		delete(s.unhealthyReports, name)
 	}
 }
 
@@ -473,13 +878,37 @@ func (s *Supervisor) handleEvent(name string, event *domain.Event) {
 	stats := s.getOrCreateStats(name)
 
 	s.updateStatsForEvent(stats, event)
+	s.updateAvailability(name, event)
+	s.updateJobRuns(name, event)
 	s.updateHealthMonitor(name, event)
 	s.updateMetricsTracker(name, event)
+	s.updateStartDeadline(name, event)
+	s.updateSocketWait(name, event)
+	s.updateRuntimeLimit(name, event)
+	s.updateDependencyFailure(name, event)
+	boot, booted := s.updateBoot(name, event)
+	s.publishServiceStatus(name)
 
 	statsSnap := s.getStatsSnapshot(stats)
 	s.mu.Unlock()
 
+	// Report the boot outcome once every service is resolved.
+	if booted {
+		s.completeBoot(boot)
+	}
+
+	// Start the next phases once the started services are resolved.
+	s.advanceStartPhases()
+
+	// Admit proxied traffic again once the service is back.
+	if event.Type == domain.EventStarted {
+		s.resumeProxies(name)
+	}
+
 	s.callEventHandler(name, event, statsSnap)
+
+	// Make the event visible to operations waiting for it.
+	s.fence.apply(name, event)
 }
 
 // getOrCreateStats gets or creates stats for a service.
@@ -511,20 +940,38 @@ func (s *Supervisor) updateStatsForEvent(stats *ServiceStats, event *domain.Even
 	// Process started.
 	case domain.EventStarted:
 		stats.IncrementStart()
+		stats.MarkUp(eventTime(event))
 	// Process stopped cleanly.
 	case domain.EventStopped:
 		stats.IncrementStop()
+		stats.MarkDown(eventTime(event))
 	// Process failed.
 	case domain.EventFailed:
 		stats.IncrementFail()
+		stats.MarkDown(eventTime(event))
+		// count how the process ended
+		if cause, ok := exitCause(event); ok {
+			stats.RecordExit(cause, eventTime(event))
+		}
 	// Process restarting.
 	case domain.EventRestarting:
 		stats.IncrementRestart()
 	// Restart attempts exhausted.
 	case domain.EventExhausted:
 		stats.IncrementFail()
-	// Health events are tracked separately.
-	case domain.EventHealthy, domain.EventUnhealthy:
+		stats.MarkDown(eventTime(event))
+	// Health and resource events are tracked separately.
+	case domain.EventHealthy, domain.EventUnhealthy, domain.EventThrottled, domain.EventUnthrottled,
+		domain.EventPressureAlert, domain.EventPressureCleared,
+		domain.EventListenerConflict, domain.EventListenerConflictCleared, domain.EventStartTimeout,
+		domain.EventDependencyDown, domain.EventDependencyUp, domain.EventIncident, domain.EventFileChanged, domain.EventSecretChanged,
+		domain.EventProbesPaused, domain.EventProbesResumed,
+		domain.EventQuiesced, domain.EventUnquiesced, domain.EventQuiesceFailed, domain.EventSafeModeEntered, domain.EventSafeModeExited, domain.EventConfigReloaded,
+		domain.EventReloaded, domain.EventReloadFailed,
+		domain.EventSLOBurn, domain.EventSLOBurnCleared, domain.EventOvercommitted, domain.EventShed, domain.EventShedResumed,
+		domain.EventResourceLeaked, domain.EventRuntimeWarning, domain.EventRuntimeExceeded, domain.EventRestartDeferred,
+		domain.EventCertificateExpiring, domain.EventCertificateChanged,
+		domain.EventClockJump, domain.EventCustom, domain.EventSkipped:
 		// Health events are tracked by the health monitor, not stats.
 	default:
 		// Unknown event type, ignore.
@@ -552,8 +999,21 @@ func (s *Supervisor) updateHealthMonitor(name string, event *domain.Event) {
 	// process stopped or failed
 	case domain.EventStopped, domain.EventFailed, domain.EventExhausted:
 		monitor.SetProcessState(domain.StateStopped)
+	// start skipped for unmet conditions
+	case domain.EventSkipped:
+		monitor.SetProcessState(domain.StateSkipped)
 	// No state change for these events.
-	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy:
+	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy,
+		domain.EventThrottled, domain.EventUnthrottled, domain.EventPressureAlert, domain.EventPressureCleared,
+		domain.EventListenerConflict, domain.EventListenerConflictCleared, domain.EventStartTimeout,
+		domain.EventDependencyDown, domain.EventDependencyUp, domain.EventIncident, domain.EventFileChanged, domain.EventSecretChanged,
+		domain.EventProbesPaused, domain.EventProbesResumed,
+		domain.EventQuiesced, domain.EventUnquiesced, domain.EventQuiesceFailed, domain.EventSafeModeEntered, domain.EventSafeModeExited, domain.EventConfigReloaded,
+		domain.EventReloaded, domain.EventReloadFailed,
+		domain.EventSLOBurn, domain.EventSLOBurnCleared, domain.EventOvercommitted, domain.EventShed, domain.EventShedResumed,
+		domain.EventResourceLeaked, domain.EventRuntimeWarning, domain.EventRuntimeExceeded, domain.EventRestartDeferred,
+		domain.EventCertificateExpiring, domain.EventCertificateChanged,
+		domain.EventClockJump, domain.EventCustom:
 		// No state change needed.
 	default:
 		// Unknown event type, ignore.
@@ -583,8 +1043,20 @@ func (s *Supervisor) updateMetricsTracker(name string, event *domain.Event) {
 	// Stop tracking metrics.
 	case domain.EventStopped, domain.EventFailed, domain.EventExhausted:
 		s.metricsTracker.Untrack(name)
+		s.resetThrottling(name)
+		s.resetPressureAlerts(name)
 	// No metrics action needed.
-	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy:
+	case domain.EventRestarting, domain.EventHealthy, domain.EventUnhealthy,
+		domain.EventThrottled, domain.EventUnthrottled, domain.EventPressureAlert, domain.EventPressureCleared,
+		domain.EventListenerConflict, domain.EventListenerConflictCleared, domain.EventStartTimeout,
+		domain.EventDependencyDown, domain.EventDependencyUp, domain.EventIncident, domain.EventFileChanged, domain.EventSecretChanged,
+		domain.EventProbesPaused, domain.EventProbesResumed,
+		domain.EventQuiesced, domain.EventUnquiesced, domain.EventQuiesceFailed, domain.EventSafeModeEntered, domain.EventSafeModeExited, domain.EventConfigReloaded,
+		domain.EventReloaded, domain.EventReloadFailed,
+		domain.EventSLOBurn, domain.EventSLOBurnCleared, domain.EventOvercommitted, domain.EventShed, domain.EventShedResumed,
+		domain.EventResourceLeaked, domain.EventRuntimeWarning, domain.EventRuntimeExceeded, domain.EventRestartDeferred,
+		domain.EventCertificateExpiring, domain.EventCertificateChanged,
+		domain.EventClockJump, domain.EventCustom, domain.EventSkipped:
 		// No action needed.
 	default:
 		// Unknown event type, ignore.
@@ -608,17 +1080,26 @@ func (s *Supervisor) getStatsSnapshot(stats *ServiceStats) *ServiceStatsSnapshot
 	return stats.SnapshotPtr()
 }
 
-// callEventHandler calls user event handler if registered.
+// callEventHandler labels, correlates and persists the event, calls user event
+// handler if registered, then delivers the incident the event opened, if any.
 //
 // Params:
 //   - name: the service name.
 //   - event: the process event.
 //   - statsSnap: the statistics snapshot.
 func (s *Supervisor) callEventHandler(name string, event *domain.Event, statsSnap *ServiceStatsSnapshot) {
+	s.labelEvent(name, event)
+	incident := s.correlateIncident(name, event)
+	s.recordEvent(name, event)
 	// call handler if registered
 	if s.eventHandler != nil {
 		s.eventHandler(name, event, statsSnap)
 	}
+	s.runEventHooks(name, event)
+	// report the incident after the failure opening it
+	if incident != nil {
+		s.callEventHandler("", incident, nil)
+	}
 }
 
 // SetEventHandler sets the callback for process events.
@@ -632,6 +1113,22 @@ func (s *Supervisor) SetEventHandler(handler EventHandler) {
 	s.eventHandler = handler
 }
 
+// SetClock replaces the clock timing the restart backoff, the health probes
+// and the service deadlines, by default shared.DefaultClock. It must be
+// called before Start.
+//
+// Params:
+//   - clock: the clock.
+func (s *Supervisor) SetClock(clock shared.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = clock
+	// apply to the managers already created
+	for _, mgr := range s.managers {
+		mgr.SetClock(clock)
+	}
+}
+
 // SetErrorHandler sets the callback for non-fatal errors in recovery paths.
 // These errors occur during best-effort operations like shutdown cleanup
 // or configuration reload where the supervisor continues despite errors.
@@ -659,6 +1156,40 @@ func (s *Supervisor) SetProberFactory(factory apphealth.Creator) {
 	s.proberFactory = factory
 }
 
+// SetPreflighter sets the pre-flight checker run before applying a reload.
+// When set, a reload whose configuration fails any check is refused as a
+// whole and the running configuration is left untouched.
+//
+// Params:
+//   - preflighter: the pre-flight checker to use.
+func (s *Supervisor) SetPreflighter(preflighter appconfig.Preflighter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store pre-flight checker
+	s.preflighter = preflighter
+}
+
+// preflight runs the configured pre-flight checks on a configuration.
+//
+// Params:
+//   - cfg: the configuration to check.
+//
+// Returns:
+//   - error: the pre-flight report, nil if checks pass or none are configured.
+func (s *Supervisor) preflight(cfg *domainconfig.Config) error {
+	s.mu.RLock()
+	preflighter := s.preflighter
+	s.mu.RUnlock()
+
+	// Skip when no pre-flight checker is configured.
+	if preflighter == nil {
+		// Nothing to check.
+		return nil
+	}
+	// Run checks without holding the lock (filesystem and user lookups).
+	return preflighter.Preflight(cfg)
+}
+
 // SetMetricsTracker sets the process metrics tracker.
 // When set, the supervisor will track CPU and memory usage per service.
 //
@@ -705,7 +1236,7 @@ func (s *Supervisor) hasConfiguredProbes(svc *domainconfig.ServiceConfig) bool {
 	// Iterate through listeners looking for configured probes.
 	for i := range svc.Listeners {
 		// Check if listener has a valid probe configuration.
-		if svc.Listeners[i].Probe != nil && svc.Listeners[i].Probe.Type != "" {
+		if svc.Listeners[i].HasProbe() {
 			// Return true when probe found.
 			return true
 		}
@@ -725,18 +1256,35 @@ func (s *Supervisor) createProbeMonitorConfig(serviceName string) apphealth.Prob
 	// return monitor configuration
 	return apphealth.ProbeMonitorConfig{
 		Factory: s.proberFactory,
+		Clock:   s.clock,
 		OnStateChange: func(_ string, _, _ domainhealth.SubjectState, _ domainhealth.CheckResult) {
 			// Health state transitions are tracked internally.
 			// Events are emitted via OnHealthy/OnUnhealthy callbacks.
 		},
-		OnUnhealthy: func(_, reason string) {
-			// Trigger restart on health failure (event emitted by restart logic).
-			// Attempt to restart the service on health failure.
-			if err := s.RestartOnHealthFailure(serviceName, reason); err != nil {
-				s.handleRecoveryError("health-restart", serviceName, err)
-			}
+		OnUnhealthy: func(listener, reason string) {
+			// Failing probes make the service unavailable.
+			s.mu.Lock()
+			s.recordAvailability(serviceName, false, time.Now())
+			s.mu.Unlock()
+			// Refuse new proxied connections and let in-flight ones finish.
+			s.drainProxies(serviceName)
+			// Run the unhealthy action of the probe, restart by default.
+			s.unhealthyReported(serviceName, listener, reason)
 		},
-		OnHealthy: func(_ string) {
+		OnHealthy: func(listener string) {
+			// The service is ready: its start deadline no longer applies.
+			s.mu.Lock()
+			s.recordAvailability(serviceName, true, time.Now())
+			s.clearStartDeadline(serviceName)
+			s.unhealthyRecovered(serviceName, listener)
+			boot, booted := s.markBootReady(serviceName)
+			s.mu.Unlock()
+			// Report the boot outcome once every service is resolved.
+			if booted {
+				s.completeBoot(boot)
+			}
+			// Start the next phases once the started services are resolved.
+			s.advanceStartPhases()
 			// Emit healthy event when service becomes healthy.
 			// Call event handler if registered.
 			if s.eventHandler != nil {
@@ -755,6 +1303,10 @@ func (s *Supervisor) createProbeMonitorConfig(serviceName string) apphealth.Prob
 				s.eventHandler(serviceName, event, statsSnap)
 			}
 		},
+		OnProbeAttempt: func(attempt domainhealth.ProbeAttempt) {
+			// Forward attempts of debug probes.
+			s.notifyProbeAttempt(serviceName, attempt)
+		},
 	}
 	// restart service on consecutive failures
 }
@@ -800,19 +1352,13 @@ func (s *Supervisor) addSingleListenerWithProbe(monitor AddListenerWithBindinger
 // Returns:
 //   - *listener.Listener: the domain listener ready for health monitoring.
 func (s *Supervisor) createDomainListener(lc *domainconfig.ListenerConfig) *listener.Listener {
-	// Resolve protocol with default.
-	protocol := lc.Protocol
-	// use default protocol if not specified
-	if protocol == "" {
-		protocol = "tcp"
-	}
-	// Resolve address with default.
-	address := lc.Address
+	// Resolve address with the loopback of the listener family.
+	address := lc.Host()
 	// use default address if not specified
 	if address == "" {
-		address = "localhost"
+		address = lc.Family().Loopback()
 	}
-	domainListener := listener.NewListener(lc.Name, protocol, address, lc.Port)
+	domainListener := listener.NewListener(lc.Name, lc.EffectiveProtocol(), address, lc.Port)
 	domainListener.MarkListening()
 	// return configured domain listener
 	return domainListener
@@ -826,30 +1372,85 @@ func (s *Supervisor) createDomainListener(lc *domainconfig.ListenerConfig) *list
 // Returns:
 //   - *apphealth.ProbeBinding: the probe binding for health monitoring.
 func (s *Supervisor) createProbeBinding(lc *domainconfig.ListenerConfig) *apphealth.ProbeBinding {
-	// Resolve address with default for target.
-	address := lc.Address
-	// use default address if not specified
-	if address == "" {
-		address = "localhost"
-	}
 	// return probe binding configuration
 	return &apphealth.ProbeBinding{
 		ListenerName: lc.Name,
 		Type:         apphealth.ProbeType(lc.Probe.Type),
 		Target: apphealth.ProbeTarget{
-			Address: fmt.Sprintf("%s:%d", address, lc.Port),
-			Path:    lc.Probe.Path,
-			Service: lc.Probe.Service,
+			Network:    lc.ProbeNetwork(lc.Probe.Type),
+			Address:    lc.DialAddress(),
+			Path:       lc.Probe.Path,
+			Service:    lc.Probe.Service,
+			Method:     lc.Probe.Method,
+			StatusCode: lc.Probe.StatusCode,
+			Command:    lc.Probe.Command,
+			Args:       lc.Probe.Args,
+			Steps:      scenarioSteps(lc.Probe.Steps),
+			Threshold:  probeThreshold(lc.Probe.Threshold),
 		},
 		Config: apphealth.ProbeConfig{
-			Timeout:          lc.Probe.Timeout.Duration(),
-			Interval:         lc.Probe.Interval.Duration(),
+			Timeout:          probeTimeout(lc.Probe),
+			Interval:         probeInterval(lc.Probe),
 			SuccessThreshold: lc.Probe.SuccessThreshold,
 			FailureThreshold: lc.Probe.FailureThreshold,
+			Debug:            lc.Probe.Debug,
 		},
 	}
 }
 
+// scenarioSteps converts configured scenario steps to health check steps.
+//
+// Params:
+//   - steps: the configured scenario steps.
+//
+// Returns:
+//   - []domainhealth.ScenarioStep: the steps, nil when none are configured.
+func scenarioSteps(steps []domainconfig.ScenarioStep) []domainhealth.ScenarioStep {
+	// skip probes without scenario
+	if len(steps) == 0 {
+		// no steps
+		return nil
+	}
+	out := make([]domainhealth.ScenarioStep, 0, len(steps))
+	// copy each step in order
+	for i := range steps {
+		step := &steps[i]
+		out = append(out, domainhealth.ScenarioStep{
+			Name:       step.Name,
+			Method:     step.Method,
+			Path:       step.Path,
+			Headers:    step.Headers,
+			Body:       step.Body,
+			StatusCode: step.StatusCode,
+			Extract:    step.Extract,
+		})
+	}
+	// return converted steps
+	return out
+}
+
+// probeThreshold converts a configured value check to a health check threshold.
+//
+// Params:
+//   - threshold: the configured value check, nil outside threshold probes.
+//
+// Returns:
+//   - *domainhealth.Threshold: the converted value check, nil when none.
+func probeThreshold(threshold *domainconfig.ThresholdConfig) *domainhealth.Threshold {
+	// skip probes without value check; validation requires a fail threshold
+	if threshold == nil || threshold.Fail == nil {
+		// no value check
+		return nil
+	}
+	// return converted value check
+	return &domainhealth.Threshold{
+		Pattern: threshold.Pattern,
+		Warn:    threshold.Warn,
+		Fail:    *threshold.Fail,
+		Below:   threshold.Below,
+	}
+}
+
 // handleRecoveryError reports a non-fatal error to the error handler if set.
 // This method is called from recovery/cleanup paths where errors don't stop
 // the overall operation.
@@ -884,10 +1485,8 @@ func (s *Supervisor) handleRecoveryError(operation, serviceName string, err erro
 // Returns:
 //   - *ServiceStatsSnapshot: the service statistics snapshot, or nil if not found.
 func (s *Supervisor) Stats(name string) *ServiceStatsSnapshot {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
 	// return snapshot if stats exist
-	if stats, ok := s.stats[name]; ok {
+	if stats, ok := s.loadStatus().stats[name]; ok {
 		snap := stats.Snapshot()
 		// Return pointer to snapshot.
 		return &snap
@@ -901,12 +1500,11 @@ func (s *Supervisor) Stats(name string) *ServiceStatsSnapshot {
 // Returns:
 //   - map[string]*ServiceStatsSnapshot: atomic snapshots of all service statistics.
 func (s *Supervisor) AllStats() map[string]*ServiceStatsSnapshot {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	status := s.loadStatus()
 	// Use SnapshotPtr to avoid escape analysis issue from &Snapshot().
-	result := make(map[string]*ServiceStatsSnapshot, len(s.stats))
+	result := make(map[string]*ServiceStatsSnapshot, len(status.stats))
 	// Iterate through stats and collect snapshots.
-	for name, stats := range s.stats {
+	for name, stats := range status.stats {
 		result[name] = stats.SnapshotPtr()
 	}
 	// return all stats snapshots
@@ -941,6 +1539,12 @@ func (s *Supervisor) RestartOnHealthFailure(serviceName, reason string) error {
 		return fmt.Errorf("%w: %s", ErrServiceNotFound, serviceName)
 	}
 
+	// Restarting does not help while a gating dependency is down.
+	if dep, gated := s.restartGate(serviceName); gated {
+		// Return gated error with the dependency.
+		return fmt.Errorf("%w: %q", ErrRestartGated, dep)
+	}
+
 	// delegate to manager
 	return mgr.RestartOnHealthFailure(reason)
 }
@@ -956,23 +1560,26 @@ func (s *Supervisor) State() State {
 	return s.state
 }
 
-// Services returns information about all managed services.
+// Services returns information about all managed services, as of the last
+// service event.
 //
 // Returns:
 //   - map[string]ServiceInfo: a map of service names to their information.
 func (s *Supervisor) Services() map[string]ServiceInfo {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	// collect information from each manager
-	info := make(map[string]ServiceInfo, len(s.managers))
-	// Collect information from each manager.
-	for name, mgr := range s.managers {
+	status := s.loadStatus()
+	now := time.Now()
+
+	// collect information from each service status
+	info := make(map[string]ServiceInfo, len(status.services))
+	// Collect information from each service status.
+	for name, entry := range status.services {
+		st := entry.status.Load()
 		info[name] = ServiceInfo{
 			Name:   name,
-			State:  mgr.State(),
-			PID:    mgr.PID(),
-			Uptime: mgr.Uptime(),
+			State:  st.state,
+			PID:    st.pid,
+			Uptime: st.uptime(now),
+			Reason: st.reason,
 		}
 	}
 	// return collected service information
@@ -1074,7 +1681,7 @@ func (s *Supervisor) enrichSnapshotWithMetrics(snap *ServiceSnapshotForTUI, name
 // Status logic:
 //   - 0 (OK/Green): port listening and state matches config
 //   - 1 (Warning/Yellow): mismatch (exposed but not reachable, or vice versa)
-//   - 2 (Error/Red): expected port but nothing listening
+//   - 2 (Error/Red): expected port but nothing listening, or port held by another process
 //
 // Params:
 //   - svc: the service configuration with listener definitions.
@@ -1093,16 +1700,20 @@ func (s *Supervisor) buildListenerSnapshots(svc *domainconfig.ServiceConfig, lis
 	// create listener snapshot for each configured listener
 	for _, lc := range svc.Listeners {
 		ls := ListenerSnapshotForTUI{
-			Name:      lc.Name,
-			Port:      lc.Port,
-			Protocol:  lc.Protocol,
-			Exposed:   lc.Exposed,
-			Listening: listening[lc.Port],
+			Name:       lc.Name,
+			Port:       lc.Port,
+			Protocol:   lc.Protocol,
+			Exposed:    lc.Exposed,
+			Listening:  listening[lc.Port],
+			Conflicted: s.listenerConflicted(svc.Name, lc.Name),
 		}
 
 		// Determine status based on listening state.
 		// StatusInt: 0=OK, 2=Error
-		if ls.Listening {
+		if ls.Conflicted {
+			// Port held by another process → Error (red).
+			ls.StatusInt = ListenerStatusError
+		} else if ls.Listening {
 			// Listening → OK (green), whether exposed or internal.
 			ls.StatusInt = ListenerStatusOK
 		} else {
@@ -1141,8 +1752,25 @@ func (s *Supervisor) Service(name string) (*applifecycle.Manager, bool) {
 // Returns:
 //   - error: an error if the service is not found or fails to start.
 func (s *Supervisor) StartService(name string) error {
+	s.mu.RLock()
+	// get context for manager start (fallback to Background if supervisor not started)
+	ctx, cfg := s.ctx, s.config
+	s.mu.RUnlock()
+	// Use context from supervisor or fallback to Background
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// Read the host facts the start conditions are checked against.
+	s.refreshHostFacts(ctx, cfg)
+
 	s.mu.RLock()
 	mgr, ok := s.managers[name]
+	var event *domain.Event
+	var refused bool
+	// Gate the start of a stopped service.
+	if ok && !mgr.Supervised() {
+		event, refused = s.gateStart(s.config, name)
+	}
 	s.mu.RUnlock()
 
 	// validate service exists
@@ -1150,11 +1778,16 @@ func (s *Supervisor) StartService(name string) error {
 		// Return error for missing service.
 		return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
 	}
-	// get context for manager start (fallback to Background if supervisor not started)
-	ctx := s.ctx
-	// Use context from supervisor or fallback to Background
-	if ctx == nil {
-		ctx = context.Background()
+	// A manual start overrides shedding.
+	s.forgetShed(name)
+	// Report a skipped or over-committing start.
+	if event != nil {
+		s.handleEvent(name, event)
+	}
+	// Leave the service stopped when skipped or refused.
+	if refused {
+		// Return the conditions or admission error.
+		return event.Error
 	}
 	// start the service
 	return mgr.Start(ctx)
@@ -1177,6 +1810,14 @@ func (s *Supervisor) StopService(name string) error {
 		// Return error for missing service.
 		return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
 	}
+	// A manual stop overrides shedding.
+	s.forgetShed(name)
+	// A manual stop cancels the retry on dependency recovery.
+	s.forgetDependencyFailure(name)
+	// A manual stop restarts the unhealthy escalation.
+	s.forgetUnhealthy(name)
+	// Let in-flight proxied connections finish first.
+	s.drainProxies(name)
 	// stop the service
 	return mgr.Stop()
 }
@@ -1199,6 +1840,12 @@ func (s *Supervisor) RestartService(name string) error {
 		return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
 	}
 
+	// A manual restart overrides shedding.
+	s.forgetShed(name)
+
+	// Let in-flight proxied connections finish first.
+	s.drainProxies(name)
+
 	// Stop the service first.
 	// stop the service first
 	if err := mgr.Stop(); err != nil {
@@ -1215,3 +1862,39 @@ func (s *Supervisor) RestartService(name string) error {
 	// start the service after stop
 	return mgr.Start(ctx)
 }
+
+// SignalService sends a signal to the process of a service, for
+// application-level reloads without looking up its PID.
+//
+// Params:
+//   - name: the service name.
+//   - signal: the signal name (SIGHUP, usr1).
+//
+// Returns:
+//   - error: ErrServiceNotFound, an invalid argument for unknown signals,
+//     ErrSignalNotAllowed, or the manager error (not running).
+func (s *Supervisor) SignalService(name, signal string) error {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	svc := s.config.FindService(name)
+	s.mu.RUnlock()
+
+	// validate service exists
+	if !ok || svc == nil {
+		// Return error for missing service.
+		return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	posix, err := domainconfig.NormalizeSignal(signal)
+	// signal name not sendable
+	if err != nil {
+		// Return malformed request error.
+		return shared.WithCode(shared.CodeInvalidArgument, err)
+	}
+	// signal must be allowed for the service
+	if !svc.SignalAllowed(posix) {
+		// Return error with the signal.
+		return fmt.Errorf("%w: %s to %s", ErrSignalNotAllowed, posix, name)
+	}
+	// deliver the signal
+	return mgr.Signal(posix)
+}
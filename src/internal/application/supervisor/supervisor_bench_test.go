@@ -5,6 +5,7 @@ package supervisor_test
 import (
 	"context"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -58,7 +59,7 @@ func createTestConfig(serviceCount int) *domainconfig.Config {
 	services := make([]domainconfig.ServiceConfig, serviceCount)
 	for i := range serviceCount {
 		services[i] = domainconfig.ServiceConfig{
-			Name:    "test-service-" + string(rune('a'+i)),
+			Name:    "test-service-" + strconv.Itoa(i),
 			Command: "/bin/sleep",
 			Args:    []string{"infinity"},
 			Restart: domainconfig.RestartConfig{
@@ -133,6 +134,7 @@ func BenchmarkSupervisorServices(b *testing.B) {
 		{"10Services", 10},
 		{"50Services", 50},
 		{"100Services", 100},
+		{"1000Services", 1000},
 	}
 
 	for _, bm := range benchmarks {
@@ -159,21 +161,33 @@ func BenchmarkSupervisorServices(b *testing.B) {
 
 // BenchmarkSupervisorStats measures statistics retrieval performance.
 func BenchmarkSupervisorStats(b *testing.B) {
-	cfg := createTestConfig(10)
-	loader := &benchmarkLoader{cfg: cfg}
-	executor := &benchmarkExecutor{}
-	reaper := &benchmarkReaper{}
+	benchmarks := []struct {
+		name         string
+		serviceCount int
+	}{
+		{"10Services", 10},
+		{"1000Services", 1000},
+	}
 
-	sup, _ := supervisor.NewSupervisor(cfg, loader, executor, reaper)
-	ctx := context.Background()
-	_ = sup.Start(ctx)
-	defer sup.Stop()
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			cfg := createTestConfig(bm.serviceCount)
+			loader := &benchmarkLoader{cfg: cfg}
+			executor := &benchmarkExecutor{}
+			reaper := &benchmarkReaper{}
 
-	b.ResetTimer()
-	b.ReportAllocs()
+			sup, _ := supervisor.NewSupervisor(cfg, loader, executor, reaper)
+			ctx := context.Background()
+			_ = sup.Start(ctx)
+			defer sup.Stop()
 
-	for range b.N {
-		_ = sup.AllStats()
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for range b.N {
+				_ = sup.AllStats()
+			}
+		})
 	}
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/kodflow/daemon/internal/domain/config"
 	domainhealth "github.com/kodflow/daemon/internal/domain/health"
 	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
 )
 
 // mockLoader implements appconfig.Loader for testing.
@@ -192,6 +193,11 @@ func TestStateValues(t *testing.T) {
 			got:  supervisor.StateStopping,
 			want: supervisor.State(3),
 		},
+		{
+			name: "StateReloading_is_4",
+			got:  supervisor.StateReloading,
+			want: supervisor.State(4),
+		},
 	}
 
 	// Iterate through all test cases.
@@ -394,6 +400,14 @@ func TestNewSupervisor(t *testing.T) {
 			wantErr:     true,
 			errContains: "invalid configuration",
 		},
+		{
+			name: "unknown_restart_strategy_returns_error",
+			cfg: &config.Config{ConfigPath: "/test/config.yaml", Services: []config.ServiceConfig{
+				{Name: "test", Command: "/bin/echo", Restart: config.RestartConfig{Strategy: "lucky"}},
+			}},
+			wantErr:     true,
+			errContains: `service "test": unknown restart strategy: "lucky"`,
+		},
 	}
 
 	// Iterate through all test cases.
@@ -467,6 +481,16 @@ func TestSupervisor_Reload(t *testing.T) {
 
 			sup, err := supervisor.NewSupervisor(cfg, loader, executor, nil)
 			require.NoError(t, err)
+			var mu sync.Mutex
+			reloaded := 0
+			sup.SetEventHandler(func(name string, event *domain.Event, _ *supervisor.ServiceStatsSnapshot) {
+				// count daemon-wide reload events
+				if event.Type == domain.EventConfigReloaded && name == "" {
+					mu.Lock()
+					reloaded++
+					mu.Unlock()
+				}
+			})
 
 			// Start supervisor if required.
 			if tt.startFirst {
@@ -478,15 +502,94 @@ func TestSupervisor_Reload(t *testing.T) {
 
 			err = sup.Reload()
 
+			mu.Lock()
+			defer mu.Unlock()
 			// Check if error is expected.
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Zero(t, reloaded)
 				// Check sentinel error if specified.
 				if tt.errIs != nil {
 					assert.ErrorIs(t, err, tt.errIs)
 				}
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, 1, reloaded)
+			}
+		})
+	}
+}
+
+// mockPreflighter implements appconfig.Preflighter for testing.
+type mockPreflighter struct {
+	// err is the error to return.
+	err error
+}
+
+// Preflight returns the mock error.
+//
+// Params:
+//   - cfg: the configuration to check (unused).
+//
+// Returns:
+//   - error: the mock error.
+func (mp *mockPreflighter) Preflight(_ *config.Config) error {
+	return mp.err
+}
+
+// TestSupervisor_Reload_Preflight tests that failed pre-flight checks refuse the reload.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_Reload_Preflight(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// preflightErr is the error returned by the pre-flight checker.
+		preflightErr error
+		// wantServices is the expected set of services after reload.
+		wantServices []string
+	}{
+		{
+			name:         "passing_checks_apply_reload",
+			wantServices: []string{"test-service", "new-service"},
+		},
+		{
+			name: "failing_checks_refuse_reload",
+			preflightErr: &config.PreflightError{Failures: []config.PreflightFailure{
+				{Service: "new-service", Check: config.PreflightCheckBinary, Detail: "not found"},
+			}},
+			wantServices: []string{"test-service"},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			newCfg := createValidConfig()
+			newCfg.Services = append(newCfg.Services, config.ServiceConfig{Name: "new-service", Command: "/missing"})
+			sup, err := supervisor.NewSupervisor(createValidConfig(), &mockLoader{cfg: newCfg}, &mockExecutor{}, nil)
+			require.NoError(t, err)
+			sup.SetPreflighter(&mockPreflighter{err: tt.preflightErr})
+			require.NoError(t, sup.Start(context.Background()))
+			defer func() { _ = sup.Stop() }()
+
+			err = sup.Reload()
+
+			// Verify the pre-flight report is surfaced.
+			if tt.preflightErr != nil {
+				assert.ErrorIs(t, err, config.ErrPreflightFailed)
+				assert.ErrorIs(t, err, supervisor.ErrReloadRefused)
+				assert.Equal(t, shared.CodeConfigPreflightFailed, shared.CodeOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			// Verify the running services.
+			services := sup.Services()
+			assert.Len(t, services, len(tt.wantServices))
+			for _, name := range tt.wantServices {
+				assert.Contains(t, services, name)
 			}
 		})
 	}
@@ -830,6 +933,65 @@ func TestSupervisor_RestartService(t *testing.T) {
 	}
 }
 
+// TestSupervisor_SignalService tests the SignalService method on the Supervisor type.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_SignalService(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// serviceName is the name of the service to signal.
+		serviceName string
+		// signal is the signal name.
+		signal string
+		// startFirst indicates if supervisor should be started first.
+		startFirst bool
+		// errIs is the expected sentinel error.
+		errIs error
+		// code is the expected error code.
+		code shared.Code
+	}{
+		{name: "allowed_signal", serviceName: "test-service", signal: "usr1", startFirst: true},
+		{name: "non_existing_service", serviceName: "nonexistent", signal: "SIGHUP", startFirst: true,
+			errIs: supervisor.ErrServiceNotFound, code: shared.CodeServiceNotFound},
+		{name: "unknown_signal", serviceName: "test-service", signal: "SIGFOO", startFirst: true,
+			errIs: config.ErrUnknownSignal, code: shared.CodeInvalidArgument},
+		{name: "signal_not_allowed", serviceName: "test-service", signal: "SIGKILL", startFirst: true,
+			errIs: supervisor.ErrSignalNotAllowed, code: shared.CodeSignalNotAllowed},
+		{name: "service_not_running", serviceName: "test-service", signal: "SIGHUP",
+			errIs: domain.ErrNotRunning, code: shared.CodeServiceNotRunning},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createValidConfig()
+			sup, err := supervisor.NewSupervisor(cfg, &mockLoader{cfg: cfg}, &mockExecutor{}, nil)
+			require.NoError(t, err)
+
+			// Start supervisor if required.
+			if tt.startFirst {
+				require.NoError(t, sup.Start(context.Background()))
+				defer func() { _ = sup.Stop() }()
+				mgr, _ := sup.Service("test-service")
+				require.Eventually(t, func() bool { return mgr.PID() != 0 }, time.Second, time.Millisecond)
+			}
+
+			err = sup.SignalService(tt.serviceName, tt.signal)
+
+			// Check if error is expected.
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.Equal(t, tt.code, shared.CodeOf(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 // TestSupervisor_Stats tests the Stats method on the Supervisor type.
 // This test validates the Stats method behavior using black-box testing.
 //
@@ -1446,6 +1446,26 @@ func Test_Supervisor_createDomainListener(t *testing.T) {
 			expectedProtocol: "udp",
 			expectedAddress:  "0.0.0.0",
 		},
+		{
+			name: "creates_ipv6_listener_with_loopback",
+			lc: &domainconfig.ListenerConfig{
+				Name:     "v6-listener",
+				Protocol: "tcp6",
+				Port:     8443,
+			},
+			expectedProtocol: "tcp6",
+			expectedAddress:  "::1",
+		},
+		{
+			name: "strips_ipv6_brackets",
+			lc: &domainconfig.ListenerConfig{
+				Name:    "bracketed-listener",
+				Address: "[fd00::1]",
+				Port:    8443,
+			},
+			expectedProtocol: "tcp",
+			expectedAddress:  "fd00::1",
+		},
 	}
 
 	// Iterate through all test cases.
@@ -1457,6 +1477,8 @@ func Test_Supervisor_createDomainListener(t *testing.T) {
 			listener := s.createDomainListener(tt.lc)
 
 			assert.NotNil(t, listener)
+			assert.Equal(t, tt.expectedProtocol, listener.Protocol)
+			assert.Equal(t, tt.expectedAddress, listener.Address)
 		})
 	}
 }
@@ -1466,11 +1488,18 @@ func Test_Supervisor_createDomainListener(t *testing.T) {
 // Params:
 //   - t: the testing context.
 func Test_Supervisor_createProbeBinding(t *testing.T) {
+	fail := 10000.0
 	tests := []struct {
 		// name is the test case name.
 		name string
 		// lc is the listener configuration.
 		lc *domainconfig.ListenerConfig
+		// expectedNetwork is the expected probe network.
+		expectedNetwork string
+		// expectedAddress is the expected probe address.
+		expectedAddress string
+		// expectedSteps is the expected number of scenario steps.
+		expectedSteps int
 	}{
 		{
 			name: "creates_binding_with_defaults",
@@ -1482,6 +1511,8 @@ func Test_Supervisor_createProbeBinding(t *testing.T) {
 					Path: "/health",
 				},
 			},
+			expectedNetwork: "tcp",
+			expectedAddress: "localhost:8080",
 		},
 		{
 			name: "creates_binding_with_custom_address",
@@ -1494,6 +1525,54 @@ func Test_Supervisor_createProbeBinding(t *testing.T) {
 					Service: "health.v1.Health",
 				},
 			},
+			expectedNetwork: "tcp",
+			expectedAddress: "127.0.0.1:9090",
+		},
+		{
+			name: "creates_binding_with_ipv6_address",
+			lc: &domainconfig.ListenerConfig{
+				Name:     "v6-listener",
+				Protocol: "udp6",
+				Address:  "fd00::1",
+				Port:     53,
+				Probe: &domainconfig.ProbeConfig{
+					Type: "udp",
+				},
+			},
+			expectedNetwork: "udp6",
+			expectedAddress: "[fd00::1]:53",
+		},
+		{
+			name: "creates_binding_with_scenario_steps",
+			lc: &domainconfig.ListenerConfig{
+				Name: "api",
+				Port: 8080,
+				Probe: &domainconfig.ProbeConfig{
+					Type: "scenario",
+					Steps: []domainconfig.ScenarioStep{
+						{Name: "login", Method: "POST", Path: "/login", Extract: map[string]string{"token": `"token":"([^"]+)"`}},
+						{Name: "me", Path: "/me", Headers: map[string]string{"Authorization": "Bearer {{token}}"}},
+					},
+				},
+			},
+			expectedNetwork: "tcp",
+			expectedAddress: "localhost:8080",
+			expectedSteps:   2,
+		},
+		{
+			name: "creates_binding_with_threshold",
+			lc: &domainconfig.ListenerConfig{
+				Name: "consumer",
+				Port: 9100,
+				Probe: &domainconfig.ProbeConfig{
+					Type:      "threshold",
+					Command:   "/bin/queue-lag",
+					Args:      []string{"orders"},
+					Threshold: &domainconfig.ThresholdConfig{Pattern: `lag=(\d+)`, Fail: &fail},
+				},
+			},
+			expectedNetwork: "tcp",
+			expectedAddress: "localhost:9100",
 		},
 	}
 
@@ -1508,6 +1587,25 @@ func Test_Supervisor_createProbeBinding(t *testing.T) {
 			assert.NotNil(t, binding)
 			assert.Equal(t, tt.lc.Name, binding.ListenerName)
 			assert.Equal(t, apphealth.ProbeType(tt.lc.Probe.Type), binding.Type)
+			assert.Equal(t, tt.expectedNetwork, binding.Target.Network)
+			assert.Equal(t, tt.expectedAddress, binding.Target.Address)
+			assert.Len(t, binding.Target.Steps, tt.expectedSteps)
+			assert.Equal(t, tt.lc.Probe.Command, binding.Target.Command)
+			assert.Equal(t, tt.lc.Probe.Args, binding.Target.Args)
+			// Thresholds are converted with their fail value.
+			if tt.lc.Probe.Threshold != nil {
+				require.NotNil(t, binding.Target.Threshold)
+				assert.Equal(t, tt.lc.Probe.Threshold.Pattern, binding.Target.Threshold.Pattern)
+				assert.InDelta(t, *tt.lc.Probe.Threshold.Fail, binding.Target.Threshold.Fail, 0)
+			} else {
+				assert.Nil(t, binding.Target.Threshold)
+			}
+			// Steps keep their order and fields.
+			for i, step := range binding.Target.Steps {
+				assert.Equal(t, tt.lc.Probe.Steps[i].Name, step.Name)
+				assert.Equal(t, tt.lc.Probe.Steps[i].Headers, step.Headers)
+				assert.Equal(t, tt.lc.Probe.Steps[i].Extract, step.Extract)
+			}
 		})
 	}
 }
@@ -0,0 +1,80 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file contains cgroup CPU throttling detection for managed services.
+package supervisor
+
+import (
+	"fmt"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// throttledCustomStatus is the health custom status set while a service is CPU-throttled.
+// Any non-healthy custom status reports the service as degraded.
+const throttledCustomStatus string = "THROTTLED"
+
+// checkThrottling compares a metrics update against the throttling threshold.
+// On a threshold crossing it updates the service health and emits an event.
+//
+// Params:
+//   - m: the metrics update.
+func (s *Supervisor) checkThrottling(m *domainmetrics.ProcessMetrics) {
+	s.mu.Lock()
+	threshold := s.config.Monitoring.Metrics.CPU.ThrottleThreshold
+	over := threshold > 0 && m.ThrottledPercent > threshold
+	// Skip when the throttling state did not change.
+	if s.throttled[m.ServiceName] == over {
+		s.mu.Unlock()
+		// No transition.
+		return
+	}
+	s.throttled[m.ServiceName] = over
+	s.setThrottledHealth(m.ServiceName, over)
+	statsSnap := s.getStatsSnapshot(s.stats[m.ServiceName])
+	s.mu.Unlock()
+
+	event := domain.NewEvent(domain.EventUnthrottled, m.ServiceName, m.PID, 0, nil)
+	// Attach throttling details when entering the throttled state.
+	if over {
+		event = domain.NewEvent(domain.EventThrottled, m.ServiceName, m.PID, 0,
+			fmt.Errorf("%w: %.1f%% of wall time exceeds %.1f%%", ErrCPUThrottled, m.ThrottledPercent, threshold))
+	}
+	s.callEventHandler(m.ServiceName, &event, statsSnap)
+}
+
+// resetThrottling clears the throttling state of a service that stopped.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) resetThrottling(name string) {
+	// Skip services that were not throttled.
+	if !s.throttled[name] {
+		// Nothing to reset.
+		return
+	}
+	delete(s.throttled, name)
+	s.setThrottledHealth(name, false)
+}
+
+// setThrottledHealth marks the service health monitor as degraded while throttled.
+// Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - throttled: whether the service is currently throttled.
+func (s *Supervisor) setThrottledHealth(name string, throttled bool) {
+	monitor, ok := s.healthMonitors[name]
+	// Skip if no health monitor configured.
+	if !ok {
+		// Not found.
+		return
+	}
+
+	// Set or clear the degraded custom status.
+	if throttled {
+		monitor.SetCustomStatus(throttledCustomStatus)
+	} else {
+		monitor.SetCustomStatus("")
+	}
+}
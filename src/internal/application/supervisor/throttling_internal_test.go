@@ -0,0 +1,99 @@
+// Package supervisor provides internal tests for throttling.go.
+// It tests cgroup CPU throttling detection using white-box testing.
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphealth "github.com/kodflow/daemon/internal/application/health"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// newThrottlingTestSupervisor creates a supervisor with a health monitor for throttling tests.
+//
+// Params:
+//   - threshold: the CPU throttle threshold percentage.
+//
+// Returns:
+//   - *Supervisor: the supervisor under test.
+//   - *[]domain.Event: events received by the event handler.
+func newThrottlingTestSupervisor(threshold float64) (*Supervisor, *[]domain.Event) {
+	cfg := &domainconfig.Config{}
+	cfg.Monitoring.Metrics.CPU.ThrottleThreshold = threshold
+	monitor := apphealth.NewProbeMonitor(apphealth.ProbeMonitorConfig{})
+	monitor.SetProcessState(domain.StateRunning)
+
+	events := &[]domain.Event{}
+	s := &Supervisor{
+		config:         cfg,
+		healthMonitors: map[string]*apphealth.ProbeMonitor{"app": monitor},
+		stats:          map[string]*ServiceStats{"app": NewServiceStats()},
+		throttled:      make(map[string]bool),
+		eventHandler: func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+			*events = append(*events, *event)
+		},
+	}
+	// return supervisor and captured events
+	return s, events
+}
+
+// Test_Supervisor_checkThrottling tests throttling threshold transitions.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkThrottling(t *testing.T) {
+	s, events := newThrottlingTestSupervisor(25)
+
+	// Below threshold: no transition.
+	s.checkThrottling(&domainmetrics.ProcessMetrics{ServiceName: "app", PID: 10, ThrottledPercent: 10})
+	assert.Empty(t, *events)
+
+	// Above threshold: throttled event and degraded health.
+	s.checkThrottling(&domainmetrics.ProcessMetrics{ServiceName: "app", PID: 10, ThrottledPercent: 40})
+	require.Len(t, *events, 1)
+	assert.Equal(t, domain.EventThrottled, (*events)[0].Type)
+	assert.ErrorIs(t, (*events)[0].Error, ErrCPUThrottled)
+	assert.True(t, s.healthMonitors["app"].Health().IsDegraded())
+
+	// Still above threshold: no duplicate event.
+	s.checkThrottling(&domainmetrics.ProcessMetrics{ServiceName: "app", PID: 10, ThrottledPercent: 50})
+	assert.Len(t, *events, 1)
+
+	// Back below threshold: unthrottled event and health restored.
+	s.checkThrottling(&domainmetrics.ProcessMetrics{ServiceName: "app", PID: 10, ThrottledPercent: 5})
+	require.Len(t, *events, 2)
+	assert.Equal(t, domain.EventUnthrottled, (*events)[1].Type)
+	assert.False(t, s.healthMonitors["app"].Health().IsDegraded())
+}
+
+// Test_Supervisor_checkThrottling_Disabled tests that a zero threshold disables detection.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_checkThrottling_Disabled(t *testing.T) {
+	s, events := newThrottlingTestSupervisor(0)
+
+	s.checkThrottling(&domainmetrics.ProcessMetrics{ServiceName: "app", ThrottledPercent: 90})
+
+	assert.Empty(t, *events)
+}
+
+// Test_Supervisor_resetThrottling tests clearing throttling state on service exit.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_resetThrottling(t *testing.T) {
+	s, _ := newThrottlingTestSupervisor(25)
+	s.checkThrottling(&domainmetrics.ProcessMetrics{ServiceName: "app", ThrottledPercent: 40})
+	require.True(t, s.throttled["app"])
+
+	s.resetThrottling("app")
+
+	assert.False(t, s.throttled["app"])
+	assert.False(t, s.healthMonitors["app"].Health().IsDegraded())
+}
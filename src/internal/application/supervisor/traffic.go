@@ -0,0 +1,482 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file samples the connections of the services into a dependency map.
+package supervisor
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// trafficRecheckInterval is how often a disabled sampler checks whether a
+// reload enabled it.
+const trafficRecheckInterval time.Duration = time.Minute
+
+// portBitSize is the bit size of a port number.
+const portBitSize int = 16
+
+// Traffic sampling errors.
+var (
+	// ErrNoSocketReader is returned by DependencyMap when no socket reader is configured.
+	ErrNoSocketReader error = fmt.Errorf("no socket reader configured")
+	// ErrTrafficSamplingDisabled is returned by DependencyMap when traffic_sampling has no interval.
+	ErrTrafficSamplingDisabled error = fmt.Errorf("traffic sampling disabled: set traffic_sampling.interval")
+)
+
+// trafficKey identifies an edge of the dependency map: a service and the
+// peer it reaches, or the endpoint for endpoints outside the daemon.
+type trafficKey struct {
+	// service is the service opening the connections.
+	service string
+	// peer is the supervised service reached.
+	peer string
+	// endpoint is the endpoint reached, set without peer.
+	endpoint string
+}
+
+// trafficSample is a connection seen by a sample.
+type trafficSample struct {
+	// service is the service opening the connection.
+	service string
+	// peer is the supervised service reached, empty outside the daemon.
+	peer string
+	// endpoint is the address and port reached.
+	endpoint string
+}
+
+// SetSocketReader sets the adapter reading the TCP sockets of running
+// services. Without it, connections are not sampled.
+//
+// Params:
+//   - reader: the socket reader to use.
+func (s *Supervisor) SetSocketReader(reader domain.SocketReader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store socket reader
+	s.socketReader = reader
+}
+
+// startTrafficSampler samples the connections of the services
+// periodically. It is skipped without socket reader.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine sampling on a ticker.
+//   - Goroutine exits when the supervisor context is cancelled.
+func (s *Supervisor) startTrafficSampler() {
+	s.mu.RLock()
+	reader := s.socketReader
+	interval := s.config.TrafficSampling.EffectiveInterval()
+	s.mu.RUnlock()
+
+	// Skip without reader.
+	if reader == nil {
+		// Nothing to sample.
+		return
+	}
+	// Wait for a reload enabling sampling.
+	if interval <= 0 {
+		interval = trafficRecheckInterval
+	}
+
+	// Sample until shutdown.
+	s.wg.Go(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		// Loop until context is cancelled.
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Return when context is cancelled.
+				return
+			case <-ticker.C:
+				// Follow interval changes of reloads.
+				ticker.Reset(s.sampleTraffic(reader))
+			}
+		}
+	})
+}
+
+// sampleTraffic reads the sockets of the running services once and
+// records the connections they opened. Disabling sampling drops the map.
+//
+// Params:
+//   - reader: the socket reader.
+//
+// Returns:
+//   - time.Duration: the interval until the next sample.
+func (s *Supervisor) sampleTraffic(reader domain.SocketReader) time.Duration {
+	s.mu.RLock()
+	cfg := s.config.TrafficSampling
+	roots := make(map[int]string, len(s.managers))
+	// Collect the main process of each running service.
+	for svc, mgr := range s.managers {
+		// Stopped services hold no socket.
+		if pid := mgr.PID(); pid > 0 {
+			roots[pid] = svc
+		}
+	}
+	s.mu.RUnlock()
+
+	// Drop the map while disabled.
+	if !cfg.Enabled() {
+		s.mu.Lock()
+		s.traffic = nil
+		s.mu.Unlock()
+		// Check again for a reload enabling it.
+		return trafficRecheckInterval
+	}
+	sockets, err := reader.Sockets(s.ctx, slices.Collect(maps.Keys(roots)))
+	// Skip this sample when procfs is unreadable.
+	if err != nil {
+		s.handleRecoveryError("traffic-sampling", "", err)
+		// Try again after the interval.
+		return cfg.EffectiveInterval()
+	}
+	byService := make(map[string][]domain.Socket, len(sockets))
+	// Attribute the sockets of each process tree to its service.
+	for pid, list := range sockets {
+		byService[roots[pid]] = append(byService[roots[pid]], list...)
+	}
+	s.recordTraffic(classifyTraffic(byService), cfg.EffectiveMaxEndpoints(), s.clock.Now())
+	// Sample again after the interval.
+	return cfg.EffectiveInterval()
+}
+
+// recordTraffic adds the connections of a sample to the dependency map.
+// Edges of services no longer configured are dropped, and new endpoints
+// outside the daemon are ignored once a service reached its limit.
+//
+// Params:
+//   - samples: the connections seen by the sample.
+//   - maxEndpoints: the endpoints outside the daemon kept per service.
+//   - now: the time of the sample.
+func (s *Supervisor) recordTraffic(samples []trafficSample, maxEndpoints int, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Create the map on first use.
+	if s.traffic == nil {
+		s.traffic = make(map[trafficKey]*domain.TrafficEdge)
+	}
+	external := make(map[string]int)
+	// Drop removed services and count the endpoints of the others.
+	for key, edge := range s.traffic {
+		// The service was removed by a reload.
+		if s.config.FindService(key.service) == nil {
+			delete(s.traffic, key)
+			continue
+		}
+		// Count endpoints outside the daemon.
+		if edge.IsExternal() {
+			external[key.service]++
+		}
+	}
+
+	seen := make(map[trafficKey]bool, len(samples))
+	// Record each edge once per sample.
+	for _, sample := range samples {
+		key := trafficKey{service: sample.service, peer: sample.peer}
+		// Endpoints outside the daemon are edges of their own.
+		if sample.peer == "" {
+			key.endpoint = sample.endpoint
+		}
+		// Several connections to the same edge count as one sample.
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		edge, ok := s.traffic[key]
+		// Add the edge seen for the first time.
+		if !ok {
+			// Bound the endpoints outside the daemon.
+			if sample.peer == "" {
+				// The service reached its limit.
+				if external[sample.service] >= maxEndpoints {
+					continue
+				}
+				external[sample.service]++
+			}
+			edge = &domain.TrafficEdge{Service: sample.service, Peer: sample.peer, FirstSeen: now}
+			s.traffic[key] = edge
+		}
+		edge.Endpoint = sample.endpoint
+		edge.Samples++
+		edge.LastSeen = now
+	}
+}
+
+// classifyTraffic finds the connections each service opened, and the
+// supervised service or endpoint each one reaches. Connections accepted
+// on a listener of the service, and within a service, are left out.
+//
+// Params:
+//   - sockets: the sockets of each service.
+//
+// Returns:
+//   - []trafficSample: the connections, sorted by service, peer and endpoint.
+func classifyTraffic(sockets map[string][]domain.Socket) []trafficSample {
+	listeners := make(map[netip.AddrPort]string)
+	endpoints := make(map[netip.AddrPort]string)
+	// Index the listeners and local endpoints of every service.
+	for svc, list := range sockets {
+		// Index each socket by its local address.
+		for _, socket := range list {
+			// Listeners accept connections, other sockets end one.
+			if socket.Listening {
+				listeners[socket.Local] = svc
+			} else {
+				endpoints[socket.Local] = svc
+			}
+		}
+	}
+
+	var samples []trafficSample
+	// Classify the connections of each service.
+	for svc, list := range sockets {
+		// Keep the connections the service opened.
+		for _, socket := range list {
+			// Skip listeners and connections accepted by the service.
+			if socket.Listening || listenerOwner(socket.Local, listeners) == svc {
+				continue
+			}
+			// The other end is a socket of a supervised service.
+			peer := endpoints[socket.Remote]
+			// Otherwise, look for a supervised service listening there.
+			if peer == "" && (socket.Remote.Addr().IsLoopback() || socket.Remote.Addr() == socket.Local.Addr()) {
+				peer = listenerOwner(socket.Remote, listeners)
+			} else if peer == "" {
+				peer = listeners[socket.Remote]
+			}
+			// Skip connections within the service.
+			if peer == svc {
+				continue
+			}
+			samples = append(samples, trafficSample{service: svc, peer: peer, endpoint: socket.Remote.String()})
+		}
+	}
+	slices.SortFunc(samples, func(a, b trafficSample) int {
+		// Order by service, peer, then endpoint.
+		return cmp.Or(cmp.Compare(a.service, b.service), cmp.Compare(a.peer, b.peer), cmp.Compare(a.endpoint, b.endpoint))
+	})
+	// Return the connections.
+	return samples
+}
+
+// listenerOwner returns the service listening on an address, directly or
+// on the unspecified address of either family.
+//
+// Params:
+//   - addr: the local address and port.
+//   - listeners: the service of each listener.
+//
+// Returns:
+//   - string: the service, empty when none listens there.
+func listenerOwner(addr netip.AddrPort, listeners map[netip.AddrPort]string) string {
+	// Prefer the listener bound to the address.
+	if svc, ok := listeners[addr]; ok {
+		// Return the bound listener.
+		return svc
+	}
+	// Dual-stack listeners accept IPv4 connections too.
+	if svc, ok := listeners[netip.AddrPortFrom(netip.IPv4Unspecified(), addr.Port())]; ok {
+		// Return the IPv4 wildcard listener.
+		return svc
+	}
+	// Return the IPv6 wildcard listener, if any.
+	return listeners[netip.AddrPortFrom(netip.IPv6Unspecified(), addr.Port())]
+}
+
+// DependencyMap returns the connections sampled from a service, or from
+// every service when name is empty, sorted by service with supervised
+// peers first. Each edge reports whether depends_on or
+// external_dependencies declares it.
+//
+// Params:
+//   - name: the service name, empty for every service.
+//   - undeclaredOnly: keep only the edges the configuration does not declare.
+//
+// Returns:
+//   - []domain.TrafficEdge: the sampled edges.
+//   - error: ErrNoSocketReader, ErrServiceNotFound or ErrTrafficSamplingDisabled.
+func (s *Supervisor) DependencyMap(name string, undeclaredOnly bool) ([]domain.TrafficEdge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// the socket reader is optional
+	if s.socketReader == nil {
+		// return disabled feature
+		return nil, ErrNoSocketReader
+	}
+	// validate service exists
+	if name != "" && s.config.FindService(name) == nil {
+		// return not found error
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	// sampling is enabled by the configuration
+	if !s.config.TrafficSampling.Enabled() {
+		// return disabled sampling
+		return nil, ErrTrafficSamplingDisabled
+	}
+
+	edges := make([]domain.TrafficEdge, 0, len(s.traffic))
+	// copy the edges of the requested services
+	for key, edge := range s.traffic {
+		svc := s.config.FindService(key.service)
+		// keep the requested, still configured services
+		if svc == nil || (name != "" && key.service != name) {
+			continue
+		}
+		copied := *edge
+		copied.Declared = dependencyDeclared(svc, &copied)
+		// keep undeclared edges only when asked
+		if undeclaredOnly && copied.Declared {
+			continue
+		}
+		edges = append(edges, copied)
+	}
+	slices.SortFunc(edges, func(a, b domain.TrafficEdge) int {
+		// order by service, supervised peers first, then peer and endpoint
+		return cmp.Or(cmp.Compare(a.Service, b.Service), compareBool(a.IsExternal(), b.IsExternal()),
+			cmp.Compare(a.Peer, b.Peer), cmp.Compare(a.Endpoint, b.Endpoint))
+	})
+	// return the edges
+	return edges, nil
+}
+
+// compareBool orders false before true.
+//
+// Params:
+//   - a: the first value.
+//   - b: the second value.
+//
+// Returns:
+//   - int: -1, 0 or 1.
+func compareBool(a, b bool) int {
+	// order as integers
+	return cmp.Compare(boolRank(a), boolRank(b))
+}
+
+// boolRank ranks a boolean.
+//
+// Params:
+//   - v: the value.
+//
+// Returns:
+//   - int: 1 for true, 0 for false.
+func boolRank(v bool) int {
+	// true sorts last
+	if v {
+		// return rank of true
+		return 1
+	}
+	// return rank of false
+	return 0
+}
+
+// dependencyDeclared reports whether the configuration of a service
+// declares an edge: the peer is in depends_on, or an external dependency
+// addresses the endpoint. A dependency addressed by host name matches any
+// endpoint on its port, the name not being resolved.
+//
+// Params:
+//   - svc: the service opening the connections.
+//   - edge: the sampled edge.
+//
+// Returns:
+//   - bool: true when declared.
+func dependencyDeclared(svc *domainconfig.ServiceConfig, edge *domain.TrafficEdge) bool {
+	// the peer is a declared dependency
+	if edge.Peer != "" && slices.Contains(svc.DependsOn, edge.Peer) {
+		// return declared peer
+		return true
+	}
+	endpoint, err := netip.ParseAddrPort(edge.Endpoint)
+	// the endpoint is always an address and port
+	if err != nil {
+		// return undeclared
+		return false
+	}
+	// match each external dependency
+	for i := range svc.ExternalDependencies {
+		host, port, ok := dependencyHostPort(svc.ExternalDependencies[i].Address)
+		// skip addresses without port
+		if !ok || port != endpoint.Port() {
+			continue
+		}
+		addr, err := netip.ParseAddr(host)
+		// host names match any address, addresses must be equal
+		if err != nil || addr.Unmap() == endpoint.Addr() {
+			// return declared endpoint
+			return true
+		}
+	}
+	// return undeclared
+	return false
+}
+
+// dependencyHostPort extracts the host and port of an external dependency
+// address: "host:port", or a URL whose port defaults from its scheme.
+//
+// Params:
+//   - address: the dependency address.
+//
+// Returns:
+//   - string: the host.
+//   - uint16: the port.
+//   - bool: false when the address has no port.
+func dependencyHostPort(address string) (string, uint16, bool) {
+	host, portText, err := net.SplitHostPort(address)
+	// URLs carry a scheme, which SplitHostPort would take for the host
+	if strings.Contains(address, "://") {
+		u, urlErr := url.Parse(address)
+		// the URL must name a host
+		if urlErr != nil || u.Host == "" {
+			// return no port
+			return "", 0, false
+		}
+		host, portText, err = u.Hostname(), u.Port(), nil
+		// default the port from the scheme
+		if portText == "" {
+			portText = defaultSchemePort(u.Scheme)
+		}
+	}
+	// the address has no port
+	if err != nil {
+		// return no port
+		return "", 0, false
+	}
+	port, err := strconv.ParseUint(portText, shared.Base10, portBitSize)
+	// return the host and port
+	return host, uint16(port), err == nil
+}
+
+// defaultSchemePort returns the default port of a URL scheme.
+//
+// Params:
+//   - scheme: the URL scheme.
+//
+// Returns:
+//   - string: the port, empty when unknown.
+func defaultSchemePort(scheme string) string {
+	// match known schemes
+	switch scheme {
+	case "http":
+		// return HTTP port
+		return "80"
+	case "https":
+		// return HTTPS port
+		return "443"
+	default:
+		// return unknown port
+		return ""
+	}
+}
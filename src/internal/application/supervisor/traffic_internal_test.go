@@ -0,0 +1,255 @@
+// Package supervisor provides internal tests for traffic.go.
+// It tests the sampled dependency map using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	applifecycle "github.com/kodflow/daemon/internal/application/lifecycle"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// trafficTestReader returns fixed sockets.
+type trafficTestReader struct {
+	// sockets is returned by Sockets.
+	sockets map[int][]domain.Socket
+	// err is returned by Sockets when set.
+	err error
+}
+
+// Sockets returns the configured sockets.
+//
+// Returns:
+//   - map[int][]domain.Socket: the configured sockets.
+//   - error: the configured error.
+func (r *trafficTestReader) Sockets(_ context.Context, _ []int) (map[int][]domain.Socket, error) {
+	return r.sockets, r.err
+}
+
+// trafficTestExecutor starts processes with a fixed pid that stay running.
+type trafficTestExecutor struct {
+	proxyTestExecutor
+	// pid is the pid of started processes.
+	pid int
+}
+
+// Start returns a process that stays running.
+//
+// Returns:
+//   - int: the configured pid.
+//   - <-chan domain.ExitResult: a channel that never fires.
+//   - error: always nil.
+func (e *trafficTestExecutor) Start(_ context.Context, _ domain.Spec) (int, <-chan domain.ExitResult, error) {
+	return e.pid, make(chan domain.ExitResult), nil
+}
+
+// newTrafficTestSupervisor builds a supervisor with "web" running as pid
+// 10 and "api" as pid 20, sampling every 30 seconds. "web" depends on
+// "api", "api" declares a database and a payment API.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *Supervisor: the supervisor.
+func newTrafficTestSupervisor(t *testing.T) *Supervisor {
+	t.Helper()
+	cfg := &domainconfig.Config{
+		TrafficSampling: domainconfig.TrafficSamplingConfig{Interval: shared.Seconds(30)},
+		Services: []domainconfig.ServiceConfig{
+			{Name: "web", Command: "/bin/web", DependsOn: []string{"api"}},
+			{Name: "api", Command: "/bin/api", ExternalDependencies: []domainconfig.DependencyConfig{
+				{Name: "db", Address: "10.0.0.5:5432"},
+				{Name: "payments", Address: "https://payments.example.com/health"},
+			}},
+		},
+	}
+	s := &Supervisor{config: cfg, managers: map[string]*applifecycle.Manager{}, clock: shared.NewFakeClock(time.Unix(1000, 0))}
+	// Start each service with its pid.
+	for i, pid := range []int{10, 20} {
+		mgr := applifecycle.NewManager(&cfg.Services[i], &trafficTestExecutor{pid: pid})
+		require.NoError(t, mgr.Start(context.Background()))
+		t.Cleanup(func() { _ = mgr.Stop() })
+		require.Eventually(t, func() bool { return mgr.PID() != 0 }, time.Second, 10*time.Millisecond)
+		s.managers[cfg.Services[i].Name] = mgr
+	}
+	return s
+}
+
+// Test_classifyTraffic tests the peer or endpoint of each connection.
+//
+// Params:
+//   - t: the testing context.
+func Test_classifyTraffic(t *testing.T) {
+	addr := netip.MustParseAddrPort
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// sockets is the sockets of each service.
+		sockets map[string][]domain.Socket
+		// want is the expected connections.
+		want []trafficSample
+	}{
+		{
+			name: "both_ends_sampled",
+			sockets: map[string][]domain.Socket{
+				"web": {{Local: addr("127.0.0.1:40000"), Remote: addr("127.0.0.1:8080")}},
+				"api": {
+					{Local: addr("0.0.0.0:8080"), Listening: true},
+					{Local: addr("127.0.0.1:8080"), Remote: addr("127.0.0.1:40000")},
+				},
+			},
+			want: []trafficSample{{service: "web", peer: "api", endpoint: "127.0.0.1:8080"}},
+		},
+		{
+			name: "wildcard_listener_on_loopback",
+			sockets: map[string][]domain.Socket{
+				"web": {{Local: addr("127.0.0.1:40000"), Remote: addr("127.0.0.1:8080")}},
+				"api": {{Local: addr("[::]:8080"), Listening: true}},
+			},
+			want: []trafficSample{{service: "web", peer: "api", endpoint: "127.0.0.1:8080"}},
+		},
+		{
+			name: "same_port_on_another_host",
+			sockets: map[string][]domain.Socket{
+				"web": {{Local: addr("10.0.0.2:40000"), Remote: addr("10.0.0.9:8080")}},
+				"api": {{Local: addr("0.0.0.0:8080"), Listening: true}},
+			},
+			want: []trafficSample{{service: "web", endpoint: "10.0.0.9:8080"}},
+		},
+		{
+			name: "accepted_and_internal_connections",
+			sockets: map[string][]domain.Socket{
+				"api": {
+					{Local: addr("0.0.0.0:8080"), Listening: true},
+					{Local: addr("10.0.0.2:8080"), Remote: addr("10.0.0.7:51000")},
+					{Local: addr("127.0.0.1:41000"), Remote: addr("127.0.0.1:8080")},
+					{Local: addr("10.0.0.2:42000"), Remote: addr("10.0.0.5:5432")},
+				},
+			},
+			want: []trafficSample{{service: "api", endpoint: "10.0.0.5:5432"}},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyTraffic(tt.sockets))
+		})
+	}
+}
+
+// Test_Supervisor_recordTraffic tests sample counting and the endpoint limit.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_recordTraffic(t *testing.T) {
+	s := &Supervisor{config: &domainconfig.Config{Services: []domainconfig.ServiceConfig{{Name: "api"}}}}
+	first, second := time.Unix(1000, 0), time.Unix(1030, 0)
+	s.traffic = map[trafficKey]*domain.TrafficEdge{
+		{service: "gone", endpoint: "10.0.0.1:80"}: {Service: "gone", Endpoint: "10.0.0.1:80"},
+	}
+
+	s.recordTraffic([]trafficSample{
+		{service: "api", endpoint: "10.0.0.5:5432"},
+		{service: "api", endpoint: "10.0.0.5:5432"},
+		{service: "api", endpoint: "10.0.0.6:5432"},
+	}, 1, first)
+	s.recordTraffic([]trafficSample{{service: "api", endpoint: "10.0.0.5:5432"}}, 1, second)
+
+	assert.Equal(t, map[trafficKey]*domain.TrafficEdge{
+		{service: "api", endpoint: "10.0.0.5:5432"}: {
+			Service: "api", Endpoint: "10.0.0.5:5432", Samples: 2, FirstSeen: first, LastSeen: second,
+		},
+	}, s.traffic)
+}
+
+// Test_Supervisor_sampleTraffic tests the sampling of running services.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_sampleTraffic(t *testing.T) {
+	s := newTrafficTestSupervisor(t)
+	reader := &trafficTestReader{sockets: map[int][]domain.Socket{
+		10: {{Local: netip.MustParseAddrPort("127.0.0.1:40000"), Remote: netip.MustParseAddrPort("127.0.0.1:8080")}},
+		20: {{Local: netip.MustParseAddrPort("0.0.0.0:8080"), Listening: true}},
+	}}
+	s.SetSocketReader(reader)
+
+	assert.Equal(t, 30*time.Second, s.sampleTraffic(reader))
+	edges, err := s.DependencyMap("", false)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TrafficEdge{{
+		Service: "web", Peer: "api", Endpoint: "127.0.0.1:8080", Declared: true, Samples: 1,
+		FirstSeen: time.Unix(1000, 0), LastSeen: time.Unix(1000, 0),
+	}}, edges)
+
+	// A failed read keeps the map.
+	reader.err = errors.New("no procfs")
+	assert.Equal(t, 30*time.Second, s.sampleTraffic(reader))
+	assert.Len(t, s.traffic, 1)
+
+	// Disabling sampling drops the map.
+	s.config.TrafficSampling = domainconfig.TrafficSamplingConfig{}
+	assert.Equal(t, trafficRecheckInterval, s.sampleTraffic(reader))
+	assert.Nil(t, s.traffic)
+}
+
+// Test_Supervisor_DependencyMap tests the declared flag and the filters.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_DependencyMap(t *testing.T) {
+	s := newTrafficTestSupervisor(t)
+	s.SetSocketReader(&trafficTestReader{})
+	s.recordTraffic([]trafficSample{
+		{service: "api", endpoint: "10.0.0.5:5432"},
+		{service: "api", endpoint: "10.0.0.6:6379"},
+		{service: "api", endpoint: "93.184.216.34:443"},
+		{service: "api", peer: "web", endpoint: "127.0.0.1:80"},
+		{service: "web", peer: "api", endpoint: "127.0.0.1:8080"},
+	}, 64, time.Unix(1000, 0))
+
+	summarize := func(edges []domain.TrafficEdge) []string {
+		out := make([]string, 0, len(edges))
+		for _, edge := range edges {
+			out = append(out, edge.Service+">"+edge.Peer+"@"+edge.Endpoint+map[bool]string{true: " declared", false: ""}[edge.Declared])
+		}
+		return out
+	}
+
+	edges, err := s.DependencyMap("", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"api>web@127.0.0.1:80",
+		"api>@10.0.0.5:5432 declared",
+		"api>@10.0.0.6:6379",
+		"api>@93.184.216.34:443 declared",
+		"web>api@127.0.0.1:8080 declared",
+	}, summarize(edges))
+
+	edges, err = s.DependencyMap("api", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api>web@127.0.0.1:80", "api>@10.0.0.6:6379"}, summarize(edges))
+
+	_, err = s.DependencyMap("db", false)
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+
+	s.config.TrafficSampling = domainconfig.TrafficSamplingConfig{}
+	_, err = s.DependencyMap("", false)
+	assert.ErrorIs(t, err, ErrTrafficSamplingDisabled)
+
+	s.SetSocketReader(nil)
+	_, err = s.DependencyMap("", false)
+	assert.ErrorIs(t, err, ErrNoSocketReader)
+}
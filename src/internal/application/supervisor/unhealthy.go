@@ -0,0 +1,207 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file routes health failures to the unhealthy actions of listener probes.
+package supervisor
+
+import (
+	"fmt"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// unhealthyReported runs the escalation step reached by a report of a
+// failing listener probe. Runs in the probe callback, with the monitor
+// lock held: stops and hooks run in their own goroutine.
+//
+// Params:
+//   - name: the service name.
+//   - listener: the failing listener.
+//   - reason: the probe failure reason.
+func (s *Supervisor) unhealthyReported(name, listener, reason string) {
+	s.mu.Lock()
+	// create the maps on first use
+	if s.unhealthyReports == nil {
+		s.unhealthyReports = make(map[string]map[string]int)
+	}
+	if s.unhealthyReports[name] == nil {
+		s.unhealthyReports[name] = make(map[string]int)
+	}
+	s.unhealthyReports[name][listener]++
+	step := s.unhealthyStep(name, listener, s.unhealthyReports[name][listener])
+	s.mu.Unlock()
+
+	// run the reached step
+	switch step.Action {
+	// the manager reports the failure before restarting
+	case domainconfig.UnhealthyActionRestart:
+		// Attempt to restart the service on health failure.
+		if err := s.RestartOnHealthFailure(name, reason); err != nil {
+			s.handleRecoveryError("health-restart", name, err)
+		}
+	// stop until started again
+	case domainconfig.UnhealthyActionStop:
+		s.notifyUnhealthy(name, reason)
+		s.wg.Go(func() {
+			// Report services that could not be stopped.
+			if err := s.StopService(name); err != nil {
+				s.handleRecoveryError("health-stop", name, err)
+			}
+		})
+	// let the service react
+	case domainconfig.UnhealthyActionSignal:
+		s.notifyUnhealthy(name, reason)
+		// Report signals that could not be sent.
+		if err := s.signalUnhealthy(name, step.SignalName()); err != nil {
+			s.handleRecoveryError("health-signal", name, err)
+		}
+	// run the hook
+	case domainconfig.UnhealthyActionExec:
+		s.notifyUnhealthy(name, reason)
+		s.wg.Go(func() {
+			// Run outside the probe callback.
+			s.runUnhealthyHook(name, listener, reason, &step)
+		})
+	// event only
+	case domainconfig.UnhealthyActionNotify:
+		s.notifyUnhealthy(name, reason)
+	default:
+		// Unknown action, rejected by validation.
+	}
+}
+
+// unhealthyStep returns the escalation step of a listener probe reached by
+// a report. Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - listener: the failing listener.
+//   - report: the report number since the listener was last healthy.
+//
+// Returns:
+//   - domainconfig.UnhealthyStep: the step to run, restart when unknown.
+func (s *Supervisor) unhealthyStep(name, listener string, report int) domainconfig.UnhealthyStep {
+	var probe domainconfig.ProbeConfig
+	// look the probe up in the loaded configuration
+	if s.config != nil {
+		// find the listener of the service
+		if svc := s.config.FindService(name); svc != nil {
+			// match the listener by name
+			for i := range svc.Listeners {
+				// keep the failing listener probe
+				if svc.Listeners[i].Name == listener && svc.Listeners[i].Probe != nil {
+					probe = *svc.Listeners[i].Probe
+					break
+				}
+			}
+		}
+	}
+	// return the reached step
+	return probe.UnhealthyStepFor(report)
+}
+
+// unhealthyRecovered restarts the escalation chain of a listener once its
+// probe reports the service healthy again. Must be called with s.mu held.
+//
+// Params:
+//   - name: the service name.
+//   - listener: the recovered listener.
+func (s *Supervisor) unhealthyRecovered(name, listener string) {
+	delete(s.unhealthyReports[name], listener)
+}
+
+// forgetUnhealthy restarts the escalation chains of a service, for a
+// manual stop.
+//
+// Params:
+//   - name: the service name.
+func (s *Supervisor) forgetUnhealthy(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.unhealthyReports, name)
+}
+
+// notifyUnhealthy emits the unhealthy event of an action other than restart,
+// which the manager reports itself.
+//
+// Params:
+//   - name: the service name.
+//   - reason: the probe failure reason.
+func (s *Supervisor) notifyUnhealthy(name, reason string) {
+	s.mu.RLock()
+	mgr := s.managers[name]
+	s.mu.RUnlock()
+
+	pid := 0
+	// attach the running process
+	if mgr != nil {
+		pid = mgr.PID()
+	}
+	event := domain.NewEvent(domain.EventUnhealthy, name, pid, 0,
+		fmt.Errorf("%s: %w", reason, domain.ErrHealthProbeFailed))
+	s.handleEvent(name, &event)
+}
+
+// signalUnhealthy sends the signal of a signal action to a running service.
+//
+// Params:
+//   - name: the service name.
+//   - signal: the POSIX signal name.
+//
+// Returns:
+//   - error: ErrServiceNotFound or the signal error.
+func (s *Supervisor) signalUnhealthy(name, signal string) error {
+	s.mu.RLock()
+	mgr, ok := s.managers[name]
+	s.mu.RUnlock()
+
+	// validate service exists
+	if !ok {
+		// Return error for missing service.
+		return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	// Only a running process can be signaled.
+	if !mgr.State().IsRunning() {
+		// Nothing to signal.
+		return nil
+	}
+	// deliver the signal
+	return mgr.Signal(signal)
+}
+
+// runUnhealthyHook runs the exec action of a failing listener probe. The
+// hook receives the service, the listener and the failure reason in its
+// environment.
+//
+// Params:
+//   - name: the service name.
+//   - listener: the failing listener.
+//   - reason: the probe failure reason.
+//   - step: the exec step.
+func (s *Supervisor) runUnhealthyHook(name, listener, reason string, step *domainconfig.UnhealthyStep) {
+	s.mu.RLock()
+	runner := s.hookRunner
+	ctx := s.ctx
+	s.mu.RUnlock()
+
+	// Exec actions need a runner.
+	if runner == nil {
+		s.handleRecoveryError("health-exec", name, ErrNoHookRunner)
+		// Nothing to run with.
+		return
+	}
+	err := runner.Run(ctx, apphook.Command{
+		Path: step.Command,
+		Args: step.Args,
+		Env: []string{
+			"SUPERVIZIO_SERVICE=" + name,
+			"SUPERVIZIO_LISTENER=" + listener,
+			"SUPERVIZIO_REASON=" + reason,
+		},
+		Timeout: step.HookTimeout(),
+	})
+	// Report failed hooks.
+	if err != nil {
+		s.handleRecoveryError("health-exec", name, err)
+	}
+}
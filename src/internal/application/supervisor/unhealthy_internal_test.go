@@ -0,0 +1,185 @@
+// Package supervisor provides internal tests for unhealthy.go.
+// It tests the unhealthy actions of listener probes using white-box testing.
+package supervisor
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// newUnhealthyTestConfig builds a configuration whose listener probe runs steps.
+//
+// Params:
+//   - steps: the escalation chain.
+//
+// Returns:
+//   - *domainconfig.Config: the configuration.
+func newUnhealthyTestConfig(steps ...domainconfig.UnhealthyStep) *domainconfig.Config {
+	return &domainconfig.Config{Services: []domainconfig.ServiceConfig{{
+		Name:    "api",
+		Command: "/bin/api",
+		Listeners: []domainconfig.ListenerConfig{{
+			Name:  "http",
+			Port:  8080,
+			Probe: &domainconfig.ProbeConfig{Type: domainconfig.ProbeTypeTCP, OnUnhealthy: steps},
+		}},
+	}}}
+}
+
+// managerEvent returns the next event emitted by the manager of a service.
+//
+// Params:
+//   - t: the testing context.
+//   - s: the supervisor.
+//
+// Returns:
+//   - domain.EventType: the event type.
+func managerEvent(t *testing.T, s *Supervisor) domain.EventType {
+	t.Helper()
+	select {
+	case event := <-s.managers["api"].Events():
+		return event.Type
+	case <-time.After(time.Second):
+		t.Fatal("no manager event")
+		return 0
+	}
+}
+
+// Test_Supervisor_unhealthyReported tests the action run by each step.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_unhealthyReported(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// steps is the escalation chain.
+		steps []domainconfig.UnhealthyStep
+		// wantEvent is whether the supervisor emits the unhealthy event.
+		wantEvent bool
+		// check verifies the action.
+		check func(t *testing.T, s *Supervisor, executor *countingExecutor, runner *fakeHookRunner)
+	}{
+		{
+			name: "restart_by_default",
+			check: func(t *testing.T, s *Supervisor, _ *countingExecutor, _ *fakeHookRunner) {
+				// The manager reports the failure before stopping the process.
+				assert.Equal(t, domain.EventStarted, managerEvent(t, s))
+				assert.Equal(t, domain.EventUnhealthy, managerEvent(t, s))
+			},
+		},
+		{
+			name:      "notify",
+			steps:     []domainconfig.UnhealthyStep{{Action: domainconfig.UnhealthyActionNotify}},
+			wantEvent: true,
+			check: func(t *testing.T, s *Supervisor, _ *countingExecutor, runner *fakeHookRunner) {
+				assert.True(t, s.managers["api"].State().IsRunning())
+				assert.Empty(t, runner.commands)
+			},
+		},
+		{
+			name:      "signal",
+			steps:     []domainconfig.UnhealthyStep{{Action: domainconfig.UnhealthyActionSignal, Signal: "usr1"}},
+			wantEvent: true,
+			check: func(t *testing.T, _ *Supervisor, executor *countingExecutor, _ *fakeHookRunner) {
+				assert.Equal(t, syscall.SIGUSR1, <-executor.signals)
+			},
+		},
+		{
+			name:      "stop",
+			steps:     []domainconfig.UnhealthyStep{{Action: domainconfig.UnhealthyActionStop}},
+			wantEvent: true,
+			check: func(t *testing.T, s *Supervisor, _ *countingExecutor, _ *fakeHookRunner) {
+				require.Eventually(t, func() bool { return !s.managers["api"].Supervised() }, time.Second, time.Millisecond)
+			},
+		},
+		{
+			name: "exec",
+			steps: []domainconfig.UnhealthyStep{{Action: domainconfig.UnhealthyActionExec,
+				Command: "/usr/local/bin/dump-threads", Args: []string{"api"}}},
+			wantEvent: true,
+			check: func(t *testing.T, s *Supervisor, _ *countingExecutor, runner *fakeHookRunner) {
+				s.wg.Wait()
+				require.Len(t, runner.commands, 1)
+				assert.Equal(t, apphook.Command{
+					Path: "/usr/local/bin/dump-threads",
+					Args: []string{"api"},
+					Env: []string{
+						"SUPERVIZIO_SERVICE=api",
+						"SUPERVIZIO_LISTENER=http",
+						"SUPERVIZIO_REASON=connection refused",
+					},
+					Timeout: domainconfig.DefaultUnhealthyTimeout,
+				}, runner.commands[0])
+			},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			s, executor, _, events := newWatchTestSupervisor(t, newUnhealthyTestConfig(tt.steps...))
+			runner := &fakeHookRunner{}
+			s.SetHookRunner(runner)
+
+			s.unhealthyReported("api", "http", "connection refused")
+
+			// Actions other than restart are reported by the supervisor.
+			if tt.wantEvent {
+				delivered := events()
+				require.Len(t, delivered, 1)
+				assert.Equal(t, domain.EventUnhealthy, delivered[0].Type)
+				assert.ErrorIs(t, delivered[0].Error, domain.ErrHealthProbeFailed)
+			} else {
+				assert.Empty(t, events())
+			}
+			tt.check(t, s, executor, runner)
+		})
+	}
+}
+
+// Test_Supervisor_unhealthyReported_escalation tests that the chain moves to
+// the next step after further reports, and starts over once healthy.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_unhealthyReported_escalation(t *testing.T) {
+	cfg := newUnhealthyTestConfig(
+		domainconfig.UnhealthyStep{Action: domainconfig.UnhealthyActionNotify},
+		domainconfig.UnhealthyStep{Action: domainconfig.UnhealthyActionSignal, After: 2, Signal: "SIGUSR2"},
+	)
+	s, executor, _, events := newWatchTestSupervisor(t, cfg)
+
+	// The first reports only notify.
+	s.unhealthyReported("api", "http", "timeout")
+	s.unhealthyReported("api", "http", "timeout")
+	assert.Len(t, events(), 2)
+	assert.Empty(t, executor.signals)
+
+	// Two reports after the first one, the signal step takes over.
+	s.unhealthyReported("api", "http", "timeout")
+	assert.Equal(t, syscall.SIGUSR2, <-executor.signals)
+	s.unhealthyReported("api", "http", "timeout")
+	assert.Equal(t, syscall.SIGUSR2, <-executor.signals)
+
+	// A healthy probe starts the chain over.
+	s.mu.Lock()
+	s.unhealthyRecovered("api", "http")
+	s.mu.Unlock()
+	s.unhealthyReported("api", "http", "timeout")
+	assert.Empty(t, executor.signals)
+	assert.Len(t, events(), 5)
+
+	// A manual stop forgets the reports.
+	s.forgetUnhealthy("api")
+	assert.Empty(t, s.unhealthyReports)
+}
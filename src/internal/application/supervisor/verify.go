@@ -0,0 +1,51 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file dry-runs service binaries on demand.
+package supervisor
+
+import (
+	"fmt"
+
+	appconfig "github.com/kodflow/daemon/internal/application/config"
+)
+
+// ErrVerifyUnavailable is returned when the pre-flight checker cannot dry-run services.
+var ErrVerifyUnavailable error = fmt.Errorf("service verification not configured")
+
+// VerifyServices runs the pre-flight checks of services against the running
+// configuration and dry-runs their binary under their user and group, with
+// their verify_command or the binary with --version, then --help. Nothing is
+// started or restarted.
+//
+// Params:
+//   - names: the services to verify, all when empty.
+//
+// Returns:
+//   - error: ErrServiceNotFound, ErrVerifyUnavailable, or a
+//     *config.PreflightError listing every failed check.
+func (s *Supervisor) VerifyServices(names ...string) error {
+	s.mu.RLock()
+	cfg := s.config
+	verifier, ok := s.preflighter.(appconfig.Verifier)
+	s.mu.RUnlock()
+
+	// Verification needs a checker able to run commands.
+	if !ok {
+		// Report disabled feature.
+		return ErrVerifyUnavailable
+	}
+	// Reject unknown services before running anything.
+	for _, name := range names {
+		// Check the service is configured.
+		if cfg == nil || cfg.FindService(name) == nil {
+			// Return error for missing service.
+			return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+		}
+	}
+	// Nothing is configured yet.
+	if cfg == nil {
+		// Nothing to verify.
+		return nil
+	}
+	// Run commands without holding the lock.
+	return verifier.Verify(cfg, names)
+}
@@ -0,0 +1,104 @@
+// Package supervisor provides internal tests for verify.go.
+// It tests on-demand service verification using white-box testing.
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// verifyTestChecker records the services it was asked to verify.
+type verifyTestChecker struct {
+	// err is returned by Verify.
+	err error
+	// names records the requested services.
+	names []string
+	// calls counts Verify calls.
+	calls int
+}
+
+// Preflight passes every configuration.
+//
+// Params:
+//   - cfg: the configuration to check (unused).
+//
+// Returns:
+//   - error: always nil.
+func (c *verifyTestChecker) Preflight(_ *domainconfig.Config) error {
+	return nil
+}
+
+// Verify records the requested services and returns the configured error.
+//
+// Params:
+//   - cfg: the configuration holding the services (unused).
+//   - names: the services to verify.
+//
+// Returns:
+//   - error: the configured error.
+func (c *verifyTestChecker) Verify(_ *domainconfig.Config, names []string) error {
+	c.calls++
+	c.names = names
+	return c.err
+}
+
+// TestSupervisor_VerifyServices tests service verification requests.
+//
+// Params:
+//   - t: the testing context.
+func TestSupervisor_VerifyServices(t *testing.T) {
+	report := &domainconfig.PreflightError{Failures: []domainconfig.PreflightFailure{
+		{Service: "api", Check: domainconfig.PreflightCheckVerify, Detail: "exit status 127"},
+	}}
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// checker is the configured checker; nil leaves none.
+		checker *verifyTestChecker
+		// names are the requested services.
+		names []string
+		// wantErr is the expected error.
+		wantErr error
+		// wantCalls is the expected number of Verify calls.
+		wantCalls int
+	}{
+		{name: "all services", checker: &verifyTestChecker{}, wantCalls: 1},
+		{name: "named service", checker: &verifyTestChecker{}, names: []string{"api"}, wantCalls: 1},
+		{name: "failed checks", checker: &verifyTestChecker{err: report}, wantErr: domainconfig.ErrPreflightFailed, wantCalls: 1},
+		{name: "unknown service", checker: &verifyTestChecker{}, names: []string{"ghost"}, wantErr: ErrServiceNotFound},
+		{name: "no checker", wantErr: ErrVerifyUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Supervisor{config: &domainconfig.Config{Services: []domainconfig.ServiceConfig{
+				{Name: "api", Command: "/bin/api"},
+			}}}
+			// Leave the preflighter unset without a checker.
+			if tt.checker != nil {
+				s.preflighter = tt.checker
+			}
+
+			err := s.VerifyServices(tt.names...)
+			// Check the outcome.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			// Check the checker was called with the requested services.
+			if tt.checker != nil && tt.wantCalls > 0 {
+				assert.Equal(t, tt.names, tt.checker.names)
+			}
+			// Check unknown services run nothing.
+			if tt.checker != nil {
+				assert.Equal(t, tt.wantCalls, tt.checker.calls)
+			}
+		})
+	}
+}
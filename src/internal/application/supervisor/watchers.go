@@ -0,0 +1,225 @@
+// Package supervisor provides the application service for orchestrating multiple services.
+// This file runs the watchers emitting custom events.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// watcherOutputLimit bounds the output quoted by output change events.
+const watcherOutputLimit int = 256
+
+// ErrWatcherOutputChanged is the error of output change events.
+var ErrWatcherOutputChanged error = fmt.Errorf("watcher output changed")
+
+// watcherState is the outcome of the previous run of a watcher.
+type watcherState struct {
+	// ran is set once the watcher ran.
+	ran bool
+	// failed is set when the previous run failed.
+	failed bool
+	// output is the standard output of the previous run.
+	output string
+}
+
+// checkWatchers verifies that no watcher emits an event named like a
+// built-in one, which configuration validation cannot know about.
+//
+// Params:
+//   - cfg: the configuration.
+//
+// Returns:
+//   - error: domain.ErrReservedEventName naming the watcher.
+func checkWatchers(cfg *domainconfig.Config) error {
+	// check the events of each watcher
+	for i := range cfg.Watchers {
+		w := &cfg.Watchers[i]
+		// check each event name
+		for _, name := range w.Events() {
+			// built-in names cannot be reused
+			if err := domain.CheckCustomEventName(name); err != nil {
+				// return error naming the watcher
+				return fmt.Errorf("watcher %q: %w", w.Name, err)
+			}
+		}
+	}
+	// every name available
+	return nil
+}
+
+// startWatchers starts the watchers of the configuration.
+// A configuration with watchers but no hook runner is reported through
+// the error handler.
+func (s *Supervisor) startWatchers() {
+	s.mu.Lock()
+	err := s.runWatchers(s.config)
+	s.mu.Unlock()
+	s.handleRecoveryError("watcher", "", err)
+}
+
+// runWatchers replaces the running watchers with those of cfg. Watchers end
+// with the supervisor context. Must be called with s.mu held.
+//
+// Params:
+//   - cfg: the configuration to watch.
+//
+// Returns:
+//   - error: ErrNoHookRunner when cfg has watchers but no runner is set.
+//
+// Goroutine lifecycle:
+//   - Spawns one goroutine per watcher.
+//   - Goroutines exit on the next call or when the supervisor context is cancelled.
+func (s *Supervisor) runWatchers(cfg *domainconfig.Config) error {
+	// stop the watchers of the previous configuration
+	if s.watcherCancel != nil {
+		s.watcherCancel()
+		s.watcherCancel = nil
+	}
+	// Skip when not started or nothing to watch.
+	if s.ctx == nil || len(cfg.Watchers) == 0 {
+		// Nothing to run.
+		return nil
+	}
+	// Watchers need a runner.
+	if s.hookRunner == nil {
+		// Nothing to run with.
+		return ErrNoHookRunner
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.watcherCancel = cancel
+	runner := s.hookRunner
+	// start each watcher
+	for i := range cfg.Watchers {
+		w := cfg.Watchers[i]
+		s.wg.Go(func() {
+			// Run until replaced or shutdown.
+			s.watch(ctx, runner, &w)
+		})
+	}
+	// watchers started
+	return nil
+}
+
+// watch runs a watcher at once, then on each interval until ctx is cancelled.
+//
+// Params:
+//   - ctx: the watcher context.
+//   - runner: the hook runner.
+//   - w: the watcher.
+func (s *Supervisor) watch(ctx context.Context, runner apphook.Runner, w *domainconfig.WatcherConfig) {
+	var state watcherState
+	s.checkWatcher(ctx, runner, w, &state)
+	ticker := time.NewTicker(w.WatcherInterval())
+	defer ticker.Stop()
+	// Loop until context is cancelled.
+	for {
+		select {
+		case <-ctx.Done():
+			// Return when context is cancelled.
+			return
+		case <-ticker.C:
+			s.checkWatcher(ctx, runner, w, &state)
+		}
+	}
+}
+
+// checkWatcher runs the watcher command once and emits the events of its
+// outcome transitions. The command receives the watcher name, and the
+// service it reports for, in its environment.
+//
+// Params:
+//   - ctx: the watcher context.
+//   - runner: the hook runner.
+//   - w: the watcher.
+//   - state: the outcome of the previous run, updated.
+func (s *Supervisor) checkWatcher(ctx context.Context, runner apphook.Runner, w *domainconfig.WatcherConfig, state *watcherState) {
+	env := []string{"SUPERVIZIO_WATCHER=" + w.Name}
+	// name the reported service
+	if w.Service != "" {
+		env = append(env, "SUPERVIZIO_SERVICE="+w.Service)
+	}
+	output, err := runner.Output(ctx, apphook.Command{
+		Path:    w.Command,
+		Args:    w.Args,
+		Env:     env,
+		Timeout: w.WatcherTimeout(),
+	})
+	// A run cut by a reload or shutdown is no outcome.
+	if ctx.Err() != nil {
+		// Keep the previous outcome.
+		return
+	}
+	events := watcherEvents(w, state, output, err)
+	// Skip without transition.
+	if len(events) == 0 {
+		// Nothing to report.
+		return
+	}
+
+	s.mu.RLock()
+	snap := s.getStatsSnapshot(s.stats[w.Service])
+	s.mu.RUnlock()
+	// deliver each event
+	for i := range events {
+		s.callEventHandler(w.Service, &events[i], snap)
+	}
+}
+
+// watcherEvents returns the custom events of a run and records its outcome.
+// The failure event is emitted on the first failing run and when a run fails
+// after a success, the recovery event when a run succeeds after a failure,
+// and the output change event when the output differs from the previous run.
+//
+// Params:
+//   - w: the watcher.
+//   - state: the outcome of the previous run, updated.
+//   - output: the standard output of the run.
+//   - err: the error of the run, nil on success.
+//
+// Returns:
+//   - []domain.Event: the events to emit, in failure, recovery, output change order.
+func watcherEvents(w *domainconfig.WatcherConfig, state *watcherState, output string, err error) []domain.Event {
+	var events []domain.Event
+	failed := err != nil
+	// command started failing
+	if failed && (!state.ran || !state.failed) && w.OnFailure != "" {
+		events = append(events, domain.NewCustomEvent(w.OnFailure, w.Service, err))
+	}
+	// command succeeds again
+	if !failed && state.ran && state.failed && w.OnRecovery != "" {
+		events = append(events, domain.NewCustomEvent(w.OnRecovery, w.Service, nil))
+	}
+	// output differs from the previous run
+	if state.ran && output != state.output && w.OnOutputChange != "" {
+		events = append(events, domain.NewCustomEvent(w.OnOutputChange, w.Service,
+			fmt.Errorf("%w: %s", ErrWatcherOutputChanged, boundOutput(output))))
+	}
+	*state = watcherState{ran: true, failed: failed, output: output}
+	// return events to emit
+	return events
+}
+
+// boundOutput trims the output and cuts it to watcherOutputLimit bytes.
+//
+// Params:
+//   - output: the command output.
+//
+// Returns:
+//   - string: the bounded output.
+func boundOutput(output string) string {
+	output = strings.TrimSpace(output)
+	// cut long outputs
+	if len(output) > watcherOutputLimit {
+		// return the beginning of the output
+		return strings.ToValidUTF8(output[:watcherOutputLimit], "") + "..."
+	}
+	// return whole output
+	return output
+}
@@ -0,0 +1,198 @@
+// Package supervisor provides internal tests for watchers.go.
+// It tests the custom events of watchers using white-box testing.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// watcherRun is the scripted outcome of one watcher run.
+type watcherRun struct {
+	// output is the standard output.
+	output string
+	// err is the run error.
+	err error
+}
+
+// scriptedHookRunner returns scripted outcomes, repeating the last one.
+type scriptedHookRunner struct {
+	fakeHookRunner
+	// runs are the outcomes, in order.
+	runs []watcherRun
+	// next is the index of the next outcome.
+	next int
+}
+
+// Output records the command and returns the next outcome.
+//
+// Params:
+//   - ctx: the context.
+//   - cmd: the command.
+//
+// Returns:
+//   - string: the scripted output.
+//   - error: the scripted error.
+func (r *scriptedHookRunner) Output(ctx context.Context, cmd apphook.Command) (string, error) {
+	_ = r.Run(ctx, cmd)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run := r.runs[min(r.next, len(r.runs)-1)]
+	r.next++
+	return run.output, run.err
+}
+
+// Test_watcherEvents tests the events of watcher outcome transitions.
+//
+// Params:
+//   - t: the testing context.
+func Test_watcherEvents(t *testing.T) {
+	errExit := errors.New("exit status 1")
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// runs are the successive outcomes.
+		runs []watcherRun
+		// want is the expected event names, per run.
+		want [][]string
+	}{
+		{
+			name: "first run succeeding is the baseline",
+			runs: []watcherRun{{output: "ok"}},
+			want: [][]string{nil},
+		},
+		{
+			name: "first run failing",
+			runs: []watcherRun{{err: errExit}, {err: errExit}},
+			want: [][]string{{"raid_degraded"}, nil},
+		},
+		{
+			name: "failure then recovery",
+			runs: []watcherRun{{}, {err: errExit}, {}, {}},
+			want: [][]string{nil, {"raid_degraded"}, {"raid_recovered"}, nil},
+		},
+		{
+			name: "output changes",
+			runs: []watcherRun{{output: "clean"}, {output: "clean"}, {output: "resyncing"}},
+			want: [][]string{nil, nil, {"raid_changed"}},
+		},
+		{
+			name: "failure changing output",
+			runs: []watcherRun{{output: "clean"}, {output: "degraded", err: errExit}},
+			want: [][]string{nil, {"raid_degraded", "raid_changed"}},
+		},
+	}
+
+	w := &domainconfig.WatcherConfig{
+		Name:           "raid",
+		Command:        "/usr/local/bin/check-raid",
+		Service:        "db",
+		OnFailure:      "raid_degraded",
+		OnRecovery:     "raid_recovered",
+		OnOutputChange: "raid_changed",
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state watcherState
+			for i, run := range tt.runs {
+				var names []string
+				for _, event := range watcherEvents(w, &state, run.output, run.err) {
+					assert.Equal(t, domain.EventCustom, event.Type)
+					assert.Equal(t, "db", event.Process)
+					names = append(names, event.Name())
+				}
+				assert.Equal(t, tt.want[i], names, "run %d", i)
+			}
+		})
+	}
+}
+
+// Test_checkWatchers tests that watcher events cannot reuse built-in names.
+//
+// Params:
+//   - t: the testing context.
+func Test_checkWatchers(t *testing.T) {
+	cfg := &domainconfig.Config{Watchers: []domainconfig.WatcherConfig{
+		{Name: "raid", Command: "/bin/check", OnFailure: "raid_degraded"},
+	}}
+	require.NoError(t, checkWatchers(cfg))
+
+	cfg.Watchers[0].OnRecovery = "healthy"
+	assert.ErrorIs(t, checkWatchers(cfg), domain.ErrReservedEventName)
+}
+
+// Test_Supervisor_runWatchers tests that running watchers deliver custom events.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_runWatchers(t *testing.T) {
+	runner := &scriptedHookRunner{runs: []watcherRun{{output: "clean"}, {output: "degraded", err: errors.New("exit status 2")}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var events []domain.Event
+	s := &Supervisor{
+		ctx:        ctx,
+		hookRunner: runner,
+		eventHandler: func(_ string, event *domain.Event, _ *ServiceStatsSnapshot) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, *event)
+		},
+	}
+	cfg := &domainconfig.Config{Watchers: []domainconfig.WatcherConfig{{
+		Name:      "raid",
+		Command:   "/usr/local/bin/check-raid",
+		Interval:  shared.Duration(10 * time.Millisecond),
+		OnFailure: "raid_degraded",
+	}}}
+
+	s.mu.Lock()
+	require.NoError(t, s.runWatchers(cfg))
+	s.mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) > 0
+	}, time.Second, 5*time.Millisecond)
+	s.mu.Lock()
+	s.watcherCancel()
+	s.mu.Unlock()
+	s.wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, events, 1)
+	assert.Equal(t, "raid_degraded", events[0].Name())
+	assert.ErrorContains(t, events[0].Error, "exit status 2")
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	assert.Contains(t, runner.commands[0].Env, "SUPERVIZIO_WATCHER=raid")
+}
+
+// Test_Supervisor_runWatchers_noRunner tests that watchers need a hook runner.
+//
+// Params:
+//   - t: the testing context.
+func Test_Supervisor_runWatchers_noRunner(t *testing.T) {
+	s := &Supervisor{ctx: context.Background()}
+	cfg := &domainconfig.Config{Watchers: []domainconfig.WatcherConfig{{Name: "raid", Command: "/bin/check", OnFailure: "raid_degraded"}}}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.ErrorIs(t, s.runWatchers(cfg), ErrNoHookRunner)
+	assert.NoError(t, s.runWatchers(&domainconfig.Config{}))
+}
@@ -11,15 +11,22 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
 	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
 	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
 	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
 	domainprocess "github.com/kodflow/daemon/internal/domain/process"
 	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
 	"github.com/kodflow/daemon/internal/infrastructure/probe"
+	"github.com/kodflow/daemon/internal/infrastructure/process/mountns"
+	"github.com/kodflow/daemon/internal/infrastructure/process/pidns"
+	"github.com/kodflow/daemon/internal/infrastructure/process/systemd"
 	"github.com/kodflow/daemon/internal/infrastructure/transport/tui"
 )
 
@@ -35,6 +42,8 @@ var (
 	version string = "dev"
 	// configPath is the path to the YAML configuration file.
 	configPath string = ""
+	// lockdown keeps the control plane read-only, as control.read_only does.
+	lockdown bool = false
 	// ErrUnsupportedTUIMode indicates an unknown TUI mode was requested.
 	ErrUnsupportedTUIMode error = errors.New("unsupported TUI mode")
 )
@@ -47,6 +56,15 @@ type AppSupervisor interface {
 	Stop() error
 	Reload() error
 	SetEventHandler(handler appsupervisor.EventHandler)
+	SetBootHandler(handler appsupervisor.BootHandler)
+	SetShutdownHandler(handler appsupervisor.ShutdownHandler)
+	SetProbeTraceHandler(handler appsupervisor.ProbeTraceHandler)
+	SetProbeDefaults(interval, timeout time.Duration)
+	SetLockdown(on bool)
+	OnTransition(from, to appsupervisor.State, hook appsupervisor.StateHook)
+	State() appsupervisor.State
+	Healthy(services []string) bool
+	HostInventory(ctx context.Context) (domainmetrics.HostInventory, error)
 }
 
 // App holds all application dependencies injected by Wire.
@@ -88,17 +106,30 @@ type Runner interface {
 // It parses flags, initializes the application via Wire, and runs the main loop.
 //
 // Returns:
-//   - int: exit code (0 for success, 1 for error).
+//   - int: exit code (0 for success, 78 for configuration errors, 1 for other errors).
 func Run() int {
+	// a re-executed mount or PID namespace helper never returns from here
+	mountns.Init()
+	pidns.Init()
+
 	flag.StringVar(&configPath, "config", "/etc/daemon/config.yaml", "path to configuration file")
 	showVersion := flag.Bool("version", false, "show version and exit")
+	versionJSON := flag.Bool("json", false, "with --version, print the build information as JSON")
 	forceInteractive := flag.Bool("tui", false, "enable interactive TUI mode")
 	probeMode := flag.Bool("probe", false, "collect all system metrics and output as JSON")
+	timeScale := flag.Float64("time-scale", 1, "debug: run backoffs, probes and deadlines this many times faster")
+	flag.BoolVar(&lockdown, "lockdown", false, "refuse mutating API requests, whatever control.read_only says")
 	flag.Parse()
 
 	// print version and exit early if requested
 	if *showVersion {
-		fmt.Printf("supervizio %s\n", version)
+		info := currentBuildInfo()
+		// report an unwritable output
+		if err := writeVersion(os.Stdout, &info, *versionJSON); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			// return error code on write failure
+			return 1
+		}
 		// return success after showing version
 		return 0
 	}
@@ -109,16 +140,89 @@ func Run() int {
 		return runProbeMode()
 	}
 
+	// run snapshot mode if requested
+	if flag.Arg(0) == snapshotCommand {
+		// return exit code from snapshot mode
+		return runSnapshotMode(flag.Args()[1:])
+	}
+
+	// run plan mode if requested
+	if flag.Arg(0) == planCommand {
+		// return exit code from plan mode
+		return runPlanMode(flag.Args()[1:])
+	}
+
+	// run logs mode if requested
+	if flag.Arg(0) == logsCommand {
+		// return exit code from logs mode
+		return runLogsMode(flag.Args()[1:])
+	}
+
+	// run timeline mode if requested
+	if flag.Arg(0) == timelineCommand {
+		// return exit code from timeline mode
+		return runTimelineMode(flag.Args()[1:])
+	}
+
+	// run probes mode if requested
+	if flag.Arg(0) == probesCommand {
+		// return exit code from probes mode
+		return runProbesMode(flag.Args()[1:])
+	}
+
+	// run safe-mode mode if requested
+	if flag.Arg(0) == safeModeCommand {
+		// return exit code from safe-mode mode
+		return runSafeModeMode(flag.Args()[1:])
+	}
+
+	// run host-info mode if requested
+	if flag.Arg(0) == hostInfoCommand {
+		// return exit code from host-info mode
+		return runHostInfoMode(flag.Args()[1:])
+	}
+
+	// run top mode if requested
+	if flag.Arg(0) == topCommand {
+		// return exit code from top mode
+		return runTopMode(flag.Args()[1:])
+	}
+
+	// run tree mode if requested
+	if flag.Arg(0) == treeCommand {
+		// return exit code from tree mode
+		return runTreeMode(flag.Args()[1:])
+	}
+
+	// run deps mode if requested
+	if flag.Arg(0) == depsCommand {
+		// return exit code from deps mode
+		return runDepsMode(flag.Args()[1:])
+	}
+
+	// run ps mode if requested
+	if flag.Arg(0) == psCommand {
+		// return exit code from ps mode
+		return runPsMode(flag.Args()[1:])
+	}
+
+	// fast-forward timers before any component captures the clock
+	if err := applyTimeScale(*timeScale, os.Stderr); err != nil {
+		reportError(os.Stderr, err)
+		// map the error code to an exit code
+		return exitCode(err)
+	}
+
 	tuiMode := determineTUIMode(*forceInteractive)
 
 	// run main application logic with error handling
-	if err := run(configPath, tuiMode); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		// return error code on failure
-		return 1
+	err := run(configPath, tuiMode)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
 	}
-	// return success code on clean exit
-	return 0
+	// map the error code to an exit code
+	return exitCode(err)
 }
 
 // determineTUIMode determines the TUI mode based on flags.
@@ -206,8 +310,13 @@ func run(cfgPath string, tuiMode tui.Mode) error {
 	logger, bufferedConsole := setupLoggingAndEvents(app, logAdapter, tuiMode)
 	defer func() { _ = logger.Close() }()
 
+	applyLockdown(app.Supervisor, lockdown, logger)
+
 	ctx, cancel, sigCh := setupContextAndSignals()
 	defer cancel()
+	bootAborted := setupBootReport(app.Supervisor, logger, cancel, setupSystemd(app.Supervisor, logger))
+	setupShutdownReport(app.Supervisor, app.Config, logger)
+	setupHostShutdown(ctx, app.Supervisor, app.Config, sigCh, logger)
 
 	// start all core services before TUI
 	if err := startSupervisorAndMetrics(ctx, app, logger); err != nil {
@@ -215,6 +324,8 @@ func run(cfgPath string, tuiMode tui.Mode) error {
 		return err
 	}
 
+	startHeartbeats(ctx, app, logger)
+
 	t := setupTUI(app.Supervisor, logAdapter, cfgPath, tuiMode)
 
 	cfg := tuiModeConfig{
@@ -227,7 +338,14 @@ func run(cfgPath string, tuiMode tui.Mode) error {
 		sup:             app.Supervisor,
 	}
 	// delegate to mode-specific execution logic
-	return runTUIMode(cfg)
+	err = runTUIMode(cfg)
+	// report a boot aborted by the failure policy
+	if err == nil && bootAborted.Load() {
+		// exit with the boot failure code
+		return appsupervisor.ErrBootFailed
+	}
+	// return shutdown result
+	return err
 }
 
 // initializeAppAndLogAdapter initializes the app and log adapter.
@@ -280,9 +398,30 @@ func setupLoggingAndEvents(app *App, logAdapter *tui.LogAdapter, tuiMode tui.Mod
 
 	attachTUIWriter(logger, logAdapter)
 
+	notifier, closeNotifications := setupNotifications(app.Config, logger)
 	app.Supervisor.SetEventHandler(func(serviceName string, event *domainprocess.Event, stats *appsupervisor.ServiceStatsSnapshot) {
 		logEvent := convertProcessEventToLogEvent(serviceName, event, stats)
 		logger.Log(logEvent)
+		// forward to notification channels when configured
+		if notifier != nil {
+			notifier.Notify(serviceName, event)
+		}
+	})
+	// flush pending digests once every service is stopped
+	if notifier != nil {
+		app.Supervisor.OnTransition(appsupervisor.StateStopping, appsupervisor.StateStopped, func(_, _ appsupervisor.State) {
+			closeNotifications()
+		})
+	}
+
+	loadParameters(app.Supervisor, logger, notifier)
+
+	app.Supervisor.OnTransition(appsupervisor.StateAny, appsupervisor.StateAny, func(from, to appsupervisor.State) {
+		logger.Info("", "supervisor_state", "Supervisor "+to.String(), map[string]any{"from": from.String(), "to": to.String()})
+	})
+
+	app.Supervisor.SetProbeTraceHandler(func(serviceName string, attempt domainhealth.ProbeAttempt) {
+		logProbeAttempt(logger, serviceName, &attempt)
 	})
 
 	// return configured logging infrastructure
@@ -315,13 +454,15 @@ func setupContextAndSignals() (context.Context, context.CancelFunc, chan os.Sign
 // Returns:
 //   - error: startup error if any.
 func startSupervisorAndMetrics(ctx context.Context, app *App, logger domainlogging.Logger) error {
-	logger.Info("", "daemon_started", "Supervisor started", map[string]any{"version": version})
+	info := currentBuildInfo()
+	logger.Info("", "daemon_started", "Supervisor started", buildInfoMeta(&info))
 
 	// start supervisor before metrics tracking
 	if err := app.Supervisor.Start(ctx); err != nil {
 		// propagate supervisor start error with context
 		return fmt.Errorf("failed to start supervisor: %w", err)
 	}
+	logHostFeatures(ctx, app.Supervisor, app.Config, logger)
 
 	// start metrics tracker if configured
 	if app.MetricsTracker != nil {
@@ -346,17 +487,18 @@ func initializeLogger(cfg *domainconfig.Config, tuiMode tui.Mode) (domainlogging
 	var logger domainlogging.Logger
 	var bufferedConsole *daemonlogger.BufferedWriter
 	var loggerErr error
+	daemonLogging := defaultDaemonLogging(cfg.Logging.Daemon, os.Getpid() == 1, systemd.JournalStream())
 
 	// select logger type based on TUI mode requirements
 	if tuiMode == tui.ModeInteractive {
 		logger, loggerErr = daemonlogger.BuildLoggerWithoutConsole(
-			cfg.Logging.Daemon,
+			daemonLogging,
 			cfg.Logging.BaseDir,
 		)
 		// use buffered console for raw mode
 	} else {
 		logger, bufferedConsole, loggerErr = daemonlogger.BuildLoggerWithBufferedConsole(
-			cfg.Logging.Daemon,
+			daemonLogging,
 			cfg.Logging.BaseDir,
 		)
 	}
@@ -376,6 +518,41 @@ func initializeLogger(cfg *domainconfig.Config, tuiMode tui.Mode) (domainlogging
 	return logger, bufferedConsole, loggerErr
 }
 
+// defaultDaemonLogging fills in the daemon writers when none are configured.
+// As container init (PID 1), humans read docker logs directly, so the console
+// renders the compact human format; under systemd, events go to the journal
+// with their fields; elsewhere the writers are left for the logger factory
+// default.
+//
+// Params:
+//   - daemon: the configured daemon logging.
+//   - pid1: whether the daemon runs as PID 1.
+//   - journal: whether standard error is connected to the journal.
+//
+// Returns:
+//   - domainconfig.DaemonLogging: the daemon logging to build the logger from.
+func defaultDaemonLogging(daemon domainconfig.DaemonLogging, pid1, journal bool) domainconfig.DaemonLogging {
+	// select the default writers
+	switch {
+	// keep configured writers
+	case len(daemon.Writers) > 0:
+		// return configuration unchanged
+		return daemon
+	// container init
+	case pid1:
+		// return the container console
+		return domainconfig.ContainerDaemonLogging()
+	// started by systemd
+	case journal:
+		// return the journal
+		return domainconfig.JournalDaemonLogging()
+	// leave the factory default
+	default:
+		// return configuration unchanged
+		return daemon
+	}
+}
+
 // attachTUIWriter adds TUI writer to logger if it's a MultiLogger.
 //
 // Params:
@@ -568,7 +745,7 @@ func WaitForSignals(ctx context.Context, cancel context.CancelFunc, sigCh <-chan
 //   - domainlogging.LogEvent: the converted log event.
 func convertProcessEventToLogEvent(serviceName string, event *domainprocess.Event, stats *appsupervisor.ServiceStatsSnapshot) domainlogging.LogEvent {
 	level := determineLogLevel(event.Type)
-	eventType := event.Type.String()
+	eventType := event.Name()
 	message := buildEventMessage(event, stats)
 	logEvent := domainlogging.NewLogEvent(level, serviceName, eventType, message)
 	// return event enriched with metadata
@@ -586,16 +763,28 @@ func determineLogLevel(eventType domainprocess.EventType) domainlogging.Level {
 	// map event types to severity levels
 	switch eventType {
 	// warn level for recoverable failures
-	case domainprocess.EventFailed, domainprocess.EventUnhealthy:
+	case domainprocess.EventFailed, domainprocess.EventUnhealthy, domainprocess.EventThrottled,
+		domainprocess.EventPressureAlert, domainprocess.EventListenerConflict, domainprocess.EventStartTimeout,
+		domainprocess.EventDependencyDown, domainprocess.EventIncident, domainprocess.EventFileChanged,
+		domainprocess.EventReloadFailed, domainprocess.EventSLOBurn, domainprocess.EventOvercommitted,
+		domainprocess.EventShed, domainprocess.EventResourceLeaked, domainprocess.EventRuntimeWarning,
+		domainprocess.EventRuntimeExceeded, domainprocess.EventRestartDeferred, domainprocess.EventCertificateExpiring,
+		domainprocess.EventCustom, domainprocess.EventQuiesceFailed:
 		// return warning for recoverable failures
 		return domainlogging.LevelWarn
-	// error level for permanent failures
-	case domainprocess.EventExhausted:
+	// error level for permanent failures and restart storms
+	case domainprocess.EventExhausted, domainprocess.EventSafeModeEntered:
 		// return error for permanent failures
 		return domainlogging.LevelError
 	// info level for normal lifecycle events
 	case domainprocess.EventStarted, domainprocess.EventStopped,
-		domainprocess.EventRestarting, domainprocess.EventHealthy:
+		domainprocess.EventRestarting, domainprocess.EventHealthy, domainprocess.EventUnthrottled,
+		domainprocess.EventPressureCleared, domainprocess.EventListenerConflictCleared, domainprocess.EventDependencyUp,
+		domainprocess.EventReloaded, domainprocess.EventSLOBurnCleared, domainprocess.EventShedResumed,
+		domainprocess.EventCertificateChanged, domainprocess.EventClockJump, domainprocess.EventSkipped,
+		domainprocess.EventSecretChanged, domainprocess.EventProbesPaused, domainprocess.EventProbesResumed,
+		domainprocess.EventQuiesced, domainprocess.EventUnquiesced, domainprocess.EventSafeModeExited,
+		domainprocess.EventConfigReloaded:
 		// return info for normal lifecycle events
 		return domainlogging.LevelInfo
 	// safe default for unknown events
@@ -644,7 +833,151 @@ func buildEventMessage(event *domainprocess.Event, stats *appsupervisor.ServiceS
 	case domainprocess.EventExhausted:
 		// return message with total restart count
 		return buildExhaustedMessage(stats)
-	// unknown or custom event
+	// service exceeded cgroup CPU throttling threshold
+	case domainprocess.EventThrottled:
+		// return throttling degradation message
+		return "Service CPU throttled by cgroup limit"
+	// service fell back below cgroup CPU throttling threshold
+	case domainprocess.EventUnthrottled:
+		// return throttling recovery message
+		return "Service no longer CPU throttled"
+	// service cgroup PSI exceeded a pressure alert rule
+	case domainprocess.EventPressureAlert:
+		// return resource saturation message
+		return "Service resource pressure above alert threshold"
+	// service cgroup PSI fell back below a pressure alert rule
+	case domainprocess.EventPressureCleared:
+		// return resource saturation recovery message
+		return "Service resource pressure back below alert threshold"
+	// listener port bound by a process outside the service
+	case domainprocess.EventListenerConflict:
+		// return port conflict message
+		return "Service listener port bound by another process"
+	// listener port released by the foreign process
+	case domainprocess.EventListenerConflictCleared:
+		// return port conflict recovery message
+		return "Service listener port conflict cleared"
+	// service did not become ready within its start timeout
+	case domainprocess.EventStartTimeout:
+		// return hung startup message
+		return "Service not ready within start timeout, killed"
+	// external dependency stopped answering its probe
+	case domainprocess.EventDependencyDown:
+		// return dependency outage message
+		return "Service external dependency down"
+	// external dependency answers its probe again
+	case domainprocess.EventDependencyUp:
+		// return dependency recovery message
+		return "Service external dependency recovered"
+	// close failures of several services correlated together
+	case domainprocess.EventIncident:
+		// return incident message
+		return "Correlated failure of several services"
+	// service binary or watched file modified on disk
+	case domainprocess.EventFileChanged:
+		// return file change message
+		return "Service file changed on disk"
+	// service reloaded in place and passes its probes
+	case domainprocess.EventReloaded:
+		// return reload message
+		return "Service reloaded"
+	// in-place reload not triggered or not verified
+	case domainprocess.EventReloadFailed:
+		// return reload failure message
+		return "Service reload failed"
+	// error budget burning too fast
+	case domainprocess.EventSLOBurn:
+		// return slo burn message
+		return "Service error budget burning too fast"
+	// error budget burn back to normal
+	case domainprocess.EventSLOBurnCleared:
+		// return slo burn recovery message
+		return "Service error budget burn back to normal"
+	// start reserving more than the admitted host capacity
+	case domainprocess.EventOvercommitted:
+		// return overcommit message
+		return "Service reservation exceeds admitted host capacity"
+	// stopped to relieve memory pressure
+	case domainprocess.EventShed:
+		// return shed message
+		return "Service stopped to relieve memory pressure"
+	// started again once pressure subsided
+	case domainprocess.EventShedResumed:
+		// return shed resume message
+		return "Service resumed after memory pressure subsided"
+	// executor resources outlived the service process
+	case domainprocess.EventResourceLeaked:
+		// return resource leak message
+		return "Resources outlived their service process"
+	// process nears its max runtime
+	case domainprocess.EventRuntimeWarning:
+		// return runtime warning message
+		return "Service approaching its max runtime"
+	// process stopped at its max runtime
+	case domainprocess.EventRuntimeExceeded:
+		// return runtime exceeded message
+		return "Service stopped after reaching its max runtime"
+	// restart waits for the daemon restart budget
+	case domainprocess.EventRestartDeferred:
+		// return restart deferred message
+		return "Service restart deferred by the restart budget"
+	// listener certificate nears its expiry
+	case domainprocess.EventCertificateExpiring:
+		// return certificate expiring message
+		return "Service certificate expiring soon"
+	// listener certificate replaced
+	case domainprocess.EventCertificateChanged:
+		// return certificate changed message
+		return "Service certificate changed"
+	// wall clock jumped
+	case domainprocess.EventClockJump:
+		// return clock jump message
+		return "Wall clock jumped"
+	// user-defined event of a watcher
+	case domainprocess.EventCustom:
+		// return message naming the event
+		return "Watcher reported " + event.Custom
+	// start conditions unmet
+	case domainprocess.EventSkipped:
+		// return skip message
+		return "Service skipped, start conditions unmet"
+	// secret file rotated
+	case domainprocess.EventSecretChanged:
+		// return secret change message
+		return "Service secret changed"
+	// probes paused through the API
+	case domainprocess.EventProbesPaused:
+		// return probe pause message
+		return "Service probes paused"
+	// paused probes run again
+	case domainprocess.EventProbesResumed:
+		// return probe resume message
+		return "Service probes resumed"
+	// quiesced for a job
+	case domainprocess.EventQuiesced:
+		// return quiesce message
+		return "Service quiesced for job"
+	// job done
+	case domainprocess.EventUnquiesced:
+		// return unquiesce message
+		return "Service resumed after job"
+	// quiesce or health verification failed
+	case domainprocess.EventQuiesceFailed:
+		// return quiesce failure message
+		return "Service quiesce failed"
+	// restart storm or request
+	case domainprocess.EventSafeModeEntered:
+		// return safe mode message
+		return "Safe mode entered, non-critical restarts held"
+	// held restarts released
+	case domainprocess.EventSafeModeExited:
+		// return safe mode exit message
+		return "Safe mode exited, held restarts released"
+	// configuration file applied again
+	case domainprocess.EventConfigReloaded:
+		// return config reload message
+		return "Configuration reloaded"
+	// unknown event
 	default:
 		// return generic message for unknown events
 		return "Service event"
@@ -757,6 +1090,8 @@ func addEventMetadata(logEvent WithMetaer, event *domainprocess.Event, stats *ap
 	result = addPIDMetadata(result, event)
 	result = addExitMetadata(result, event)
 	result = addRestartMetadata(result, event, stats)
+	result = addIncidentMetadata(result, event)
+	result = addLabelMetadata(result, event)
 
 	// return fully enriched event
 	return result
@@ -796,6 +1131,11 @@ func addExitMetadata(result WithMetaer, event *domainprocess.Event) domainloggin
 		enriched = enriched.WithMeta("exit_code", event.ExitCode)
 	}
 
+	// add terminating signal if any
+	if event.Signal != 0 {
+		enriched = enriched.WithMeta("signal", event.Signal)
+	}
+
 	// add error message if available
 	if event.Error != nil {
 		enriched = enriched.WithMeta("error", event.Error.Error())
@@ -826,6 +1166,51 @@ func addRestartMetadata(result WithMetaer, event *domainprocess.Event, stats *ap
 	return logEvent
 }
 
+// addIncidentMetadata adds the correlated incident to log event.
+//
+// Params:
+//   - result: the log event to enrich (uses WithMetaer interface).
+//   - event: the process event.
+//
+// Returns:
+//   - domainlogging.LogEvent: the enriched log event.
+func addIncidentMetadata(result WithMetaer, event *domainprocess.Event) domainlogging.LogEvent {
+	enriched := result
+	// add incident for correlated failures
+	if event.Incident != nil {
+		enriched = enriched.WithMeta("incident", event.Incident.ID)
+		// describe the incident on its own event
+		if event.Type == domainprocess.EventIncident {
+			enriched = enriched.WithMeta("cause", string(event.Incident.Cause))
+			enriched = enriched.WithMeta("services", strings.Join(event.Incident.Services, ","))
+		}
+	}
+
+	logEvent, _ := enriched.(domainlogging.LogEvent)
+	// return event with incident metadata
+	return logEvent
+}
+
+// addLabelMetadata adds the service labels to log event.
+//
+// Params:
+//   - result: the log event to enrich (uses WithMetaer interface).
+//   - event: the process event.
+//
+// Returns:
+//   - domainlogging.LogEvent: the enriched log event.
+func addLabelMetadata(result WithMetaer, event *domainprocess.Event) domainlogging.LogEvent {
+	enriched := result
+	// add labels of labeled services
+	if len(event.Labels) > 0 {
+		enriched = enriched.WithMeta("labels", event.Labels)
+	}
+
+	logEvent, _ := enriched.(domainlogging.LogEvent)
+	// return event with label metadata
+	return logEvent
+}
+
 // findLogFilePath finds the first file writer's path from the config.
 // Returns the absolute path to the log file, or empty string if not configured.
 //
@@ -6,6 +6,7 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync/atomic"
 	"syscall"
@@ -15,6 +16,7 @@ import (
 	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
 	domainconfig "github.com/kodflow/daemon/internal/domain/config"
 	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
 	domainprocess "github.com/kodflow/daemon/internal/domain/process"
 	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
 	"github.com/kodflow/daemon/internal/infrastructure/transport/tui"
@@ -680,6 +682,31 @@ func Test_determineLogLevel(t *testing.T) {
 			eventType: domainprocess.EventHealthy,
 			wantLevel: domainlogging.LevelInfo,
 		},
+		{
+			name:      "pressure_alert_is_warn",
+			eventType: domainprocess.EventPressureAlert,
+			wantLevel: domainlogging.LevelWarn,
+		},
+		{
+			name:      "pressure_cleared_is_info",
+			eventType: domainprocess.EventPressureCleared,
+			wantLevel: domainlogging.LevelInfo,
+		},
+		{
+			name:      "listener_conflict_is_warn",
+			eventType: domainprocess.EventListenerConflict,
+			wantLevel: domainlogging.LevelWarn,
+		},
+		{
+			name:      "listener_conflict_cleared_is_info",
+			eventType: domainprocess.EventListenerConflictCleared,
+			wantLevel: domainlogging.LevelInfo,
+		},
+		{
+			name:      "incident_is_warn",
+			eventType: domainprocess.EventIncident,
+			wantLevel: domainlogging.LevelWarn,
+		},
 	}
 
 	// Run all test cases.
@@ -944,6 +971,30 @@ func Test_buildEventMessage(t *testing.T) {
 			stats:        nil,
 			wantContains: "unhealthy",
 		},
+		{
+			name:         "pressure_alert_event",
+			eventType:    domainprocess.EventPressureAlert,
+			stats:        nil,
+			wantContains: "pressure above",
+		},
+		{
+			name:         "pressure_cleared_event",
+			eventType:    domainprocess.EventPressureCleared,
+			stats:        nil,
+			wantContains: "pressure back below",
+		},
+		{
+			name:         "listener_conflict_event",
+			eventType:    domainprocess.EventListenerConflict,
+			stats:        nil,
+			wantContains: "bound by another process",
+		},
+		{
+			name:         "listener_conflict_cleared_event",
+			eventType:    domainprocess.EventListenerConflictCleared,
+			stats:        nil,
+			wantContains: "conflict cleared",
+		},
 	}
 
 	// Run all test cases.
@@ -1350,6 +1401,43 @@ func Test_initializeLogger(t *testing.T) {
 	}
 }
 
+// Test_defaultDaemonLogging tests the container default of daemon writers.
+//
+// Params:
+//   - t: the testing context.
+func Test_defaultDaemonLogging(t *testing.T) {
+	t.Parallel()
+
+	configured := domainconfig.DaemonLogging{Writers: []domainconfig.WriterConfig{{Type: "json"}}}
+
+	tests := []struct {
+		name     string
+		daemon   domainconfig.DaemonLogging
+		pid1     bool
+		journal  bool
+		expected domainconfig.DaemonLogging
+	}{
+		{name: "container without writers", pid1: true, expected: domainconfig.ContainerDaemonLogging()},
+		{name: "container with writers", daemon: configured, pid1: true, expected: configured},
+		{name: "host without writers", pid1: false, expected: domainconfig.DaemonLogging{}},
+		{name: "journal without writers", journal: true, expected: domainconfig.JournalDaemonLogging()},
+		{name: "journal with writers", daemon: configured, journal: true, expected: configured},
+	}
+
+	// run all test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := defaultDaemonLogging(tt.daemon, tt.pid1, tt.journal)
+			// compare the selected writers
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("defaultDaemonLogging() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
 // Test_attachTUIWriter verifies TUI writer attachment.
 //
 // Params:
@@ -1511,6 +1599,16 @@ func Test_setupLoggingAndEvents(t *testing.T) {
 				t.Error("setupLoggingAndEvents() returned nil logger")
 			}
 
+			// Verify state changes are observed.
+			if mockSup.stateHook == nil {
+				t.Error("setupLoggingAndEvents() did not register a state hook")
+			}
+
+			// Verify traced probe attempts are logged.
+			if mockSup.probeTraceHandler == nil {
+				t.Error("setupLoggingAndEvents() did not register a probe trace handler")
+			}
+
 			// Close logger.
 			_ = logger.Close()
 			_ = buffered
@@ -1520,7 +1618,12 @@ func Test_setupLoggingAndEvents(t *testing.T) {
 
 // mockAppSupervisor is a test double for AppSupervisor interface.
 type mockAppSupervisor struct {
-	eventHandler appsupervisor.EventHandler
+	eventHandler      appsupervisor.EventHandler
+	bootHandler       appsupervisor.BootHandler
+	shutdownHandler   appsupervisor.ShutdownHandler
+	stateHook         appsupervisor.StateHook
+	probeTraceHandler appsupervisor.ProbeTraceHandler
+	lockdown          bool
 }
 
 // Start does nothing.
@@ -1561,6 +1664,88 @@ func (m *mockAppSupervisor) SetEventHandler(handler appsupervisor.EventHandler)
 	m.eventHandler = handler
 }
 
+// SetBootHandler stores the boot handler.
+//
+// Params:
+//   - handler: the boot handler.
+func (m *mockAppSupervisor) SetBootHandler(handler appsupervisor.BootHandler) {
+	m.bootHandler = handler
+}
+
+// SetShutdownHandler stores the shutdown handler.
+//
+// Params:
+//   - handler: the shutdown handler.
+func (m *mockAppSupervisor) SetShutdownHandler(handler appsupervisor.ShutdownHandler) {
+	m.shutdownHandler = handler
+}
+
+// SetProbeTraceHandler stores the probe trace handler.
+//
+// Params:
+//   - handler: the probe trace handler.
+func (m *mockAppSupervisor) SetProbeTraceHandler(handler appsupervisor.ProbeTraceHandler) {
+	m.probeTraceHandler = handler
+}
+
+// SetProbeDefaults does nothing.
+//
+// Params:
+//   - interval: the default probe interval (unused).
+//   - timeout: the default probe timeout (unused).
+func (m *mockAppSupervisor) SetProbeDefaults(_, _ time.Duration) {
+	// Do nothing.
+}
+
+// SetLockdown stores the lockdown.
+//
+// Params:
+//   - on: the lockdown.
+func (m *mockAppSupervisor) SetLockdown(on bool) {
+	m.lockdown = on
+}
+
+// OnTransition stores the state hook.
+//
+// Params:
+//   - from: the source state (unused).
+//   - to: the target state (unused).
+//   - hook: the state hook.
+func (m *mockAppSupervisor) OnTransition(_, _ appsupervisor.State, hook appsupervisor.StateHook) {
+	m.stateHook = hook
+}
+
+// State reports a running supervisor.
+//
+// Returns:
+//   - appsupervisor.State: always StateRunning.
+func (m *mockAppSupervisor) State() appsupervisor.State {
+	return appsupervisor.StateRunning
+}
+
+// Healthy reports a healthy daemon.
+//
+// Params:
+//   - services: the checked services (unused).
+//
+// Returns:
+//   - bool: always true.
+func (m *mockAppSupervisor) Healthy(_ []string) bool {
+	return true
+}
+
+// HostInventory reports a Linux host.
+//
+// Params:
+//   - ctx: the context (unused).
+//
+// Returns:
+//   - domainmetrics.HostInventory: the inventory.
+//   - error: the inventory error.
+func (m *mockAppSupervisor) HostInventory(_ context.Context) (domainmetrics.HostInventory, error) {
+	return domainmetrics.HostInventory{OS: "linux"}, nil
+}
+
 // Test_startSupervisorAndMetrics verifies supervisor and metrics startup.
 //
 // Params:
@@ -1653,6 +1838,88 @@ func (m *mockAppSupervisorWithErr) SetEventHandler(_ appsupervisor.EventHandler)
 	// Do nothing.
 }
 
+// SetBootHandler does nothing.
+//
+// Params:
+//   - handler: the boot handler (unused).
+func (m *mockAppSupervisorWithErr) SetBootHandler(_ appsupervisor.BootHandler) {
+	// Do nothing.
+}
+
+// SetShutdownHandler does nothing.
+//
+// Params:
+//   - handler: the shutdown handler (unused).
+func (m *mockAppSupervisorWithErr) SetShutdownHandler(_ appsupervisor.ShutdownHandler) {
+	// Do nothing.
+}
+
+// SetProbeTraceHandler does nothing.
+//
+// Params:
+//   - handler: the probe trace handler (unused).
+func (m *mockAppSupervisorWithErr) SetProbeTraceHandler(_ appsupervisor.ProbeTraceHandler) {
+	// Do nothing.
+}
+
+// SetProbeDefaults does nothing.
+//
+// Params:
+//   - interval: the default probe interval (unused).
+//   - timeout: the default probe timeout (unused).
+func (m *mockAppSupervisorWithErr) SetProbeDefaults(_, _ time.Duration) {
+	// Do nothing.
+}
+
+// SetLockdown does nothing.
+//
+// Params:
+//   - on: the lockdown (unused).
+func (m *mockAppSupervisorWithErr) SetLockdown(_ bool) {
+	// Do nothing.
+}
+
+// OnTransition does nothing.
+//
+// Params:
+//   - from: the source state (unused).
+//   - to: the target state (unused).
+//   - hook: the state hook (unused).
+func (m *mockAppSupervisorWithErr) OnTransition(_, _ appsupervisor.State, _ appsupervisor.StateHook) {
+	// Do nothing.
+}
+
+// State reports a stopped supervisor.
+//
+// Returns:
+//   - appsupervisor.State: always StateStopped.
+func (m *mockAppSupervisorWithErr) State() appsupervisor.State {
+	return appsupervisor.StateStopped
+}
+
+// Healthy reports an unhealthy daemon.
+//
+// Params:
+//   - services: the checked services (unused).
+//
+// Returns:
+//   - bool: always false.
+func (m *mockAppSupervisorWithErr) Healthy(_ []string) bool {
+	return false
+}
+
+// HostInventory reports an unavailable inventory.
+//
+// Params:
+//   - ctx: the context (unused).
+//
+// Returns:
+//   - domainmetrics.HostInventory: the inventory.
+//   - error: the inventory error.
+func (m *mockAppSupervisorWithErr) HostInventory(_ context.Context) (domainmetrics.HostInventory, error) {
+	return domainmetrics.HostInventory{}, appsupervisor.ErrHostInventoryUnavailable
+}
+
 // Test_addPIDMetadata verifies PID metadata enrichment.
 //
 // Params:
@@ -1808,6 +2075,105 @@ func Test_addExitMetadata(t *testing.T) {
 	}
 }
 
+// Test_addIncidentMetadata verifies correlated incident metadata enrichment.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_addIncidentMetadata(t *testing.T) {
+	t.Parallel()
+
+	incident := &domainprocess.Incident{
+		ID:       3,
+		Cause:    domainprocess.IncidentSharedDependency,
+		Detail:   "db:5432",
+		Services: []string{"api", "worker"},
+	}
+	tests := []struct {
+		name         string
+		event        *domainprocess.Event
+		wantIncident any
+		wantCause    any
+		wantServices any
+	}{
+		{
+			name:         "incident_event_describes_incident",
+			event:        &domainprocess.Event{Type: domainprocess.EventIncident, Incident: incident},
+			wantIncident: uint64(3),
+			wantCause:    "shared_dependency",
+			wantServices: "api,worker",
+		},
+		{
+			name:         "joined_failure_names_incident",
+			event:        &domainprocess.Event{Type: domainprocess.EventFailed, Incident: incident},
+			wantIncident: uint64(3),
+		},
+		{
+			name:  "uncorrelated_event_unchanged",
+			event: &domainprocess.Event{Type: domainprocess.EventFailed},
+		},
+	}
+
+	// Run all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logEvent := domainlogging.NewLogEvent(domainlogging.LevelInfo, "test", "test_event", "test message")
+			result := addIncidentMetadata(logEvent, tt.event)
+
+			// Verify incident metadata.
+			for key, want := range map[string]any{"incident": tt.wantIncident, "cause": tt.wantCause, "services": tt.wantServices} {
+				if got := result.Metadata[key]; got != want {
+					t.Errorf("addIncidentMetadata() %s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+// Test_addLabelMetadata verifies service label metadata enrichment.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_addLabelMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		event      *domainprocess.Event
+		wantLabels bool
+	}{
+		{
+			name:       "labeled_service",
+			event:      &domainprocess.Event{Type: domainprocess.EventFailed, Labels: map[string]string{"team": "payments"}},
+			wantLabels: true,
+		},
+		{
+			name:  "unlabeled_service_unchanged",
+			event: &domainprocess.Event{Type: domainprocess.EventFailed},
+		},
+	}
+
+	// Run all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logEvent := domainlogging.NewLogEvent(domainlogging.LevelInfo, "test", "test_event", "test message")
+			result := addLabelMetadata(logEvent, tt.event)
+
+			// Verify label metadata.
+			labels, ok := result.Metadata["labels"].(map[string]string)
+			if ok != tt.wantLabels {
+				t.Fatalf("addLabelMetadata() labels present = %v, want %v", ok, tt.wantLabels)
+			}
+			if ok && labels["team"] != "payments" {
+				t.Errorf("addLabelMetadata() team = %q, want %q", labels["team"], "payments")
+			}
+		})
+	}
+}
+
 // Test_addRestartMetadata verifies restart count metadata enrichment.
 //
 // Params:
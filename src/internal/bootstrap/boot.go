@@ -0,0 +1,86 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// setupBootReport logs the boot report once the initial startup is over
+// and applies the boot failure policy.
+//
+// Params:
+//   - sup: the supervisor reporting the boot.
+//   - logger: the daemon logger.
+//   - cancel: the cancel function stopping the daemon.
+//   - notify: also receives the report when set, to tell systemd.
+//
+// Returns:
+//   - *atomic.Bool: set when the boot was aborted by the failure policy.
+func setupBootReport(sup AppSupervisor, logger domainlogging.Logger, cancel context.CancelFunc, notify func(*domainlifecycle.BootReport)) *atomic.Bool {
+	aborted := &atomic.Bool{}
+	sup.SetBootHandler(func(report domainlifecycle.BootReport) {
+		logBootReport(logger, &report)
+		// tell the service manager
+		if notify != nil {
+			notify(&report)
+		}
+		// Shut down when a critical service failed under the shutdown policy.
+		if report.Aborted {
+			aborted.Store(true)
+			cancel()
+		}
+	})
+	// return abort flag checked after shutdown
+	return aborted
+}
+
+// logBootReport logs the boot summary and the outcome of each service.
+//
+// Params:
+//   - logger: the daemon logger.
+//   - report: the completed boot report.
+func logBootReport(logger domainlogging.Logger, report *domainlifecycle.BootReport) {
+	// log each service outcome
+	for _, svc := range report.Services {
+		meta := map[string]any{
+			"status":      svc.Status.String(),
+			"critical":    svc.Critical,
+			"duration_ms": svc.Duration.Milliseconds(),
+		}
+		// failed services carry their reason
+		if svc.Status == domainlifecycle.BootFailed {
+			meta["error"] = svc.Error
+			logger.Warn(svc.Name, "boot_service", "Service failed at boot", meta)
+			continue
+		}
+		// skipped services carry their unmet conditions
+		if svc.Status == domainlifecycle.BootSkipped {
+			meta["reason"] = svc.Error
+		}
+		logger.Info(svc.Name, "boot_service", "Service boot "+svc.Status.String(), meta)
+	}
+
+	failed := len(report.Failed())
+	meta := map[string]any{
+		"services":    len(report.Services),
+		"failed":      failed,
+		"duration_ms": report.CompletedAt.Sub(report.StartedAt).Milliseconds(),
+	}
+	// summary level follows the outcome
+	switch {
+	// policy requested a shutdown
+	case report.Aborted:
+		logger.Error("", "boot_aborted", "Critical service failed at boot, shutting down", meta)
+	// some services failed
+	case failed > 0:
+		logger.Warn("", "boot_completed", fmt.Sprintf("Boot completed with %d failed service(s)", failed), meta)
+	// every service is ready
+	default:
+		logger.Info("", "boot_completed", "Boot completed", meta)
+	}
+}
@@ -0,0 +1,111 @@
+// Package bootstrap provides internal tests for boot.go.
+package bootstrap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+)
+
+// recordingWriter keeps every written log event.
+type recordingWriter struct {
+	events []domainlogging.LogEvent
+}
+
+// Write records the event.
+//
+// Params:
+//   - event: the log event.
+//
+// Returns:
+//   - error: nil.
+func (w *recordingWriter) Write(event domainlogging.LogEvent) error {
+	w.events = append(w.events, event)
+	// Return nil.
+	return nil
+}
+
+// Close does nothing.
+//
+// Returns:
+//   - error: nil.
+func (w *recordingWriter) Close() error {
+	// Return nil.
+	return nil
+}
+
+// Test_setupBootReport verifies boot report logging and the failure policy.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_setupBootReport(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	tests := []struct {
+		name        string
+		report      domainlifecycle.BootReport
+		wantSummary string
+		wantLevel   domainlogging.Level
+		wantAborted bool
+	}{
+		{
+			name: "all ready",
+			report: domainlifecycle.BootReport{StartedAt: started, CompletedAt: started.Add(time.Second), Services: []domainlifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: domainlifecycle.BootReady},
+			}},
+			wantSummary: "boot_completed",
+			wantLevel:   domainlogging.LevelInfo,
+		},
+		{
+			name: "non-critical failure",
+			report: domainlifecycle.BootReport{StartedAt: started, CompletedAt: started.Add(time.Second), Services: []domainlifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: domainlifecycle.BootReady},
+				{Name: "cron", Status: domainlifecycle.BootFailed, Error: "exited with code 1"},
+			}},
+			wantSummary: "boot_completed",
+			wantLevel:   domainlogging.LevelWarn,
+		},
+		{
+			name: "aborted",
+			report: domainlifecycle.BootReport{StartedAt: started, CompletedAt: started.Add(time.Second), Aborted: true, Services: []domainlifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: domainlifecycle.BootFailed, Error: "exited with code 1"},
+			}},
+			wantSummary: "boot_aborted",
+			wantLevel:   domainlogging.LevelError,
+			wantAborted: true,
+		},
+	}
+
+	// Run all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := &recordingWriter{}
+			logger := daemonlogger.New(writer)
+			sup := &mockAppSupervisor{}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			aborted := setupBootReport(sup, logger, cancel, nil)
+			require.NotNil(t, sup.bootHandler)
+			sup.bootHandler(tt.report)
+
+			// One line per service plus the summary.
+			require.Len(t, writer.events, len(tt.report.Services)+1)
+			summary := writer.events[len(writer.events)-1]
+			assert.Equal(t, tt.wantSummary, summary.EventType)
+			assert.Equal(t, tt.wantLevel, summary.Level)
+			assert.Equal(t, tt.wantAborted, aborted.Load())
+			assert.Equal(t, tt.wantAborted, ctx.Err() != nil)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+//go:build linux
+
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+// platformFeatures returns the capabilities only built on Linux.
+//
+// Returns:
+//   - []string: cgroup limits, egress rules, inotify file watches, LSM labels and mount namespaces.
+func platformFeatures() []string {
+	// return linux capabilities
+	return []string{"cgroups", "egress", "inotify", "lsm", "mountns", "pidns"}
+}
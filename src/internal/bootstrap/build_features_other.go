@@ -0,0 +1,13 @@
+//go:build !linux
+
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+// platformFeatures returns the capabilities only built on Linux.
+//
+// Returns:
+//   - []string: nil outside Linux.
+func platformFeatures() []string {
+	// no linux capabilities
+	return nil
+}
@@ -0,0 +1,133 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+var (
+	// commit is the revision the binary was built from, set at build time via ldflags.
+	// Empty falls back to the revision recorded by the Go toolchain.
+	commit string = ""
+	// buildDate is when the binary was built (RFC 3339), set at build time via ldflags.
+	// Empty falls back to the commit time recorded by the Go toolchain.
+	buildDate string = ""
+)
+
+// buildInfoJSON is the output of --version --json.
+type buildInfoJSON struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit,omitempty"`
+	BuildDate string   `json:"build_date,omitempty"`
+	GoVersion string   `json:"go_version"`
+	Features  []string `json:"features"`
+}
+
+// currentBuildInfo describes the running binary from the values set at
+// build time, completed by what the Go toolchain records.
+//
+// Returns:
+//   - metrics.BuildInfo: the build of the daemon.
+func currentBuildInfo() metrics.BuildInfo {
+	info := metrics.BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		GoVersion: runtime.Version(),
+		Features:  platformFeatures(),
+	}
+	date := buildDate
+	// complete with the settings recorded by the toolchain
+	if recorded, ok := debug.ReadBuildInfo(); ok {
+		// scan each recorded setting
+		for _, setting := range recorded.Settings {
+			switch {
+			// revision of a build from a checkout
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			// commit time of a build from a checkout
+			case setting.Key == "vcs.time" && date == "":
+				date = setting.Value
+			// the system metrics probe is linked through cgo
+			case setting.Key == "CGO_ENABLED" && setting.Value == "1":
+				info.Features = append(info.Features, "probe")
+			// race detector builds
+			case setting.Key == "-race" && setting.Value == "true":
+				info.Features = append(info.Features, "race")
+			}
+		}
+	}
+	// unparsable dates are left unknown
+	if parsed, err := time.Parse(time.RFC3339, date); err == nil {
+		info.BuildDate = parsed.UTC()
+	}
+	slices.Sort(info.Features)
+	// return the build description
+	return info
+}
+
+// writeVersion prints the build of the daemon.
+//
+// Params:
+//   - out: the destination.
+//   - info: the build of the daemon.
+//   - asJSON: whether to print every field as JSON instead of the version line.
+//
+// Returns:
+//   - error: the write error.
+func writeVersion(out io.Writer, info *metrics.BuildInfo, asJSON bool) error {
+	// keep the historical line for scripts
+	if !asJSON {
+		_, err := fmt.Fprintf(out, "supervizio %s\n", info.Version)
+		// return write result
+		return err
+	}
+	doc := buildInfoJSON{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		GoVersion: info.GoVersion,
+		Features:  info.Features,
+	}
+	// dates are only printed when known
+	if !info.BuildDate.IsZero() {
+		doc.BuildDate = info.BuildDate.Format(time.RFC3339)
+	}
+	// always print a list
+	if doc.Features == nil {
+		doc.Features = []string{}
+	}
+	// return encoding result
+	return json.NewEncoder(out).Encode(doc)
+}
+
+// buildInfoMeta returns the build of the daemon as log metadata.
+//
+// Params:
+//   - info: the build of the daemon.
+//
+// Returns:
+//   - map[string]any: version, commit, build date, Go version and features.
+func buildInfoMeta(info *metrics.BuildInfo) map[string]any {
+	meta := map[string]any{
+		"version":    info.Version,
+		"go_version": info.GoVersion,
+		"features":   info.Features,
+	}
+	// the commit is only logged when known
+	if info.Commit != "" {
+		meta["commit"] = info.Commit
+	}
+	// the date is only logged when known
+	if !info.BuildDate.IsZero() {
+		meta["build_date"] = info.BuildDate.Format(time.RFC3339)
+	}
+	// return the banner metadata
+	return meta
+}
@@ -0,0 +1,102 @@
+// Package bootstrap provides internal tests for build_info.go.
+package bootstrap
+
+import (
+	"bytes"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// Test_currentBuildInfo tests that the values set at build time are kept.
+//
+// Params:
+//   - t: the testing context.
+func Test_currentBuildInfo(t *testing.T) {
+	tests := []struct {
+		name      string
+		commit    string
+		buildDate string
+		wantDate  time.Time
+	}{
+		{name: "set at build time", commit: "4f2a9c1", buildDate: "2026-03-01T13:00:00+01:00", wantDate: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "malformed date", commit: "4f2a9c1", buildDate: "yesterday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldCommit, oldDate := commit, buildDate
+			defer func() { commit, buildDate = oldCommit, oldDate }()
+			commit, buildDate = tt.commit, tt.buildDate
+
+			info := currentBuildInfo()
+
+			assert.Equal(t, version, info.Version)
+			assert.Equal(t, tt.commit, info.Commit)
+			assert.Equal(t, tt.wantDate, info.BuildDate)
+			assert.Equal(t, runtime.Version(), info.GoVersion)
+			assert.True(t, slices.IsSorted(info.Features))
+		})
+	}
+}
+
+// Test_writeVersion tests the text and JSON version outputs.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		info   metrics.BuildInfo
+		asJSON bool
+		want   string
+	}{
+		{
+			name: "text",
+			info: metrics.BuildInfo{Version: "1.4.0", Commit: "4f2a9c1", GoVersion: "go1.25.6"},
+			want: "supervizio 1.4.0\n",
+		},
+		{
+			name: "json",
+			info: metrics.BuildInfo{
+				Version: "1.4.0", Commit: "4f2a9c1", BuildDate: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+				GoVersion: "go1.25.6", Features: []string{"cgroups", "probe"},
+			},
+			asJSON: true,
+			want:   `{"version":"1.4.0","commit":"4f2a9c1","build_date":"2026-03-01T12:00:00Z","go_version":"go1.25.6","features":["cgroups","probe"]}` + "\n",
+		},
+		{
+			name:   "json with unknown build",
+			info:   metrics.BuildInfo{Version: "dev", GoVersion: "go1.25.6"},
+			asJSON: true,
+			want:   `{"version":"dev","go_version":"go1.25.6","features":[]}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, writeVersion(&out, &tt.info, tt.asJSON))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+// Test_buildInfoMeta tests that unknown values are not logged.
+//
+// Params:
+//   - t: the testing context.
+func Test_buildInfoMeta(t *testing.T) {
+	meta := buildInfoMeta(&metrics.BuildInfo{Version: "dev", GoVersion: "go1.25.6"})
+
+	assert.Equal(t, "dev", meta["version"])
+	assert.Equal(t, "go1.25.6", meta["go_version"])
+	assert.NotContains(t, meta, "commit")
+	assert.NotContains(t, meta, "build_date")
+}
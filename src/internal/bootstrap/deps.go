@@ -0,0 +1,117 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// depsCommand is the subcommand printing the dependency map sampled from service connections.
+const depsCommand string = "deps"
+
+// ErrDepsUsage indicates a malformed deps command line.
+var ErrDepsUsage error = errors.New("usage: supervizio deps [SERVICE] [--undeclared] [--address HOST:PORT]")
+
+// runDepsMode prints the dependency map of one or every service of the
+// daemon.
+//
+// Params:
+//   - args: the arguments following the deps command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runDepsMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runDeps(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runDeps asks the daemon for the connections sampled from the services
+// and prints them.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the optional service name and the flags of the deps command.
+//   - out: the destination of the map.
+//
+// Returns:
+//   - error: ErrDepsUsage, or the daemon or write error.
+func runDeps(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(depsCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	undeclared := flags.Bool("undeclared", false, "only print dependencies the configuration does not declare")
+	var service string
+	// the service name may precede the flags
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		service, args = args[0], args[1:]
+	}
+	// flags are valid
+	if err := flags.Parse(args); err != nil {
+		// return usage error
+		return ErrDepsUsage
+	}
+	// the service name may follow the flags
+	if service == "" && flags.NArg() == 1 {
+		service = flags.Arg(0)
+	} else if flags.NArg() != 0 {
+		// return usage error
+		return ErrDepsUsage
+	}
+
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	edges, err := client.DependencyMap(ctx, service, *undeclared)
+	// daemon unreachable, sampling disabled or service unknown
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("reading dependency map: %w", err)
+	}
+	// return write result
+	return writeDeps(out, edges)
+}
+
+// writeDeps prints one line per sampled edge: the supervised service
+// reached, or "-" for endpoints outside the daemon.
+//
+// Params:
+//   - out: the destination of the map.
+//   - edges: the sampled edges.
+//
+// Returns:
+//   - error: the write error.
+func writeDeps(out io.Writer, edges []process.TrafficEdge) error {
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "SERVICE\tPEER\tENDPOINT\tDECLARED\tSAMPLES\tLAST SEEN")
+	// print each edge
+	for i := range edges {
+		edge := &edges[i]
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d\t%s\n", edge.Service, orNone(edge.Peer, "-"), edge.Endpoint,
+			yesNo(edge.Declared), edge.Samples, edge.LastSeen.Local().Format(time.RFC3339))
+	}
+	// return write result
+	return table.Flush()
+}
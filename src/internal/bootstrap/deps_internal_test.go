@@ -0,0 +1,71 @@
+// Package bootstrap provides internal tests for deps.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_runDeps_usage tests that malformed deps commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runDeps_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "two services", args: []string{"api", "web"}},
+		{name: "unknown flag", args: []string{"api", "--external"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runDeps(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrDepsUsage)
+		})
+	}
+}
+
+// Test_runDeps_unreachable tests that an unreachable daemon is reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_runDeps_unreachable(t *testing.T) {
+	for _, args := range [][]string{
+		{"--address", "127.0.0.1:1"},
+		{"api", "--undeclared", "--address", "127.0.0.1:1"},
+	} {
+		var out bytes.Buffer
+		err := runDeps(context.Background(), args, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading dependency map")
+		assert.Empty(t, out.String())
+	}
+}
+
+// Test_writeDeps tests the printed map.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeDeps(t *testing.T) {
+	seen := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	var out bytes.Buffer
+	err := writeDeps(&out, []process.TrafficEdge{
+		{Service: "web", Peer: "api", Endpoint: "127.0.0.1:8080", Declared: true, Samples: 12, LastSeen: seen},
+		{Service: "web", Endpoint: "10.0.0.6:6379", Samples: 3, LastSeen: seen},
+	})
+	require.NoError(t, err)
+
+	stamp := seen.Format(time.RFC3339)
+	assert.Equal(t, "SERVICE  PEER  ENDPOINT        DECLARED  SAMPLES  LAST SEEN\n"+
+		"web      api   127.0.0.1:8080  yes       12       "+stamp+"\n"+
+		"web      -     10.0.0.6:6379   no        3        "+stamp+"\n", out.String())
+}
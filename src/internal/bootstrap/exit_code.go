@@ -0,0 +1,70 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Process exit codes, following the BSD sysexits convention where one applies.
+const (
+	// exitOK reports a clean exit.
+	exitOK int = 0
+	// exitFailure reports an error without a more specific code.
+	exitFailure int = 1
+	// exitUnavailable reports a service or supervisor in the wrong state (EX_UNAVAILABLE).
+	exitUnavailable int = 69
+	// exitNoInput reports a configuration file that cannot be read (EX_NOINPUT).
+	exitNoInput int = 66
+	// exitNoPermission reports a change refused by a read-only control plane (EX_NOPERM).
+	exitNoPermission int = 77
+	// exitConfig reports an invalid or refused configuration (EX_CONFIG).
+	exitConfig int = 78
+)
+
+// exitCodes maps domain error codes to process exit codes.
+var exitCodes map[shared.Code]int = map[shared.Code]int{
+	shared.CodeConfigInvalid:            exitConfig,
+	shared.CodeConfigPreflightFailed:    exitConfig,
+	shared.CodeConfigUnreadable:         exitNoInput,
+	shared.CodeServiceNotFound:          exitConfig,
+	shared.CodeSupervisorAlreadyRunning: exitUnavailable,
+	shared.CodeSupervisorNotRunning:     exitUnavailable,
+	shared.CodeBootFailed:               exitUnavailable,
+	shared.CodeReadOnly:                 exitNoPermission,
+}
+
+// exitCode returns the process exit code for an error.
+//
+// Params:
+//   - err: the error that ended the run, or nil.
+//
+// Returns:
+//   - int: 0 on success, the mapped code for coded errors, 1 otherwise.
+func exitCode(err error) int {
+	// clean exit
+	if err == nil {
+		// success
+		return exitOK
+	}
+	code, ok := exitCodes[shared.CodeOf(err)]
+	// uncoded or unmapped errors
+	if !ok {
+		// generic failure
+		return exitFailure
+	}
+	// mapped exit code
+	return code
+}
+
+// reportError writes an error with its code so scripts can match on it.
+//
+// Params:
+//   - w: the destination, usually stderr.
+//   - err: the error to report.
+func reportError(w io.Writer, err error) {
+	// prefix the message with its stable code
+	_, _ = fmt.Fprintf(w, "error [%s]: %v\n", shared.CodeOf(err), err)
+}
@@ -0,0 +1,50 @@
+// Package bootstrap provides internal tests for exit_code.go.
+package bootstrap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Test_exitCode tests mapping of error codes to process exit codes.
+//
+// Params:
+//   - t: the testing context.
+func Test_exitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitOK},
+		{name: "uncoded", err: errors.New("boom"), want: exitFailure},
+		{name: "invalid_config", err: fmt.Errorf("failed to initialize: %w", shared.WithCode(shared.CodeConfigInvalid, errors.New("bad"))), want: exitConfig},
+		{name: "unreadable_config", err: shared.WithCode(shared.CodeConfigUnreadable, errors.New("missing")), want: exitNoInput},
+		{name: "read_only", err: shared.NewCodedError(shared.CodeReadOnly, "read-only"), want: exitNoPermission},
+		{name: "unmapped_code", err: shared.NewCodedError(shared.CodeServiceFailed, "failed"), want: exitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.err))
+		})
+	}
+}
+
+// Test_reportError tests that reported errors carry their code.
+//
+// Params:
+//   - t: the testing context.
+func Test_reportError(t *testing.T) {
+	var buf bytes.Buffer
+
+	reportError(&buf, shared.WithCode(shared.CodeConfigInvalid, errors.New("no services")))
+
+	assert.Equal(t, "error [CFG_INVALID]: no services\n", buf.String())
+}
@@ -0,0 +1,330 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// hostInfoCommand is the subcommand printing the platform capabilities of the daemon host.
+const hostInfoCommand string = "host-info"
+
+// ErrHostInfoUsage indicates a malformed host-info command line.
+var ErrHostInfoUsage error = errors.New("usage: supervizio host-info [--address HOST:PORT]")
+
+// hostInventoryReader returns the host inventory detected by the supervisor.
+type hostInventoryReader interface {
+	HostInventory(ctx context.Context) (domainmetrics.HostInventory, error)
+}
+
+// runHostInfoMode prints the platform capabilities of the daemon host.
+//
+// Params:
+//   - args: the arguments following the host-info command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runHostInfoMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runHostInfo(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runHostInfo asks the daemon for the capabilities of its host and prints
+// them, with the support of each optional feature.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the flags of the host-info command.
+//   - out: the destination of the report.
+//
+// Returns:
+//   - error: ErrHostInfoUsage, or the daemon or write error.
+func runHostInfo(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(hostInfoCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	// flags are valid and nothing follows them
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		// return usage error
+		return ErrHostInfoUsage
+	}
+
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	inventory, err := client.HostInventory(ctx)
+	// daemon unreachable or inventory unavailable
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("reading host inventory: %w", err)
+	}
+	// return write result
+	return writeHostInventory(out, &inventory)
+}
+
+// writeHostInventory prints the capabilities of the host, then one line per
+// optional feature with the reason of each disabled one.
+//
+// Params:
+//   - out: the destination of the report.
+//   - inventory: the host inventory.
+//
+// Returns:
+//   - error: the write error.
+func writeHostInventory(out io.Writer, inventory *domainmetrics.HostInventory) error {
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(table, "platform\t%s/%s\n", inventory.OS, inventory.Architecture)
+	_, _ = fmt.Fprintf(table, "kernel\t%s\n", orNone(inventory.KernelVersion, "unknown"))
+	cgroup := "none"
+	// name the hierarchy
+	if inventory.CgroupVersion > 0 {
+		cgroup = "v" + strconv.Itoa(inventory.CgroupVersion)
+	}
+	_, _ = fmt.Fprintf(table, "cgroup\t%s\n", cgroup)
+	_, _ = fmt.Fprintf(table, "pressure stall\t%s\n", yesNo(inventory.PressureStall))
+	_, _ = fmt.Fprintf(table, "security modules\t%s\n", orNone(strings.Join(inventory.SecurityModules, ", "), "none"))
+	_, _ = fmt.Fprintf(table, "nftables\t%s\n", yesNo(inventory.Nftables))
+	_, _ = fmt.Fprintf(table, "systemd\t%s\n", yesNo(inventory.Systemd))
+	sockets := make([]string, 0, len(inventory.ContainerSockets))
+	// list each runtime with its socket
+	for _, socket := range inventory.ContainerSockets {
+		sockets = append(sockets, socket.Runtime+" ("+socket.Path+")")
+	}
+	_, _ = fmt.Fprintf(table, "container runtimes\t%s\n", orNone(strings.Join(sockets, ", "), "none"))
+	_, _ = fmt.Fprintf(table, "pid 1\t%s\n", yesNo(inventory.PID1))
+	_, _ = fmt.Fprintf(table, "root\t%s\n", yesNo(inventory.Privileged))
+	fds := "unknown"
+	// the limits are unknown outside Linux
+	if inventory.MaxFDs > 0 {
+		fds = fmt.Sprintf("%d (hard %d)", inventory.MaxFDs, inventory.MaxFDsHard)
+	}
+	_, _ = fmt.Fprintf(table, "max open files\t%s\n", fds)
+	_, _ = fmt.Fprintln(table, "\t")
+	_, _ = fmt.Fprintln(table, "FEATURE\tSTATUS")
+	// print each feature
+	for _, support := range inventory.Features() {
+		status := "supported"
+		// say why the feature is off
+		if !support.Supported {
+			status = "disabled: " + support.Reason
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s\n", support.Name, status)
+	}
+	// return write result
+	return table.Flush()
+}
+
+// yesNo formats a capability.
+//
+// Params:
+//   - present: whether the capability is present.
+//
+// Returns:
+//   - string: "yes" or "no".
+func yesNo(present bool) string {
+	// capability present
+	if present {
+		// return yes
+		return "yes"
+	}
+	// return no
+	return "no"
+}
+
+// orNone replaces an empty value.
+//
+// Params:
+//   - value: the value.
+//   - none: the replacement of an empty value.
+//
+// Returns:
+//   - string: the value, or none when empty.
+func orNone(value, none string) string {
+	// keep a set value
+	if value != "" {
+		// return value
+		return value
+	}
+	// return replacement
+	return none
+}
+
+// detectHost detects the host once while the daemon is wired. A failed or
+// missing detection returns nil, leaving every feature enabled.
+//
+// Params:
+//   - collector: the host inventory collector, nil when none.
+//
+// Returns:
+//   - *domainmetrics.HostInventory: the inventory, nil when unknown.
+func detectHost(collector appmetrics.HostInventoryCollector) *domainmetrics.HostInventory {
+	// no detection without collector
+	if collector == nil {
+		// return unknown host
+		return nil
+	}
+	inventory, err := collector.CollectHostInventory(context.Background())
+	// keep every feature on failure
+	if err != nil {
+		// return unknown host
+		return nil
+	}
+	// return detected host
+	return &inventory
+}
+
+// hostSupports reports whether a host can run a feature. Features are
+// supported on an unknown host.
+//
+// Params:
+//   - inventory: the host inventory, nil when unknown.
+//   - name: the feature name.
+//
+// Returns:
+//   - bool: true unless the host is known not to support the feature.
+func hostSupports(inventory *domainmetrics.HostInventory, name string) bool {
+	// return support, assumed when unknown
+	return inventory == nil || inventory.Supports(name).Supported
+}
+
+// logHostFeatures logs the capabilities of the host, then warns about each
+// feature the configuration uses but the host cannot run, with the reason
+// it is disabled.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - sup: the supervisor holding the host inventory.
+//   - cfg: the daemon configuration.
+//   - logger: the daemon logger.
+func logHostFeatures(ctx context.Context, sup hostInventoryReader, cfg *domainconfig.Config, logger domainlogging.Logger) {
+	inventory, err := sup.HostInventory(ctx)
+	// nothing to report on an unknown host
+	if err != nil {
+		// inventory unavailable
+		return
+	}
+	var disabled []string
+	// collect the disabled features
+	for _, support := range inventory.Features() {
+		// skip runnable features
+		if support.Supported {
+			continue
+		}
+		disabled = append(disabled, support.Name)
+		// warn only about features the configuration relies on
+		if cfg != nil && featureInUse(cfg, support.Name) {
+			logger.Warn("", "feature_disabled", "Feature disabled: "+support.Name+": "+support.Reason, map[string]any{
+				"feature": support.Name,
+				"reason":  support.Reason,
+			})
+		}
+	}
+	logger.Info("", "host_inventory", "Host inventory detected", map[string]any{
+		"cgroup_version":    inventory.CgroupVersion,
+		"security_modules":  strings.Join(inventory.SecurityModules, ","),
+		"systemd":           inventory.Systemd,
+		"pid1":              inventory.PID1,
+		"max_fds":           inventory.MaxFDs,
+		"disabled_features": strings.Join(disabled, ","),
+	})
+}
+
+// featureInUse reports whether the configuration relies on a feature.
+//
+// Params:
+//   - cfg: the daemon configuration.
+//   - name: the feature name.
+//
+// Returns:
+//   - bool: true if the configuration enables the feature.
+func featureInUse(cfg *domainconfig.Config, name string) bool {
+	discovery := &cfg.Monitoring.Discovery
+	// match the configuration enabling each feature
+	switch name {
+	case domainmetrics.FeatureCgroupMetrics:
+		// return CPU throttling collection
+		return cfg.Monitoring.Metrics.ThrottlingEnabled()
+	case domainmetrics.FeatureCgroupPressure:
+		// return cgroup PSI collection
+		return cfg.Monitoring.Metrics.PressureEnabled()
+	case domainmetrics.FeatureShedding:
+		// return shedding threshold
+		return cfg.Shedding.Enabled()
+	case domainmetrics.FeatureSystemdDiscovery:
+		// return systemd discovery
+		return discovery.Systemd != nil && discovery.Systemd.Enabled
+	case domainmetrics.FeatureContainerDiscovery:
+		// return docker or podman discovery
+		return (discovery.Docker != nil && discovery.Docker.Enabled) || (discovery.Podman != nil && discovery.Podman.Enabled)
+	default:
+		// return service settings
+		return serviceFeatureInUse(cfg, name)
+	}
+}
+
+// serviceFeatureInUse reports whether a service of the configuration relies
+// on a feature.
+//
+// Params:
+//   - cfg: the daemon configuration.
+//   - name: the feature name.
+//
+// Returns:
+//   - bool: true if a service sets the feature.
+func serviceFeatureInUse(cfg *domainconfig.Config, name string) bool {
+	// check each service
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		// match the setting of the feature
+		switch name {
+		case domainmetrics.FeatureEgress:
+			// egress rules
+			if len(svc.Egress) > 0 {
+				// return in use
+				return true
+			}
+		case domainmetrics.FeatureSecurityLabels:
+			// SELinux context or AppArmor profile
+			if svc.SELinuxContext != "" || svc.AppArmorProfile != "" {
+				// return in use
+				return true
+			}
+		case domainmetrics.FeatureNamespaces:
+			// PID namespace, mount namespace paths or DNS overrides
+			if svc.PIDNamespace || len(svc.ReadOnlyPaths)+len(svc.MaskedPaths)+len(svc.TmpfsPaths) > 0 || !svc.DNS.IsZero() {
+				// return in use
+				return true
+			}
+		// other features are not set per service
+		default:
+		}
+	}
+	// return unused
+	return false
+}
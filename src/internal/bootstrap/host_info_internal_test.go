@@ -0,0 +1,141 @@
+// Package bootstrap provides internal tests for host_info.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+)
+
+// stubHostInventory returns a fixed host inventory.
+type stubHostInventory struct {
+	inventory domainmetrics.HostInventory
+}
+
+// HostInventory returns the fixed inventory.
+//
+// Params:
+//   - ctx: the context (unused).
+//
+// Returns:
+//   - domainmetrics.HostInventory: the inventory.
+//   - error: nil.
+func (s *stubHostInventory) HostInventory(_ context.Context) (domainmetrics.HostInventory, error) {
+	// Return the fixed inventory.
+	return s.inventory, nil
+}
+
+// CollectHostInventory returns the fixed inventory.
+//
+// Params:
+//   - ctx: the context (unused).
+//
+// Returns:
+//   - domainmetrics.HostInventory: the inventory.
+//   - error: nil.
+func (s *stubHostInventory) CollectHostInventory(ctx context.Context) (domainmetrics.HostInventory, error) {
+	// Return the fixed inventory.
+	return s.HostInventory(ctx)
+}
+
+// Test_runHostInfo_usage tests that malformed host-info commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runHostInfo_usage(t *testing.T) {
+	for _, args := range [][]string{{"extra"}, {"--verbose"}} {
+		err := runHostInfo(context.Background(), args, &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrHostInfoUsage)
+	}
+}
+
+// Test_runHostInfo_unreachable tests that an unreachable daemon is reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_runHostInfo_unreachable(t *testing.T) {
+	var out bytes.Buffer
+	err := runHostInfo(context.Background(), []string{"--address", "127.0.0.1:1"}, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading host inventory")
+	assert.Empty(t, out.String())
+}
+
+// Test_writeHostInventory tests the printed capabilities and features.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeHostInventory(t *testing.T) {
+	var out bytes.Buffer
+	err := writeHostInventory(&out, &domainmetrics.HostInventory{
+		OS: "linux", Architecture: "amd64", KernelVersion: "6.1.0",
+		CgroupVersion: 2, SecurityModules: []string{"apparmor"}, Nftables: true,
+		ContainerSockets: []domainmetrics.ContainerSocket{{Runtime: "docker", Path: "/run/docker.sock"}},
+		Privileged:       true, MaxFDs: 1024, MaxFDsHard: 524288,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "platform             linux/amd64\n"+
+		"kernel               6.1.0\n"+
+		"cgroup               v2\n"+
+		"pressure stall       no\n"+
+		"security modules     apparmor\n"+
+		"nftables             yes\n"+
+		"systemd              no\n"+
+		"container runtimes   docker (/run/docker.sock)\n"+
+		"pid 1                no\n"+
+		"root                 yes\n"+
+		"max open files       1024 (hard 524288)\n"+
+		"                     \n"+
+		"FEATURE              STATUS\n"+
+		"cgroup_metrics       supported\n"+
+		"cgroup_pressure      disabled: kernel without pressure stall information\n"+
+		"shedding             disabled: kernel without pressure stall information\n"+
+		"egress               supported\n"+
+		"security_labels      supported\n"+
+		"namespaces           supported\n"+
+		"reaping              disabled: not PID 1, orphans are reaped by the host init\n"+
+		"systemd_discovery    disabled: systemd is not the init system\n"+
+		"container_discovery  supported\n", out.String())
+}
+
+// Test_logHostFeatures tests that only the disabled features the
+// configuration uses are warned about.
+//
+// Params:
+//   - t: the testing context.
+func Test_logHostFeatures(t *testing.T) {
+	writer := &recordingWriter{}
+	cfg := &domainconfig.Config{Services: []domainconfig.ServiceConfig{{Name: "web", PIDNamespace: true}}}
+	host := &stubHostInventory{inventory: domainmetrics.HostInventory{OS: "linux", CgroupVersion: 2}}
+
+	logHostFeatures(context.Background(), host, cfg, daemonlogger.New(writer))
+
+	// One warning for the PID namespace, then the summary.
+	require.Len(t, writer.events, 2)
+	assert.Equal(t, "feature_disabled", writer.events[0].EventType)
+	assert.Equal(t, domainlogging.LevelWarn, writer.events[0].Level)
+	assert.Equal(t, domainmetrics.FeatureNamespaces, writer.events[0].Metadata["feature"])
+	assert.Equal(t, "host_inventory", writer.events[1].EventType)
+}
+
+// Test_ProvideMetricsTracker_hostSupport tests that cgroup collectors are
+// left out on a host without cgroups.
+//
+// Params:
+//   - t: the testing context.
+func Test_ProvideMetricsTracker_hostSupport(t *testing.T) {
+	assert.True(t, hostSupports(detectHost(nil), domainmetrics.FeatureCgroupMetrics))
+	host := detectHost(&stubHostInventory{inventory: domainmetrics.HostInventory{OS: "linux"}})
+	require.NotNil(t, host)
+	assert.False(t, hostSupports(host, domainmetrics.FeatureCgroupMetrics))
+	assert.False(t, hostSupports(host, domainmetrics.FeatureCgroupPressure))
+}
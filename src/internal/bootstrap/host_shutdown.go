@@ -0,0 +1,76 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"syscall"
+
+	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/process/hostpower"
+)
+
+// hostShutdownWatcher delivers host shutdown notices and holds the host
+// shutdown until released.
+type hostShutdownWatcher interface {
+	Start(ctx context.Context, handler hostpower.NoticeHandler) error
+	Release() error
+}
+
+// setupHostShutdown starts the ordered shutdown as soon as the host
+// announces its own shutdown, when host_shutdown is configured.
+//
+// Params:
+//   - ctx: the daemon context, ending the listening.
+//   - sup: the supervisor stopping the services.
+//   - cfg: the daemon configuration.
+//   - sigCh: the signal channel the shutdown is requested on.
+//   - logger: the daemon logger.
+func setupHostShutdown(ctx context.Context, sup AppSupervisor, cfg *domainconfig.Config, sigCh chan<- os.Signal, logger domainlogging.Logger) {
+	// the notice is disabled without source
+	if cfg == nil || !cfg.HostShutdown.Enabled() {
+		// nothing to listen to
+		return
+	}
+	watchHostShutdown(ctx, hostpower.New(&cfg.HostShutdown), sup, sigCh, logger)
+}
+
+// watchHostShutdown requests the shutdown of the daemon, as SIGTERM does,
+// on the first notice, and releases the host once every service is stopped.
+// A source that cannot be used is reported but does not fail the start.
+//
+// Params:
+//   - ctx: the daemon context, ending the listening.
+//   - watcher: the source of the notices.
+//   - sup: the supervisor stopping the services.
+//   - sigCh: the signal channel the shutdown is requested on.
+//   - logger: the daemon logger.
+func watchHostShutdown(ctx context.Context, watcher hostShutdownWatcher, sup AppSupervisor, sigCh chan<- os.Signal, logger domainlogging.Logger) {
+	// let the host proceed once the services are down
+	sup.OnTransition(appsupervisor.StateStopping, appsupervisor.StateStopped, func(_, _ appsupervisor.State) {
+		// a failed lock is reported, the daemon stops anyway
+		if err := watcher.Release(); err != nil {
+			logger.Warn("", "host_shutdown_lock_failed", "Host shutdown inhibitor lock failed", map[string]any{
+				"error": err.Error(),
+			})
+		}
+	})
+	err := watcher.Start(ctx, func(source hostpower.Source) {
+		logger.Info("", "host_shutdown", "Host shutdown announced, stopping services", map[string]any{
+			"source": string(source),
+		})
+		// request the ordered shutdown unless the daemon is already leaving
+		select {
+		case sigCh <- syscall.SIGTERM:
+		case <-ctx.Done():
+		}
+	})
+	// report the sources that cannot be used
+	if err != nil {
+		logger.Warn("", "host_shutdown_unavailable", "Host shutdown notice unavailable", map[string]any{
+			"error": err.Error(),
+		})
+	}
+}
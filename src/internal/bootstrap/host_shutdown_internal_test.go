@@ -0,0 +1,100 @@
+// Package bootstrap provides internal tests for host_shutdown.go.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+	"github.com/kodflow/daemon/internal/infrastructure/process/hostpower"
+)
+
+// fakeHostShutdownWatcher announces a host shutdown as soon as it starts.
+type fakeHostShutdownWatcher struct {
+	startErr   error
+	releaseErr error
+	released   int
+}
+
+// Start delivers one logind notice.
+//
+// Params:
+//   - ctx: the context (unused).
+//   - handler: the notice handler.
+//
+// Returns:
+//   - error: the configured start error.
+func (w *fakeHostShutdownWatcher) Start(_ context.Context, handler hostpower.NoticeHandler) error {
+	handler(hostpower.SourceLogind)
+	// Return configured error.
+	return w.startErr
+}
+
+// Release counts the releases.
+//
+// Returns:
+//   - error: the configured release error.
+func (w *fakeHostShutdownWatcher) Release() error {
+	w.released++
+	// Return configured error.
+	return w.releaseErr
+}
+
+// Test_watchHostShutdown verifies a notice requests the shutdown like
+// SIGTERM, and the lock is released once the services are stopped.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_watchHostShutdown(t *testing.T) {
+	t.Parallel()
+
+	writer := &recordingWriter{}
+	watcher := &fakeHostShutdownWatcher{releaseErr: errors.New("exit status 1")}
+	sup := &mockAppSupervisor{}
+	sigCh := make(chan os.Signal, 1)
+
+	watchHostShutdown(context.Background(), watcher, sup, sigCh, daemonlogger.New(writer))
+
+	assert.Equal(t, syscall.SIGTERM, <-sigCh)
+	require.Len(t, writer.events, 1)
+	assert.Equal(t, "host_shutdown", writer.events[0].EventType)
+	assert.Equal(t, "logind", writer.events[0].Metadata["source"])
+	assert.Zero(t, watcher.released)
+
+	require.NotNil(t, sup.stateHook)
+	sup.stateHook(appsupervisor.StateStopping, appsupervisor.StateStopped)
+	assert.Equal(t, 1, watcher.released)
+	require.Len(t, writer.events, 2)
+	assert.Equal(t, domainlogging.LevelWarn, writer.events[1].Level)
+}
+
+// Test_setupHostShutdown verifies nothing is watched without source, and an
+// unusable source is reported without failing.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_setupHostShutdown(t *testing.T) {
+	t.Parallel()
+
+	writer := &recordingWriter{}
+	sup := &mockAppSupervisor{}
+	setupHostShutdown(context.Background(), sup, &domainconfig.Config{}, make(chan os.Signal, 1), daemonlogger.New(writer))
+	assert.Nil(t, sup.stateHook)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := &domainconfig.Config{HostShutdown: domainconfig.HostShutdownConfig{ACPISocket: "/nonexistent/acpid.socket"}}
+	setupHostShutdown(ctx, sup, cfg, make(chan os.Signal, 1), daemonlogger.New(writer))
+	assert.NotNil(t, sup.stateHook)
+	require.Len(t, writer.events, 1)
+	assert.Equal(t, "host_shutdown_unavailable", writer.events[0].EventType)
+}
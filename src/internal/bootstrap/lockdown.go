@@ -0,0 +1,23 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// applyLockdown keeps the control plane read-only for the --lockdown flag,
+// whatever control.read_only says across reloads.
+//
+// Params:
+//   - sup: the supervisor answering the control policy.
+//   - on: whether the flag was set.
+//   - logger: the daemon logger.
+func applyLockdown(sup AppSupervisor, on bool, logger domainlogging.Logger) {
+	// follow the configuration without the flag
+	if !on {
+		// nothing to lock
+		return
+	}
+	sup.SetLockdown(true)
+	logger.Info("", "control_locked_down", "Control plane is read-only (--lockdown)", nil)
+}
@@ -0,0 +1,43 @@
+// Package bootstrap provides internal tests for lockdown.go.
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+)
+
+// Test_applyLockdown tests that the flag locks the supervisor down and is logged.
+//
+// Params:
+//   - t: the testing context.
+func Test_applyLockdown(t *testing.T) {
+	tests := []struct {
+		name string
+		on   bool
+	}{
+		{name: "flag_set", on: true},
+		{name: "flag_unset", on: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &recordingWriter{}
+			sup := &mockAppSupervisor{}
+
+			applyLockdown(sup, tt.on, daemonlogger.New(writer))
+
+			assert.Equal(t, tt.on, sup.lockdown)
+			// Only the lockdown is announced.
+			if !tt.on {
+				assert.Empty(t, writer.events)
+				return
+			}
+			require.Len(t, writer.events, 1)
+			assert.Equal(t, "control_locked_down", writer.events[0].EventType)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// logsCommand is the subcommand printing the log files of a service.
+const logsCommand string = "logs"
+
+// ErrLogsUsage indicates a malformed logs command line.
+var ErrLogsUsage error = errors.New("usage: supervizio logs SERVICE [--since DURATION] [--grep PATTERN] [--address HOST:PORT]")
+
+// runLogsMode prints the lines of a service read by the daemon from its log
+// files, until they are all printed or the command is interrupted.
+//
+// Params:
+//   - args: the arguments following the logs command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runLogsMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runLogs(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runLogs asks the daemon for the lines of a service log files, rotated
+// and compressed files included, and prints them oldest first.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the service name and the flags of the logs command.
+//   - out: the destination of the lines.
+//
+// Returns:
+//   - error: ErrLogsUsage, or the daemon or write error.
+func runLogs(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(logsCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	since := flags.Duration("since", 0, "age of the oldest line to print")
+	pattern := flags.String("grep", "", "regular expression lines must match")
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	var service string
+	// the service name may precede the flags
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		service, args = args[0], args[1:]
+	}
+	// flags are valid and the age is not negative
+	if err := flags.Parse(args); err != nil || *since < 0 {
+		// return usage error
+		return ErrLogsUsage
+	}
+	// the service name may follow the flags
+	if service == "" && flags.NArg() == 1 {
+		service = flags.Arg(0)
+	} else if flags.NArg() != 0 {
+		// return usage error
+		return ErrLogsUsage
+	}
+	// a service is required
+	if service == "" {
+		// return usage error
+		return ErrLogsUsage
+	}
+
+	var from time.Time
+	// read recent lines only if requested
+	if *since > 0 {
+		from = time.Now().Add(-*since)
+	}
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	err = client.ReadLogs(ctx, service, from, *pattern, func(line *logging.OutputLine) error {
+		// print each line
+		return writeLogLine(out, line)
+	})
+	// daemon unreachable, unknown service or interrupted
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("reading logs of %s: %w", service, err)
+	}
+	// lines printed
+	return nil
+}
+
+// writeLogLine prints a line with its time and stream.
+//
+// Params:
+//   - out: the destination of the line.
+//   - line: the log line.
+//
+// Returns:
+//   - error: the write error.
+func writeLogLine(out io.Writer, line *logging.OutputLine) error {
+	_, err := fmt.Fprintf(out, "%s %s %s\n", line.Timestamp.Local().Format(time.RFC3339), line.Stream, line.Message)
+	// return write result
+	return err
+}
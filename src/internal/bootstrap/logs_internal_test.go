@@ -0,0 +1,74 @@
+// Package bootstrap provides internal tests for logs.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// Test_runLogs_usage tests that malformed logs commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runLogs_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no service", args: nil},
+		{name: "flags only", args: []string{"--since", "1h"}},
+		{name: "two services", args: []string{"api", "web"}},
+		{name: "negative age", args: []string{"api", "--since", "-1h"}},
+		{name: "malformed age", args: []string{"api", "--since", "yesterday"}},
+		{name: "unknown flag", args: []string{"api", "--follow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runLogs(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrLogsUsage)
+		})
+	}
+}
+
+// Test_runLogs_unreachable tests that an unreachable daemon is reported,
+// whether the service precedes or follows the flags.
+//
+// Params:
+//   - t: the testing context.
+func Test_runLogs_unreachable(t *testing.T) {
+	for _, args := range [][]string{
+		{"api", "--since", "1h", "--grep", "ERROR", "--address", "127.0.0.1:1"},
+		{"--address", "127.0.0.1:1", "api"},
+	} {
+		var out bytes.Buffer
+		err := runLogs(context.Background(), args, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading logs of api")
+		assert.Empty(t, out.String())
+	}
+}
+
+// Test_writeLogLine tests the printed line.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeLogLine(t *testing.T) {
+	at := time.Date(2026, 1, 15, 10, 30, 0, 0, time.Local)
+	var out bytes.Buffer
+	require.NoError(t, writeLogLine(&out, &logging.OutputLine{
+		Service:   "api",
+		Stream:    logging.StreamStderr,
+		Timestamp: at,
+		Message:   "ERROR db down",
+	}))
+
+	assert.Equal(t, at.Format(time.RFC3339)+" stderr ERROR db down\n", out.String())
+}
@@ -0,0 +1,109 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+
+	appnotification "github.com/kodflow/daemon/internal/application/notification"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/notify"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/spool"
+)
+
+// setupNotifications creates the dispatcher delivering events to the
+// configured notification channels. With a spool directory, messages to
+// unavailable endpoints are kept on disk and replayed.
+//
+// Params:
+//   - cfg: the daemon configuration.
+//   - logger: the daemon logger reporting failed deliveries.
+//
+// Returns:
+//   - *appnotification.Dispatcher: the dispatcher, nil without channels.
+//   - func(): flushes pending digests and stops replaying, nil without channels.
+func setupNotifications(cfg *domainconfig.Config, logger domainlogging.Logger) (*appnotification.Dispatcher, func()) {
+	// notifications are disabled without channels
+	if cfg == nil || len(cfg.Notifications.Channels) == 0 {
+		// nothing to deliver
+		return nil, nil
+	}
+	onError := func(channel string, err error) {
+		logger.Warn("", "notification_failed", "Notification delivery failed", map[string]any{
+			"channel": channel,
+			"error":   err.Error(),
+		})
+	}
+	webhook := notify.NewWebhookSender(0)
+	spooled := newSpooledSender(&cfg.Notifications.Spool, webhook, logger, onError)
+	// post directly without a spool
+	if spooled == nil {
+		dispatcher := appnotification.NewDispatcher(cfg.Notifications, webhook, onError)
+		// return dispatcher posting to webhooks
+		return dispatcher, dispatcher.Close
+	}
+	dispatcher := appnotification.NewDispatcher(cfg.Notifications, spooled, onError)
+	// return dispatcher posting through the spool
+	return dispatcher, func() {
+		dispatcher.Close()
+		spooled.Close()
+	}
+}
+
+// newSpooledSender opens the notification spool.
+//
+// Params:
+//   - sc: the spool configuration.
+//   - webhook: the webhook sender.
+//   - logger: the daemon logger.
+//   - onError: reports failed replays.
+//
+// Returns:
+//   - *notify.SpooledSender: the sender, nil when spooling is disabled or unavailable.
+func newSpooledSender(sc *domainconfig.NotificationSpool, webhook *notify.WebhookSender, logger domainlogging.Logger, onError appnotification.ErrorHandler) *notify.SpooledSender {
+	// spooling is disabled without a directory
+	if !sc.Enabled() {
+		// no spool
+		return nil
+	}
+	var maxBytes int64
+	// size already validated with the configuration
+	if sc.MaxSize != "" {
+		maxBytes, _ = shared.ParseSize(sc.MaxSize)
+	}
+	sp, err := spool.New(sc.Dir, maxBytes)
+	// deliver without spool rather than not at all
+	if err != nil {
+		logger.Warn("", "notification_spool_unavailable", "Notification spool unavailable, delivering without it", map[string]any{
+			"dir":   sc.Dir,
+			"error": err.Error(),
+		})
+		// no spool
+		return nil
+	}
+	// return sender replaying the spool
+	return notify.NewSpooledSender(webhook, sp, sc.RetryInterval.Duration(), onError)
+}
+
+// startHeartbeats pings the configured heartbeat endpoints in the background
+// until the context is cancelled.
+//
+// Params:
+//   - ctx: the daemon context.
+//   - app: the application instance.
+//   - logger: the daemon logger reporting failed pings.
+func startHeartbeats(ctx context.Context, app *App, logger domainlogging.Logger) {
+	// heartbeats are disabled without endpoints
+	if app.Config == nil || len(app.Config.Notifications.Heartbeats) == 0 {
+		// nothing to ping
+		return
+	}
+	heartbeat := appnotification.NewHeartbeat(app.Config.Notifications.Heartbeats, app.Supervisor, notify.NewWebhookSender(0), func(endpoint string, err error) {
+		logger.Warn("", "heartbeat_failed", "Heartbeat ping failed", map[string]any{
+			"endpoint": endpoint,
+			"error":    err.Error(),
+		})
+	})
+	go heartbeat.Run(ctx)
+}
@@ -0,0 +1,60 @@
+// Package bootstrap provides internal tests for notifications.go.
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+)
+
+// Test_setupNotifications verifies the dispatcher is only created with channels.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_setupNotifications(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     func(dir string) *domainconfig.Config
+		wantNil bool
+	}{
+		{name: "nil_config", cfg: func(string) *domainconfig.Config { return nil }, wantNil: true},
+		{name: "no_channels", cfg: func(string) *domainconfig.Config { return &domainconfig.Config{} }, wantNil: true},
+		{
+			name: "webhook_channel",
+			cfg: func(string) *domainconfig.Config {
+				return &domainconfig.Config{Notifications: domainconfig.NotificationsConfig{
+					Channels: []domainconfig.NotificationChannel{{Name: "ops", URL: "http://127.0.0.1:9/hook"}},
+				}}
+			},
+		},
+		{
+			name: "spooled_channel",
+			cfg: func(dir string) *domainconfig.Config {
+				return &domainconfig.Config{Notifications: domainconfig.NotificationsConfig{
+					Channels: []domainconfig.NotificationChannel{{Name: "ops", URL: "http://127.0.0.1:9/hook"}},
+					Spool:    domainconfig.NotificationSpool{Dir: dir, MaxSize: "1MB"},
+				}}
+			},
+		},
+	}
+
+	// Run all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, closeFn := setupNotifications(tt.cfg(t.TempDir()), daemonlogger.New(&recordingWriter{}))
+			assert.Equal(t, tt.wantNil, d == nil)
+			assert.Equal(t, tt.wantNil, closeFn == nil)
+			// release the dispatcher
+			if closeFn != nil {
+				closeFn()
+			}
+		})
+	}
+}
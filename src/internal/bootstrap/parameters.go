@@ -0,0 +1,32 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	appnotification "github.com/kodflow/daemon/internal/application/notification"
+	appparameters "github.com/kodflow/daemon/internal/application/parameters"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	infraparameters "github.com/kodflow/daemon/internal/infrastructure/persistence/parameters"
+)
+
+// loadParameters applies the daemon parameters saved by a previous run,
+// so that runtime changes survive restarts.
+//
+// Params:
+//   - probes: the supervisor receiving the probe defaults.
+//   - logger: the daemon logger receiving the level override.
+//   - notifier: the notification dispatcher, nil without channels.
+func loadParameters(probes appparameters.ProbeDefaulter, logger domainlogging.Logger, notifier *appnotification.Dispatcher) {
+	levels, _ := logger.(appparameters.LevelSetter)
+	var muter appparameters.Muter
+	// avoid a typed nil without notification channels
+	if notifier != nil {
+		muter = notifier
+	}
+	service := appparameters.NewService(infraparameters.NewFile(""), levels, probes, muter)
+	// keep the configured settings when the saved ones are unusable
+	if err := service.Load(); err != nil {
+		logger.Warn("", "parameters_unavailable", "Saved daemon parameters ignored", map[string]any{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	infraconfig "github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+const (
+	// planCommand is the subcommand reporting what a reload would do.
+	planCommand string = "plan"
+	// defaultDaemonAddress is the address of the daemon API.
+	defaultDaemonAddress string = "localhost:50051"
+	// planTimeout bounds the call to the daemon.
+	planTimeout time.Duration = 30 * time.Second
+)
+
+// ErrPlanUsage indicates a malformed plan command line.
+var ErrPlanUsage error = errors.New("usage: supervizio plan -f FILE [--address HOST:PORT]")
+
+// runPlanMode reports what reloading a configuration would do to the
+// running daemon, without applying it.
+//
+// Params:
+//   - args: the arguments following the plan command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runPlanMode(args []string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), planTimeout)
+	defer cancel()
+	err := runPlan(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runPlan renders the configuration file, with its includes merged, and
+// asks the daemon for the plan of its reload.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the flags of the plan command.
+//   - out: the destination of the plan.
+//
+// Returns:
+//   - error: ErrPlanUsage, or the render or daemon error.
+func runPlan(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(planCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	file := flags.String("f", "", "configuration file to plan")
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	// a file and no extra argument are required
+	if err := flags.Parse(args); err != nil || *file == "" || flags.NArg() != 0 {
+		// return usage error
+		return ErrPlanUsage
+	}
+
+	data, err := infraconfig.NewLoader().RenderDecrypted(*file)
+	// configuration invalid or unreadable
+	if err != nil {
+		// return render error
+		return err
+	}
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	plan, err := client.PlanReload(ctx, data)
+	// daemon unreachable or reload refused
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("planning reload of %s: %w", *file, err)
+	}
+	writePlan(out, &plan)
+	// plan reported
+	return nil
+}
+
+// writePlan prints one line per service, then the totals.
+//
+// Params:
+//   - out: the destination of the plan.
+//   - plan: the reload plan.
+func writePlan(out io.Writer, plan *lifecycle.ReloadPlan) {
+	// describe each service
+	for i := range plan.Services {
+		svc := &plan.Services[i]
+		var line string
+		// explain the action
+		switch svc.Action {
+		// new service
+		case lifecycle.ReloadAdd:
+			line = "+ " + svc.Name + ": added, started"
+		// service left out
+		case lifecycle.ReloadRemove:
+			line = "- " + svc.Name + ": removed, stopped"
+		// kept service with changes
+		case lifecycle.ReloadRestart:
+			line = "~ " + svc.Name + ": restarted, unchanged"
+			// name the changed settings
+			if len(svc.Changes) > 0 {
+				line = "~ " + svc.Name + ": restarted, changed " + strings.Join(svc.Changes, ", ")
+			}
+		}
+		_, _ = fmt.Fprintln(out, line)
+	}
+	_, _ = fmt.Fprintf(out, "%d to add, %d to restart, %d to remove\n",
+		plan.Count(lifecycle.ReloadAdd), plan.Count(lifecycle.ReloadRestart), plan.Count(lifecycle.ReloadRemove))
+}
@@ -0,0 +1,72 @@
+// Package bootstrap provides internal tests for plan.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Test_runPlan_usage tests that malformed plan commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runPlan_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no file", args: nil},
+		{name: "extra argument", args: []string{"-f", "new.yaml", "now"}},
+		{name: "unknown flag", args: []string{"-f", "new.yaml", "--force"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runPlan(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrPlanUsage)
+		})
+	}
+}
+
+// Test_runPlan_errors tests that invalid files and unreachable daemons are reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_runPlan_errors(t *testing.T) {
+	err := runPlan(context.Background(), []string{"-f", filepath.Join("testdata", "invalid.yaml")}, &bytes.Buffer{})
+	assert.Equal(t, shared.CodeConfigInvalid, shared.CodeOf(err))
+
+	var out bytes.Buffer
+	err = runPlan(context.Background(), []string{"-f", filepath.Join("testdata", "valid.yaml"), "--address", "127.0.0.1:1"}, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "planning reload")
+	assert.Empty(t, out.String())
+}
+
+// Test_writePlan tests the printed plan.
+//
+// Params:
+//   - t: the testing context.
+func Test_writePlan(t *testing.T) {
+	var out bytes.Buffer
+	writePlan(&out, &lifecycle.ReloadPlan{Services: []lifecycle.ServicePlan{
+		{Name: "web", Action: lifecycle.ReloadRestart, Changes: []string{"command", "environment"}},
+		{Name: "worker", Action: lifecycle.ReloadRestart},
+		{Name: "api", Action: lifecycle.ReloadAdd},
+		{Name: "old", Action: lifecycle.ReloadRemove},
+	}})
+
+	assert.Equal(t, "~ web: restarted, changed command, environment\n"+
+		"~ worker: restarted, unchanged\n"+
+		"+ api: added, started\n"+
+		"- old: removed, stopped\n"+
+		"1 to add, 2 to restart, 1 to remove\n", out.String())
+}
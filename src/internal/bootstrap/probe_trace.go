@@ -0,0 +1,33 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// logProbeAttempt logs one attempt of a probe with debug enabled.
+// Attempts are logged at info level, or warn on failure, so tracing a
+// single probe does not require raising the daemon log level.
+//
+// Params:
+//   - logger: the daemon logger.
+//   - serviceName: the probed service.
+//   - attempt: the probe attempt.
+func logProbeAttempt(logger domainlogging.Logger, serviceName string, attempt *domainhealth.ProbeAttempt) {
+	meta := map[string]any{
+		"listener":   attempt.Listener,
+		"probe":      attempt.Type,
+		"target":     attempt.Target,
+		"success":    attempt.Success,
+		"latency_ms": attempt.Latency.Milliseconds(),
+	}
+	// failed attempts carry their reason
+	if !attempt.Success {
+		meta["error"] = attempt.Error
+		logger.Warn(serviceName, "probe_attempt", "Probe attempt failed", meta)
+		// failure logged
+		return
+	}
+	logger.Info(serviceName, "probe_attempt", "Probe attempt succeeded", meta)
+}
@@ -0,0 +1,62 @@
+// Package bootstrap provides internal tests for probe_trace.go.
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainhealth "github.com/kodflow/daemon/internal/domain/health"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+)
+
+// Test_logProbeAttempt verifies probe attempt logging.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_logProbeAttempt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		attempt   domainhealth.ProbeAttempt
+		wantLevel domainlogging.Level
+		wantError bool
+	}{
+		{
+			name:      "success",
+			attempt:   domainhealth.ProbeAttempt{Listener: "http", Type: "tcp", Target: "127.0.0.1:8080", Latency: time.Millisecond, Success: true},
+			wantLevel: domainlogging.LevelInfo,
+		},
+		{
+			name:      "failure",
+			attempt:   domainhealth.ProbeAttempt{Listener: "http", Type: "tcp", Target: "127.0.0.1:8080", Latency: time.Second, Error: "connection refused"},
+			wantLevel: domainlogging.LevelWarn,
+			wantError: true,
+		},
+	}
+
+	// Run all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := &recordingWriter{}
+			logger := daemonlogger.New(writer)
+
+			logProbeAttempt(logger, "api", &tt.attempt)
+
+			require.Len(t, writer.events, 1)
+			event := writer.events[0]
+			assert.Equal(t, "probe_attempt", event.EventType)
+			assert.Equal(t, "api", event.Service)
+			assert.Equal(t, tt.wantLevel, event.Level)
+			assert.Equal(t, tt.attempt.Target, event.Metadata["target"])
+			_, hasError := event.Metadata["error"]
+			assert.Equal(t, tt.wantError, hasError)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+const (
+	// probesCommand is the subcommand pausing and resuming service probes.
+	probesCommand string = "probes"
+	// probesPause is the probes action pausing probes.
+	probesPause string = "pause"
+	// probesResume is the probes action resuming probes.
+	probesResume string = "resume"
+)
+
+// ErrProbesUsage indicates a malformed probes command line.
+var ErrProbesUsage error = errors.New("usage: supervizio probes pause SERVICE [--listener NAME] [--ttl DURATION] " +
+	"[--address HOST:PORT] | supervizio probes resume SERVICE [--listener NAME] [--address HOST:PORT]")
+
+// runProbesMode pauses or resumes the probes of a service.
+//
+// Params:
+//   - args: the arguments following the probes command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runProbesMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runProbes(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runProbes asks the daemon to pause or resume the probes of a service, or
+// of one of its listeners, and prints the pauses left active.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the action, the service name and the flags of the probes command.
+//   - out: the destination of the active pauses.
+//
+// Returns:
+//   - error: ErrProbesUsage, or the daemon or write error.
+func runProbes(ctx context.Context, args []string, out io.Writer) error {
+	// an action is required
+	if len(args) == 0 || (args[0] != probesPause && args[0] != probesResume) {
+		// return usage error
+		return ErrProbesUsage
+	}
+	action, args := args[0], args[1:]
+	flags := flag.NewFlagSet(probesCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	listener := flags.String("listener", "", "listener whose probe to pause or resume, empty for all")
+	ttl := flags.Duration("ttl", 0, "length of the pause, 0 for the daemon default")
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	var service string
+	// the service name may precede the flags
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		service, args = args[0], args[1:]
+	}
+	// flags are valid and a resume has no length
+	if err := flags.Parse(args); err != nil || *ttl < 0 || (action == probesResume && *ttl != 0) {
+		// return usage error
+		return ErrProbesUsage
+	}
+	// the service name may follow the flags
+	if service == "" && flags.NArg() == 1 {
+		service = flags.Arg(0)
+	} else if flags.NArg() != 0 {
+		// return usage error
+		return ErrProbesUsage
+	}
+	// a service is required
+	if service == "" {
+		// return usage error
+		return ErrProbesUsage
+	}
+
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	var pauses []health.ProbePause
+	// run the requested action
+	if action == probesPause {
+		pauses, err = client.PauseProbes(ctx, &health.ProbePauseSpec{Service: service, Listener: *listener, TTL: *ttl})
+	} else {
+		pauses, err = client.ResumeProbes(ctx, service, *listener)
+	}
+	// daemon unreachable, unknown service or listener, or interrupted
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("%s probes of %s: %w", action, service, err)
+	}
+	// return write result
+	return writeProbePauses(out, service, pauses)
+}
+
+// writeProbePauses prints one line per active pause of a service.
+//
+// Params:
+//   - out: the destination of the pauses.
+//   - service: the service name.
+//   - pauses: the active pauses.
+//
+// Returns:
+//   - error: the write error.
+func writeProbePauses(out io.Writer, service string, pauses []health.ProbePause) error {
+	// say so rather than print nothing
+	if len(pauses) == 0 {
+		_, err := fmt.Fprintf(out, "probes of %s running\n", service)
+		// return write result
+		return err
+	}
+	// describe each pause
+	for _, pause := range pauses {
+		listener := "all listeners"
+		// name the paused listener
+		if pause.Listener != "" {
+			listener = "listener " + pause.Listener
+		}
+		// stop at the first write error
+		if _, err := fmt.Fprintf(out, "%s %s paused until %s\n", service, listener, pause.Until.Local().Format(time.RFC3339)); err != nil {
+			// return write error
+			return err
+		}
+	}
+	// all pauses printed
+	return nil
+}
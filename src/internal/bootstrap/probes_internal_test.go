@@ -0,0 +1,89 @@
+// Package bootstrap provides internal tests for probes.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// Test_runProbes_usage tests that malformed probes commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runProbes_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no action", args: nil},
+		{name: "unknown action", args: []string{"stop", "api"}},
+		{name: "no service", args: []string{"pause"}},
+		{name: "flags only", args: []string{"pause", "--ttl", "1h"}},
+		{name: "two services", args: []string{"pause", "api", "web"}},
+		{name: "negative ttl", args: []string{"pause", "api", "--ttl", "-1m"}},
+		{name: "resume with ttl", args: []string{"resume", "api", "--ttl", "1m"}},
+		{name: "unknown flag", args: []string{"resume", "api", "--force"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runProbes(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrProbesUsage)
+		})
+	}
+}
+
+// Test_runProbes_unreachable tests that an unreachable daemon is reported,
+// whether the service precedes or follows the flags.
+//
+// Params:
+//   - t: the testing context.
+func Test_runProbes_unreachable(t *testing.T) {
+	for _, args := range [][]string{
+		{"pause", "api", "--listener", "http", "--ttl", "30m", "--address", "127.0.0.1:1"},
+		{"resume", "--address", "127.0.0.1:1", "api"},
+	} {
+		var out bytes.Buffer
+		err := runProbes(context.Background(), args, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), args[0]+" probes of api")
+		assert.Empty(t, out.String())
+	}
+}
+
+// Test_writeProbePauses tests the printed pauses.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeProbePauses(t *testing.T) {
+	until := time.Date(2026, 1, 15, 10, 30, 0, 0, time.Local)
+	stamp := until.Format(time.RFC3339)
+
+	tests := []struct {
+		name   string
+		pauses []health.ProbePause
+		want   string
+	}{
+		{name: "none", want: "probes of api running\n"},
+		{
+			name:   "paused",
+			pauses: []health.ProbePause{{Until: until}, {Listener: "http", Until: until}},
+			want:   "api all listeners paused until " + stamp + "\napi listener http paused until " + stamp + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, writeProbePauses(&out, "api", tt.pauses))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -9,10 +9,15 @@ import (
 
 	appconfig "github.com/kodflow/daemon/internal/application/config"
 	apphealth "github.com/kodflow/daemon/internal/application/health"
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
 	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
 	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
 	domainconfig "github.com/kodflow/daemon/internal/domain/config"
 	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domainprocess "github.com/kodflow/daemon/internal/domain/process"
 	infrahealthcheck "github.com/kodflow/daemon/internal/infrastructure/observability/healthcheck"
 )
 
@@ -36,7 +41,29 @@ type supervisorConfigurer interface {
 	Reload() error
 	SetProberFactory(factory apphealth.Creator)
 	SetMetricsTracker(tracker appmetrics.ProcessTracker)
+	SetCapacityCollector(collector appmetrics.CapacityCollector)
+	SetHostPressureCollector(collector appmetrics.HostPressureCollector)
+	SetSelfCollector(collector appmetrics.SelfCollector)
+	SetHostFactsCollector(collector appmetrics.HostFactsCollector)
+	SetHostInventoryCollector(collector appmetrics.HostInventoryCollector)
+	SetResourceLedger(ledger domainprocess.ResourceLedger)
+	SetTreeReader(reader domainprocess.TreeReader)
+	SetSocketReader(reader domainprocess.SocketReader)
+	SetPreflighter(preflighter appconfig.Preflighter)
+	SetProxyOpener(opener appproxy.Opener)
+	SetFileWatcher(watcher appintegrity.Watcher)
+	SetHookRunner(runner apphook.Runner)
+	SetCertificateInspector(inspector apphealth.CertificateInspector)
 	SetEventHandler(handler appsupervisor.EventHandler)
+	SetBootHandler(handler appsupervisor.BootHandler)
+	SetShutdownHandler(handler appsupervisor.ShutdownHandler)
+	SetProbeTraceHandler(handler appsupervisor.ProbeTraceHandler)
+	SetProbeDefaults(interval, timeout time.Duration)
+	SetLockdown(on bool)
+	OnTransition(from, to appsupervisor.State, hook appsupervisor.StateHook)
+	State() appsupervisor.State
+	Healthy(services []string) bool
+	HostInventory(ctx context.Context) (domainmetrics.HostInventory, error)
 }
 
 // ProvideReaper returns the zombie reaper only if running as PID 1.
@@ -98,36 +125,130 @@ func ProvideProberFactory() *infrahealthcheck.Factory {
 	return infrahealthcheck.NewFactory(defaultProbeTimeout)
 }
 
+// ProvideCertificateInspector creates the adapter retrieving the
+// certificates of tls listeners.
+//
+// Returns:
+//   - *infrahealthcheck.CertificateInspector: the certificate inspector instance.
+func ProvideCertificateInspector() *infrahealthcheck.CertificateInspector {
+	// construct inspector with default probe timeout
+	return infrahealthcheck.NewCertificateInspector(defaultProbeTimeout)
+}
+
 // ProvideMetricsTracker creates a metrics tracker with a platform-specific collector.
+// The metrics configuration selects the collection interval and which cgroup
+// collectors are enabled, as expanded from the performance template. Cgroup
+// collectors the host cannot serve are left out. CPU times are read from
+// procfs and reported in the configured percent mode. PSS, USS, swap and
+// major faults are read from smaps when enabled.
 //
 // Params:
+//   - cfg: the domain configuration holding metrics settings.
 //   - collector: the process metrics collector.
+//   - cpu: the procfs CPU times collector.
+//   - memDetail: the procfs memory attribution collector.
+//   - throttling: the cgroup CPU throttling collector.
+//   - pressure: the cgroup PSI collector.
+//   - inventory: the host inventory collector, nil to skip host detection.
 //
 // Returns:
 //   - *appmetrics.Tracker: the metrics tracker instance.
-func ProvideMetricsTracker(collector appmetrics.Collector) *appmetrics.Tracker {
-	// construct tracker with platform collector
-	return appmetrics.NewTracker(collector)
+func ProvideMetricsTracker(
+	cfg *domainconfig.Config,
+	collector appmetrics.Collector,
+	cpu appmetrics.CPUCollector,
+	memDetail appmetrics.MemoryDetailCollector,
+	throttling appmetrics.ThrottlingCollector,
+	pressure appmetrics.PressureCollector,
+	inventory appmetrics.HostInventoryCollector,
+) *appmetrics.Tracker {
+	metricsCfg := &cfg.Monitoring.Metrics
+	opts := []appmetrics.TrackerOption{
+		appmetrics.WithCollectionInterval(metricsCfg.Interval.Duration()),
+		appmetrics.WithCPUCollector(cpu),
+		appmetrics.WithCPUPercentMode(metricsCfg.CPU.PercentMode),
+	}
+	// enable memory attribution when smaps reads are configured
+	if metricsCfg.SmapsEnabled() {
+		opts = append(opts, appmetrics.WithMemoryDetailCollector(memDetail))
+	}
+	host := detectHost(inventory)
+	// enable cgroup throttling collection when CPU metrics are enabled and the host has cgroups
+	if metricsCfg.ThrottlingEnabled() && hostSupports(host, domainmetrics.FeatureCgroupMetrics) {
+		opts = append(opts, appmetrics.WithThrottlingCollector(throttling))
+	}
+	// enable cgroup PSI collection when pressure metrics or alerts are configured and the host has cgroup PSI
+	if metricsCfg.PressureEnabled() && hostSupports(host, domainmetrics.FeatureCgroupPressure) {
+		opts = append(opts, appmetrics.WithPressureCollector(pressure))
+	}
+	// construct tracker with platform and cgroup collectors
+	return appmetrics.NewTracker(collector, opts...)
 }
 
 // NewAppWithHealth creates the App struct with health monitoring and metrics wired.
 // This provider connects the health prober factory and metrics tracker to the supervisor,
 // enabling health-probe-triggered restarts following the Kubernetes
-// liveness probe pattern and process CPU/memory tracking.
+// liveness probe pattern, process CPU/memory tracking and the host facts
+// checked by service start conditions and the host capabilities disabling
+// unsupported features. It also installs
+// the pre-flight checker that guards configuration reloads, the
+// adapter binding public endpoints of proxied listeners, the watcher
+// of service files, the runner of watch hooks, the inspector of
+// listener certificates, the reader of service process trees and the
+// reader of service sockets.
 //
 // Params:
 //   - sup: the configured supervisor instance (minimal interface).
 //   - factory: the health prober factory.
 //   - tracker: the metrics tracker for CPU/memory monitoring.
+//   - capacity: the host capacity collector for reservation admission.
+//   - hostPressure: the host pressure collector for shedding.
+//   - self: the daemon process collector for daemon usage.
+//   - facts: the host facts collector for service start conditions.
+//   - inventory: the host inventory collector disabling unsupported features.
+//   - ledger: the executor resource ledger for leak checks.
+//   - trees: the process tree reader for service process trees.
+//   - sockets: the socket reader for the sampled dependency map.
+//   - preflighter: the pre-flight checker run before applying a reload.
+//   - opener: the relay opener for proxied listeners.
+//   - watcher: the file watcher for service files.
+//   - runner: the runner of watch hook commands.
+//   - inspector: the certificate inspector for tls listeners.
 //   - cfg: the domain configuration for daemon logging.
 //
 // Returns:
 //   - *App: the application container with health monitoring and metrics enabled.
-func NewAppWithHealth(sup supervisorConfigurer, factory apphealth.Creator, tracker *appmetrics.Tracker, cfg *domainconfig.Config) *App {
+func NewAppWithHealth(sup supervisorConfigurer, factory apphealth.Creator, tracker *appmetrics.Tracker, capacity appmetrics.CapacityCollector, hostPressure appmetrics.HostPressureCollector, self appmetrics.SelfCollector, facts appmetrics.HostFactsCollector, inventory appmetrics.HostInventoryCollector, ledger domainprocess.ResourceLedger, trees domainprocess.TreeReader, sockets domainprocess.SocketReader, preflighter appconfig.Preflighter, opener appproxy.Opener, watcher appintegrity.Watcher, runner apphook.Runner, inspector apphealth.CertificateInspector, cfg *domainconfig.Config) *App {
 	// configure supervisor with prober factory
 	sup.SetProberFactory(factory)
 	// configure supervisor with metrics tracker
 	sup.SetMetricsTracker(tracker)
+	// configure supervisor with host capacity admission
+	sup.SetCapacityCollector(capacity)
+	// configure supervisor with host pressure shedding
+	sup.SetHostPressureCollector(hostPressure)
+	// configure supervisor with daemon process usage
+	sup.SetSelfCollector(self)
+	// configure supervisor with service start conditions
+	sup.SetHostFactsCollector(facts)
+	// configure supervisor with host capability detection
+	sup.SetHostInventoryCollector(inventory)
+	// configure supervisor with executor leak checks
+	sup.SetResourceLedger(ledger)
+	// configure supervisor with service process trees
+	sup.SetTreeReader(trees)
+	// configure supervisor with connection sampling
+	sup.SetSocketReader(sockets)
+	// configure supervisor with reload pre-flight checks
+	sup.SetPreflighter(preflighter)
+	// configure supervisor with listener proxy adapter
+	sup.SetProxyOpener(opener)
+	// configure supervisor with file integrity watcher
+	sup.SetFileWatcher(watcher)
+	// configure supervisor with watch hook runner
+	sup.SetHookRunner(runner)
+	// configure supervisor with listener certificate checks
+	sup.SetCertificateInspector(inspector)
 
 	// construct app with all components
 	return &App{
@@ -8,6 +8,15 @@ import (
 	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
 	"github.com/kodflow/daemon/internal/bootstrap"
 	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	infraintegrity "github.com/kodflow/daemon/internal/infrastructure/observability/integrity"
+	"github.com/kodflow/daemon/internal/infrastructure/process/cgroup"
+	"github.com/kodflow/daemon/internal/infrastructure/process/credentials"
+	"github.com/kodflow/daemon/internal/infrastructure/process/executor"
+	infrahook "github.com/kodflow/daemon/internal/infrastructure/process/hook"
+	"github.com/kodflow/daemon/internal/infrastructure/process/hostinfo"
+	"github.com/kodflow/daemon/internal/infrastructure/process/inspect"
+	"github.com/kodflow/daemon/internal/infrastructure/process/preflight"
+	infraproxy "github.com/kodflow/daemon/internal/infrastructure/transport/proxy"
 )
 
 // mockReaper is a test double for bootstrap.ReaperMinimal interface.
@@ -312,14 +321,16 @@ func TestNewAppWithHealth(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			// Create a supervisor, factory, tracker, and config.
+			// Create a supervisor, factory, tracker, cgroup reader, pre-flight checker, proxy opener, and config.
 			sup := &appsupervisor.Supervisor{}
 			factory := bootstrap.ProvideProberFactory()
 			tracker := appmetrics.NewTracker(nil)
+			checker := preflight.New(credentials.New())
+			reader := cgroup.New()
 			cfg := &domainconfig.Config{}
 
 			// Call NewAppWithHealth.
-			app := bootstrap.NewAppWithHealth(sup, factory, tracker, cfg)
+			app := bootstrap.NewAppWithHealth(sup, factory, tracker, reader, reader, reader, reader, hostinfo.New(), executor.New(), inspect.New(), inspect.New(), checker, infraproxy.New(), infraintegrity.New(), infrahook.New(), bootstrap.ProvideCertificateInspector(), cfg)
 
 			// Verify app was created.
 			if app == nil {
@@ -347,10 +358,16 @@ func TestProvideMetricsTracker(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name string
+		name     string
+		template domainconfig.MetricsTemplate
 	}{
 		{
-			name: "returns_non_nil_tracker",
+			name:     "returns_non_nil_tracker",
+			template: domainconfig.MetricsTemplateStandard,
+		},
+		{
+			name:     "minimal_template_without_pressure",
+			template: domainconfig.MetricsTemplateMinimal,
 		},
 	}
 
@@ -359,8 +376,11 @@ func TestProvideMetricsTracker(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			// Call ProvideMetricsTracker with nil collector.
-			result := bootstrap.ProvideMetricsTracker(nil)
+			cfg := &domainconfig.Config{}
+			cfg.Monitoring.Metrics = domainconfig.MetricsConfigForTemplate(tt.template)
+
+			// Call ProvideMetricsTracker with nil collectors.
+			result := bootstrap.ProvideMetricsTracker(cfg, nil, nil, nil, nil, nil, nil)
 
 			// Verify tracker is not nil.
 			if result == nil {
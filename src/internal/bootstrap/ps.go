@@ -0,0 +1,179 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/tui/widget"
+)
+
+// psCommand is the subcommand listing the services of the daemon.
+const psCommand string = "ps"
+
+// psFields are the process fields printed by the ps command.
+var psFields []string = []string{"pid", "state", "cpu", "memory", "restart_count", "uptime"}
+
+// ErrPsUsage indicates a malformed ps command line.
+var ErrPsUsage error = errors.New("usage: supervizio ps [--label KEY=VALUE]... [--state STATE]... " +
+	"[--sort KEY] [--desc] [--limit N] [--page-token TOKEN] [--address HOST:PORT]")
+
+// runPsMode lists the services of the running daemon matching the filters.
+//
+// Params:
+//   - args: the arguments following the ps command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runPsMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runPs(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runPs asks the daemon for a page of its services and prints it. Filters,
+// sorting and paging run in the daemon, so large hosts send only the page.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the flags of the ps command.
+//   - out: the destination of the table.
+//
+// Returns:
+//   - error: ErrPsUsage, or the daemon or write error.
+func runPs(ctx context.Context, args []string, out io.Writer) error {
+	query := infragrpc.ProcessQuery{Labels: map[string]string{}, Fields: psFields}
+	flags := flag.NewFlagSet(psCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Func("label", "label KEY=VALUE the services must carry", func(value string) error {
+		key, val, ok := strings.Cut(value, "=")
+		// labels need a key
+		if !ok || key == "" {
+			// return usage error
+			return ErrPsUsage
+		}
+		query.Labels[key] = val
+		// label recorded
+		return nil
+	})
+	flags.Func("state", "state the services must be in", func(value string) error {
+		state, ok := parseProcessState(value)
+		// unknown state
+		if !ok {
+			// return usage error
+			return ErrPsUsage
+		}
+		query.States = append(query.States, state)
+		// state recorded
+		return nil
+	})
+	sortKey := flags.String("sort", "", "sort key: name, state, cpu, memory, restarts or uptime")
+	descending := flags.Bool("desc", false, "reverse the sort order")
+	flags.IntVar(&query.PageSize, "limit", 0, "largest number of services to print")
+	flags.StringVar(&query.PageToken, "page-token", "", "token of the page to print")
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	// flags are valid and the limit is not negative
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || query.PageSize < 0 {
+		// return usage error
+		return ErrPsUsage
+	}
+	query.OrderBy = *sortKey
+	// reverse the order of the key, names by default
+	if *descending {
+		query.OrderBy = cmp.Or(*sortKey, "name") + " desc"
+	}
+
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	page, err := client.ListProcesses(ctx, &query)
+	// daemon unreachable or query refused
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("listing services: %w", err)
+	}
+	// return write result
+	return writePs(out, &page)
+}
+
+// parseProcessState returns the process state of a name.
+//
+// Params:
+//   - name: the state name, as printed by the ps command.
+//
+// Returns:
+//   - process.State: the state.
+//   - bool: false for an unknown name.
+func parseProcessState(name string) (process.State, bool) {
+	// match the name of each state
+	for state := process.StateStopped; state <= process.StateSkipped; state++ {
+		// names are case insensitive
+		if strings.EqualFold(state.String(), name) {
+			// return matching state
+			return state, true
+		}
+	}
+	// unknown state
+	return process.StateStopped, false
+}
+
+// writePs prints one line per service of a page, then how to get the next page.
+//
+// Params:
+//   - out: the destination of the table.
+//   - page: the page of services.
+//
+// Returns:
+//   - error: the write error.
+func writePs(out io.Writer, page *infragrpc.ProcessPage) error {
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "SERVICE\tSTATE\tPID\tCPU%\tMEM\tRESTARTS\tUPTIME")
+	// describe each service
+	for i := range page.Processes {
+		proc := &page.Processes[i]
+		pid, uptime := "-", "-"
+		// only running processes have a pid and an uptime
+		if proc.PID > 0 {
+			pid, uptime = strconv.Itoa(proc.PID), widget.FormatDurationShort(proc.Uptime)
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%.1f\t%s\t%d\t%s\n",
+			proc.ServiceName, proc.State, pid, proc.CPU.UsagePercent,
+			widget.FormatBytesShort(proc.Memory.RSS), proc.RestartCount, uptime)
+	}
+	// align the columns before the footer
+	if err := table.Flush(); err != nil {
+		// return write error
+		return err
+	}
+	// the whole list was printed
+	if page.NextPageToken == "" {
+		// nothing more to print
+		return nil
+	}
+	_, err := fmt.Fprintf(out, "%d of %d services, next page: --page-token %s\n",
+		len(page.Processes), page.Total, page.NextPageToken)
+	// return write result
+	return err
+}
@@ -0,0 +1,93 @@
+// Package bootstrap provides internal tests for ps.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/process"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// Test_runPs_usage tests that malformed ps commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runPs_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "argument", args: []string{"api"}},
+		{name: "label without value", args: []string{"--label", "team"}},
+		{name: "unknown state", args: []string{"--state", "sleeping"}},
+		{name: "negative limit", args: []string{"--limit", "-1"}},
+		{name: "unknown flag", args: []string{"--depth", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runPs(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrPsUsage)
+		})
+	}
+}
+
+// Test_runPs_unreachable tests that an unreachable daemon is reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_runPs_unreachable(t *testing.T) {
+	var out bytes.Buffer
+	err := runPs(context.Background(), []string{"--state", "running", "--address", "127.0.0.1:1"}, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listing services")
+	assert.Empty(t, out.String())
+}
+
+// Test_parseProcessState tests the state names accepted by --state.
+//
+// Params:
+//   - t: the testing context.
+func Test_parseProcessState(t *testing.T) {
+	state, ok := parseProcessState("Failed")
+	assert.True(t, ok)
+	assert.Equal(t, process.StateFailed, state)
+
+	state, ok = parseProcessState("skipped")
+	assert.True(t, ok)
+	assert.Equal(t, process.StateSkipped, state)
+
+	_, ok = parseProcessState("sleeping")
+	assert.False(t, ok)
+}
+
+// Test_writePs tests the printed table and the next page hint.
+//
+// Params:
+//   - t: the testing context.
+func Test_writePs(t *testing.T) {
+	page := infragrpc.ProcessPage{
+		Processes: []metrics.ProcessMetrics{
+			{ServiceName: "api", State: process.StateRunning, PID: 42, RestartCount: 3,
+				CPU: metrics.ProcessCPU{UsagePercent: 12.5}, Memory: metrics.ProcessMemory{RSS: 2048}, Uptime: 90 * time.Second},
+			{ServiceName: "cron", State: process.StateStopped},
+		},
+	}
+	var out bytes.Buffer
+	require.NoError(t, writePs(&out, &page))
+	assert.Equal(t, "SERVICE  STATE    PID  CPU%  MEM    RESTARTS  UPTIME\n"+
+		"api      running  42   12.5  2.00K  3         1m\n"+
+		"cron     stopped  -    0.0   0B     0         -\n", out.String())
+
+	page.NextPageToken, page.Total = "Mg", 5
+	out.Reset()
+	require.NoError(t, writePs(&out, &page))
+	assert.Contains(t, out.String(), "2 of 5 services, next page: --page-token Mg\n")
+}
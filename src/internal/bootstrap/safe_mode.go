@@ -0,0 +1,138 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+const (
+	// safeModeCommand is the subcommand reporting, entering and exiting safe mode.
+	safeModeCommand string = "safe-mode"
+	// safeModeStatus is the safe-mode action reporting safe mode, the default.
+	safeModeStatus string = "status"
+	// safeModeOn is the safe-mode action entering safe mode.
+	safeModeOn string = "on"
+	// safeModeOff is the safe-mode action exiting safe mode.
+	safeModeOff string = "off"
+)
+
+// ErrSafeModeUsage indicates a malformed safe-mode command line.
+var ErrSafeModeUsage error = errors.New("usage: supervizio safe-mode [status|on|off] [--address HOST:PORT]")
+
+// runSafeModeMode reports, enters or exits the safe mode of the daemon.
+//
+// Params:
+//   - args: the arguments following the safe-mode command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runSafeModeMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runSafeMode(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runSafeMode asks the daemon for its safe mode, or to enter or exit it,
+// and prints the resulting safe mode.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the action and the flags of the safe-mode command.
+//   - out: the destination of the safe mode.
+//
+// Returns:
+//   - error: ErrSafeModeUsage, or the daemon or write error.
+func runSafeMode(ctx context.Context, args []string, out io.Writer) error {
+	action := safeModeStatus
+	// the action may be omitted
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+	flags := flag.NewFlagSet(safeModeCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	// flags are valid and the action is known
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 ||
+		(action != safeModeStatus && action != safeModeOn && action != safeModeOff) {
+		// return usage error
+		return ErrSafeModeUsage
+	}
+
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	var mode process.SafeMode
+	// run the requested action
+	switch action {
+	// enter safe mode
+	case safeModeOn:
+		mode, err = client.SetSafeMode(ctx, true)
+	// exit safe mode, releasing the held restarts
+	case safeModeOff:
+		mode, err = client.SetSafeMode(ctx, false)
+	// report safe mode
+	default:
+		mode, err = client.SafeMode(ctx)
+	}
+	// daemon unreachable, read-only or interrupted
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("safe mode %s: %w", action, err)
+	}
+	// return write result
+	return writeSafeMode(out, &mode)
+}
+
+// writeSafeMode prints the safe mode: why it was entered, since when, and
+// the services whose restart it holds.
+//
+// Params:
+//   - out: the destination of the safe mode.
+//   - mode: the safe mode.
+//
+// Returns:
+//   - error: the write error.
+func writeSafeMode(out io.Writer, mode *process.SafeMode) error {
+	// restarts proceed
+	if !mode.Active {
+		_, err := fmt.Fprintln(out, "safe mode off")
+		// return write result
+		return err
+	}
+	reason := "on request"
+	// name the storm that entered safe mode
+	if !mode.Manual {
+		reason = fmt.Sprintf("after %d restarts within %s", mode.Restarts, mode.Window)
+	}
+	held := "none"
+	// list the waiting services
+	if len(mode.Held) > 0 {
+		held = strings.Join(mode.Held, ", ")
+	}
+	_, err := fmt.Fprintf(out, "safe mode on since %s, %s\nheld restarts: %s\n",
+		mode.Since.Local().Format(time.RFC3339), reason, held)
+	// return write result
+	return err
+}
@@ -0,0 +1,94 @@
+// Package bootstrap provides internal tests for safe_mode.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_runSafeMode_usage tests that malformed safe-mode commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runSafeMode_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown action", args: []string{"toggle"}},
+		{name: "two actions", args: []string{"on", "off"}},
+		{name: "unknown flag", args: []string{"off", "--force"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSafeMode(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrSafeModeUsage)
+		})
+	}
+}
+
+// Test_runSafeMode_unreachable tests that an unreachable daemon is reported,
+// with or without an action.
+//
+// Params:
+//   - t: the testing context.
+func Test_runSafeMode_unreachable(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"--address", "127.0.0.1:1"}, want: "safe mode status"},
+		{args: []string{"off", "--address", "127.0.0.1:1"}, want: "safe mode off"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := runSafeMode(context.Background(), tt.args, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.want)
+		assert.Empty(t, out.String())
+	}
+}
+
+// Test_writeSafeMode tests the printed safe mode.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeSafeMode(t *testing.T) {
+	since := time.Date(2026, 1, 15, 10, 30, 0, 0, time.Local)
+	stamp := since.Format(time.RFC3339)
+
+	tests := []struct {
+		name string
+		mode process.SafeMode
+		want string
+	}{
+		{name: "off", want: "safe mode off\n"},
+		{
+			name: "storm",
+			mode: process.SafeMode{Active: true, Since: since, Restarts: 21, Window: time.Minute, Held: []string{"api", "web"}},
+			want: "safe mode on since " + stamp + ", after 21 restarts within 1m0s\nheld restarts: api, web\n",
+		},
+		{
+			name: "manual",
+			mode: process.SafeMode{Active: true, Manual: true, Since: since},
+			want: "safe mode on since " + stamp + ", on request\nheld restarts: none\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, writeSafeMode(&out, &tt.mode))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"os"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/notify"
+)
+
+// shutdownReporter delivers the shutdown report.
+type shutdownReporter interface {
+	Deliver(ctx context.Context, report *domainlifecycle.ShutdownReport) error
+}
+
+// setupShutdownReport delivers the final statistics and health of the
+// services to the configured file and webhook once a graceful shutdown has
+// stopped them.
+//
+// Params:
+//   - sup: the supervisor reporting the shutdown.
+//   - cfg: the daemon configuration.
+//   - logger: the daemon logger.
+func setupShutdownReport(sup AppSupervisor, cfg *domainconfig.Config, logger domainlogging.Logger) {
+	// the report is disabled without destination
+	if cfg == nil || !cfg.ShutdownReport.Enabled() {
+		// nothing to deliver
+		return
+	}
+	host, _ := os.Hostname()
+	reporter := notify.NewShutdownReporter(&cfg.ShutdownReport, host)
+	sup.SetShutdownHandler(func(report domainlifecycle.ShutdownReport) {
+		deliverShutdownReport(reporter, &cfg.ShutdownReport, &report, logger)
+	})
+}
+
+// deliverShutdownReport delivers the report within the configured timeout,
+// so an unreachable webhook cannot hold the shutdown.
+//
+// Params:
+//   - reporter: the report destinations.
+//   - cfg: the shutdown report configuration.
+//   - report: the shutdown report.
+//   - logger: the daemon logger.
+func deliverShutdownReport(reporter shutdownReporter, cfg *domainconfig.ShutdownReportConfig, report *domainlifecycle.ShutdownReport, logger domainlogging.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.EffectiveTimeout())
+	defer cancel()
+	meta := map[string]any{
+		"services":  len(report.Services),
+		"uptime_ms": report.Uptime().Milliseconds(),
+	}
+	// a failing destination is reported but does not fail the shutdown
+	if err := reporter.Deliver(ctx, report); err != nil {
+		meta["error"] = err.Error()
+		logger.Warn("", "shutdown_report_failed", "Shutdown report delivery failed", meta)
+		return
+	}
+	logger.Info("", "shutdown_report", "Shutdown report delivered", meta)
+}
@@ -0,0 +1,81 @@
+// Package bootstrap provides internal tests for shutdown_report.go.
+package bootstrap
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+)
+
+// Test_setupShutdownReport verifies the report is delivered once the
+// supervisor reports the shutdown, and only when a destination is set.
+//
+// Params:
+//   - t: testing context for assertions.
+func Test_setupShutdownReport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		path        string
+		wantHandler bool
+		wantLevel   domainlogging.Level
+	}{
+		{name: "disabled"},
+		{name: "written", path: "reports/shutdown.json", wantHandler: true, wantLevel: domainlogging.LevelInfo},
+		{name: "unwritable", path: "blocker/shutdown.json", wantHandler: true, wantLevel: domainlogging.LevelWarn},
+	}
+
+	// Run all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "blocker"), nil, 0o600))
+			cfg := &domainconfig.Config{}
+			// Point the report into the temporary directory.
+			if tt.path != "" {
+				cfg.ShutdownReport.Path = filepath.Join(dir, tt.path)
+			}
+			writer := &recordingWriter{}
+			sup := &mockAppSupervisor{}
+
+			setupShutdownReport(sup, cfg, daemonlogger.New(writer))
+			// Without destination, no handler is set.
+			if !tt.wantHandler {
+				assert.Nil(t, sup.shutdownHandler)
+				return
+			}
+			require.NotNil(t, sup.shutdownHandler)
+
+			started := time.Now()
+			sup.shutdownHandler(domainlifecycle.ShutdownReport{
+				StartedAt: started,
+				StoppedAt: started.Add(time.Minute),
+				Services:  []domainlifecycle.ServiceShutdown{{Stats: metrics.ServiceStats{ServiceName: "api", StartCount: 1}}},
+			})
+
+			require.Len(t, writer.events, 1)
+			assert.Equal(t, tt.wantLevel, writer.events[0].Level)
+			assert.Equal(t, 1, writer.events[0].Metadata["services"])
+			// A delivered report is on disk.
+			if tt.wantLevel == domainlogging.LevelInfo {
+				data, err := os.ReadFile(cfg.ShutdownReport.Path)
+				require.NoError(t, err)
+				assert.True(t, json.Valid(data))
+			}
+		})
+	}
+}
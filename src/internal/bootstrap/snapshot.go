@@ -0,0 +1,202 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	appsnapshot "github.com/kodflow/daemon/internal/application/snapshot"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/storage"
+	infraconfig "github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+	infrasnapshot "github.com/kodflow/daemon/internal/infrastructure/persistence/snapshot"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/storage/boltdb"
+)
+
+const (
+	// snapshotCommand is the subcommand saving and restoring bundles.
+	snapshotCommand string = "snapshot"
+	// snapshotFileMode is the permission of saved bundles, which hold the configuration.
+	snapshotFileMode os.FileMode = 0o600
+	// stateDirMode is the permission of the created statistics directory.
+	stateDirMode os.FileMode = 0o750
+)
+
+// ErrSnapshotUsage indicates a malformed snapshot command line.
+var ErrSnapshotUsage error = errors.New("usage: supervizio [--config FILE] snapshot save|restore BUNDLE")
+
+// runSnapshotMode saves or restores a bundle of the configuration and
+// persisted state. This is a standalone mode that doesn't start the supervisor.
+//
+// Params:
+//   - args: the arguments following the snapshot command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runSnapshotMode(args []string) int {
+	err := runSnapshot(context.Background(), args, configPath, storage.DefaultStoreConfig(), os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runSnapshot runs a snapshot command.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the action and the bundle path.
+//   - cfgPath: the configuration file.
+//   - store: the statistics store settings.
+//   - out: the destination of the summary.
+//
+// Returns:
+//   - error: ErrSnapshotUsage, or the save or restore error.
+func runSnapshot(ctx context.Context, args []string, cfgPath string, store storage.StoreConfig, out io.Writer) error {
+	// an action and a bundle path are required
+	if len(args) != 2 {
+		// return usage error
+		return ErrSnapshotUsage
+	}
+	action, bundlePath := args[0], args[1]
+	// dispatch on action
+	switch action {
+	// bundle the configuration and statistics
+	case "save":
+		// return save result
+		return saveSnapshot(ctx, cfgPath, bundlePath, store, out)
+	// replace the configuration and statistics
+	case "restore":
+		// return restore result
+		return restoreSnapshot(ctx, cfgPath, bundlePath, store, out)
+	default:
+		// return usage error
+		return ErrSnapshotUsage
+	}
+}
+
+// saveSnapshot writes a bundle file. Statistics are included when the
+// statistics store exists; the partial file is removed on failure.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - cfgPath: the configuration file.
+//   - bundlePath: the bundle to write.
+//   - store: the statistics store settings.
+//   - out: the destination of the summary.
+//
+// Returns:
+//   - error: the save error.
+func saveSnapshot(ctx context.Context, cfgPath, bundlePath string, store storage.StoreConfig, out io.Writer) error {
+	var stats appsnapshot.StatsStore
+	// only read an existing store, never create one
+	if _, err := os.Stat(store.Path); err == nil {
+		db, err := openStatsStore(store)
+		// store locked or corrupt
+		if err != nil {
+			// return store error
+			return err
+		}
+		defer func() { _ = db.Close() }()
+		stats = db
+	}
+
+	file, err := os.OpenFile(bundlePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, snapshotFileMode) // #nosec G304 - bundle path is operator input
+	// destination not writable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("creating snapshot: %w", err)
+	}
+	service := appsnapshot.NewService(infraconfig.NewLoader(), infrasnapshot.NewTarball(), stats, version)
+	bundle, err := service.Save(ctx, cfgPath, file)
+	err = errors.Join(err, file.Close())
+	// remove the partial bundle
+	if err != nil {
+		_ = os.Remove(bundlePath)
+		// return save error
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "snapshot saved to %s (%d service statistics)\n", bundlePath, len(bundle.Stats))
+	// bundle written
+	return nil
+}
+
+// restoreSnapshot replaces the configuration file and the statistics with
+// those of a bundle. The daemon must be stopped, as it holds the store. A
+// configuration with a read-only control plane is never replaced.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - cfgPath: the configuration file to replace.
+//   - bundlePath: the bundle to read.
+//   - store: the statistics store settings.
+//   - out: the destination of the summary.
+//
+// Returns:
+//   - error: domainconfig.ErrReadOnly, or the restore error.
+func restoreSnapshot(ctx context.Context, cfgPath, bundlePath string, store storage.StoreConfig, out io.Writer) error {
+	// changes go through configuration management only
+	if current, err := infraconfig.NewLoader().Load(cfgPath); err == nil && current.Control.ReadOnly {
+		// return refusal
+		return fmt.Errorf("restoring snapshot over %s: %w", cfgPath, domainconfig.ErrReadOnly)
+	}
+	file, err := os.Open(bundlePath) // #nosec G304 - bundle path is operator input
+	// bundle not readable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("opening snapshot: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	// create the statistics directory on a new host
+	if err := os.MkdirAll(filepath.Dir(store.Path), stateDirMode); err != nil {
+		// return error with context
+		return fmt.Errorf("creating statistics directory: %w", err)
+	}
+	db, err := openStatsStore(store)
+	// store locked or corrupt
+	if err != nil {
+		// return store error
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	service := appsnapshot.NewService(infraconfig.NewLoader(), infrasnapshot.NewTarball(), db, version)
+	bundle, err := service.Restore(ctx, file, cfgPath)
+	// bundle invalid or not written
+	if err != nil {
+		// return restore error
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "snapshot of %s restored to %s (%d service statistics)\n",
+		bundle.CreatedAt.Format(time.RFC3339), cfgPath, len(bundle.Stats))
+	// bundle restored
+	return nil
+}
+
+// openStatsStore opens the statistics store, which fails while a running
+// daemon holds it.
+//
+// Params:
+//   - store: the statistics store settings.
+//
+// Returns:
+//   - *boltdb.Store: the opened store.
+//   - error: the open error, hinting at a running daemon.
+func openStatsStore(store storage.StoreConfig) (*boltdb.Store, error) {
+	db, err := boltdb.NewStore(store)
+	// locked by a running daemon or corrupt
+	if err != nil {
+		// return error with hint
+		return nil, fmt.Errorf("opening statistics store %s (is the daemon running?): %w", store.Path, err)
+	}
+	// return opened store
+	return db, nil
+}
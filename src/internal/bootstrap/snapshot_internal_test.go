@@ -0,0 +1,120 @@
+// Package bootstrap provides internal tests for snapshot.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/storage"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/storage/boltdb"
+)
+
+// Test_runSnapshot_usage tests that malformed snapshot commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runSnapshot_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no action", args: nil},
+		{name: "no bundle", args: []string{"save"}},
+		{name: "unknown action", args: []string{"copy", "bundle.tar.gz"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSnapshot(context.Background(), tt.args, "config.yaml", storage.StoreConfig{}, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrSnapshotUsage)
+		})
+	}
+}
+
+// Test_runSnapshot_saveRestore tests that a saved bundle restores the
+// configuration and statistics on another host.
+//
+// Params:
+//   - t: the testing context.
+func Test_runSnapshot_saveRestore(t *testing.T) {
+	ctx := context.Background()
+	source, target := t.TempDir(), t.TempDir()
+	cfgPath := filepath.Join(source, "config.yaml")
+	require.NoError(t, os.WriteFile(filepath.Join(source, "common.yaml"), []byte("logging:\n  base_dir: /var/log/app\n"), 0o600))
+	require.NoError(t, os.WriteFile(cfgPath, []byte("version: \"1\"\ninclude: common.yaml\nservices:\n  - name: api\n    command: /bin/api\n"), 0o600))
+	sourceStore := storage.StoreConfig{Path: filepath.Join(source, "state", "metrics.db")}
+	require.NoError(t, os.MkdirAll(filepath.Dir(sourceStore.Path), 0o750))
+	db, err := boltdb.NewStore(sourceStore)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveServiceStats(ctx, &storage.ServiceStatsRecord{Service: "api", StartCount: 7}))
+	require.NoError(t, db.Close())
+
+	bundlePath := filepath.Join(source, "bundle.tar.gz")
+	var out bytes.Buffer
+	require.NoError(t, runSnapshot(ctx, []string{"save", bundlePath}, cfgPath, sourceStore, &out))
+	assert.Contains(t, out.String(), "1 service statistics")
+
+	targetCfg := filepath.Join(target, "config.yaml")
+	targetStore := storage.StoreConfig{Path: filepath.Join(target, "state", "metrics.db")}
+	require.NoError(t, runSnapshot(ctx, []string{"restore", bundlePath}, targetCfg, targetStore, &out))
+
+	restored, err := os.ReadFile(targetCfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(restored), "base_dir: /var/log/app")
+	assert.NotContains(t, string(restored), "include")
+	db, err = boltdb.NewStore(targetStore)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	rec, found, err := db.LoadServiceStats(ctx, "api")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 7, rec.StartCount)
+}
+
+// Test_runSnapshot_invalidConfig tests that invalid configurations are not saved.
+//
+// Params:
+//   - t: the testing context.
+func Test_runSnapshot_invalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("version: \"1\"\nservices:\n  - name: api\n"), 0o600))
+	bundlePath := filepath.Join(dir, "bundle.tar.gz")
+
+	err := runSnapshot(context.Background(), []string{"save", bundlePath}, cfgPath, storage.StoreConfig{Path: filepath.Join(dir, "none.db")}, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.NoFileExists(t, bundlePath)
+}
+
+// Test_runSnapshot_readOnly tests that a read-only configuration is not replaced.
+//
+// Params:
+//   - t: the testing context.
+func Test_runSnapshot_readOnly(t *testing.T) {
+	ctx := context.Background()
+	source, target := t.TempDir(), t.TempDir()
+	cfgPath := filepath.Join(source, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("version: \"1\"\nservices:\n  - name: api\n    command: /bin/api\n"), 0o600))
+	bundlePath := filepath.Join(source, "bundle.tar.gz")
+	require.NoError(t, runSnapshot(ctx, []string{"save", bundlePath}, cfgPath, storage.StoreConfig{Path: filepath.Join(source, "none.db")}, &bytes.Buffer{}))
+
+	targetCfg := filepath.Join(target, "config.yaml")
+	locked := "version: \"1\"\ncontrol:\n  read_only: true\nservices:\n  - name: web\n    command: /bin/web\n"
+	require.NoError(t, os.WriteFile(targetCfg, []byte(locked), 0o600))
+	targetStore := storage.StoreConfig{Path: filepath.Join(target, "state", "metrics.db")}
+
+	err := runSnapshot(ctx, []string{"restore", bundlePath}, targetCfg, targetStore, &bytes.Buffer{})
+	require.ErrorIs(t, err, domainconfig.ErrReadOnly)
+	assert.Equal(t, exitNoPermission, exitCode(err))
+	current, err := os.ReadFile(targetCfg)
+	require.NoError(t, err)
+	assert.Equal(t, locked, string(current))
+	assert.NoFileExists(t, targetStore.Path)
+}
@@ -0,0 +1,150 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/process/systemd"
+)
+
+// watchdogPetsPerInterval is how many times the watchdog is petted within its timeout.
+const watchdogPetsPerInterval int = 2
+
+// systemdNotifier reports the daemon state to the systemd service manager.
+type systemdNotifier interface {
+	Ready(status string) error
+	Reloading(status string) error
+	Stopping(status string) error
+	Status(status string) error
+	Watchdog() error
+	WatchdogInterval() time.Duration
+}
+
+// setupSystemd reports the daemon state to systemd when it runs the daemon
+// with a notification socket (Type=notify).
+//
+// Params:
+//   - sup: the supervisor whose state is reported.
+//   - logger: the daemon logger.
+//
+// Returns:
+//   - func(*domainlifecycle.BootReport): reports the completed boot, nil when not run by systemd.
+func setupSystemd(sup AppSupervisor, logger domainlogging.Logger) func(*domainlifecycle.BootReport) {
+	notifier := systemd.New()
+	// not run by systemd
+	if !notifier.Enabled() {
+		// nothing to report
+		return nil
+	}
+	// report the supervisor state
+	return watchSystemd(notifier, sup, logger)
+}
+
+// watchSystemd follows the supervisor state: the daemon is ready once the
+// boot completed, reloading while the configuration is reloaded, stopping
+// on shutdown. With WatchdogSec, the watchdog is petted until every
+// service is stopped, and only while the supervisor answers.
+//
+// Params:
+//   - notifier: the systemd notification socket.
+//   - sup: the supervisor whose state is reported.
+//   - logger: the daemon logger.
+//
+// Returns:
+//   - func(*domainlifecycle.BootReport): reports the completed boot.
+func watchSystemd(notifier systemdNotifier, sup AppSupervisor, logger domainlogging.Logger) func(*domainlifecycle.BootReport) {
+	ready := &atomic.Bool{}
+	report := func(err error) {
+		// a lost notification does not stop the daemon
+		if err != nil {
+			logger.Warn("", "systemd_notify_failed", "systemd notification failed", map[string]any{"error": err.Error()})
+		}
+	}
+	// a reload before the boot completed is not reported
+	sup.OnTransition(appsupervisor.StateRunning, appsupervisor.StateReloading, func(_, _ appsupervisor.State) {
+		// systemd waits for READY=1 after RELOADING=1
+		if ready.Load() {
+			report(notifier.Reloading("Reloading configuration"))
+		}
+	})
+	sup.OnTransition(appsupervisor.StateReloading, appsupervisor.StateRunning, func(_, _ appsupervisor.State) {
+		// ready again once reloaded
+		if ready.Load() {
+			report(notifier.Ready("Configuration reloaded"))
+		}
+	})
+	sup.OnTransition(appsupervisor.StateAny, appsupervisor.StateStopping, func(_, _ appsupervisor.State) {
+		report(notifier.Stopping("Stopping services"))
+	})
+	stopped := make(chan struct{})
+	var once sync.Once
+	sup.OnTransition(appsupervisor.StateAny, appsupervisor.StateStopped, func(_, _ appsupervisor.State) {
+		once.Do(func() { close(stopped) })
+	})
+	// pet the watchdog well within its timeout
+	if interval := notifier.WatchdogInterval(); interval > 0 {
+		go petWatchdog(notifier, sup, interval/time.Duration(watchdogPetsPerInterval), stopped, report)
+	}
+	// report the boot outcome
+	return func(boot *domainlifecycle.BootReport) {
+		// the daemon is shutting down
+		if boot.Aborted {
+			report(notifier.Status("Critical service failed at boot, shutting down"))
+			// never ready
+			return
+		}
+		ready.Store(true)
+		report(notifier.Ready(bootStatus(boot)))
+	}
+}
+
+// petWatchdog pets the watchdog at each period until the services are
+// stopped. The supervisor state is read first: a wedged supervisor stops
+// the petting, and systemd restarts the daemon.
+//
+// Params:
+//   - notifier: the systemd notification socket.
+//   - sup: the supervisor checked before each pet.
+//   - period: the time between pets.
+//   - stopped: closed once every service is stopped.
+//   - report: reports a failed notification.
+func petWatchdog(notifier systemdNotifier, sup AppSupervisor, period time.Duration, stopped <-chan struct{}, report func(error)) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	// pet until stopped
+	for {
+		select {
+		// the daemon is leaving
+		case <-stopped:
+			// stop petting
+			return
+		case <-ticker.C:
+			// a wedged supervisor blocks here
+			_ = sup.State()
+			report(notifier.Watchdog())
+		}
+	}
+}
+
+// bootStatus summarizes the boot for systemctl status.
+//
+// Params:
+//   - boot: the completed boot report.
+//
+// Returns:
+//   - string: the status.
+func bootStatus(boot *domainlifecycle.BootReport) string {
+	// some services failed
+	if failed := len(boot.Failed()); failed > 0 {
+		// return the failures
+		return fmt.Sprintf("Boot completed: %d services, %d failed", len(boot.Services), failed)
+	}
+	// return the services
+	return fmt.Sprintf("Boot completed: %d services", len(boot.Services))
+}
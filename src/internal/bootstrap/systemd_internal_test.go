@@ -0,0 +1,202 @@
+// Package bootstrap provides internal tests for systemd.go.
+package bootstrap
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	daemonlogger "github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+)
+
+// fakeSystemdNotifier records the notifications.
+type fakeSystemdNotifier struct {
+	mu       sync.Mutex
+	sent     []string
+	watchdog time.Duration
+	err      error
+}
+
+// record stores a notification.
+//
+// Params:
+//   - state: the notification.
+//
+// Returns:
+//   - error: the configured error.
+func (n *fakeSystemdNotifier) record(state string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, state)
+	// Return configured error.
+	return n.err
+}
+
+// notifications returns the notifications sent so far.
+//
+// Returns:
+//   - []string: the notifications.
+func (n *fakeSystemdNotifier) notifications() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	// Return a copy.
+	return append([]string(nil), n.sent...)
+}
+
+// Ready records READY.
+//
+// Params:
+//   - status: the status.
+//
+// Returns:
+//   - error: the configured error.
+func (n *fakeSystemdNotifier) Ready(status string) error { return n.record("READY " + status) }
+
+// Reloading records RELOADING.
+//
+// Params:
+//   - status: the status.
+//
+// Returns:
+//   - error: the configured error.
+func (n *fakeSystemdNotifier) Reloading(status string) error { return n.record("RELOADING " + status) }
+
+// Stopping records STOPPING.
+//
+// Params:
+//   - status: the status.
+//
+// Returns:
+//   - error: the configured error.
+func (n *fakeSystemdNotifier) Stopping(status string) error { return n.record("STOPPING " + status) }
+
+// Status records STATUS.
+//
+// Params:
+//   - status: the status.
+//
+// Returns:
+//   - error: the configured error.
+func (n *fakeSystemdNotifier) Status(status string) error { return n.record("STATUS " + status) }
+
+// Watchdog records WATCHDOG.
+//
+// Returns:
+//   - error: the configured error.
+func (n *fakeSystemdNotifier) Watchdog() error { return n.record("WATCHDOG") }
+
+// WatchdogInterval returns the configured watchdog timeout.
+//
+// Returns:
+//   - time.Duration: the timeout.
+func (n *fakeSystemdNotifier) WatchdogInterval() time.Duration { return n.watchdog }
+
+// transitionSupervisor records every transition hook.
+type transitionSupervisor struct {
+	mockAppSupervisor
+	hooks map[[2]appsupervisor.State]appsupervisor.StateHook
+}
+
+// OnTransition records the hook of a transition.
+//
+// Params:
+//   - from: the source state.
+//   - to: the target state.
+//   - hook: the state hook.
+func (s *transitionSupervisor) OnTransition(from, to appsupervisor.State, hook appsupervisor.StateHook) {
+	// Create the map on first use.
+	if s.hooks == nil {
+		s.hooks = make(map[[2]appsupervisor.State]appsupervisor.StateHook)
+	}
+	s.hooks[[2]appsupervisor.State{from, to}] = hook
+}
+
+// fire runs the hook of a transition.
+//
+// Params:
+//   - from: the source state of the registered hook.
+//   - to: the target state of the registered hook.
+func (s *transitionSupervisor) fire(from, to appsupervisor.State) {
+	s.hooks[[2]appsupervisor.State{from, to}](from, to)
+}
+
+// Test_watchSystemd tests the notifications of each supervisor state.
+//
+// Params:
+//   - t: the testing context.
+func Test_watchSystemd(t *testing.T) {
+	t.Parallel()
+
+	notifier := &fakeSystemdNotifier{}
+	sup := &transitionSupervisor{}
+	writer := &recordingWriter{}
+	boot := watchSystemd(notifier, sup, daemonlogger.New(writer))
+
+	// A reload before the boot completed is not reported.
+	sup.fire(appsupervisor.StateRunning, appsupervisor.StateReloading)
+	sup.fire(appsupervisor.StateReloading, appsupervisor.StateRunning)
+	assert.Empty(t, notifier.notifications())
+
+	boot(&domainlifecycle.BootReport{Services: []domainlifecycle.ServiceBoot{
+		{Name: "api", Status: domainlifecycle.BootReady},
+		{Name: "cron", Status: domainlifecycle.BootFailed},
+	}})
+	sup.fire(appsupervisor.StateRunning, appsupervisor.StateReloading)
+	sup.fire(appsupervisor.StateReloading, appsupervisor.StateRunning)
+	sup.fire(appsupervisor.StateAny, appsupervisor.StateStopping)
+	sup.fire(appsupervisor.StateAny, appsupervisor.StateStopped)
+	sup.fire(appsupervisor.StateAny, appsupervisor.StateStopped)
+
+	assert.Equal(t, []string{
+		"READY Boot completed: 2 services, 1 failed",
+		"RELOADING Reloading configuration",
+		"READY Configuration reloaded",
+		"STOPPING Stopping services",
+	}, notifier.notifications())
+
+	// A lost notification is logged.
+	notifier.err = errors.New("connection refused")
+	boot(&domainlifecycle.BootReport{Aborted: true})
+	assert.Equal(t, "STATUS Critical service failed at boot, shutting down", notifier.notifications()[4])
+	require.Len(t, writer.events, 1)
+	assert.Equal(t, "systemd_notify_failed", writer.events[0].EventType)
+}
+
+// Test_watchSystemd_watchdog tests the watchdog is petted until the services are stopped.
+//
+// Params:
+//   - t: the testing context.
+func Test_watchSystemd_watchdog(t *testing.T) {
+	t.Parallel()
+
+	notifier := &fakeSystemdNotifier{watchdog: 20 * time.Millisecond}
+	sup := &transitionSupervisor{}
+	watchSystemd(notifier, sup, daemonlogger.New(&recordingWriter{}))
+
+	require.Eventually(t, func() bool { return len(notifier.notifications()) >= 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "WATCHDOG", notifier.notifications()[0])
+
+	sup.fire(appsupervisor.StateAny, appsupervisor.StateStopped)
+	time.Sleep(30 * time.Millisecond)
+	pets := len(notifier.notifications())
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, pets, len(notifier.notifications()))
+}
+
+// Test_setupSystemd tests nothing is reported outside systemd.
+//
+// Params:
+//   - t: the testing context.
+func Test_setupSystemd(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	sup := &transitionSupervisor{}
+	assert.Nil(t, setupSystemd(sup, daemonlogger.New(&recordingWriter{})))
+	assert.Empty(t, sup.hooks)
+}
@@ -0,0 +1,36 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// applyTimeScale installs a scaled default clock for the --time-scale flag,
+// so restart backoffs, probe intervals and deadlines run faster while
+// testing by hand. It must run before the components are created.
+//
+// Params:
+//   - scale: the speed factor, 1 to keep the system clock.
+//   - w: the destination of the warning, usually stderr.
+//
+// Returns:
+//   - error: a CFG_INVALID error when scale is not positive.
+func applyTimeScale(scale float64, w io.Writer) error {
+	// a zero or negative speed cannot drive timers
+	if scale <= 0 {
+		// refuse the flag value
+		return shared.NewCodedError(shared.CodeConfigInvalid, fmt.Sprintf("--time-scale must be positive, got %g", scale))
+	}
+	// keep the system clock at normal speed
+	if scale == 1 {
+		// nothing to install
+		return nil
+	}
+	shared.DefaultClock = shared.NewScaledClock(scale)
+	_, _ = fmt.Fprintf(w, "warning: timers run %gx faster (--time-scale), for debugging only\n", scale)
+	// scaled clock installed
+	return nil
+}
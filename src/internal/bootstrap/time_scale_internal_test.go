@@ -0,0 +1,52 @@
+// Package bootstrap provides internal tests for time_scale.go.
+package bootstrap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Test_applyTimeScale tests installation of the scaled default clock.
+//
+// Params:
+//   - t: the testing context.
+func Test_applyTimeScale(t *testing.T) {
+	tests := []struct {
+		name       string
+		scale      float64
+		wantErr    bool
+		wantScaled bool
+	}{
+		{name: "normal_speed_keeps_clock", scale: 1},
+		{name: "fast_forward_installs_scaled_clock", scale: 60, wantScaled: true},
+		{name: "zero_refused", scale: 0, wantErr: true},
+		{name: "negative_refused", scale: -2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := shared.DefaultClock
+			t.Cleanup(func() { shared.DefaultClock = previous })
+			var out bytes.Buffer
+
+			err := applyTimeScale(tt.scale, &out)
+
+			// verify refused values map to the configuration exit code
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, exitConfig, exitCode(err))
+				assert.Same(t, previous, shared.DefaultClock)
+				return
+			}
+			require.NoError(t, err)
+			_, scaled := shared.DefaultClock.(*shared.ScaledClock)
+			assert.Equal(t, tt.wantScaled, scaled)
+			assert.Equal(t, tt.wantScaled, out.Len() > 0)
+		})
+	}
+}
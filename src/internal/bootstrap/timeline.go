@@ -0,0 +1,184 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/storage"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+const (
+	// timelineCommand is the subcommand printing the events of a service.
+	timelineCommand string = "timeline"
+
+	// defaultTimelineSince is the age of the oldest event printed by default.
+	defaultTimelineSince time.Duration = 24 * time.Hour
+)
+
+// ErrTimelineUsage indicates a malformed timeline command line.
+var ErrTimelineUsage error = errors.New("usage: supervizio timeline SERVICE [--since DURATION] [--until DURATION] " +
+	"[--limit N] [--address HOST:PORT]")
+
+// runTimelineMode prints what happened to a service over a time range.
+//
+// Params:
+//   - args: the arguments following the timeline command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runTimelineMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runTimeline(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runTimeline asks the daemon for the persisted events of a service between
+// two ages, the last day by default, and prints them oldest first.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the service name and the flags of the timeline command.
+//   - out: the destination of the timeline.
+//
+// Returns:
+//   - error: ErrTimelineUsage, or the daemon or write error.
+func runTimeline(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(timelineCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	since := flags.Duration("since", defaultTimelineSince, "age of the oldest event to print, 0 for all")
+	until := flags.Duration("until", 0, "age of the end of the range, 0 for now")
+	limit := flags.Int("limit", 0, "largest number of events to print, 0 for the daemon default")
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	var service string
+	// the service name may precede the flags
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		service, args = args[0], args[1:]
+	}
+	// flags are valid and the range is not reversed
+	if err := flags.Parse(args); err != nil || *since < 0 || *until < 0 || *limit < 0 || (*since > 0 && *until > *since) {
+		// return usage error
+		return ErrTimelineUsage
+	}
+	// the service name may follow the flags
+	if service == "" && flags.NArg() == 1 {
+		service = flags.Arg(0)
+	} else if flags.NArg() != 0 {
+		// return usage error
+		return ErrTimelineUsage
+	}
+	// a service is required
+	if service == "" {
+		// return usage error
+		return ErrTimelineUsage
+	}
+
+	now := time.Now()
+	var from, to time.Time
+	// bound the start unless every event is requested
+	if *since > 0 {
+		from = now.Add(-*since)
+	}
+	// bound the end if requested
+	if *until > 0 {
+		to = now.Add(-*until)
+	}
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	timeline, err := client.ServiceTimeline(ctx, service, from, to, *limit)
+	// daemon unreachable, no event store or interrupted
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("reading timeline of %s: %w", service, err)
+	}
+	// return write result
+	return writeTimeline(out, &timeline)
+}
+
+// writeTimeline prints one line per event, then whether events were left out.
+//
+// Params:
+//   - out: the destination of the timeline.
+//   - timeline: the events of the service.
+//
+// Returns:
+//   - error: the write error.
+func writeTimeline(out io.Writer, timeline *infragrpc.Timeline) error {
+	// say so rather than print nothing
+	if len(timeline.Events) == 0 {
+		_, err := fmt.Fprintln(out, "no events in range")
+		// return write result
+		return err
+	}
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	// describe each event
+	for i := range timeline.Events {
+		rec := &timeline.Events[i]
+		cells := []string{rec.Timestamp.Local().Format(time.RFC3339), string(process.CategoryOf(rec.Type)), rec.Type}
+		// leave no trailing padding on events without detail
+		if detail := timelineDetail(rec); detail != "" {
+			cells = append(cells, detail)
+		}
+		_, _ = fmt.Fprintln(table, strings.Join(cells, "\t"))
+	}
+	// align the columns before the footer
+	if err := table.Flush(); err != nil {
+		// return write error
+		return err
+	}
+	// the whole range was printed
+	if !timeline.Truncated {
+		// nothing more to print
+		return nil
+	}
+	_, err := fmt.Fprintln(out, "more events in range: narrow it with --since and --until, or raise --limit")
+	// return write result
+	return err
+}
+
+// timelineDetail describes the process and outcome of an event.
+//
+// Params:
+//   - rec: the event.
+//
+// Returns:
+//   - string: the pid, exit code and error that are set, space separated.
+func timelineDetail(rec *storage.EventRecord) string {
+	var parts []string
+	// events of a running process carry its pid
+	if rec.PID > 0 {
+		parts = append(parts, fmt.Sprintf("pid=%d", rec.PID))
+	}
+	// exits carry their code
+	if rec.ExitCode != 0 {
+		parts = append(parts, fmt.Sprintf("exit=%d", rec.ExitCode))
+	}
+	// failures carry their reason
+	if rec.Error != "" {
+		parts = append(parts, rec.Error)
+	}
+	// return joined detail
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,103 @@
+// Package bootstrap provides internal tests for timeline.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/storage"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// Test_runTimeline_usage tests that malformed timeline commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runTimeline_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no service", args: nil},
+		{name: "flags only", args: []string{"--since", "1h"}},
+		{name: "two services", args: []string{"api", "web"}},
+		{name: "negative age", args: []string{"api", "--since", "-1h"}},
+		{name: "reversed range", args: []string{"api", "--since", "1h", "--until", "2h"}},
+		{name: "negative limit", args: []string{"api", "--limit", "-1"}},
+		{name: "unknown flag", args: []string{"api", "--follow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runTimeline(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrTimelineUsage)
+		})
+	}
+}
+
+// Test_runTimeline_unreachable tests that an unreachable daemon is reported,
+// whether the service precedes or follows the flags.
+//
+// Params:
+//   - t: the testing context.
+func Test_runTimeline_unreachable(t *testing.T) {
+	for _, args := range [][]string{
+		{"api", "--since", "48h", "--until", "24h", "--address", "127.0.0.1:1"},
+		{"--since", "0", "--address", "127.0.0.1:1", "api"},
+	} {
+		var out bytes.Buffer
+		err := runTimeline(context.Background(), args, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading timeline of api")
+		assert.Empty(t, out.String())
+	}
+}
+
+// Test_writeTimeline tests the printed timeline.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeTimeline(t *testing.T) {
+	at := time.Date(2026, 1, 15, 10, 30, 0, 0, time.Local)
+	stamp := func(d time.Duration) string { return at.Add(d).Format(time.RFC3339) }
+
+	tests := []struct {
+		name     string
+		timeline infragrpc.Timeline
+		want     string
+	}{
+		{name: "empty", want: "no events in range\n"},
+		{
+			name: "events",
+			timeline: infragrpc.Timeline{Events: []storage.EventRecord{
+				{Timestamp: at, Type: "started", PID: 10},
+				{Timestamp: at.Add(time.Minute), Type: "unhealthy", PID: 10, Error: "connection refused"},
+				{Timestamp: at.Add(2 * time.Minute), Type: "failed", PID: 10, ExitCode: 2},
+				{Timestamp: at.Add(3 * time.Minute), Type: "raid_degraded"},
+			}},
+			want: stamp(0) + "  lifecycle  started    pid=10\n" +
+				stamp(time.Minute) + "  health     unhealthy  pid=10 connection refused\n" +
+				stamp(2*time.Minute) + "  lifecycle  failed     pid=10 exit=2\n" +
+				stamp(3*time.Minute) + "  alert      raid_degraded\n",
+		},
+		{
+			name:     "truncated",
+			timeline: infragrpc.Timeline{Events: []storage.EventRecord{{Timestamp: at, Type: "reloaded"}}, Truncated: true},
+			want: stamp(0) + "  reload  reloaded\n" +
+				"more events in range: narrow it with --since and --until, or raise --limit\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, writeTimeline(&out, &tt.timeline))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
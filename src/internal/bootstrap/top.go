@@ -0,0 +1,141 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/tui/terminal"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/tui/widget"
+)
+
+const (
+	// topCommand is the subcommand printing the resource usage of services.
+	topCommand string = "top"
+	// defaultTopInterval is the default time between two refreshes.
+	defaultTopInterval time.Duration = 2 * time.Second
+	// clearScreen moves the cursor home and clears the terminal.
+	clearScreen string = "\033[H\033[2J"
+)
+
+// ErrTopUsage indicates a malformed top command line.
+var ErrTopUsage error = errors.New("usage: supervizio top [--interval DURATION] [--address HOST:PORT]")
+
+// runTopMode prints the resource usage of every service of the running
+// daemon, refreshed until the command is interrupted.
+//
+// Params:
+//   - args: the arguments following the top command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runTopMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runTop(ctx, args, os.Stdout, terminal.IsTTY())
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runTop streams the metrics of the services from the daemon and prints a
+// table at each refresh. On a terminal, each table replaces the previous one.
+//
+// Params:
+//   - ctx: the context for cancellation; cancelling it ends the command.
+//   - args: the flags of the top command.
+//   - out: the destination of the tables.
+//   - redraw: whether to clear the screen before each table.
+//
+// Returns:
+//   - error: ErrTopUsage, or the daemon or write error.
+func runTop(ctx context.Context, args []string, out io.Writer, redraw bool) error {
+	flags := flag.NewFlagSet(topCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	interval := flags.Duration("interval", defaultTopInterval, "time between two refreshes")
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	// flags are valid and the interval is positive
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || *interval <= 0 {
+		// return usage error
+		return ErrTopUsage
+	}
+
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	err = client.StreamProcesses(ctx, *interval, func(processes []metrics.ProcessMetrics) error {
+		// print each refresh
+		return writeTop(out, processes, redraw)
+	})
+	// interrupted by the user
+	if ctx.Err() != nil {
+		// normal end of the command
+		return nil
+	}
+	// daemon unreachable or gone
+	return fmt.Errorf("streaming metrics: %w", err)
+}
+
+// writeTop prints one line per service, busiest first.
+//
+// Params:
+//   - out: the destination of the table.
+//   - processes: the metrics of the services.
+//   - redraw: whether to clear the screen first, else a blank line separates tables.
+//
+// Returns:
+//   - error: the write error.
+func writeTop(out io.Writer, processes []metrics.ProcessMetrics, redraw bool) error {
+	separator := "\n"
+	// replace the previous table on terminals
+	if redraw {
+		separator = clearScreen
+	}
+	// start the table
+	if _, err := io.WriteString(out, separator); err != nil {
+		// return write error
+		return err
+	}
+
+	sorted := slices.Clone(processes)
+	slices.SortStableFunc(sorted, func(a, b metrics.ProcessMetrics) int {
+		// busiest first, then by name
+		return cmp.Or(cmp.Compare(b.CPU.UsagePercent, a.CPU.UsagePercent), cmp.Compare(a.ServiceName, b.ServiceName))
+	})
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "SERVICE\tSTATE\tPID\tCPU%\tMEM\tFDS\tRESTARTS\tUPTIME")
+	// describe each service
+	for i := range sorted {
+		proc := &sorted[i]
+		pid, uptime := "-", "-"
+		// only running processes have a pid and an uptime
+		if proc.PID > 0 {
+			pid, uptime = strconv.Itoa(proc.PID), widget.FormatDurationShort(proc.Uptime)
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%.1f\t%s\t%d\t%d\t%s\n",
+			proc.ServiceName, proc.State, pid, proc.CPU.UsagePercent,
+			widget.FormatBytesShort(proc.Memory.RSS), proc.NumFDs, proc.RestartCount, uptime)
+	}
+	// return flush result
+	return table.Flush()
+}
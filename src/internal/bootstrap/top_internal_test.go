@@ -0,0 +1,88 @@
+// Package bootstrap provides internal tests for top.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_runTop_usage tests that malformed top commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runTop_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "extra argument", args: []string{"api"}},
+		{name: "zero interval", args: []string{"--interval", "0s"}},
+		{name: "malformed interval", args: []string{"--interval", "often"}},
+		{name: "unknown flag", args: []string{"--sort", "mem"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runTop(context.Background(), tt.args, &bytes.Buffer{}, false)
+			assert.ErrorIs(t, err, ErrTopUsage)
+		})
+	}
+}
+
+// Test_runTop_end tests that an unreachable daemon is reported, while an
+// interrupted command ends cleanly.
+//
+// Params:
+//   - t: the testing context.
+func Test_runTop_end(t *testing.T) {
+	var out bytes.Buffer
+	err := runTop(context.Background(), []string{"--address", "127.0.0.1:1"}, &out, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "streaming metrics")
+	assert.Empty(t, out.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, runTop(ctx, []string{"--address", "127.0.0.1:1"}, &out, false))
+}
+
+// Test_writeTop tests the printed table.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeTop(t *testing.T) {
+	processes := []metrics.ProcessMetrics{
+		{ServiceName: "worker", State: process.StateFailed, RestartCount: 5},
+		{
+			ServiceName:  "api",
+			PID:          1234,
+			State:        process.StateRunning,
+			CPU:          metrics.ProcessCPU{UsagePercent: 12.5},
+			Memory:       metrics.ProcessMemory{RSS: 64 << 20},
+			NumFDs:       42,
+			Uptime:       90 * time.Minute,
+			RestartCount: 1,
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeTop(&out, processes, false))
+	assert.Equal(t, "\n"+
+		"SERVICE  STATE    PID   CPU%  MEM    FDS  RESTARTS  UPTIME\n"+
+		"api      running  1234  12.5  64.0M  42   1         1h30m\n"+
+		"worker   failed   -     0.0   0B     0    5         -\n", out.String())
+
+	out.Reset()
+	require.NoError(t, writeTop(&out, processes, true))
+	assert.Contains(t, out.String(), clearScreen+"SERVICE")
+	// The caller's slice keeps its order.
+	assert.Equal(t, "worker", processes[0].ServiceName)
+}
@@ -0,0 +1,169 @@
+// Package bootstrap provides dependency injection wiring using Google Wire.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+	infragrpc "github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/tui/widget"
+)
+
+const (
+	// treeCommand is the subcommand printing the processes spawned by services.
+	treeCommand string = "tree"
+	// treeBranch prefixes a child followed by siblings.
+	treeBranch string = "├─ "
+	// treeLastBranch prefixes the last child.
+	treeLastBranch string = "└─ "
+	// treeIndent continues the branch of an ancestor followed by siblings.
+	treeIndent string = "│  "
+	// treeLastIndent replaces the branch of a last ancestor.
+	treeLastIndent string = "   "
+)
+
+// ErrTreeUsage indicates a malformed tree command line.
+var ErrTreeUsage error = errors.New("usage: supervizio tree [SERVICE] [--address HOST:PORT]")
+
+// runTreeMode prints the process tree of one or every running service of
+// the daemon.
+//
+// Params:
+//   - args: the arguments following the tree command.
+//
+// Returns:
+//   - int: exit code (0 for success).
+func runTreeMode(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := runTree(ctx, args, os.Stdout)
+	// report failure with its error code
+	if err != nil {
+		reportError(os.Stderr, err)
+	}
+	// map the error code to an exit code
+	return exitCode(err)
+}
+
+// runTree asks the daemon for the process trees of the services and
+// prints them.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - args: the optional service name and the flags of the tree command.
+//   - out: the destination of the trees.
+//
+// Returns:
+//   - error: ErrTreeUsage, or the daemon or write error.
+func runTree(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(treeCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	address := flags.String("address", defaultDaemonAddress, "address of the daemon API")
+	var service string
+	// the service name may precede the flags
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		service, args = args[0], args[1:]
+	}
+	// flags are valid
+	if err := flags.Parse(args); err != nil {
+		// return usage error
+		return ErrTreeUsage
+	}
+	// the service name may follow the flags
+	if service == "" && flags.NArg() == 1 {
+		service = flags.Arg(0)
+	} else if flags.NArg() != 0 {
+		// return usage error
+		return ErrTreeUsage
+	}
+
+	client, err := infragrpc.NewClient(*address)
+	// malformed address
+	if err != nil {
+		// return client error
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	trees, err := client.ProcessTrees(ctx, service)
+	// daemon unreachable, service unknown or not running
+	if err != nil {
+		// return daemon error
+		return fmt.Errorf("reading process trees: %w", err)
+	}
+	// return write result
+	return writeTree(out, trees)
+}
+
+// writeTree prints one line per process, children indented under their
+// parent, then the process count and memory of each service.
+//
+// Params:
+//   - out: the destination of the trees.
+//   - trees: the process tree of each service.
+//
+// Returns:
+//   - error: the write error.
+func writeTree(out io.Writer, trees []process.ServiceTree) error {
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "SERVICE\tPID\tSTATE\tRSS\tCPU%\tTIME\tCOMMAND")
+	// print each service tree
+	for i := range trees {
+		writeTreeNode(table, trees[i].Service, &trees[i].Root, "", "")
+	}
+	// align the columns before the summary
+	if err := table.Flush(); err != nil {
+		// return write error
+		return err
+	}
+	// summarize each service
+	for i := range trees {
+		root := &trees[i].Root
+		noun := "processes"
+		// a service without children runs a single process
+		if root.Size() == 1 {
+			noun = "process"
+		}
+		_, _ = fmt.Fprintf(out, "%s: %d %s, %s resident\n",
+			trees[i].Service, root.Size(), noun, widget.FormatBytesShort(root.TotalRSS()))
+	}
+	// summary written
+	return nil
+}
+
+// writeTreeNode prints a process, then its children.
+//
+// Params:
+//   - out: the destination of the lines.
+//   - service: the service name, printed on the main process line only.
+//   - node: the process.
+//   - branch: the prefix of the process command.
+//   - indent: the prefix of the branches of its children.
+func writeTreeNode(out io.Writer, service string, node *process.ProcessNode, branch, indent string) {
+	command := node.Cmdline
+	// kernel threads and zombies have no command line, like ps
+	if command == "" {
+		command = "[" + node.Name + "]"
+	}
+	_, _ = fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%.1f\t%s\t%s%s\n",
+		service, strconv.Itoa(node.PID), node.State, widget.FormatBytesShort(node.RSS),
+		node.CPUPercent, widget.FormatDurationShort(node.CPUTime), branch, command)
+	// print each child under the process
+	for i := range node.Children {
+		childBranch, childIndent := treeBranch, treeIndent
+		// the last child closes the branch
+		if i == len(node.Children)-1 {
+			childBranch, childIndent = treeLastBranch, treeLastIndent
+		}
+		writeTreeNode(out, "", &node.Children[i], indent+childBranch, indent+childIndent)
+	}
+}
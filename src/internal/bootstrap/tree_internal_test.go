@@ -0,0 +1,84 @@
+// Package bootstrap provides internal tests for tree.go.
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_runTree_usage tests that malformed tree commands are refused.
+//
+// Params:
+//   - t: the testing context.
+func Test_runTree_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "two services", args: []string{"api", "web"}},
+		{name: "unknown flag", args: []string{"api", "--depth", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runTree(context.Background(), tt.args, &bytes.Buffer{})
+			assert.ErrorIs(t, err, ErrTreeUsage)
+		})
+	}
+}
+
+// Test_runTree_unreachable tests that an unreachable daemon is reported,
+// with or without a service.
+//
+// Params:
+//   - t: the testing context.
+func Test_runTree_unreachable(t *testing.T) {
+	for _, args := range [][]string{
+		{"--address", "127.0.0.1:1"},
+		{"api", "--address", "127.0.0.1:1"},
+		{"--address", "127.0.0.1:1", "api"},
+	} {
+		var out bytes.Buffer
+		err := runTree(context.Background(), args, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading process trees")
+		assert.Empty(t, out.String())
+	}
+}
+
+// Test_writeTree tests the printed trees.
+//
+// Params:
+//   - t: the testing context.
+func Test_writeTree(t *testing.T) {
+	var out bytes.Buffer
+	err := writeTree(&out, []process.ServiceTree{
+		{Service: "web", Root: process.ProcessNode{
+			PID: 10, Name: "sh", Cmdline: "sh -c serve", State: "S", RSS: 2048, CPUTime: 90 * time.Second, CPUPercent: 2.5,
+			Children: []process.ProcessNode{
+				{PID: 11, Name: "serve", Cmdline: "serve --port 80", State: "S", RSS: 4096, Children: []process.ProcessNode{
+					{PID: 13, Name: "worker", State: "Z"},
+				}},
+				{PID: 12, Name: "logger", Cmdline: "logger", State: "R", RSS: 1024},
+			},
+		}},
+		{Service: "cron", Root: process.ProcessNode{PID: 20, Name: "cron", Cmdline: "cron -f", State: "S", RSS: 512}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "SERVICE  PID  STATE  RSS    CPU%  TIME  COMMAND\n"+
+		"web      10   S      2.00K  2.5   1m    sh -c serve\n"+
+		"         11   S      4.00K  0.0   0s    ├─ serve --port 80\n"+
+		"         13   Z      0B     0.0   0s    │  └─ [worker]\n"+
+		"         12   R      1.00K  0.0   0s    └─ logger\n"+
+		"cron     20   S      512B   0.0   0s    cron -f\n"+
+		"web: 4 processes, 7.00K resident\n"+
+		"cron: 1 process, 512B resident\n", out.String())
+}
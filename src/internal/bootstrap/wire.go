@@ -6,15 +6,26 @@ import (
 	"github.com/google/wire"
 	appconfig "github.com/kodflow/daemon/internal/application/config"
 	apphealth "github.com/kodflow/daemon/internal/application/health"
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
+	appmetrics "github.com/kodflow/daemon/internal/application/metrics"
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
 	appsupervisor "github.com/kodflow/daemon/internal/application/supervisor"
 	domainprocess "github.com/kodflow/daemon/internal/domain/process"
 	infrahealthcheck "github.com/kodflow/daemon/internal/infrastructure/observability/healthcheck"
+	infraintegrity "github.com/kodflow/daemon/internal/infrastructure/observability/integrity"
 	infraconfig "github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
 	infraprobe "github.com/kodflow/daemon/internal/infrastructure/probe"
+	"github.com/kodflow/daemon/internal/infrastructure/process/cgroup"
 	"github.com/kodflow/daemon/internal/infrastructure/process/control"
 	"github.com/kodflow/daemon/internal/infrastructure/process/credentials"
 	"github.com/kodflow/daemon/internal/infrastructure/process/executor"
+	infrahook "github.com/kodflow/daemon/internal/infrastructure/process/hook"
+	"github.com/kodflow/daemon/internal/infrastructure/process/hostinfo"
+	"github.com/kodflow/daemon/internal/infrastructure/process/inspect"
+	"github.com/kodflow/daemon/internal/infrastructure/process/preflight"
 	infrareaper "github.com/kodflow/daemon/internal/infrastructure/process/reaper"
+	infraproxy "github.com/kodflow/daemon/internal/infrastructure/transport/proxy"
 )
 
 // InitializeApp creates the application with all dependencies wired.
@@ -52,9 +63,50 @@ func InitializeApp(configPath string) (*App, error) {
 		ProvideProberFactory,
 		wire.Bind(new(apphealth.Creator), new(*infrahealthcheck.Factory)),
 
+		// Infrastructure: Listener certificate checks.
+		ProvideCertificateInspector,
+		wire.Bind(new(apphealth.CertificateInspector), new(*infrahealthcheck.CertificateInspector)),
+
 		// Infrastructure: Process metrics collector via Rust probe (cross-platform).
 		infraprobe.NewAppProcessCollector,
 
+		// Infrastructure: Cgroup CPU throttling, pressure, capacity and host facts collector.
+		cgroup.New,
+		wire.Bind(new(appmetrics.ThrottlingCollector), new(*cgroup.Reader)),
+		wire.Bind(new(appmetrics.PressureCollector), new(*cgroup.Reader)),
+		wire.Bind(new(appmetrics.CapacityCollector), new(*cgroup.Reader)),
+		wire.Bind(new(appmetrics.HostPressureCollector), new(*cgroup.Reader)),
+		wire.Bind(new(appmetrics.SelfCollector), new(*cgroup.Reader)),
+		wire.Bind(new(appmetrics.HostFactsCollector), new(*cgroup.Reader)),
+		wire.Bind(new(domainprocess.ResourceLedger), new(*executor.Executor)),
+
+		// Infrastructure: Host platform capabilities.
+		hostinfo.New,
+		wire.Bind(new(appmetrics.HostInventoryCollector), new(*hostinfo.Detector)),
+
+		// Infrastructure: Process trees, sockets, CPU times and memory attribution under services.
+		inspect.New,
+		wire.Bind(new(domainprocess.TreeReader), new(*inspect.Inspector)),
+		wire.Bind(new(domainprocess.SocketReader), new(*inspect.Inspector)),
+		wire.Bind(new(appmetrics.CPUCollector), new(*inspect.Inspector)),
+		wire.Bind(new(appmetrics.MemoryDetailCollector), new(*inspect.Inspector)),
+
+		// Infrastructure: Reload pre-flight checks.
+		preflight.New,
+		wire.Bind(new(appconfig.Preflighter), new(*preflight.Checker)),
+
+		// Infrastructure: Supervisor-owned listener proxies.
+		infraproxy.New,
+		wire.Bind(new(appproxy.Opener), new(*infraproxy.Opener)),
+
+		// Infrastructure: Service binary integrity watch.
+		infraintegrity.New,
+		wire.Bind(new(appintegrity.Watcher), new(*infraintegrity.Watcher)),
+
+		// Infrastructure: File watch hook commands.
+		infrahook.New,
+		wire.Bind(new(apphook.Runner), new(*infrahook.Runner)),
+
 		// Application: Metrics tracker.
 		ProvideMetricsTracker,
 
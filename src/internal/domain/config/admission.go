@@ -0,0 +1,153 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultOvercommit is the share of host capacity services may reserve
+// when none is configured.
+const DefaultOvercommit float64 = 1
+
+// AdmissionAction defines what happens to a service whose start would
+// reserve more than the admitted host capacity.
+type AdmissionAction string
+
+// Admission action constants.
+const (
+	// AdmissionRefuse leaves the service stopped.
+	AdmissionRefuse AdmissionAction = "refuse"
+	// AdmissionWarn starts the service anyway.
+	AdmissionWarn AdmissionAction = "warn"
+)
+
+// Admission validation errors.
+var (
+	// ErrInvalidOvercommit indicates a negative admission overcommit ratio.
+	ErrInvalidOvercommit error = errors.New("admission overcommit must not be negative")
+	// ErrInvalidAdmissionAction indicates an unknown admission action.
+	ErrInvalidAdmissionAction error = errors.New("invalid admission action")
+	// ErrInvalidReservationMemory indicates an unparsable reservation memory size.
+	ErrInvalidReservationMemory error = errors.New("invalid reservation memory")
+	// ErrInvalidReservationCPU indicates a negative reservation cpu.
+	ErrInvalidReservationCPU error = errors.New("reservation cpu must not be negative")
+)
+
+// AdmissionConfig configures the admission of service starts against host
+// capacity. Only services declaring a reservation are checked.
+type AdmissionConfig struct {
+	// Overcommit is the share of host memory and CPUs services may reserve
+	// together (1.5 allows 150%). Zero uses DefaultOvercommit.
+	Overcommit float64
+	// Action applies to over-committing starts. Empty behaves as AdmissionRefuse.
+	Action AdmissionAction
+}
+
+// EffectiveOvercommit returns the share of host capacity services may reserve.
+//
+// Returns:
+//   - float64: the configured ratio, or DefaultOvercommit.
+func (a *AdmissionConfig) EffectiveOvercommit() float64 {
+	// fall back to the default ratio
+	if a.Overcommit <= 0 {
+		// return default
+		return DefaultOvercommit
+	}
+	// return configured ratio
+	return a.Overcommit
+}
+
+// Refuses reports whether over-committing starts are refused.
+//
+// Returns:
+//   - bool: false only for AdmissionWarn.
+func (a *AdmissionConfig) Refuses() bool {
+	// refuse unless warning is asked for
+	return a.Action != AdmissionWarn
+}
+
+// ReservationConfig declares the resources a service is expected to use.
+type ReservationConfig struct {
+	// Memory is the expected memory use (e.g., "256MB"). Empty reserves none.
+	Memory string
+	// CPU is the expected number of busy CPUs (0.5 is half a core).
+	CPU float64
+}
+
+// MemoryBytes returns the reserved memory.
+//
+// Returns:
+//   - int64: the reserved bytes, zero when unset or invalid.
+func (r *ReservationConfig) MemoryBytes() int64 {
+	// no memory reserved
+	if r.Memory == "" {
+		// return zero
+		return 0
+	}
+	bytes, _ := shared.ParseSize(r.Memory)
+	// return validated size
+	return bytes
+}
+
+// IsZero reports whether nothing is reserved.
+//
+// Returns:
+//   - bool: true when neither memory nor CPU is reserved.
+func (r *ReservationConfig) IsZero() bool {
+	// nothing declared
+	return r.MemoryBytes() == 0 && r.CPU == 0
+}
+
+// validateAdmission validates the admission settings.
+//
+// Params:
+//   - a: admission configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateAdmission(a *AdmissionConfig) error {
+	// check ratio is not negative
+	if a.Overcommit < 0 {
+		// return error with the ratio
+		return fmt.Errorf("%w: %g", ErrInvalidOvercommit, a.Overcommit)
+	}
+	// check action is known
+	switch a.Action {
+	// empty defaults to refuse
+	case "", AdmissionRefuse, AdmissionWarn:
+		// known action
+		return nil
+	// unknown action
+	default:
+		// return error with the action
+		return fmt.Errorf("%w: %q", ErrInvalidAdmissionAction, a.Action)
+	}
+}
+
+// validateReservation validates the resource reservation of a service.
+//
+// Params:
+//   - r: reservation to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateReservation(r *ReservationConfig) error {
+	// check memory parses when set
+	if r.Memory != "" {
+		// parse human-readable size
+		if _, err := shared.ParseSize(r.Memory); err != nil {
+			// return error with the size
+			return fmt.Errorf("%w %q: %w", ErrInvalidReservationMemory, r.Memory, err)
+		}
+	}
+	// check cpu is not negative
+	if r.CPU < 0 {
+		// return error with the cpu
+		return fmt.Errorf("%w: %g", ErrInvalidReservationCPU, r.CPU)
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestAdmissionConfig tests the admission defaults.
+//
+// Params:
+//   - t: the testing context.
+func TestAdmissionConfig(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// admission is the configuration.
+		admission config.AdmissionConfig
+		// wantOvercommit is the expected ratio.
+		wantOvercommit float64
+		// wantRefuses is the expected refusal.
+		wantRefuses bool
+	}{
+		{name: "defaults", wantOvercommit: config.DefaultOvercommit, wantRefuses: true},
+		{name: "refuse", admission: config.AdmissionConfig{Overcommit: 1.2, Action: config.AdmissionRefuse}, wantOvercommit: 1.2, wantRefuses: true},
+		{name: "warn", admission: config.AdmissionConfig{Overcommit: 2, Action: config.AdmissionWarn}, wantOvercommit: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.wantOvercommit, tt.admission.EffectiveOvercommit(), 1e-9)
+			assert.Equal(t, tt.wantRefuses, tt.admission.Refuses())
+		})
+	}
+}
+
+// TestReservationConfig tests reserved memory parsing.
+//
+// Params:
+//   - t: the testing context.
+func TestReservationConfig(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// reservation is the configuration.
+		reservation config.ReservationConfig
+		// wantMemory is the expected reserved bytes.
+		wantMemory int64
+		// wantZero is the expected IsZero result.
+		wantZero bool
+	}{
+		{name: "empty", wantZero: true},
+		{name: "memory", reservation: config.ReservationConfig{Memory: "256MB"}, wantMemory: 256 * shared.Megabyte},
+		{name: "cpu only", reservation: config.ReservationConfig{CPU: 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantMemory, tt.reservation.MemoryBytes())
+			assert.Equal(t, tt.wantZero, tt.reservation.IsZero())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultReloadTimeout bounds an in-place reload when none is configured.
+const DefaultReloadTimeout time.Duration = 30 * time.Second
+
+// In-place reload validation errors.
+var (
+	// ErrReloadSignalAndCommand indicates a service with both reload_signal and reload_command.
+	ErrReloadSignalAndCommand error = errors.New("reload_signal and reload_command are mutually exclusive")
+	// ErrInvalidReloadTimeout indicates a negative reload_timeout.
+	ErrInvalidReloadTimeout error = errors.New("reload_timeout must not be negative")
+)
+
+// AppReloadSignal returns the POSIX name of the signal reloading the service.
+//
+// Returns:
+//   - string: the configured signal, or DefaultReloadSignal.
+func (s *ServiceConfig) AppReloadSignal() string {
+	// fall back to SIGHUP
+	if s.ReloadSignal == "" {
+		// return default
+		return DefaultReloadSignal
+	}
+	name, _ := NormalizeSignal(s.ReloadSignal)
+	// return validated name
+	return name
+}
+
+// AppReloadCommand splits the reload command line into the program and
+// its arguments, as the service command is.
+//
+// Returns:
+//   - string: the program, empty when the reload uses a signal.
+//   - []string: the arguments.
+func (s *ServiceConfig) AppReloadCommand() (string, []string) {
+	fields := strings.Fields(s.ReloadCommand)
+	// reload by signal
+	if len(fields) == 0 {
+		// return no command
+		return "", nil
+	}
+	// return program and arguments
+	return fields[0], fields[1:]
+}
+
+// AppReloadTimeout returns the effective reload timeout.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultReloadTimeout.
+func (s *ServiceConfig) AppReloadTimeout() time.Duration {
+	// fall back to the default timeout
+	if s.ReloadTimeout <= 0 {
+		// return default
+		return DefaultReloadTimeout
+	}
+	// return configured timeout
+	return s.ReloadTimeout.Duration()
+}
+
+// validateAppReload validates the in-place reload settings of a service.
+//
+// Params:
+//   - svc: the service to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateAppReload(svc *ServiceConfig) error {
+	// only one reload mechanism
+	if svc.ReloadSignal != "" && strings.TrimSpace(svc.ReloadCommand) != "" {
+		// return conflict error
+		return ErrReloadSignalAndCommand
+	}
+	// timeout must not be negative
+	if svc.ReloadTimeout < 0 {
+		// return timeout error
+		return ErrInvalidReloadTimeout
+	}
+	// signal must be sendable
+	if svc.ReloadSignal != "" {
+		_, err := NormalizeSignal(svc.ReloadSignal)
+		// return signal error
+		return err
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,66 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestServiceConfig_AppReload tests the effective in-place reload settings.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_AppReload(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// svc is the service configuration.
+		svc config.ServiceConfig
+		// signal is the expected reload signal.
+		signal string
+		// program is the expected reload program.
+		program string
+		// args are the expected reload arguments.
+		args []string
+		// timeout is the expected reload timeout.
+		timeout time.Duration
+	}{
+		{
+			name:    "defaults",
+			svc:     config.ServiceConfig{},
+			signal:  config.DefaultReloadSignal,
+			timeout: config.DefaultReloadTimeout,
+		},
+		{
+			name:    "signal",
+			svc:     config.ServiceConfig{ReloadSignal: "usr2", ReloadTimeout: shared.Duration(time.Minute)},
+			signal:  "SIGUSR2",
+			timeout: time.Minute,
+		},
+		{
+			name:    "command",
+			svc:     config.ServiceConfig{ReloadCommand: "/usr/sbin/nginx  -s reload"},
+			signal:  config.DefaultReloadSignal,
+			program: "/usr/sbin/nginx",
+			args:    []string{"-s", "reload"},
+			timeout: config.DefaultReloadTimeout,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			program, args := tt.svc.AppReloadCommand()
+			assert.Equal(t, tt.signal, tt.svc.AppReloadSignal())
+			assert.Equal(t, tt.program, program)
+			assert.Equal(t, tt.args, args)
+			assert.Equal(t, tt.timeout, tt.svc.AppReloadTimeout())
+		})
+	}
+}
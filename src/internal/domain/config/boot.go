@@ -0,0 +1,32 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+// BootFailurePolicy defines what the daemon does when a critical service fails at boot.
+type BootFailurePolicy string
+
+// Boot failure policy constants.
+const (
+	// BootFailureContinue keeps the daemon running with the services that did start.
+	BootFailureContinue BootFailurePolicy = "continue"
+	// BootFailureShutdown stops every service and exits the daemon.
+	BootFailureShutdown BootFailurePolicy = "shutdown"
+)
+
+// IsValid reports whether the policy is known.
+// The empty policy is valid and behaves as BootFailureContinue.
+//
+// Returns:
+//   - bool: true for an empty or known policy.
+func (p BootFailurePolicy) IsValid() bool {
+	// accept known policies
+	switch p {
+	// empty defaults to continue
+	case "", BootFailureContinue, BootFailureShutdown:
+		// known policy
+		return true
+	// unknown policy
+	default:
+		// reject
+		return false
+	}
+}
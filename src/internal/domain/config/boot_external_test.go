@@ -0,0 +1,34 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestBootFailurePolicy_IsValid tests the IsValid method of BootFailurePolicy.
+//
+// Params:
+//   - t: testing context
+func TestBootFailurePolicy_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy config.BootFailurePolicy
+		want   bool
+	}{
+		{"empty", "", true},
+		{"continue", config.BootFailureContinue, true},
+		{"shutdown", config.BootFailureShutdown, true},
+		{"unknown", "reboot", false},
+	}
+
+	// Iterate through all test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.IsValid())
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// conditionNegation prefixes the paths that must not exist.
+const conditionNegation string = "!"
+
+// Condition validation errors.
+var (
+	// ErrInvalidKernelVersion indicates a minimum kernel version that is not dotted numbers.
+	ErrInvalidKernelVersion error = errors.New("invalid kernel version")
+	// ErrInvalidMinFreeMemory indicates an unparsable minimum free memory size.
+	ErrInvalidMinFreeMemory error = errors.New("invalid min_free_memory")
+	// ErrInvalidConditionPath indicates an empty or relative condition path.
+	ErrInvalidConditionPath error = errors.New("condition path must be absolute")
+	// ErrEmptyArchitecture indicates an empty architecture name.
+	ErrEmptyArchitecture error = errors.New("architecture must not be empty")
+)
+
+// architectureAliases maps the uname names of CPU architectures to their GOARCH names.
+var architectureAliases map[string]string = map[string]string{
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+	"i386":    "386",
+	"i686":    "386",
+	"armv7l":  "arm",
+}
+
+// ConditionConfig declares host facts a service needs to start, like the
+// Condition* directives of systemd. A service whose conditions are unmet is
+// skipped: it stays stopped without counting as a failure.
+type ConditionConfig struct {
+	// MinKernelVersion is the oldest kernel release allowed (e.g., "5.10").
+	MinKernelVersion string
+	// CgroupV2 requires the unified cgroup v2 hierarchy.
+	CgroupV2 bool
+	// Architectures lists the CPU architectures allowed, as GOARCH or uname
+	// names (amd64 or x86_64). Empty allows any.
+	Architectures []string
+	// PathExists lists absolute paths that must exist; a path prefixed
+	// with "!" must not exist.
+	PathExists []string
+	// MinFreeMemory is the memory that must be available (e.g., "512MB").
+	MinFreeMemory string
+}
+
+// IsZero reports whether no condition is declared.
+//
+// Returns:
+//   - bool: true when the service starts unconditionally.
+func (c *ConditionConfig) IsZero() bool {
+	// nothing declared
+	return c.MinKernelVersion == "" && !c.CgroupV2 && len(c.Architectures) == 0 &&
+		len(c.PathExists) == 0 && c.MinFreeMemory == ""
+}
+
+// MinFreeMemoryBytes returns the memory that must be available.
+//
+// Returns:
+//   - uint64: the bytes, zero when unset or invalid.
+func (c *ConditionConfig) MinFreeMemoryBytes() uint64 {
+	// no memory required
+	if c.MinFreeMemory == "" {
+		// return zero
+		return 0
+	}
+	bytes, _ := shared.ParseSize(c.MinFreeMemory)
+	// return validated size
+	return uint64(bytes)
+}
+
+// Paths returns the paths whose existence is checked, without negation.
+//
+// Returns:
+//   - []string: the paths, in declaration order.
+func (c *ConditionConfig) Paths() []string {
+	paths := make([]string, 0, len(c.PathExists))
+	// strip the negation prefix
+	for _, path := range c.PathExists {
+		paths = append(paths, strings.TrimPrefix(path, conditionNegation))
+	}
+	// return checked paths
+	return paths
+}
+
+// Unmet checks the conditions against the host facts. Facts that could not
+// be read fail the conditions depending on them.
+//
+// Params:
+//   - facts: the host facts.
+//
+// Returns:
+//   - []string: a description of each unmet condition, empty when all are met.
+func (c *ConditionConfig) Unmet(facts *HostFacts) []string {
+	var unmet []string
+	// compare the kernel release
+	if c.MinKernelVersion != "" {
+		// an unknown kernel cannot be compared
+		if facts.KernelVersion == "" {
+			unmet = append(unmet, "kernel version unknown")
+		} else if CompareKernelVersions(facts.KernelVersion, c.MinKernelVersion) < 0 {
+			unmet = append(unmet, fmt.Sprintf("kernel %s older than %s", facts.KernelVersion, c.MinKernelVersion))
+		}
+	}
+	// require the unified hierarchy
+	if c.CgroupV2 && !facts.CgroupV2 {
+		unmet = append(unmet, "cgroup v2 not mounted")
+	}
+	// match the architecture
+	if len(c.Architectures) > 0 && !slices.ContainsFunc(c.Architectures, func(arch string) bool {
+		return NormalizeArchitecture(arch) == facts.Architecture
+	}) {
+		unmet = append(unmet, fmt.Sprintf("architecture %s not in %s", facts.Architecture, strings.Join(c.Architectures, ", ")))
+	}
+	// check each path
+	for _, path := range c.PathExists {
+		name, negated := strings.CutPrefix(path, conditionNegation)
+		// the path must exist, or must not when negated
+		if facts.Paths[name] == negated {
+			unmet = append(unmet, pathCondition(name, negated))
+		}
+	}
+	// compare the available memory
+	if required := c.MinFreeMemoryBytes(); required > 0 && facts.AvailableMemory < required {
+		unmet = append(unmet, fmt.Sprintf("available memory %s below %s",
+			shared.FormatSize(int64(facts.AvailableMemory)), shared.FormatSize(int64(required))))
+	}
+	// return unmet conditions
+	return unmet
+}
+
+// pathCondition describes an unmet path condition.
+//
+// Params:
+//   - path: the path.
+//   - negated: true if the path must not exist.
+//
+// Returns:
+//   - string: the description.
+func pathCondition(path string, negated bool) string {
+	// the path exists but must not
+	if negated {
+		// return unexpected path
+		return fmt.Sprintf("path %s exists", path)
+	}
+	// return missing path
+	return fmt.Sprintf("path %s does not exist", path)
+}
+
+// NormalizeArchitecture returns the GOARCH name of an architecture.
+//
+// Params:
+//   - arch: a GOARCH or uname architecture name.
+//
+// Returns:
+//   - string: the lower-case GOARCH name.
+func NormalizeArchitecture(arch string) string {
+	arch = strings.ToLower(strings.TrimSpace(arch))
+	// translate uname names
+	if alias, ok := architectureAliases[arch]; ok {
+		// return GOARCH name
+		return alias
+	}
+	// return name as is
+	return arch
+}
+
+// CompareKernelVersions compares the leading dotted numbers of two kernel
+// releases: "6.1.0-18-amd64" is newer than "5.10". Missing numbers count as zero.
+//
+// Params:
+//   - a: the first release.
+//   - b: the second release.
+//
+// Returns:
+//   - int: -1 if a is older than b, 1 if newer, 0 if equal.
+func CompareKernelVersions(a, b string) int {
+	// compare number by number
+	return slices.Compare(padVersion(kernelNumbers(a), kernelNumbers(b)))
+}
+
+// padVersion pads two version number lists to the same length with zeros.
+//
+// Params:
+//   - a: the first numbers.
+//   - b: the second numbers.
+//
+// Returns:
+//   - []int: the padded first numbers.
+//   - []int: the padded second numbers.
+func padVersion(a, b []int) ([]int, []int) {
+	// extend the shorter list
+	for len(a) < len(b) {
+		a = append(a, 0)
+	}
+	// extend the shorter list
+	for len(b) < len(a) {
+		b = append(b, 0)
+	}
+	// return equal-length lists
+	return a, b
+}
+
+// kernelNumbers parses the leading dotted numbers of a kernel release.
+//
+// Params:
+//   - release: the release, such as "6.1.0-18-amd64".
+//
+// Returns:
+//   - []int: the numbers, such as 6, 1 and 0.
+func kernelNumbers(release string) []int {
+	var numbers []int
+	// stop at the first non-numeric part
+	for part := range strings.SplitSeq(release, ".") {
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		// keep the digits before a suffix such as "0-18-amd64"
+		if end >= 0 {
+			part = part[:end]
+		}
+		n, err := strconv.Atoi(part)
+		// no digits left
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		// the suffix ends the version
+		if end >= 0 {
+			break
+		}
+	}
+	// return parsed numbers
+	return numbers
+}
+
+// validateConditions validates the start conditions of a service.
+//
+// Params:
+//   - c: conditions to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateConditions(c *ConditionConfig) error {
+	// check kernel version is dotted numbers
+	if c.MinKernelVersion != "" {
+		// each part must be a number
+		for part := range strings.SplitSeq(c.MinKernelVersion, ".") {
+			// refuse suffixes and empty parts
+			if _, err := strconv.ParseUint(part, shared.Base10, shared.BitSize64); err != nil {
+				// return error with the version
+				return fmt.Errorf("%w: %q", ErrInvalidKernelVersion, c.MinKernelVersion)
+			}
+		}
+	}
+	// check memory parses when set
+	if c.MinFreeMemory != "" {
+		// parse human-readable size
+		if _, err := shared.ParseSize(c.MinFreeMemory); err != nil {
+			// return error with the size
+			return fmt.Errorf("%w %q: %w", ErrInvalidMinFreeMemory, c.MinFreeMemory, err)
+		}
+	}
+	// check architectures are named
+	if slices.ContainsFunc(c.Architectures, func(arch string) bool { return strings.TrimSpace(arch) == "" }) {
+		// return empty architecture
+		return ErrEmptyArchitecture
+	}
+	// check paths are absolute
+	for _, path := range c.Paths() {
+		// relative paths depend on the daemon directory
+		if !filepath.IsAbs(path) {
+			// return error with the path
+			return fmt.Errorf("%w: %q", ErrInvalidConditionPath, path)
+		}
+	}
+	// validation passed
+	return nil
+}
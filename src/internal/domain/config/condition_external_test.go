@@ -0,0 +1,128 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestCompareKernelVersions tests kernel release ordering.
+//
+// Params:
+//   - t: the testing context.
+func TestCompareKernelVersions(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// a is the first release.
+		a string
+		// b is the second release.
+		b string
+		// want is the expected comparison.
+		want int
+	}{
+		{name: "distribution suffix", a: "6.1.0-18-amd64", b: "5.10", want: 1},
+		{name: "older minor", a: "5.4.0-150-generic", b: "5.10", want: -1},
+		{name: "missing numbers are zero", a: "5.10", b: "5.10.0", want: 0},
+		{name: "plus suffix", a: "5.15.153.1-microsoft-standard-WSL2+", b: "5.15.154", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, config.CompareKernelVersions(tt.a, tt.b))
+		})
+	}
+}
+
+// TestConditionConfig_Unmet tests the conditions against host facts.
+//
+// Params:
+//   - t: the testing context.
+func TestConditionConfig_Unmet(t *testing.T) {
+	facts := &config.HostFacts{
+		KernelVersion:   "5.4.0-150-generic",
+		Architecture:    "amd64",
+		CgroupV2:        false,
+		AvailableMemory: uint64(256 * shared.Megabyte),
+		Paths:           map[string]bool{"/dev/kvm": true},
+	}
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// conditions is the configuration.
+		conditions config.ConditionConfig
+		// want is the expected unmet conditions.
+		want []string
+	}{
+		{name: "none", want: nil},
+		{
+			name:       "met",
+			conditions: config.ConditionConfig{MinKernelVersion: "4.19", Architectures: []string{"x86_64", "arm64"}, PathExists: []string{"/dev/kvm", "!/etc/disabled"}, MinFreeMemory: "128MB"},
+			want:       nil,
+		},
+		{name: "kernel", conditions: config.ConditionConfig{MinKernelVersion: "5.10"}, want: []string{"kernel 5.4.0-150-generic older than 5.10"}},
+		{name: "cgroup v2", conditions: config.ConditionConfig{CgroupV2: true}, want: []string{"cgroup v2 not mounted"}},
+		{name: "architecture", conditions: config.ConditionConfig{Architectures: []string{"arm64"}}, want: []string{"architecture amd64 not in arm64"}},
+		{name: "missing path", conditions: config.ConditionConfig{PathExists: []string{"/dev/nvidia0"}}, want: []string{"path /dev/nvidia0 does not exist"}},
+		{name: "negated path", conditions: config.ConditionConfig{PathExists: []string{"!/dev/kvm"}}, want: []string{"path /dev/kvm exists"}},
+		{name: "memory", conditions: config.ConditionConfig{MinFreeMemory: "1GB"}, want: []string{"available memory 256MB below 1GB"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.conditions.Unmet(facts))
+			assert.Equal(t, tt.name == "none", tt.conditions.IsZero())
+		})
+	}
+}
+
+// TestConditionConfig_Unmet_unknownKernel tests that an unread kernel release fails its condition.
+//
+// Params:
+//   - t: the testing context.
+func TestConditionConfig_Unmet_unknownKernel(t *testing.T) {
+	conditions := config.ConditionConfig{MinKernelVersion: "5.10"}
+	assert.Equal(t, []string{"kernel version unknown"}, conditions.Unmet(&config.HostFacts{}))
+}
+
+// TestValidate_conditions tests the validation of service start conditions.
+//
+// Params:
+//   - t: the testing context.
+func TestValidate_conditions(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// conditions is the configuration.
+		conditions config.ConditionConfig
+		// wantErr is the expected error, nil when valid.
+		wantErr error
+	}{
+		{name: "valid", conditions: config.ConditionConfig{MinKernelVersion: "5.10", Architectures: []string{"amd64"}, PathExists: []string{"!/etc/disabled"}, MinFreeMemory: "512MB"}},
+		{name: "kernel suffix", conditions: config.ConditionConfig{MinKernelVersion: "5.10-rc1"}, wantErr: config.ErrInvalidKernelVersion},
+		{name: "memory", conditions: config.ConditionConfig{MinFreeMemory: "lots"}, wantErr: config.ErrInvalidMinFreeMemory},
+		{name: "relative path", conditions: config.ConditionConfig{PathExists: []string{"!run/flag"}}, wantErr: config.ErrInvalidConditionPath},
+		{name: "empty architecture", conditions: config.ConditionConfig{Architectures: []string{""}}, wantErr: config.ErrEmptyArchitecture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Version:  "1",
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Conditions: tt.conditions}},
+			}
+			err := cfg.Validate()
+			// Valid conditions pass.
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -17,6 +17,35 @@ type Config struct {
 	Monitoring MonitoringConfig
 	// Services contains the list of service configurations to manage.
 	Services []ServiceConfig
+	// OnBootFailure defines what happens when a critical service fails at boot.
+	// Empty behaves as BootFailureContinue.
+	OnBootFailure BootFailurePolicy
+	// Incidents configures the correlation of close failures into incidents.
+	Incidents IncidentConfig
+	// Notifications configures the delivery of events to external channels.
+	Notifications NotificationsConfig
+	// Admission checks service reservations against host capacity at start.
+	Admission AdmissionConfig
+	// Shedding stops low-priority services under host memory pressure.
+	Shedding SheddingConfig
+	// LeakCheck reconciles the resources held per process start with the services.
+	LeakCheck LeakCheckConfig
+	// RestartBudget bounds the restarts performed across all services.
+	RestartBudget RestartBudgetConfig
+	// SafeMode holds non-critical restarts during restart storms.
+	SafeMode SafeModeConfig
+	// TrafficSampling samples the connections of the services into a dependency map.
+	TrafficSampling TrafficSamplingConfig
+	// Hooks run commands on events, the event as JSON on their standard input.
+	Hooks EventHooksConfig
+	// Watchers run commands whose outcome transitions emit custom events.
+	Watchers []WatcherConfig
+	// Control configures what the control plane accepts.
+	Control ControlConfig
+	// ShutdownReport configures the report written when the daemon stops.
+	ShutdownReport ShutdownReportConfig
+	// HostShutdown starts the ordered shutdown on advance notice from the host.
+	HostShutdown HostShutdownConfig
 	// ConfigPath stores the path from which this configuration was loaded.
 	ConfigPath string
 }
@@ -0,0 +1,30 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+// Console writer color modes.
+const (
+	// ConsoleColorAuto colors the output when stdout is a terminal and NO_COLOR is unset.
+	ConsoleColorAuto string = "auto"
+	// ConsoleColorAlways colors the output, even when piped.
+	ConsoleColorAlways string = "always"
+	// ConsoleColorNever never colors the output.
+	ConsoleColorNever string = "never"
+)
+
+// Console writer line formats.
+const (
+	// ConsoleFormatText renders full timestamps and bracketed levels.
+	ConsoleFormatText string = "text"
+	// ConsoleFormatHuman renders compact lines: short time, level, service,
+	// message and quoted key=value pairs, for humans reading docker logs.
+	ConsoleFormatHuman string = "human"
+)
+
+// ConsoleWriterConfig defines configuration for console writers.
+// It specifies how lines written to stdout and stderr are colored and rendered.
+type ConsoleWriterConfig struct {
+	// Color is the color mode: "auto" (default), "always" or "never".
+	Color string
+	// Format is the line format: "text" (default) or "human".
+	Format string
+}
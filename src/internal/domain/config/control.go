@@ -0,0 +1,15 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import "github.com/kodflow/daemon/internal/domain/shared"
+
+// ErrReadOnly is returned for changes requested while the control plane is read-only.
+var ErrReadOnly error = shared.NewCodedError(shared.CodeReadOnly, "control plane is read-only: changes go through the configuration file")
+
+// ControlConfig configures what the control plane accepts.
+type ControlConfig struct {
+	// ReadOnly refuses every mutating API request and CLI command, for
+	// hosts where changes only go through configuration management.
+	// Signals sent to the daemon and to services keep working.
+	ReadOnly bool
+}
@@ -21,3 +21,33 @@ func DefaultDaemonLogging() DaemonLogging {
 		},
 	}
 }
+
+// ContainerDaemonLogging returns the daemon logging used as container init.
+// Humans read docker logs directly, so the console writer renders the
+// compact human format, colored when attached to a terminal.
+//
+// Returns:
+//   - DaemonLogging: container daemon logging configuration.
+func ContainerDaemonLogging() DaemonLogging {
+	// return console writer at info level with human format
+	return DaemonLogging{
+		Writers: []WriterConfig{
+			{Type: "console", Level: "info", Console: ConsoleWriterConfig{Color: ConsoleColorAuto, Format: ConsoleFormatHuman}},
+		},
+	}
+}
+
+// JournalDaemonLogging returns the daemon logging used when systemd
+// connected the daemon to the journal. Events go to the journal with their
+// service and metadata as fields, rather than as console text.
+//
+// Returns:
+//   - DaemonLogging: journal daemon logging configuration.
+func JournalDaemonLogging() DaemonLogging {
+	// return journal writer at info level
+	return DaemonLogging{
+		Writers: []WriterConfig{
+			{Type: "journal", Level: "info"},
+		},
+	}
+}
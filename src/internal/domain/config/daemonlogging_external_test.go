@@ -50,3 +50,39 @@ func TestDefaultDaemonLogging(t *testing.T) {
 		})
 	}
 }
+
+// TestContainerDaemonLogging tests config.ContainerDaemonLogging function.
+// It verifies that container init mode logs to a human formatted console.
+//
+// Params:
+//   - t: testing context
+func TestContainerDaemonLogging(t *testing.T) {
+	t.Parallel()
+
+	result := config.ContainerDaemonLogging()
+
+	// Verify the single console writer.
+	if assert.Len(t, result.Writers, 1) {
+		assert.Equal(t, "console", result.Writers[0].Type)
+		assert.Equal(t, "info", result.Writers[0].Level)
+		assert.Equal(t, config.ConsoleFormatHuman, result.Writers[0].Console.Format)
+		assert.Equal(t, config.ConsoleColorAuto, result.Writers[0].Console.Color)
+	}
+}
+
+// TestJournalDaemonLogging tests config.JournalDaemonLogging function.
+// It verifies that a daemon connected to the journal logs to it.
+//
+// Params:
+//   - t: testing context
+func TestJournalDaemonLogging(t *testing.T) {
+	t.Parallel()
+
+	result := config.JournalDaemonLogging()
+
+	// Verify the single journal writer.
+	if assert.Len(t, result.Writers, 1) {
+		assert.Equal(t, "journal", result.Writers[0].Type)
+		assert.Equal(t, "info", result.Writers[0].Level)
+	}
+}
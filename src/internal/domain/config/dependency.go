@@ -0,0 +1,99 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// External dependency validation errors.
+var (
+	// ErrEmptyDependencyName indicates an external dependency without a name.
+	ErrEmptyDependencyName error = errors.New("external dependency name is required")
+	// ErrDuplicateDependencyName indicates two external dependencies with the same name.
+	ErrDuplicateDependencyName error = errors.New("duplicate external dependency name")
+	// ErrMissingDependencyProbe indicates an external dependency without a probe type.
+	ErrMissingDependencyProbe error = errors.New("external dependency requires a probe type")
+	// ErrMissingDependencyAddress indicates a network probe without a dependency address.
+	ErrMissingDependencyAddress error = errors.New("external dependency requires an address")
+	// ErrDependencyUnhealthyAction indicates an unhealthy action on a dependency probe.
+	ErrDependencyUnhealthyAction error = errors.New("on_unhealthy applies to listener probes only")
+)
+
+// DependencyConfig declares an external system a service relies on, such as
+// a database, a cache or a third-party API. The dependency is probed like a
+// listener, but outside the service: its status is reported next to the
+// service and never makes the service itself unhealthy.
+type DependencyConfig struct {
+	// Name identifies the dependency within the service.
+	Name string
+	// Address is the dependency address.
+	// Format: "host:port" for tcp, udp and grpc, a URL or "host:port" for http
+	// and scenario, "host" for icmp. Unused by exec probes.
+	Address string
+	// Probe configures how the dependency is checked.
+	Probe ProbeConfig
+	// GateRestart holds back health-check restarts of the service while the
+	// dependency is down, since restarting would not help.
+	GateRestart bool
+}
+
+// validateDependencies validates the external dependencies of a service.
+//
+// Params:
+//   - deps: the external dependencies to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateDependencies(deps []DependencyConfig) error {
+	seen := make(map[string]bool, len(deps))
+	// validate each dependency
+	for i := range deps {
+		dep := &deps[i]
+		// check if dependency has a name
+		if dep.Name == "" {
+			// return error when name is empty
+			return ErrEmptyDependencyName
+		}
+		// check for name reuse
+		if seen[dep.Name] {
+			// return error with duplicated name
+			return fmt.Errorf("%w: %q", ErrDuplicateDependencyName, dep.Name)
+		}
+		seen[dep.Name] = true
+
+		// check that a probe is configured
+		if dep.Probe.Type == "" {
+			// return error with dependency name
+			return fmt.Errorf("external dependency %q: %w", dep.Name, ErrMissingDependencyProbe)
+		}
+		// network probes need somewhere to connect
+		if dep.Address == "" && dep.Probe.Type != ProbeTypeExec && (dep.Probe.Type != ProbeTypeThreshold || dep.Probe.Command == "") {
+			// return error with dependency name
+			return fmt.Errorf("external dependency %q: %w", dep.Name, ErrMissingDependencyAddress)
+		}
+		// a failing dependency never acts on the service
+		if len(dep.Probe.OnUnhealthy) > 0 {
+			// return error with dependency name
+			return fmt.Errorf("external dependency %q: %w", dep.Name, ErrDependencyUnhealthyAction)
+		}
+		// validate the scenario steps
+		if dep.Probe.Type == ProbeTypeScenario {
+			// propagate scenario error
+			if err := validateScenario(dep.Probe.Steps); err != nil {
+				// return error with dependency name
+				return fmt.Errorf("external dependency %q: probe: %w", dep.Name, err)
+			}
+		}
+		// validate the value check
+		if dep.Probe.Type == ProbeTypeThreshold {
+			// propagate threshold error
+			if err := validateThreshold(dep.Probe.Threshold); err != nil {
+				// return error with dependency name
+				return fmt.Errorf("external dependency %q: probe: %w", dep.Name, err)
+			}
+		}
+	}
+	// validation passed
+	return nil
+}
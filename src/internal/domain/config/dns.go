@@ -0,0 +1,214 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// maxNameservers is how many nameservers the resolver uses; further ones
+// in resolv.conf are ignored.
+const maxNameservers int = 3
+
+// DNS override validation errors.
+var (
+	// ErrInvalidHostsIP indicates a hosts entry without a valid IP address.
+	ErrInvalidHostsIP error = errors.New("hosts entry requires a valid ip")
+	// ErrHostsEntryWithoutName indicates a hosts entry without host name.
+	ErrHostsEntryWithoutName error = errors.New("hosts entry requires at least one name")
+	// ErrInvalidDNSName indicates an empty name or one containing spaces.
+	ErrInvalidDNSName error = errors.New("dns names must be non-empty without spaces")
+	// ErrInvalidNameserver indicates a nameserver which is not an IP address.
+	ErrInvalidNameserver error = errors.New("nameserver must be an ip address")
+	// ErrTooManyNameservers indicates more nameservers than the resolver uses.
+	ErrTooManyNameservers error = errors.New("at most 3 nameservers are used by the resolver")
+	// ErrDNSWithoutNameserver indicates search domains or options without nameserver.
+	ErrDNSWithoutNameserver error = errors.New("dns search and options require nameservers")
+)
+
+// DNSConfig overrides the name resolution of a service: the hosts file and
+// resolv.conf it sees are rendered from it and mounted over /etc/hosts and
+// /etc/resolv.conf in its mount namespace, leaving the host's untouched.
+type DNSConfig struct {
+	// Hosts are the entries of the service's /etc/hosts, after the
+	// localhost ones. Empty keeps the host's file unless nameservers are set.
+	Hosts []HostEntry
+	// Nameservers replace the host's resolvers in the service's
+	// /etc/resolv.conf. Empty keeps the host's resolv.conf.
+	Nameservers []string
+	// Search are the search domains of the service's resolv.conf.
+	Search []string
+	// Options are the resolver options of the service's resolv.conf
+	// (ndots:2, timeout:1, ...).
+	Options []string
+}
+
+// HostEntry maps an IP address to host names.
+type HostEntry struct {
+	// IP is the address the names resolve to.
+	IP string
+	// Names are the host names, the canonical one first.
+	Names []string
+}
+
+// IsZero reports whether no override is configured.
+//
+// Returns:
+//   - bool: true when the service sees the host's files.
+func (d *DNSConfig) IsZero() bool {
+	// no entry and no resolver
+	return len(d.Hosts) == 0 && len(d.Nameservers) == 0
+}
+
+// OverridesHosts reports whether the service gets its own /etc/hosts.
+//
+// Returns:
+//   - bool: true when hosts entries are configured.
+func (d *DNSConfig) OverridesHosts() bool {
+	// hosts entries replace the file
+	return len(d.Hosts) > 0
+}
+
+// OverridesResolvConf reports whether the service gets its own /etc/resolv.conf.
+//
+// Returns:
+//   - bool: true when nameservers are configured.
+func (d *DNSConfig) OverridesResolvConf() bool {
+	// nameservers replace the file
+	return len(d.Nameservers) > 0
+}
+
+// HostsFile renders the service's /etc/hosts: the localhost entries, so
+// loopback keeps resolving, followed by the configured ones.
+//
+// Returns:
+//   - string: the hosts file content.
+func (d *DNSConfig) HostsFile() string {
+	var b strings.Builder
+	b.WriteString("127.0.0.1\tlocalhost\n")
+	b.WriteString("::1\tlocalhost ip6-localhost ip6-loopback\n")
+	// one line per entry
+	for _, entry := range d.Hosts {
+		b.WriteString(entry.IP)
+		b.WriteByte('\t')
+		b.WriteString(strings.Join(entry.Names, " "))
+		b.WriteByte('\n')
+	}
+	// return rendered file
+	return b.String()
+}
+
+// ResolvConf renders the service's /etc/resolv.conf.
+//
+// Returns:
+//   - string: the resolv.conf content.
+func (d *DNSConfig) ResolvConf() string {
+	var b strings.Builder
+	// one line per resolver
+	for _, ns := range d.Nameservers {
+		b.WriteString("nameserver " + ns + "\n")
+	}
+	// search domains on one line
+	if len(d.Search) > 0 {
+		b.WriteString("search " + strings.Join(d.Search, " ") + "\n")
+	}
+	// options on one line
+	if len(d.Options) > 0 {
+		b.WriteString("options " + strings.Join(d.Options, " ") + "\n")
+	}
+	// return rendered file
+	return b.String()
+}
+
+// validateDNS validates the DNS overrides of a service.
+//
+// Params:
+//   - d: the DNS overrides to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateDNS(d *DNSConfig) error {
+	// validate each hosts entry
+	for i := range d.Hosts {
+		entry := &d.Hosts[i]
+		// address must parse
+		if _, err := netip.ParseAddr(entry.IP); err != nil {
+			// return error with the entry index
+			return fmt.Errorf("hosts entry %d: %w: %q", i, ErrInvalidHostsIP, entry.IP)
+		}
+		// entry must name a host
+		if len(entry.Names) == 0 {
+			// return error with the entry index
+			return fmt.Errorf("hosts entry %d: %w", i, ErrHostsEntryWithoutName)
+		}
+		// names must fit the file format
+		if err := validateDNSNames(entry.Names); err != nil {
+			// return error with the entry index
+			return fmt.Errorf("hosts entry %d: %w", i, err)
+		}
+	}
+	// the resolver ignores extra nameservers
+	if len(d.Nameservers) > maxNameservers {
+		// return error with the count
+		return fmt.Errorf("%w: %d", ErrTooManyNameservers, len(d.Nameservers))
+	}
+	// validate each nameserver
+	for _, ns := range d.Nameservers {
+		// resolvers are addressed by IP
+		if _, err := netip.ParseAddr(ns); err != nil {
+			// return error with the nameserver
+			return fmt.Errorf("%w: %q", ErrInvalidNameserver, ns)
+		}
+	}
+	// search and options only land in a rendered resolv.conf
+	if len(d.Nameservers) == 0 && len(d.Search)+len(d.Options) > 0 {
+		// return missing nameserver error
+		return ErrDNSWithoutNameserver
+	}
+	// search domains must fit the file format
+	if err := validateDNSNames(d.Search); err != nil {
+		// return error with the field
+		return fmt.Errorf("search: %w", err)
+	}
+	// options must fit the file format
+	if err := validateDNSNames(d.Options); err != nil {
+		// return error with the field
+		return fmt.Errorf("options: %w", err)
+	}
+	// validation passed
+	return nil
+}
+
+// validateDNSNames validates words written on a hosts or resolv.conf line.
+//
+// Params:
+//   - names: the words to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateDNSNames(names []string) error {
+	// check each word
+	for _, name := range names {
+		// empty or spaced words break the line
+		if name == "" || strings.ContainsFunc(name, isDNSSpace) {
+			// return error with the name
+			return fmt.Errorf("%w: %q", ErrInvalidDNSName, name)
+		}
+	}
+	// names valid
+	return nil
+}
+
+// isDNSSpace reports whether a rune separates words in hosts or resolv.conf.
+//
+// Params:
+//   - r: the rune to check.
+//
+// Returns:
+//   - bool: true for spaces, tabs, newlines and comment markers.
+func isDNSSpace(r rune) bool {
+	// separators and comments
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '#'
+}
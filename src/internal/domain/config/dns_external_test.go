@@ -0,0 +1,124 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestDNSConfig_render tests the rendered hosts file and resolv.conf.
+//
+// Params:
+//   - t: the testing context.
+func TestDNSConfig_render(t *testing.T) {
+	dns := config.DNSConfig{
+		Hosts: []config.HostEntry{
+			{IP: "10.20.0.5", Names: []string{"db.staging.internal", "db"}},
+			{IP: "fd00::7", Names: []string{"cache"}},
+		},
+		Nameservers: []string{"10.20.0.53", "10.20.0.54"},
+		Search:      []string{"staging.internal"},
+		Options:     []string{"ndots:2", "timeout:1"},
+	}
+	assert.False(t, dns.IsZero())
+	assert.True(t, dns.OverridesHosts())
+	assert.True(t, dns.OverridesResolvConf())
+	assert.Equal(t, "127.0.0.1\tlocalhost\n"+
+		"::1\tlocalhost ip6-localhost ip6-loopback\n"+
+		"10.20.0.5\tdb.staging.internal db\n"+
+		"fd00::7\tcache\n", dns.HostsFile())
+	assert.Equal(t, "nameserver 10.20.0.53\n"+
+		"nameserver 10.20.0.54\n"+
+		"search staging.internal\n"+
+		"options ndots:2 timeout:1\n", dns.ResolvConf())
+
+	// Hosts entries alone keep the host's resolvers.
+	hostsOnly := config.DNSConfig{Hosts: dns.Hosts}
+	assert.True(t, hostsOnly.OverridesHosts())
+	assert.False(t, hostsOnly.OverridesResolvConf())
+
+	var zero config.DNSConfig
+	assert.True(t, zero.IsZero())
+}
+
+// TestValidate_DNS tests that malformed DNS overrides are refused.
+//
+// Params:
+//   - t: the testing context.
+func TestValidate_DNS(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// dns is the validated configuration.
+		dns config.DNSConfig
+		// wantErr is the expected error, nil when valid.
+		wantErr error
+	}{
+		{
+			name: "valid",
+			dns: config.DNSConfig{
+				Hosts:       []config.HostEntry{{IP: "10.0.0.5", Names: []string{"db"}}},
+				Nameservers: []string{"10.0.0.53", "::1"},
+				Search:      []string{"staging.internal"},
+				Options:     []string{"ndots:2"},
+			},
+		},
+		{
+			name:    "invalid_ip",
+			dns:     config.DNSConfig{Hosts: []config.HostEntry{{IP: "db", Names: []string{"db"}}}},
+			wantErr: config.ErrInvalidHostsIP,
+		},
+		{
+			name:    "entry_without_name",
+			dns:     config.DNSConfig{Hosts: []config.HostEntry{{IP: "10.0.0.5"}}},
+			wantErr: config.ErrHostsEntryWithoutName,
+		},
+		{
+			name:    "spaced_name",
+			dns:     config.DNSConfig{Hosts: []config.HostEntry{{IP: "10.0.0.5", Names: []string{"db cache"}}}},
+			wantErr: config.ErrInvalidDNSName,
+		},
+		{
+			name:    "invalid_nameserver",
+			dns:     config.DNSConfig{Nameservers: []string{"dns.internal"}},
+			wantErr: config.ErrInvalidNameserver,
+		},
+		{
+			name:    "too_many_nameservers",
+			dns:     config.DNSConfig{Nameservers: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}},
+			wantErr: config.ErrTooManyNameservers,
+		},
+		{
+			name:    "search_without_nameserver",
+			dns:     config.DNSConfig{Search: []string{"staging.internal"}},
+			wantErr: config.ErrDNSWithoutNameserver,
+		},
+		{
+			name:    "empty_option",
+			dns:     config.DNSConfig{Nameservers: []string{"10.0.0.53"}, Options: []string{""}},
+			wantErr: config.ErrInvalidDNSName,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", DNS: tt.dns}}}
+			err := config.Validate(cfg)
+			// Valid overrides pass.
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+			var fieldErr *config.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Contains(t, fieldErr.Key, "dns")
+		})
+	}
+}
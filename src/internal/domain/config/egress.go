@@ -0,0 +1,71 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Egress validation errors.
+var (
+	// ErrInvalidEgressCIDR indicates an egress rule without a valid CIDR.
+	ErrInvalidEgressCIDR error = errors.New("egress rule requires a valid cidr")
+	// ErrInvalidEgressPort indicates an egress port outside 1-65535.
+	ErrInvalidEgressPort error = errors.New("egress port must be between 1 and 65535")
+	// ErrInvalidEgressProtocol indicates an egress protocol other than tcp or udp.
+	ErrInvalidEgressProtocol error = errors.New("egress protocol must be tcp or udp")
+)
+
+// EgressRule allows outbound traffic of a service to a destination.
+// A service with egress rules can only open connections matching one
+// of them; replies to inbound connections are always allowed.
+type EgressRule struct {
+	// CIDR is the destination network (10.0.0.0/8, 2001:db8::/32, 1.2.3.4/32).
+	CIDR string
+	// Ports restricts the destination ports. Empty allows every port.
+	Ports []int
+	// Protocol restricts the transport (tcp or udp). Empty allows both,
+	// and any protocol when no port is listed.
+	Protocol string
+}
+
+// validateEgress validates the egress rules of a service.
+//
+// Params:
+//   - rules: the rules to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateEgress(rules []EgressRule) error {
+	// validate each rule
+	for i := range rules {
+		rule := &rules[i]
+		// destination must be a network
+		if _, err := netip.ParsePrefix(rule.CIDR); err != nil {
+			// return error with the rule index
+			return fmt.Errorf("egress rule %d: %w: %q", i, ErrInvalidEgressCIDR, rule.CIDR)
+		}
+		// ports must be valid
+		for _, port := range rule.Ports {
+			// out of range port
+			if port < 1 || port > shared.MaxValidPort {
+				// return error with the port
+				return fmt.Errorf("egress rule %d: %w: %d", i, ErrInvalidEgressPort, port)
+			}
+		}
+		// only tcp and udp carry ports
+		switch rule.Protocol {
+		// supported protocols
+		case "", "tcp", "udp":
+		// unsupported protocol
+		default:
+			// return error with the protocol
+			return fmt.Errorf("egress rule %d: %w: %q", i, ErrInvalidEgressProtocol, rule.Protocol)
+		}
+	}
+	// validation passed
+	return nil
+}
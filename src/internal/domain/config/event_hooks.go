@@ -0,0 +1,150 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// DefaultEventHookTimeout bounds an event hook when none is configured.
+	DefaultEventHookTimeout time.Duration = 30 * time.Second
+	// DefaultEventHookConcurrency is how many event hooks run at once when none is configured.
+	DefaultEventHookConcurrency int = 4
+)
+
+// Event hook validation errors.
+var (
+	// ErrEventHookWithoutCommand indicates an event hook without command.
+	ErrEventHookWithoutCommand error = errors.New("event hook requires a command")
+	// ErrInvalidEventHookEvent indicates an empty event type in an event hook.
+	ErrInvalidEventHookEvent error = errors.New("event hook event types must not be empty")
+	// ErrInvalidEventHookTimeout indicates a negative event hook timeout.
+	ErrInvalidEventHookTimeout error = errors.New("event hook timeout must not be negative")
+	// ErrInvalidEventHookConcurrency indicates a negative max_concurrent.
+	ErrInvalidEventHookConcurrency error = errors.New("hooks max_concurrent must not be negative")
+)
+
+// EventHooksConfig runs commands on events, the event as JSON on their
+// standard input: the simplest extension point, without a webhook receiver.
+type EventHooksConfig struct {
+	// Commands are the hooks run on events.
+	Commands []EventHook
+	// MaxConcurrent bounds the hooks running at once across commands; the
+	// others wait for a slot. Zero uses DefaultEventHookConcurrency.
+	MaxConcurrent int
+}
+
+// EventHook is a command run on some event types.
+type EventHook struct {
+	// Events restricts the event types running the hook (started,
+	// exhausted, unhealthy, config_reloaded, ...). Empty runs it on every event.
+	Events []string
+	// Command is the executable, absolute or looked up in PATH.
+	Command string
+	// Args are the command arguments.
+	Args []string
+	// Timeout bounds the hook; it is killed once expired. Zero uses
+	// DefaultEventHookTimeout.
+	Timeout shared.Duration
+}
+
+// Runs reports whether the hook runs on an event type.
+//
+// Params:
+//   - event: the event type name.
+//
+// Returns:
+//   - bool: true without event types, or when the type is listed.
+func (h *EventHook) Runs(event string) bool {
+	// every event without restriction, listed ones otherwise
+	return len(h.Events) == 0 || slices.Contains(h.Events, event)
+}
+
+// EffectiveTimeout returns the timeout bounding the hook.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultEventHookTimeout.
+func (h *EventHook) EffectiveTimeout() time.Duration {
+	// fall back to the default timeout
+	if h.Timeout <= 0 {
+		// return default
+		return DefaultEventHookTimeout
+	}
+	// return configured timeout
+	return h.Timeout.Duration()
+}
+
+// EffectiveMaxConcurrent returns how many hooks run at once.
+//
+// Returns:
+//   - int: the configured limit, or DefaultEventHookConcurrency.
+func (e *EventHooksConfig) EffectiveMaxConcurrent() int {
+	// fall back to the default limit
+	if e.MaxConcurrent <= 0 {
+		// return default
+		return DefaultEventHookConcurrency
+	}
+	// return configured limit
+	return e.MaxConcurrent
+}
+
+// Matching returns the hooks running on an event type.
+//
+// Params:
+//   - event: the event type name.
+//
+// Returns:
+//   - []EventHook: the hooks to run, in configuration order.
+func (e *EventHooksConfig) Matching(event string) []EventHook {
+	var hooks []EventHook
+	// keep the hooks listening to the event
+	for i := range e.Commands {
+		// the hook runs on this event
+		if e.Commands[i].Runs(event) {
+			hooks = append(hooks, e.Commands[i])
+		}
+	}
+	// return matching hooks
+	return hooks
+}
+
+// validateEventHooks validates the event hooks.
+//
+// Params:
+//   - e: event hooks configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateEventHooks(e *EventHooksConfig) error {
+	// check concurrency is not negative
+	if e.MaxConcurrent < 0 {
+		// return error with the value
+		return fmt.Errorf("%w: %d", ErrInvalidEventHookConcurrency, e.MaxConcurrent)
+	}
+	// validate each hook
+	for i := range e.Commands {
+		hook := &e.Commands[i]
+		// check the hook has a command
+		if hook.Command == "" {
+			// return error with hook index
+			return fmt.Errorf("hook %d: %w", i, ErrEventHookWithoutCommand)
+		}
+		// check event types are named
+		if slices.Contains(hook.Events, "") {
+			// return error with hook index
+			return fmt.Errorf("hook %d: %w", i, ErrInvalidEventHookEvent)
+		}
+		// check timeout is not negative
+		if hook.Timeout < 0 {
+			// return error with hook index
+			return fmt.Errorf("hook %d: %w", i, ErrInvalidEventHookTimeout)
+		}
+	}
+	// validation passed
+	return nil
+}
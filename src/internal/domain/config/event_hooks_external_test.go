@@ -0,0 +1,95 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestEventHooksConfig tests the event hook defaults and matching.
+//
+// Params:
+//   - t: the testing context.
+func TestEventHooksConfig(t *testing.T) {
+	hooks := config.EventHooksConfig{Commands: []config.EventHook{
+		{Command: "/bin/audit"},
+		{Command: "/bin/page", Events: []string{"exhausted", "unhealthy"}, Timeout: shared.Seconds(5)},
+	}}
+	assert.Equal(t, config.DefaultEventHookConcurrency, hooks.EffectiveMaxConcurrent())
+	assert.Equal(t, config.DefaultEventHookTimeout, hooks.Commands[0].EffectiveTimeout())
+	assert.Equal(t, 5*time.Second, hooks.Commands[1].EffectiveTimeout())
+
+	// Hooks without event types run on every event.
+	assert.Equal(t, []config.EventHook{hooks.Commands[0]}, hooks.Matching("started"))
+	assert.Equal(t, hooks.Commands, hooks.Matching("exhausted"))
+
+	hooks.MaxConcurrent = 1
+	assert.Equal(t, 1, hooks.EffectiveMaxConcurrent())
+}
+
+// TestValidate_EventHooks tests that incomplete event hooks are refused.
+//
+// Params:
+//   - t: the testing context.
+func TestValidate_EventHooks(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// hooks is the validated configuration.
+		hooks config.EventHooksConfig
+		// wantErr is the expected error, nil when valid.
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			hooks: config.EventHooksConfig{MaxConcurrent: 2, Commands: []config.EventHook{{Command: "/bin/page", Events: []string{"config_reloaded"}}}},
+		},
+		{
+			name:    "missing_command",
+			hooks:   config.EventHooksConfig{Commands: []config.EventHook{{Events: []string{"started"}}}},
+			wantErr: config.ErrEventHookWithoutCommand,
+		},
+		{
+			name:    "empty_event",
+			hooks:   config.EventHooksConfig{Commands: []config.EventHook{{Command: "/bin/page", Events: []string{""}}}},
+			wantErr: config.ErrInvalidEventHookEvent,
+		},
+		{
+			name:    "negative_timeout",
+			hooks:   config.EventHooksConfig{Commands: []config.EventHook{{Command: "/bin/page", Timeout: -1}}},
+			wantErr: config.ErrInvalidEventHookTimeout,
+		},
+		{
+			name:    "negative_concurrency",
+			hooks:   config.EventHooksConfig{MaxConcurrent: -1},
+			wantErr: config.ErrInvalidEventHookConcurrency,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+				Hooks:    tt.hooks,
+			}
+			err := config.Validate(cfg)
+			// Valid hooks pass.
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+			var fieldErr *config.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, "hooks", fieldErr.Key)
+		})
+	}
+}
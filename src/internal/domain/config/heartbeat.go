@@ -0,0 +1,69 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Heartbeat validation errors.
+var (
+	// ErrInvalidHeartbeatURL indicates a heartbeat without an http(s) URL.
+	ErrInvalidHeartbeatURL error = errors.New("heartbeat requires an http or https url")
+	// ErrInvalidHeartbeatInterval indicates a heartbeat without a positive interval.
+	ErrInvalidHeartbeatInterval error = errors.New("heartbeat interval must be positive")
+	// ErrUnknownHeartbeatService indicates a heartbeat watching an undefined service.
+	ErrUnknownHeartbeatService error = errors.New("heartbeat service is not defined")
+)
+
+// HeartbeatConfig pings an external dead man's switch endpoint
+// (healthchecks.io style) while the daemon and the selected services are
+// healthy. The endpoint alerts when pings stop, even if the host is down.
+type HeartbeatConfig struct {
+	// URL is the http(s) endpoint pinged.
+	URL string
+	// Interval is the time between pings.
+	Interval shared.Duration
+	// Services must all be running and healthy for a ping to be sent.
+	// Empty only requires the daemon to be running.
+	Services []string
+}
+
+// validateHeartbeats validates the heartbeat endpoints.
+//
+// Params:
+//   - heartbeats: the heartbeats to validate.
+//   - services: the defined service names.
+//
+// Returns:
+//   - error: validation error if any.
+func validateHeartbeats(heartbeats []HeartbeatConfig, services map[string]bool) error {
+	// validate each heartbeat
+	for i := range heartbeats {
+		hb := &heartbeats[i]
+		u, err := url.Parse(hb.URL)
+		// only http(s) endpoints are supported
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			// return error with heartbeat index
+			return fmt.Errorf("heartbeat %d: %w", i, ErrInvalidHeartbeatURL)
+		}
+		// check interval is positive
+		if hb.Interval <= 0 {
+			// return error with heartbeat index
+			return fmt.Errorf("heartbeat %d: %w", i, ErrInvalidHeartbeatInterval)
+		}
+		// check watched services exist
+		for _, name := range hb.Services {
+			// unknown service
+			if !services[name] {
+				// return error with service name
+				return fmt.Errorf("heartbeat %d: %w: %q", i, ErrUnknownHeartbeatService, name)
+			}
+		}
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,17 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+// HostFacts are the host properties that service start conditions are
+// checked against. Facts that could not be read keep their zero value.
+type HostFacts struct {
+	// KernelVersion is the kernel release (e.g., "6.1.0-18-amd64"), empty when unknown.
+	KernelVersion string
+	// Architecture is the CPU architecture, as a GOARCH name (e.g., "amd64").
+	Architecture string
+	// CgroupV2 reports whether the unified cgroup v2 hierarchy is mounted.
+	CgroupV2 bool
+	// AvailableMemory is the memory available for new processes, in bytes.
+	AvailableMemory uint64
+	// Paths reports, for each path asked for, whether it exists.
+	Paths map[string]bool
+}
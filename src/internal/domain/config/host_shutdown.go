@@ -0,0 +1,49 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// ErrInvalidACPISocket indicates an acpid socket path that is not absolute.
+var ErrInvalidACPISocket error = errors.New("host shutdown acpi socket must be an absolute path")
+
+// HostShutdownConfig configures the advance notice of a host shutdown, so
+// the ordered stop of the services begins before the host kills them.
+type HostShutdownConfig struct {
+	// Logind holds a delay inhibitor lock from systemd-logind and starts the
+	// shutdown when logind announces the host shutdown or reboot. The lock
+	// is released once every service is stopped.
+	Logind bool
+	// ACPISocket is the acpid socket whose power button events start the
+	// shutdown. Empty disables it.
+	ACPISocket string
+}
+
+// Enabled reports whether any source of host shutdown notice is set.
+//
+// Returns:
+//   - bool: true when logind or an acpid socket is used.
+func (h *HostShutdownConfig) Enabled() bool {
+	// listen when a source is configured
+	return h.Logind || h.ACPISocket != ""
+}
+
+// validateHostShutdown validates the host shutdown settings.
+//
+// Params:
+//   - h: host shutdown configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateHostShutdown(h *HostShutdownConfig) error {
+	// the socket is found by its path
+	if h.ACPISocket != "" && !filepath.IsAbs(h.ACPISocket) {
+		// return error with the path
+		return fmt.Errorf("%w: %q", ErrInvalidACPISocket, h.ACPISocket)
+	}
+	// validation passed
+	return nil
+}
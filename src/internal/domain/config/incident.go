@@ -0,0 +1,28 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import "github.com/kodflow/daemon/internal/domain/shared"
+
+// minIncidentServices is the smallest number of services an incident can group.
+const minIncidentServices int = 2
+
+// IncidentConfig configures the correlation of failures into incidents.
+// Failures of distinct services occurring within Window of each other are
+// reported once as an incident event, in addition to the per-service events.
+type IncidentConfig struct {
+	// Window is the maximum gap between correlated failures.
+	// Zero disables correlation.
+	Window shared.Duration
+	// MinServices is the number of distinct failing services opening an incident.
+	// Zero defaults to 2.
+	MinServices int
+}
+
+// Enabled reports whether failures are correlated.
+//
+// Returns:
+//   - bool: true when a window is configured.
+func (c *IncidentConfig) Enabled() bool {
+	// a window is required to correlate
+	return c.Window > 0
+}
@@ -0,0 +1,84 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultIntegrityInterval is the re-hash period of watched files when
+// none is configured. Change notifications are immediate; the re-hash
+// catches changes the kernel did not report (network filesystems).
+const DefaultIntegrityInterval time.Duration = 5 * time.Minute
+
+// Integrity validation errors.
+var (
+	// ErrInvalidIntegrityPath indicates a watched path that is not absolute.
+	ErrInvalidIntegrityPath error = errors.New("integrity path must be absolute")
+	// ErrInvalidIntegrityInterval indicates a negative re-hash interval.
+	ErrInvalidIntegrityInterval error = errors.New("integrity interval must not be negative")
+)
+
+// IntegrityConfig watches the service binary and extra files for
+// modification. Files are hashed when the watch starts and re-hashed on
+// change notifications and periodically; a content change emits a
+// file_changed event.
+type IntegrityConfig struct {
+	// Paths are extra files watched besides the service binary.
+	Paths []string
+	// Interval is the periodic re-hash period.
+	// Zero uses DefaultIntegrityInterval.
+	Interval shared.Duration
+}
+
+// WatchesIntegrity reports whether the files of the service are watched.
+//
+// Returns:
+//   - bool: true if a restart on binary change, extra paths or an interval is set.
+func (s *ServiceConfig) WatchesIntegrity() bool {
+	// any integrity setting enables the watch
+	return s.RestartOnBinaryChange || len(s.Integrity.Paths) > 0 || s.Integrity.Interval > 0
+}
+
+// IntegrityInterval returns the effective re-hash period.
+//
+// Returns:
+//   - time.Duration: the configured interval, or DefaultIntegrityInterval.
+func (c *IntegrityConfig) IntegrityInterval() time.Duration {
+	// fall back to the default period
+	if c.Interval <= 0 {
+		// return default
+		return DefaultIntegrityInterval
+	}
+	// return configured period
+	return c.Interval.Duration()
+}
+
+// validateIntegrity validates the integrity watch of a service.
+//
+// Params:
+//   - cfg: the integrity configuration to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateIntegrity(cfg *IntegrityConfig) error {
+	// interval must not be negative
+	if cfg.Interval < 0 {
+		// return error with the interval
+		return fmt.Errorf("%w: %s", ErrInvalidIntegrityInterval, cfg.Interval)
+	}
+	// watched paths must be absolute
+	for _, path := range cfg.Paths {
+		// relative path
+		if !filepath.IsAbs(path) {
+			// return error with the path
+			return fmt.Errorf("%w: %q", ErrInvalidIntegrityPath, path)
+		}
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,49 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestServiceConfig_WatchesIntegrity tests which settings enable the integrity watch.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_WatchesIntegrity(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// svc is the service configuration.
+		svc config.ServiceConfig
+		// expected is the expected result.
+		expected bool
+	}{
+		{name: "disabled", svc: config.ServiceConfig{}, expected: false},
+		{name: "restart_on_binary_change", svc: config.ServiceConfig{RestartOnBinaryChange: true}, expected: true},
+		{name: "extra_paths", svc: config.ServiceConfig{Integrity: config.IntegrityConfig{Paths: []string{"/etc/a"}}}, expected: true},
+		{name: "interval", svc: config.ServiceConfig{Integrity: config.IntegrityConfig{Interval: shared.Duration(time.Minute)}}, expected: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.svc.WatchesIntegrity())
+		})
+	}
+}
+
+// TestIntegrityConfig_IntegrityInterval tests the default re-hash period.
+//
+// Params:
+//   - t: the testing context.
+func TestIntegrityConfig_IntegrityInterval(t *testing.T) {
+	assert.Equal(t, config.DefaultIntegrityInterval, (&config.IntegrityConfig{}).IntegrityInterval())
+	assert.Equal(t, time.Minute, (&config.IntegrityConfig{Interval: shared.Duration(time.Minute)}).IntegrityInterval())
+}
@@ -0,0 +1,98 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import "net"
+
+// IPFamily identifies the address family a listener binds or a probe dials.
+// Its value is the suffix appended to the transport to form a Go network
+// name, so "tcp" + IPFamilyV6 yields "tcp6".
+type IPFamily string
+
+// IP family values.
+const (
+	// IPFamilyDual accepts both IPv4 and IPv6 (dual-stack).
+	IPFamilyDual IPFamily = ""
+	// IPFamilyV4 restricts sockets to IPv4.
+	IPFamilyV4 IPFamily = "4"
+	// IPFamilyV6 restricts sockets to IPv6.
+	IPFamilyV6 IPFamily = "6"
+)
+
+// Loopback hosts used when a listener does not set a bind address.
+const (
+	// loopbackHostDual resolves to the IPv4 or IPv6 loopback.
+	loopbackHostDual string = "localhost"
+	// loopbackHostV4 is the IPv4 loopback address.
+	loopbackHostV4 string = "127.0.0.1"
+	// loopbackHostV6 is the IPv6 loopback address.
+	loopbackHostV6 string = "::1"
+)
+
+// Overlaps reports whether two families can bind or reach the same addresses.
+// Dual-stack overlaps with every family.
+//
+// Params:
+//   - other: the family to compare against.
+//
+// Returns:
+//   - bool: true unless one family is IPv4-only and the other IPv6-only.
+func (f IPFamily) Overlaps(other IPFamily) bool {
+	// dual-stack covers both families
+	if f == IPFamilyDual || other == IPFamilyDual {
+		// any family overlaps dual-stack
+		return true
+	}
+	// single families overlap only with themselves
+	return f == other
+}
+
+// Loopback returns the loopback host for the family.
+//
+// Returns:
+//   - string: the loopback host.
+func (f IPFamily) Loopback() string {
+	// pick loopback matching the family
+	switch f {
+	// ipv4 only
+	case IPFamilyV4:
+		// ipv4 loopback literal
+		return loopbackHostV4
+	// ipv6 only
+	case IPFamilyV6:
+		// ipv6 loopback literal
+		return loopbackHostV6
+	// dual-stack
+	default:
+		// let the resolver choose
+		return loopbackHostDual
+	}
+}
+
+// addressFamily returns the family implied by an IP literal.
+// Hostnames and unspecified IPv6 addresses are dual-stack.
+//
+// Params:
+//   - host: the host without brackets.
+//
+// Returns:
+//   - IPFamily: the family of the literal.
+func addressFamily(host string) IPFamily {
+	ip := net.ParseIP(host)
+	// hostnames may resolve to either family
+	if ip == nil {
+		// resolver decides
+		return IPFamilyDual
+	}
+	// ipv4 and ipv4-mapped literals
+	if ip.To4() != nil {
+		// ipv4 address
+		return IPFamilyV4
+	}
+	// "::" binds both families on dual-stack hosts
+	if ip.IsUnspecified() {
+		// dual-stack wildcard
+		return IPFamilyDual
+	}
+	// concrete ipv6 address
+	return IPFamilyV6
+}
@@ -0,0 +1,48 @@
+// Package config_test provides black-box tests for the config package.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestIPFamily_Overlaps verifies family overlap rules.
+//
+// Params:
+//   - t: testing context for assertions
+func TestIPFamily_Overlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a    config.IPFamily
+		b    config.IPFamily
+		want bool
+	}{
+		{name: "dual_and_v4", a: config.IPFamilyDual, b: config.IPFamilyV4, want: true},
+		{name: "dual_and_v6", a: config.IPFamilyDual, b: config.IPFamilyV6, want: true},
+		{name: "same_family", a: config.IPFamilyV6, b: config.IPFamilyV6, want: true},
+		{name: "v4_and_v6", a: config.IPFamilyV4, b: config.IPFamilyV6, want: false},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// overlap is symmetric
+			assert.Equal(t, tt.want, tt.a.Overlaps(tt.b))
+			assert.Equal(t, tt.want, tt.b.Overlaps(tt.a))
+		})
+	}
+}
+
+// TestIPFamily_Loopback verifies the loopback host per family.
+//
+// Params:
+//   - t: testing context for assertions
+func TestIPFamily_Loopback(t *testing.T) {
+	// verify each family loopback
+	assert.Equal(t, "localhost", config.IPFamilyDual.Loopback())
+	assert.Equal(t, "127.0.0.1", config.IPFamilyV4.Loopback())
+	assert.Equal(t, "::1", config.IPFamilyV6.Loopback())
+}
@@ -0,0 +1,25 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import "errors"
+
+// DefaultJobHistory is the number of past runs kept for a oneshot service
+// when none is configured.
+const DefaultJobHistory int = 10
+
+// ErrInvalidJobHistory indicates a negative service job_history.
+var ErrInvalidJobHistory error = errors.New("job_history must not be negative")
+
+// EffectiveJobHistory returns the number of past runs kept for the service.
+//
+// Returns:
+//   - int: the configured size, or DefaultJobHistory.
+func (s *ServiceConfig) EffectiveJobHistory() int {
+	// fall back to the default size
+	if s.JobHistory <= 0 {
+		// return default
+		return DefaultJobHistory
+	}
+	// return configured size
+	return s.JobHistory
+}
@@ -0,0 +1,35 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestServiceConfig_EffectiveJobHistory tests the job history size defaults.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_EffectiveJobHistory(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// history is the configured size.
+		history int
+		// want is the expected size.
+		want int
+	}{
+		{name: "default", history: 0, want: config.DefaultJobHistory},
+		{name: "configured", history: 3, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := config.ServiceConfig{Name: "backup", Oneshot: true, JobHistory: tt.history}
+			assert.Equal(t, tt.want, svc.EffectiveJobHistory())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// reservedLabelPrefix starts label names reserved for internal use by
+// Prometheus-compatible tooling.
+const reservedLabelPrefix string = "__"
+
+// ErrInvalidLabel indicates a service label name that is not a valid
+// Prometheus label name.
+var ErrInvalidLabel error = errors.New("invalid label name")
+
+// validateLabels validates the label names of a service.
+// Names follow the Prometheus rules so labels can be exported as-is:
+// letters, digits and underscores, not starting with a digit or "__".
+//
+// Params:
+//   - labels: the service labels.
+//
+// Returns:
+//   - error: validation error if any
+func validateLabels(labels map[string]string) error {
+	// check each label name
+	for name := range labels {
+		// reject malformed and reserved names
+		if !isLabelName(name) || strings.HasPrefix(name, reservedLabelPrefix) {
+			// return error with the name
+			return fmt.Errorf("%w: %q", ErrInvalidLabel, name)
+		}
+	}
+	// validation passed
+	return nil
+}
+
+// isLabelName reports whether a name matches [a-zA-Z_][a-zA-Z0-9_]*.
+//
+// Params:
+//   - name: the label name.
+//
+// Returns:
+//   - bool: true when the name is well formed.
+func isLabelName(name string) bool {
+	// reject empty names
+	if name == "" {
+		// not a name
+		return false
+	}
+	// check each character
+	for i, r := range name {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		digit := r >= '0' && r <= '9'
+		// digits are allowed after the first character only
+		if !letter && (!digit || i == 0) {
+			// invalid character
+			return false
+		}
+	}
+	// well formed
+	return true
+}
@@ -0,0 +1,58 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultLeakCheckInterval is how often executor resources are reconciled with the services.
+const DefaultLeakCheckInterval time.Duration = time.Minute
+
+// ErrInvalidLeakCheckInterval indicates a negative leak check interval.
+var ErrInvalidLeakCheckInterval error = errors.New("leak check interval must not be negative")
+
+// LeakCheckConfig configures the reconciliation of the resources the
+// executor holds per process start with the processes services own.
+type LeakCheckConfig struct {
+	// Interval is how often resources are reconciled. Zero uses
+	// DefaultLeakCheckInterval.
+	Interval shared.Duration
+	// Reap kills leaked processes and removes leaked cgroups instead of
+	// only reporting them.
+	Reap bool
+}
+
+// EffectiveInterval returns how often resources are reconciled.
+//
+// Returns:
+//   - time.Duration: the configured interval, or DefaultLeakCheckInterval.
+func (l *LeakCheckConfig) EffectiveInterval() time.Duration {
+	// fall back to the default interval
+	if l.Interval <= 0 {
+		// return default
+		return DefaultLeakCheckInterval
+	}
+	// return configured interval
+	return l.Interval.Duration()
+}
+
+// validateLeakCheck validates the leak check settings.
+//
+// Params:
+//   - l: leak check configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateLeakCheck(l *LeakCheckConfig) error {
+	// check interval is not negative
+	if l.Interval < 0 {
+		// return error with the interval
+		return fmt.Errorf("%w: %s", ErrInvalidLeakCheckInterval, l.Interval)
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestLeakCheckConfig_EffectiveInterval tests the leak check interval default.
+//
+// Params:
+//   - t: the testing context.
+func TestLeakCheckConfig_EffectiveInterval(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// leakCheck is the configuration.
+		leakCheck config.LeakCheckConfig
+		// want is the expected interval.
+		want time.Duration
+	}{
+		{name: "default", want: config.DefaultLeakCheckInterval},
+		{name: "configured", leakCheck: config.LeakCheckConfig{Interval: shared.Seconds(30)}, want: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.leakCheck.EffectiveInterval())
+		})
+	}
+}
@@ -1,6 +1,12 @@
 // Package config provides domain value objects for service configuration.
 package config
 
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
 // Default listener probe configuration values.
 const (
 	// defaultProbeInterval is the default interval between probes (10 seconds).
@@ -13,6 +19,9 @@ const (
 	defaultProbeFailureThreshold int = 3
 )
 
+// defaultListenerProtocol is the protocol used when a listener does not set one.
+const defaultListenerProtocol string = "tcp"
+
 // ListenerConfig defines a network listener with optional health probing.
 // It specifies the port, protocol, and probe configuration for a listener.
 type ListenerConfig struct {
@@ -24,11 +33,14 @@ type ListenerConfig struct {
 	Port int
 
 	// Protocol is the network protocol.
-	// Supported values: "tcp" (default), "udp".
+	// Supported values: "tcp" (default), "udp", and the family-restricted
+	// "tcp4", "tcp6", "udp4", "udp6". Without a suffix the listener is dual-stack.
+	// "unix" is a unix socket, ready as soon as its path appears.
 	Protocol string
 
 	// Address is the optional bind address.
-	// Empty means bind to all interfaces (0.0.0.0).
+	// Empty means bind to all interfaces. IPv6 literals may be bracketed.
+	// For unix listeners, the absolute socket path.
 	Address string
 
 	// Exposed indicates whether this port should be publicly accessible.
@@ -41,6 +53,14 @@ type ListenerConfig struct {
 	// Probe contains the probe configuration for this listener.
 	// If nil, no probing is performed (only port listening is checked).
 	Probe *ProbeConfig
+
+	// Proxy makes the supervisor bind the public endpoint and forward
+	// TCP connections to this listener. Requires Exposed.
+	Proxy *ProxyConfig
+
+	// TLS makes the supervisor check the certificate served by this listener.
+	// If nil, no certificate is checked.
+	TLS *ListenerTLSConfig
 }
 
 // NewListenerConfig creates a new listener configuration.
@@ -56,7 +76,7 @@ func NewListenerConfig(name string, port int) ListenerConfig {
 	return ListenerConfig{
 		Name:     name,
 		Port:     port,
-		Protocol: "tcp",
+		Protocol: defaultListenerProtocol,
 	}
 }
 
@@ -110,3 +130,211 @@ func (l ListenerConfig) WithGRPCProbe(service string) ListenerConfig {
 	// return listener with grpc probe
 	return l.WithProbe(&probe)
 }
+
+// HasProbe reports whether the listener has a configured probe.
+//
+// Returns:
+//   - bool: true if a probe type is set.
+func (l *ListenerConfig) HasProbe() bool {
+	// a probe without type is not run
+	return l.Probe != nil && l.Probe.Type != ""
+}
+
+// EffectiveProtocol returns the listener protocol with the tcp default applied.
+//
+// Returns:
+//   - string: the lowercase protocol.
+func (l *ListenerConfig) EffectiveProtocol() string {
+	// apply default protocol when unset
+	if l.Protocol == "" {
+		// listeners default to tcp
+		return defaultListenerProtocol
+	}
+	// normalize configured protocol
+	return strings.ToLower(l.Protocol)
+}
+
+// Transport returns the listener transport without its family suffix.
+//
+// Returns:
+//   - string: "tcp" or "udp".
+func (l *ListenerConfig) Transport() string {
+	// strip the ip family suffix
+	return strings.TrimRight(l.EffectiveProtocol(), string(IPFamilyV4)+string(IPFamilyV6))
+}
+
+// Family returns the address family requested by the protocol suffix.
+//
+// Returns:
+//   - IPFamily: IPFamilyV4, IPFamilyV6, or IPFamilyDual without suffix.
+func (l *ListenerConfig) Family() IPFamily {
+	protocol := l.EffectiveProtocol()
+	// match the protocol suffix
+	switch {
+	// ipv4 only
+	case strings.HasSuffix(protocol, string(IPFamilyV4)):
+		// tcp4 or udp4
+		return IPFamilyV4
+	// ipv6 only
+	case strings.HasSuffix(protocol, string(IPFamilyV6)):
+		// tcp6 or udp6
+		return IPFamilyV6
+	// no suffix
+	default:
+		// dual-stack
+		return IPFamilyDual
+	}
+}
+
+// Host returns the bind address with IPv6 brackets removed.
+//
+// Returns:
+//   - string: the bare host, empty when unset.
+func (l *ListenerConfig) Host() string {
+	// "[::1]" and "::1" designate the same address
+	return strings.TrimSuffix(strings.TrimPrefix(l.Address, "["), "]")
+}
+
+// DialAddress returns the host:port used to reach the listener.
+// IPv6 hosts are bracketed; an unset address maps to the loopback
+// of the listener family. Unix listeners are reached at their socket path.
+//
+// Returns:
+//   - string: the dial address.
+func (l *ListenerConfig) DialAddress() string {
+	// sockets are dialed by path
+	if l.EffectiveProtocol() == ListenerProtocolUnix {
+		// return socket path
+		return l.Address
+	}
+	host := l.Host()
+	// probe the local host when no bind address is set
+	if host == "" {
+		host = l.Family().Loopback()
+	}
+	// join with brackets for ipv6 literals
+	return joinHostPort(host, l.Port)
+}
+
+// joinHostPort formats a host and numeric port, bracketing IPv6 hosts.
+//
+// Params:
+//   - host: the host without brackets.
+//   - port: the port number.
+//
+// Returns:
+//   - string: the host:port address.
+func joinHostPort(host string, port int) string {
+	// delegate bracket handling to net
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// ProbeNetwork returns the network a prober dials for this listener.
+// The transport follows the probe type and the family follows the listener;
+// unix listeners are dialed as unix streams.
+//
+// Params:
+//   - probeType: the probe type (tcp, udp, http, grpc, ...).
+//
+// Returns:
+//   - string: the network name, such as "tcp", "tcp6" or "udp4".
+func (l *ListenerConfig) ProbeNetwork(probeType string) string {
+	// sockets are dialed as unix streams
+	if l.EffectiveProtocol() == ListenerProtocolUnix {
+		// return unix network
+		return ListenerProtocolUnix
+	}
+	transport := defaultListenerProtocol
+	// only udp probes dial datagram sockets
+	if probeType == "udp" {
+		transport = "udp"
+	}
+	// append the family suffix
+	return transport + string(l.Family())
+}
+
+// bindFamily returns the family the listener actually binds.
+// An explicit protocol suffix wins over the family implied by the address.
+//
+// Returns:
+//   - IPFamily: the effective bind family.
+func (l *ListenerConfig) bindFamily() IPFamily {
+	// explicit protocol family
+	if family := l.Family(); family != IPFamilyDual {
+		// protocol restricts the family
+		return family
+	}
+	// infer from the address literal
+	return addressFamily(l.Host())
+}
+
+// ConflictsWith reports whether two listeners compete for the same socket.
+// They conflict when they share a transport, port and address family and
+// their bind addresses overlap; a wildcard address overlaps with every
+// address of its family.
+//
+// Params:
+//   - other: the listener to compare against.
+//
+// Returns:
+//   - bool: true if both listeners cannot bind at the same time.
+func (l *ListenerConfig) ConflictsWith(other *ListenerConfig) bool {
+	// listeners without a port do not bind
+	if l.Port <= 0 || l.Port != other.Port {
+		// different or unset ports never conflict
+		return false
+	}
+
+	// tcp and udp sockets do not share a port space
+	if l.Transport() != other.Transport() {
+		// different protocols never conflict
+		return false
+	}
+
+	// ipv4-only and ipv6-only sockets do not share a port space
+	if !l.bindFamily().Overlaps(other.bindFamily()) {
+		// disjoint families never conflict
+		return false
+	}
+
+	// wildcard binds every interface
+	if isWildcardAddress(l.Host()) || isWildcardAddress(other.Host()) {
+		// any address collides with a wildcard
+		return true
+	}
+	// concrete addresses collide only when equal
+	return sameHost(l.Host(), other.Host())
+}
+
+// sameHost reports whether two bind hosts designate the same address.
+// IP literals are compared by value so "::1" equals "0:0::1".
+//
+// Params:
+//   - a: the first host.
+//   - b: the second host.
+//
+// Returns:
+//   - bool: true if both hosts are equal.
+func sameHost(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	// compare parsed literals by value
+	if ipA != nil && ipB != nil {
+		// ip equality
+		return ipA.Equal(ipB)
+	}
+	// compare hostnames textually
+	return strings.EqualFold(a, b)
+}
+
+// isWildcardAddress reports whether a bind address covers all interfaces.
+//
+// Params:
+//   - addr: the bind host without brackets.
+//
+// Returns:
+//   - bool: true for empty or unspecified addresses.
+func isWildcardAddress(addr string) bool {
+	ip := net.ParseIP(addr)
+	// empty, 0.0.0.0, and :: bind every interface
+	return addr == "" || (ip != nil && ip.IsUnspecified())
+}
@@ -0,0 +1,96 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import "fmt"
+
+// ListenerConflict describes a listener that competes for the socket of a
+// listener declared before it.
+type ListenerConflict struct {
+	// Service is the name of the service owning the conflicting listener.
+	Service string
+	// Listener is the conflicting listener.
+	Listener *ListenerConfig
+	// OwnerService is the name of the service that declared the socket first.
+	OwnerService string
+	// Owner is the listener that declared the socket first.
+	Owner *ListenerConfig
+}
+
+// String returns a human-readable description of the conflict.
+//
+// Returns:
+//   - string: the formatted conflict.
+func (c ListenerConflict) String() string {
+	// name both listeners and the contested socket
+	return fmt.Sprintf("service %q listener %q binds %s/%d already bound by service %q listener %q",
+		c.Service, c.Listener.Name, c.Listener.EffectiveProtocol(), c.Listener.Port, c.OwnerService, c.Owner.Name)
+}
+
+// listenerBinding is a socket bound for a service: a listener or the
+// public endpoint of a proxied listener.
+type listenerBinding struct {
+	// service is the owning service name.
+	service string
+	// listener is the bound endpoint.
+	listener *ListenerConfig
+}
+
+// FindListenerConflicts returns every listener, within or across services,
+// that conflicts with a listener declared before it. Public endpoints of
+// proxied listeners are checked like listeners.
+//
+// Params:
+//   - services: the service configurations.
+//
+// Returns:
+//   - []ListenerConflict: conflicts in declaration order.
+func FindListenerConflicts(services []ServiceConfig) []ListenerConflict {
+	var conflicts []ListenerConflict
+	bindings := collectBindings(services)
+
+	// compare each binding against every binding declared before it
+	for i := range bindings {
+		// record the first earlier binding it conflicts with
+		for j := range i {
+			// skip compatible bindings
+			if !bindings[j].listener.ConflictsWith(bindings[i].listener) {
+				continue
+			}
+			conflicts = append(conflicts, ListenerConflict{
+				Service:      bindings[i].service,
+				Listener:     bindings[i].listener,
+				OwnerService: bindings[j].service,
+				Owner:        bindings[j].listener,
+			})
+			break
+		}
+	}
+
+	// return collected conflicts
+	return conflicts
+}
+
+// collectBindings lists listeners and proxy endpoints in declaration order.
+//
+// Params:
+//   - services: the service configurations.
+//
+// Returns:
+//   - []listenerBinding: every socket the configuration binds.
+func collectBindings(services []ServiceConfig) []listenerBinding {
+	var bindings []listenerBinding
+	// iterate over services in order
+	for i := range services {
+		// iterate over listeners of the service
+		for j := range services[i].Listeners {
+			lc := &services[i].Listeners[j]
+			bindings = append(bindings, listenerBinding{service: services[i].Name, listener: lc})
+			// the proxy endpoint follows its listener
+			if public := lc.PublicListener(); public != nil {
+				bindings = append(bindings, listenerBinding{service: services[i].Name, listener: public})
+			}
+		}
+	}
+	// return all bindings
+	return bindings
+}
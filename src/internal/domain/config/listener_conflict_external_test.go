@@ -0,0 +1,59 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestFindListenerConflicts verifies conflicts are reported in declaration order.
+//
+// Params:
+//   - t: testing context for assertions
+func TestFindListenerConflicts(t *testing.T) {
+	services := []config.ServiceConfig{
+		{Name: "api", Listeners: []config.ListenerConfig{
+			{Name: "http", Port: 8080},
+			{Name: "admin", Port: 8080, Address: "127.0.0.1"},
+		}},
+		{Name: "web", Listeners: []config.ListenerConfig{
+			{Name: "http", Port: 8080, Protocol: "udp"},
+			{Name: "metrics", Port: 8080},
+		}},
+	}
+
+	conflicts := config.FindListenerConflicts(services)
+
+	// both conflicts point back at the first declaration
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, "service \"api\" listener \"admin\" binds tcp/8080 already bound by service \"api\" listener \"http\"", conflicts[0].String())
+	assert.Equal(t, "web", conflicts[1].Service)
+	assert.Equal(t, "metrics", conflicts[1].Listener.Name)
+	assert.Equal(t, "api", conflicts[1].OwnerService)
+	assert.Equal(t, "http", conflicts[1].Owner.Name)
+}
+
+// TestFindListenerConflicts_Proxy verifies proxy endpoints take part in conflict detection.
+//
+// Params:
+//   - t: testing context for assertions
+func TestFindListenerConflicts_Proxy(t *testing.T) {
+	services := []config.ServiceConfig{
+		{Name: "api", Listeners: []config.ListenerConfig{
+			{Name: "http", Port: 8080, Address: "127.0.0.1", Exposed: true, Proxy: &config.ProxyConfig{Port: 80}},
+		}},
+		{Name: "web", Listeners: []config.ListenerConfig{
+			{Name: "http", Port: 80},
+		}},
+	}
+
+	conflicts := config.FindListenerConflicts(services)
+
+	// the web listener collides with the api public endpoint
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "service \"web\" listener \"http\" binds tcp/80 already bound by service \"api\" listener \"http (proxy)\"", conflicts[0].String())
+}
@@ -306,3 +306,123 @@ func TestListenerConfig_ChainedProbes(t *testing.T) {
 		})
 	}
 }
+
+// TestListenerConfig_ConflictsWith verifies listener socket conflict detection.
+//
+// Params:
+//   - t: testing context for assertions
+func TestListenerConfig_ConflictsWith(t *testing.T) {
+	tests := []struct {
+		name string
+		a    config.ListenerConfig
+		b    config.ListenerConfig
+		want bool
+	}{
+		{name: "same_port_wildcards", a: config.ListenerConfig{Port: 80}, b: config.ListenerConfig{Port: 80, Protocol: "TCP"}, want: true},
+		{name: "wildcard_and_concrete", a: config.ListenerConfig{Port: 80, Address: "::"}, b: config.ListenerConfig{Port: 80, Address: "127.0.0.1"}, want: true},
+		{name: "same_concrete_address", a: config.ListenerConfig{Port: 80, Address: "10.0.0.1"}, b: config.ListenerConfig{Port: 80, Address: "10.0.0.1"}, want: true},
+		{name: "distinct_concrete_addresses", a: config.ListenerConfig{Port: 80, Address: "10.0.0.1"}, b: config.ListenerConfig{Port: 80, Address: "10.0.0.2"}, want: false},
+		{name: "different_protocols", a: config.ListenerConfig{Port: 53}, b: config.ListenerConfig{Port: 53, Protocol: "udp"}, want: false},
+		{name: "different_ports", a: config.ListenerConfig{Port: 80}, b: config.ListenerConfig{Port: 81}, want: false},
+		{name: "unset_ports", a: config.ListenerConfig{}, b: config.ListenerConfig{}, want: false},
+		{name: "ipv4_and_ipv6_only_wildcards", a: config.ListenerConfig{Port: 80, Protocol: "tcp4"}, b: config.ListenerConfig{Port: 80, Protocol: "tcp6"}, want: false},
+		{name: "dual_stack_and_ipv4_only", a: config.ListenerConfig{Port: 80, Address: "::"}, b: config.ListenerConfig{Port: 80, Protocol: "tcp4"}, want: true},
+		{name: "ipv4_wildcard_and_ipv6_literal", a: config.ListenerConfig{Port: 80, Address: "0.0.0.0"}, b: config.ListenerConfig{Port: 80, Address: "::1"}, want: false},
+		{name: "bracketed_and_bare_ipv6", a: config.ListenerConfig{Port: 80, Address: "[::1]"}, b: config.ListenerConfig{Port: 80, Address: "0:0::1"}, want: true},
+		{name: "family_suffix_same_transport", a: config.ListenerConfig{Port: 53, Protocol: "udp6"}, b: config.ListenerConfig{Port: 53, Protocol: "udp"}, want: true},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// conflict detection is symmetric
+			assert.Equal(t, tt.want, tt.a.ConflictsWith(&tt.b))
+			assert.Equal(t, tt.want, tt.b.ConflictsWith(&tt.a))
+		})
+	}
+}
+
+// TestListenerConfig_Family verifies transport and family parsing of the protocol.
+//
+// Params:
+//   - t: testing context for assertions
+func TestListenerConfig_Family(t *testing.T) {
+	tests := []struct {
+		name          string
+		protocol      string
+		wantTransport string
+		wantFamily    config.IPFamily
+	}{
+		{name: "default", protocol: "", wantTransport: "tcp", wantFamily: config.IPFamilyDual},
+		{name: "tcp4", protocol: "tcp4", wantTransport: "tcp", wantFamily: config.IPFamilyV4},
+		{name: "tcp6_uppercase", protocol: "TCP6", wantTransport: "tcp", wantFamily: config.IPFamilyV6},
+		{name: "udp", protocol: "udp", wantTransport: "udp", wantFamily: config.IPFamilyDual},
+		{name: "udp6", protocol: "udp6", wantTransport: "udp", wantFamily: config.IPFamilyV6},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := config.ListenerConfig{Protocol: tt.protocol}
+			// verify transport and family
+			assert.Equal(t, tt.wantTransport, lc.Transport())
+			assert.Equal(t, tt.wantFamily, lc.Family())
+		})
+	}
+}
+
+// TestListenerConfig_DialAddress verifies host:port formatting for IPv4 and IPv6.
+//
+// Params:
+//   - t: testing context for assertions
+func TestListenerConfig_DialAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		listener config.ListenerConfig
+		want     string
+	}{
+		{name: "unset_dual_stack", listener: config.ListenerConfig{Port: 80}, want: "localhost:80"},
+		{name: "unset_ipv4", listener: config.ListenerConfig{Port: 80, Protocol: "tcp4"}, want: "127.0.0.1:80"},
+		{name: "unset_ipv6", listener: config.ListenerConfig{Port: 80, Protocol: "tcp6"}, want: "[::1]:80"},
+		{name: "ipv4_literal", listener: config.ListenerConfig{Port: 80, Address: "10.0.0.1"}, want: "10.0.0.1:80"},
+		{name: "bare_ipv6_literal", listener: config.ListenerConfig{Port: 80, Address: "fd00::1"}, want: "[fd00::1]:80"},
+		{name: "bracketed_ipv6_literal", listener: config.ListenerConfig{Port: 80, Address: "[fd00::1]"}, want: "[fd00::1]:80"},
+		{name: "hostname", listener: config.ListenerConfig{Port: 80, Address: "api.local"}, want: "api.local:80"},
+		{name: "unix_socket", listener: config.ListenerConfig{Protocol: "unix", Address: "/run/api.sock"}, want: "/run/api.sock"},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// verify formatted address
+			assert.Equal(t, tt.want, tt.listener.DialAddress())
+		})
+	}
+}
+
+// TestListenerConfig_ProbeNetwork verifies probe networks follow the listener family.
+//
+// Params:
+//   - t: testing context for assertions
+func TestListenerConfig_ProbeNetwork(t *testing.T) {
+	tests := []struct {
+		name      string
+		protocol  string
+		probeType string
+		want      string
+	}{
+		{name: "tcp_probe_dual_stack", protocol: "tcp", probeType: "tcp", want: "tcp"},
+		{name: "http_probe_ipv6", protocol: "tcp6", probeType: "http", want: "tcp6"},
+		{name: "udp_probe_ipv4", protocol: "udp4", probeType: "udp", want: "udp4"},
+		{name: "tcp_probe_unix", protocol: "unix", probeType: "tcp", want: "unix"},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := config.ListenerConfig{Protocol: tt.protocol}
+			// verify network name
+			assert.Equal(t, tt.want, lc.ProbeNetwork(tt.probeType))
+		})
+	}
+}
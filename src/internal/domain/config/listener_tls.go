@@ -0,0 +1,139 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// DefaultTLSCheckInterval is the period between certificate checks when none is configured.
+	DefaultTLSCheckInterval time.Duration = time.Hour
+
+	// DefaultTLSWarnBefore is how long before expiry a certificate is reported when none is configured.
+	DefaultTLSWarnBefore time.Duration = 14 * 24 * time.Hour
+
+	// DefaultTLSRenewTimeout bounds a renewal command when no timeout is configured.
+	DefaultTLSRenewTimeout time.Duration = 5 * time.Minute
+)
+
+// Listener TLS validation errors.
+var (
+	// ErrTLSProtocol indicates certificate checks on a non-tcp listener.
+	ErrTLSProtocol error = errors.New("listener tls supports tcp only")
+	// ErrInvalidTLSDuration indicates a negative certificate check duration.
+	ErrInvalidTLSDuration error = errors.New("listener tls interval, warn_before, renew_before and renew_timeout must not be negative")
+	// ErrTLSRenewWithoutCommand indicates renewal settings without renewal command.
+	ErrTLSRenewWithoutCommand error = errors.New("listener tls renew_args, renew_before and renew_timeout require renew_command")
+)
+
+// ListenerTLSConfig makes the supervisor check the certificate a TLS listener
+// serves: it connects, tracks the expiry, issuer and fingerprint, reports
+// changes and approaching expiry, and optionally runs a renewal command
+// before restarting the service.
+type ListenerTLSConfig struct {
+	// ServerName is the SNI sent on connect. Empty sends none.
+	ServerName string
+	// Interval is the period between checks. Zero uses DefaultTLSCheckInterval.
+	Interval shared.Duration
+	// WarnBefore is how long before expiry the certificate_expiring event is
+	// emitted. Zero uses DefaultTLSWarnBefore.
+	WarnBefore shared.Duration
+	// RenewCommand runs when the certificate expires within RenewBefore; the
+	// service is restarted once it succeeds. Empty disables renewal.
+	RenewCommand string
+	// RenewArgs are the arguments of the renewal command.
+	RenewArgs []string
+	// RenewBefore is how long before expiry the renewal runs. Zero uses the
+	// warning delay.
+	RenewBefore shared.Duration
+	// RenewTimeout bounds the renewal command. Zero uses DefaultTLSRenewTimeout.
+	RenewTimeout shared.Duration
+}
+
+// CheckInterval returns the effective period between checks.
+//
+// Returns:
+//   - time.Duration: the configured interval, or DefaultTLSCheckInterval.
+func (t *ListenerTLSConfig) CheckInterval() time.Duration {
+	// fall back to the default interval
+	if t.Interval <= 0 {
+		// return default
+		return DefaultTLSCheckInterval
+	}
+	// return configured interval
+	return t.Interval.Duration()
+}
+
+// WarnDelay returns how long before expiry the certificate is reported.
+//
+// Returns:
+//   - time.Duration: the configured delay, or DefaultTLSWarnBefore.
+func (t *ListenerTLSConfig) WarnDelay() time.Duration {
+	// fall back to the default delay
+	if t.WarnBefore <= 0 {
+		// return default
+		return DefaultTLSWarnBefore
+	}
+	// return configured delay
+	return t.WarnBefore.Duration()
+}
+
+// RenewDelay returns how long before expiry the renewal runs.
+//
+// Returns:
+//   - time.Duration: the configured delay, or the warning delay.
+func (t *ListenerTLSConfig) RenewDelay() time.Duration {
+	// renew when the expiry is reported
+	if t.RenewBefore <= 0 {
+		// return warning delay
+		return t.WarnDelay()
+	}
+	// return configured delay
+	return t.RenewBefore.Duration()
+}
+
+// RenewLimit returns the effective bound of the renewal command.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultTLSRenewTimeout.
+func (t *ListenerTLSConfig) RenewLimit() time.Duration {
+	// fall back to the default timeout
+	if t.RenewTimeout <= 0 {
+		// return default
+		return DefaultTLSRenewTimeout
+	}
+	// return configured timeout
+	return t.RenewTimeout.Duration()
+}
+
+// validateListenerTLS validates the certificate checks of a listener.
+//
+// Params:
+//   - lc: listener configuration with tls settings
+//
+// Returns:
+//   - error: validation error if any
+func validateListenerTLS(lc *ListenerConfig) error {
+	t := lc.TLS
+	// only stream listeners serve tls
+	if lc.Transport() != defaultListenerProtocol {
+		// return error on datagram listener
+		return fmt.Errorf("%w: %s", ErrTLSProtocol, lc.EffectiveProtocol())
+	}
+	// negative durations are meaningless
+	if t.Interval < 0 || t.WarnBefore < 0 || t.RenewBefore < 0 || t.RenewTimeout < 0 {
+		// return error on negative duration
+		return ErrInvalidTLSDuration
+	}
+	// renewal settings need a command
+	if t.RenewCommand == "" && (len(t.RenewArgs) > 0 || t.RenewBefore > 0 || t.RenewTimeout > 0) {
+		// return error on orphan renewal settings
+		return ErrTLSRenewWithoutCommand
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,64 @@
+// Package config_test provides black-box tests for the config package.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestListenerTLSConfig_Defaults verifies the effective check and renewal durations.
+//
+// Params:
+//   - t: testing context for assertions
+func TestListenerTLSConfig_Defaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		tls          config.ListenerTLSConfig
+		wantInterval time.Duration
+		wantWarn     time.Duration
+		wantRenew    time.Duration
+		wantTimeout  time.Duration
+	}{
+		{
+			name:         "defaults",
+			wantInterval: config.DefaultTLSCheckInterval,
+			wantWarn:     config.DefaultTLSWarnBefore,
+			wantRenew:    config.DefaultTLSWarnBefore,
+			wantTimeout:  config.DefaultTLSRenewTimeout,
+		},
+		{
+			name:         "renewal_follows_warning",
+			tls:          config.ListenerTLSConfig{WarnBefore: shared.Seconds(86400)},
+			wantInterval: config.DefaultTLSCheckInterval,
+			wantWarn:     24 * time.Hour,
+			wantRenew:    24 * time.Hour,
+			wantTimeout:  config.DefaultTLSRenewTimeout,
+		},
+		{
+			name: "configured",
+			tls: config.ListenerTLSConfig{
+				Interval: shared.Seconds(600), WarnBefore: shared.Seconds(86400),
+				RenewBefore: shared.Seconds(3600), RenewTimeout: shared.Seconds(30),
+			},
+			wantInterval: 10 * time.Minute,
+			wantWarn:     24 * time.Hour,
+			wantRenew:    time.Hour,
+			wantTimeout:  30 * time.Second,
+		},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantInterval, tt.tls.CheckInterval())
+			assert.Equal(t, tt.wantWarn, tt.tls.WarnDelay())
+			assert.Equal(t, tt.wantRenew, tt.tls.RenewDelay())
+			assert.Equal(t, tt.wantTimeout, tt.tls.RenewLimit())
+		})
+	}
+}
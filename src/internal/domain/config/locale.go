@@ -0,0 +1,135 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Environment variables set from the time zone and locale of a service.
+const (
+	// envTimezone is the variable read by libc and most runtimes for the zone.
+	envTimezone string = "TZ"
+	// envLang is the default locale of every category.
+	envLang string = "LANG"
+	// envLCAll overrides the locale of every category, LC_* included.
+	envLCAll string = "LC_ALL"
+)
+
+// localePattern matches POSIX locale names: language[_TERRITORY][.codeset][@modifier].
+var localePattern *regexp.Regexp = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?(\.[A-Za-z0-9-]+)?(@[A-Za-z0-9]+)?$`)
+
+// Time zone and locale validation errors.
+var (
+	// ErrUnknownTimezone indicates a time zone missing from the host tzdata.
+	ErrUnknownTimezone error = errors.New("unknown time zone")
+	// ErrInvalidLocale indicates a locale that is not a POSIX locale name.
+	ErrInvalidLocale error = errors.New("invalid locale")
+	// ErrDuplicateLocaleEnv indicates a variable set by timezone or locale and by environment or a secret.
+	ErrDuplicateLocaleEnv error = errors.New("variable is already set")
+)
+
+// LocaleEnv returns the variables set from the time zone and locale of the
+// service: TZ, and LANG with LC_ALL, so the daemon's own environment cannot
+// leak into the service through inheritance or LC_* overrides.
+//
+// Returns:
+//   - map[string]string: the variables, nil when neither is set.
+func (s *ServiceConfig) LocaleEnv() map[string]string {
+	// nothing configured
+	if s.Timezone == "" && s.Locale == "" {
+		// return no variables
+		return nil
+	}
+	env := make(map[string]string, 3)
+	// set the zone when configured
+	if s.Timezone != "" {
+		env[envTimezone] = s.Timezone
+	}
+	// set the locale when configured
+	if s.Locale != "" {
+		env[envLang] = s.Locale
+		env[envLCAll] = s.Locale
+	}
+	// return variables
+	return env
+}
+
+// validateTimezone validates the time zone of a service against the host
+// tzdata, and that TZ has no other source.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - error: validation error if any.
+func validateTimezone(svc *ServiceConfig) error {
+	// nothing to validate
+	if svc.Timezone == "" {
+		// return valid
+		return nil
+	}
+	// the zone must exist on the host
+	if _, err := time.LoadLocation(svc.Timezone); err != nil {
+		// return error with the zone
+		return fmt.Errorf("%w: %q", ErrUnknownTimezone, svc.Timezone)
+	}
+	// return conflict check
+	return checkLocaleEnv(svc, envTimezone)
+}
+
+// validateLocale validates the locale name of a service, and that LANG and
+// LC_ALL have no other source. Whether the locale is installed is left to
+// the service, which falls back to C when it is not.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - error: validation error if any.
+func validateLocale(svc *ServiceConfig) error {
+	// nothing to validate
+	if svc.Locale == "" {
+		// return valid
+		return nil
+	}
+	// accept C, POSIX and their UTF-8 forms, or a language locale
+	if !slices.Contains([]string{"C", "POSIX", "C.UTF-8", "C.utf8"}, svc.Locale) && !localePattern.MatchString(svc.Locale) {
+		// return error with the locale
+		return fmt.Errorf("%w: %q", ErrInvalidLocale, svc.Locale)
+	}
+	// return conflict check
+	return checkLocaleEnv(svc, envLang, envLCAll)
+}
+
+// checkLocaleEnv reports variables also set by environment or a secret.
+//
+// Params:
+//   - svc: the service configuration.
+//   - keys: the variables set from the time zone or locale.
+//
+// Returns:
+//   - error: ErrDuplicateLocaleEnv naming the first duplicate.
+func checkLocaleEnv(svc *ServiceConfig, keys ...string) error {
+	// check each variable
+	for _, key := range keys {
+		// environment must not set it too
+		if _, ok := svc.Environment[key]; ok {
+			// return error with the variable
+			return fmt.Errorf("%w by environment: %s", ErrDuplicateLocaleEnv, key)
+		}
+		// secrets must not set it too
+		for i := range svc.Secrets {
+			// compare variable names
+			if svc.Secrets[i].Env == key {
+				// return error with the variable
+				return fmt.Errorf("%w by secret %d: %s", ErrDuplicateLocaleEnv, i, key)
+			}
+		}
+	}
+	// return no conflict
+	return nil
+}
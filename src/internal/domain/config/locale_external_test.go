@@ -0,0 +1,58 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestServiceConfig_LocaleEnv tests the time zone and locale are set in the
+// environment of the process.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_LocaleEnv(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// timezone is the time zone of the service.
+		timezone string
+		// locale is the locale of the service.
+		locale string
+		// want is the expected process environment.
+		want map[string]string
+	}{
+		{
+			name: "neither",
+			want: map[string]string{"MODE": "prod"},
+		},
+		{
+			name:     "timezone",
+			timezone: "Europe/Paris",
+			want:     map[string]string{"MODE": "prod", "TZ": "Europe/Paris"},
+		},
+		{
+			name:     "timezone and locale",
+			timezone: "UTC",
+			locale:   "en_US.UTF-8",
+			want:     map[string]string{"MODE": "prod", "TZ": "UTC", "LANG": "en_US.UTF-8", "LC_ALL": "en_US.UTF-8"},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			svc := config.ServiceConfig{Environment: map[string]string{"MODE": "prod"}, Timezone: tt.timezone, Locale: tt.locale}
+			env, err := svc.ProcessEnv(shared.DefaultFileSystem)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, env)
+			assert.Equal(t, map[string]string{"MODE": "prod"}, svc.Environment)
+		})
+	}
+}
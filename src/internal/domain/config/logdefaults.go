@@ -8,4 +8,7 @@ type LogDefaults struct {
 	TimestampFormat string
 	// Rotation defines default log rotation settings.
 	Rotation RotationConfig
+	// MaxTotalSize is the default per-service log quota inherited by services
+	// that do not set their own. Empty disables the quota.
+	MaxTotalSize string
 }
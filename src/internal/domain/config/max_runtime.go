@@ -0,0 +1,24 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxRuntimeWarningRatio is the share of max_runtime after which a process
+// is reported as approaching it.
+const MaxRuntimeWarningRatio float64 = 0.9
+
+// ErrInvalidMaxRuntime indicates a negative service max_runtime.
+var ErrInvalidMaxRuntime error = errors.New("max_runtime must not be negative")
+
+// MaxRuntimeWarning returns how long a process of the service runs before
+// it is reported as approaching its max runtime.
+//
+// Returns:
+//   - time.Duration: MaxRuntimeWarningRatio of the max runtime, zero when disabled.
+func (s *ServiceConfig) MaxRuntimeWarning() time.Duration {
+	// return share of the limit
+	return time.Duration(float64(s.MaxRuntime.Duration()) * MaxRuntimeWarningRatio)
+}
@@ -0,0 +1,37 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestServiceConfig_MaxRuntimeWarning tests the delay of max runtime warnings.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_MaxRuntimeWarning(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// limit is the configured max runtime.
+		limit time.Duration
+		// want is the expected warning delay.
+		want time.Duration
+	}{
+		{name: "disabled", limit: 0, want: 0},
+		{name: "configured", limit: time.Hour, want: 54 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := config.ServiceConfig{Name: "batch", MaxRuntime: shared.Duration(tt.limit)}
+			assert.Equal(t, tt.want, svc.MaxRuntimeWarning())
+		})
+	}
+}
@@ -6,6 +6,53 @@
 // Splitting into separate files would reduce cohesion without improving clarity.
 package config
 
+import "github.com/kodflow/daemon/internal/domain/shared"
+
+// DefaultThrottleThreshold is the default percentage of wall time a service
+// may spend CPU-throttled by its cgroup before it is reported as degraded.
+const DefaultThrottleThreshold float64 = 25.0
+
+// Collection intervals applied by each template, in seconds.
+const (
+	// minimalMetricsIntervalSeconds trades freshness for lower overhead.
+	minimalMetricsIntervalSeconds int = 10
+	// standardMetricsIntervalSeconds is the default collection interval.
+	standardMetricsIntervalSeconds int = 5
+	// fullMetricsIntervalSeconds gives near real-time visibility.
+	fullMetricsIntervalSeconds int = 2
+)
+
+// CPUPercentMode selects the scale of the CPU usage reported for a service.
+type CPUPercentMode string
+
+const (
+	// CPUPercentPerCore reports 100% per fully busy CPU, up to N*100% on N
+	// CPUs, like top. It is the default.
+	CPUPercentPerCore CPUPercentMode = "per_core"
+
+	// CPUPercentNormalized reports the share of the total capacity, 100%
+	// meaning every CPU is busy.
+	CPUPercentNormalized CPUPercentMode = "normalized"
+)
+
+// IsValid reports whether the mode is known.
+//
+// Returns:
+//   - bool: true for per_core, normalized and empty (per_core).
+func (m CPUPercentMode) IsValid() bool {
+	// match known modes
+	switch m {
+	// known modes
+	case "", CPUPercentPerCore, CPUPercentNormalized:
+		// mode is known
+		return true
+	// unknown mode
+	default:
+		// reject unknown names
+		return false
+	}
+}
+
 // MetricsTemplate defines preset configurations for common use cases.
 type MetricsTemplate string
 
@@ -18,8 +65,7 @@ const (
 	// Equivalent to existing behavior with all collection enabled.
 	MetricsTemplateStandard MetricsTemplate = "standard"
 
-	// MetricsTemplateFull enables all metrics explicitly.
-	// Identical to standard, provided for forward compatibility.
+	// MetricsTemplateFull enables all metrics with a shorter collection interval.
 	MetricsTemplateFull MetricsTemplate = "full"
 
 	// MetricsTemplateCustom indicates user-defined granular configuration.
@@ -27,13 +73,37 @@ const (
 	MetricsTemplateCustom MetricsTemplate = "custom"
 )
 
+// IsValid reports whether the template is a known preset.
+//
+// Returns:
+//   - bool: true for minimal, standard, full, and custom.
+func (t MetricsTemplate) IsValid() bool {
+	// match known template names
+	switch t {
+	// known templates
+	case MetricsTemplateMinimal, MetricsTemplateStandard, MetricsTemplateFull, MetricsTemplateCustom:
+		// template is known
+		return true
+	// unknown template
+	default:
+		// reject unknown names
+		return false
+	}
+}
+
 // MetricsConfig defines granular control over metrics collection.
 // Each category can be independently enabled/disabled to reduce resource consumption.
 type MetricsConfig struct {
+	// Template is the preset the configuration was expanded from.
+	Template MetricsTemplate
+
 	// Enabled is the global metrics toggle.
 	// When false, no metrics are collected regardless of category settings.
 	Enabled bool
 
+	// Interval is the time between metrics collections.
+	Interval shared.Duration
+
 	// CPU configures CPU metrics collection.
 	CPU CPUMetricsConfig
 
@@ -70,6 +140,9 @@ type MetricsConfig struct {
 
 	// Runtime configures runtime detection metrics.
 	Runtime RuntimeMetricsConfig
+
+	// PressureAlerts lists PSI alert rules evaluated per service cgroup.
+	PressureAlerts []PressureAlertRule
 }
 
 // CPUMetricsConfig defines CPU metrics collection settings.
@@ -81,6 +154,15 @@ type CPUMetricsConfig struct {
 
 	// Pressure controls PSI (Pressure Stall Information) collection.
 	Pressure bool
+
+	// ThrottleThreshold is the percentage of wall time a service may spend
+	// throttled by its cgroup CPU limit before it is reported as degraded.
+	// Zero disables throttling detection.
+	ThrottleThreshold float64
+
+	// PercentMode selects the scale of the CPU usage reported for each
+	// service; both figures are always collected. Empty is per_core.
+	PercentMode CPUPercentMode
 }
 
 // MemoryMetricsConfig defines memory metrics collection settings.
@@ -92,6 +174,11 @@ type MemoryMetricsConfig struct {
 
 	// Pressure controls PSI (Pressure Stall Information) collection.
 	Pressure bool
+
+	// Smaps controls reading /proc/[pid]/smaps_rollup for the PSS, USS,
+	// swap and major page faults of each service. The kernel walks every
+	// mapping of the process on each read, so it is off by default.
+	Smaps bool
 }
 
 // LoadMetricsConfig defines load average metrics collection settings.
@@ -219,6 +306,34 @@ func DefaultMetricsConfig() MetricsConfig {
 	return StandardMetricsConfig()
 }
 
+// MetricsConfigForTemplate expands a template into its concrete configuration.
+// Custom and unknown templates expand to the standard preset while keeping
+// the requested template name, so validation can reject unknown names.
+//
+// Params:
+//   - template: the template to expand.
+//
+// Returns:
+//   - MetricsConfig: the expanded configuration.
+func MetricsConfigForTemplate(template MetricsTemplate) MetricsConfig {
+	var cfg MetricsConfig
+	// select preset for template
+	switch template {
+	// essential metrics only
+	case MetricsTemplateMinimal:
+		cfg = MinimalMetricsConfig()
+	// all metrics with short interval
+	case MetricsTemplateFull:
+		cfg = FullMetricsConfig()
+	// standard preset for standard, custom, and unknown templates
+	default:
+		cfg = StandardMetricsConfig()
+	}
+	cfg.Template = template
+	// return expanded configuration
+	return cfg
+}
+
 // StandardMetricsConfig returns the standard template configuration.
 // All metrics are enabled, matching existing default behavior.
 //
@@ -226,18 +341,28 @@ func DefaultMetricsConfig() MetricsConfig {
 //   - MetricsConfig: standard configuration with all metrics enabled.
 func StandardMetricsConfig() MetricsConfig {
 	// Enable all categories and sub-features.
-	return newMetricsConfig(true, true)
+	return newMetricsConfig(MetricsTemplateStandard, true, true, standardMetricsIntervalSeconds)
 }
 
 // MinimalMetricsConfig returns the minimal template configuration.
-// Only essential metrics (CPU, memory, load) are enabled.
+// Only essential metrics (CPU, memory, load) are enabled and collected less often.
 // Provides 70-80% allocation reduction compared to standard.
 //
 // Returns:
 //   - MetricsConfig: minimal configuration for low resource consumption.
 func MinimalMetricsConfig() MetricsConfig {
 	// Enable only essential metrics without expensive sub-features.
-	return newMetricsConfig(false, false)
+	return newMetricsConfig(MetricsTemplateMinimal, false, false, minimalMetricsIntervalSeconds)
+}
+
+// FullMetricsConfig returns the full template configuration.
+// Enables the same categories as standard with a shorter collection interval.
+//
+// Returns:
+//   - MetricsConfig: full configuration with all metrics enabled.
+func FullMetricsConfig() MetricsConfig {
+	// Enable all categories with near real-time collection.
+	return newMetricsConfig(MetricsTemplateFull, true, true, fullMetricsIntervalSeconds)
 }
 
 // newMetricsConfig creates a MetricsConfig with essential metrics always enabled.
@@ -246,16 +371,20 @@ func MinimalMetricsConfig() MetricsConfig {
 // The pressure parameter controls PSI collection for CPU/memory.
 //
 // Params:
+//   - template: the template the configuration is expanded from.
 //   - allCategories: when true enables all metric categories, when false only essential metrics.
 //   - pressure: when true enables PSI collection for CPU/memory/IO.
+//   - intervalSeconds: collection interval in seconds.
 //
 // Returns:
 //   - MetricsConfig: configured metrics with specified categories and pressure settings.
-func newMetricsConfig(allCategories, pressure bool) MetricsConfig {
+func newMetricsConfig(template MetricsTemplate, allCategories, pressure bool, intervalSeconds int) MetricsConfig {
 	// Build config with essential metrics always enabled and optional categories based on flags.
 	return MetricsConfig{
+		Template:    template,
 		Enabled:     true,
-		CPU:         CPUMetricsConfig{Enabled: true, Pressure: pressure},
+		Interval:    shared.Seconds(intervalSeconds),
+		CPU:         CPUMetricsConfig{Enabled: true, Pressure: pressure, ThrottleThreshold: DefaultThrottleThreshold},
 		Memory:      MemoryMetricsConfig{Enabled: true, Pressure: pressure},
 		Load:        LoadMetricsConfig{Enabled: true},
 		Disk:        DiskMetricsConfig{Enabled: allCategories, Partitions: allCategories, Usage: allCategories, IO: allCategories},
@@ -270,12 +399,35 @@ func newMetricsConfig(allCategories, pressure bool) MetricsConfig {
 	}
 }
 
-// FullMetricsConfig returns the full template configuration.
-// Identical to StandardMetricsConfig, provided for forward compatibility.
+// ThrottlingEnabled reports whether cgroup CPU throttling should be collected.
 //
 // Returns:
-//   - MetricsConfig: full configuration with all metrics enabled.
-func FullMetricsConfig() MetricsConfig {
-	// Full template is identical to standard.
-	return StandardMetricsConfig()
+//   - bool: true if metrics and CPU metrics are enabled.
+func (m *MetricsConfig) ThrottlingEnabled() bool {
+	// require global and CPU toggles
+	return m.Enabled && m.CPU.Enabled
+}
+
+// SmapsEnabled reports whether per-process memory attribution should be collected.
+//
+// Returns:
+//   - bool: true if metrics, memory metrics and smaps reads are enabled.
+func (m *MetricsConfig) SmapsEnabled() bool {
+	// require global, memory and smaps toggles
+	return m.Enabled && m.Memory.Enabled && m.Memory.Smaps
+}
+
+// PressureEnabled reports whether PSI should be collected.
+// Pressure alert rules require PSI and enable it on their own.
+//
+// Returns:
+//   - bool: true if any resource pressure or alert rule is configured.
+func (m *MetricsConfig) PressureEnabled() bool {
+	// alert rules need PSI even when display is disabled
+	if len(m.PressureAlerts) > 0 {
+		// alerts configured
+		return true
+	}
+	// require global toggle and at least one resource pressure flag
+	return m.Enabled && (m.CPU.Pressure || m.Memory.Pressure || m.IO.Pressure)
 }
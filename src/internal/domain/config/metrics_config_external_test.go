@@ -25,6 +25,7 @@ func TestDefaultMetricsConfig(t *testing.T) {
 			// Verify all categories enabled
 			assert.True(t, cfg.CPU.Enabled)
 			assert.True(t, cfg.CPU.Pressure)
+			assert.Equal(t, config.DefaultThrottleThreshold, cfg.CPU.ThrottleThreshold)
 			assert.True(t, cfg.Memory.Enabled)
 			assert.True(t, cfg.Memory.Pressure)
 			assert.True(t, cfg.Load.Enabled)
@@ -112,7 +113,7 @@ func TestFullMetricsConfig(t *testing.T) {
 	tests := []struct {
 		name string
 	}{
-		{name: "full template matches standard"},
+		{name: "full template enables standard categories faster"},
 	}
 
 	for _, tt := range tests {
@@ -120,12 +121,95 @@ func TestFullMetricsConfig(t *testing.T) {
 			full := config.FullMetricsConfig()
 			std := config.StandardMetricsConfig()
 
-			// Full should match standard for forward compatibility
+			// Full collects more often than standard
+			assert.Equal(t, config.MetricsTemplateFull, full.Template)
+			assert.Less(t, full.Interval, std.Interval)
+
+			// Categories match standard
+			full.Template, full.Interval = std.Template, std.Interval
 			assert.Equal(t, std, full)
 		})
 	}
 }
 
+// TestMetricsConfigForTemplate verifies template expansion.
+func TestMetricsConfigForTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     config.MetricsTemplate
+		wantSeconds  float64
+		wantDisk     bool
+		wantTemplate config.MetricsTemplate
+	}{
+		{name: "minimal", template: config.MetricsTemplateMinimal, wantSeconds: 10, wantDisk: false, wantTemplate: config.MetricsTemplateMinimal},
+		{name: "standard", template: config.MetricsTemplateStandard, wantSeconds: 5, wantDisk: true, wantTemplate: config.MetricsTemplateStandard},
+		{name: "full", template: config.MetricsTemplateFull, wantSeconds: 2, wantDisk: true, wantTemplate: config.MetricsTemplateFull},
+		{name: "custom uses standard base", template: config.MetricsTemplateCustom, wantSeconds: 5, wantDisk: true, wantTemplate: config.MetricsTemplateCustom},
+		{name: "unknown keeps name", template: "turbo", wantSeconds: 5, wantDisk: true, wantTemplate: "turbo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.MetricsConfigForTemplate(tt.template)
+
+			// Verify expansion
+			assert.Equal(t, tt.wantTemplate, cfg.Template)
+			assert.InDelta(t, tt.wantSeconds, cfg.Interval.Seconds(), 0.001)
+			assert.Equal(t, tt.wantDisk, cfg.Disk.Enabled)
+		})
+	}
+}
+
+// TestMetricsTemplate_IsValid verifies template name validation.
+func TestMetricsTemplate_IsValid(t *testing.T) {
+	// Verify known and unknown names
+	assert.True(t, config.MetricsTemplateMinimal.IsValid())
+	assert.True(t, config.MetricsTemplateCustom.IsValid())
+	assert.False(t, config.MetricsTemplate("turbo").IsValid())
+	assert.False(t, config.MetricsTemplate("").IsValid())
+}
+
+// TestMetricsConfig_CollectorToggles verifies derived collector toggles.
+func TestMetricsConfig_CollectorToggles(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            config.MetricsConfig
+		wantThrottling bool
+		wantPressure   bool
+		wantSmaps      bool
+	}{
+		{name: "standard", cfg: config.StandardMetricsConfig(), wantThrottling: true, wantPressure: true},
+		{name: "minimal", cfg: config.MinimalMetricsConfig(), wantThrottling: true, wantPressure: false},
+		{name: "globally disabled", cfg: config.MetricsConfig{CPU: config.CPUMetricsConfig{Enabled: true, Pressure: true}}, wantThrottling: false, wantPressure: false},
+		{
+			name:           "alert rules force pressure",
+			cfg:            config.MetricsConfig{PressureAlerts: []config.PressureAlertRule{{Resource: "io"}}},
+			wantThrottling: false,
+			wantPressure:   true,
+		},
+		{
+			name:           "smaps enabled",
+			cfg:            config.MetricsConfig{Enabled: true, Memory: config.MemoryMetricsConfig{Enabled: true, Smaps: true}},
+			wantThrottling: false,
+			wantPressure:   false,
+			wantSmaps:      true,
+		},
+		{
+			name: "smaps without memory metrics",
+			cfg:  config.MetricsConfig{Enabled: true, Memory: config.MemoryMetricsConfig{Smaps: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Verify toggles
+			assert.Equal(t, tt.wantThrottling, tt.cfg.ThrottlingEnabled())
+			assert.Equal(t, tt.wantPressure, tt.cfg.PressureEnabled())
+			assert.Equal(t, tt.wantSmaps, tt.cfg.SmapsEnabled())
+		})
+	}
+}
+
 // TestNewMonitoringConfigIncludesMetrics verifies monitoring config includes metrics.
 func TestNewMonitoringConfigIncludesMetrics(t *testing.T) {
 	tests := []struct {
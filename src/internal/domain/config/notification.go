@@ -0,0 +1,231 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Notification validation errors.
+var (
+	// ErrEmptyChannelName indicates a notification channel without a name.
+	ErrEmptyChannelName error = errors.New("notification channel name is required")
+	// ErrDuplicateChannelName indicates two notification channels with the same name.
+	ErrDuplicateChannelName error = errors.New("duplicate notification channel name")
+	// ErrInvalidChannelURL indicates a notification channel without an http(s) URL.
+	ErrInvalidChannelURL error = errors.New("notification channel requires an http or https url")
+	// ErrInvalidChannelDigest indicates a negative notification channel digest.
+	ErrInvalidChannelDigest error = errors.New("notification channel digest must not be negative")
+	// ErrInvalidChannelSelector indicates a notification channel selector with an empty label name.
+	ErrInvalidChannelSelector error = errors.New("notification channel selector label names must not be empty")
+	// ErrInvalidRateLimit indicates a negative notification rate limit.
+	ErrInvalidRateLimit error = errors.New("notification rate_limit must not be negative")
+	// ErrInvalidEscalation indicates an escalation rule without event, count or window.
+	ErrInvalidEscalation error = errors.New("escalation requires event, count and within")
+	// ErrUnknownEscalationChannel indicates an escalation rule naming an undefined channel.
+	ErrUnknownEscalationChannel error = errors.New("escalation channel is not defined")
+	// ErrInvalidSpoolSize indicates an unparsable notification spool size.
+	ErrInvalidSpoolSize error = errors.New("invalid notification spool max_size")
+	// ErrInvalidSpoolRetry indicates a negative notification spool retry interval.
+	ErrInvalidSpoolRetry error = errors.New("notification spool retry_interval must not be negative")
+)
+
+// NotificationsConfig configures the delivery of lifecycle events to
+// external channels.
+type NotificationsConfig struct {
+	// Channels are the destinations receiving events.
+	Channels []NotificationChannel
+	// RateLimit caps the messages sent across all channels.
+	RateLimit NotificationRateLimit
+	// Escalations send repeated events straight to a channel.
+	Escalations []EscalationRule
+	// Heartbeats ping dead man's switch endpoints while healthy.
+	Heartbeats []HeartbeatConfig
+	// Spool keeps undelivered messages on disk for replay.
+	Spool NotificationSpool
+}
+
+// NotificationChannel is a webhook receiving lifecycle events.
+type NotificationChannel struct {
+	// Name identifies the channel in escalation rules and logs.
+	Name string
+	// URL is the http(s) endpoint receiving a JSON POST per message.
+	URL string
+	// Events restricts the event types sent (failed, unhealthy, ...).
+	// Empty sends every event.
+	Events []string
+	// Digest batches the events of this period into one message.
+	// Zero sends each event on its own.
+	Digest shared.Duration
+	// Selector restricts the events sent to those whose service or target
+	// labels hold every pair (e.g., team: payments). Empty sends every event.
+	Selector map[string]string
+}
+
+// Selects reports whether events carrying the given labels match the
+// channel selector.
+//
+// Params:
+//   - labels: the labels of the event.
+//
+// Returns:
+//   - bool: true if every selector pair is present in labels.
+func (c *NotificationChannel) Selects(labels map[string]string) bool {
+	// check each selector pair
+	for name, value := range c.Selector {
+		// reject missing or different labels
+		if got, ok := labels[name]; !ok || got != value {
+			// not selected
+			return false
+		}
+	}
+	// every pair matched
+	return true
+}
+
+// NotificationRateLimit caps the messages sent across all channels.
+// Messages over the limit are dropped and counted in the next message sent.
+type NotificationRateLimit struct {
+	// Max is the number of messages allowed per period; zero disables the limit.
+	Max int
+	// Period is the length of a rate limit window; zero means one minute.
+	Period shared.Duration
+}
+
+// EscalationRule sends an event straight to a channel when it repeats more
+// than Count times for one service within a window. Escalations bypass the
+// channel digest and the global rate limit.
+type EscalationRule struct {
+	// Event is the event type counted (failed, unhealthy, ...).
+	Event string
+	// Count is the number of occurrences tolerated before escalating.
+	Count int
+	// Within is the window the occurrences are counted over.
+	Within shared.Duration
+	// Channel is the channel receiving the escalation.
+	Channel string
+}
+
+// NotificationSpool stores messages on disk while their endpoint is
+// unavailable and replays them in order once it is reachable again.
+type NotificationSpool struct {
+	// Dir is the spool directory; empty disables spooling.
+	Dir string
+	// MaxSize caps the spool (e.g., "64MB"); the oldest messages are
+	// dropped beyond it. Empty means 64MB.
+	MaxSize string
+	// RetryInterval is the time between replays; zero means 30s.
+	RetryInterval shared.Duration
+}
+
+// Enabled reports whether spooling is configured.
+//
+// Returns:
+//   - bool: true if a spool directory is set.
+func (s *NotificationSpool) Enabled() bool {
+	// spooling requires a directory
+	return s.Dir != ""
+}
+
+// HasChannel reports whether a channel with the given name is defined.
+//
+// Params:
+//   - name: the channel name.
+//
+// Returns:
+//   - bool: true if the channel exists.
+func (n *NotificationsConfig) HasChannel(name string) bool {
+	// search channels by name
+	for i := range n.Channels {
+		// matching channel
+		if n.Channels[i].Name == name {
+			// channel found
+			return true
+		}
+	}
+	// channel not found
+	return false
+}
+
+// validateNotifications validates the notification channels and rules.
+//
+// Params:
+//   - n: the notification configuration to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateNotifications(n *NotificationsConfig) error {
+	seen := make(map[string]bool, len(n.Channels))
+	// validate each channel
+	for i := range n.Channels {
+		ch := &n.Channels[i]
+		// check if channel has a name
+		if ch.Name == "" {
+			// return error when name is empty
+			return ErrEmptyChannelName
+		}
+		// check for name reuse
+		if seen[ch.Name] {
+			// return error with duplicated name
+			return fmt.Errorf("%w: %q", ErrDuplicateChannelName, ch.Name)
+		}
+		seen[ch.Name] = true
+
+		u, err := url.Parse(ch.URL)
+		// only http(s) webhooks are supported
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			// return error with channel name
+			return fmt.Errorf("notification channel %q: %w", ch.Name, ErrInvalidChannelURL)
+		}
+		// check digest is not negative
+		if ch.Digest < 0 {
+			// return error with channel name
+			return fmt.Errorf("notification channel %q: %w", ch.Name, ErrInvalidChannelDigest)
+		}
+		// check selector label names
+		if _, empty := ch.Selector[""]; empty {
+			// return error with channel name
+			return fmt.Errorf("notification channel %q: %w", ch.Name, ErrInvalidChannelSelector)
+		}
+	}
+
+	// check rate limit is not negative
+	if n.RateLimit.Max < 0 || n.RateLimit.Period < 0 {
+		// return rate limit error
+		return ErrInvalidRateLimit
+	}
+
+	// check spool size when set
+	if n.Spool.MaxSize != "" {
+		// size must be a valid size
+		if _, err := shared.ParseSize(n.Spool.MaxSize); err != nil {
+			// return error with parse details
+			return fmt.Errorf("%w %q: %w", ErrInvalidSpoolSize, n.Spool.MaxSize, err)
+		}
+	}
+	// check spool retry interval is not negative
+	if n.Spool.RetryInterval < 0 {
+		// return spool retry error
+		return ErrInvalidSpoolRetry
+	}
+
+	// validate each escalation rule
+	for i := range n.Escalations {
+		rule := &n.Escalations[i]
+		// check rule is complete
+		if rule.Event == "" || rule.Count < 1 || rule.Within <= 0 {
+			// return error with rule index
+			return fmt.Errorf("escalation %d: %w", i, ErrInvalidEscalation)
+		}
+		// check target channel exists
+		if !n.HasChannel(rule.Channel) {
+			// return error with channel name
+			return fmt.Errorf("escalation %d: %w: %q", i, ErrUnknownEscalationChannel, rule.Channel)
+		}
+	}
+	// validation passed
+	return nil
+}
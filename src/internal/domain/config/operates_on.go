@@ -0,0 +1,149 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultQuiesceHealthTimeout bounds the health verification of a service
+// after a job operating on it when none is configured.
+const DefaultQuiesceHealthTimeout time.Duration = 2 * time.Minute
+
+// QuiesceMode is how a service is quiesced while a job operates on its state.
+type QuiesceMode string
+
+// Quiesce modes.
+const (
+	// QuiesceNone leaves the service running during the job.
+	QuiesceNone QuiesceMode = ""
+	// QuiesceReloadSignal sends the service its reload signal before and after the job.
+	QuiesceReloadSignal QuiesceMode = "reload_signal"
+	// QuiesceStop stops the service before the job and starts it again after.
+	QuiesceStop QuiesceMode = "stop"
+)
+
+// Operated service validation errors.
+var (
+	// ErrOperatesOnNotOneshot indicates operates_on on a service that is not oneshot.
+	ErrOperatesOnNotOneshot error = errors.New("operates_on requires oneshot: true")
+	// ErrOperatesOnNoService indicates operates_on without a service.
+	ErrOperatesOnNoService error = errors.New("operates_on requires a service")
+	// ErrOperatesOnItself indicates a job operating on its own state.
+	ErrOperatesOnItself error = errors.New("operates_on cannot name the job itself")
+	// ErrUnknownOperatedService indicates operates_on naming an undefined service.
+	ErrUnknownOperatedService error = errors.New("operates_on names an unknown service")
+	// ErrInvalidQuiesceMode indicates an unknown operates_on quiesce value.
+	ErrInvalidQuiesceMode error = errors.New("operates_on quiesce must be stop or reload_signal")
+	// ErrInvalidQuiesceHealthTimeout indicates a negative operates_on health_timeout.
+	ErrInvalidQuiesceHealthTimeout error = errors.New("operates_on health_timeout must not be negative")
+)
+
+// OperatesOnConfig declares that a oneshot service, such as a nightly
+// backup, operates on the state directory of another service, quiesced
+// around each run.
+type OperatesOnConfig struct {
+	// Service is the service whose state directory the job operates on.
+	Service string
+	// Quiesce is how the service is quiesced during the job. Empty leaves
+	// it running.
+	Quiesce QuiesceMode
+	// VerifyHealth waits for the service to be healthy after the job.
+	VerifyHealth bool
+	// HealthTimeout bounds the health verification. Zero uses
+	// DefaultQuiesceHealthTimeout.
+	HealthTimeout shared.Duration
+}
+
+// IsValid reports whether the quiesce mode is known.
+//
+// Returns:
+//   - bool: true for none, reload_signal and stop.
+func (q QuiesceMode) IsValid() bool {
+	// match known modes
+	switch q {
+	case QuiesceNone, QuiesceReloadSignal, QuiesceStop:
+		// return known mode
+		return true
+	default:
+		// return unknown mode
+		return false
+	}
+}
+
+// EffectiveHealthTimeout returns the bound of the health verification.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultQuiesceHealthTimeout.
+func (o *OperatesOnConfig) EffectiveHealthTimeout() time.Duration {
+	// fall back to the default timeout
+	if o.HealthTimeout <= 0 {
+		// return default
+		return DefaultQuiesceHealthTimeout
+	}
+	// return configured timeout
+	return o.HealthTimeout.Duration()
+}
+
+// validateOperatesOn validates the operated service settings of a job.
+// The operated service is checked against the others by validateOperatedServices.
+//
+// Params:
+//   - svc: the service to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateOperatesOn(svc *ServiceConfig) error {
+	op := svc.OperatesOn
+	// report the first invalid setting
+	switch {
+	case op == nil:
+		// nothing declared
+		return nil
+	case !svc.Oneshot:
+		// return job requirement
+		return ErrOperatesOnNotOneshot
+	case op.Service == "":
+		// return missing service
+		return ErrOperatesOnNoService
+	case op.Service == svc.Name:
+		// return self reference
+		return ErrOperatesOnItself
+	case !op.Quiesce.IsValid():
+		// return error with the mode
+		return fmt.Errorf("%w: %q", ErrInvalidQuiesceMode, op.Quiesce)
+	case op.HealthTimeout < 0:
+		// return negative timeout
+		return ErrInvalidQuiesceHealthTimeout
+	default:
+		// settings valid
+		return nil
+	}
+}
+
+// validateOperatedServices checks that every job operates on a defined service.
+//
+// Params:
+//   - services: the service configurations.
+//   - defined: the names of the defined services.
+//
+// Returns:
+//   - []error: a ServiceError per job naming an unknown service.
+func validateOperatedServices(services []ServiceConfig, defined map[string]bool) []error {
+	var errs []error
+	// check each job
+	for i := range services {
+		op := services[i].OperatesOn
+		// an empty name is reported by validateOperatesOn
+		if op == nil || op.Service == "" || defined[op.Service] {
+			continue
+		}
+		errs = append(errs, &ServiceError{Index: i, Name: services[i].Name,
+			Err: keyed("operates_on", fmt.Errorf("%w: %q", ErrUnknownOperatedService, op.Service))})
+	}
+	// return failing jobs
+	return errs
+}
@@ -0,0 +1,77 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestOperatesOnConfig_EffectiveHealthTimeout tests the default of the
+// health verification bound.
+//
+// Params:
+//   - t: the testing context.
+func TestOperatesOnConfig_EffectiveHealthTimeout(t *testing.T) {
+	assert.Equal(t, config.DefaultQuiesceHealthTimeout, (&config.OperatesOnConfig{}).EffectiveHealthTimeout())
+	op := config.OperatesOnConfig{HealthTimeout: shared.Seconds(30)}
+	assert.Equal(t, 30*time.Second, op.EffectiveHealthTimeout())
+}
+
+// TestValidate_OperatesOn tests the validation of jobs operating on the
+// state of a service.
+//
+// Params:
+//   - t: the testing context.
+func TestValidate_OperatesOn(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// oneshot marks the backup service as a job.
+		oneshot bool
+		// op is the operated service declaration of the job.
+		op config.OperatesOnConfig
+		// wantErr is the expected error, nil when valid.
+		wantErr error
+	}{
+		{name: "stop", oneshot: true, op: config.OperatesOnConfig{Service: "db", Quiesce: config.QuiesceStop, VerifyHealth: true}},
+		{name: "reload signal", oneshot: true, op: config.OperatesOnConfig{Service: "db", Quiesce: config.QuiesceReloadSignal}},
+		{name: "running", oneshot: true, op: config.OperatesOnConfig{Service: "db"}},
+		{name: "not a job", op: config.OperatesOnConfig{Service: "db"}, wantErr: config.ErrOperatesOnNotOneshot},
+		{name: "no service", oneshot: true, wantErr: config.ErrOperatesOnNoService},
+		{name: "itself", oneshot: true, op: config.OperatesOnConfig{Service: "backup"}, wantErr: config.ErrOperatesOnItself},
+		{name: "unknown service", oneshot: true, op: config.OperatesOnConfig{Service: "cache"}, wantErr: config.ErrUnknownOperatedService},
+		{name: "unknown mode", oneshot: true, op: config.OperatesOnConfig{Service: "db", Quiesce: "pause"}, wantErr: config.ErrInvalidQuiesceMode},
+		{name: "negative timeout", oneshot: true, op: config.OperatesOnConfig{Service: "db", HealthTimeout: shared.Seconds(-1)}, wantErr: config.ErrInvalidQuiesceHealthTimeout},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			op := tt.op
+			cfg := &config.Config{Services: []config.ServiceConfig{
+				{Name: "db", Command: "/usr/bin/postgres"},
+				{Name: "backup", Command: "/usr/local/bin/backup", Oneshot: tt.oneshot, OperatesOn: &op},
+			}}
+			err := config.Validate(cfg)
+			// Expect a valid configuration.
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+			var svcErr *config.ServiceError
+			require.ErrorAs(t, err, &svcErr)
+			assert.Equal(t, "backup", svcErr.Name)
+			var fieldErr *config.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, "operates_on", fieldErr.Key)
+		})
+	}
+}
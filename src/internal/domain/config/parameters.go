@@ -0,0 +1,73 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"fmt"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Daemon parameter validation errors.
+var (
+	// ErrInvalidParameterLevel indicates a log level parameter that is not a level.
+	ErrInvalidParameterLevel error = shared.NewCodedError(shared.CodeInvalidArgument, "log_level must be debug, info, warn or error")
+	// ErrInvalidParameterProbe indicates a negative probe interval or timeout parameter.
+	ErrInvalidParameterProbe error = shared.NewCodedError(shared.CodeInvalidArgument, "probe_interval and probe_timeout must not be negative")
+)
+
+// DaemonParameters are the daemon settings that can be changed at runtime
+// through the API, without editing the configuration file. They are kept
+// apart from the configuration and survive restarts and reloads.
+// The zero value keeps every configured setting.
+type DaemonParameters struct {
+	// LogLevel replaces the level of every daemon log writer.
+	// Empty keeps the level configured for each writer.
+	LogLevel string
+	// ProbeInterval is the interval of the probes that configure none.
+	// Zero keeps the default (10s).
+	ProbeInterval shared.Duration
+	// ProbeTimeout is the timeout of the probes that configure none.
+	// Zero keeps the default (5s).
+	ProbeTimeout shared.Duration
+	// NotificationsMuted stops delivering events to notification channels.
+	// Heartbeats are still sent.
+	NotificationsMuted bool
+}
+
+// Validate checks the parameters.
+//
+// Returns:
+//   - error: ErrInvalidParameterLevel or ErrInvalidParameterProbe.
+func (p *DaemonParameters) Validate() error {
+	// the level must be known when set
+	if p.LogLevel != "" {
+		// reject unknown levels
+		if _, err := logging.ParseLevel(p.LogLevel); err != nil {
+			// return error with the level
+			return fmt.Errorf("%w: %q", ErrInvalidParameterLevel, p.LogLevel)
+		}
+	}
+	// check probe timings are not negative
+	if p.ProbeInterval < 0 || p.ProbeTimeout < 0 {
+		// return probe error
+		return ErrInvalidParameterProbe
+	}
+	// validation passed
+	return nil
+}
+
+// Level returns the log level replacing the configured writer levels.
+//
+// Returns:
+//   - *logging.Level: the level, nil to keep the configured levels.
+func (p *DaemonParameters) Level() *logging.Level {
+	level, err := logging.ParseLevel(p.LogLevel)
+	// keep the configured levels when unset
+	if p.LogLevel == "" || err != nil {
+		// no override
+		return nil
+	}
+	// return the override
+	return &level
+}
@@ -0,0 +1,43 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPreflightFailed indicates that a configuration failed pre-flight checks.
+var ErrPreflightFailed error = errors.New("pre-flight checks failed")
+
+// PreflightError reports every failed pre-flight check of a configuration.
+// It wraps ErrPreflightFailed for errors.Is matching.
+type PreflightError struct {
+	// Failures lists the failed checks in discovery order.
+	Failures []PreflightFailure
+}
+
+// Error returns the report with one failure per line.
+//
+// Returns:
+//   - string: the formatted report.
+func (e *PreflightError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d issue(s)", ErrPreflightFailed, len(e.Failures))
+	// append one line per failure
+	for _, f := range e.Failures {
+		b.WriteString("\n  - ")
+		b.WriteString(f.String())
+	}
+	// return full report
+	return b.String()
+}
+
+// Unwrap returns ErrPreflightFailed.
+//
+// Returns:
+//   - error: the sentinel error.
+func (e *PreflightError) Unwrap() error {
+	// expose sentinel for errors.Is
+	return ErrPreflightFailed
+}
@@ -0,0 +1,25 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPreflightError verifies the pre-flight report formatting and unwrapping.
+func TestPreflightError(t *testing.T) {
+	err := &config.PreflightError{Failures: []config.PreflightFailure{
+		{Service: "api", Check: config.PreflightCheckBinary, Detail: "/opt/api: no such file or directory"},
+		{Service: "worker", Check: config.PreflightCheckUser, Detail: "user \"ghost\" not found"},
+	}}
+
+	// Verify sentinel matching
+	assert.True(t, errors.Is(err, config.ErrPreflightFailed))
+
+	// Verify report lists every failure
+	assert.Equal(t, "pre-flight checks failed: 2 issue(s)\n"+
+		"  - service \"api\": binary: /opt/api: no such file or directory\n"+
+		"  - service \"worker\": user: user \"ghost\" not found", err.Error())
+}
@@ -0,0 +1,43 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import "fmt"
+
+// Pre-flight check names.
+const (
+	// PreflightCheckBinary verifies the service binary exists and is executable.
+	PreflightCheckBinary string = "binary"
+	// PreflightCheckUser verifies the service user can be resolved.
+	PreflightCheckUser string = "user"
+	// PreflightCheckGroup verifies the service group can be resolved.
+	PreflightCheckGroup string = "group"
+	// PreflightCheckWorkingDirectory verifies the working directory exists.
+	PreflightCheckWorkingDirectory string = "working_directory"
+	// PreflightCheckPort verifies listeners do not bind the same port.
+	PreflightCheckPort string = "port"
+	// PreflightCheckSecurityLabel verifies the SELinux context or AppArmor profile can be applied.
+	PreflightCheckSecurityLabel string = "security_label"
+	// PreflightCheckEgress verifies the host can enforce egress rules (cgroup v2, nft).
+	PreflightCheckEgress string = "egress"
+	// PreflightCheckVerify verifies the service binary runs under its user and group.
+	PreflightCheckVerify string = "verify"
+)
+
+// PreflightFailure describes a single failed pre-flight check.
+type PreflightFailure struct {
+	// Service is the name of the service the check applies to.
+	Service string
+	// Check is the name of the failed check.
+	Check string
+	// Detail explains why the check failed.
+	Detail string
+}
+
+// String returns a human-readable description of the failure.
+//
+// Returns:
+//   - string: the formatted failure.
+func (f PreflightFailure) String() string {
+	// format service, check, and detail
+	return fmt.Sprintf("service %q: %s: %s", f.Service, f.Check, f.Detail)
+}
@@ -0,0 +1,31 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import "slices"
+
+// PressureAlertRule raises an alert when a service cgroup PSI average exceeds a threshold.
+// Rules are evaluated against every process metrics update.
+type PressureAlertRule struct {
+	// Resource is the PSI resource: "cpu", "memory", or "io".
+	Resource string
+
+	// Scope is the PSI scope: "some" or "full".
+	Scope string
+
+	// Window is the averaging window: "avg10", "avg60", or "avg300".
+	Window string
+
+	// Threshold is the stall percentage above which the alert fires.
+	Threshold float64
+}
+
+// hasValidSelector reports whether resource, scope, and window name a PSI value.
+//
+// Returns:
+//   - bool: true if all selectors are known
+func (r *PressureAlertRule) hasValidSelector() bool {
+	// check resource, scope, and window against PSI file layout
+	return slices.Contains([]string{"cpu", "memory", "io"}, r.Resource) &&
+		slices.Contains([]string{"some", "full"}, r.Scope) &&
+		slices.Contains([]string{"avg10", "avg60", "avg300"}, r.Window)
+}
@@ -46,7 +46,7 @@ const (
 // It specifies timing, thresholds, and protocol-specific settings for health probes.
 type ProbeConfig struct {
 	// Type specifies the probe type.
-	// Supported values: "tcp", "udp", "http", "grpc", "exec", "icmp".
+	// Supported values: "tcp", "udp", "http", "grpc", "exec", "icmp", "scenario", "threshold".
 	Type string
 
 	// Interval specifies the time between consecutive probes.
@@ -55,6 +55,14 @@ type ProbeConfig struct {
 	// Timeout specifies the maximum time to wait for a probe response.
 	Timeout shared.Duration
 
+	// InheritsInterval reports that no interval was configured: Interval
+	// holds the default, replaced by the probe_interval daemon parameter.
+	InheritsInterval bool
+
+	// InheritsTimeout reports that no timeout was configured: Timeout
+	// holds the default, replaced by the probe_timeout daemon parameter.
+	InheritsTimeout bool
+
 	// SuccessThreshold specifies consecutive successes to mark ready.
 	SuccessThreshold int
 
@@ -87,6 +95,21 @@ type ProbeConfig struct {
 	// Valid values: "native", "fallback", "auto".
 	// Default is "auto" for automatic capability detection.
 	ICMPMode ICMPMode
+
+	// Steps lists the HTTP requests of scenario probes, run in order.
+	// The probe timeout is the budget for the whole scenario.
+	Steps []ScenarioStep
+
+	// Threshold is the value check of threshold probes.
+	Threshold *ThresholdConfig
+
+	// Debug enables probe tracing: every attempt is kept in a trace buffer
+	// and logged, regardless of the daemon log level.
+	Debug bool
+
+	// OnUnhealthy is the escalation chain run when a listener probe reports
+	// the service unhealthy. Empty restarts the service on every report.
+	OnUnhealthy []UnhealthyStep
 }
 
 // NewProbeConfig creates a new probe configuration with the specified type.
@@ -0,0 +1,89 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// defaultProxyDrainTimeout bounds how long in-flight connections may finish
+// once the service starts stopping or becomes unhealthy.
+const defaultProxyDrainTimeout time.Duration = 30 * time.Second
+
+// ProxyConfig makes the supervisor own the public side of an exposed listener.
+// The supervisor binds the public endpoint and forwards TCP connections to the
+// listener, so the service itself can bind only to localhost.
+type ProxyConfig struct {
+	// Address is the public bind address.
+	// Empty binds every interface. IPv6 literals may be bracketed.
+	Address string
+
+	// Port is the public port.
+	// Zero reuses the listener port, which requires distinct bind addresses.
+	Port int
+
+	// HealthGate refuses new connections while the service is not running
+	// or, for probed listeners, while the listener is not ready.
+	HealthGate bool
+
+	// DrainTimeout bounds how long in-flight connections may finish once the
+	// service starts stopping or becomes unhealthy. New connections are
+	// refused immediately; remaining ones are closed when it elapses.
+	// Zero uses the default of 30s.
+	DrainTimeout shared.Duration
+}
+
+// EffectiveDrainTimeout returns the drain timeout with the default applied.
+//
+// Returns:
+//   - time.Duration: the configured drain timeout, or 30s when unset.
+func (p *ProxyConfig) EffectiveDrainTimeout() time.Duration {
+	// apply default when unset
+	if p.DrainTimeout <= 0 {
+		// default drain window
+		return defaultProxyDrainTimeout
+	}
+	// configured drain window
+	return p.DrainTimeout.Duration()
+}
+
+// proxyListenerSuffix is appended to the listener name for its public endpoint.
+const proxyListenerSuffix string = " (proxy)"
+
+// PublicListener returns the endpoint bound by the supervisor for a proxied listener.
+// The endpoint keeps the listener protocol so it takes part in conflict detection.
+//
+// Returns:
+//   - *ListenerConfig: the public endpoint, or nil when the listener is not proxied.
+func (l *ListenerConfig) PublicListener() *ListenerConfig {
+	// listeners without proxy have no public endpoint
+	if l.Proxy == nil {
+		// not proxied
+		return nil
+	}
+
+	port := l.Proxy.Port
+	// reuse the listener port by default
+	if port == 0 {
+		port = l.Port
+	}
+	// build the public endpoint
+	return &ListenerConfig{
+		Name:     l.Name + proxyListenerSuffix,
+		Port:     port,
+		Protocol: l.Protocol,
+		Address:  l.Proxy.Address,
+		Exposed:  true,
+	}
+}
+
+// ListenAddress returns the host:port passed to a socket bind.
+// IPv6 hosts are bracketed; an unset address binds every interface.
+//
+// Returns:
+//   - string: the bind address, such as ":8080" or "[::1]:8080".
+func (l *ListenerConfig) ListenAddress() string {
+	// join host and port for a listen call
+	return joinHostPort(l.Host(), l.Port)
+}
@@ -0,0 +1,89 @@
+// Package config_test provides black-box tests for the config package.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestListenerConfig_PublicListener verifies the proxy public endpoint.
+//
+// Params:
+//   - t: testing context for assertions
+func TestListenerConfig_PublicListener(t *testing.T) {
+	tests := []struct {
+		name        string
+		listener    config.ListenerConfig
+		wantNil     bool
+		wantPort    int
+		wantAddress string
+	}{
+		{
+			name:     "not_proxied",
+			listener: config.ListenerConfig{Name: "http", Port: 8080},
+			wantNil:  true,
+		},
+		{
+			name:     "reuses_listener_port",
+			listener: config.ListenerConfig{Name: "http", Port: 8080, Address: "127.0.0.1", Proxy: &config.ProxyConfig{Address: "10.0.0.5"}},
+			wantPort: 8080, wantAddress: "10.0.0.5:8080",
+		},
+		{
+			name:     "explicit_public_port",
+			listener: config.ListenerConfig{Name: "http", Port: 8080, Address: "127.0.0.1", Proxy: &config.ProxyConfig{Port: 80}},
+			wantPort: 80, wantAddress: ":80",
+		},
+		{
+			name:     "ipv6_public_address",
+			listener: config.ListenerConfig{Name: "http", Port: 8080, Protocol: "tcp6", Address: "::1", Proxy: &config.ProxyConfig{Address: "[fd00::5]", Port: 443}},
+			wantPort: 443, wantAddress: "[fd00::5]:443",
+		},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			public := tt.listener.PublicListener()
+			// unproxied listeners have no public endpoint
+			if tt.wantNil {
+				assert.Nil(t, public)
+				return
+			}
+
+			// verify the public endpoint
+			require.NotNil(t, public)
+			assert.Equal(t, "http (proxy)", public.Name)
+			assert.Equal(t, tt.wantPort, public.Port)
+			assert.Equal(t, tt.listener.Protocol, public.Protocol)
+			assert.Equal(t, tt.wantAddress, public.ListenAddress())
+		})
+	}
+}
+
+// TestProxyConfig_EffectiveDrainTimeout verifies the drain timeout default.
+//
+// Params:
+//   - t: testing context for assertions
+func TestProxyConfig_EffectiveDrainTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy config.ProxyConfig
+		want  time.Duration
+	}{
+		{name: "default", proxy: config.ProxyConfig{}, want: 30 * time.Second},
+		{name: "explicit", proxy: config.ProxyConfig{DrainTimeout: shared.Seconds(5)}, want: 5 * time.Second},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.proxy.EffectiveDrainTimeout())
+		})
+	}
+}
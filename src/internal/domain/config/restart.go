@@ -21,6 +21,9 @@ type RestartConfig struct {
 	Delay shared.Duration
 	// DelayMax specifies the maximum delay for exponential backoff.
 	DelayMax shared.Duration
+	// Strategy names how the delay grows between attempts.
+	// Empty behaves as StrategyExponential.
+	Strategy RestartStrategy
 	// StabilityWindow specifies the duration of stable running required
 	// before the restart counter resets. If not set, defaults to 5 minutes.
 	StabilityWindow shared.Duration
@@ -41,6 +44,35 @@ const (
 	RestartUnless RestartPolicy = "unless-stopped"
 )
 
+// RestartStrategy names how the restart delay grows between attempts.
+// Besides the built-in strategies, any strategy registered with the process
+// domain can be named.
+type RestartStrategy string
+
+// Built-in restart strategy constants.
+const (
+	// StrategyExponential doubles the delay at each attempt, up to DelayMax.
+	StrategyExponential RestartStrategy = "exponential"
+	// StrategyFixed waits Delay before every attempt.
+	StrategyFixed RestartStrategy = "fixed"
+	// StrategyFibonacci grows the delay along the Fibonacci sequence, up to DelayMax.
+	StrategyFibonacci RestartStrategy = "fibonacci"
+)
+
+// EffectiveStrategy returns the strategy of the restart delay.
+//
+// Returns:
+//   - RestartStrategy: the configured strategy, or StrategyExponential.
+func (r *RestartConfig) EffectiveStrategy() RestartStrategy {
+	// fall back to exponential backoff
+	if r.Strategy == "" {
+		// return default
+		return StrategyExponential
+	}
+	// return configured strategy
+	return r.Strategy
+}
+
 // String returns the string representation of the restart policy.
 //
 // Returns:
@@ -0,0 +1,61 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRestartBudget indicates a negative restart rate or burst.
+var ErrInvalidRestartBudget error = errors.New("restart budget must not be negative")
+
+// RestartBudgetConfig bounds the restarts the daemon performs across all
+// services, so a crash of many services at once does not overload the host.
+type RestartBudgetConfig struct {
+	// PerMinute is how many restarts per minute the daemon performs.
+	// Zero disables the budget.
+	PerMinute int
+	// Burst is how many restarts may run at once before the rate applies.
+	// Zero uses PerMinute.
+	Burst int
+}
+
+// Enabled reports whether restarts are rate limited.
+//
+// Returns:
+//   - bool: true when a restart rate is set.
+func (r *RestartBudgetConfig) Enabled() bool {
+	// limited only with a rate
+	return r.PerMinute > 0
+}
+
+// EffectiveBurst returns how many restarts may run at once.
+//
+// Returns:
+//   - int: the configured burst, or PerMinute.
+func (r *RestartBudgetConfig) EffectiveBurst() int {
+	// fall back to the rate
+	if r.Burst <= 0 {
+		// return rate
+		return r.PerMinute
+	}
+	// return configured burst
+	return r.Burst
+}
+
+// validateRestartBudget validates the restart budget settings.
+//
+// Params:
+//   - r: restart budget configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateRestartBudget(r *RestartBudgetConfig) error {
+	// check rate and burst are not negative
+	if r.PerMinute < 0 || r.Burst < 0 {
+		// return error with the values
+		return fmt.Errorf("%w: per_minute %d, burst %d", ErrInvalidRestartBudget, r.PerMinute, r.Burst)
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestRestartBudgetConfig_EffectiveBurst tests the restart burst default.
+//
+// Params:
+//   - t: the testing context.
+func TestRestartBudgetConfig_EffectiveBurst(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// budget is the configuration.
+		budget config.RestartBudgetConfig
+		// wantEnabled is whether restarts are limited.
+		wantEnabled bool
+		// want is the expected burst.
+		want int
+	}{
+		{name: "disabled"},
+		{name: "default burst", budget: config.RestartBudgetConfig{PerMinute: 20}, wantEnabled: true, want: 20},
+		{name: "configured burst", budget: config.RestartBudgetConfig{PerMinute: 20, Burst: 5}, wantEnabled: true, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEnabled, tt.budget.Enabled())
+			assert.Equal(t, tt.want, tt.budget.EffectiveBurst())
+		})
+	}
+}
@@ -287,3 +287,27 @@ func TestDefaultRestartConfig(t *testing.T) {
 		})
 	}
 }
+
+// TestRestartConfig_EffectiveStrategy tests the restart strategy default.
+//
+// Params:
+//   - t: testing context
+func TestRestartConfig_EffectiveStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy config.RestartStrategy
+		expected config.RestartStrategy
+	}{
+		{name: "default", expected: config.StrategyExponential},
+		{name: "configured", strategy: config.StrategyFibonacci, expected: config.StrategyFibonacci},
+		{name: "registered", strategy: "jittered", expected: "jittered"},
+	}
+
+	// Iterate through all test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.RestartConfig{Strategy: tt.strategy}
+			assert.Equal(t, tt.expected, cfg.EffectiveStrategy())
+		})
+	}
+}
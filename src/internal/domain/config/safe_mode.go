@@ -0,0 +1,69 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultSafeModeWindow is the window restarts are counted over when none is configured.
+const DefaultSafeModeWindow time.Duration = time.Minute
+
+// ErrInvalidSafeMode indicates a negative restart threshold or window.
+var ErrInvalidSafeMode error = errors.New("safe mode restarts and window must not be negative")
+
+// SafeModeConfig detects restart storms: when the services restart more
+// than a threshold across the daemon, the cause is likely the host, and
+// restarting them only amplifies the outage. The daemon then enters safe
+// mode and holds the restarts of non-critical services until told to exit.
+type SafeModeConfig struct {
+	// Restarts is how many automatic restarts across all services within
+	// the window enter safe mode. Zero disables the detection.
+	Restarts int
+	// Window is the sliding window restarts are counted over. Zero uses
+	// DefaultSafeModeWindow.
+	Window shared.Duration
+}
+
+// Enabled reports whether restart storms are detected.
+//
+// Returns:
+//   - bool: true when a restart threshold is set.
+func (s *SafeModeConfig) Enabled() bool {
+	// detected only with a threshold
+	return s.Restarts > 0
+}
+
+// EffectiveWindow returns the window restarts are counted over.
+//
+// Returns:
+//   - time.Duration: the configured window, or DefaultSafeModeWindow.
+func (s *SafeModeConfig) EffectiveWindow() time.Duration {
+	// fall back to the default window
+	if s.Window <= 0 {
+		// return default
+		return DefaultSafeModeWindow
+	}
+	// return configured window
+	return s.Window.Duration()
+}
+
+// validateSafeMode validates the safe mode settings.
+//
+// Params:
+//   - s: safe mode configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateSafeMode(s *SafeModeConfig) error {
+	// check threshold and window are not negative
+	if s.Restarts < 0 || s.Window < 0 {
+		// return error with the values
+		return fmt.Errorf("%w: restarts %d, window %s", ErrInvalidSafeMode, s.Restarts, s.Window)
+	}
+	// validation passed
+	return nil
+}
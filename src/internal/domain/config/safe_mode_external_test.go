@@ -0,0 +1,43 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestSafeModeConfig tests the safe mode defaults.
+//
+// Params:
+//   - t: the testing context.
+func TestSafeModeConfig(t *testing.T) {
+	disabled := config.SafeModeConfig{}
+	assert.False(t, disabled.Enabled())
+	assert.Equal(t, config.DefaultSafeModeWindow, disabled.EffectiveWindow())
+
+	safeMode := config.SafeModeConfig{Restarts: 20, Window: shared.Seconds(30)}
+	assert.True(t, safeMode.Enabled())
+	assert.Equal(t, 30*time.Second, safeMode.EffectiveWindow())
+}
+
+// TestValidate_SafeMode tests that negative safe mode settings are refused.
+//
+// Params:
+//   - t: the testing context.
+func TestValidate_SafeMode(t *testing.T) {
+	cfg := &config.Config{
+		Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+		SafeMode: config.SafeModeConfig{Restarts: -1},
+	}
+	err := config.Validate(cfg)
+	require.ErrorIs(t, err, config.ErrInvalidSafeMode)
+	var fieldErr *config.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "safe_mode", fieldErr.Key)
+}
@@ -0,0 +1,76 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ProbeTypeScenario runs an ordered list of HTTP steps.
+const ProbeTypeScenario string = "scenario"
+
+// Scenario validation errors.
+var (
+	// ErrMissingScenarioSteps indicates a scenario probe without steps.
+	ErrMissingScenarioSteps error = errors.New("scenario probe requires steps")
+	// ErrInvalidScenarioExtract indicates an extraction pattern that does not compile
+	// or has no capture group.
+	ErrInvalidScenarioExtract error = errors.New("invalid scenario extract pattern")
+)
+
+// ScenarioStep is one HTTP request of a scenario probe.
+// Path, header values and body may reference variables extracted by earlier
+// steps as {{name}}.
+type ScenarioStep struct {
+	// Name identifies the step in failure reports.
+	Name string
+	// Method is the HTTP method. Default is GET.
+	Method string
+	// Path is the request path on the listener.
+	Path string
+	// Headers are the request headers.
+	Headers map[string]string
+	// Body is the request body.
+	Body string
+	// StatusCode is the expected response status. Default is 200.
+	StatusCode int
+	// Extract maps variable names to regular expressions matched against the
+	// response body; the first capture group becomes the variable value.
+	Extract map[string]string
+}
+
+// validateScenario checks the steps of a scenario probe.
+//
+// Params:
+//   - steps: the scenario steps.
+//
+// Returns:
+//   - error: validation error if any.
+func validateScenario(steps []ScenarioStep) error {
+	// a scenario needs at least one step
+	if len(steps) == 0 {
+		// return error when no steps are defined
+		return ErrMissingScenarioSteps
+	}
+	// check every extraction pattern
+	for i := range steps {
+		step := &steps[i]
+		// each pattern must capture the variable value
+		for name, pattern := range step.Extract {
+			re, err := regexp.Compile(pattern)
+			// reject patterns that do not compile
+			if err != nil {
+				// return error with step and variable
+				return fmt.Errorf("%w: step %d variable %q: %w", ErrInvalidScenarioExtract, i+1, name, err)
+			}
+			// reject patterns without a capture group
+			if re.NumSubexp() == 0 {
+				// return error with step and variable
+				return fmt.Errorf("%w: step %d variable %q: no capture group", ErrInvalidScenarioExtract, i+1, name)
+			}
+		}
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,162 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultSecretInterval is the re-hash period of secret files, on top of
+// change notifications, which miss the symlink swaps of Kubernetes volumes.
+const DefaultSecretInterval time.Duration = time.Minute
+
+// SecretChangeAction is what a service does once one of its secrets changed.
+type SecretChangeAction string
+
+const (
+	// SecretChangeNone only emits the secret_changed events.
+	SecretChangeNone SecretChangeAction = ""
+	// SecretChangeReload reloads the service in place, with its
+	// reload_command or reload_signal.
+	SecretChangeReload SecretChangeAction = "reload"
+	// SecretChangeRestart restarts the service with the new values.
+	SecretChangeRestart SecretChangeAction = "restart"
+)
+
+// Secret validation errors.
+var (
+	// ErrInvalidSecretEnv indicates a secret without a valid environment variable name.
+	ErrInvalidSecretEnv error = errors.New("secret env must be a variable name")
+	// ErrInvalidSecretFile indicates a secret file path that is not absolute.
+	ErrInvalidSecretFile error = errors.New("secret file must be an absolute path")
+	// ErrDuplicateSecretEnv indicates a variable set by two secrets, or by a secret and environment.
+	ErrDuplicateSecretEnv error = errors.New("secret env is already set")
+	// ErrInvalidSecretChange indicates an unknown on_secret_change action.
+	ErrInvalidSecretChange error = errors.New("on_secret_change must be reload or restart")
+)
+
+// SecretConfig sets an environment variable of the service to the content
+// of a file, as mounted by Docker, Kubernetes or a Vault agent. The file is
+// read at each start, and watched for rotation.
+type SecretConfig struct {
+	// Env is the environment variable receiving the secret.
+	Env string
+	// File is the absolute path of the file holding the secret. A final
+	// line ending is not part of the value.
+	File string
+}
+
+// SecretFiles returns the secret files of the service, in declaration order.
+//
+// Returns:
+//   - []string: the files, nil without secrets.
+func (s *ServiceConfig) SecretFiles() []string {
+	var files []string
+	// collect each file
+	for i := range s.Secrets {
+		files = append(files, s.Secrets[i].File)
+	}
+	// return files
+	return files
+}
+
+// RedactedEnv returns the environment variables redacted from dumps
+// whatever their name: decrypted values and secret files.
+//
+// Returns:
+//   - []string: the variable names.
+func (s *ServiceConfig) RedactedEnv() []string {
+	keys := append([]string(nil), s.SecretEnv...)
+	// secret files are redacted too
+	for i := range s.Secrets {
+		keys = append(keys, s.Secrets[i].Env)
+	}
+	// return variable names
+	return keys
+}
+
+// ProcessEnv returns the environment of the process: the configured
+// variables, those of the time zone and locale, and the current content of
+// the secret files.
+//
+// Params:
+//   - fs: the filesystem the secrets are read from.
+//
+// Returns:
+//   - map[string]string: the variables, Environment itself without secrets,
+//     time zone or locale.
+//   - error: the read error of a secret file.
+func (s *ServiceConfig) ProcessEnv(fs shared.FileSystem) (map[string]string, error) {
+	localeEnv := s.LocaleEnv()
+	// nothing to add
+	if len(s.Secrets) == 0 && len(localeEnv) == 0 {
+		// return configured variables
+		return s.Environment, nil
+	}
+	env := make(map[string]string, len(s.Environment)+len(localeEnv)+len(s.Secrets))
+	maps.Copy(env, s.Environment)
+	maps.Copy(env, localeEnv)
+	// read each secret
+	for i := range s.Secrets {
+		secret := &s.Secrets[i]
+		data, err := fs.ReadFile(secret.File)
+		// a missing secret fails the start
+		if err != nil {
+			// return error with the variable
+			return nil, fmt.Errorf("secret %s: %w", secret.Env, err)
+		}
+		value := strings.TrimSuffix(string(data), "\n")
+		env[secret.Env] = strings.TrimSuffix(value, "\r")
+	}
+	// return variables with secrets
+	return env, nil
+}
+
+// validateSecrets validates the secret files of a service and the action
+// run when they change.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - error: validation error if any.
+func validateSecrets(svc *ServiceConfig) error {
+	seen := make(map[string]bool, len(svc.Secrets))
+	// validate each secret
+	for i := range svc.Secrets {
+		secret := &svc.Secrets[i]
+		// name must be a variable name
+		if secret.Env == "" || strings.ContainsAny(secret.Env, "= \t\n\x00") {
+			// return error with the secret index
+			return fmt.Errorf("secret %d: %w: %q", i, ErrInvalidSecretEnv, secret.Env)
+		}
+		// each variable has one source
+		if _, ok := svc.Environment[secret.Env]; ok || seen[secret.Env] {
+			// return error with the variable
+			return fmt.Errorf("secret %d: %w: %s", i, ErrDuplicateSecretEnv, secret.Env)
+		}
+		seen[secret.Env] = true
+		// path must be absolute
+		if !filepath.IsAbs(secret.File) {
+			// return error with the path
+			return fmt.Errorf("secret %d: %w: %q", i, ErrInvalidSecretFile, secret.File)
+		}
+	}
+	// validate the action
+	switch svc.OnSecretChange {
+	// known actions
+	case SecretChangeNone, SecretChangeReload, SecretChangeRestart:
+		// secrets valid
+		return nil
+	// unknown action
+	default:
+		// return error with the action
+		return fmt.Errorf("%w: %q", ErrInvalidSecretChange, svc.OnSecretChange)
+	}
+}
@@ -0,0 +1,118 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestServiceConfig_secrets tests the files and redacted variables of the
+// secrets of a service.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_secrets(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// svc is the service configuration.
+		svc config.ServiceConfig
+		// files are the expected secret files.
+		files []string
+		// redacted are the expected redacted variables.
+		redacted []string
+	}{
+		{
+			name:     "no secrets",
+			svc:      config.ServiceConfig{SecretEnv: []string{"API_TOKEN"}},
+			redacted: []string{"API_TOKEN"},
+		},
+		{
+			name: "secret files",
+			svc: config.ServiceConfig{
+				SecretEnv: []string{"API_TOKEN"},
+				Secrets: []config.SecretConfig{
+					{Env: "DB_PASSWORD", File: "/run/secrets/db"},
+					{Env: "UPSTREAM", File: "/run/secrets/upstream"},
+				},
+			},
+			files:    []string{"/run/secrets/db", "/run/secrets/upstream"},
+			redacted: []string{"API_TOKEN", "DB_PASSWORD", "UPSTREAM"},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.files, tt.svc.SecretFiles())
+			assert.Equal(t, tt.redacted, tt.svc.RedactedEnv())
+		})
+	}
+}
+
+// TestServiceConfig_ProcessEnv tests the secret files are read into the
+// environment of the process.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_ProcessEnv(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lf"), []byte("one\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crlf"), []byte("two\r\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "multi"), []byte("a\nb\n\n"), 0o600))
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// secrets are the secrets of the service.
+		secrets []config.SecretConfig
+		// want is the expected environment.
+		want map[string]string
+		// wantErr indicates a read error is expected.
+		wantErr bool
+	}{
+		{
+			name: "no secrets",
+			want: map[string]string{"MODE": "prod"},
+		},
+		{
+			name: "final line endings trimmed",
+			secrets: []config.SecretConfig{
+				{Env: "ONE", File: filepath.Join(dir, "lf")},
+				{Env: "TWO", File: filepath.Join(dir, "crlf")},
+				{Env: "MULTI", File: filepath.Join(dir, "multi")},
+			},
+			want: map[string]string{"MODE": "prod", "ONE": "one", "TWO": "two", "MULTI": "a\nb\n"},
+		},
+		{
+			name:    "missing file",
+			secrets: []config.SecretConfig{{Env: "ONE", File: filepath.Join(dir, "missing")}},
+			wantErr: true,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			svc := config.ServiceConfig{Environment: map[string]string{"MODE": "prod"}, Secrets: tt.secrets}
+			env, err := svc.ProcessEnv(shared.DefaultFileSystem)
+			// Check the read error.
+			if tt.wantErr {
+				assert.ErrorIs(t, err, os.ErrNotExist)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, env)
+			assert.Equal(t, map[string]string{"MODE": "prod"}, svc.Environment)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Changes lists the settings of the service that differ in next, by their
+// configuration key, in declaration order. The name is not compared.
+//
+// Params:
+//   - next: the configuration replacing this one.
+//
+// Returns:
+//   - []string: the changed keys, empty when the services are equivalent.
+func (s *ServiceConfig) Changes(next *ServiceConfig) []string {
+	fields := []struct {
+		// key is the configuration key of the setting.
+		key string
+		// changed reports that the setting differs.
+		changed bool
+	}{
+		{"command", s.Command != next.Command},
+		{"args", !slices.Equal(s.Args, next.Args)},
+		{"user", s.User != next.User},
+		{"group", s.Group != next.Group},
+		{"working_dir", s.WorkingDirectory != next.WorkingDirectory},
+		{"environment", !maps.Equal(s.Environment, next.Environment)},
+		{"timezone", s.Timezone != next.Timezone},
+		{"locale", s.Locale != next.Locale},
+		{"secrets", !slices.Equal(s.Secrets, next.Secrets)},
+		{"on_secret_change", s.OnSecretChange != next.OnSecretChange},
+		{"selinux_context", s.SELinuxContext != next.SELinuxContext},
+		{"apparmor_profile", s.AppArmorProfile != next.AppArmorProfile},
+		{"read_only_paths", !slices.Equal(s.ReadOnlyPaths, next.ReadOnlyPaths)},
+		{"masked_paths", !slices.Equal(s.MaskedPaths, next.MaskedPaths)},
+		{"tmpfs_paths", !slices.Equal(s.TmpfsPaths, next.TmpfsPaths)},
+		{"pid_namespace", s.PIDNamespace != next.PIDNamespace},
+		{"egress", !reflect.DeepEqual(s.Egress, next.Egress)},
+		{"dns", !reflect.DeepEqual(s.DNS, next.DNS)},
+		{"integrity", !reflect.DeepEqual(s.Integrity, next.Integrity)},
+		{"restart_on_binary_change", s.RestartOnBinaryChange != next.RestartOnBinaryChange},
+		{"watches", !reflect.DeepEqual(s.Watches, next.Watches)},
+		{"allowed_signals", !slices.Equal(s.AllowedSignals, next.AllowedSignals)},
+		{"reload_signal", s.ReloadSignal != next.ReloadSignal},
+		{"reload_command", s.ReloadCommand != next.ReloadCommand},
+		{"reload_timeout", s.ReloadTimeout != next.ReloadTimeout},
+		{"verify_command", s.VerifyCommand != next.VerifyCommand},
+		{"verify_timeout", s.VerifyTimeout != next.VerifyTimeout},
+		{"reservation", !reflect.DeepEqual(s.Reservation, next.Reservation)},
+		{"conditions", !reflect.DeepEqual(s.Conditions, next.Conditions)},
+		{"priority", s.Priority != next.Priority},
+		{"slo", !reflect.DeepEqual(s.SLO, next.SLO)},
+		{"restart", !reflect.DeepEqual(s.Restart, next.Restart)},
+		{"health_checks", !reflect.DeepEqual(s.HealthChecks, next.HealthChecks)},
+		{"listeners", !reflect.DeepEqual(s.Listeners, next.Listeners)},
+		{"logging", !reflect.DeepEqual(s.Logging, next.Logging)},
+		{"depends_on", !slices.Equal(s.DependsOn, next.DependsOn)},
+		{"start_phase", s.StartPhase != next.StartPhase},
+		{"oneshot", s.Oneshot != next.Oneshot},
+		{"job_history", s.JobHistory != next.JobHistory},
+		{"operates_on", !reflect.DeepEqual(s.OperatesOn, next.OperatesOn)},
+		{"start_timeout", s.StartTimeout != next.StartTimeout},
+		{"max_runtime", s.MaxRuntime != next.MaxRuntime},
+		{"critical", s.Critical != next.Critical},
+		{"external_dependencies", !reflect.DeepEqual(s.ExternalDependencies, next.ExternalDependencies)},
+		{"labels", !maps.Equal(s.Labels, next.Labels)},
+	}
+	var changes []string
+	// collect the changed settings
+	for _, field := range fields {
+		// keep changed settings only
+		if field.changed {
+			changes = append(changes, field.key)
+		}
+	}
+	// return changed keys
+	return changes
+}
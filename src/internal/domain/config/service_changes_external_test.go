@@ -0,0 +1,55 @@
+// Package config_test provides black-box tests for service_changes.go.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestServiceConfig_Changes verifies the keys reported as changed.
+//
+// Params:
+//   - t: testing context for assertions.
+func TestServiceConfig_Changes(t *testing.T) {
+	base := config.NewServiceConfig("web", "/bin/web")
+	base.Environment = map[string]string{"PORT": "8080"}
+
+	tests := []struct {
+		name   string
+		mutate func(svc *config.ServiceConfig)
+		want   []string
+	}{
+		{
+			name:   "equivalent",
+			mutate: func(svc *config.ServiceConfig) { svc.Environment = map[string]string{"PORT": "8080"} },
+			want:   nil,
+		},
+		{
+			name:   "name is not compared",
+			mutate: func(svc *config.ServiceConfig) { svc.Name = "api" },
+			want:   nil,
+		},
+		{
+			name: "several settings in declaration order",
+			mutate: func(svc *config.ServiceConfig) {
+				svc.Labels = map[string]string{"team": "web"}
+				svc.Command = "/bin/web2"
+				svc.Environment = map[string]string{"PORT": "9090"}
+				svc.Restart.MaxRetries = 10
+			},
+			want: []string{"command", "environment", "restart", "labels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := base
+			next.Environment = map[string]string{"PORT": "8080"}
+			tt.mutate(&next)
+			assert.Equal(t, tt.want, base.Changes(&next))
+		})
+	}
+}
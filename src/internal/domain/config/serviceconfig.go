@@ -27,6 +27,81 @@ type ServiceConfig struct {
 	WorkingDirectory string
 	// Environment contains key-value pairs of environment variables.
 	Environment map[string]string
+	// Timezone is the IANA time zone of the service (Europe/Paris), set
+	// as TZ. Empty inherits the daemon's TZ.
+	Timezone string
+	// Locale is the locale of the service (en_US.UTF-8), set as LANG and
+	// LC_ALL. Empty inherits the daemon's locale variables.
+	Locale string
+	// SecretEnv names the environment variables whose value was decrypted
+	// from the configuration file; dumps redact them whatever their name.
+	SecretEnv []string
+	// Secrets set environment variables to the content of files, read at
+	// each start and watched for rotation.
+	Secrets []SecretConfig
+	// OnSecretChange is what the service does once a secret file changed.
+	// Empty only emits events.
+	OnSecretChange SecretChangeAction
+	// SELinuxContext is the SELinux context the service executes under
+	// (user:role:type[:level]). Empty keeps the daemon's.
+	SELinuxContext string
+	// AppArmorProfile is the AppArmor profile the service executes under.
+	// Empty keeps the daemon's.
+	AppArmorProfile string
+	// ReadOnlyPaths are made read-only in the service's mount namespace.
+	ReadOnlyPaths []string
+	// MaskedPaths are hidden in the service's mount namespace: files are
+	// replaced by /dev/null, directories by an empty read-only tmpfs.
+	MaskedPaths []string
+	// TmpfsPaths are covered by a private writable tmpfs in the service's
+	// mount namespace.
+	TmpfsPaths []string
+	// PIDNamespace runs the service in its own PID namespace, under a
+	// minimal init reaping its orphans: the whole process tree ends with it.
+	PIDNamespace bool
+	// Egress restricts outbound traffic to the listed destinations.
+	// Empty leaves the network unrestricted.
+	Egress []EgressRule
+	// DNS overrides the /etc/hosts and /etc/resolv.conf the service sees
+	// in its mount namespace. Zero keeps the host's.
+	DNS DNSConfig
+	// Integrity watches the service binary and extra files for modification.
+	Integrity IntegrityConfig
+	// RestartOnBinaryChange restarts the service when its binary changes,
+	// to pick up deployments copying a new binary over the old one.
+	RestartOnBinaryChange bool
+	// Watches act on the service when watched files change.
+	Watches []WatchConfig
+	// AllowedSignals are the signals operators may send to the service.
+	// Empty allows DefaultAllowedSignals.
+	AllowedSignals []string
+	// ReloadSignal is the signal making the service reload its own
+	// configuration. Empty uses DefaultReloadSignal.
+	ReloadSignal string
+	// ReloadCommand is the command line making the service reload its own
+	// configuration (nginx -s reload), run instead of sending ReloadSignal.
+	ReloadCommand string
+	// ReloadTimeout bounds the reload command and the probes confirming
+	// the reload. Zero uses DefaultReloadTimeout.
+	ReloadTimeout shared.Duration
+	// VerifyCommand is the command line dry-running the service binary
+	// under its user, group and working directory. When set, it also runs
+	// in the pre-flight checks of a reload. Empty verifies with the service
+	// binary and --version, then --help.
+	VerifyCommand string
+	// VerifyTimeout bounds the verify command. Zero uses DefaultVerifyTimeout.
+	VerifyTimeout shared.Duration
+	// Reservation declares the memory and CPU the service is expected to
+	// use, checked against host capacity before it starts.
+	Reservation ReservationConfig
+	// Conditions declares host facts the service needs to start. Unmet
+	// conditions skip the service instead of failing it.
+	Conditions ConditionConfig
+	// Priority is the class ranking the service for shedding under memory
+	// pressure. Empty behaves as PriorityNormal.
+	Priority PriorityClass
+	// SLO is the availability objective of the service. Nil declares none.
+	SLO *SLOConfig
 	// Restart defines the restart behavior when the service exits.
 	Restart RestartConfig
 	// HealthChecks defines the health check configurations for this service.
@@ -40,8 +115,34 @@ type ServiceConfig struct {
 	Logging ServiceLogging
 	// DependsOn lists service names that must start before this service.
 	DependsOn []string
+	// StartPhase orders the initial startup: services of a phase start
+	// together once every service of the lower phases is ready or failed.
+	StartPhase int
 	// Oneshot indicates the service runs once and exits without restart.
 	Oneshot bool
+	// JobHistory is the number of past runs kept for a oneshot service.
+	// Zero uses DefaultJobHistory.
+	JobHistory int
+	// OperatesOn declares the service whose state directory a oneshot
+	// service operates on, quiesced around each run. Nil declares none.
+	OperatesOn *OperatesOnConfig
+	// StartTimeout bounds the time a started service has to become ready.
+	// A service still not ready is killed and handled by its restart policy.
+	// Zero disables the deadline.
+	StartTimeout shared.Duration
+	// MaxRuntime bounds the time a process of the service may run. A process
+	// reaching it is stopped: the restart policy decides what happens next,
+	// and a oneshot run is complete. Zero disables the limit.
+	MaxRuntime shared.Duration
+	// Critical marks the service as required for a successful boot.
+	// When no service is critical, every service is.
+	Critical bool
+	// ExternalDependencies lists external systems the service relies on.
+	// They are probed and reported with the service.
+	ExternalDependencies []DependencyConfig
+	// Labels are free-form key/value annotations (team, tier, environment)
+	// attached to the events, notifications and metrics of the service.
+	Labels map[string]string
 }
 
 // NewServiceConfig creates a new ServiceConfig with the given name and command.
@@ -1,6 +1,10 @@
 // Package config provides domain value objects for service configuration.
 package config
 
+// NormalizedTimestampFormat is the timestamp layout of lines written with
+// NormalizeTimestamps: UTC with nanoseconds at fixed width, so lines sort as text.
+const NormalizedTimestampFormat string = "2006-01-02T15:04:05.000000000Z07:00"
+
 // ServiceLogging defines per-service logging configuration.
 // It configures separate settings for stdout and stderr streams.
 type ServiceLogging struct {
@@ -8,4 +12,30 @@ type ServiceLogging struct {
 	Stdout LogStreamConfig
 	// Stderr configures logging for the service's standard error stream.
 	Stderr LogStreamConfig
+	// MaxTotalSize caps the combined size of the service's stdout and stderr
+	// logs, rotated files included (e.g., "1GB"). When exceeded, the oldest
+	// rotated files are deleted first. Empty disables the quota.
+	MaxTotalSize string
+	// NormalizeTimestamps prefixes each output line with a
+	// NormalizedTimestampFormat timestamp and tags whether the line carried
+	// its own timestamp. It replaces the timestamp_format of both streams.
+	NormalizeTimestamps bool
+}
+
+// TimestampFormat returns the timestamp format the lines of a stream are
+// written with.
+//
+// Params:
+//   - stream: the stdout or stderr configuration of the service.
+//
+// Returns:
+//   - string: the timestamp format, empty for lines written as is.
+func (s *ServiceLogging) TimestampFormat(stream *LogStreamConfig) string {
+	// normalized lines share one format
+	if s.NormalizeTimestamps {
+		// return normalized layout
+		return NormalizedTimestampFormat
+	}
+	// return stream format
+	return stream.TimestampFormat()
 }
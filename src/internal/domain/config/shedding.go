@@ -0,0 +1,216 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Shedding defaults.
+const (
+	// DefaultShedScope is the PSI scope compared with the shedding thresholds.
+	DefaultShedScope string = "some"
+	// DefaultShedWindow is the PSI averaging window compared with the shedding thresholds.
+	DefaultShedWindow string = "avg10"
+	// DefaultShedInterval is how often host memory pressure is checked.
+	DefaultShedInterval time.Duration = 10 * time.Second
+)
+
+// PriorityClass ranks services for shedding under memory pressure.
+type PriorityClass string
+
+// Priority class constants, from the first shed to the never shed.
+const (
+	// PriorityLow services are stopped first.
+	PriorityLow PriorityClass = "low"
+	// PriorityNormal services are stopped once no low service is left.
+	PriorityNormal PriorityClass = "normal"
+	// PriorityHigh services are never stopped for pressure.
+	PriorityHigh PriorityClass = "high"
+)
+
+// Shedding validation errors.
+var (
+	// ErrInvalidPriority indicates an unknown priority class.
+	ErrInvalidPriority error = errors.New("invalid priority class")
+	// ErrInvalidShedThreshold indicates a shedding threshold outside 0-100.
+	ErrInvalidShedThreshold error = errors.New("shedding threshold must be between 0 and 100")
+	// ErrInvalidShedResume indicates a resume threshold not below the shedding threshold.
+	ErrInvalidShedResume error = errors.New("shedding resume must be below threshold")
+	// ErrInvalidShedSelector indicates an unknown PSI scope or window.
+	ErrInvalidShedSelector error = errors.New("invalid shedding pressure selector")
+	// ErrInvalidShedInterval indicates a negative shedding check interval.
+	ErrInvalidShedInterval error = errors.New("shedding interval must not be negative")
+)
+
+// Rank orders priority classes, the lowest being shed first.
+//
+// Returns:
+//   - int: 0 for low, 1 for normal or unset, 2 for high.
+func (p PriorityClass) Rank() int {
+	// map class to its rank
+	switch p {
+	// shed first
+	case PriorityLow:
+		// lowest rank
+		return 0
+	// never shed
+	case PriorityHigh:
+		// highest rank
+		return 2
+	// unset behaves as normal
+	default:
+		// middle rank
+		return 1
+	}
+}
+
+// Sheddable reports whether services of the class may be stopped for pressure.
+//
+// Returns:
+//   - bool: false for PriorityHigh.
+func (p PriorityClass) Sheddable() bool {
+	// only high services are kept
+	return p != PriorityHigh
+}
+
+// SheddingConfig configures the stop of low-priority services when host
+// memory pressure crosses a threshold, and their start once it subsides.
+type SheddingConfig struct {
+	// Threshold is the host memory stall percentage above which services are
+	// stopped. Zero disables shedding.
+	Threshold float64
+	// Resume is the stall percentage below which shed services start again.
+	// Zero uses half the threshold.
+	Resume float64
+	// Scope is the PSI scope: "some" or "full". Empty uses DefaultShedScope.
+	Scope string
+	// Window is the PSI averaging window: "avg10", "avg60" or "avg300".
+	// Empty uses DefaultShedWindow.
+	Window string
+	// Interval is how often pressure is checked; one priority class is shed
+	// or resumed per check. Zero uses DefaultShedInterval.
+	Interval shared.Duration
+}
+
+// Enabled reports whether services are shed under pressure.
+//
+// Returns:
+//   - bool: true when a threshold is configured.
+func (s *SheddingConfig) Enabled() bool {
+	// a threshold is required to shed
+	return s.Threshold > 0
+}
+
+// EffectiveResume returns the stall percentage below which shed services start again.
+//
+// Returns:
+//   - float64: the configured resume threshold, or half the threshold.
+func (s *SheddingConfig) EffectiveResume() float64 {
+	// fall back to half the threshold
+	if s.Resume <= 0 {
+		// return default
+		return s.Threshold / 2
+	}
+	// return configured threshold
+	return s.Resume
+}
+
+// EffectiveScope returns the PSI scope compared with the thresholds.
+//
+// Returns:
+//   - string: the configured scope, or DefaultShedScope.
+func (s *SheddingConfig) EffectiveScope() string {
+	// fall back to the default scope
+	if s.Scope == "" {
+		// return default
+		return DefaultShedScope
+	}
+	// return configured scope
+	return s.Scope
+}
+
+// EffectiveWindow returns the PSI averaging window compared with the thresholds.
+//
+// Returns:
+//   - string: the configured window, or DefaultShedWindow.
+func (s *SheddingConfig) EffectiveWindow() string {
+	// fall back to the default window
+	if s.Window == "" {
+		// return default
+		return DefaultShedWindow
+	}
+	// return configured window
+	return s.Window
+}
+
+// EffectiveInterval returns how often pressure is checked.
+//
+// Returns:
+//   - time.Duration: the configured interval, or DefaultShedInterval.
+func (s *SheddingConfig) EffectiveInterval() time.Duration {
+	// fall back to the default interval
+	if s.Interval <= 0 {
+		// return default
+		return DefaultShedInterval
+	}
+	// return configured interval
+	return s.Interval.Duration()
+}
+
+// validateShedding validates the shedding settings.
+//
+// Params:
+//   - s: shedding configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateShedding(s *SheddingConfig) error {
+	// check threshold is a percentage
+	if s.Threshold < 0 || s.Threshold > 100 {
+		// return error with the threshold
+		return fmt.Errorf("%w: %g", ErrInvalidShedThreshold, s.Threshold)
+	}
+	// check resume leaves a gap below the threshold
+	if s.Resume < 0 || (s.Enabled() && s.EffectiveResume() >= s.Threshold) {
+		// return error with the resume threshold
+		return fmt.Errorf("%w: %g", ErrInvalidShedResume, s.Resume)
+	}
+	rule := PressureAlertRule{Resource: "memory", Scope: s.EffectiveScope(), Window: s.EffectiveWindow()}
+	// check scope and window name a PSI value
+	if !rule.hasValidSelector() {
+		// return error with the selector
+		return fmt.Errorf("%w: %s %s", ErrInvalidShedSelector, rule.Scope, rule.Window)
+	}
+	// check interval is not negative
+	if s.Interval < 0 {
+		// return error with the interval
+		return fmt.Errorf("%w: %s", ErrInvalidShedInterval, s.Interval)
+	}
+	// validation passed
+	return nil
+}
+
+// validatePriority validates the priority class of a service.
+//
+// Params:
+//   - p: priority class to validate
+//
+// Returns:
+//   - error: validation error if any
+func validatePriority(p PriorityClass) error {
+	// check class is known
+	switch p {
+	// empty defaults to normal
+	case "", PriorityLow, PriorityNormal, PriorityHigh:
+		// known class
+		return nil
+	// unknown class
+	default:
+		// return error with the class
+		return fmt.Errorf("%w: %q", ErrInvalidPriority, p)
+	}
+}
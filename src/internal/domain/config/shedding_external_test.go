@@ -0,0 +1,99 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestSheddingConfig tests the shedding defaults.
+//
+// Params:
+//   - t: the testing context.
+func TestSheddingConfig(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// shedding is the configuration.
+		shedding config.SheddingConfig
+		// wantEnabled is the expected Enabled result.
+		wantEnabled bool
+		// wantResume is the expected resume threshold.
+		wantResume float64
+		// wantScope is the expected PSI scope.
+		wantScope string
+		// wantWindow is the expected PSI window.
+		wantWindow string
+		// wantInterval is the expected check interval.
+		wantInterval time.Duration
+	}{
+		{
+			name:         "disabled",
+			wantScope:    config.DefaultShedScope,
+			wantWindow:   config.DefaultShedWindow,
+			wantInterval: config.DefaultShedInterval,
+		},
+		{
+			name:         "defaults",
+			shedding:     config.SheddingConfig{Threshold: 40},
+			wantEnabled:  true,
+			wantResume:   20,
+			wantScope:    config.DefaultShedScope,
+			wantWindow:   config.DefaultShedWindow,
+			wantInterval: config.DefaultShedInterval,
+		},
+		{
+			name:         "configured",
+			shedding:     config.SheddingConfig{Threshold: 40, Resume: 5, Scope: "full", Window: "avg60", Interval: shared.Seconds(30)},
+			wantEnabled:  true,
+			wantResume:   5,
+			wantScope:    "full",
+			wantWindow:   "avg60",
+			wantInterval: 30 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEnabled, tt.shedding.Enabled())
+			assert.InDelta(t, tt.wantResume, tt.shedding.EffectiveResume(), 1e-9)
+			assert.Equal(t, tt.wantScope, tt.shedding.EffectiveScope())
+			assert.Equal(t, tt.wantWindow, tt.shedding.EffectiveWindow())
+			assert.Equal(t, tt.wantInterval, tt.shedding.EffectiveInterval())
+		})
+	}
+}
+
+// TestPriorityClass tests the shedding order of priority classes.
+//
+// Params:
+//   - t: the testing context.
+func TestPriorityClass(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// priority is the class.
+		priority config.PriorityClass
+		// wantRank is the expected rank.
+		wantRank int
+		// wantSheddable is the expected Sheddable result.
+		wantSheddable bool
+	}{
+		{name: "low", priority: config.PriorityLow, wantRank: 0, wantSheddable: true},
+		{name: "unset", wantRank: 1, wantSheddable: true},
+		{name: "normal", priority: config.PriorityNormal, wantRank: 1, wantSheddable: true},
+		{name: "high", priority: config.PriorityHigh, wantRank: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRank, tt.priority.Rank())
+			assert.Equal(t, tt.wantSheddable, tt.priority.Sheddable())
+		})
+	}
+}
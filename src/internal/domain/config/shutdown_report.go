@@ -0,0 +1,84 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultShutdownReportTimeout bounds the delivery of the shutdown report when none is configured.
+const DefaultShutdownReportTimeout time.Duration = 10 * time.Second
+
+// Shutdown report validation errors.
+var (
+	// ErrInvalidShutdownReportURL indicates a shutdown report url other than http(s).
+	ErrInvalidShutdownReportURL error = errors.New("shutdown report url must be an http or https url")
+	// ErrInvalidShutdownReportTimeout indicates a negative shutdown report timeout.
+	ErrInvalidShutdownReportTimeout error = errors.New("shutdown report timeout must not be negative")
+)
+
+// ShutdownReportConfig configures the report of the final statistics and
+// health of the services, written when the daemon stops gracefully, so the
+// figures kept in memory outlive the host.
+type ShutdownReportConfig struct {
+	// Path is the file the report is written to, as JSON.
+	Path string
+	// URL is the http(s) endpoint the report is posted to.
+	URL string
+	// Timeout bounds the delivery of the report. Zero uses
+	// DefaultShutdownReportTimeout.
+	Timeout shared.Duration
+}
+
+// Enabled reports whether the report is written or posted anywhere.
+//
+// Returns:
+//   - bool: true when a path or a url is set.
+func (r *ShutdownReportConfig) Enabled() bool {
+	// report when it has a destination
+	return r.Path != "" || r.URL != ""
+}
+
+// EffectiveTimeout returns the bound of the delivery of the report.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultShutdownReportTimeout.
+func (r *ShutdownReportConfig) EffectiveTimeout() time.Duration {
+	// fall back to the default timeout
+	if r.Timeout <= 0 {
+		// return default
+		return DefaultShutdownReportTimeout
+	}
+	// return configured timeout
+	return r.Timeout.Duration()
+}
+
+// validateShutdownReport validates the shutdown report settings.
+//
+// Params:
+//   - r: shutdown report configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateShutdownReport(r *ShutdownReportConfig) error {
+	// only http(s) endpoints are supported
+	if r.URL != "" {
+		u, err := url.Parse(r.URL)
+		// reject other schemes and missing hosts
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			// return url error
+			return ErrInvalidShutdownReportURL
+		}
+	}
+	// check timeout is not negative
+	if r.Timeout < 0 {
+		// return error with the timeout
+		return fmt.Errorf("%w: %s", ErrInvalidShutdownReportTimeout, r.Timeout)
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,42 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestShutdownReportConfig tests when the shutdown report is enabled and its timeout default.
+//
+// Params:
+//   - t: the testing context.
+func TestShutdownReportConfig(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// report is the configuration.
+		report config.ShutdownReportConfig
+		// wantEnabled is whether the report is delivered.
+		wantEnabled bool
+		// wantTimeout is the expected delivery bound.
+		wantTimeout time.Duration
+	}{
+		{name: "disabled", wantTimeout: config.DefaultShutdownReportTimeout},
+		{name: "file", report: config.ShutdownReportConfig{Path: "/var/lib/supervizio/shutdown.json"},
+			wantEnabled: true, wantTimeout: config.DefaultShutdownReportTimeout},
+		{name: "webhook", report: config.ShutdownReportConfig{URL: "https://reports.example.com", Timeout: shared.Seconds(3)},
+			wantEnabled: true, wantTimeout: 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEnabled, tt.report.Enabled())
+			assert.Equal(t, tt.wantTimeout, tt.report.EffectiveTimeout())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownSignal indicates a signal name the daemon cannot send.
+var ErrUnknownSignal error = errors.New("unknown signal")
+
+// DefaultAllowedSignals are the signals operators may send to a service
+// without allowed_signals: the conventional application reload signals.
+var DefaultAllowedSignals []string = []string{"SIGHUP", "SIGUSR1", "SIGUSR2"}
+
+// signalNames lists the signals a service may be sent, by POSIX name.
+var signalNames []string = []string{
+	"SIGHUP", "SIGINT", "SIGQUIT", "SIGKILL", "SIGUSR1", "SIGUSR2",
+	"SIGTERM", "SIGCONT", "SIGSTOP", "SIGWINCH",
+}
+
+// NormalizeSignal returns the POSIX name of a signal given with or
+// without the SIG prefix, in any case (hup, SIGHUP, usr1).
+//
+// Params:
+//   - name: the signal name.
+//
+// Returns:
+//   - string: the POSIX name (SIGHUP).
+//   - error: ErrUnknownSignal if the name is not a known signal.
+func NormalizeSignal(name string) (string, error) {
+	upper := strings.ToUpper(strings.TrimSpace(name))
+	// accept names without prefix
+	if !strings.HasPrefix(upper, "SIG") {
+		upper = "SIG" + upper
+	}
+	// signal must be known
+	if !slices.Contains(signalNames, upper) {
+		// return error with the name
+		return "", fmt.Errorf("%w: %q", ErrUnknownSignal, name)
+	}
+	// return POSIX name
+	return upper, nil
+}
+
+// SignalAllowed reports whether operators may send a signal to the service.
+//
+// Params:
+//   - posix: the POSIX signal name, as returned by NormalizeSignal.
+//
+// Returns:
+//   - bool: true if the signal is in the allowlist.
+func (s *ServiceConfig) SignalAllowed(posix string) bool {
+	// fall back to the reload signals
+	if len(s.AllowedSignals) == 0 {
+		// check default allowlist
+		return slices.Contains(DefaultAllowedSignals, posix)
+	}
+	// check configured allowlist
+	for _, name := range s.AllowedSignals {
+		// names are validated, only the spelling differs
+		if normalized, _ := NormalizeSignal(name); normalized == posix {
+			// signal allowed
+			return true
+		}
+	}
+	// signal not listed
+	return false
+}
+
+// validateAllowedSignals validates the signal allowlist of a service.
+//
+// Params:
+//   - names: the allowed signal names.
+//
+// Returns:
+//   - error: ErrUnknownSignal if a name is not a known signal.
+func validateAllowedSignals(names []string) error {
+	// validate each name
+	for _, name := range names {
+		// propagate unknown signal
+		if _, err := NormalizeSignal(name); err != nil {
+			// return signal error
+			return fmt.Errorf("allowed_signals: %w", err)
+		}
+	}
+	// validation passed
+	return nil
+}
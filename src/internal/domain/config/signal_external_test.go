@@ -0,0 +1,81 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestNormalizeSignal tests signal name normalization.
+//
+// Params:
+//   - t: the testing context.
+func TestNormalizeSignal(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// input is the signal name.
+		input string
+		// expected is the POSIX name.
+		expected string
+		// wantErr indicates if an error is expected.
+		wantErr bool
+	}{
+		{name: "posix_name", input: "SIGHUP", expected: "SIGHUP"},
+		{name: "without_prefix", input: "USR1", expected: "SIGUSR1"},
+		{name: "lower_case", input: "sigterm", expected: "SIGTERM"},
+		{name: "padded", input: " winch ", expected: "SIGWINCH"},
+		{name: "unknown", input: "SIGFOO", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.NormalizeSignal(tt.input)
+			// Check error expectation.
+			if tt.wantErr {
+				assert.ErrorIs(t, err, config.ErrUnknownSignal)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+// TestServiceConfig_SignalAllowed tests the signal allowlist of a service.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_SignalAllowed(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// allowed is the configured allowlist.
+		allowed []string
+		// signal is the POSIX signal name.
+		signal string
+		// expected is the expected result.
+		expected bool
+	}{
+		{name: "default_reload_signal", signal: "SIGUSR1", expected: true},
+		{name: "default_denies_kill", signal: "SIGKILL", expected: false},
+		{name: "configured_signal", allowed: []string{"hup", "SIGWINCH"}, signal: "SIGWINCH", expected: true},
+		{name: "configured_spelling", allowed: []string{"hup"}, signal: "SIGHUP", expected: true},
+		{name: "configured_replaces_default", allowed: []string{"SIGWINCH"}, signal: "SIGHUP", expected: false},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			svc := config.ServiceConfig{AllowedSignals: tt.allowed}
+			assert.Equal(t, tt.expected, svc.SignalAllowed(tt.signal))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultSLOWindow is the compliance window of an SLO when none is configured.
+const DefaultSLOWindow time.Duration = 30 * 24 * time.Hour
+
+// DefaultBurnAlerts are the burn rate alerts of an SLO declaring none: a
+// fast burn consuming 2% of a 30-day budget in an hour, and a slow burn
+// consuming 5% in six hours.
+var DefaultBurnAlerts []BurnAlertConfig = []BurnAlertConfig{
+	{Rate: 14.4, Window: shared.Duration(time.Hour)},
+	{Rate: 6, Window: shared.Duration(6 * time.Hour)},
+}
+
+// SLO validation errors.
+var (
+	// ErrInvalidSLOTarget indicates a target outside ]0, 100[.
+	ErrInvalidSLOTarget error = errors.New("slo target must be between 0 and 100 percent, exclusive")
+	// ErrInvalidSLOWindow indicates a negative window or one longer than 30 days.
+	ErrInvalidSLOWindow error = errors.New("slo window must be positive and at most 30 days")
+	// ErrInvalidBurnRate indicates a burn alert rate that is not positive.
+	ErrInvalidBurnRate error = errors.New("burn alert rate must be positive")
+	// ErrInvalidBurnWindow indicates a burn alert window outside the SLO window.
+	ErrInvalidBurnWindow error = errors.New("burn alert window must be positive and within the slo window")
+)
+
+// SLOConfig declares an availability objective for a service. The service
+// is counted available while it runs and, with health probes, while they pass.
+type SLOConfig struct {
+	// Target is the objective, in percent of the window (99.9).
+	Target float64
+	// Window is the compliance window. Zero uses DefaultSLOWindow.
+	Window shared.Duration
+	// BurnAlerts fire when the error budget burns faster than their rate.
+	// Empty uses DefaultBurnAlerts.
+	BurnAlerts []BurnAlertConfig
+}
+
+// BurnAlertConfig fires an alert when the error budget burns at least Rate
+// times faster than sustainable over Window.
+type BurnAlertConfig struct {
+	// Rate is the burn rate threshold; 1 consumes the budget in exactly the SLO window.
+	Rate float64
+	// Window is the lookback over which the burn rate is measured.
+	Window shared.Duration
+}
+
+// EffectiveWindow returns the compliance window.
+//
+// Returns:
+//   - time.Duration: the configured window, or DefaultSLOWindow.
+func (c *SLOConfig) EffectiveWindow() time.Duration {
+	// fall back to the default window
+	if c.Window <= 0 {
+		// return default
+		return DefaultSLOWindow
+	}
+	// return configured window
+	return c.Window.Duration()
+}
+
+// EffectiveBurnAlerts returns the burn rate alerts.
+//
+// Returns:
+//   - []BurnAlertConfig: the configured alerts, or DefaultBurnAlerts.
+func (c *SLOConfig) EffectiveBurnAlerts() []BurnAlertConfig {
+	// fall back to the default alerts
+	if len(c.BurnAlerts) == 0 {
+		// return defaults
+		return DefaultBurnAlerts
+	}
+	// return configured alerts
+	return c.BurnAlerts
+}
+
+// validateSLO validates the SLO of a service, if any.
+//
+// Params:
+//   - slo: the SLO to validate, nil when none is declared.
+//
+// Returns:
+//   - error: validation error if any.
+func validateSLO(slo *SLOConfig) error {
+	// no objective declared
+	if slo == nil {
+		// nothing to validate
+		return nil
+	}
+	// target must leave an error budget
+	if slo.Target <= 0 || slo.Target >= 100 {
+		// return target error
+		return fmt.Errorf("slo: %w", ErrInvalidSLOTarget)
+	}
+	window := slo.EffectiveWindow()
+	// window must fit the retained history
+	if slo.Window < 0 || window > DefaultSLOWindow {
+		// return window error
+		return fmt.Errorf("slo: %w", ErrInvalidSLOWindow)
+	}
+	// validate each burn alert
+	for i, alert := range slo.BurnAlerts {
+		// rate must be positive
+		if alert.Rate <= 0 {
+			// return rate error
+			return fmt.Errorf("slo: burn_alerts[%d]: %w", i, ErrInvalidBurnRate)
+		}
+		// window must be measurable within the SLO window
+		if alert.Window <= 0 || alert.Window.Duration() > window {
+			// return window error
+			return fmt.Errorf("slo: burn_alerts[%d]: %w", i, ErrInvalidBurnWindow)
+		}
+	}
+	// validation passed
+	return nil
+}
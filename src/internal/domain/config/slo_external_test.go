@@ -0,0 +1,55 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestSLOConfig_defaults tests the effective SLO window and burn alerts.
+//
+// Params:
+//   - t: the testing context.
+func TestSLOConfig_defaults(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// slo is the SLO configuration.
+		slo config.SLOConfig
+		// window is the expected window.
+		window time.Duration
+		// alerts are the expected burn alerts.
+		alerts []config.BurnAlertConfig
+	}{
+		{
+			name:   "defaults",
+			slo:    config.SLOConfig{Target: 99.9},
+			window: config.DefaultSLOWindow,
+			alerts: config.DefaultBurnAlerts,
+		},
+		{
+			name: "configured",
+			slo: config.SLOConfig{
+				Target:     99,
+				Window:     shared.Duration(7 * 24 * time.Hour),
+				BurnAlerts: []config.BurnAlertConfig{{Rate: 2, Window: shared.Duration(time.Hour)}},
+			},
+			window: 7 * 24 * time.Hour,
+			alerts: []config.BurnAlertConfig{{Rate: 2, Window: shared.Duration(time.Hour)}},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.window, tt.slo.EffectiveWindow())
+			assert.Equal(t, tt.alerts, tt.slo.EffectiveBurnAlerts())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// ListenerProtocolUnix is the protocol of listeners on a unix socket. The
+// listener address is the socket path.
+const ListenerProtocolUnix string = "unix"
+
+// DefaultSocketPoll is how often a socket path is checked, on top of the
+// creation notifications of its directory, which a directory created after
+// the start does not get.
+const DefaultSocketPoll time.Duration = time.Second
+
+// Unix listener validation errors.
+var (
+	// ErrInvalidSocketPath indicates a unix listener without an absolute socket path.
+	ErrInvalidSocketPath error = errors.New("unix listener address must be an absolute socket path")
+	// ErrSocketPort indicates a unix listener with a port.
+	ErrSocketPort error = errors.New("unix listener must not set a port")
+	// ErrSocketProbe indicates a probe that cannot reach a unix socket.
+	ErrSocketProbe error = errors.New("unix listener supports tcp and exec probes only")
+)
+
+// SocketPath returns the path of the unix socket of the listener.
+//
+// Returns:
+//   - string: the socket path, empty for network listeners.
+func (l *ListenerConfig) SocketPath() string {
+	// only unix listeners have a socket path
+	if l.EffectiveProtocol() != ListenerProtocolUnix {
+		// network listener
+		return ""
+	}
+	// return the address as configured
+	return l.Address
+}
+
+// SocketListeners returns the listeners of the service on a unix socket.
+//
+// Returns:
+//   - []*ListenerConfig: the unix listeners, in declaration order.
+func (s *ServiceConfig) SocketListeners() []*ListenerConfig {
+	var sockets []*ListenerConfig
+	// collect each unix listener
+	for i := range s.Listeners {
+		// Skip network listeners.
+		if s.Listeners[i].SocketPath() == "" {
+			continue
+		}
+		sockets = append(sockets, &s.Listeners[i])
+	}
+	// return unix listeners
+	return sockets
+}
+
+// validateSocketListener validates the path, port and probe of a unix
+// listener. Proxies and certificate checks reject it as a non-tcp listener.
+//
+// Params:
+//   - lc: listener configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateSocketListener(lc *ListenerConfig) error {
+	// the socket is found by its path
+	if !filepath.IsAbs(lc.Address) {
+		// return error with the path
+		return fmt.Errorf("%w: %q", ErrInvalidSocketPath, lc.Address)
+	}
+	// sockets have no port
+	if lc.Port != 0 {
+		// return error with the port
+		return fmt.Errorf("%w: %d", ErrSocketPort, lc.Port)
+	}
+	// only connections and commands reach a socket
+	if lc.HasProbe() && lc.Probe.Type != ProbeTypeTCP && lc.Probe.Type != ProbeTypeExec {
+		// return error with the probe type
+		return fmt.Errorf("%w: %s", ErrSocketProbe, lc.Probe.Type)
+	}
+	// validation passed
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestServiceConfig_SocketListeners tests the unix listeners of a service
+// and their socket paths.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_SocketListeners(t *testing.T) {
+	svc := config.ServiceConfig{Listeners: []config.ListenerConfig{
+		{Name: "http", Port: 8080},
+		{Name: "sock", Protocol: "unix", Address: "/run/api/api.sock"},
+		{Name: "admin", Protocol: "UNIX", Address: "/run/api/admin.sock"},
+	}}
+
+	sockets := svc.SocketListeners()
+
+	// Only unix listeners are returned, in declaration order.
+	assert.Len(t, sockets, 2)
+	assert.Equal(t, "sock", sockets[0].Name)
+	assert.Equal(t, "/run/api/api.sock", sockets[0].SocketPath())
+	assert.Equal(t, "/run/api/admin.sock", sockets[1].SocketPath())
+	assert.Empty(t, svc.Listeners[0].SocketPath())
+}
@@ -0,0 +1,34 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrInvalidStartPhase indicates a negative service start_phase.
+var ErrInvalidStartPhase error = errors.New("start_phase must not be negative")
+
+// StartPhases groups the services by start phase, in ascending phase
+// order. Services of a phase keep their configuration order.
+//
+// Returns:
+//   - [][]string: the service names of each phase.
+func (c *Config) StartPhases() [][]string {
+	phases := make([]int, 0, len(c.Services))
+	// collect distinct phases
+	for i := range c.Services {
+		phases = append(phases, c.Services[i].StartPhase)
+	}
+	slices.Sort(phases)
+	phases = slices.Compact(phases)
+
+	groups := make([][]string, len(phases))
+	// place each service in its phase
+	for i := range c.Services {
+		idx, _ := slices.BinarySearch(phases, c.Services[i].StartPhase)
+		groups[idx] = append(groups[idx], c.Services[i].Name)
+	}
+	// return phases in start order
+	return groups
+}
@@ -0,0 +1,52 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// TestConfig_StartPhases tests the grouping of services by start phase.
+//
+// Params:
+//   - t: the testing context.
+func TestConfig_StartPhases(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// services are the configured services.
+		services []config.ServiceConfig
+		// want is the expected grouping.
+		want [][]string
+	}{
+		{
+			name:     "no_phases",
+			services: []config.ServiceConfig{{Name: "api"}, {Name: "worker"}},
+			want:     [][]string{{"api", "worker"}},
+		},
+		{
+			name: "ordered_phases",
+			services: []config.ServiceConfig{
+				{Name: "proxy", StartPhase: 3},
+				{Name: "api", StartPhase: 2},
+				{Name: "db", StartPhase: 1},
+				{Name: "worker", StartPhase: 2},
+			},
+			want: [][]string{{"db"}, {"api", "worker"}, {"proxy"}},
+		},
+		{
+			name: "no_services",
+			want: [][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{Services: tt.services}
+			assert.Equal(t, tt.want, cfg.StartPhases())
+		})
+	}
+}
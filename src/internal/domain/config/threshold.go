@@ -0,0 +1,79 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ProbeTypeThreshold reads a number from a command or an HTTP response and
+// compares it with warn and fail thresholds.
+const ProbeTypeThreshold string = "threshold"
+
+// Threshold validation errors.
+var (
+	// ErrMissingThreshold indicates a threshold probe without a fail threshold.
+	ErrMissingThreshold error = errors.New("threshold probe requires a fail threshold")
+	// ErrInvalidThresholdPattern indicates a value pattern that does not compile
+	// or has no capture group.
+	ErrInvalidThresholdPattern error = errors.New("invalid threshold pattern")
+	// ErrInvalidThresholdOrder indicates a warn threshold past the fail threshold.
+	ErrInvalidThresholdOrder error = errors.New("warn threshold must be reached before the fail threshold")
+)
+
+// ThresholdConfig is the value check of a threshold probe.
+// The value is read from the output of Command when set, from the body of a
+// GET on Path otherwise.
+type ThresholdConfig struct {
+	// Pattern is a regular expression whose first capture group is the
+	// value; empty reads the whole trimmed output.
+	Pattern string
+	// Warn is the value past which the probe reports degraded; nil for none.
+	Warn *float64
+	// Fail is the value past which the probe fails.
+	Fail *float64
+	// Below makes the probe fail under the thresholds rather than above,
+	// for values such as throughput where less is worse.
+	Below bool
+}
+
+// validateThreshold checks the value check of a threshold probe.
+//
+// Params:
+//   - threshold: the value check, nil when not configured.
+//
+// Returns:
+//   - error: validation error if any.
+func validateThreshold(threshold *ThresholdConfig) error {
+	// a threshold probe needs a fail threshold
+	if threshold == nil || threshold.Fail == nil {
+		// return error when no fail threshold is defined
+		return ErrMissingThreshold
+	}
+	// the pattern must capture the value
+	if threshold.Pattern != "" {
+		re, err := regexp.Compile(threshold.Pattern)
+		// reject patterns that do not compile
+		if err != nil {
+			// return error with compile detail
+			return fmt.Errorf("%w: %w", ErrInvalidThresholdPattern, err)
+		}
+		// reject patterns without a capture group
+		if re.NumSubexp() == 0 {
+			// return error when nothing is captured
+			return fmt.Errorf("%w: no capture group", ErrInvalidThresholdPattern)
+		}
+	}
+	// warn must come first in the direction of the check
+	if threshold.Warn != nil {
+		warn, fail := *threshold.Warn, *threshold.Fail
+		// below checks warn above the fail threshold, above checks under it
+		if (threshold.Below && warn < fail) || (!threshold.Below && warn > fail) {
+			// return error with both thresholds
+			return fmt.Errorf("%w: warn %g, fail %g", ErrInvalidThresholdOrder, warn, fail)
+		}
+	}
+	// validation passed
+	return nil
+}
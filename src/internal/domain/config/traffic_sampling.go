@@ -0,0 +1,78 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultTrafficMaxEndpoints bounds the endpoints outside the daemon kept
+// per service when none is configured.
+const DefaultTrafficMaxEndpoints int = 64
+
+// ErrInvalidTrafficSampling indicates a negative sampling interval or endpoint limit.
+var ErrInvalidTrafficSampling error = errors.New("traffic sampling interval and max_endpoints must not be negative")
+
+// TrafficSamplingConfig configures the sampling of the TCP connections of
+// the services, inferring which services talk to each other and to which
+// endpoints outside the daemon.
+type TrafficSamplingConfig struct {
+	// Interval is how often connections are sampled. Zero disables sampling.
+	Interval shared.Duration
+	// MaxEndpoints bounds the endpoints outside the daemon kept per
+	// service. Zero uses DefaultTrafficMaxEndpoints.
+	MaxEndpoints int
+}
+
+// Enabled reports whether connections are sampled.
+//
+// Returns:
+//   - bool: true when an interval is set.
+func (t *TrafficSamplingConfig) Enabled() bool {
+	// sampled only with an interval
+	return t.Interval > 0
+}
+
+// EffectiveInterval returns how often connections are sampled.
+//
+// Returns:
+//   - time.Duration: the configured interval, zero when disabled.
+func (t *TrafficSamplingConfig) EffectiveInterval() time.Duration {
+	// return configured interval
+	return t.Interval.Duration()
+}
+
+// EffectiveMaxEndpoints returns how many endpoints outside the daemon are
+// kept per service.
+//
+// Returns:
+//   - int: the configured limit, or DefaultTrafficMaxEndpoints.
+func (t *TrafficSamplingConfig) EffectiveMaxEndpoints() int {
+	// fall back to the default limit
+	if t.MaxEndpoints <= 0 {
+		// return default
+		return DefaultTrafficMaxEndpoints
+	}
+	// return configured limit
+	return t.MaxEndpoints
+}
+
+// validateTrafficSampling validates the traffic sampling settings.
+//
+// Params:
+//   - t: traffic sampling configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateTrafficSampling(t *TrafficSamplingConfig) error {
+	// check interval and limit are not negative
+	if t.Interval < 0 || t.MaxEndpoints < 0 {
+		// return error with the values
+		return fmt.Errorf("%w: interval %s, max_endpoints %d", ErrInvalidTrafficSampling, t.Interval, t.MaxEndpoints)
+	}
+	// validation passed
+	return nil
+}
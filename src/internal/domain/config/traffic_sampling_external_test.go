@@ -0,0 +1,44 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestTrafficSamplingConfig tests the traffic sampling defaults.
+//
+// Params:
+//   - t: the testing context.
+func TestTrafficSamplingConfig(t *testing.T) {
+	disabled := config.TrafficSamplingConfig{}
+	assert.False(t, disabled.Enabled())
+	assert.Equal(t, config.DefaultTrafficMaxEndpoints, disabled.EffectiveMaxEndpoints())
+
+	sampling := config.TrafficSamplingConfig{Interval: shared.Seconds(30), MaxEndpoints: 8}
+	assert.True(t, sampling.Enabled())
+	assert.Equal(t, 30*time.Second, sampling.EffectiveInterval())
+	assert.Equal(t, 8, sampling.EffectiveMaxEndpoints())
+}
+
+// TestValidate_TrafficSampling tests that negative sampling settings are refused.
+//
+// Params:
+//   - t: the testing context.
+func TestValidate_TrafficSampling(t *testing.T) {
+	cfg := &config.Config{
+		Services:        []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+		TrafficSampling: config.TrafficSamplingConfig{Interval: shared.Seconds(30), MaxEndpoints: -1},
+	}
+	err := config.Validate(cfg)
+	require.ErrorIs(t, err, config.ErrInvalidTrafficSampling)
+	var fieldErr *config.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "traffic_sampling", fieldErr.Key)
+}
@@ -0,0 +1,189 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultUnhealthyTimeout bounds an exec unhealthy action when none is configured.
+const DefaultUnhealthyTimeout time.Duration = 30 * time.Second
+
+// UnhealthyAction is what the supervisor does when a listener probe reports
+// the service unhealthy.
+type UnhealthyAction string
+
+const (
+	// UnhealthyActionRestart restarts the service, subject to gate_restart dependencies.
+	UnhealthyActionRestart UnhealthyAction = "restart"
+	// UnhealthyActionStop stops the service until started again.
+	UnhealthyActionStop UnhealthyAction = "stop"
+	// UnhealthyActionExec runs a hook command.
+	UnhealthyActionExec UnhealthyAction = "exec"
+	// UnhealthyActionSignal sends a signal to the service.
+	UnhealthyActionSignal UnhealthyAction = "signal"
+	// UnhealthyActionNotify only emits the unhealthy event.
+	UnhealthyActionNotify UnhealthyAction = "notify"
+)
+
+// Unhealthy action validation errors.
+var (
+	// ErrInvalidUnhealthyAction indicates an unknown unhealthy action.
+	ErrInvalidUnhealthyAction error = errors.New("unhealthy action must be restart, stop, exec, signal or notify")
+	// ErrUnhealthyWithoutCommand indicates an exec action without command.
+	ErrUnhealthyWithoutCommand error = errors.New("exec unhealthy action requires a command")
+	// ErrUnhealthyWithoutSignal indicates a signal action without signal.
+	ErrUnhealthyWithoutSignal error = errors.New("signal unhealthy action requires a signal")
+	// ErrInvalidUnhealthyAfter indicates a negative after, or an after on the first step.
+	ErrInvalidUnhealthyAfter error = errors.New("unhealthy after must be positive, and unset on the first step")
+	// ErrInvalidUnhealthyTimeout indicates a negative exec timeout.
+	ErrInvalidUnhealthyTimeout error = errors.New("unhealthy timeout must not be negative")
+)
+
+// UnhealthyStep is one step of the escalation chain run when a listener
+// probe reports the service unhealthy. A report is sent each time the probe
+// reaches its failure threshold, then its failure count starts over.
+type UnhealthyStep struct {
+	// Action is what the step does.
+	Action UnhealthyAction
+	// After is the number of further reports, since the previous step took
+	// over, before this step replaces it. Unset on the first step.
+	After int
+	// Signal is the signal of signal actions.
+	Signal string
+	// Command is the hook run by exec actions.
+	Command string
+	// Args are the arguments of the hook.
+	Args []string
+	// Timeout bounds the hook. Zero uses DefaultUnhealthyTimeout.
+	Timeout shared.Duration
+}
+
+// SignalName returns the POSIX name of the signal of signal actions.
+//
+// Returns:
+//   - string: the validated signal name.
+func (u *UnhealthyStep) SignalName() string {
+	name, _ := NormalizeSignal(u.Signal)
+	// return validated name
+	return name
+}
+
+// HookTimeout returns the effective hook timeout.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultUnhealthyTimeout.
+func (u *UnhealthyStep) HookTimeout() time.Duration {
+	// fall back to the default timeout
+	if u.Timeout <= 0 {
+		// return default
+		return DefaultUnhealthyTimeout
+	}
+	// return configured timeout
+	return u.Timeout.Duration()
+}
+
+// UnhealthyStepFor returns the step handling the given report of a failing
+// probe, counted from 1 since the listener was last healthy. Each step
+// handles every report until the next one takes over; without steps, every
+// report restarts the service.
+//
+// Params:
+//   - report: the report number, from 1.
+//
+// Returns:
+//   - UnhealthyStep: the step to run.
+func (p *ProbeConfig) UnhealthyStepFor(report int) UnhealthyStep {
+	// restart when no chain is configured
+	if len(p.OnUnhealthy) == 0 {
+		// return default action
+		return UnhealthyStep{Action: UnhealthyActionRestart}
+	}
+	step := p.OnUnhealthy[0]
+	reached := 1
+	// follow the chain while the reports reach the next step
+	for i := 1; i < len(p.OnUnhealthy); i++ {
+		reached += p.OnUnhealthy[i].After
+		// the next step is not reached yet
+		if report < reached {
+			break
+		}
+		step = p.OnUnhealthy[i]
+	}
+	// return the reached step
+	return step
+}
+
+// validateUnhealthySteps validates the escalation chain of a probe.
+//
+// Params:
+//   - steps: the steps to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateUnhealthySteps(steps []UnhealthyStep) error {
+	// validate each step
+	for i := range steps {
+		// propagate with the step index
+		if err := validateUnhealthyStep(&steps[i], i == 0); err != nil {
+			// return error with the step index
+			return fmt.Errorf("on_unhealthy %d: %w", i, err)
+		}
+	}
+	// validation passed
+	return nil
+}
+
+// validateUnhealthyStep validates one step of an escalation chain.
+//
+// Params:
+//   - u: the step to validate.
+//   - first: whether the step starts the chain.
+//
+// Returns:
+//   - error: validation error if any.
+func validateUnhealthyStep(u *UnhealthyStep, first bool) error {
+	// the first step runs on the first report, the others after some
+	if (first && u.After != 0) || (!first && u.After <= 0) {
+		// return after error
+		return fmt.Errorf("%w: %d", ErrInvalidUnhealthyAfter, u.After)
+	}
+	// timeout must not be negative
+	if u.Timeout < 0 {
+		// return timeout error
+		return ErrInvalidUnhealthyTimeout
+	}
+	// validate the action settings
+	switch u.Action {
+	// no settings
+	case UnhealthyActionRestart, UnhealthyActionStop, UnhealthyActionNotify:
+		// nothing more to check
+		return nil
+	// signal must be sendable
+	case UnhealthyActionSignal:
+		// signal required
+		if u.Signal == "" {
+			// return missing signal error
+			return ErrUnhealthyWithoutSignal
+		}
+		_, err := NormalizeSignal(u.Signal)
+		// return signal error
+		return err
+	// hook must be set
+	case UnhealthyActionExec:
+		// command required
+		if u.Command == "" {
+			// return missing command error
+			return ErrUnhealthyWithoutCommand
+		}
+		// hook valid
+		return nil
+	// unknown action
+	default:
+		// return error with the action
+		return fmt.Errorf("%w: %q", ErrInvalidUnhealthyAction, u.Action)
+	}
+}
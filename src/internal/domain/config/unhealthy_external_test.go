@@ -0,0 +1,63 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestProbeConfig_UnhealthyStepFor tests the step reached by each report.
+//
+// Params:
+//   - t: the testing context.
+func TestProbeConfig_UnhealthyStepFor(t *testing.T) {
+	chain := []config.UnhealthyStep{
+		{Action: config.UnhealthyActionNotify},
+		{Action: config.UnhealthyActionSignal, After: 2, Signal: "SIGUSR1"},
+		{Action: config.UnhealthyActionRestart, After: 1},
+	}
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// steps is the escalation chain.
+		steps []config.UnhealthyStep
+		// report is the report number.
+		report int
+		// want is the expected action.
+		want config.UnhealthyAction
+	}{
+		{name: "default_restart", report: 1, want: config.UnhealthyActionRestart},
+		{name: "first_report", steps: chain, report: 1, want: config.UnhealthyActionNotify},
+		{name: "before_next_step", steps: chain, report: 2, want: config.UnhealthyActionNotify},
+		{name: "next_step", steps: chain, report: 3, want: config.UnhealthyActionSignal},
+		{name: "last_step", steps: chain, report: 4, want: config.UnhealthyActionRestart},
+		{name: "last_step_repeats", steps: chain, report: 9, want: config.UnhealthyActionRestart},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			probe := config.ProbeConfig{OnUnhealthy: tt.steps}
+			assert.Equal(t, tt.want, probe.UnhealthyStepFor(tt.report).Action)
+		})
+	}
+}
+
+// TestUnhealthyStep_defaults tests the effective settings of a step.
+//
+// Params:
+//   - t: the testing context.
+func TestUnhealthyStep_defaults(t *testing.T) {
+	step := config.UnhealthyStep{Signal: "usr2"}
+	assert.Equal(t, "SIGUSR2", step.SignalName())
+	assert.Equal(t, config.DefaultUnhealthyTimeout, step.HookTimeout())
+
+	step.Timeout = shared.Duration(time.Minute)
+	assert.Equal(t, time.Minute, step.HookTimeout())
+}
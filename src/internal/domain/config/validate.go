@@ -4,6 +4,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
 )
 
 // Validation errors.
@@ -26,9 +32,62 @@ var (
 	ErrMissingTCPPort error = errors.New("tcp health check requires port")
 	// ErrMissingHealthCommand indicates command check missing command.
 	ErrMissingHealthCommand error = errors.New("command health check requires command")
+	// ErrInvalidThrottleThreshold indicates a CPU throttle threshold outside 0-100.
+	ErrInvalidThrottleThreshold error = errors.New("cpu throttle threshold must be between 0 and 100")
+	// ErrInvalidCPUPercentMode indicates an unknown CPU percent mode.
+	ErrInvalidCPUPercentMode error = errors.New("cpu percent mode must be per_core or normalized")
+	// ErrInvalidPressureSelector indicates a PSI alert rule with unknown resource, scope, or window.
+	ErrInvalidPressureSelector error = errors.New("invalid pressure alert selector")
+	// ErrInvalidPressureThreshold indicates a PSI alert threshold outside 0-100.
+	ErrInvalidPressureThreshold error = errors.New("pressure alert threshold must be between 0 and 100")
+	// ErrUnknownMetricsTemplate indicates a performance template name that is not a known preset.
+	ErrUnknownMetricsTemplate error = errors.New("unknown metrics performance template")
+	// ErrInvalidMetricsInterval indicates a negative metrics collection interval.
+	ErrInvalidMetricsInterval error = errors.New("metrics interval must not be negative")
+	// ErrListenerPortConflict indicates two listeners bind the same protocol, address, and port.
+	ErrListenerPortConflict error = errors.New("listener port conflict")
+	// ErrInvalidListenerProtocol indicates an unsupported listener protocol.
+	ErrInvalidListenerProtocol error = errors.New("invalid listener protocol")
+	// ErrInvalidListenerAddress indicates a malformed listener bind address.
+	ErrInvalidListenerAddress error = errors.New("invalid listener address")
+	// ErrAddressFamilyMismatch indicates a bind address outside the protocol family.
+	ErrAddressFamilyMismatch error = errors.New("listener address family mismatch")
+	// ErrProxyNotExposed indicates a proxy on a listener that is not exposed.
+	ErrProxyNotExposed error = errors.New("listener proxy requires exposed: true")
+	// ErrProxyProtocol indicates a proxy on a non-tcp listener.
+	ErrProxyProtocol error = errors.New("listener proxy supports tcp only")
+	// ErrInvalidProxyPort indicates a proxy port outside the valid range.
+	ErrInvalidProxyPort error = errors.New("invalid listener proxy port")
+	// ErrInvalidDrainTimeout indicates a negative proxy drain timeout.
+	ErrInvalidDrainTimeout error = errors.New("listener proxy drain_timeout must not be negative")
+	// ErrInvalidLogQuota indicates an unparsable log max_total_size.
+	ErrInvalidLogQuota error = errors.New("invalid logging max_total_size")
+	// ErrInvalidStartTimeout indicates a negative service start_timeout.
+	ErrInvalidStartTimeout error = errors.New("start_timeout must not be negative")
+	// ErrInvalidBootFailurePolicy indicates an unknown on_boot_failure value.
+	ErrInvalidBootFailurePolicy error = errors.New("on_boot_failure must be continue or shutdown")
+	// ErrInvalidIncidentWindow indicates a negative incidents window.
+	ErrInvalidIncidentWindow error = errors.New("incidents window must not be negative")
+	// ErrInvalidIncidentMinServices indicates an incidents min_services below 2.
+	ErrInvalidIncidentMinServices error = errors.New("incidents min_services must be at least 2")
+	// ErrInvalidSELinuxContext indicates a selinux_context not shaped user:role:type[:level].
+	ErrInvalidSELinuxContext error = errors.New("selinux_context must be user:role:type[:level]")
+	// ErrConflictingSecurityLabels indicates both selinux_context and apparmor_profile.
+	ErrConflictingSecurityLabels error = errors.New("selinux_context and apparmor_profile are mutually exclusive")
+	// ErrInvalidSandboxPath indicates a read_only/masked/tmpfs path that is not absolute.
+	ErrInvalidSandboxPath error = errors.New("sandbox path must be absolute")
+	// ErrSandboxRootPath indicates the root directory in masked_paths or tmpfs_paths.
+	ErrSandboxRootPath error = errors.New("masked_paths and tmpfs_paths cannot contain /")
 )
 
+// maxPercentThreshold is the upper bound of percentage thresholds.
+const maxPercentThreshold float64 = 100.0
+
+// minSELinuxContextParts is the number of user:role:type fields of an SELinux context.
+const minSELinuxContextParts int = 3
+
 // Validate validates the configuration.
+// Errors carry shared.CodeConfigInvalid and wrap the specific sentinel.
 //
 // Params:
 //   - cfg: configuration to validate
@@ -36,10 +95,134 @@ var (
 // Returns:
 //   - error: validation error if any
 func Validate(cfg *Config) error {
+	// tag any validation failure with the config code
+	return shared.WithCode(shared.CodeConfigInvalid, validate(cfg))
+}
+
+// ServiceError is a validation error of one service.
+type ServiceError struct {
+	// Index is the position of the service in Config.Services.
+	Index int
+	// Name is the service name.
+	Name string
+	// Err is the validation error.
+	Err error
+}
+
+// Error returns the error prefixed with the service name.
+//
+// Returns:
+//   - string: the error message.
+func (e *ServiceError) Error() string {
+	// return prefixed message
+	return fmt.Sprintf("service %q: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the validation error.
+//
+// Returns:
+//   - error: the wrapped error.
+func (e *ServiceError) Unwrap() error {
+	// return wrapped error
+	return e.Err
+}
+
+// FieldError is a validation error of one configuration key, so loaders
+// can point at the key. Its message is that of Err.
+type FieldError struct {
+	// Key is the configuration key of the failing setting, such as
+	// "command" in a service or "incidents" at the top level.
+	Key string
+	// Err is the validation error.
+	Err error
+}
+
+// Error returns the validation error message.
+//
+// Returns:
+//   - string: the error message.
+func (e *FieldError) Error() string {
+	// return wrapped message
+	return e.Err.Error()
+}
+
+// Unwrap returns the validation error.
+//
+// Returns:
+//   - error: the wrapped error.
+func (e *FieldError) Unwrap() error {
+	// return wrapped error
+	return e.Err
+}
+
+// keyed attaches a configuration key to a validation error, unless it
+// already names a more precise one.
+//
+// Params:
+//   - key: the configuration key.
+//   - err: the validation error.
+//
+// Returns:
+//   - error: the keyed error, nil when err is nil.
+func keyed(key string, err error) error {
+	var fieldErr *FieldError
+	// keep nil and already keyed errors
+	if err == nil || errors.As(err, &fieldErr) {
+		// return unchanged error
+		return err
+	}
+	// return keyed error
+	return &FieldError{Key: key, Err: err}
+}
+
+// ValidationError lists every validation failure of a configuration, in
+// document order: services first, then the top-level sections.
+type ValidationError struct {
+	// Errs are the failures, ServiceError or FieldError values.
+	Errs []error
+}
+
+// Error returns the failure alone, or the count and one failure per line.
+//
+// Returns:
+//   - string: the error message.
+func (e *ValidationError) Error() string {
+	// a single failure reads as itself
+	if len(e.Errs) == 1 {
+		// return the failure
+		return e.Errs[0].Error()
+	}
+	msgs := make([]string, 0, len(e.Errs))
+	// list each failure
+	for _, err := range e.Errs {
+		msgs = append(msgs, err.Error())
+	}
+	// return the list
+	return fmt.Sprintf("%d errors:\n  - %s", len(e.Errs), strings.Join(msgs, "\n  - "))
+}
+
+// Unwrap returns the failures.
+//
+// Returns:
+//   - []error: the wrapped errors.
+func (e *ValidationError) Unwrap() []error {
+	// return wrapped errors
+	return e.Errs
+}
+
+// validate runs every configuration check and collects the failures.
+//
+// Params:
+//   - cfg: configuration to validate
+//
+// Returns:
+//   - error: a ValidationError listing the failures, nil if none
+func validate(cfg *Config) error {
+	var errs []error
+
 	// check if services are configured
 	if len(cfg.Services) == 0 {
-		// return error when no services
-		return ErrNoServices
+		errs = append(errs, keyed("services", ErrNoServices))
 	}
 
 	seen := make(map[string]bool, len(cfg.Services))
@@ -48,57 +231,533 @@ func Validate(cfg *Config) error {
 	for i := range cfg.Services {
 		svc := &cfg.Services[i]
 
-		// validate service configuration
-		if err := validateService(svc); err != nil {
-			// propagate validation error
-			return fmt.Errorf("service %q: %w", svc.Name, err)
+		// report each failing setting of the service
+		for _, err := range validateService(svc) {
+			errs = append(errs, &ServiceError{Index: i, Name: svc.Name, Err: err})
 		}
 
 		// check for duplicate service names
-		if seen[svc.Name] {
-			// return error on duplicate
-			return fmt.Errorf("%w: %s", ErrDuplicateServiceName, svc.Name)
+		if svc.Name != "" && seen[svc.Name] {
+			errs = append(errs, &ServiceError{Index: i, Name: svc.Name, Err: keyed("name", ErrDuplicateServiceName)})
 		}
 		seen[svc.Name] = true
 	}
+	// check the services jobs operate on, once every name is known
+	errs = append(errs, validateOperatedServices(cfg.Services, seen)...)
+
+	// check the top-level sections by their key
+	for _, section := range []struct {
+		key string
+		err error
+	}{
+		// detect listeners competing for the same socket
+		{key: "services", err: validateListenerPorts(cfg.Services)},
+		// validate metrics collection settings
+		{key: "monitoring", err: validateMetrics(&cfg.Monitoring.Metrics)},
+		// check boot failure policy
+		{key: "on_boot_failure", err: validateBootFailure(cfg.OnBootFailure)},
+		// validate admission settings
+		{key: "admission", err: validateAdmission(&cfg.Admission)},
+		// validate shedding settings
+		{key: "shedding", err: validateShedding(&cfg.Shedding)},
+		// validate leak check settings
+		{key: "leak_check", err: validateLeakCheck(&cfg.LeakCheck)},
+		// validate restart budget settings
+		{key: "restart_budget", err: validateRestartBudget(&cfg.RestartBudget)},
+		// validate safe mode settings
+		{key: "safe_mode", err: validateSafeMode(&cfg.SafeMode)},
+		// validate traffic sampling settings
+		{key: "traffic_sampling", err: validateTrafficSampling(&cfg.TrafficSampling)},
+		// validate incident correlation settings
+		{key: "incidents", err: validateIncidents(&cfg.Incidents)},
+		// validate notification channels and rules
+		{key: "notifications", err: validateNotifications(&cfg.Notifications)},
+		// validate heartbeat endpoints against the defined services
+		{key: "notifications", err: validateHeartbeats(cfg.Notifications.Heartbeats, seen)},
+		// validate event hooks
+		{key: "hooks", err: validateEventHooks(&cfg.Hooks)},
+		// validate watchers against the defined services
+		{key: "watchers", err: validateWatchers(cfg.Watchers, seen)},
+		// validate shutdown report settings
+		{key: "shutdown_report", err: validateShutdownReport(&cfg.ShutdownReport)},
+		// validate host shutdown notice settings
+		{key: "host_shutdown", err: validateHostShutdown(&cfg.HostShutdown)},
+	} {
+		// collect failing sections
+		if section.err != nil {
+			errs = append(errs, keyed(section.key, section.err))
+		}
+	}
 
+	// validation passed
+	if len(errs) == 0 {
+		// no failure
+		return nil
+	}
+	// return every failure
+	return &ValidationError{Errs: errs}
+}
+
+// validateBootFailure validates the boot failure policy.
+//
+// Params:
+//   - policy: the on_boot_failure value
+//
+// Returns:
+//   - error: validation error if any
+func validateBootFailure(policy BootFailurePolicy) error {
+	// check boot failure policy
+	if !policy.IsValid() {
+		// return error with the unknown value
+		return fmt.Errorf("%w: %q", ErrInvalidBootFailurePolicy, policy)
+	}
 	// validation passed
 	return nil
 }
 
-// validateService validates a single service configuration.
+// validateIncidents validates the incident correlation settings.
 //
 // Params:
-//   - svc: service configuration to validate
+//   - ic: incident configuration to validate
 //
 // Returns:
 //   - error: validation error if any
-func validateService(svc *ServiceConfig) error {
+func validateIncidents(ic *IncidentConfig) error {
+	// check window is not negative
+	if ic.Window < 0 {
+		// return error with the window
+		return fmt.Errorf("%w: %s", ErrInvalidIncidentWindow, ic.Window)
+	}
+	// check service count when set
+	if ic.MinServices != 0 && ic.MinServices < minIncidentServices {
+		// return error with the count
+		return fmt.Errorf("%w: %d", ErrInvalidIncidentMinServices, ic.MinServices)
+	}
+	// validation passed
+	return nil
+}
+
+// validateListenerPorts detects listeners, within or across services,
+// that would bind the same protocol and port on overlapping addresses.
+//
+// Params:
+//   - services: service configurations to validate
+//
+// Returns:
+//   - error: validation error describing the first conflict
+func validateListenerPorts(services []ServiceConfig) error {
+	conflicts := FindListenerConflicts(services)
+	// no conflicts found
+	if len(conflicts) == 0 {
+		// validation passed
+		return nil
+	}
+
+	// report the first conflict
+	return fmt.Errorf("%w: %s", ErrListenerPortConflict, conflicts[0])
+}
+
+// isBracketedIPv6 reports whether an address is an IPv6 literal in brackets.
+//
+// Params:
+//   - addr: the raw bind address
+//
+// Returns:
+//   - bool: true for forms like "[::1]"
+func isBracketedIPv6(addr string) bool {
+	// require both brackets
+	if !strings.HasPrefix(addr, "[") || !strings.HasSuffix(addr, "]") {
+		// unbalanced or missing brackets
+		return false
+	}
+	host := addr[1 : len(addr)-1]
+	// only ipv6 literals contain colons
+	return strings.Contains(host, ":") && net.ParseIP(host) != nil
+}
+
+// validateMetrics validates the metrics collection configuration.
+//
+// Params:
+//   - m: metrics configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateMetrics(m *MetricsConfig) error {
+	// check template name (empty for programmatic configurations)
+	if m.Template != "" && !m.Template.IsValid() {
+		// return error on unknown template
+		return fmt.Errorf("%w: %q (expected minimal, standard, full or custom)", ErrUnknownMetricsTemplate, m.Template)
+	}
+
+	// check collection interval
+	if m.Interval < 0 {
+		// return error on negative interval
+		return fmt.Errorf("%w: %s", ErrInvalidMetricsInterval, m.Interval)
+	}
+
+	// validate CPU throttle threshold bounds
+	threshold := m.CPU.ThrottleThreshold
+	if threshold < 0 || threshold > maxPercentThreshold {
+		// return error on out-of-range threshold
+		return fmt.Errorf("%w: %g", ErrInvalidThrottleThreshold, threshold)
+	}
+
+	// check CPU percent mode
+	if !m.CPU.PercentMode.IsValid() {
+		// return error on unknown mode
+		return fmt.Errorf("%w: %q", ErrInvalidCPUPercentMode, m.CPU.PercentMode)
+	}
+
+	// validate PSI alert rules
+	for i := range m.PressureAlerts {
+		// propagate rule validation error
+		if err := validatePressureAlert(&m.PressureAlerts[i]); err != nil {
+			// return error with rule index
+			return fmt.Errorf("pressure alert %d: %w", i, err)
+		}
+	}
+
+	// validation passed
+	return nil
+}
+
+// validatePressureAlert validates a single PSI alert rule.
+//
+// Params:
+//   - rule: alert rule to validate
+//
+// Returns:
+//   - error: validation error if any
+func validatePressureAlert(rule *PressureAlertRule) error {
+	// check resource, scope, and window names
+	if !rule.hasValidSelector() {
+		// return error on unknown selector
+		return fmt.Errorf("%w: %s/%s/%s", ErrInvalidPressureSelector, rule.Resource, rule.Scope, rule.Window)
+	}
+
+	// check threshold bounds
+	if rule.Threshold <= 0 || rule.Threshold > maxPercentThreshold {
+		// return error on out-of-range threshold
+		return fmt.Errorf("%w: %g", ErrInvalidPressureThreshold, rule.Threshold)
+	}
+
+	// rule is valid
+	return nil
+}
+
+// validateService validates a single service configuration. Every setting
+// is checked, and each failing one reported under its configuration key.
+//
+// Params:
+//   - svc: service configuration to validate
+//
+// Returns:
+//   - []error: the FieldError of each failing setting, empty if valid
+func validateService(svc *ServiceConfig) []error {
+	var errs []error
+	// report keyed failures
+	report := func(key string, err error) {
+		// keep failing settings only
+		if err != nil {
+			errs = append(errs, keyed(key, err))
+		}
+	}
+
 	// check if service has a name
 	if svc.Name == "" {
-		// return error when name is empty
-		return ErrEmptyServiceName
+		report("name", ErrEmptyServiceName)
 	}
 
 	// check if service has a command
 	if svc.Command == "" {
-		// return error when command is empty
-		return ErrEmptyCommand
+		report("command", ErrEmptyCommand)
 	}
 
+	// check start timeout
+	if svc.StartTimeout < 0 {
+		report("start_timeout", ErrInvalidStartTimeout)
+	}
+
+	// check max runtime
+	if svc.MaxRuntime < 0 {
+		report("max_runtime", ErrInvalidMaxRuntime)
+	}
+
+	// check start phase
+	if svc.StartPhase < 0 {
+		report("start_phase", ErrInvalidStartPhase)
+	}
+
+	// check job history size
+	if svc.JobHistory < 0 {
+		report("job_history", ErrInvalidJobHistory)
+	}
+
+	// check verify timeout
+	if svc.VerifyTimeout < 0 {
+		report("verify_timeout", ErrInvalidVerifyTimeout)
+	}
+
+	report("reservation", validateReservation(&svc.Reservation))
+	report("conditions", validateConditions(&svc.Conditions))
+	report("priority", validatePriority(svc.Priority))
+
 	// validate each health check
 	for i := range svc.HealthChecks {
-		// validate health check configuration
-		if err := validateHealthCheck(&svc.HealthChecks[i]); err != nil {
-			// propagate validation error
+		report("health_checks", validateHealthCheck(&svc.HealthChecks[i]))
+	}
+
+	// validate each listener
+	for i := range svc.Listeners {
+		lc := &svc.Listeners[i]
+		// validate listener protocol and address
+		if err := validateListener(lc); err != nil {
+			report("listeners", fmt.Errorf("listener %q: %w", lc.Name, err))
+		}
+	}
+
+	report("external_dependencies", validateDependencies(svc.ExternalDependencies))
+	report("labels", validateLabels(svc.Labels))
+	report("selinux_context", validateSecurityLabels(svc))
+	report("read_only_paths", validateSandboxPaths(svc))
+	report("egress", validateEgress(svc.Egress))
+	report("dns", validateDNS(&svc.DNS))
+	report("integrity", validateIntegrity(&svc.Integrity))
+	report("watches", validateWatches(svc.Watches))
+	report("secrets", validateSecrets(svc))
+	report("timezone", validateTimezone(svc))
+	report("locale", validateLocale(svc))
+	report("allowed_signals", validateAllowedSignals(svc.AllowedSignals))
+	report("reload_signal", validateAppReload(svc))
+	report("slo", validateSLO(svc.SLO))
+	report("operates_on", validateOperatesOn(svc))
+
+	// validate the log quota when set
+	if svc.Logging.MaxTotalSize != "" {
+		// quota must be a valid size
+		if _, err := shared.ParseSize(svc.Logging.MaxTotalSize); err != nil {
+			report("logging", fmt.Errorf("%w %q: %w", ErrInvalidLogQuota, svc.Logging.MaxTotalSize, err))
+		}
+	}
+
+	// return failing settings
+	return errs
+}
+
+// validateSecurityLabels validates the SELinux context and AppArmor profile.
+// Whether the host supports them is checked when the service starts.
+//
+// Params:
+//   - svc: service configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateSecurityLabels(svc *ServiceConfig) error {
+	// only one LSM label can be applied
+	if svc.SELinuxContext != "" && svc.AppArmorProfile != "" {
+		// return conflict error
+		return ErrConflictingSecurityLabels
+	}
+	// no SELinux context to check
+	if svc.SELinuxContext == "" {
+		// nothing to validate
+		return nil
+	}
+	parts := strings.Split(svc.SELinuxContext, ":")
+	// user, role and type are required, the MLS level is optional
+	if len(parts) < minSELinuxContextParts || slices.Contains(parts[:minSELinuxContextParts], "") {
+		// return error with the context
+		return fmt.Errorf("%w: %q", ErrInvalidSELinuxContext, svc.SELinuxContext)
+	}
+	// labels valid
+	return nil
+}
+
+// validateSandboxPaths validates the read-only, masked and tmpfs paths.
+//
+// Params:
+//   - svc: service configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateSandboxPaths(svc *ServiceConfig) error {
+	// check each list against its field name
+	for _, list := range []struct {
+		field string
+		paths []string
+		root  bool
+	}{
+		{field: "read_only_paths", paths: svc.ReadOnlyPaths, root: true},
+		{field: "masked_paths", paths: svc.MaskedPaths},
+		{field: "tmpfs_paths", paths: svc.TmpfsPaths},
+	} {
+		// check each path
+		for _, p := range list.paths {
+			// mounts need absolute targets
+			if !filepath.IsAbs(p) {
+				// return error with the field and path
+				return &FieldError{Key: list.field, Err: fmt.Errorf("%s: %w: %q", list.field, ErrInvalidSandboxPath, p)}
+			}
+			// hiding or replacing / leaves nothing to execute
+			if !list.root && filepath.Clean(p) == "/" {
+				// return root error
+				return &FieldError{Key: list.field, Err: fmt.Errorf("%s: %w", list.field, ErrSandboxRootPath)}
+			}
+		}
+	}
+	// paths valid
+	return nil
+}
+
+// validateListener validates a listener protocol and bind address.
+// IPv6 literals may be bracketed; an IP literal must belong to the
+// family requested by a tcp4/tcp6/udp4/udp6 protocol.
+//
+// Params:
+//   - lc: listener configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateListener(lc *ListenerConfig) error {
+	// check protocol against supported networks
+	switch lc.EffectiveProtocol() {
+	// supported protocols
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+		// validate the bind address
+		if err := validateBindAddress(lc); err != nil {
+			// propagate address error
+			return err
+		}
+	// unix socket
+	case ListenerProtocolUnix:
+		// validate the socket path
+		if err := validateSocketListener(lc); err != nil {
+			// propagate socket error
 			return err
 		}
+	// unsupported protocol
+	default:
+		// return error on unknown protocol
+		return fmt.Errorf("%w: %q (expected tcp, tcp4, tcp6, udp, udp4, udp6 or unix)", ErrInvalidListenerProtocol, lc.Protocol)
+	}
+
+	// validate the scenario steps
+	if lc.Probe != nil && lc.Probe.Type == ProbeTypeScenario {
+		// propagate scenario error
+		if err := validateScenario(lc.Probe.Steps); err != nil {
+			// return error with probe context
+			return fmt.Errorf("probe: %w", err)
+		}
+	}
+
+	// validate the value check
+	if lc.Probe != nil && lc.Probe.Type == ProbeTypeThreshold {
+		// propagate threshold error
+		if err := validateThreshold(lc.Probe.Threshold); err != nil {
+			// return error with probe context
+			return fmt.Errorf("probe: %w", err)
+		}
+	}
+
+	// validate the unhealthy escalation chain
+	if lc.Probe != nil {
+		// propagate escalation error
+		if err := validateUnhealthySteps(lc.Probe.OnUnhealthy); err != nil {
+			// return error with probe context
+			return fmt.Errorf("probe: %w", err)
+		}
+	}
+
+	// validate the proxy endpoint
+	if lc.Proxy != nil {
+		// propagate proxy error
+		if err := validateProxy(lc); err != nil {
+			// return error with proxy context
+			return fmt.Errorf("proxy: %w", err)
+		}
+	}
+
+	// validate the certificate checks
+	if lc.TLS != nil {
+		// propagate tls error
+		if err := validateListenerTLS(lc); err != nil {
+			// return error with tls context
+			return fmt.Errorf("tls: %w", err)
+		}
 	}
 
 	// validation passed
 	return nil
 }
 
+// validateBindAddress validates a listener bind address against its protocol.
+//
+// Params:
+//   - lc: listener configuration to validate
+//
+// Returns:
+//   - error: validation error if any
+func validateBindAddress(lc *ListenerConfig) error {
+	host := lc.Host()
+	// brackets must enclose an ipv6 literal on both sides
+	if host != lc.Address && !isBracketedIPv6(lc.Address) {
+		// return error on malformed brackets
+		return fmt.Errorf("%w: %q", ErrInvalidListenerAddress, lc.Address)
+	}
+	// a colon outside an ip literal means a port or a broken literal
+	if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		// return error on host:port or invalid ipv6
+		return fmt.Errorf("%w: %q", ErrInvalidListenerAddress, lc.Address)
+	}
+
+	family := lc.Family()
+	literal := addressFamily(host)
+	// wildcards bind any family; concrete literals must match the protocol
+	if family != IPFamilyDual && literal != IPFamilyDual && literal != family && !isWildcardAddress(host) {
+		// return error on family mismatch
+		return fmt.Errorf("%w: %s address %q", ErrAddressFamilyMismatch, lc.EffectiveProtocol(), lc.Address)
+	}
+
+	// validation passed
+	return nil
+}
+
+// validateProxy validates the public endpoint of a proxied listener.
+//
+// Params:
+//   - lc: listener configuration with a proxy
+//
+// Returns:
+//   - error: validation error if any
+func validateProxy(lc *ListenerConfig) error {
+	// only exposed listeners have a public side
+	if !lc.Exposed {
+		// return error on internal listener
+		return ErrProxyNotExposed
+	}
+
+	// the proxy forwards streams only
+	if lc.Transport() != defaultListenerProtocol {
+		// return error on datagram listener
+		return fmt.Errorf("%w: %s", ErrProxyProtocol, lc.EffectiveProtocol())
+	}
+
+	// check the public port range
+	if lc.Proxy.Port < 0 || lc.Proxy.Port > shared.MaxValidPort {
+		// return error on out-of-range port
+		return fmt.Errorf("%w: %d", ErrInvalidProxyPort, lc.Proxy.Port)
+	}
+
+	// negative drain windows are meaningless
+	if lc.Proxy.DrainTimeout < 0 {
+		// return error on negative drain timeout
+		return fmt.Errorf("%w: %s", ErrInvalidDrainTimeout, lc.Proxy.DrainTimeout)
+	}
+
+	// the public address follows the listener address rules
+	return validateBindAddress(lc.PublicListener())
+}
+
 // validateHealthCheck validates a health check configuration.
 //
 // Params:
@@ -4,11 +4,13 @@ package config_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
 )
 
 // TestValidate tests the Validate function for configuration validation.
@@ -22,6 +24,1449 @@ func TestValidate(t *testing.T) {
 		wantErr   bool
 		errTarget error
 	}{
+		{
+			name: "negative throttle threshold",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfig{CPU: config.CPUMetricsConfig{ThrottleThreshold: -1}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidThrottleThreshold,
+		},
+		{
+			name: "throttle threshold above 100",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfig{CPU: config.CPUMetricsConfig{ThrottleThreshold: 150}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidThrottleThreshold,
+		},
+		{
+			name: "unknown cpu percent mode",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfig{CPU: config.CPUMetricsConfig{PercentMode: "per_socket"}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidCPUPercentMode,
+		},
+		{
+			name: "listener port conflict across services",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080}}},
+					{Name: "web", Command: "/bin/web", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Address: "127.0.0.1"}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrListenerPortConflict,
+		},
+		{
+			name: "same port on distinct addresses",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Address: "127.0.0.1"}}},
+					{Name: "web", Command: "/bin/web", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Address: "10.0.0.1"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ipv4 and ipv6 only listeners share a port",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "v4", Port: 8080, Protocol: "tcp4"}}},
+					{Name: "web", Command: "/bin/web", Listeners: []config.ListenerConfig{{Name: "v6", Port: 8080, Protocol: "tcp6", Address: "[::]"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unix listener",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "sock", Protocol: "unix", Address: "/run/api/api.sock", Probe: &config.ProbeConfig{Type: config.ProbeTypeTCP}},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unix listener with relative path",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "sock", Protocol: "unix", Address: "api.sock"}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSocketPath,
+		},
+		{
+			name: "unix listener with port",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "sock", Protocol: "unix", Address: "/run/api.sock", Port: 80}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrSocketPort,
+		},
+		{
+			name: "unix listener with http probe",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "sock", Protocol: "unix", Address: "/run/api.sock", Probe: &config.ProbeConfig{Type: config.ProbeTypeHTTP, Path: "/"}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrSocketProbe,
+		},
+		{
+			name: "proxied unix listener",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "sock", Protocol: "unix", Address: "/run/api.sock", Exposed: true, Proxy: &config.ProxyConfig{}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrProxyProtocol,
+		},
+		{
+			name: "invalid listener protocol",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Protocol: "sctp"}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidListenerProtocol,
+		},
+		{
+			name: "valid scenario probe",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{
+						Type: config.ProbeTypeScenario,
+						Steps: []config.ScenarioStep{
+							{Name: "login", Method: "POST", Path: "/login", Extract: map[string]string{"token": `"token":"([^"]+)"`}},
+							{Name: "me", Path: "/me", Headers: map[string]string{"Authorization": "Bearer {{token}}"}},
+						},
+					}}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "scenario probe without steps",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{Type: config.ProbeTypeScenario}}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrMissingScenarioSteps,
+		},
+		{
+			name: "scenario extract without capture group",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{
+						Type:  config.ProbeTypeScenario,
+						Steps: []config.ScenarioStep{{Path: "/login", Extract: map[string]string{"token": "token"}}},
+					}}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidScenarioExtract,
+		},
+		{
+			name: "scenario extract not compiling",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{
+						Type:  config.ProbeTypeScenario,
+						Steps: []config.ScenarioStep{{Path: "/login", Extract: map[string]string{"token": "(["}}},
+					}}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidScenarioExtract,
+		},
+		{
+			name: "valid threshold probe",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{
+						Type:      config.ProbeTypeThreshold,
+						Path:      "/lag",
+						Threshold: &config.ThresholdConfig{Pattern: `"lag":(\d+)`, Warn: floatPtr(100), Fail: floatPtr(1000)},
+					}}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "threshold probe without fail threshold",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{
+						Type:      config.ProbeTypeThreshold,
+						Threshold: &config.ThresholdConfig{Warn: floatPtr(100)},
+					}}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrMissingThreshold,
+		},
+		{
+			name: "threshold pattern without capture group",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{
+						Type:      config.ProbeTypeThreshold,
+						Threshold: &config.ThresholdConfig{Pattern: `lag`, Fail: floatPtr(1000)},
+					}}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidThresholdPattern,
+		},
+		{
+			name: "threshold warn past fail",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Probe: &config.ProbeConfig{
+						Type:      config.ProbeTypeThreshold,
+						Threshold: &config.ThresholdConfig{Warn: floatPtr(10), Fail: floatPtr(100), Below: true},
+					}}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidThresholdOrder,
+		},
+		{
+			name: "threshold dependency reading a command",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Name: "queue", Probe: config.ProbeConfig{
+							Type:      config.ProbeTypeThreshold,
+							Command:   "/bin/queue-lag",
+							Threshold: &config.ThresholdConfig{Fail: floatPtr(5000)},
+						}},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "threshold dependency reading nothing",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Name: "queue", Probe: config.ProbeConfig{Type: config.ProbeTypeThreshold, Threshold: &config.ThresholdConfig{Fail: floatPtr(5000)}}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrMissingDependencyAddress,
+		},
+		{
+			name: "valid external dependencies",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Name: "db", Address: "db.internal:5432", Probe: config.ProbeConfig{Type: config.ProbeTypeTCP}, GateRestart: true},
+						{Name: "queue", Probe: config.ProbeConfig{Type: config.ProbeTypeExec, Command: "/bin/check-queue"}},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "external dependency without name",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Address: "db.internal:5432", Probe: config.ProbeConfig{Type: config.ProbeTypeTCP}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrEmptyDependencyName,
+		},
+		{
+			name: "duplicate external dependency",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Name: "db", Address: "db1:5432", Probe: config.ProbeConfig{Type: config.ProbeTypeTCP}},
+						{Name: "db", Address: "db2:5432", Probe: config.ProbeConfig{Type: config.ProbeTypeTCP}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDuplicateDependencyName,
+		},
+		{
+			name: "external dependency without probe",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Name: "db", Address: "db.internal:5432"},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrMissingDependencyProbe,
+		},
+		{
+			name: "external dependency without address",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Name: "cache", Probe: config.ProbeConfig{Type: config.ProbeTypeTCP}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrMissingDependencyAddress,
+		},
+		{
+			name: "external dependency with unhealthy action",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", ExternalDependencies: []config.DependencyConfig{
+						{Name: "db", Address: "db.internal:5432", Probe: config.ProbeConfig{
+							Type:        config.ProbeTypeTCP,
+							OnUnhealthy: []config.UnhealthyStep{{Action: config.UnhealthyActionStop}},
+						}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDependencyUnhealthyAction,
+		},
+		{
+			name: "unhealthy escalation chain",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{
+					Name: "http", Port: 8080, Probe: &config.ProbeConfig{Type: config.ProbeTypeTCP, OnUnhealthy: []config.UnhealthyStep{
+						{Action: config.UnhealthyActionNotify},
+						{Action: config.UnhealthyActionSignal, After: 1, Signal: "usr1"},
+						{Action: config.UnhealthyActionExec, After: 1, Command: "/bin/dump"},
+						{Action: config.UnhealthyActionRestart, After: 2},
+						{Action: config.UnhealthyActionStop, After: 3},
+					}},
+				}}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown unhealthy action",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{
+					Name: "http", Port: 8080, Probe: &config.ProbeConfig{Type: config.ProbeTypeTCP, OnUnhealthy: []config.UnhealthyStep{{Action: "reload"}}},
+				}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidUnhealthyAction,
+		},
+		{
+			name: "unhealthy step without after",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{
+					Name: "http", Port: 8080, Probe: &config.ProbeConfig{Type: config.ProbeTypeTCP, OnUnhealthy: []config.UnhealthyStep{
+						{Action: config.UnhealthyActionNotify},
+						{Action: config.UnhealthyActionRestart},
+					}},
+				}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidUnhealthyAfter,
+		},
+		{
+			name: "signal unhealthy action without signal",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{
+					Name: "http", Port: 8080, Probe: &config.ProbeConfig{Type: config.ProbeTypeTCP, OnUnhealthy: []config.UnhealthyStep{{Action: config.UnhealthyActionSignal}}},
+				}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnhealthyWithoutSignal,
+		},
+		{
+			name: "exec unhealthy action without command",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{
+					Name: "http", Port: 8080, Probe: &config.ProbeConfig{Type: config.ProbeTypeTCP, OnUnhealthy: []config.UnhealthyStep{{Action: config.UnhealthyActionExec}}},
+				}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnhealthyWithoutCommand,
+		},
+		{
+			name: "listener address with port",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Address: "localhost:8080"}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidListenerAddress,
+		},
+		{
+			name: "brackets around ipv4 literal",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Address: "[127.0.0.1]"}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidListenerAddress,
+		},
+		{
+			name: "tcp4 listener on ipv6 literal",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Protocol: "tcp4", Address: "::1"}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrAddressFamilyMismatch,
+		},
+		{
+			name: "udp6 listener on ipv4 literal",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "dns", Command: "/bin/dns", Listeners: []config.ListenerConfig{{Name: "dns", Port: 53, Protocol: "udp6", Address: "10.0.0.1"}}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrAddressFamilyMismatch,
+		},
+		{
+			name: "bracketed ipv6 listener",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Protocol: "tcp6", Address: "[fd00::1]"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "proxy on internal listener",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "http", Port: 8080, Address: "127.0.0.1", Proxy: &config.ProxyConfig{}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrProxyNotExposed,
+		},
+		{
+			name: "proxy on udp listener",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "dns", Command: "/bin/dns", Listeners: []config.ListenerConfig{
+						{Name: "dns", Port: 53, Protocol: "udp", Address: "127.0.0.1", Exposed: true, Proxy: &config.ProxyConfig{}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrProxyProtocol,
+		},
+		{
+			name: "proxy port out of range",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "http", Port: 8080, Address: "127.0.0.1", Exposed: true, Proxy: &config.ProxyConfig{Port: 70000}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidProxyPort,
+		},
+		{
+			name: "proxy drain timeout negative",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "http", Port: 8080, Address: "127.0.0.1", Exposed: true,
+							Proxy: &config.ProxyConfig{Port: 80, DrainTimeout: shared.Seconds(-1)}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidDrainTimeout,
+		},
+		{
+			name: "tls on udp listener",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "dns", Command: "/bin/dns", Listeners: []config.ListenerConfig{
+						{Name: "dot", Port: 853, Protocol: "udp", TLS: &config.ListenerTLSConfig{}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrTLSProtocol,
+		},
+		{
+			name: "tls renewal without command",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "https", Port: 8443, TLS: &config.ListenerTLSConfig{RenewBefore: shared.Seconds(3600)}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrTLSRenewWithoutCommand,
+		},
+		{
+			name: "tls negative interval",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "https", Port: 8443, TLS: &config.ListenerTLSConfig{Interval: shared.Seconds(-1)}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidTLSDuration,
+		},
+		{
+			name: "log quota valid",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Logging: config.ServiceLogging{MaxTotalSize: "1GB"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "log quota invalid",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Logging: config.ServiceLogging{MaxTotalSize: "lots"}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidLogQuota,
+		},
+		{
+			name: "boot failure shutdown",
+			cfg: &config.Config{
+				OnBootFailure: config.BootFailureShutdown,
+				Services:      []config.ServiceConfig{{Name: "api", Command: "/bin/api", Critical: true}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown boot failure policy",
+			cfg: &config.Config{
+				OnBootFailure: "reboot",
+				Services:      []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidBootFailurePolicy,
+		},
+		{
+			name: "incident correlation",
+			cfg: &config.Config{
+				Incidents: config.IncidentConfig{Window: shared.Seconds(30), MinServices: 3},
+				Services:  []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "admission with reservations",
+			cfg: &config.Config{
+				Admission: config.AdmissionConfig{Overcommit: 1.5, Action: config.AdmissionWarn},
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Reservation: config.ReservationConfig{Memory: "256MB", CPU: 0.5}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative admission overcommit",
+			cfg: &config.Config{
+				Admission: config.AdmissionConfig{Overcommit: -1},
+				Services:  []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidOvercommit,
+		},
+		{
+			name: "unknown admission action",
+			cfg: &config.Config{
+				Admission: config.AdmissionConfig{Action: "ignore"},
+				Services:  []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidAdmissionAction,
+		},
+		{
+			name: "invalid reservation memory",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Reservation: config.ReservationConfig{Memory: "lots"}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidReservationMemory,
+		},
+		{
+			name: "negative reservation cpu",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Reservation: config.ReservationConfig{CPU: -0.5}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidReservationCPU,
+		},
+		{
+			name: "shedding with priorities",
+			cfg: &config.Config{
+				Shedding: config.SheddingConfig{Threshold: 40, Resume: 10, Scope: "full", Window: "avg60"},
+				Services: []config.ServiceConfig{
+					{Name: "batch", Command: "/bin/batch", Priority: config.PriorityLow},
+					{Name: "api", Command: "/bin/api", Priority: config.PriorityHigh},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shedding threshold over 100",
+			cfg: &config.Config{
+				Shedding: config.SheddingConfig{Threshold: 120},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidShedThreshold,
+		},
+		{
+			name: "shedding resume above threshold",
+			cfg: &config.Config{
+				Shedding: config.SheddingConfig{Threshold: 20, Resume: 30},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidShedResume,
+		},
+		{
+			name: "unknown shedding window",
+			cfg: &config.Config{
+				Shedding: config.SheddingConfig{Threshold: 20, Window: "avg5"},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidShedSelector,
+		},
+		{
+			name: "negative leak check interval",
+			cfg: &config.Config{
+				LeakCheck: config.LeakCheckConfig{Interval: shared.Seconds(-1)},
+				Services:  []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidLeakCheckInterval,
+		},
+		{
+			name: "shutdown report url without http scheme",
+			cfg: &config.Config{
+				ShutdownReport: config.ShutdownReportConfig{URL: "ftp://reports.example.com"},
+				Services:       []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidShutdownReportURL,
+		},
+		{
+			name: "negative shutdown report timeout",
+			cfg: &config.Config{
+				ShutdownReport: config.ShutdownReportConfig{Path: "/var/lib/supervizio/shutdown.json", Timeout: shared.Seconds(-1)},
+				Services:       []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidShutdownReportTimeout,
+		},
+		{
+			name: "relative acpi socket",
+			cfg: &config.Config{
+				HostShutdown: config.HostShutdownConfig{ACPISocket: "acpid.socket"},
+				Services:     []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidACPISocket,
+		},
+		{
+			name: "negative restart budget",
+			cfg: &config.Config{
+				RestartBudget: config.RestartBudgetConfig{PerMinute: 10, Burst: -1},
+				Services:      []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidRestartBudget,
+		},
+		{
+			name: "unknown priority class",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Priority: "urgent"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidPriority,
+		},
+		{
+			name: "negative incident window",
+			cfg: &config.Config{
+				Incidents: config.IncidentConfig{Window: shared.Seconds(-1)},
+				Services:  []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidIncidentWindow,
+		},
+		{
+			name: "incident of a single service",
+			cfg: &config.Config{
+				Incidents: config.IncidentConfig{Window: shared.Seconds(30), MinServices: 1},
+				Services:  []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidIncidentMinServices,
+		},
+		{
+			name: "notifications",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Channels:    []config.NotificationChannel{{Name: "ops", URL: "https://hooks.example.com/ops", Digest: shared.Seconds(30), Selector: map[string]string{"team": "payments"}}},
+					RateLimit:   config.NotificationRateLimit{Max: 10, Period: shared.Minutes(1)},
+					Escalations: []config.EscalationRule{{Event: "failed", Count: 3, Within: shared.Minutes(5), Channel: "ops"}},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate notification channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{Channels: []config.NotificationChannel{
+					{Name: "ops", URL: "https://hooks.example.com/a"},
+					{Name: "ops", URL: "https://hooks.example.com/b"},
+				}},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDuplicateChannelName,
+		},
+		{
+			name: "notification channel with empty selector label",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{Channels: []config.NotificationChannel{
+					{Name: "payments", URL: "https://hooks.example.com/payments", Selector: map[string]string{"": "payments"}},
+				}},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidChannelSelector,
+		},
+		{
+			name: "notification channel without url",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{Channels: []config.NotificationChannel{{Name: "ops", URL: "hooks.example.com"}}},
+				Services:      []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidChannelURL,
+		},
+		{
+			name: "negative notification rate limit",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{RateLimit: config.NotificationRateLimit{Max: -1}},
+				Services:      []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidRateLimit,
+		},
+		{
+			name: "watchers",
+			cfg: &config.Config{
+				Watchers: []config.WatcherConfig{
+					{Name: "raid", Command: "/usr/local/bin/check-raid", OnFailure: "raid_degraded", OnRecovery: "raid_recovered"},
+					{Name: "lag", Command: "/usr/local/bin/lag", Service: "api", OnOutputChange: "lag_changed2"},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate watcher",
+			cfg: &config.Config{
+				Watchers: []config.WatcherConfig{
+					{Name: "raid", Command: "/bin/a", OnFailure: "raid_degraded"},
+					{Name: "raid", Command: "/bin/b", OnFailure: "raid_degraded"},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDuplicateWatcherName,
+		},
+		{
+			name: "watcher without event",
+			cfg: &config.Config{
+				Watchers: []config.WatcherConfig{{Name: "raid", Command: "/bin/check"}},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrWatcherWithoutEvent,
+		},
+		{
+			name: "watcher with malformed event",
+			cfg: &config.Config{
+				Watchers: []config.WatcherConfig{{Name: "raid", Command: "/bin/check", OnFailure: "RAID-degraded"}},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidWatcherEvent,
+		},
+		{
+			name: "watcher for unknown service",
+			cfg: &config.Config{
+				Watchers: []config.WatcherConfig{{Name: "lag", Command: "/bin/lag", Service: "db", OnFailure: "lagging"}},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownWatcherService,
+		},
+		{
+			name: "notification spool",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{Spool: config.NotificationSpool{Dir: "/var/spool/daemon", MaxSize: "16MB", RetryInterval: shared.Seconds(10)}},
+				Services:      []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid notification spool size",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{Spool: config.NotificationSpool{Dir: "/var/spool/daemon", MaxSize: "lots"}},
+				Services:      []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSpoolSize,
+		},
+		{
+			name: "incomplete escalation",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Channels:    []config.NotificationChannel{{Name: "ops", URL: "https://hooks.example.com/ops"}},
+					Escalations: []config.EscalationRule{{Event: "failed", Channel: "ops"}},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidEscalation,
+		},
+		{
+			name: "escalation to unknown channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Escalations: []config.EscalationRule{{Event: "failed", Count: 3, Within: shared.Minutes(5), Channel: "pager"}},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownEscalationChannel,
+		},
+		{
+			name: "heartbeat",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Heartbeats: []config.HeartbeatConfig{{URL: "https://hc-ping.com/uuid", Interval: shared.Minutes(1), Services: []string{"api"}}},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "heartbeat without interval",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Heartbeats: []config.HeartbeatConfig{{URL: "https://hc-ping.com/uuid"}},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidHeartbeatInterval,
+		},
+		{
+			name: "heartbeat of unknown service",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Heartbeats: []config.HeartbeatConfig{{URL: "https://hc-ping.com/uuid", Interval: shared.Minutes(1), Services: []string{"db"}}},
+				},
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownHeartbeatService,
+		},
+		{
+			name: "selinux context",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SELinuxContext: "system_u:system_r:httpd_t:s0"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed selinux context",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SELinuxContext: "httpd_t"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSELinuxContext,
+		},
+		{
+			name: "selinux context and apparmor profile",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SELinuxContext: "system_u:system_r:httpd_t", AppArmorProfile: "api"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrConflictingSecurityLabels,
+		},
+		{
+			name: "sandbox paths",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{
+					Name: "api", Command: "/bin/api",
+					ReadOnlyPaths: []string{"/"}, MaskedPaths: []string{"/etc/shadow"}, TmpfsPaths: []string{"/tmp"},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "relative sandbox path",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", ReadOnlyPaths: []string{"usr"}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSandboxPath,
+		},
+		{
+			name: "egress rules",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Egress: []config.EgressRule{
+					{CIDR: "10.0.0.0/8", Ports: []int{443}, Protocol: "tcp"},
+					{CIDR: "2001:db8::/32"},
+				}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "egress rule without cidr",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Egress: []config.EgressRule{{Ports: []int{443}}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidEgressCIDR,
+		},
+		{
+			name: "egress port out of range",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Egress: []config.EgressRule{{CIDR: "10.0.0.0/8", Ports: []int{70000}}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidEgressPort,
+		},
+		{
+			name: "egress protocol",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Egress: []config.EgressRule{{CIDR: "10.0.0.0/8", Protocol: "icmp"}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidEgressProtocol,
+		},
+		{
+			name: "integrity watch",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", RestartOnBinaryChange: true,
+					Integrity: config.IntegrityConfig{Paths: []string{"/etc/api.conf"}, Interval: shared.Duration(time.Minute)}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "relative integrity path",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api",
+					Integrity: config.IntegrityConfig{Paths: []string{"api.conf"}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidIntegrityPath,
+		},
+		{
+			name: "negative integrity interval",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api",
+					Integrity: config.IntegrityConfig{Interval: shared.Duration(-time.Second)}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidIntegrityInterval,
+		},
+		{
+			name: "file watches",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Watches: []config.WatchConfig{
+					{Paths: []string{"/etc/api/*.conf"}, Action: config.WatchActionReload, Signal: "usr1"},
+					{Paths: []string{"/opt/api/app.jar"}, Action: config.WatchActionExec, Command: "/bin/deploy"},
+				}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "watch without paths",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Watches: []config.WatchConfig{{Action: config.WatchActionRestart}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrWatchWithoutPaths,
+		},
+		{
+			name: "watch glob in directory",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Watches: []config.WatchConfig{{Paths: []string{"/etc/*/app.conf"}}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidWatchPath,
+		},
+		{
+			name: "unknown watch action",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Watches: []config.WatchConfig{{Paths: []string{"/etc/app.conf"}, Action: "stop"}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidWatchAction,
+		},
+		{
+			name: "unknown reload signal",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Watches: []config.WatchConfig{
+					{Paths: []string{"/etc/app.conf"}, Action: config.WatchActionReload, Signal: "SIGFOO"},
+				}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownSignal,
+		},
+		{
+			name: "exec watch without command",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Watches: []config.WatchConfig{
+					{Paths: []string{"/etc/app.conf"}, Action: config.WatchActionExec},
+				}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrWatchWithoutCommand,
+		},
+		{
+			name: "negative watch debounce",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Watches: []config.WatchConfig{
+					{Paths: []string{"/etc/app.conf"}, Debounce: shared.Duration(-time.Second)},
+				}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidWatchDuration,
+		},
+		{
+			name: "secret files",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", OnSecretChange: config.SecretChangeReload,
+					Secrets: []config.SecretConfig{{Env: "DB_PASSWORD", File: "/run/secrets/db_password"}}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "secret without env",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api",
+					Secrets: []config.SecretConfig{{File: "/run/secrets/db_password"}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSecretEnv,
+		},
+		{
+			name: "secret with relative file",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api",
+					Secrets: []config.SecretConfig{{Env: "DB_PASSWORD", File: "secrets/db_password"}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSecretFile,
+		},
+		{
+			name: "secret env already in environment",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Environment: map[string]string{"DB_PASSWORD": "x"},
+					Secrets: []config.SecretConfig{{Env: "DB_PASSWORD", File: "/run/secrets/db_password"}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDuplicateSecretEnv,
+		},
+		{
+			name: "duplicate secret env",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Secrets: []config.SecretConfig{
+					{Env: "DB_PASSWORD", File: "/run/secrets/a"},
+					{Env: "DB_PASSWORD", File: "/run/secrets/b"},
+				}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDuplicateSecretEnv,
+		},
+		{
+			name: "valid timezone and locale",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Timezone: "Europe/Paris", Locale: "fr_FR.UTF-8"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown timezone",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Timezone: "Europe/Atlantis"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownTimezone,
+		},
+		{
+			name: "invalid locale",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Locale: "french"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidLocale,
+		},
+		{
+			name: "timezone with TZ in environment",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Timezone: "UTC",
+					Environment: map[string]string{"TZ": "Asia/Tokyo"}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDuplicateLocaleEnv,
+		},
+		{
+			name: "locale with LC_ALL from a secret",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", Locale: "C.UTF-8",
+					Secrets: []config.SecretConfig{{Env: "LC_ALL", File: "/run/secrets/locale"}}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrDuplicateLocaleEnv,
+		},
+		{
+			name: "unknown secret change action",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", OnSecretChange: "stop"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSecretChange,
+		},
+		{
+			name: "unknown allowed signal",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", AllowedSignals: []string{"SIGHUP", "SIGFOO"}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownSignal,
+		},
+		{
+			name: "reload command",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "nginx", Command: "/usr/sbin/nginx", ReloadCommand: "/usr/sbin/nginx -s reload"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reload signal and command",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "nginx", Command: "/usr/sbin/nginx", ReloadSignal: "SIGHUP", ReloadCommand: "/usr/sbin/nginx -s reload"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrReloadSignalAndCommand,
+		},
+		{
+			name: "unknown reload signal",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "nginx", Command: "/usr/sbin/nginx", ReloadSignal: "SIGFOO"}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownSignal,
+		},
+		{
+			name: "negative reload timeout",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "nginx", Command: "/usr/sbin/nginx", ReloadTimeout: shared.Duration(-time.Second)}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidReloadTimeout,
+		},
+		{
+			name: "slo with defaults",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SLO: &config.SLOConfig{Target: 99.9}}},
+			},
+		},
+		{
+			name: "slo target of 100 percent",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SLO: &config.SLOConfig{Target: 100}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSLOTarget,
+		},
+		{
+			name: "slo window beyond 30 days",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SLO: &config.SLOConfig{
+					Target: 99, Window: shared.Duration(31 * 24 * time.Hour),
+				}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidSLOWindow,
+		},
+		{
+			name: "slo burn rate not positive",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SLO: &config.SLOConfig{
+					Target: 99, BurnAlerts: []config.BurnAlertConfig{{Rate: 0, Window: shared.Duration(time.Hour)}},
+				}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidBurnRate,
+		},
+		{
+			name: "slo burn window beyond slo window",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", SLO: &config.SLOConfig{
+					Target: 99, Window: shared.Duration(24 * time.Hour),
+					BurnAlerts: []config.BurnAlertConfig{{Rate: 2, Window: shared.Duration(48 * time.Hour)}},
+				}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidBurnWindow,
+		},
+		{
+			name: "tmpfs over root",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{{Name: "api", Command: "/bin/api", TmpfsPaths: []string{"/"}}},
+			},
+			wantErr:   true,
+			errTarget: config.ErrSandboxRootPath,
+		},
+		{
+			name: "negative start timeout",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", StartTimeout: shared.Duration(-time.Second)},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidStartTimeout,
+		},
+		{
+			name: "negative max runtime",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "batch", Command: "/bin/batch", MaxRuntime: shared.Duration(-time.Minute)},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidMaxRuntime,
+		},
+		{
+			name: "valid labels",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Labels: map[string]string{"team": "payments", "tier_1": "", "_env": "prod"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "label name with dash",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Labels: map[string]string{"cost-center": "42"}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidLabel,
+		},
+		{
+			name: "label name starting with digit",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Labels: map[string]string{"1tier": "front"}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidLabel,
+		},
+		{
+			name: "reserved label name",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Labels: map[string]string{"__name__": "api"}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidLabel,
+		},
+		{
+			name: "negative start phase",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", StartPhase: -1},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidStartPhase,
+		},
+		{
+			name: "negative job history",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "backup", Command: "/bin/backup", Oneshot: true, JobHistory: -1},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidJobHistory,
+		},
+		{
+			name: "negative verify timeout",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", VerifyTimeout: shared.Seconds(-1)},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidVerifyTimeout,
+		},
+		{
+			name: "proxy endpoint overlapping its listener",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "http", Port: 8080, Address: "127.0.0.1", Exposed: true, Proxy: &config.ProxyConfig{}},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrListenerPortConflict,
+		},
+		{
+			name: "proxy on public port",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "api", Command: "/bin/api", Listeners: []config.ListenerConfig{
+						{Name: "http", Port: 8080, Address: "127.0.0.1", Exposed: true, Proxy: &config.ProxyConfig{Port: 80, HealthGate: true}},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid pressure alert selector",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfig{PressureAlerts: []config.PressureAlertRule{
+						{Resource: "disk", Scope: "some", Window: "avg10", Threshold: 10},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidPressureSelector,
+		},
+		{
+			name: "invalid pressure alert threshold",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfig{PressureAlerts: []config.PressureAlertRule{
+						{Resource: "memory", Scope: "full", Window: "avg60", Threshold: 0},
+					}},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidPressureThreshold,
+		},
+		{
+			name: "unknown metrics template",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfigForTemplate("turbo"),
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrUnknownMetricsTemplate,
+		},
+		{
+			name: "negative metrics interval",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfig{Interval: -1},
+				},
+			},
+			wantErr:   true,
+			errTarget: config.ErrInvalidMetricsInterval,
+		},
+		{
+			name: "valid pressure alert",
+			cfg: &config.Config{
+				Services: []config.ServiceConfig{
+					{Name: "app", Command: "/bin/app"},
+				},
+				Monitoring: config.MonitoringConfig{
+					Metrics: config.MetricsConfig{PressureAlerts: []config.PressureAlertRule{
+						{Resource: "io", Scope: "some", Window: "avg300", Threshold: 30},
+					}},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid config with single service",
 			cfg: &config.Config{
@@ -86,6 +1531,7 @@ func TestValidate(t *testing.T) {
 
 			if tt.wantErr {
 				require.Error(t, err)
+				assert.Equal(t, shared.CodeConfigInvalid, shared.CodeOf(err))
 				if tt.errTarget != nil {
 					assert.True(t, errors.Is(err, tt.errTarget))
 				}
@@ -415,3 +1861,60 @@ func TestValidate_InvalidHealthCheckType(t *testing.T) {
 		})
 	}
 }
+
+// TestValidate_ServiceError tests that service errors name the failing service and its index.
+//
+// Params:
+//   - t: the testing context.
+func TestValidate_ServiceError(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// services are the configured services.
+		services []config.ServiceConfig
+		// wantIndex is the index of the failing service.
+		wantIndex int
+		// wantErr is the wrapped validation error.
+		wantErr error
+		// wantMsg is the expected message.
+		wantMsg string
+	}{
+		{
+			name:      "invalid service",
+			services:  []config.ServiceConfig{{Name: "app", Command: "/bin/app"}, {Name: "worker"}},
+			wantIndex: 1,
+			wantErr:   config.ErrEmptyCommand,
+			wantMsg:   `service "worker": service command is required`,
+		},
+		{
+			name:      "duplicate service",
+			services:  []config.ServiceConfig{{Name: "app", Command: "/bin/app"}, {Name: "app", Command: "/bin/other"}},
+			wantIndex: 1,
+			wantErr:   config.ErrDuplicateServiceName,
+			wantMsg:   `service "app": duplicate service name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := config.Validate(&config.Config{Services: tt.services})
+
+			var svcErr *config.ServiceError
+			require.ErrorAs(t, err, &svcErr)
+			assert.Equal(t, tt.wantIndex, svcErr.Index)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.EqualError(t, err, tt.wantMsg)
+		})
+	}
+}
+
+// floatPtr returns a pointer to a threshold value.
+//
+// Params:
+//   - f: the value.
+//
+// Returns:
+//   - *float64: pointer to the value.
+func floatPtr(f float64) *float64 {
+	return &f
+}
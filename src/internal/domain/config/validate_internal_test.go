@@ -2,6 +2,7 @@
 package config
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,7 +40,7 @@ func TestValidateService(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateService(tt.svc)
+			err := errors.Join(validateService(tt.svc)...)
 
 			if tt.wantErr {
 				assert.ErrorIs(t, err, tt.errTarget)
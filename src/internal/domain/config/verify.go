@@ -0,0 +1,50 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultVerifyTimeout bounds a verify command when none is configured.
+const DefaultVerifyTimeout time.Duration = 10 * time.Second
+
+// ErrInvalidVerifyTimeout indicates a negative verify_timeout.
+var ErrInvalidVerifyTimeout error = errors.New("verify_timeout must not be negative")
+
+// VerifyCommands returns the command lines dry-running the service binary,
+// split into fields. The binary passes verification if any of them exits 0.
+//
+// Returns:
+//   - [][]string: the configured verify command, or the service binary
+//     with --version then --help.
+func (s *ServiceConfig) VerifyCommands() [][]string {
+	// prefer the configured command
+	if fields := strings.Fields(s.VerifyCommand); len(fields) > 0 {
+		// return configured command only
+		return [][]string{fields}
+	}
+	fields := strings.Fields(s.Command)
+	// validation rejects empty commands
+	if len(fields) == 0 {
+		// nothing to run
+		return nil
+	}
+	// return the binary with the conventional flags
+	return [][]string{{fields[0], "--version"}, {fields[0], "--help"}}
+}
+
+// EffectiveVerifyTimeout returns the bound of one verify command.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultVerifyTimeout.
+func (s *ServiceConfig) EffectiveVerifyTimeout() time.Duration {
+	// fall back to the default timeout
+	if s.VerifyTimeout <= 0 {
+		// return default
+		return DefaultVerifyTimeout
+	}
+	// return configured timeout
+	return s.VerifyTimeout.Duration()
+}
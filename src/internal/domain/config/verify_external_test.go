@@ -0,0 +1,74 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestServiceConfig_VerifyCommands tests the verify command defaults.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_VerifyCommands(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// svc is the service configuration.
+		svc config.ServiceConfig
+		// want is the expected command lines.
+		want [][]string
+	}{
+		{
+			name: "configured command",
+			svc:  config.ServiceConfig{Command: "/usr/sbin/nginx -g daemon off;", VerifyCommand: "/usr/sbin/nginx -t"},
+			want: [][]string{{"/usr/sbin/nginx", "-t"}},
+		},
+		{
+			name: "default flags",
+			svc:  config.ServiceConfig{Command: "/usr/sbin/nginx -g daemon off;"},
+			want: [][]string{{"/usr/sbin/nginx", "--version"}, {"/usr/sbin/nginx", "--help"}},
+		},
+		{
+			name: "empty command",
+			svc:  config.ServiceConfig{},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.svc.VerifyCommands())
+		})
+	}
+}
+
+// TestServiceConfig_EffectiveVerifyTimeout tests the verify timeout defaults.
+//
+// Params:
+//   - t: the testing context.
+func TestServiceConfig_EffectiveVerifyTimeout(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// timeout is the configured timeout.
+		timeout shared.Duration
+		// want is the expected timeout.
+		want time.Duration
+	}{
+		{name: "default", timeout: 0, want: config.DefaultVerifyTimeout},
+		{name: "configured", timeout: shared.Seconds(3), want: 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := config.ServiceConfig{Name: "api", Command: "/bin/api", VerifyTimeout: tt.timeout}
+			assert.Equal(t, tt.want, svc.EffectiveVerifyTimeout())
+		})
+	}
+}
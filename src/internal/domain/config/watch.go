@@ -0,0 +1,242 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// DefaultWatchDebounce is the quiet period after a change before a
+	// watch acts, so a deployment copying several files acts once.
+	DefaultWatchDebounce time.Duration = 500 * time.Millisecond
+
+	// DefaultWatchTimeout bounds an exec action when none is configured.
+	DefaultWatchTimeout time.Duration = 30 * time.Second
+
+	// DefaultReloadSignal is the signal of reload actions when none is configured.
+	DefaultReloadSignal string = "SIGHUP"
+
+	// globWildcards are the characters making a path a glob pattern.
+	globWildcards string = `*?[\`
+)
+
+// WatchAction is what a watch does once its files changed.
+type WatchAction string
+
+const (
+	// WatchActionNone only emits the file_changed events.
+	WatchActionNone WatchAction = ""
+	// WatchActionRestart restarts the service.
+	WatchActionRestart WatchAction = "restart"
+	// WatchActionReload sends the reload signal to the service.
+	WatchActionReload WatchAction = "reload"
+	// WatchActionExec runs a hook command.
+	WatchActionExec WatchAction = "exec"
+)
+
+// Watch validation errors.
+var (
+	// ErrWatchWithoutPaths indicates a watch without any path.
+	ErrWatchWithoutPaths error = errors.New("watch requires at least one path")
+	// ErrInvalidWatchPath indicates a watch path that is not absolute or a malformed pattern.
+	ErrInvalidWatchPath error = errors.New("watch path must be absolute, with wildcards in the file name only")
+	// ErrInvalidWatchAction indicates an unknown watch action.
+	ErrInvalidWatchAction error = errors.New("watch action must be restart, reload or exec")
+	// ErrWatchWithoutCommand indicates an exec action without command.
+	ErrWatchWithoutCommand error = errors.New("exec watch action requires a command")
+	// ErrInvalidWatchDuration indicates a negative debounce, interval or timeout.
+	ErrInvalidWatchDuration error = errors.New("watch durations must not be negative")
+)
+
+// WatchConfig acts on a service when files change, like entr or
+// watchexec wrappers: configuration files, JARs, .env files.
+// Every change emits a file_changed event, then the action runs once per
+// debounce window.
+type WatchConfig struct {
+	// Paths are the watched files, or glob patterns with wildcards in the
+	// file name only (/etc/app/*.conf).
+	Paths []string
+	// Action is what the watch does on change. Empty only emits events.
+	Action WatchAction
+	// Signal is the signal of reload actions. Empty uses DefaultReloadSignal.
+	Signal string
+	// Command is the hook run by exec actions.
+	Command string
+	// Args are the arguments of the hook.
+	Args []string
+	// Timeout bounds the hook. Zero uses DefaultWatchTimeout.
+	Timeout shared.Duration
+	// Debounce is the quiet period before acting. Zero uses DefaultWatchDebounce.
+	Debounce shared.Duration
+	// Interval is the periodic re-hash period. Zero uses DefaultIntegrityInterval.
+	Interval shared.Duration
+}
+
+// ReloadSignal returns the POSIX name of the reload signal.
+//
+// Returns:
+//   - string: the configured signal, or DefaultReloadSignal.
+func (w *WatchConfig) ReloadSignal() string {
+	// fall back to SIGHUP
+	if w.Signal == "" {
+		// return default
+		return DefaultReloadSignal
+	}
+	name, _ := NormalizeSignal(w.Signal)
+	// return validated name
+	return name
+}
+
+// WatchTimeout returns the effective hook timeout.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultWatchTimeout.
+func (w *WatchConfig) WatchTimeout() time.Duration {
+	// fall back to the default timeout
+	if w.Timeout <= 0 {
+		// return default
+		return DefaultWatchTimeout
+	}
+	// return configured timeout
+	return w.Timeout.Duration()
+}
+
+// WatchDebounce returns the effective quiet period.
+//
+// Returns:
+//   - time.Duration: the configured debounce, or DefaultWatchDebounce.
+func (w *WatchConfig) WatchDebounce() time.Duration {
+	// fall back to the default quiet period
+	if w.Debounce <= 0 {
+		// return default
+		return DefaultWatchDebounce
+	}
+	// return configured debounce
+	return w.Debounce.Duration()
+}
+
+// WatchInterval returns the effective re-hash period.
+//
+// Returns:
+//   - time.Duration: the configured interval, or DefaultIntegrityInterval.
+func (w *WatchConfig) WatchInterval() time.Duration {
+	// fall back to the integrity period
+	if w.Interval <= 0 {
+		// return default
+		return DefaultIntegrityInterval
+	}
+	// return configured period
+	return w.Interval.Duration()
+}
+
+// validateWatches validates the file watches of a service.
+//
+// Params:
+//   - watches: the watches to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateWatches(watches []WatchConfig) error {
+	// validate each watch
+	for i := range watches {
+		// propagate with the watch index
+		if err := validateWatch(&watches[i]); err != nil {
+			// return error with the watch index
+			return fmt.Errorf("watch %d: %w", i, err)
+		}
+	}
+	// validation passed
+	return nil
+}
+
+// validateWatch validates one file watch.
+//
+// Params:
+//   - w: the watch to validate.
+//
+// Returns:
+//   - error: validation error if any.
+func validateWatch(w *WatchConfig) error {
+	// a watch needs files
+	if len(w.Paths) == 0 {
+		// return missing paths error
+		return ErrWatchWithoutPaths
+	}
+	// validate each path
+	for _, path := range w.Paths {
+		// propagate path error
+		if err := validateWatchPath(path); err != nil {
+			// return path error
+			return err
+		}
+	}
+	// durations must not be negative
+	if w.Debounce < 0 || w.Interval < 0 || w.Timeout < 0 {
+		// return duration error
+		return ErrInvalidWatchDuration
+	}
+	// validate the action settings
+	switch w.Action {
+	// no settings
+	case WatchActionNone, WatchActionRestart:
+		// nothing more to check
+		return nil
+	// signal must be sendable
+	case WatchActionReload:
+		// default signal
+		if w.Signal == "" {
+			// nothing more to check
+			return nil
+		}
+		_, err := NormalizeSignal(w.Signal)
+		// return signal error
+		return err
+	// hook must be set
+	case WatchActionExec:
+		// command required
+		if w.Command == "" {
+			// return missing command error
+			return ErrWatchWithoutCommand
+		}
+		// hook valid
+		return nil
+	// unknown action
+	default:
+		// return error with the action
+		return fmt.Errorf("%w: %q", ErrInvalidWatchAction, w.Action)
+	}
+}
+
+// validateWatchPath validates a watched file or pattern. Wildcards are
+// only allowed in the file name, so the directory can be watched.
+//
+// Params:
+//   - path: the path to validate.
+//
+// Returns:
+//   - error: ErrInvalidWatchPath if the path is invalid.
+func validateWatchPath(path string) error {
+	// path must be absolute
+	if !filepath.IsAbs(path) {
+		// return error with the path
+		return fmt.Errorf("%w: %q", ErrInvalidWatchPath, path)
+	}
+	// the directory must be literal
+	if strings.ContainsAny(filepath.Dir(path), globWildcards) {
+		// return error with the path
+		return fmt.Errorf("%w: %q", ErrInvalidWatchPath, path)
+	}
+	// the pattern must be well-formed
+	if _, err := filepath.Match(path, ""); err != nil {
+		// return error with the path
+		return fmt.Errorf("%w: %q: %w", ErrInvalidWatchPath, path, err)
+	}
+	// path valid
+	return nil
+}
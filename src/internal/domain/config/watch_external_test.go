@@ -0,0 +1,66 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestWatchConfig_defaults tests the effective settings of a file watch.
+//
+// Params:
+//   - t: the testing context.
+func TestWatchConfig_defaults(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// watch is the watch configuration.
+		watch config.WatchConfig
+		// signal is the expected reload signal.
+		signal string
+		// timeout is the expected hook timeout.
+		timeout time.Duration
+		// debounce is the expected quiet period.
+		debounce time.Duration
+		// interval is the expected re-hash period.
+		interval time.Duration
+	}{
+		{
+			name:     "defaults",
+			watch:    config.WatchConfig{},
+			signal:   config.DefaultReloadSignal,
+			timeout:  config.DefaultWatchTimeout,
+			debounce: config.DefaultWatchDebounce,
+			interval: config.DefaultIntegrityInterval,
+		},
+		{
+			name: "configured",
+			watch: config.WatchConfig{
+				Signal:   "usr2",
+				Timeout:  shared.Duration(time.Minute),
+				Debounce: shared.Duration(2 * time.Second),
+				Interval: shared.Duration(time.Hour),
+			},
+			signal:   "SIGUSR2",
+			timeout:  time.Minute,
+			debounce: 2 * time.Second,
+			interval: time.Hour,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.signal, tt.watch.ReloadSignal())
+			assert.Equal(t, tt.timeout, tt.watch.WatchTimeout())
+			assert.Equal(t, tt.debounce, tt.watch.WatchDebounce())
+			assert.Equal(t, tt.interval, tt.watch.WatchInterval())
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Package config provides domain value objects for service configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// DefaultWatcherInterval is the period between watcher runs when none is configured.
+	DefaultWatcherInterval time.Duration = 30 * time.Second
+
+	// DefaultWatcherTimeout bounds a watcher run when no timeout is configured.
+	DefaultWatcherTimeout time.Duration = 10 * time.Second
+)
+
+// Watcher validation errors.
+var (
+	// ErrEmptyWatcherName indicates a watcher without a name.
+	ErrEmptyWatcherName error = errors.New("watcher name is required")
+	// ErrDuplicateWatcherName indicates two watchers with the same name.
+	ErrDuplicateWatcherName error = errors.New("duplicate watcher name")
+	// ErrWatcherWithoutCommand indicates a watcher without command.
+	ErrWatcherWithoutCommand error = errors.New("watcher requires a command")
+	// ErrWatcherWithoutEvent indicates a watcher emitting no event.
+	ErrWatcherWithoutEvent error = errors.New("watcher requires on_failure, on_recovery or on_output_change")
+	// ErrInvalidWatcherEvent indicates a malformed custom event name.
+	ErrInvalidWatcherEvent error = errors.New("watcher event names must be lowercase letters, digits and underscores, starting with a letter")
+	// ErrInvalidWatcherDuration indicates a negative watcher interval or timeout.
+	ErrInvalidWatcherDuration error = errors.New("watcher interval and timeout must not be negative")
+	// ErrUnknownWatcherService indicates a watcher reporting for an undefined service.
+	ErrUnknownWatcherService error = errors.New("watcher service is not defined")
+)
+
+// WatcherConfig runs a command periodically and turns the transitions of
+// its outcome into custom events, for conditions the daemon does not model
+// itself (RAID state, certificate expiry, replication lag, ...).
+type WatcherConfig struct {
+	// Name identifies the watcher in logs and errors.
+	Name string
+	// Command is the executable run on each check.
+	Command string
+	// Args are the arguments of the command.
+	Args []string
+	// Interval is the period between runs. Zero uses DefaultWatcherInterval.
+	Interval shared.Duration
+	// Timeout bounds each run. Zero uses DefaultWatcherTimeout.
+	Timeout shared.Duration
+	// Service is the service the events are reported for. Empty reports
+	// daemon-wide events.
+	Service string
+	// OnFailure is the event emitted when the command starts failing
+	// (non-zero exit or timeout). Empty emits none.
+	OnFailure string
+	// OnRecovery is the event emitted when the command succeeds again.
+	// Empty emits none.
+	OnRecovery string
+	// OnOutputChange is the event emitted when the standard output differs
+	// from the previous run. Empty emits none.
+	OnOutputChange string
+}
+
+// WatcherInterval returns the effective period between runs.
+//
+// Returns:
+//   - time.Duration: the configured interval, or DefaultWatcherInterval.
+func (w *WatcherConfig) WatcherInterval() time.Duration {
+	// fall back to the default interval
+	if w.Interval <= 0 {
+		// return default
+		return DefaultWatcherInterval
+	}
+	// return configured interval
+	return w.Interval.Duration()
+}
+
+// WatcherTimeout returns the effective bound of each run.
+//
+// Returns:
+//   - time.Duration: the configured timeout, or DefaultWatcherTimeout.
+func (w *WatcherConfig) WatcherTimeout() time.Duration {
+	// fall back to the default timeout
+	if w.Timeout <= 0 {
+		// return default
+		return DefaultWatcherTimeout
+	}
+	// return configured timeout
+	return w.Timeout.Duration()
+}
+
+// Events returns the custom event names the watcher may emit.
+//
+// Returns:
+//   - []string: the configured event names, in failure, recovery, output change order.
+func (w *WatcherConfig) Events() []string {
+	var events []string
+	// keep the configured names
+	for _, name := range []string{w.OnFailure, w.OnRecovery, w.OnOutputChange} {
+		// skip transitions without event
+		if name != "" {
+			events = append(events, name)
+		}
+	}
+	// return configured names
+	return events
+}
+
+// validateWatchers validates the watchers against the defined services.
+//
+// Params:
+//   - watchers: the watchers to validate.
+//   - services: the defined service names.
+//
+// Returns:
+//   - error: validation error if any.
+func validateWatchers(watchers []WatcherConfig, services map[string]bool) error {
+	seen := make(map[string]bool, len(watchers))
+	// validate each watcher
+	for i := range watchers {
+		w := &watchers[i]
+		// check if watcher has a name
+		if w.Name == "" {
+			// return error with watcher index
+			return fmt.Errorf("watcher %d: %w", i, ErrEmptyWatcherName)
+		}
+		// check for name reuse
+		if seen[w.Name] {
+			// return error with duplicated name
+			return fmt.Errorf("%w: %q", ErrDuplicateWatcherName, w.Name)
+		}
+		seen[w.Name] = true
+		// propagate watcher error with its name
+		if err := validateWatcher(w, services); err != nil {
+			// return error with watcher name
+			return fmt.Errorf("watcher %q: %w", w.Name, err)
+		}
+	}
+	// validation passed
+	return nil
+}
+
+// validateWatcher validates a single watcher.
+//
+// Params:
+//   - w: the watcher to validate.
+//   - services: the defined service names.
+//
+// Returns:
+//   - error: validation error if any.
+func validateWatcher(w *WatcherConfig, services map[string]bool) error {
+	// check the command
+	if w.Command == "" {
+		// return error when command is empty
+		return ErrWatcherWithoutCommand
+	}
+	// check the durations
+	if w.Interval < 0 || w.Timeout < 0 {
+		// return error on negative durations
+		return ErrInvalidWatcherDuration
+	}
+	// check the reported service
+	if w.Service != "" && !services[w.Service] {
+		// return error with service name
+		return fmt.Errorf("%w: %q", ErrUnknownWatcherService, w.Service)
+	}
+	events := w.Events()
+	// at least one transition must be reported
+	if len(events) == 0 {
+		// return error without events
+		return ErrWatcherWithoutEvent
+	}
+	// check each event name
+	for _, name := range events {
+		// reject malformed names
+		if !isEventName(name) {
+			// return error with the name
+			return fmt.Errorf("%w: %q", ErrInvalidWatcherEvent, name)
+		}
+	}
+	// watcher is valid
+	return nil
+}
+
+// isEventName reports whether a name matches [a-z][a-z0-9_]*, like the
+// built-in event names.
+//
+// Params:
+//   - name: the event name.
+//
+// Returns:
+//   - bool: true when the name is well formed.
+func isEventName(name string) bool {
+	// check each character
+	for i, r := range name {
+		lower := r >= 'a' && r <= 'z'
+		// digits and underscores are allowed after the first character only
+		if !lower && (i == 0 || (r != '_' && (r < '0' || r > '9'))) {
+			// invalid character
+			return false
+		}
+	}
+	// reject empty names
+	return name != ""
+}
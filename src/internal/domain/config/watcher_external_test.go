@@ -0,0 +1,60 @@
+// Package config provides domain value objects for service configuration.
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestWatcherConfig_Effective tests the effective watcher settings.
+//
+// Params:
+//   - t: the testing context.
+func TestWatcherConfig_Effective(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// watcher is the configured watcher.
+		watcher config.WatcherConfig
+		// wantInterval is the expected interval.
+		wantInterval time.Duration
+		// wantTimeout is the expected timeout.
+		wantTimeout time.Duration
+		// wantEvents is the expected event names.
+		wantEvents []string
+	}{
+		{
+			name:         "defaults",
+			watcher:      config.WatcherConfig{OnFailure: "raid_degraded"},
+			wantInterval: config.DefaultWatcherInterval,
+			wantTimeout:  config.DefaultWatcherTimeout,
+			wantEvents:   []string{"raid_degraded"},
+		},
+		{
+			name: "configured",
+			watcher: config.WatcherConfig{
+				Interval:       shared.Minutes(1),
+				Timeout:        shared.Seconds(5),
+				OnFailure:      "raid_degraded",
+				OnRecovery:     "raid_recovered",
+				OnOutputChange: "raid_changed",
+			},
+			wantInterval: time.Minute,
+			wantTimeout:  5 * time.Second,
+			wantEvents:   []string{"raid_degraded", "raid_recovered", "raid_changed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantInterval, tt.watcher.WatcherInterval())
+			assert.Equal(t, tt.wantTimeout, tt.watcher.WatcherTimeout())
+			assert.Equal(t, tt.wantEvents, tt.watcher.Events())
+		})
+	}
+}
@@ -2,12 +2,14 @@
 package config
 
 // WriterConfig defines configuration for a single log writer.
-// It supports multiple writer types (console, file, json) with individual level filtering.
+// It supports multiple writer types (console, file, json, journal) with individual level filtering.
 type WriterConfig struct {
-	// Type specifies the writer type: "console", "file", "json".
+	// Type specifies the writer type: "console", "file", "json", "journal".
 	Type string
 	// Level specifies the minimum log level for this writer.
 	Level string
+	// Console contains console writer specific configuration.
+	Console ConsoleWriterConfig
 	// File contains file writer specific configuration.
 	File FileWriterConfig
 	// JSON contains JSON writer specific configuration.
@@ -0,0 +1,60 @@
+// Package health provides domain abstractions for service probing.
+package health
+
+import "time"
+
+// Certificate is the leaf certificate served by a TLS listener.
+type Certificate struct {
+	// Subject is the distinguished name of the certificate subject.
+	Subject string
+	// Issuer is the distinguished name of the issuer.
+	Issuer string
+	// DNSNames are the subject alternative DNS names.
+	DNSNames []string
+	// SerialNumber is the serial number, in decimal.
+	SerialNumber string
+	// NotBefore is the start of the validity period.
+	NotBefore time.Time
+	// NotAfter is the expiry.
+	NotAfter time.Time
+	// Fingerprint is the hex SHA-256 digest of the DER certificate.
+	Fingerprint string
+}
+
+// Remaining returns how long the certificate stays valid.
+//
+// Params:
+//   - now: the reference time.
+//
+// Returns:
+//   - time.Duration: the time left before expiry, negative once expired.
+func (c *Certificate) Remaining(now time.Time) time.Duration {
+	// time until expiry
+	return c.NotAfter.Sub(now)
+}
+
+// CertificateStatus is the checked state of the certificate served by a
+// listener. A listener not checked yet has no certificate and no error.
+type CertificateStatus struct {
+	// Listener is the listener name.
+	Listener string
+	// Address is the checked address.
+	Address string
+	// Certificate is the certificate of the last successful check.
+	Certificate Certificate
+	// LastCheck is when the listener was last checked, zero if never.
+	LastCheck time.Time
+	// Error describes the last failure, empty when the last check passed.
+	Error string
+	// RenewedAt is when the renewal command last succeeded, zero if never.
+	RenewedAt time.Time
+}
+
+// Checked reports whether a certificate has been retrieved at least once.
+//
+// Returns:
+//   - bool: true once a certificate is known.
+func (s *CertificateStatus) Checked() bool {
+	// an empty fingerprint means no check succeeded yet
+	return s.Certificate.Fingerprint != ""
+}
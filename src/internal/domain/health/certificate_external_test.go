@@ -0,0 +1,58 @@
+// Package health_test provides black-box tests for certificate.go.
+package health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// TestCertificate_Remaining tests the validity left of certificates.
+//
+// Params:
+//   - t: the testing context.
+func TestCertificate_Remaining(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		notAfter time.Time
+		want     time.Duration
+	}{
+		{name: "valid", notAfter: now.Add(48 * time.Hour), want: 48 * time.Hour},
+		{name: "expired", notAfter: now.Add(-time.Hour), want: -time.Hour},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := health.Certificate{NotAfter: tt.notAfter}
+			assert.Equal(t, tt.want, cert.Remaining(now))
+		})
+	}
+}
+
+// TestCertificateStatus_Checked tests the checked state of listener certificates.
+//
+// Params:
+//   - t: the testing context.
+func TestCertificateStatus_Checked(t *testing.T) {
+	tests := []struct {
+		name   string
+		status health.CertificateStatus
+		want   bool
+	}{
+		{name: "not checked yet", status: health.CertificateStatus{Listener: "https"}},
+		{name: "failing", status: health.CertificateStatus{Listener: "https", LastCheck: time.Now(), Error: "connection refused"}},
+		{name: "checked", status: health.CertificateStatus{Listener: "https", LastCheck: time.Now(), Certificate: health.Certificate{Fingerprint: "ab12"}}, want: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.status.Checked())
+		})
+	}
+}
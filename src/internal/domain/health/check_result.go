@@ -7,7 +7,7 @@ import "time"
 // It contains the probe status, latency measurement, output, and any error.
 //
 // Fields are ordered by size for optimal memory alignment:
-// error interface (16B), string (16B), Duration (8B), bool (1B).
+// error interface (16B), string (16B), Duration (8B), bools (1B each).
 type CheckResult struct {
 	// Error holds any error that occurred during probing.
 	// When Success is false, this should contain the failure reason.
@@ -25,6 +25,10 @@ type CheckResult struct {
 
 	// Success indicates whether the probe succeeded.
 	Success bool
+
+	// Warning indicates a successful probe whose measure is past its warn
+	// threshold; the target is reported degraded.
+	Warning bool
 }
 
 // NewCheckResult creates a new probe result with the specified parameters.
@@ -64,6 +68,24 @@ func NewSuccessCheckResult(latency time.Duration, output string) CheckResult {
 	}
 }
 
+// NewWarningCheckResult creates a successful probe result past its warn threshold.
+//
+// Params:
+//   - latency: how long the probe took to complete.
+//   - output: any output from the probe.
+//
+// Returns:
+//   - CheckResult: a successful probe result with a warning.
+func NewWarningCheckResult(latency time.Duration, output string) CheckResult {
+	// return successful probe result with warning
+	return CheckResult{
+		Success: true,
+		Warning: true,
+		Latency: latency,
+		Output:  output,
+	}
+}
+
 // NewFailureCheckResult creates a failed probe result.
 //
 // Params:
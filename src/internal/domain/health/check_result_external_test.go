@@ -108,6 +108,18 @@ func TestNewSuccessCheckResult(t *testing.T) {
 	}
 }
 
+// TestNewWarningCheckResult tests successful results past their warn threshold.
+func TestNewWarningCheckResult(t *testing.T) {
+	result := health.NewWarningCheckResult(20*time.Millisecond, "value 1500")
+
+	assert.True(t, result.Success)
+	assert.True(t, result.Warning)
+	assert.Equal(t, 20*time.Millisecond, result.Latency)
+	assert.Equal(t, "value 1500", result.Output)
+	assert.Nil(t, result.Error)
+	assert.False(t, health.NewSuccessCheckResult(0, "").Warning)
+}
+
 // TestNewFailureCheckResult tests failed result creation.
 func TestNewFailureCheckResult(t *testing.T) {
 	tests := []struct {
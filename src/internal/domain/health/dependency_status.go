@@ -0,0 +1,43 @@
+// Package health provides domain abstractions for service probing.
+package health
+
+import "time"
+
+// DependencyStatus is the probed state of an external dependency of a service.
+// A dependency not probed yet is neither healthy nor down.
+type DependencyStatus struct {
+	// Name is the dependency name.
+	Name string
+	// Type is the probe type (tcp, http, grpc, ...).
+	Type string
+	// Address is the probed address.
+	Address string
+	// GateRestart reports that health-check restarts wait for the dependency.
+	GateRestart bool
+	// Healthy reports that the dependency passed its probe thresholds.
+	Healthy bool
+	// LastCheck is when the dependency was last probed, zero if never.
+	LastCheck time.Time
+	// Latency is how long the last probe took.
+	Latency time.Duration
+	// Error describes the last failure, empty when the last probe passed.
+	Error string
+}
+
+// Checked reports whether the dependency has been probed at least once.
+//
+// Returns:
+//   - bool: true once a probe result is known.
+func (d *DependencyStatus) Checked() bool {
+	// a zero time means no probe completed yet
+	return !d.LastCheck.IsZero()
+}
+
+// Down reports whether the dependency was probed and is not healthy.
+//
+// Returns:
+//   - bool: true if the dependency is known to be unavailable.
+func (d *DependencyStatus) Down() bool {
+	// unknown dependencies are not down
+	return d.Checked() && !d.Healthy
+}
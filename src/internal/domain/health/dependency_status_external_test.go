@@ -0,0 +1,36 @@
+// Package health_test provides black-box tests for dependency_status.go.
+package health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// TestDependencyStatus_Down tests the down state of external dependencies.
+//
+// Params:
+//   - t: the testing context.
+func TestDependencyStatus_Down(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      health.DependencyStatus
+		wantChecked bool
+		wantDown    bool
+	}{
+		{name: "not probed yet", status: health.DependencyStatus{Name: "db"}},
+		{name: "healthy", status: health.DependencyStatus{Name: "db", Healthy: true, LastCheck: time.Now()}, wantChecked: true},
+		{name: "failing", status: health.DependencyStatus{Name: "db", LastCheck: time.Now(), Error: "connection refused"}, wantChecked: true, wantDown: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantChecked, tt.status.Checked())
+			assert.Equal(t, tt.wantDown, tt.status.Down())
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package health provides domain abstractions for service probing.
+package health
+
+import "time"
+
+// ProbeAttempt records one execution of a traced probe.
+// Attempts are kept for probes with debug enabled, to investigate
+// intermittent health flaps without raising the global log level.
+type ProbeAttempt struct {
+	// Listener is the name of the probed listener.
+	Listener string
+	// Type is the probe type (tcp, http, grpc, ...).
+	Type string
+	// Target is the probed address, or the command for exec probes.
+	Target string
+	// Timestamp is when the probe completed.
+	Timestamp time.Time
+	// Latency is how long the probe took.
+	Latency time.Duration
+	// Success reports whether the probe succeeded.
+	Success bool
+	// Output is the probe output.
+	Output string
+	// Error describes the failure, empty on success.
+	Error string
+}
+
+// NewProbeAttempt records a probe result for a listener.
+//
+// Params:
+//   - listener: the probed listener name.
+//   - probeType: the probe type.
+//   - target: the probed address or command.
+//   - result: the probe result.
+//   - at: when the probe completed.
+//
+// Returns:
+//   - ProbeAttempt: the recorded attempt.
+func NewProbeAttempt(listener, probeType, target string, result CheckResult, at time.Time) ProbeAttempt {
+	attempt := ProbeAttempt{
+		Listener:  listener,
+		Type:      probeType,
+		Target:    target,
+		Timestamp: at,
+		Latency:   result.Latency,
+		Success:   result.Success,
+		Output:    result.Output,
+	}
+	// keep the error message only
+	if result.Error != nil {
+		attempt.Error = result.Error.Error()
+	}
+	// return recorded attempt
+	return attempt
+}
@@ -0,0 +1,43 @@
+// Package health_test provides black-box tests for probe_attempt.go.
+package health_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// TestNewProbeAttempt tests recording probe results.
+//
+// Params:
+//   - t: the testing context.
+func TestNewProbeAttempt(t *testing.T) {
+	at := time.Now()
+	tests := []struct {
+		name      string
+		result    health.CheckResult
+		wantError string
+	}{
+		{name: "success", result: health.NewSuccessCheckResult(time.Millisecond, "200 OK")},
+		{name: "failure", result: health.NewFailureCheckResult(time.Second, "", errors.New("connection refused")), wantError: "connection refused"},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := health.NewProbeAttempt("http", "tcp", "127.0.0.1:8080", tt.result, at)
+			assert.Equal(t, "http", got.Listener)
+			assert.Equal(t, "tcp", got.Type)
+			assert.Equal(t, "127.0.0.1:8080", got.Target)
+			assert.Equal(t, at, got.Timestamp)
+			assert.Equal(t, tt.result.Latency, got.Latency)
+			assert.Equal(t, tt.result.Success, got.Success)
+			assert.Equal(t, tt.result.Output, got.Output)
+			assert.Equal(t, tt.wantError, got.Error)
+		})
+	}
+}
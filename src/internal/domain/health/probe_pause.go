@@ -0,0 +1,66 @@
+// Package health provides domain abstractions for service probing.
+package health
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// DefaultProbePauseTTL bounds probe pauses requested without a TTL.
+	DefaultProbePauseTTL time.Duration = 15 * time.Minute
+	// MaxProbePauseTTL is the longest probe pause.
+	MaxProbePauseTTL time.Duration = 4 * time.Hour
+)
+
+// ErrInvalidProbePause indicates a probe pause request that cannot apply.
+var ErrInvalidProbePause error = shared.NewCodedError(shared.CodeInvalidArgument, "invalid probe pause")
+
+// ProbePauseSpec asks to stop probing the listeners of a service for a while,
+// so that health failures neither change its state nor trigger its unhealthy
+// actions during a debugging session.
+type ProbePauseSpec struct {
+	// Service is the service whose probes pause.
+	Service string
+	// Listener is the listener whose probe pauses; empty pauses every
+	// listener of the service.
+	Listener string
+	// TTL bounds the pause; probes resume once it elapses.
+	// Zero uses DefaultProbePauseTTL.
+	TTL time.Duration
+}
+
+// EffectiveTTL validates the spec and returns the length of the pause.
+//
+// Returns:
+//   - time.Duration: the TTL, or DefaultProbePauseTTL.
+//   - error: ErrInvalidProbePause describing the problem.
+func (s *ProbePauseSpec) EffectiveTTL() (time.Duration, error) {
+	// a service is required
+	if s.Service == "" {
+		// return error without service
+		return 0, fmt.Errorf("%w: service is required", ErrInvalidProbePause)
+	}
+	// bound the pause
+	if s.TTL < 0 || s.TTL > MaxProbePauseTTL {
+		// return error with the bound
+		return 0, fmt.Errorf("%w: ttl must be between 0 and %s", ErrInvalidProbePause, MaxProbePauseTTL)
+	}
+	// fall back to the default TTL
+	if s.TTL == 0 {
+		// return default
+		return DefaultProbePauseTTL, nil
+	}
+	// return requested TTL
+	return s.TTL, nil
+}
+
+// ProbePause is an active pause of the probes of a service.
+type ProbePause struct {
+	// Listener is the paused listener, empty when every listener is paused.
+	Listener string
+	// Until is when the probes resume.
+	Until time.Time
+}
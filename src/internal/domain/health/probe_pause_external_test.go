@@ -0,0 +1,50 @@
+// Package health_test provides black-box tests for probe_pause.go.
+package health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// TestProbePauseSpec_EffectiveTTL tests the validation and TTL of probe pauses.
+//
+// Params:
+//   - t: the testing context.
+func TestProbePauseSpec_EffectiveTTL(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// spec is the pause request.
+		spec health.ProbePauseSpec
+		// want is the expected TTL.
+		want time.Duration
+		// wantErr reports whether the spec is rejected.
+		wantErr bool
+	}{
+		{name: "default", spec: health.ProbePauseSpec{Service: "api"}, want: health.DefaultProbePauseTTL},
+		{name: "requested", spec: health.ProbePauseSpec{Service: "api", Listener: "http", TTL: time.Minute}, want: time.Minute},
+		{name: "maximum", spec: health.ProbePauseSpec{Service: "api", TTL: health.MaxProbePauseTTL}, want: health.MaxProbePauseTTL},
+		{name: "no_service", spec: health.ProbePauseSpec{TTL: time.Minute}, wantErr: true},
+		{name: "negative", spec: health.ProbePauseSpec{Service: "api", TTL: -time.Second}, wantErr: true},
+		{name: "too_long", spec: health.ProbePauseSpec{Service: "api", TTL: 2 * health.MaxProbePauseTTL}, wantErr: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := tt.spec.EffectiveTTL()
+			// Check rejected specs.
+			if tt.wantErr {
+				require.ErrorIs(t, err, health.ErrInvalidProbePause)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ttl)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Package health provides domain abstractions for service probing.
+package health
+
+// ScenarioStep is one HTTP request of a scenario probe.
+// Path, header values and body may reference variables extracted by
+// earlier steps as {{name}}.
+type ScenarioStep struct {
+	// Name identifies the step in failure reports.
+	Name string
+	// Method is the HTTP method. Default is GET.
+	Method string
+	// Path is the request path on the target address.
+	Path string
+	// Headers are the request headers.
+	Headers map[string]string
+	// Body is the request body.
+	Body string
+	// StatusCode is the expected response status. Default is 200.
+	StatusCode int
+	// Extract maps variable names to regular expressions matched against the
+	// response body; the first capture group becomes the variable value.
+	Extract map[string]string
+}
@@ -14,6 +14,8 @@ const (
 	SubjectListening SubjectState = "listening"
 	// SubjectClosed indicates the subject is not operational.
 	SubjectClosed SubjectState = "closed"
+	// SubjectConflicted indicates the subject's port is bound by another process.
+	SubjectConflicted SubjectState = "conflicted"
 	// SubjectRunning indicates a process is running.
 	SubjectRunning SubjectState = "running"
 	// SubjectStopped indicates a process is stopped.
@@ -41,13 +43,13 @@ func (s SubjectState) IsListening() bool {
 	return s == SubjectListening || s == SubjectReady
 }
 
-// IsClosed returns true if this state indicates closed/stopped/failed.
+// IsClosed returns true if this state indicates closed/conflicted/stopped/failed.
 //
 // Returns:
-//   - bool: true if state is closed, stopped, or failed, false otherwise.
+//   - bool: true if state is closed, conflicted, stopped, or failed, false otherwise.
 func (s SubjectState) IsClosed() bool {
-	// check for closed, stopped, or failed state
-	return s == SubjectClosed || s == SubjectStopped || s == SubjectFailed
+	// check for closed, conflicted, stopped, or failed state
+	return s == SubjectClosed || s == SubjectConflicted || s == SubjectStopped || s == SubjectFailed
 }
 
 // SubjectSnapshot represents a point-in-time view of a subject's state.
@@ -97,11 +99,11 @@ func (s SubjectSnapshot) IsListening() bool {
 	return s.State == SubjectListening || s.State == SubjectReady
 }
 
-// IsClosed returns true if the subject is closed/stopped/failed.
+// IsClosed returns true if the subject is closed/conflicted/stopped/failed.
 //
 // Returns:
-//   - bool: true if subject is closed, stopped, or failed, false otherwise.
+//   - bool: true if subject is closed, conflicted, stopped, or failed, false otherwise.
 func (s SubjectSnapshot) IsClosed() bool {
-	// check for closed, stopped, or failed state
-	return s.State == SubjectClosed || s.State == SubjectStopped || s.State == SubjectFailed
+	// delegate to state check
+	return s.State.IsClosed()
 }
@@ -132,6 +132,7 @@ func TestSubjectState_IsClosed(t *testing.T) {
 		expected bool
 	}{
 		{"closed_is_closed", health.SubjectClosed, true},
+		{"conflicted_is_closed", health.SubjectConflicted, true},
 		{"stopped_is_closed", health.SubjectStopped, true},
 		{"failed_is_closed", health.SubjectFailed, true},
 		{"ready_not_closed", health.SubjectReady, false},
@@ -201,6 +202,7 @@ func TestSubjectSnapshot_IsClosed(t *testing.T) {
 		expected bool
 	}{
 		{"closed", health.SubjectClosed, true},
+		{"conflicted", health.SubjectConflicted, true},
 		{"stopped", health.SubjectStopped, true},
 		{"failed", health.SubjectFailed, true},
 		{"ready", health.SubjectReady, false},
@@ -36,6 +36,12 @@ type Target struct {
 	// StatusCode is the expected HTTP status code for HTTP probes.
 	// Default is 200 if not specified.
 	StatusCode int
+
+	// Steps lists the HTTP requests of scenario probes, run in order.
+	Steps []ScenarioStep
+
+	// Threshold is the value check of threshold probes.
+	Threshold *Threshold
 }
 
 // NewTarget creates a new probe target with the specified network and address.
@@ -0,0 +1,33 @@
+// Package health provides domain abstractions for service probing.
+package health
+
+// Threshold is the value check of a threshold probe.
+type Threshold struct {
+	// Pattern is a regular expression whose first capture group is the
+	// value; empty reads the whole trimmed output.
+	Pattern string
+	// Warn is the value past which the probe reports a warning; nil for none.
+	Warn *float64
+	// Fail is the value past which the probe fails.
+	Fail float64
+	// Below makes the probe fail under the thresholds rather than above.
+	Below bool
+}
+
+// Exceeds reports whether a value is past a limit in the direction of the check.
+//
+// Params:
+//   - value: the measured value.
+//   - limit: the warn or fail threshold.
+//
+// Returns:
+//   - bool: true when the value is past the limit.
+func (t *Threshold) Exceeds(value, limit float64) bool {
+	// lower values are worse
+	if t.Below {
+		// return whether the value fell under the limit
+		return value < limit
+	}
+	// return whether the value rose over the limit
+	return value > limit
+}
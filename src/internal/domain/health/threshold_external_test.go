@@ -0,0 +1,37 @@
+// Package health_test provides black-box tests for the health package.
+package health_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// TestThreshold_Exceeds tests the direction of threshold checks.
+//
+// Params:
+//   - t: the testing context.
+func TestThreshold_Exceeds(t *testing.T) {
+	tests := []struct {
+		name  string
+		below bool
+		value float64
+		want  bool
+	}{
+		{name: "above over limit", value: 11, want: true},
+		{name: "above at limit", value: 10, want: false},
+		{name: "above under limit", value: 9, want: false},
+		{name: "below under limit", below: true, value: 9, want: true},
+		{name: "below at limit", below: true, value: 10, want: false},
+		{name: "below over limit", below: true, value: 11, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold := health.Threshold{Fail: 10, Below: tt.below}
+			assert.Equal(t, tt.want, threshold.Exceeds(tt.value, threshold.Fail))
+		})
+	}
+}
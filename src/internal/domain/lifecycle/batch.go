@@ -0,0 +1,105 @@
+// Package lifecycle provides domain types for daemon lifecycle management.
+package lifecycle
+
+import "github.com/kodflow/daemon/internal/domain/process"
+
+// DefaultBatchParallelism is how many services of a batch are acted on at
+// once when the request sets no limit.
+const DefaultBatchParallelism int = 4
+
+// ServiceAction is a lifecycle action requested for services.
+type ServiceAction string
+
+// Service action constants.
+const (
+	// ActionStart starts the services.
+	ActionStart ServiceAction = "start"
+	// ActionStop stops the services.
+	ActionStop ServiceAction = "stop"
+	// ActionRestart restarts the services.
+	ActionRestart ServiceAction = "restart"
+)
+
+// BatchRequest is one action applied to several services: the named ones
+// and those whose labels hold every selector pair.
+type BatchRequest struct {
+	// Action is the lifecycle action.
+	Action ServiceAction
+	// Services are the names of the target services.
+	Services []string
+	// Selector adds the services carrying each label with its value.
+	Selector map[string]string
+	// MaxParallel bounds the services acted on at once; zero means
+	// DefaultBatchParallelism.
+	MaxParallel int
+}
+
+// Parallelism returns the number of services acted on at once.
+//
+// Returns:
+//   - int: MaxParallel, or DefaultBatchParallelism when unset.
+func (r *BatchRequest) Parallelism() int {
+	// apply default when unset
+	if r.MaxParallel <= 0 {
+		// default bound
+		return DefaultBatchParallelism
+	}
+	// return configured bound
+	return r.MaxParallel
+}
+
+// Selects reports whether a service carrying the given labels matches the
+// selector of the request.
+//
+// Params:
+//   - labels: the labels of the service.
+//
+// Returns:
+//   - bool: true if the selector is set and every pair is present in labels.
+func (r *BatchRequest) Selects(labels map[string]string) bool {
+	// an empty selector adds no service
+	if len(r.Selector) == 0 {
+		// not selected
+		return false
+	}
+	// check each selector pair
+	for name, value := range r.Selector {
+		// reject missing or different labels
+		if got, ok := labels[name]; !ok || got != value {
+			// not selected
+			return false
+		}
+	}
+	// every pair matched
+	return true
+}
+
+// BatchResult is the outcome of a batch action on one service.
+type BatchResult struct {
+	// Service is the service name.
+	Service string
+	// Err is the action error, nil on success.
+	Err error
+	// Snapshot is the state of the service once the action completed.
+	Snapshot process.ServiceSnapshot
+}
+
+// BatchFailures returns the number of failed items of a batch.
+//
+// Params:
+//   - results: the batch results.
+//
+// Returns:
+//   - int: the count of results with an error.
+func BatchFailures(results []BatchResult) int {
+	var failed int
+	// count failed items
+	for i := range results {
+		// keep failures only
+		if results[i].Err != nil {
+			failed++
+		}
+	}
+	// return count
+	return failed
+}
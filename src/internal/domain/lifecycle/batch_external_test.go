@@ -0,0 +1,70 @@
+// Package lifecycle_test provides external tests for batch.go.
+package lifecycle_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+func TestBatchRequest_Parallelism(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		maxParallel int
+		want        int
+	}{
+		{name: "default", want: lifecycle.DefaultBatchParallelism},
+		{name: "negative", maxParallel: -1, want: lifecycle.DefaultBatchParallelism},
+		{name: "configured", maxParallel: 16, want: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := lifecycle.BatchRequest{MaxParallel: tt.maxParallel}
+			assert.Equal(t, tt.want, req.Parallelism())
+		})
+	}
+}
+
+func TestBatchRequest_Selects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		selector map[string]string
+		labels   map[string]string
+		want     bool
+	}{
+		{name: "empty_selector", labels: map[string]string{"team": "payments"}, want: false},
+		{name: "match", selector: map[string]string{"team": "payments"}, labels: map[string]string{"team": "payments", "tier": "web"}, want: true},
+		{name: "different_value", selector: map[string]string{"team": "payments"}, labels: map[string]string{"team": "search"}, want: false},
+		{name: "missing_label", selector: map[string]string{"team": "payments", "tier": "web"}, labels: map[string]string{"team": "payments"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := lifecycle.BatchRequest{Selector: tt.selector}
+			assert.Equal(t, tt.want, req.Selects(tt.labels))
+		})
+	}
+}
+
+func TestBatchFailures(t *testing.T) {
+	t.Parallel()
+
+	results := []lifecycle.BatchResult{
+		{Service: "web"},
+		{Service: "worker", Err: errors.New("boom")},
+		{Service: "api", Err: errors.New("boom")},
+	}
+
+	assert.Equal(t, 2, lifecycle.BatchFailures(results))
+	assert.Zero(t, lifecycle.BatchFailures(nil))
+}
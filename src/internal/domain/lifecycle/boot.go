@@ -0,0 +1,137 @@
+// Package lifecycle provides domain types for daemon lifecycle management.
+package lifecycle
+
+import "time"
+
+// BootStatus is the outcome of one service during the initial startup.
+type BootStatus int
+
+// Boot status constants.
+const (
+	// BootPending means the service has not become ready yet.
+	BootPending BootStatus = iota
+	// BootReady means the service started (and passed its probes, if any).
+	BootReady
+	// BootFailed means the service failed before becoming ready.
+	BootFailed
+	// BootSkipped means the service was not started because its start conditions are unmet.
+	BootSkipped
+)
+
+// String returns the string representation of the boot status.
+//
+// Returns:
+//   - string: the status name.
+func (s BootStatus) String() string {
+	// map status to name
+	switch s {
+	// waiting for readiness
+	case BootPending:
+		// pending name
+		return "pending"
+	// ready
+	case BootReady:
+		// ready name
+		return "ready"
+	// failed
+	case BootFailed:
+		// failed name
+		return "failed"
+	// skipped
+	case BootSkipped:
+		// skipped name
+		return "skipped"
+	// unknown status
+	default:
+		// unknown name
+		return "unknown"
+	}
+}
+
+// ServiceBoot is the boot outcome of one service.
+type ServiceBoot struct {
+	// Name is the service name.
+	Name string
+	// Critical reports whether the service is required for a successful boot.
+	Critical bool
+	// Status is the boot outcome.
+	Status BootStatus
+	// Duration is the time from boot start to readiness or failure.
+	Duration time.Duration
+	// Error describes the failure or the unmet conditions, empty unless
+	// Status is BootFailed or BootSkipped.
+	Error string
+}
+
+// BootReport summarizes the initial startup of the supervised services.
+type BootReport struct {
+	// StartedAt is when the supervisor began starting services.
+	StartedAt time.Time
+	// CompletedAt is when every service was resolved, zero while booting.
+	CompletedAt time.Time
+	// Aborted reports that a critical service failed and the boot failure
+	// policy requested a shutdown.
+	Aborted bool
+	// Services lists the outcome of each service in configuration order.
+	Services []ServiceBoot
+}
+
+// Complete reports whether the boot is over.
+//
+// Returns:
+//   - bool: true once every service is resolved or the boot was aborted.
+func (r *BootReport) Complete() bool {
+	// completion time is set once
+	return !r.CompletedAt.IsZero()
+}
+
+// Failed returns the services that failed to boot.
+//
+// Returns:
+//   - []ServiceBoot: the failed services, in configuration order.
+func (r *BootReport) Failed() []ServiceBoot {
+	var failed []ServiceBoot
+	// collect failed services
+	for _, svc := range r.Services {
+		// keep failed only
+		if svc.Status == BootFailed {
+			failed = append(failed, svc)
+		}
+	}
+	// return failed services
+	return failed
+}
+
+// CriticalFailed reports whether a critical service failed to boot.
+//
+// Returns:
+//   - bool: true if at least one critical service failed.
+func (r *BootReport) CriticalFailed() bool {
+	// look for a failed critical service
+	for _, svc := range r.Services {
+		// critical failure found
+		if svc.Critical && svc.Status == BootFailed {
+			// boot failed
+			return true
+		}
+	}
+	// no critical failure
+	return false
+}
+
+// Pending reports whether some service has not been resolved yet.
+//
+// Returns:
+//   - bool: true if at least one service is still pending.
+func (r *BootReport) Pending() bool {
+	// look for a pending service
+	for _, svc := range r.Services {
+		// pending service found
+		if svc.Status == BootPending {
+			// still booting
+			return true
+		}
+	}
+	// every service resolved
+	return false
+}
@@ -0,0 +1,89 @@
+// Package lifecycle_test provides external tests for boot.go.
+package lifecycle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+func TestBootStatus_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status lifecycle.BootStatus
+		want   string
+	}{
+		{name: "pending", status: lifecycle.BootPending, want: "pending"},
+		{name: "ready", status: lifecycle.BootReady, want: "ready"},
+		{name: "failed", status: lifecycle.BootFailed, want: "failed"},
+		{name: "skipped", status: lifecycle.BootSkipped, want: "skipped"},
+		{name: "unknown", status: lifecycle.BootStatus(99), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.status.String())
+		})
+	}
+}
+
+func TestBootReport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		report         lifecycle.BootReport
+		wantComplete   bool
+		wantPending    bool
+		wantCritical   bool
+		wantFailedName []string
+	}{
+		{
+			name: "still booting",
+			report: lifecycle.BootReport{Services: []lifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: lifecycle.BootReady},
+				{Name: "db", Critical: true, Status: lifecycle.BootPending},
+			}},
+			wantPending: true,
+		},
+		{
+			name: "non-critical failure",
+			report: lifecycle.BootReport{CompletedAt: time.Now(), Services: []lifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: lifecycle.BootReady},
+				{Name: "cron", Status: lifecycle.BootFailed},
+			}},
+			wantComplete:   true,
+			wantFailedName: []string{"cron"},
+		},
+		{
+			name: "critical failure",
+			report: lifecycle.BootReport{CompletedAt: time.Now(), Services: []lifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: lifecycle.BootFailed},
+			}},
+			wantComplete:   true,
+			wantCritical:   true,
+			wantFailedName: []string{"api"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.wantComplete, tt.report.Complete())
+			assert.Equal(t, tt.wantPending, tt.report.Pending())
+			assert.Equal(t, tt.wantCritical, tt.report.CriticalFailed())
+			var names []string
+			for _, svc := range tt.report.Failed() {
+				names = append(names, svc.Name)
+			}
+			assert.Equal(t, tt.wantFailedName, names)
+		})
+	}
+}
@@ -15,6 +15,8 @@ type SystemState struct {
 	CPU metrics.SystemCPU `json:"cpu"`
 	// Memory contains system memory metrics.
 	Memory metrics.SystemMemory `json:"memory"`
+	// Pressure contains host PSI for CPU, memory, and I/O.
+	Pressure metrics.ResourcePressure `json:"pressure"`
 }
 
 // DaemonState represents the complete state of the daemon.
@@ -35,6 +37,10 @@ type DaemonState struct {
 	Mesh *MeshTopology `json:"mesh,omitempty"`
 	// Kubernetes contains K8s state if available (optional).
 	Kubernetes *KubernetesState `json:"kubernetes,omitempty"`
+	// Sequence is the number of service events applied when the state was
+	// taken. A state reflects every control operation whose snapshot
+	// sequence is not greater.
+	Sequence uint64 `json:"sequence"`
 }
 
 // MeshTopology represents the mesh network topology.
@@ -0,0 +1,47 @@
+// Package lifecycle provides domain types for daemon lifecycle management.
+package lifecycle
+
+import (
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// ReloadStatus is the progress and last result of configuration reloads.
+// Reloads run one at a time; requests arriving while one is queued are
+// merged into it. Reload runs are numbered from 1 in start order, so a
+// request served by run N is done once Completed reaches N.
+type ReloadStatus struct {
+	// Running reports that a reload is being applied.
+	Running bool
+	// Pending reports that a reload is queued behind the running one.
+	Pending bool
+	// Requested counts the reload requests accepted.
+	Requested uint64
+	// Coalesced counts the requests merged into an already queued reload.
+	Coalesced uint64
+	// Started counts the reload runs started.
+	Started uint64
+	// Completed counts the reload runs finished, applied or refused.
+	Completed uint64
+	// LastStartedAt is when the last reload run started.
+	LastStartedAt time.Time
+	// LastFinishedAt is when the last reload run finished.
+	LastFinishedAt time.Time
+	// LastError describes why the last reload failed, empty on success.
+	LastError string
+	// LastErrorCode is the code of the last reload error, empty on success.
+	LastErrorCode shared.Code
+}
+
+// Done reports whether the reload run with the given number has finished.
+//
+// Params:
+//   - run: the reload run number returned when the reload was requested.
+//
+// Returns:
+//   - bool: true once the run completed.
+func (s *ReloadStatus) Done(run uint64) bool {
+	// runs complete in start order
+	return s.Completed >= run
+}
@@ -0,0 +1,33 @@
+// Package lifecycle_test provides external tests for reload.go.
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+func TestReloadStatus_Done(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status lifecycle.ReloadStatus
+		run    uint64
+		want   bool
+	}{
+		{name: "nothing completed", status: lifecycle.ReloadStatus{Started: 1, Running: true}, run: 1, want: false},
+		{name: "run completed", status: lifecycle.ReloadStatus{Started: 1, Completed: 1}, run: 1, want: true},
+		{name: "later run completed", status: lifecycle.ReloadStatus{Started: 3, Completed: 3}, run: 2, want: true},
+		{name: "queued run", status: lifecycle.ReloadStatus{Started: 2, Completed: 1, Pending: true}, run: 3, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.status.Done(tt.run))
+		})
+	}
+}
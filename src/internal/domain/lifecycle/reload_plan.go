@@ -0,0 +1,80 @@
+// Package lifecycle provides domain types for daemon lifecycle management.
+package lifecycle
+
+// ReloadAction is what a configuration reload does to one service.
+type ReloadAction int
+
+// Reload action constants.
+const (
+	// ReloadAdd means the service is new and gets started.
+	ReloadAdd ReloadAction = iota
+	// ReloadRemove means the service is gone and gets stopped.
+	ReloadRemove
+	// ReloadRestart means the service is kept and gets restarted.
+	ReloadRestart
+)
+
+// String returns the string representation of the reload action.
+//
+// Returns:
+//   - string: the action name.
+func (a ReloadAction) String() string {
+	// map action to name
+	switch a {
+	// started
+	case ReloadAdd:
+		// add name
+		return "add"
+	// stopped
+	case ReloadRemove:
+		// remove name
+		return "remove"
+	// restarted
+	case ReloadRestart:
+		// restart name
+		return "restart"
+	// unknown action
+	default:
+		// unknown name
+		return "unknown"
+	}
+}
+
+// ServicePlan is what a reload would do to one service, and why.
+type ServicePlan struct {
+	// Name is the service name.
+	Name string
+	// Action is what the reload does to the service.
+	Action ReloadAction
+	// Changes are the changed settings of a restarted service, by
+	// configuration key; empty when it is restarted unchanged.
+	Changes []string
+}
+
+// ReloadPlan is what a reload of a configuration would do, computed
+// without applying it.
+type ReloadPlan struct {
+	// Services are the affected services: kept and added ones in
+	// configuration order, then removed ones by name.
+	Services []ServicePlan
+}
+
+// Count returns the number of services the plan applies an action to.
+//
+// Params:
+//   - action: the reload action.
+//
+// Returns:
+//   - int: the count of services.
+func (p *ReloadPlan) Count(action ReloadAction) int {
+	var count int
+	// count matching services
+	for i := range p.Services {
+		// keep matching action only
+		if p.Services[i].Action == action {
+			count++
+		}
+	}
+	// return count
+	return count
+}
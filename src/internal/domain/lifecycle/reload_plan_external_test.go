@@ -0,0 +1,46 @@
+// Package lifecycle_test provides external tests for reload_plan.go.
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+func TestReloadAction_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		action lifecycle.ReloadAction
+		want   string
+	}{
+		{name: "add", action: lifecycle.ReloadAdd, want: "add"},
+		{name: "remove", action: lifecycle.ReloadRemove, want: "remove"},
+		{name: "restart", action: lifecycle.ReloadRestart, want: "restart"},
+		{name: "unknown", action: lifecycle.ReloadAction(99), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.action.String())
+		})
+	}
+}
+
+func TestReloadPlan_Count(t *testing.T) {
+	t.Parallel()
+
+	plan := lifecycle.ReloadPlan{Services: []lifecycle.ServicePlan{
+		{Name: "web", Action: lifecycle.ReloadRestart, Changes: []string{"command"}},
+		{Name: "worker", Action: lifecycle.ReloadRestart},
+		{Name: "api", Action: lifecycle.ReloadAdd},
+	}}
+
+	assert.Equal(t, 1, plan.Count(lifecycle.ReloadAdd))
+	assert.Equal(t, 0, plan.Count(lifecycle.ReloadRemove))
+	assert.Equal(t, 2, plan.Count(lifecycle.ReloadRestart))
+}
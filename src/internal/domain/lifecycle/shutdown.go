@@ -0,0 +1,45 @@
+// Package lifecycle provides domain types for daemon lifecycle management.
+package lifecycle
+
+import (
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// ServiceShutdown is the final state of one service when the daemon stopped.
+type ServiceShutdown struct {
+	// Stats are the lifetime statistics of the service, final stop included.
+	Stats metrics.ServiceStats
+	// Health is the health of the service when the shutdown began, before
+	// its probes and process were stopped.
+	Health health.AggregatedHealth
+}
+
+// ShutdownReport keeps the figures the daemon held in memory when it
+// stopped gracefully.
+type ShutdownReport struct {
+	// StartedAt is when the supervisor began starting services.
+	StartedAt time.Time
+	// StoppingAt is when the shutdown began.
+	StoppingAt time.Time
+	// StoppedAt is when every service was stopped.
+	StoppedAt time.Time
+	// Services lists the final state of each service, sorted by name.
+	Services []ServiceShutdown
+}
+
+// Uptime returns how long the supervisor ran.
+//
+// Returns:
+//   - time.Duration: the time from start to the end of the shutdown.
+func (r *ShutdownReport) Uptime() time.Duration {
+	// never started
+	if r.StartedAt.IsZero() {
+		// no uptime
+		return 0
+	}
+	// return run time
+	return r.StoppedAt.Sub(r.StartedAt)
+}
@@ -0,0 +1,36 @@
+// Package lifecycle_test provides external tests for shutdown.go.
+package lifecycle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+// TestShutdownReport_Uptime tests the run time of the supervisor.
+//
+// Params:
+//   - t: the testing context.
+func TestShutdownReport_Uptime(t *testing.T) {
+	start := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// report is the shutdown report.
+		report lifecycle.ShutdownReport
+		// want is the expected uptime.
+		want time.Duration
+	}{
+		{name: "never started", report: lifecycle.ShutdownReport{StoppedAt: start}},
+		{name: "ran", report: lifecycle.ShutdownReport{StartedAt: start, StoppedAt: start.Add(26 * time.Hour)}, want: 26 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.report.Uptime())
+		})
+	}
+}
@@ -117,3 +117,12 @@ func (l *Listener) MarkClosed() bool {
 	// transition to closed state
 	return l.SetState(StateClosed)
 }
+
+// MarkConflicted transitions the listener to StateConflicted state.
+//
+// Returns:
+//   - bool: true if transition was successful.
+func (l *Listener) MarkConflicted() bool {
+	// transition to conflicted state
+	return l.SetState(StateConflicted)
+}
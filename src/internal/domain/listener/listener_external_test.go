@@ -288,3 +288,47 @@ func TestListener_MarkClosed(t *testing.T) {
 		})
 	}
 }
+
+// TestListener_MarkConflicted tests MarkConflicted method.
+func TestListener_MarkConflicted(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialState  listener.State
+		shouldSucceed bool
+		expectedState listener.State
+	}{
+		{
+			name:          "from_closed",
+			initialState:  listener.StateClosed,
+			shouldSucceed: true,
+			expectedState: listener.StateConflicted,
+		},
+		{
+			name:          "from_ready",
+			initialState:  listener.StateReady,
+			shouldSucceed: true,
+			expectedState: listener.StateConflicted,
+		},
+		{
+			name:          "already_conflicted",
+			initialState:  listener.StateConflicted,
+			shouldSucceed: false,
+			expectedState: listener.StateConflicted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create listener with initial state.
+			l := listener.NewListener("test", "tcp", "localhost", 8080)
+			l.State = tt.initialState
+
+			// Mark as conflicted.
+			result := l.MarkConflicted()
+
+			// Verify result.
+			assert.Equal(t, tt.shouldSucceed, result)
+			assert.Equal(t, tt.expectedState, l.State)
+		})
+	}
+}
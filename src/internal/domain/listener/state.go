@@ -19,6 +19,10 @@ const (
 	// StateReady indicates health checks have passed.
 	// The listener is fully operational and ready for traffic.
 	StateReady
+
+	// StateConflicted indicates the port is bound by a process outside the service.
+	// Probes are not trusted while conflicted since another process answers them.
+	StateConflicted
 )
 
 // String returns the string representation of the state.
@@ -40,6 +44,10 @@ func (s State) String() string {
 	case StateReady:
 		// return ready state name
 		return "ready"
+	// conflicted state
+	case StateConflicted:
+		// return conflicted state name
+		return "conflicted"
 	// unknown state
 	default:
 		// return unknown for unmapped states
@@ -74,6 +82,15 @@ func (s State) IsReady() bool {
 	return s == StateReady
 }
 
+// IsConflicted returns true if the listener port is held by another process.
+//
+// Returns:
+//   - bool: true if state is Conflicted.
+func (s State) IsConflicted() bool {
+	// return conflicted state check
+	return s == StateConflicted
+}
+
 // CanTransitionTo checks if a transition to the target state is valid.
 //
 // Params:
@@ -86,15 +103,19 @@ func (s State) CanTransitionTo(target State) bool {
 	switch s {
 	// transitions from closed state
 	case StateClosed:
-		// from closed can only go to listening
-		return target == StateListening
+		// from closed can go to listening or conflicted
+		return target == StateListening || target == StateConflicted
 	// transitions from listening state
 	case StateListening:
-		// from listening can go to ready or closed
-		return target == StateReady || target == StateClosed
+		// from listening can go to ready, closed, or conflicted
+		return target == StateReady || target == StateClosed || target == StateConflicted
 	// transitions from ready state
 	case StateReady:
-		// from ready can go to listening or closed
+		// from ready can go to listening, closed, or conflicted
+		return target == StateListening || target == StateClosed || target == StateConflicted
+	// transitions from conflicted state
+	case StateConflicted:
+		// from conflicted can go back to listening or closed
 		return target == StateListening || target == StateClosed
 	// transitions from unknown state
 	default:
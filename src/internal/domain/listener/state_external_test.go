@@ -31,6 +31,11 @@ func TestState_String(t *testing.T) {
 			state:    listener.StateReady,
 			expected: "ready",
 		},
+		{
+			name:     "conflicted",
+			state:    listener.StateConflicted,
+			expected: "conflicted",
+		},
 		{
 			name:     "unknown",
 			state:    listener.State(99),
@@ -186,6 +191,36 @@ func TestState_CanTransitionTo(t *testing.T) {
 			to:       listener.StateClosed,
 			expected: true,
 		},
+		{
+			name:     "closed_to_conflicted",
+			from:     listener.StateClosed,
+			to:       listener.StateConflicted,
+			expected: true,
+		},
+		{
+			name:     "ready_to_conflicted",
+			from:     listener.StateReady,
+			to:       listener.StateConflicted,
+			expected: true,
+		},
+		{
+			name:     "conflicted_to_closed",
+			from:     listener.StateConflicted,
+			to:       listener.StateClosed,
+			expected: true,
+		},
+		{
+			name:     "conflicted_to_listening",
+			from:     listener.StateConflicted,
+			to:       listener.StateListening,
+			expected: true,
+		},
+		{
+			name:     "conflicted_to_ready",
+			from:     listener.StateConflicted,
+			to:       listener.StateReady,
+			expected: false,
+		},
 		{
 			name:     "unknown_state_returns_false",
 			from:     listener.State(99),
@@ -0,0 +1,26 @@
+// Package logging provides domain types for daemon event logging.
+package logging
+
+import (
+	"context"
+	"time"
+)
+
+// LogFile is a file a service output stream is written to.
+type LogFile struct {
+	// Path is the active file; rotated files are numbered after it.
+	Path string
+	// Stream is the output stream written to the file.
+	Stream string
+	// TimestampFormat is the format of the timestamp prefixing each line,
+	// empty when lines are written without timestamp.
+	TimestampFormat string
+}
+
+// OutputHistory is the port for reading service output from log files.
+// Infrastructure layer implements this interface over rotated files.
+type OutputHistory interface {
+	// Read sends the lines of a service written since a time and matching
+	// the filter, oldest first, until send fails or the files are read.
+	Read(ctx context.Context, service string, since time.Time, filter OutputFilter, send func(*OutputLine) error) error
+}
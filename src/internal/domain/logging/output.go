@@ -0,0 +1,146 @@
+// Package logging provides domain types for daemon event logging.
+package logging
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Output stream names.
+const (
+	// StreamStdout identifies the standard output of a service.
+	StreamStdout string = "stdout"
+	// StreamStderr identifies the standard error of a service.
+	StreamStderr string = "stderr"
+)
+
+// levelKeyword associates a lowercase line keyword with the level it denotes.
+type levelKeyword struct {
+	// keyword is the lowercase text searched in the line.
+	keyword string
+	// level is the level reported when the keyword is found.
+	level Level
+}
+
+// levelKeywords lists detected keywords from the most to the least severe.
+var levelKeywords []levelKeyword = []levelKeyword{
+	{keyword: "fatal", level: LevelError},
+	{keyword: "panic", level: LevelError},
+	{keyword: "error", level: LevelError},
+	{keyword: "warn", level: LevelWarn},
+	{keyword: "debug", level: LevelDebug},
+	{keyword: "trace", level: LevelDebug},
+}
+
+// OutputLine is one line captured from the stdout or stderr of a service.
+type OutputLine struct {
+	// Service is the name of the service that wrote the line.
+	Service string
+	// Stream is StreamStdout or StreamStderr.
+	Stream string
+	// Timestamp is when the line was captured.
+	Timestamp time.Time
+	// Level is the severity detected from the line content.
+	Level Level
+	// Message is the line without its trailing newline.
+	Message string
+}
+
+// NewOutputLine creates a captured line, detecting its level from the content.
+//
+// Params:
+//   - service: the service name.
+//   - stream: StreamStdout or StreamStderr.
+//   - message: the line without its trailing newline.
+//
+// Returns:
+//   - OutputLine: the captured line stamped with the current time.
+func NewOutputLine(service, stream, message string) OutputLine {
+	// construct line with detected level
+	return OutputLine{
+		Service:   service,
+		Stream:    stream,
+		Timestamp: time.Now(),
+		Level:     DetectLevel(message),
+		Message:   message,
+	}
+}
+
+// DetectLevel guesses the severity of a free-form output line.
+// It looks for common level keywords (ERROR, warn, level=debug, ...) and
+// falls back to LevelInfo.
+//
+// Params:
+//   - message: the line content.
+//
+// Returns:
+//   - Level: the detected level.
+func DetectLevel(message string) Level {
+	lower := strings.ToLower(message)
+	// check keywords from the most severe
+	for _, kw := range levelKeywords {
+		// keyword present in line
+		if strings.Contains(lower, kw.keyword) {
+			// return mapped level
+			return kw.level
+		}
+	}
+	// default severity
+	return LevelInfo
+}
+
+// OutputFilter selects captured lines.
+// Empty fields match every line.
+type OutputFilter struct {
+	// Services restricts lines to these service names.
+	Services []string
+	// MinLevel is the lowest level kept.
+	MinLevel Level
+	// Pattern, when set, must match the line message.
+	Pattern *regexp.Regexp
+}
+
+// Matches reports whether a line satisfies the filter.
+//
+// Params:
+//   - line: the line to check.
+//
+// Returns:
+//   - bool: true if the line matches every criterion.
+func (f *OutputFilter) Matches(line *OutputLine) bool {
+	// check service criterion
+	if len(f.Services) > 0 && !slices.Contains(f.Services, line.Service) {
+		// service excluded
+		return false
+	}
+	// check severity criterion
+	if line.Level < f.MinLevel {
+		// too verbose
+		return false
+	}
+	// check pattern criterion
+	return f.Pattern == nil || f.Pattern.MatchString(line.Message)
+}
+
+// OutputSubscription delivers captured lines to one reader.
+// Delivery never blocks the service: when the reader falls behind, lines
+// are dropped and counted instead.
+type OutputSubscription interface {
+	// Lines returns the channel of matching lines; it is closed by Close.
+	Lines() <-chan OutputLine
+	// Dropped returns the number of lines dropped because the reader was too slow.
+	Dropped() uint64
+	// Close stops delivery and releases the subscription.
+	Close()
+}
+
+// OutputStreamer is the port for reading captured service output.
+// Infrastructure layer implements this interface with an in-memory hub.
+type OutputStreamer interface {
+	// Tail returns up to n of the most recent matching lines, oldest first.
+	Tail(filter OutputFilter, n int) []OutputLine
+	// Subscribe returns the last tail matching lines followed by new ones.
+	Subscribe(filter OutputFilter, tail int) OutputSubscription
+}
@@ -0,0 +1,59 @@
+package logging_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		message  string
+		expected logging.Level
+	}{
+		{"server listening on :8080", logging.LevelInfo},
+		{"ERROR: connection refused", logging.LevelError},
+		{"level=warn msg=\"slow query\"", logging.LevelWarn},
+		{"[DEBUG] cache miss", logging.LevelDebug},
+		{"panic: runtime error", logging.LevelError},
+		{"TRACE entering handler", logging.LevelDebug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, logging.DetectLevel(tt.message))
+		})
+	}
+}
+
+func TestOutputFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	line := logging.NewOutputLine("api", logging.StreamStderr, "WARN disk almost full")
+
+	tests := []struct {
+		name     string
+		filter   logging.OutputFilter
+		expected bool
+	}{
+		{"empty filter", logging.OutputFilter{}, true},
+		{"matching service", logging.OutputFilter{Services: []string{"api"}}, true},
+		{"other service", logging.OutputFilter{Services: []string{"web"}}, false},
+		{"level reached", logging.OutputFilter{MinLevel: logging.LevelWarn}, true},
+		{"level too low", logging.OutputFilter{MinLevel: logging.LevelError}, false},
+		{"matching pattern", logging.OutputFilter{Pattern: regexp.MustCompile(`disk .* full`)}, true},
+		{"other pattern", logging.OutputFilter{Pattern: regexp.MustCompile(`^ERROR`)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.filter.Matches(&line))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import (
+	"slices"
+	"time"
+)
+
+// Availability windows.
+const (
+	// AvailabilityDay is the short availability window.
+	AvailabilityDay time.Duration = 24 * time.Hour
+	// AvailabilityWeek is the medium availability window.
+	AvailabilityWeek time.Duration = 7 * AvailabilityDay
+	// AvailabilityMonth is the long availability window, and the retention
+	// of availability logs.
+	AvailabilityMonth time.Duration = 30 * AvailabilityDay
+)
+
+// Availability is the share of observed time a service was up, in percent,
+// over the standard windows. A window without observed downtime reports 100.
+type Availability struct {
+	// Day is the availability over the last day.
+	Day float64 `json:"day"`
+	// Week is the availability over the last 7 days.
+	Week float64 `json:"week"`
+	// Month is the availability over the last 30 days.
+	Month float64 `json:"month"`
+}
+
+// AvailabilityHour is the time a service was observed up and down during
+// one hour.
+type AvailabilityHour struct {
+	// Start is the beginning of the hour.
+	Start time.Time
+	// Up is the time the service was running.
+	Up time.Duration
+	// Down is the time the service was not running.
+	Down time.Duration
+}
+
+// AvailabilityLog is the observed up and down time of a service in hourly
+// buckets, oldest first. Windows are resolved to the hour.
+type AvailabilityLog []AvailabilityHour
+
+// Record adds an interval during which the service was up or down, split
+// over the hours it spans, and drops hours older than AvailabilityMonth.
+//
+// Params:
+//   - from: the start of the interval.
+//   - to: the end of the interval.
+//   - up: true if the service was running.
+//
+// Returns:
+//   - AvailabilityLog: the updated log.
+func (l AvailabilityLog) Record(from, to time.Time, up bool) AvailabilityLog {
+	// split the interval at hour boundaries
+	for from.Before(to) {
+		hour := from.Truncate(time.Hour)
+		end := hour.Add(time.Hour)
+		// the interval ends within the hour
+		if to.Before(end) {
+			end = to
+		}
+		l = l.add(hour, end.Sub(from), up)
+		from = end
+	}
+	// keep the retention only
+	return l.Trim(to)
+}
+
+// add accounts a duration to the bucket of an hour.
+//
+// Params:
+//   - hour: the start of the hour.
+//   - d: the duration to add.
+//   - up: true to add uptime, false to add downtime.
+//
+// Returns:
+//   - AvailabilityLog: the updated log.
+func (l AvailabilityLog) add(hour time.Time, d time.Duration, up bool) AvailabilityLog {
+	// open a bucket unless the hour is the last one
+	if len(l) == 0 || !l[len(l)-1].Start.Equal(hour) {
+		l = append(l, AvailabilityHour{Start: hour})
+	}
+	last := &l[len(l)-1]
+	// account the duration
+	if up {
+		last.Up += d
+	} else {
+		last.Down += d
+	}
+	// return updated log
+	return l
+}
+
+// Trim drops the hours that ended more than AvailabilityMonth before now.
+//
+// Params:
+//   - now: the current time.
+//
+// Returns:
+//   - AvailabilityLog: the retained hours.
+func (l AvailabilityLog) Trim(now time.Time) AvailabilityLog {
+	cutoff := now.Add(-AvailabilityMonth)
+	// find the first hour to keep
+	i := 0
+	for i < len(l) && !l[i].Start.Add(time.Hour).After(cutoff) {
+		i++
+	}
+	// return retained hours
+	return l[i:]
+}
+
+// Merge combines two logs, summing the hours present in both.
+//
+// Params:
+//   - other: the log to merge.
+//
+// Returns:
+//   - AvailabilityLog: the merged log, oldest first.
+func (l AvailabilityLog) Merge(other AvailabilityLog) AvailabilityLog {
+	merged := slices.Clone(l)
+	// add each hour of the other log
+	for _, h := range other {
+		i, found := slices.BinarySearchFunc(merged, h.Start, func(e AvailabilityHour, t time.Time) int {
+			// order by hour start
+			return e.Start.Compare(t)
+		})
+		// sum hours present in both
+		if found {
+			merged[i].Up += h.Up
+			merged[i].Down += h.Down
+			continue
+		}
+		merged = slices.Insert(merged, i, h)
+	}
+	// return merged log
+	return merged
+}
+
+// Percent returns the availability over a window ending now.
+//
+// Params:
+//   - window: the window length.
+//   - now: the end of the window.
+//
+// Returns:
+//   - float64: the share of observed time the service was up, 100 without
+//     observed downtime.
+func (l AvailabilityLog) Percent(window time.Duration, now time.Time) float64 {
+	cutoff := now.Add(-window)
+	var up, down time.Duration
+	// sum the hours overlapping the window
+	for _, h := range l {
+		// hour ended before the window
+		if !h.Start.Add(time.Hour).After(cutoff) {
+			continue
+		}
+		up += h.Up
+		down += h.Down
+	}
+	// no downtime observed
+	if down == 0 {
+		// fully available
+		return percentMultiplier
+	}
+	// compute the up share
+	return float64(up) / float64(up+down) * percentMultiplier
+}
+
+// Availability returns the availability over the standard windows.
+//
+// Params:
+//   - now: the end of the windows.
+//
+// Returns:
+//   - Availability: the day, week and month availability.
+func (l AvailabilityLog) Availability(now time.Time) Availability {
+	// compute each window
+	return Availability{
+		Day:   l.Percent(AvailabilityDay, now),
+		Week:  l.Percent(AvailabilityWeek, now),
+		Month: l.Percent(AvailabilityMonth, now),
+	}
+}
@@ -0,0 +1,102 @@
+// Package metrics_test provides black-box tests for the metrics package.
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// TestAvailabilityLog_Record tests that intervals are split over hours.
+func TestAvailabilityLog_Record(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	var log metrics.AvailabilityLog
+	log = log.Record(base, base.Add(time.Hour), true)
+	log = log.Record(base.Add(time.Hour), base.Add(90*time.Minute), false)
+
+	require.Len(t, log, 2)
+	assert.Equal(t, base.Truncate(time.Hour), log[0].Start)
+	assert.Equal(t, 30*time.Minute, log[0].Up)
+	assert.Equal(t, 30*time.Minute, log[1].Up)
+	assert.Equal(t, 30*time.Minute, log[1].Down)
+}
+
+// TestAvailabilityLog_Trim tests that hours beyond the retention are dropped.
+func TestAvailabilityLog_Trim(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	log := metrics.AvailabilityLog{
+		{Start: now.Add(-metrics.AvailabilityMonth - time.Hour), Up: time.Hour},
+		{Start: now.Add(-time.Hour), Up: time.Hour},
+	}
+
+	trimmed := log.Trim(now)
+
+	require.Len(t, trimmed, 1)
+	assert.Equal(t, now.Add(-time.Hour), trimmed[0].Start)
+}
+
+// TestAvailabilityLog_Merge tests that shared hours are summed and order is kept.
+func TestAvailabilityLog_Merge(t *testing.T) {
+	t.Parallel()
+
+	h := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := metrics.AvailabilityLog{{Start: h.Add(time.Hour), Up: time.Minute}}
+	saved := metrics.AvailabilityLog{{Start: h, Down: time.Minute}, {Start: h.Add(time.Hour), Up: time.Minute}}
+
+	merged := current.Merge(saved)
+
+	require.Len(t, merged, 2)
+	assert.Equal(t, h, merged[0].Start)
+	assert.Equal(t, 2*time.Minute, merged[1].Up)
+	assert.Len(t, current, 1)
+}
+
+// TestAvailabilityLog_Availability tests the availability windows.
+func TestAvailabilityLog_Availability(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		log  metrics.AvailabilityLog
+		want metrics.Availability
+	}{
+		{
+			name: "nothing_observed",
+			want: metrics.Availability{Day: 100, Week: 100, Month: 100},
+		},
+		{
+			name: "recent_outage",
+			log:  metrics.AvailabilityLog{{Start: now.Add(-time.Hour), Up: 45 * time.Minute, Down: 15 * time.Minute}},
+			want: metrics.Availability{Day: 75, Week: 75, Month: 75},
+		},
+		{
+			name: "old_outage",
+			log: metrics.AvailabilityLog{
+				{Start: now.Add(-10 * metrics.AvailabilityDay), Down: time.Hour},
+				{Start: now.Add(-time.Hour), Up: time.Hour},
+			},
+			want: metrics.Availability{Day: 100, Week: 100, Month: 50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.log.Availability(now)
+			assert.InDelta(t, tt.want.Day, got.Day, 0.001)
+			assert.InDelta(t, tt.want.Week, got.Week, 0.001)
+			assert.InDelta(t, tt.want.Month, got.Month, 0.001)
+		})
+	}
+}
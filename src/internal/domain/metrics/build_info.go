@@ -0,0 +1,19 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import "time"
+
+// BuildInfo identifies the build of the daemon binary, so that fleet
+// tooling can audit the versions deployed.
+type BuildInfo struct {
+	// Version is the release version, "dev" for local builds.
+	Version string
+	// Commit is the revision the binary was built from, empty when unknown.
+	Commit string
+	// BuildDate is when the binary was built; zero when unknown.
+	BuildDate time.Time
+	// GoVersion is the Go toolchain version, such as "go1.25.6".
+	GoVersion string
+	// Features are the optional capabilities compiled in, sorted.
+	Features []string
+}
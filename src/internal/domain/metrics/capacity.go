@@ -0,0 +1,20 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+// HostCapacity is the memory and CPU available to supervised services: the
+// host resources, bounded by the limits of the daemon's own cgroup.
+type HostCapacity struct {
+	// Memory is the available memory in bytes.
+	Memory uint64
+	// CPUs is the number of available CPUs, fractional under a CPU quota.
+	CPUs float64
+}
+
+// IsZero reports whether the capacity is unknown.
+//
+// Returns:
+//   - bool: true when neither memory nor CPUs are known.
+func (c HostCapacity) IsZero() bool {
+	// nothing measured
+	return c.Memory == 0 && c.CPUs == 0
+}
@@ -0,0 +1,73 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import "time"
+
+// CPUThrottling represents CFS bandwidth throttling counters for a cgroup.
+// The counters come from cpu.stat and are cumulative since cgroup creation,
+// so throttling ratios are computed from the delta between two samples.
+type CPUThrottling struct {
+	// Timestamp is when this sample was taken.
+	Timestamp time.Time
+	// ThrottledTime is the cumulative time the cgroup spent throttled.
+	ThrottledTime time.Duration
+	// Periods is the number of enforcement periods that have elapsed.
+	Periods uint64
+	// ThrottledPeriods is the number of periods in which the cgroup was throttled.
+	ThrottledPeriods uint64
+}
+
+// NewCPUThrottling creates a new CPUThrottling instance.
+//
+// Params:
+//   - periods: number of elapsed enforcement periods
+//   - throttledPeriods: number of periods in which the cgroup was throttled
+//   - throttledTime: cumulative time spent throttled
+//   - timestamp: when this sample was taken
+//
+// Returns:
+//   - CPUThrottling: the created CPUThrottling instance
+func NewCPUThrottling(periods, throttledPeriods uint64, throttledTime time.Duration, timestamp time.Time) CPUThrottling {
+	// initialize with all throttling counters
+	return CPUThrottling{
+		Periods:          periods,
+		ThrottledPeriods: throttledPeriods,
+		ThrottledTime:    throttledTime,
+		Timestamp:        timestamp,
+	}
+}
+
+// ThrottledPercent returns the share of wall time spent throttled since a previous sample.
+// Returns 0 when the samples are out of order or counters were reset.
+//
+// Params:
+//   - prev: the previous throttling sample
+//
+// Returns:
+//   - float64: throttled time as a percentage of elapsed wall time
+func (t CPUThrottling) ThrottledPercent(prev CPUThrottling) float64 {
+	elapsed := t.Timestamp.Sub(prev.Timestamp)
+	// no elapsed wall time means no ratio can be computed
+	if elapsed <= 0 {
+		// invalid time delta
+		return 0
+	}
+
+	// counters going backwards means the cgroup was recreated
+	if t.ThrottledTime < prev.ThrottledTime {
+		// invalid counter delta
+		return 0
+	}
+
+	// compute throttled time relative to elapsed wall time
+	return float64(t.ThrottledTime-prev.ThrottledTime) / float64(elapsed) * percentMultiplier
+}
+
+// IsThrottled returns true if the cgroup was throttled during any period.
+//
+// Returns:
+//   - bool: true if at least one period was throttled
+func (t CPUThrottling) IsThrottled() bool {
+	// check throttled period counter
+	return t.ThrottledPeriods > 0
+}
@@ -0,0 +1,82 @@
+// Package metrics_test provides black-box tests for the metrics package.
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// TestNewCPUThrottling tests the NewCPUThrottling constructor.
+func TestNewCPUThrottling(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	throttling := metrics.NewCPUThrottling(100, 25, 2*time.Second, ts)
+
+	// Verify all fields are correctly set.
+	assert.Equal(t, uint64(100), throttling.Periods)
+	assert.Equal(t, uint64(25), throttling.ThrottledPeriods)
+	assert.Equal(t, 2*time.Second, throttling.ThrottledTime)
+	assert.Equal(t, ts, throttling.Timestamp)
+}
+
+// TestCPUThrottling_ThrottledPercent tests the ThrottledPercent method.
+func TestCPUThrottling_ThrottledPercent(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		prev metrics.CPUThrottling
+		curr metrics.CPUThrottling
+		want float64
+	}{
+		{
+			name: "quarter_of_wall_time",
+			prev: metrics.CPUThrottling{ThrottledTime: time.Second, Timestamp: base},
+			curr: metrics.CPUThrottling{ThrottledTime: 2 * time.Second, Timestamp: base.Add(4 * time.Second)},
+			want: 25.0,
+		},
+		{
+			name: "no_throttling",
+			prev: metrics.CPUThrottling{ThrottledTime: time.Second, Timestamp: base},
+			curr: metrics.CPUThrottling{ThrottledTime: time.Second, Timestamp: base.Add(5 * time.Second)},
+			want: 0,
+		},
+		{
+			name: "zero_elapsed_time",
+			prev: metrics.CPUThrottling{ThrottledTime: time.Second, Timestamp: base},
+			curr: metrics.CPUThrottling{ThrottledTime: 2 * time.Second, Timestamp: base},
+			want: 0,
+		},
+		{
+			name: "counter_reset",
+			prev: metrics.CPUThrottling{ThrottledTime: 5 * time.Second, Timestamp: base},
+			curr: metrics.CPUThrottling{ThrottledTime: time.Second, Timestamp: base.Add(time.Second)},
+			want: 0,
+		},
+	}
+
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Verify computed percentage.
+			assert.InDelta(t, tt.want, tt.curr.ThrottledPercent(tt.prev), 0.001)
+		})
+	}
+}
+
+// TestCPUThrottling_IsThrottled tests the IsThrottled method.
+func TestCPUThrottling_IsThrottled(t *testing.T) {
+	t.Parallel()
+
+	// Verify throttled periods drive the result.
+	assert.False(t, metrics.CPUThrottling{Periods: 10}.IsThrottled())
+	assert.True(t, metrics.CPUThrottling{Periods: 10, ThrottledPeriods: 1}.IsThrottled())
+}
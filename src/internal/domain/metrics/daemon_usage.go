@@ -0,0 +1,53 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import "time"
+
+// SelfUsage is the memory and file descriptors held by the daemon process,
+// as seen by the operating system.
+type SelfUsage struct {
+	// RSS is the resident set size in bytes.
+	RSS uint64
+	// OpenFDs is the number of open file descriptors.
+	OpenFDs uint32
+}
+
+// DaemonUsage is the resource usage of the daemon itself, used to detect the
+// supervisor misbehaving: leaking memory, goroutines or descriptors, falling
+// behind on events, or stalling in probe loops.
+type DaemonUsage struct {
+	// SelfUsage is the operating system view; zero when it cannot be read.
+	SelfUsage
+	// Goroutines is the number of live goroutines.
+	Goroutines int
+	// HeapAlloc is the number of bytes of allocated heap objects.
+	HeapAlloc uint64
+	// GCCycles is the number of completed garbage collections.
+	GCCycles uint32
+	// GCPauseTotal is the cumulative stop-the-world pause time.
+	GCPauseTotal time.Duration
+	// LastGCPause is the pause of the last garbage collection.
+	LastGCPause time.Duration
+	// Queues are the depths of the internal event queues.
+	Queues []QueueDepth
+	// Loops are the durations of the last iteration of periodic loops.
+	Loops []LoopLatency
+}
+
+// QueueDepth is the number of pending items of a bounded queue.
+type QueueDepth struct {
+	// Name identifies the queue, such as "events/api" or "reload".
+	Name string
+	// Depth is the number of pending items.
+	Depth int
+	// Capacity is the number of items the queue holds before blocking.
+	Capacity int
+}
+
+// LoopLatency is how long the last iteration of a periodic loop took.
+type LoopLatency struct {
+	// Name identifies the loop, such as "metrics" or "health/api".
+	Name string
+	// Latency is the duration of the last iteration.
+	Latency time.Duration
+}
@@ -0,0 +1,200 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// TopExitCauses is how many failure modes the statistics of a service report.
+const TopExitCauses int = 5
+
+// exitDay is the resolution of exit logs.
+const exitDay time.Duration = 24 * time.Hour
+
+// ExitCause is how a failed process ended: with an exit code, or killed by
+// a signal. It tells a configuration error (exit 2) from a crash (SIGSEGV).
+type ExitCause struct {
+	// Code is the exit code, zero when the process was killed by a signal.
+	Code int
+	// Signal is the POSIX name of the signal that killed the process,
+	// empty for an exit code.
+	Signal string
+}
+
+// String returns the signal name, or "exit" followed by the exit code.
+//
+// Returns:
+//   - string: the cause, SIGSEGV or exit 2.
+func (c ExitCause) String() string {
+	// killed by a signal
+	if c.Signal != "" {
+		// return the signal name
+		return c.Signal
+	}
+	// return the exit code
+	return "exit " + strconv.Itoa(c.Code)
+}
+
+// ExitDay counts the failures of a service with one cause during one day.
+type ExitDay struct {
+	// Start is the beginning of the day, in UTC.
+	Start time.Time
+	// Cause is how the process ended.
+	Cause ExitCause
+	// Count is the number of failures.
+	Count int
+	// Last is when the last of them occurred.
+	Last time.Time
+}
+
+// ExitCount is the number of failures of a service with one cause over a window.
+type ExitCount struct {
+	// Cause is how the process ended.
+	Cause ExitCause
+	// Count is the number of failures.
+	Count int
+	// Last is when the last of them occurred.
+	Last time.Time
+}
+
+// ExitLog is the histogram of the exit causes of a service in daily
+// buckets, oldest day first, retained for AvailabilityMonth.
+type ExitLog []ExitDay
+
+// Record counts a failure and drops the days older than AvailabilityMonth.
+//
+// Params:
+//   - cause: how the process ended.
+//   - at: when it ended.
+//
+// Returns:
+//   - ExitLog: the updated log.
+func (l ExitLog) Record(cause ExitCause, at time.Time) ExitLog {
+	day := at.UTC().Truncate(exitDay)
+	// look for the bucket among the buckets of the day
+	for i := len(l) - 1; i >= 0 && l[i].Start.Equal(day); i-- {
+		// the cause already failed this day
+		if l[i].Cause == cause {
+			l[i].Count++
+			l[i].Last = later(l[i].Last, at)
+			// keep the retention only
+			return l.Trim(at)
+		}
+	}
+	l = append(l, ExitDay{Start: day, Cause: cause, Count: 1, Last: at})
+	// keep the retention only
+	return l.Trim(at)
+}
+
+// Trim drops the days that ended more than AvailabilityMonth before now.
+//
+// Params:
+//   - now: the current time.
+//
+// Returns:
+//   - ExitLog: the retained days.
+func (l ExitLog) Trim(now time.Time) ExitLog {
+	cutoff := now.Add(-AvailabilityMonth)
+	// find the first day to keep
+	i := 0
+	for i < len(l) && !l[i].Start.Add(exitDay).After(cutoff) {
+		i++
+	}
+	// return retained days
+	return l[i:]
+}
+
+// Merge combines two logs, summing the causes of the days present in both.
+//
+// Params:
+//   - other: the log to merge.
+//
+// Returns:
+//   - ExitLog: the combined log, oldest day first.
+func (l ExitLog) Merge(other ExitLog) ExitLog {
+	merged := slices.Clone(l)
+	// add each bucket of the other log
+	for _, bucket := range other {
+		i := slices.IndexFunc(merged, func(d ExitDay) bool {
+			// same day and cause
+			return d.Start.Equal(bucket.Start) && d.Cause == bucket.Cause
+		})
+		// a new cause or day
+		if i < 0 {
+			merged = append(merged, bucket)
+			continue
+		}
+		merged[i].Count += bucket.Count
+		merged[i].Last = later(merged[i].Last, bucket.Last)
+	}
+	slices.SortStableFunc(merged, func(a, b ExitDay) int {
+		// order by day
+		return a.Start.Compare(b.Start)
+	})
+	// return combined log
+	return merged
+}
+
+// Top counts the failures of each cause over the window ending now, most
+// frequent first, the most recent first among equals.
+//
+// Params:
+//   - now: the end of the window.
+//   - window: the length of the window, resolved to the day.
+//   - limit: the number of causes to return, zero for all.
+//
+// Returns:
+//   - []ExitCount: the failure modes of the service.
+func (l ExitLog) Top(now time.Time, window time.Duration, limit int) []ExitCount {
+	cutoff := now.Add(-window)
+	var counts []ExitCount
+	// sum the days within the window
+	for _, bucket := range l {
+		// the day ended before the window
+		if !bucket.Start.Add(exitDay).After(cutoff) {
+			continue
+		}
+		i := slices.IndexFunc(counts, func(c ExitCount) bool {
+			// same cause
+			return c.Cause == bucket.Cause
+		})
+		// first failure of the cause
+		if i < 0 {
+			counts = append(counts, ExitCount{Cause: bucket.Cause, Count: bucket.Count, Last: bucket.Last})
+			continue
+		}
+		counts[i].Count += bucket.Count
+		counts[i].Last = later(counts[i].Last, bucket.Last)
+	}
+	slices.SortFunc(counts, func(a, b ExitCount) int {
+		// most frequent, then most recent, then by name
+		return cmp.Or(cmp.Compare(b.Count, a.Count), b.Last.Compare(a.Last), cmp.Compare(a.Cause.String(), b.Cause.String()))
+	})
+	// keep the most frequent
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	// return the failure modes
+	return counts
+}
+
+// later returns the later of two times.
+//
+// Params:
+//   - a: a time.
+//   - b: another time.
+//
+// Returns:
+//   - time.Time: the later one.
+func later(a, b time.Time) time.Time {
+	// b is more recent
+	if b.After(a) {
+		// return b
+		return b
+	}
+	// return a
+	return a
+}
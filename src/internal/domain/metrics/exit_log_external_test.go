@@ -0,0 +1,114 @@
+// Package metrics_test provides black-box tests for the metrics package.
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// TestExitCause_String tests the names of exit causes.
+func TestExitCause_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "exit 2", metrics.ExitCause{Code: 2}.String())
+	assert.Equal(t, "SIGSEGV", metrics.ExitCause{Signal: "SIGSEGV"}.String())
+}
+
+// TestExitLog_Record tests that failures are counted per day and cause.
+func TestExitLog_Record(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	config := metrics.ExitCause{Code: 2}
+	crash := metrics.ExitCause{Signal: "SIGSEGV"}
+
+	var log metrics.ExitLog
+	log = log.Record(config, base)
+	log = log.Record(crash, base.Add(time.Minute))
+	log = log.Record(config, base.Add(time.Hour))
+	log = log.Record(config, base.Add(24*time.Hour))
+
+	require.Len(t, log, 3)
+	assert.Equal(t, metrics.ExitDay{Start: base.Truncate(24 * time.Hour), Cause: config, Count: 2, Last: base.Add(time.Hour)}, log[0])
+	assert.Equal(t, 1, log[1].Count)
+	assert.Equal(t, crash, log[1].Cause)
+	assert.Equal(t, base.Add(24*time.Hour).Truncate(24*time.Hour), log[2].Start)
+
+	// Days beyond the retention are dropped.
+	log = log.Record(crash, base.Add(metrics.AvailabilityMonth+24*time.Hour))
+	require.Len(t, log, 2)
+	assert.Equal(t, config, log[0].Cause)
+}
+
+// TestExitLog_Merge tests that shared days and causes are summed and order is kept.
+func TestExitLog_Merge(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := metrics.ExitCause{Code: 2}
+	current := metrics.ExitLog{{Start: day.Add(24 * time.Hour), Cause: config, Count: 1, Last: day.Add(25 * time.Hour)}}
+	saved := metrics.ExitLog{
+		{Start: day, Cause: config, Count: 3, Last: day.Add(time.Hour)},
+		{Start: day.Add(24 * time.Hour), Cause: config, Count: 2, Last: day.Add(26 * time.Hour)},
+	}
+
+	merged := current.Merge(saved)
+
+	require.Len(t, merged, 2)
+	assert.Equal(t, day, merged[0].Start)
+	assert.Equal(t, 3, merged[1].Count)
+	assert.Equal(t, day.Add(26*time.Hour), merged[1].Last)
+	assert.Equal(t, 1, current[0].Count)
+}
+
+// TestExitLog_Top tests that causes are ranked by count, then recency, within the window.
+func TestExitLog_Top(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	day := now.Truncate(24 * time.Hour)
+	config := metrics.ExitCause{Code: 2}
+	crash := metrics.ExitCause{Signal: "SIGSEGV"}
+	oom := metrics.ExitCause{Signal: "SIGKILL"}
+	log := metrics.ExitLog{
+		{Start: day.Add(-5 * 24 * time.Hour), Cause: crash, Count: 9, Last: day.Add(-5 * 24 * time.Hour)},
+		{Start: day.Add(-24 * time.Hour), Cause: config, Count: 2, Last: day.Add(-23 * time.Hour)},
+		{Start: day.Add(-24 * time.Hour), Cause: oom, Count: 3, Last: day.Add(-22 * time.Hour)},
+		{Start: day, Cause: config, Count: 1, Last: now},
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		limit  int
+		want   []metrics.ExitCount
+	}{
+		{
+			name:   "day",
+			window: 24 * time.Hour,
+			want: []metrics.ExitCount{
+				{Cause: config, Count: 3, Last: now},
+				{Cause: oom, Count: 3, Last: day.Add(-22 * time.Hour)},
+			},
+		},
+		{
+			name:   "month limited",
+			window: metrics.AvailabilityMonth,
+			limit:  1,
+			want:   []metrics.ExitCount{{Cause: crash, Count: 9, Last: day.Add(-5 * 24 * time.Hour)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, log.Top(now, tt.window, tt.limit))
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+// Names of the platform-dependent features reported by HostInventory.Features.
+const (
+	// FeatureCgroupMetrics is the CPU throttling collection of services, read from their cgroup.
+	FeatureCgroupMetrics string = "cgroup_metrics"
+	// FeatureCgroupPressure is the pressure stall collection of services, read from their cgroup v2.
+	FeatureCgroupPressure string = "cgroup_pressure"
+	// FeatureShedding is the stop of low-priority services under host memory pressure.
+	FeatureShedding string = "shedding"
+	// FeatureEgress is the restriction of the outbound traffic of services.
+	FeatureEgress string = "egress"
+	// FeatureSecurityLabels is the start of services under an SELinux context or AppArmor profile.
+	FeatureSecurityLabels string = "security_labels"
+	// FeatureNamespaces is the start of services in private mount and PID namespaces.
+	FeatureNamespaces string = "namespaces"
+	// FeatureReaping is the reaping of orphaned processes by the daemon.
+	FeatureReaping string = "reaping"
+	// FeatureSystemdDiscovery is the discovery of systemd units.
+	FeatureSystemdDiscovery string = "systemd_discovery"
+	// FeatureContainerDiscovery is the discovery of containers through a runtime socket.
+	FeatureContainerDiscovery string = "container_discovery"
+)
+
+// osLinux is the operating system the cgroup, LSM and namespace features are built for.
+const osLinux string = "linux"
+
+// HostInventory describes the platform capabilities detected on the host
+// running the daemon, from which the support of each optional feature is
+// derived.
+type HostInventory struct {
+	// OS is the operating system the daemon was built for, such as "linux".
+	OS string
+	// Architecture is the architecture the daemon was built for, such as "amd64".
+	Architecture string
+	// KernelVersion is the kernel release, empty when unknown.
+	KernelVersion string
+	// CgroupVersion is the version of the cgroup hierarchy: 1, 2, or 0 without one.
+	CgroupVersion int
+	// PressureStall reports whether the kernel exposes pressure stall information.
+	PressureStall bool
+	// SecurityModules are the active Linux security modules among "apparmor" and "selinux".
+	SecurityModules []string
+	// Nftables reports whether the nft command is installed.
+	Nftables bool
+	// Systemd reports whether systemd is the init system of the host.
+	Systemd bool
+	// ContainerSockets are the container runtime sockets found.
+	ContainerSockets []ContainerSocket
+	// PID1 reports whether the daemon runs as PID 1.
+	PID1 bool
+	// Privileged reports whether the daemon runs as root.
+	Privileged bool
+	// MaxFDs is the soft limit of open file descriptors of the daemon.
+	MaxFDs uint64
+	// MaxFDsHard is the hard limit of open file descriptors of the daemon.
+	MaxFDsHard uint64
+}
+
+// ContainerSocket is the API socket of a container runtime found on the host.
+type ContainerSocket struct {
+	// Runtime is the container runtime, such as "docker" or "podman".
+	Runtime string
+	// Path is the path of the socket.
+	Path string
+}
+
+// FeatureSupport tells whether an optional feature can run on the host.
+type FeatureSupport struct {
+	// Name is the feature name, one of the Feature constants.
+	Name string
+	// Supported reports whether the feature can run.
+	Supported bool
+	// Reason explains why an unsupported feature is disabled, empty when supported.
+	Reason string
+}
+
+// Features derives the support of each optional feature from the
+// inventory, in a fixed order.
+//
+// Returns:
+//   - []FeatureSupport: the support of every feature.
+func (h *HostInventory) Features() []FeatureSupport {
+	// return every feature with its requirement
+	return []FeatureSupport{
+		feature(FeatureCgroupMetrics, h.CgroupVersion > 0, "no cgroup hierarchy mounted"),
+		h.cgroupPressure(),
+		feature(FeatureShedding, h.PressureStall, "kernel without pressure stall information"),
+		h.egress(),
+		feature(FeatureSecurityLabels, len(h.SecurityModules) > 0, "no SELinux or AppArmor module active"),
+		h.namespaces(),
+		feature(FeatureReaping, h.PID1, "not PID 1, orphans are reaped by the host init"),
+		feature(FeatureSystemdDiscovery, h.Systemd, "systemd is not the init system"),
+		feature(FeatureContainerDiscovery, len(h.ContainerSockets) > 0, "no container runtime socket found"),
+	}
+}
+
+// Supports returns the support of one feature.
+//
+// Params:
+//   - name: the feature name.
+//
+// Returns:
+//   - FeatureSupport: the support of the feature; unknown features are unsupported.
+func (h *HostInventory) Supports(name string) FeatureSupport {
+	// find the feature
+	for _, support := range h.Features() {
+		// feature found
+		if support.Name == name {
+			// return its support
+			return support
+		}
+	}
+	// return unknown feature
+	return FeatureSupport{Name: name, Reason: "unknown feature"}
+}
+
+// cgroupPressure derives the support of cgroup PSI, which needs a cgroup v2
+// hierarchy and a kernel with PSI.
+//
+// Returns:
+//   - FeatureSupport: the support of cgroup PSI.
+func (h *HostInventory) cgroupPressure() FeatureSupport {
+	// report the first missing requirement
+	switch {
+	case h.CgroupVersion != 2:
+		// return missing cgroup v2
+		return feature(FeatureCgroupPressure, false, "needs a cgroup v2 hierarchy")
+	case !h.PressureStall:
+		// return missing PSI
+		return feature(FeatureCgroupPressure, false, "kernel without pressure stall information")
+	default:
+		// return supported
+		return feature(FeatureCgroupPressure, true, "")
+	}
+}
+
+// egress derives the support of egress rules, which need a cgroup v2
+// hierarchy to classify sockets, nftables and root to load the rules.
+//
+// Returns:
+//   - FeatureSupport: the support of egress rules.
+func (h *HostInventory) egress() FeatureSupport {
+	// report the first missing requirement
+	switch {
+	case h.CgroupVersion != 2:
+		// return missing cgroup v2
+		return feature(FeatureEgress, false, "needs a cgroup v2 hierarchy")
+	case !h.Nftables:
+		// return missing nft
+		return feature(FeatureEgress, false, "nft command not installed")
+	case !h.Privileged:
+		// return missing privileges
+		return feature(FeatureEgress, false, "daemon not running as root")
+	default:
+		// return supported
+		return feature(FeatureEgress, true, "")
+	}
+}
+
+// namespaces derives the support of private namespaces, which need Linux
+// and root to be created.
+//
+// Returns:
+//   - FeatureSupport: the support of namespaces.
+func (h *HostInventory) namespaces() FeatureSupport {
+	// report the first missing requirement
+	switch {
+	case h.OS != osLinux:
+		// return unsupported platform
+		return feature(FeatureNamespaces, false, "only available on Linux")
+	case !h.Privileged:
+		// return missing privileges
+		return feature(FeatureNamespaces, false, "daemon not running as root")
+	default:
+		// return supported
+		return feature(FeatureNamespaces, true, "")
+	}
+}
+
+// feature builds the support of a feature, keeping the reason only when
+// the feature is unsupported.
+//
+// Params:
+//   - name: the feature name.
+//   - supported: whether the feature can run.
+//   - reason: why the feature is disabled otherwise.
+//
+// Returns:
+//   - FeatureSupport: the support of the feature.
+func feature(name string, supported bool, reason string) FeatureSupport {
+	// a supported feature needs no reason
+	if supported {
+		// return supported
+		return FeatureSupport{Name: name, Supported: true}
+	}
+	// return disabled with its reason
+	return FeatureSupport{Name: name, Reason: reason}
+}
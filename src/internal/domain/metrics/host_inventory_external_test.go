@@ -0,0 +1,113 @@
+// Package metrics_test provides external tests for the metrics domain package.
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// TestHostInventory_Features tests the support derived for each feature.
+func TestHostInventory_Features(t *testing.T) {
+	t.Parallel()
+
+	full := metrics.HostInventory{
+		OS:               "linux",
+		CgroupVersion:    2,
+		PressureStall:    true,
+		SecurityModules:  []string{"apparmor"},
+		Nftables:         true,
+		Systemd:          true,
+		ContainerSockets: []metrics.ContainerSocket{{Runtime: "docker", Path: "/run/docker.sock"}},
+		PID1:             true,
+		Privileged:       true,
+	}
+	features := full.Features()
+	require.Len(t, features, 9)
+	// Every feature runs on a fully capable host.
+	for _, support := range features {
+		assert.True(t, support.Supported, support.Name)
+		assert.Empty(t, support.Reason, support.Name)
+	}
+
+	// Nothing runs on a bare host, and each reason is given.
+	bare := metrics.HostInventory{OS: "darwin"}
+	for _, support := range bare.Features() {
+		assert.False(t, support.Supported, support.Name)
+		assert.NotEmpty(t, support.Reason, support.Name)
+	}
+}
+
+// TestHostInventory_Supports tests the requirements of single features.
+func TestHostInventory_Supports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		inventory metrics.HostInventory
+		feature   string
+		supported bool
+		reason    string
+	}{
+		{
+			name:      "egress on cgroup v1",
+			inventory: metrics.HostInventory{OS: "linux", CgroupVersion: 1, Nftables: true, Privileged: true},
+			feature:   metrics.FeatureEgress,
+			reason:    "needs a cgroup v2 hierarchy",
+		},
+		{
+			name:      "egress without nft",
+			inventory: metrics.HostInventory{OS: "linux", CgroupVersion: 2, Privileged: true},
+			feature:   metrics.FeatureEgress,
+			reason:    "nft command not installed",
+		},
+		{
+			name:      "egress unprivileged",
+			inventory: metrics.HostInventory{OS: "linux", CgroupVersion: 2, Nftables: true},
+			feature:   metrics.FeatureEgress,
+			reason:    "daemon not running as root",
+		},
+		{
+			name:      "cgroup metrics on cgroup v1",
+			inventory: metrics.HostInventory{OS: "linux", CgroupVersion: 1},
+			feature:   metrics.FeatureCgroupMetrics,
+			supported: true,
+		},
+		{
+			name:      "cgroup pressure on cgroup v1",
+			inventory: metrics.HostInventory{OS: "linux", CgroupVersion: 1},
+			feature:   metrics.FeatureCgroupPressure,
+			reason:    "needs a cgroup v2 hierarchy",
+		},
+		{
+			name:      "cgroup pressure without psi",
+			inventory: metrics.HostInventory{OS: "linux", CgroupVersion: 2},
+			feature:   metrics.FeatureCgroupPressure,
+			reason:    "kernel without pressure stall information",
+		},
+		{
+			name:      "namespaces outside linux",
+			inventory: metrics.HostInventory{OS: "freebsd", Privileged: true},
+			feature:   metrics.FeatureNamespaces,
+			reason:    "only available on Linux",
+		},
+		{
+			name:      "unknown feature",
+			inventory: metrics.HostInventory{OS: "linux"},
+			feature:   "teleport",
+			reason:    "unknown feature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			support := tt.inventory.Supports(tt.feature)
+			assert.Equal(t, metrics.FeatureSupport{Name: tt.feature, Supported: tt.supported, Reason: tt.reason}, support)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import "time"
+
+// MemoryDetail is the memory attribution of a process read from
+// /proc/[pid]/smaps_rollup, with its major page faults.
+//
+// Unlike RSS, which counts shared pages fully in every process mapping
+// them, PSS splits each shared page between its mappers and USS only
+// counts the pages no other process maps, so the memory of services
+// sharing libraries or forked workers adds up correctly.
+type MemoryDetail struct {
+	// Timestamp is when this sample was taken.
+	Timestamp time.Time
+	// PID is the process identifier.
+	PID int
+	// StartTime is the process start time in clock ticks after boot,
+	// telling a reused PID apart.
+	StartTime uint64
+	// PSS is the proportional set size in bytes.
+	PSS uint64
+	// USS is the unique set size in bytes (private clean and dirty pages).
+	USS uint64
+	// Swap is the swapped-out memory in bytes.
+	Swap uint64
+	// SwapPSS is the proportional share of the swapped-out memory in bytes.
+	SwapPSS uint64
+	// MajorFaults is the number of page faults that required disk I/O
+	// since the process started.
+	MajorFaults uint64
+}
+
+// SameProcess reports whether two samples were taken from the same
+// process, which a reused PID is not.
+//
+// Params:
+//   - other: the other sample.
+//
+// Returns:
+//   - bool: true when the PID and start time match.
+func (d *MemoryDetail) SameProcess(other *MemoryDetail) bool {
+	// a reused PID has a later start time
+	return d.PID == other.PID && d.StartTime == other.StartTime
+}
+
+// MajorFaultRate returns the major page faults per second since a previous
+// sample of the same process.
+//
+// Params:
+//   - prev: the previous sample.
+//
+// Returns:
+//   - float64: the faults per second, zero when the samples are not comparable.
+func (d *MemoryDetail) MajorFaultRate(prev *MemoryDetail) float64 {
+	elapsed := d.Timestamp.Sub(prev.Timestamp).Seconds()
+	// the counter restarts with another process
+	if !d.SameProcess(prev) || elapsed <= 0 || d.MajorFaults < prev.MajorFaults {
+		// no comparable baseline
+		return 0
+	}
+	// return faults per second
+	return float64(d.MajorFaults-prev.MajorFaults) / elapsed
+}
+
+// ApplyTo copies the attribution of the sample into process memory
+// metrics, with the major fault rate since a previous sample.
+//
+// Params:
+//   - mem: the process memory metrics to update.
+//   - prev: the previous sample, zero when none.
+func (d *MemoryDetail) ApplyTo(mem *ProcessMemory, prev *MemoryDetail) {
+	mem.PSS = d.PSS
+	mem.USS = d.USS
+	mem.Swap = d.Swap
+	mem.SwapPSS = d.SwapPSS
+	mem.MajorFaults = d.MajorFaults
+	mem.MajorFaultRate = d.MajorFaultRate(prev)
+}
@@ -0,0 +1,76 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// TestMemoryDetail_MajorFaultRate tests the fault rate between two samples.
+func TestMemoryDetail_MajorFaultRate(t *testing.T) {
+	start := time.Now()
+	prev := metrics.MemoryDetail{Timestamp: start, PID: 42, StartTime: 1000, MajorFaults: 100}
+
+	tests := []struct {
+		name     string
+		curr     metrics.MemoryDetail
+		expected float64
+	}{
+		{
+			name:     "faults_per_second",
+			curr:     metrics.MemoryDetail{Timestamp: start.Add(2 * time.Second), PID: 42, StartTime: 1000, MajorFaults: 150},
+			expected: 25,
+		},
+		{
+			name:     "reused_pid",
+			curr:     metrics.MemoryDetail{Timestamp: start.Add(2 * time.Second), PID: 42, StartTime: 2000, MajorFaults: 150},
+			expected: 0,
+		},
+		{
+			name:     "counter_reset",
+			curr:     metrics.MemoryDetail{Timestamp: start.Add(2 * time.Second), PID: 42, StartTime: 1000, MajorFaults: 10},
+			expected: 0,
+		},
+		{
+			name:     "no_elapsed_time",
+			curr:     metrics.MemoryDetail{Timestamp: start, PID: 42, StartTime: 1000, MajorFaults: 150},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.curr.MajorFaultRate(&prev), 0.001)
+		})
+	}
+}
+
+// TestMemoryDetail_ApplyTo tests that the attribution overlays process memory.
+func TestMemoryDetail_ApplyTo(t *testing.T) {
+	start := time.Now()
+	prev := metrics.MemoryDetail{Timestamp: start, PID: 7, StartTime: 5, MajorFaults: 10}
+	curr := metrics.MemoryDetail{
+		Timestamp:   start.Add(time.Second),
+		PID:         7,
+		StartTime:   5,
+		PSS:         3000,
+		USS:         2000,
+		Swap:        512,
+		SwapPSS:     256,
+		MajorFaults: 14,
+	}
+	mem := metrics.ProcessMemory{PID: 7, RSS: 8000, Swap: 500}
+
+	curr.ApplyTo(&mem, &prev)
+
+	assert.Equal(t, uint64(8000), mem.RSS)
+	assert.Equal(t, uint64(3000), mem.PSS)
+	assert.Equal(t, uint64(2000), mem.USS)
+	assert.Equal(t, uint64(512), mem.Swap)
+	assert.Equal(t, uint64(256), mem.SwapPSS)
+	assert.Equal(t, uint64(14), mem.MajorFaults)
+	assert.InDelta(t, 4.0, mem.MajorFaultRate, 0.001)
+}
@@ -62,3 +62,60 @@ func (p *Pressure) IsUnderPressure() bool {
 	// check if either some or full pressure exceeds threshold
 	return p.SomeAvg10 > PressureThreshold || p.FullAvg10 > PressureThreshold
 }
+
+// Average returns the stall percentage for a PSI scope and averaging window.
+//
+// Params:
+//   - scope: PSI scope ("some" or "full")
+//   - window: averaging window ("avg10", "avg60" or "avg300")
+//
+// Returns:
+//   - float64: the stall percentage
+//   - bool: false if scope or window is unknown
+func (p *Pressure) Average(scope, window string) (float64, bool) {
+	some := scope == PressureScopeSome
+	// reject unknown scopes
+	if !some && scope != PressureScopeFull {
+		// unknown scope
+		return 0, false
+	}
+
+	// select averaging window
+	switch window {
+	// 10-second window
+	case PressureWindowAvg10:
+		// return 10s average for scope
+		return pickScope(some, p.SomeAvg10, p.FullAvg10), true
+	// 60-second window
+	case PressureWindowAvg60:
+		// return 60s average for scope
+		return pickScope(some, p.SomeAvg60, p.FullAvg60), true
+	// 300-second window
+	case PressureWindowAvg300:
+		// return 300s average for scope
+		return pickScope(some, p.SomeAvg300, p.FullAvg300), true
+	// unknown window
+	default:
+		// reject unknown windows
+		return 0, false
+	}
+}
+
+// pickScope selects the some or full value.
+//
+// Params:
+//   - some: true to select the some value
+//   - someValue: value for the some scope
+//   - fullValue: value for the full scope
+//
+// Returns:
+//   - float64: the selected value
+func pickScope(some bool, someValue, fullValue float64) float64 {
+	// select some value when requested
+	if some {
+		// some scope
+		return someValue
+	}
+	// full scope
+	return fullValue
+}
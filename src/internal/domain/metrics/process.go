@@ -24,6 +24,10 @@ type ProcessMetrics struct {
 	CPU ProcessCPU
 	// Memory contains memory usage metrics for the process.
 	Memory ProcessMemory
+	// ThrottledPercent is the share of wall time the process cgroup spent CPU-throttled.
+	ThrottledPercent float64
+	// Pressure is the PSI of the process cgroup (cgroup v2 only).
+	Pressure ResourcePressure
 	// NumFDs is the number of open file descriptors for the process.
 	NumFDs uint32
 	// ReadBytesPerSec is the disk read rate in bytes per second.
@@ -58,6 +62,8 @@ func NewProcessMetrics(params *ProcessMetricsParams) *ProcessMetrics {
 		Healthy:          params.Healthy,
 		CPU:              params.CPU,
 		Memory:           params.Memory,
+		ThrottledPercent: params.ThrottledPercent,
+		Pressure:         params.Pressure,
 		NumFDs:           params.NumFDs,
 		ReadBytesPerSec:  params.ReadBytesPerSec,
 		WriteBytesPerSec: params.WriteBytesPerSec,
@@ -3,6 +3,10 @@ package metrics
 
 import "time"
 
+// DefaultClockTicks is USER_HZ on every Linux architecture: the clock
+// ticks per second of CPU times when a sample does not tell.
+const DefaultClockTicks uint64 = 100
+
 // ProcessCPU represents per-process CPU metrics collected from /proc/[pid]/stat.
 //
 // This value object captures the CPU time used by a specific process and its children.
@@ -27,8 +31,17 @@ type ProcessCPU struct {
 	ChildrenSystem uint64
 	// StartTime is when the process started (jiffies after system boot).
 	StartTime uint64
-	// UsagePercent is the calculated CPU usage percentage (0-100).
+	// ClockTicks is the number of jiffies per second (CLK_TCK), zero when
+	// unknown and DefaultClockTicks applies.
+	ClockTicks uint64
+	// UsagePercent is the CPU usage in the configured mode: PerCorePercent
+	// or NormalizedPercent.
 	UsagePercent float64
+	// PerCorePercent is the CPU usage where a fully busy CPU is 100%
+	// (0 to N*100 on N CPUs).
+	PerCorePercent float64
+	// NormalizedPercent is the CPU usage as a share of every CPU (0-100).
+	NormalizedPercent float64
 }
 
 // NewProcessCPU creates a new ProcessCPU instance.
@@ -48,11 +61,53 @@ func NewProcessCPU(params *ProcessCPUParams) *ProcessCPU {
 		ChildrenUser:   params.ChildrenUser,
 		ChildrenSystem: params.ChildrenSystem,
 		StartTime:      params.StartTime,
+		ClockTicks:     params.ClockTicks,
 		UsagePercent:   params.UsagePercent,
 		Timestamp:      params.Timestamp,
 	}
 }
 
+// TicksPerSecond returns the number of jiffies per second of the CPU times.
+//
+// Returns:
+//   - uint64: ClockTicks, or DefaultClockTicks when unknown.
+func (p *ProcessCPU) TicksPerSecond() uint64 {
+	// fall back to USER_HZ
+	if p.ClockTicks == 0 {
+		// return default rate
+		return DefaultClockTicks
+	}
+	// return detected rate
+	return p.ClockTicks
+}
+
+// TicksToDuration converts jiffies of this sample to a duration.
+//
+// Params:
+//   - ticks: the jiffies.
+//
+// Returns:
+//   - time.Duration: the CPU time.
+func (p *ProcessCPU) TicksToDuration(ticks uint64) time.Duration {
+	hz := p.TicksPerSecond()
+	// whole seconds first, so large counters do not overflow
+	return time.Duration(ticks/hz)*time.Second + time.Duration(ticks%hz)*time.Second/time.Duration(hz)
+}
+
+// SameProcess reports whether two samples come from the same process.
+// A PID reused by a new process has another start time: its counters
+// restart from zero and cannot be compared with the previous ones.
+//
+// Params:
+//   - other: the other sample.
+//
+// Returns:
+//   - bool: true when the PID and the start time match.
+func (p *ProcessCPU) SameProcess(other *ProcessCPU) bool {
+	// a process is identified by its PID and start time
+	return p.PID == other.PID && p.StartTime == other.StartTime
+}
+
 // Total returns the total CPU time used by this process.
 //
 // Returns:
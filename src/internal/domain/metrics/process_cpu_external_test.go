@@ -240,3 +240,33 @@ func TestNewProcessCPU(t *testing.T) {
 		})
 	}
 }
+
+// TestProcessCPU_TicksToDuration tests the conversion of jiffies with the sample clock rate.
+func TestProcessCPU_TicksToDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		proc     metrics.ProcessCPU
+		ticks    uint64
+		expected time.Duration
+	}{
+		{name: "default_rate", proc: metrics.ProcessCPU{}, ticks: 250, expected: 2500 * time.Millisecond},
+		{name: "detected_rate", proc: metrics.ProcessCPU{ClockTicks: 1000}, ticks: 250, expected: 250 * time.Millisecond},
+		{name: "large_counter", proc: metrics.ProcessCPU{ClockTicks: 100}, ticks: 1 << 35, expected: (1 << 35) * 10 * time.Millisecond},
+	}
+
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.proc.TicksToDuration(tt.ticks))
+		})
+	}
+}
+
+// TestProcessCPU_SameProcess tests that a reused PID is told apart by its start time.
+func TestProcessCPU_SameProcess(t *testing.T) {
+	prev := metrics.ProcessCPU{PID: 42, StartTime: 1000}
+
+	assert.True(t, prev.SameProcess(&metrics.ProcessCPU{PID: 42, StartTime: 1000}))
+	assert.False(t, prev.SameProcess(&metrics.ProcessCPU{PID: 42, StartTime: 2000}))
+	assert.False(t, prev.SameProcess(&metrics.ProcessCPU{PID: 43, StartTime: 1000}))
+}
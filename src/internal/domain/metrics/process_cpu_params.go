@@ -20,6 +20,8 @@ type ProcessCPUParams struct {
 	ChildrenSystem uint64
 	// StartTime is when the process started (jiffies after system boot).
 	StartTime uint64
+	// ClockTicks is the number of jiffies per second, zero when unknown.
+	ClockTicks uint64
 	// UsagePercent is the calculated CPU usage percentage (0-100).
 	UsagePercent float64
 	// Timestamp is when this sample was taken.
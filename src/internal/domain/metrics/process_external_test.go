@@ -24,17 +24,18 @@ func TestNewProcessMetrics(t *testing.T) {
 		{
 			name: "all_fields_populated",
 			params: &metrics.ProcessMetricsParams{
-				ServiceName:  "test-service",
-				PID:          1234,
-				State:        process.StateRunning,
-				Healthy:      true,
-				CPU:          metrics.ProcessCPU{User: 100, System: 50},
-				Memory:       metrics.ProcessMemory{RSS: 1024 * 1024},
-				StartTime:    now,
-				Uptime:       5 * time.Minute,
-				RestartCount: 2,
-				LastError:    "previous failure",
-				Timestamp:    now,
+				ServiceName:      "test-service",
+				PID:              1234,
+				State:            process.StateRunning,
+				Healthy:          true,
+				CPU:              metrics.ProcessCPU{User: 100, System: 50},
+				Memory:           metrics.ProcessMemory{RSS: 1024 * 1024},
+				ThrottledPercent: 12.5,
+				StartTime:        now,
+				Uptime:           5 * time.Minute,
+				RestartCount:     2,
+				LastError:        "previous failure",
+				Timestamp:        now,
 			},
 		},
 		{
@@ -89,6 +90,7 @@ func TestNewProcessMetrics(t *testing.T) {
 			assert.Equal(t, tt.params.CPU.User, m.CPU.User)
 			assert.Equal(t, tt.params.CPU.System, m.CPU.System)
 			assert.Equal(t, tt.params.Memory.RSS, m.Memory.RSS)
+			assert.Equal(t, tt.params.ThrottledPercent, m.ThrottledPercent)
 			assert.Equal(t, tt.params.StartTime, m.StartTime)
 			assert.Equal(t, tt.params.Uptime, m.Uptime)
 			assert.Equal(t, tt.params.RestartCount, m.RestartCount)
@@ -29,6 +29,18 @@ type ProcessMemory struct {
 	Data uint64
 	// Stack is the stack size in bytes.
 	Stack uint64
+	// PSS is the proportional set size in bytes, zero unless smaps are read.
+	PSS uint64
+	// USS is the unique set size in bytes, zero unless smaps are read.
+	USS uint64
+	// SwapPSS is the proportional share of Swap in bytes, zero unless smaps are read.
+	SwapPSS uint64
+	// MajorFaults is the number of page faults that required disk I/O,
+	// zero unless smaps are read.
+	MajorFaults uint64
+	// MajorFaultRate is the major page faults per second since the
+	// previous sample, zero unless smaps are read.
+	MajorFaultRate float64
 	// UsagePercent is the percentage of total system RAM used by this process (0-100).
 	UsagePercent float64
 }
@@ -22,6 +22,10 @@ type ProcessMetricsParams struct {
 	CPU ProcessCPU
 	// Memory contains memory usage metrics for the process.
 	Memory ProcessMemory
+	// ThrottledPercent is the share of wall time the process cgroup spent CPU-throttled.
+	ThrottledPercent float64
+	// Pressure is the PSI of the process cgroup (cgroup v2 only).
+	Pressure ResourcePressure
 	// NumFDs is the number of open file descriptors for the process.
 	NumFDs uint32
 	// ReadBytesPerSec is the disk read rate in bytes per second.
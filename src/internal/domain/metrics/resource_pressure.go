@@ -0,0 +1,106 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import "time"
+
+// PSI resource names.
+const (
+	// PressureResourceCPU selects CPU pressure.
+	PressureResourceCPU string = "cpu"
+	// PressureResourceMemory selects memory pressure.
+	PressureResourceMemory string = "memory"
+	// PressureResourceIO selects I/O pressure.
+	PressureResourceIO string = "io"
+)
+
+// PSI scopes.
+const (
+	// PressureScopeSome selects the share of time at least one task was stalled.
+	PressureScopeSome string = "some"
+	// PressureScopeFull selects the share of time all non-idle tasks were stalled.
+	PressureScopeFull string = "full"
+)
+
+// PSI averaging windows.
+const (
+	// PressureWindowAvg10 selects the 10-second average.
+	PressureWindowAvg10 string = "avg10"
+	// PressureWindowAvg60 selects the 60-second average.
+	PressureWindowAvg60 string = "avg60"
+	// PressureWindowAvg300 selects the 300-second average.
+	PressureWindowAvg300 string = "avg300"
+)
+
+// ResourcePressure groups CPU, memory, and I/O PSI for a host or a cgroup.
+// Host values come from /proc/pressure, cgroup values from the cgroup v2
+// cpu.pressure, memory.pressure, and io.pressure files.
+type ResourcePressure struct {
+	// Timestamp is when this sample was taken (zero if never collected).
+	Timestamp time.Time
+	// CPU is the CPU pressure (full is reported on Linux 5.13+).
+	CPU Pressure
+	// Memory is the memory pressure.
+	Memory Pressure
+	// IO is the I/O pressure.
+	IO Pressure
+}
+
+// Resource returns the pressure for a named resource.
+//
+// Params:
+//   - name: resource name ("cpu", "memory" or "io")
+//
+// Returns:
+//   - Pressure: the resource pressure
+//   - bool: false if the resource name is unknown
+func (r *ResourcePressure) Resource(name string) (Pressure, bool) {
+	// select resource by name
+	switch name {
+	// CPU pressure
+	case PressureResourceCPU:
+		// return CPU pressure
+		return r.CPU, true
+	// memory pressure
+	case PressureResourceMemory:
+		// return memory pressure
+		return r.Memory, true
+	// I/O pressure
+	case PressureResourceIO:
+		// return I/O pressure
+		return r.IO, true
+	// unknown resource
+	default:
+		// reject unknown resources
+		return Pressure{}, false
+	}
+}
+
+// Value returns a single PSI average selected by resource, scope, and window.
+//
+// Params:
+//   - resource: resource name ("cpu", "memory" or "io")
+//   - scope: PSI scope ("some" or "full")
+//   - window: averaging window ("avg10", "avg60" or "avg300")
+//
+// Returns:
+//   - float64: the stall percentage
+//   - bool: false if any selector is unknown
+func (r *ResourcePressure) Value(resource, scope, window string) (float64, bool) {
+	p, ok := r.Resource(resource)
+	// reject unknown resources
+	if !ok {
+		// unknown resource
+		return 0, false
+	}
+	// delegate scope and window selection
+	return p.Average(scope, window)
+}
+
+// IsCollected returns true if the sample was collected.
+//
+// Returns:
+//   - bool: true if the timestamp is set
+func (r *ResourcePressure) IsCollected() bool {
+	// zero timestamp means never collected
+	return !r.Timestamp.IsZero()
+}
@@ -0,0 +1,60 @@
+// Package metrics_test provides black-box tests for the metrics package.
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// TestResourcePressure_Value tests PSI value selection by resource, scope, and window.
+func TestResourcePressure_Value(t *testing.T) {
+	t.Parallel()
+
+	rp := metrics.ResourcePressure{
+		CPU:    metrics.Pressure{SomeAvg10: 1, SomeAvg60: 2, SomeAvg300: 3, FullAvg10: 4},
+		Memory: metrics.Pressure{FullAvg60: 5, FullAvg300: 6},
+		IO:     metrics.Pressure{SomeAvg10: 7},
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		scope    string
+		window   string
+		want     float64
+		wantOK   bool
+	}{
+		{"cpu_some_avg10", "cpu", "some", "avg10", 1, true},
+		{"cpu_some_avg60", "cpu", "some", "avg60", 2, true},
+		{"cpu_some_avg300", "cpu", "some", "avg300", 3, true},
+		{"cpu_full_avg10", "cpu", "full", "avg10", 4, true},
+		{"memory_full_avg60", "memory", "full", "avg60", 5, true},
+		{"memory_full_avg300", "memory", "full", "avg300", 6, true},
+		{"io_some_avg10", "io", "some", "avg10", 7, true},
+		{"unknown_resource", "disk", "some", "avg10", 0, false},
+		{"unknown_scope", "cpu", "most", "avg10", 0, false},
+		{"unknown_window", "cpu", "some", "avg5", 0, false},
+	}
+
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := rp.Value(tt.resource, tt.scope, tt.window)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.InDelta(t, tt.want, got, 0.001)
+		})
+	}
+}
+
+// TestResourcePressure_IsCollected tests the IsCollected method.
+func TestResourcePressure_IsCollected(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&metrics.ResourcePressure{}).IsCollected())
+	assert.True(t, (&metrics.ResourcePressure{Timestamp: time.Now()}).IsCollected())
+}
@@ -0,0 +1,30 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import "time"
+
+// ServiceStats is the lifetime statistics of a service. Counters and
+// durations carry on across daemon restarts when statistics are persisted.
+type ServiceStats struct {
+	// ServiceName is the name from the service configuration.
+	ServiceName string
+	// StartCount is the number of starts.
+	StartCount int
+	// StopCount is the number of clean stops.
+	StopCount int
+	// FailCount is the number of failures.
+	FailCount int
+	// RestartCount is the number of automatic restarts.
+	RestartCount int
+	// Uptime is the cumulative time the service was running.
+	Uptime time.Duration
+	// Downtime is the cumulative time the service was supervised but not running.
+	Downtime time.Duration
+	// Availability is the share of time the service was up over 1d, 7d and 30d.
+	Availability Availability
+	// SLO is the compliance with the availability objective, nil without one.
+	SLO *SLOStatus
+	// TopExits are the most frequent failure modes of the last 30 days,
+	// at most TopExitCauses, most frequent first.
+	TopExits []ExitCount
+}
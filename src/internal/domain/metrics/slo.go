@@ -0,0 +1,148 @@
+// Package metrics provides domain types for system and process metrics collection.
+package metrics
+
+import "time"
+
+// HealthChange records that a service became available or unavailable.
+type HealthChange struct {
+	// At is when the change happened.
+	At time.Time
+	// Good is true when the service became available.
+	Good bool
+}
+
+// HealthLog is the availability history of a service, as state changes,
+// oldest first. Time before the first change is not observed.
+type HealthLog []HealthChange
+
+// Record appends a state change, ignoring changes to the current state, and
+// drops the changes older than the retention, except the one in effect at
+// the cutoff.
+//
+// Params:
+//   - at: when the change happened.
+//   - good: true if the service became available.
+//   - retention: how long history is kept.
+//
+// Returns:
+//   - HealthLog: the updated log.
+func (l HealthLog) Record(at time.Time, good bool, retention time.Duration) HealthLog {
+	// keep the log ordered: a change in the past restarts the history
+	if len(l) > 0 && at.Before(l[len(l)-1].At) {
+		l = l[:0]
+	}
+	// append real changes only
+	if len(l) == 0 || l[len(l)-1].Good != good {
+		l = append(l, HealthChange{At: at, Good: good})
+	}
+	cutoff := at.Add(-retention)
+	// find the change in effect at the cutoff
+	i := 0
+	for i+1 < len(l) && !l[i+1].At.After(cutoff) {
+		i++
+	}
+	// return retained changes
+	return l[i:]
+}
+
+// BadRatio returns the share of observed time the service was unavailable
+// over a window ending now.
+//
+// Params:
+//   - window: the window length.
+//   - now: the end of the window.
+//
+// Returns:
+//   - float64: the unavailable share, between 0 and 1; 0 when nothing was observed.
+func (l HealthLog) BadRatio(window time.Duration, now time.Time) float64 {
+	cutoff := now.Add(-window)
+	var good, bad time.Duration
+	// sum each state interval clipped to the window
+	for i, change := range l {
+		from := change.At
+		to := now
+		// the state lasts until the next change
+		if i+1 < len(l) {
+			to = l[i+1].At
+		}
+		// clip the interval to the window
+		if from.Before(cutoff) {
+			from = cutoff
+		}
+		// interval outside the window
+		if !to.After(from) {
+			continue
+		}
+		// account the interval
+		if change.Good {
+			good += to.Sub(from)
+		} else {
+			bad += to.Sub(from)
+		}
+	}
+	// nothing observed
+	if good+bad == 0 {
+		// no downtime known
+		return 0
+	}
+	// compute the unavailable share
+	return float64(bad) / float64(good+bad)
+}
+
+// BurnRate is the error budget burn of a service over a lookback window.
+type BurnRate struct {
+	// Window is the lookback over which the rate is measured.
+	Window time.Duration `json:"window"`
+	// Threshold is the rate at which the alert fires.
+	Threshold float64 `json:"threshold"`
+	// Rate is the measured burn rate; 1 consumes the budget in exactly the SLO window.
+	Rate float64 `json:"rate"`
+	// Alerting is true when Rate reaches Threshold.
+	Alerting bool `json:"alerting"`
+}
+
+// SLOStatus is the compliance of a service with its availability objective.
+type SLOStatus struct {
+	// Target is the objective, in percent.
+	Target float64 `json:"target"`
+	// Window is the compliance window.
+	Window time.Duration `json:"window"`
+	// Availability is the share of observed time the service was available
+	// over the window, in percent.
+	Availability float64 `json:"availability"`
+	// BudgetRemaining is the share of the error budget left, in percent;
+	// negative once the budget is exhausted.
+	BudgetRemaining float64 `json:"budgetRemaining"`
+	// BurnRates are the measured burn rates, one per burn alert.
+	BurnRates []BurnRate `json:"burnRates"`
+}
+
+// SLOStatus measures the log against an availability objective.
+//
+// Params:
+//   - target: the objective, in percent, below 100.
+//   - window: the compliance window.
+//   - alerts: the burn alerts, with Window and Threshold set.
+//   - now: the end of the windows.
+//
+// Returns:
+//   - SLOStatus: the availability, remaining budget and burn rates.
+func (l HealthLog) SLOStatus(target float64, window time.Duration, alerts []BurnRate, now time.Time) SLOStatus {
+	budget := 1 - target/percentMultiplier
+	bad := l.BadRatio(window, now)
+	status := SLOStatus{
+		Target:          target,
+		Window:          window,
+		Availability:    (1 - bad) * percentMultiplier,
+		BudgetRemaining: (1 - bad/budget) * percentMultiplier,
+		BurnRates:       make([]BurnRate, 0, len(alerts)),
+	}
+	// measure each burn alert
+	for _, alert := range alerts {
+		alert.Rate = l.BadRatio(alert.Window, now) / budget
+		alert.Alerting = alert.Rate >= alert.Threshold
+		status.BurnRates = append(status.BurnRates, alert)
+	}
+	// return measured status
+	return status
+}
@@ -0,0 +1,97 @@
+// Package metrics_test provides black-box tests for the metrics package.
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// TestHealthLog_Record tests that only changes are kept within the retention.
+func TestHealthLog_Record(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var log metrics.HealthLog
+	log = log.Record(base, true, time.Hour)
+	log = log.Record(base.Add(time.Minute), true, time.Hour)
+	log = log.Record(base.Add(10*time.Minute), false, time.Hour)
+	require.Len(t, log, 2)
+
+	// The change in effect at the cutoff is kept.
+	log = log.Record(base.Add(2*time.Hour), true, time.Hour)
+	require.Len(t, log, 2)
+	assert.False(t, log[0].Good)
+	assert.Equal(t, base.Add(10*time.Minute), log[0].At)
+
+	// A change in the past restarts the history.
+	log = log.Record(base, false, time.Hour)
+	require.Len(t, log, 1)
+}
+
+// TestHealthLog_BadRatio tests the unavailable share over a window.
+func TestHealthLog_BadRatio(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	log := metrics.HealthLog{
+		{At: now.Add(-4 * time.Hour), Good: true},
+		{At: now.Add(-time.Hour), Good: false},
+		{At: now.Add(-30 * time.Minute), Good: true},
+	}
+
+	tests := []struct {
+		name   string
+		log    metrics.HealthLog
+		window time.Duration
+		want   float64
+	}{
+		{name: "nothing_observed", window: time.Hour, want: 0},
+		{name: "last_hour", log: log, window: time.Hour, want: 0.5},
+		{name: "last_two_hours", log: log, window: 2 * time.Hour, want: 0.25},
+		{name: "beyond_history", log: log, window: 8 * time.Hour, want: 0.125},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, tt.log.BadRatio(tt.window, now), 0.0001)
+		})
+	}
+}
+
+// TestHealthLog_SLOStatus tests the remaining budget and burn alerts.
+func TestHealthLog_SLOStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	// Down 6 minutes in the last hour of 10 observed days.
+	log := metrics.HealthLog{
+		{At: now.Add(-240 * time.Hour), Good: true},
+		{At: now.Add(-6 * time.Minute), Good: false},
+	}
+	alerts := []metrics.BurnRate{
+		{Window: time.Hour, Threshold: 14.4},
+		{Window: 6 * time.Hour, Threshold: 20},
+	}
+
+	status := log.SLOStatus(99, 720*time.Hour, alerts, now)
+
+	assert.InDelta(t, 99.0, status.Target, 0.0001)
+	assert.InDelta(t, 99.9583, status.Availability, 0.001)
+	assert.InDelta(t, 95.8333, status.BudgetRemaining, 0.001)
+	require.Len(t, status.BurnRates, 2)
+	assert.InDelta(t, 10.0, status.BurnRates[0].Rate, 0.0001)
+	assert.False(t, status.BurnRates[0].Alerting)
+	assert.InDelta(t, 1.6667, status.BurnRates[1].Rate, 0.001)
+
+	status = log.SLOStatus(99.9, 720*time.Hour, alerts, now)
+	assert.True(t, status.BurnRates[0].Alerting)
+	assert.InDelta(t, 100.0, status.BurnRates[0].Rate, 0.001)
+	assert.InDelta(t, 58.3333, status.BudgetRemaining, 0.001)
+}
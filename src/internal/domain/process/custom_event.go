@@ -0,0 +1,68 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrReservedEventName indicates a custom event named like a built-in event.
+var ErrReservedEventName error = errors.New("event name is reserved by a built-in event")
+
+// NewCustomEvent creates a user-defined event.
+//
+// Params:
+//   - name: the user-defined event name.
+//   - processName: the service the event is reported for, empty for daemon-wide events.
+//   - err: the detail of the event, may be nil.
+//
+// Returns:
+//   - Event: the custom event.
+func NewCustomEvent(name, processName string, err error) Event {
+	// construct custom event
+	return Event{
+		Type:      EventCustom,
+		Custom:    name,
+		Process:   processName,
+		Timestamp: time.Now(),
+		Error:     err,
+	}
+}
+
+// Name returns the name of the event as used by notification filters and
+// the event store: the user-defined name of custom events, the type name
+// otherwise.
+//
+// Returns:
+//   - string: the event name.
+func (e *Event) Name() string {
+	// custom events carry their own name
+	if e.Type == EventCustom && e.Custom != "" {
+		// return user-defined name
+		return e.Custom
+	}
+	// return type name
+	return e.Type.String()
+}
+
+// CheckCustomEventName verifies that a custom event name cannot be mistaken
+// for a built-in event.
+//
+// Params:
+//   - name: the user-defined event name.
+//
+// Returns:
+//   - error: ErrReservedEventName when a built-in event has the name.
+func CheckCustomEventName(name string) error {
+	// compare with each built-in event
+	for t := EventStarted; t <= EventCustom; t++ {
+		// reject names of built-in events
+		if t.String() == name {
+			// return error with the name
+			return fmt.Errorf("%w: %q", ErrReservedEventName, name)
+		}
+	}
+	// name available
+	return nil
+}
@@ -0,0 +1,72 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestEvent_Name verifies the names of built-in and custom events.
+//
+// Params:
+//   - t: testing context for assertions
+func TestEvent_Name(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// event is the named event.
+		event process.Event
+		// want is the expected event name.
+		want string
+	}{
+		{name: "built-in", event: process.NewEvent(process.EventFailed, "api", 0, 1, nil), want: "failed"},
+		{name: "custom", event: process.NewCustomEvent("raid_degraded", "", nil), want: "raid_degraded"},
+		{name: "custom without name", event: process.Event{Type: process.EventCustom}, want: "custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.event.Name())
+		})
+	}
+}
+
+// TestCheckCustomEventName verifies that built-in event names are reserved.
+//
+// Params:
+//   - t: testing context for assertions
+func TestCheckCustomEventName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// event is the checked event name.
+		event string
+		// wantErr reports whether the name is reserved.
+		wantErr bool
+	}{
+		{name: "available", event: "raid_degraded"},
+		{name: "built-in", event: "unhealthy", wantErr: true},
+		{name: "custom type", event: "custom", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := process.CheckCustomEventName(tt.event)
+			// check the expected outcome
+			if tt.wantErr {
+				assert.ErrorIs(t, err, process.ErrReservedEventName)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// DefaultEphemeralTTL bounds ephemeral services spawned without a TTL.
+	DefaultEphemeralTTL time.Duration = 5 * time.Minute
+	// MaxEphemeralTTL is the longest lifetime of an ephemeral service.
+	MaxEphemeralTTL time.Duration = time.Hour
+)
+
+// ErrInvalidEphemeral indicates an ephemeral service spec that cannot run.
+var ErrInvalidEphemeral error = shared.NewCodedError(shared.CodeInvalidArgument, "invalid ephemeral service")
+
+// EphemeralSpec describes a transient service run once for diagnostics.
+type EphemeralSpec struct {
+	// Command is the executable to run.
+	Command string
+	// Args are the arguments of the command.
+	Args []string
+	// Env holds environment variables, set over those of the sandbox service.
+	Env map[string]string
+	// TTL bounds the run; the process is stopped once it elapses.
+	// Zero uses DefaultEphemeralTTL.
+	TTL time.Duration
+	// SandboxFrom names the service whose user, working directory,
+	// environment, confinement and egress rules the run reuses.
+	// Empty runs with the daemon defaults.
+	SandboxFrom string
+}
+
+// EffectiveTTL validates the spec and returns the lifetime of the run.
+//
+// Returns:
+//   - time.Duration: the TTL, or DefaultEphemeralTTL.
+//   - error: ErrInvalidEphemeral describing the problem.
+func (s *EphemeralSpec) EffectiveTTL() (time.Duration, error) {
+	// a command is required
+	if s.Command == "" {
+		// return error without command
+		return 0, fmt.Errorf("%w: command is required", ErrInvalidEphemeral)
+	}
+	// bound the lifetime
+	if s.TTL < 0 || s.TTL > MaxEphemeralTTL {
+		// return error with the bound
+		return 0, fmt.Errorf("%w: ttl must be between 0 and %s", ErrInvalidEphemeral, MaxEphemeralTTL)
+	}
+	// fall back to the default TTL
+	if s.TTL == 0 {
+		// return default
+		return DefaultEphemeralTTL, nil
+	}
+	// return requested TTL
+	return s.TTL, nil
+}
+
+// EphemeralService identifies a spawned ephemeral service.
+type EphemeralService struct {
+	// Name is the generated service name.
+	Name string
+	// ExpiresAt is when the run is stopped if still running.
+	ExpiresAt time.Time
+}
@@ -0,0 +1,50 @@
+// Package process_test provides external tests for ephemeral.go.
+// It tests the public API of EphemeralSpec using black-box testing.
+package process_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestEphemeralSpec_EffectiveTTL tests the validation and TTL of ephemeral specs.
+//
+// Params:
+//   - t: the testing context.
+func TestEphemeralSpec_EffectiveTTL(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// spec is the validated spec.
+		spec process.EphemeralSpec
+		// want is the expected TTL.
+		want time.Duration
+		// wantErr is true when the spec is invalid.
+		wantErr bool
+	}{
+		{name: "default", spec: process.EphemeralSpec{Command: "/bin/ss"}, want: process.DefaultEphemeralTTL},
+		{name: "requested", spec: process.EphemeralSpec{Command: "/bin/ss", TTL: time.Minute}, want: time.Minute},
+		{name: "maximum", spec: process.EphemeralSpec{Command: "/bin/ss", TTL: process.MaxEphemeralTTL}, want: process.MaxEphemeralTTL},
+		{name: "no_command", spec: process.EphemeralSpec{TTL: time.Minute}, wantErr: true},
+		{name: "negative", spec: process.EphemeralSpec{Command: "/bin/ss", TTL: -time.Second}, wantErr: true},
+		{name: "too_long", spec: process.EphemeralSpec{Command: "/bin/ss", TTL: 2 * process.MaxEphemeralTTL}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := tt.spec.EffectiveTTL()
+			// Invalid specs are refused.
+			if tt.wantErr {
+				require.ErrorIs(t, err, process.ErrInvalidEphemeral)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ttl)
+		})
+	}
+}
@@ -1,20 +1,24 @@
 // Package process provides domain entities and value objects for process lifecycle management.
 package process
 
-import "errors"
+import "github.com/kodflow/daemon/internal/domain/shared"
 
 // Process domain sentinel errors.
 var (
 	// ErrAlreadyRunning indicates an attempt to start a process that is already running.
-	ErrAlreadyRunning error = errors.New("process already running")
+	ErrAlreadyRunning error = shared.NewCodedError(shared.CodeServiceAlreadyRunning, "process already running")
 	// ErrNotRunning indicates an attempt to operate on a non-running process.
-	ErrNotRunning error = errors.New("process not running")
+	ErrNotRunning error = shared.NewCodedError(shared.CodeServiceNotRunning, "process not running")
 	// ErrMaxRetriesExceeded indicates the maximum restart retries have been exceeded.
-	ErrMaxRetriesExceeded error = errors.New("max retries exceeded")
+	ErrMaxRetriesExceeded error = shared.NewCodedError(shared.CodeServiceRetriesExhausted, "max retries exceeded")
 	// ErrInvalidTransition indicates an invalid state transition was attempted.
-	ErrInvalidTransition error = errors.New("invalid state transition")
+	ErrInvalidTransition error = shared.NewCodedError(shared.CodeServiceInvalidTransition, "invalid state transition")
 	// ErrProcessFailed indicates the process exited with a non-zero exit code.
-	ErrProcessFailed error = errors.New("process failed")
+	ErrProcessFailed error = shared.NewCodedError(shared.CodeServiceFailed, "process failed")
 	// ErrHealthProbeFailed indicates the health probe failed for a process.
-	ErrHealthProbeFailed error = errors.New("health probe failed")
+	ErrHealthProbeFailed error = shared.NewCodedError(shared.CodeServiceUnhealthy, "health probe failed")
+	// ErrStartTimeout indicates the process did not become ready within its start timeout.
+	ErrStartTimeout error = shared.NewCodedError(shared.CodeServiceStartTimeout, "start timeout exceeded")
+	// ErrMaxRuntime indicates the process ran for the max runtime of its service.
+	ErrMaxRuntime error = shared.NewCodedError(shared.CodeServiceMaxRuntime, "max runtime exceeded")
 )
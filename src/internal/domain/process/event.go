@@ -25,6 +25,78 @@ const (
 	EventUnhealthy
 	// EventExhausted indicates max restart attempts have been reached.
 	EventExhausted
+	// EventThrottled indicates the process cgroup exceeded the CPU throttling threshold.
+	EventThrottled
+	// EventUnthrottled indicates the process cgroup fell back below the CPU throttling threshold.
+	EventUnthrottled
+	// EventPressureAlert indicates the process cgroup PSI exceeded a pressure alert rule.
+	EventPressureAlert
+	// EventPressureCleared indicates the process cgroup PSI fell back below a pressure alert rule.
+	EventPressureCleared
+	// EventListenerConflict indicates a listener port is bound by a process outside the service.
+	EventListenerConflict
+	// EventListenerConflictCleared indicates a listener port is no longer held by another process.
+	EventListenerConflictCleared
+	// EventStartTimeout indicates the process did not become ready within its start timeout and was killed.
+	EventStartTimeout
+	// EventDependencyDown indicates an external dependency of the service stopped passing its probe.
+	EventDependencyDown
+	// EventDependencyUp indicates an external dependency of the service passes its probe again.
+	EventDependencyUp
+	// EventIncident indicates several services failed close together and were correlated into one incident.
+	EventIncident
+	// EventFileChanged indicates the content of the service binary or of a watched file changed on disk.
+	EventFileChanged
+	// EventReloaded indicates the service reloaded its configuration in place and passes its probes.
+	EventReloaded
+	// EventReloadFailed indicates an in-place reload of the service could not be triggered or verified.
+	EventReloadFailed
+	// EventSLOBurn indicates the error budget of the service burns faster than a burn alert rate.
+	EventSLOBurn
+	// EventSLOBurnCleared indicates the error budget burn fell back below a burn alert rate.
+	EventSLOBurnCleared
+	// EventOvercommitted indicates the start of the service would reserve more than the admitted host capacity.
+	EventOvercommitted
+	// EventShed indicates the service was stopped to relieve host memory pressure.
+	EventShed
+	// EventShedResumed indicates a shed service was started again once host memory pressure subsided.
+	EventShedResumed
+	// EventResourceLeaked indicates executor resources of the service outlived the process the service owns.
+	EventResourceLeaked
+	// EventRuntimeWarning indicates the process approaches the max runtime of its service.
+	EventRuntimeWarning
+	// EventRuntimeExceeded indicates the process reached the max runtime of its service and is stopped.
+	EventRuntimeExceeded
+	// EventRestartDeferred indicates the restart of the service waits for the daemon restart budget.
+	EventRestartDeferred
+	// EventCertificateExpiring indicates the certificate served by a listener of the service expires soon.
+	EventCertificateExpiring
+	// EventCertificateChanged indicates the certificate served by a listener of the service was replaced.
+	EventCertificateChanged
+	// EventClockJump indicates the wall clock jumped (suspend and resume, NTP step); it is daemon-wide.
+	EventClockJump
+	// EventCustom indicates a user-defined event reported by a watcher; its name is in Event.Custom.
+	EventCustom
+	// EventSkipped indicates the service was not started because its start conditions are unmet.
+	EventSkipped
+	// EventSecretChanged indicates a secret file of the service changed; Event.File is the file.
+	EventSecretChanged
+	// EventProbesPaused indicates the probes of the service, or of one of its listeners, were paused.
+	EventProbesPaused
+	// EventProbesResumed indicates paused probes of the service run again, resumed or at the end of their pause.
+	EventProbesResumed
+	// EventQuiesced indicates the service was quiesced for a job operating on its state; Event.Error names the job.
+	EventQuiesced
+	// EventUnquiesced indicates the service runs normally again after a job operating on its state.
+	EventUnquiesced
+	// EventQuiesceFailed indicates the service could not be quiesced for a job, or was not healthy after it.
+	EventQuiesceFailed
+	// EventSafeModeEntered indicates a restart storm, or a request, put the daemon in safe mode; it is daemon-wide.
+	EventSafeModeEntered
+	// EventSafeModeExited indicates the daemon left safe mode and released the held restarts; it is daemon-wide.
+	EventSafeModeExited
+	// EventConfigReloaded indicates the daemon applied its configuration file again; it is daemon-wide.
+	EventConfigReloaded
 )
 
 // String returns the string representation of the event type.
@@ -62,6 +134,150 @@ func (e EventType) String() string {
 	case EventExhausted:
 		// return exhausted string
 		return "exhausted"
+	// throttled event type
+	case EventThrottled:
+		// return throttled string
+		return "throttled"
+	// unthrottled event type
+	case EventUnthrottled:
+		// return unthrottled string
+		return "unthrottled"
+	// pressure alert event type
+	case EventPressureAlert:
+		// return pressure alert string
+		return "pressure_alert"
+	// pressure cleared event type
+	case EventPressureCleared:
+		// return pressure cleared string
+		return "pressure_cleared"
+	// listener conflict event type
+	case EventListenerConflict:
+		// return listener conflict string
+		return "listener_conflict"
+	// listener conflict cleared event type
+	case EventListenerConflictCleared:
+		// return listener conflict cleared string
+		return "listener_conflict_cleared"
+	// start timeout event type
+	case EventStartTimeout:
+		// return start timeout string
+		return "start_timeout"
+	// dependency down event type
+	case EventDependencyDown:
+		// return dependency down string
+		return "dependency_down"
+	// dependency up event type
+	case EventDependencyUp:
+		// return dependency up string
+		return "dependency_up"
+	// incident event type
+	case EventIncident:
+		// return incident string
+		return "incident"
+	// file changed event type
+	case EventFileChanged:
+		// return file changed string
+		return "file_changed"
+	// reloaded event type
+	case EventReloaded:
+		// return reloaded string
+		return "reloaded"
+	// reload failed event type
+	case EventReloadFailed:
+		// return reload failed string
+		return "reload_failed"
+	// slo burn event type
+	case EventSLOBurn:
+		// return slo burn string
+		return "slo_burn"
+	// slo burn cleared event type
+	case EventSLOBurnCleared:
+		// return slo burn cleared string
+		return "slo_burn_cleared"
+	// overcommitted event type
+	case EventOvercommitted:
+		// return overcommitted string
+		return "overcommitted"
+	// shed event type
+	case EventShed:
+		// return shed string
+		return "shed"
+	// shed resumed event type
+	case EventShedResumed:
+		// return shed resumed string
+		return "shed_resumed"
+	// resource leaked event type
+	case EventResourceLeaked:
+		// return resource leaked string
+		return "resource_leaked"
+	// runtime warning event type
+	case EventRuntimeWarning:
+		// return runtime warning string
+		return "runtime_warning"
+	// runtime exceeded event type
+	case EventRuntimeExceeded:
+		// return runtime exceeded string
+		return "runtime_exceeded"
+	// restart deferred event type
+	case EventRestartDeferred:
+		// return restart deferred string
+		return "restart_deferred"
+	// certificate expiring event type
+	case EventCertificateExpiring:
+		// return certificate expiring string
+		return "certificate_expiring"
+	// certificate changed event type
+	case EventCertificateChanged:
+		// return certificate changed string
+		return "certificate_changed"
+	// clock jump event type
+	case EventClockJump:
+		// return clock jump string
+		return "clock_jump"
+	// custom event type
+	case EventCustom:
+		// return custom string
+		return "custom"
+	// skipped event type
+	case EventSkipped:
+		// return skipped string
+		return "skipped"
+	// secret changed event type
+	case EventSecretChanged:
+		// return secret changed string
+		return "secret_changed"
+	// probes paused event type
+	case EventProbesPaused:
+		// return probes paused string
+		return "probes_paused"
+	// probes resumed event type
+	case EventProbesResumed:
+		// return probes resumed string
+		return "probes_resumed"
+	// quiesced event type
+	case EventQuiesced:
+		// return quiesced string
+		return "quiesced"
+	// unquiesced event type
+	case EventUnquiesced:
+		// return unquiesced string
+		return "unquiesced"
+	// quiesce failed event type
+	case EventQuiesceFailed:
+		// return quiesce failed string
+		return "quiesce_failed"
+	// safe mode entered event type
+	case EventSafeModeEntered:
+		// return safe mode entered string
+		return "safe_mode_entered"
+	// safe mode exited event type
+	case EventSafeModeExited:
+		// return safe mode exited string
+		return "safe_mode_exited"
+	// config reloaded event type
+	case EventConfigReloaded:
+		// return config reloaded string
+		return "config_reloaded"
 	// unknown event type
 	default:
 		// return unknown string
@@ -82,10 +298,26 @@ type Event struct {
 	PID int
 	// ExitCode is the exit code if process exited.
 	ExitCode int
+	// Signal is the number of the signal that terminated the process, zero if none.
+	Signal int
+	// Dependency is the address of the external dependency for dependency events.
+	Dependency string
+	// File is the path of the changed file for file changed events.
+	File string
+	// Incident is the correlated incident, set on incident events and on
+	// failures joining an open incident.
+	Incident *Incident
+	// Labels are the labels of the service, set by the supervisor.
+	Labels map[string]string
+	// Custom is the user-defined name of custom events.
+	Custom string
 	// Timestamp is when the event occurred.
 	Timestamp time.Time
 	// Error contains any error associated with the event.
 	Error error
+	// Seq orders the events emitted by lifecycle managers, zero for events
+	// raised by the supervisor itself.
+	Seq uint64
 }
 
 // NewEvent creates a new process event.
@@ -0,0 +1,66 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+// EventCategory groups event types by what they tell about a service.
+type EventCategory string
+
+// Event category constants.
+const (
+	// CategoryLifecycle covers starts, stops, failures and restarts.
+	CategoryLifecycle EventCategory = "lifecycle"
+	// CategoryHealth covers health and dependency transitions.
+	CategoryHealth EventCategory = "health"
+	// CategoryReload covers in-place reloads and the changes that trigger them.
+	CategoryReload EventCategory = "reload"
+	// CategoryAlert covers resource, capacity and user-defined alerts.
+	CategoryAlert EventCategory = "alert"
+)
+
+// Category returns the category of the event type.
+//
+// Returns:
+//   - EventCategory: the category, CategoryAlert for unknown types.
+func (e EventType) Category() EventCategory {
+	// match event type to its category
+	switch e {
+	// process lifecycle transitions
+	case EventStarted, EventStopped, EventFailed, EventRestarting, EventExhausted,
+		EventStartTimeout, EventSkipped, EventShed, EventShedResumed,
+		EventRuntimeExceeded, EventRestartDeferred, EventQuiesced, EventUnquiesced:
+		// return lifecycle category
+		return CategoryLifecycle
+	// health transitions
+	case EventHealthy, EventUnhealthy, EventDependencyDown, EventDependencyUp, EventProbesPaused, EventProbesResumed:
+		// return health category
+		return CategoryHealth
+	// reloads and their triggers
+	case EventReloaded, EventReloadFailed, EventFileChanged, EventSecretChanged, EventCertificateChanged, EventConfigReloaded:
+		// return reload category
+		return CategoryReload
+	// everything else is worth attention
+	default:
+		// return alert category
+		return CategoryAlert
+	}
+}
+
+// CategoryOf returns the category of an event name as stored by the event
+// store. Custom event names are alerts.
+//
+// Params:
+//   - name: the event name.
+//
+// Returns:
+//   - EventCategory: the category of the built-in event of that name, CategoryAlert otherwise.
+func CategoryOf(name string) EventCategory {
+	// look for the built-in event of that name
+	for t := EventStarted; t <= EventConfigReloaded; t++ {
+		// built-in event found
+		if t.String() == name {
+			// return its category
+			return t.Category()
+		}
+	}
+	// custom events are alerts
+	return CategoryAlert
+}
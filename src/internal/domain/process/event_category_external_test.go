@@ -0,0 +1,48 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestCategoryOf verifies the categories of built-in and custom event names.
+//
+// Params:
+//   - t: testing context for assertions
+func TestCategoryOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// event is the categorized event name.
+		event string
+		// want is the expected category.
+		want process.EventCategory
+	}{
+		{name: "start", event: "started", want: process.CategoryLifecycle},
+		{name: "failure", event: "failed", want: process.CategoryLifecycle},
+		{name: "health", event: "unhealthy", want: process.CategoryHealth},
+		{name: "dependency", event: "dependency_down", want: process.CategoryHealth},
+		{name: "probe pause", event: "probes_paused", want: process.CategoryHealth},
+		{name: "quiesce", event: "unquiesced", want: process.CategoryLifecycle},
+		{name: "quiesce failure", event: "quiesce_failed", want: process.CategoryAlert},
+		{name: "safe mode", event: "safe_mode_entered", want: process.CategoryAlert},
+		{name: "reload", event: "reloaded", want: process.CategoryReload},
+		{name: "secret rotation", event: "secret_changed", want: process.CategoryReload},
+		{name: "config reload", event: "config_reloaded", want: process.CategoryReload},
+		{name: "pressure", event: "pressure_alert", want: process.CategoryAlert},
+		{name: "custom", event: "raid_degraded", want: process.CategoryAlert},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, process.CategoryOf(tt.event))
+		})
+	}
+}
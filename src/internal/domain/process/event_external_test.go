@@ -32,6 +32,43 @@ func TestEventType_String(t *testing.T) {
 		{"restarting", process.EventRestarting, "restarting"},
 		{"healthy", process.EventHealthy, "healthy"},
 		{"unhealthy", process.EventUnhealthy, "unhealthy"},
+		{"exhausted", process.EventExhausted, "exhausted"},
+		{"throttled", process.EventThrottled, "throttled"},
+		{"unthrottled", process.EventUnthrottled, "unthrottled"},
+		{"pressure_alert", process.EventPressureAlert, "pressure_alert"},
+		{"pressure_cleared", process.EventPressureCleared, "pressure_cleared"},
+		{"listener_conflict", process.EventListenerConflict, "listener_conflict"},
+		{"listener_conflict_cleared", process.EventListenerConflictCleared, "listener_conflict_cleared"},
+		{"start_timeout", process.EventStartTimeout, "start_timeout"},
+		{"dependency_down", process.EventDependencyDown, "dependency_down"},
+		{"dependency_up", process.EventDependencyUp, "dependency_up"},
+		{"incident", process.EventIncident, "incident"},
+		{"file_changed", process.EventFileChanged, "file_changed"},
+		{"reloaded", process.EventReloaded, "reloaded"},
+		{"reload_failed", process.EventReloadFailed, "reload_failed"},
+		{"slo_burn", process.EventSLOBurn, "slo_burn"},
+		{"slo_burn_cleared", process.EventSLOBurnCleared, "slo_burn_cleared"},
+		{"overcommitted", process.EventOvercommitted, "overcommitted"},
+		{"shed", process.EventShed, "shed"},
+		{"shed_resumed", process.EventShedResumed, "shed_resumed"},
+		{"resource_leaked", process.EventResourceLeaked, "resource_leaked"},
+		{"runtime_warning", process.EventRuntimeWarning, "runtime_warning"},
+		{"runtime_exceeded", process.EventRuntimeExceeded, "runtime_exceeded"},
+		{"restart_deferred", process.EventRestartDeferred, "restart_deferred"},
+		{"certificate_expiring", process.EventCertificateExpiring, "certificate_expiring"},
+		{"certificate_changed", process.EventCertificateChanged, "certificate_changed"},
+		{"clock_jump", process.EventClockJump, "clock_jump"},
+		{"custom", process.EventCustom, "custom"},
+		{"skipped", process.EventSkipped, "skipped"},
+		{"secret_changed", process.EventSecretChanged, "secret_changed"},
+		{"probes_paused", process.EventProbesPaused, "probes_paused"},
+		{"probes_resumed", process.EventProbesResumed, "probes_resumed"},
+		{"quiesced", process.EventQuiesced, "quiesced"},
+		{"unquiesced", process.EventUnquiesced, "unquiesced"},
+		{"quiesce_failed", process.EventQuiesceFailed, "quiesce_failed"},
+		{"safe_mode_entered", process.EventSafeModeEntered, "safe_mode_entered"},
+		{"safe_mode_exited", process.EventSafeModeExited, "safe_mode_exited"},
+		{"config_reloaded", process.EventConfigReloaded, "config_reloaded"},
 		{"unknown", process.EventType(99), "unknown"},
 	}
 
@@ -8,6 +8,8 @@ package process
 type ExitResult struct {
 	// Code is the process exit code (0 indicates success).
 	Code int
+	// Signal is the number of the signal that terminated the process, zero if none.
+	Signal int
 	// Error is any error that occurred during process execution.
 	Error error
 }
@@ -0,0 +1,290 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// defaultIncidentMinServices is the number of failing services opening an incident by default.
+const defaultIncidentMinServices int = 2
+
+// IncidentCause is the reason several failures were grouped into one incident.
+type IncidentCause string
+
+// Incident cause constants.
+const (
+	// IncidentSharedDependency indicates the services lost the same external dependency.
+	IncidentSharedDependency IncidentCause = "shared_dependency"
+	// IncidentSameSignal indicates the processes were terminated by the same signal.
+	IncidentSameSignal IncidentCause = "same_signal"
+	// IncidentHostPressure indicates the services hit resource pressure together.
+	IncidentHostPressure IncidentCause = "host_pressure"
+	// IncidentConcurrent indicates the services failed together without a shared cause.
+	IncidentConcurrent IncidentCause = "concurrent"
+)
+
+// incidentCauses lists the shared causes in order of precedence.
+var incidentCauses []IncidentCause = []IncidentCause{
+	IncidentSharedDependency,
+	IncidentSameSignal,
+	IncidentHostPressure,
+}
+
+// Incident groups failures of several services occurring close together.
+type Incident struct {
+	// ID identifies the incident, increasing for each opened incident.
+	ID uint64
+	// Cause is the shared cause detected when the incident opened.
+	Cause IncidentCause
+	// Detail qualifies the cause: dependency address or signal number.
+	Detail string
+	// Services lists the failed services, in failure order.
+	Services []string
+	// OpenedAt is when the failure opening the incident occurred.
+	OpenedAt time.Time
+}
+
+// String returns a one-line description of the incident.
+//
+// Returns:
+//   - string: the cause, its detail if any, and the failed services.
+func (i *Incident) String() string {
+	cause := string(i.Cause)
+	// qualify the cause when known
+	if i.Detail != "" {
+		cause += " " + i.Detail
+	}
+	// describe cause and services
+	return fmt.Sprintf("%s: %s", cause, strings.Join(i.Services, ", "))
+}
+
+// incidentFailure is a failure kept for correlation.
+type incidentFailure struct {
+	// service is the failed service name.
+	service string
+	// at is when the failure occurred.
+	at time.Time
+	// cause is the shared cause the failure may belong to, empty if none.
+	cause IncidentCause
+	// detail qualifies the cause.
+	detail string
+}
+
+// IncidentCorrelator groups failures of distinct services occurring within a
+// window into a single incident. Once open, an incident absorbs every further
+// failure until no failure occurs for a whole window.
+// IncidentCorrelator is not safe for concurrent use.
+type IncidentCorrelator struct {
+	// window is the maximum gap between correlated failures.
+	window time.Duration
+	// minServices is the number of distinct failing services opening an incident.
+	minServices int
+	// failures are the recent failures not part of an incident.
+	failures []incidentFailure
+	// open is the incident absorbing failures, nil when none.
+	open *Incident
+	// lastFailure is when the open incident last absorbed a failure.
+	lastFailure time.Time
+	// opened counts the incidents opened so far.
+	opened uint64
+}
+
+// NewIncidentCorrelator creates a new incident correlator.
+//
+// Params:
+//   - window: the maximum gap between correlated failures.
+//   - minServices: distinct failing services opening an incident, 2 if below 2.
+//
+// Returns:
+//   - *IncidentCorrelator: the correlator.
+func NewIncidentCorrelator(window time.Duration, minServices int) *IncidentCorrelator {
+	// a single failure is not an incident
+	if minServices < defaultIncidentMinServices {
+		minServices = defaultIncidentMinServices
+	}
+	// return correlator without failures
+	return &IncidentCorrelator{
+		window:      window,
+		minServices: minServices,
+	}
+}
+
+// Observe feeds an event to the correlator. Failures joining an incident get
+// the incident attached to their Incident field.
+//
+// Params:
+//   - service: the service the event belongs to.
+//   - event: the event, updated in place when it joins an incident.
+//
+// Returns:
+//   - *Incident: the incident opened by this event, nil otherwise.
+func (c *IncidentCorrelator) Observe(service string, event *Event) *Incident {
+	// only failures are correlated
+	if !isIncidentFailure(event.Type) {
+		// not a failure
+		return nil
+	}
+	at := event.Timestamp
+	// stamp events created without a time
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	// join the open incident while failures keep coming
+	if c.open != nil && at.Sub(c.lastFailure) <= c.window {
+		// add newly failing services
+		if !slices.Contains(c.open.Services, service) {
+			c.open.Services = append(c.open.Services, service)
+		}
+		c.lastFailure = at
+		event.Incident = c.open.snapshot()
+		// incident already reported
+		return nil
+	}
+	c.open = nil
+
+	// forget failures outside the window
+	c.failures = slices.DeleteFunc(c.failures, func(f incidentFailure) bool {
+		// too old to correlate
+		return at.Sub(f.at) > c.window
+	})
+	cause, detail := failureCause(event)
+	c.failures = append(c.failures, incidentFailure{service: service, at: at, cause: cause, detail: detail})
+
+	services := c.failedServices()
+	// wait for enough distinct services
+	if len(services) < c.minServices {
+		// no incident yet
+		return nil
+	}
+
+	// open an incident grouping the recent failures
+	c.opened++
+	cause, detail = c.sharedCause()
+	c.open = &Incident{
+		ID:       c.opened,
+		Cause:    cause,
+		Detail:   detail,
+		Services: services,
+		OpenedAt: at,
+	}
+	c.failures = nil
+	c.lastFailure = at
+	event.Incident = c.open.snapshot()
+	// return the incident to report
+	return c.open.snapshot()
+}
+
+// failedServices returns the distinct services of the recent failures.
+//
+// Returns:
+//   - []string: the service names, in failure order.
+func (c *IncidentCorrelator) failedServices() []string {
+	services := make([]string, 0, len(c.failures))
+	// keep each service once
+	for _, f := range c.failures {
+		// skip services already listed
+		if !slices.Contains(services, f.service) {
+			services = append(services, f.service)
+		}
+	}
+	// return distinct services
+	return services
+}
+
+// sharedCause returns the first cause, by precedence, shared by at least
+// two services among the recent failures.
+//
+// Returns:
+//   - IncidentCause: the shared cause, IncidentConcurrent if none.
+//   - string: the detail of the shared cause.
+func (c *IncidentCorrelator) sharedCause() (IncidentCause, string) {
+	// check causes by precedence
+	for _, cause := range incidentCauses {
+		services := make(map[string]map[string]bool)
+		// group services by cause detail
+		for _, f := range c.failures {
+			// skip failures with another cause
+			if f.cause != cause {
+				continue
+			}
+			// track services per detail
+			if services[f.detail] == nil {
+				services[f.detail] = make(map[string]bool)
+			}
+			services[f.detail][f.service] = true
+			// shared by two services
+			if len(services[f.detail]) >= defaultIncidentMinServices {
+				// return shared cause
+				return cause, f.detail
+			}
+		}
+	}
+	// no shared cause found
+	return IncidentConcurrent, ""
+}
+
+// snapshot returns a copy of the incident safe to hand out.
+//
+// Returns:
+//   - *Incident: the copy.
+func (i *Incident) snapshot() *Incident {
+	incident := *i
+	incident.Services = slices.Clone(i.Services)
+	// return the copy
+	return &incident
+}
+
+// isIncidentFailure reports whether an event type is correlated into incidents.
+//
+// Params:
+//   - eventType: the event type.
+//
+// Returns:
+//   - bool: true for failures.
+func isIncidentFailure(eventType EventType) bool {
+	// match failure event types
+	switch eventType {
+	// failures of the service or around it
+	case EventFailed, EventUnhealthy, EventExhausted, EventStartTimeout, EventDependencyDown, EventPressureAlert:
+		// correlated
+		return true
+	// other events are not failures
+	default:
+		// ignored
+		return false
+	}
+}
+
+// failureCause returns the cause a failure may share with others.
+//
+// Params:
+//   - event: the failure event.
+//
+// Returns:
+//   - IncidentCause: the shared cause candidate, empty if none.
+//   - string: the cause detail.
+func failureCause(event *Event) (IncidentCause, string) {
+	// match failure types carrying a cause
+	switch {
+	// lost dependency identified by address
+	case event.Type == EventDependencyDown && event.Dependency != "":
+		// dependency cause
+		return IncidentSharedDependency, event.Dependency
+	// process killed by a signal
+	case event.Signal != 0:
+		// signal cause
+		return IncidentSameSignal, fmt.Sprintf("signal %d", event.Signal)
+	// resource pressure on the service cgroup
+	case event.Type == EventPressureAlert:
+		// pressure cause
+		return IncidentHostPressure, ""
+	// no identifiable cause
+	default:
+		// no cause
+		return "", ""
+	}
+}
@@ -0,0 +1,220 @@
+// Package process_test provides external tests for incident.go.
+// It tests the public API of IncidentCorrelator using black-box testing.
+package process_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// failure describes an event fed to the correlator.
+type failure struct {
+	service    string
+	eventType  process.EventType
+	offset     time.Duration
+	signal     int
+	dependency string
+}
+
+// TestIncidentCorrelator_Observe tests grouping of failures into incidents.
+//
+// Params:
+//   - t: the testing context.
+func TestIncidentCorrelator_Observe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		minServices  int
+		failures     []failure
+		wantOpenedBy int
+		wantCause    process.IncidentCause
+		wantDetail   string
+		wantServices []string
+	}{
+		{
+			name: "shared_dependency",
+			failures: []failure{
+				{service: "api", eventType: process.EventDependencyDown, dependency: "db:5432"},
+				{service: "worker", eventType: process.EventDependencyDown, offset: time.Second, dependency: "db:5432"},
+			},
+			wantOpenedBy: 1,
+			wantCause:    process.IncidentSharedDependency,
+			wantDetail:   "db:5432",
+			wantServices: []string{"api", "worker"},
+		},
+		{
+			name: "same_signal",
+			failures: []failure{
+				{service: "api", eventType: process.EventFailed, signal: 9},
+				{service: "worker", eventType: process.EventFailed, offset: time.Second, signal: 9},
+			},
+			wantOpenedBy: 1,
+			wantCause:    process.IncidentSameSignal,
+			wantDetail:   "signal 9",
+			wantServices: []string{"api", "worker"},
+		},
+		{
+			name: "host_pressure",
+			failures: []failure{
+				{service: "api", eventType: process.EventPressureAlert},
+				{service: "worker", eventType: process.EventPressureAlert, offset: time.Second},
+			},
+			wantOpenedBy: 1,
+			wantCause:    process.IncidentHostPressure,
+			wantServices: []string{"api", "worker"},
+		},
+		{
+			name: "concurrent_without_shared_cause",
+			failures: []failure{
+				{service: "api", eventType: process.EventFailed, signal: 9},
+				{service: "worker", eventType: process.EventUnhealthy, offset: time.Second},
+			},
+			wantOpenedBy: 1,
+			wantCause:    process.IncidentConcurrent,
+			wantServices: []string{"api", "worker"},
+		},
+		{
+			name: "same_service_twice_is_not_an_incident",
+			failures: []failure{
+				{service: "api", eventType: process.EventFailed},
+				{service: "api", eventType: process.EventFailed, offset: time.Second},
+			},
+			wantOpenedBy: -1,
+		},
+		{
+			name: "failures_outside_window",
+			failures: []failure{
+				{service: "api", eventType: process.EventFailed},
+				{service: "worker", eventType: process.EventFailed, offset: time.Minute},
+			},
+			wantOpenedBy: -1,
+		},
+		{
+			name: "non_failures_ignored",
+			failures: []failure{
+				{service: "api", eventType: process.EventFailed},
+				{service: "worker", eventType: process.EventRestarting, offset: time.Second},
+			},
+			wantOpenedBy: -1,
+		},
+		{
+			name:        "min_services",
+			minServices: 3,
+			failures: []failure{
+				{service: "api", eventType: process.EventFailed},
+				{service: "worker", eventType: process.EventFailed, offset: time.Second},
+				{service: "cron", eventType: process.EventStartTimeout, offset: 2 * time.Second},
+			},
+			wantOpenedBy: 2,
+			wantCause:    process.IncidentConcurrent,
+			wantServices: []string{"api", "worker", "cron"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			correlator := process.NewIncidentCorrelator(10*time.Second, tt.minServices)
+			base := time.Now()
+
+			opened := -1
+			var incident *process.Incident
+			// feed failures in order
+			for i, f := range tt.failures {
+				event := process.NewEvent(f.eventType, f.service, 0, 0, nil)
+				event.Timestamp = base.Add(f.offset)
+				event.Signal = f.signal
+				event.Dependency = f.dependency
+				// remember the event opening the incident
+				if got := correlator.Observe(f.service, &event); got != nil {
+					opened, incident = i, got
+					assert.Equal(t, got, event.Incident)
+				}
+			}
+
+			assert.Equal(t, tt.wantOpenedBy, opened)
+			// check the incident when one opened
+			if tt.wantOpenedBy >= 0 {
+				require.NotNil(t, incident)
+				assert.Equal(t, uint64(1), incident.ID)
+				assert.Equal(t, tt.wantCause, incident.Cause)
+				assert.Equal(t, tt.wantDetail, incident.Detail)
+				assert.Equal(t, tt.wantServices, incident.Services)
+			}
+		})
+	}
+}
+
+// TestIncidentCorrelator_Observe_joinsOpenIncident tests that failures
+// following an incident join it until a quiet window elapses.
+//
+// Params:
+//   - t: the testing context.
+func TestIncidentCorrelator_Observe_joinsOpenIncident(t *testing.T) {
+	t.Parallel()
+	correlator := process.NewIncidentCorrelator(10*time.Second, 2)
+	base := time.Now()
+	observe := func(service string, offset time.Duration) (*process.Incident, *process.Event) {
+		event := process.NewEvent(process.EventFailed, service, 0, 0, nil)
+		event.Timestamp = base.Add(offset)
+		return correlator.Observe(service, &event), &event
+	}
+
+	_, _ = observe("api", 0)
+	opened, _ := observe("worker", time.Second)
+	require.NotNil(t, opened)
+
+	// A later failure joins silently, extending the incident.
+	joined, event := observe("cron", 8*time.Second)
+	assert.Nil(t, joined)
+	require.NotNil(t, event.Incident)
+	assert.Equal(t, uint64(1), event.Incident.ID)
+	assert.Equal(t, []string{"api", "worker", "cron"}, event.Incident.Services)
+	// The reported incident is a copy.
+	assert.Equal(t, []string{"api", "worker"}, opened.Services)
+
+	// After a quiet window a new incident can open.
+	_, event = observe("api", 30*time.Second)
+	assert.Nil(t, event.Incident)
+	reopened, _ := observe("worker", 31*time.Second)
+	require.NotNil(t, reopened)
+	assert.Equal(t, uint64(2), reopened.ID)
+}
+
+// TestIncident_String tests the incident description.
+//
+// Params:
+//   - t: the testing context.
+func TestIncident_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		incident process.Incident
+		want     string
+	}{
+		{
+			name:     "with_detail",
+			incident: process.Incident{Cause: process.IncidentSharedDependency, Detail: "db:5432", Services: []string{"api", "worker"}},
+			want:     "shared_dependency db:5432: api, worker",
+		},
+		{
+			name:     "without_detail",
+			incident: process.Incident{Cause: process.IncidentConcurrent, Services: []string{"api", "worker"}},
+			want:     "concurrent: api, worker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.incident.String())
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import "time"
+
+// JobRun is the outcome of one run of a oneshot service.
+type JobRun struct {
+	// Service is the name of the oneshot service.
+	Service string
+	// StartedAt is when the run started, or failed to start.
+	StartedAt time.Time
+	// Duration is how long the process ran.
+	Duration time.Duration
+	// ExitCode is the exit code of the process.
+	ExitCode int
+	// Signal is the number of the signal that terminated the process, zero if none.
+	Signal int
+	// Succeeded is true when the process exited with code 0.
+	Succeeded bool
+	// Error describes the failure, empty on success.
+	Error string
+	// Output holds the last lines the run wrote, oldest first.
+	Output []string
+}
+
+// NewJobRun creates the outcome of a run from its terminal event.
+//
+// Params:
+//   - startedAt: when the run started; zero when the process never started.
+//   - event: the stopped or failed event ending the run.
+//   - output: the last lines the run wrote.
+//
+// Returns:
+//   - JobRun: the run outcome.
+func NewJobRun(startedAt time.Time, event *Event, output []string) JobRun {
+	run := JobRun{
+		Service:   event.Process,
+		StartedAt: startedAt,
+		ExitCode:  event.ExitCode,
+		Signal:    event.Signal,
+		Succeeded: event.Type == EventStopped,
+		Output:    output,
+	}
+	// the process never started
+	if startedAt.IsZero() || event.Timestamp.Before(startedAt) {
+		run.StartedAt = event.Timestamp
+	}
+	run.Duration = event.Timestamp.Sub(run.StartedAt)
+	// describe the failure
+	if event.Error != nil {
+		run.Error = event.Error.Error()
+	}
+	// return run outcome
+	return run
+}
@@ -0,0 +1,67 @@
+// Package process_test provides external tests for job_run.go.
+// It tests the public API of JobRun using black-box testing.
+package process_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestNewJobRun tests building a run outcome from its terminal event.
+//
+// Params:
+//   - t: the testing context.
+func TestNewJobRun(t *testing.T) {
+	start := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// startedAt is when the run started.
+		startedAt time.Time
+		// event is the terminal event.
+		event process.Event
+		// want is the expected run.
+		want process.JobRun
+	}{
+		{
+			name:      "succeeded",
+			startedAt: start,
+			event:     process.Event{Type: process.EventStopped, Process: "backup", Timestamp: start.Add(time.Minute)},
+			want: process.JobRun{
+				Service: "backup", StartedAt: start, Duration: time.Minute, Succeeded: true,
+				Output: []string{"done"},
+			},
+		},
+		{
+			name:      "failed",
+			startedAt: start,
+			event: process.Event{
+				Type: process.EventFailed, Process: "backup", ExitCode: 2,
+				Timestamp: start.Add(time.Second), Error: errors.New("exit code 2"),
+			},
+			want: process.JobRun{
+				Service: "backup", StartedAt: start, Duration: time.Second, ExitCode: 2,
+				Error: "exit code 2", Output: []string{"done"},
+			},
+		},
+		{
+			name: "never_started",
+			event: process.Event{
+				Type: process.EventFailed, Process: "backup", Timestamp: start, Error: errors.New("no such file"),
+			},
+			want: process.JobRun{Service: "backup", StartedAt: start, Error: "no such file", Output: []string{"done"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, process.NewJobRun(tt.startedAt, &tt.event, []string{"done"}))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"context"
+	"time"
+)
+
+// ProcessNode is a process and the processes it spawned.
+type ProcessNode struct {
+	// PID is the process ID.
+	PID int
+	// Name is the command name reported by the kernel.
+	Name string
+	// Cmdline is the command line, empty for kernel threads and zombies.
+	Cmdline string
+	// State is the kernel state letter (R, S, D, Z, ...).
+	State string
+	// RSS is the resident set size in bytes.
+	RSS uint64
+	// CPUTime is the user and system CPU time consumed.
+	CPUTime time.Duration
+	// CPUPercent is the CPU time over the process lifetime, like ps.
+	CPUPercent float64
+	// Children lists the child processes, by PID.
+	Children []ProcessNode
+}
+
+// Size returns the number of processes in the tree.
+//
+// Returns:
+//   - int: the node itself and all its descendants.
+func (n *ProcessNode) Size() int {
+	size := 1
+	// count each subtree
+	for i := range n.Children {
+		size += n.Children[i].Size()
+	}
+	// return the count
+	return size
+}
+
+// TotalRSS returns the resident memory of the whole tree.
+//
+// Returns:
+//   - uint64: the RSS of the node and all its descendants, in bytes.
+func (n *ProcessNode) TotalRSS() uint64 {
+	total := n.RSS
+	// add each subtree
+	for i := range n.Children {
+		total += n.Children[i].TotalRSS()
+	}
+	// return the sum
+	return total
+}
+
+// ServiceTree is the process tree under a supervised service.
+type ServiceTree struct {
+	// Service is the service name.
+	Service string
+	// Root is the main process of the service.
+	Root ProcessNode
+}
+
+// TreeReader reads the processes spawned by running processes.
+// Infrastructure layer implements this interface with procfs.
+type TreeReader interface {
+	// ProcessTrees returns the tree under each root PID, following parent
+	// PIDs. Roots that no longer exist are missing from the result.
+	ProcessTrees(ctx context.Context, roots []int) (map[int]ProcessNode, error)
+}
@@ -0,0 +1,44 @@
+// Package process_test provides black-box tests for the process_tree.go file.
+package process_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestProcessNode_totals validates the process count and memory of a tree.
+//
+// Params:
+//   - t: the testing context
+func TestProcessNode_totals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		node     process.ProcessNode
+		wantSize int
+		wantRSS  uint64
+	}{
+		{name: "single process", node: process.ProcessNode{PID: 10, RSS: 100}, wantSize: 1, wantRSS: 100},
+		{
+			name: "nested workers",
+			node: process.ProcessNode{PID: 10, RSS: 100, Children: []process.ProcessNode{
+				{PID: 11, RSS: 20, Children: []process.ProcessNode{{PID: 13, RSS: 5}}},
+				{PID: 12, RSS: 30},
+			}},
+			wantSize: 4,
+			wantRSS:  155,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantSize, tt.node.Size())
+			assert.Equal(t, tt.wantRSS, tt.node.TotalRSS())
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// RedactedValue replaces the value of environment variables that look like secrets.
+const RedactedValue string = "[REDACTED]"
+
+// secretKeyMarkers lists the upper-case fragments that mark an environment
+// variable name as secret.
+var secretKeyMarkers []string = []string{
+	"SECRET",
+	"PASSWORD",
+	"PASSWD",
+	"TOKEN",
+	"CREDENTIAL",
+	"PRIVATE",
+	"API_KEY",
+	"APIKEY",
+	"ACCESS_KEY",
+	"AUTH",
+}
+
+// ResourceLimit is one resource limit applied to a running process.
+type ResourceLimit struct {
+	// Name is the limit name as reported by the kernel (e.g. "Max open files").
+	Name string
+	// Soft is the soft limit, "unlimited" when not bounded.
+	Soft string
+	// Hard is the hard limit, "unlimited" when not bounded.
+	Hard string
+	// Unit is the limit unit, empty when the kernel reports none.
+	Unit string
+}
+
+// Inspection holds the kernel-side view of a running process.
+type Inspection struct {
+	// CgroupPath is the cgroup the process belongs to, relative to the cgroup root.
+	CgroupPath string
+	// Limits lists the resource limits of the process.
+	Limits []ResourceLimit
+}
+
+// Inspector reads the kernel-side view of a running process.
+// Infrastructure layer implements this interface with procfs.
+type Inspector interface {
+	// Inspect returns the cgroup and resource limits of a process.
+	Inspect(ctx context.Context, pid int) (Inspection, error)
+}
+
+// ResolvedSpec is the specification a service was actually launched with.
+// Secret-looking environment values are redacted.
+type ResolvedSpec struct {
+	// Service is the service name.
+	Service string
+	// PID is the process ID, zero when the process is not running.
+	PID int
+	// StartedAt is when the process was launched.
+	StartedAt time.Time
+	// Command is the executable path or command.
+	Command string
+	// Args contains command-line arguments.
+	Args []string
+	// Dir is the working directory.
+	Dir string
+	// Env contains environment variables, with secret values redacted.
+	Env map[string]string
+	// User is the user the process runs as.
+	User string
+	// Group is the group the process runs as.
+	Group string
+	// CgroupPath is the cgroup of the process, empty when unknown.
+	CgroupPath string
+	// Limits lists the resource limits of the process.
+	Limits []ResourceLimit
+	// Listeners lists the configured listeners of the service.
+	Listeners []config.ListenerConfig
+}
+
+// NewResolvedSpec builds the resolved view of a launched specification.
+// The environment is copied with secret values redacted.
+//
+// Params:
+//   - service: the service name.
+//   - pid: the process ID.
+//   - startedAt: the launch time.
+//   - spec: the specification passed to the executor.
+//   - secretKeys: variables redacted whatever their name, such as decrypted ones.
+//
+// Returns:
+//   - ResolvedSpec: the resolved view, without kernel-side details.
+func NewResolvedSpec(service string, pid int, startedAt time.Time, spec *Spec, secretKeys ...string) ResolvedSpec {
+	// copy spec fields with redacted environment
+	return ResolvedSpec{
+		Service:   service,
+		PID:       pid,
+		StartedAt: startedAt,
+		Command:   spec.Command,
+		Args:      spec.Args,
+		Dir:       spec.Dir,
+		Env:       RedactEnv(spec.Env, secretKeys...),
+		User:      spec.User,
+		Group:     spec.Group,
+	}
+}
+
+// RedactEnv returns a copy of env where secret values are replaced by RedactedValue.
+//
+// Params:
+//   - env: the environment variables.
+//   - secretKeys: variables redacted whatever their name.
+//
+// Returns:
+//   - map[string]string: the redacted copy, nil when env is nil.
+func RedactEnv(env map[string]string, secretKeys ...string) map[string]string {
+	redacted := maps.Clone(env)
+	// replace secret values
+	for key := range redacted {
+		// keep non-secret values
+		if IsSecretKey(key) || slices.Contains(secretKeys, key) {
+			redacted[key] = RedactedValue
+		}
+	}
+	// return redacted copy
+	return redacted
+}
+
+// IsSecretKey reports whether an environment variable name looks like a secret.
+//
+// Params:
+//   - key: the variable name.
+//
+// Returns:
+//   - bool: true if the name contains a secret marker, case-insensitively.
+func IsSecretKey(key string) bool {
+	upper := strings.ToUpper(key)
+	// check each marker
+	for _, marker := range secretKeyMarkers {
+		// marker found in name
+		if strings.Contains(upper, marker) {
+			// secret variable
+			return true
+		}
+	}
+	// ordinary variable
+	return false
+}
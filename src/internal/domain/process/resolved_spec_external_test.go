@@ -0,0 +1,95 @@
+// Package process_test provides black-box tests for the resolved_spec.go file.
+package process_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestIsSecretKey validates secret detection on environment variable names.
+//
+// Params:
+//   - t: the testing context
+func TestIsSecretKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "plain variable", key: "PATH", want: false},
+		{name: "port variable", key: "HTTP_PORT", want: false},
+		{name: "password", key: "DB_PASSWORD", want: true},
+		{name: "token lower case", key: "github_token", want: true},
+		{name: "api key", key: "STRIPE_API_KEY", want: true},
+		{name: "secret", key: "JWT_SECRET", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, process.IsSecretKey(tt.key))
+		})
+	}
+}
+
+// TestRedactEnv verifies secret values are replaced without mutating the input.
+//
+// Params:
+//   - t: the testing context
+func TestRedactEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{"PATH": "/usr/bin", "DB_PASSWORD": "hunter2"}
+	redacted := process.RedactEnv(env)
+
+	assert.Equal(t, "/usr/bin", redacted["PATH"])
+	assert.Equal(t, process.RedactedValue, redacted["DB_PASSWORD"])
+	assert.Equal(t, "hunter2", env["DB_PASSWORD"])
+	assert.Nil(t, process.RedactEnv(nil))
+
+	// decrypted variables are redacted whatever their name
+	env["DATABASE_URL"] = "postgres://app:hunter2@db/app"
+	redacted = process.RedactEnv(env, "DATABASE_URL")
+	assert.Equal(t, process.RedactedValue, redacted["DATABASE_URL"])
+	assert.Equal(t, "/usr/bin", redacted["PATH"])
+}
+
+// TestNewResolvedSpec verifies the resolved view copies the launched spec.
+//
+// Params:
+//   - t: the testing context
+func TestNewResolvedSpec(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	spec := process.NewSpec(process.SpecParams{
+		Command: "/usr/bin/app",
+		Args:    []string{"--port", "8080"},
+		Dir:     "/srv",
+		Env:     map[string]string{"API_TOKEN": "abc", "MODE": "prod"},
+		User:    "app",
+		Group:   "app",
+	})
+
+	resolved := process.NewResolvedSpec("api", 42, started, &spec)
+
+	assert.Equal(t, "api", resolved.Service)
+	assert.Equal(t, 42, resolved.PID)
+	assert.Equal(t, started, resolved.StartedAt)
+	assert.Equal(t, "/usr/bin/app", resolved.Command)
+	assert.Equal(t, []string{"--port", "8080"}, resolved.Args)
+	assert.Equal(t, "/srv", resolved.Dir)
+	assert.Equal(t, "app", resolved.User)
+	assert.Equal(t, "app", resolved.Group)
+	assert.Equal(t, map[string]string{"API_TOKEN": process.RedactedValue, "MODE": "prod"}, resolved.Env)
+
+	// decrypted variables are redacted whatever their name
+	resolved = process.NewResolvedSpec("api", 42, started, &spec, "MODE")
+	assert.Equal(t, process.RedactedValue, resolved.Env["MODE"])
+}
@@ -0,0 +1,28 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import "time"
+
+// ProcessResources are the resources an executor holds for one process start.
+type ProcessResources struct {
+	// PID is the process ID.
+	PID int
+	// Service is the service name of the start.
+	Service string
+	// StartedAt is when the process started.
+	StartedAt time.Time
+	// Waiting reports that the executor still waits for the process exit.
+	Waiting bool
+	// Cgroup is the cgroup directory created for the process, empty when none is held.
+	Cgroup string
+}
+
+// ResourceLedger accounts for the resources an executor creates per process
+// start, so that resources outliving their owner can be detected.
+// Infrastructure layer implements this interface alongside the Executor.
+type ResourceLedger interface {
+	// Resources returns the resources held, ordered by PID.
+	Resources() []ProcessResources
+	// Release removes the resources still held for an exited process.
+	Release(pid int) error
+}
@@ -0,0 +1,70 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import "time"
+
+// RestartBudget is a token bucket bounding the restarts the daemon performs
+// across all services. Restarts beyond the bucket are queued: each
+// reservation takes the next token, even one not refilled yet, and reports
+// how long to wait for it, so deferred restarts run in reservation order.
+// RestartBudget is not safe for concurrent use.
+type RestartBudget struct {
+	// interval is the time refilling one token.
+	interval time.Duration
+	// tolerance is how far ahead of the rate a full bucket lets restarts run.
+	tolerance time.Duration
+	// next is when the bucket would be back to full, zero when it is full.
+	next time.Time
+}
+
+// NewRestartBudget creates a full restart budget.
+//
+// Params:
+//   - perMinute: the restarts refilled per minute, at least 1.
+//   - burst: the restarts a full bucket allows at once, at least 1.
+//
+// Returns:
+//   - *RestartBudget: the budget.
+func NewRestartBudget(perMinute, burst int) *RestartBudget {
+	interval := time.Minute / time.Duration(max(perMinute, 1))
+	// construct budget with a full bucket
+	return &RestartBudget{
+		interval:  interval,
+		tolerance: time.Duration(max(burst, 1)-1) * interval,
+	}
+}
+
+// Reserve takes a token for one restart.
+//
+// Params:
+//   - now: the current time.
+//
+// Returns:
+//   - time.Duration: how long the restart must wait for its token, zero when available.
+func (b *RestartBudget) Reserve(now time.Time) time.Duration {
+	// the bucket refilled since the last reservation
+	if b.next.Before(now) {
+		b.next = now
+	}
+	wait := b.next.Add(-b.tolerance).Sub(now)
+	b.next = b.next.Add(b.interval)
+	// tokens left in the bucket
+	if wait < 0 {
+		// restart at once
+		return 0
+	}
+	// return time until the token is refilled
+	return wait
+}
+
+// Cancel returns the token of a reservation given up before its restart.
+//
+// Params:
+//   - now: the current time.
+func (b *RestartBudget) Cancel(now time.Time) {
+	b.next = b.next.Add(-b.interval)
+	// a bucket never holds more than its burst
+	if b.next.Before(now) {
+		b.next = now
+	}
+}
@@ -0,0 +1,80 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestRestartBudget_Reserve tests that restarts beyond the burst wait for refilled tokens.
+//
+// Params:
+//   - t: the testing context.
+func TestRestartBudget_Reserve(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// perMinute is the refill rate.
+		perMinute int
+		// burst is the bucket size.
+		burst int
+		// at are the reservation times, as offsets from the start.
+		at []time.Duration
+		// want are the expected waits.
+		want []time.Duration
+	}{
+		{
+			name:      "burst then queued",
+			perMinute: 3,
+			burst:     2,
+			at:        []time.Duration{0, 0, 0, 0},
+			want:      []time.Duration{0, 0, 20 * time.Second, 40 * time.Second},
+		},
+		{
+			name:      "refilled over time",
+			perMinute: 6,
+			burst:     1,
+			at:        []time.Duration{0, 5 * time.Second, 20 * time.Second},
+			want:      []time.Duration{0, 5 * time.Second, 0},
+		},
+		{
+			name:      "idle bucket holds no more than its burst",
+			perMinute: 60,
+			burst:     2,
+			at:        []time.Duration{time.Hour, time.Hour, time.Hour},
+			want:      []time.Duration{0, 0, time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Unix(1_700_000_000, 0)
+			budget := process.NewRestartBudget(tt.perMinute, tt.burst)
+			got := make([]time.Duration, 0, len(tt.at))
+			for _, at := range tt.at {
+				got = append(got, budget.Reserve(start.Add(at)))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestRestartBudget_Cancel tests that a cancelled reservation returns its token.
+//
+// Params:
+//   - t: the testing context.
+func TestRestartBudget_Cancel(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	budget := process.NewRestartBudget(6, 1)
+
+	assert.Equal(t, time.Duration(0), budget.Reserve(now))
+	assert.Equal(t, 10*time.Second, budget.Reserve(now))
+	budget.Cancel(now)
+
+	// The cancelled token goes to the next reservation.
+	assert.Equal(t, 10*time.Second, budget.Reserve(now))
+}
@@ -22,12 +22,18 @@ const (
 	MaxBackoffAttempts int = 30
 )
 
-// RestartTracker tracks restart attempts for a service and implements
-// exponential backoff logic to prevent rapid restart cycles.
+// Compile-time interface check.
+var _ RestartDecider = (*RestartTracker)(nil)
+
+// RestartTracker tracks restart attempts for a service and delays restarts
+// with the strategy of the service to prevent rapid restart cycles.
 type RestartTracker struct {
 	// config holds the restart configuration from the service definition.
 	config *config.RestartConfig
 
+	// strategy computes the delay before each restart.
+	strategy RestartStrategy
+
 	// attempts tracks the current number of restart attempts since the last reset.
 	attempts int
 
@@ -40,6 +46,8 @@ type RestartTracker struct {
 }
 
 // NewRestartTracker creates a new restart tracker with the given configuration.
+// A strategy that is not registered falls back to exponential backoff; use
+// NewRestartStrategy to detect it beforehand.
 //
 // Params:
 //   - cfg: the restart configuration containing policy, max retries, and delays
@@ -52,10 +60,16 @@ func NewRestartTracker(cfg *config.RestartConfig) *RestartTracker {
 	if cfg.StabilityWindow.Duration() > 0 {
 		window = cfg.StabilityWindow.Duration()
 	}
+	strategy, err := NewRestartStrategy(cfg)
+	// fall back to exponential backoff
+	if err != nil {
+		strategy = newExponentialBackoff(cfg)
+	}
 	// construct tracker with configuration
 	return &RestartTracker{
-		config: cfg,
-		window: window,
+		config:   cfg,
+		strategy: strategy,
+		window:   window,
 	}
 }
 
@@ -140,29 +154,16 @@ func (rt *RestartTracker) Attempts() int {
 	return rt.attempts
 }
 
-// NextDelay calculates the next restart delay using exponential backoff.
-// The delay doubles with each attempt: delay = baseDelay * 2^attempts,
-// capped at the configured maximum delay.
+// NextDelay calculates the next restart delay with the strategy of the
+// service. With the default exponential strategy, the delay doubles with
+// each attempt: delay = baseDelay * 2^attempts, capped at the configured
+// maximum delay.
 //
 // Returns:
 //   - time.Duration: the calculated delay before the next restart attempt
 func (rt *RestartTracker) NextDelay() time.Duration {
-	baseDelay := rt.config.Delay.Duration()
-	maxDelay := rt.config.DelayMax.Duration()
-
-	// use default max delay if not configured
-	if maxDelay == 0 {
-		maxDelay = baseDelay * time.Duration(DefaultMaxDelayMultiplier)
-	}
-
-	// Exponential backoff: delay * 2^attempts
-	// Cap attempts to prevent overflow
-	attempts := min(rt.attempts, MaxBackoffAttempts)
-	// #nosec G115 - attempts is capped to MaxBackoffAttempts (30), safe for uint conversion
-	delay := baseDelay * time.Duration(1<<uint(attempts))
-
-	// cap delay at maximum
-	return min(delay, maxDelay)
+	// delegate to the strategy
+	return rt.strategy.Delay(rt.attempts)
 }
 
 // IsExhausted returns true if all restart attempts have been used.
@@ -0,0 +1,222 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// Restart strategy errors.
+var (
+	// ErrUnknownRestartStrategy is returned when a service names a strategy that is not registered.
+	ErrUnknownRestartStrategy error = errors.New("unknown restart strategy")
+	// ErrRestartStrategyExists is returned when a strategy name is registered twice.
+	ErrRestartStrategyExists error = errors.New("restart strategy already registered")
+)
+
+// RestartDecider decides whether and when a service restarts after its
+// process exits. RestartTracker is the default implementation.
+type RestartDecider interface {
+	// ShouldRestart reports whether the process is restarted after exiting with exitCode (-1 when it failed to start).
+	ShouldRestart(exitCode int) bool
+	// RecordAttempt counts a restart attempt.
+	RecordAttempt()
+	// MaybeReset forgets past attempts once the process ran for long enough.
+	MaybeReset(uptime time.Duration)
+	// NextDelay returns the delay before the next restart.
+	NextDelay() time.Duration
+	// IsExhausted reports whether the attempt budget of the service is spent.
+	IsExhausted() bool
+	// Attempts returns the attempts since the last reset.
+	Attempts() int
+}
+
+// RestartStrategy computes the delay before a restart.
+type RestartStrategy interface {
+	// Delay returns the delay before the restart following attempts
+	// restarts since the last reset.
+	Delay(attempts int) time.Duration
+}
+
+// RestartStrategyFactory builds a restart strategy from the restart
+// settings of a service.
+type RestartStrategyFactory func(cfg *config.RestartConfig) RestartStrategy
+
+// restartStrategies holds the strategies selectable by name.
+var restartStrategies = struct {
+	mu        sync.RWMutex
+	factories map[config.RestartStrategy]RestartStrategyFactory
+}{
+	factories: map[config.RestartStrategy]RestartStrategyFactory{
+		config.StrategyExponential: newExponentialBackoff,
+		config.StrategyFixed:       newFixedBackoff,
+		config.StrategyFibonacci:   newFibonacciBackoff,
+	},
+}
+
+// RegisterRestartStrategy makes a custom strategy selectable through
+// restart.strategy. It is meant to be called at program start, before the
+// configuration is loaded.
+//
+// Params:
+//   - name: the strategy name used in the configuration.
+//   - factory: builds the strategy of a service.
+//
+// Returns:
+//   - error: ErrRestartStrategyExists if the name is taken.
+func RegisterRestartStrategy(name config.RestartStrategy, factory RestartStrategyFactory) error {
+	restartStrategies.mu.Lock()
+	defer restartStrategies.mu.Unlock()
+	// names are unique, built-in ones included
+	if _, ok := restartStrategies.factories[name]; ok {
+		// return error with the name
+		return fmt.Errorf("%w: %q", ErrRestartStrategyExists, name)
+	}
+	restartStrategies.factories[name] = factory
+	// strategy registered
+	return nil
+}
+
+// NewRestartStrategy builds the restart strategy a service selects.
+//
+// Params:
+//   - cfg: the restart settings of the service.
+//
+// Returns:
+//   - RestartStrategy: the strategy.
+//   - error: ErrUnknownRestartStrategy if the name is not registered.
+func NewRestartStrategy(cfg *config.RestartConfig) (RestartStrategy, error) {
+	name := cfg.EffectiveStrategy()
+	restartStrategies.mu.RLock()
+	factory, ok := restartStrategies.factories[name]
+	restartStrategies.mu.RUnlock()
+	// strategy must be registered
+	if !ok {
+		// return error with the name
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRestartStrategy, name)
+	}
+	// build strategy from settings
+	return factory(cfg), nil
+}
+
+// backoff holds the delay bounds shared by the built-in strategies.
+type backoff struct {
+	// base is the delay before the first restart.
+	base time.Duration
+	// max caps the delay.
+	max time.Duration
+}
+
+// newBackoff reads the delay bounds of a service.
+//
+// Params:
+//   - cfg: the restart settings.
+//
+// Returns:
+//   - backoff: the bounds, max defaulting to DefaultMaxDelayMultiplier times base.
+func newBackoff(cfg *config.RestartConfig) backoff {
+	b := backoff{base: cfg.Delay.Duration(), max: cfg.DelayMax.Duration()}
+	// use default max delay if not configured
+	if b.max == 0 {
+		b.max = b.base * time.Duration(DefaultMaxDelayMultiplier)
+	}
+	// return bounds
+	return b
+}
+
+// exponentialBackoff doubles the delay at each attempt.
+type exponentialBackoff struct{ backoff }
+
+// newExponentialBackoff builds the exponential strategy.
+//
+// Params:
+//   - cfg: the restart settings.
+//
+// Returns:
+//   - RestartStrategy: the strategy.
+func newExponentialBackoff(cfg *config.RestartConfig) RestartStrategy {
+	// return strategy with the service bounds
+	return exponentialBackoff{newBackoff(cfg)}
+}
+
+// Delay returns base * 2^attempts, capped at max.
+//
+// Params:
+//   - attempts: the restarts since the last reset.
+//
+// Returns:
+//   - time.Duration: the delay.
+func (b exponentialBackoff) Delay(attempts int) time.Duration {
+	// Cap attempts to prevent overflow
+	attempts = min(attempts, MaxBackoffAttempts)
+	// #nosec G115 - attempts is capped to MaxBackoffAttempts (30), safe for uint conversion
+	delay := b.base * time.Duration(1<<uint(attempts))
+	// cap delay at maximum
+	return min(delay, b.max)
+}
+
+// fixedBackoff waits the same delay before every attempt.
+type fixedBackoff struct{ backoff }
+
+// newFixedBackoff builds the fixed strategy.
+//
+// Params:
+//   - cfg: the restart settings.
+//
+// Returns:
+//   - RestartStrategy: the strategy.
+func newFixedBackoff(cfg *config.RestartConfig) RestartStrategy {
+	// return strategy with the service bounds
+	return fixedBackoff{newBackoff(cfg)}
+}
+
+// Delay returns base whatever the attempts.
+//
+// Returns:
+//   - time.Duration: the delay.
+func (b fixedBackoff) Delay(int) time.Duration {
+	// same delay for every attempt
+	return b.base
+}
+
+// fibonacciBackoff grows the delay along the Fibonacci sequence.
+type fibonacciBackoff struct{ backoff }
+
+// newFibonacciBackoff builds the fibonacci strategy.
+//
+// Params:
+//   - cfg: the restart settings.
+//
+// Returns:
+//   - RestartStrategy: the strategy.
+func newFibonacciBackoff(cfg *config.RestartConfig) RestartStrategy {
+	// return strategy with the service bounds
+	return fibonacciBackoff{newBackoff(cfg)}
+}
+
+// Delay returns base times the Fibonacci number of attempts+1 (1, 1, 2, 3,
+// 5, ...), capped at max.
+//
+// Params:
+//   - attempts: the restarts since the last reset.
+//
+// Returns:
+//   - time.Duration: the delay.
+func (b fibonacciBackoff) Delay(attempts int) time.Duration {
+	prev, delay := time.Duration(0), b.base
+	// grow until the attempt or the cap is reached
+	for range min(attempts, MaxBackoffAttempts) {
+		prev, delay = delay, prev+delay
+		// stop growing at the cap
+		if delay >= b.max {
+			// return capped delay
+			return b.max
+		}
+	}
+	// cap delay at maximum
+	return min(delay, b.max)
+}
@@ -0,0 +1,125 @@
+// Package process_test provides external tests for restart_strategy.go.
+// It tests the restart strategies using black-box testing.
+package process_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// TestNewRestartStrategy tests the delays of the built-in strategies.
+//
+// Params:
+//   - t: the testing context.
+func TestNewRestartStrategy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// strategy is the selected strategy.
+		strategy config.RestartStrategy
+		// want are the delays of attempts 0 to 6.
+		want []time.Duration
+	}{
+		{
+			name: "default is exponential",
+			want: []time.Duration{1, 2, 4, 8, 16, 20, 20},
+		},
+		{
+			name:     "exponential",
+			strategy: config.StrategyExponential,
+			want:     []time.Duration{1, 2, 4, 8, 16, 20, 20},
+		},
+		{
+			name:     "fixed",
+			strategy: config.StrategyFixed,
+			want:     []time.Duration{1, 1, 1, 1, 1, 1, 1},
+		},
+		{
+			name:     "fibonacci",
+			strategy: config.StrategyFibonacci,
+			want:     []time.Duration{1, 1, 2, 3, 5, 8, 13},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.RestartConfig{Delay: shared.Seconds(1), DelayMax: shared.Seconds(20), Strategy: tt.strategy}
+			strategy, err := process.NewRestartStrategy(cfg)
+			require.NoError(t, err)
+
+			for attempts, want := range tt.want {
+				assert.Equal(t, want*time.Second, strategy.Delay(attempts), "attempt %d", attempts)
+			}
+		})
+	}
+}
+
+// TestNewRestartStrategy_Fibonacci_Capped tests that fibonacci delays stop at the maximum.
+//
+// Params:
+//   - t: the testing context.
+func TestNewRestartStrategy_Fibonacci_Capped(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.RestartConfig{Delay: shared.Seconds(1), DelayMax: shared.Seconds(20), Strategy: config.StrategyFibonacci}
+	strategy, err := process.NewRestartStrategy(cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, 20*time.Second, strategy.Delay(7))
+	assert.Equal(t, 20*time.Second, strategy.Delay(1000))
+}
+
+// stepStrategy is a custom strategy adding one step per attempt.
+type stepStrategy struct {
+	// step is the delay added per attempt.
+	step time.Duration
+}
+
+// Delay returns step times attempts+1.
+//
+// Params:
+//   - attempts: the restarts since the last reset.
+//
+// Returns:
+//   - time.Duration: the delay.
+func (s stepStrategy) Delay(attempts int) time.Duration {
+	return s.step * time.Duration(attempts+1)
+}
+
+// TestRegisterRestartStrategy tests that registered strategies are selectable by name.
+//
+// Params:
+//   - t: the testing context.
+func TestRegisterRestartStrategy(t *testing.T) {
+	t.Parallel()
+
+	name := config.RestartStrategy("test-linear")
+	cfg := &config.RestartConfig{Delay: shared.Seconds(2), Strategy: name}
+
+	// Unregistered strategies are refused.
+	_, err := process.NewRestartStrategy(cfg)
+	require.ErrorIs(t, err, process.ErrUnknownRestartStrategy)
+
+	require.NoError(t, process.RegisterRestartStrategy(name, func(cfg *config.RestartConfig) process.RestartStrategy {
+		return stepStrategy{step: cfg.Delay.Duration()}
+	}))
+	// Names are registered once, built-in ones included.
+	require.ErrorIs(t, process.RegisterRestartStrategy(name, nil), process.ErrRestartStrategyExists)
+	require.ErrorIs(t, process.RegisterRestartStrategy(config.StrategyFixed, nil), process.ErrRestartStrategyExists)
+
+	tracker := process.NewRestartTracker(cfg)
+	tracker.RecordAttempt()
+	tracker.RecordAttempt()
+	assert.Equal(t, 6*time.Second, tracker.NextDelay())
+}
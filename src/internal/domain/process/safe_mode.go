@@ -0,0 +1,105 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"slices"
+	"time"
+)
+
+// SafeMode is the state of the daemon safe mode. In safe mode, the
+// automatic restarts of non-critical services are held until the daemon
+// is told to exit it.
+type SafeMode struct {
+	// Active reports that the daemon is in safe mode.
+	Active bool
+	// Manual reports that safe mode was entered on request rather than by a restart storm.
+	Manual bool
+	// Since is when safe mode was entered.
+	Since time.Time
+	// Restarts is how many restarts were counted within the window when safe mode was entered.
+	Restarts int
+	// Window is the window the restarts were counted over.
+	Window time.Duration
+	// Held lists the services whose restart is held, sorted.
+	Held []string
+}
+
+// stormRestart is a restart counted by a RestartStorm.
+type stormRestart struct {
+	// at is when the service restarted.
+	at time.Time
+	// service is the restarted service.
+	service string
+}
+
+// RestartStorm counts the restarts across all services over a sliding
+// window, to tell a host-level outage from a single failing service.
+// RestartStorm is not safe for concurrent use.
+type RestartStorm struct {
+	// window is the length of the sliding window.
+	window time.Duration
+	// restarts holds each restart within the window, oldest first.
+	restarts []stormRestart
+}
+
+// NewRestartStorm creates an empty restart counter.
+//
+// Params:
+//   - window: the length of the sliding window.
+//
+// Returns:
+//   - *RestartStorm: the counter.
+func NewRestartStorm(window time.Duration) *RestartStorm {
+	// construct an empty counter
+	return &RestartStorm{window: window}
+}
+
+// Record counts a restart.
+//
+// Params:
+//   - service: the restarted service.
+//   - now: the time of the restart.
+//
+// Returns:
+//   - int: the restarts within the window, this one included.
+func (r *RestartStorm) Record(service string, now time.Time) int {
+	cutoff := now.Add(-r.window)
+	dropped := 0
+	// drop the restarts that left the window
+	for dropped < len(r.restarts) && !r.restarts[dropped].at.After(cutoff) {
+		dropped++
+	}
+	r.restarts = append(r.restarts[dropped:], stormRestart{at: now, service: service})
+	// return the restarts within the window
+	return len(r.restarts)
+}
+
+// Services lists the services that restarted within the window, as of
+// the last restart counted.
+//
+// Returns:
+//   - []string: the services, sorted.
+func (r *RestartStorm) Services() []string {
+	services := make([]string, 0, len(r.restarts))
+	// collect each restarted service
+	for _, restart := range r.restarts {
+		services = append(services, restart.service)
+	}
+	slices.Sort(services)
+	// return each service once
+	return slices.Compact(services)
+}
+
+// Reset forgets the restarts counted so far.
+func (r *RestartStorm) Reset() {
+	r.restarts = nil
+}
+
+// Window returns the length of the sliding window.
+//
+// Returns:
+//   - time.Duration: the window.
+func (r *RestartStorm) Window() time.Duration {
+	// return the window
+	return r.window
+}
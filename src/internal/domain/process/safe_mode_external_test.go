@@ -0,0 +1,37 @@
+// Package process_test provides black-box tests for safe_mode.go.
+package process_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestRestartStorm_Record tests the restarts are counted over the window.
+//
+// Params:
+//   - t: the testing context.
+func TestRestartStorm_Record(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+	storm := process.NewRestartStorm(time.Minute)
+
+	assert.Equal(t, 1, storm.Record("web", start))
+	assert.Equal(t, 2, storm.Record("api", start.Add(30*time.Second)))
+	assert.Equal(t, 3, storm.Record("web", start.Add(59*time.Second)))
+	assert.Equal(t, []string{"api", "web"}, storm.Services())
+	// The first restart left the window.
+	assert.Equal(t, 3, storm.Record("db", start.Add(60*time.Second)))
+	// Only the restarts of the last minute remain.
+	assert.Equal(t, 2, storm.Record("db", start.Add(119*time.Second)))
+	assert.Equal(t, []string{"db"}, storm.Services())
+
+	storm.Reset()
+	assert.Empty(t, storm.Services())
+	assert.Equal(t, 1, storm.Record("web", start.Add(120*time.Second)))
+	assert.Equal(t, time.Minute, storm.Window())
+}
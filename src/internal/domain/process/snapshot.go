@@ -0,0 +1,20 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+// ServiceSnapshot is the state of a service once the supervisor applied
+// every event of a control operation, so a later read sees at least that
+// state.
+type ServiceSnapshot struct {
+	// Name is the service name.
+	Name string
+	// State is the process state.
+	State State
+	// PID is the process ID, zero when not running.
+	PID int
+	// OperationID identifies the operation that produced the snapshot,
+	// empty for synchronous requests.
+	OperationID string
+	// Sequence is the number of events applied by the supervisor when the
+	// snapshot was taken.
+	Sequence uint64
+}
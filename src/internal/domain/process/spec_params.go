@@ -1,6 +1,8 @@
 // Package process provides domain entities and value objects for process lifecycle management.
 package process
 
+import "github.com/kodflow/daemon/internal/domain/config"
+
 // SpecParams contains the configuration parameters for creating a process Spec.
 // This groups related parameters to simplify the NewSpec function signature.
 type SpecParams struct {
@@ -16,4 +18,22 @@ type SpecParams struct {
 	User string
 	// Group specifies the group to run as.
 	Group string
+	// SELinuxContext is the SELinux context the command executes under.
+	SELinuxContext string
+	// AppArmorProfile is the AppArmor profile the command executes under.
+	AppArmorProfile string
+	// ReadOnlyPaths are made read-only in a private mount namespace.
+	ReadOnlyPaths []string
+	// MaskedPaths are hidden in a private mount namespace.
+	MaskedPaths []string
+	// TmpfsPaths are covered by a private tmpfs in a private mount namespace.
+	TmpfsPaths []string
+	// PIDNamespace runs the command in its own PID namespace.
+	PIDNamespace bool
+	// Name is the service name, naming the per-service resources.
+	Name string
+	// Egress restricts outbound traffic; empty leaves it unrestricted.
+	Egress []config.EgressRule
+	// DNS overrides /etc/hosts and /etc/resolv.conf in a private mount namespace.
+	DNS config.DNSConfig
 }
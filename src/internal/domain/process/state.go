@@ -17,6 +17,8 @@ const (
 	StateStopping
 	// StateFailed indicates the process has terminated with an error or non-zero exit code.
 	StateFailed
+	// StateSkipped indicates the process was not started because the start conditions of its service are unmet.
+	StateSkipped
 )
 
 // String returns the string representation of the State.
@@ -46,6 +48,10 @@ func (s State) String() string {
 	case StateFailed:
 		// return failed string
 		return "failed"
+	// skipped state
+	case StateSkipped:
+		// return skipped string
+		return "skipped"
 	// unknown state
 	default:
 		// return unknown string
@@ -53,13 +59,13 @@ func (s State) String() string {
 	}
 }
 
-// IsTerminal returns true if the state is a terminal state (stopped or failed).
+// IsTerminal returns true if the state is a terminal state (stopped, failed or skipped).
 //
 // Returns:
 //   - bool: true if the process has reached a terminal state
 func (s State) IsTerminal() bool {
-	// check if state is stopped or failed
-	return s == StateStopped || s == StateFailed
+	// check if state is stopped, failed or skipped
+	return s == StateStopped || s == StateFailed || s == StateSkipped
 }
 
 // IsActive returns true if the process is starting or running.
@@ -115,3 +121,12 @@ func (s State) IsStopped() bool {
 	// check if state is stopped
 	return s == StateStopped
 }
+
+// IsSkipped returns true if the process was skipped for unmet start conditions.
+//
+// Returns:
+//   - bool: true if the process is in skipped state
+func (s State) IsSkipped() bool {
+	// check if state is skipped
+	return s == StateSkipped
+}
@@ -28,6 +28,7 @@ func TestState_String(t *testing.T) {
 		{"running", process.StateRunning, "running"},
 		{"stopping", process.StateStopping, "stopping"},
 		{"failed", process.StateFailed, "failed"},
+		{"skipped", process.StateSkipped, "skipped"},
 		{"unknown", process.State(99), "unknown"},
 	}
 
@@ -55,6 +56,7 @@ func TestState_IsTerminal(t *testing.T) {
 	}{
 		{"stopped is terminal", process.StateStopped, true},
 		{"failed is terminal", process.StateFailed, true},
+		{"skipped is terminal", process.StateSkipped, true},
 		{"starting is not terminal", process.StateStarting, false},
 		{"running is not terminal", process.StateRunning, false},
 		{"stopping is not terminal", process.StateStopping, false},
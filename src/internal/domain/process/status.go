@@ -20,4 +20,6 @@ type Status struct {
 	Restarts int
 	// ExitCode is the last exit code.
 	ExitCode int
+	// Reason explains the state, such as the unmet conditions of a skipped process.
+	Reason string
 }
@@ -0,0 +1,57 @@
+// Package process provides domain entities and value objects for process lifecycle management.
+package process
+
+import (
+	"context"
+	"net/netip"
+	"time"
+)
+
+// Socket is a TCP socket held by a process.
+type Socket struct {
+	// Local is the local address and port.
+	Local netip.AddrPort
+	// Remote is the peer address and port, unset on listening sockets.
+	Remote netip.AddrPort
+	// Listening reports a socket accepting connections.
+	Listening bool
+}
+
+// SocketReader reads the TCP sockets held by running processes.
+// Infrastructure layer implements this interface with procfs.
+type SocketReader interface {
+	// Sockets returns the listening and established TCP sockets held by
+	// each root and the processes it spawned. Roots that no longer exist
+	// are missing from the result.
+	Sockets(ctx context.Context, roots []int) (map[int][]Socket, error)
+}
+
+// TrafficEdge is traffic sampled from a service to a peer: another
+// supervised service, or an endpoint outside the daemon.
+type TrafficEdge struct {
+	// Service is the service opening the connections.
+	Service string
+	// Peer is the supervised service reached, empty for other endpoints.
+	Peer string
+	// Endpoint is the address and port reached, the last seen for a peer.
+	Endpoint string
+	// Declared reports that the configuration declares the dependency,
+	// in depends_on or external_dependencies.
+	Declared bool
+	// Samples counts the samples that saw a connection.
+	Samples int
+	// FirstSeen is when a connection was first seen.
+	FirstSeen time.Time
+	// LastSeen is when a connection was last seen.
+	LastSeen time.Time
+}
+
+// IsExternal reports whether the edge reaches an endpoint no supervised
+// service listens on.
+//
+// Returns:
+//   - bool: true without peer service.
+func (e *TrafficEdge) IsExternal() bool {
+	// return external endpoint
+	return e.Peer == ""
+}
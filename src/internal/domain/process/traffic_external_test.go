@@ -0,0 +1,20 @@
+// Package process_test provides black-box tests for the traffic.go file.
+package process_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// TestTrafficEdge_IsExternal validates the edges reaching no supervised service.
+//
+// Params:
+//   - t: the testing context
+func TestTrafficEdge_IsExternal(t *testing.T) {
+	t.Parallel()
+	assert.True(t, (&process.TrafficEdge{Service: "api", Endpoint: "10.0.0.5:5432"}).IsExternal())
+	assert.False(t, (&process.TrafficEdge{Service: "api", Peer: "cache", Endpoint: "127.0.0.1:6379"}).IsExternal())
+}
@@ -11,8 +11,41 @@ type Nower interface {
 	Now() time.Time
 }
 
-// RealClock implements Nower using the system time.
-// It is a stateless implementation that delegates to time.Now().
+// Clock is the time port of timing-sensitive code: restart backoff, probe
+// intervals, deadlines and log timestamps. Tests inject a FakeClock and
+// advance it instead of sleeping.
+type Clock interface {
+	Nower
+	// NewTimer creates a timer firing once after d.
+	NewTimer(d time.Duration) Timer
+	// NewTicker creates a ticker firing every d.
+	NewTicker(d time.Duration) Ticker
+	// AfterFunc calls f once after d; the returned timer has no channel.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single-shot timer created by a Clock.
+type Timer interface {
+	// C returns the channel receiving the fire time, nil for AfterFunc timers.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing; it reports whether it was active.
+	Stop() bool
+	// Reset restarts the timer for d; it reports whether it was active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is a periodic timer created by a Clock.
+type Ticker interface {
+	// C returns the channel receiving the tick times.
+	C() <-chan time.Time
+	// Stop turns the ticker off.
+	Stop()
+	// Reset changes the period to d.
+	Reset(d time.Duration)
+}
+
+// RealClock implements Clock using the system time.
+// It is a stateless implementation that delegates to the time package.
 type RealClock struct{}
 
 // NewRealClock creates a new RealClock instance.
@@ -33,5 +66,191 @@ func (RealClock) Now() time.Time {
 	return time.Now()
 }
 
+// NewTimer creates a system timer.
+//
+// Params:
+//   - d: the delay before the timer fires.
+//
+// Returns:
+//   - Timer: the timer.
+func (RealClock) NewTimer(d time.Duration) Timer {
+	// wrap system timer
+	return realTimer{time.NewTimer(d)}
+}
+
+// NewTicker creates a system ticker.
+//
+// Params:
+//   - d: the period, which must be positive.
+//
+// Returns:
+//   - Ticker: the ticker.
+func (RealClock) NewTicker(d time.Duration) Ticker {
+	// wrap system ticker
+	return realTicker{time.NewTicker(d)}
+}
+
+// AfterFunc calls f in its own goroutine after d.
+//
+// Params:
+//   - d: the delay before the call.
+//   - f: the function to call.
+//
+// Returns:
+//   - Timer: the timer, to stop the call.
+func (RealClock) AfterFunc(d time.Duration, f func()) Timer {
+	// wrap system timer
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+// realTimer adapts a system timer to Timer.
+type realTimer struct {
+	*time.Timer
+}
+
+// C returns the timer channel.
+//
+// Returns:
+//   - <-chan time.Time: the channel, nil for AfterFunc timers.
+func (t realTimer) C() <-chan time.Time {
+	// expose system channel
+	return t.Timer.C
+}
+
+// realTicker adapts a system ticker to Ticker.
+type realTicker struct {
+	*time.Ticker
+}
+
+// C returns the ticker channel.
+//
+// Returns:
+//   - <-chan time.Time: the channel.
+func (t realTicker) C() <-chan time.Time {
+	// expose system channel
+	return t.Ticker.C
+}
+
+// ScaledClock is a system clock running scale times faster, to fast-forward
+// backoffs, probes and deadlines while testing by hand. Now extrapolates from
+// the creation time; delays are divided by the scale.
+type ScaledClock struct {
+	// scale is the speed factor, above 1 to fast-forward.
+	scale float64
+	// origin is the system time the clock was created.
+	origin time.Time
+}
+
+// NewScaledClock creates a clock running scale times faster than the system.
+//
+// Params:
+//   - scale: the positive speed factor.
+//
+// Returns:
+//   - *ScaledClock: the clock, starting at the current system time.
+func NewScaledClock(scale float64) *ScaledClock {
+	// anchor the scaled time on now
+	return &ScaledClock{scale: scale, origin: time.Now()}
+}
+
+// Now returns the scaled time.
+//
+// Returns:
+//   - time.Time: the origin plus the scaled elapsed time.
+func (c *ScaledClock) Now() time.Time {
+	// scale the elapsed system time
+	return c.origin.Add(time.Duration(float64(time.Since(c.origin)) * c.scale))
+}
+
+// NewTimer creates a system timer for the scaled delay.
+//
+// Params:
+//   - d: the delay in scaled time.
+//
+// Returns:
+//   - Timer: the timer.
+func (c *ScaledClock) NewTimer(d time.Duration) Timer {
+	// wrap system timer with the real delay
+	return scaledTimer{realTimer{time.NewTimer(c.real(d))}, c}
+}
+
+// NewTicker creates a system ticker for the scaled period.
+//
+// Params:
+//   - d: the period in scaled time.
+//
+// Returns:
+//   - Ticker: the ticker.
+func (c *ScaledClock) NewTicker(d time.Duration) Ticker {
+	// wrap system ticker with the real period
+	return scaledTicker{realTicker{time.NewTicker(c.real(d))}, c}
+}
+
+// AfterFunc calls f in its own goroutine after the scaled delay.
+//
+// Params:
+//   - d: the delay in scaled time.
+//   - f: the function to call.
+//
+// Returns:
+//   - Timer: the timer, to stop the call.
+func (c *ScaledClock) AfterFunc(d time.Duration, f func()) Timer {
+	// wrap system timer with the real delay
+	return scaledTimer{realTimer{time.AfterFunc(c.real(d), f)}, c}
+}
+
+// real converts a scaled duration to system time.
+//
+// Params:
+//   - d: the scaled duration.
+//
+// Returns:
+//   - time.Duration: the system duration, at least one nanosecond when d is positive.
+func (c *ScaledClock) real(d time.Duration) time.Duration {
+	// keep zero and negative delays as is
+	if d <= 0 {
+		// fire immediately
+		return d
+	}
+	// tickers refuse a zero period
+	return max(time.Duration(float64(d)/c.scale), 1)
+}
+
+// scaledTimer resets its system timer with scaled delays.
+type scaledTimer struct {
+	realTimer
+	// clock converts the delays.
+	clock *ScaledClock
+}
+
+// Reset restarts the timer for the scaled delay.
+//
+// Params:
+//   - d: the delay in scaled time.
+//
+// Returns:
+//   - bool: true if the timer was active.
+func (t scaledTimer) Reset(d time.Duration) bool {
+	// reset with the real delay
+	return t.Timer.Reset(t.clock.real(d))
+}
+
+// scaledTicker resets its system ticker with scaled periods.
+type scaledTicker struct {
+	realTicker
+	// clock converts the periods.
+	clock *ScaledClock
+}
+
+// Reset changes the period to the scaled one.
+//
+// Params:
+//   - d: the period in scaled time.
+func (t scaledTicker) Reset(d time.Duration) {
+	t.Ticker.Reset(t.clock.real(d))
+}
+
 // DefaultClock is the default clock instance using system time.
-var DefaultClock Nower = &RealClock{}
+// Components capture it when created; the daemon replaces it with a
+// ScaledClock under --time-scale before creating them.
+var DefaultClock Clock = &RealClock{}
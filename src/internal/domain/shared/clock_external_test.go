@@ -125,3 +125,116 @@ func TestNower_Interface(t *testing.T) {
 		})
 	}
 }
+
+// TestRealClock_Timers tests the system timers created by RealClock.
+//
+// Params:
+//   - t: the testing context.
+func TestRealClock_Timers(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, clock shared.Clock)
+	}{
+		{
+			name: "timer_fires",
+			run: func(t *testing.T, clock shared.Clock) {
+				timer := clock.NewTimer(time.Millisecond)
+				select {
+				case <-timer.C():
+				case <-time.After(time.Second):
+					t.Fatal("timer should fire")
+				}
+			},
+		},
+		{
+			name: "ticker_ticks",
+			run: func(t *testing.T, clock shared.Clock) {
+				ticker := clock.NewTicker(time.Millisecond)
+				defer ticker.Stop()
+				select {
+				case <-ticker.C():
+				case <-time.After(time.Second):
+					t.Fatal("ticker should tick")
+				}
+			},
+		},
+		{
+			name: "stopped_after_func_never_runs",
+			run: func(t *testing.T, clock shared.Clock) {
+				timer := clock.AfterFunc(time.Hour, func() { t.Error("callback should not run") })
+				assert.Nil(t, timer.C())
+				assert.True(t, timer.Stop())
+			},
+		},
+	}
+
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, shared.NewRealClock())
+		})
+	}
+}
+
+// TestScaledClock tests the fast-forwarding ScaledClock.
+//
+// Params:
+//   - t: the testing context.
+func TestScaledClock(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, clock *shared.ScaledClock)
+	}{
+		{
+			name: "now_runs_faster",
+			run: func(t *testing.T, clock *shared.ScaledClock) {
+				start := clock.Now()
+				time.Sleep(10 * time.Millisecond)
+				// 10ms of system time is at least 10s of scaled time
+				assert.GreaterOrEqual(t, clock.Now().Sub(start), 10*time.Second)
+			},
+		},
+		{
+			name: "timer_fires_early",
+			run: func(t *testing.T, clock *shared.ScaledClock) {
+				timer := clock.NewTimer(time.Minute)
+				select {
+				case <-timer.C():
+				case <-time.After(time.Second):
+					t.Fatal("a scaled minute should pass within a second")
+				}
+			},
+		},
+		{
+			name: "reset_uses_scaled_delay",
+			run: func(t *testing.T, clock *shared.ScaledClock) {
+				timer := clock.NewTimer(time.Hour * 1000)
+				assert.True(t, timer.Reset(time.Minute))
+				select {
+				case <-timer.C():
+				case <-time.After(time.Second):
+					t.Fatal("reset timer should fire within a second")
+				}
+			},
+		},
+		{
+			name: "ticker_ticks_early",
+			run: func(t *testing.T, clock *shared.ScaledClock) {
+				ticker := clock.NewTicker(time.Minute)
+				defer ticker.Stop()
+				select {
+				case <-ticker.C():
+				case <-time.After(time.Second):
+					t.Fatal("scaled ticker should tick within a second")
+				}
+			},
+		},
+	}
+
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, shared.NewScaledClock(1000))
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package shared provides common domain types used across multiple domain packages.
+package shared
+
+import "errors"
+
+// Code is a stable, machine-readable error code.
+// Codes are part of the public API: gRPC responses and CLI exit codes are
+// derived from them, so external tooling can rely on them instead of
+// matching error messages. Existing values must never change.
+type Code string
+
+// Error codes.
+const (
+	// CodeUnknown is reported for errors that carry no code.
+	CodeUnknown Code = "UNKNOWN"
+	// CodeInvalidArgument indicates a malformed request.
+	CodeInvalidArgument Code = "INVALID_ARGUMENT"
+
+	// CodeServiceNotFound indicates the named service is not configured.
+	CodeServiceNotFound Code = "SVC_NOT_FOUND"
+	// CodeServiceAlreadyRunning indicates the service is already running.
+	CodeServiceAlreadyRunning Code = "SVC_ALREADY_RUNNING"
+	// CodeServiceNotRunning indicates the service is not running.
+	CodeServiceNotRunning Code = "SVC_NOT_RUNNING"
+	// CodeServiceInvalidTransition indicates a state change not allowed from the current state.
+	CodeServiceInvalidTransition Code = "SVC_INVALID_TRANSITION"
+	// CodeServiceFailed indicates the service process failed.
+	CodeServiceFailed Code = "SVC_FAILED"
+	// CodeServiceRetriesExhausted indicates the restart policy gave up.
+	CodeServiceRetriesExhausted Code = "SVC_RETRIES_EXHAUSTED"
+	// CodeServiceUnhealthy indicates the service failed its health probes.
+	CodeServiceUnhealthy Code = "SVC_UNHEALTHY"
+	// CodeServiceStartTimeout indicates the service did not become ready within its start timeout.
+	CodeServiceStartTimeout Code = "SVC_START_TIMEOUT"
+	// CodeServiceMaxRuntime indicates the service was stopped after running for its max runtime.
+	CodeServiceMaxRuntime Code = "SVC_MAX_RUNTIME"
+	// CodeSignalNotAllowed indicates a signal not in the allowlist of the service.
+	CodeSignalNotAllowed Code = "SVC_SIGNAL_NOT_ALLOWED"
+	// CodeAdmissionRefused indicates a start refused because reservations exceed the admitted host capacity.
+	CodeAdmissionRefused Code = "SVC_ADMISSION_REFUSED"
+	// CodeConditionsUnmet indicates a start skipped because the host does not meet the service start conditions.
+	CodeConditionsUnmet Code = "SVC_CONDITIONS_UNMET"
+
+	// CodeSupervisorAlreadyRunning indicates the supervisor is already started.
+	CodeSupervisorAlreadyRunning Code = "SUP_ALREADY_RUNNING"
+	// CodeSupervisorNotRunning indicates the supervisor is not started or stopping.
+	CodeSupervisorNotRunning Code = "SUP_NOT_RUNNING"
+	// CodeBootFailed indicates a critical service failed at boot under the shutdown policy.
+	CodeBootFailed Code = "SUP_BOOT_FAILED"
+
+	// CodeConfigInvalid indicates a configuration that cannot be parsed or fails validation.
+	CodeConfigInvalid Code = "CFG_INVALID"
+	// CodeConfigUnreadable indicates the configuration file cannot be read.
+	CodeConfigUnreadable Code = "CFG_UNREADABLE"
+	// CodeConfigPreflightFailed indicates a configuration refused by host pre-flight checks.
+	CodeConfigPreflightFailed Code = "CFG_PREFLIGHT_FAILED"
+
+	// CodeOperationConflict indicates an operation ID reused for a different action.
+	CodeOperationConflict Code = "OP_CONFLICT"
+
+	// CodeEventStoreUnavailable indicates event replay was requested without an event store.
+	CodeEventStoreUnavailable Code = "EVT_STORE_UNAVAILABLE"
+
+	// CodeReadOnly indicates a change refused because the control plane is read-only.
+	CodeReadOnly Code = "CTL_READ_ONLY"
+)
+
+// CodedError is an error carrying a Code.
+// Sentinels are created with NewCodedError and matched with errors.Is;
+// causes are tagged at layer boundaries with WithCode.
+type CodedError struct {
+	// code is the machine-readable error code.
+	code Code
+	// message is the error text; empty for tagged causes.
+	message string
+	// err is the tagged cause, if any.
+	err error
+}
+
+// NewCodedError creates a sentinel error with a code.
+//
+// Params:
+//   - code: the machine-readable error code.
+//   - message: the error text.
+//
+// Returns:
+//   - *CodedError: the sentinel error.
+func NewCodedError(code Code, message string) *CodedError {
+	// construct sentinel without cause
+	return &CodedError{code: code, message: message}
+}
+
+// WithCode tags an error with a code, keeping its message and chain.
+//
+// Params:
+//   - code: the machine-readable error code.
+//   - err: the error to tag.
+//
+// Returns:
+//   - error: the tagged error, or nil when err is nil.
+func WithCode(code Code, err error) error {
+	// nothing to tag
+	if err == nil {
+		// propagate success
+		return nil
+	}
+	// wrap cause with code
+	return &CodedError{code: code, err: err}
+}
+
+// Error returns the error text.
+//
+// Returns:
+//   - string: the sentinel message or the tagged cause message.
+func (e *CodedError) Error() string {
+	// tagged causes keep their own message
+	if e.err != nil {
+		// cause message
+		return e.err.Error()
+	}
+	// sentinel message
+	return e.message
+}
+
+// Unwrap returns the tagged cause.
+//
+// Returns:
+//   - error: the cause, or nil for sentinels.
+func (e *CodedError) Unwrap() error {
+	// expose cause to errors.Is and errors.As
+	return e.err
+}
+
+// Code returns the error code.
+//
+// Returns:
+//   - Code: the machine-readable error code.
+func (e *CodedError) Code() Code {
+	// return code
+	return e.code
+}
+
+// CodeOf returns the code of the outermost coded error in the chain.
+//
+// Params:
+//   - err: the error to inspect.
+//
+// Returns:
+//   - Code: the error code, or CodeUnknown when the chain carries none.
+func CodeOf(err error) Code {
+	var coded *CodedError
+	// search the chain for a code
+	if errors.As(err, &coded) {
+		// code found
+		return coded.code
+	}
+	// no code in chain
+	return CodeUnknown
+}
@@ -0,0 +1,60 @@
+// Package shared provides common domain types used across multiple domain packages.
+package shared_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// errSentinel is a coded sentinel used by the tests.
+var errSentinel error = shared.NewCodedError(shared.CodeServiceNotFound, "service not found")
+
+// TestCodeOf verifies code extraction through wrapped error chains.
+//
+// Params:
+//   - t: testing context for assertions
+func TestCodeOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want shared.Code
+	}{
+		{name: "nil", err: nil, want: shared.CodeUnknown},
+		{name: "uncoded", err: errors.New("boom"), want: shared.CodeUnknown},
+		{name: "sentinel", err: errSentinel, want: shared.CodeServiceNotFound},
+		{name: "wrapped sentinel", err: fmt.Errorf("%w: api", errSentinel), want: shared.CodeServiceNotFound},
+		{name: "tagged cause", err: shared.WithCode(shared.CodeConfigInvalid, errSentinel), want: shared.CodeConfigInvalid},
+	}
+
+	// iterate over test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, shared.CodeOf(tt.err))
+		})
+	}
+}
+
+// TestWithCode verifies that tagging keeps the message and error chain.
+//
+// Params:
+//   - t: testing context for assertions
+func TestWithCode(t *testing.T) {
+	t.Parallel()
+
+	cause := fmt.Errorf("%w: api", errSentinel)
+	tagged := shared.WithCode(shared.CodeConfigInvalid, cause)
+
+	// message and chain are preserved
+	assert.Equal(t, "service not found: api", tagged.Error())
+	assert.ErrorIs(t, tagged, errSentinel)
+	// nil stays nil
+	assert.NoError(t, shared.WithCode(shared.CodeConfigInvalid, nil))
+}
@@ -0,0 +1,316 @@
+// Package shared provides common value objects and interfaces for the domain layer.
+package shared
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock for tests: time only moves when Advance is called,
+// firing the timers and tickers that became due, so timing-sensitive code
+// is tested without real sleeps.
+type FakeClock struct {
+	// mu protects now and waiters.
+	mu sync.Mutex
+	// added is signaled when a timer or ticker is armed.
+	added *sync.Cond
+	// now is the current fake time.
+	now time.Time
+	// waiters holds the armed timers and tickers.
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a timer or ticker of a FakeClock.
+type fakeWaiter struct {
+	// clock owns the waiter.
+	clock *FakeClock
+	// at is when the waiter fires next.
+	at time.Time
+	// period is the ticker period, zero for timers.
+	period time.Duration
+	// ch receives the fire times, nil for AfterFunc timers.
+	ch chan time.Time
+	// fn is called when an AfterFunc timer fires.
+	fn func()
+}
+
+// NewFakeClock creates a fake clock stopped at start.
+//
+// Params:
+//   - start: the initial time.
+//
+// Returns:
+//   - *FakeClock: the clock.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.added = sync.NewCond(&c.mu)
+	// return stopped clock
+	return c
+}
+
+// Now returns the fake time.
+//
+// Returns:
+//   - time.Time: the time set by the last Advance.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// return fake time
+	return c.now
+}
+
+// NewTimer arms a timer firing once the clock advanced by d.
+//
+// Params:
+//   - d: the delay.
+//
+// Returns:
+//   - Timer: the timer.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	c.arm(w, d)
+	// return armed timer
+	return fakeTimer{w}
+}
+
+// NewTicker arms a ticker firing each time the clock advanced by d.
+// It panics when d is not positive, as the system ticker does.
+//
+// Params:
+//   - d: the period.
+//
+// Returns:
+//   - Ticker: the ticker.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	// a ticker without period would fire forever
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+	w := &fakeWaiter{clock: c, period: d, ch: make(chan time.Time, 1)}
+	c.arm(w, d)
+	// return armed ticker
+	return fakeTicker{w}
+}
+
+// AfterFunc arms a timer calling f once the clock advanced by d.
+// Unlike the system clock, f runs on the goroutine calling Advance.
+//
+// Params:
+//   - d: the delay.
+//   - f: the function to call.
+//
+// Returns:
+//   - Timer: the timer.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	w := &fakeWaiter{clock: c, fn: f}
+	c.arm(w, d)
+	// return armed timer
+	return fakeTimer{w}
+}
+
+// Advance moves the clock forward by d and fires, in time order, every
+// timer and ticker due meanwhile. Ticks are dropped when their channel is
+// full, as with the system ticker.
+//
+// Params:
+//   - d: the duration to advance.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	// fire the due waiters one at a time, callbacks outside the lock
+	for {
+		w := c.nextDue(target)
+		// nothing left before target
+		if w == nil {
+			break
+		}
+		c.now = w.at
+		fn := w.fire()
+		c.mu.Unlock()
+		// run the callback without the lock, it may use the clock
+		if fn != nil {
+			fn()
+		}
+		c.mu.Lock()
+	}
+	c.now = target
+	c.mu.Unlock()
+}
+
+// Waiters returns the number of armed timers and tickers.
+//
+// Returns:
+//   - int: the armed count.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// return armed count
+	return len(c.waiters)
+}
+
+// BlockUntil waits until at least n timers and tickers are armed, so a test
+// advances the clock only once the code under test waits on it.
+//
+// Params:
+//   - n: the expected armed count.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// wait for the code under test to arm its timers
+	for len(c.waiters) < n {
+		c.added.Wait()
+	}
+}
+
+// arm schedules a waiter d after the current time.
+//
+// Params:
+//   - w: the waiter.
+//   - d: the delay.
+func (c *FakeClock) arm(w *fakeWaiter, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.at = c.now.Add(d)
+	// add once, a reset only moves the deadline
+	if !slices.Contains(c.waiters, w) {
+		c.waiters = append(c.waiters, w)
+	}
+	c.added.Broadcast()
+}
+
+// disarm removes a waiter. Must be called with c.mu held.
+//
+// Params:
+//   - w: the waiter.
+//
+// Returns:
+//   - bool: true if the waiter was armed.
+func (c *FakeClock) disarm(w *fakeWaiter) bool {
+	i := slices.Index(c.waiters, w)
+	// already fired or stopped
+	if i < 0 {
+		// report inactive waiter
+		return false
+	}
+	c.waiters = slices.Delete(c.waiters, i, i+1)
+	// report active waiter
+	return true
+}
+
+// nextDue returns the earliest waiter due by target. Must be called with c.mu held.
+//
+// Params:
+//   - target: the time the clock advances to.
+//
+// Returns:
+//   - *fakeWaiter: the waiter, nil when none is due.
+func (c *FakeClock) nextDue(target time.Time) *fakeWaiter {
+	var next *fakeWaiter
+	// find the earliest deadline
+	for _, w := range c.waiters {
+		// keep the earliest due waiter
+		if !w.at.After(target) && (next == nil || w.at.Before(next.at)) {
+			next = w
+		}
+	}
+	// return earliest waiter or nil
+	return next
+}
+
+// fire delivers a due waiter and rearms tickers. Must be called with c.mu held.
+//
+// Returns:
+//   - func(): the AfterFunc callback to run, nil otherwise.
+func (w *fakeWaiter) fire() func() {
+	at := w.at
+	// tickers fire again one period later
+	if w.period > 0 {
+		w.at = w.at.Add(w.period)
+	} else {
+		w.clock.disarm(w)
+	}
+	// AfterFunc timers run their callback
+	if w.fn != nil {
+		// return callback to run outside the lock
+		return w.fn
+	}
+	// drop the tick when the receiver is behind
+	select {
+	case w.ch <- at:
+	default:
+	}
+	// no callback
+	return nil
+}
+
+// fakeTimer is a FakeClock timer.
+type fakeTimer struct {
+	*fakeWaiter
+}
+
+// C returns the timer channel.
+//
+// Returns:
+//   - <-chan time.Time: the channel, nil for AfterFunc timers.
+func (t fakeTimer) C() <-chan time.Time {
+	// expose fake channel
+	return t.ch
+}
+
+// Stop disarms the timer.
+//
+// Returns:
+//   - bool: true if the timer was armed.
+func (t fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	// report whether it was armed
+	return t.clock.disarm(t.fakeWaiter)
+}
+
+// Reset rearms the timer for d.
+//
+// Params:
+//   - d: the delay.
+//
+// Returns:
+//   - bool: true if the timer was armed.
+func (t fakeTimer) Reset(d time.Duration) bool {
+	active := t.Stop()
+	t.clock.arm(t.fakeWaiter, d)
+	// report previous state
+	return active
+}
+
+// fakeTicker is a FakeClock ticker.
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+// C returns the ticker channel.
+//
+// Returns:
+//   - <-chan time.Time: the channel.
+func (t fakeTicker) C() <-chan time.Time {
+	// expose fake channel
+	return t.ch
+}
+
+// Stop disarms the ticker.
+func (t fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.disarm(t.fakeWaiter)
+}
+
+// Reset changes the period and rearms the ticker.
+//
+// Params:
+//   - d: the period.
+func (t fakeTicker) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	t.period = d
+	t.clock.mu.Unlock()
+	t.clock.arm(t.fakeWaiter, d)
+}
@@ -0,0 +1,122 @@
+// Package shared_test provides external tests for the shared domain package.
+package shared_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// fakeStart is the initial time of the fake clocks under test.
+var fakeStart time.Time = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// TestFakeClock tests that FakeClock only moves and fires on Advance.
+//
+// Params:
+//   - t: the testing context.
+func TestFakeClock(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, clock *shared.FakeClock)
+	}{
+		{
+			name: "now_moves_on_advance",
+			run: func(t *testing.T, clock *shared.FakeClock) {
+				assert.Equal(t, fakeStart, clock.Now())
+				clock.Advance(90 * time.Second)
+				assert.Equal(t, fakeStart.Add(90*time.Second), clock.Now())
+			},
+		},
+		{
+			name: "timer_fires_when_due",
+			run: func(t *testing.T, clock *shared.FakeClock) {
+				timer := clock.NewTimer(time.Minute)
+				clock.Advance(59 * time.Second)
+				assert.Empty(t, timer.C())
+				clock.Advance(time.Second)
+				require.Len(t, timer.C(), 1)
+				assert.Equal(t, fakeStart.Add(time.Minute), <-timer.C())
+				assert.Zero(t, clock.Waiters())
+			},
+		},
+		{
+			name: "stopped_timer_never_fires",
+			run: func(t *testing.T, clock *shared.FakeClock) {
+				timer := clock.NewTimer(time.Minute)
+				assert.True(t, timer.Stop())
+				assert.False(t, timer.Stop())
+				clock.Advance(time.Hour)
+				assert.Empty(t, timer.C())
+			},
+		},
+		{
+			name: "reset_moves_deadline",
+			run: func(t *testing.T, clock *shared.FakeClock) {
+				timer := clock.NewTimer(time.Minute)
+				clock.Advance(30 * time.Second)
+				assert.True(t, timer.Reset(time.Minute))
+				clock.Advance(45 * time.Second)
+				assert.Empty(t, timer.C())
+				clock.Advance(15 * time.Second)
+				assert.Len(t, timer.C(), 1)
+			},
+		},
+		{
+			name: "ticker_fires_each_period_and_drops_unread_ticks",
+			run: func(t *testing.T, clock *shared.FakeClock) {
+				ticker := clock.NewTicker(10 * time.Second)
+				clock.Advance(10 * time.Second)
+				assert.Equal(t, fakeStart.Add(10*time.Second), <-ticker.C())
+				clock.Advance(30 * time.Second)
+				// the channel holds one tick, the others were dropped
+				assert.Equal(t, fakeStart.Add(20*time.Second), <-ticker.C())
+				assert.Empty(t, ticker.C())
+				ticker.Stop()
+				clock.Advance(time.Hour)
+				assert.Empty(t, ticker.C())
+			},
+		},
+		{
+			name: "after_func_runs_in_time_order",
+			run: func(t *testing.T, clock *shared.FakeClock) {
+				var order []string
+				var at []time.Time
+				clock.AfterFunc(2*time.Second, func() {
+					order = append(order, "second")
+					at = append(at, clock.Now())
+				})
+				clock.AfterFunc(time.Second, func() {
+					order = append(order, "first")
+					at = append(at, clock.Now())
+				})
+				clock.Advance(time.Minute)
+				assert.Equal(t, []string{"first", "second"}, order)
+				assert.Equal(t, []time.Time{fakeStart.Add(time.Second), fakeStart.Add(2 * time.Second)}, at)
+			},
+		},
+		{
+			name: "block_until_waits_for_armed_timer",
+			run: func(t *testing.T, clock *shared.FakeClock) {
+				fired := make(chan time.Time, 1)
+				go func() {
+					timer := clock.NewTimer(time.Minute)
+					fired <- <-timer.C()
+				}()
+				clock.BlockUntil(1)
+				clock.Advance(time.Minute)
+				assert.Equal(t, fakeStart.Add(time.Minute), <-fired)
+			},
+		},
+	}
+
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, shared.NewFakeClock(fakeStart))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package storage provides domain interfaces for event persistence.
+package storage
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// EventRecord is a persisted service lifecycle event.
+// Records are numbered by a store-assigned sequence that only grows, so a
+// client can resume from the last sequence it processed without missing or
+// repeating events.
+type EventRecord struct {
+	// Seq is the store-assigned sequence number, starting at 1.
+	Seq uint64
+	// Timestamp is when the event occurred.
+	Timestamp time.Time
+	// Service is the name of the service that emitted the event.
+	Service string
+	// Type is the event type name (started, failed, ...).
+	Type string
+	// PID is the process ID at the time of the event.
+	PID int
+	// ExitCode is the exit code for exit events.
+	ExitCode int
+	// Error is the error message attached to the event, if any.
+	Error string
+	// Labels are the labels of the service at the time of the event.
+	Labels map[string]string
+}
+
+// EventFilter selects events returned by ListEvents.
+// Empty fields match every event.
+type EventFilter struct {
+	// Services restricts results to these service names.
+	Services []string
+	// Types restricts results to these event type names.
+	Types []string
+	// From excludes events that occurred before it; zero means no lower bound.
+	From time.Time
+	// To excludes events that occurred at or after it; zero means no upper bound.
+	To time.Time
+	// Limit caps the number of returned events; zero means no limit.
+	Limit int
+}
+
+// Matches reports whether a record satisfies the filter.
+//
+// Params:
+//   - rec: the record to check.
+//
+// Returns:
+//   - bool: true if the record matches every non-empty criterion.
+func (f *EventFilter) Matches(rec *EventRecord) bool {
+	// check service criterion
+	if len(f.Services) > 0 && !slices.Contains(f.Services, rec.Service) {
+		// service excluded
+		return false
+	}
+	// check time range criterion
+	if (!f.From.IsZero() && rec.Timestamp.Before(f.From)) || (!f.To.IsZero() && !rec.Timestamp.Before(f.To)) {
+		// outside the time range
+		return false
+	}
+	// check type criterion
+	return len(f.Types) == 0 || slices.Contains(f.Types, rec.Type)
+}
+
+// EventWriter defines the interface for appending events to storage.
+type EventWriter interface {
+	// AppendEvent persists an event and returns its assigned sequence.
+	AppendEvent(ctx context.Context, rec *EventRecord) (uint64, error)
+}
+
+// EventReader defines the interface for replaying events from storage.
+type EventReader interface {
+	// ListEvents returns matching events with a sequence greater than since, oldest first.
+	ListEvents(ctx context.Context, since uint64, filter EventFilter) ([]EventRecord, error)
+}
+
+// CursorStore defines the interface for per-subscriber replay cursors.
+type CursorStore interface {
+	// SaveCursor records the last sequence processed by a subscriber.
+	// Cursors never move backwards; a lower sequence is ignored.
+	SaveCursor(ctx context.Context, subscriber string, seq uint64) error
+	// LoadCursor returns the last sequence processed by a subscriber, zero if unknown.
+	LoadCursor(ctx context.Context, subscriber string) (uint64, error)
+}
+
+// EventStore defines the complete interface for event persistence.
+// It composes EventWriter, EventReader, and CursorStore.
+type EventStore interface {
+	EventWriter
+	EventReader
+	CursorStore
+}
@@ -0,0 +1,47 @@
+// Package storage provides domain interfaces for event persistence.
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// TestEventFilter_Matches verifies service, type and time range filtering.
+//
+// Params:
+//   - t: testing context for assertions
+func TestEventFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rec := &storage.EventRecord{Service: "api", Type: "failed", Timestamp: at}
+
+	tests := []struct {
+		name     string
+		filter   storage.EventFilter
+		expected bool
+	}{
+		{name: "empty filter matches all", filter: storage.EventFilter{}, expected: true},
+		{name: "matching service", filter: storage.EventFilter{Services: []string{"web", "api"}}, expected: true},
+		{name: "other service", filter: storage.EventFilter{Services: []string{"web"}}, expected: false},
+		{name: "matching type", filter: storage.EventFilter{Types: []string{"failed"}}, expected: true},
+		{name: "other type", filter: storage.EventFilter{Types: []string{"started"}}, expected: false},
+		{name: "service and type", filter: storage.EventFilter{Services: []string{"api"}, Types: []string{"started"}}, expected: false},
+		{name: "inside range", filter: storage.EventFilter{From: at, To: at.Add(time.Hour)}, expected: true},
+		{name: "before range", filter: storage.EventFilter{From: at.Add(time.Second)}, expected: false},
+		{name: "end of range excluded", filter: storage.EventFilter{To: at}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Verify filter decision.
+			assert.Equal(t, tt.expected, tt.filter.Matches(rec))
+		})
+	}
+}
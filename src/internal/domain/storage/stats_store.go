@@ -0,0 +1,48 @@
+// Package storage provides domain interfaces for service statistics persistence.
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// ServiceStatsRecord is the persisted lifetime statistics of a service,
+// kept across daemon restarts.
+type ServiceStatsRecord struct {
+	// Service is the service name the record is keyed by.
+	Service string
+	// StartCount is the number of starts.
+	StartCount int
+	// StopCount is the number of clean stops.
+	StopCount int
+	// FailCount is the number of failures.
+	FailCount int
+	// RestartCount is the number of automatic restarts.
+	RestartCount int
+	// Uptime is the cumulative time the service was running.
+	Uptime time.Duration
+	// Downtime is the cumulative time the service was supervised but not running.
+	Downtime time.Duration
+	// Hours is the up and down time of the last 30 days.
+	Hours metrics.AvailabilityLog
+	// Exits is the histogram of the exit causes of the last 30 days.
+	Exits metrics.ExitLog
+	// UpdatedAt is when the record was saved.
+	UpdatedAt time.Time
+}
+
+// StatsStore defines the interface for service statistics persistence.
+type StatsStore interface {
+	// SaveServiceStats replaces the record of a service.
+	SaveServiceStats(ctx context.Context, rec *ServiceStatsRecord) error
+	// LoadServiceStats returns the record of a service, false if none was saved.
+	LoadServiceStats(ctx context.Context, service string) (ServiceStatsRecord, bool, error)
+}
+
+// StatsLister lists the persisted statistics of every service.
+type StatsLister interface {
+	// ListServiceStats returns every saved record, ordered by service name.
+	ListServiceStats(ctx context.Context) ([]ServiceStatsRecord, error)
+}
@@ -9,8 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/kodflow/daemon/internal/domain/config"
@@ -307,7 +309,7 @@ func (d *KubernetesDiscoverer) configureProbe(t *target.ExternalTarget, pod k8sP
 			protocol := strings.ToUpper(port.Protocol)
 			// Configure probe if this is a TCP port.
 			if protocol == "" || protocol == "TCP" {
-				addr := fmt.Sprintf("%s:%d", pod.Status.PodIP, port.ContainerPort)
+				addr := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(port.ContainerPort))
 				t.ProbeType = kubernetesProbeTypeTCP
 				t.ProbeTarget = health.NewTCPTarget(addr)
 				// Return after configuring with first TCP port.
@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -391,7 +393,7 @@ func (d *NomadDiscoverer) configureProbe(t *target.ExternalTarget, detail *nomad
 		if ip == "" {
 			ip = "127.0.0.1"
 		}
-		addr := fmt.Sprintf("%s:%d", ip, port.Value)
+		addr := net.JoinHostPort(ip, strconv.Itoa(port.Value))
 		t.ProbeType = nomadProbeTypeTCP
 		t.ProbeTarget = health.NewTCPTarget(addr)
 
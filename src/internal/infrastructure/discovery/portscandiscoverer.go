@@ -195,7 +195,7 @@ func (d *PortScanDiscoverer) filterAndConvertPorts(allPorts []listeningPort) []t
 	// Iterate through each listening port.
 	for _, port := range allPorts {
 		// Skip if already processed this port.
-		key := fmt.Sprintf("%s:%d", port.LocalAddr, port.LocalPort)
+		key := net.JoinHostPort(port.LocalAddr, strconv.Itoa(port.LocalPort))
 		// Check if port was already seen.
 		if seenPorts[key] {
 			continue
@@ -559,7 +559,7 @@ func (d *PortScanDiscoverer) interfaceContainsIP(iface Addrser, targetIP net.IP)
 //   - target.ExternalTarget: the external target.
 func (d *PortScanDiscoverer) portToTarget(port listeningPort) target.ExternalTarget {
 	// Format target address.
-	address := fmt.Sprintf("%s:%d", port.LocalAddr, port.LocalPort)
+	address := net.JoinHostPort(port.LocalAddr, strconv.Itoa(port.LocalPort))
 
 	// Create unique ID for this port.
 	id := fmt.Sprintf("portscan:%s", address)
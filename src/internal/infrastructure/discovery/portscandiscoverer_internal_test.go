@@ -770,7 +770,7 @@ func TestPortScanDiscoverer_portToTarget(t *testing.T) {
 				LocalPort: 443,
 				State:     "0A",
 			},
-			wantID:        "portscan:::1:443",
+			wantID:        "portscan:[::1]:443",
 			wantName:      "tcp6:443",
 			wantType:      target.TypeCustom,
 			wantProbeType: "tcp",
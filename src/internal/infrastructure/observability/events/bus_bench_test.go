@@ -0,0 +1,49 @@
+//go:build !race
+
+package events_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/events"
+)
+
+// BenchmarkBusPublish measures event throughput to draining subscribers.
+func BenchmarkBusPublish(b *testing.B) {
+	benchmarks := []struct {
+		name        string
+		subscribers int
+	}{
+		{"1Subscriber", 1},
+		{"10Subscribers", 10},
+		{"100Subscribers", 100},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			bus := events.NewBus(events.WithBufferSize(1024))
+			var wg sync.WaitGroup
+			for range bm.subscribers {
+				ch := bus.Subscribe()
+				wg.Go(func() {
+					for range ch {
+					}
+				})
+			}
+			event := lifecycle.NewEvent(lifecycle.TypeProcessStarted, "started").WithServiceName("api")
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for range b.N {
+				bus.Publish(event)
+			}
+
+			b.StopTimer()
+			bus.Close()
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Package healthcheck provides infrastructure adapters for service probing.
+package healthcheck
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// ErrNoCertificate indicates a TLS endpoint that presented no certificate.
+var ErrNoCertificate error = errors.New("no certificate presented")
+
+// CertificateInspector retrieves the certificates served by TLS listeners.
+// It implements the application health CertificateInspector port.
+type CertificateInspector struct {
+	// timeout bounds the connection and handshake.
+	timeout time.Duration
+}
+
+// NewCertificateInspector creates a certificate inspector.
+//
+// Params:
+//   - timeout: the maximum duration of the connection and handshake.
+//
+// Returns:
+//   - *CertificateInspector: the inspector.
+func NewCertificateInspector(timeout time.Duration) *CertificateInspector {
+	// simple constructor with timeout configuration
+	return &CertificateInspector{timeout: timeout}
+}
+
+// Inspect connects to a TLS endpoint and returns its leaf certificate.
+// The chain is not verified: self-signed and expired certificates are
+// reported like the others.
+//
+// Params:
+//   - ctx: the context bounding the connection and handshake.
+//   - address: the host:port to connect to.
+//   - serverName: the SNI to send, empty for none.
+//
+// Returns:
+//   - health.Certificate: the leaf certificate.
+//   - error: if the connection or handshake fails.
+func (i *CertificateInspector) Inspect(ctx context.Context, address, serverName string) (health.Certificate, error) {
+	dialer := &tls.Dialer{
+		NetDialer: newDialer(i.timeout),
+		// #nosec G402 - the certificate is inspected, not trusted
+		Config: &tls.Config{ServerName: serverName, InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	// connection or handshake failed
+	if err != nil {
+		// return wrapped error
+		return health.Certificate{}, fmt.Errorf("tls handshake: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// tls.Dialer always returns a *tls.Conn
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	// the server must present its certificate
+	if len(certs) == 0 {
+		// return missing certificate error
+		return health.Certificate{}, ErrNoCertificate
+	}
+	leaf := certs[0]
+	digest := sha256.Sum256(leaf.Raw)
+	// return leaf certificate
+	return health.Certificate{
+		Subject:      leaf.Subject.String(),
+		Issuer:       leaf.Issuer.String(),
+		DNSNames:     leaf.DNSNames,
+		SerialNumber: leaf.SerialNumber.String(),
+		NotBefore:    leaf.NotBefore,
+		NotAfter:     leaf.NotAfter,
+		Fingerprint:  hex.EncodeToString(digest[:]),
+	}, nil
+}
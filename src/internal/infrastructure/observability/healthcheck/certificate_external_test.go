@@ -0,0 +1,63 @@
+// Package healthcheck_test provides black-box tests for the healthcheck package.
+package healthcheck_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/observability/healthcheck"
+)
+
+// TestCertificateInspector_Inspect verifies the certificate of a TLS endpoint is read.
+//
+// Params:
+//   - t: the testing context.
+func TestCertificateInspector_Inspect(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+	leaf := server.Certificate()
+	digest := sha256.Sum256(leaf.Raw)
+
+	inspector := healthcheck.NewCertificateInspector(time.Second)
+	cert, err := inspector.Inspect(context.Background(), server.Listener.Addr().String(), "example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(digest[:]), cert.Fingerprint)
+	assert.Equal(t, leaf.Issuer.String(), cert.Issuer)
+	assert.Equal(t, leaf.NotAfter, cert.NotAfter)
+	assert.Equal(t, leaf.DNSNames, cert.DNSNames)
+}
+
+// TestCertificateInspector_Inspect_NotTLS verifies endpoints without TLS fail.
+//
+// Params:
+//   - t: the testing context.
+func TestCertificateInspector_Inspect_NotTLS(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	// Close each connection without answering the handshake.
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	inspector := healthcheck.NewCertificateInspector(time.Second)
+	_, err = inspector.Inspect(context.Background(), ln.Addr().String(), "")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+// Package healthcheck provides infrastructure adapters for service probing.
+package healthcheck
+
+import (
+	"net"
+	"time"
+)
+
+// happyEyeballsDelay is how long a dual-stack dial waits on the preferred
+// address family before racing the other one (RFC 8305 recommends 250ms).
+const happyEyeballsDelay time.Duration = 250 * time.Millisecond
+
+// newDialer creates a dialer used by connection-based probers.
+// On the "tcp" network, hostnames resolving to both IPv6 and IPv4 are dialed
+// with happy eyeballs so a dead family does not stall the probe; "tcp4" and
+// "tcp6" pin a single family.
+//
+// Params:
+//   - timeout: the maximum duration for connection attempts (0 for none).
+//
+// Returns:
+//   - *net.Dialer: the configured dialer.
+func newDialer(timeout time.Duration) *net.Dialer {
+	// race address families after the fallback delay
+	return &net.Dialer{
+		Timeout:       timeout,
+		FallbackDelay: happyEyeballsDelay,
+	}
+}
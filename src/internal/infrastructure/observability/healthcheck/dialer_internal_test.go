@@ -0,0 +1,36 @@
+// Package healthcheck provides internal tests for the shared prober dialer.
+package healthcheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_newDialer tests dialer timeout and happy eyeballs configuration.
+func Test_newDialer(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{
+			name:    "with_timeout",
+			timeout: 5 * time.Second,
+		},
+		{
+			name:    "without_timeout",
+			timeout: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialer := newDialer(tt.timeout)
+
+			// Verify timeout and family racing delay.
+			assert.Equal(t, tt.timeout, dialer.Timeout)
+			assert.Equal(t, happyEyeballsDelay, dialer.FallbackDelay)
+		})
+	}
+}
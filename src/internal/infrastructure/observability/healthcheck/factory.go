@@ -17,12 +17,14 @@ var (
 
 	// proberConstructors maps prober types to their constructor functions.
 	proberConstructors map[string]proberConstructor = map[string]proberConstructor{
-		proberTypeTCP:  func(t time.Duration) health.Prober { return NewTCPProber(t) },
-		proberTypeUDP:  func(t time.Duration) health.Prober { return NewUDPProber(t) },
-		proberTypeHTTP: func(t time.Duration) health.Prober { return NewHTTPProber(t) },
-		proberTypeGRPC: func(t time.Duration) health.Prober { return NewGRPCProber(t) },
-		proberTypeExec: func(t time.Duration) health.Prober { return NewExecProber(t) },
-		proberTypeICMP: func(t time.Duration) health.Prober { return NewICMPProber(t) },
+		proberTypeTCP:       func(t time.Duration) health.Prober { return NewTCPProber(t) },
+		proberTypeUDP:       func(t time.Duration) health.Prober { return NewUDPProber(t) },
+		proberTypeHTTP:      func(t time.Duration) health.Prober { return NewHTTPProber(t) },
+		proberTypeGRPC:      func(t time.Duration) health.Prober { return NewGRPCProber(t) },
+		proberTypeExec:      func(t time.Duration) health.Prober { return NewExecProber(t) },
+		proberTypeICMP:      func(t time.Duration) health.Prober { return NewICMPProber(t) },
+		proberTypeScenario:  func(t time.Duration) health.Prober { return NewScenarioProber(t) },
+		proberTypeThreshold: func(t time.Duration) health.Prober { return NewThresholdProber(t) },
 	}
 )
 
@@ -154,3 +156,15 @@ func (f *Factory) CreateICMP(timeout time.Duration) *ICMPProber {
 	// return ICMP prober with normalized timeout
 	return NewICMPProber(f.normalizeTimeout(timeout))
 }
+
+// CreateScenario creates a scenario prober.
+//
+// Params:
+//   - timeout: the budget for the whole scenario (uses default if zero).
+//
+// Returns:
+//   - *ScenarioProber: the created scenario prober.
+func (f *Factory) CreateScenario(timeout time.Duration) *ScenarioProber {
+	// return scenario prober with normalized timeout
+	return NewScenarioProber(f.normalizeTimeout(timeout))
+}
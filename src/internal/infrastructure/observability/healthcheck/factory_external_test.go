@@ -82,6 +82,18 @@ func TestFactory_Create(t *testing.T) {
 			timeout:     time.Second,
 			expectError: false,
 		},
+		{
+			name:        "scenario_prober",
+			proberType:  "scenario",
+			timeout:     time.Second,
+			expectError: false,
+		},
+		{
+			name:        "threshold_prober",
+			proberType:  "threshold",
+			timeout:     time.Second,
+			expectError: false,
+		},
 		{
 			name:        "unknown_prober",
 			proberType:  "unknown",
@@ -290,3 +302,32 @@ func TestFactory_CreateICMP(t *testing.T) {
 		})
 	}
 }
+
+// TestFactory_CreateScenario tests scenario prober creation.
+func TestFactory_CreateScenario(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{
+			name:    "with_timeout",
+			timeout: time.Second,
+		},
+		{
+			name:    "default_timeout",
+			timeout: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create factory and scenario prober.
+			f := healthcheck.NewFactory(5 * time.Second)
+			prober := f.CreateScenario(tt.timeout)
+
+			// Verify prober.
+			require.NotNil(t, prober)
+			assert.Equal(t, "scenario", prober.Type())
+		})
+	}
+}
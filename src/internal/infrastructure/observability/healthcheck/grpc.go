@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"google.golang.org/grpc"
@@ -100,6 +101,21 @@ func (p *GRPCProber) Probe(ctx context.Context, target health.Target) health.Che
 	return p.handleHealthStatus(resp, latency, target)
 }
 
+// dialContext opens the transport connection for gRPC probes.
+// Dual-stack hostnames are dialed with happy eyeballs.
+//
+// Params:
+//   - ctx: context for cancellation and timeout control.
+//   - address: the target address in host:port format.
+//
+// Returns:
+//   - net.Conn: the established connection.
+//   - error: connection error if any.
+func (p *GRPCProber) dialContext(ctx context.Context, address string) (net.Conn, error) {
+	// dial tcp with family racing
+	return newDialer(p.timeout).DialContext(ctx, "tcp", address)
+}
+
 // connect establishes a gRPC connection to the target address.
 //
 // Params:
@@ -118,6 +134,7 @@ func (p *GRPCProber) connect(ctx context.Context, address string) (*grpc.ClientC
 	//nolint:staticcheck // SA1019: grpc.WithBlock is deprecated but required for blocking health checks.
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
+		grpc.WithContextDialer(p.dialContext),
 	}
 	// add insecure credentials if needed
 	if p.insecureMode {
@@ -40,6 +40,7 @@ var (
 	// Reusing a single transport across all HTTP probers enables connection reuse
 	// and reduces TCP handshake overhead for repeated health checks.
 	defaultHTTPTransport *http.Transport = &http.Transport{
+		DialContext:         newDialer(0).DialContext,
 		MaxIdleConns:        maxIdleConns,
 		MaxIdleConnsPerHost: maxIdleConnsPerHost,
 		IdleConnTimeout:     idleConnTimeout,
@@ -158,30 +159,14 @@ func (p *HTTPProber) Probe(ctx context.Context, target health.Target) health.Che
 //   - int: the HTTP status code from the response.
 //   - error: any error that occurred during the request.
 func (p *HTTPProber) getStatusCode(ctx context.Context, method, address, path string) (int, error) {
-	targetURL, err := url.Parse(address)
+	targetURL, err := buildURL(address, path)
 	// handle malformed URL
 	if err != nil {
 		// malformed urls indicate configuration error
-		return 0, fmt.Errorf("failed to parse url: %w", err)
-	}
-
-	// Go's url.Parse treats "host:port" as "scheme:opaque", so prepend http:// if needed.
-	// handle missing scheme
-	if targetURL.Scheme != "http" && targetURL.Scheme != "https" {
-		targetURL, err = url.Parse("http://" + address)
-		// handle malformed URL after adding scheme
-		if err != nil {
-			// second parse failure means address is truly invalid
-			return 0, fmt.Errorf("failed to parse url: %w", err)
-		}
-	}
-
-	// append path to URL if provided
-	if path != "" {
-		targetURL.Path = strings.TrimRight(targetURL.Path, "/") + "/" + strings.TrimLeft(path, "/")
+		return 0, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, targetURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, method, targetURL, http.NoBody)
 	// handle request creation failure
 	if err != nil {
 		// request creation errors indicate programming error
@@ -200,3 +185,34 @@ func (p *HTTPProber) getStatusCode(ctx context.Context, method, address, path st
 	// return status code from successful response
 	return resp.StatusCode, nil
 }
+
+// buildURL joins a probe address and path into a request URL.
+// Addresses without an http or https scheme are treated as host:port.
+//
+// Params:
+//   - address: the base URL or host:port to request.
+//   - path: optional path to append to the URL.
+//
+// Returns:
+//   - string: the request URL.
+//   - error: any error parsing the address.
+func buildURL(address, path string) (string, error) {
+	targetURL, err := url.Parse(address)
+	// Go's url.Parse treats "host:port" as "scheme:opaque" and rejects "ip:port",
+	// so prepend http:// when no http or https scheme was found.
+	if err != nil || (targetURL.Scheme != "http" && targetURL.Scheme != "https") {
+		targetURL, err = url.Parse("http://" + address)
+		// handle malformed URL after adding scheme
+		if err != nil {
+			// second parse failure means address is truly invalid
+			return "", fmt.Errorf("failed to parse url: %w", err)
+		}
+	}
+
+	// append path to URL if provided
+	if path != "" {
+		targetURL.Path = strings.TrimRight(targetURL.Path, "/") + "/" + strings.TrimLeft(path, "/")
+	}
+	// return assembled URL
+	return targetURL.String(), nil
+}
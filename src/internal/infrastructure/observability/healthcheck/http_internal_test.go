@@ -440,6 +440,30 @@ func TestHTTPProber_getStatusCode_secondParseError(t *testing.T) {
 	}
 }
 
+// TestBuildURL tests URL assembly from probe addresses.
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		path     string
+		expected string
+	}{
+		{name: "host_port", address: "localhost:8080", path: "/health", expected: "http://localhost:8080/health"},
+		{name: "ip_port", address: "127.0.0.1:8080", path: "health", expected: "http://127.0.0.1:8080/health"},
+		{name: "ipv6_port", address: "[::1]:8080", path: "", expected: "http://[::1]:8080"},
+		{name: "full_url", address: "https://example.com/api/", path: "/v1", expected: "https://example.com/api/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildURL(tt.address, tt.path)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
 // TestHTTPProber_getStatusCode_invalidMethod tests invalid HTTP method.
 func TestHTTPProber_getStatusCode_invalidMethod(t *testing.T) {
 	tests := []struct {
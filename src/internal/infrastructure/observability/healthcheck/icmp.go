@@ -161,8 +161,7 @@ func (p *ICMPProber) tcpPing(ctx context.Context, host string, start time.Time)
 		tcpPort = defaultTCPFallbackPort
 	}
 	address := net.JoinHostPort(host, strconv.Itoa(tcpPort))
-	dialer := &net.Dialer{Timeout: p.timeout}
-	conn, err := dialer.DialContext(ctx, "tcp", address)
+	conn, err := newDialer(p.timeout).DialContext(ctx, "tcp", address)
 	latency := time.Since(start)
 	// Check for connection failure.
 	if err != nil {
@@ -0,0 +1,277 @@
+// Package healthcheck provides infrastructure adapters for service probing.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// proberTypeScenario is the type identifier for scenario probers.
+const proberTypeScenario string = "scenario"
+
+// maxScenarioBodySize is the maximum response body read for extraction.
+const maxScenarioBodySize int64 = 1 << 20
+
+// scenarioVarPattern matches {{name}} variable references.
+var scenarioVarPattern *regexp.Regexp = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Scenario probing errors.
+var (
+	// ErrScenarioNoSteps indicates a scenario probe without steps.
+	ErrScenarioNoSteps error = errors.New("scenario has no steps")
+
+	// ErrScenarioExtract indicates an extraction pattern found no match.
+	ErrScenarioExtract error = errors.New("extraction failed")
+)
+
+// ScenarioProber runs multi-step HTTP scenarios.
+// Steps run in order against the target address; values extracted from a
+// response are substituted into the following requests. The whole scenario
+// must pass within the prober timeout.
+type ScenarioProber struct {
+	// client is the HTTP client used for requests.
+	client *http.Client
+	// timeout is the budget for the whole scenario.
+	timeout time.Duration
+}
+
+// NewScenarioProber creates a new scenario prober.
+// Uses the HTTP transport shared with HTTP probers.
+//
+// Params:
+//   - timeout: the budget for the whole scenario.
+//
+// Returns:
+//   - *ScenarioProber: a configured scenario prober ready to perform probes.
+func NewScenarioProber(timeout time.Duration) *ScenarioProber {
+	// use default timeout if not specified
+	if timeout <= 0 {
+		timeout = health.DefaultTimeout
+	}
+
+	// share transport with HTTP probers
+	return &ScenarioProber{
+		timeout: timeout,
+		client: &http.Client{
+			Transport: defaultHTTPTransport,
+		},
+	}
+}
+
+// Type returns the prober type.
+//
+// Returns:
+//   - string: the constant "scenario" identifying the prober type.
+func (p *ScenarioProber) Type() string {
+	// identify this prober as scenario type
+	return proberTypeScenario
+}
+
+// Probe runs the scenario steps in order.
+// The probe fails at the first step whose request fails, whose status does
+// not match or whose extraction finds nothing.
+//
+// Params:
+//   - ctx: context for cancellation and timeout control.
+//   - target: the target with address and scenario steps.
+//
+// Returns:
+//   - health.CheckResult: the probe result, naming the failed step if any.
+func (p *ScenarioProber) Probe(ctx context.Context, target health.Target) health.CheckResult {
+	start := time.Now()
+
+	// the timeout bounds the whole scenario
+	budgetCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	// refuse empty scenarios
+	if len(target.Steps) == 0 {
+		// nothing to run
+		return health.NewFailureCheckResult(time.Since(start), ErrScenarioNoSteps.Error(), ErrScenarioNoSteps)
+	}
+
+	vars := make(map[string]string)
+	// run steps in order
+	for i := range target.Steps {
+		step := &target.Steps[i]
+		// stop at the first failing step
+		if err := p.runStep(budgetCtx, target.Address, step, vars); err != nil {
+			// name the step in the report
+			return health.NewFailureCheckResult(
+				time.Since(start),
+				fmt.Sprintf("step %d (%s): %v", i+1, stepName(step), err),
+				err,
+			)
+		}
+	}
+
+	// return success
+	return health.NewSuccessCheckResult(
+		time.Since(start),
+		fmt.Sprintf("scenario passed: %d steps", len(target.Steps)),
+	)
+}
+
+// runStep sends one step request and extracts its variables.
+//
+// Params:
+//   - ctx: context bounded by the scenario budget.
+//   - address: the target address.
+//   - step: the step to run.
+//   - vars: the variables extracted so far, updated in place.
+//
+// Returns:
+//   - error: nil if the step passed.
+func (p *ScenarioProber) runStep(ctx context.Context, address string, step *health.ScenarioStep, vars map[string]string) error {
+	targetURL, err := buildURL(address, expandVars(step.Path, vars))
+	// handle malformed URL
+	if err != nil {
+		// malformed urls indicate configuration error
+		return err
+	}
+
+	method := step.Method
+	// use default HTTP method if not specified
+	if method == "" {
+		method = defaultHTTPMethod
+	}
+
+	var body io.Reader = http.NoBody
+	// send body when configured
+	if step.Body != "" {
+		body = strings.NewReader(expandVars(step.Body, vars))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, targetURL, body)
+	// handle request creation failure
+	if err != nil {
+		// request creation errors indicate configuration error
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	// set headers with variables substituted
+	for name, value := range step.Headers {
+		req.Header.Set(name, expandVars(value, vars))
+	}
+
+	resp, err := p.client.Do(req)
+	// handle request execution failure
+	if err != nil {
+		// network errors or budget exhausted
+		return fmt.Errorf("request failed: %w", err)
+	}
+	// ensure response body is closed
+	defer func() { _ = resp.Body.Close() }()
+
+	expectedStatus := step.StatusCode
+	// use default status code if not specified
+	if expectedStatus == 0 {
+		expectedStatus = defaultHTTPStatusCode
+	}
+	// validate status code matches expectation
+	if resp.StatusCode != expectedStatus {
+		// status mismatch fails the scenario
+		return fmt.Errorf("%w: %d (expected %d)", ErrHTTPStatusMismatch, resp.StatusCode, expectedStatus)
+	}
+
+	// skip body when nothing is extracted
+	if len(step.Extract) == 0 {
+		// step passed
+		return nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxScenarioBodySize))
+	// handle body read failure
+	if err != nil {
+		// truncated response
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+	// return extraction outcome
+	return extractVars(data, step.Extract, vars)
+}
+
+// extractVars applies extraction patterns to a response body.
+//
+// Params:
+//   - data: the response body.
+//   - extract: variable names mapped to patterns with a capture group.
+//   - vars: the variables, updated in place.
+//
+// Returns:
+//   - error: ErrScenarioExtract if a pattern is invalid or does not match.
+func extractVars(data []byte, extract map[string]string, vars map[string]string) error {
+	// apply each pattern
+	for name, pattern := range extract {
+		re, err := regexp.Compile(pattern)
+		// handle invalid pattern
+		if err != nil {
+			// configuration validation normally rejects these
+			return fmt.Errorf("%w: %s: %w", ErrScenarioExtract, name, err)
+		}
+		match := re.FindSubmatch(data)
+		// require the first capture group
+		if len(match) < 2 {
+			// pattern did not match
+			return fmt.Errorf("%w: %s not found in response", ErrScenarioExtract, name)
+		}
+		vars[name] = string(match[1])
+	}
+	// all variables extracted
+	return nil
+}
+
+// expandVars replaces {{name}} references with extracted values.
+// Unknown variables are left as is.
+//
+// Params:
+//   - s: the text to expand.
+//   - vars: the extracted variables.
+//
+// Returns:
+//   - string: the expanded text.
+func expandVars(s string, vars map[string]string) string {
+	// skip text without references
+	if !strings.Contains(s, "{{") {
+		// nothing to expand
+		return s
+	}
+	// substitute known variables
+	return scenarioVarPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := scenarioVarPattern.FindStringSubmatch(ref)[1]
+		// keep unknown references
+		if value, ok := vars[name]; ok {
+			// substituted value
+			return value
+		}
+		// unknown variable
+		return ref
+	})
+}
+
+// stepName returns the step name, or its method and path when unnamed.
+//
+// Params:
+//   - step: the scenario step.
+//
+// Returns:
+//   - string: the name used in reports.
+func stepName(step *health.ScenarioStep) string {
+	// prefer configured name
+	if step.Name != "" {
+		// configured name
+		return step.Name
+	}
+	method := step.Method
+	// use default HTTP method if not specified
+	if method == "" {
+		method = defaultHTTPMethod
+	}
+	// describe by request line
+	return method + " " + step.Path
+}
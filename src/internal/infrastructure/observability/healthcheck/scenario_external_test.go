@@ -0,0 +1,150 @@
+// Package healthcheck_test provides black-box tests for the probe package.
+package healthcheck_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/healthcheck"
+)
+
+// newScenarioServer starts a server with a login endpoint issuing a token
+// and an API endpoint requiring it.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - *httptest.Server: the running server, closed on cleanup.
+func newScenarioServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// Only POST with the right credentials gets a token.
+		if r.Method != http.MethodPost || string(body) != `{"user":"probe"}` {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, `{"token":"abc123"}`)
+	})
+	mux.HandleFunc("/orders/abc123", func(w http.ResponseWriter, r *http.Request) {
+		// The token must come back in the header.
+		if r.Header.Get("Authorization") != "Bearer abc123" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = io.WriteString(w, `[]`)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestScenarioProber_Type tests the Type method.
+func TestScenarioProber_Type(t *testing.T) {
+	prober := healthcheck.NewScenarioProber(time.Second)
+
+	assert.Equal(t, "scenario", prober.Type())
+}
+
+// TestScenarioProber_Probe tests multi-step scenario probing.
+func TestScenarioProber_Probe(t *testing.T) {
+	server := newScenarioServer(t)
+	login := health.ScenarioStep{
+		Name:    "login",
+		Method:  http.MethodPost,
+		Path:    "/login",
+		Body:    `{"user":"probe"}`,
+		Extract: map[string]string{"token": `"token":"([^"]+)"`},
+	}
+
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		steps       []health.ScenarioStep
+		wantSuccess bool
+		wantErr     error
+		wantOutput  string
+	}{
+		{
+			name:    "login_then_authorized_call",
+			timeout: time.Second,
+			steps: []health.ScenarioStep{
+				login,
+				{Name: "orders", Path: "/orders/{{token}}", Headers: map[string]string{"Authorization": "Bearer {{ token }}"}},
+			},
+			wantSuccess: true,
+			wantOutput:  "scenario passed: 2 steps",
+		},
+		{
+			name:    "status_mismatch_names_step",
+			timeout: time.Second,
+			steps: []health.ScenarioStep{
+				login,
+				{Name: "orders", Path: "/orders/{{token}}"},
+			},
+			wantErr:    healthcheck.ErrHTTPStatusMismatch,
+			wantOutput: "step 2 (orders): status code mismatch: 403 (expected 200)",
+		},
+		{
+			name:    "missing_extraction",
+			timeout: time.Second,
+			steps: []health.ScenarioStep{
+				{Method: http.MethodPost, Path: "/login", Body: `{"user":"probe"}`, Extract: map[string]string{"id": `"id":"([^"]+)"`}},
+			},
+			wantErr:    healthcheck.ErrScenarioExtract,
+			wantOutput: "step 1 (POST /login): extraction failed: id not found in response",
+		},
+		{
+			name:       "no_steps",
+			timeout:    time.Second,
+			wantErr:    healthcheck.ErrScenarioNoSteps,
+			wantOutput: "scenario has no steps",
+		},
+		{
+			name:    "budget_covers_whole_scenario",
+			timeout: 50 * time.Millisecond,
+			steps: []health.ScenarioStep{
+				login,
+				{Name: "slow", Path: "/slow"},
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prober := healthcheck.NewScenarioProber(tt.timeout)
+
+			result := prober.Probe(context.Background(), health.Target{
+				Address: server.Listener.Addr().String(),
+				Steps:   tt.steps,
+			})
+
+			assert.Equal(t, tt.wantSuccess, result.Success, result.Output)
+			// Check the failure cause and report when expected.
+			if tt.wantErr != nil {
+				require.Error(t, result.Error)
+				assert.ErrorIs(t, result.Error, tt.wantErr)
+			}
+			if tt.wantOutput != "" {
+				assert.Equal(t, tt.wantOutput, result.Output)
+			}
+		})
+	}
+}
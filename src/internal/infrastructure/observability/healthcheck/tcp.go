@@ -5,7 +5,6 @@ package healthcheck
 import (
 	"context"
 	"fmt"
-	"net"
 	"time"
 
 	"github.com/kodflow/daemon/internal/domain/health"
@@ -62,11 +61,7 @@ func (p *TCPProber) Probe(ctx context.Context, target health.Target) health.Chec
 		network = "tcp"
 	}
 
-	dialer := &net.Dialer{
-		Timeout: p.timeout,
-	}
-
-	conn, err := dialer.DialContext(ctx, network, target.Address)
+	conn, err := newDialer(p.timeout).DialContext(ctx, network, target.Address)
 	latency := time.Since(start)
 
 	// handle connection failure
@@ -198,3 +198,60 @@ func TestTCPProber_Probe_ContextCancellation(t *testing.T) {
 		})
 	}
 }
+
+// TestTCPProber_Probe_IPv6 tests probing bracketed IPv6 addresses and pinned families.
+func TestTCPProber_Probe_IPv6(t *testing.T) {
+	// Start a test TCP server on the IPv6 loopback.
+	listener, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("ipv6 loopback unavailable: %v", err)
+		return
+	}
+	defer func() { _ = listener.Close() }()
+
+	// Accept connections in background.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		name        string
+		network     string
+		wantSuccess bool
+	}{
+		{
+			name:        "dual_stack_network",
+			network:     "tcp",
+			wantSuccess: true,
+		},
+		{
+			name:        "ipv6_network",
+			network:     "tcp6",
+			wantSuccess: true,
+		},
+		{
+			name:        "ipv4_network_rejects_ipv6_address",
+			network:     "tcp4",
+			wantSuccess: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prober := healthcheck.NewTCPProber(time.Second)
+			target := health.NewTarget(tt.network, listener.Addr().String())
+
+			// Probe the bracketed address.
+			result := prober.Probe(context.Background(), target)
+
+			// Verify result matches the network family.
+			assert.Equal(t, tt.wantSuccess, result.Success)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Package healthcheck provides infrastructure adapters for service probing.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// proberTypeThreshold is the type identifier for threshold probers.
+const proberTypeThreshold string = "threshold"
+
+// maxThresholdBodySize is the maximum response body read for the value.
+const maxThresholdBodySize int64 = 1 << 20
+
+// Threshold probing errors.
+var (
+	// ErrThresholdMissing indicates a threshold probe without a value check.
+	ErrThresholdMissing error = errors.New("threshold probe has no value check")
+
+	// ErrThresholdValue indicates the output holds no readable number.
+	ErrThresholdValue error = errors.New("no numeric value")
+
+	// ErrThresholdExceeded indicates a value past the fail threshold.
+	ErrThresholdExceeded error = errors.New("fail threshold exceeded")
+)
+
+// ThresholdProber reads a number and compares it with warn and fail thresholds.
+// The number comes from the output of the target command when set, from the
+// body of an HTTP request to the target address otherwise, such as the lag
+// of a queue consumer. A value past the warn threshold reports a warning,
+// past the fail threshold a failure.
+type ThresholdProber struct {
+	// exec runs the command of command targets.
+	exec *ExecProber
+	// client is the HTTP client used for requests.
+	client *http.Client
+}
+
+// NewThresholdProber creates a new threshold prober.
+// Uses the HTTP transport shared with HTTP probers.
+//
+// Params:
+//   - timeout: the maximum duration for reading the value.
+//
+// Returns:
+//   - *ThresholdProber: a configured threshold prober ready to perform probes.
+func NewThresholdProber(timeout time.Duration) *ThresholdProber {
+	// use default timeout if not specified
+	if timeout <= 0 {
+		timeout = health.DefaultTimeout
+	}
+
+	// share transport with HTTP probers
+	return &ThresholdProber{
+		exec: NewExecProber(timeout),
+		client: &http.Client{
+			Transport: defaultHTTPTransport,
+			Timeout:   timeout,
+		},
+	}
+}
+
+// Type returns the prober type.
+//
+// Returns:
+//   - string: the constant "threshold" identifying the prober type.
+func (p *ThresholdProber) Type() string {
+	// identify this prober as threshold type
+	return proberTypeThreshold
+}
+
+// Probe reads the value of the target and checks it against its thresholds.
+//
+// Params:
+//   - ctx: context for cancellation and timeout control.
+//   - target: the target with command or address, and the value check.
+//
+// Returns:
+//   - health.CheckResult: the probe result with the value read.
+func (p *ThresholdProber) Probe(ctx context.Context, target health.Target) health.CheckResult {
+	start := time.Now()
+
+	// refuse targets without thresholds
+	if target.Threshold == nil {
+		// nothing to compare with
+		return health.NewFailureCheckResult(time.Since(start), ErrThresholdMissing.Error(), ErrThresholdMissing)
+	}
+
+	output, err := p.read(ctx, target)
+	// the value source failed
+	if err != nil {
+		// return failure with the read error
+		return health.NewFailureCheckResult(time.Since(start), err.Error(), err)
+	}
+
+	value, err := parseThresholdValue(output, target.Threshold.Pattern)
+	// the output holds no number
+	if err != nil {
+		// return failure with the parse error
+		return health.NewFailureCheckResult(time.Since(start), err.Error(), err)
+	}
+
+	// return the outcome of the comparison
+	return evaluateThreshold(target.Threshold, value, time.Since(start))
+}
+
+// read returns the output holding the value.
+//
+// Params:
+//   - ctx: context for cancellation and timeout control.
+//   - target: the target with command or address.
+//
+// Returns:
+//   - string: the command output or response body.
+//   - error: nil if the value source answered.
+func (p *ThresholdProber) read(ctx context.Context, target health.Target) (string, error) {
+	// commands take precedence over the address
+	if target.Command != "" {
+		result := p.exec.Probe(ctx, target)
+		// failed commands carry no value
+		if !result.Success {
+			// return the command error
+			return "", fmt.Errorf("%s: %w", result.Output, result.Error)
+		}
+		// return trimmed command output
+		return result.Output, nil
+	}
+	// return the response body
+	return p.fetch(ctx, target)
+}
+
+// fetch requests the target path and returns the response body.
+//
+// Params:
+//   - ctx: context for cancellation and timeout control.
+//   - target: the target with address, path, method and expected status.
+//
+// Returns:
+//   - string: the response body.
+//   - error: nil if the response has the expected status.
+func (p *ThresholdProber) fetch(ctx context.Context, target health.Target) (string, error) {
+	targetURL, err := buildURL(target.Address, target.Path)
+	// handle malformed URL
+	if err != nil {
+		// malformed urls indicate configuration error
+		return "", err
+	}
+
+	method := target.Method
+	// use default HTTP method if not specified
+	if method == "" {
+		method = defaultHTTPMethod
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, targetURL, http.NoBody)
+	// handle request creation failure
+	if err != nil {
+		// request creation errors indicate configuration error
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	// handle request execution failure
+	if err != nil {
+		// network errors or timeout
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	// ensure response body is closed
+	defer func() { _ = resp.Body.Close() }()
+
+	expectedStatus := target.StatusCode
+	// use default status code if not specified
+	if expectedStatus == 0 {
+		expectedStatus = defaultHTTPStatusCode
+	}
+	// validate status code matches expectation
+	if resp.StatusCode != expectedStatus {
+		// status mismatch carries no value
+		return "", fmt.Errorf("%w: %d (expected %d)", ErrHTTPStatusMismatch, resp.StatusCode, expectedStatus)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxThresholdBodySize))
+	// handle body read failure
+	if err != nil {
+		// truncated response
+		return "", fmt.Errorf("failed to read body: %w", err)
+	}
+	// return the body
+	return string(data), nil
+}
+
+// parseThresholdValue reads the number in an output.
+//
+// Params:
+//   - output: the command output or response body.
+//   - pattern: regular expression whose first group is the value, empty for
+//     the whole trimmed output.
+//
+// Returns:
+//   - float64: the value read.
+//   - error: ErrThresholdValue when no number is found.
+func parseThresholdValue(output, pattern string) (float64, error) {
+	raw := output
+	// narrow the output to the captured value
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		// invalid patterns are rejected at config load
+		if err != nil {
+			// return compile error
+			return 0, fmt.Errorf("%w: %w", ErrThresholdValue, err)
+		}
+		match := re.FindStringSubmatch(output)
+		// the value must be captured
+		if len(match) < 2 {
+			// return error naming the pattern
+			return 0, fmt.Errorf("%w: pattern %q matched nothing", ErrThresholdValue, pattern)
+		}
+		raw = match[1]
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	// the capture is not a number
+	if err != nil {
+		// return error quoting the text read
+		return 0, fmt.Errorf("%w: %q", ErrThresholdValue, truncateValue(raw))
+	}
+	// return parsed value
+	return value, nil
+}
+
+// evaluateThreshold compares a value with the fail and warn thresholds.
+//
+// Params:
+//   - threshold: the value check.
+//   - value: the value read.
+//   - latency: how long reading the value took.
+//
+// Returns:
+//   - health.CheckResult: failure past fail, warning past warn, success otherwise.
+func evaluateThreshold(threshold *health.Threshold, value float64, latency time.Duration) health.CheckResult {
+	// past the fail threshold
+	if threshold.Exceeds(value, threshold.Fail) {
+		// return failure with both values
+		return health.NewFailureCheckResult(latency,
+			fmt.Sprintf("value %g past fail threshold %g", value, threshold.Fail), ErrThresholdExceeded)
+	}
+	// past the warn threshold
+	if threshold.Warn != nil && threshold.Exceeds(value, *threshold.Warn) {
+		// return warning with both values
+		return health.NewWarningCheckResult(latency,
+			fmt.Sprintf("value %g past warn threshold %g", value, *threshold.Warn))
+	}
+	// return success with the value
+	return health.NewSuccessCheckResult(latency, fmt.Sprintf("value %g", value))
+}
+
+// truncateValue bounds the text quoted in a parse error.
+//
+// Params:
+//   - raw: the text that is not a number.
+//
+// Returns:
+//   - string: the text, cut to 64 bytes.
+func truncateValue(raw string) string {
+	const maxQuoted int = 64
+	// keep short text whole
+	if len(raw) <= maxQuoted {
+		// return text unchanged
+		return raw
+	}
+	// return the start of the text
+	return raw[:maxQuoted] + "..."
+}
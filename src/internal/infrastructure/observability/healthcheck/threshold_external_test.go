@@ -0,0 +1,158 @@
+// Package healthcheck_test provides black-box tests for the probe package.
+package healthcheck_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/healthcheck"
+)
+
+// TestThresholdProber_Type tests the Type method.
+func TestThresholdProber_Type(t *testing.T) {
+	prober := healthcheck.NewThresholdProber(time.Second)
+
+	assert.Equal(t, "threshold", prober.Type())
+}
+
+// TestThresholdProber_Probe_HTTP tests values read from a response body.
+func TestThresholdProber_Probe_HTTP(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lag", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"group":"orders","lag":1500}`)
+	})
+	mux.HandleFunc("/down", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	warn := 1000.0
+
+	tests := []struct {
+		name        string
+		path        string
+		threshold   *health.Threshold
+		wantSuccess bool
+		wantWarning bool
+		wantErr     error
+		wantOutput  string
+	}{
+		{
+			name:        "under thresholds",
+			path:        "/lag",
+			threshold:   &health.Threshold{Pattern: `"lag":(\d+)`, Fail: 10000},
+			wantSuccess: true,
+			wantOutput:  "value 1500",
+		},
+		{
+			name:        "past warn",
+			path:        "/lag",
+			threshold:   &health.Threshold{Pattern: `"lag":(\d+)`, Warn: &warn, Fail: 10000},
+			wantSuccess: true,
+			wantWarning: true,
+			wantOutput:  "value 1500 past warn threshold 1000",
+		},
+		{
+			name:       "past fail",
+			path:       "/lag",
+			threshold:  &health.Threshold{Pattern: `"lag":(\d+)`, Fail: 1200},
+			wantErr:    healthcheck.ErrThresholdExceeded,
+			wantOutput: "value 1500 past fail threshold 1200",
+		},
+		{
+			name:      "pattern matching nothing",
+			path:      "/lag",
+			threshold: &health.Threshold{Pattern: `"offset":(\d+)`, Fail: 10000},
+			wantErr:   healthcheck.ErrThresholdValue,
+		},
+		{
+			name:      "body is not a number",
+			path:      "/lag",
+			threshold: &health.Threshold{Fail: 10000},
+			wantErr:   healthcheck.ErrThresholdValue,
+		},
+		{
+			name:      "unexpected status",
+			path:      "/down",
+			threshold: &health.Threshold{Fail: 10000},
+			wantErr:   healthcheck.ErrHTTPStatusMismatch,
+		},
+		{
+			name:    "no value check",
+			path:    "/lag",
+			wantErr: healthcheck.ErrThresholdMissing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prober := healthcheck.NewThresholdProber(time.Second)
+			target := health.Target{Address: server.URL, Path: tt.path, Threshold: tt.threshold}
+
+			result := prober.Probe(context.Background(), target)
+
+			assert.Equal(t, tt.wantSuccess, result.Success)
+			assert.Equal(t, tt.wantWarning, result.Warning)
+			// Failures carry their cause.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, result.Error, tt.wantErr)
+			}
+			// Compare the report when it is fixed.
+			if tt.wantOutput != "" {
+				assert.Equal(t, tt.wantOutput, result.Output)
+			}
+		})
+	}
+}
+
+// TestThresholdProber_Probe_Command tests values read from a command output.
+func TestThresholdProber_Probe_Command(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		threshold   *health.Threshold
+		wantSuccess bool
+		wantErr     error
+	}{
+		{
+			name:        "whole output",
+			args:        []string{"-c", "echo 42"},
+			threshold:   &health.Threshold{Fail: 100},
+			wantSuccess: true,
+		},
+		{
+			name:      "below fail threshold",
+			args:      []string{"-c", "echo rate=3.5"},
+			threshold: &health.Threshold{Pattern: `rate=([0-9.]+)`, Fail: 10, Below: true},
+			wantErr:   healthcheck.ErrThresholdExceeded,
+		},
+		{
+			name:      "command failing",
+			args:      []string{"-c", "exit 3"},
+			threshold: &health.Threshold{Fail: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prober := healthcheck.NewThresholdProber(time.Second)
+			target := health.Target{Command: "/bin/sh", Args: tt.args, Threshold: tt.threshold}
+
+			result := prober.Probe(context.Background(), target)
+
+			assert.Equal(t, tt.wantSuccess, result.Success)
+			// Failures carry their cause.
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, result.Error, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Package integrity watches files for content changes.
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AwaitFile returns once the file exists. The creation is noticed through
+// the notifications of its directory, and every poll period otherwise: a
+// directory missing at start is not watched.
+//
+// Params:
+//   - ctx: stops the wait when cancelled.
+//   - path: the absolute file path.
+//   - poll: how often the path is checked, on top of notifications.
+//
+// Returns:
+//   - error: the context error when cancelled first, or the error of the
+//     notification setup.
+func (w *Watcher) AwaitFile(ctx context.Context, path string, poll time.Duration) error {
+	file := filepath.Clean(path)
+	created := make(chan string, 1)
+	stop, err := notify([]string{filepath.Dir(file)}, func(name string) bool { return name == file }, created)
+	// notifications unavailable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("watching files: %w", err)
+	}
+	defer stop()
+
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	// check after each notification or poll period
+	for {
+		// a socket is never read: its presence is enough
+		if _, err := os.Lstat(file); err == nil {
+			// file appeared
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			// Return when context is cancelled.
+			return ctx.Err()
+		case <-created:
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Package integrity watches files for content changes.
+package integrity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// fileSet tracks the hashes of the files of one watch.
+type fileSet struct {
+	// literals maps each literal file to its path as given.
+	literals map[string]string
+	// patterns are the glob patterns.
+	patterns []string
+	// hashes holds the last hash of each known file.
+	hashes map[string]string
+	// scanned reports whether the initial scan is done.
+	scanned bool
+}
+
+// newFileSet resolves the watched paths. Paths without a slash are
+// command names, looked up in PATH like the executor does.
+//
+// Params:
+//   - paths: the watched files or glob patterns.
+//
+// Returns:
+//   - *fileSet: the file set, not yet hashed.
+//   - error: if a command name is not in PATH or a pattern is malformed.
+func newFileSet(paths []string) (*fileSet, error) {
+	set := &fileSet{
+		literals: make(map[string]string, len(paths)),
+		hashes:   make(map[string]string, len(paths)),
+	}
+	// classify each path
+	for _, path := range paths {
+		// command names are resolved in PATH
+		if !strings.Contains(path, "/") {
+			file, err := exec.LookPath(path)
+			// command not found
+			if err != nil {
+				// return error with context
+				return nil, fmt.Errorf("resolving %q: %w", path, err)
+			}
+			set.literals[file] = path
+			continue
+		}
+		clean := filepath.Clean(path)
+		// paths without wildcards are literal files
+		if !hasMeta(clean) {
+			set.literals[clean] = path
+			continue
+		}
+		// reject malformed patterns
+		if _, err := filepath.Match(clean, ""); err != nil {
+			// return error with the pattern
+			return nil, fmt.Errorf("pattern %q: %w", path, err)
+		}
+		set.patterns = append(set.patterns, clean)
+	}
+	// return resolved set
+	return set, nil
+}
+
+// dirs returns the directories holding the watched files.
+//
+// Returns:
+//   - []string: the directories, without duplicates.
+func (s *fileSet) dirs() []string {
+	dirs := make([]string, 0, len(s.literals)+len(s.patterns))
+	// collect the parent of each literal and pattern
+	for file := range s.literals {
+		dirs = append(dirs, filepath.Dir(file))
+	}
+	// patterns only have wildcards in the file name
+	for _, pattern := range s.patterns {
+		dirs = append(dirs, filepath.Dir(pattern))
+	}
+	slices.Sort(dirs)
+	// return unique directories
+	return slices.Compact(dirs)
+}
+
+// relevant reports whether a file belongs to the set.
+//
+// Params:
+//   - file: the clean file path.
+//
+// Returns:
+//   - bool: true for literal files and pattern matches.
+func (s *fileSet) relevant(file string) bool {
+	// literal file
+	if _, ok := s.literals[file]; ok {
+		// watched
+		return true
+	}
+	// return whether a pattern matches
+	return slices.ContainsFunc(s.patterns, func(pattern string) bool {
+		matched, _ := filepath.Match(pattern, file)
+		// pattern match
+		return matched
+	})
+}
+
+// scan re-hashes every literal file, pattern match and known file.
+//
+// Returns:
+//   - []string: the changed files, sorted.
+func (s *fileSet) scan() []string {
+	files := make(map[string]bool, len(s.hashes)+len(s.literals))
+	// known files detect removals
+	for file := range s.hashes {
+		files[file] = true
+	}
+	// literal files detect appearances
+	for file := range s.literals {
+		files[file] = true
+	}
+	// pattern matches detect new files
+	for _, pattern := range s.patterns {
+		matches, _ := filepath.Glob(pattern)
+		// add each match
+		for _, file := range matches {
+			files[file] = true
+		}
+	}
+	changed := s.rehash(files)
+	s.scanned = true
+	// return changes of this scan
+	return changed
+}
+
+// rehash hashes files and compares them with their last hash.
+//
+// Params:
+//   - files: the files to hash.
+//
+// Returns:
+//   - []string: the changed files, sorted, literal files as given.
+func (s *fileSet) rehash(files map[string]bool) []string {
+	var changed []string
+	// hash each file
+	for file := range files {
+		given, literal := s.literals[file]
+		previous, known := s.hashes[file]
+		sum, err := hashFile(file)
+		// missing or unreadable file
+		if err != nil {
+			// a pattern match vanished
+			if !literal && known && errors.Is(err, fs.ErrNotExist) {
+				delete(s.hashes, file)
+				changed = append(changed, file)
+			}
+			continue
+		}
+		s.hashes[file] = sum
+		// report the path as configured
+		if literal {
+			// content changed since the last hash
+			if known && previous != sum {
+				changed = append(changed, given)
+			}
+			continue
+		}
+		// pattern matches report changes and appearances after the initial scan
+		if (known && previous != sum) || (!known && s.scanned) {
+			changed = append(changed, file)
+		}
+	}
+	slices.Sort(changed)
+	// return changed files
+	return changed
+}
+
+// hasMeta reports whether a path contains glob wildcards.
+//
+// Params:
+//   - path: the path.
+//
+// Returns:
+//   - bool: true if the path is a pattern.
+func hasMeta(path string) bool {
+	// same wildcards as filepath.Match
+	return strings.ContainsAny(path, `*?[\`)
+}
+
+// hashFile returns the SHA-256 of a file content.
+//
+// Params:
+//   - file: the file path.
+//
+// Returns:
+//   - string: the hex-encoded digest.
+//   - error: if the file cannot be read.
+func hashFile(file string) (string, error) {
+	f, err := os.Open(file)
+	// file missing or unreadable
+	if err != nil {
+		// propagate open error
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	// hash the content
+	if _, err := io.Copy(h, f); err != nil {
+		// propagate read error
+		return "", err
+	}
+	// return digest
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,95 @@
+// Package integrity watches files for content changes.
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
+)
+
+// defaultDebounce is the quiet period after a change notification when
+// the target sets none, so a copy in progress is hashed once complete.
+const defaultDebounce time.Duration = 500 * time.Millisecond
+
+// Watcher hashes files and reports content changes, from change
+// notifications when the platform provides them and periodic re-hashes.
+type Watcher struct{}
+
+// New creates a file watcher.
+//
+// Returns:
+//   - *Watcher: the watcher.
+func New() *Watcher {
+	// return stateless watcher
+	return &Watcher{}
+}
+
+// Watch hashes the files, then reports content changes until the context
+// is cancelled. Bare command names are looked up in PATH. A file missing
+// at start is watched from its first appearance, and a literal file
+// missing at re-hash keeps its last hash.
+//
+// Params:
+//   - ctx: stops the watch when cancelled.
+//   - target: the watched files.
+//   - onChange: called with the files changed within one debounce window.
+//
+// Returns:
+//   - error: if a path cannot be resolved or notifications cannot be set up.
+func (w *Watcher) Watch(ctx context.Context, target appintegrity.Target, onChange func(paths []string)) error {
+	set, err := newFileSet(target.Paths)
+	// invalid path or command not in PATH
+	if err != nil {
+		// propagate resolution error
+		return err
+	}
+	set.scan()
+
+	changes := make(chan string, len(target.Paths))
+	stop, err := notify(set.dirs(), set.relevant, changes)
+	// notifications unavailable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("watching files: %w", err)
+	}
+	defer stop()
+
+	debounce := target.Debounce
+	// fall back to the default quiet period
+	if debounce <= 0 {
+		debounce = defaultDebounce
+	}
+	ticker := time.NewTicker(target.Interval)
+	defer ticker.Stop()
+	settle := time.NewTimer(debounce)
+	settle.Stop()
+	defer settle.Stop()
+	pending := make(map[string]bool, len(target.Paths))
+
+	report := func(changed []string) {
+		// only report actual changes
+		if len(changed) > 0 {
+			onChange(changed)
+		}
+	}
+	// Loop until context is cancelled.
+	for {
+		select {
+		case <-ctx.Done():
+			// Return when context is cancelled.
+			return nil
+		case file := <-changes:
+			pending[file] = true
+			settle.Reset(debounce)
+		case <-settle.C:
+			// re-hash notified files once writes settled
+			report(set.rehash(pending))
+			clear(pending)
+		case <-ticker.C:
+			// re-hash every file
+			report(set.scan())
+		}
+	}
+}
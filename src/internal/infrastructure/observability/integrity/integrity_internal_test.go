@@ -0,0 +1,252 @@
+// Package integrity provides internal tests for integrity.go.
+// It tests change detection using white-box testing.
+package integrity
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appintegrity "github.com/kodflow/daemon/internal/application/integrity"
+)
+
+// changeRecorder collects reported changes.
+type changeRecorder struct {
+	// mu guards paths.
+	mu sync.Mutex
+	// paths are the reported paths.
+	paths []string
+}
+
+// record stores a reported batch.
+//
+// Params:
+//   - paths: the changed paths.
+func (r *changeRecorder) record(paths []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths = append(r.paths, paths...)
+}
+
+// reported returns the reported paths.
+//
+// Returns:
+//   - []string: the paths.
+func (r *changeRecorder) reported() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.paths...)
+}
+
+// startWatch runs a watch until the test ends.
+//
+// Params:
+//   - t: the testing context.
+//   - paths: the watched files.
+//   - interval: the re-hash period.
+//
+// Returns:
+//   - *changeRecorder: the reported changes.
+func startWatch(t *testing.T, paths []string, interval time.Duration) *changeRecorder {
+	t.Helper()
+	target := appintegrity.Target{Paths: paths, Interval: interval, Debounce: 10 * time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := &changeRecorder{}
+	done := make(chan error, 1)
+	go func() { done <- New().Watch(ctx, target, rec.record) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+	// let the watch hash the files
+	time.Sleep(50 * time.Millisecond)
+	return rec
+}
+
+// TestWatcher_Watch tests the reporting of content changes.
+//
+// Params:
+//   - t: the testing context.
+func TestWatcher_Watch(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// interval is the re-hash period.
+		interval time.Duration
+		// linuxOnly marks changes only seen through notifications.
+		linuxOnly bool
+	}{
+		{name: "notification", interval: time.Hour, linuxOnly: true},
+		{name: "periodic_rehash", interval: 20 * time.Millisecond},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			// Skip notification cases without inotify.
+			if tt.linuxOnly && runtime.GOOS != "linux" {
+				t.Skip("change notifications require inotify")
+			}
+			dir := t.TempDir()
+			binary := filepath.Join(dir, "api")
+			conf := filepath.Join(dir, "api.conf")
+			late := filepath.Join(dir, "late.conf")
+			require.NoError(t, os.WriteFile(binary, []byte("v1"), 0o755))
+			require.NoError(t, os.WriteFile(conf, []byte("a"), 0o644))
+
+			rec := startWatch(t, []string{binary, conf, late}, tt.interval)
+
+			// Rewriting the same content is not a change.
+			require.NoError(t, os.WriteFile(conf, []byte("a"), 0o644))
+			// A file missing at start is watched from its appearance.
+			require.NoError(t, os.WriteFile(late, []byte("x"), 0o644))
+			time.Sleep(100 * time.Millisecond)
+			assert.Empty(t, rec.reported())
+
+			// A new binary renamed over the old one is a change.
+			staged := filepath.Join(dir, ".api.new")
+			require.NoError(t, os.WriteFile(staged, []byte("v2"), 0o755))
+			require.NoError(t, os.Rename(staged, binary))
+			require.Eventually(t, func() bool { return len(rec.reported()) == 1 }, 2*time.Second, 5*time.Millisecond)
+
+			// In-place writes are changes too.
+			require.NoError(t, os.WriteFile(late, []byte("y"), 0o644))
+			require.Eventually(t, func() bool { return len(rec.reported()) == 2 }, 2*time.Second, 5*time.Millisecond)
+			assert.Equal(t, []string{binary, late}, rec.reported())
+		})
+	}
+}
+
+// TestWatcher_AwaitFile tests the wait for a unix socket to appear.
+//
+// Params:
+//   - t: the testing context.
+func TestWatcher_AwaitFile(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// poll is the check period.
+		poll time.Duration
+		// linuxOnly marks appearances only seen through notifications.
+		linuxOnly bool
+	}{
+		{name: "notification", poll: time.Hour, linuxOnly: true},
+		{name: "poll", poll: 10 * time.Millisecond},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			// Skip notification cases without inotify.
+			if tt.linuxOnly && runtime.GOOS != "linux" {
+				t.Skip("change notifications require inotify")
+			}
+			socket := filepath.Join(t.TempDir(), "api.sock")
+			done := make(chan error, 1)
+			go func() { done <- New().AwaitFile(context.Background(), socket, tt.poll) }()
+
+			// The wait goes on while the socket is missing.
+			time.Sleep(50 * time.Millisecond)
+			assert.Empty(t, done)
+
+			ln, err := net.Listen("unix", socket)
+			require.NoError(t, err)
+			defer func() { _ = ln.Close() }()
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(2 * time.Second):
+				t.Fatal("socket creation not noticed")
+			}
+		})
+	}
+}
+
+// TestWatcher_AwaitFile_cancel tests the wait ends with its context.
+//
+// Params:
+//   - t: the testing context.
+func TestWatcher_AwaitFile_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New().AwaitFile(ctx, filepath.Join(t.TempDir(), "api.sock"), time.Hour)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestWatcher_Watch_glob tests the reporting of files matching a pattern.
+//
+// Params:
+//   - t: the testing context.
+func TestWatcher_Watch_glob(t *testing.T) {
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib")
+	require.NoError(t, os.Mkdir(lib, 0o755))
+	core := filepath.Join(lib, "core.jar")
+	extra := filepath.Join(lib, "extra.jar")
+	require.NoError(t, os.WriteFile(core, []byte("v1"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(lib, "notes.txt"), []byte("a"), 0o644))
+
+	rec := startWatch(t, []string{filepath.Join(lib, "*.jar")}, 20*time.Millisecond)
+
+	// Files outside the pattern are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(lib, "notes.txt"), []byte("b"), 0o644))
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, rec.reported())
+
+	// Modified, added and removed matches are changes.
+	require.NoError(t, os.WriteFile(core, []byte("v2"), 0o644))
+	require.Eventually(t, func() bool { return len(rec.reported()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	require.NoError(t, os.WriteFile(extra, []byte("v1"), 0o644))
+	require.Eventually(t, func() bool { return len(rec.reported()) == 2 }, 2*time.Second, 5*time.Millisecond)
+	require.NoError(t, os.Remove(core))
+	require.Eventually(t, func() bool { return len(rec.reported()) == 3 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{core, extra, core}, rec.reported())
+}
+
+// TestWatcher_Watch_unknownCommand tests the rejection of commands not in PATH.
+//
+// Params:
+//   - t: the testing context.
+func TestWatcher_Watch_unknownCommand(t *testing.T) {
+	target := appintegrity.Target{Paths: []string{"supervizio-no-such-command"}, Interval: time.Hour}
+
+	err := New().Watch(context.Background(), target, func([]string) {})
+
+	assert.Error(t, err)
+}
+
+// Test_newFileSet tests the classification of watched paths.
+//
+// Params:
+//   - t: the testing context.
+func Test_newFileSet(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "svc"), []byte("#!/bin/sh\n"), 0o755))
+	t.Setenv("PATH", dir)
+
+	set, err := newFileSet([]string{"/opt//api/../api/bin", "svc", "/etc/api/*.conf"})
+	require.NoError(t, err)
+
+	// Literal paths are cleaned and command names looked up in PATH.
+	assert.Equal(t, map[string]string{"/opt/api/bin": "/opt//api/../api/bin", filepath.Join(dir, "svc"): "svc"}, set.literals)
+	assert.Equal(t, []string{"/etc/api/*.conf"}, set.patterns)
+	assert.ElementsMatch(t, []string{dir, "/etc/api", "/opt/api"}, set.dirs())
+	assert.True(t, set.relevant("/etc/api/db.conf"))
+	assert.False(t, set.relevant("/etc/api/db.conf.bak"))
+
+	// Malformed patterns are rejected.
+	_, err = newFileSet([]string{"/etc/[a"})
+	assert.Error(t, err)
+}
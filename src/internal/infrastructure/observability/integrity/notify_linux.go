@@ -0,0 +1,113 @@
+//go:build linux
+
+// Package integrity watches files for content changes.
+package integrity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// watchMask selects the events rewriting, replacing or removing a
+	// file: in-place writes, renames over it, creation and removal.
+	watchMask uint32 = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE |
+		syscall.IN_DELETE | syscall.IN_MOVED_FROM
+
+	// eventBufferSize holds a batch of inotify events.
+	eventBufferSize int = 64 * (syscall.SizeofInotifyEvent + syscall.NAME_MAX + 1)
+)
+
+// notify sends the watched files rewritten, replaced or removed, using
+// inotify on their directories so replacements by rename are seen.
+// Directories missing at start are left to the periodic re-hash.
+//
+// Params:
+//   - dirs: the directories holding the watched files.
+//   - relevant: reports whether a file is watched.
+//   - changes: receives the changed files.
+//
+// Returns:
+//   - func(): stops the notifications and waits for the reader.
+//   - error: if inotify is unavailable.
+func notify(dirs []string, relevant func(file string) bool, changes chan<- string) (func(), error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	// inotify unavailable
+	if err != nil {
+		// return error with context
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	// the runtime poller unblocks reads when the file is closed
+	f := os.NewFile(uintptr(fd), "inotify")
+
+	watches := make(map[int32]string, len(dirs))
+	// watch each directory
+	for _, dir := range dirs {
+		wd, err := syscall.InotifyAddWatch(fd, dir, watchMask)
+		// missing directory: periodic re-hash only
+		if err != nil {
+			continue
+		}
+		watches[int32(wd)] = dir
+	}
+
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		read(f, watches, relevant, changes, quit)
+	}()
+	// return the stop function
+	return func() {
+		close(quit)
+		_ = f.Close()
+		<-done
+	}, nil
+}
+
+// read forwards the events of watched files until the inotify file is closed.
+//
+// Params:
+//   - f: the inotify file.
+//   - watches: the watched directories by watch descriptor.
+//   - relevant: reports whether a file is watched.
+//   - changes: receives the changed files.
+//   - quit: closed to abandon a pending send.
+func read(f *os.File, watches map[int32]string, relevant func(file string) bool, changes chan<- string, quit <-chan struct{}) {
+	buf := make([]byte, eventBufferSize)
+	// read until closed
+	for {
+		n, err := f.Read(buf)
+		// file closed or inotify failure
+		if err != nil {
+			return
+		}
+		// walk the events of the batch
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			offset = nameStart + int(event.Len)
+			// events of the directory itself carry no name
+			if event.Len == 0 || offset > n {
+				continue
+			}
+			// names are NUL padded
+			name := strings.TrimRight(string(buf[nameStart:offset]), "\x00")
+			file := filepath.Join(watches[event.Wd], name)
+			// only watched files are reported
+			if !relevant(file) {
+				continue
+			}
+			// wait for the watch loop, which may be hashing
+			select {
+			case changes <- file:
+			case <-quit:
+				// watch stopped
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+// Package integrity watches files for content changes.
+package integrity
+
+// notify provides no change notification outside Linux: changes are
+// detected by the periodic re-hash.
+//
+// Params:
+//   - dirs: the watched directories (unused).
+//   - relevant: the file filter (unused).
+//   - changes: the change channel (unused).
+//
+// Returns:
+//   - func(): a no-op stop function.
+//   - error: always nil.
+func notify(_ []string, _ func(string) bool, _ chan<- string) (func(), error) {
+	// periodic re-hash only
+	return func() {}, nil
+}
@@ -3,13 +3,20 @@
 package logging
 
 import (
+	"context"
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
 )
 
+// captureDrainTimeout bounds the wait for the output still in the pipes when
+// a capture is closed, as a process child may keep them open.
+const captureDrainTimeout time.Duration = time.Second
+
 // GetServiceLogPather defines the interface for configuration access.
 // It provides the method needed to get service log paths.
 type GetServiceLogPather interface {
@@ -26,6 +33,83 @@ type serviceLogging interface {
 	StderrConfig() *config.LogStreamConfig
 }
 
+// CaptureOption configures Capture behavior.
+type CaptureOption func(*captureOptions)
+
+// captureOptions holds optional capture settings.
+type captureOptions struct {
+	// quota is the service-wide log quota shared by file writers.
+	quota *Quota
+	// hub receives output lines for live streaming.
+	hub *Hub
+	// mux reads the output pipes.
+	mux *Multiplexer
+	// normalize prefixes each line with a normalized timestamp.
+	normalize bool
+}
+
+// WithQuota enforces a service-wide log quota across the stdout and stderr
+// files, rotated files included.
+//
+// Params:
+//   - quota: the quota to enforce; nil disables enforcement.
+//
+// Returns:
+//   - CaptureOption: configuration option
+func WithQuota(quota *Quota) CaptureOption {
+	// Return closure that applies the quota.
+	return func(o *captureOptions) {
+		o.quota = quota
+	}
+}
+
+// WithHub also publishes every stdout and stderr line to a hub, so the
+// output can be streamed live over the control API.
+//
+// Params:
+//   - hub: the output hub; nil disables publishing.
+//
+// Returns:
+//   - CaptureOption: configuration option
+func WithHub(hub *Hub) CaptureOption {
+	// Return closure that applies the hub.
+	return func(o *captureOptions) {
+		o.hub = hub
+	}
+}
+
+// WithMultiplexer hands pipes read by a multiplexer to the process instead
+// of the writers, so that os/exec starts no copy goroutine per stream.
+//
+// Params:
+//   - mux: the log pipe multiplexer; nil keeps the writers.
+//
+// Returns:
+//   - CaptureOption: configuration option
+func WithMultiplexer(mux *Multiplexer) CaptureOption {
+	// Return closure that applies the multiplexer.
+	return func(o *captureOptions) {
+		o.mux = mux
+	}
+}
+
+// WithNormalizedTimestamps prefixes each stdout and stderr line with a
+// normalized timestamp and the source of its clock, in place of the
+// timestamp_format of the streams. The live output hub receives the lines as
+// written by the service.
+//
+// Params:
+//   - on: the service normalize_timestamps setting.
+//
+// Returns:
+//   - CaptureOption: configuration option
+func WithNormalizedTimestamps(on bool) CaptureOption {
+	// Return closure that applies the setting.
+	return func(o *captureOptions) {
+		o.normalize = on
+	}
+}
+
 // Capture captures stdout and stderr for a service.
 // It wraps output streams and provides thread-safe close operations.
 type Capture struct {
@@ -35,6 +119,12 @@ type Capture struct {
 	stdout io.WriteCloser
 	// stderr is the writer for standard error.
 	stderr io.WriteCloser
+	// mux reads the pipes, nil without multiplexer.
+	mux *Multiplexer
+	// stdoutPipe is the multiplexed standard output, nil without multiplexer.
+	stdoutPipe *Pipe
+	// stderrPipe is the multiplexed standard error, nil without multiplexer.
+	stderrPipe *Pipe
 	// closed indicates whether the capture has been closed.
 	closed bool
 }
@@ -46,12 +136,18 @@ type Capture struct {
 //   - serviceName: the name of the service being captured.
 //   - cfg: the global configuration containing log path information.
 //   - svcCfg: the service-specific logging configuration.
+//   - opts: optional configuration options.
 //
 // Returns:
 //   - *Capture: the initialized capture instance.
 //   - error: an error if writer creation fails.
-func NewCapture(serviceName string, cfg GetServiceLogPather, svcCfg serviceLogging) (*Capture, error) {
+func NewCapture(serviceName string, cfg GetServiceLogPather, svcCfg serviceLogging, opts ...CaptureOption) (*Capture, error) {
 	c := &Capture{}
+	var options captureOptions
+	// apply all provided options
+	for _, opt := range opts {
+		opt(&options)
+	}
 
 	// create file writer for stdout if configured
 	if svcCfg.StdoutConfig().File() != "" {
@@ -84,30 +180,173 @@ func NewCapture(serviceName string, cfg GetServiceLogPather, svcCfg serviceLoggi
 		c.stderr = &nopCloser{os.Stderr}
 	}
 
+	// enforce the service log quota, pruning files left by previous runs
+	if options.quota != nil {
+		c.attachQuota(options.quota)
+	}
+
+	// prefix the lines before the files and the passthrough output
+	if options.normalize {
+		c.stdout, c.stderr = normalized(c.stdout), normalized(c.stderr)
+	}
+
+	// tee both streams to the live output hub
+	if options.hub != nil {
+		c.stdout = NewMultiWriter(c.stdout, options.hub.Writer(serviceName, domainlogging.StreamStdout))
+		c.stderr = NewMultiWriter(c.stderr, options.hub.Writer(serviceName, domainlogging.StreamStderr))
+	}
+
+	// read both streams through multiplexed pipes
+	if options.mux != nil {
+		// handle pipe creation failure
+		if err := c.attachPipes(serviceName, options.mux); err != nil {
+			_ = c.Close()
+			// propagate error after cleanup
+			return nil, err
+		}
+	}
+
 	// return fully initialized capture
 	return c, nil
 }
 
+// attachPipes creates the stdout and stderr pipes feeding the writers.
+//
+// Params:
+//   - serviceName: the name of the service being captured.
+//   - mux: the log pipe multiplexer.
+//
+// Returns:
+//   - error: the pipe creation error.
+func (c *Capture) attachPipes(serviceName string, mux *Multiplexer) error {
+	c.mux = mux
+	stdout, err := mux.Attach(serviceName, domainlogging.StreamStdout, c.stdout)
+	// handle stdout pipe failure
+	if err != nil {
+		// propagate error to caller
+		return err
+	}
+	c.stdoutPipe = stdout
+	stderr, err := mux.Attach(serviceName, domainlogging.StreamStderr, c.stderr)
+	// handle stderr pipe failure
+	if err != nil {
+		// propagate error to caller
+		return err
+	}
+	c.stderrPipe = stderr
+	// pipes attached
+	return nil
+}
+
+// normalized wraps a stream in a timestamp normalizer, replacing the
+// timestamp prefix of its file writer.
+//
+// Params:
+//   - stream: the file writer or passthrough output.
+//
+// Returns:
+//   - io.WriteCloser: the normalized stream.
+func normalized(stream io.WriteCloser) io.WriteCloser {
+	// the normalizer writes the only timestamp
+	if w, ok := stream.(*Writer); ok {
+		w.mu.Lock()
+		w.addTimestamp = false
+		w.mu.Unlock()
+	}
+	// return wrapped stream
+	return NewTimestampNormalizer(stream)
+}
+
+// attachQuota shares a quota between the file writers and enforces it once.
+//
+// Params:
+//   - quota: the service-wide log quota.
+func (c *Capture) attachQuota(quota *Quota) {
+	// register each file-backed stream
+	for _, stream := range []io.WriteCloser{c.stdout, c.stderr} {
+		// passthrough streams have no files
+		if w, ok := stream.(*Writer); ok {
+			w.attachQuota(quota)
+		}
+	}
+	// best-effort initial enforcement
+	_ = quota.Enforce()
+}
+
 // Stdout returns the stdout writer.
 // It provides access to the configured standard output stream.
+// With a multiplexer, it is the write end of the stdout pipe.
 //
 // Returns:
 //   - io.Writer: the stdout writer instance.
 func (c *Capture) Stdout() io.Writer {
+	// hand the pipe to the process when multiplexed
+	if c.stdoutPipe != nil {
+		// expose pipe write end to caller
+		return c.stdoutPipe.File()
+	}
 	// expose stdout writer to caller
 	return c.stdout
 }
 
 // Stderr returns the stderr writer.
 // It provides access to the configured standard error stream.
+// With a multiplexer, it is the write end of the stderr pipe.
 //
 // Returns:
 //   - io.Writer: the stderr writer instance.
 func (c *Capture) Stderr() io.Writer {
+	// hand the pipe to the process when multiplexed
+	if c.stderrPipe != nil {
+		// expose pipe write end to caller
+		return c.stderrPipe.File()
+	}
 	// expose stderr writer to caller
 	return c.stderr
 }
 
+// Release closes the daemon copies of the pipe write ends. With a
+// multiplexer it must be called once the process started, so that the
+// pipes end when the process exits; it does nothing otherwise.
+func (c *Capture) Release() {
+	// release each multiplexed stream
+	for _, p := range c.multiplexed() {
+		_ = p.Release()
+	}
+}
+
+// multiplexed returns the attached pipes.
+//
+// Returns:
+//   - []*Pipe: the pipes, empty without multiplexer.
+func (c *Capture) multiplexed() []*Pipe {
+	var pipes []*Pipe
+	// skip streams whose pipe was not created
+	for _, p := range []*Pipe{c.stdoutPipe, c.stderrPipe} {
+		// keep created pipes
+		if p != nil {
+			pipes = append(pipes, p)
+		}
+	}
+	// return attached pipes
+	return pipes
+}
+
+// drain waits for the output left in the pipes, then stops reading them.
+func (c *Capture) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), captureDrainTimeout)
+	defer cancel()
+	// wait for each pipe to end
+	for _, p := range c.multiplexed() {
+		_ = p.Release()
+		select {
+		case <-p.Done():
+		case <-ctx.Done():
+		}
+		c.mux.Detach(p)
+	}
+}
+
 // Close closes both output streams.
 // It is thread-safe and can be called multiple times safely.
 //
@@ -123,6 +362,8 @@ func (c *Capture) Close() error {
 		return nil
 	}
 	c.closed = true
+	// stop writing to the writers before closing them
+	c.drain()
 
 	var firstErr error
 	// close stdout and track first error
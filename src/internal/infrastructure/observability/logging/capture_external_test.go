@@ -1,6 +1,9 @@
 package logging_test
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/kodflow/daemon/internal/domain/config"
@@ -164,3 +167,52 @@ func TestCapture_Close(t *testing.T) {
 		})
 	}
 }
+
+func TestCapture_WithMultiplexer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		script     string
+		wantStdout string
+		wantStderr string
+	}{
+		{name: "both streams", script: "echo out; echo err >&2", wantStdout: "out\n", wantStderr: "err\n"},
+		{name: "silent process", script: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			cfg := &mockConfig{logPath: dir}
+			svcCfg := &mockServiceLogging{
+				stdout: config.LogStreamConfig{FilePath: "out.log"},
+				stderr: config.LogStreamConfig{FilePath: "err.log"},
+			}
+			mux := logging.NewMultiplexer()
+			defer func() { _ = mux.Close() }()
+
+			capture, err := logging.NewCapture("test", cfg, svcCfg, logging.WithMultiplexer(mux))
+			require.NoError(t, err)
+			assert.IsType(t, &os.File{}, capture.Stdout())
+			assert.IsType(t, &os.File{}, capture.Stderr())
+
+			cmd := exec.Command("sh", "-c", tt.script)
+			cmd.Stdout = capture.Stdout()
+			cmd.Stderr = capture.Stderr()
+			require.NoError(t, cmd.Start())
+			capture.Release()
+			require.NoError(t, cmd.Wait())
+			require.NoError(t, capture.Close())
+
+			stdout, err := os.ReadFile(filepath.Join(dir, "test", "out.log"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStdout, string(stdout))
+			stderr, err := os.ReadFile(filepath.Join(dir, "test", "err.log"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStderr, string(stderr))
+		})
+	}
+}
@@ -17,6 +17,7 @@ const (
 	writerTypeConsole string = "console"
 	writerTypeFile    string = "file"
 	writerTypeJSON    string = "json"
+	writerTypeJournal string = "journal"
 )
 
 // Sentinel errors for factory operations.
@@ -90,7 +91,7 @@ func buildWriter(wcfg config.WriterConfig, baseDir string) (logging.Writer, erro
 	// console writer outputs to stdout/stderr
 	case writerTypeConsole:
 		// create console writer for terminal output
-		return NewConsoleWriter(), nil
+		return NewConsoleWriterFromConfig(wcfg.Console)
 	// file writer outputs to log file
 	case writerTypeFile:
 		path := wcfg.File.Path
@@ -123,6 +124,10 @@ func buildWriter(wcfg config.WriterConfig, baseDir string) (logging.Writer, erro
 		}
 		// create JSON writer with resolved path
 		return NewJSONWriter(resolvedPath)
+	// journal writer sends events with their fields to systemd-journald
+	case writerTypeJournal:
+		// create journal writer on the native socket
+		return NewJournalWriter(journalSocket)
 	// unknown writer type
 	default:
 		// return error for unknown type
@@ -262,7 +267,16 @@ func BuildLoggerWithBufferedConsole(cfg config.DaemonLogging, baseDir string) (l
 		if wcfg.Type == writerTypeConsole {
 			// create buffered console writer once
 			if bufferedConsole == nil {
-				consoleWriter := NewConsoleWriter()
+				consoleWriter, err := NewConsoleWriterFromConfig(wcfg.Console)
+				// handle invalid console configuration
+				if err != nil {
+					// clean up already created writers
+					for _, created := range writers {
+						_ = created.Close()
+					}
+					// wrap error with context
+					return nil, nil, fmt.Errorf("building writer %s: %w", wcfg.Type, err)
+				}
 				bufferedConsole = NewBufferedWriter(consoleWriter)
 			}
 			w = bufferedConsole
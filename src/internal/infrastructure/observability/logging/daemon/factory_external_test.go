@@ -33,6 +33,26 @@ func TestBuildLogger(t *testing.T) {
 			baseDir: t.TempDir(),
 			wantErr: false,
 		},
+		{
+			name: "human console writer",
+			cfg: config.DaemonLogging{
+				Writers: []config.WriterConfig{
+					{Type: "console", Console: config.ConsoleWriterConfig{Color: "never", Format: "human"}},
+				},
+			},
+			baseDir: t.TempDir(),
+			wantErr: false,
+		},
+		{
+			name: "unknown console color",
+			cfg: config.DaemonLogging{
+				Writers: []config.WriterConfig{
+					{Type: "console", Console: config.ConsoleWriterConfig{Color: "rainbow"}},
+				},
+			},
+			baseDir: t.TempDir(),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -155,13 +155,9 @@ func (f *TextFormatter) Format(event logging.LogEvent) string {
 //   - sb: the string builder to write to.
 //   - meta: the metadata map to format.
 func formatMetadataToBuilder(sb *strings.Builder, meta map[string]any) {
-	// Sort keys for consistent output.
-	keys := slices.Collect(maps.Keys(meta))
-	sort.Strings(keys)
-
 	// Format each key-value pair.
 	// write each metadata key-value pair
-	for i, k := range keys {
+	for i, k := range sortedKeys(meta) {
 		// Add space separator between pairs.
 		// add separator between pairs
 		if i > 0 {
@@ -173,6 +169,20 @@ func formatMetadataToBuilder(sb *strings.Builder, meta map[string]any) {
 	}
 }
 
+// sortedKeys returns the metadata keys in order, for consistent output.
+//
+// Params:
+//   - meta: the metadata map.
+//
+// Returns:
+//   - []string: the sorted keys.
+func sortedKeys(meta map[string]any) []string {
+	keys := slices.Collect(maps.Keys(meta))
+	sort.Strings(keys)
+	// return sorted keys
+	return keys
+}
+
 // formatValue formats a value to the builder using type switch for efficiency.
 //
 // Params:
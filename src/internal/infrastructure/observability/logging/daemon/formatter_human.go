@@ -0,0 +1,130 @@
+// Package daemon provides daemon event logging infrastructure.
+package daemon
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// humanTimeFormat is the short time of human formatted lines.
+const humanTimeFormat string = "15:04:05"
+
+// humanLevelWidth aligns the messages after levels of different lengths.
+const humanLevelWidth int = 5
+
+// Ensure HumanFormatter implements Formatter.
+var _ Formatter = (*HumanFormatter)(nil)
+
+// HumanFormatter formats log events as compact lines for humans reading
+// docker logs: short time, padded level, service, message, then key=value
+// pairs, with values quoted only when they hold spaces, quotes or '='.
+//
+// Output: 15:04:05 WARN  nginx Service failed exit_code=1 reason="out of memory"
+type HumanFormatter struct{}
+
+// NewHumanFormatter creates a new human formatter.
+//
+// Returns:
+//   - *HumanFormatter: the created formatter.
+func NewHumanFormatter() *HumanFormatter {
+	// return stateless formatter
+	return &HumanFormatter{}
+}
+
+// Format formats a log event as a compact line.
+//
+// Params:
+//   - event: the log event to format.
+//
+// Returns:
+//   - string: the formatted log line.
+func (f *HumanFormatter) Format(event logging.LogEvent) string {
+	sb := getBuilder()
+	defer putBuilder(sb)
+
+	// Pre-grow for typical log line length.
+	sb.Grow(typicalLogLineLength)
+
+	sb.WriteString(event.Timestamp.Format(humanTimeFormat))
+	sb.WriteByte(' ')
+
+	level := event.Level.String()
+	sb.WriteString(level)
+	// pad short levels so messages line up
+	for i := len(level); i < humanLevelWidth; i++ {
+		sb.WriteByte(' ')
+	}
+	sb.WriteByte(' ')
+
+	// add service name if present
+	if event.Service != "" {
+		sb.WriteString(event.Service)
+		sb.WriteByte(' ')
+	}
+
+	// use message or fall back to event type
+	if event.Message != "" {
+		sb.WriteString(event.Message)
+	} else {
+		// Use event type as fallback.
+		sb.WriteString(event.EventType)
+	}
+
+	// add metadata if present
+	if len(event.Metadata) > 0 {
+		sb.WriteByte(' ')
+		formatHumanMetadata(sb, event.Metadata)
+	}
+
+	// Return formatted log line.
+	return sb.String()
+}
+
+// formatHumanMetadata writes metadata as key=value pairs, quoting values
+// that would otherwise be ambiguous.
+//
+// Params:
+//   - sb: the string builder to write to.
+//   - meta: the metadata map to format.
+func formatHumanMetadata(sb *strings.Builder, meta map[string]any) {
+	value := getBuilder()
+	defer putBuilder(value)
+
+	// write each pair in sorted key order
+	for i, k := range sortedKeys(meta) {
+		// add separator between pairs
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		value.Reset()
+		formatValue(value, meta[k])
+		sb.WriteString(quoteHumanValue(value.String()))
+	}
+}
+
+// quoteHumanValue quotes a value that is empty or holds spaces, quotes, '='
+// or control characters, so each pair stays readable and parseable.
+//
+// Params:
+//   - v: the formatted value.
+//
+// Returns:
+//   - string: the value, quoted when needed.
+func quoteHumanValue(v string) string {
+	// empty values would read as a missing value
+	if v == "" {
+		// return empty quotes
+		return `""`
+	}
+	// quote values that would split or blur the pair
+	if strings.ContainsFunc(v, func(r rune) bool { return r <= ' ' || r == '"' || r == '=' || r == 0x7f }) {
+		// return Go-quoted value
+		return strconv.Quote(v)
+	}
+	// return bare value
+	return v
+}
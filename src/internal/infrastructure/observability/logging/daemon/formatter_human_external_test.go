@@ -0,0 +1,53 @@
+package daemon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 4, 9, 8, 7, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		event    logging.LogEvent
+		expected string
+	}{
+		{
+			name: "info event with service",
+			event: logging.NewLogEvent(logging.LevelInfo, "nginx", "started", "Service started").
+				WithMeta("pid", 1234),
+			expected: "09:08:07 INFO  nginx Service started pid=1234",
+		},
+		{
+			name: "quoted values",
+			event: logging.NewLogEvent(logging.LevelWarn, "nginx", "failed", "Service failed").
+				WithMeta("exit_code", 1).
+				WithMeta("reason", "out of memory").
+				WithMeta("cmd", "").
+				WithMeta("expr", "a=b"),
+			expected: `09:08:07 WARN  nginx Service failed cmd="" exit_code=1 expr="a=b" reason="out of memory"`,
+		},
+		{
+			name:     "event type without message or service",
+			event:    logging.NewLogEvent(logging.LevelError, "", "reload_failed", ""),
+			expected: "09:08:07 ERROR reload_failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event := tt.event
+			event.Timestamp = at
+			assert.Equal(t, tt.expected, daemon.NewHumanFormatter().Format(event))
+		})
+	}
+}
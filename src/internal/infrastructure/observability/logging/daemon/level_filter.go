@@ -2,14 +2,18 @@
 package daemon
 
 import (
+	"sync/atomic"
+
 	"github.com/kodflow/daemon/internal/domain/logging"
 )
 
 // LevelFilter wraps a writer and filters events below a minimum level.
 // Events below the threshold are silently discarded without error.
+// The configured level can be replaced at runtime by an override.
 type LevelFilter struct {
 	writer   logging.Writer
 	minLevel logging.Level
+	override atomic.Pointer[logging.Level]
 }
 
 // WithLevelFilter wraps a writer with level filtering.
@@ -50,8 +54,13 @@ func NewLevelFilter(w logging.Writer, minLevel logging.Level) *LevelFilter {
 // Returns:
 //   - error: nil on success or if filtered, error on write failure.
 func (f *LevelFilter) Write(event logging.LogEvent) error {
+	minLevel := f.minLevel
+	// Use the override level when set.
+	if override := f.override.Load(); override != nil {
+		minLevel = *override
+	}
 	// Filter out events below minimum level.
-	if event.Level < f.minLevel {
+	if event.Level < minLevel {
 		// Silently discard.
 		return nil
 	}
@@ -59,6 +68,20 @@ func (f *LevelFilter) Write(event logging.LogEvent) error {
 	return f.writer.Write(event)
 }
 
+// SetLevelOverride replaces the minimum level until it is cleared.
+//
+// Params:
+//   - level: the minimum level to pass through, nil to restore the configured level.
+func (f *LevelFilter) SetLevelOverride(level *logging.Level) {
+	// Clear the override.
+	if level == nil {
+		f.override.Store(nil)
+		return
+	}
+	override := *level
+	f.override.Store(&override)
+}
+
 // Close closes the underlying writer.
 //
 // Returns:
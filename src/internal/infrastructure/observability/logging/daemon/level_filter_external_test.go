@@ -123,6 +123,61 @@ func TestLevelFilter_Write(t *testing.T) {
 	}
 }
 
+func TestLevelFilter_SetLevelOverride(t *testing.T) {
+	t.Parallel()
+
+	debug, errLevel := logging.LevelDebug, logging.LevelError
+	tests := []struct {
+		name       string
+		override   *logging.Level
+		clear      bool
+		eventLevel logging.Level
+		shouldPass bool
+	}{
+		{
+			name:       "lower override passes debug",
+			override:   &debug,
+			eventLevel: logging.LevelDebug,
+			shouldPass: true,
+		},
+		{
+			name:       "higher override filters warn",
+			override:   &errLevel,
+			eventLevel: logging.LevelWarn,
+			shouldPass: false,
+		},
+		{
+			name:       "cleared override restores configured level",
+			override:   &debug,
+			clear:      true,
+			eventLevel: logging.LevelDebug,
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &mockWriter{}
+			filter := daemon.WithLevelFilter(mock, logging.LevelInfo)
+			filter.SetLevelOverride(tt.override)
+			if tt.clear {
+				filter.SetLevelOverride(nil)
+			}
+
+			err := filter.Write(logging.NewLogEvent(tt.eventLevel, "test", "event", "message"))
+			require.NoError(t, err)
+
+			if tt.shouldPass {
+				assert.Len(t, mock.events, 1)
+			} else {
+				assert.Empty(t, mock.events)
+			}
+		})
+	}
+}
+
 func TestLevelFilter_Close(t *testing.T) {
 	t.Parallel()
 
@@ -11,8 +11,9 @@ import (
 // MultiLogger aggregates multiple writers and dispatches events to all of them.
 // It implements the logging.Logger interface.
 type MultiLogger struct {
-	mu      sync.RWMutex
-	writers []logging.Writer
+	mu       sync.RWMutex
+	writers  []logging.Writer
+	override *logging.Level
 }
 
 // New creates a new MultiLogger with the specified writers.
@@ -117,9 +118,31 @@ func (l *MultiLogger) Error(service, eventType, message string, meta map[string]
 func (l *MultiLogger) AddWriter(w logging.Writer) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	// Apply the current override to level-filtered writers.
+	if filter, ok := w.(*LevelFilter); ok && l.override != nil {
+		filter.SetLevelOverride(l.override)
+	}
 	l.writers = append(l.writers, w)
 }
 
+// SetLevelOverride replaces the level of every level-filtered writer, such
+// as the writers built from configuration, until it is cleared.
+//
+// Params:
+//   - level: the minimum level to pass through, nil to restore the configured levels.
+func (l *MultiLogger) SetLevelOverride(level *logging.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.override = level
+	// Apply to each level-filtered writer.
+	for _, w := range l.writers {
+		// Only filtered writers have a level.
+		if filter, ok := w.(*LevelFilter); ok {
+			filter.SetLevelOverride(level)
+		}
+	}
+}
+
 // Close closes all writers.
 //
 // Returns:
@@ -266,6 +266,39 @@ func TestMultiLogger_AddWriter(t *testing.T) {
 	}
 }
 
+func TestMultiLogger_SetLevelOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		level logging.Level
+	}{
+		{name: "debug override", level: logging.LevelDebug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configured := &testWriter{}
+			logger := daemon.New(daemon.WithLevelFilter(configured, logging.LevelInfo))
+			logger.SetLevelOverride(&tt.level)
+
+			added := &testWriter{}
+			logger.AddWriter(daemon.WithLevelFilter(added, logging.LevelInfo))
+
+			logger.Debug("test", "event", "message", nil)
+			assert.Len(t, configured.events, 1)
+			assert.Len(t, added.events, 1)
+
+			logger.SetLevelOverride(nil)
+			logger.Debug("test", "event", "message", nil)
+			assert.Len(t, configured.events, 1)
+			assert.Len(t, added.events, 1)
+		})
+	}
+}
+
 func TestMultiLogger_Close(t *testing.T) {
 	t.Parallel()
 
@@ -2,15 +2,29 @@
 package daemon
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"sync"
 
 	"golang.org/x/term"
 
+	"github.com/kodflow/daemon/internal/domain/config"
 	"github.com/kodflow/daemon/internal/domain/logging"
 )
 
+// noColorEnv disables colors when set to a non-empty value (https://no-color.org).
+const noColorEnv string = "NO_COLOR"
+
+// Console writer configuration errors.
+var (
+	// ErrUnknownConsoleColor indicates an unknown console color mode.
+	ErrUnknownConsoleColor error = errors.New("unknown console color mode")
+	// ErrUnknownConsoleFormat indicates an unknown console line format.
+	ErrUnknownConsoleFormat error = errors.New("unknown console format")
+)
+
 // ANSI color codes for log levels.
 const (
 	colorReset string = "\033[0m"
@@ -37,12 +51,41 @@ type ConsoleWriter struct {
 }
 
 // NewConsoleWriter creates a new console writer with auto-detected color support.
+// Colors are used when stdout is a terminal and NO_COLOR is unset.
 //
 // Returns:
 //   - *ConsoleWriter: the created console writer.
 func NewConsoleWriter() *ConsoleWriter {
 	// Create console writer with OS defaults and auto-detected color.
-	return NewConsoleWriterWithOptions(os.Stdout, os.Stderr, isTerminal(os.Stdout))
+	return NewConsoleWriterWithOptions(os.Stdout, os.Stderr, os.Getenv(noColorEnv) == "" && isTerminal(os.Stdout))
+}
+
+// NewConsoleWriterFromConfig creates a console writer on stdout/stderr with
+// the configured color mode and line format.
+//
+// Params:
+//   - cfg: the console writer configuration.
+//
+// Returns:
+//   - *ConsoleWriter: the created console writer.
+//   - error: ErrUnknownConsoleColor or ErrUnknownConsoleFormat for invalid values.
+func NewConsoleWriterFromConfig(cfg config.ConsoleWriterConfig) (*ConsoleWriter, error) {
+	color, err := resolveColor(cfg.Color, os.Getenv(noColorEnv), isTerminal(os.Stdout))
+	// handle invalid color mode
+	if err != nil {
+		// return color mode error
+		return nil, err
+	}
+	format, err := newConsoleFormatter(cfg.Format)
+	// handle invalid format
+	if err != nil {
+		// return format error
+		return nil, err
+	}
+	w := NewConsoleWriterWithOptions(os.Stdout, os.Stderr, color)
+	w.format = format
+	// return configured console writer
+	return w, nil
 }
 
 // NewConsoleWriterWithOptions creates a console writer with explicit options.
@@ -142,6 +185,66 @@ func (w *ConsoleWriter) Close() error {
 	return nil
 }
 
+// resolveColor decides whether to color the output.
+// In auto mode, a non-empty NO_COLOR disables colors and a non-terminal
+// stdout, such as a pipe or docker logs without -t, does too.
+//
+// Params:
+//   - mode: the configured color mode, empty for auto.
+//   - noColor: the value of NO_COLOR.
+//   - tty: whether stdout is a terminal.
+//
+// Returns:
+//   - bool: true to color the output.
+//   - error: ErrUnknownConsoleColor for an unknown mode.
+func resolveColor(mode, noColor string, tty bool) (bool, error) {
+	// dispatch on the configured mode
+	switch mode {
+	// auto mode follows NO_COLOR and the terminal
+	case "", config.ConsoleColorAuto:
+		// return color when attached and allowed
+		return noColor == "" && tty, nil
+	// forced colors
+	case config.ConsoleColorAlways:
+		// return color
+		return true, nil
+	// disabled colors
+	case config.ConsoleColorNever:
+		// return no color
+		return false, nil
+	// unknown mode
+	default:
+		// return error naming the mode
+		return false, fmt.Errorf("%w: %s", ErrUnknownConsoleColor, mode)
+	}
+}
+
+// newConsoleFormatter creates the formatter of a console line format.
+//
+// Params:
+//   - format: the configured format, empty for text.
+//
+// Returns:
+//   - Formatter: the formatter.
+//   - error: ErrUnknownConsoleFormat for an unknown format.
+func newConsoleFormatter(format string) (Formatter, error) {
+	// dispatch on the configured format
+	switch format {
+	// full timestamps and bracketed levels
+	case "", config.ConsoleFormatText:
+		// return text formatter
+		return NewTextFormatter(""), nil
+	// compact lines for humans
+	case config.ConsoleFormatHuman:
+		// return human formatter
+		return NewHumanFormatter(), nil
+	// unknown format
+	default:
+		// return error naming the format
+		return nil, fmt.Errorf("%w: %s", ErrUnknownConsoleFormat, format)
+	}
+}
+
 // isTerminal checks if the given writer is a terminal.
 //
 // Params:
@@ -5,8 +5,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/kodflow/daemon/internal/domain/config"
 	"github.com/kodflow/daemon/internal/domain/logging"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConsoleWriter_Colorize(t *testing.T) {
@@ -94,3 +96,59 @@ func TestIsTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveColor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mode     string
+		noColor  string
+		tty      bool
+		expected bool
+		wantErr  error
+	}{
+		{name: "auto on terminal", mode: "", tty: true, expected: true},
+		{name: "auto when piped", mode: config.ConsoleColorAuto, tty: false, expected: false},
+		{name: "auto with NO_COLOR", mode: config.ConsoleColorAuto, noColor: "1", tty: true, expected: false},
+		{name: "always when piped", mode: config.ConsoleColorAlways, noColor: "1", expected: true},
+		{name: "never on terminal", mode: config.ConsoleColorNever, tty: true, expected: false},
+		{name: "unknown mode", mode: "rainbow", wantErr: ErrUnknownConsoleColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			color, err := resolveColor(tt.mode, tt.noColor, tt.tty)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.expected, color)
+		})
+	}
+}
+
+func TestNewConsoleFormatter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		format   string
+		expected Formatter
+		wantErr  error
+	}{
+		{name: "default is text", format: "", expected: NewTextFormatter("")},
+		{name: "text", format: config.ConsoleFormatText, expected: NewTextFormatter("")},
+		{name: "human", format: config.ConsoleFormatHuman, expected: NewHumanFormatter()},
+		{name: "unknown format", format: "yaml", wantErr: ErrUnknownConsoleFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			formatter, err := newConsoleFormatter(tt.format)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.expected, formatter)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Package daemon provides daemon event logging infrastructure.
+package daemon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// Journal native protocol constants.
+const (
+	// journalSocket is the socket of the journal native protocol.
+	journalSocket string = "/run/systemd/journal/socket"
+	// journalIdentifier is the SYSLOG_IDENTIFIER of daemon events.
+	journalIdentifier string = "supervizio"
+	// journalFieldPrefix starts the fields of the event and its metadata.
+	journalFieldPrefix string = "SUPERVIZIO_"
+)
+
+// journalPriorities maps log levels to syslog priorities: debug, info,
+// warning and err.
+var journalPriorities map[logging.Level]int = map[logging.Level]int{
+	logging.LevelDebug: 7,
+	logging.LevelInfo:  6,
+	logging.LevelWarn:  4,
+	logging.LevelError: 3,
+}
+
+// Ensure JournalWriter implements logging.Writer.
+var _ logging.Writer = (*JournalWriter)(nil)
+
+// JournalWriter sends log events to the systemd journal over its native
+// protocol, so the service and metadata of each event become journal
+// fields: journalctl SUPERVIZIO_SERVICE=nginx lists the events of a service.
+// Writes are protected by a mutex for concurrent access safety.
+type JournalWriter struct {
+	// mu protects the connection.
+	mu sync.Mutex
+	// socket is the journal socket.
+	socket string
+	// conn is the connection to the journal, reopened after a failed write.
+	conn *net.UnixConn
+}
+
+// NewJournalWriter creates a writer sending events to the journal socket.
+//
+// Params:
+//   - socket: the journal socket, /run/systemd/journal/socket on systemd hosts.
+//
+// Returns:
+//   - *JournalWriter: the created journal writer.
+//   - error: nil on success, error when the journal is unreachable.
+func NewJournalWriter(socket string) (*JournalWriter, error) {
+	w := &JournalWriter{socket: socket}
+	// fail early without journal
+	if err := w.dial(); err != nil {
+		// return the connection error
+		return nil, err
+	}
+	// return journal writer
+	return w, nil
+}
+
+// dial connects to the journal socket.
+//
+// Returns:
+//   - error: nil on success, error when the journal is unreachable.
+func (w *JournalWriter) dial() error {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: w.socket, Net: "unixgram"})
+	// journald is not running
+	if err != nil {
+		// return wrapped connection error
+		return fmt.Errorf("connecting to journal: %w", err)
+	}
+	w.conn = conn
+	// connected
+	return nil
+}
+
+// Write sends a log event as one journal entry. A write failing because
+// journald restarted is retried once on a new connection.
+//
+// Params:
+//   - event: the log event to write.
+//
+// Returns:
+//   - error: nil on success, error on failure.
+func (w *JournalWriter) Write(event logging.LogEvent) error {
+	entry := journalEntry(&event)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// reconnect after a failed write
+	if w.conn == nil {
+		// journal still unreachable
+		if err := w.dial(); err != nil {
+			// return the connection error
+			return err
+		}
+	}
+	_, err := w.conn.Write(entry)
+	// journald may have restarted
+	if err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+		// retry on a new connection
+		if dialErr := w.dial(); dialErr != nil {
+			// return the first error
+			return fmt.Errorf("writing to journal: %w", err)
+		}
+		_, err = w.conn.Write(entry)
+	}
+	// return write result
+	return err
+}
+
+// Close closes the connection to the journal.
+//
+// Returns:
+//   - error: nil on success, error on failure.
+func (w *JournalWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// already disconnected
+	if w.conn == nil {
+		// nothing to close
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	// return close result
+	return err
+}
+
+// journalEntry encodes an event in the journal native protocol: MESSAGE,
+// PRIORITY and SYSLOG_IDENTIFIER, then SUPERVIZIO_EVENT,
+// SUPERVIZIO_SERVICE and one SUPERVIZIO_<KEY> field per metadata key.
+//
+// Params:
+//   - event: the log event.
+//
+// Returns:
+//   - []byte: the datagram.
+func journalEntry(event *logging.LogEvent) []byte {
+	var buf bytes.Buffer
+	message := event.Message
+	// events without message are named by their type
+	if message == "" {
+		message = event.EventType
+	}
+	writeJournalField(&buf, "MESSAGE", message)
+	writeJournalField(&buf, "PRIORITY", strconv.Itoa(journalPriorities[event.Level]))
+	writeJournalField(&buf, "SYSLOG_IDENTIFIER", journalIdentifier)
+	writeJournalField(&buf, journalFieldPrefix+"EVENT", event.EventType)
+	// daemon-wide events have no service
+	if event.Service != "" {
+		writeJournalField(&buf, journalFieldPrefix+"SERVICE", event.Service)
+	}
+	keys := make([]string, 0, len(event.Metadata))
+	// order metadata for stable entries
+	for key := range event.Metadata {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	// pass each metadata key through as a field
+	for _, key := range keys {
+		writeJournalField(&buf, journalFieldPrefix+journalFieldName(key), fmt.Sprint(event.Metadata[key]))
+	}
+	// return the datagram
+	return buf.Bytes()
+}
+
+// writeJournalField appends a field. Values spanning several lines are
+// written as the name, a newline, their little-endian 64-bit length and
+// the raw value.
+//
+// Params:
+//   - buf: the datagram.
+//   - name: the field name.
+//   - value: the field value.
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	// single-line values use the simple form
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		// field written
+		return
+	}
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName turns a metadata key into a journal field name:
+// uppercase letters, digits and underscores only.
+//
+// Params:
+//   - key: the metadata key.
+//
+// Returns:
+//   - string: the field name, without prefix.
+func journalFieldName(key string) string {
+	// map each character to the allowed set
+	return strings.Map(func(r rune) rune {
+		// keep letters, uppercased, and digits
+		switch {
+		case r >= 'a' && r <= 'z':
+			// uppercase letter
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			// allowed as is
+			return r
+		default:
+			// anything else becomes an underscore
+			return '_'
+		}
+	}, key)
+}
@@ -0,0 +1,66 @@
+package daemon_test
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging/daemon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenJournal opens a datagram socket standing in for journald.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - string: the socket path.
+//   - *net.UnixConn: the listening socket.
+func listenJournal(t *testing.T) (string, *net.UnixConn) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return path, conn
+}
+
+func TestNewJournalWriter(t *testing.T) {
+	t.Parallel()
+
+	_, err := daemon.NewJournalWriter(filepath.Join(t.TempDir(), "missing.sock"))
+	assert.Error(t, err)
+}
+
+func TestJournalWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	path, journal := listenJournal(t)
+	writer, err := daemon.NewJournalWriter(path)
+	require.NoError(t, err)
+	defer func() { _ = writer.Close() }()
+
+	event := logging.NewLogEvent(logging.LevelWarn, "nginx", "failed", "Service failed")
+	event.Metadata = map[string]any{"exit_code": 1, "error": "line one\nline two", "restart.count": 3}
+	require.NoError(t, writer.Write(event))
+
+	buf := make([]byte, 4096)
+	require.NoError(t, journal.SetReadDeadline(time.Now().Add(time.Second)))
+	n, err := journal.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "MESSAGE=Service failed\n"+
+		"PRIORITY=4\n"+
+		"SYSLOG_IDENTIFIER=supervizio\n"+
+		"SUPERVIZIO_EVENT=failed\n"+
+		"SUPERVIZIO_SERVICE=nginx\n"+
+		"SUPERVIZIO_ERROR\n\x11\x00\x00\x00\x00\x00\x00\x00line one\nline two\n"+
+		"SUPERVIZIO_EXIT_CODE=1\n"+
+		"SUPERVIZIO_RESTART_COUNT=3\n", string(buf[:n]))
+
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, writer.Close())
+}
@@ -0,0 +1,361 @@
+// Package logging provides history.go implementing reads of service output from log files.
+// It merges the active and rotated files of every stream in time order.
+package logging
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// Compile-time interface check.
+var _ domainlogging.OutputHistory = (*History)(nil)
+
+// FileLocator resolves the files the output of a service is written to.
+type FileLocator interface {
+	// LogFiles returns the log files of a service.
+	LogFiles(service string) ([]domainlogging.LogFile, error)
+}
+
+// History reads the output of services from their log files, rotated files
+// included, so readers need not know the rotation naming scheme.
+type History struct {
+	// locator resolves the files of a service.
+	locator FileLocator
+}
+
+// NewHistory creates a reader of service log files.
+//
+// Params:
+//   - locator: the resolver of service log files.
+//
+// Returns:
+//   - *History: the reader.
+func NewHistory(locator FileLocator) *History {
+	// return reader over the located files
+	return &History{locator: locator}
+}
+
+// Read sends the lines of a service written since a time and matching the
+// filter, oldest first. Lines of the rotated and active files of each stream
+// are merged by timestamp; gzip-compressed rotations are decompressed.
+// A line without timestamp takes the time of the line before it, and lines
+// of files written without timestamp take the modification time of the file.
+//
+// Params:
+//   - ctx: the context for cancellation.
+//   - service: the service name.
+//   - since: the time of the oldest line sent; zero sends every line.
+//   - filter: the filter lines must match.
+//   - send: the callback receiving each line; an error stops the read.
+//
+// Returns:
+//   - error: the locator, read, send or context error.
+func (h *History) Read(ctx context.Context, service string, since time.Time, filter domainlogging.OutputFilter, send func(*domainlogging.OutputLine) error) error {
+	files, err := h.locator.LogFiles(service)
+	// unknown service
+	if err != nil {
+		// propagate locator error
+		return err
+	}
+	readers := make([]*historyReader, 0, len(files))
+	// read the files of each stream in order
+	for i := range files {
+		readers = append(readers, newHistoryReader(service, &files[i], since))
+	}
+	defer func() {
+		// release files left open by an interrupted read
+		for _, reader := range readers {
+			reader.close()
+		}
+	}()
+
+	// send the oldest pending line until every stream is drained
+	for {
+		// stop when the client is gone
+		if err := ctx.Err(); err != nil {
+			// propagate context error
+			return err
+		}
+		next, err := oldestReader(readers)
+		// unreadable file
+		if err != nil {
+			// propagate read error
+			return err
+		}
+		// every stream drained
+		if next == nil {
+			// read complete
+			return nil
+		}
+		line := next.take()
+		// skip older and filtered out lines
+		if line.Timestamp.Before(since) || !filter.Matches(&line) {
+			continue
+		}
+		// forward the line
+		if err := send(&line); err != nil {
+			// propagate send error
+			return err
+		}
+	}
+}
+
+// oldestReader returns the reader whose pending line is the oldest.
+//
+// Params:
+//   - readers: the readers of each stream.
+//
+// Returns:
+//   - *historyReader: the reader, nil when every stream is drained.
+//   - error: the first read error.
+func oldestReader(readers []*historyReader) (*historyReader, error) {
+	var oldest *historyReader
+	// compare the pending line of each stream
+	for _, reader := range readers {
+		line, err := reader.peek()
+		// unreadable file
+		if err != nil {
+			// propagate read error
+			return nil, err
+		}
+		// keep the earliest line, the first stream on ties
+		if line != nil && (oldest == nil || line.Timestamp.Before(oldest.head.Timestamp)) {
+			oldest = reader
+		}
+	}
+	// return oldest reader
+	return oldest, nil
+}
+
+// historyReader reads the lines of one stream across its rotated files.
+type historyReader struct {
+	// service is the service name.
+	service string
+	// file is the stream log file.
+	file *domainlogging.LogFile
+	// paths are the files left to read, oldest first.
+	paths []string
+	// current is the open file.
+	current *os.File
+	// reader reads the open file, decompressed.
+	reader *bufio.Reader
+	// modTime is the modification time of the open file.
+	modTime time.Time
+	// last is the timestamp of the previous line.
+	last time.Time
+	// head is the pending line, nil until read.
+	head *domainlogging.OutputLine
+}
+
+// newHistoryReader creates the reader of a stream.
+//
+// Params:
+//   - service: the service name.
+//   - file: the stream log file.
+//   - since: the time of the oldest line wanted.
+//
+// Returns:
+//   - *historyReader: the reader, positioned before the first line.
+func newHistoryReader(service string, file *domainlogging.LogFile, since time.Time) *historyReader {
+	// return reader over the files of the stream
+	return &historyReader{
+		service: service,
+		file:    file,
+		paths:   historyFiles(file.Path, since),
+	}
+}
+
+// historyFiles lists the rotated files then the active file of a stream,
+// oldest first, leaving out files last written before a time.
+//
+// Params:
+//   - path: the active log file path.
+//   - since: the time of the oldest line wanted.
+//
+// Returns:
+//   - []string: the files to read.
+func historyFiles(path string, since time.Time) []string {
+	type backup struct {
+		// path is the rotated file path.
+		path string
+		// index is the backup number, 1 for the most recent.
+		index int
+	}
+	var backups []backup
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	// keep numbered backups only
+	for _, entry := range entries {
+		candidate := filepath.Join(filepath.Dir(path), entry.Name())
+		// skip unrelated files of the directory
+		if index, ok := rotationIndex(path, candidate); ok {
+			backups = append(backups, backup{path: candidate, index: index})
+		}
+	}
+	// the highest number is the oldest backup
+	slices.SortFunc(backups, func(a, b backup) int {
+		// order by descending number
+		return b.index - a.index
+	})
+
+	paths := make([]string, 0, len(backups)+1)
+	// keep the files holding lines written since the time
+	for _, candidate := range append(backups, backup{path: path}) {
+		info, err := os.Stat(candidate.path)
+		// skip missing files and files older than wanted
+		if err != nil || !info.Mode().IsRegular() || info.ModTime().Before(since) {
+			continue
+		}
+		paths = append(paths, candidate.path)
+	}
+	// return files oldest first
+	return paths
+}
+
+// peek returns the pending line of the stream, reading it if needed.
+//
+// Returns:
+//   - *domainlogging.OutputLine: the pending line, nil when drained.
+//   - error: the open or read error.
+func (r *historyReader) peek() (*domainlogging.OutputLine, error) {
+	// read the next line once
+	for r.head == nil {
+		// open the next file
+		if r.reader == nil {
+			// every file read
+			if len(r.paths) == 0 {
+				// stream drained
+				return nil, nil
+			}
+			// unreadable file
+			if err := r.open(); err != nil {
+				// propagate open error
+				return nil, err
+			}
+			continue
+		}
+		text, err := r.reader.ReadString('\n')
+		// keep the line read, even without final newline
+		if text != "" {
+			line := r.line(strings.TrimRight(text, "\r\n"))
+			r.head = &line
+			continue
+		}
+		// end of the file reached
+		if errors.Is(err, io.EOF) {
+			r.close()
+			continue
+		}
+		// read failure
+		if err != nil {
+			// propagate read error
+			return nil, fmt.Errorf("reading %s: %w", r.current.Name(), err)
+		}
+	}
+	// return pending line
+	return r.head, nil
+}
+
+// take returns the pending line and clears it.
+//
+// Returns:
+//   - domainlogging.OutputLine: the pending line.
+func (r *historyReader) take() domainlogging.OutputLine {
+	line := *r.head
+	r.head = nil
+	// return consumed line
+	return line
+}
+
+// open opens the next file of the stream, decompressing gzip rotations.
+// A file rotated away since it was listed is skipped.
+//
+// Returns:
+//   - error: the open or decompression error.
+func (r *historyReader) open() error {
+	path := r.paths[0]
+	r.paths = r.paths[1:]
+	file, err := os.Open(path)
+	// skip files removed since listed
+	if errors.Is(err, os.ErrNotExist) {
+		// nothing to read
+		return nil
+	}
+	// unreadable file
+	if err != nil {
+		// propagate open error
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	info, err := file.Stat()
+	// unreadable file
+	if err != nil {
+		_ = file.Close()
+		// propagate stat error
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	var source io.Reader = file
+	// decompress compressed rotations
+	if strings.HasSuffix(path, compressedSuffix) {
+		gz, err := gzip.NewReader(file)
+		// corrupt archive
+		if err != nil {
+			_ = file.Close()
+			// propagate decompression error
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		source = gz
+	}
+	r.current = file
+	r.reader = bufio.NewReader(source)
+	r.modTime = info.ModTime()
+	// file ready
+	return nil
+}
+
+// close closes the open file of the stream, if any.
+func (r *historyReader) close() {
+	// nothing open
+	if r.current == nil {
+		return
+	}
+	_ = r.current.Close()
+	r.current = nil
+	r.reader = nil
+}
+
+// line builds the output line of a text read from the stream.
+//
+// Params:
+//   - text: the line without trailing newline.
+//
+// Returns:
+//   - domainlogging.OutputLine: the line with its timestamp and level.
+func (r *historyReader) line(text string) domainlogging.OutputLine {
+	timestamp, message := r.modTime, text
+	// split the timestamp written before the line
+	if r.file.TimestampFormat != "" {
+		// continuation lines keep the time of the previous line
+		if t, rest, ok := ParseTimestamp(text, r.file.TimestampFormat); ok {
+			r.last, message = t, rest
+		}
+		timestamp = r.last
+	}
+	// return line of the stream
+	return domainlogging.OutputLine{
+		Service:   r.service,
+		Stream:    r.file.Stream,
+		Timestamp: timestamp,
+		Level:     domainlogging.DetectLevel(message),
+		Message:   message,
+	}
+}
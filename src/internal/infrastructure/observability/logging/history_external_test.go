@@ -0,0 +1,198 @@
+package logging_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errUnknownService is returned by the stub locator for unknown services.
+var errUnknownService error = errors.New("service not found")
+
+// stubLocator resolves the log files of a single service.
+type stubLocator struct {
+	// service is the known service.
+	service string
+	// files are the log files of the service.
+	files []domainlogging.LogFile
+}
+
+// LogFiles returns the files of the known service.
+func (l *stubLocator) LogFiles(service string) ([]domainlogging.LogFile, error) {
+	if service != l.service {
+		return nil, errUnknownService
+	}
+	return l.files, nil
+}
+
+// writeLog writes a log file, gzip-compressed for .gz paths, with a
+// modification time.
+func writeLog(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+	data := []byte(content)
+	if filepath.Ext(path) == ".gz" {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		data = buf.Bytes()
+	}
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+}
+
+// readHistory returns the messages read from a history.
+func readHistory(t *testing.T, history *logging.History, since time.Time, filter domainlogging.OutputFilter) []string {
+	t.Helper()
+	var messages []string
+	err := history.Read(context.Background(), "api", since, filter, func(line *domainlogging.OutputLine) error {
+		messages = append(messages, line.Stream+" "+line.Message)
+		return nil
+	})
+	require.NoError(t, err)
+	return messages
+}
+
+func TestHistory_Read(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) string {
+		return base.Add(time.Duration(minutes) * time.Minute).Format(time.RFC3339)
+	}
+	out := filepath.Join(dir, "out.log")
+	errLog := filepath.Join(dir, "err.log")
+	writeLog(t, out+".2.gz", at(0)+" starting\n"+at(10)+" ready\n", base.Add(10*time.Minute))
+	writeLog(t, out+".1", at(20)+" ERROR db down\n\tat db.go:12\n", base.Add(20*time.Minute))
+	writeLog(t, out, at(40)+" recovered", base.Add(40*time.Minute))
+	writeLog(t, errLog, at(5)+" warn slow start\n"+at(30)+" error retrying\n", base.Add(30*time.Minute))
+	writeLog(t, filepath.Join(dir, "out.log.old"), at(50)+" unrelated\n", base.Add(50*time.Minute))
+
+	history := logging.NewHistory(&stubLocator{service: "api", files: []domainlogging.LogFile{
+		{Path: out, Stream: domainlogging.StreamStdout, TimestampFormat: logging.FormatISO8601},
+		{Path: errLog, Stream: domainlogging.StreamStderr, TimestampFormat: logging.FormatISO8601},
+	}})
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		filter   domainlogging.OutputFilter
+		expected []string
+	}{
+		{
+			name: "all lines in time order",
+			expected: []string{
+				"stdout starting",
+				"stderr warn slow start",
+				"stdout ready",
+				"stdout ERROR db down",
+				"stdout \tat db.go:12",
+				"stderr error retrying",
+				"stdout recovered",
+			},
+		},
+		{
+			name:  "since skips older lines and files",
+			since: base.Add(15 * time.Minute),
+			expected: []string{
+				"stdout ERROR db down",
+				"stdout \tat db.go:12",
+				"stderr error retrying",
+				"stdout recovered",
+			},
+		},
+		{
+			name:   "pattern",
+			filter: domainlogging.OutputFilter{Pattern: regexp.MustCompile("(?i)error")},
+			expected: []string{
+				"stdout ERROR db down",
+				"stderr error retrying",
+			},
+		},
+		{
+			name:   "minimum level",
+			filter: domainlogging.OutputFilter{MinLevel: domainlogging.LevelWarn},
+			expected: []string{
+				"stderr warn slow start",
+				"stdout ERROR db down",
+				"stderr error retrying",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, readHistory(t, history, tt.since, tt.filter))
+		})
+	}
+}
+
+func TestHistory_Read_withoutTimestamps(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Now()
+	out := filepath.Join(dir, "out.log")
+	writeLog(t, out+".1", "old line\n", now.Add(-2*time.Hour))
+	writeLog(t, out, "new line\n", now)
+	history := logging.NewHistory(&stubLocator{service: "api", files: []domainlogging.LogFile{
+		{Path: out, Stream: domainlogging.StreamStdout},
+	}})
+
+	// Lines take the modification time of their file.
+	assert.Equal(t, []string{"stdout old line", "stdout new line"},
+		readHistory(t, history, time.Time{}, domainlogging.OutputFilter{}))
+	assert.Equal(t, []string{"stdout new line"},
+		readHistory(t, history, now.Add(-time.Hour), domainlogging.OutputFilter{}))
+}
+
+func TestHistory_Read_errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.log")
+	writeLog(t, out, "one\ntwo\n", time.Now())
+	history := logging.NewHistory(&stubLocator{service: "api", files: []domainlogging.LogFile{
+		{Path: out, Stream: domainlogging.StreamStdout},
+	}})
+	send := func(*domainlogging.OutputLine) error { return nil }
+
+	// Unknown services are reported by the locator.
+	err := history.Read(context.Background(), "web", time.Time{}, domainlogging.OutputFilter{}, send)
+	assert.ErrorIs(t, err, errUnknownService)
+
+	// A failing send stops the read.
+	errStop := errors.New("stop")
+	calls := 0
+	err = history.Read(context.Background(), "api", time.Time{}, domainlogging.OutputFilter{}, func(*domainlogging.OutputLine) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+
+	// A cancelled read stops.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = history.Read(ctx, "api", time.Time{}, domainlogging.OutputFilter{}, send)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// A corrupt compressed rotation is reported.
+	require.NoError(t, os.WriteFile(out+".1.gz", []byte("not gzip"), 0o600))
+	err = history.Read(context.Background(), "api", time.Time{}, domainlogging.OutputFilter{}, send)
+	assert.ErrorContains(t, err, "out.log.1.gz")
+}
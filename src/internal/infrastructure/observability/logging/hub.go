@@ -0,0 +1,353 @@
+// Package logging provides hub.go implementing live streaming of captured service output.
+// It keeps recent lines per service and fans new lines out to subscribers.
+package logging
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+const (
+	// defaultHubBacklog is the number of recent lines kept per service.
+	defaultHubBacklog int = 1000
+	// subscriptionBuffer is the number of lines queued for a subscriber before dropping.
+	subscriptionBuffer int = 256
+)
+
+// Compile-time interface checks.
+var (
+	_ domainlogging.OutputStreamer     = (*Hub)(nil)
+	_ domainlogging.OutputSubscription = (*subscription)(nil)
+)
+
+// Hub collects the output lines of every service and streams them to subscribers.
+// Publishing never blocks: a subscriber that cannot keep up loses lines and
+// the loss is counted on its subscription.
+type Hub struct {
+	// mu protects rings and subs.
+	mu sync.Mutex
+	// backlog is the number of lines kept per service.
+	backlog int
+	// rings holds the recent lines of each service.
+	rings map[string]*lineRing
+	// subs holds the active subscriptions.
+	subs map[*subscription]struct{}
+}
+
+// NewHub creates an output hub.
+//
+// Params:
+//   - backlog: the number of recent lines kept per service; defaults to 1000 when not positive.
+//
+// Returns:
+//   - *Hub: the hub, without subscribers.
+func NewHub(backlog int) *Hub {
+	// apply default backlog
+	if backlog <= 0 {
+		backlog = defaultHubBacklog
+	}
+	// return empty hub
+	return &Hub{
+		backlog: backlog,
+		rings:   make(map[string]*lineRing),
+		subs:    make(map[*subscription]struct{}),
+	}
+}
+
+// Publish records a line and delivers it to matching subscribers.
+//
+// Params:
+//   - line: the captured line.
+func (h *Hub) Publish(line domainlogging.OutputLine) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ring, ok := h.rings[line.Service]
+	// create the ring of a new service
+	if !ok {
+		ring = newLineRing(h.backlog)
+		h.rings[line.Service] = ring
+	}
+	ring.push(line)
+
+	// fan out without blocking the writer
+	for sub := range h.subs {
+		sub.deliver(&line)
+	}
+}
+
+// Tail returns up to n of the most recent matching lines, oldest first.
+//
+// Params:
+//   - filter: the line filter.
+//   - n: the maximum number of lines; zero or less returns none.
+//
+// Returns:
+//   - []domainlogging.OutputLine: the matching lines.
+func (h *Hub) Tail(filter domainlogging.OutputFilter, n int) []domainlogging.OutputLine {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// return lines under lock
+	return h.tail(&filter, n)
+}
+
+// Subscribe returns the last tail matching lines followed by new ones.
+// The backlog and live lines are taken atomically, so none is missed or repeated.
+//
+// Params:
+//   - filter: the line filter.
+//   - tail: the number of recent lines to replay first.
+//
+// Returns:
+//   - domainlogging.OutputSubscription: the subscription; the caller must Close it.
+func (h *Hub) Subscribe(filter domainlogging.OutputFilter, tail int) domainlogging.OutputSubscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	backlog := h.tail(&filter, tail)
+	sub := &subscription{
+		hub:    h,
+		filter: filter,
+		lines:  make(chan domainlogging.OutputLine, max(subscriptionBuffer, len(backlog))),
+	}
+	// replay backlog first
+	for i := range backlog {
+		sub.lines <- backlog[i]
+	}
+	h.subs[sub] = struct{}{}
+	// return registered subscription
+	return sub
+}
+
+// Writer returns a writer publishing each complete line of a service stream.
+// Close flushes a trailing partial line.
+//
+// Params:
+//   - service: the service name.
+//   - stream: domainlogging.StreamStdout or domainlogging.StreamStderr.
+//
+// Returns:
+//   - *HubWriter: the line-buffered writer.
+func (h *Hub) Writer(service, stream string) *HubWriter {
+	// wrap publisher in a line buffer
+	return &HubWriter{lines: NewLineWriter(&hubPublisher{hub: h, service: service, stream: stream}, "")}
+}
+
+// tail collects the most recent matching lines across services.
+// Must be called with h.mu held.
+//
+// Params:
+//   - filter: the line filter.
+//   - n: the maximum number of lines.
+//
+// Returns:
+//   - []domainlogging.OutputLine: the matching lines, oldest first.
+func (h *Hub) tail(filter *domainlogging.OutputFilter, n int) []domainlogging.OutputLine {
+	// nothing requested
+	if n <= 0 {
+		// no backlog
+		return nil
+	}
+	var result []domainlogging.OutputLine
+	// gather matching lines of each service
+	for _, ring := range h.rings {
+		ring.each(func(line *domainlogging.OutputLine) {
+			// keep matching lines only
+			if filter.Matches(line) {
+				result = append(result, *line)
+			}
+		})
+	}
+	// interleave services chronologically
+	slices.SortStableFunc(result, func(a, b domainlogging.OutputLine) int {
+		// order by capture time
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	// keep the most recent lines
+	if len(result) > n {
+		result = result[len(result)-n:]
+	}
+	// return backlog
+	return result
+}
+
+// unsubscribe removes a subscription and closes its channel.
+//
+// Params:
+//   - sub: the subscription to remove.
+func (h *Hub) unsubscribe(sub *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// ignore repeated close
+	if _, ok := h.subs[sub]; !ok {
+		// already closed
+		return
+	}
+	delete(h.subs, sub)
+	close(sub.lines)
+}
+
+// subscription is a reader registered on a hub.
+type subscription struct {
+	// hub is the owning hub.
+	hub *Hub
+	// filter selects delivered lines.
+	filter domainlogging.OutputFilter
+	// lines is the delivery channel.
+	lines chan domainlogging.OutputLine
+	// dropped counts lines lost because the channel was full.
+	dropped atomic.Uint64
+}
+
+// Lines returns the delivery channel.
+//
+// Returns:
+//   - <-chan domainlogging.OutputLine: matching lines, closed by Close.
+func (s *subscription) Lines() <-chan domainlogging.OutputLine {
+	// expose channel
+	return s.lines
+}
+
+// Dropped returns the number of lines lost to backpressure.
+//
+// Returns:
+//   - uint64: the dropped line count.
+func (s *subscription) Dropped() uint64 {
+	// read counter
+	return s.dropped.Load()
+}
+
+// Close stops delivery.
+func (s *subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// deliver queues a matching line without blocking.
+// Must be called with the hub lock held.
+//
+// Params:
+//   - line: the line to deliver.
+func (s *subscription) deliver(line *domainlogging.OutputLine) {
+	// skip lines outside the filter
+	if !s.filter.Matches(line) {
+		// not for this reader
+		return
+	}
+	select {
+	// queued
+	case s.lines <- *line:
+	// reader too slow: drop and count
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// HubWriter publishes the output of one service stream to a hub, line by line.
+type HubWriter struct {
+	// lines buffers partial lines.
+	lines *LineWriter
+}
+
+// Write buffers data and publishes every complete line.
+//
+// Params:
+//   - p: the output bytes.
+//
+// Returns:
+//   - int: the number of bytes consumed.
+//   - error: always nil.
+func (w *HubWriter) Write(p []byte) (int, error) {
+	// delegate to line buffer
+	return w.lines.Write(p)
+}
+
+// Close publishes a trailing partial line.
+//
+// Returns:
+//   - error: always nil.
+func (w *HubWriter) Close() error {
+	// flush remaining bytes
+	return w.lines.Flush()
+}
+
+// hubPublisher receives complete lines and publishes them.
+type hubPublisher struct {
+	// hub is the destination hub.
+	hub *Hub
+	// service is the service name.
+	service string
+	// stream is the stream name.
+	stream string
+}
+
+// Write publishes one complete line.
+//
+// Params:
+//   - p: the line, including its newline.
+//
+// Returns:
+//   - int: the number of bytes consumed.
+//   - error: always nil.
+func (hp *hubPublisher) Write(p []byte) (int, error) {
+	message := strings.TrimRight(string(p), "\r\n")
+	hp.hub.Publish(domainlogging.NewOutputLine(hp.service, hp.stream, message))
+	// line consumed
+	return len(p), nil
+}
+
+// lineRing is a fixed-size ring of recent lines.
+type lineRing struct {
+	// buf holds the lines.
+	buf []domainlogging.OutputLine
+	// next is the index of the next write.
+	next int
+	// full indicates the ring has wrapped.
+	full bool
+}
+
+// newLineRing creates an empty ring.
+//
+// Params:
+//   - size: the ring capacity.
+//
+// Returns:
+//   - *lineRing: the ring.
+func newLineRing(size int) *lineRing {
+	// allocate buffer
+	return &lineRing{buf: make([]domainlogging.OutputLine, size)}
+}
+
+// push appends a line, overwriting the oldest when full.
+//
+// Params:
+//   - line: the line to append.
+func (r *lineRing) push(line domainlogging.OutputLine) {
+	r.buf[r.next] = line
+	r.next = (r.next + 1) % len(r.buf)
+	// detect wrap-around
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// each visits the lines oldest first.
+//
+// Params:
+//   - fn: the visitor.
+func (r *lineRing) each(fn func(line *domainlogging.OutputLine)) {
+	// visit older half after wrap-around
+	if r.full {
+		// walk from the oldest slot to the end
+		for i := r.next; i < len(r.buf); i++ {
+			fn(&r.buf[i])
+		}
+	}
+	// visit newer half
+	for i := range r.next {
+		fn(&r.buf[i])
+	}
+}
@@ -0,0 +1,119 @@
+package logging_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// messages extracts line messages.
+func messages(lines []domainlogging.OutputLine) []string {
+	var result []string
+	for _, line := range lines {
+		result = append(result, line.Message)
+	}
+	return result
+}
+
+func TestHub_Tail(t *testing.T) {
+	t.Parallel()
+
+	hub := logging.NewHub(3)
+	for i := range 5 {
+		hub.Publish(domainlogging.NewOutputLine("api", domainlogging.StreamStdout, fmt.Sprintf("api %d", i)))
+	}
+	hub.Publish(domainlogging.NewOutputLine("web", domainlogging.StreamStdout, "web 0"))
+
+	// The backlog keeps the most recent lines per service, interleaved by time.
+	assert.Equal(t, []string{"api 2", "api 3", "api 4", "web 0"}, messages(hub.Tail(domainlogging.OutputFilter{}, 10)))
+	assert.Equal(t, []string{"api 4", "web 0"}, messages(hub.Tail(domainlogging.OutputFilter{}, 2)))
+	assert.Equal(t, []string{"web 0"}, messages(hub.Tail(domainlogging.OutputFilter{Services: []string{"web"}}, 10)))
+	assert.Empty(t, hub.Tail(domainlogging.OutputFilter{}, 0))
+}
+
+func TestHub_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	hub := logging.NewHub(0)
+	hub.Publish(domainlogging.NewOutputLine("api", domainlogging.StreamStdout, "before"))
+
+	sub := hub.Subscribe(domainlogging.OutputFilter{Services: []string{"api"}}, 1)
+	hub.Publish(domainlogging.NewOutputLine("web", domainlogging.StreamStdout, "other"))
+	hub.Publish(domainlogging.NewOutputLine("api", domainlogging.StreamStderr, "after"))
+
+	// Backlog first, then live lines matching the filter.
+	assert.Equal(t, "before", (<-sub.Lines()).Message)
+	line := <-sub.Lines()
+	assert.Equal(t, "after", line.Message)
+	assert.Equal(t, domainlogging.StreamStderr, line.Stream)
+
+	sub.Close()
+	sub.Close()
+	_, open := <-sub.Lines()
+	assert.False(t, open)
+}
+
+func TestHub_Subscribe_Backpressure(t *testing.T) {
+	t.Parallel()
+
+	hub := logging.NewHub(0)
+	sub := hub.Subscribe(domainlogging.OutputFilter{}, 0)
+	defer sub.Close()
+
+	// Publishing never blocks on a reader that does not consume.
+	done := make(chan struct{})
+	go func() {
+		for i := range 300 {
+			hub.Publish(domainlogging.NewOutputLine("api", domainlogging.StreamStdout, fmt.Sprint(i)))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked")
+	}
+
+	assert.Equal(t, uint64(300-256), sub.Dropped())
+}
+
+func TestHub_Writer(t *testing.T) {
+	t.Parallel()
+
+	hub := logging.NewHub(0)
+	w := hub.Writer("api", domainlogging.StreamStdout)
+
+	_, err := w.Write([]byte("first\r\nsec"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("ond\npartial"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"first", "second", "partial"}, messages(hub.Tail(domainlogging.OutputFilter{}, 10)))
+}
+
+func TestCapture_WithHub(t *testing.T) {
+	t.Parallel()
+
+	cfg := &mockConfig{logPath: t.TempDir()}
+	svcCfg := &mockServiceLogging{stdout: config.LogStreamConfig{FilePath: "out.log"}}
+	hub := logging.NewHub(0)
+
+	capture, err := logging.NewCapture("api", cfg, svcCfg, logging.WithHub(hub))
+	require.NoError(t, err)
+
+	_, err = capture.Stdout().Write([]byte("hello\n"))
+	require.NoError(t, err)
+	require.NoError(t, capture.Close())
+
+	lines := hub.Tail(domainlogging.OutputFilter{}, 10)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "api", lines[0].Service)
+	assert.Equal(t, "hello", lines[0].Message)
+}
@@ -0,0 +1,227 @@
+// Package logging provides mux.go implementing the log pipe multiplexer.
+// It reads the output pipes of every service from a bounded number of goroutines.
+package logging
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrMultiplexerClosed indicates a pipe attached to a closed multiplexer.
+var ErrMultiplexerClosed error = errors.New("log multiplexer closed")
+
+// PipeStats reports the bytes read from one output pipe.
+type PipeStats struct {
+	// Service is the name of the service writing to the pipe.
+	Service string
+	// Stream is StreamStdout or StreamStderr.
+	Stream string
+	// Bytes is the number of bytes read from the pipe.
+	Bytes int64
+}
+
+// Pipe is a service output pipe read by a Multiplexer.
+// The process inherits File; the bytes it writes are copied to the
+// destination writer of the pipe until every write end is closed.
+type Pipe struct {
+	// mu serializes reads with detaching.
+	mu sync.Mutex
+	// service is the name of the service writing to the pipe.
+	service string
+	// stream is StreamStdout or StreamStderr.
+	stream string
+	// dst receives the bytes read from the pipe.
+	dst io.Writer
+	// file is the write end handed to the process.
+	file *os.File
+	// reader is the platform read end.
+	reader pipeReader
+	// bytes counts the bytes read from the pipe.
+	bytes atomic.Int64
+	// detached indicates the read end is closed.
+	detached bool
+	// release closes file once.
+	release sync.Once
+	// done is closed once the pipe is detached.
+	done chan struct{}
+}
+
+// File returns the write end of the pipe, to pass as the stdout or stderr
+// of the process. An *os.File is inherited as is, without a copy goroutine.
+//
+// Returns:
+//   - *os.File: the write end.
+func (p *Pipe) File() *os.File {
+	// expose write end to caller
+	return p.file
+}
+
+// Release closes the daemon copy of the write end. It must be called once
+// the process started, so that the pipe ends when the process exits.
+//
+// Returns:
+//   - error: the close error, nil after the first call.
+func (p *Pipe) Release() error {
+	var err error
+	p.release.Do(func() {
+		err = p.file.Close()
+	})
+	// return the first close result
+	return err
+}
+
+// Bytes returns the number of bytes read from the pipe.
+//
+// Returns:
+//   - int64: the bytes read so far.
+func (p *Pipe) Bytes() int64 {
+	// return the counter
+	return p.bytes.Load()
+}
+
+// Done returns a channel closed once the pipe ended or was detached.
+//
+// Returns:
+//   - <-chan struct{}: the channel.
+func (p *Pipe) Done() <-chan struct{} {
+	// expose completion channel
+	return p.done
+}
+
+// copyFrom writes a chunk read from the pipe to its destination.
+//
+// Params:
+//   - chunk: the bytes read.
+func (p *Pipe) copyFrom(chunk []byte) {
+	p.bytes.Add(int64(len(chunk)))
+	// destination errors must not stop the reads, or the process would block
+	_, _ = p.dst.Write(chunk)
+}
+
+// Multiplexer reads the output pipes of many services. On Linux a single
+// goroutine waits on every pipe with epoll, whatever the number of services;
+// elsewhere each pipe is copied by its own goroutine.
+// Destination writers are called from the reading goroutine and should not
+// block, as a slow writer delays the other pipes.
+type Multiplexer struct {
+	// mu protects pipes, closed and the platform loop state.
+	mu sync.Mutex
+	// pipes holds the attached pipes.
+	pipes map[*Pipe]struct{}
+	// closed indicates Close was called.
+	closed bool
+	// loop is the platform reading loop.
+	loop muxLoop
+}
+
+// NewMultiplexer creates a log pipe multiplexer.
+// The reading goroutine starts with the first pipe.
+//
+// Returns:
+//   - *Multiplexer: the multiplexer, without pipes.
+func NewMultiplexer() *Multiplexer {
+	// return empty multiplexer
+	return &Multiplexer{pipes: make(map[*Pipe]struct{})}
+}
+
+// Attach creates a pipe copying the output of a service stream to dst.
+//
+// Params:
+//   - service: the name of the service.
+//   - stream: StreamStdout or StreamStderr.
+//   - dst: the writer receiving the output.
+//
+// Returns:
+//   - *Pipe: the pipe, whose File is passed to the process.
+//   - error: ErrMultiplexerClosed, or the pipe creation error.
+func (m *Multiplexer) Attach(service, stream string, dst io.Writer) (*Pipe, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// refuse new pipes once closed
+	if m.closed {
+		// nothing would read the pipe
+		return nil, ErrMultiplexerClosed
+	}
+	p := &Pipe{service: service, stream: stream, dst: dst, done: make(chan struct{})}
+	// register the read end with the platform loop
+	if err := m.attach(p); err != nil {
+		// propagate pipe creation error
+		return nil, err
+	}
+	m.pipes[p] = struct{}{}
+	// return attached pipe
+	return p, nil
+}
+
+// Detach stops reading a pipe, dropping the output not read yet.
+//
+// Params:
+//   - p: the pipe.
+func (m *Multiplexer) Detach(p *Pipe) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// already detached
+	if p.detached {
+		return
+	}
+	p.detached = true
+	m.mu.Lock()
+	delete(m.pipes, p)
+	m.detach(p)
+	m.mu.Unlock()
+	close(p.done)
+}
+
+// Stats returns the bytes read from each attached pipe.
+//
+// Returns:
+//   - []PipeStats: the statistics, sorted by service then stream.
+func (m *Multiplexer) Stats() []PipeStats {
+	m.mu.Lock()
+	stats := make([]PipeStats, 0, len(m.pipes))
+	// snapshot each pipe counter
+	for p := range m.pipes {
+		stats = append(stats, PipeStats{Service: p.service, Stream: p.stream, Bytes: p.Bytes()})
+	}
+	m.mu.Unlock()
+	slices.SortFunc(stats, func(a, b PipeStats) int {
+		return cmp.Or(cmp.Compare(a.Service, b.Service), cmp.Compare(a.Stream, b.Stream))
+	})
+	// return sorted statistics
+	return stats
+}
+
+// Close detaches every pipe and stops the reading goroutine.
+//
+// Returns:
+//   - error: always nil; Close is idempotent.
+func (m *Multiplexer) Close() error {
+	m.mu.Lock()
+	// skip if already closed
+	if m.closed {
+		m.mu.Unlock()
+		// return early for idempotent close
+		return nil
+	}
+	m.closed = true
+	pipes := make([]*Pipe, 0, len(m.pipes))
+	// collect pipes to detach outside the lock
+	for p := range m.pipes {
+		pipes = append(pipes, p)
+	}
+	m.mu.Unlock()
+
+	// detach every remaining pipe
+	for _, p := range pipes {
+		_ = p.Release()
+		m.Detach(p)
+	}
+	m.stop()
+	// close completed
+	return nil
+}
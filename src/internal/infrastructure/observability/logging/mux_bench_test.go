@@ -0,0 +1,64 @@
+//go:build !race
+
+package logging_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
+)
+
+// BenchmarkMultiplexer measures the log pipeline of many services: output
+// written to the pipes, read by the multiplexer and split into prefixed lines.
+func BenchmarkMultiplexer(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		services int
+	}{
+		{"1Service", 1},
+		{"100Services", 100},
+		{"1000Services", 1000},
+	}
+	chunk := bytes.Repeat([]byte("2024-01-15 10:30:45 request served in 12ms\n"), 64)
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			mux := logging.NewMultiplexer()
+			defer func() { _ = mux.Close() }()
+			pipes := make([]*logging.Pipe, bm.services)
+			for i := range pipes {
+				dst := logging.NewLineWriter(io.Discard, "[svc] ")
+				p, err := mux.Attach("svc", domainlogging.StreamStdout, dst)
+				if err != nil {
+					b.Fatalf("Attach failed: %v", err)
+				}
+				pipes[i] = p
+			}
+
+			b.SetBytes(int64(len(chunk)))
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := range b.N {
+				if _, err := pipes[i%len(pipes)].File().Write(chunk); err != nil {
+					b.Fatalf("Write failed: %v", err)
+				}
+			}
+			want := int64(b.N) * int64(len(chunk))
+			for {
+				var read int64
+				for _, p := range pipes {
+					read += p.Bytes()
+				}
+				if read >= want {
+					break
+				}
+				time.Sleep(10 * time.Microsecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package logging_test provides black-box tests for the log pipe multiplexer.
+package logging_test
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
+)
+
+// syncBuffer is a buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestMultiplexer_Attach verifies process output reaches the destination
+// writers and is counted per pipe.
+//
+// Params:
+//   - t: testing context for assertions
+func TestMultiplexer_Attach(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		services int
+	}{
+		{name: "single service", services: 1},
+		{name: "many services", services: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := logging.NewMultiplexer()
+			defer func() { _ = mux.Close() }()
+
+			outputs := make([]*syncBuffer, tt.services)
+			pipes := make([]*logging.Pipe, tt.services)
+			// start one process per service
+			for i := range tt.services {
+				outputs[i] = &syncBuffer{}
+				pipe, err := mux.Attach(fmt.Sprintf("svc-%02d", i), domainlogging.StreamStdout, outputs[i])
+				require.NoError(t, err)
+				pipes[i] = pipe
+
+				cmd := exec.Command("sh", "-c", fmt.Sprintf("echo line-%d", i))
+				cmd.Stdout = pipe.File()
+				require.NoError(t, cmd.Start())
+				require.NoError(t, pipe.Release())
+				require.NoError(t, cmd.Wait())
+			}
+
+			// every pipe ends with its process
+			for i, pipe := range pipes {
+				select {
+				case <-pipe.Done():
+				case <-time.After(5 * time.Second):
+					t.Fatalf("pipe %d not drained", i)
+				}
+				want := fmt.Sprintf("line-%d\n", i)
+				assert.Equal(t, want, outputs[i].String())
+				assert.Equal(t, int64(len(want)), pipe.Bytes())
+			}
+			assert.Empty(t, mux.Stats())
+		})
+	}
+}
+
+// TestMultiplexer_Stats verifies open pipes are reported in order.
+//
+// Params:
+//   - t: testing context for assertions
+func TestMultiplexer_Stats(t *testing.T) {
+	t.Parallel()
+
+	mux := logging.NewMultiplexer()
+	defer func() { _ = mux.Close() }()
+
+	out, err := mux.Attach("web", domainlogging.StreamStdout, &syncBuffer{})
+	require.NoError(t, err)
+	_, err = mux.Attach("web", domainlogging.StreamStderr, &syncBuffer{})
+	require.NoError(t, err)
+	_, err = mux.Attach("api", domainlogging.StreamStdout, &syncBuffer{})
+	require.NoError(t, err)
+
+	_, err = out.File().WriteString("hello\n")
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return out.Bytes() == 6 }, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []logging.PipeStats{
+		{Service: "api", Stream: domainlogging.StreamStdout},
+		{Service: "web", Stream: domainlogging.StreamStderr},
+		{Service: "web", Stream: domainlogging.StreamStdout, Bytes: 6},
+	}, mux.Stats())
+}
+
+// TestMultiplexer_Close verifies closing detaches the pipes and refuses new ones.
+//
+// Params:
+//   - t: testing context for assertions
+func TestMultiplexer_Close(t *testing.T) {
+	t.Parallel()
+
+	mux := logging.NewMultiplexer()
+	pipe, err := mux.Attach("web", domainlogging.StreamStdout, &syncBuffer{})
+	require.NoError(t, err)
+
+	require.NoError(t, mux.Close())
+	require.NoError(t, mux.Close())
+
+	<-pipe.Done()
+	assert.Empty(t, mux.Stats())
+	_, err = mux.Attach("web", domainlogging.StreamStdout, &syncBuffer{})
+	assert.ErrorIs(t, err, logging.ErrMultiplexerClosed)
+}
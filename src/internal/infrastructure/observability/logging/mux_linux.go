@@ -0,0 +1,221 @@
+//go:build linux
+
+// Package logging provides mux_linux.go implementing the epoll reading loop
+// of the log pipe multiplexer.
+package logging
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// muxEvents is the number of pipe events handled per wait.
+	muxEvents int = 128
+	// muxChunk is the size of the buffer shared by every pipe read.
+	muxChunk int = 32 * 1024
+)
+
+// pipeReader is the non-blocking read end of a pipe.
+type pipeReader struct {
+	// fd is the read end file descriptor.
+	fd int
+}
+
+// muxLoop is the epoll instance watching every pipe.
+type muxLoop struct {
+	// started indicates the epoll instance and the goroutine exist.
+	started bool
+	// epfd is the epoll file descriptor.
+	epfd int
+	// wakefd is the eventfd stopping the loop.
+	wakefd int
+	// readers maps the read ends to their pipes.
+	readers map[int32]*Pipe
+	// stopped is closed once the goroutine returned.
+	stopped chan struct{}
+}
+
+// start creates the epoll instance and the reading goroutine.
+// It is called with the multiplexer lock held.
+//
+// Returns:
+//   - error: the epoll or eventfd creation error.
+func (m *Multiplexer) start() error {
+	epfd, err := unix.EpollCreate1(unix.EPOLL_CLOEXEC)
+	// handle epoll creation failure
+	if err != nil {
+		// propagate error to caller
+		return os.NewSyscallError("epoll_create1", err)
+	}
+	wakefd, err := unix.Eventfd(0, unix.EFD_CLOEXEC|unix.EFD_NONBLOCK)
+	// handle eventfd creation failure
+	if err != nil {
+		_ = unix.Close(epfd)
+		// propagate error after cleanup
+		return os.NewSyscallError("eventfd", err)
+	}
+	ev := unix.EpollEvent{Events: unix.EPOLLIN, Fd: int32(wakefd)}
+	// handle registration failure
+	if err := unix.EpollCtl(epfd, unix.EPOLL_CTL_ADD, wakefd, &ev); err != nil {
+		_ = unix.Close(wakefd)
+		_ = unix.Close(epfd)
+		// propagate error after cleanup
+		return os.NewSyscallError("epoll_ctl", err)
+	}
+	m.loop = muxLoop{
+		started: true,
+		epfd:    epfd,
+		wakefd:  wakefd,
+		readers: make(map[int32]*Pipe),
+		stopped: make(chan struct{}),
+	}
+	// Goroutine lifecycle: one per multiplexer, returns when Close wakes it up.
+	go m.run(epfd, wakefd)
+	// loop started
+	return nil
+}
+
+// attach creates the pipe and registers its read end with epoll.
+// It is called with the multiplexer lock held.
+//
+// Params:
+//   - p: the pipe to fill.
+//
+// Returns:
+//   - error: the pipe or epoll error.
+func (m *Multiplexer) attach(p *Pipe) error {
+	// start the loop with the first pipe
+	if !m.loop.started {
+		// handle loop creation failure
+		if err := m.start(); err != nil {
+			// propagate error to caller
+			return err
+		}
+	}
+	var fds [2]int
+	// both ends stay out of other processes; the write end is inherited through dup2
+	if err := unix.Pipe2(fds[:], unix.O_CLOEXEC); err != nil {
+		// propagate error to caller
+		return os.NewSyscallError("pipe2", err)
+	}
+	ev := unix.EpollEvent{Events: unix.EPOLLIN, Fd: int32(fds[0])}
+	err := unix.SetNonblock(fds[0], true)
+	// register the read end unless it cannot be made non-blocking
+	if err == nil {
+		err = unix.EpollCtl(m.loop.epfd, unix.EPOLL_CTL_ADD, fds[0], &ev)
+	}
+	// handle registration failure
+	if err != nil {
+		_ = unix.Close(fds[0])
+		_ = unix.Close(fds[1])
+		// propagate error after cleanup
+		return os.NewSyscallError("epoll_ctl", err)
+	}
+	p.reader.fd = fds[0]
+	p.file = os.NewFile(uintptr(fds[1]), "|"+p.service+"."+p.stream)
+	m.loop.readers[int32(fds[0])] = p
+	// pipe registered
+	return nil
+}
+
+// detach unregisters and closes the read end of a pipe.
+// It is called with the pipe and multiplexer locks held.
+//
+// Params:
+//   - p: the pipe.
+func (m *Multiplexer) detach(p *Pipe) {
+	delete(m.loop.readers, int32(p.reader.fd))
+	_ = unix.EpollCtl(m.loop.epfd, unix.EPOLL_CTL_DEL, p.reader.fd, nil)
+	_ = unix.Close(p.reader.fd)
+}
+
+// run reads the ready pipes until the loop is woken up.
+//
+// Params:
+//   - epfd: the epoll file descriptor.
+//   - wakefd: the eventfd stopping the loop.
+func (m *Multiplexer) run(epfd, wakefd int) {
+	defer close(m.loop.stopped)
+	events := make([]unix.EpollEvent, muxEvents)
+	chunk := make([]byte, muxChunk)
+	// wait for ready pipes
+	for {
+		n, err := unix.EpollWait(epfd, events, -1)
+		// retry waits interrupted by a signal
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		// the epoll instance is unusable
+		if err != nil {
+			return
+		}
+		// handle each ready descriptor
+		for _, ev := range events[:n] {
+			// Close stops the loop
+			if int(ev.Fd) == wakefd {
+				return
+			}
+			m.mu.Lock()
+			p := m.loop.readers[ev.Fd]
+			m.mu.Unlock()
+			// the pipe was detached after the wait
+			if p != nil {
+				m.read(p, chunk)
+			}
+		}
+	}
+}
+
+// read copies one chunk of a ready pipe, detaching it at end of file.
+// One read per event keeps a busy pipe from starving the others; the
+// level-triggered epoll reports the rest on the next wait.
+//
+// Params:
+//   - p: the ready pipe.
+//   - chunk: the read buffer.
+func (m *Multiplexer) read(p *Pipe, chunk []byte) {
+	p.mu.Lock()
+	// a pipe detached after the lookup may have a reused descriptor
+	if p.detached {
+		p.mu.Unlock()
+		return
+	}
+	n, err := unix.Read(p.reader.fd, chunk)
+	// retry reads interrupted by a signal
+	for errors.Is(err, unix.EINTR) {
+		n, err = unix.Read(p.reader.fd, chunk)
+	}
+	// forward the bytes read
+	if n > 0 {
+		p.copyFrom(chunk[:n])
+	}
+	p.mu.Unlock()
+	// nothing left to read yet
+	if errors.Is(err, unix.EAGAIN) || n > 0 {
+		return
+	}
+	// every write end is closed, or the pipe failed
+	m.Detach(p)
+}
+
+// stop wakes the reading goroutine up, waits for it and closes the epoll
+// instance.
+func (m *Multiplexer) stop() {
+	m.mu.Lock()
+	loop := m.loop
+	m.mu.Unlock()
+	// no pipe was ever attached
+	if !loop.started {
+		return
+	}
+	var one [8]byte
+	binary.NativeEndian.PutUint64(one[:], 1)
+	_, _ = unix.Write(loop.wakefd, one[:])
+	<-loop.stopped
+	_ = unix.Close(loop.wakefd)
+	_ = unix.Close(loop.epfd)
+}
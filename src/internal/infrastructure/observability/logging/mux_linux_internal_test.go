@@ -0,0 +1,93 @@
+//go:build linux
+
+// Package logging provides internal tests for mux_linux.go.
+package logging
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// Test_Multiplexer_boundedGoroutines verifies hundreds of pipes are read by
+// a single goroutine.
+//
+// Params:
+//   - t: testing context for assertions
+func Test_Multiplexer_boundedGoroutines(t *testing.T) {
+	tests := []struct {
+		name  string
+		pipes int
+	}{
+		{name: "hundreds of pipes", pipes: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewMultiplexer()
+			defer func() { _ = mux.Close() }()
+			_, err := mux.Attach("warmup", domainlogging.StreamStdout, &bytesCounter{})
+			require.NoError(t, err)
+			before := runtime.NumGoroutine()
+
+			counter := &bytesCounter{}
+			pipes := make([]*Pipe, tt.pipes)
+			// attach and write to every pipe
+			for i := range pipes {
+				pipes[i], err = mux.Attach("svc-"+strconv.Itoa(i), domainlogging.StreamStdout, counter)
+				require.NoError(t, err)
+				_, err = pipes[i].File().WriteString("x\n")
+				require.NoError(t, err)
+			}
+
+			assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+			// each pipe ends once its write end is closed
+			for _, p := range pipes {
+				require.NoError(t, p.Release())
+				select {
+				case <-p.Done():
+				case <-time.After(5 * time.Second):
+					t.Fatal("pipe not drained")
+				}
+				assert.Equal(t, int64(2), p.Bytes())
+			}
+			assert.Len(t, mux.loop.readers, 1)
+		})
+	}
+}
+
+// Test_Multiplexer_Detach verifies a detached pipe is no longer read.
+//
+// Params:
+//   - t: testing context for assertions
+func Test_Multiplexer_Detach(t *testing.T) {
+	mux := NewMultiplexer()
+	defer func() { _ = mux.Close() }()
+	counter := &bytesCounter{}
+	p, err := mux.Attach("web", domainlogging.StreamStdout, counter)
+	require.NoError(t, err)
+
+	mux.Detach(p)
+	mux.Detach(p)
+
+	<-p.Done()
+	assert.True(t, p.detached)
+	assert.Empty(t, mux.loop.readers)
+	assert.NoError(t, p.Release())
+}
+
+// bytesCounter counts the bytes written.
+type bytesCounter struct {
+	n int
+}
+
+func (c *bytesCounter) Write(p []byte) (int, error) {
+	c.n += len(p)
+	return len(p), nil
+}
@@ -0,0 +1,77 @@
+//go:build !linux
+
+// Package logging provides mux_other.go implementing the per-pipe reading
+// goroutines of the log pipe multiplexer on platforms without epoll.
+package logging
+
+import (
+	"os"
+)
+
+// muxChunk is the size of the buffer of each pipe reader.
+const muxChunk int = 32 * 1024
+
+// pipeReader is the read end of a pipe.
+type pipeReader struct {
+	// file is the read end.
+	file *os.File
+}
+
+// muxLoop holds no state: each pipe is copied by its own goroutine.
+type muxLoop struct{}
+
+// attach creates the pipe and starts its copy goroutine.
+//
+// Params:
+//   - p: the pipe to fill.
+//
+// Returns:
+//   - error: the pipe creation error.
+func (m *Multiplexer) attach(p *Pipe) error {
+	r, w, err := os.Pipe()
+	// handle pipe creation failure
+	if err != nil {
+		// propagate error to caller
+		return err
+	}
+	p.reader.file = r
+	p.file = w
+	// Goroutine lifecycle: one per pipe, returns when the pipe ends or is detached.
+	go m.copy(p)
+	// pipe created
+	return nil
+}
+
+// detach closes the read end, ending the copy goroutine.
+//
+// Params:
+//   - p: the pipe.
+func (m *Multiplexer) detach(p *Pipe) {
+	_ = p.reader.file.Close()
+}
+
+// copy forwards the pipe output until it ends.
+//
+// Params:
+//   - p: the pipe.
+func (m *Multiplexer) copy(p *Pipe) {
+	chunk := make([]byte, muxChunk)
+	// read until end of file or detach
+	for {
+		n, err := p.reader.file.Read(chunk)
+		p.mu.Lock()
+		// forward the bytes unless detached meanwhile
+		if n > 0 && !p.detached {
+			p.copyFrom(chunk[:n])
+		}
+		p.mu.Unlock()
+		// end of file or closed read end
+		if err != nil {
+			m.Detach(p)
+			return
+		}
+	}
+}
+
+// stop has nothing to stop: the copy goroutines end with their pipes.
+func (m *Multiplexer) stop() {}
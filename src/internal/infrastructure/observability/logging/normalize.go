@@ -0,0 +1,171 @@
+// Package logging provides log management with rotation and capture.
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Clock source tags written after normalized timestamps.
+const (
+	// ClockDaemon tags lines timestamped by the daemon when captured.
+	ClockDaemon string = "clock=daemon"
+	// ClockChild tags lines carrying their own timestamp, normalized.
+	ClockChild string = "clock=child"
+)
+
+// childTimestampLayouts are the leading timestamps recognized in child
+// output, tried on the first field, then on the first two fields.
+var childTimestampLayouts [2][]string = [2][]string{
+	{time.RFC3339Nano, "2006-01-02T15:04:05"},
+	{"2006-01-02 15:04:05Z07:00", "2006-01-02 15:04:05", "2006/01/02 15:04:05"},
+}
+
+// TimestampNormalizer prefixes each line with a normalized UTC timestamp
+// and the source of its clock. A line starting with its own timestamp
+// keeps it, converted to UTC; other lines take the capture time.
+type TimestampNormalizer struct {
+	// mu protects the buffer and the clock.
+	mu sync.Mutex
+	// writer receives the normalized lines.
+	writer io.WriteCloser
+	// buf holds the incomplete last line.
+	buf []byte
+	// clock timestamps lines without their own timestamp.
+	clock shared.Clock
+}
+
+// NewTimestampNormalizer creates a normalizer writing complete lines to w.
+//
+// Params:
+//   - w: the writer receiving the normalized lines.
+//
+// Returns:
+//   - *TimestampNormalizer: the normalizer.
+func NewTimestampNormalizer(w io.WriteCloser) *TimestampNormalizer {
+	// return normalizer timestamping with the default clock
+	return &TimestampNormalizer{writer: w, clock: shared.DefaultClock}
+}
+
+// SetClock replaces the clock timestamping lines without their own
+// timestamp, by default shared.DefaultClock.
+//
+// Params:
+//   - clock: the clock.
+func (n *TimestampNormalizer) SetClock(clock shared.Clock) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.clock = clock
+}
+
+// Write implements io.Writer. Complete lines are written at once; the
+// incomplete last line waits for its end.
+//
+// Params:
+//   - p: the output of the child.
+//
+// Returns:
+//   - int: the number of bytes of p consumed.
+//   - error: the write error of the underlying writer.
+func (n *TimestampNormalizer) Write(p []byte) (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.buf = append(n.buf, p...)
+	end := bytes.LastIndexByte(n.buf, newlineChar)
+	// wait for the end of the line
+	if end == indexNotFound {
+		// bytes kept for the next write
+		return len(p), nil
+	}
+	var out []byte
+	// prefix each complete line
+	for line := range bytes.Lines(n.buf[:end+1]) {
+		out = n.appendLine(out, line)
+	}
+	n.buf = append(n.buf[:0], n.buf[end+1:]...)
+	// write every complete line at once
+	if _, err := n.writer.Write(out); err != nil {
+		// return write error
+		return zeroBytes, err
+	}
+	// bytes consumed
+	return len(p), nil
+}
+
+// Close writes the incomplete last line and closes the underlying writer.
+//
+// Returns:
+//   - error: the write or close error.
+func (n *TimestampNormalizer) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var err error
+	// write the line left without newline
+	if len(n.buf) > 0 {
+		_, err = n.writer.Write(n.appendLine(nil, append(n.buf, newlineChar)))
+		n.buf = nil
+	}
+	// return the first error
+	return errors.Join(err, n.writer.Close())
+}
+
+// appendLine appends a line prefixed with its timestamp and clock tag.
+//
+// Params:
+//   - out: the buffer to append to.
+//   - line: the line, newline included.
+//
+// Returns:
+//   - []byte: the extended buffer.
+func (n *TimestampNormalizer) appendLine(out, line []byte) []byte {
+	ts, tag := n.clock.Now(), ClockDaemon
+	// keep the time the child wrote
+	if own, ok := ParseChildTimestamp(string(line)); ok {
+		ts, tag = own, ClockChild
+	}
+	out = ts.UTC().AppendFormat(out, config.NormalizedTimestampFormat)
+	out = append(out, ' ')
+	out = append(out, tag...)
+	out = append(out, ' ')
+	// return line with prefix
+	return append(out, line...)
+}
+
+// ParseChildTimestamp returns the timestamp a line starts with. RFC 3339,
+// ISO 8601 with a space separator and Go log timestamps are recognized,
+// optionally in brackets; timestamps without zone are in local time.
+//
+// Params:
+//   - line: the output line.
+//
+// Returns:
+//   - time.Time: the timestamp of the line.
+//   - bool: false when the line does not start with a timestamp.
+func ParseChildTimestamp(line string) (time.Time, bool) {
+	fields := strings.SplitN(strings.TrimLeft(line, "["), " ", 3)
+	// try the one-field then the two-field layouts
+	for count, layouts := range childTimestampLayouts {
+		// the line is too short for the layouts
+		if len(fields) <= count {
+			break
+		}
+		value := strings.TrimRight(strings.Join(fields[:count+1], " "), "]\r\n")
+		// try each layout of the width
+		for _, layout := range layouts {
+			// check if the value is a timestamp
+			if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+				// return own timestamp
+				return t, true
+			}
+		}
+	}
+	// no leading timestamp
+	return time.Time{}, false
+}
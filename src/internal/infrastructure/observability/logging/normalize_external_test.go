@@ -0,0 +1,166 @@
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
+)
+
+// bufferCloser is an in-memory io.WriteCloser.
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestTimestampNormalizer_Write(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			name:   "lines without timestamp take the capture time",
+			writes: []string{"starting\nlistening\n"},
+			want: "2026-03-01T12:00:00.000000000Z clock=daemon starting\n" +
+				"2026-03-01T12:00:00.000000000Z clock=daemon listening\n",
+		},
+		{
+			name:   "own timestamps are kept in UTC",
+			writes: []string{"2026-03-01T13:59:58.5+02:00 ready\n"},
+			want:   "2026-03-01T11:59:58.500000000Z clock=child 2026-03-01T13:59:58.5+02:00 ready\n",
+		},
+		{
+			name:   "bracketed timestamps with a space",
+			writes: []string{"[2026-03-01 11:59:59Z] tick\n"},
+			want:   "2026-03-01T11:59:59.000000000Z clock=child [2026-03-01 11:59:59Z] tick\n",
+		},
+		{
+			name:   "lines split across writes",
+			writes: []string{"hel", "lo\nwor", "ld\n"},
+			want: "2026-03-01T12:00:00.000000000Z clock=daemon hello\n" +
+				"2026-03-01T12:00:00.000000000Z clock=daemon world\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bufferCloser{}
+			n := logging.NewTimestampNormalizer(out)
+			n.SetClock(shared.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)))
+			for _, w := range tt.writes {
+				written, err := n.Write([]byte(w))
+				require.NoError(t, err)
+				assert.Equal(t, len(w), written)
+			}
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestTimestampNormalizer_Close(t *testing.T) {
+	t.Parallel()
+
+	out := &bufferCloser{}
+	n := logging.NewTimestampNormalizer(out)
+	n.SetClock(shared.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)))
+	_, err := n.Write([]byte("partial"))
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	require.NoError(t, n.Close())
+	assert.Equal(t, "2026-03-01T12:00:00.000000000Z clock=daemon partial\n", out.String())
+	assert.True(t, out.closed)
+}
+
+func TestParseChildTimestamp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		line   string
+		want   time.Time
+		wantOK bool
+	}{
+		{name: "rfc3339", line: "2026-03-01T12:00:00Z msg", want: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "rfc3339 nano", line: "2026-03-01T12:00:00.123456789Z msg", want: time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC), wantOK: true},
+		{name: "space separator with offset", line: "2026-03-01 14:00:00+02:00 msg", want: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "local time", line: "2026-03-01 12:00:00,250 INFO msg", want: time.Date(2026, 3, 1, 12, 0, 0, 250000000, time.Local), wantOK: true},
+		{name: "go log", line: "2026/03/01 12:00:00 msg", want: time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local), wantOK: true},
+		{name: "timestamp only", line: "2026-03-01T12:00:00Z\n", want: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "date only", line: "2026-03-01 msg"},
+		{name: "plain text", line: "listening on :8080"},
+		{name: "empty", line: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := logging.ParseChildTimestamp(tt.line)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestNewCapture_normalizedTimestamps(t *testing.T) {
+	t.Parallel()
+
+	cfg := &mockConfig{logPath: t.TempDir()}
+	svcCfg := &mockServiceLogging{
+		stdout: config.LogStreamConfig{FilePath: "out.log", Format: "iso8601"},
+		stderr: config.LogStreamConfig{FilePath: "err.log", Format: "iso8601"},
+	}
+
+	capture, err := logging.NewCapture("api", cfg, svcCfg, logging.WithNormalizedTimestamps(true))
+	require.NoError(t, err)
+	_, err = capture.Stdout().Write([]byte("2026-03-01T12:00:00Z ready\n"))
+	require.NoError(t, err)
+	require.NoError(t, capture.Close())
+
+	content, err := os.ReadFile(filepath.Join(cfg.logPath, "api", "out.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01T12:00:00.000000000Z clock=child 2026-03-01T12:00:00Z ready\n", string(content))
+
+	ts, message, ok := logging.ParseTimestamp(string(content), config.NormalizedTimestampFormat)
+	require.True(t, ok)
+	assert.True(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Equal(ts))
+	assert.Equal(t, "clock=child 2026-03-01T12:00:00Z ready\n", message)
+}
+
+// failingCloser fails every write.
+type failingCloser struct{}
+
+func (failingCloser) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func (failingCloser) Close() error { return nil }
+
+func TestTimestampNormalizer_WriteError(t *testing.T) {
+	t.Parallel()
+
+	n := logging.NewTimestampNormalizer(failingCloser{})
+	_, err := n.Write([]byte("line\n"))
+	require.Error(t, err)
+	_, err = n.Write([]byte("partial"))
+	require.NoError(t, err)
+	assert.Error(t, n.Close())
+}
@@ -0,0 +1,296 @@
+// Package logging provides quota.go implementing per-service log disk quotas.
+// It caps the combined size of a service's log files, rotated files included.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// compressedSuffix is the extension of compressed rotated files.
+const compressedSuffix string = ".gz"
+
+// QuotaEvent reports rotated log files deleted to keep a service under its quota.
+type QuotaEvent struct {
+	// Service is the name of the service whose logs were pruned.
+	Service string
+	// Deleted lists the removed files, oldest first.
+	Deleted []string
+	// Freed is the number of bytes released by the deletions.
+	Freed int64
+	// Total is the combined log size after the deletions.
+	Total int64
+	// Limit is the configured quota in bytes.
+	Limit int64
+}
+
+// QuotaHandler receives quota events.
+// It runs on the writing goroutine and must not write to the service logs.
+type QuotaHandler func(event QuotaEvent)
+
+// Quota enforces a total size limit across the log files of one service.
+// Writers sharing a quota register their path; after each rotation the
+// oldest rotated files of every registered stream are deleted until the
+// total fits the limit. Active log files are never deleted.
+type Quota struct {
+	// mu serializes enforcement and protects paths.
+	mu sync.Mutex
+	// service is the name of the service owning the logs.
+	service string
+	// limit is the maximum combined size in bytes.
+	limit int64
+	// paths are the active log files of the registered writers.
+	paths []string
+	// handler is notified when deletions occur.
+	handler QuotaHandler
+}
+
+// quotaFile is a log file considered for enforcement.
+type quotaFile struct {
+	// path is the file path.
+	path string
+	// size is the file size in bytes.
+	size int64
+	// modTime is the last modification time.
+	modTime time.Time
+	// rotated indicates the file is a rotated backup that may be deleted.
+	rotated bool
+}
+
+// NewQuota creates a log quota for a service.
+//
+// Params:
+//   - service: the name of the service owning the logs.
+//   - limit: the maximum combined size in bytes.
+//   - handler: optional callback notified when deletions occur.
+//
+// Returns:
+//   - *Quota: the quota, with no registered writer.
+func NewQuota(service string, limit int64, handler QuotaHandler) *Quota {
+	// return quota without registered paths
+	return &Quota{
+		service: service,
+		limit:   limit,
+		handler: handler,
+	}
+}
+
+// NewServiceQuota creates the log quota configured for a service.
+//
+// Params:
+//   - service: the name of the service owning the logs.
+//   - svcCfg: the service logging configuration.
+//   - handler: optional callback notified when deletions occur.
+//
+// Returns:
+//   - *Quota: the quota, or nil when no quota is configured.
+//   - error: if the configured size cannot be parsed.
+func NewServiceQuota(service string, svcCfg *config.ServiceLogging, handler QuotaHandler) (*Quota, error) {
+	// quota disabled
+	if svcCfg.MaxTotalSize == "" {
+		// no quota
+		return nil, nil
+	}
+	limit, err := shared.ParseSize(svcCfg.MaxTotalSize)
+	// reject unparsable sizes
+	if err != nil {
+		// propagate parse error
+		return nil, fmt.Errorf("parsing log quota: %w", err)
+	}
+	// return configured quota
+	return NewQuota(service, limit, handler), nil
+}
+
+// register adds the active log file of a writer to the quota.
+//
+// Params:
+//   - path: the active log file path.
+func (q *Quota) register(path string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	// skip already registered paths
+	if slices.Contains(q.paths, path) {
+		// nothing to add
+		return
+	}
+	q.paths = append(q.paths, path)
+}
+
+// Enforce deletes the oldest rotated files until the logs fit the limit.
+// The handler is notified when at least one file was deleted.
+//
+// Returns:
+//   - error: the first deletion failure, if any.
+func (q *Quota) Enforce() error {
+	q.mu.Lock()
+	files := q.collect()
+	deleted, freed, total, err := q.prune(files)
+	q.mu.Unlock()
+
+	// notify when quota pressure forced deletions
+	if len(deleted) > 0 && q.handler != nil {
+		q.handler(QuotaEvent{
+			Service: q.service,
+			Deleted: deleted,
+			Freed:   freed,
+			Total:   total,
+			Limit:   q.limit,
+		})
+	}
+	// return deletion result
+	return err
+}
+
+// collect lists the active and rotated files of every registered writer.
+// Must be called with q.mu held.
+//
+// Returns:
+//   - []quotaFile: the files, sorted oldest first.
+func (q *Quota) collect() []quotaFile {
+	var files []quotaFile
+	// gather files of each registered stream
+	for _, path := range q.paths {
+		files = appendQuotaFile(files, path, false)
+		entries, _ := os.ReadDir(filepath.Dir(path))
+		// keep numbered backups only
+		for _, entry := range entries {
+			candidate := filepath.Join(filepath.Dir(path), entry.Name())
+			// skip unrelated files of the directory
+			if !isRotatedFile(path, candidate) {
+				continue
+			}
+			files = appendQuotaFile(files, candidate, true)
+		}
+	}
+	// oldest files are deleted first
+	slices.SortFunc(files, func(a, b quotaFile) int {
+		// order by modification time
+		return a.modTime.Compare(b.modTime)
+	})
+	// return sorted files
+	return files
+}
+
+// prune deletes rotated files oldest first until the total fits the limit.
+// Must be called with q.mu held.
+//
+// Params:
+//   - files: the files sorted oldest first.
+//
+// Returns:
+//   - []string: the deleted files.
+//   - int64: the bytes freed.
+//   - int64: the total size after deletion.
+//   - error: the first deletion failure, if any.
+func (q *Quota) prune(files []quotaFile) ([]string, int64, int64, error) {
+	var total int64
+	// sum current usage
+	for i := range files {
+		total += files[i].size
+	}
+
+	var deleted []string
+	var freed int64
+	var firstErr error
+	// delete oldest rotated files while over quota
+	for i := range files {
+		// stop once under the limit
+		if total <= q.limit {
+			break
+		}
+		// never delete an active log file
+		if !files[i].rotated {
+			continue
+		}
+		// record failure and keep going
+		if err := os.Remove(files[i].path); err != nil && !os.IsNotExist(err) {
+			// keep the first error
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		deleted = append(deleted, files[i].path)
+		freed += files[i].size
+		total -= files[i].size
+	}
+	// return pruning result
+	return deleted, freed, total, firstErr
+}
+
+// appendQuotaFile appends a file with its size and age when it exists.
+//
+// Params:
+//   - files: the collected files.
+//   - path: the file path.
+//   - rotated: whether the file is a rotated backup.
+//
+// Returns:
+//   - []quotaFile: the files with the new entry appended.
+func appendQuotaFile(files []quotaFile, path string, rotated bool) []quotaFile {
+	info, err := os.Stat(path)
+	// skip files removed meanwhile
+	if err != nil || !info.Mode().IsRegular() {
+		// nothing to account
+		return files
+	}
+	// append file entry
+	return append(files, quotaFile{
+		path:    path,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+		rotated: rotated,
+	})
+}
+
+// isRotatedFile reports whether a path is a numbered backup of a log file,
+// such as "app.log.3" or "app.log.3.gz".
+//
+// Params:
+//   - path: the active log file path.
+//   - candidate: the path to check.
+//
+// Returns:
+//   - bool: true for numbered backups.
+func isRotatedFile(path, candidate string) bool {
+	_, ok := rotationIndex(path, candidate)
+	// backups carry an index
+	return ok
+}
+
+// rotationIndex returns the number of a backup of a log file, 1 for the
+// most recent one.
+//
+// Params:
+//   - path: the active log file path.
+//   - candidate: the path to check.
+//
+// Returns:
+//   - int: the backup number.
+//   - bool: false when the candidate is not a numbered backup.
+func rotationIndex(path, candidate string) (int, bool) {
+	suffix, ok := strings.CutPrefix(candidate, path+".")
+	// other files of the directory
+	if !ok {
+		// not a backup of this log
+		return 0, false
+	}
+	suffix = strings.TrimSuffix(suffix, compressedSuffix)
+	index, err := strconv.Atoi(suffix)
+	// backups are numbered from one
+	if err != nil || index < firstBackupIndex {
+		// not a backup of this log
+		return 0, false
+	}
+	// return backup number
+	return index, true
+}
@@ -0,0 +1,140 @@
+package logging_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAged creates a file of the given size and age.
+func writeAged(t *testing.T, path string, size int, age time.Duration) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o600))
+	mtime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+}
+
+func TestQuota_Enforce(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := &mockConfig{logPath: dir}
+	svcCfg := &mockServiceLogging{
+		stdout: config.LogStreamConfig{FilePath: "out.log"},
+		stderr: config.LogStreamConfig{FilePath: "err.log"},
+	}
+	svcDir := filepath.Join(dir, "api")
+	require.NoError(t, os.MkdirAll(svcDir, 0o750))
+
+	// Leftovers from a previous run: 4 backups of 100 bytes each.
+	writeAged(t, filepath.Join(svcDir, "out.log.1"), 100, 2*time.Hour)
+	writeAged(t, filepath.Join(svcDir, "out.log.2"), 100, 4*time.Hour)
+	writeAged(t, filepath.Join(svcDir, "err.log.1"), 100, 1*time.Hour)
+	writeAged(t, filepath.Join(svcDir, "err.log.2.gz"), 100, 3*time.Hour)
+	writeAged(t, filepath.Join(svcDir, "other.log.1"), 500, 5*time.Hour)
+
+	var events []logging.QuotaEvent
+	quota := logging.NewQuota("api", 250, func(event logging.QuotaEvent) {
+		events = append(events, event)
+	})
+
+	capture, err := logging.NewCapture("api", cfg, svcCfg, logging.WithQuota(quota))
+	require.NoError(t, err)
+	defer func() { _ = capture.Close() }()
+
+	// The two oldest backups of the service are deleted, across streams.
+	require.Len(t, events, 1)
+	assert.Equal(t, "api", events[0].Service)
+	assert.Equal(t, []string{
+		filepath.Join(svcDir, "out.log.2"),
+		filepath.Join(svcDir, "err.log.2.gz"),
+	}, events[0].Deleted)
+	assert.Equal(t, int64(200), events[0].Freed)
+	assert.Equal(t, int64(200), events[0].Total)
+	assert.Equal(t, int64(250), events[0].Limit)
+	assert.FileExists(t, filepath.Join(svcDir, "out.log.1"))
+	assert.FileExists(t, filepath.Join(svcDir, "err.log.1"))
+	// Files of other logs are never touched.
+	assert.FileExists(t, filepath.Join(svcDir, "other.log.1"))
+
+	// Enforcing again under quota is a no-op.
+	require.NoError(t, quota.Enforce())
+	assert.Len(t, events, 1)
+}
+
+func TestQuota_EnforceOnRotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := &mockConfig{logPath: dir}
+	rotation := config.RotationConfig{MaxSize: "64B", MaxFiles: 10}
+	svcCfg := &mockServiceLogging{
+		stdout: config.LogStreamConfig{FilePath: "out.log", RotationConfig: rotation},
+		stderr: config.LogStreamConfig{FilePath: "err.log", RotationConfig: rotation},
+	}
+
+	var deleted int
+	quota := logging.NewQuota("api", 200, func(event logging.QuotaEvent) {
+		deleted += len(event.Deleted)
+	})
+	capture, err := logging.NewCapture("api", cfg, svcCfg, logging.WithQuota(quota))
+	require.NoError(t, err)
+	defer func() { _ = capture.Close() }()
+
+	line := []byte(strings.Repeat("x", 49) + "\n")
+	// Write enough to rotate both streams several times.
+	for range 10 {
+		_, err := capture.Stdout().Write(line)
+		require.NoError(t, err)
+		_, err = capture.Stderr().Write(line)
+		require.NoError(t, err)
+	}
+
+	// Backups stay within the quota; active files grow until their next rotation.
+	var total int64
+	entries, err := os.ReadDir(filepath.Join(dir, "api"))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		require.NoError(t, err)
+		total += info.Size()
+	}
+	assert.LessOrEqual(t, total, int64(200+2*64))
+	assert.Positive(t, deleted)
+}
+
+func TestNewServiceQuota(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		size    string
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "disabled", size: "", wantNil: true},
+		{name: "valid", size: "1GB"},
+		{name: "invalid", size: "lots", wantNil: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			quota, err := logging.NewServiceQuota("api", &config.ServiceLogging{MaxTotalSize: tt.size}, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantNil, quota == nil)
+		})
+	}
+}
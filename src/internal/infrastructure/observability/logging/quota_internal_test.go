@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRotatedFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		candidate string
+		expected  bool
+	}{
+		{name: "numbered backup", candidate: "/logs/app.log.3", expected: true},
+		{name: "compressed backup", candidate: "/logs/app.log.3.gz", expected: true},
+		{name: "active file", candidate: "/logs/app.log", expected: false},
+		{name: "zero index", candidate: "/logs/app.log.0", expected: false},
+		{name: "other suffix", candidate: "/logs/app.log.bak", expected: false},
+		{name: "other log", candidate: "/logs/app.log2.1", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, isRotatedFile("/logs/app.log", tt.candidate))
+		})
+	}
+}
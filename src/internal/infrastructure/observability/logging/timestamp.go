@@ -2,6 +2,7 @@
 package logging
 
 import (
+	"strings"
 	"time"
 )
 
@@ -21,6 +22,10 @@ const (
 	FormatCustom string = "custom"
 )
 
+// timestampReference is the time formatted to measure the timestamps of a
+// format; its two-digit day and hour keep padded layouts at full width.
+var timestampReference time.Time = time.Date(2006, time.December, 25, 15, 4, 5, 0, time.UTC)
+
 // FormatTimestamp formats a timestamp according to the specified format.
 // It supports predefined format constants as well as custom Go time format strings.
 //
@@ -31,32 +36,74 @@ const (
 // Returns:
 //   - string: the formatted timestamp string
 func FormatTimestamp(t time.Time, format string) string {
+	// Return the timestamp formatted with the layout of the format.
+	return t.Format(timestampLayout(format))
+}
+
+// ParseTimestamp splits a line written with FormatTimestamp and a space
+// separator into its timestamp and its message.
+//
+// Params:
+//   - line: the written line.
+//   - format: the format specifier the line was written with.
+//
+// Returns:
+//   - time.Time: the timestamp of the line.
+//   - string: the message following the timestamp.
+//   - bool: false when the line does not start with a timestamp.
+func ParseTimestamp(line, format string) (time.Time, string, bool) {
+	// The timestamp spans as many fields as the format produces.
+	fields := strings.Count(FormatTimestamp(timestampReference, format), " ") + 1
+	parts := strings.SplitN(line, " ", fields+1)
+	// Check if the line is too short to hold a timestamp and a message.
+	if len(parts) <= fields {
+		// Report a line without timestamp.
+		return time.Time{}, line, false
+	}
+	t, err := time.Parse(timestampLayout(format), strings.Join(parts[:fields], " "))
+	// Check if the prefix is not a timestamp.
+	if err != nil {
+		// Report a line without timestamp.
+		return time.Time{}, line, false
+	}
+	// Return the timestamp and the message.
+	return t, parts[fields], true
+}
+
+// timestampLayout returns the Go time layout of a format.
+//
+// Params:
+//   - format: the format specifier.
+//
+// Returns:
+//   - string: the Go time layout.
+func timestampLayout(format string) string {
 	// Switch on format to determine which timestamp representation to use.
 	switch format {
 	// Case FormatISO8601 or empty string handles the default ISO8601 format.
 	case FormatISO8601, "":
-		// Return the timestamp formatted as RFC3339 (ISO8601 compatible).
-		return t.Format(time.RFC3339)
+		// Return the RFC3339 layout (ISO8601 compatible).
+		return time.RFC3339
 	// Case FormatRFC3339 handles full precision RFC3339 with nanoseconds.
 	case FormatRFC3339:
-		// Return the timestamp formatted as RFC3339Nano for maximum precision.
-		return t.Format(time.RFC3339Nano)
+		// Return the RFC3339Nano layout for maximum precision.
+		return time.RFC3339Nano
 	// Case FormatUnix handles Unix epoch seconds representation.
 	case FormatUnix:
-		// Return the timestamp as Unix epoch seconds.
-		return t.Format("1136239445")
+		// Return the Unix epoch seconds layout.
+		return "1136239445"
 	// Case FormatUnixMilli handles Unix epoch with milliseconds precision.
 	case FormatUnixMilli:
-		// Return the timestamp as Unix epoch with milliseconds.
-		return t.Format("1136239445.000")
+		// Return the Unix epoch with milliseconds layout.
+		return "1136239445.000"
 	// Case FormatUnixNano handles Unix epoch with nanoseconds precision.
 	case FormatUnixNano:
-		// Return the timestamp as Unix epoch with nanoseconds.
-		return t.Format("1136239445.000000000")
+		// Return the Unix epoch with nanoseconds layout.
+		return "1136239445.000000000"
 	// Case default handles custom user-defined Go time formats.
 	default:
-		// Return the timestamp formatted using the custom format string.
-		return t.Format(format)
+		// Return the custom format string as layout.
+		return format
 	}
 }
 
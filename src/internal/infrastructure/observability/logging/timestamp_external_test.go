@@ -137,3 +137,75 @@ func TestParseTimestampFormat(t *testing.T) {
 		})
 	}
 }
+
+// TestParseTimestamp tests that lines written with a format are split back
+// into their timestamp and message.
+//
+// Params:
+//   - t: the testing context.
+func TestParseTimestamp(t *testing.T) {
+	at := time.Date(2024, 1, 15, 10, 30, 45, 123456789, time.UTC)
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// format is the timestamp format of the line.
+		format string
+		// line is the written line.
+		line string
+		// expected is the parsed timestamp.
+		expected time.Time
+		// message is the message following the timestamp.
+		message string
+		// ok is whether the line starts with a timestamp.
+		ok bool
+	}{
+		{
+			name:     "iso8601",
+			format:   logging.FormatISO8601,
+			line:     logging.FormatTimestamp(at, logging.FormatISO8601) + " server ready",
+			expected: at.Truncate(time.Second),
+			message:  "server ready",
+			ok:       true,
+		},
+		{
+			name:     "rfc3339",
+			format:   logging.FormatRFC3339,
+			line:     logging.FormatTimestamp(at, logging.FormatRFC3339) + " server ready",
+			expected: at,
+			message:  "server ready",
+			ok:       true,
+		},
+		{
+			name:     "custom_with_spaces",
+			format:   "2006-01-02 15:04:05",
+			line:     "2024-01-15 10:30:45 server ready",
+			expected: at.Truncate(time.Second),
+			message:  "server ready",
+			ok:       true,
+		},
+		{
+			name:    "continuation_line",
+			format:  logging.FormatISO8601,
+			line:    "\tat main.go:12",
+			message: "\tat main.go:12",
+		},
+		{
+			name:    "timestamp_only",
+			format:  logging.FormatISO8601,
+			line:    "2024-01-15T10:30:45Z",
+			message: "2024-01-15T10:30:45Z",
+		},
+	}
+
+	// Iterate through all parsing test cases.
+	for _, tt := range tests {
+		// Test case runs the specific parsing scenario.
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, message, ok := logging.ParseTimestamp(tt.line, tt.format)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.expected.Equal(parsed))
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
-	"time"
 
 	"github.com/kodflow/daemon/internal/domain/config"
 	"github.com/kodflow/daemon/internal/domain/shared"
@@ -53,11 +52,15 @@ type Writer struct {
 	compress bool
 	// size is the current size of the log file in bytes.
 	size int64
+	// quota is the optional service-wide log quota enforced after rotation.
+	quota *Quota
 
 	// timestampFormat is the format string for timestamps.
 	timestampFormat string
 	// addTimestamp indicates whether to prepend timestamps to log entries.
 	addTimestamp bool
+	// clock timestamps the log entries.
+	clock shared.Clock
 }
 
 // writerConfig defines the interface for log stream configuration.
@@ -122,6 +125,7 @@ func NewWriterFromConfig(path string, cfg writerConfig) (*Writer, error) {
 		size:            size,
 		timestampFormat: timestampFormat,
 		addTimestamp:    timestampFormat != "",
+		clock:           shared.DefaultClock,
 	}, nil
 }
 
@@ -169,6 +173,7 @@ func NewWriter(path string, cfg writerConfig) (*Writer, error) {
 		size:            size,
 		timestampFormat: timestampFormat,
 		addTimestamp:    timestampFormat != "",
+		clock:           shared.DefaultClock,
 	}, nil
 }
 
@@ -240,11 +245,15 @@ func (w *Writer) Write(p []byte) (n int, err error) {
 			// propagate rotation error to caller
 			return 0, fmt.Errorf("rotating log: %w", err)
 		}
+		// prune old files of the service (best-effort, never drops the entry)
+		if w.quota != nil {
+			_ = w.quota.Enforce()
+		}
 	}
 
 	// add timestamp prefix if configured
 	if w.addTimestamp {
-		ts := FormatTimestamp(time.Now(), w.timestampFormat)
+		ts := FormatTimestamp(w.clock.Now(), w.timestampFormat)
 		// write timestamp prefix
 		if _, err := w.writer.WriteString(ts + " "); err != nil {
 			// propagate timestamp write error
@@ -310,6 +319,18 @@ func (w *Writer) rotate() error {
 	return nil
 }
 
+// attachQuota makes the writer enforce a service-wide log quota after
+// each rotation.
+//
+// Params:
+//   - quota: the quota shared by the service writers.
+func (w *Writer) attachQuota(quota *Quota) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	quota.register(w.path)
+	w.quota = quota
+}
+
 // openNewFile creates and opens a new log file.
 //
 // Returns:
@@ -393,6 +414,17 @@ func (w *Writer) Sync() error {
 	return w.file.Sync()
 }
 
+// SetClock replaces the clock timestamping the entries, by default
+// shared.DefaultClock.
+//
+// Params:
+//   - clock: the clock.
+func (w *Writer) SetClock(clock shared.Clock) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.clock = clock
+}
+
 // Path returns the absolute path to the log file.
 //
 // Returns:
@@ -1,10 +1,13 @@
 package logging_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
 	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -156,6 +159,45 @@ func TestWriter_Write(t *testing.T) {
 	}
 }
 
+func TestWriter_SetClock(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "iso8601 timestamps from the clock",
+			format: "iso8601",
+			want:   "2026-03-01T12:00:00Z first\n2026-03-01T12:00:30Z second\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "test.log")
+			writer, err := logging.NewWriter(path, &mockWriterConfig{file: "test.log", timestamp: tt.format})
+			require.NoError(t, err)
+			defer writer.Close()
+			clock := shared.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+			writer.SetClock(clock)
+
+			_, err = writer.Write([]byte("first\n"))
+			require.NoError(t, err)
+			clock.Advance(30 * time.Second)
+			_, err = writer.Write([]byte("second\n"))
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+}
+
 func TestWriter_Close(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,227 @@
+// Package notify provides infrastructure adapters delivering notifications.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+const (
+	// reportDirPerm is the mode of the directory created for the report.
+	reportDirPerm os.FileMode = 0o755
+	// reportFilePerm is the mode of the report file.
+	reportFilePerm os.FileMode = 0o644
+	// reportTmpSuffix names the file the report is written to before replacing the previous one.
+	reportTmpSuffix string = ".tmp"
+)
+
+// ShutdownReporter writes the shutdown report to a JSON file and posts it
+// to a webhook, so the figures kept in memory outlive the host.
+type ShutdownReporter struct {
+	// host is the name of the host the daemon ran on.
+	host string
+	// path is the report file, empty to skip it.
+	path string
+	// url is the webhook, empty to skip it.
+	url string
+	// webhook posts the report.
+	webhook *WebhookSender
+}
+
+// shutdownPayload is the JSON document of the shutdown report.
+type shutdownPayload struct {
+	Host          string            `json:"host"`
+	StartedAt     time.Time         `json:"started_at"`
+	StoppingAt    time.Time         `json:"stopping_at"`
+	StoppedAt     time.Time         `json:"stopped_at"`
+	UptimeSeconds float64           `json:"uptime_seconds"`
+	Services      []shutdownService `json:"services"`
+}
+
+// shutdownService is the final state of a service in the JSON document.
+type shutdownService struct {
+	Name            string               `json:"name"`
+	Starts          int                  `json:"starts"`
+	Stops           int                  `json:"stops"`
+	Failures        int                  `json:"failures"`
+	Restarts        int                  `json:"restarts"`
+	UptimeSeconds   float64              `json:"uptime_seconds"`
+	DowntimeSeconds float64              `json:"downtime_seconds"`
+	Availability    metrics.Availability `json:"availability"`
+	SLO             *metrics.SLOStatus   `json:"slo,omitempty"`
+	Health          shutdownHealth       `json:"health"`
+}
+
+// shutdownHealth is the health of a service when the shutdown began.
+type shutdownHealth struct {
+	Status    string            `json:"status"`
+	Process   string            `json:"process"`
+	LastCheck *time.Time        `json:"last_check,omitempty"`
+	Subjects  []shutdownSubject `json:"subjects,omitempty"`
+}
+
+// shutdownSubject is a probed subject of a service.
+type shutdownSubject struct {
+	Name                string `json:"name"`
+	State               string `json:"state"`
+	ConsecutiveFailures int    `json:"consecutive_failures,omitempty"`
+	LastError           string `json:"last_error,omitempty"`
+}
+
+// NewShutdownReporter creates a reporter delivering to the configured file and webhook.
+//
+// Params:
+//   - cfg: the shutdown report configuration.
+//   - host: the name of the host, recorded in the report.
+//
+// Returns:
+//   - *ShutdownReporter: the reporter.
+func NewShutdownReporter(cfg *domainconfig.ShutdownReportConfig, host string) *ShutdownReporter {
+	// return reporter bounded by the delivery timeout
+	return &ShutdownReporter{
+		host:    host,
+		path:    cfg.Path,
+		url:     cfg.URL,
+		webhook: NewWebhookSender(cfg.EffectiveTimeout()),
+	}
+}
+
+// Deliver writes the report to the file and posts it to the webhook. A
+// failing destination does not prevent delivery to the other.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - report: the shutdown report.
+//
+// Returns:
+//   - error: the errors of the failing destinations.
+func (r *ShutdownReporter) Deliver(ctx context.Context, report *domainlifecycle.ShutdownReport) error {
+	body, err := json.MarshalIndent(r.encode(report), "", "  ")
+	// check marshal error
+	if err != nil {
+		// return wrapped error
+		return fmt.Errorf("encoding shutdown report: %w", err)
+	}
+	var errs []error
+	// write the file when configured
+	if r.path != "" {
+		errs = append(errs, r.write(body))
+	}
+	// post the report when configured
+	if r.url != "" {
+		// check post error
+		if err := r.webhook.post(ctx, r.url, body); err != nil {
+			errs = append(errs, fmt.Errorf("posting shutdown report: %w", err))
+		}
+	}
+	// return every failure
+	return errors.Join(errs...)
+}
+
+// write replaces the report file at once.
+//
+// Params:
+//   - body: the JSON document.
+//
+// Returns:
+//   - error: if the directory or file cannot be written.
+func (r *ShutdownReporter) write(body []byte) error {
+	// create the directory on a new host
+	if err := os.MkdirAll(filepath.Dir(r.path), reportDirPerm); err != nil {
+		// return error with context
+		return fmt.Errorf("creating %s: %w", filepath.Dir(r.path), err)
+	}
+	tmp := r.path + reportTmpSuffix
+	// write the new report aside
+	if err := os.WriteFile(tmp, append(body, '\n'), reportFilePerm); err != nil {
+		_ = os.Remove(tmp)
+		// return error with context
+		return fmt.Errorf("writing %s: %w", r.path, err)
+	}
+	// replace the previous report at once
+	if err := os.Rename(tmp, r.path); err != nil {
+		_ = os.Remove(tmp)
+		// return error with context
+		return fmt.Errorf("writing %s: %w", r.path, err)
+	}
+	// report written
+	return nil
+}
+
+// encode converts the report to its JSON document.
+//
+// Params:
+//   - report: the shutdown report.
+//
+// Returns:
+//   - shutdownPayload: the JSON document.
+func (r *ShutdownReporter) encode(report *domainlifecycle.ShutdownReport) shutdownPayload {
+	payload := shutdownPayload{
+		Host:          r.host,
+		StartedAt:     report.StartedAt,
+		StoppingAt:    report.StoppingAt,
+		StoppedAt:     report.StoppedAt,
+		UptimeSeconds: report.Uptime().Seconds(),
+		Services:      make([]shutdownService, 0, len(report.Services)),
+	}
+	// convert each service
+	for i := range report.Services {
+		payload.Services = append(payload.Services, encodeShutdownService(&report.Services[i]))
+	}
+	// return document
+	return payload
+}
+
+// encodeShutdownService converts the final state of a service.
+//
+// Params:
+//   - svc: the final state of the service.
+//
+// Returns:
+//   - shutdownService: the JSON service.
+func encodeShutdownService(svc *domainlifecycle.ServiceShutdown) shutdownService {
+	stats := &svc.Stats
+	health := shutdownHealth{
+		Status:  svc.Health.Status().String(),
+		Process: svc.Health.ProcessState.String(),
+	}
+	// services without probes were never checked
+	if !svc.Health.LastCheck.IsZero() {
+		health.LastCheck = &svc.Health.LastCheck
+	}
+	// convert each probed subject
+	for _, subject := range svc.Health.Subjects {
+		entry := shutdownSubject{
+			Name:                subject.Name,
+			State:               string(subject.State),
+			ConsecutiveFailures: subject.ConsecutiveFailures,
+		}
+		// keep the last probe failure
+		if subject.LastProbeResult != nil && subject.LastProbeResult.Error != nil {
+			entry.LastError = subject.LastProbeResult.Error.Error()
+		}
+		health.Subjects = append(health.Subjects, entry)
+	}
+	// return JSON service
+	return shutdownService{
+		Name:            stats.ServiceName,
+		Starts:          stats.StartCount,
+		Stops:           stats.StopCount,
+		Failures:        stats.FailCount,
+		Restarts:        stats.RestartCount,
+		UptimeSeconds:   stats.Uptime.Seconds(),
+		DowntimeSeconds: stats.Downtime.Seconds(),
+		Availability:    stats.Availability,
+		SLO:             stats.SLO,
+		Health:          health,
+	}
+}
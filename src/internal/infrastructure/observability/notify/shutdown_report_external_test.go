@@ -0,0 +1,121 @@
+// Package notify_test provides black-box tests for the notify package.
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/health"
+	domainlifecycle "github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/notify"
+)
+
+// newTestShutdownReport returns a report of one probed service.
+func newTestShutdownReport() *domainlifecycle.ShutdownReport {
+	started := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	return &domainlifecycle.ShutdownReport{
+		StartedAt:  started,
+		StoppingAt: started.Add(time.Hour),
+		StoppedAt:  started.Add(time.Hour + 2*time.Second),
+		Services: []domainlifecycle.ServiceShutdown{{
+			Stats: metrics.ServiceStats{
+				ServiceName:  "api",
+				StartCount:   3,
+				FailCount:    1,
+				RestartCount: 2,
+				Uptime:       50 * time.Minute,
+				Downtime:     10 * time.Minute,
+				Availability: metrics.Availability{Day: 99.5, Week: 99.9, Month: 99.9},
+			},
+			Health: health.AggregatedHealth{
+				ProcessState: process.StateRunning,
+				LastCheck:    started.Add(time.Hour),
+				Subjects: []health.SubjectStatus{{
+					Name:                "http",
+					State:               health.SubjectClosed,
+					ConsecutiveFailures: 2,
+					LastProbeResult:     &health.Result{Error: errors.New("connection refused")},
+				}},
+			},
+		}},
+	}
+}
+
+// TestShutdownReporter_Deliver tests writing the report file and posting it.
+func TestShutdownReporter_Deliver(t *testing.T) {
+	var posted map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "reports", "shutdown.json")
+
+	reporter := notify.NewShutdownReporter(&domainconfig.ShutdownReportConfig{Path: path, URL: server.URL}, "node-1")
+	require.NoError(t, reporter.Deliver(context.Background(), newTestShutdownReport()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var written map[string]any
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, posted, written)
+	assert.NoFileExists(t, path+".tmp")
+
+	assert.Equal(t, "node-1", written["host"])
+	assert.InDelta(t, 3602.0, written["uptime_seconds"], 0.001)
+	services, ok := written["services"].([]any)
+	require.True(t, ok)
+	require.Len(t, services, 1)
+	svc := services[0].(map[string]any)
+	assert.Equal(t, "api", svc["name"])
+	assert.InDelta(t, 3.0, svc["starts"], 0)
+	assert.InDelta(t, 1.0, svc["failures"], 0)
+	assert.InDelta(t, 3000.0, svc["uptime_seconds"], 0.001)
+	assert.Equal(t, map[string]any{"day": 99.5, "week": 99.9, "month": 99.9}, svc["availability"])
+	svcHealth := svc["health"].(map[string]any)
+	assert.Equal(t, "running", svcHealth["process"])
+	assert.Equal(t, []any{map[string]any{
+		"name":                 "http",
+		"state":                "closed",
+		"consecutive_failures": 2.0,
+		"last_error":           "connection refused",
+	}}, svcHealth["subjects"])
+}
+
+// TestShutdownReporter_Deliver_errors tests that a failing destination does
+// not prevent delivery to the other.
+func TestShutdownReporter_Deliver_errors(t *testing.T) {
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		posted = true
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	blocker := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o600))
+
+	reporter := notify.NewShutdownReporter(&domainconfig.ShutdownReportConfig{
+		Path: filepath.Join(blocker, "shutdown.json"),
+		URL:  server.URL,
+	}, "node-1")
+	err := reporter.Deliver(context.Background(), newTestShutdownReport())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, notify.ErrWebhookStatus)
+	assert.Contains(t, err.Error(), "creating")
+	assert.True(t, posted)
+}
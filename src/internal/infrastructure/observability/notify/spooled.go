@@ -0,0 +1,202 @@
+// Package notify provides infrastructure adapters delivering notifications.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kodflow/daemon/internal/application/notification"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/spool"
+)
+
+// defaultRetryInterval is the spool replay interval when none is given.
+const defaultRetryInterval time.Duration = 30 * time.Second
+
+// SpooledSender delivers webhook messages through a disk spool: messages
+// that cannot be delivered because the endpoint is unavailable are spooled
+// and replayed in order once it is reachable again.
+type SpooledSender struct {
+	// webhook posts the messages.
+	webhook *WebhookSender
+	// spool holds the undelivered messages.
+	spool *spool.Spool
+	// onError is called when a replay fails, may be nil.
+	onError notification.ErrorHandler
+	// mu serializes sends with replays so messages keep their order.
+	mu sync.Mutex
+	// stop ends the replay loop.
+	stop chan struct{}
+	// done is closed when the replay loop has ended.
+	done chan struct{}
+	// closeOnce guards Close.
+	closeOnce sync.Once
+}
+
+// spooledMessage is a message as stored in the spool.
+type spooledMessage struct {
+	// Channel is the destination channel name.
+	Channel string `json:"channel"`
+	// URL is the webhook URL.
+	URL string `json:"url"`
+	// Body is the encoded message.
+	Body json.RawMessage `json:"body"`
+}
+
+// NewSpooledSender creates a webhook sender backed by a spool and starts
+// replaying it at the given interval.
+//
+// Params:
+//   - webhook: the webhook sender.
+//   - sp: the spool.
+//   - retry: the replay interval; zero or negative uses 30s.
+//   - onError: called with the channel name when a replay fails, may be nil.
+//
+// Returns:
+//   - *SpooledSender: the sender; call Close to stop replaying.
+func NewSpooledSender(webhook *WebhookSender, sp *spool.Spool, retry time.Duration, onError notification.ErrorHandler) *SpooledSender {
+	// use default interval if not specified
+	if retry <= 0 {
+		retry = defaultRetryInterval
+	}
+	s := &SpooledSender{
+		webhook: webhook,
+		spool:   sp,
+		onError: onError,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.loop(retry)
+	// return started sender
+	return s
+}
+
+// Send posts the message, or spools it when the endpoint is unavailable
+// or older messages are still spooled.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - channel: the destination channel.
+//   - msg: the message to deliver.
+//
+// Returns:
+//   - error: if the message was rejected or could not be spooled.
+func (s *SpooledSender) Send(ctx context.Context, channel *domainconfig.NotificationChannel, msg *notification.Message) error {
+	body, err := encodeMessage(msg)
+	// check encoding error
+	if err != nil {
+		// propagate encoding error
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// queue behind spooled messages to keep the order
+	if s.spool.Len() == 0 {
+		err = s.webhook.post(ctx, channel.URL, body)
+		// delivered or rejected for good
+		if !errors.Is(err, ErrWebhookUnavailable) {
+			// return delivery outcome
+			return err
+		}
+	}
+	// return spooling outcome
+	return s.put(&spooledMessage{Channel: channel.Name, URL: channel.URL, Body: body})
+}
+
+// Close stops replaying. Spooled messages stay on disk for the next run.
+func (s *SpooledSender) Close() {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
+
+// put spools a message.
+// Must be called with s.mu held.
+//
+// Params:
+//   - m: the message.
+//
+// Returns:
+//   - error: if the message could not be spooled.
+func (s *SpooledSender) put(m *spooledMessage) error {
+	record, err := json.Marshal(m)
+	// check marshal error
+	if err != nil {
+		// return wrapped error
+		return fmt.Errorf("encoding spooled notification: %w", err)
+	}
+	// store the message
+	if err := s.spool.Put(record); err != nil {
+		// return wrapped error
+		return fmt.Errorf("spooling notification: %w", err)
+	}
+	// spooled
+	return nil
+}
+
+// loop replays the spool at each interval until stopped.
+//
+// Params:
+//   - retry: the replay interval.
+func (s *SpooledSender) loop(retry time.Duration) {
+	defer close(s.done)
+	ticker := time.NewTicker(retry)
+	defer ticker.Stop()
+
+	// replay at each tick
+	for {
+		select {
+		case <-s.stop:
+			// stopped
+			return
+		case <-ticker.C:
+			s.Replay(context.Background())
+		}
+	}
+}
+
+// Replay delivers the spooled messages in order, stopping at the first
+// endpoint still unavailable. Messages rejected by their endpoint are
+// dropped and reported.
+//
+// Params:
+//   - ctx: context for cancellation.
+func (s *SpooledSender) Replay(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var failed *spooledMessage
+	var failure error
+	_, _ = s.spool.Replay(func(record []byte) error {
+		var m spooledMessage
+		// drop corrupted records
+		if err := json.Unmarshal(record, &m); err != nil {
+			// skip record
+			return nil
+		}
+		err := s.webhook.post(ctx, m.URL, m.Body)
+		// keep it for the next replay while unavailable
+		if errors.Is(err, ErrWebhookUnavailable) {
+			failed, failure = &m, err
+			// stop replaying
+			return err
+		}
+		// report rejections, the record is dropped
+		if err != nil && s.onError != nil {
+			s.onError(m.Channel, err)
+		}
+		// record done
+		return nil
+	})
+	// report the endpoint still unavailable
+	if failed != nil && s.onError != nil {
+		s.onError(failed.Channel, failure)
+	}
+}
@@ -0,0 +1,142 @@
+// Package notify_test provides black-box tests for the notify package.
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/notification"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/notify"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/spool"
+)
+
+// flakyEndpoint is a webhook answering with a configurable status.
+type flakyEndpoint struct {
+	status   atomic.Int32
+	mu       sync.Mutex
+	received []string
+}
+
+// ServeHTTP records delivered messages.
+//
+// Params:
+//   - w: the response writer.
+//   - r: the request.
+func (e *flakyEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := int(e.status.Load())
+	// record accepted messages only
+	if status == http.StatusOK {
+		var body struct {
+			Events []struct {
+				Service string `json:"service"`
+			} `json:"events"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		e.mu.Lock()
+		e.received = append(e.received, body.Events[0].Service)
+		e.mu.Unlock()
+	}
+	w.WriteHeader(status)
+}
+
+// got returns the delivered services.
+//
+// Returns:
+//   - []string: the services, in delivery order.
+func (e *flakyEndpoint) got() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.received...)
+}
+
+// message builds a message for a service.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - *notification.Message: the message.
+func message(service string) *notification.Message {
+	return &notification.Message{Channel: "ops", Events: []notification.Entry{{Time: time.Now(), Service: service, Type: "failed"}}}
+}
+
+// TestSpooledSender_Send tests spooling during an outage and in-order replay.
+func TestSpooledSender_Send(t *testing.T) {
+	endpoint := &flakyEndpoint{}
+	endpoint.status.Store(http.StatusServiceUnavailable)
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	sp, err := spool.New(t.TempDir(), 0)
+	require.NoError(t, err)
+	sender := notify.NewSpooledSender(notify.NewWebhookSender(time.Second), sp, time.Hour, nil)
+	defer sender.Close()
+	channel := &domainconfig.NotificationChannel{Name: "ops", URL: server.URL}
+
+	// The outage spools the messages.
+	require.NoError(t, sender.Send(context.Background(), channel, message("api")))
+	require.NoError(t, sender.Send(context.Background(), channel, message("worker")))
+	assert.Equal(t, 2, sp.Len())
+
+	// Back online: new messages queue behind the spooled ones.
+	endpoint.status.Store(http.StatusOK)
+	require.NoError(t, sender.Send(context.Background(), channel, message("cron")))
+	assert.Empty(t, endpoint.got())
+
+	sender.Replay(context.Background())
+	assert.Equal(t, []string{"api", "worker", "cron"}, endpoint.got())
+	assert.Zero(t, sp.Len())
+
+	// Spool empty: messages are sent directly.
+	require.NoError(t, sender.Send(context.Background(), channel, message("web")))
+	assert.Equal(t, []string{"api", "worker", "cron", "web"}, endpoint.got())
+}
+
+// TestSpooledSender_Send_rejected tests that rejected messages are not spooled.
+func TestSpooledSender_Send_rejected(t *testing.T) {
+	endpoint := &flakyEndpoint{}
+	endpoint.status.Store(http.StatusBadRequest)
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	sp, err := spool.New(t.TempDir(), 0)
+	require.NoError(t, err)
+	sender := notify.NewSpooledSender(notify.NewWebhookSender(time.Second), sp, time.Hour, nil)
+	defer sender.Close()
+
+	err = sender.Send(context.Background(), &domainconfig.NotificationChannel{Name: "ops", URL: server.URL}, message("api"))
+	assert.ErrorIs(t, err, notify.ErrWebhookStatus)
+	assert.Zero(t, sp.Len())
+}
+
+// TestSpooledSender_Replay_stillUnavailable tests that replay keeps messages
+// and reports the channel while the endpoint is down.
+func TestSpooledSender_Replay_stillUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	sp, err := spool.New(t.TempDir(), 0)
+	require.NoError(t, err)
+	var reported []string
+	sender := notify.NewSpooledSender(notify.NewWebhookSender(time.Second), sp, time.Hour, func(channel string, err error) {
+		assert.ErrorIs(t, err, notify.ErrWebhookUnavailable)
+		reported = append(reported, channel)
+	})
+	defer sender.Close()
+
+	require.NoError(t, sender.Send(context.Background(), &domainconfig.NotificationChannel{Name: "ops", URL: url}, message("api")))
+	sender.Replay(context.Background())
+	assert.Equal(t, 1, sp.Len())
+	assert.Equal(t, []string{"ops"}, reported)
+}
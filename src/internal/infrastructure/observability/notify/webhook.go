@@ -0,0 +1,210 @@
+// Package notify provides infrastructure adapters delivering notifications.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kodflow/daemon/internal/application/notification"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// defaultWebhookTimeout is the request timeout when none is given.
+const defaultWebhookTimeout time.Duration = 10 * time.Second
+
+// maxDrainedBody is the response body size read before closing.
+const maxDrainedBody int64 = 4096
+
+// Webhook delivery errors.
+var (
+	// ErrWebhookStatus indicates the webhook answered with a non-2xx status.
+	ErrWebhookStatus error = errors.New("webhook returned unexpected status")
+	// ErrWebhookUnavailable indicates a transient failure worth retrying:
+	// the endpoint is unreachable, overloaded or failing (5xx, 429).
+	ErrWebhookUnavailable error = errors.New("webhook unavailable")
+)
+
+// WebhookSender posts notification messages as JSON to the channel URL
+// and pings heartbeat endpoints.
+type WebhookSender struct {
+	// client is the HTTP client used for requests.
+	client *http.Client
+	// timeout is the maximum duration of a request.
+	timeout time.Duration
+}
+
+// webhookPayload is the JSON body posted to channels.
+type webhookPayload struct {
+	Channel    string         `json:"channel"`
+	Escalation bool           `json:"escalation"`
+	Suppressed int            `json:"suppressed"`
+	Events     []webhookEvent `json:"events"`
+}
+
+// webhookEvent is an event in the JSON body.
+type webhookEvent struct {
+	Time    time.Time         `json:"time"`
+	Service string            `json:"service,omitempty"`
+	Target  string            `json:"target,omitempty"`
+	Type    string            `json:"type"`
+	Error   string            `json:"error,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// NewWebhookSender creates a new webhook sender.
+//
+// Params:
+//   - timeout: the maximum duration of a request.
+//
+// Returns:
+//   - *WebhookSender: the sender.
+func NewWebhookSender(timeout time.Duration) *WebhookSender {
+	// use default timeout if not specified
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	// return sender with its own client
+	return &WebhookSender{
+		client:  &http.Client{},
+		timeout: timeout,
+	}
+}
+
+// Send posts the message to the channel URL.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - channel: the destination channel.
+//   - msg: the message to deliver.
+//
+// Returns:
+//   - error: if the request failed or the status is not 2xx.
+func (s *WebhookSender) Send(ctx context.Context, channel *domainconfig.NotificationChannel, msg *notification.Message) error {
+	body, err := encodeMessage(msg)
+	// check encoding error
+	if err != nil {
+		// propagate encoding error
+		return err
+	}
+	// return the request outcome
+	return s.post(ctx, channel.URL, body)
+}
+
+// post sends an encoded message to a webhook.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - endpoint: the webhook URL.
+//   - body: the JSON body.
+//
+// Returns:
+//   - error: if the request failed or the status is not 2xx.
+func (s *WebhookSender) post(ctx context.Context, endpoint string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	// check request creation error
+	if err != nil {
+		// return wrapped error
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// return the request outcome
+	return s.do(req)
+}
+
+// Ping sends a GET to a heartbeat endpoint.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - endpoint: the endpoint URL.
+//
+// Returns:
+//   - error: if the request failed or the status is not 2xx.
+func (s *WebhookSender) Ping(ctx context.Context, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	// check request creation error
+	if err != nil {
+		// return wrapped error
+		return fmt.Errorf("creating heartbeat request: %w", err)
+	}
+	// return the request outcome
+	return s.do(req)
+}
+
+// do sends a request and checks its status.
+//
+// Params:
+//   - req: the request.
+//
+// Returns:
+//   - error: if the request failed or the status is not 2xx.
+func (s *WebhookSender) do(req *http.Request) error {
+	resp, err := s.client.Do(req)
+	// check transport error
+	if err != nil {
+		var urlErr *url.Error
+		// drop the URL, its path may hold a secret token
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		// return error naming the host only
+		return fmt.Errorf("%w: %s %s: %w", ErrWebhookUnavailable, req.Method, req.URL.Host, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBody))
+
+	// server failures and throttling are transient
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		// return retryable status error
+		return fmt.Errorf("%w: %w: %d", ErrWebhookUnavailable, ErrWebhookStatus, resp.StatusCode)
+	}
+	// other non-2xx statuses are rejections
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		// return status error
+		return fmt.Errorf("%w: %d", ErrWebhookStatus, resp.StatusCode)
+	}
+	// delivered
+	return nil
+}
+
+// encodeMessage encodes a message as the webhook JSON body.
+//
+// Params:
+//   - msg: the message.
+//
+// Returns:
+//   - []byte: the JSON body.
+//   - error: if encoding failed.
+func encodeMessage(msg *notification.Message) ([]byte, error) {
+	payload := webhookPayload{
+		Channel:    msg.Channel,
+		Escalation: msg.Escalation,
+		Suppressed: msg.Suppressed,
+		Events:     make([]webhookEvent, 0, len(msg.Events)),
+	}
+	// convert each event
+	for _, e := range msg.Events {
+		payload.Events = append(payload.Events, webhookEvent{Time: e.Time, Service: e.Service, Target: e.Target, Type: e.Type, Error: e.Error, Labels: e.Labels})
+	}
+	body, err := json.Marshal(payload)
+	// check marshal error
+	if err != nil {
+		// return wrapped error
+		return nil, fmt.Errorf("encoding notification: %w", err)
+	}
+	// return encoded body
+	return body, nil
+}
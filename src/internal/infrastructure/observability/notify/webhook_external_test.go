@@ -0,0 +1,130 @@
+// Package notify_test provides black-box tests for the notify package.
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/application/notification"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/notify"
+)
+
+// TestWebhookSender_Send tests posting messages to a webhook.
+func TestWebhookSender_Send(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "accepted", status: http.StatusAccepted},
+		{name: "server_error", status: http.StatusInternalServerError, wantErr: notify.ErrWebhookUnavailable},
+		{name: "bad_request", status: http.StatusBadRequest, wantErr: notify.ErrWebhookStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			sender := notify.NewWebhookSender(time.Second)
+			err := sender.Send(context.Background(), &domainconfig.NotificationChannel{Name: "ops", URL: server.URL}, &notification.Message{
+				Channel:    "ops",
+				Escalation: true,
+				Suppressed: 3,
+				Events: []notification.Entry{
+					{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Service: "api", Type: "failed", Error: "exit code 1", Labels: map[string]string{"team": "payments"}},
+				},
+			})
+
+			// check the status handling
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, "ops", got["channel"])
+			assert.Equal(t, true, got["escalation"])
+			assert.InDelta(t, 3, got["suppressed"], 0)
+			events, ok := got["events"].([]any)
+			require.True(t, ok)
+			require.Len(t, events, 1)
+			assert.Equal(t, map[string]any{
+				"time":    "2024-01-02T03:04:05Z",
+				"service": "api",
+				"type":    "failed",
+				"error":   "exit code 1",
+				"labels":  map[string]any{"team": "payments"},
+			}, events[0])
+		})
+	}
+}
+
+// TestWebhookSender_Send_unreachable tests the transport error.
+func TestWebhookSender_Send_unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	sender := notify.NewWebhookSender(0)
+	err := sender.Send(context.Background(), &domainconfig.NotificationChannel{Name: "ops", URL: url}, &notification.Message{Channel: "ops"})
+	assert.Error(t, err)
+}
+
+// TestWebhookSender_Ping tests pinging a heartbeat endpoint.
+func TestWebhookSender_Ping(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "not_found", status: http.StatusNotFound, wantErr: notify.ErrWebhookStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method, path = r.Method, r.URL.Path
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := notify.NewWebhookSender(time.Second).Ping(context.Background(), server.URL+"/uuid")
+
+			// check the status handling
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, http.MethodGet, method)
+			assert.Equal(t, "/uuid", path)
+		})
+	}
+}
+
+// TestWebhookSender_Ping_hidesPath tests that errors keep the secret path out.
+func TestWebhookSender_Ping_hidesPath(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	endpoint := server.URL + "/secret-uuid"
+	server.Close()
+
+	err := notify.NewWebhookSender(time.Second).Ping(context.Background(), endpoint)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-uuid")
+}
@@ -0,0 +1,424 @@
+// Package sops provides the decryption of configurations encrypted with sops and age.
+package sops
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// age v1 format constants, see https://age-encryption.org/v1.
+const (
+	// ageVersionLine is the first line of an age header.
+	ageVersionLine string = "age-encryption.org/v1"
+	// ageX25519Label is the HKDF info of X25519 recipient stanzas.
+	ageX25519Label string = "age-encryption.org/v1/X25519"
+	// ageX25519Type is the stanza type of X25519 recipients.
+	ageX25519Type string = "X25519"
+	// ageStanzaPrefix starts each recipient stanza.
+	ageStanzaPrefix string = "-> "
+	// ageMACPrefix starts the header MAC line.
+	ageMACPrefix string = "---"
+	// ageArmorHeader starts an ASCII-armored age file.
+	ageArmorHeader string = "-----BEGIN AGE ENCRYPTED FILE-----"
+	// ageArmorFooter ends an ASCII-armored age file.
+	ageArmorFooter string = "-----END AGE ENCRYPTED FILE-----"
+	// ageSecretKeyHRP is the bech32 prefix of X25519 identities.
+	ageSecretKeyHRP string = "age-secret-key-"
+	// ageFileKeySize is the size of the key wrapped in each stanza.
+	ageFileKeySize int = 16
+	// ageStanzaColumns is the length of the full lines of a stanza body.
+	ageStanzaColumns int = 64
+	// ageNonceSize is the size of the payload nonce.
+	ageNonceSize int = 16
+	// ageChunkSize is the plaintext size of the payload chunks.
+	ageChunkSize int = 64 * 1024
+)
+
+// Age errors.
+var (
+	// ErrNoIdentity is returned when no identity unwraps the file key.
+	ErrNoIdentity error = errors.New("no age identity matches the recipients")
+	// ErrMalformedAge is returned for files that are not valid age v1.
+	ErrMalformedAge error = errors.New("malformed age file")
+	// ErrInvalidIdentity is returned for keys that are not AGE-SECRET-KEY-1 identities.
+	ErrInvalidIdentity error = errors.New("invalid age identity")
+)
+
+// Identity is an age X25519 identity, the private key of a recipient.
+type Identity struct {
+	// key is the X25519 private key.
+	key *ecdh.PrivateKey
+}
+
+// ParseIdentities parses the identities of an age keys file: one
+// AGE-SECRET-KEY-1 key per line, empty lines and # comments ignored.
+//
+// Params:
+//   - data: the keys file content.
+//
+// Returns:
+//   - []*Identity: the identities, in file order.
+//   - error: ErrInvalidIdentity for a malformed key, with its line.
+func ParseIdentities(data []byte) ([]*Identity, error) {
+	var identities []*Identity
+	// parse each key line
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		// skip blank lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		identity, err := parseIdentity(line)
+		// report the faulty line without the key
+		if err != nil {
+			// return parse error
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		identities = append(identities, identity)
+	}
+	// return parsed identities
+	return identities, nil
+}
+
+// parseIdentity parses one AGE-SECRET-KEY-1 identity.
+//
+// Params:
+//   - s: the bech32 encoded key.
+//
+// Returns:
+//   - *Identity: the identity.
+//   - error: ErrInvalidIdentity when malformed.
+func parseIdentity(s string) (*Identity, error) {
+	hrp, data, err := bech32Decode(s)
+	// refuse keys that are not bech32
+	if err != nil {
+		// return decoding error
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIdentity, err)
+	}
+	// refuse other key types, such as plugin identities
+	if hrp != ageSecretKeyHRP {
+		// return unsupported type
+		return nil, fmt.Errorf("%w: unsupported type %q", ErrInvalidIdentity, hrp)
+	}
+	key, err := ecdh.X25519().NewPrivateKey(data)
+	// refuse keys of the wrong size
+	if err != nil {
+		// return key error
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIdentity, err)
+	}
+	// return identity
+	return &Identity{key: key}, nil
+}
+
+// unwrap decrypts the file key of an X25519 stanza.
+//
+// Params:
+//   - share: the ephemeral public key of the stanza.
+//   - body: the wrapped file key.
+//
+// Returns:
+//   - []byte: the file key.
+//   - error: an error when the stanza is not for this identity.
+func (id *Identity) unwrap(share, body []byte) ([]byte, error) {
+	ephemeral, err := ecdh.X25519().NewPublicKey(share)
+	// refuse shares of the wrong size
+	if err != nil {
+		// return share error
+		return nil, fmt.Errorf("%w: %w", ErrMalformedAge, err)
+	}
+	shared, err := id.key.ECDH(ephemeral)
+	// refuse low order points
+	if err != nil {
+		// return exchange error
+		return nil, fmt.Errorf("%w: %w", ErrMalformedAge, err)
+	}
+	salt := append(bytes.Clone(share), id.key.PublicKey().Bytes()...)
+	wrapKey, err := hkdf.Key(sha256.New, shared, salt, ageX25519Label, chacha20poly1305.KeySize)
+	// derivation cannot fail for this size
+	if err != nil {
+		// return derivation error
+		return nil, err
+	}
+	aead, err := chacha20poly1305.New(wrapKey)
+	// key size is fixed
+	if err != nil {
+		// return cipher error
+		return nil, err
+	}
+	// a stanza for another recipient fails authentication
+	return aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), body, nil)
+}
+
+// stanza is a recipient stanza of an age header.
+type stanza struct {
+	// args holds the type and arguments of the stanza.
+	args []string
+	// body is the decoded stanza body.
+	body []byte
+}
+
+// decryptArmored decrypts an ASCII-armored age file.
+//
+// Params:
+//   - armored: the PEM-like armored file.
+//   - identities: the identities to try.
+//
+// Returns:
+//   - []byte: the plaintext.
+//   - error: ErrNoIdentity, or ErrMalformedAge for invalid files.
+func decryptArmored(armored string, identities []*Identity) ([]byte, error) {
+	armored = strings.TrimSpace(armored)
+	// only armored files are stored in sops metadata
+	if !strings.HasPrefix(armored, ageArmorHeader) || !strings.HasSuffix(armored, ageArmorFooter) {
+		// return format error
+		return nil, fmt.Errorf("%w: missing armor", ErrMalformedAge)
+	}
+	encoded := strings.Join(strings.Fields(armored[len(ageArmorHeader):len(armored)-len(ageArmorFooter)]), "")
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	// refuse corrupted armor
+	if err != nil {
+		// return decoding error
+		return nil, fmt.Errorf("%w: %w", ErrMalformedAge, err)
+	}
+	// decrypt the binary file
+	return decrypt(data, identities)
+}
+
+// decrypt decrypts a binary age file.
+//
+// Params:
+//   - data: the age file.
+//   - identities: the identities to try.
+//
+// Returns:
+//   - []byte: the plaintext.
+//   - error: ErrNoIdentity, or ErrMalformedAge for invalid files.
+func decrypt(data []byte, identities []*Identity) ([]byte, error) {
+	stanzas, header, mac, payload, err := parseHeader(data)
+	// refuse invalid headers
+	if err != nil {
+		// return header error
+		return nil, err
+	}
+	fileKey, err := unwrapFileKey(stanzas, identities)
+	// no identity is a recipient
+	if err != nil {
+		// return unwrap error
+		return nil, err
+	}
+	hmacKey, err := hkdf.Key(sha256.New, fileKey, nil, "header", sha256.Size)
+	// derivation cannot fail for this size
+	if err != nil {
+		// return derivation error
+		return nil, err
+	}
+	h := hmac.New(sha256.New, hmacKey)
+	h.Write(header)
+	// refuse tampered headers
+	if !hmac.Equal(h.Sum(nil), mac) {
+		// return MAC error
+		return nil, fmt.Errorf("%w: header MAC mismatch", ErrMalformedAge)
+	}
+	// decrypt the payload stream
+	return decryptPayload(fileKey, payload)
+}
+
+// unwrapFileKey returns the file key of the first stanza an identity unwraps.
+//
+// Params:
+//   - stanzas: the recipient stanzas.
+//   - identities: the identities to try.
+//
+// Returns:
+//   - []byte: the file key.
+//   - error: ErrNoIdentity when no identity is a recipient.
+func unwrapFileKey(stanzas []stanza, identities []*Identity) ([]byte, error) {
+	// try each X25519 stanza with each identity
+	for _, s := range stanzas {
+		// other recipient types need other identities
+		if len(s.args) != 2 || s.args[0] != ageX25519Type {
+			continue
+		}
+		share, err := base64.RawStdEncoding.Strict().DecodeString(s.args[1])
+		// refuse corrupted shares
+		if err != nil {
+			// return share error
+			return nil, fmt.Errorf("%w: %w", ErrMalformedAge, err)
+		}
+		// the first identity that authenticates wins
+		for _, id := range identities {
+			// keep a key of the expected size
+			if key, err := id.unwrap(share, s.body); err == nil && len(key) == ageFileKeySize {
+				// return file key
+				return key, nil
+			}
+		}
+	}
+	// no identity is a recipient
+	return nil, ErrNoIdentity
+}
+
+// parseHeader splits an age file into its header and payload.
+//
+// Params:
+//   - data: the age file.
+//
+// Returns:
+//   - []stanza: the recipient stanzas.
+//   - []byte: the header covered by the MAC, up to the --- marker.
+//   - []byte: the header MAC.
+//   - []byte: the payload.
+//   - error: ErrMalformedAge when invalid.
+func parseHeader(data []byte) ([]stanza, []byte, []byte, []byte, error) {
+	line, rest, ok := bytes.Cut(data, []byte("\n"))
+	// the version line comes first
+	if !ok || string(line) != ageVersionLine {
+		// return version error
+		return nil, nil, nil, nil, fmt.Errorf("%w: unknown version", ErrMalformedAge)
+	}
+	var stanzas []stanza
+	// read stanzas up to the MAC line
+	for {
+		start := len(data) - len(rest)
+		line, rest, ok = bytes.Cut(rest, []byte("\n"))
+		// the header ends with the MAC line
+		if !ok {
+			// return truncated header
+			return nil, nil, nil, nil, fmt.Errorf("%w: truncated header", ErrMalformedAge)
+		}
+		// the MAC line ends the header
+		if mac, found := bytes.CutPrefix(line, []byte(ageMACPrefix+" ")); found {
+			sum, err := base64.RawStdEncoding.Strict().DecodeString(string(mac))
+			// refuse corrupted MACs
+			if err != nil {
+				// return MAC error
+				return nil, nil, nil, nil, fmt.Errorf("%w: %w", ErrMalformedAge, err)
+			}
+			// return header parts
+			return stanzas, data[:start+len(ageMACPrefix)], sum, rest, nil
+		}
+		args, found := bytes.CutPrefix(line, []byte(ageStanzaPrefix))
+		// anything else must be a stanza
+		if !found {
+			// return stanza error
+			return nil, nil, nil, nil, fmt.Errorf("%w: unexpected header line", ErrMalformedAge)
+		}
+		var body []byte
+		var err error
+		body, rest, err = readStanzaBody(rest)
+		// refuse corrupted bodies
+		if err != nil {
+			// return body error
+			return nil, nil, nil, nil, err
+		}
+		stanzas = append(stanzas, stanza{args: strings.Fields(string(args)), body: body})
+	}
+}
+
+// readStanzaBody reads the base64 body of a stanza: full lines of 64
+// columns ended by a shorter, possibly empty, line.
+//
+// Params:
+//   - data: the header from the body on.
+//
+// Returns:
+//   - []byte: the decoded body.
+//   - []byte: the header after the body.
+//   - error: ErrMalformedAge when invalid.
+func readStanzaBody(data []byte) ([]byte, []byte, error) {
+	var encoded []byte
+	// read lines up to the short one
+	for {
+		line, rest, ok := bytes.Cut(data, []byte("\n"))
+		// the body ends with a line
+		if !ok {
+			// return truncated body
+			return nil, nil, fmt.Errorf("%w: truncated stanza", ErrMalformedAge)
+		}
+		// refuse overlong lines
+		if len(line) > ageStanzaColumns {
+			// return line error
+			return nil, nil, fmt.Errorf("%w: stanza line too long", ErrMalformedAge)
+		}
+		encoded = append(encoded, line...)
+		data = rest
+		// a short line ends the body
+		if len(line) < ageStanzaColumns {
+			break
+		}
+	}
+	body, err := base64.RawStdEncoding.Strict().DecodeString(string(encoded))
+	// refuse corrupted bodies
+	if err != nil {
+		// return decoding error
+		return nil, nil, fmt.Errorf("%w: %w", ErrMalformedAge, err)
+	}
+	// return body and remaining header
+	return body, data, nil
+}
+
+// decryptPayload decrypts the STREAM payload of an age file: a nonce,
+// then 64 KiB chunks sealed with a counter nonce, the last one flagged.
+//
+// Params:
+//   - fileKey: the unwrapped file key.
+//   - payload: the nonce and chunks.
+//
+// Returns:
+//   - []byte: the plaintext.
+//   - error: ErrMalformedAge when truncated or tampered.
+func decryptPayload(fileKey, payload []byte) ([]byte, error) {
+	// the payload starts with its nonce
+	if len(payload) < ageNonceSize {
+		// return truncated payload
+		return nil, fmt.Errorf("%w: truncated payload", ErrMalformedAge)
+	}
+	key, err := hkdf.Key(sha256.New, fileKey, payload[:ageNonceSize], "payload", chacha20poly1305.KeySize)
+	// derivation cannot fail for this size
+	if err != nil {
+		// return derivation error
+		return nil, err
+	}
+	aead, err := chacha20poly1305.New(key)
+	// key size is fixed
+	if err != nil {
+		// return cipher error
+		return nil, err
+	}
+	ciphertext := payload[ageNonceSize:]
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	var plaintext []byte
+	// open each chunk in order
+	for counter := uint64(0); ; counter++ {
+		chunk := ciphertext[:min(len(ciphertext), ageChunkSize+aead.Overhead())]
+		ciphertext = ciphertext[len(chunk):]
+		last := len(ciphertext) == 0
+		binary.BigEndian.PutUint64(nonce[3:11], counter)
+		// the last chunk is flagged so truncation is detected
+		if last {
+			nonce[11] = 1
+		}
+		opened, err := aead.Open(nil, nonce, chunk, nil)
+		// refuse tampered or truncated chunks
+		if err != nil {
+			// return chunk error
+			return nil, fmt.Errorf("%w: payload chunk %d: %w", ErrMalformedAge, counter, err)
+		}
+		plaintext = append(plaintext, opened...)
+		// stop after the last chunk
+		if last {
+			// return plaintext
+			return plaintext, nil
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package sops_test provides black-box tests for the age identities.
+package sops_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
+)
+
+// TestParseIdentities tests parsing of age keys files.
+//
+// Params:
+//   - t: the testing context.
+func TestParseIdentities(t *testing.T) {
+	const key string = "AGE-SECRET-KEY-10S4WLE89LE9PJQNVNCSZA5LCLSUHGRFN44GEUZDXFR4PSMR3AV3SWWSJSG"
+
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{name: "keygen_output", data: "# created: 2026-10-01T10:00:00Z\n# public key: age1...\n" + key + "\n", want: 1},
+		{name: "blank_lines_and_two_keys", data: "\n" + key + "\n\n  " + key + "  \n", want: 2},
+		{name: "empty", data: "", want: 0},
+		{name: "bad_checksum", data: key[:len(key)-1] + "Q", wantErr: true},
+		{name: "mixed_case", data: "AGE-SECRET-KEY-1" + "0s4wle89le9pjqnvncsza5lclsuhgrfn44geuzdxfr4psmr3av3swwsjsg", wantErr: true},
+		{name: "public_key", data: "age1fpsmle8qwkdkglpnk58q8q2qpjc8evghah8ws5mqqkaluh7qrqrs35tms7", wantErr: true},
+		{name: "plugin_identity", data: "AGE-PLUGIN-YUBIKEY-1QQQQQQ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identities, err := sops.ParseIdentities([]byte(tt.data))
+
+			// verify failures
+			if tt.wantErr {
+				assert.ErrorIs(t, err, sops.ErrInvalidIdentity)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, identities, tt.want)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Package sops provides the decryption of configurations encrypted with sops and age.
+package sops
+
+import (
+	"errors"
+	"strings"
+)
+
+// bech32 constants, see BIP 173.
+const (
+	// bech32Charset maps 5-bit values to characters.
+	bech32Charset string = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	// bech32ChecksumSize is the number of checksum characters.
+	bech32ChecksumSize int = 6
+)
+
+// bech32Generator holds the generator coefficients of the checksum.
+var bech32Generator [5]uint32 = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// Bech32 errors.
+var (
+	// errBech32Format is returned for strings without separator, mixed case or unknown characters.
+	errBech32Format error = errors.New("malformed bech32 string")
+	// errBech32Checksum is returned when the checksum does not match.
+	errBech32Checksum error = errors.New("bech32 checksum mismatch")
+)
+
+// bech32Decode decodes a bech32 string. Unlike BIP 173 it accepts strings
+// longer than 90 characters, as age does.
+//
+// Params:
+//   - s: the encoded string.
+//
+// Returns:
+//   - string: the lower-case human-readable part.
+//   - []byte: the decoded data.
+//   - error: errBech32Format or errBech32Checksum.
+func bech32Decode(s string) (string, []byte, error) {
+	lower := strings.ToLower(s)
+	// refuse mixed case
+	if lower != s && strings.ToUpper(s) != s {
+		// return format error
+		return "", nil, errBech32Format
+	}
+	sep := strings.LastIndexByte(lower, '1')
+	// a prefix and a checksum are required
+	if sep < 1 || sep+1+bech32ChecksumSize > len(lower) {
+		// return format error
+		return "", nil, errBech32Format
+	}
+	hrp := lower[:sep]
+	values := make([]byte, 0, len(lower)-sep-1)
+	// map each character to its value
+	for _, c := range lower[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		// refuse characters outside the charset
+		if v < 0 {
+			// return format error
+			return "", nil, errBech32Format
+		}
+		values = append(values, byte(v))
+	}
+	// verify the checksum over the prefix and data
+	if bech32Polymod(append(bech32ExpandHRP(hrp), values...)) != 1 {
+		// return checksum error
+		return "", nil, errBech32Checksum
+	}
+	data, err := convertBits(values[:len(values)-bech32ChecksumSize])
+	// refuse non-zero padding
+	if err != nil {
+		// return padding error
+		return "", nil, err
+	}
+	// return decoded parts
+	return hrp, data, nil
+}
+
+// bech32Polymod computes the bech32 checksum polynomial.
+//
+// Params:
+//   - values: the expanded prefix and the 5-bit values.
+//
+// Returns:
+//   - uint32: the checksum, 1 for a valid string.
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	// fold each value into the checksum
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		// apply the generator of each set bit
+		for i, g := range bech32Generator {
+			// bit i of the top is set
+			if (top>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	// return checksum
+	return chk
+}
+
+// bech32ExpandHRP expands the human-readable part for the checksum.
+//
+// Params:
+//   - hrp: the human-readable part.
+//
+// Returns:
+//   - []byte: the high bits, a zero, then the low bits of each character.
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	// high bits first
+	for i := range len(hrp) {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	// then low bits
+	for i := range len(hrp) {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	// return expanded prefix
+	return expanded
+}
+
+// convertBits regroups 5-bit values into bytes, refusing padding bits.
+//
+// Params:
+//   - values: the 5-bit values.
+//
+// Returns:
+//   - []byte: the bytes.
+//   - error: errBech32Format when the padding is invalid.
+func convertBits(values []byte) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	out := make([]byte, 0, len(values)*5/8)
+	// accumulate 5 bits at a time
+	for _, v := range values {
+		acc = (acc<<5 | uint32(v)) & 0xfff
+		bits += 5
+		// emit each full byte
+		for bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+	}
+	// only zero padding shorter than a value is allowed
+	if bits >= 5 || (acc<<(8-bits))&0xff != 0 {
+		// return padding error
+		return nil, errBech32Format
+	}
+	// return bytes
+	return out, nil
+}
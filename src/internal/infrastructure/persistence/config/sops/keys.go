@@ -0,0 +1,167 @@
+// Package sops provides the decryption of configurations encrypted with sops and age.
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Environment variables naming the age identities, as read by sops.
+const (
+	// EnvAgeKey holds identities inline.
+	EnvAgeKey string = "SOPS_AGE_KEY"
+	// EnvAgeKeyFile names a keys file.
+	EnvAgeKeyFile string = "SOPS_AGE_KEY_FILE"
+	// EnvAgeKeyCmd is a command printing identities, such as a KMS client.
+	EnvAgeKeyCmd string = "SOPS_AGE_KEY_CMD"
+)
+
+// keyCommandTimeout bounds the run of a key command.
+const keyCommandTimeout time.Duration = 30 * time.Second
+
+// ErrEmptyKeyCommand is returned for a key command without program.
+var ErrEmptyKeyCommand error = errors.New("empty key command")
+
+// KeySource provides the age identities decrypting sops files.
+// It is consulted only when an encrypted file is loaded.
+type KeySource interface {
+	// Identities returns the identities to try.
+	Identities() ([]*Identity, error)
+}
+
+// KeyText holds identities inline, one per line.
+type KeyText string
+
+// Identities parses the inline identities.
+//
+// Returns:
+//   - []*Identity: the identities.
+//   - error: ErrInvalidIdentity for a malformed key.
+func (k KeyText) Identities() ([]*Identity, error) {
+	// parse inline keys
+	return ParseIdentities([]byte(k))
+}
+
+// KeyFile reads identities from an age keys file.
+type KeyFile string
+
+// Identities reads and parses the keys file.
+//
+// Returns:
+//   - []*Identity: the identities.
+//   - error: a read error or ErrInvalidIdentity.
+func (k KeyFile) Identities() ([]*Identity, error) {
+	data, err := os.ReadFile(string(k)) // #nosec G304 - key path comes from the daemon environment
+	// key file unreadable
+	if err != nil {
+		// return read error
+		return nil, fmt.Errorf("reading key file: %w", err)
+	}
+	identities, err := ParseIdentities(data)
+	// name the faulty file
+	if err != nil {
+		// return parse error
+		return nil, fmt.Errorf("%s: %w", k, err)
+	}
+	// return identities
+	return identities, nil
+}
+
+// KeyCommand runs a command printing identities on stdout, such as a KMS
+// or secret manager client. The command line is split on spaces.
+type KeyCommand string
+
+// Identities runs the command and parses its output.
+//
+// Returns:
+//   - []*Identity: the identities.
+//   - error: ErrEmptyKeyCommand, a run error with stderr, or ErrInvalidIdentity.
+func (k KeyCommand) Identities() ([]*Identity, error) {
+	fields := strings.Fields(string(k))
+	// a program is required
+	if len(fields) == 0 {
+		// return empty command
+		return nil, ErrEmptyKeyCommand
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyCommandTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...) // #nosec G204 - key command comes from the daemon environment
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// report failures with their output
+	if err := cmd.Run(); err != nil {
+		// return run error
+		return nil, fmt.Errorf("running key command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// parse printed keys
+	return ParseIdentities(stdout.Bytes())
+}
+
+// Keys combines key sources: the identities of all are tried.
+type Keys []KeySource
+
+// Identities collects the identities of each source.
+//
+// Returns:
+//   - []*Identity: the identities, in source order.
+//   - error: the first source error.
+func (k Keys) Identities() ([]*Identity, error) {
+	var identities []*Identity
+	// collect each source
+	for _, source := range k {
+		found, err := source.Identities()
+		// a configured source must work
+		if err != nil {
+			// return source error
+			return nil, err
+		}
+		identities = append(identities, found...)
+	}
+	// return all identities
+	return identities, nil
+}
+
+// EnvKeys reads the identities named by the environment, as sops does:
+// SOPS_AGE_KEY, SOPS_AGE_KEY_FILE and SOPS_AGE_KEY_CMD, plus the sops
+// keys file of the user configuration directory when it exists. The
+// environment is read on each load.
+type EnvKeys struct{}
+
+// Identities collects the identities of the configured sources.
+//
+// Returns:
+//   - []*Identity: the identities.
+//   - error: the first source error.
+func (EnvKeys) Identities() ([]*Identity, error) {
+	var sources Keys
+	// inline keys
+	if text := os.Getenv(EnvAgeKey); text != "" {
+		sources = append(sources, KeyText(text))
+	}
+	// keys file
+	if path := os.Getenv(EnvAgeKeyFile); path != "" {
+		sources = append(sources, KeyFile(path))
+	}
+	// key command
+	if command := os.Getenv(EnvAgeKeyCmd); command != "" {
+		sources = append(sources, KeyCommand(command))
+	}
+	// default keys file of sops
+	if dir, err := os.UserConfigDir(); err == nil {
+		path := filepath.Join(dir, "sops", "age", "keys.txt")
+		// only an existing file is a source
+		if _, err := os.Stat(path); err == nil {
+			sources = append(sources, KeyFile(path))
+		}
+	}
+	// collect configured sources
+	return sources.Identities()
+}
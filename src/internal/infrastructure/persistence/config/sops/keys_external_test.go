@@ -0,0 +1,90 @@
+// Package sops_test provides black-box tests for the age key sources.
+package sops_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
+)
+
+// TestKeySources tests reading identities from text, files, commands and the environment.
+//
+// Params:
+//   - t: the testing context.
+func TestKeySources(t *testing.T) {
+	keys, err := os.ReadFile("testdata/keys.txt")
+	require.NoError(t, err)
+	keysPath, err := filepath.Abs("testdata/keys.txt")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		source  sops.KeySource
+		want    int
+		wantErr bool
+	}{
+		{name: "inline_text", source: sops.KeyText(keys), want: 1},
+		{name: "file", source: sops.KeyFile("testdata/keys.txt"), want: 1},
+		{name: "missing_file", source: sops.KeyFile("testdata/missing.txt"), wantErr: true},
+		{name: "command", source: sops.KeyCommand("cat " + keysPath), want: 1},
+		{name: "failing_command", source: sops.KeyCommand("false"), wantErr: true},
+		{name: "empty_command", source: sops.KeyCommand(" "), wantErr: true},
+		{name: "combined", source: sops.Keys{sops.KeyText(keys), sops.KeyFile("testdata/other-keys.txt")}, want: 2},
+		{name: "combined_failing", source: sops.Keys{sops.KeyText(keys), sops.KeyFile("testdata/missing.txt")}, wantErr: true},
+		{
+			name:   "environment",
+			env:    map[string]string{sops.EnvAgeKey: string(keys), sops.EnvAgeKeyFile: "testdata/other-keys.txt", sops.EnvAgeKeyCmd: "cat " + keysPath},
+			source: sops.EnvKeys{},
+			want:   3,
+		},
+		{name: "empty_environment", source: sops.EnvKeys{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// isolate from the keys of the user running the tests
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			t.Setenv("HOME", t.TempDir())
+			for _, name := range []string{sops.EnvAgeKey, sops.EnvAgeKeyFile, sops.EnvAgeKeyCmd} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			identities, err := tt.source.Identities()
+
+			// verify failures
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, identities, tt.want)
+		})
+	}
+}
+
+// TestEnvKeys_defaultFile tests that the sops keys file of the user is used when present.
+//
+// Params:
+//   - t: the testing context.
+func TestEnvKeys_defaultFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	for _, name := range []string{sops.EnvAgeKey, sops.EnvAgeKeyFile, sops.EnvAgeKeyCmd} {
+		t.Setenv(name, "")
+	}
+	keys, err := os.ReadFile("testdata/keys.txt")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sops", "age"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sops", "age", "keys.txt"), keys, 0o600))
+
+	identities, err := sops.EnvKeys{}.Identities()
+
+	require.NoError(t, err)
+	assert.Len(t, identities, 1)
+}
@@ -0,0 +1,342 @@
+// Package sops provides the decryption of configurations encrypted with sops and age.
+package sops
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MetadataKey is the top-level key holding the sops metadata.
+	MetadataKey string = "sops"
+	// dataKeySize is the size of the AES-256 data key.
+	dataKeySize int = 32
+)
+
+// Sops errors.
+var (
+	// ErrUnsupported is returned for sops files this reader cannot decrypt.
+	ErrUnsupported error = errors.New("unsupported sops file")
+	// ErrMalformedValue is returned for encrypted values that fail to decrypt.
+	ErrMalformedValue error = errors.New("malformed sops value")
+)
+
+// encryptedValue matches a value encrypted by sops.
+var encryptedValue *regexp.Regexp = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+// metadata is the sops metadata of a file.
+type metadata struct {
+	// Age lists the data key encrypted for each age recipient.
+	Age []recipient `yaml:"age"`
+	// KeyGroups lists recipient groups, of which one is enough without Shamir.
+	KeyGroups []keyGroup `yaml:"key_groups"`
+	// ShamirThreshold is the number of key groups needed, set when split with Shamir.
+	ShamirThreshold int `yaml:"shamir_threshold"`
+}
+
+// keyGroup is a group of recipients.
+type keyGroup struct {
+	// Age lists the age recipients of the group.
+	Age []recipient `yaml:"age"`
+}
+
+// recipient is the data key encrypted for one age recipient.
+type recipient struct {
+	// Recipient is the age public key.
+	Recipient string `yaml:"recipient"`
+	// Enc is the armored age file holding the data key.
+	Enc string `yaml:"enc"`
+}
+
+// IsEncrypted reports whether a root mapping carries sops metadata.
+//
+// Params:
+//   - root: the root node of a document.
+//
+// Returns:
+//   - bool: true if the document was encrypted with sops.
+func IsEncrypted(root *yaml.Node) bool {
+	_, meta := metadataNode(root)
+	// sops always stores a MAC
+	return meta != nil && mappingHas(meta, "mac")
+}
+
+// Decrypt decrypts in place the values of a sops document and removes its
+// metadata, so the document decodes like a plain one. Only age recipients
+// are supported. Each value is authenticated with its key path by AES-GCM;
+// the sops MAC over the whole file is not verified.
+//
+// Params:
+//   - root: the root mapping of the document.
+//   - keys: the source of the age identities.
+//
+// Returns:
+//   - []string: the decrypted values, to redact them where they appear.
+//   - error: ErrNoIdentity, ErrUnsupported or ErrMalformedValue.
+func Decrypt(root *yaml.Node, keys KeySource) ([]string, error) {
+	index, meta := metadataNode(root)
+	// refuse plain documents
+	if meta == nil {
+		// return missing metadata
+		return nil, fmt.Errorf("%w: no %s metadata", ErrUnsupported, MetadataKey)
+	}
+	var md metadata
+	// decode the recipients
+	if err := meta.Decode(&md); err != nil {
+		// return metadata error
+		return nil, fmt.Errorf("%w: metadata: %w", ErrUnsupported, err)
+	}
+	dataKey, err := md.dataKey(keys)
+	// no identity decrypts the data key
+	if err != nil {
+		// return key error
+		return nil, err
+	}
+	root.Content = slices.Delete(root.Content, index, index+2)
+	var secrets []string
+	// decrypt every value of the document
+	if err := decryptNode(root, nil, dataKey, &secrets); err != nil {
+		// return value error
+		return nil, err
+	}
+	// return decrypted values
+	return secrets, nil
+}
+
+// metadataNode returns the sops metadata of a root mapping.
+//
+// Params:
+//   - root: the root node of a document.
+//
+// Returns:
+//   - int: the index of the metadata key in the mapping.
+//   - *yaml.Node: the metadata mapping, nil when absent.
+func metadataNode(root *yaml.Node) (int, *yaml.Node) {
+	// only mappings carry metadata
+	if root == nil || root.Kind != yaml.MappingNode {
+		// return no metadata
+		return 0, nil
+	}
+	// search the metadata key
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		// return the metadata mapping
+		if root.Content[i].Value == MetadataKey && root.Content[i+1].Kind == yaml.MappingNode {
+			return i, root.Content[i+1]
+		}
+	}
+	// return no metadata
+	return 0, nil
+}
+
+// mappingHas reports whether a mapping has a key.
+//
+// Params:
+//   - mapping: the mapping node.
+//   - key: the key.
+//
+// Returns:
+//   - bool: true if the key is set.
+func mappingHas(mapping *yaml.Node, key string) bool {
+	// search the key
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		// key found
+		if mapping.Content[i].Value == key {
+			// report key
+			return true
+		}
+	}
+	// report absent key
+	return false
+}
+
+// dataKey decrypts the data key with the first matching identity.
+//
+// Params:
+//   - keys: the source of the age identities.
+//
+// Returns:
+//   - []byte: the AES-256 data key.
+//   - error: ErrNoIdentity, or ErrUnsupported without age recipient.
+func (md *metadata) dataKey(keys KeySource) ([]byte, error) {
+	// Shamir splits need several groups, not one identity
+	if md.ShamirThreshold > 1 {
+		// return unsupported split
+		return nil, fmt.Errorf("%w: shamir_threshold %d", ErrUnsupported, md.ShamirThreshold)
+	}
+	recipients := slices.Clone(md.Age)
+	// any group can decrypt without Shamir
+	for _, group := range md.KeyGroups {
+		recipients = append(recipients, group.Age...)
+	}
+	// pgp, kms and vault keys need their own clients
+	if len(recipients) == 0 {
+		// return unsupported keys
+		return nil, fmt.Errorf("%w: no age recipient", ErrUnsupported)
+	}
+	identities, err := keys.Identities()
+	// key source failed
+	if err != nil {
+		// return source error
+		return nil, fmt.Errorf("reading age identities: %w", err)
+	}
+	names := make([]string, 0, len(recipients))
+	// try each recipient
+	for _, r := range recipients {
+		key, err := decryptArmored(r.Enc, identities)
+		// use the first data key decrypted
+		if err == nil && len(key) == dataKeySize {
+			// return data key
+			return key, nil
+		}
+		// a malformed entry is not a missing key
+		if err != nil && !errors.Is(err, ErrNoIdentity) {
+			// return entry error
+			return nil, fmt.Errorf("data key for %s: %w", r.Recipient, err)
+		}
+		names = append(names, r.Recipient)
+	}
+	// no identity matches
+	return nil, fmt.Errorf("%w: %s", ErrNoIdentity, strings.Join(names, ", "))
+}
+
+// decryptNode decrypts the values under a node. The key path of each value
+// is its additional authenticated data, as sops encrypted it.
+//
+// Params:
+//   - node: the node.
+//   - path: the mapping keys leading to the node; sequences add none.
+//   - key: the data key.
+//   - secrets: receives the decrypted values.
+//
+// Returns:
+//   - error: ErrMalformedValue with the key path.
+func decryptNode(node *yaml.Node, path []string, key []byte, secrets *[]string) error {
+	switch node.Kind {
+	// mapping values extend the path with their key
+	case yaml.MappingNode:
+		// decrypt each value
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			// propagate the value error
+			if err := decryptNode(node.Content[i+1], append(slices.Clip(path), node.Content[i].Value), key, secrets); err != nil {
+				// return value error
+				return err
+			}
+		}
+	// sequence items share the path of the sequence
+	case yaml.SequenceNode, yaml.DocumentNode:
+		// decrypt each item
+		for _, child := range node.Content {
+			// propagate the item error
+			if err := decryptNode(child, path, key, secrets); err != nil {
+				// return item error
+				return err
+			}
+		}
+	// encrypted scalars are replaced by their value
+	case yaml.ScalarNode:
+		match := encryptedValue.FindStringSubmatch(node.Value)
+		// keep plain values
+		if match == nil {
+			// nothing to decrypt
+			return nil
+		}
+		value, tag, err := decryptValue(match, key, strings.Join(path, ":")+":")
+		// report the value at fault
+		if err != nil {
+			// return value error
+			return fmt.Errorf("%w at %s: %w", ErrMalformedValue, strings.Join(path, "."), err)
+		}
+		node.Value, node.Tag, node.Style = value, tag, 0
+		// remember non-empty values to redact them
+		if value != "" {
+			*secrets = append(*secrets, value)
+		}
+	// aliases point at nodes decrypted where defined
+	default:
+	}
+	// node decrypted
+	return nil
+}
+
+// decryptValue decrypts one ENC[AES256_GCM,...] value.
+//
+// Params:
+//   - match: the submatches of encryptedValue: data, iv, tag and type.
+//   - key: the data key.
+//   - aad: the key path of the value.
+//
+// Returns:
+//   - string: the plaintext value.
+//   - string: the YAML tag of its type.
+//   - error: a decoding, authentication or type error.
+func decryptValue(match []string, key []byte, aad string) (string, string, error) {
+	var parts [3][]byte
+	// decode data, iv and tag
+	for i := range parts {
+		decoded, err := base64.StdEncoding.DecodeString(match[i+1])
+		// refuse corrupted fields
+		if err != nil {
+			// return decoding error
+			return "", "", err
+		}
+		parts[i] = decoded
+	}
+	data, iv, tag := parts[0], parts[1], parts[2]
+	block, err := aes.NewCipher(key)
+	// key size is fixed
+	if err != nil {
+		// return cipher error
+		return "", "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	// refuse empty nonces
+	if err != nil {
+		// return cipher error
+		return "", "", err
+	}
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(aad))
+	// refuse tampered or moved values
+	if err != nil {
+		// return authentication error
+		return "", "", err
+	}
+	value := string(plaintext)
+	// map the sops type to a YAML tag
+	switch match[4] {
+	// strings and bytes stay strings
+	case "str", "bytes":
+		// return string
+		return value, "!!str", nil
+	// integers keep their digits
+	case "int":
+		// return integer
+		return value, "!!int", nil
+	// floats keep their digits
+	case "float":
+		// return float
+		return value, "!!float", nil
+	// booleans are written True and False by sops
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		// refuse other spellings
+		if err != nil {
+			// return boolean error
+			return "", "", err
+		}
+		// return boolean
+		return strconv.FormatBool(b), "!!bool", nil
+	// unknown types cannot be decoded
+	default:
+		// return type error
+		return "", "", fmt.Errorf("unknown type %q", match[4])
+	}
+}
@@ -0,0 +1,169 @@
+// Package sops_test provides black-box tests for the sops decryption.
+package sops_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
+)
+
+// readFixture parses a testdata file into its root mapping.
+//
+// Params:
+//   - t: the testing context.
+//   - name: the testdata file name.
+//   - edit: rewrites the file before parsing, nil to keep it.
+//
+// Returns:
+//   - *yaml.Node: the root mapping.
+func readFixture(t *testing.T, name string, edit func(string) string) *yaml.Node {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+	text := string(data)
+	// apply the edit of the case
+	if edit != nil {
+		text = edit(text)
+	}
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(text), &doc))
+	return doc.Content[0]
+}
+
+// TestIsEncrypted tests the detection of sops metadata.
+//
+// Params:
+//   - t: the testing context.
+func TestIsEncrypted(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want bool
+	}{
+		{name: "sops_metadata", yaml: "a: b\nsops:\n  mac: x\n  version: 3.9.0\n", want: true},
+		{name: "plain_document", yaml: "a: b\n", want: false},
+		{name: "unrelated_sops_key", yaml: "sops: enabled\n", want: false},
+		{name: "sops_mapping_without_mac", yaml: "sops:\n  enabled: true\n", want: false},
+		{name: "sequence_root", yaml: "- a\n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &doc))
+			assert.Equal(t, tt.want, sops.IsEncrypted(doc.Content[0]))
+		})
+	}
+}
+
+// TestDecrypt tests the decryption of a sops file with an age identity.
+//
+// Params:
+//   - t: the testing context.
+func TestDecrypt(t *testing.T) {
+	root := readFixture(t, "values.enc.yaml", nil)
+
+	secrets, err := sops.Decrypt(root, sops.KeyFile("testdata/keys.txt"))
+	require.NoError(t, err)
+
+	var got struct {
+		Name    string            `yaml:"name"`
+		Port    int               `yaml:"port"`
+		Ratio   float64           `yaml:"ratio"`
+		Enabled bool              `yaml:"enabled"`
+		Tags    []string          `yaml:"tags"`
+		Nested  map[string]string `yaml:"nested"`
+		Sops    any               `yaml:"sops"`
+	}
+	require.NoError(t, root.Decode(&got))
+	assert.Equal(t, "api", got.Name)
+	assert.Equal(t, 8080, got.Port)
+	assert.InDelta(t, 0.5, got.Ratio, 0)
+	assert.True(t, got.Enabled)
+	assert.Equal(t, []string{"blue", "green"}, got.Tags)
+	assert.Equal(t, map[string]string{"token": "s3cr3t-token"}, got.Nested)
+	assert.Nil(t, got.Sops, "metadata should be removed")
+	assert.ElementsMatch(t, []string{"api", "8080", "0.5", "true", "blue", "green", "s3cr3t-token"}, secrets)
+	assert.False(t, sops.IsEncrypted(root))
+}
+
+// TestDecrypt_errors tests the files and keys that cannot be decrypted.
+//
+// Params:
+//   - t: the testing context.
+func TestDecrypt_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(string) string
+		keys    sops.KeySource
+		wantErr error
+	}{
+		{
+			name:    "identity_not_a_recipient",
+			keys:    sops.KeyFile("testdata/other-keys.txt"),
+			wantErr: sops.ErrNoIdentity,
+		},
+		{
+			name:    "no_identity",
+			keys:    sops.Keys{},
+			wantErr: sops.ErrNoIdentity,
+		},
+		{
+			name: "value_moved_to_another_key",
+			edit: func(s string) string {
+				// the name ciphertext authenticates only under name
+				return strings.Replace(s, "name: ENC", "title: ENC", 1)
+			},
+			keys:    sops.KeyFile("testdata/keys.txt"),
+			wantErr: sops.ErrMalformedValue,
+		},
+		{
+			name: "value_tampered",
+			edit: func(s string) string {
+				return strings.Replace(s, "data:ziFe", "data:ziFf", 1)
+			},
+			keys:    sops.KeyFile("testdata/keys.txt"),
+			wantErr: sops.ErrMalformedValue,
+		},
+		{
+			name: "pgp_only",
+			edit: func(s string) string {
+				return strings.Replace(s, "    age:\n", "    pgp:\n", 1)
+			},
+			keys:    sops.KeyFile("testdata/keys.txt"),
+			wantErr: sops.ErrUnsupported,
+		},
+		{
+			name: "shamir_split",
+			edit: func(s string) string {
+				return strings.Replace(s, "    version:", "    shamir_threshold: 2\n    version:", 1)
+			},
+			keys:    sops.KeyFile("testdata/keys.txt"),
+			wantErr: sops.ErrUnsupported,
+		},
+		{
+			name: "corrupted_data_key",
+			edit: func(s string) string {
+				return strings.Replace(s, "-----BEGIN AGE ENCRYPTED FILE-----\n            YWdl", "-----BEGIN AGE ENCRYPTED FILE-----\n            ZWdl", 1)
+			},
+			keys:    sops.KeyFile("testdata/keys.txt"),
+			wantErr: sops.ErrMalformedAge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := readFixture(t, "values.enc.yaml", tt.edit)
+
+			_, err := sops.Decrypt(root, tt.keys)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
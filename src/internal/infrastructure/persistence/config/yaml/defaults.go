@@ -0,0 +1,216 @@
+// Package yaml provides YAML configuration loading infrastructure.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Keys of the service defaults.
+const (
+	// defaultsKey is the top-level key of the fields every service inherits.
+	defaultsKey string = "defaults"
+	// groupsKey is the top-level key of the fields inherited by group.
+	groupsKey string = "groups"
+	// groupKey is the service key naming the group it inherits from.
+	groupKey string = "defaults_group"
+)
+
+// Service defaults errors.
+var (
+	// ErrUnknownGroup is returned when defaults_group names a group that does not exist.
+	ErrUnknownGroup error = errors.New("unknown defaults group")
+	// ErrInvalidDefaults is returned when defaults, a group or a defaults_group value has the wrong shape.
+	ErrInvalidDefaults error = errors.New("invalid defaults")
+)
+
+// identityKeys are service keys that cannot be inherited.
+var identityKeys []string = []string{"name", extendsKey, groupKey}
+
+// defaultSet applies the defaults and group defaults of a document.
+type defaultSet struct {
+	// global holds the fields every service inherits, nil when none.
+	global *yaml.Node
+	// groups holds the fields inherited by group, by group name.
+	groups map[string]*yaml.Node
+	// host is the platform the overrides are resolved for.
+	host platform
+	// src records the file of each node.
+	src *sources
+}
+
+// declareGlobal records the defaults block, resolved for the platform.
+//
+// Params:
+//   - node: the value of the defaults key.
+//
+// Returns:
+//   - error: ErrInvalidDefaults when the block is not a mapping of inheritable fields, or ErrInvalidPlatform.
+func (d *defaultSet) declareGlobal(node *yaml.Node) error {
+	// Defaults are service fields.
+	if err := d.checkFields(node, "defaults"); err != nil {
+		// Report the malformed defaults.
+		return err
+	}
+	global, err := d.host.resolve(node, d.src)
+	// Report the override position.
+	if err != nil {
+		// Propagate the override error.
+		return err
+	}
+	d.global = global
+	// Defaults recorded.
+	return nil
+}
+
+// declareGroups records the groups block, each group resolved for the platform.
+//
+// Params:
+//   - node: the value of the groups key.
+//
+// Returns:
+//   - error: ErrInvalidDefaults when the block or a group is not a mapping of inheritable fields, or ErrInvalidPlatform.
+func (d *defaultSet) declareGroups(node *yaml.Node) error {
+	// Groups are keyed by name.
+	if node.Kind != yaml.MappingNode {
+		// Report the block line.
+		return fmt.Errorf("%s: %w: groups must be a mapping of names to service fields", d.src.position(node), ErrInvalidDefaults)
+	}
+	// Record each group by name.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i].Value, resolveAlias(node.Content[i+1])
+		// A group holds service fields.
+		if err := d.checkFields(value, fmt.Sprintf("group %q", name)); err != nil {
+			// Report the malformed group.
+			return err
+		}
+		fields, err := d.host.resolve(value, d.src)
+		// Report the override position.
+		if err != nil {
+			// Propagate the override error.
+			return err
+		}
+		d.groups[name] = fields
+	}
+	// All groups recorded.
+	return nil
+}
+
+// checkFields verifies that a node is a mapping of inheritable service fields.
+//
+// Params:
+//   - node: the defaults or group node.
+//   - what: "defaults" or the group, for error messages.
+//
+// Returns:
+//   - error: ErrInvalidDefaults with the position of the faulty node.
+func (d *defaultSet) checkFields(node *yaml.Node, what string) error {
+	// Inherited fields form a mapping.
+	if node.Kind != yaml.MappingNode {
+		// Report the node line.
+		return fmt.Errorf("%s: %w: %s must be a mapping", d.src.position(node), ErrInvalidDefaults, what)
+	}
+	// Identity keys belong to each service.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		// Skip inheritable fields.
+		if !slices.Contains(identityKeys, node.Content[i].Value) {
+			continue
+		}
+		// Report the key line.
+		return fmt.Errorf("%s: %w: %s cannot set %s", d.src.position(node.Content[i]), ErrInvalidDefaults, what, node.Content[i].Value)
+	}
+	// All fields inheritable.
+	return nil
+}
+
+// apply merges a service over its group and the defaults.
+//
+// Params:
+//   - svc: the service mapping, with its template already merged.
+//
+// Returns:
+//   - *yaml.Node: the merged mapping, without defaults_group key.
+//   - error: an unknown or malformed group, with the position of the defaults_group key.
+func (d *defaultSet) apply(svc *yaml.Node) (*yaml.Node, error) {
+	// Only mappings inherit defaults.
+	if svc.Kind != yaml.MappingNode {
+		// Leave other nodes to the decoder.
+		return svc, nil
+	}
+	own := &yaml.Node{Kind: yaml.MappingNode, Tag: svc.Tag, Line: svc.Line, Column: svc.Column}
+	var group *yaml.Node
+	// Split the defaults_group key from the fields.
+	for i := 0; i+1 < len(svc.Content); i += 2 {
+		// Keep the fields as is.
+		if svc.Content[i].Value != groupKey {
+			own.Content = append(own.Content, svc.Content[i], svc.Content[i+1])
+			continue
+		}
+		group = resolveAlias(svc.Content[i+1])
+	}
+	base := d.global
+	// Merge the group over the defaults.
+	if group != nil {
+		fields, err := d.group(group)
+		// Report unknown or malformed groups.
+		if err != nil {
+			// Propagate the group error.
+			return nil, err
+		}
+		base = mergeOptional(base, fields)
+	}
+	// Nothing to inherit, hence no defaults_group key either.
+	if base == nil {
+		// Return the service unchanged.
+		return svc, nil
+	}
+	merged := mergeMappings(base, own)
+	d.src.files[merged] = d.src.files[svc]
+	// return fields merged over the inherited ones
+	return merged, nil
+}
+
+// group returns the fields of the group a defaults_group value names.
+//
+// Params:
+//   - ref: the defaults_group value.
+//
+// Returns:
+//   - *yaml.Node: the group fields.
+//   - error: ErrInvalidDefaults or ErrUnknownGroup, with the position of ref.
+func (d *defaultSet) group(ref *yaml.Node) (*yaml.Node, error) {
+	// A group is named by a string.
+	if ref.Kind != yaml.ScalarNode || ref.Value == "" {
+		// Report the value position.
+		return nil, fmt.Errorf("%s: %w: defaults_group must name a group", d.src.position(ref), ErrInvalidDefaults)
+	}
+	fields, ok := d.groups[ref.Value]
+	// The group must be declared.
+	if !ok {
+		// Report the value position.
+		return nil, fmt.Errorf("%s: %w %q", d.src.position(ref), ErrUnknownGroup, ref.Value)
+	}
+	// return group fields
+	return fields, nil
+}
+
+// mergeOptional merges override over base, either of which may be nil.
+//
+// Params:
+//   - base: the inherited mapping, or nil.
+//   - override: the mapping taking precedence.
+//
+// Returns:
+//   - *yaml.Node: the merged mapping.
+func mergeOptional(base, override *yaml.Node) *yaml.Node {
+	// Nothing to merge over.
+	if base == nil {
+		// Return the override alone.
+		return override
+	}
+	// return merged mapping
+	return mergeMappings(base, override)
+}
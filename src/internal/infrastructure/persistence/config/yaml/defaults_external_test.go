@@ -0,0 +1,175 @@
+// Package yaml_test provides black-box tests for service defaults and groups.
+package yaml_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+)
+
+// testDefaultsConfig layers defaults, a group, a template and service fields.
+const testDefaultsConfig string = `
+version: "1"
+
+defaults:
+  user: app
+  environment:
+    TZ: UTC
+    LOG_FORMAT: json
+  labels:
+    environment: prod
+  restart:
+    policy: always
+    delay: 1s
+
+groups:
+  workers:
+    user: worker
+    environment:
+      ROLE: worker
+    labels:
+      tier: backend
+    restart:
+      delay: 5s
+
+templates:
+  queue:
+    defaults_group: workers
+    command: /usr/local/bin/queue
+    environment:
+      LOG_FORMAT: text
+
+services:
+  - name: mailer
+    extends: queue
+    environment:
+      QUEUE: mail
+    labels:
+      team: messaging
+  - name: reports
+    defaults_group: workers
+    command: /usr/local/bin/reports
+    restart:
+      policy: on-failure
+  - name: api
+    command: /usr/local/bin/api
+    user: www
+`
+
+// TestLoader_Parse_Defaults tests that services inherit defaults, then their group, template and own fields.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Parse_Defaults(t *testing.T) {
+	cfg, err := yaml.NewLoader().Parse([]byte(testDefaultsConfig))
+	require.NoError(t, err)
+	require.Len(t, cfg.Services, 3)
+
+	mailer := cfg.Services[0]
+	// The group set by the template overrides the defaults.
+	assert.Equal(t, "worker", mailer.User)
+	assert.Equal(t, map[string]string{"TZ": "UTC", "LOG_FORMAT": "text", "ROLE": "worker", "QUEUE": "mail"}, mailer.Environment)
+	assert.Equal(t, map[string]string{"environment": "prod", "tier": "backend", "team": "messaging"}, mailer.Labels)
+	assert.Equal(t, "always", string(mailer.Restart.Policy))
+	assert.Equal(t, 5*time.Second, mailer.Restart.Delay.Duration())
+
+	reports := cfg.Services[1]
+	assert.Equal(t, "worker", reports.User)
+	// Nested fields merge across levels.
+	assert.Equal(t, "on-failure", string(reports.Restart.Policy))
+	assert.Equal(t, 5*time.Second, reports.Restart.Delay.Duration())
+
+	api := cfg.Services[2]
+	assert.Equal(t, "www", api.User)
+	assert.Equal(t, map[string]string{"TZ": "UTC", "LOG_FORMAT": "json"}, api.Environment)
+	assert.Equal(t, time.Second, api.Restart.Delay.Duration())
+}
+
+// TestLoader_Parse_DefaultsErrors tests that invalid defaults are reported with their line.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Parse_DefaultsErrors(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// yamlText is the parsed configuration.
+		yamlText string
+		// wantErr is the expected error.
+		wantErr error
+		// wantMsg is the expected error message fragment.
+		wantMsg string
+	}{
+		{
+			name: "unknown_group",
+			yamlText: `
+services:
+  - name: api
+    command: /bin/api
+    defaults_group: web
+`,
+			wantErr: yaml.ErrUnknownGroup,
+			wantMsg: `line 5, column 21: unknown defaults group "web"`,
+		},
+		{
+			name: "defaults_not_mapping",
+			yamlText: `
+defaults: [user]
+services: []
+`,
+			wantErr: yaml.ErrInvalidDefaults,
+			wantMsg: `line 2`,
+		},
+		{
+			name: "defaults_name",
+			yamlText: `
+defaults:
+  user: app
+  name: api
+services: []
+`,
+			wantErr: yaml.ErrInvalidDefaults,
+			wantMsg: `line 4, column 3: invalid defaults: defaults cannot set name`,
+		},
+		{
+			name: "group_extends",
+			yamlText: `
+groups:
+  web:
+    extends: base
+services: []
+`,
+			wantErr: yaml.ErrInvalidDefaults,
+			wantMsg: `line 4, column 5: invalid defaults: group "web" cannot set extends`,
+		},
+		{
+			name: "group_not_name",
+			yamlText: `
+groups:
+  web:
+    user: www
+services:
+  - name: api
+    command: /bin/api
+    defaults_group: {web: true}
+`,
+			wantErr: yaml.ErrInvalidDefaults,
+			wantMsg: `line 8`,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := yaml.NewLoader().Parse([]byte(tt.yamlText))
+
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Package yaml provides YAML configuration loading infrastructure.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Keys handled before the configuration is decoded.
+const (
+	// templatesKey is the top-level key of the service templates.
+	templatesKey string = "templates"
+	// extendsKey is the service key naming the template it inherits from.
+	extendsKey string = "extends"
+	// extensionPrefix starts the top-level keys left to the user, as in docker-compose.
+	extensionPrefix string = "x-"
+)
+
+// Template expansion errors.
+var (
+	// ErrUnknownTemplate is returned when extends names a template that does not exist.
+	ErrUnknownTemplate error = errors.New("unknown template")
+	// ErrTemplateCycle is returned when templates extend each other in a loop.
+	ErrTemplateCycle error = errors.New("template extends itself")
+	// ErrInvalidTemplate is returned when a template or an extends value has the wrong shape.
+	ErrInvalidTemplate error = errors.New("invalid template")
+)
+
+// templateSet resolves the service templates of a document.
+type templateSet struct {
+	// nodes holds the declared templates, by name.
+	nodes map[string]*yaml.Node
+	// resolved caches the templates with their own extends applied.
+	resolved map[string]*yaml.Node
+	// resolving marks the templates being resolved, to detect cycles.
+	resolving map[string]bool
+	// host is the platform the overrides are resolved for.
+	host platform
+	// src records the file of each node.
+	src *sources
+}
+
+// expandDocument removes the x- extension blocks, the templates and the
+// defaults of a document, resolves the platform overrides of each of them,
+// and merges each service over the template it extends, then over its
+// group and the defaults.
+//
+// Params:
+//   - doc: the parsed document node.
+//   - src: the record of node files.
+//   - host: the platform the overrides are resolved for.
+//
+// Returns:
+//   - error: an unknown, cyclic or malformed template or override, with its position.
+func expandDocument(doc *yaml.Node, src *sources, host platform) error {
+	// Empty documents have nothing to expand.
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// Leave the document to the decoder.
+		return nil
+	}
+	root := doc.Content[0]
+	set := &templateSet{nodes: map[string]*yaml.Node{}, resolved: map[string]*yaml.Node{}, resolving: map[string]bool{}, host: host, src: src}
+	defaults := &defaultSet{groups: map[string]*yaml.Node{}, host: host, src: src}
+	var services *yaml.Node
+	content := make([]*yaml.Node, 0, len(root.Content))
+	// Keep the top-level keys the decoder knows.
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], resolveAlias(root.Content[i+1])
+		switch {
+		// Extension blocks only hold anchors for the rest of the file.
+		case strings.HasPrefix(key.Value, extensionPrefix):
+			continue
+		// Templates are merged into services, not decoded.
+		case key.Value == templatesKey:
+			// Templates are named mappings.
+			if err := set.declare(value); err != nil {
+				// Report the malformed templates.
+				return err
+			}
+			continue
+		// Defaults are merged into services, not decoded.
+		case key.Value == defaultsKey:
+			// Defaults are service fields.
+			if err := defaults.declareGlobal(value); err != nil {
+				// Report the malformed defaults.
+				return err
+			}
+			continue
+		// Groups are merged into services, not decoded.
+		case key.Value == groupsKey:
+			// Groups are named service fields.
+			if err := defaults.declareGroups(value); err != nil {
+				// Report the malformed groups.
+				return err
+			}
+			continue
+		// Services are expanded once templates are known.
+		case key.Value == "services":
+			services = value
+		}
+		content = append(content, key, root.Content[i+1])
+	}
+	root.Content = content
+
+	// Nothing extends without services.
+	if services == nil || services.Kind != yaml.SequenceNode {
+		// Leave the services to the decoder.
+		return nil
+	}
+	// Merge each service over its template, then its defaults.
+	for i, svc := range services.Content {
+		own, err := host.resolve(resolveAlias(svc), src)
+		// Report the override position.
+		if err != nil {
+			// Stop at the first invalid service.
+			return err
+		}
+		expanded, err := set.expand(own, "service")
+		// Report the service position.
+		if err != nil {
+			// Stop at the first invalid service.
+			return err
+		}
+		expanded, err = defaults.apply(expanded)
+		// Report the defaults_group position.
+		if err != nil {
+			// Stop at the first invalid service.
+			return err
+		}
+		services.Content[i] = expanded
+	}
+	// Every service expanded.
+	return nil
+}
+
+// declare records the templates of the templates block, each resolved for the platform.
+//
+// Params:
+//   - node: the value of the templates key.
+//
+// Returns:
+//   - error: ErrInvalidTemplate when the block or a template is not a mapping, or ErrInvalidPlatform.
+func (t *templateSet) declare(node *yaml.Node) error {
+	// Templates are keyed by name.
+	if node.Kind != yaml.MappingNode {
+		// Report the block line.
+		return fmt.Errorf("%s: %w: templates must be a mapping of names to service fields", t.src.position(node), ErrInvalidTemplate)
+	}
+	// Record each template by name.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i].Value, resolveAlias(node.Content[i+1])
+		// A template holds service fields.
+		if value.Kind != yaml.MappingNode {
+			// Report the template line.
+			return fmt.Errorf("%s: %w: template %q must be a mapping", t.src.position(value), ErrInvalidTemplate, name)
+		}
+		resolved, err := t.host.resolve(value, t.src)
+		// Report the override position.
+		if err != nil {
+			// Propagate the override error.
+			return err
+		}
+		t.nodes[name] = resolved
+	}
+	// All templates recorded.
+	return nil
+}
+
+// expand merges a service or template with the template it extends.
+//
+// Params:
+//   - node: the service or template mapping.
+//   - kind: "service" or "template", for error messages.
+//
+// Returns:
+//   - *yaml.Node: the merged mapping, without extends key.
+//   - error: an unknown or cyclic template, with the position of the extends key.
+func (t *templateSet) expand(node *yaml.Node, kind string) (*yaml.Node, error) {
+	// Only mappings extend templates.
+	if node.Kind != yaml.MappingNode {
+		// Leave other nodes to the decoder.
+		return node, nil
+	}
+	own := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag, Line: node.Line, Column: node.Column}
+	var extends *yaml.Node
+	// Split the extends key from the fields.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		// Keep the fields as is.
+		if node.Content[i].Value != extendsKey {
+			own.Content = append(own.Content, node.Content[i], node.Content[i+1])
+			continue
+		}
+		extends = resolveAlias(node.Content[i+1])
+	}
+	// Nothing to inherit.
+	if extends == nil {
+		// Return the mapping unchanged.
+		return node, nil
+	}
+	// A template is named by a string.
+	if extends.Kind != yaml.ScalarNode || extends.Value == "" {
+		// Report the extends position.
+		return nil, fmt.Errorf("%s: %w: %s extends must name a template", t.src.position(extends), ErrInvalidTemplate, kind)
+	}
+	base, err := t.resolve(extends.Value, extends)
+	// Report unknown or cyclic templates.
+	if err != nil {
+		// Propagate the resolution error.
+		return nil, err
+	}
+	merged := mergeMappings(base, own)
+	t.src.files[merged] = t.src.files[node]
+	// return fields merged over the template
+	return merged, nil
+}
+
+// resolve returns a template with its own extends applied.
+//
+// Params:
+//   - name: the template name.
+//   - ref: the extends value naming it.
+//
+// Returns:
+//   - *yaml.Node: the resolved template mapping.
+//   - error: ErrUnknownTemplate or ErrTemplateCycle, with the position of ref.
+func (t *templateSet) resolve(name string, ref *yaml.Node) (*yaml.Node, error) {
+	// Reuse templates resolved for other services.
+	if node, ok := t.resolved[name]; ok {
+		// return cached template
+		return node, nil
+	}
+	node, ok := t.nodes[name]
+	// The template must be declared.
+	if !ok {
+		// Report the extends position.
+		return nil, fmt.Errorf("%s: %w %q", t.src.position(ref), ErrUnknownTemplate, name)
+	}
+	// A template being resolved extends itself through others.
+	if t.resolving[name] {
+		// Report the extends line closing the loop.
+		return nil, fmt.Errorf("%s: %w: %q", t.src.position(ref), ErrTemplateCycle, name)
+	}
+	t.resolving[name] = true
+	defer delete(t.resolving, name)
+	resolved, err := t.expand(node, "template")
+	// Propagate the errors of inherited templates.
+	if err != nil {
+		// Report the innermost error.
+		return nil, err
+	}
+	t.resolved[name] = resolved
+	// return resolved template
+	return resolved, nil
+}
+
+// mergeMappings merges override over base: nested mappings are merged key by
+// key, any other value of override replaces the one of base. Neither input
+// is modified.
+//
+// Params:
+//   - base: the inherited mapping.
+//   - override: the mapping taking precedence.
+//
+// Returns:
+//   - *yaml.Node: the merged mapping.
+func mergeMappings(base, override *yaml.Node) *yaml.Node {
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: override.Tag, Line: override.Line, Column: override.Column}
+	overrides := make(map[string]*yaml.Node, len(override.Content)/2)
+	// Index the overriding values by key.
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		overrides[override.Content[i].Value] = override.Content[i+1]
+	}
+	// Keep the base keys, merged with their overrides.
+	for i := 0; i+1 < len(base.Content); i += 2 {
+		key, value := base.Content[i], base.Content[i+1]
+		// Overridden keys take the overriding value.
+		if over, ok := overrides[key.Value]; ok {
+			value = over
+			// Nested mappings are merged instead of replaced.
+			if b, o := resolveAlias(base.Content[i+1]), resolveAlias(over); b.Kind == yaml.MappingNode && o.Kind == yaml.MappingNode {
+				value = mergeMappings(b, o)
+			}
+			delete(overrides, key.Value)
+		}
+		merged.Content = append(merged.Content, key, value)
+	}
+	// Add the keys missing from base, in their order.
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		// Skip the keys merged above.
+		if _, ok := overrides[override.Content[i].Value]; ok {
+			merged.Content = append(merged.Content, override.Content[i], override.Content[i+1])
+		}
+	}
+	// return merged mapping
+	return merged
+}
+
+// resolveAlias returns the node an alias points to.
+//
+// Params:
+//   - node: the node, alias or not.
+//
+// Returns:
+//   - *yaml.Node: the aliased node, or node itself.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	// Follow chained aliases.
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	// return target node
+	return node
+}
@@ -0,0 +1,154 @@
+// Package yaml_test provides black-box tests for service templates and extension blocks.
+package yaml_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+)
+
+// testTemplatesConfig uses an extension block, anchors and a chain of templates.
+const testTemplatesConfig string = `
+version: "1"
+
+x-env: &env
+  LOG_FORMAT: json
+  REGION: eu
+
+x-restart: &restart
+  policy: always
+  delay: 2s
+
+templates:
+  base:
+    user: app
+    environment: *env
+    restart: *restart
+  worker:
+    extends: base
+    command: /usr/local/bin/worker
+    args: ["--queue", "default"]
+    environment:
+      ROLE: worker
+
+services:
+  - name: mailer
+    extends: worker
+    args: ["--queue", "mail"]
+    environment:
+      REGION: us
+  - name: api
+    command: /usr/local/bin/api
+    restart:
+      <<: *restart
+      delay: 10s
+`
+
+// TestLoader_Parse_Templates tests that services inherit their templates and anchors resolve.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Parse_Templates(t *testing.T) {
+	cfg, err := yaml.NewLoader().Parse([]byte(testTemplatesConfig))
+	require.NoError(t, err)
+	require.Len(t, cfg.Services, 2)
+
+	mailer := cfg.Services[0]
+	assert.Equal(t, "mailer", mailer.Name)
+	assert.Equal(t, "/usr/local/bin/worker", mailer.Command)
+	assert.Equal(t, "app", mailer.User)
+	// Lists are replaced, mappings merged key by key.
+	assert.Equal(t, []string{"--queue", "mail"}, mailer.Args)
+	assert.Equal(t, map[string]string{"LOG_FORMAT": "json", "REGION": "us", "ROLE": "worker"}, mailer.Environment)
+	assert.Equal(t, "always", string(mailer.Restart.Policy))
+	assert.Equal(t, 2*time.Second, mailer.Restart.Delay.Duration())
+
+	api := cfg.Services[1]
+	assert.Equal(t, "always", string(api.Restart.Policy))
+	assert.Equal(t, 10*time.Second, api.Restart.Delay.Duration())
+	assert.Empty(t, api.User)
+}
+
+// TestLoader_Parse_TemplateErrors tests that invalid templates are reported with their line.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Parse_TemplateErrors(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// yamlText is the parsed configuration.
+		yamlText string
+		// wantErr is the expected error.
+		wantErr error
+		// wantMsg is the expected error message fragment.
+		wantMsg string
+	}{
+		{
+			name: "unknown_template",
+			yamlText: `
+services:
+  - name: api
+    command: /bin/api
+    extends: missing
+`,
+			wantErr: yaml.ErrUnknownTemplate,
+			wantMsg: `line 5, column 14: unknown template "missing"`,
+		},
+		{
+			name: "cycle",
+			yamlText: `
+templates:
+  a:
+    extends: b
+  b:
+    extends: a
+services:
+  - name: api
+    command: /bin/api
+    extends: a
+`,
+			wantErr: yaml.ErrTemplateCycle,
+			wantMsg: `line 6`,
+		},
+		{
+			name: "template_not_mapping",
+			yamlText: `
+templates:
+  base: /bin/api
+services: []
+`,
+			wantErr: yaml.ErrInvalidTemplate,
+			wantMsg: `line 3`,
+		},
+		{
+			name: "extends_not_name",
+			yamlText: `
+templates:
+  base:
+    user: app
+services:
+  - name: api
+    command: /bin/api
+    extends: [base]
+`,
+			wantErr: yaml.ErrInvalidTemplate,
+			wantMsg: `line 8`,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := yaml.NewLoader().Parse([]byte(tt.yamlText))
+
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
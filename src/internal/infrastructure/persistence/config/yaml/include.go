@@ -0,0 +1,416 @@
+// Package yaml provides YAML configuration loading infrastructure.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
+)
+
+// includeKey is the top-level key listing the files merged into a configuration.
+const includeKey string = "include"
+
+// Include errors.
+var (
+	// ErrIncludeCycle is returned when a file includes itself through other files.
+	ErrIncludeCycle error = errors.New("include cycle")
+	// ErrInvalidInclude is returned when include is not a list of paths or a path matches no file.
+	ErrInvalidInclude error = errors.New("invalid include")
+)
+
+// sources records where the nodes of a configuration were read.
+type sources struct {
+	// files holds the file of each node, empty for bytes.
+	files map[*yaml.Node]string
+	// roots holds the root mapping of each file, in reading order.
+	roots []*yaml.Node
+	// keys provides the identities decrypting sops files.
+	keys sops.KeySource
+	// secrets holds the values decrypted from sops files.
+	secrets []string
+}
+
+// newSources creates an empty record.
+//
+// Params:
+//   - keys: the identities decrypting sops files.
+//
+// Returns:
+//   - *sources: the record.
+func newSources(keys sops.KeySource) *sources {
+	// return empty record
+	return &sources{files: make(map[*yaml.Node]string), keys: keys}
+}
+
+// record marks a node and its descendants as read from a file.
+//
+// Params:
+//   - file: the file path, empty for configurations parsed from bytes.
+//   - node: the root of the nodes read from the file.
+func (s *sources) record(file string, node *yaml.Node) {
+	s.files[node] = file
+	// Mark each descendant.
+	for _, child := range node.Content {
+		s.record(file, child)
+	}
+}
+
+// position returns where a node was read, as file:line:column, or line N,
+// column M when the file is unknown.
+//
+// Params:
+//   - node: the node.
+//
+// Returns:
+//   - string: the node position.
+func (s *sources) position(node *yaml.Node) string {
+	// Name the file when known.
+	if file := s.files[node]; file != "" {
+		// return file, line and column
+		return fmt.Sprintf("%s:%d:%d", file, node.Line, node.Column)
+	}
+	// return line and column only
+	return fmt.Sprintf("line %d, column %d", node.Line, node.Column)
+}
+
+// decodeError attributes an error decoding the merged configuration to the
+// first file whose own fields fail to decode.
+//
+// Params:
+//   - err: the error decoding the merged configuration.
+//
+// Returns:
+//   - error: the error of the faulty file, or err when no single file fails.
+func (s *sources) decodeError(err error) error {
+	// Decode each file alone.
+	for _, root := range s.roots {
+		var dto ConfigDTO
+		// Report the first faulty file.
+		if fileErr := root.Decode(&dto); fileErr != nil {
+			// return file error
+			return fmt.Errorf("parsing %s: %w", displayName(s.files[root]), fileErr)
+		}
+	}
+	// return merged error
+	return fmt.Errorf("parsing yaml: %w", err)
+}
+
+// readDocument parses a configuration and merges the files it includes.
+// Included files are merged in order before the including file: mappings
+// are merged key by key, lists are concatenated, and other values of the
+// including file take precedence. Files encrypted with sops are decrypted
+// first.
+//
+// Params:
+//   - data: the raw YAML of the configuration.
+//   - file: the configuration path, empty when parsed from bytes.
+//   - chain: the files including this one, outermost first.
+//   - src: the record of node files.
+//
+// Returns:
+//   - *yaml.Node: the merged document.
+//   - error: a parsing, reading or include error, with its file.
+func readDocument(data []byte, file string, chain []string, src *sources) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	// parse YAML bytes, resolving anchors.
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		// return YAML parsing error.
+		return nil, shared.WithCode(shared.CodeConfigInvalid, fmt.Errorf("parsing %s: %w", displayName(file), err))
+	}
+	// decrypt files encrypted with sops, keeping the values in memory only
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 && sops.IsEncrypted(doc.Content[0]) {
+		secrets, err := sops.Decrypt(doc.Content[0], src.keys)
+		// missing keys make the file unreadable
+		if err != nil {
+			// return decryption error
+			return nil, shared.WithCode(shared.CodeConfigUnreadable, fmt.Errorf("decrypting %s: %w", displayName(file), err))
+		}
+		src.secrets = append(src.secrets, secrets...)
+	}
+	src.record(file, &doc)
+
+	// Only mappings include files.
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// Leave the document to the decoder.
+		return &doc, nil
+	}
+	root := doc.Content[0]
+	src.roots = append(src.roots, root)
+	patterns, err := takeIncludes(root, src)
+	// Report malformed include lists.
+	if err != nil {
+		// return include error
+		return nil, shared.WithCode(shared.CodeConfigInvalid, err)
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: root.Tag, Line: root.Line, Column: root.Column}
+	including := append(slices.Clone(chain), file)
+	// Merge each included file before this one.
+	for _, pattern := range patterns {
+		paths, err := globInclude(pattern, file, src)
+		// Report patterns matching nothing.
+		if err != nil {
+			// return include error
+			return nil, err
+		}
+		// Merge each matched file in order.
+		for _, path := range paths {
+			included, err := readIncluded(path, including, src)
+			// Report errors of included files.
+			if err != nil {
+				// return include error
+				return nil, err
+			}
+			merged = mergeDocuments(merged, included)
+		}
+	}
+	doc.Content[0] = mergeDocuments(merged, root)
+	// return merged document
+	return &doc, nil
+}
+
+// takeIncludes removes the include key of a root mapping.
+//
+// Params:
+//   - root: the root mapping.
+//   - src: the record of node files.
+//
+// Returns:
+//   - []*yaml.Node: the include patterns.
+//   - error: ErrInvalidInclude when include is not a path or list of paths.
+func takeIncludes(root *yaml.Node, src *sources) ([]*yaml.Node, error) {
+	var patterns []*yaml.Node
+	content := make([]*yaml.Node, 0, len(root.Content))
+	// Split the include key from the other keys.
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		// Keep the other keys.
+		if root.Content[i].Value != includeKey {
+			content = append(content, root.Content[i], root.Content[i+1])
+			continue
+		}
+		value := resolveAlias(root.Content[i+1])
+		switch value.Kind {
+		// A single pattern.
+		case yaml.ScalarNode:
+			patterns = append(patterns, value)
+		// A list of patterns.
+		case yaml.SequenceNode:
+			patterns = append(patterns, value.Content...)
+		// Anything else is malformed.
+		default:
+			// return shape error
+			return nil, fmt.Errorf("%s: %w: expected a path or a list of paths", src.position(value), ErrInvalidInclude)
+		}
+	}
+	root.Content = content
+	// Each pattern is a path.
+	for _, pattern := range patterns {
+		// Reject nested lists and mappings.
+		if pattern = resolveAlias(pattern); pattern.Kind != yaml.ScalarNode || pattern.Value == "" {
+			// return shape error
+			return nil, fmt.Errorf("%s: %w: expected a path", src.position(pattern), ErrInvalidInclude)
+		}
+	}
+	// return include patterns
+	return patterns, nil
+}
+
+// globInclude returns the files matched by an include pattern, sorted.
+// Relative patterns are relative to the including file.
+//
+// Params:
+//   - pattern: the include pattern node.
+//   - file: the including file, empty when parsed from bytes.
+//   - src: the record of node files.
+//
+// Returns:
+//   - []string: the matched files.
+//   - error: ErrInvalidInclude for malformed patterns or a missing literal path.
+func globInclude(pattern *yaml.Node, file string, src *sources) ([]string, error) {
+	path := resolveAlias(pattern).Value
+	// Resolve relative paths against the including file.
+	if !filepath.IsAbs(path) && file != "" {
+		path = filepath.Join(filepath.Dir(file), path)
+	}
+	matches, err := filepath.Glob(path)
+	// Report malformed patterns.
+	if err != nil {
+		// return pattern error
+		return nil, shared.WithCode(shared.CodeConfigInvalid, fmt.Errorf("%s: %w %q: %w", src.position(pattern), ErrInvalidInclude, path, err))
+	}
+	// A glob may match nothing, a plain path must exist.
+	if len(matches) == 0 && !strings.ContainsAny(path, "*?[") {
+		// return missing file error
+		return nil, shared.WithCode(shared.CodeConfigUnreadable, fmt.Errorf("%s: %w: %s does not exist", src.position(pattern), ErrInvalidInclude, path))
+	}
+	// return matches in lexical order
+	return matches, nil
+}
+
+// readIncluded reads an included file and the files it includes.
+//
+// Params:
+//   - path: the included file.
+//   - chain: the files including this one, outermost first.
+//   - src: the record of node files.
+//
+// Returns:
+//   - *yaml.Node: the root mapping of the merged file, nil for empty files.
+//   - error: a cycle, reading or parsing error.
+func readIncluded(path string, chain []string, src *sources) (*yaml.Node, error) {
+	abs, err := filepath.Abs(path)
+	// Cycles are detected on absolute paths.
+	if err != nil {
+		// return path error
+		return nil, shared.WithCode(shared.CodeConfigUnreadable, fmt.Errorf("resolving include %s: %w", path, err))
+	}
+	// A file including itself loops forever.
+	if slices.ContainsFunc(chain, func(f string) bool { return sameFile(f, abs) }) {
+		// return cycle with the chain of files
+		return nil, shared.WithCode(shared.CodeConfigInvalid, fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(append(displayNames(chain), path), " -> ")))
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - include paths come from the trusted config
+	// Report unreadable files.
+	if err != nil {
+		// return read error
+		return nil, shared.WithCode(shared.CodeConfigUnreadable, fmt.Errorf("reading include: %w", err))
+	}
+	doc, err := readDocument(data, path, chain, src)
+	// Propagate errors of the included file.
+	if err != nil {
+		// return nested error
+		return nil, err
+	}
+	// Empty files add nothing.
+	if len(doc.Content) == 0 {
+		// return no content
+		return nil, nil
+	}
+	// An included file holds top-level fields.
+	if root := doc.Content[0]; root.Kind != yaml.MappingNode {
+		// return shape error
+		return nil, shared.WithCode(shared.CodeConfigInvalid, fmt.Errorf("%s: %w: included files must be mappings", src.position(root), ErrInvalidInclude))
+	}
+	// return root mapping
+	return doc.Content[0], nil
+}
+
+// mergeDocuments merges over into base: mappings are merged key by key,
+// lists are concatenated, and any other value of over replaces the one of
+// base. Neither input is modified.
+//
+// Params:
+//   - base: the mapping merged first, may be nil.
+//   - over: the mapping merged last.
+//
+// Returns:
+//   - *yaml.Node: the merged mapping.
+func mergeDocuments(base, over *yaml.Node) *yaml.Node {
+	// Nothing to merge into.
+	if base == nil {
+		// return over as is
+		return over
+	}
+	// Nothing to merge.
+	if over == nil {
+		// return base as is
+		return base
+	}
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: over.Tag, Line: over.Line, Column: over.Column}
+	index := make(map[string]int, len(base.Content)/2)
+	// Start from the base keys.
+	for i := 0; i+1 < len(base.Content); i += 2 {
+		index[base.Content[i].Value] = len(merged.Content) + 1
+		merged.Content = append(merged.Content, base.Content[i], base.Content[i+1])
+	}
+	// Merge each key of over.
+	for i := 0; i+1 < len(over.Content); i += 2 {
+		key, value := over.Content[i], over.Content[i+1]
+		at, ok := index[key.Value]
+		// New keys are appended.
+		if !ok {
+			index[key.Value] = len(merged.Content) + 1
+			merged.Content = append(merged.Content, key, value)
+			continue
+		}
+		b, o := resolveAlias(merged.Content[at]), resolveAlias(value)
+		switch {
+		// Mappings are merged key by key.
+		case b.Kind == yaml.MappingNode && o.Kind == yaml.MappingNode:
+			merged.Content[at] = mergeDocuments(b, o)
+		// Lists are concatenated.
+		case b.Kind == yaml.SequenceNode && o.Kind == yaml.SequenceNode:
+			list := &yaml.Node{Kind: yaml.SequenceNode, Tag: o.Tag, Style: o.Style, Line: o.Line, Column: o.Column}
+			list.Content = append(slices.Clone(b.Content), o.Content...)
+			merged.Content[at] = list
+		// Other values are replaced.
+		default:
+			merged.Content[at-1], merged.Content[at] = key, value
+		}
+	}
+	// return merged mapping
+	return merged
+}
+
+// sameFile reports whether two paths name the same file, following links.
+//
+// Params:
+//   - a: the first path.
+//   - b: the second path.
+//
+// Returns:
+//   - bool: true for the same file.
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	// Fall back to the paths for missing files.
+	if errA != nil || errB != nil {
+		// return path comparison
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	// return file identity
+	return os.SameFile(infoA, infoB)
+}
+
+// displayName names a configuration in errors.
+//
+// Params:
+//   - file: the configuration path, empty when parsed from bytes.
+//
+// Returns:
+//   - string: the path, or "config" for bytes.
+func displayName(file string) string {
+	// Bytes have no path.
+	if file == "" {
+		// return generic name
+		return "config"
+	}
+	// return path
+	return file
+}
+
+// displayNames names configurations in errors.
+//
+// Params:
+//   - files: the configuration paths.
+//
+// Returns:
+//   - []string: the display names.
+func displayNames(files []string) []string {
+	names := make([]string, len(files))
+	// Name each file.
+	for i, file := range files {
+		names[i] = displayName(file)
+	}
+	// return display names
+	return names
+}
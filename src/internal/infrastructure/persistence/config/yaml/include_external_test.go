@@ -0,0 +1,181 @@
+// Package yaml_test provides black-box tests for configuration includes.
+package yaml_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+)
+
+// writeConfigFiles writes configuration files into a temporary directory.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+//   - files: the file contents, by path relative to the directory
+//
+// Returns:
+//   - string: the directory
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+// TestLoader_Load_Include tests that included files are merged before the including file.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Load_Include(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yaml": `
+version: "1"
+include:
+  - conf.d/*.yaml
+  - missing.d/*.yaml
+logging:
+  base_dir: /var/log/main
+services:
+  - name: api
+    extends: web
+    command: /bin/api
+`,
+		"conf.d/10-templates.yaml": `
+templates:
+  web:
+    user: www
+logging:
+  base_dir: /var/log/included
+  defaults:
+    timestamp_format: unix
+`,
+		"conf.d/20-workers.yaml": `
+include: ../shared/cache.yaml
+services:
+  - name: worker
+    command: /bin/worker
+`,
+		"shared/cache.yaml": `
+services:
+  - name: cache
+    command: /bin/cache
+`,
+	})
+
+	cfg, err := yaml.NewLoader().Load(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(cfg.Services))
+	for _, svc := range cfg.Services {
+		names = append(names, svc.Name)
+	}
+	// Included services come first, in file order.
+	assert.Equal(t, []string{"cache", "worker", "api"}, names)
+	// Templates of included files apply to every file.
+	assert.Equal(t, "www", cfg.Services[2].User)
+	// The including file takes precedence, nested fields merge.
+	assert.Equal(t, "/var/log/main", cfg.Logging.BaseDir)
+	assert.Equal(t, "unix", cfg.Logging.Defaults.TimestampFormat)
+}
+
+// TestLoader_Load_IncludeErrors tests that include errors name the file at fault.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Load_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// files are the configuration files, config.yaml being loaded.
+		files map[string]string
+		// wantErr is the expected error, nil to check the message only.
+		wantErr error
+		// wantMsg is the expected error message fragment, with %s for the directory.
+		wantMsg string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"config.yaml": "include: a.yaml\n",
+				"a.yaml":      "include: b.yaml\n",
+				"b.yaml":      "include: a.yaml\n",
+			},
+			wantErr: yaml.ErrIncludeCycle,
+			wantMsg: "%[1]s/config.yaml -> %[1]s/a.yaml -> %[1]s/b.yaml -> %[1]s/a.yaml",
+		},
+		{
+			name:    "missing_file",
+			files:   map[string]string{"config.yaml": "\ninclude: [other.yaml]\n"},
+			wantErr: yaml.ErrInvalidInclude,
+			wantMsg: "%s/config.yaml:2:11: invalid include: %[1]s/other.yaml does not exist",
+		},
+		{
+			name:    "not_a_path",
+			files:   map[string]string{"config.yaml": "include:\n  file: a.yaml\n"},
+			wantErr: yaml.ErrInvalidInclude,
+			wantMsg: "%s/config.yaml:2",
+		},
+		{
+			name: "syntax_error",
+			files: map[string]string{
+				"config.yaml": "include: bad.yaml\n",
+				"bad.yaml":    "services: [\n",
+			},
+			wantMsg: "parsing %s/bad.yaml: yaml: line",
+		},
+		{
+			name: "type_error",
+			files: map[string]string{
+				"config.yaml": "include: bad.yaml\nservices:\n  - name: api\n    command: /bin/api\n",
+				"bad.yaml":    "services:\n  - name: worker\n    command: /bin/worker\n    oneshot: sometimes\n",
+			},
+			wantMsg: "parsing %s/bad.yaml: yaml: unmarshal errors:\n  line 4",
+		},
+		{
+			name: "invalid_service",
+			files: map[string]string{
+				"config.yaml":  "include: workers.yaml\nservices:\n  - name: api\n    command: /bin/api\n",
+				"workers.yaml": "services:\n  - name: worker\n    command: /bin/worker\n  - name: api\n    command: /bin/other\n",
+			},
+			wantErr: config.ErrDuplicateServiceName,
+			wantMsg: `validating config: %s/config.yaml:3:5: service "api": duplicate service name`,
+		},
+		{
+			name: "unknown_template",
+			files: map[string]string{
+				"config.yaml":  "include: workers.yaml\n",
+				"workers.yaml": "services:\n  - name: worker\n    extends: base\n",
+			},
+			wantErr: yaml.ErrUnknownTemplate,
+			wantMsg: `%s/workers.yaml:3:14: unknown template "base"`,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigFiles(t, tt.files)
+
+			_, err := yaml.NewLoader().Load(filepath.Join(dir, "config.yaml"))
+
+			require.Error(t, err)
+			// Check the sentinel when there is one.
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Contains(t, err.Error(), fmt.Sprintf(tt.wantMsg, dir))
+		})
+	}
+}
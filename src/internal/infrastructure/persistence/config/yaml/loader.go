@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
 )
 
 // Default configuration values.
@@ -43,15 +46,41 @@ var ErrNoConfigurationLoaded error = errors.New("no configuration loaded")
 // to support configuration reloading.
 type Loader struct {
 	lastPath string
+	// keys provides the identities decrypting sops files.
+	keys sops.KeySource
+	// host is the platform the service overrides are resolved for.
+	host platform
 }
 
-// NewLoader creates a new YAML configuration loader.
+// NewLoader creates a new YAML configuration loader. Files encrypted with
+// sops are decrypted with the age identities named by the environment, and
+// platform overrides are resolved for the platform the daemon runs on.
 //
 // Returns:
 //   - *Loader: a new loader instance ready to load configurations
 func NewLoader() *Loader {
 	// return initialized loader.
-	return &Loader{}
+	return &Loader{keys: sops.EnvKeys{}, host: hostPlatform()}
+}
+
+// SetKeySource replaces the source of the age identities decrypting sops
+// files, by default sops.EnvKeys.
+//
+// Params:
+//   - keys: the key source.
+func (l *Loader) SetKeySource(keys sops.KeySource) {
+	l.keys = keys
+}
+
+// SetPlatform replaces the platform the service overrides such as
+// command.linux_amd64 are resolved for, by default the one the daemon
+// runs on.
+//
+// Params:
+//   - goos: the operating system, as GOOS.
+//   - goarch: the architecture, as GOARCH.
+func (l *Loader) SetPlatform(goos, goarch string) {
+	l.host = platform{os: goos, arch: goarch}
 }
 
 // Load reads and parses a configuration file from the given path.
@@ -67,10 +96,10 @@ func (l *Loader) Load(path string) (*config.Config, error) {
 	// file read failed.
 	if err != nil {
 		// return wrapped error with context.
-		return nil, fmt.Errorf("reading config file: %w", err)
+		return nil, shared.WithCode(shared.CodeConfigUnreadable, fmt.Errorf("reading config file: %w", err))
 	}
 
-	cfg, err := l.Parse(data)
+	cfg, err := l.parse(data, path)
 	// parsing or validation failed.
 	if err != nil {
 		// return parse error to caller.
@@ -84,7 +113,8 @@ func (l *Loader) Load(path string) (*config.Config, error) {
 	return cfg, nil
 }
 
-// Parse parses configuration from YAML bytes.
+// Parse parses configuration from YAML bytes. Relative include paths are
+// resolved against the working directory.
 //
 // Params:
 //   - data: raw YAML configuration bytes
@@ -93,28 +123,244 @@ func (l *Loader) Load(path string) (*config.Config, error) {
 //   - *config.Config: parsed and validated configuration
 //   - error: any error during parsing or validation
 func (l *Loader) Parse(data []byte) (*config.Config, error) {
+	// parse without file name.
+	return l.parse(data, "")
+}
+
+// parse merges the included files, applies the service templates, then
+// decodes and validates the configuration. Errors name the file and line
+// at fault.
+//
+// Params:
+//   - data: raw YAML configuration bytes
+//   - path: the configuration path, empty when parsed from bytes
+//
+// Returns:
+//   - *config.Config: parsed and validated configuration
+//   - error: any error during parsing or validation
+func (l *Loader) parse(data []byte, path string) (*config.Config, error) {
+	doc, src, err := expand(data, path, l.keys, l.host)
+	// reading, parsing or expansion failed.
+	if err != nil {
+		// return include, parsing or template error.
+		return nil, err
+	}
+	// return decoded and validated configuration.
+	return decode(doc, src)
+}
+
+// expand merges the included files, resolves the platform overrides and
+// applies the service templates and defaults of a configuration.
+//
+// Params:
+//   - data: raw YAML configuration bytes
+//   - path: the configuration path, empty when parsed from bytes
+//   - keys: the identities decrypting sops files
+//   - host: the platform the overrides are resolved for
+//
+// Returns:
+//   - *yaml.Node: the expanded document
+//   - *sources: the record of node files and decrypted values
+//   - error: any include, decryption, parsing or template error
+func expand(data []byte, path string, keys sops.KeySource, host platform) (*yaml.Node, *sources, error) {
+	src := newSources(keys)
+
+	// parse the file and the files it includes.
+	doc, err := readDocument(data, path, nil, src)
+	// reading or parsing failed.
+	if err != nil {
+		// return include or parsing error.
+		return nil, nil, err
+	}
+
+	// drop extension blocks, resolve overrides and apply service templates and defaults.
+	if err := expandDocument(doc, src, host); err != nil {
+		// return template error.
+		return nil, nil, shared.WithCode(shared.CodeConfigInvalid, fmt.Errorf("expanding templates and defaults: %w", err))
+	}
+	// return expanded document.
+	return doc, src, nil
+}
+
+// decode decodes and validates an expanded document.
+//
+// Params:
+//   - doc: the expanded document
+//   - src: the record of node files
+//
+// Returns:
+//   - *config.Config: validated configuration
+//   - error: any decoding or validation error
+func decode(doc *yaml.Node, src *sources) (*config.Config, error) {
 	var dto ConfigDTO
 
-	// unmarshal YAML bytes into DTO.
-	if err := yaml.Unmarshal(data, &dto); err != nil {
-		// return YAML parsing error.
-		return nil, fmt.Errorf("parsing yaml: %w", err)
+	// decode expanded document into DTO.
+	if err := doc.Decode(&dto); err != nil {
+		// return YAML decoding error of the faulty file.
+		return nil, shared.WithCode(shared.CodeConfigInvalid, src.decodeError(err))
 	}
 
 	applyDefaults(&dto)
 
 	cfg := dto.ToDomain("")
+	markSecretEnv(cfg, src.secrets)
 
 	// validate domain configuration.
 	if err := config.Validate(cfg); err != nil {
-		// return validation error.
-		return nil, fmt.Errorf("validating config: %w", err)
+		// return every validation error, located at the setting at fault.
+		return nil, fmt.Errorf("validating config: %w", locateErrors(doc, src, err))
 	}
 
 	// return validated configuration.
 	return cfg, nil
 }
 
+// markSecretEnv lists, for each service, the environment variables holding
+// a value decrypted from a sops file, so dumps redact them whatever their name.
+//
+// Params:
+//   - cfg: the decoded configuration
+//   - secrets: the decrypted values
+func markSecretEnv(cfg *config.Config, secrets []string) {
+	// plain configurations hold no secret
+	if len(secrets) == 0 {
+		return
+	}
+	// mark the variables of each service
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		// compare each value with the decrypted ones
+		for key, value := range svc.Environment {
+			// keep values typed in clear
+			if slices.Contains(secrets, value) {
+				svc.SecretEnv = append(svc.SecretEnv, key)
+			}
+		}
+		slices.Sort(svc.SecretEnv)
+	}
+}
+
+// locateErrors prefixes each validation failure with the position of the
+// setting at fault: the key of a service or of a top-level section, or the
+// service definition when the failure names no key.
+//
+// Params:
+//   - doc: the expanded document
+//   - src: the record of node files
+//   - err: the validation error
+//
+// Returns:
+//   - error: the located failures, or err when it lists none.
+func locateErrors(doc *yaml.Node, src *sources, err error) error {
+	var validationErr *config.ValidationError
+	// Only listed failures can be located.
+	if !errors.As(err, &validationErr) || len(doc.Content) == 0 {
+		// return unlocated error.
+		return err
+	}
+	root := resolveAlias(doc.Content[0])
+	located := make([]error, 0, len(validationErr.Errs))
+	// Locate each failure.
+	for _, failure := range validationErr.Errs {
+		// Keep failures without position as they are.
+		if node := locateError(root, failure); node != nil {
+			failure = fmt.Errorf("%s: %w", src.position(node), failure)
+		}
+		located = append(located, failure)
+	}
+	// return located failures.
+	return shared.WithCode(shared.CodeConfigInvalid, &config.ValidationError{Errs: located})
+}
+
+// locateError returns the node of the setting at fault.
+//
+// Params:
+//   - root: the root mapping of the document
+//   - failure: a validation failure
+//
+// Returns:
+//   - *yaml.Node: the node at fault, nil when not found.
+func locateError(root *yaml.Node, failure error) *yaml.Node {
+	var fieldErr *config.FieldError
+	var svcErr *config.ServiceError
+	// Service failures point into the service definition.
+	if errors.As(failure, &svcErr) {
+		services := resolveAlias(mappingValue(root, "services"))
+		// Skip malformed lists.
+		if services == nil || svcErr.Index >= len(services.Content) {
+			// not found.
+			return nil
+		}
+		svc := resolveAlias(services.Content[svcErr.Index])
+		// Point at the failing key when the service sets it.
+		if errors.As(svcErr.Err, &fieldErr) {
+			if key := mappingKey(svc, fieldErr.Key); key != nil && key.Line > 0 {
+				// return key node.
+				return key
+			}
+		}
+		// return service node.
+		return svc
+	}
+	// Section failures point at the section key.
+	if errors.As(failure, &fieldErr) {
+		// return key node, nil when unset.
+		return mappingKey(root, fieldErr.Key)
+	}
+	// not found.
+	return nil
+}
+
+// mappingKey returns the key node of a mapping entry.
+//
+// Params:
+//   - mapping: the mapping node
+//   - key: the entry key
+//
+// Returns:
+//   - *yaml.Node: the key node, nil when absent or not a mapping.
+func mappingKey(mapping *yaml.Node, key string) *yaml.Node {
+	// Only mappings have keys.
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		// not a mapping.
+		return nil
+	}
+	// Search the key among entries.
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		// return matching key.
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i]
+		}
+	}
+	// absent key.
+	return nil
+}
+
+// mappingValue returns the value node of a mapping entry.
+//
+// Params:
+//   - mapping: the mapping node
+//   - key: the entry key
+//
+// Returns:
+//   - *yaml.Node: the value node, nil when absent or not a mapping.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	// Only mappings have values.
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		// not a mapping.
+		return nil
+	}
+	// Search the key among entries.
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		// return matching value.
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	// absent key.
+	return nil
+}
+
 // Reload reloads configuration from the last loaded path.
 //
 // Returns:
@@ -203,6 +449,11 @@ func applyServiceDefaults(svc *ServiceConfigDTO, logging *LoggingConfigDTO) {
 		svc.Logging.Stderr.Rotation = logging.Defaults.Rotation
 	}
 
+	// inherit log quota from global defaults.
+	if svc.Logging.MaxTotalSize == "" {
+		svc.Logging.MaxTotalSize = logging.Defaults.MaxTotalSize
+	}
+
 	// apply health check defaults.
 	for j := range svc.HealthChecks {
 		applyHealthCheckDefaults(&svc.HealthChecks[j])
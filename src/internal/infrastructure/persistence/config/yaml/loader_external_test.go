@@ -9,6 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
 	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
 )
 
@@ -225,6 +228,11 @@ func TestLoader_Parse(t *testing.T) {
 			data:    []byte("not: valid: yaml:"),
 			wantErr: true,
 		},
+		{
+			name:    "unknown_performance_template",
+			data:    []byte(testValidBasicConfig + "monitoring:\n  performance_template: turbo\n"),
+			wantErr: true,
+		},
 	}
 
 	// Iterate over test cases.
@@ -252,6 +260,40 @@ func TestLoader_Parse(t *testing.T) {
 	}
 }
 
+// TestLoader_Parse_ValidationErrors tests that every validation error is
+// reported at the line and column of the setting at fault.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Parse_ValidationErrors(t *testing.T) {
+	data := []byte(`version: "1"
+on_boot_failure: abort
+services:
+  - name: api
+    command: /bin/api
+    start_timeout: -5s
+  - name: worker
+    priority: urgent
+  - name: api
+    command: /bin/other
+`)
+
+	_, err := yaml.NewLoader().Parse(data)
+
+	require.Error(t, err)
+	assert.Equal(t, shared.CodeConfigInvalid, shared.CodeOf(err))
+	assert.ErrorIs(t, err, config.ErrInvalidStartTimeout)
+	assert.ErrorIs(t, err, config.ErrEmptyCommand)
+	assert.ErrorIs(t, err, config.ErrDuplicateServiceName)
+	assert.ErrorIs(t, err, config.ErrInvalidBootFailurePolicy)
+	assert.EqualError(t, err, `validating config: 5 errors:
+  - line 6, column 5: service "api": start_timeout must not be negative
+  - line 7, column 5: service "worker": service command is required
+  - line 8, column 5: service "worker": invalid priority class: "urgent"
+  - line 9, column 5: service "api": duplicate service name
+  - line 2, column 1: on_boot_failure must be continue or shutdown: "abort"`)
+}
+
 // TestLoader_Reload tests the Reload method.
 //
 // Params:
@@ -411,3 +453,41 @@ func TestLoader_ConfigPath(t *testing.T) {
 		})
 	}
 }
+
+// TestLoader_Load_encrypted tests loading a configuration encrypted with sops.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Load_encrypted(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     sops.KeySource
+		wantCode shared.Code
+	}{
+		{name: "matching_identity", keys: sops.KeyFile("testdata/age-keys.txt")},
+		{name: "no_identity", keys: sops.Keys{}, wantCode: shared.CodeConfigUnreadable},
+		{name: "unreadable_key_file", keys: sops.KeyFile("testdata/missing.txt"), wantCode: shared.CodeConfigUnreadable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := yaml.NewLoader()
+			loader.SetKeySource(tt.keys)
+
+			cfg, err := loader.Load("testdata/config.enc.yaml")
+
+			// verify refused decryption
+			if tt.wantCode != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, shared.CodeOf(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, cfg.Services, 1)
+			svc := cfg.Services[0]
+			assert.Equal(t, "/usr/bin/api", svc.Command)
+			assert.Equal(t, map[string]string{"DATABASE_URL": "postgres://api:hunter2@db/api", "LOG_LEVEL": "info"}, svc.Environment)
+			assert.Equal(t, []string{"DATABASE_URL", "LOG_LEVEL"}, svc.SecretEnv)
+		})
+	}
+}
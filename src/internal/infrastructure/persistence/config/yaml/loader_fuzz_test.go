@@ -0,0 +1,57 @@
+// Package yaml_test provides fuzz tests for the YAML configuration loader.
+package yaml_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+)
+
+// fuzzSeeds are configurations exercising templates, defaults, anchors and
+// validation failures.
+var fuzzSeeds []string = []string{
+	testValidMinimalConfig,
+	testValidBasicConfig,
+	"not: valid: yaml:",
+	"services: {}",
+	"services:\n  - *missing\n",
+	"x-base: &base\n  command: /bin/app\nservices:\n  - <<: *base\n    name: app\n",
+	"templates:\n  web:\n    command: /bin/web\nservices:\n  - name: a\n    extends: web\n",
+	"templates:\n  a:\n    extends: b\n  b:\n    extends: a\nservices:\n  - name: x\n    extends: a\n",
+	"defaults:\n  restart:\n    policy: always\n  groups:\n    web:\n      user: www\nservices:\n  - name: a\n    command: /bin/a\n    defaults_group: web\n",
+	"on_boot_failure: abort\nservices:\n  - name: a\n    start_timeout: -1s\n  - name: a\n",
+	"services:\n  - name: a\n    command: /bin/a\n    listeners:\n      - name: http\n        port: 99999\n        protocol: sctp\n",
+}
+
+// FuzzLoader_Parse checks that no input makes the loader panic, and that
+// every rejected input is reported as a configuration error.
+//
+// Params:
+//   - f: fuzzing context
+func FuzzLoader_Parse(f *testing.F) {
+	// Seed the corpus.
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Includes would read arbitrary files of the host.
+		if bytes.Contains(data, []byte("include")) {
+			t.Skip()
+		}
+		cfg, err := yaml.NewLoader().Parse(data)
+		// Accepted inputs yield a configuration.
+		if err == nil {
+			if cfg == nil {
+				t.Fatal("nil configuration without error")
+			}
+			return
+		}
+		// Rejected inputs are configuration errors.
+		if code := shared.CodeOf(err); code != shared.CodeConfigInvalid {
+			t.Fatalf("error code %q, want %q: %v", code, shared.CodeConfigInvalid, err)
+		}
+	})
+}
@@ -161,6 +161,7 @@ func Test_applyServiceDefaults(t *testing.T) {
 		expectedStdoutFile string
 		expectedStderrFile string
 		expectedMaxRetries int
+		expectedQuota      string
 	}{
 		{
 			name: "service_gets_default_log_files",
@@ -316,6 +317,33 @@ func Test_applyServiceDefaults(t *testing.T) {
 			expectedStderrFile: "ts-svc.err.log",
 			expectedMaxRetries: defaultMaxRetries,
 		},
+		{
+			name: "service_inherits_log_quota",
+			svc: ServiceConfigDTO{
+				Name: "quota-svc",
+			},
+			logging: LoggingConfigDTO{
+				Defaults: LogDefaultsDTO{MaxTotalSize: "2GB"},
+			},
+			expectedStdoutFile: "quota-svc.out.log",
+			expectedStderrFile: "quota-svc.err.log",
+			expectedMaxRetries: defaultMaxRetries,
+			expectedQuota:      "2GB",
+		},
+		{
+			name: "service_preserves_custom_log_quota",
+			svc: ServiceConfigDTO{
+				Name:    "quota-svc-2",
+				Logging: ServiceLoggingDTO{MaxTotalSize: "500MB"},
+			},
+			logging: LoggingConfigDTO{
+				Defaults: LogDefaultsDTO{MaxTotalSize: "2GB"},
+			},
+			expectedStdoutFile: "quota-svc-2.out.log",
+			expectedStderrFile: "quota-svc-2.err.log",
+			expectedMaxRetries: defaultMaxRetries,
+			expectedQuota:      "500MB",
+		},
 	}
 
 	// Iterate over test cases.
@@ -328,6 +356,7 @@ func Test_applyServiceDefaults(t *testing.T) {
 			assert.Equal(t, tt.expectedStdoutFile, tt.svc.Logging.Stdout.File)
 			assert.Equal(t, tt.expectedStderrFile, tt.svc.Logging.Stderr.File)
 			assert.Equal(t, tt.expectedMaxRetries, tt.svc.Restart.MaxRetries)
+			assert.Equal(t, tt.expectedQuota, tt.svc.Logging.MaxTotalSize)
 		})
 	}
 }
@@ -4,32 +4,56 @@
 package yaml
 
 import (
+	"time"
+
 	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Default PSI alert selectors.
+const (
+	// defaultPressureScope is the PSI scope used when none is configured.
+	defaultPressureScope string = "some"
+	// defaultPressureWindow is the PSI averaging window used when none is configured.
+	defaultPressureWindow string = "avg10"
 )
 
 // MetricsConfigDTO is the YAML representation of metrics configuration.
 // It provides granular control over metrics collection to reduce resource consumption.
 type MetricsConfigDTO struct {
-	Enabled     *bool                       `yaml:"enabled,omitempty"`     // global metrics toggle
-	CPU         *CPUMetricsConfigDTO        `yaml:"cpu,omitempty"`         // CPU metrics configuration
-	Memory      *MemoryMetricsConfigDTO     `yaml:"memory,omitempty"`      // memory metrics configuration
-	Load        *LoadMetricsConfigDTO       `yaml:"load,omitempty"`        // load average configuration
-	Disk        *DiskMetricsConfigDTO       `yaml:"disk,omitempty"`        // disk metrics configuration
-	Network     *NetworkMetricsConfigDTO    `yaml:"network,omitempty"`     // network metrics configuration
-	Connections *ConnectionMetricsConfigDTO `yaml:"connections,omitempty"` // connection metrics configuration
-	Thermal     *ThermalMetricsConfigDTO    `yaml:"thermal,omitempty"`     // thermal zone configuration
-	Process     *ProcessMetricsConfigDTO    `yaml:"process,omitempty"`     // process metrics configuration
-	IO          *IOMetricsConfigDTO         `yaml:"io,omitempty"`          // I/O metrics configuration
-	Quota       *QuotaMetricsConfigDTO      `yaml:"quota,omitempty"`       // quota metrics configuration
-	Container   *ContainerMetricsConfigDTO  `yaml:"container,omitempty"`   // container metrics configuration
-	Runtime     *RuntimeMetricsConfigDTO    `yaml:"runtime,omitempty"`     // runtime metrics configuration
+	Enabled        *bool                       `yaml:"enabled,omitempty"`         // global metrics toggle
+	Interval       Duration                    `yaml:"interval,omitempty"`        // collection interval override
+	CPU            *CPUMetricsConfigDTO        `yaml:"cpu,omitempty"`             // CPU metrics configuration
+	Memory         *MemoryMetricsConfigDTO     `yaml:"memory,omitempty"`          // memory metrics configuration
+	Load           *LoadMetricsConfigDTO       `yaml:"load,omitempty"`            // load average configuration
+	Disk           *DiskMetricsConfigDTO       `yaml:"disk,omitempty"`            // disk metrics configuration
+	Network        *NetworkMetricsConfigDTO    `yaml:"network,omitempty"`         // network metrics configuration
+	Connections    *ConnectionMetricsConfigDTO `yaml:"connections,omitempty"`     // connection metrics configuration
+	Thermal        *ThermalMetricsConfigDTO    `yaml:"thermal,omitempty"`         // thermal zone configuration
+	Process        *ProcessMetricsConfigDTO    `yaml:"process,omitempty"`         // process metrics configuration
+	IO             *IOMetricsConfigDTO         `yaml:"io,omitempty"`              // I/O metrics configuration
+	Quota          *QuotaMetricsConfigDTO      `yaml:"quota,omitempty"`           // quota metrics configuration
+	Container      *ContainerMetricsConfigDTO  `yaml:"container,omitempty"`       // container metrics configuration
+	Runtime        *RuntimeMetricsConfigDTO    `yaml:"runtime,omitempty"`         // runtime metrics configuration
+	PressureAlerts []PressureAlertDTO          `yaml:"pressure_alerts,omitempty"` // PSI alert rules per service cgroup
+}
+
+// PressureAlertDTO is the YAML representation of a PSI alert rule.
+// Scope defaults to "some" and window defaults to "avg10".
+type PressureAlertDTO struct {
+	Resource  string  `yaml:"resource"`         // PSI resource (cpu, memory, io)
+	Scope     string  `yaml:"scope,omitempty"`  // PSI scope (some, full)
+	Window    string  `yaml:"window,omitempty"` // averaging window (avg10, avg60, avg300)
+	Threshold float64 `yaml:"threshold"`        // stall percentage that fires the alert
 }
 
 // CPUMetricsConfigDTO is the YAML representation of CPU metrics configuration.
 // It controls CPU usage metrics and pressure stall information collection.
 type CPUMetricsConfigDTO struct {
-	Enabled  *bool `yaml:"enabled,omitempty"`  // enable CPU metrics collection
-	Pressure *bool `yaml:"pressure,omitempty"` // enable PSI (pressure stall information)
+	Enabled           *bool    `yaml:"enabled,omitempty"`            // enable CPU metrics collection
+	Pressure          *bool    `yaml:"pressure,omitempty"`           // enable PSI (pressure stall information)
+	ThrottleThreshold *float64 `yaml:"throttle_threshold,omitempty"` // throttled wall time percentage before degraded (0 disables)
+	PercentMode       string   `yaml:"percent_mode,omitempty"`       // per_core or normalized service CPU usage
 }
 
 // MemoryMetricsConfigDTO is the YAML representation of memory metrics configuration.
@@ -37,6 +61,7 @@ type CPUMetricsConfigDTO struct {
 type MemoryMetricsConfigDTO struct {
 	Enabled  *bool `yaml:"enabled,omitempty"`  // enable memory metrics collection
 	Pressure *bool `yaml:"pressure,omitempty"` // enable PSI (pressure stall information)
+	Smaps    *bool `yaml:"smaps,omitempty"`    // enable PSS/USS, swap and major faults from smaps_rollup
 }
 
 // LoadMetricsConfigDTO is the YAML representation of load metrics configuration.
@@ -120,12 +145,16 @@ type RuntimeMetricsConfigDTO struct {
 //   - config.MetricsConfig: the converted domain metrics configuration
 func (m *MetricsConfigDTO) ToDomain(template config.MetricsTemplate) config.MetricsConfig {
 	// start with template as base configuration.
-	result := resolveTemplate(template)
+	result := config.MetricsConfigForTemplate(template)
 
 	// apply global enabled override if specified.
 	if m.Enabled != nil {
 		result.Enabled = *m.Enabled
 	}
+	// apply collection interval override if specified (negative values fail validation).
+	if m.Interval != 0 {
+		result.Interval = shared.FromTimeDuration(time.Duration(m.Interval))
+	}
 
 	// apply CPU category overrides if specified.
 	if m.CPU != nil {
@@ -175,6 +204,10 @@ func (m *MetricsConfigDTO) ToDomain(template config.MetricsTemplate) config.Metr
 	if m.Runtime != nil {
 		result.Runtime = m.Runtime.toDomain(result.Runtime)
 	}
+	// convert PSI alert rules.
+	for i := range m.PressureAlerts {
+		result.PressureAlerts = append(result.PressureAlerts, m.PressureAlerts[i].ToDomain())
+	}
 
 	// return merged configuration.
 	return result
@@ -198,6 +231,14 @@ func (c *CPUMetricsConfigDTO) toDomain(base config.CPUMetricsConfig) config.CPUM
 	if c.Pressure != nil {
 		result.Pressure = *c.Pressure
 	}
+	// override throttle threshold if specified.
+	if c.ThrottleThreshold != nil {
+		result.ThrottleThreshold = *c.ThrottleThreshold
+	}
+	// override percent mode if specified.
+	if c.PercentMode != "" {
+		result.PercentMode = config.CPUPercentMode(c.PercentMode)
+	}
 	// return merged configuration.
 	return result
 }
@@ -220,6 +261,10 @@ func (m *MemoryMetricsConfigDTO) toDomain(base config.MemoryMetricsConfig) confi
 	if m.Pressure != nil {
 		result.Pressure = *m.Pressure
 	}
+	// override smaps if specified.
+	if m.Smaps != nil {
+		result.Smaps = *m.Smaps
+	}
 	// return merged configuration.
 	return result
 }
@@ -448,32 +493,26 @@ func (r *RuntimeMetricsConfigDTO) toDomain(base config.RuntimeMetricsConfig) con
 	return result
 }
 
-// resolveTemplate resolves a template name to a MetricsConfig.
-// Unknown templates default to standard.
-//
-// Params:
-//   - template: the template name to resolve
+// ToDomain converts PressureAlertDTO to domain PressureAlertRule.
+// Missing scope and window default to "some" and "avg10".
 //
 // Returns:
-//   - config.MetricsConfig: the resolved metrics configuration
-func resolveTemplate(template config.MetricsTemplate) config.MetricsConfig {
-	// resolve template to configuration.
-	switch template {
-	// return minimal configuration for minimal template.
-	case config.MetricsTemplateMinimal:
-		// use minimal configuration factory.
-		return config.MinimalMetricsConfig()
-	// return full configuration for full template.
-	case config.MetricsTemplateFull:
-		// use full configuration factory.
-		return config.FullMetricsConfig()
-	// return standard configuration for standard/custom templates.
-	case config.MetricsTemplateStandard, config.MetricsTemplateCustom:
-		// use standard configuration factory.
-		return config.StandardMetricsConfig()
-	// default to standard configuration for unknown templates.
-	default:
-		// use standard configuration as safe fallback.
-		return config.StandardMetricsConfig()
-	}
+//   - config.PressureAlertRule: the converted alert rule
+func (p *PressureAlertDTO) ToDomain() config.PressureAlertRule {
+	rule := config.PressureAlertRule{
+		Resource:  p.Resource,
+		Scope:     p.Scope,
+		Window:    p.Window,
+		Threshold: p.Threshold,
+	}
+	// default scope to some.
+	if rule.Scope == "" {
+		rule.Scope = defaultPressureScope
+	}
+	// default window to 10-second average.
+	if rule.Window == "" {
+		rule.Window = defaultPressureWindow
+	}
+	// return converted rule.
+	return rule
 }
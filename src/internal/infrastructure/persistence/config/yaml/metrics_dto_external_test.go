@@ -2,7 +2,9 @@ package yaml_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/kodflow/daemon/internal/domain/config"
 	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -259,3 +261,225 @@ monitoring:
 		})
 	}
 }
+
+// TestMetricsConfigDTO_ToDomain_ThrottleThreshold verifies CPU throttle threshold overrides.
+func TestMetricsConfigDTO_ToDomain_ThrottleThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		yamlText string
+		want     float64
+	}{
+		{
+			name: "default threshold from template",
+			yamlText: `
+monitoring:
+  performance_template: "standard"
+`,
+			want: 25.0,
+		},
+		{
+			name: "explicit threshold override",
+			yamlText: `
+monitoring:
+  metrics:
+    cpu:
+      throttle_threshold: 40
+`,
+			want: 40.0,
+		},
+		{
+			name: "threshold disabled",
+			yamlText: `
+monitoring:
+  metrics:
+    cpu:
+      throttle_threshold: 0
+`,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configDTO yaml.ConfigDTO
+			err := goyaml.Unmarshal([]byte(tt.yamlText), &configDTO)
+			require.NoError(t, err)
+
+			mon := configDTO.Monitoring.ToDomain()
+
+			assert.InDelta(t, tt.want, mon.Metrics.CPU.ThrottleThreshold, 0.001)
+		})
+	}
+}
+
+// TestMetricsConfigDTO_ToDomain_PercentMode verifies CPU percent mode overrides.
+func TestMetricsConfigDTO_ToDomain_PercentMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		yamlText string
+		want     config.CPUPercentMode
+	}{
+		{
+			name: "per core by default",
+			yamlText: `
+monitoring:
+  performance_template: "standard"
+`,
+			want: "",
+		},
+		{
+			name: "normalized override",
+			yamlText: `
+monitoring:
+  metrics:
+    cpu:
+      percent_mode: normalized
+`,
+			want: config.CPUPercentNormalized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configDTO yaml.ConfigDTO
+			err := goyaml.Unmarshal([]byte(tt.yamlText), &configDTO)
+			require.NoError(t, err)
+
+			mon := configDTO.Monitoring.ToDomain()
+
+			assert.Equal(t, tt.want, mon.Metrics.CPU.PercentMode)
+		})
+	}
+}
+
+// TestMetricsConfigDTO_ToDomain_Smaps verifies the smaps switch is off unless configured.
+func TestMetricsConfigDTO_ToDomain_Smaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		yamlText string
+		want     bool
+	}{
+		{
+			name: "off by default",
+			yamlText: `
+monitoring:
+  performance_template: "full"
+`,
+			want: false,
+		},
+		{
+			name: "enabled override",
+			yamlText: `
+monitoring:
+  metrics:
+    memory:
+      smaps: true
+`,
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configDTO yaml.ConfigDTO
+			err := goyaml.Unmarshal([]byte(tt.yamlText), &configDTO)
+			require.NoError(t, err)
+
+			mon := configDTO.Monitoring.ToDomain()
+
+			assert.Equal(t, tt.want, mon.Metrics.Memory.Smaps)
+			assert.True(t, mon.Metrics.Memory.Enabled)
+		})
+	}
+}
+
+// TestMetricsConfigDTO_ToDomain_PressureAlerts verifies PSI alert rule conversion and defaults.
+func TestMetricsConfigDTO_ToDomain_PressureAlerts(t *testing.T) {
+	yamlText := `
+monitoring:
+  metrics:
+    pressure_alerts:
+      - resource: memory
+        scope: full
+        window: avg60
+        threshold: 20
+      - resource: io
+        threshold: 35
+`
+	var configDTO yaml.ConfigDTO
+	err := goyaml.Unmarshal([]byte(yamlText), &configDTO)
+	require.NoError(t, err)
+
+	mon := configDTO.Monitoring.ToDomain()
+
+	require.Len(t, mon.Metrics.PressureAlerts, 2)
+	assert.Equal(t, "memory", mon.Metrics.PressureAlerts[0].Resource)
+	assert.Equal(t, "full", mon.Metrics.PressureAlerts[0].Scope)
+	assert.Equal(t, "avg60", mon.Metrics.PressureAlerts[0].Window)
+	assert.InDelta(t, 20.0, mon.Metrics.PressureAlerts[0].Threshold, 0.001)
+	// Defaults applied when scope and window are omitted.
+	assert.Equal(t, "some", mon.Metrics.PressureAlerts[1].Scope)
+	assert.Equal(t, "avg10", mon.Metrics.PressureAlerts[1].Window)
+}
+
+// TestMetricsConfigDTO_ToDomain_Interval verifies template intervals and overrides.
+func TestMetricsConfigDTO_ToDomain_Interval(t *testing.T) {
+	tests := []struct {
+		name         string
+		yamlText     string
+		wantInterval time.Duration
+		wantTemplate config.MetricsTemplate
+	}{
+		{
+			name: "minimal template interval",
+			yamlText: `
+monitoring:
+  performance_template: "minimal"
+`,
+			wantInterval: 10 * time.Second,
+			wantTemplate: config.MetricsTemplateMinimal,
+		},
+		{
+			name: "full template interval",
+			yamlText: `
+monitoring:
+  performance_template: "full"
+`,
+			wantInterval: 2 * time.Second,
+			wantTemplate: config.MetricsTemplateFull,
+		},
+		{
+			name: "explicit interval overrides template",
+			yamlText: `
+monitoring:
+  performance_template: "minimal"
+  metrics:
+    interval: 30s
+`,
+			wantInterval: 30 * time.Second,
+			wantTemplate: config.MetricsTemplateMinimal,
+		},
+		{
+			name: "unknown template is kept for validation",
+			yamlText: `
+monitoring:
+  performance_template: "turbo"
+`,
+			wantInterval: 5 * time.Second,
+			wantTemplate: config.MetricsTemplate("turbo"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configDTO yaml.ConfigDTO
+			err := goyaml.Unmarshal([]byte(tt.yamlText), &configDTO)
+			require.NoError(t, err)
+
+			mon := configDTO.Monitoring.ToDomain()
+
+			assert.Equal(t, tt.wantInterval, mon.Metrics.Interval.Duration())
+			assert.Equal(t, tt.wantTemplate, mon.Metrics.Template)
+		})
+	}
+}
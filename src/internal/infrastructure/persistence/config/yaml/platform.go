@@ -0,0 +1,175 @@
+// Package yaml provides YAML configuration loading infrastructure.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Separators of the platform overrides.
+const (
+	// platformSeparator splits a field from the platform it overrides, as in command.linux.
+	platformSeparator string = "."
+	// archSeparator splits the operating system from the architecture, as in linux_amd64.
+	archSeparator string = "_"
+)
+
+// ErrInvalidPlatform is returned when a platform override names an unknown
+// operating system or architecture, or a field that cannot be overridden.
+var ErrInvalidPlatform error = errors.New("invalid platform override")
+
+// knownOS lists the GOOS values a platform override may name.
+var knownOS []string = []string{
+	"aix", "android", "darwin", "dragonfly", "freebsd", "illumos", "ios", "js",
+	"linux", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows",
+}
+
+// knownArch lists the GOARCH values a platform override may name.
+var knownArch []string = []string{
+	"386", "amd64", "arm", "arm64", "loong64", "mips", "mips64", "mips64le",
+	"mipsle", "ppc64", "ppc64le", "riscv64", "s390x", "wasm",
+}
+
+// platform is the operating system and architecture service fields are resolved for.
+type platform struct {
+	// os is the GOOS value.
+	os string
+	// arch is the GOARCH value.
+	arch string
+}
+
+// hostPlatform returns the platform the daemon runs on.
+//
+// Returns:
+//   - platform: the GOOS and GOARCH of the daemon.
+func hostPlatform() platform {
+	// return build platform
+	return platform{os: runtime.GOOS, arch: runtime.GOARCH}
+}
+
+// override is a field value for one platform.
+type override struct {
+	// field is the overridden service field.
+	field *yaml.Node
+	// value is the value on the platform.
+	value *yaml.Node
+	// specific is true for an operating system and architecture pair.
+	specific bool
+}
+
+// resolve replaces the fields of a service, template or defaults mapping by
+// their overrides for the platform, and drops the overrides of other
+// platforms. A field.os override applies over the field, and a
+// field.os_arch override over both; mappings are merged key by key like
+// templates, any other value is replaced.
+//
+// Params:
+//   - node: the service fields.
+//   - src: the record of node files.
+//
+// Returns:
+//   - *yaml.Node: the fields for the platform, node itself without overrides.
+//   - error: ErrInvalidPlatform with the position of the faulty key.
+func (p platform) resolve(node *yaml.Node, src *sources) (*yaml.Node, error) {
+	// Only mappings hold overrides.
+	if node.Kind != yaml.MappingNode {
+		// Leave other nodes to the decoder.
+		return node, nil
+	}
+	resolved := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag, Line: node.Line, Column: node.Column}
+	var overrides []override
+	// Split the overrides from the fields.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		field, selector, found := strings.Cut(key.Value, platformSeparator)
+		// Keep the fields as is.
+		if !found {
+			resolved.Content = append(resolved.Content, key, node.Content[i+1])
+			continue
+		}
+		matches, specific, err := p.match(field, selector)
+		// Report the key position.
+		if err != nil {
+			// Refuse typos that would silently drop the override.
+			return nil, fmt.Errorf("%s: %w %q: %w", src.position(key), ErrInvalidPlatform, key.Value, err)
+		}
+		// Drop the overrides of other platforms.
+		if matches {
+			fieldKey := &yaml.Node{Kind: yaml.ScalarNode, Tag: key.Tag, Value: field, Line: key.Line, Column: key.Column}
+			overrides = append(overrides, override{field: fieldKey, value: node.Content[i+1], specific: specific})
+		}
+	}
+	// Nothing overridden.
+	if len(overrides) == 0 && len(resolved.Content) == len(node.Content) {
+		// Return the mapping unchanged.
+		return node, nil
+	}
+	// Apply the operating system overrides before the more specific ones.
+	slices.SortStableFunc(overrides, func(a, b override) int {
+		// order by specificity
+		return boolOrder(a.specific) - boolOrder(b.specific)
+	})
+	// Apply each override over the field.
+	for _, over := range overrides {
+		resolved = mergeMappings(resolved, &yaml.Node{
+			Kind: yaml.MappingNode, Tag: resolved.Tag, Line: resolved.Line, Column: resolved.Column,
+			Content: []*yaml.Node{over.field, over.value},
+		})
+	}
+	src.files[resolved] = src.files[node]
+	// return fields for the platform
+	return resolved, nil
+}
+
+// match reports whether a platform selector applies to p.
+//
+// Params:
+//   - field: the overridden field.
+//   - selector: the os or os_arch selector.
+//
+// Returns:
+//   - bool: true when the override applies.
+//   - bool: true for an os_arch selector.
+//   - error: an identity field, or an unknown operating system or architecture.
+func (p platform) match(field, selector string) (bool, bool, error) {
+	// Identity keys belong to the service on every platform.
+	if field == "" || slices.Contains(identityKeys, field) {
+		// Report the field.
+		return false, false, fmt.Errorf("field %q cannot be overridden", field)
+	}
+	goos, goarch, specific := strings.Cut(selector, archSeparator)
+	// The operating system must exist.
+	if !slices.Contains(knownOS, goos) {
+		// Report the operating system.
+		return false, false, fmt.Errorf("unknown operating system %q", goos)
+	}
+	// The architecture must exist when named.
+	if specific && !slices.Contains(knownArch, goarch) {
+		// Report the architecture.
+		return false, false, fmt.Errorf("unknown architecture %q", goarch)
+	}
+	// return whether the selector names the platform
+	return goos == p.os && (!specific || goarch == p.arch), specific, nil
+}
+
+// boolOrder orders false before true.
+//
+// Params:
+//   - b: the value.
+//
+// Returns:
+//   - int: 0 for false, 1 for true.
+func boolOrder(b bool) int {
+	// true sorts last
+	if b {
+		// return last
+		return 1
+	}
+	// return first
+	return 0
+}
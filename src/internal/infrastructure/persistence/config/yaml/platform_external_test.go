@@ -0,0 +1,173 @@
+// Package yaml_test provides black-box tests for platform overrides.
+package yaml_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+)
+
+// testPlatformConfig overrides fields by operating system and architecture,
+// in a template, the defaults and a service.
+const testPlatformConfig string = `
+version: "1"
+
+defaults:
+  user: app
+  user.darwin: _app
+
+templates:
+  agent:
+    command: /usr/local/bin/agent
+    command.linux_arm64: /opt/agent/arm64/agent
+
+services:
+  - name: agent
+    extends: agent
+    environment:
+      LOG_FORMAT: json
+    environment.darwin:
+      DYLD_LIBRARY_PATH: /opt/agent/lib
+  - name: api
+    command: /usr/local/bin/api
+    command.linux: /usr/bin/api
+    command.linux_amd64: /usr/bin/api-amd64
+    args.windows: ["--service"]
+`
+
+// TestLoader_Parse_Platform tests that fields take the overrides of the platform.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Parse_Platform(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// goos is the operating system of the loader.
+		goos string
+		// goarch is the architecture of the loader.
+		goarch string
+		// wantUser is the expected user of both services.
+		wantUser string
+		// wantAgent is the expected command of agent.
+		wantAgent string
+		// wantEnv is the expected environment of agent.
+		wantEnv map[string]string
+		// wantAPI is the expected command of api.
+		wantAPI string
+		// wantArgs is the expected arguments of api.
+		wantArgs []string
+	}{
+		{
+			name: "linux_amd64", goos: "linux", goarch: "amd64",
+			wantUser: "app", wantAgent: "/usr/local/bin/agent", wantEnv: map[string]string{"LOG_FORMAT": "json"},
+			wantAPI: "/usr/bin/api-amd64",
+		},
+		{
+			name: "linux_arm64", goos: "linux", goarch: "arm64",
+			wantUser: "app", wantAgent: "/opt/agent/arm64/agent", wantEnv: map[string]string{"LOG_FORMAT": "json"},
+			wantAPI: "/usr/bin/api",
+		},
+		{
+			name: "darwin_arm64", goos: "darwin", goarch: "arm64",
+			wantUser: "_app", wantAgent: "/usr/local/bin/agent",
+			wantEnv: map[string]string{"LOG_FORMAT": "json", "DYLD_LIBRARY_PATH": "/opt/agent/lib"},
+			wantAPI: "/usr/local/bin/api",
+		},
+		{
+			name: "windows_amd64", goos: "windows", goarch: "amd64",
+			wantUser: "app", wantAgent: "/usr/local/bin/agent", wantEnv: map[string]string{"LOG_FORMAT": "json"},
+			wantAPI: "/usr/local/bin/api", wantArgs: []string{"--service"},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			loader := yaml.NewLoader()
+			loader.SetPlatform(tt.goos, tt.goarch)
+
+			cfg, err := loader.Parse([]byte(testPlatformConfig))
+			require.NoError(t, err)
+			require.Len(t, cfg.Services, 2)
+
+			agent, api := cfg.Services[0], cfg.Services[1]
+			assert.Equal(t, tt.wantUser, agent.User)
+			assert.Equal(t, tt.wantUser, api.User)
+			assert.Equal(t, tt.wantAgent, agent.Command)
+			assert.Equal(t, tt.wantEnv, agent.Environment)
+			assert.Equal(t, tt.wantAPI, api.Command)
+			assert.Equal(t, tt.wantArgs, api.Args)
+		})
+	}
+}
+
+// TestLoader_Parse_PlatformErrors tests that invalid overrides are reported with their line.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Parse_PlatformErrors(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// yamlText is the parsed configuration.
+		yamlText string
+		// wantMsg is the expected error message fragment.
+		wantMsg string
+	}{
+		{
+			name: "unknown_os",
+			yamlText: `
+services:
+  - name: api
+    command: /bin/api
+    command.linx: /usr/bin/api
+`,
+			wantMsg: `line 5, column 5: invalid platform override "command.linx": unknown operating system "linx"`,
+		},
+		{
+			name: "unknown_arch",
+			yamlText: `
+templates:
+  base:
+    command.linux_x86_64: /bin/api
+services: []
+`,
+			wantMsg: `unknown architecture "x86_64"`,
+		},
+		{
+			name: "identity_field",
+			yamlText: `
+services:
+  - name: api
+    name.darwin: api-mac
+    command: /bin/api
+`,
+			wantMsg: `field "name" cannot be overridden`,
+		},
+		{
+			name: "defaults",
+			yamlText: `
+defaults:
+  user.macos: app
+services: []
+`,
+			wantMsg: `line 3`,
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := yaml.NewLoader().Parse([]byte(tt.yamlText))
+
+			require.ErrorIs(t, err, yaml.ErrInvalidPlatform)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Package yaml provides YAML configuration loading infrastructure.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
+)
+
+// configFileMode is the permission of configuration files written from scratch.
+const configFileMode os.FileMode = 0o644
+
+// ErrEncryptedConfig is returned when a sops-encrypted configuration would
+// be replaced by a plain one.
+var ErrEncryptedConfig error = errors.New("encrypted configuration can only be replaced by an encrypted one")
+
+// Render returns the effective configuration of a file as a single YAML
+// document: included files merged, platform overrides resolved, templates
+// and defaults applied, and extension blocks dropped. The configuration is validated first. Values
+// decrypted from sops files are redacted wherever they appear.
+//
+// Params:
+//   - path: absolute or relative path to the YAML configuration file
+//
+// Returns:
+//   - []byte: the effective configuration
+//   - error: any error during reading, parsing, validation or encoding
+func (l *Loader) Render(path string) ([]byte, error) {
+	// render with secrets redacted.
+	return l.render(path, true)
+}
+
+// RenderDecrypted returns the effective configuration of a file like Render,
+// but keeps the values decrypted from sops files. It is meant for the daemon
+// API only, which compares them with the running configuration, and must
+// never be written or printed.
+//
+// Params:
+//   - path: absolute or relative path to the YAML configuration file
+//
+// Returns:
+//   - []byte: the effective configuration, secrets in clear
+//   - error: any error during reading, parsing, validation or encoding
+func (l *Loader) RenderDecrypted(path string) ([]byte, error) {
+	// render with secrets in clear.
+	return l.render(path, false)
+}
+
+// render returns the effective configuration of a file.
+//
+// Params:
+//   - path: absolute or relative path to the YAML configuration file
+//   - redact: whether decrypted values are redacted
+//
+// Returns:
+//   - []byte: the effective configuration
+//   - error: any error during reading, parsing, validation or encoding
+func (l *Loader) render(path string, redact bool) ([]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - config path is trusted input
+	// file read failed.
+	if err != nil {
+		// return wrapped error with context.
+		return nil, shared.WithCode(shared.CodeConfigUnreadable, fmt.Errorf("reading config file: %w", err))
+	}
+
+	doc, src, err := expand(data, path, l.keys, l.host)
+	// reading, parsing or expansion failed.
+	if err != nil {
+		// return include, parsing or template error.
+		return nil, err
+	}
+
+	// refuse configurations the daemon would not load.
+	if _, err := decode(doc, src); err != nil {
+		// return decoding or validation error.
+		return nil, err
+	}
+
+	effective := inlineAliases(doc)
+	// hide decrypted values.
+	if redact {
+		redactSecrets(effective, src.secrets)
+	}
+	out, err := yaml.Marshal(effective)
+	// encoding failed.
+	if err != nil {
+		// return wrapped encoding error.
+		return nil, fmt.Errorf("encoding effective config: %w", err)
+	}
+	// return effective configuration.
+	return out, nil
+}
+
+// Replace validates a configuration and writes it over a file atomically,
+// keeping the permissions of the replaced file. A file encrypted with sops
+// is only replaced by another encrypted file, so secrets never reach the
+// disk in clear and redacted renderings do not overwrite them.
+//
+// Params:
+//   - path: the configuration file to replace
+//   - data: raw YAML configuration bytes
+//
+// Returns:
+//   - error: ErrEncryptedConfig, or any validation or write error
+func (l *Loader) Replace(path string, data []byte) error {
+	// refuse configurations the daemon would not load.
+	if _, err := l.parse(data, path); err != nil {
+		// return parsing or validation error.
+		return err
+	}
+
+	// keep encrypted files encrypted.
+	if current, err := os.ReadFile(path); err == nil && isEncrypted(current) && !isEncrypted(data) { // #nosec G304 - config path is trusted input
+		// return refused replacement.
+		return shared.WithCode(shared.CodeConfigInvalid, fmt.Errorf("%w: %s", ErrEncryptedConfig, path))
+	}
+
+	mode := configFileMode
+	// keep the permissions of the replaced file.
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	// temporary file creation failed.
+	if err != nil {
+		// return wrapped error with context.
+		return fmt.Errorf("writing config file: %w", err)
+	}
+	_, err = tmp.Write(data)
+	err = errors.Join(err, tmp.Chmod(mode), tmp.Close())
+	// move the complete file into place.
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	// remove the partial file on failure.
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		// return wrapped error with context.
+		return fmt.Errorf("writing config file: %w", err)
+	}
+	// configuration replaced.
+	return nil
+}
+
+// isEncrypted reports whether raw YAML was encrypted with sops.
+//
+// Params:
+//   - data: raw YAML configuration bytes
+//
+// Returns:
+//   - bool: true if the document carries sops metadata.
+func isEncrypted(data []byte) bool {
+	var doc yaml.Node
+	// unparsable files are not encrypted ones.
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		// report plain file.
+		return false
+	}
+	// report sops metadata.
+	return sops.IsEncrypted(doc.Content[0])
+}
+
+// redactSecrets replaces in place the scalars equal to a decrypted value.
+//
+// Params:
+//   - node: the document to redact.
+//   - secrets: the decrypted values.
+func redactSecrets(node *yaml.Node, secrets []string) {
+	// redact matching scalars.
+	if node.Kind == yaml.ScalarNode && slices.Contains(secrets, node.Value) {
+		node.Value, node.Tag, node.Style = process.RedactedValue, "!!str", 0
+	}
+	// redact each child.
+	for _, child := range node.Content {
+		redactSecrets(child, secrets)
+	}
+}
+
+// inlineAliases returns a copy of a node where each alias is replaced by
+// the node it points to, so the document stands without the anchors of
+// the dropped extension blocks.
+//
+// Params:
+//   - node: the node to copy.
+//
+// Returns:
+//   - *yaml.Node: the copy without aliases and anchors.
+func inlineAliases(node *yaml.Node) *yaml.Node {
+	// Nothing to copy.
+	if node == nil {
+		// return nil node
+		return nil
+	}
+	copied := *resolveAlias(node)
+	copied.Anchor = ""
+	copied.Content = make([]*yaml.Node, 0, len(copied.Content))
+	// copy each child
+	for _, child := range resolveAlias(node).Content {
+		copied.Content = append(copied.Content, inlineAliases(child))
+	}
+	// return copy
+	return &copied
+}
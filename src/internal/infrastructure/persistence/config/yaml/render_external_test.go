@@ -0,0 +1,146 @@
+// Package yaml_test provides black-box tests for the effective configuration rendering.
+package yaml_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/sops"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+)
+
+// TestLoader_Render tests that the rendered configuration loads like the original files.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Render(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yaml": `
+version: "1"
+include: conf.d/*.yaml
+x-env: &env
+  APP_ENV: staging
+defaults:
+  user: app
+services:
+  - name: api
+    extends: web
+    command: /bin/api
+    environment: *env
+`,
+		"conf.d/templates.yaml": `
+templates:
+  web:
+    labels:
+      team: payments
+`,
+	})
+	loader := yaml.NewLoader()
+	path := filepath.Join(dir, "config.yaml")
+
+	rendered, err := loader.Render(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(rendered), "include")
+	assert.NotContains(t, string(rendered), "templates")
+	assert.NotContains(t, string(rendered), "x-env")
+
+	want, err := loader.Load(path)
+	require.NoError(t, err)
+	got, err := loader.Parse(rendered)
+	require.NoError(t, err)
+	got.ConfigPath = want.ConfigPath
+	assert.Equal(t, want, got)
+}
+
+// TestLoader_Render_invalid tests that invalid configurations are not rendered.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Render_invalid(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yaml": "version: \"1\"\nservices:\n  - name: api\n",
+	})
+
+	_, err := yaml.NewLoader().Render(filepath.Join(dir, "config.yaml"))
+	assert.Error(t, err)
+
+	_, err = yaml.NewLoader().Render(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+// TestLoader_Replace tests that only valid configurations replace a file.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Replace(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yaml": "version: \"1\"\nservices:\n  - name: api\n    command: /bin/api\n",
+	})
+	loader := yaml.NewLoader()
+	path := filepath.Join(dir, "config.yaml")
+
+	err := loader.Replace(path, []byte("version: \"1\"\nservices:\n  - name: web\n"))
+	require.Error(t, err)
+	cfg, err := loader.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "api", cfg.Services[0].Name)
+
+	require.NoError(t, loader.Replace(path, []byte("version: \"1\"\nservices:\n  - name: web\n    command: /bin/web\n")))
+	cfg, err = loader.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "web", cfg.Services[0].Name)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+// TestLoader_Render_encrypted tests that decrypted values are redacted from the rendering.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Render_encrypted(t *testing.T) {
+	loader := yaml.NewLoader()
+	loader.SetKeySource(sops.KeyFile("testdata/age-keys.txt"))
+
+	rendered, err := loader.Render("testdata/config.enc.yaml")
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(rendered), "hunter2")
+	assert.NotContains(t, string(rendered), "ENC[")
+	assert.NotContains(t, string(rendered), "sops:")
+	assert.Contains(t, string(rendered), "DATABASE_URL: '"+process.RedactedValue+"'")
+	assert.Contains(t, string(rendered), "/usr/bin/api")
+
+	decrypted, err := loader.RenderDecrypted("testdata/config.enc.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(decrypted), "postgres://api:hunter2@db/api")
+	assert.NotContains(t, string(decrypted), "sops:")
+}
+
+// TestLoader_Replace_encrypted tests that encrypted files are only replaced by encrypted ones.
+//
+// Params:
+//   - t: testing context for assertions and error reporting
+func TestLoader_Replace_encrypted(t *testing.T) {
+	encrypted, err := os.ReadFile("testdata/config.enc.yaml")
+	require.NoError(t, err)
+	dir := writeConfigFiles(t, map[string]string{"config.yaml": string(encrypted)})
+	loader := yaml.NewLoader()
+	loader.SetKeySource(sops.KeyFile("testdata/age-keys.txt"))
+	path := filepath.Join(dir, "config.yaml")
+
+	rendered, err := loader.Render(path)
+	require.NoError(t, err)
+	err = loader.Replace(path, rendered)
+	require.ErrorIs(t, err, yaml.ErrEncryptedConfig)
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted, current)
+
+	require.NoError(t, loader.Replace(path, encrypted))
+}
@@ -78,16 +78,382 @@ func (d *Duration) MarshalText() ([]byte, error) {
 // ConfigDTO is the YAML representation of the root configuration.
 // It serves as the data transfer object for parsing the main configuration file.
 type ConfigDTO struct {
-	Version    string              `yaml:"version"`              // configuration schema version
-	Logging    LoggingConfigDTO    `yaml:"logging"`              // logging configuration
-	Monitoring MonitoringConfigDTO `yaml:"monitoring,omitempty"` // monitoring configuration
-	Services   []ServiceConfigDTO  `yaml:"services"`             // service definitions
+	Version         string              `yaml:"version"`                    // configuration schema version
+	Logging         LoggingConfigDTO    `yaml:"logging"`                    // logging configuration
+	Monitoring      MonitoringConfigDTO `yaml:"monitoring,omitempty"`       // monitoring configuration
+	OnBootFailure   string              `yaml:"on_boot_failure,omitempty"`  // boot failure policy (continue/shutdown)
+	Incidents       IncidentConfigDTO   `yaml:"incidents,omitempty"`        // failure correlation settings
+	Notifications   NotificationsDTO    `yaml:"notifications,omitempty"`    // event delivery to external channels
+	Admission       AdmissionDTO        `yaml:"admission,omitempty"`        // service starts against host capacity
+	Shedding        SheddingDTO         `yaml:"shedding,omitempty"`         // low-priority stops under memory pressure
+	LeakCheck       LeakCheckDTO        `yaml:"leak_check,omitempty"`       // reconciliation of executor resources
+	RestartBudget   RestartBudgetDTO    `yaml:"restart_budget,omitempty"`   // restarts across all services
+	SafeMode        SafeModeDTO         `yaml:"safe_mode,omitempty"`        // restart storm detection
+	TrafficSampling TrafficSamplingDTO  `yaml:"traffic_sampling,omitempty"` // connection sampling into a dependency map
+	Hooks           EventHooksDTO       `yaml:"hooks,omitempty"`            // commands run on events
+	Watchers        []WatcherDTO        `yaml:"watchers,omitempty"`         // commands emitting custom events
+	Control         ControlDTO          `yaml:"control,omitempty"`          // changes accepted by the control plane
+	ShutdownReport  ShutdownReportDTO   `yaml:"shutdown_report,omitempty"`  // final statistics written at shutdown
+	HostShutdown    HostShutdownDTO     `yaml:"host_shutdown,omitempty"`    // advance notice of host shutdown
+	Services        []ServiceConfigDTO  `yaml:"services"`                   // service definitions
+}
+
+// IncidentConfigDTO is the YAML representation of failure correlation settings.
+// It groups close failures of several services into incidents.
+type IncidentConfigDTO struct {
+	Window      Duration `yaml:"window,omitempty"`       // maximum gap between correlated failures (disabled when unset)
+	MinServices int      `yaml:"min_services,omitempty"` // distinct failing services opening an incident
+}
+
+// ToDomain converts IncidentConfigDTO to domain IncidentConfig.
+//
+// Returns:
+//   - config.IncidentConfig: the converted domain incident configuration
+func (i *IncidentConfigDTO) ToDomain() config.IncidentConfig {
+	// return converted incident configuration
+	return config.IncidentConfig{
+		Window:      shared.Duration(i.Window),
+		MinServices: i.MinServices,
+	}
+}
+
+// AdmissionDTO is the YAML representation of admission control settings.
+type AdmissionDTO struct {
+	Overcommit float64 `yaml:"overcommit,omitempty"` // share of host capacity services may reserve (1 when unset)
+	Action     string  `yaml:"action,omitempty"`     // refuse or warn (refuse when unset)
+}
+
+// ToDomain converts AdmissionDTO to domain AdmissionConfig.
+//
+// Returns:
+//   - config.AdmissionConfig: the converted domain admission configuration
+func (a *AdmissionDTO) ToDomain() config.AdmissionConfig {
+	// return converted admission configuration
+	return config.AdmissionConfig{
+		Overcommit: a.Overcommit,
+		Action:     config.AdmissionAction(a.Action),
+	}
+}
+
+// SheddingDTO is the YAML representation of shedding under memory pressure.
+type SheddingDTO struct {
+	Threshold float64  `yaml:"threshold,omitempty"` // host memory stall percentage stopping services (disabled when unset)
+	Resume    float64  `yaml:"resume,omitempty"`    // stall percentage starting shed services (half the threshold when unset)
+	Scope     string   `yaml:"scope,omitempty"`     // PSI scope: some or full (some when unset)
+	Window    string   `yaml:"window,omitempty"`    // PSI window: avg10, avg60 or avg300 (avg10 when unset)
+	Interval  Duration `yaml:"interval,omitempty"`  // pressure check period (10s when unset)
+}
+
+// ToDomain converts SheddingDTO to domain SheddingConfig.
+//
+// Returns:
+//   - config.SheddingConfig: the converted domain shedding configuration
+func (s *SheddingDTO) ToDomain() config.SheddingConfig {
+	// return converted shedding configuration
+	return config.SheddingConfig{
+		Threshold: s.Threshold,
+		Resume:    s.Resume,
+		Scope:     s.Scope,
+		Window:    s.Window,
+		Interval:  shared.Duration(s.Interval),
+	}
+}
+
+// LeakCheckDTO is the YAML representation of the executor resource reconciliation.
+type LeakCheckDTO struct {
+	Interval Duration `yaml:"interval,omitempty"` // reconciliation period (1m when unset)
+	Reap     bool     `yaml:"reap,omitempty"`     // kill leaked processes and remove leaked cgroups
+}
+
+// ToDomain converts LeakCheckDTO to domain LeakCheckConfig.
+//
+// Returns:
+//   - config.LeakCheckConfig: the converted domain leak check configuration
+func (l *LeakCheckDTO) ToDomain() config.LeakCheckConfig {
+	// return converted leak check configuration
+	return config.LeakCheckConfig{
+		Interval: shared.Duration(l.Interval),
+		Reap:     l.Reap,
+	}
+}
+
+// RestartBudgetDTO is the YAML representation of the daemon-wide restart rate limit.
+type RestartBudgetDTO struct {
+	PerMinute int `yaml:"per_minute,omitempty"` // restarts per minute across all services (unlimited when unset)
+	Burst     int `yaml:"burst,omitempty"`      // restarts allowed at once (per_minute when unset)
+}
+
+// ToDomain converts RestartBudgetDTO to domain RestartBudgetConfig.
+//
+// Returns:
+//   - config.RestartBudgetConfig: the converted domain restart budget configuration
+func (r *RestartBudgetDTO) ToDomain() config.RestartBudgetConfig {
+	// return converted restart budget configuration
+	return config.RestartBudgetConfig{
+		PerMinute: r.PerMinute,
+		Burst:     r.Burst,
+	}
+}
+
+// SafeModeDTO is the YAML representation of the restart storm detection.
+type SafeModeDTO struct {
+	Restarts int      `yaml:"restarts,omitempty"` // restarts across all services entering safe mode (disabled when unset)
+	Window   Duration `yaml:"window,omitempty"`   // window the restarts are counted over (1m when unset)
+}
+
+// ToDomain converts SafeModeDTO to domain SafeModeConfig.
+//
+// Returns:
+//   - config.SafeModeConfig: the converted domain safe mode configuration
+func (s *SafeModeDTO) ToDomain() config.SafeModeConfig {
+	// return converted safe mode configuration
+	return config.SafeModeConfig{
+		Restarts: s.Restarts,
+		Window:   shared.Duration(s.Window),
+	}
+}
+
+// TrafficSamplingDTO is the YAML representation of the connection sampling of services.
+type TrafficSamplingDTO struct {
+	Interval     Duration `yaml:"interval,omitempty"`      // sampling period (disabled when unset)
+	MaxEndpoints int      `yaml:"max_endpoints,omitempty"` // endpoints outside the daemon kept per service (64 when unset)
+}
+
+// ToDomain converts TrafficSamplingDTO to domain TrafficSamplingConfig.
+//
+// Returns:
+//   - config.TrafficSamplingConfig: the converted domain traffic sampling configuration
+func (t *TrafficSamplingDTO) ToDomain() config.TrafficSamplingConfig {
+	// return converted traffic sampling configuration
+	return config.TrafficSamplingConfig{
+		Interval:     shared.Duration(t.Interval),
+		MaxEndpoints: t.MaxEndpoints,
+	}
+}
+
+// ControlDTO is the YAML representation of the control plane settings.
+type ControlDTO struct {
+	ReadOnly bool `yaml:"read_only,omitempty"` // refuse mutating API requests and CLI commands
+}
+
+// ToDomain converts ControlDTO to domain ControlConfig.
+//
+// Returns:
+//   - config.ControlConfig: the converted domain control configuration
+func (c *ControlDTO) ToDomain() config.ControlConfig {
+	// return converted control configuration
+	return config.ControlConfig{
+		ReadOnly: c.ReadOnly,
+	}
+}
+
+// ShutdownReportDTO is the YAML representation of the shutdown report settings.
+type ShutdownReportDTO struct {
+	Path    string   `yaml:"path,omitempty"`    // JSON file the report is written to
+	URL     string   `yaml:"url,omitempty"`     // http(s) endpoint the report is posted to
+	Timeout Duration `yaml:"timeout,omitempty"` // delivery bound (10s when unset)
+}
+
+// ToDomain converts ShutdownReportDTO to domain ShutdownReportConfig.
+//
+// Returns:
+//   - config.ShutdownReportConfig: the converted domain shutdown report configuration
+func (r *ShutdownReportDTO) ToDomain() config.ShutdownReportConfig {
+	// return converted shutdown report configuration
+	return config.ShutdownReportConfig{
+		Path:    r.Path,
+		URL:     r.URL,
+		Timeout: shared.Duration(r.Timeout),
+	}
+}
+
+// HostShutdownDTO is the YAML representation of the host shutdown notice settings.
+type HostShutdownDTO struct {
+	Logind     bool   `yaml:"logind,omitempty"`      // systemd-logind delay inhibitor lock and shutdown signal
+	ACPISocket string `yaml:"acpi_socket,omitempty"` // acpid socket reporting power button presses
+}
+
+// ToDomain converts HostShutdownDTO to domain HostShutdownConfig.
+//
+// Returns:
+//   - config.HostShutdownConfig: the converted domain host shutdown configuration
+func (h *HostShutdownDTO) ToDomain() config.HostShutdownConfig {
+	// return converted host shutdown configuration
+	return config.HostShutdownConfig{
+		Logind:     h.Logind,
+		ACPISocket: h.ACPISocket,
+	}
+}
+
+// WatcherDTO is the YAML representation of a command emitting custom events.
+type WatcherDTO struct {
+	Name           string   `yaml:"name"`                       // watcher name
+	Command        string   `yaml:"command"`                    // executable run on each check
+	Args           []string `yaml:"args,omitempty"`             // command arguments
+	Interval       Duration `yaml:"interval,omitempty"`         // period between runs (30s when unset)
+	Timeout        Duration `yaml:"timeout,omitempty"`          // bound of each run (10s when unset)
+	Service        string   `yaml:"service,omitempty"`          // service the events are reported for (daemon-wide when unset)
+	OnFailure      string   `yaml:"on_failure,omitempty"`       // event emitted when the command starts failing
+	OnRecovery     string   `yaml:"on_recovery,omitempty"`      // event emitted when the command succeeds again
+	OnOutputChange string   `yaml:"on_output_change,omitempty"` // event emitted when the output changes
+}
+
+// ToDomain converts WatcherDTO to domain WatcherConfig.
+//
+// Returns:
+//   - config.WatcherConfig: the converted domain watcher configuration
+func (w *WatcherDTO) ToDomain() config.WatcherConfig {
+	// return converted watcher configuration
+	return config.WatcherConfig{
+		Name:           w.Name,
+		Command:        w.Command,
+		Args:           w.Args,
+		Interval:       shared.Duration(w.Interval),
+		Timeout:        shared.Duration(w.Timeout),
+		Service:        w.Service,
+		OnFailure:      w.OnFailure,
+		OnRecovery:     w.OnRecovery,
+		OnOutputChange: w.OnOutputChange,
+	}
+}
+
+// EventHooksDTO is the YAML representation of the commands run on events.
+type EventHooksDTO struct {
+	MaxConcurrent int            `yaml:"max_concurrent,omitempty"` // hooks running at once (4 when unset)
+	Commands      []EventHookDTO `yaml:"commands,omitempty"`       // hooks and their event types
+}
+
+// EventHookDTO is the YAML representation of a command run on events.
+type EventHookDTO struct {
+	Events  []string `yaml:"events,omitempty"`  // event types running the hook (every event when unset)
+	Command string   `yaml:"command"`           // executable, the event as JSON on its standard input
+	Args    []string `yaml:"args,omitempty"`    // command arguments
+	Timeout Duration `yaml:"timeout,omitempty"` // bound of each run (30s when unset)
+}
+
+// ToDomain converts EventHooksDTO to domain EventHooksConfig.
+//
+// Returns:
+//   - config.EventHooksConfig: the converted domain event hooks configuration
+func (e *EventHooksDTO) ToDomain() config.EventHooksConfig {
+	hooks := config.EventHooksConfig{MaxConcurrent: e.MaxConcurrent}
+	// convert each hook
+	for i := range e.Commands {
+		hook := &e.Commands[i]
+		hooks.Commands = append(hooks.Commands, config.EventHook{
+			Events:  hook.Events,
+			Command: hook.Command,
+			Args:    hook.Args,
+			Timeout: shared.Duration(hook.Timeout),
+		})
+	}
+	// return converted event hooks configuration
+	return hooks
+}
+
+// NotificationsDTO is the YAML representation of notification settings.
+// It configures webhook channels, digests, rate limits and escalations.
+type NotificationsDTO struct {
+	Channels    []NotificationChannelDTO `yaml:"channels,omitempty"`    // webhook channels
+	RateLimit   NotificationRateLimitDTO `yaml:"rate_limit,omitempty"`  // global message rate limit
+	Escalations []EscalationRuleDTO      `yaml:"escalations,omitempty"` // repeated event escalations
+	Heartbeats  []HeartbeatDTO           `yaml:"heartbeats,omitempty"`  // dead man's switch pings
+	Spool       NotificationSpoolDTO     `yaml:"spool,omitempty"`       // disk spool of undelivered messages
+}
+
+// NotificationChannelDTO is the YAML representation of a notification channel.
+type NotificationChannelDTO struct {
+	Name     string            `yaml:"name"`               // channel name
+	URL      string            `yaml:"url"`                // webhook URL
+	Events   []string          `yaml:"events,omitempty"`   // event types sent (all when empty)
+	Digest   Duration          `yaml:"digest,omitempty"`   // batching period (each event sent when unset)
+	Selector map[string]string `yaml:"selector,omitempty"` // labels events must carry (all when empty)
+}
+
+// NotificationRateLimitDTO is the YAML representation of the notification rate limit.
+type NotificationRateLimitDTO struct {
+	Max    int      `yaml:"max,omitempty"`    // messages per period (unlimited when unset)
+	Period Duration `yaml:"period,omitempty"` // rate limit window (1m when unset)
+}
+
+// EscalationRuleDTO is the YAML representation of an escalation rule.
+type EscalationRuleDTO struct {
+	Event   string   `yaml:"event"`   // event type counted
+	Count   int      `yaml:"count"`   // occurrences tolerated before escalating
+	Within  Duration `yaml:"within"`  // counting window
+	Channel string   `yaml:"channel"` // channel receiving the escalation
+}
+
+// NotificationSpoolDTO is the YAML representation of the notification spool.
+type NotificationSpoolDTO struct {
+	Dir           string   `yaml:"dir,omitempty"`            // spool directory (disabled when unset)
+	MaxSize       string   `yaml:"max_size,omitempty"`       // size cap (64MB when unset)
+	RetryInterval Duration `yaml:"retry_interval,omitempty"` // replay interval (30s when unset)
+}
+
+// HeartbeatDTO is the YAML representation of a heartbeat endpoint.
+type HeartbeatDTO struct {
+	URL      string   `yaml:"url"`                // endpoint pinged
+	Interval Duration `yaml:"interval"`           // time between pings
+	Services []string `yaml:"services,omitempty"` // services required healthy
+}
+
+// ToDomain converts NotificationsDTO to domain NotificationsConfig.
+//
+// Returns:
+//   - config.NotificationsConfig: the converted domain notification configuration
+func (n *NotificationsDTO) ToDomain() config.NotificationsConfig {
+	channels := make([]config.NotificationChannel, 0, len(n.Channels))
+	// convert each channel
+	for _, ch := range n.Channels {
+		channels = append(channels, config.NotificationChannel{
+			Name:     ch.Name,
+			URL:      ch.URL,
+			Events:   ch.Events,
+			Digest:   shared.Duration(ch.Digest),
+			Selector: ch.Selector,
+		})
+	}
+	escalations := make([]config.EscalationRule, 0, len(n.Escalations))
+	// convert each escalation rule
+	for _, rule := range n.Escalations {
+		escalations = append(escalations, config.EscalationRule{
+			Event:   rule.Event,
+			Count:   rule.Count,
+			Within:  shared.Duration(rule.Within),
+			Channel: rule.Channel,
+		})
+	}
+	heartbeats := make([]config.HeartbeatConfig, 0, len(n.Heartbeats))
+	// convert each heartbeat
+	for _, hb := range n.Heartbeats {
+		heartbeats = append(heartbeats, config.HeartbeatConfig{
+			URL:      hb.URL,
+			Interval: shared.Duration(hb.Interval),
+			Services: hb.Services,
+		})
+	}
+	// return converted notification configuration
+	return config.NotificationsConfig{
+		Channels: channels,
+		RateLimit: config.NotificationRateLimit{
+			Max:    n.RateLimit.Max,
+			Period: shared.Duration(n.RateLimit.Period),
+		},
+		Escalations: escalations,
+		Heartbeats:  heartbeats,
+		Spool: config.NotificationSpool{
+			Dir:           n.Spool.Dir,
+			MaxSize:       n.Spool.MaxSize,
+			RetryInterval: shared.Duration(n.Spool.RetryInterval),
+		},
+	}
 }
 
 // MonitoringConfigDTO is the YAML representation of monitoring configuration.
 // It configures external target monitoring including discovery and static targets.
 type MonitoringConfigDTO struct {
-	PerformanceTemplate string                `yaml:"performance_template,omitempty"` // metrics template (minimal/standard/full)
+	PerformanceTemplate string                `yaml:"performance_template,omitempty"` // metrics template (minimal/standard/full/custom)
 	Metrics             *MetricsConfigDTO     `yaml:"metrics,omitempty"`              // granular metrics configuration
 	Defaults            MonitoringDefaultsDTO `yaml:"defaults,omitempty"`             // default probe settings
 	Discovery           DiscoveryConfigDTO    `yaml:"discovery,omitempty"`            // service discovery configuration
@@ -198,47 +564,304 @@ type TargetConfigDTO struct {
 // ServiceConfigDTO is the YAML representation of a service configuration.
 // It contains all settings needed to define and manage a supervised config.
 type ServiceConfigDTO struct {
-	Name             string            `yaml:"name"`                    // service name
-	Command          string            `yaml:"command"`                 // command to execute
-	Args             []string          `yaml:"args,omitempty"`          // command arguments
-	User             string            `yaml:"user,omitempty"`          // user to run as
-	Group            string            `yaml:"group,omitempty"`         // group to run as
-	WorkingDirectory string            `yaml:"working_dir,omitempty"`   // working directory
-	Environment      map[string]string `yaml:"environment,omitempty"`   // environment variables
-	Restart          RestartConfigDTO  `yaml:"restart"`                 // restart policy
-	HealthChecks     []HealthCheckDTO  `yaml:"health_checks,omitempty"` // health check definitions
-	Listeners        []ListenerDTO     `yaml:"listeners,omitempty"`     // network listeners
-	Logging          ServiceLoggingDTO `yaml:"logging,omitempty"`       // logging configuration
-	DependsOn        []string          `yaml:"depends_on,omitempty"`    // service dependencies
-	Oneshot          bool              `yaml:"oneshot,omitempty"`       // one-shot execution mode
+	Name                  string            `yaml:"name"`                               // service name
+	Command               string            `yaml:"command"`                            // command to execute
+	Args                  []string          `yaml:"args,omitempty"`                     // command arguments
+	User                  string            `yaml:"user,omitempty"`                     // user to run as
+	Group                 string            `yaml:"group,omitempty"`                    // group to run as
+	WorkingDirectory      string            `yaml:"working_dir,omitempty"`              // working directory
+	Environment           map[string]string `yaml:"environment,omitempty"`              // environment variables
+	Timezone              string            `yaml:"timezone,omitempty"`                 // IANA time zone, set as TZ
+	Locale                string            `yaml:"locale,omitempty"`                   // locale, set as LANG and LC_ALL
+	Secrets               []SecretDTO       `yaml:"secrets,omitempty"`                  // environment variables read from files
+	OnSecretChange        string            `yaml:"on_secret_change,omitempty"`         // reload or restart (events only when empty)
+	SELinuxContext        string            `yaml:"selinux_context,omitempty"`          // SELinux exec context
+	AppArmorProfile       string            `yaml:"apparmor_profile,omitempty"`         // AppArmor exec profile
+	ReadOnlyPaths         []string          `yaml:"read_only_paths,omitempty"`          // paths mounted read-only
+	MaskedPaths           []string          `yaml:"masked_paths,omitempty"`             // paths hidden from the service
+	TmpfsPaths            []string          `yaml:"tmpfs_paths,omitempty"`              // paths covered by a private tmpfs
+	PIDNamespace          bool              `yaml:"pid_namespace,omitempty"`            // own PID namespace under a minimal init
+	Egress                []EgressRuleDTO   `yaml:"egress,omitempty"`                   // allowed outbound destinations
+	DNS                   DNSDTO            `yaml:"dns,omitempty"`                      // hosts and resolv.conf overrides
+	Integrity             IntegrityDTO      `yaml:"integrity,omitempty"`                // watched files
+	RestartOnBinaryChange bool              `yaml:"restart_on_binary_change,omitempty"` // restart when the binary changes
+	Watches               []WatchDTO        `yaml:"watches,omitempty"`                  // file watches with actions
+	AllowedSignals        []string          `yaml:"allowed_signals,omitempty"`          // signals operators may send
+	ReloadSignal          string            `yaml:"reload_signal,omitempty"`            // signal of in-place reloads
+	ReloadCommand         string            `yaml:"reload_command,omitempty"`           // command of in-place reloads
+	ReloadTimeout         Duration          `yaml:"reload_timeout,omitempty"`           // bound of in-place reloads
+	VerifyCommand         string            `yaml:"verify_command,omitempty"`           // dry run of the service binary
+	VerifyTimeout         Duration          `yaml:"verify_timeout,omitempty"`           // bound of the dry run
+	Reservation           ReservationDTO    `yaml:"reservation,omitempty"`              // expected memory and CPU use
+	Conditions            ConditionDTO      `yaml:"conditions,omitempty"`               // host facts needed to start
+	Priority              string            `yaml:"priority,omitempty"`                 // shedding class: low, normal or high
+	SLO                   *SLODTO           `yaml:"slo,omitempty"`                      // availability objective
+	Restart               RestartConfigDTO  `yaml:"restart"`                            // restart policy
+	HealthChecks          []HealthCheckDTO  `yaml:"health_checks,omitempty"`            // health check definitions
+	Listeners             []ListenerDTO     `yaml:"listeners,omitempty"`                // network listeners
+	Logging               ServiceLoggingDTO `yaml:"logging,omitempty"`                  // logging configuration
+	DependsOn             []string          `yaml:"depends_on,omitempty"`               // service dependencies
+	StartPhase            int               `yaml:"start_phase,omitempty"`              // ordered startup group
+	Oneshot               bool              `yaml:"oneshot,omitempty"`                  // one-shot execution mode
+	JobHistory            int               `yaml:"job_history,omitempty"`              // past runs kept for oneshot services
+	OperatesOn            *OperatesOnDTO    `yaml:"operates_on,omitempty"`              // service whose state the job operates on
+	StartTimeout          Duration          `yaml:"start_timeout,omitempty"`            // deadline to become ready
+	MaxRuntime            Duration          `yaml:"max_runtime,omitempty"`              // stop after running this long
+	Critical              bool              `yaml:"critical,omitempty"`                 // required for a successful boot
+	ExternalDependencies  []DependencyDTO   `yaml:"external_dependencies,omitempty"`    // probed external systems
+	Labels                map[string]string `yaml:"labels,omitempty"`                   // free-form annotations
+}
+
+// EgressRuleDTO is the YAML representation of an allowed outbound destination.
+type EgressRuleDTO struct {
+	CIDR     string `yaml:"cidr"`               // destination network
+	Ports    []int  `yaml:"ports,omitempty"`    // destination ports (all when empty)
+	Protocol string `yaml:"protocol,omitempty"` // tcp or udp (both when empty)
+}
+
+// DNSDTO is the YAML representation of the name resolution overrides.
+type DNSDTO struct {
+	Hosts       []HostEntryDTO `yaml:"hosts,omitempty"`       // /etc/hosts entries after localhost
+	Nameservers []string       `yaml:"nameservers,omitempty"` // resolvers of /etc/resolv.conf
+	Search      []string       `yaml:"search,omitempty"`      // search domains
+	Options     []string       `yaml:"options,omitempty"`     // resolver options
+}
+
+// HostEntryDTO is the YAML representation of a hosts file entry.
+type HostEntryDTO struct {
+	IP    string   `yaml:"ip"`    // address the names resolve to
+	Names []string `yaml:"names"` // host names, canonical first
+}
+
+// ToDomain converts DNSDTO to domain DNSConfig.
+//
+// Returns:
+//   - config.DNSConfig: the domain DNS overrides.
+func (d *DNSDTO) ToDomain() config.DNSConfig {
+	var hosts []config.HostEntry
+	// convert each hosts entry to domain model.
+	for _, entry := range d.Hosts {
+		hosts = append(hosts, config.HostEntry{IP: entry.IP, Names: entry.Names})
+	}
+	// return domain DNS overrides.
+	return config.DNSConfig{
+		Hosts:       hosts,
+		Nameservers: d.Nameservers,
+		Search:      d.Search,
+		Options:     d.Options,
+	}
+}
+
+// IntegrityDTO is the YAML representation of a file integrity watch.
+type IntegrityDTO struct {
+	Paths    []string `yaml:"paths,omitempty"`    // extra watched files
+	Interval Duration `yaml:"interval,omitempty"` // periodic re-hash period (5m when unset)
+}
+
+// WatchDTO is the YAML representation of a file watch with its action.
+type WatchDTO struct {
+	Paths    []string `yaml:"paths"`              // watched files or glob patterns
+	Action   string   `yaml:"action,omitempty"`   // restart, reload or exec (events only when empty)
+	Signal   string   `yaml:"signal,omitempty"`   // reload signal (SIGHUP when unset)
+	Command  string   `yaml:"command,omitempty"`  // exec hook
+	Args     []string `yaml:"args,omitempty"`     // exec hook arguments
+	Timeout  Duration `yaml:"timeout,omitempty"`  // exec hook timeout (30s when unset)
+	Debounce Duration `yaml:"debounce,omitempty"` // quiet period before acting (500ms when unset)
+	Interval Duration `yaml:"interval,omitempty"` // periodic re-hash period (5m when unset)
+}
+
+// SecretDTO is the YAML representation of an environment variable read from a file.
+type SecretDTO struct {
+	Env  string `yaml:"env"`  // environment variable
+	File string `yaml:"file"` // absolute path of the secret file
+}
+
+// ReservationDTO is the YAML representation of a resource reservation.
+type ReservationDTO struct {
+	Memory string  `yaml:"memory,omitempty"` // expected memory use (e.g., "256MB")
+	CPU    float64 `yaml:"cpu,omitempty"`    // expected busy CPUs (0.5 is half a core)
+}
+
+// ConditionDTO is the YAML representation of service start conditions.
+type ConditionDTO struct {
+	MinKernelVersion string   `yaml:"min_kernel_version,omitempty"` // oldest kernel release allowed (e.g., "5.10")
+	CgroupV2         bool     `yaml:"cgroup_v2,omitempty"`          // require the unified cgroup hierarchy
+	Architectures    []string `yaml:"architectures,omitempty"`      // allowed CPU architectures
+	PathExists       []string `yaml:"path_exists,omitempty"`        // paths that must exist, "!" for must not
+	MinFreeMemory    string   `yaml:"min_free_memory,omitempty"`    // memory that must be available (e.g., "512MB")
+}
+
+// SLODTO is the YAML representation of an availability objective.
+type SLODTO struct {
+	Target     float64        `yaml:"target"`                // objective in percent (99.9)
+	Window     Duration       `yaml:"window,omitempty"`      // compliance window (720h when unset)
+	BurnAlerts []BurnAlertDTO `yaml:"burn_alerts,omitempty"` // burn rate alerts (14.4/1h and 6/6h when unset)
+}
+
+// OperatesOnDTO is the YAML representation of the service a job operates on.
+type OperatesOnDTO struct {
+	Service       string   `yaml:"service"`                  // service whose state directory the job operates on
+	Quiesce       string   `yaml:"quiesce,omitempty"`        // stop or reload_signal (left running when empty)
+	VerifyHealth  bool     `yaml:"verify_health,omitempty"`  // wait for the service to be healthy after the job
+	HealthTimeout Duration `yaml:"health_timeout,omitempty"` // bound of the health verification (2m when unset)
+}
+
+// BurnAlertDTO is the YAML representation of an error budget burn rate alert.
+type BurnAlertDTO struct {
+	Rate   float64  `yaml:"rate"`   // burn rate threshold
+	Window Duration `yaml:"window"` // lookback window
+}
+
+// ToDomain converts ConditionDTO to the domain ConditionConfig.
+//
+// Returns:
+//   - config.ConditionConfig: the domain start conditions.
+func (c *ConditionDTO) ToDomain() config.ConditionConfig {
+	// copy each condition
+	return config.ConditionConfig{
+		MinKernelVersion: c.MinKernelVersion,
+		CgroupV2:         c.CgroupV2,
+		Architectures:    c.Architectures,
+		PathExists:       c.PathExists,
+		MinFreeMemory:    c.MinFreeMemory,
+	}
+}
+
+// ToDomain converts SLODTO to the domain SLOConfig.
+//
+// Returns:
+//   - *config.SLOConfig: the domain objective, nil when none is declared.
+func (s *SLODTO) ToDomain() *config.SLOConfig {
+	// no objective declared
+	if s == nil {
+		// return no objective
+		return nil
+	}
+	var alerts []config.BurnAlertConfig
+	// convert each burn alert to domain model.
+	for _, a := range s.BurnAlerts {
+		alerts = append(alerts, config.BurnAlertConfig{Rate: a.Rate, Window: shared.Duration(a.Window)})
+	}
+	// return assembled domain objective.
+	return &config.SLOConfig{
+		Target:     s.Target,
+		Window:     shared.Duration(s.Window),
+		BurnAlerts: alerts,
+	}
+}
+
+// ToDomain converts OperatesOnDTO to the domain OperatesOnConfig.
+//
+// Returns:
+//   - *config.OperatesOnConfig: the domain declaration, nil when none is declared.
+func (o *OperatesOnDTO) ToDomain() *config.OperatesOnConfig {
+	// no operated service declared
+	if o == nil {
+		// return no declaration
+		return nil
+	}
+	// return assembled domain declaration.
+	return &config.OperatesOnConfig{
+		Service:       o.Service,
+		Quiesce:       config.QuiesceMode(o.Quiesce),
+		VerifyHealth:  o.VerifyHealth,
+		HealthTimeout: shared.Duration(o.HealthTimeout),
+	}
+}
+
+// DependencyDTO is the YAML representation of an external dependency.
+// It defines an external system probed and reported alongside the service.
+type DependencyDTO struct {
+	Name        string   `yaml:"name"`                   // dependency name
+	Address     string   `yaml:"address,omitempty"`      // dependency address
+	Probe       ProbeDTO `yaml:"probe"`                  // probe configuration
+	GateRestart bool     `yaml:"gate_restart,omitempty"` // hold back health restarts while down
 }
 
 // ListenerDTO is the YAML representation of a network listener.
 // It defines a port with optional health probe configuration.
 type ListenerDTO struct {
-	Name     string   `yaml:"name"`               // listener name
-	Port     int      `yaml:"port"`               // port number
-	Protocol string   `yaml:"protocol,omitempty"` // protocol (tcp/udp)
-	Address  string   `yaml:"address,omitempty"`  // bind address
-	Exposed  bool     `yaml:"exposed,omitempty"`  // exposed to external networks
-	Probe    ProbeDTO `yaml:"probe,omitempty"`    // probe configuration
+	Name     string    `yaml:"name"`               // listener name
+	Port     int       `yaml:"port"`               // port number
+	Protocol string    `yaml:"protocol,omitempty"` // protocol (tcp/udp, tcp4/tcp6, udp4/udp6)
+	Address  string    `yaml:"address,omitempty"`  // bind address
+	Exposed  bool      `yaml:"exposed,omitempty"`  // exposed to external networks
+	Probe    ProbeDTO  `yaml:"probe,omitempty"`    // probe configuration
+	Proxy    *ProxyDTO `yaml:"proxy,omitempty"`    // supervisor-owned public endpoint
+	TLS      *TLSDTO   `yaml:"tls,omitempty"`      // served certificate checks
+}
+
+// TLSDTO is the YAML representation of listener certificate checks.
+// It defines how the served certificate is checked and renewed.
+type TLSDTO struct {
+	ServerName   string   `yaml:"server_name,omitempty"`   // SNI sent on connect
+	Interval     Duration `yaml:"interval,omitempty"`      // period between checks
+	WarnBefore   Duration `yaml:"warn_before,omitempty"`   // expiry warning delay
+	RenewCommand string   `yaml:"renew_command,omitempty"` // renewal command
+	RenewArgs    []string `yaml:"renew_args,omitempty"`    // renewal command arguments
+	RenewBefore  Duration `yaml:"renew_before,omitempty"`  // renewal delay before expiry
+	RenewTimeout Duration `yaml:"renew_timeout,omitempty"` // renewal command bound
+}
+
+// ProxyDTO is the YAML representation of a listener proxy.
+// It defines the public endpoint the supervisor binds and forwards to the listener.
+type ProxyDTO struct {
+	Address      string   `yaml:"address,omitempty"`       // public bind address
+	Port         int      `yaml:"port,omitempty"`          // public port (defaults to listener port)
+	HealthGate   bool     `yaml:"health_gate,omitempty"`   // refuse connections while not ready
+	DrainTimeout Duration `yaml:"drain_timeout,omitempty"` // in-flight connection drain window
 }
 
 // ProbeDTO is the YAML representation of a probe configuration.
 // It defines how to probe a listener for health checking.
 type ProbeDTO struct {
-	Type             string   `yaml:"type"`                        // probe type (http, tcp, grpc, icmp, exec)
-	Interval         Duration `yaml:"interval,omitempty"`          // probe interval
-	Timeout          Duration `yaml:"timeout,omitempty"`           // probe timeout
-	SuccessThreshold int      `yaml:"success_threshold,omitempty"` // required successes to mark healthy
-	FailureThreshold int      `yaml:"failure_threshold,omitempty"` // required failures to mark unhealthy
-	Path             string   `yaml:"path,omitempty"`              // HTTP path
-	Method           string   `yaml:"method,omitempty"`            // HTTP method
-	StatusCode       int      `yaml:"status_code,omitempty"`       // expected HTTP status code
-	Service          string   `yaml:"service,omitempty"`           // gRPC service name
-	Command          string   `yaml:"command,omitempty"`           // exec command
-	Args             []string `yaml:"args,omitempty"`              // exec command arguments
-	ICMPMode         string   `yaml:"icmp_mode,omitempty"`         // ICMP mode (ping/echo)
+	Type             string            `yaml:"type"`                        // probe type (http, tcp, grpc, icmp, exec, scenario, threshold)
+	Interval         Duration          `yaml:"interval,omitempty"`          // probe interval
+	Timeout          Duration          `yaml:"timeout,omitempty"`           // probe timeout
+	SuccessThreshold int               `yaml:"success_threshold,omitempty"` // required successes to mark healthy
+	FailureThreshold int               `yaml:"failure_threshold,omitempty"` // required failures to mark unhealthy
+	Path             string            `yaml:"path,omitempty"`              // HTTP path
+	Method           string            `yaml:"method,omitempty"`            // HTTP method
+	StatusCode       int               `yaml:"status_code,omitempty"`       // expected HTTP status code
+	Service          string            `yaml:"service,omitempty"`           // gRPC service name
+	Command          string            `yaml:"command,omitempty"`           // exec command
+	Args             []string          `yaml:"args,omitempty"`              // exec command arguments
+	ICMPMode         string            `yaml:"icmp_mode,omitempty"`         // ICMP mode (ping/echo)
+	Steps            []ScenarioStepDTO `yaml:"steps,omitempty"`             // scenario HTTP steps
+	Threshold        *ThresholdDTO     `yaml:"threshold,omitempty"`         // threshold value check
+	Debug            bool              `yaml:"debug,omitempty"`             // trace every probe attempt
+	OnUnhealthy      []UnhealthyDTO    `yaml:"on_unhealthy,omitempty"`      // escalation chain (restart when unset)
+}
+
+// UnhealthyDTO is the YAML representation of an unhealthy escalation step.
+type UnhealthyDTO struct {
+	Action  string   `yaml:"action"`            // restart, stop, exec, signal or notify
+	After   int      `yaml:"after,omitempty"`   // further reports before this step takes over
+	Signal  string   `yaml:"signal,omitempty"`  // signal of signal actions
+	Command string   `yaml:"command,omitempty"` // exec hook
+	Args    []string `yaml:"args,omitempty"`    // exec hook arguments
+	Timeout Duration `yaml:"timeout,omitempty"` // exec hook timeout (30s when unset)
+}
+
+// ScenarioStepDTO is the YAML representation of a scenario probe step.
+// It defines one HTTP request and the variables extracted from its response.
+type ScenarioStepDTO struct {
+	Name       string            `yaml:"name,omitempty"`        // step name in failure reports
+	Method     string            `yaml:"method,omitempty"`      // HTTP method
+	Path       string            `yaml:"path"`                  // request path
+	Headers    map[string]string `yaml:"headers,omitempty"`     // request headers
+	Body       string            `yaml:"body,omitempty"`        // request body
+	StatusCode int               `yaml:"status_code,omitempty"` // expected HTTP status code
+	Extract    map[string]string `yaml:"extract,omitempty"`     // variable name to body regex
+}
+
+// ThresholdDTO is the YAML representation of a threshold probe value check.
+// It defines how the value is read and the thresholds it is compared with.
+type ThresholdDTO struct {
+	Pattern string   `yaml:"pattern,omitempty"` // regex whose first group is the value
+	Warn    *float64 `yaml:"warn,omitempty"`    // value past which the probe is degraded
+	Fail    *float64 `yaml:"fail"`              // value past which the probe fails
+	Below   bool     `yaml:"below,omitempty"`   // fail under the thresholds instead of above
 }
 
 // RestartConfigDTO is the YAML representation of restart configuration.
@@ -248,6 +871,7 @@ type RestartConfigDTO struct {
 	MaxRetries      int      `yaml:"max_retries,omitempty"`      // maximum restart attempts
 	Delay           Duration `yaml:"delay,omitempty"`            // initial restart delay
 	DelayMax        Duration `yaml:"delay_max,omitempty"`        // maximum restart delay
+	Strategy        string   `yaml:"strategy,omitempty"`         // delay growth (exponential, fixed, fibonacci or registered)
 	StabilityWindow Duration `yaml:"stability_window,omitempty"` // time service must run to be considered stable
 }
 
@@ -284,10 +908,18 @@ type DaemonLoggingDTO struct {
 // WriterConfigDTO is the YAML representation of a log writer configuration.
 // It defines the type, level, and specific writer settings for file or JSON output.
 type WriterConfigDTO struct {
-	Type  string              `yaml:"type"`            // writer type (file, json)
-	Level string              `yaml:"level,omitempty"` // log level (debug, info, warn, error)
-	File  FileWriterConfigDTO `yaml:"file,omitempty"`  // file writer configuration
-	JSON  JSONWriterConfigDTO `yaml:"json,omitempty"`  // JSON writer configuration
+	Type    string                 `yaml:"type"`              // writer type (console, file, json)
+	Level   string                 `yaml:"level,omitempty"`   // log level (debug, info, warn, error)
+	Console ConsoleWriterConfigDTO `yaml:"console,omitempty"` // console writer configuration
+	File    FileWriterConfigDTO    `yaml:"file,omitempty"`    // file writer configuration
+	JSON    JSONWriterConfigDTO    `yaml:"json,omitempty"`    // JSON writer configuration
+}
+
+// ConsoleWriterConfigDTO is the YAML representation of console writer configuration.
+// It specifies the color mode and line format of stdout/stderr logging.
+type ConsoleWriterConfigDTO struct {
+	Color  string `yaml:"color,omitempty"`  // color mode (auto, always, never)
+	Format string `yaml:"format,omitempty"` // line format (text, human)
 }
 
 // FileWriterConfigDTO is the YAML representation of file writer configuration.
@@ -307,8 +939,9 @@ type JSONWriterConfigDTO struct {
 // LogDefaultsDTO is the YAML representation of logging defaults.
 // It defines default timestamp format and rotation settings for all log streams.
 type LogDefaultsDTO struct {
-	TimestampFormat string            `yaml:"timestamp_format"` // timestamp format string
-	Rotation        RotationConfigDTO `yaml:"rotation"`         // default rotation policy
+	TimestampFormat string            `yaml:"timestamp_format"`         // timestamp format string
+	Rotation        RotationConfigDTO `yaml:"rotation"`                 // default rotation policy
+	MaxTotalSize    string            `yaml:"max_total_size,omitempty"` // default per-service log quota
 }
 
 // RotationConfigDTO is the YAML representation of rotation configuration.
@@ -323,8 +956,10 @@ type RotationConfigDTO struct {
 // ServiceLoggingDTO is the YAML representation of service logging.
 // It defines separate configurations for stdout and stderr log streams.
 type ServiceLoggingDTO struct {
-	Stdout LogStreamConfigDTO `yaml:"stdout,omitempty"` // stdout logging configuration
-	Stderr LogStreamConfigDTO `yaml:"stderr,omitempty"` // stderr logging configuration
+	Stdout              LogStreamConfigDTO `yaml:"stdout,omitempty"`               // stdout logging configuration
+	Stderr              LogStreamConfigDTO `yaml:"stderr,omitempty"`               // stderr logging configuration
+	MaxTotalSize        string             `yaml:"max_total_size,omitempty"`       // combined log quota incl. rotated files
+	NormalizeTimestamps bool               `yaml:"normalize_timestamps,omitempty"` // normalized timestamp and clock tag on each line
 }
 
 // LogStreamConfigDTO is the YAML representation of a log stream.
@@ -351,13 +986,33 @@ func (c *ConfigDTO) ToDomain(configPath string) *config.Config {
 		services = append(services, c.Services[i].ToDomain())
 	}
 
+	var watchers []config.WatcherConfig
+	// convert each watcher to domain model.
+	for i := range c.Watchers {
+		watchers = append(watchers, c.Watchers[i].ToDomain())
+	}
+
 	// return assembled domain configuration.
 	return &config.Config{
-		Version:    c.Version,
-		ConfigPath: configPath,
-		Logging:    c.Logging.ToDomain(),
-		Monitoring: c.Monitoring.ToDomain(),
-		Services:   services,
+		Version:         c.Version,
+		ConfigPath:      configPath,
+		Logging:         c.Logging.ToDomain(),
+		Monitoring:      c.Monitoring.ToDomain(),
+		OnBootFailure:   config.BootFailurePolicy(c.OnBootFailure),
+		Incidents:       c.Incidents.ToDomain(),
+		Notifications:   c.Notifications.ToDomain(),
+		Admission:       c.Admission.ToDomain(),
+		Shedding:        c.Shedding.ToDomain(),
+		LeakCheck:       c.LeakCheck.ToDomain(),
+		RestartBudget:   c.RestartBudget.ToDomain(),
+		SafeMode:        c.SafeMode.ToDomain(),
+		TrafficSampling: c.TrafficSampling.ToDomain(),
+		Hooks:           c.Hooks.ToDomain(),
+		Watchers:        watchers,
+		Control:         c.Control.ToDomain(),
+		ShutdownReport:  c.ShutdownReport.ToDomain(),
+		HostShutdown:    c.HostShutdown.ToDomain(),
+		Services:        services,
 	}
 }
 
@@ -385,7 +1040,7 @@ func (m *MonitoringConfigDTO) ToDomain() config.MonitoringConfig {
 		monitoring.Metrics = m.Metrics.ToDomain(template)
 	} else {
 		// no explicit config, use template directly
-		monitoring.Metrics = resolveMetricsTemplate(template)
+		monitoring.Metrics = config.MetricsConfigForTemplate(template)
 	}
 
 	// convert static targets
@@ -401,64 +1056,18 @@ func (m *MonitoringConfigDTO) ToDomain() config.MonitoringConfig {
 }
 
 // resolveMetricsTemplate resolves the performance template string to a template enum.
-// Defaults to standard if empty or invalid.
+// Defaults to standard if empty; unknown names are kept so validation rejects them.
 //
 // Returns:
 //   - config.MetricsTemplate: the resolved template
 func (m *MonitoringConfigDTO) resolveMetricsTemplate() config.MetricsTemplate {
-	// resolve template string to domain template enum
-	switch m.PerformanceTemplate {
-	// return minimal template for minimal configuration.
-	case "minimal":
-		// use minimal template enum.
-		return config.MetricsTemplateMinimal
-	// return full template for full configuration.
-	case "full":
-		// use full template enum.
-		return config.MetricsTemplateFull
-	// return standard template for standard configuration.
-	case "standard":
-		// use standard template enum.
-		return config.MetricsTemplateStandard
-	// return custom template for custom configuration.
-	case "custom":
-		// use custom template enum.
-		return config.MetricsTemplateCustom
-	// default to standard template for unknown values.
-	default:
-		// use standard as safe default.
+	// default to standard template when unset
+	if m.PerformanceTemplate == "" {
+		// use standard as default
 		return config.MetricsTemplateStandard
 	}
-}
-
-// resolveMetricsTemplate resolves a template to its configuration.
-// This is a helper function used by MonitoringConfigDTO.
-//
-// Params:
-//   - template: the template to resolve
-//
-// Returns:
-//   - config.MetricsConfig: the resolved configuration
-func resolveMetricsTemplate(template config.MetricsTemplate) config.MetricsConfig {
-	// map template enum to configuration factory
-	switch template {
-	// use minimal configuration factory.
-	case config.MetricsTemplateMinimal:
-		// create minimal configuration.
-		return config.MinimalMetricsConfig()
-	// use full configuration factory.
-	case config.MetricsTemplateFull:
-		// create full configuration.
-		return config.FullMetricsConfig()
-	// use standard configuration for standard and custom templates.
-	case config.MetricsTemplateStandard, config.MetricsTemplateCustom:
-		// create standard configuration.
-		return config.StandardMetricsConfig()
-	// default to standard configuration for unknown templates.
-	default:
-		// create standard as safe default.
-		return config.StandardMetricsConfig()
-	}
+	// keep name as-is for validation
+	return config.MetricsTemplate(m.PerformanceTemplate)
 }
 
 // ToDomain converts MonitoringDefaultsDTO to domain MonitoringDefaults.
@@ -703,6 +1312,46 @@ func (s *ServiceConfigDTO) ToDomain() config.ServiceConfig {
 		listeners = append(listeners, s.Listeners[i].ToDomain())
 	}
 
+	var dependencies []config.DependencyConfig
+	// convert each external dependency to domain model.
+	for i := range s.ExternalDependencies {
+		dep := &s.ExternalDependencies[i]
+		dependencies = append(dependencies, config.DependencyConfig{
+			Name:        dep.Name,
+			Address:     dep.Address,
+			Probe:       dep.Probe.ToDomain(),
+			GateRestart: dep.GateRestart,
+		})
+	}
+
+	var watches []config.WatchConfig
+	// convert each watch to domain model.
+	for i := range s.Watches {
+		w := &s.Watches[i]
+		watches = append(watches, config.WatchConfig{
+			Paths:    w.Paths,
+			Action:   config.WatchAction(w.Action),
+			Signal:   w.Signal,
+			Command:  w.Command,
+			Args:     w.Args,
+			Timeout:  shared.Duration(w.Timeout),
+			Debounce: shared.Duration(w.Debounce),
+			Interval: shared.Duration(w.Interval),
+		})
+	}
+
+	var secrets []config.SecretConfig
+	// convert each secret to domain model.
+	for _, secret := range s.Secrets {
+		secrets = append(secrets, config.SecretConfig{Env: secret.Env, File: secret.File})
+	}
+
+	var egress []config.EgressRule
+	// convert each egress rule to domain model.
+	for _, rule := range s.Egress {
+		egress = append(egress, config.EgressRule{CIDR: rule.CIDR, Ports: rule.Ports, Protocol: rule.Protocol})
+	}
+
 	// return assembled domain service config.
 	return config.ServiceConfig{
 		Name:             s.Name,
@@ -712,12 +1361,48 @@ func (s *ServiceConfigDTO) ToDomain() config.ServiceConfig {
 		Group:            s.Group,
 		WorkingDirectory: s.WorkingDirectory,
 		Environment:      s.Environment,
-		Restart:          s.Restart.ToDomain(),
-		DependsOn:        s.DependsOn,
-		Oneshot:          s.Oneshot,
-		Logging:          s.Logging.ToDomain(),
-		HealthChecks:     healthChecks,
-		Listeners:        listeners,
+		Timezone:         s.Timezone,
+		Locale:           s.Locale,
+		Secrets:          secrets,
+		OnSecretChange:   config.SecretChangeAction(s.OnSecretChange),
+		SELinuxContext:   s.SELinuxContext,
+		AppArmorProfile:  s.AppArmorProfile,
+		ReadOnlyPaths:    s.ReadOnlyPaths,
+		MaskedPaths:      s.MaskedPaths,
+		TmpfsPaths:       s.TmpfsPaths,
+		PIDNamespace:     s.PIDNamespace,
+		Egress:           egress,
+		DNS:              s.DNS.ToDomain(),
+		Integrity: config.IntegrityConfig{
+			Paths:    s.Integrity.Paths,
+			Interval: shared.Duration(s.Integrity.Interval),
+		},
+		RestartOnBinaryChange: s.RestartOnBinaryChange,
+		Watches:               watches,
+		AllowedSignals:        s.AllowedSignals,
+		ReloadSignal:          s.ReloadSignal,
+		ReloadCommand:         s.ReloadCommand,
+		ReloadTimeout:         shared.Duration(s.ReloadTimeout),
+		VerifyCommand:         s.VerifyCommand,
+		VerifyTimeout:         shared.Duration(s.VerifyTimeout),
+		Reservation:           config.ReservationConfig{Memory: s.Reservation.Memory, CPU: s.Reservation.CPU},
+		Conditions:            s.Conditions.ToDomain(),
+		Priority:              config.PriorityClass(s.Priority),
+		SLO:                   s.SLO.ToDomain(),
+		Restart:               s.Restart.ToDomain(),
+		DependsOn:             s.DependsOn,
+		StartPhase:            s.StartPhase,
+		Oneshot:               s.Oneshot,
+		JobHistory:            s.JobHistory,
+		OperatesOn:            s.OperatesOn.ToDomain(),
+		StartTimeout:          shared.Duration(s.StartTimeout),
+		MaxRuntime:            shared.Duration(s.MaxRuntime),
+		Critical:              s.Critical,
+		Logging:               s.Logging.ToDomain(),
+		HealthChecks:          healthChecks,
+		Listeners:             listeners,
+		ExternalDependencies:  dependencies,
+		Labels:                s.Labels,
 	}
 }
 
@@ -748,6 +1433,29 @@ func (l *ListenerDTO) ToDomain() config.ListenerConfig {
 		listener.Probe = &probe
 	}
 
+	// add proxy configuration if present.
+	if l.Proxy != nil {
+		listener.Proxy = &config.ProxyConfig{
+			Address:      l.Proxy.Address,
+			Port:         l.Proxy.Port,
+			HealthGate:   l.Proxy.HealthGate,
+			DrainTimeout: shared.FromTimeDuration(time.Duration(l.Proxy.DrainTimeout)),
+		}
+	}
+
+	// add certificate checks if present.
+	if l.TLS != nil {
+		listener.TLS = &config.ListenerTLSConfig{
+			ServerName:   l.TLS.ServerName,
+			Interval:     shared.FromTimeDuration(time.Duration(l.TLS.Interval)),
+			WarnBefore:   shared.FromTimeDuration(time.Duration(l.TLS.WarnBefore)),
+			RenewCommand: l.TLS.RenewCommand,
+			RenewArgs:    l.TLS.RenewArgs,
+			RenewBefore:  shared.FromTimeDuration(time.Duration(l.TLS.RenewBefore)),
+			RenewTimeout: shared.FromTimeDuration(time.Duration(l.TLS.RenewTimeout)),
+		}
+	}
+
 	// return assembled listener config.
 	return listener
 }
@@ -767,6 +1475,8 @@ func (p *ProbeDTO) ToDomain() config.ProbeConfig {
 		Type:             p.Type,
 		Interval:         shared.FromTimeDuration(interval),
 		Timeout:          shared.FromTimeDuration(timeout),
+		InheritsInterval: p.Interval <= 0,
+		InheritsTimeout:  p.Timeout <= 0,
 		SuccessThreshold: successThreshold,
 		FailureThreshold: failureThreshold,
 		Path:             p.Path,
@@ -775,6 +1485,84 @@ func (p *ProbeDTO) ToDomain() config.ProbeConfig {
 		Service:          p.Service,
 		Command:          p.Command,
 		Args:             p.Args,
+		Steps:            p.stepsToDomain(),
+		Threshold:        p.thresholdToDomain(),
+		Debug:            p.Debug,
+		OnUnhealthy:      p.unhealthyToDomain(),
+	}
+}
+
+// unhealthyToDomain converts the unhealthy escalation chain to domain steps.
+//
+// Returns:
+//   - []config.UnhealthyStep: the converted steps, nil when none.
+func (p *ProbeDTO) unhealthyToDomain() []config.UnhealthyStep {
+	// no chain: restart on every report
+	if len(p.OnUnhealthy) == 0 {
+		// return nil for the default action
+		return nil
+	}
+	steps := make([]config.UnhealthyStep, 0, len(p.OnUnhealthy))
+	// convert each step
+	for i := range p.OnUnhealthy {
+		u := &p.OnUnhealthy[i]
+		steps = append(steps, config.UnhealthyStep{
+			Action:  config.UnhealthyAction(u.Action),
+			After:   u.After,
+			Signal:  u.Signal,
+			Command: u.Command,
+			Args:    u.Args,
+			Timeout: shared.Duration(u.Timeout),
+		})
+	}
+	// return converted steps
+	return steps
+}
+
+// stepsToDomain converts the scenario steps to domain steps.
+//
+// Returns:
+//   - []config.ScenarioStep: the converted steps, nil when none.
+func (p *ProbeDTO) stepsToDomain() []config.ScenarioStep {
+	// no steps outside scenario probes
+	if len(p.Steps) == 0 {
+		// return nil for non-scenario probes
+		return nil
+	}
+	steps := make([]config.ScenarioStep, 0, len(p.Steps))
+	// convert each step
+	for i := range p.Steps {
+		s := &p.Steps[i]
+		steps = append(steps, config.ScenarioStep{
+			Name:       s.Name,
+			Method:     s.Method,
+			Path:       s.Path,
+			Headers:    s.Headers,
+			Body:       s.Body,
+			StatusCode: s.StatusCode,
+			Extract:    s.Extract,
+		})
+	}
+	// return converted steps
+	return steps
+}
+
+// thresholdToDomain converts the threshold value check to its domain form.
+//
+// Returns:
+//   - *config.ThresholdConfig: the converted value check, nil when none.
+func (p *ProbeDTO) thresholdToDomain() *config.ThresholdConfig {
+	// no value check outside threshold probes
+	if p.Threshold == nil {
+		// return nil for non-threshold probes
+		return nil
+	}
+	// return converted value check
+	return &config.ThresholdConfig{
+		Pattern: p.Threshold.Pattern,
+		Warn:    p.Threshold.Warn,
+		Fail:    p.Threshold.Fail,
+		Below:   p.Threshold.Below,
 	}
 }
 
@@ -861,6 +1649,7 @@ func (r *RestartConfigDTO) ToDomain() config.RestartConfig {
 		Delay:           shared.FromTimeDuration(time.Duration(r.Delay)),
 		DelayMax:        shared.FromTimeDuration(time.Duration(r.DelayMax)),
 		StabilityWindow: shared.FromTimeDuration(time.Duration(r.StabilityWindow)),
+		Strategy:        config.RestartStrategy(r.Strategy),
 	}
 }
 
@@ -927,10 +1716,24 @@ func (d *DaemonLoggingDTO) ToDomain() config.DaemonLogging {
 func (w *WriterConfigDTO) ToDomain() config.WriterConfig {
 	// return assembled writer config.
 	return config.WriterConfig{
-		Type:  w.Type,
-		Level: w.Level,
-		File:  w.File.ToDomain(),
-		JSON:  w.JSON.ToDomain(),
+		Type:    w.Type,
+		Level:   w.Level,
+		Console: w.Console.ToDomain(),
+		File:    w.File.ToDomain(),
+		JSON:    w.JSON.ToDomain(),
+	}
+}
+
+// ToDomain converts ConsoleWriterConfigDTO to domain ConsoleWriterConfig.
+// It transforms console writer configuration to the domain model format.
+//
+// Returns:
+//   - config.ConsoleWriterConfig: the converted domain console writer configuration
+func (c *ConsoleWriterConfigDTO) ToDomain() config.ConsoleWriterConfig {
+	// return assembled console writer config.
+	return config.ConsoleWriterConfig{
+		Color:  c.Color,
+		Format: c.Format,
 	}
 }
 
@@ -970,6 +1773,7 @@ func (l *LogDefaultsDTO) ToDomain() config.LogDefaults {
 	return config.LogDefaults{
 		TimestampFormat: l.TimestampFormat,
 		Rotation:        l.Rotation.ToDomain(),
+		MaxTotalSize:    l.MaxTotalSize,
 	}
 }
 
@@ -996,8 +1800,10 @@ func (r *RotationConfigDTO) ToDomain() config.RotationConfig {
 func (s *ServiceLoggingDTO) ToDomain() config.ServiceLogging {
 	// return assembled service logging config.
 	return config.ServiceLogging{
-		Stdout: s.Stdout.ToDomain(),
-		Stderr: s.Stderr.ToDomain(),
+		Stdout:              s.Stdout.ToDomain(),
+		Stderr:              s.Stderr.ToDomain(),
+		MaxTotalSize:        s.MaxTotalSize,
+		NormalizeTimestamps: s.NormalizeTimestamps,
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
 	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -907,11 +909,15 @@ func TestConfigDTO_ToDomain(t *testing.T) {
 		configPath      string
 		expectedVersion string
 		expectedPath    string
+		expectedPolicy  config.BootFailurePolicy
+		expectedInc     config.IncidentConfig
 	}{
 		{
 			name: "full config converts correctly",
 			dto: &yaml.ConfigDTO{
-				Version: "1.0",
+				Version:       "1.0",
+				OnBootFailure: "shutdown",
+				Incidents:     yaml.IncidentConfigDTO{Window: yaml.Duration(30 * time.Second), MinServices: 3},
 				Logging: yaml.LoggingConfigDTO{
 					BaseDir: "/var/log",
 				},
@@ -925,6 +931,8 @@ func TestConfigDTO_ToDomain(t *testing.T) {
 			configPath:      "/etc/config.yaml",
 			expectedVersion: "1.0",
 			expectedPath:    "/etc/config.yaml",
+			expectedPolicy:  config.BootFailureShutdown,
+			expectedInc:     config.IncidentConfig{Window: shared.Seconds(30), MinServices: 3},
 		},
 		{
 			name: "empty config with defaults",
@@ -946,10 +954,137 @@ func TestConfigDTO_ToDomain(t *testing.T) {
 			require.NotNil(t, result)
 			assert.Equal(t, tt.expectedVersion, result.Version)
 			assert.Equal(t, tt.expectedPath, result.ConfigPath)
+			assert.Equal(t, tt.expectedPolicy, result.OnBootFailure)
+			assert.Equal(t, tt.expectedInc, result.Incidents)
 		})
 	}
 }
 
+// TestNotificationsDTO_ToDomain tests yaml.NotificationsDTO to domain conversion.
+// It verifies that channels, rate limit, escalations, heartbeats and spool are mapped.
+//
+// Params:
+//   - t: testing context
+func TestNotificationsDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := yaml.NotificationsDTO{
+		Channels: []yaml.NotificationChannelDTO{
+			{Name: "ops", URL: "https://hooks.example.com/ops", Events: []string{"failed"}, Digest: yaml.Duration(30 * time.Second)},
+			{Name: "pager", URL: "https://pager.example.com/hook"},
+		},
+		RateLimit:   yaml.NotificationRateLimitDTO{Max: 10, Period: yaml.Duration(time.Minute)},
+		Escalations: []yaml.EscalationRuleDTO{{Event: "failed", Count: 3, Within: yaml.Duration(5 * time.Minute), Channel: "pager"}},
+		Heartbeats:  []yaml.HeartbeatDTO{{URL: "https://hc-ping.com/uuid", Interval: yaml.Duration(time.Minute), Services: []string{"api"}}},
+		Spool:       yaml.NotificationSpoolDTO{Dir: "/var/spool/daemon", MaxSize: "16MB", RetryInterval: yaml.Duration(10 * time.Second)},
+	}
+
+	result := dto.ToDomain()
+
+	assert.Equal(t, []config.NotificationChannel{
+		{Name: "ops", URL: "https://hooks.example.com/ops", Events: []string{"failed"}, Digest: shared.Seconds(30)},
+		{Name: "pager", URL: "https://pager.example.com/hook"},
+	}, result.Channels)
+	assert.Equal(t, config.NotificationRateLimit{Max: 10, Period: shared.Minutes(1)}, result.RateLimit)
+	assert.Equal(t, []config.EscalationRule{{Event: "failed", Count: 3, Within: shared.Minutes(5), Channel: "pager"}}, result.Escalations)
+	assert.Equal(t, []config.HeartbeatConfig{{URL: "https://hc-ping.com/uuid", Interval: shared.Minutes(1), Services: []string{"api"}}}, result.Heartbeats)
+	assert.Equal(t, config.NotificationSpool{Dir: "/var/spool/daemon", MaxSize: "16MB", RetryInterval: shared.Seconds(10)}, result.Spool)
+}
+
+// TestShutdownReportDTO_ToDomain tests yaml.ShutdownReportDTO to domain conversion.
+//
+// Params:
+//   - t: testing context
+func TestShutdownReportDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := yaml.ShutdownReportDTO{
+		Path:    "/var/lib/supervizio/shutdown.json",
+		URL:     "https://reports.example.com/hosts/web-1",
+		Timeout: yaml.Duration(5 * time.Second),
+	}
+
+	assert.Equal(t, config.ShutdownReportConfig{
+		Path:    "/var/lib/supervizio/shutdown.json",
+		URL:     "https://reports.example.com/hosts/web-1",
+		Timeout: shared.Seconds(5),
+	}, dto.ToDomain())
+}
+
+// TestTrafficSamplingDTO_ToDomain tests yaml.TrafficSamplingDTO to domain conversion.
+//
+// Params:
+//   - t: testing context
+func TestTrafficSamplingDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := yaml.TrafficSamplingDTO{Interval: yaml.Duration(30 * time.Second), MaxEndpoints: 16}
+
+	assert.Equal(t, config.TrafficSamplingConfig{Interval: shared.Seconds(30), MaxEndpoints: 16}, dto.ToDomain())
+}
+
+// TestEventHooksDTO_ToDomain tests yaml.EventHooksDTO to domain conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestEventHooksDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := yaml.EventHooksDTO{MaxConcurrent: 2, Commands: []yaml.EventHookDTO{
+		{Events: []string{"exhausted", "config_reloaded"}, Command: "/bin/page", Args: []string{"--team", "ops"}, Timeout: yaml.Duration(5 * time.Second)},
+	}}
+
+	assert.Equal(t, config.EventHooksConfig{MaxConcurrent: 2, Commands: []config.EventHook{
+		{Events: []string{"exhausted", "config_reloaded"}, Command: "/bin/page", Args: []string{"--team", "ops"}, Timeout: shared.Seconds(5)},
+	}}, dto.ToDomain())
+}
+
+// TestDNSDTO_ToDomain tests yaml.DNSDTO to domain conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestDNSDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := yaml.DNSDTO{
+		Hosts:       []yaml.HostEntryDTO{{IP: "10.20.0.5", Names: []string{"db.staging.internal", "db"}}},
+		Nameservers: []string{"10.20.0.53"},
+		Search:      []string{"staging.internal"},
+		Options:     []string{"ndots:2"},
+	}
+
+	assert.Equal(t, config.DNSConfig{
+		Hosts:       []config.HostEntry{{IP: "10.20.0.5", Names: []string{"db.staging.internal", "db"}}},
+		Nameservers: []string{"10.20.0.53"},
+		Search:      []string{"staging.internal"},
+		Options:     []string{"ndots:2"},
+	}, dto.ToDomain())
+}
+
+// TestSafeModeDTO_ToDomain tests yaml.SafeModeDTO to domain conversion.
+//
+// Params:
+//   - t: testing context
+func TestSafeModeDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := yaml.SafeModeDTO{Restarts: 20, Window: yaml.Duration(2 * time.Minute)}
+
+	assert.Equal(t, config.SafeModeConfig{Restarts: 20, Window: shared.Minutes(2)}, dto.ToDomain())
+}
+
+// TestHostShutdownDTO_ToDomain tests yaml.HostShutdownDTO to domain conversion.
+//
+// Params:
+//   - t: testing context
+func TestHostShutdownDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := yaml.HostShutdownDTO{Logind: true, ACPISocket: "/var/run/acpid.socket"}
+
+	assert.Equal(t, config.HostShutdownConfig{Logind: true, ACPISocket: "/var/run/acpid.socket"}, dto.ToDomain())
+}
+
 // TestServiceConfigDTO_ToDomain tests yaml.ServiceConfigDTO to domain conversion.
 // It verifies that service configuration is correctly mapped.
 //
@@ -959,11 +1094,13 @@ func TestServiceConfigDTO_ToDomain(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name            string
-		dto             *yaml.ServiceConfigDTO
-		expectedName    string
-		expectedCommand string
-		expectedOneshot bool
+		name             string
+		dto              *yaml.ServiceConfigDTO
+		expectedName     string
+		expectedCommand  string
+		expectedOneshot  bool
+		expectedTimeout  time.Duration
+		expectedCritical bool
 	}{
 		{
 			name: "full service config",
@@ -976,10 +1113,14 @@ func TestServiceConfigDTO_ToDomain(t *testing.T) {
 				WorkingDirectory: "/var/www",
 				Environment:      map[string]string{"PORT": "8080"},
 				Oneshot:          false,
+				StartTimeout:     yaml.Duration(30 * time.Second),
+				Critical:         true,
 			},
-			expectedName:    "nginx",
-			expectedCommand: "/usr/sbin/nginx",
-			expectedOneshot: false,
+			expectedName:     "nginx",
+			expectedCommand:  "/usr/sbin/nginx",
+			expectedOneshot:  false,
+			expectedTimeout:  30 * time.Second,
+			expectedCritical: true,
 		},
 		{
 			name: "oneshot service",
@@ -1003,6 +1144,8 @@ func TestServiceConfigDTO_ToDomain(t *testing.T) {
 			assert.Equal(t, tt.expectedName, result.Name)
 			assert.Equal(t, tt.expectedCommand, result.Command)
 			assert.Equal(t, tt.expectedOneshot, result.Oneshot)
+			assert.Equal(t, tt.expectedTimeout, result.StartTimeout.Duration())
+			assert.Equal(t, tt.expectedCritical, result.Critical)
 		})
 	}
 }
@@ -1050,6 +1193,17 @@ func TestListenerDTO_ToDomain(t *testing.T) {
 			expectedProtocol: "udp",
 			expectedExposed:  false,
 		},
+		{
+			name: "unix socket listener",
+			dto: &yaml.ListenerDTO{
+				Name:     "admin",
+				Protocol: "unix",
+				Address:  "/run/app/admin.sock",
+			},
+			expectedName:     "admin",
+			expectedProtocol: "unix",
+			expectedExposed:  false,
+		},
 		{
 			name: "listener with probe",
 			dto: &yaml.ListenerDTO{
@@ -1082,6 +1236,65 @@ func TestListenerDTO_ToDomain(t *testing.T) {
 	}
 }
 
+// TestListenerDTO_ToDomain_Proxy tests proxy conversion to the domain model.
+func TestListenerDTO_ToDomain_Proxy(t *testing.T) {
+	t.Parallel()
+
+	dto := &yaml.ListenerDTO{
+		Name:    "http",
+		Port:    8080,
+		Address: "127.0.0.1",
+		Exposed: true,
+		Proxy:   &yaml.ProxyDTO{Address: "0.0.0.0", Port: 80, HealthGate: true, DrainTimeout: yaml.Duration(15 * time.Second)},
+	}
+
+	result := dto.ToDomain()
+
+	// Verify proxy fields are mapped.
+	require.NotNil(t, result.Proxy)
+	assert.Equal(t, "0.0.0.0", result.Proxy.Address)
+	assert.Equal(t, 80, result.Proxy.Port)
+	assert.True(t, result.Proxy.HealthGate)
+	assert.Equal(t, 15*time.Second, result.Proxy.DrainTimeout.Duration())
+
+	// Listeners without proxy keep a nil proxy.
+	assert.Nil(t, (&yaml.ListenerDTO{Name: "http", Port: 8080}).ToDomain().Proxy)
+}
+
+// TestListenerDTO_ToDomain_TLS tests certificate check conversion to the domain model.
+func TestListenerDTO_ToDomain_TLS(t *testing.T) {
+	t.Parallel()
+
+	dto := &yaml.ListenerDTO{
+		Name: "https",
+		Port: 8443,
+		TLS: &yaml.TLSDTO{
+			ServerName:   "api.example.com",
+			Interval:     yaml.Duration(30 * time.Minute),
+			WarnBefore:   yaml.Duration(72 * time.Hour),
+			RenewCommand: "/usr/local/bin/renew-cert",
+			RenewArgs:    []string{"api"},
+			RenewBefore:  yaml.Duration(48 * time.Hour),
+			RenewTimeout: yaml.Duration(2 * time.Minute),
+		},
+	}
+
+	result := dto.ToDomain()
+
+	// Verify certificate check fields are mapped.
+	require.NotNil(t, result.TLS)
+	assert.Equal(t, "api.example.com", result.TLS.ServerName)
+	assert.Equal(t, 30*time.Minute, result.TLS.Interval.Duration())
+	assert.Equal(t, 72*time.Hour, result.TLS.WarnBefore.Duration())
+	assert.Equal(t, "/usr/local/bin/renew-cert", result.TLS.RenewCommand)
+	assert.Equal(t, []string{"api"}, result.TLS.RenewArgs)
+	assert.Equal(t, 48*time.Hour, result.TLS.RenewBefore.Duration())
+	assert.Equal(t, 2*time.Minute, result.TLS.RenewTimeout.Duration())
+
+	// Listeners without tls keep nil checks.
+	assert.Nil(t, (&yaml.ListenerDTO{Name: "http", Port: 8080}).ToDomain().TLS)
+}
+
 // TestProbeDTO_ToDomain tests yaml.ProbeDTO to domain conversion.
 // It verifies that probe configuration is correctly mapped with defaults applied.
 //
@@ -1096,6 +1309,7 @@ func TestProbeDTO_ToDomain(t *testing.T) {
 		expectedType       string
 		expectedMethod     string
 		expectedStatusCode int
+		expectedDebug      bool
 	}{
 		{
 			name: "http probe with defaults",
@@ -1139,6 +1353,17 @@ func TestProbeDTO_ToDomain(t *testing.T) {
 			expectedMethod:     "GET",
 			expectedStatusCode: 200,
 		},
+		{
+			name: "tcp probe with debug",
+			dto: &yaml.ProbeDTO{
+				Type:  "tcp",
+				Debug: true,
+			},
+			expectedType:       "tcp",
+			expectedMethod:     "GET",
+			expectedStatusCode: 200,
+			expectedDebug:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1150,10 +1375,294 @@ func TestProbeDTO_ToDomain(t *testing.T) {
 			assert.Equal(t, tt.expectedType, result.Type)
 			assert.Equal(t, tt.expectedMethod, result.Method)
 			assert.Equal(t, tt.expectedStatusCode, result.StatusCode)
+			assert.Equal(t, tt.expectedDebug, result.Debug)
 		})
 	}
 }
 
+// TestProbeDTO_ToDomain_Scenario tests scenario step parsing and conversion.
+//
+// Params:
+//   - t: testing context
+func TestProbeDTO_ToDomain_Scenario(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: api
+    command: /bin/api
+    listeners:
+      - name: http
+        port: 8080
+        probe:
+          type: scenario
+          timeout: 3s
+          steps:
+            - name: login
+              method: POST
+              path: /login
+              headers:
+                Content-Type: application/json
+              body: '{"user":"probe"}'
+              extract:
+                token: '"token":"([^"]+)"'
+            - name: me
+              path: /me
+              status_code: 204
+              headers:
+                Authorization: Bearer {{token}}
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+	probe := cfg.Services[0].Listeners[0].Probe
+	require.NotNil(t, probe)
+
+	assert.Equal(t, config.ProbeTypeScenario, probe.Type)
+	assert.Equal(t, 3*time.Second, probe.Timeout.Duration())
+	require.Len(t, probe.Steps, 2)
+	assert.Equal(t, config.ScenarioStep{
+		Name:    "login",
+		Method:  "POST",
+		Path:    "/login",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"user":"probe"}`,
+		Extract: map[string]string{"token": `"token":"([^"]+)"`},
+	}, probe.Steps[0])
+	assert.Equal(t, 204, probe.Steps[1].StatusCode)
+	assert.Equal(t, "Bearer {{token}}", probe.Steps[1].Headers["Authorization"])
+}
+
+// TestProbeDTO_ToDomain_Threshold tests threshold value check parsing and conversion.
+//
+// Params:
+//   - t: testing context
+func TestProbeDTO_ToDomain_Threshold(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: worker
+    command: /bin/worker
+    external_dependencies:
+      - name: orders-queue
+        probe:
+          type: threshold
+          command: /usr/bin/queue-lag
+          args: ["orders"]
+          threshold:
+            pattern: 'lag=(\d+)'
+            warn: 1000
+            fail: 10000
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+	probe := cfg.Services[0].ExternalDependencies[0].Probe
+
+	assert.Equal(t, config.ProbeTypeThreshold, probe.Type)
+	assert.Equal(t, "/usr/bin/queue-lag", probe.Command)
+	require.NotNil(t, probe.Threshold)
+	assert.Equal(t, `lag=(\d+)`, probe.Threshold.Pattern)
+	require.NotNil(t, probe.Threshold.Warn)
+	assert.InDelta(t, 1000, *probe.Threshold.Warn, 0)
+	require.NotNil(t, probe.Threshold.Fail)
+	assert.InDelta(t, 10000, *probe.Threshold.Fail, 0)
+	assert.False(t, probe.Threshold.Below)
+}
+
+// TestProbeDTO_ToDomain_OnUnhealthy tests parsing of the unhealthy
+// escalation chain.
+//
+// Params:
+//   - t: testing context
+func TestProbeDTO_ToDomain_OnUnhealthy(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: api
+    command: /bin/api
+    listeners:
+      - name: http
+        port: 8080
+        probe:
+          type: tcp
+          on_unhealthy:
+            - action: exec
+              command: /usr/local/bin/dump-threads
+              args: ["api"]
+              timeout: 10s
+            - action: restart
+              after: 3
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+	probe := cfg.Services[0].Listeners[0].Probe
+	require.NotNil(t, probe)
+
+	assert.Equal(t, []config.UnhealthyStep{
+		{
+			Action:  config.UnhealthyActionExec,
+			Command: "/usr/local/bin/dump-threads",
+			Args:    []string{"api"},
+			Timeout: shared.FromTimeDuration(10 * time.Second),
+		},
+		{Action: config.UnhealthyActionRestart, After: 3},
+	}, probe.OnUnhealthy)
+}
+
+// TestServiceConfigDTO_ToDomain_ExternalDependencies tests parsing of
+// external dependencies.
+//
+// Params:
+//   - t: testing context
+func TestServiceConfigDTO_ToDomain_ExternalDependencies(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: api
+    command: /bin/api
+    external_dependencies:
+      - name: db
+        address: db.internal:5432
+        gate_restart: true
+        probe:
+          type: tcp
+          interval: 10s
+      - name: billing
+        address: https://billing.example.com
+        probe:
+          type: http
+          path: /status
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+	deps := cfg.Services[0].ExternalDependencies
+	require.Len(t, deps, 2)
+
+	assert.Equal(t, "db", deps[0].Name)
+	assert.Equal(t, "db.internal:5432", deps[0].Address)
+	assert.True(t, deps[0].GateRestart)
+	assert.Equal(t, config.ProbeTypeTCP, deps[0].Probe.Type)
+	assert.Equal(t, 10*time.Second, deps[0].Probe.Interval.Duration())
+	// Probe defaults apply as for listeners.
+	assert.Equal(t, 3, deps[0].Probe.FailureThreshold)
+
+	assert.False(t, deps[1].GateRestart)
+	assert.Equal(t, "/status", deps[1].Probe.Path)
+}
+
+// TestServiceConfigDTO_ToDomain_Secrets tests secret files and their
+// change action are parsed.
+//
+// Params:
+//   - t: testing context
+func TestServiceConfigDTO_ToDomain_Secrets(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: api
+    command: /bin/api
+    secrets:
+      - env: DB_PASSWORD
+        file: /run/secrets/db_password
+    on_secret_change: restart
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+	svc := &cfg.Services[0]
+
+	assert.Equal(t, []config.SecretConfig{{Env: "DB_PASSWORD", File: "/run/secrets/db_password"}}, svc.Secrets)
+	assert.Equal(t, config.SecretChangeRestart, svc.OnSecretChange)
+}
+
+// TestServiceConfigDTO_ToDomain_Locale tests the time zone and locale
+// of a service are parsed.
+//
+// Params:
+//   - t: testing context
+func TestServiceConfigDTO_ToDomain_Locale(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: api
+    command: /bin/api
+    timezone: Europe/Paris
+    locale: fr_FR.UTF-8
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Europe/Paris", cfg.Services[0].Timezone)
+	assert.Equal(t, "fr_FR.UTF-8", cfg.Services[0].Locale)
+}
+
+// TestServiceConfigDTO_ToDomain_PIDNamespace tests the PID namespace
+// setting of a service is parsed.
+//
+// Params:
+//   - t: testing context
+func TestServiceConfigDTO_ToDomain_PIDNamespace(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: api
+    command: /bin/api
+    pid_namespace: true
+  - name: worker
+    command: /bin/worker
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Services[0].PIDNamespace)
+	assert.False(t, cfg.Services[1].PIDNamespace)
+}
+
+// TestServiceConfigDTO_ToDomain_OperatesOn tests the service a job
+// operates on is parsed.
+//
+// Params:
+//   - t: testing context
+func TestServiceConfigDTO_ToDomain_OperatesOn(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "1"
+services:
+  - name: db
+    command: /usr/bin/postgres
+  - name: db-backup
+    command: /usr/local/bin/backup
+    oneshot: true
+    operates_on:
+      service: db
+      quiesce: stop
+      verify_health: true
+      health_timeout: 5m
+`)
+
+	cfg, err := yaml.NewLoader().Parse(data)
+	require.NoError(t, err)
+
+	assert.Nil(t, cfg.Services[0].OperatesOn)
+	assert.Equal(t, &config.OperatesOnConfig{
+		Service:       "db",
+		Quiesce:       config.QuiesceStop,
+		VerifyHealth:  true,
+		HealthTimeout: shared.Duration(5 * time.Minute),
+	}, cfg.Services[1].OperatesOn)
+}
+
 // TestRestartConfigDTO_ToDomain tests yaml.RestartConfigDTO to domain conversion.
 // It verifies that restart configuration is correctly mapped.
 //
@@ -1445,6 +1954,25 @@ func TestWriterConfigDTO_ToDomain(t *testing.T) {
 	}
 }
 
+// TestConsoleWriterConfigDTO_ToDomain tests yaml.ConsoleWriterConfigDTO to domain conversion.
+// It verifies that the color mode and line format are mapped.
+//
+// Params:
+//   - t: testing context
+func TestConsoleWriterConfigDTO_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	dto := &yaml.WriterConfigDTO{
+		Type:    "console",
+		Console: yaml.ConsoleWriterConfigDTO{Color: "never", Format: "human"},
+	}
+
+	result := dto.ToDomain()
+
+	assert.Equal(t, "never", result.Console.Color)
+	assert.Equal(t, "human", result.Console.Format)
+}
+
 // TestFileWriterConfigDTO_ToDomain tests yaml.FileWriterConfigDTO to domain conversion.
 // It verifies that file writer configuration is correctly mapped.
 //
@@ -1652,6 +2180,8 @@ func TestServiceLoggingDTO_ToDomain(t *testing.T) {
 		dto                *yaml.ServiceLoggingDTO
 		expectedStdoutFile string
 		expectedStderrFile string
+		expectedQuota      string
+		expectedNormalize  bool
 	}{
 		{
 			name: "both stdout and stderr configured",
@@ -1664,9 +2194,13 @@ func TestServiceLoggingDTO_ToDomain(t *testing.T) {
 					File:            "/var/log/app/stderr.log",
 					TimestampFormat: "2006-01-02T15:04:05",
 				},
+				MaxTotalSize:        "1GB",
+				NormalizeTimestamps: true,
 			},
 			expectedStdoutFile: "/var/log/app/stdout.log",
 			expectedStderrFile: "/var/log/app/stderr.log",
+			expectedQuota:      "1GB",
+			expectedNormalize:  true,
 		},
 		{
 			name: "only stdout configured",
@@ -1688,6 +2222,8 @@ func TestServiceLoggingDTO_ToDomain(t *testing.T) {
 
 			assert.Equal(t, tt.expectedStdoutFile, result.Stdout.FilePath)
 			assert.Equal(t, tt.expectedStderrFile, result.Stderr.FilePath)
+			assert.Equal(t, tt.expectedQuota, result.MaxTotalSize)
+			assert.Equal(t, tt.expectedNormalize, result.NormalizeTimestamps)
 		})
 	}
 }
@@ -0,0 +1,185 @@
+// Package parameters provides the file persisting the daemon parameters
+// changed at runtime, so they survive restarts.
+package parameters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kodflow/daemon/internal/application/parameters"
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// DefaultPath is the parameters file used when none is given.
+const DefaultPath string = "/var/lib/supervizio/parameters.json"
+
+// dirPerm is the permission of the created parameters directory.
+const dirPerm fs.FileMode = 0o750
+
+// filePerm is the permission of the parameters file.
+const filePerm fs.FileMode = 0o600
+
+// tmpSuffix is the file name suffix of the file being written.
+const tmpSuffix string = ".tmp"
+
+// Compile-time interface check.
+var _ parameters.Store = (*File)(nil)
+
+// File stores the daemon parameters as a JSON document.
+type File struct {
+	// path is the parameters file.
+	path string
+}
+
+// fileParameters is the JSON document of the parameters file.
+// Unset parameters are left out so the file lists the changed ones only.
+type fileParameters struct {
+	// LogLevel is the daemon log level.
+	LogLevel string `json:"log_level,omitempty"`
+	// ProbeInterval is the default probe interval, as a Go duration.
+	ProbeInterval string `json:"probe_interval,omitempty"`
+	// ProbeTimeout is the default probe timeout, as a Go duration.
+	ProbeTimeout string `json:"probe_timeout,omitempty"`
+	// NotificationsMuted reports muted notifications.
+	NotificationsMuted bool `json:"notifications_muted,omitempty"`
+}
+
+// NewFile creates the store of a parameters file.
+//
+// Params:
+//   - path: the parameters file; empty uses DefaultPath.
+//
+// Returns:
+//   - *File: the store.
+func NewFile(path string) *File {
+	// use default path if not specified
+	if path == "" {
+		path = DefaultPath
+	}
+	// return store of the file
+	return &File{path: path}
+}
+
+// Load reads the parameters file.
+//
+// Returns:
+//   - domainconfig.DaemonParameters: the saved parameters, zero when the file does not exist.
+//   - error: if the file cannot be read or decoded.
+func (f *File) Load() (domainconfig.DaemonParameters, error) {
+	data, err := os.ReadFile(f.path) // #nosec G304 - path is operator configuration
+	// nothing changed yet
+	if errors.Is(err, fs.ErrNotExist) {
+		// return zero parameters
+		return domainconfig.DaemonParameters{}, nil
+	}
+	// file unreadable
+	if err != nil {
+		// return error with context
+		return domainconfig.DaemonParameters{}, fmt.Errorf("reading %s: %w", f.path, err)
+	}
+	var doc fileParameters
+	// file corrupt
+	if err := json.Unmarshal(data, &doc); err != nil {
+		// return error with context
+		return domainconfig.DaemonParameters{}, fmt.Errorf("decoding %s: %w", f.path, err)
+	}
+	interval, err := parseDuration(doc.ProbeInterval)
+	// malformed interval
+	if err != nil {
+		// return error with context
+		return domainconfig.DaemonParameters{}, fmt.Errorf("decoding %s: probe_interval: %w", f.path, err)
+	}
+	timeout, err := parseDuration(doc.ProbeTimeout)
+	// malformed timeout
+	if err != nil {
+		// return error with context
+		return domainconfig.DaemonParameters{}, fmt.Errorf("decoding %s: probe_timeout: %w", f.path, err)
+	}
+	// return decoded parameters
+	return domainconfig.DaemonParameters{
+		LogLevel:           doc.LogLevel,
+		ProbeInterval:      interval,
+		ProbeTimeout:       timeout,
+		NotificationsMuted: doc.NotificationsMuted,
+	}, nil
+}
+
+// Save replaces the parameters file. The file is written aside then
+// renamed, so a crash never leaves a truncated file.
+//
+// Params:
+//   - params: the parameters to save.
+//
+// Returns:
+//   - error: if the directory or file cannot be written.
+func (f *File) Save(params *domainconfig.DaemonParameters) error {
+	doc := fileParameters{
+		LogLevel:           params.LogLevel,
+		NotificationsMuted: params.NotificationsMuted,
+	}
+	// keep set durations only
+	if params.ProbeInterval > 0 {
+		doc.ProbeInterval = params.ProbeInterval.String()
+	}
+	// keep set durations only
+	if params.ProbeTimeout > 0 {
+		doc.ProbeTimeout = params.ProbeTimeout.String()
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	// encoding failure
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("encoding parameters: %w", err)
+	}
+
+	// create the state directory on a new host
+	if err := os.MkdirAll(filepath.Dir(f.path), dirPerm); err != nil {
+		// return error with context
+		return fmt.Errorf("creating %s: %w", filepath.Dir(f.path), err)
+	}
+	tmp := f.path + tmpSuffix
+	// write the new content aside
+	if err := os.WriteFile(tmp, append(data, '\n'), filePerm); err != nil {
+		_ = os.Remove(tmp)
+		// return error with context
+		return fmt.Errorf("writing %s: %w", f.path, err)
+	}
+	// replace the file at once
+	if err := os.Rename(tmp, f.path); err != nil {
+		_ = os.Remove(tmp)
+		// return error with context
+		return fmt.Errorf("writing %s: %w", f.path, err)
+	}
+	// parameters saved
+	return nil
+}
+
+// parseDuration parses an optional Go duration.
+//
+// Params:
+//   - s: the duration text, empty for none.
+//
+// Returns:
+//   - shared.Duration: the duration, zero when empty.
+//   - error: if the text is not a duration.
+func parseDuration(s string) (shared.Duration, error) {
+	// unset duration
+	if s == "" {
+		// return zero duration
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	// malformed duration
+	if err != nil {
+		// return parse error
+		return 0, err
+	}
+	// return parsed duration
+	return shared.FromTimeDuration(d), nil
+}
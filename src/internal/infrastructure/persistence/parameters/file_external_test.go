@@ -0,0 +1,104 @@
+// Package parameters_test provides black-box tests for the parameters file.
+package parameters_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/parameters"
+)
+
+// TestFile_SaveLoad tests that saved parameters are loaded back.
+func TestFile_SaveLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   domainconfig.DaemonParameters
+		wantFile string
+	}{
+		{
+			name: "every parameter",
+			params: domainconfig.DaemonParameters{
+				LogLevel:           "debug",
+				ProbeInterval:      shared.Seconds(30),
+				ProbeTimeout:       shared.FromTimeDuration(1500 * time.Millisecond),
+				NotificationsMuted: true,
+			},
+			wantFile: "{\n  \"log_level\": \"debug\",\n  \"probe_interval\": \"30s\",\n  \"probe_timeout\": \"1.5s\",\n  \"notifications_muted\": true\n}\n",
+		},
+		{
+			name:     "nothing changed",
+			wantFile: "{}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state", "parameters.json")
+			store := parameters.NewFile(path)
+
+			require.NoError(t, store.Save(&tt.params))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFile, string(data))
+			loaded, err := store.Load()
+			require.NoError(t, err)
+			assert.Equal(t, tt.params, loaded)
+		})
+	}
+}
+
+// TestFile_Load tests reading missing and malformed files.
+func TestFile_Load(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    domainconfig.DaemonParameters
+		wantErr bool
+	}{
+		{
+			name: "missing file",
+		},
+		{
+			name:    "muted only",
+			content: `{"notifications_muted": true}`,
+			want:    domainconfig.DaemonParameters{NotificationsMuted: true},
+		},
+		{
+			name:    "corrupt file",
+			content: `{"log_level":`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed duration",
+			content: `{"probe_interval": "soon"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "parameters.json")
+			// write the file when the case has one
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			}
+
+			loaded, err := parameters.NewFile(path).Load()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, loaded)
+		})
+	}
+}
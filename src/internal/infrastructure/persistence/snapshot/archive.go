@@ -0,0 +1,220 @@
+// Package snapshot provides the tarball encoding of daemon bundles.
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	appsnapshot "github.com/kodflow/daemon/internal/application/snapshot"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+const (
+	// manifestFile describes the bundle.
+	manifestFile string = "manifest.json"
+	// configFile holds the effective configuration.
+	configFile string = "config.yaml"
+	// statsFile holds the persisted service statistics.
+	statsFile string = "stats.json"
+	// entryMode is the permission of the tarball entries.
+	entryMode int64 = 0o600
+	// maxEntrySize bounds each entry read from a tarball.
+	maxEntrySize int64 = 64 << 20
+)
+
+// Tarball errors.
+var (
+	// ErrUnsupportedFormat indicates a bundle written by a newer daemon.
+	ErrUnsupportedFormat error = errors.New("unsupported snapshot format")
+	// ErrMissingEntry indicates a tarball without manifest or configuration.
+	ErrMissingEntry error = errors.New("snapshot entry missing")
+	// ErrEntryTooLarge indicates an entry above maxEntrySize.
+	ErrEntryTooLarge error = errors.New("snapshot entry too large")
+)
+
+// Compile-time interface check.
+var _ appsnapshot.Archive = (*Tarball)(nil)
+
+// manifest is the JSON description of a bundle.
+type manifest struct {
+	// Format is the version of the bundle layout.
+	Format int `json:"format"`
+	// DaemonVersion is the version of the daemon that saved the bundle.
+	DaemonVersion string `json:"daemon_version"`
+	// CreatedAt is when the bundle was saved.
+	CreatedAt time.Time `json:"created_at"`
+	// Services is the number of service statistics records.
+	Services int `json:"services"`
+}
+
+// Tarball encodes bundles as gzip-compressed tar archives holding
+// manifest.json, config.yaml and stats.json.
+type Tarball struct{}
+
+// NewTarball creates a tarball encoding.
+//
+// Returns:
+//   - *Tarball: the encoding.
+func NewTarball() *Tarball {
+	// return stateless encoding
+	return &Tarball{}
+}
+
+// Write encodes a bundle.
+//
+// Params:
+//   - w: the destination.
+//   - bundle: the bundle.
+//
+// Returns:
+//   - error: encoding or write error.
+func (t *Tarball) Write(w io.Writer, bundle *appsnapshot.Bundle) error {
+	meta, err := json.MarshalIndent(manifest{
+		Format:        bundle.Format,
+		DaemonVersion: bundle.DaemonVersion,
+		CreatedAt:     bundle.CreatedAt,
+		Services:      len(bundle.Stats),
+	}, "", "  ")
+	// manifest encoding failed
+	if err != nil {
+		// return encoding error
+		return err
+	}
+	stats, err := json.MarshalIndent(bundle.Stats, "", "  ")
+	// statistics encoding failed
+	if err != nil {
+		// return encoding error
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	// write each entry, manifest first
+	for _, entry := range []struct {
+		name string
+		data []byte
+	}{{manifestFile, meta}, {configFile, bundle.Config}, {statsFile, stats}} {
+		header := &tar.Header{Name: entry.name, Mode: entryMode, Size: int64(len(entry.data)), ModTime: bundle.CreatedAt}
+		// propagate header write error
+		if err := tw.WriteHeader(header); err != nil {
+			// return write error
+			return err
+		}
+		// propagate content write error
+		if _, err := tw.Write(entry.data); err != nil {
+			// return write error
+			return err
+		}
+	}
+	// flush tar then gzip trailers
+	return errors.Join(tw.Close(), gz.Close())
+}
+
+// Read decodes a bundle. Unknown entries are ignored, so newer bundles of
+// the same format can add some.
+//
+// Params:
+//   - r: the source.
+//
+// Returns:
+//   - *appsnapshot.Bundle: the decoded bundle.
+//   - error: a corrupt tarball, ErrMissingEntry, ErrEntryTooLarge or ErrUnsupportedFormat.
+func (t *Tarball) Read(r io.Reader) (*appsnapshot.Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	// not a gzip stream
+	if err != nil {
+		// return decoding error
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
+
+	entries, err := readEntries(tar.NewReader(gz))
+	// corrupt tarball
+	if err != nil {
+		// return decoding error
+		return nil, err
+	}
+	meta, cfg := entries[manifestFile], entries[configFile]
+	// manifest and configuration are required
+	if meta == nil || cfg == nil {
+		// return missing entry error
+		return nil, fmt.Errorf("%w: %s and %s are required", ErrMissingEntry, manifestFile, configFile)
+	}
+
+	var m manifest
+	// propagate manifest decoding error
+	if err := json.Unmarshal(meta, &m); err != nil {
+		// return error naming the entry
+		return nil, fmt.Errorf("%s: %w", manifestFile, err)
+	}
+	// refuse layouts this daemon does not know
+	if m.Format < 1 || m.Format > appsnapshot.FormatVersion {
+		// return error with the format
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedFormat, m.Format)
+	}
+	bundle := &appsnapshot.Bundle{
+		Format:        m.Format,
+		DaemonVersion: m.DaemonVersion,
+		CreatedAt:     m.CreatedAt,
+		Config:        cfg,
+	}
+	// decode statistics when present
+	if stats := entries[statsFile]; stats != nil {
+		var records []storage.ServiceStatsRecord
+		// propagate statistics decoding error
+		if err := json.Unmarshal(stats, &records); err != nil {
+			// return error naming the entry
+			return nil, fmt.Errorf("%s: %w", statsFile, err)
+		}
+		bundle.Stats = records
+	}
+	// return decoded bundle
+	return bundle, nil
+}
+
+// readEntries reads the regular files of a tarball.
+//
+// Params:
+//   - tr: the tar reader.
+//
+// Returns:
+//   - map[string][]byte: the file contents, by name.
+//   - error: a corrupt tarball or ErrEntryTooLarge.
+func readEntries(tr *tar.Reader) (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+	// read each entry
+	for {
+		header, err := tr.Next()
+		// end of archive
+		if errors.Is(err, io.EOF) {
+			// return every entry
+			return entries, nil
+		}
+		// corrupt archive
+		if err != nil {
+			// return decoding error
+			return nil, err
+		}
+		// skip directories and links
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// bound memory use
+		if header.Size > maxEntrySize {
+			// return error naming the entry
+			return nil, fmt.Errorf("%w: %s", ErrEntryTooLarge, header.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxEntrySize))
+		// truncated entry
+		if err != nil {
+			// return decoding error
+			return nil, err
+		}
+		entries[header.Name] = data
+	}
+}
@@ -0,0 +1,93 @@
+// Package snapshot_test provides black-box tests for the snapshot tarball.
+package snapshot_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsnapshot "github.com/kodflow/daemon/internal/application/snapshot"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/storage"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/snapshot"
+)
+
+// TestTarball_roundTrip tests that a written bundle reads back unchanged.
+//
+// Params:
+//   - t: the testing context.
+func TestTarball_roundTrip(t *testing.T) {
+	hour := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	want := &appsnapshot.Bundle{
+		Format:        appsnapshot.FormatVersion,
+		DaemonVersion: "1.2.3",
+		CreatedAt:     hour,
+		Config:        []byte("version: \"1\"\n"),
+		Stats: []storage.ServiceStatsRecord{{
+			Service:    "api",
+			StartCount: 3,
+			Uptime:     time.Hour,
+			Hours:      metrics.AvailabilityLog{{Start: hour, Up: 59 * time.Minute, Down: time.Minute}},
+			UpdatedAt:  hour,
+		}},
+	}
+	var buf bytes.Buffer
+	tarball := snapshot.NewTarball()
+
+	require.NoError(t, tarball.Write(&buf, want))
+	got, err := tarball.Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+// TestTarball_Read_invalid tests that invalid tarballs are refused.
+//
+// Params:
+//   - t: the testing context.
+func TestTarball_Read_invalid(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// entries are the tarball files.
+		entries map[string]string
+		// wantErr is the expected error.
+		wantErr error
+	}{
+		{
+			name:    "missing configuration",
+			entries: map[string]string{"manifest.json": `{"format":1}`},
+			wantErr: snapshot.ErrMissingEntry,
+		},
+		{
+			name:    "newer format",
+			entries: map[string]string{"manifest.json": `{"format":99}`, "config.yaml": "version: \"1\"\n"},
+			wantErr: snapshot.ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			for name, content := range tt.entries {
+				require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}))
+				_, err := tw.Write([]byte(content))
+				require.NoError(t, err)
+			}
+			require.NoError(t, tw.Close())
+			require.NoError(t, gz.Close())
+
+			_, err := snapshot.NewTarball().Read(&buf)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := snapshot.NewTarball().Read(bytes.NewBufferString("not a tarball"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,300 @@
+// Package spool provides a disk-backed queue of outbound payloads.
+// Sinks spool what they could not deliver and replay it once the remote
+// end is reachable again, so transient partitions do not lose data.
+package spool
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxBytes is the spool size cap when none is given.
+const DefaultMaxBytes int64 = 64 << 20
+
+// entrySuffix is the file name suffix of spooled payloads.
+const entrySuffix string = ".spool"
+
+// tmpSuffix is the file name suffix of payloads being written.
+const tmpSuffix string = ".tmp"
+
+// seqWidth is the zero-padded width of entry sequence numbers, so that
+// file names sort in spooling order.
+const seqWidth int = 20
+
+// dirPerm is the permission of the spool directory.
+const dirPerm fs.FileMode = 0o700
+
+// filePerm is the permission of spooled payloads.
+const filePerm fs.FileMode = 0o600
+
+// ErrPayloadTooLarge indicates a payload larger than the whole spool.
+var ErrPayloadTooLarge error = errors.New("payload larger than spool")
+
+// Spool is a FIFO of payloads stored as files in a directory.
+// When the total size exceeds the cap, the oldest payloads are dropped.
+type Spool struct {
+	// dir is the spool directory.
+	dir string
+	// maxBytes caps the total size of spooled payloads.
+	maxBytes int64
+	// mu protects the fields below and the directory content.
+	mu sync.Mutex
+	// entries are the spooled payloads, oldest first.
+	entries []entry
+	// size is the total size of the entries.
+	size int64
+	// next is the sequence of the next payload.
+	next uint64
+	// dropped counts payloads dropped by the size cap.
+	dropped uint64
+	// replayMu serializes replays.
+	replayMu sync.Mutex
+}
+
+// entry is a spooled payload.
+type entry struct {
+	// seq orders the payloads.
+	seq uint64
+	// size is the payload size in bytes.
+	size int64
+}
+
+// New opens a spool directory, creating it if needed. Payloads left by a
+// previous run are kept and replayed first.
+//
+// Params:
+//   - dir: the spool directory.
+//   - maxBytes: the size cap; zero or negative uses DefaultMaxBytes.
+//
+// Returns:
+//   - *Spool: the spool.
+//   - error: if the directory cannot be created or read.
+func New(dir string, maxBytes int64) (*Spool, error) {
+	// use default cap if not specified
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	// create the directory
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		// return wrapped error
+		return nil, fmt.Errorf("creating spool directory: %w", err)
+	}
+	s := &Spool{dir: dir, maxBytes: maxBytes}
+	// load payloads left by a previous run
+	if err := s.load(); err != nil {
+		// propagate load error
+		return nil, err
+	}
+	// return opened spool
+	return s, nil
+}
+
+// load indexes the payloads found in the directory.
+//
+// Returns:
+//   - error: if the directory cannot be read.
+func (s *Spool) load() error {
+	files, err := os.ReadDir(s.dir)
+	// check read error
+	if err != nil {
+		// return wrapped error
+		return fmt.Errorf("reading spool directory: %w", err)
+	}
+	// index each payload file
+	for _, f := range files {
+		name := f.Name()
+		// discard writes interrupted by a crash
+		if strings.HasSuffix(name, tmpSuffix) {
+			_ = os.Remove(filepath.Join(s.dir, name))
+			continue
+		}
+		// skip foreign files
+		if !strings.HasSuffix(name, entrySuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, entrySuffix), 10, 64)
+		// skip foreign files with the spool suffix
+		if err != nil {
+			continue
+		}
+		info, err := f.Info()
+		// skip files removed meanwhile
+		if err != nil {
+			continue
+		}
+		s.entries = append(s.entries, entry{seq: seq, size: info.Size()})
+		s.size += info.Size()
+		s.next = max(s.next, seq+1)
+	}
+	slices.SortFunc(s.entries, func(a, b entry) int {
+		// order by sequence
+		return cmp.Compare(a.seq, b.seq)
+	})
+	// enforce the cap on previous content
+	s.trim(0)
+	// loaded
+	return nil
+}
+
+// Put spools a payload, dropping the oldest ones to stay within the cap.
+//
+// Params:
+//   - payload: the payload.
+//
+// Returns:
+//   - error: if the payload exceeds the cap or cannot be written.
+func (s *Spool) Put(payload []byte) error {
+	size := int64(len(payload))
+	// a payload larger than the cap can never be kept
+	if size > s.maxBytes {
+		// return error with size
+		return fmt.Errorf("%w: %d bytes", ErrPayloadTooLarge, size)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seq := s.next
+	path := s.path(seq)
+	tmp := path + tmpSuffix
+	// write then rename so a crash never leaves a partial payload
+	if err := os.WriteFile(tmp, payload, filePerm); err != nil {
+		_ = os.Remove(tmp)
+		// return wrapped error
+		return fmt.Errorf("writing spool entry: %w", err)
+	}
+	// publish the payload
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		// return wrapped error
+		return fmt.Errorf("publishing spool entry: %w", err)
+	}
+	s.next++
+	s.trim(size)
+	s.entries = append(s.entries, entry{seq: seq, size: size})
+	s.size += size
+	// spooled
+	return nil
+}
+
+// Replay hands the payloads to deliver, oldest first, removing each one
+// delivered. It stops at the first delivery error, keeping that payload
+// and the following ones for the next replay.
+//
+// Params:
+//   - deliver: sends one payload.
+//
+// Returns:
+//   - int: the number of payloads delivered.
+//   - error: the delivery error that stopped the replay, if any.
+func (s *Spool) Replay(deliver func(payload []byte) error) (int, error) {
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	delivered := 0
+	// deliver until empty or failing
+	for {
+		s.mu.Lock()
+		// nothing left
+		if len(s.entries) == 0 {
+			s.mu.Unlock()
+			// spool drained
+			return delivered, nil
+		}
+		head := s.entries[0]
+		payload, err := os.ReadFile(s.path(head.seq))
+		s.mu.Unlock()
+
+		// drop unreadable payloads rather than blocking the spool
+		if err == nil {
+			err = deliver(payload)
+			// keep the payload for the next replay
+			if err != nil {
+				// return delivery error
+				return delivered, err
+			}
+			delivered++
+		}
+		s.remove(head.seq)
+	}
+}
+
+// Len returns the number of spooled payloads.
+//
+// Returns:
+//   - int: the number of payloads.
+func (s *Spool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// return entry count
+	return len(s.entries)
+}
+
+// Dropped returns the number of payloads dropped by the size cap.
+//
+// Returns:
+//   - uint64: the number of dropped payloads.
+func (s *Spool) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// return drop count
+	return s.dropped
+}
+
+// remove deletes a payload if it is still spooled; the size cap may have
+// dropped it during a delivery.
+//
+// Params:
+//   - seq: the payload sequence.
+func (s *Spool) remove(seq uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// find the payload
+	i := slices.IndexFunc(s.entries, func(e entry) bool {
+		// matching sequence
+		return e.seq == seq
+	})
+	// already dropped
+	if i < 0 {
+		return
+	}
+	_ = os.Remove(s.path(seq))
+	s.size -= s.entries[i].size
+	s.entries = slices.Delete(s.entries, i, i+1)
+}
+
+// trim drops the oldest payloads until incoming bytes fit within the cap.
+// Must be called with s.mu held.
+//
+// Params:
+//   - incoming: the size about to be added.
+func (s *Spool) trim(incoming int64) {
+	// drop oldest first
+	for len(s.entries) > 0 && s.size+incoming > s.maxBytes {
+		oldest := s.entries[0]
+		_ = os.Remove(s.path(oldest.seq))
+		s.size -= oldest.size
+		s.entries = s.entries[1:]
+		s.dropped++
+	}
+}
+
+// path returns the file of a payload.
+//
+// Params:
+//   - seq: the payload sequence.
+//
+// Returns:
+//   - string: the file path.
+func (s *Spool) path(seq uint64) string {
+	// zero-padded so names sort in order
+	return filepath.Join(s.dir, fmt.Sprintf("%0*d%s", seqWidth, seq, entrySuffix))
+}
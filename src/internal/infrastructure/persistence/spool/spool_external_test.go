@@ -0,0 +1,122 @@
+// Package spool_test provides black-box tests for the spool package.
+package spool_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/spool"
+)
+
+// drain replays every payload of a spool.
+//
+// Params:
+//   - t: the testing context.
+//   - s: the spool.
+//
+// Returns:
+//   - []string: the payloads, oldest first.
+func drain(t *testing.T, s *spool.Spool) []string {
+	t.Helper()
+	var got []string
+	_, err := s.Replay(func(payload []byte) error {
+		got = append(got, string(payload))
+		return nil
+	})
+	require.NoError(t, err)
+	return got
+}
+
+// TestSpool_PutReplay tests that payloads are replayed in order.
+func TestSpool_PutReplay(t *testing.T) {
+	s, err := spool.New(filepath.Join(t.TempDir(), "spool"), 0)
+	require.NoError(t, err)
+
+	// spool payloads
+	for _, p := range []string{"a", "b", "c"} {
+		require.NoError(t, s.Put([]byte(p)))
+	}
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []string{"a", "b", "c"}, drain(t, s))
+	assert.Zero(t, s.Len())
+}
+
+// TestSpool_Replay_stopsOnError tests that a failed delivery keeps the payloads.
+func TestSpool_Replay_stopsOnError(t *testing.T) {
+	s, err := spool.New(t.TempDir(), 0)
+	require.NoError(t, err)
+	require.NoError(t, s.Put([]byte("a")))
+	require.NoError(t, s.Put([]byte("b")))
+
+	unreachable := errors.New("unreachable")
+	calls := 0
+	n, err := s.Replay(func(payload []byte) error {
+		calls++
+		// deliver the first payload only
+		if calls > 1 {
+			return unreachable
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, unreachable)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"b"}, drain(t, s))
+}
+
+// TestSpool_Put_cap tests that the oldest payloads are dropped over the cap.
+func TestSpool_Put_cap(t *testing.T) {
+	tests := []struct {
+		name        string
+		payloads    []string
+		wantErr     error
+		want        []string
+		wantDropped uint64
+	}{
+		{name: "within_cap", payloads: []string{"aaa", "bbb"}, want: []string{"aaa", "bbb"}},
+		{name: "drops_oldest", payloads: []string{"aaa", "bbb", "ccc", "dd"}, want: []string{"ccc", "dd"}, wantDropped: 2},
+		{name: "too_large", payloads: []string{"aaaaaaaaaaa"}, wantErr: spool.ErrPayloadTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := spool.New(t.TempDir(), 6)
+			require.NoError(t, err)
+
+			// spool payloads
+			for _, p := range tt.payloads {
+				err = s.Put([]byte(p))
+			}
+			// check the last result
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDropped, s.Dropped())
+			assert.Equal(t, tt.want, drain(t, s))
+		})
+	}
+}
+
+// TestNew_reopen tests that payloads survive a restart.
+func TestNew_reopen(t *testing.T) {
+	dir := t.TempDir()
+	s, err := spool.New(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, s.Put([]byte("a")))
+	require.NoError(t, s.Put([]byte("b")))
+	// An interrupted write and a foreign file are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00000000000000000009.spool.tmp"), []byte("x"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("x"), 0o600))
+
+	reopened, err := spool.New(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, reopened.Put([]byte("c")))
+	assert.Equal(t, []string{"a", "b", "c"}, drain(t, reopened))
+	assert.NoFileExists(t, filepath.Join(dir, "00000000000000000009.spool.tmp"))
+}
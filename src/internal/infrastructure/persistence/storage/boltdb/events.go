@@ -0,0 +1,291 @@
+//go:build linux
+
+// Package boltdb provides a BoltDB store for event persistence.
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"slices"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+var (
+	// bucketEvents is the bucket name for lifecycle events keyed by sequence.
+	bucketEvents []byte = []byte("events")
+	// bucketEventCursors is the bucket name for per-subscriber replay cursors.
+	bucketEventCursors []byte = []byte("event_cursors")
+)
+
+// Compile-time interface check.
+var _ storage.EventStore = (*Store)(nil)
+
+// AppendEvent persists an event and assigns its sequence number.
+//
+// Params:
+//   - ctx: context for cancellation and timeout
+//   - rec: event to persist; its Seq field is set on success
+//
+// Returns:
+//   - uint64: the assigned sequence
+//   - error: context cancellation or database write errors
+func (s *Store) AppendEvent(ctx context.Context, rec *storage.EventRecord) (uint64, error) {
+	// respect context cancellation before starting database transaction
+	if err := ctx.Err(); err != nil {
+		// propagate cancellation error
+		return 0, err
+	}
+
+	var seq uint64
+	// assign sequence and write atomically so sequences have no gaps
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketEvents)
+		next, err := b.NextSequence()
+		// abort transaction if the sequence cannot be allocated
+		if err != nil {
+			// return error with context
+			return fmt.Errorf("next event sequence: %w", err)
+		}
+		rec.Seq = next
+
+		value, err := encodeEventRecord(rec)
+		// abort transaction if encoding fails
+		if err != nil {
+			// propagate encoding error
+			return err
+		}
+		seq = next
+
+		// persist encoded event
+		return b.Put(uint64ToBytes(next), value)
+	})
+	// report write failures without a sequence
+	if err != nil {
+		rec.Seq = 0
+		// propagate write error
+		return 0, err
+	}
+
+	// return assigned sequence
+	return seq, nil
+}
+
+// ListEvents returns matching events with a sequence greater than since.
+//
+// Params:
+//   - ctx: context for cancellation and timeout
+//   - since: last sequence already seen, zero for all events
+//   - filter: service, type, and count restrictions
+//
+// Returns:
+//   - []storage.EventRecord: matching events, oldest first
+//   - error: context cancellation or database read errors
+func (s *Store) ListEvents(ctx context.Context, since uint64, filter storage.EventFilter) ([]storage.EventRecord, error) {
+	// respect context cancellation before starting database transaction
+	if err := ctx.Err(); err != nil {
+		// propagate cancellation error
+		return nil, err
+	}
+
+	var result []storage.EventRecord
+	// read events in a consistent snapshot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketEvents).Cursor()
+
+		// seek past the cursor and scan forward
+		for k, v := c.Seek(uint64ToBytes(since + 1)); k != nil; k, v = c.Next() {
+			// stop once the page is full
+			if filter.Limit > 0 && len(result) >= filter.Limit {
+				break
+			}
+			var rec storage.EventRecord
+			// abort scan if a record cannot be decoded
+			if err := decodeEventRecord(v, &rec); err != nil {
+				// return error with sequence context
+				return fmt.Errorf("decode event %d: %w", binary.BigEndian.Uint64(k), err)
+			}
+			// keep matching events only
+			if filter.Matches(&rec) {
+				result = append(result, rec)
+			}
+		}
+
+		// signal successful read
+		return nil
+	})
+
+	// return events and error
+	return result, err
+}
+
+// SaveCursor records the last sequence processed by a subscriber.
+// A sequence lower than the stored cursor is ignored.
+//
+// Params:
+//   - ctx: context for cancellation and timeout
+//   - subscriber: the subscriber name
+//   - seq: the last processed sequence
+//
+// Returns:
+//   - error: context cancellation or database write errors
+func (s *Store) SaveCursor(ctx context.Context, subscriber string, seq uint64) error {
+	// respect context cancellation before starting database transaction
+	if err := ctx.Err(); err != nil {
+		// propagate cancellation error
+		return err
+	}
+
+	// compare and update atomically
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketEventCursors)
+		key := []byte(subscriber)
+		// never move a cursor backwards
+		if current := b.Get(key); current != nil && binary.BigEndian.Uint64(current) >= seq {
+			// keep the newer cursor
+			return nil
+		}
+		// persist new cursor
+		return b.Put(key, uint64ToBytes(seq))
+	})
+}
+
+// LoadCursor returns the last sequence processed by a subscriber.
+//
+// Params:
+//   - ctx: context for cancellation and timeout
+//   - subscriber: the subscriber name
+//
+// Returns:
+//   - uint64: the stored cursor, zero for unknown subscribers
+//   - error: context cancellation or database read errors
+func (s *Store) LoadCursor(ctx context.Context, subscriber string) (uint64, error) {
+	// respect context cancellation before starting database transaction
+	if err := ctx.Err(); err != nil {
+		// propagate cancellation error
+		return 0, err
+	}
+
+	var seq uint64
+	// read cursor
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(bucketEventCursors).Get([]byte(subscriber))
+		// decode stored cursor if any
+		if value != nil {
+			seq = binary.BigEndian.Uint64(value)
+		}
+		// signal successful read
+		return nil
+	})
+
+	// return cursor and error
+	return seq, err
+}
+
+// pruneEvents removes events that occurred before the cutoff.
+// Events are stored in sequence order, which follows occurrence order, so
+// the scan stops at the first event to keep.
+//
+// Params:
+//   - b: the events bucket
+//   - cutoffKey: timestamp key threshold for deletion
+//
+// Returns:
+//   - int: number of events deleted
+//   - error: decoding or deletion errors
+func (s *Store) pruneEvents(b *bolt.Bucket, cutoffKey []byte) (int, error) {
+	// Collect keys before deleting - cursor reuses memory, so Clone is required.
+	var toDelete [][]byte
+	c := b.Cursor()
+
+	// collect expired events from the oldest
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var rec storage.EventRecord
+		// abort pruning if a record cannot be decoded
+		if err := decodeEventRecord(v, &rec); err != nil {
+			// return error with context
+			return 0, fmt.Errorf("decode event: %w", err)
+		}
+		// stop at the first event to keep
+		if bytes.Compare(timeToKey(rec.Timestamp), cutoffKey) >= 0 {
+			break
+		}
+		toDelete = append(toDelete, slices.Clone(k))
+	}
+
+	// delete collected keys in separate pass to avoid cursor issues
+	for _, k := range toDelete {
+		// abort deletion on first error to prevent partial cleanup
+		if err := b.Delete(k); err != nil {
+			// return error immediately to abort deletion
+			return 0, err
+		}
+	}
+
+	// return count of deleted events
+	return len(toDelete), nil
+}
+
+// uint64ToBytes converts a sequence number to big-endian bytes.
+//
+// Params:
+//   - n: sequence to convert
+//
+// Returns:
+//   - []byte: sortable byte representation
+func uint64ToBytes(n uint64) []byte {
+	var buf [int64ByteLength]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+
+	// return byte slice representation
+	return buf[:]
+}
+
+// encodeEventRecord serializes an event record using gob.
+//
+// Params:
+//   - data: event record to encode
+//
+// Returns:
+//   - []byte: encoded bytes
+//   - error: encoding errors (unreachable with current types)
+func encodeEventRecord(data *storage.EventRecord) ([]byte, error) {
+	buf, ok := bufferPool.Get().(*bytes.Buffer)
+	// allocate new buffer if pool returns unexpected type
+	if !ok {
+		buf = new(bytes.Buffer)
+	}
+	buf.Reset()
+	defer bufferPool.Put(buf)
+
+	// abort encoding if serialization fails
+	if err := gob.NewEncoder(buf).Encode(data); err != nil {
+		// return error with context
+		return nil, fmt.Errorf("gob encode: %w", err)
+	}
+
+	// Copy bytes - buffer will be reused by pool.
+	result := make([]byte, buf.Len())
+	copy(result, buf.Bytes())
+
+	// return encoded bytes
+	return result, nil
+}
+
+// decodeEventRecord deserializes an event record using gob.
+//
+// Params:
+//   - data: encoded bytes to decode
+//   - dest: destination for decoded record
+//
+// Returns:
+//   - error: decoding errors
+func decodeEventRecord(data []byte, dest *storage.EventRecord) error {
+	// deserialize record from encoded bytes
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(dest)
+}
@@ -0,0 +1,134 @@
+//go:build linux
+
+// Package boltdb_test provides external tests for the boltdb package.
+package boltdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// TestStore_ListEvents tests sequence assignment, cursors, and filters.
+func TestStore_ListEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		since    uint64
+		filter   storage.EventFilter
+		expected []uint64
+	}{
+		{name: "all events", since: 0, expected: []uint64{1, 2, 3, 4}},
+		{name: "after cursor", since: 2, expected: []uint64{3, 4}},
+		{name: "past the end", since: 4, expected: nil},
+		{name: "by service", filter: storage.EventFilter{Services: []string{"api"}}, expected: []uint64{1, 3}},
+		{name: "by type", filter: storage.EventFilter{Types: []string{"failed"}}, expected: []uint64{2, 4}},
+		{name: "limited page", since: 1, filter: storage.EventFilter{Limit: 2}, expected: []uint64{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			store := newTestStore(t)
+			ctx := context.Background()
+			records := []storage.EventRecord{
+				{Service: "api", Type: "started"},
+				{Service: "web", Type: "failed"},
+				{Service: "api", Type: "stopped"},
+				{Service: "web", Type: "failed"},
+			}
+			for i := range records {
+				records[i].Timestamp = time.Now()
+				seq, err := store.AppendEvent(ctx, &records[i])
+				require.NoError(t, err)
+				assert.Equal(t, uint64(i+1), seq)
+				assert.Equal(t, seq, records[i].Seq)
+			}
+
+			events, err := store.ListEvents(ctx, tt.since, tt.filter)
+			require.NoError(t, err)
+
+			var seqs []uint64
+			for _, e := range events {
+				seqs = append(seqs, e.Seq)
+			}
+			assert.Equal(t, tt.expected, seqs)
+		})
+	}
+}
+
+// TestStore_Cursor tests that subscriber cursors persist and never move backwards.
+func TestStore_Cursor(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	seq, err := store.LoadCursor(ctx, "cli")
+	require.NoError(t, err)
+	assert.Zero(t, seq)
+
+	require.NoError(t, store.SaveCursor(ctx, "cli", 7))
+	require.NoError(t, store.SaveCursor(ctx, "cli", 3))
+
+	seq, err = store.LoadCursor(ctx, "cli")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), seq)
+
+	seq, err = store.LoadCursor(ctx, "dashboard")
+	require.NoError(t, err)
+	assert.Zero(t, seq)
+}
+
+// TestStore_PruneEvents tests that Prune drops old events and keeps sequences.
+func TestStore_PruneEvents(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	old := storage.EventRecord{Service: "api", Type: "started", Timestamp: time.Now().Add(-48 * time.Hour)}
+	recent := storage.EventRecord{Service: "api", Type: "stopped", Timestamp: time.Now()}
+	_, err := store.AppendEvent(ctx, &old)
+	require.NoError(t, err)
+	_, err = store.AppendEvent(ctx, &recent)
+	require.NoError(t, err)
+
+	deleted, err := store.Prune(ctx, 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	events, err := store.ListEvents(ctx, 0, storage.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, uint64(2), events[0].Seq)
+
+	// Sequences continue after pruning.
+	next := storage.EventRecord{Service: "api", Type: "started", Timestamp: time.Now()}
+	seq, err := store.AppendEvent(ctx, &next)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), seq)
+}
+
+// TestStore_Events_CanceledContext tests that event methods honor cancellation.
+func TestStore_Events_CanceledContext(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := store.AppendEvent(ctx, &storage.EventRecord{})
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = store.ListEvents(ctx, 0, storage.EventFilter{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, store.SaveCursor(ctx, "cli", 1), context.Canceled)
+	_, err = store.LoadCursor(ctx, "cli")
+	assert.ErrorIs(t, err, context.Canceled)
+}
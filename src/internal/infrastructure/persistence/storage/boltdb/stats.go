@@ -0,0 +1,131 @@
+//go:build linux
+
+// Package boltdb provides a BoltDB store for service statistics persistence.
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// bucketServiceStats is the bucket name for lifetime service statistics keyed by service name.
+var bucketServiceStats []byte = []byte("service_stats")
+
+// Compile-time interface checks.
+var (
+	_ storage.StatsStore  = (*Store)(nil)
+	_ storage.StatsLister = (*Store)(nil)
+)
+
+// SaveServiceStats replaces the persisted statistics of a service.
+// Statistics are lifetime data and are not pruned.
+//
+// Params:
+//   - ctx: context for cancellation and timeout
+//   - rec: statistics to persist, keyed by rec.Service
+//
+// Returns:
+//   - error: context cancellation, encoding, or database write errors
+func (s *Store) SaveServiceStats(ctx context.Context, rec *storage.ServiceStatsRecord) error {
+	// respect context cancellation before starting database transaction
+	if err := ctx.Err(); err != nil {
+		// propagate cancellation error
+		return err
+	}
+
+	var buf bytes.Buffer
+	// abort if the record cannot be encoded
+	if err := gob.NewEncoder(&buf).Encode(rec); err != nil {
+		// return error with context
+		return fmt.Errorf("gob encode: %w", err)
+	}
+
+	// replace stored record
+	return s.db.Update(func(tx *bolt.Tx) error {
+		// persist encoded record
+		return tx.Bucket(bucketServiceStats).Put([]byte(rec.Service), buf.Bytes())
+	})
+}
+
+// LoadServiceStats returns the persisted statistics of a service.
+//
+// Params:
+//   - ctx: context for cancellation and timeout
+//   - service: the service name
+//
+// Returns:
+//   - storage.ServiceStatsRecord: the stored record
+//   - bool: false if no record was saved for the service
+//   - error: context cancellation, decoding, or database read errors
+func (s *Store) LoadServiceStats(ctx context.Context, service string) (storage.ServiceStatsRecord, bool, error) {
+	// respect context cancellation before starting database transaction
+	if err := ctx.Err(); err != nil {
+		// propagate cancellation error
+		return storage.ServiceStatsRecord{}, false, err
+	}
+
+	var rec storage.ServiceStatsRecord
+	var found bool
+	// read record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(bucketServiceStats).Get([]byte(service))
+		// unknown service
+		if value == nil {
+			// nothing stored
+			return nil
+		}
+		found = true
+		// decode stored record
+		if err := gob.NewDecoder(bytes.NewReader(value)).Decode(&rec); err != nil {
+			// return error with service context
+			return fmt.Errorf("decode stats of %s: %w", service, err)
+		}
+		// signal successful read
+		return nil
+	})
+
+	// return record and error
+	return rec, found && err == nil, err
+}
+
+// ListServiceStats returns the persisted statistics of every service,
+// ordered by service name.
+//
+// Params:
+//   - ctx: context for cancellation and timeout
+//
+// Returns:
+//   - []storage.ServiceStatsRecord: the stored records
+//   - error: context cancellation, decoding, or database read errors
+func (s *Store) ListServiceStats(ctx context.Context) ([]storage.ServiceStatsRecord, error) {
+	// respect context cancellation before starting database transaction
+	if err := ctx.Err(); err != nil {
+		// propagate cancellation error
+		return nil, err
+	}
+
+	var records []storage.ServiceStatsRecord
+	// read records in key order
+	err := s.db.View(func(tx *bolt.Tx) error {
+		// decode each record
+		return tx.Bucket(bucketServiceStats).ForEach(func(key, value []byte) error {
+			var rec storage.ServiceStatsRecord
+			// abort on undecodable record
+			if err := gob.NewDecoder(bytes.NewReader(value)).Decode(&rec); err != nil {
+				// return error with service context
+				return fmt.Errorf("decode stats of %s: %w", key, err)
+			}
+			records = append(records, rec)
+			// continue iteration
+			return nil
+		})
+	})
+	// return records and error
+	return records, err
+}
@@ -0,0 +1,84 @@
+//go:build linux
+
+// Package boltdb_test provides external tests for the boltdb package.
+package boltdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// TestStore_ServiceStats tests that service statistics are replaced and survive pruning.
+func TestStore_ServiceStats(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	ctx := context.Background()
+	hour := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, found, err := store.LoadServiceStats(ctx, "api")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	first := storage.ServiceStatsRecord{Service: "api", StartCount: 1}
+	require.NoError(t, store.SaveServiceStats(ctx, &first))
+	second := storage.ServiceStatsRecord{
+		Service:      "api",
+		StartCount:   3,
+		FailCount:    1,
+		RestartCount: 2,
+		Uptime:       time.Hour,
+		Downtime:     time.Minute,
+		Hours:        metrics.AvailabilityLog{{Start: hour, Up: 59 * time.Minute, Down: time.Minute}},
+		UpdatedAt:    hour.Add(time.Hour),
+	}
+	require.NoError(t, store.SaveServiceStats(ctx, &second))
+
+	_, err = store.Prune(ctx, time.Nanosecond)
+	require.NoError(t, err)
+
+	rec, found, err := store.LoadServiceStats(ctx, "api")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, second.StartCount, rec.StartCount)
+	assert.Equal(t, second.FailCount, rec.FailCount)
+	assert.Equal(t, second.RestartCount, rec.RestartCount)
+	assert.Equal(t, second.Uptime, rec.Uptime)
+	assert.Equal(t, second.Downtime, rec.Downtime)
+	require.Len(t, rec.Hours, 1)
+	assert.True(t, hour.Equal(rec.Hours[0].Start))
+	assert.Equal(t, 59*time.Minute, rec.Hours[0].Up)
+
+	_, found, err = store.LoadServiceStats(ctx, "web")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+// TestStore_ListServiceStats tests that every service record is listed by name.
+func TestStore_ListServiceStats(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	records, err := store.ListServiceStats(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	for _, name := range []string{"web", "api"} {
+		require.NoError(t, store.SaveServiceStats(ctx, &storage.ServiceStatsRecord{Service: name, StartCount: 1}))
+	}
+
+	records, err = store.ListServiceStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "api", records[0].Service)
+	assert.Equal(t, "web", records[1].Service)
+}
@@ -76,10 +76,11 @@ type bucketPruner interface {
 	Delete(key []byte) error
 }
 
-// Store implements MetricsStore using BoltDB.
+// Store implements MetricsStore and EventStore using BoltDB.
 //
 // Store provides persistent storage for system and process metrics using
-// an embedded BoltDB database with time-series optimizations.
+// an embedded BoltDB database with time-series optimizations, and keeps
+// the lifecycle event log used for replay.
 type Store struct {
 	db     *bolt.DB
 	config storage.StoreConfig
@@ -133,6 +134,9 @@ func (s *Store) initSchema() error {
 			bucketSystemMemory,
 			bucketProcessMetrics,
 			bucketMetadata,
+			bucketEvents,
+			bucketEventCursors,
+			bucketServiceStats,
 		}
 
 		// ensure all buckets exist before writing data
@@ -564,7 +568,7 @@ func (s *Store) GetLatestProcessMetrics(ctx context.Context, serviceName string)
 	return result, nil
 }
 
-// Prune removes metrics older than the specified duration.
+// Prune removes metrics and events older than the specified duration.
 //
 // Params:
 //   - ctx: context for cancellation and timeout
@@ -624,6 +628,14 @@ func (s *Store) pruneTransaction(cutoffKey []byte) (int, error) {
 		}
 		deleted += n
 
+		n, err = s.pruneEvents(tx.Bucket(bucketEvents), cutoffKey)
+		// abort transaction if deletion fails
+		if err != nil {
+			// propagate deletion error
+			return err
+		}
+		deleted += n
+
 		meta := tx.Bucket(bucketMetadata)
 
 		// update last prune timestamp
@@ -0,0 +1,192 @@
+//go:build linux
+
+// Package cgroup reads per-process control group statistics.
+// This file measures the capacity available to supervised services.
+package cgroup
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// meminfoFile is the procfs file holding the host memory.
+	meminfoFile string = "meminfo"
+
+	// keyMemTotal is the meminfo field of the total memory, in KiB.
+	keyMemTotal string = "MemTotal:"
+
+	// memoryMaxFile is the cgroup v2 memory limit file.
+	memoryMaxFile string = "memory.max"
+
+	// cpuMaxFile is the cgroup v2 CPU quota file.
+	cpuMaxFile string = "cpu.max"
+
+	// unlimited is the cgroup v2 value of an unset limit.
+	unlimited string = "max"
+
+	// kibibyte is the unit of meminfo values.
+	kibibyte uint64 = 1024
+)
+
+// CollectCapacity measures the memory and CPUs available to supervised
+// services: the host memory and online CPUs, lowered by the memory.max and
+// cpu.max limits of the daemon's cgroup v2 ancestors.
+//
+// Params:
+//   - ctx: context for cancellation.
+//
+// Returns:
+//   - domainmetrics.HostCapacity: the available capacity.
+//   - error: if the host memory cannot be read.
+func (r *Reader) CollectCapacity(ctx context.Context) (domainmetrics.HostCapacity, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.HostCapacity{}, err
+	}
+
+	memory, err := r.readMemTotal()
+	// fail when the host memory is unknown
+	if err != nil {
+		// return read error
+		return domainmetrics.HostCapacity{}, err
+	}
+	capacity := domainmetrics.HostCapacity{Memory: memory, CPUs: float64(runtime.NumCPU())}
+
+	m, err := r.resolveMembership(os.Getpid())
+	// without a cgroup, the host is the limit
+	if err != nil {
+		// return host capacity
+		return capacity, nil
+	}
+	// apply the limits of the daemon cgroup and its ancestors
+	for _, dir := range m.v2 {
+		for ; strings.HasPrefix(dir, r.cgroupRoot); dir = filepath.Dir(dir) {
+			// lower memory to the cgroup limit
+			if limit, ok := readMemoryMax(filepath.Join(dir, memoryMaxFile)); ok && limit < capacity.Memory {
+				capacity.Memory = limit
+			}
+			// lower CPUs to the cgroup quota
+			if quota, ok := readCPUMax(filepath.Join(dir, cpuMaxFile)); ok && quota < capacity.CPUs {
+				capacity.CPUs = quota
+			}
+			// stop at the hierarchy root
+			if dir == r.cgroupRoot {
+				break
+			}
+		}
+	}
+	// return bounded capacity
+	return capacity, nil
+}
+
+// readMemTotal reads the host memory from procfs.
+//
+// Returns:
+//   - uint64: the total memory in bytes.
+//   - error: if meminfo cannot be read or lacks MemTotal.
+func (r *Reader) readMemTotal() (uint64, error) {
+	// read the total memory line "MemTotal: 16318412 kB"
+	return r.readMeminfo(keyMemTotal, ErrMemTotalNotFound)
+}
+
+// readMeminfo reads a field of procfs meminfo.
+//
+// Params:
+//   - key: the field, with its colon.
+//   - missing: the error returned when the field is absent.
+//
+// Returns:
+//   - uint64: the value in bytes.
+//   - error: if meminfo cannot be read or lacks the field.
+func (r *Reader) readMeminfo(key string, missing error) (uint64, error) {
+	f, err := os.Open(filepath.Join(r.procRoot, meminfoFile))
+	// fail when meminfo is unreadable
+	if err != nil {
+		// return wrapped open error
+		return 0, process.WrapError("read meminfo", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	// find the field line, such as "MemTotal: 16318412 kB"
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// skip other fields
+		if len(fields) < 2 || fields[0] != key {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], decimalBase, bitSize64)
+		// fail on malformed value
+		if err != nil {
+			// return wrapped parse error
+			return 0, process.WrapError("parse meminfo", err)
+		}
+		// convert KiB to bytes
+		return kb * kibibyte, nil
+	}
+	// field missing
+	return 0, process.WrapError("read meminfo", missing)
+}
+
+// readMemoryMax reads a cgroup v2 memory limit.
+//
+// Params:
+//   - path: the memory.max file.
+//
+// Returns:
+//   - uint64: the limit in bytes.
+//   - bool: false when the file is missing or the memory is unlimited.
+func readMemoryMax(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	// no limit without the file
+	if err != nil {
+		// unlimited
+		return 0, false
+	}
+	value := strings.TrimSpace(string(data))
+	limit, err := strconv.ParseUint(value, decimalBase, bitSize64)
+	// "max" or malformed values are no limit
+	return limit, err == nil && value != unlimited
+}
+
+// readCPUMax reads a cgroup v2 CPU quota as a number of CPUs.
+//
+// Params:
+//   - path: the cpu.max file, "quota period" or "max period".
+//
+// Returns:
+//   - float64: the quota divided by the period.
+//   - bool: false when the file is missing or the CPU is unlimited.
+func readCPUMax(path string) (float64, bool) {
+	data, err := os.ReadFile(path)
+	// no quota without the file
+	if err != nil {
+		// unlimited
+		return 0, false
+	}
+	quota, period, ok := strings.Cut(strings.TrimSpace(string(data)), " ")
+	// "max" quota is no limit
+	if !ok || quota == unlimited {
+		// unlimited
+		return 0, false
+	}
+	q, qErr := strconv.ParseUint(quota, decimalBase, bitSize64)
+	p, pErr := strconv.ParseUint(period, decimalBase, bitSize64)
+	// malformed values are no limit
+	if qErr != nil || pErr != nil || p == 0 {
+		// unlimited
+		return 0, false
+	}
+	// return CPUs granted per period
+	return float64(q) / float64(p), true
+}
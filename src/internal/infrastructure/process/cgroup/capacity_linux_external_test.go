@@ -0,0 +1,98 @@
+//go:build linux
+
+// Package cgroup_test provides black-box tests for the cgroup package.
+// It tests capacity measurement against fixture hierarchies.
+package cgroup_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/cgroup"
+)
+
+// TestReader_CollectCapacity tests host capacity bounded by the daemon cgroup.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectCapacity(t *testing.T) {
+	const meminfo = "MemTotal:        4194304 kB\nMemFree:         1048576 kB\n"
+	cpus := float64(runtime.NumCPU())
+
+	tests := []struct {
+		name       string
+		procCgroup string
+		files      map[string]string
+		wantMemory uint64
+		wantCPUs   float64
+	}{
+		{
+			name:       "no cgroup limits",
+			procCgroup: "0::/daemon\n",
+			files:      map[string]string{"daemon/memory.max": "max\n", "daemon/cpu.max": "max 100000\n"},
+			wantMemory: 4 << 30,
+			wantCPUs:   cpus,
+		},
+		{
+			name:       "daemon cgroup limits",
+			procCgroup: "0::/daemon\n",
+			files:      map[string]string{"daemon/memory.max": "1073741824\n", "daemon/cpu.max": "50000 100000\n"},
+			wantMemory: 1 << 30,
+			wantCPUs:   0.5,
+		},
+		{
+			name:       "ancestor limit",
+			procCgroup: "0::/parent/daemon\n",
+			files:      map[string]string{"parent/memory.max": "536870912\n", "parent/daemon/memory.max": "max\n"},
+			wantMemory: 512 << 20,
+			wantCPUs:   cpus,
+		},
+		{
+			name:       "cgroup v1 only",
+			procCgroup: "3:cpu,cpuacct:/docker/abc\n",
+			wantMemory: 4 << 30,
+			wantCPUs:   cpus,
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			procRoot := t.TempDir()
+			cgroupRoot := t.TempDir()
+			writeFixture(t, filepath.Join(procRoot, "meminfo"), meminfo)
+			writeFixture(t, filepath.Join(procRoot, strconv.Itoa(os.Getpid()), "cgroup"), tt.procCgroup)
+			// Place the cgroup limit files.
+			for name, content := range tt.files {
+				writeFixture(t, filepath.Join(cgroupRoot, name), content)
+			}
+
+			capacity, err := cgroup.NewWithRoots(procRoot, cgroupRoot).CollectCapacity(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMemory, capacity.Memory)
+			assert.InDelta(t, tt.wantCPUs, capacity.CPUs, 0.001)
+		})
+	}
+}
+
+// TestReader_CollectCapacity_NoMeminfo tests errors when the host memory is unknown.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectCapacity_NoMeminfo(t *testing.T) {
+	procRoot := t.TempDir()
+	writeFixture(t, filepath.Join(procRoot, "meminfo"), "MemFree: 1024 kB\n")
+
+	_, err := cgroup.NewWithRoots(procRoot, t.TempDir()).CollectCapacity(context.Background())
+
+	assert.ErrorIs(t, err, cgroup.ErrMemTotalNotFound)
+}
@@ -0,0 +1,24 @@
+//go:build !linux
+
+// Package cgroup reads per-process control group statistics.
+package cgroup
+
+import (
+	"context"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// CollectCapacity is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//
+// Returns:
+//   - domainmetrics.HostCapacity: empty capacity.
+//   - error: process.ErrNotSupported.
+func (r *Reader) CollectCapacity(_ context.Context) (domainmetrics.HostCapacity, error) {
+	// procfs is Linux-only
+	return domainmetrics.HostCapacity{}, process.ErrNotSupported
+}
@@ -0,0 +1,64 @@
+// Package cgroup reads per-process control group statistics.
+// It resolves the cgroup a process belongs to and parses its CPU
+// controller counters and PSI, supporting both cgroup v1 and v2 hierarchies.
+package cgroup
+
+import "errors"
+
+const (
+	// defaultProcRoot is the default procfs mount point.
+	defaultProcRoot string = "/proc"
+
+	// defaultCgroupRoot is the default cgroup filesystem mount point.
+	defaultCgroupRoot string = "/sys/fs/cgroup"
+)
+
+var (
+	// ErrCPUControllerNotFound indicates that no CPU controller stats exist for the process.
+	ErrCPUControllerNotFound error = errors.New("cpu controller not found for process")
+
+	// ErrPressureNotFound indicates that no PSI files exist for the inspected target.
+	ErrPressureNotFound error = errors.New("pressure stall information not found")
+
+	// ErrMemTotalNotFound indicates that meminfo does not report the total memory.
+	ErrMemTotalNotFound error = errors.New("MemTotal not found in meminfo")
+
+	// ErrVmRSSNotFound indicates that the process status does not report the resident set size.
+	ErrVmRSSNotFound error = errors.New("VmRSS not found in process status")
+)
+
+// Reader reads cgroup statistics for supervised processes.
+// It implements the application metrics ThrottlingCollector, PressureCollector,
+// CapacityCollector, HostFactsCollector and SelfCollector ports.
+type Reader struct {
+	// procRoot is the procfs mount point used to resolve process cgroups.
+	procRoot string
+	// cgroupRoot is the cgroup filesystem mount point.
+	cgroupRoot string
+}
+
+// New creates a cgroup reader using the standard mount points.
+//
+// Returns:
+//   - *Reader: reader bound to /proc and /sys/fs/cgroup.
+func New() *Reader {
+	// use standard mount points
+	return NewWithRoots(defaultProcRoot, defaultCgroupRoot)
+}
+
+// NewWithRoots creates a cgroup reader with custom mount points.
+// This is primarily used for testing against fixture directories.
+//
+// Params:
+//   - procRoot: procfs mount point.
+//   - cgroupRoot: cgroup filesystem mount point.
+//
+// Returns:
+//   - *Reader: reader bound to the given mount points.
+func NewWithRoots(procRoot, cgroupRoot string) *Reader {
+	// construct reader with provided roots
+	return &Reader{
+		procRoot:   procRoot,
+		cgroupRoot: cgroupRoot,
+	}
+}
@@ -0,0 +1,138 @@
+//go:build linux
+
+// Package cgroup reads per-process control group statistics.
+// This file contains Linux cgroup membership resolution.
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// cgroupV2HierarchyID is the hierarchy ID of the unified cgroup v2 entry.
+	cgroupV2HierarchyID string = "0"
+
+	// cgroupLineFields is the number of fields in a /proc/[pid]/cgroup line.
+	cgroupLineFields int = 3
+
+	// cpuController is the name of the v1 CPU controller.
+	cpuController string = "cpu"
+
+	// decimalBase is the base for decimal number parsing.
+	decimalBase int = 10
+
+	// bitSize64 is the bit size for 64-bit integers.
+	bitSize64 int = 64
+)
+
+// membership holds the cgroup directories a process belongs to.
+type membership struct {
+	// v1CPU lists candidate directories of the v1 CPU controller.
+	v1CPU []string
+	// v2 lists directories of the unified v2 hierarchy.
+	v2 []string
+}
+
+// resolveMembership reads /proc/[pid]/cgroup and maps it to cgroup directories.
+//
+// Params:
+//   - pid: process ID whose cgroup is resolved.
+//
+// Returns:
+//   - membership: the resolved cgroup directories.
+//   - error: if the process cgroup file cannot be read.
+func (r *Reader) resolveMembership(pid int) (membership, error) {
+	data, err := os.ReadFile(filepath.Join(r.procRoot, strconv.Itoa(pid), "cgroup"))
+	// fail when the process cgroup file is unreadable
+	if err != nil {
+		// return wrapped read error
+		return membership{}, process.WrapError("read process cgroup", err)
+	}
+	// parse hierarchy lines
+	return r.parseMembership(string(data)), nil
+}
+
+// parseMembership maps /proc/[pid]/cgroup content to cgroup directories.
+//
+// Params:
+//   - content: content of /proc/[pid]/cgroup.
+//
+// Returns:
+//   - membership: the resolved cgroup directories.
+func (r *Reader) parseMembership(content string) membership {
+	var m membership
+	// inspect each hierarchy line "id:controllers:path"
+	for line := range strings.Lines(content) {
+		fields := strings.SplitN(strings.TrimSpace(line), ":", cgroupLineFields)
+		// skip malformed lines
+		if len(fields) != cgroupLineFields {
+			continue
+		}
+
+		// classify hierarchy
+		switch {
+		// unified cgroup v2 hierarchy
+		case fields[0] == cgroupV2HierarchyID && fields[1] == "":
+			m.v2 = append(m.v2, filepath.Join(r.cgroupRoot, fields[2]))
+		// cgroup v1 hierarchy carrying the CPU controller
+		case hasCPUController(fields[1]):
+			m.v1CPU = append(m.v1CPU,
+				filepath.Join(r.cgroupRoot, fields[1], fields[2]),
+				filepath.Join(r.cgroupRoot, cpuController, fields[2]),
+			)
+		// unrelated controller
+		default:
+			// not a tracked hierarchy
+		}
+	}
+	// return resolved directories
+	return m
+}
+
+// findFile returns the first existing file with the given name among directories.
+//
+// Params:
+//   - dirs: candidate directories in priority order.
+//   - name: file name to look for.
+//
+// Returns:
+//   - string: path to the file.
+//   - bool: true if the file exists in one of the directories.
+func findFile(dirs []string, name string) (string, bool) {
+	// return the first candidate that exists
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, name)
+		// skip missing candidates
+		if _, err := os.Stat(candidate); err == nil {
+			// found file
+			return candidate, true
+		}
+	}
+	// no candidate found
+	return "", false
+}
+
+// hasCPUController reports whether a v1 controller list contains the CPU controller.
+//
+// Params:
+//   - controllers: comma-separated controller list.
+//
+// Returns:
+//   - bool: true if the cpu controller is present.
+func hasCPUController(controllers string) bool {
+	// check each controller name
+	for name := range strings.SplitSeq(controllers, ",") {
+		// match exact controller name
+		if name == cpuController {
+			// found cpu controller
+			return true
+		}
+	}
+	// cpu controller absent
+	return false
+}
@@ -0,0 +1,88 @@
+//go:build linux
+
+// Package cgroup reads per-process control group statistics.
+// This file reads the host facts checked by service start conditions.
+package cgroup
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+const (
+	// osReleaseFile is the procfs file holding the kernel release.
+	osReleaseFile string = "sys/kernel/osrelease"
+
+	// controllersFile lists the controllers of a cgroup v2 hierarchy root.
+	controllersFile string = "cgroup.controllers"
+
+	// keyMemAvailable is the meminfo field of the memory available for new processes, in KiB.
+	keyMemAvailable string = "MemAvailable:"
+)
+
+// ErrMemAvailableNotFound indicates that meminfo does not report the available memory.
+var ErrMemAvailableNotFound error = errors.New("MemAvailable not found in meminfo")
+
+// CollectHostFacts reads the host facts checked by service start
+// conditions: the kernel release from procfs, the architecture the daemon
+// was built for, whether the cgroup root is a v2 hierarchy, MemAvailable
+// and the existence of each path. Facts that cannot be read stay zero, so
+// the conditions depending on them are unmet.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - paths: the paths whose existence is checked.
+//
+// Returns:
+//   - domainconfig.HostFacts: the facts.
+//   - error: only on cancellation.
+func (r *Reader) CollectHostFacts(ctx context.Context, paths []string) (domainconfig.HostFacts, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainconfig.HostFacts{}, err
+	}
+	facts := domainconfig.HostFacts{
+		Architecture: runtime.GOARCH,
+		Paths:        make(map[string]bool, len(paths)),
+	}
+	// read the kernel release, "6.1.0-18-amd64"
+	if data, err := os.ReadFile(filepath.Join(r.procRoot, osReleaseFile)); err == nil {
+		facts.KernelVersion = strings.TrimSpace(string(data))
+	}
+	// only the root of a v2 hierarchy lists its controllers
+	if _, err := os.Stat(filepath.Join(r.cgroupRoot, controllersFile)); err == nil {
+		facts.CgroupV2 = true
+	}
+	// read the memory available without swapping
+	if available, err := r.readMeminfo(keyMemAvailable, ErrMemAvailableNotFound); err == nil {
+		facts.AvailableMemory = available
+	}
+	// check each path
+	for _, path := range paths {
+		facts.Paths[path] = pathExists(path)
+	}
+	// return collected facts
+	return facts, nil
+}
+
+// pathExists reports whether a path exists, like the access check of
+// systemd: a path the daemon may not stat still exists.
+//
+// Params:
+//   - path: the path.
+//
+// Returns:
+//   - bool: false only when the path is missing.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	// only a missing path does not exist
+	return !errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,87 @@
+//go:build linux
+
+// Package cgroup_test provides black-box tests for the cgroup package.
+// It tests host facts reading against fixture hierarchies.
+package cgroup_test
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/cgroup"
+)
+
+// TestReader_CollectHostFacts tests the facts read from procfs and the cgroup root.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectHostFacts(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		wantKernel    string
+		wantCgroupV2  bool
+		wantAvailable uint64
+		wantProc      bool
+	}{
+		{
+			name: "cgroup v2 host",
+			files: map[string]string{
+				"proc/sys/kernel/osrelease": "6.1.0-18-amd64\n",
+				"proc/meminfo":              "MemTotal: 4194304 kB\nMemAvailable: 1048576 kB\n",
+				"cgroup/cgroup.controllers": "cpu memory io\n",
+			},
+			wantKernel:    "6.1.0-18-amd64",
+			wantCgroupV2:  true,
+			wantAvailable: 1 << 30,
+			wantProc:      true,
+		},
+		{
+			name:  "unreadable facts stay zero",
+			files: map[string]string{"cgroup/cpu,cpuacct/cpu.stat": "nr_periods 0\n"},
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			// Place the fixture files.
+			for name, content := range tt.files {
+				writeFixture(t, filepath.Join(root, name), content)
+			}
+			present := filepath.Join(root, "proc")
+			missing := filepath.Join(root, "missing")
+
+			facts, err := cgroup.NewWithRoots(filepath.Join(root, "proc"), filepath.Join(root, "cgroup")).
+				CollectHostFacts(context.Background(), []string{present, missing})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKernel, facts.KernelVersion)
+			assert.Equal(t, runtime.GOARCH, facts.Architecture)
+			assert.Equal(t, tt.wantCgroupV2, facts.CgroupV2)
+			assert.Equal(t, tt.wantAvailable, facts.AvailableMemory)
+			assert.Equal(t, tt.wantProc, facts.Paths[present])
+			assert.False(t, facts.Paths[missing])
+		})
+	}
+}
+
+// TestReader_CollectHostFacts_Canceled tests that a canceled context reads nothing.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectHostFacts_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := cgroup.NewWithRoots(t.TempDir(), t.TempDir()).CollectHostFacts(ctx, nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
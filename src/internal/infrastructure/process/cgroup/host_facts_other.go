@@ -0,0 +1,44 @@
+//go:build !linux
+
+// Package cgroup reads per-process control group statistics.
+package cgroup
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"runtime"
+
+	domainconfig "github.com/kodflow/daemon/internal/domain/config"
+)
+
+// CollectHostFacts reads the architecture and the existence of each path
+// outside Linux; the kernel release, cgroup v2 and available memory stay
+// unknown, so the conditions depending on them are unmet.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - paths: the paths whose existence is checked.
+//
+// Returns:
+//   - domainconfig.HostFacts: the facts.
+//   - error: only on cancellation.
+func (r *Reader) CollectHostFacts(ctx context.Context, paths []string) (domainconfig.HostFacts, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainconfig.HostFacts{}, err
+	}
+	facts := domainconfig.HostFacts{
+		Architecture: runtime.GOARCH,
+		Paths:        make(map[string]bool, len(paths)),
+	}
+	// check each path
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		facts.Paths[path] = !errors.Is(err, fs.ErrNotExist)
+	}
+	// return collected facts
+	return facts, nil
+}
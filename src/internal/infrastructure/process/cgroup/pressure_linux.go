@@ -0,0 +1,248 @@
+//go:build linux
+
+// Package cgroup reads per-process control group statistics.
+// This file contains the Linux PSI (Pressure Stall Information) reader.
+package cgroup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// pressureFileSuffix is the suffix of cgroup v2 PSI files.
+	pressureFileSuffix string = ".pressure"
+
+	// hostPressureDir is the procfs directory holding host PSI files.
+	hostPressureDir string = "pressure"
+)
+
+// pressure line keys.
+const (
+	// keyAvg10 is the 10-second average field.
+	keyAvg10 string = "avg10"
+	// keyAvg60 is the 60-second average field.
+	keyAvg60 string = "avg60"
+	// keyAvg300 is the 300-second average field.
+	keyAvg300 string = "avg300"
+	// keyTotal is the cumulative stall time field in microseconds.
+	keyTotal string = "total"
+)
+
+// pressureResources lists the PSI resources in collection order.
+var pressureResources [3]string = [3]string{
+	domainmetrics.PressureResourceCPU,
+	domainmetrics.PressureResourceMemory,
+	domainmetrics.PressureResourceIO,
+}
+
+// CollectPressure reads CPU, memory, and I/O PSI for the cgroup of a process.
+// PSI is only exposed by the unified cgroup v2 hierarchy; resources whose
+// pressure file is missing are left at zero.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - pid: process ID whose cgroup is inspected.
+//
+// Returns:
+//   - domainmetrics.ResourcePressure: the pressure sample.
+//   - error: if the cgroup cannot be resolved or exposes no PSI.
+func (r *Reader) CollectPressure(ctx context.Context, pid int) (domainmetrics.ResourcePressure, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.ResourcePressure{}, err
+	}
+
+	m, err := r.resolveMembership(pid)
+	// fail when the cgroup cannot be resolved
+	if err != nil {
+		// return resolution error
+		return domainmetrics.ResourcePressure{}, err
+	}
+
+	// read PSI files from the first v2 directory carrying them
+	return readPressure(func(resource string) (string, bool) {
+		// locate resource pressure file in the v2 hierarchy
+		return findFile(m.v2, resource+pressureFileSuffix)
+	}, fmt.Sprintf("pid %d", pid))
+}
+
+// CollectHostPressure reads host-wide CPU, memory, and I/O PSI from procfs.
+//
+// Params:
+//   - ctx: context for cancellation.
+//
+// Returns:
+//   - domainmetrics.ResourcePressure: the pressure sample.
+//   - error: if the kernel exposes no PSI.
+func (r *Reader) CollectHostPressure(ctx context.Context) (domainmetrics.ResourcePressure, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.ResourcePressure{}, err
+	}
+
+	dirs := []string{filepath.Join(r.procRoot, hostPressureDir)}
+	// read PSI files from /proc/pressure
+	return readPressure(func(resource string) (string, bool) {
+		// locate host resource pressure file
+		return findFile(dirs, resource)
+	}, "host")
+}
+
+// readPressure reads the PSI file of each resource located by locate.
+//
+// Params:
+//   - locate: returns the PSI file path of a resource.
+//   - subject: description of the inspected target for error messages.
+//
+// Returns:
+//   - domainmetrics.ResourcePressure: the pressure sample.
+//   - error: if no PSI file exists or one cannot be read.
+func readPressure(locate func(string) (string, bool), subject string) (domainmetrics.ResourcePressure, error) {
+	now := time.Now()
+	result := domainmetrics.ResourcePressure{Timestamp: now}
+	found := false
+
+	// read each resource independently
+	for _, resource := range pressureResources {
+		path, ok := locate(resource)
+		// skip resources without PSI
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		// fail when an existing PSI file cannot be read
+		if err != nil {
+			// return wrapped read error
+			return domainmetrics.ResourcePressure{}, process.WrapError("read "+resource+" pressure", err)
+		}
+		found = true
+		p := parsePressure(string(data), now)
+
+		// assign parsed pressure to its resource
+		switch resource {
+		// CPU pressure
+		case domainmetrics.PressureResourceCPU:
+			result.CPU = p
+		// memory pressure
+		case domainmetrics.PressureResourceMemory:
+			result.Memory = p
+		// I/O pressure
+		default:
+			result.IO = p
+		}
+	}
+
+	// fail when no resource exposes PSI
+	if !found {
+		// return PSI not found
+		return domainmetrics.ResourcePressure{}, fmt.Errorf("%w: %s", ErrPressureNotFound, subject)
+	}
+	// return collected sample
+	return result, nil
+}
+
+// parsePressure parses PSI file content.
+// Each line has the form "some avg10=0.00 avg60=0.00 avg300=0.00 total=0";
+// unknown scopes and malformed fields are ignored.
+//
+// Params:
+//   - content: content of a PSI file.
+//   - now: timestamp of the sample.
+//
+// Returns:
+//   - domainmetrics.Pressure: the parsed pressure.
+func parsePressure(content string, now time.Time) domainmetrics.Pressure {
+	p := domainmetrics.Pressure{Timestamp: now}
+
+	// parse each scope line
+	for line := range strings.Lines(content) {
+		fields := strings.Fields(line)
+		// skip empty lines
+		if len(fields) == 0 {
+			continue
+		}
+
+		var avg10, avg60, avg300 *float64
+		var total *uint64
+		// select destination fields by scope
+		switch fields[0] {
+		// some tasks stalled
+		case domainmetrics.PressureScopeSome:
+			avg10, avg60, avg300, total = &p.SomeAvg10, &p.SomeAvg60, &p.SomeAvg300, &p.SomeTotal
+		// all tasks stalled
+		case domainmetrics.PressureScopeFull:
+			avg10, avg60, avg300, total = &p.FullAvg10, &p.FullAvg60, &p.FullAvg300, &p.FullTotal
+		// unknown scope
+		default:
+			continue
+		}
+
+		// parse "key=value" fields
+		for _, field := range fields[1:] {
+			key, raw, ok := strings.Cut(field, "=")
+			// skip malformed fields
+			if !ok {
+				continue
+			}
+			parsePressureField(key, raw, avg10, avg60, avg300, total)
+		}
+	}
+	// return parsed pressure
+	return p
+}
+
+// parsePressureField stores a single PSI field value into its destination.
+//
+// Params:
+//   - key: field name.
+//   - raw: unparsed field value.
+//   - avg10: destination of the 10-second average.
+//   - avg60: destination of the 60-second average.
+//   - avg300: destination of the 300-second average.
+//   - total: destination of the cumulative stall time.
+func parsePressureField(key, raw string, avg10, avg60, avg300 *float64, total *uint64) {
+	// handle the cumulative counter separately
+	if key == keyTotal {
+		value, err := strconv.ParseUint(raw, decimalBase, bitSize64)
+		// store valid counters only
+		if err == nil {
+			*total = value
+		}
+		// counter handled
+		return
+	}
+
+	value, err := strconv.ParseFloat(raw, bitSize64)
+	// skip non-numeric averages
+	if err != nil {
+		// invalid value
+		return
+	}
+
+	// map key to average
+	switch key {
+	// 10-second average
+	case keyAvg10:
+		*avg10 = value
+	// 60-second average
+	case keyAvg60:
+		*avg60 = value
+	// 300-second average
+	case keyAvg300:
+		*avg300 = value
+	// unrelated field
+	default:
+		// ignore other keys
+	}
+}
@@ -0,0 +1,110 @@
+//go:build linux
+
+// Package cgroup_test provides black-box tests for the cgroup package.
+// It tests PSI collection against fixture hierarchies.
+package cgroup_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/cgroup"
+)
+
+// TestReader_CollectPressure tests per-cgroup PSI collection.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectPressure(t *testing.T) {
+	procRoot := t.TempDir()
+	cgroupRoot := t.TempDir()
+	writeFixture(t, filepath.Join(procRoot, "42", "cgroup"), "0::/system.slice/app.service\n")
+	dir := filepath.Join(cgroupRoot, "system.slice", "app.service")
+	writeFixture(t, filepath.Join(dir, "cpu.pressure"),
+		"some avg10=12.50 avg60=4.00 avg300=1.00 total=1000\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+	writeFixture(t, filepath.Join(dir, "memory.pressure"),
+		"some avg10=1.00 avg60=2.00 avg300=3.00 total=20\nfull avg10=0.50 avg60=0.25 avg300=0.10 total=10\n")
+
+	reader := cgroup.NewWithRoots(procRoot, cgroupRoot)
+	sample, err := reader.CollectPressure(context.Background(), 42)
+
+	require.NoError(t, err)
+	assert.True(t, sample.IsCollected())
+	assert.InDelta(t, 12.5, sample.CPU.SomeAvg10, 0.001)
+	assert.Equal(t, uint64(1000), sample.CPU.SomeTotal)
+	assert.InDelta(t, 0.5, sample.Memory.FullAvg10, 0.001)
+	// Missing io.pressure is left at zero.
+	assert.Zero(t, sample.IO.SomeAvg10)
+}
+
+// TestReader_CollectPressure_NotFound tests errors when PSI is unavailable.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectPressure_NotFound(t *testing.T) {
+	tests := []struct {
+		name       string
+		procCgroup string
+		wantErr    error
+	}{
+		{
+			name:       "cgroup v1 only",
+			procCgroup: "3:cpu,cpuacct:/docker/abc\n",
+			wantErr:    cgroup.ErrPressureNotFound,
+		},
+		{
+			name:       "v2 directory without pressure files",
+			procCgroup: "0::/empty\n",
+			wantErr:    cgroup.ErrPressureNotFound,
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			procRoot := t.TempDir()
+			writeFixture(t, filepath.Join(procRoot, "42", "cgroup"), tt.procCgroup)
+
+			reader := cgroup.NewWithRoots(procRoot, t.TempDir())
+			_, err := reader.CollectPressure(context.Background(), 42)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestReader_CollectHostPressure tests host PSI collection from procfs.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectHostPressure(t *testing.T) {
+	procRoot := t.TempDir()
+	writeFixture(t, filepath.Join(procRoot, "pressure", "io"),
+		"some avg10=30.00 avg60=20.00 avg300=10.00 total=99\nfull avg10=25.00 avg60=15.00 avg300=5.00 total=88\n")
+
+	reader := cgroup.NewWithRoots(procRoot, t.TempDir())
+	sample, err := reader.CollectHostPressure(context.Background())
+
+	require.NoError(t, err)
+	assert.InDelta(t, 25.0, sample.IO.FullAvg10, 0.001)
+	assert.InDelta(t, 10.0, sample.IO.SomeAvg300, 0.001)
+	assert.Equal(t, uint64(88), sample.IO.FullTotal)
+}
+
+// TestReader_CollectHostPressure_Cancelled tests that a cancelled context aborts collection.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectHostPressure_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := cgroup.New().CollectHostPressure(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
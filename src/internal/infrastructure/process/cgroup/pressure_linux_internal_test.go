@@ -0,0 +1,56 @@
+//go:build linux
+
+// Package cgroup provides internal (white-box) tests for PSI parsing.
+package cgroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_parsePressure tests parsing of PSI file content.
+//
+// Params:
+//   - t: the testing context
+func Test_parsePressure(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		content     string
+		wantSome10  float64
+		wantFull300 float64
+		wantTotal   uint64
+	}{
+		{
+			name:        "some and full lines",
+			content:     "some avg10=1.50 avg60=0.00 avg300=0.00 total=7\nfull avg10=0.00 avg60=0.00 avg300=2.25 total=3\n",
+			wantSome10:  1.5,
+			wantFull300: 2.25,
+			wantTotal:   7,
+		},
+		{
+			name:       "ignores malformed fields and unknown scopes",
+			content:    "partial avg10=9.00\nsome avg10 avg10=x avg10=4.00 total=y\n\n",
+			wantSome10: 4.0,
+		},
+		{
+			name:    "empty content",
+			content: "",
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			p := parsePressure(tt.content, now)
+			assert.InDelta(t, tt.wantSome10, p.SomeAvg10, 0.001)
+			assert.InDelta(t, tt.wantFull300, p.FullAvg300, 0.001)
+			assert.Equal(t, tt.wantTotal, p.SomeTotal)
+			assert.Equal(t, now, p.Timestamp)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !linux
+
+// Package cgroup reads per-process control group statistics.
+package cgroup
+
+import (
+	"context"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// CollectPressure is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - pid: process ID (unused).
+//
+// Returns:
+//   - domainmetrics.ResourcePressure: empty sample.
+//   - error: process.ErrNotSupported.
+func (r *Reader) CollectPressure(_ context.Context, _ int) (domainmetrics.ResourcePressure, error) {
+	// PSI is Linux-only
+	return domainmetrics.ResourcePressure{}, process.ErrNotSupported
+}
+
+// CollectHostPressure is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//
+// Returns:
+//   - domainmetrics.ResourcePressure: empty sample.
+//   - error: process.ErrNotSupported.
+func (r *Reader) CollectHostPressure(_ context.Context) (domainmetrics.ResourcePressure, error) {
+	// PSI is Linux-only
+	return domainmetrics.ResourcePressure{}, process.ErrNotSupported
+}
@@ -0,0 +1,99 @@
+//go:build linux
+
+// Package cgroup reads per-process control group statistics.
+// This file measures the memory and descriptors held by the daemon itself.
+package cgroup
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// statusFile is the procfs file holding the process status.
+	statusFile string = "status"
+
+	// fdDir is the procfs directory listing the open file descriptors.
+	fdDir string = "fd"
+
+	// keyVmRSS is the status field of the resident set size, in KiB.
+	keyVmRSS string = "VmRSS:"
+)
+
+// CollectSelf measures the resident memory and open file descriptors of the
+// daemon process from procfs.
+//
+// Params:
+//   - ctx: context for cancellation.
+//
+// Returns:
+//   - domainmetrics.SelfUsage: the daemon process usage.
+//   - error: if the process status or descriptors cannot be read.
+func (r *Reader) CollectSelf(ctx context.Context) (domainmetrics.SelfUsage, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.SelfUsage{}, err
+	}
+	dir := filepath.Join(r.procRoot, strconv.Itoa(os.Getpid()))
+
+	rss, err := readVmRSS(filepath.Join(dir, statusFile))
+	// fail when the resident memory is unknown
+	if err != nil {
+		// return read error
+		return domainmetrics.SelfUsage{}, err
+	}
+	fds, err := os.ReadDir(filepath.Join(dir, fdDir))
+	// fail when descriptors cannot be listed
+	if err != nil {
+		// return wrapped read error
+		return domainmetrics.SelfUsage{}, process.WrapError("read fds", err)
+	}
+	// return measured usage
+	return domainmetrics.SelfUsage{RSS: rss, OpenFDs: uint32(len(fds))}, nil
+}
+
+// readVmRSS reads the resident set size of a process status file.
+//
+// Params:
+//   - path: the /proc/<pid>/status file.
+//
+// Returns:
+//   - uint64: the resident set size in bytes.
+//   - error: if the file cannot be read or lacks VmRSS.
+func readVmRSS(path string) (uint64, error) {
+	f, err := os.Open(path)
+	// fail when status is unreadable
+	if err != nil {
+		// return wrapped open error
+		return 0, process.WrapError("read status", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	// find the resident memory line "VmRSS:     20480 kB"
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// skip other fields
+		if len(fields) < 2 || fields[0] != keyVmRSS {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], decimalBase, bitSize64)
+		// fail on malformed value
+		if err != nil {
+			// return wrapped parse error
+			return 0, process.WrapError("parse status", err)
+		}
+		// convert KiB to bytes
+		return kb * kibibyte, nil
+	}
+	// VmRSS missing
+	return 0, process.WrapError("read status", ErrVmRSSNotFound)
+}
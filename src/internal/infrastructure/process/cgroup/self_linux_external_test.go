@@ -0,0 +1,70 @@
+//go:build linux
+
+// Package cgroup_test provides black-box tests for the cgroup package.
+// It tests the measurement of the daemon process against procfs fixtures.
+package cgroup_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/cgroup"
+)
+
+// TestReader_CollectSelf tests the resident memory and descriptors of the daemon.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectSelf(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		fds     int
+		wantRSS uint64
+		wantErr error
+	}{
+		{
+			name:    "measured",
+			status:  "Name:\tdaemon\nVmPeak:\t  40960 kB\nVmRSS:\t  20480 kB\n",
+			fds:     3,
+			wantRSS: 20 << 20,
+		},
+		{
+			name:    "no VmRSS",
+			status:  "Name:\tdaemon\n",
+			wantErr: cgroup.ErrVmRSSNotFound,
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			procRoot := t.TempDir()
+			dir := filepath.Join(procRoot, strconv.Itoa(os.Getpid()))
+			writeFixture(t, filepath.Join(dir, "status"), tt.status)
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "fd"), 0o755))
+			// Place one entry per open descriptor.
+			for i := range tt.fds {
+				writeFixture(t, filepath.Join(dir, "fd", strconv.Itoa(i)), "")
+			}
+
+			usage, err := cgroup.NewWithRoots(procRoot, t.TempDir()).CollectSelf(context.Background())
+
+			// Check the expected failure.
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRSS, usage.RSS)
+			assert.Equal(t, uint32(tt.fds), usage.OpenFDs)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !linux
+
+// Package cgroup reads per-process control group statistics.
+package cgroup
+
+import (
+	"context"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// CollectSelf is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//
+// Returns:
+//   - domainmetrics.SelfUsage: empty usage.
+//   - error: process.ErrNotSupported.
+func (r *Reader) CollectSelf(_ context.Context) (domainmetrics.SelfUsage, error) {
+	// procfs is Linux-only
+	return domainmetrics.SelfUsage{}, process.ErrNotSupported
+}
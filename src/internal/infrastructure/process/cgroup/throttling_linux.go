@@ -0,0 +1,125 @@
+//go:build linux
+
+// Package cgroup reads per-process control group statistics.
+// This file contains the Linux cpu.stat throttling reader.
+package cgroup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// cpuStatFile is the name of the CPU statistics file in a cgroup directory.
+const cpuStatFile string = "cpu.stat"
+
+// cpu.stat keys used for throttling detection.
+const (
+	// keyPeriods is the number of elapsed enforcement periods.
+	keyPeriods string = "nr_periods"
+	// keyThrottled is the number of throttled periods.
+	keyThrottled string = "nr_throttled"
+	// keyThrottledUsec is the cgroup v2 throttled time in microseconds.
+	keyThrottledUsec string = "throttled_usec"
+	// keyThrottledTime is the cgroup v1 throttled time in nanoseconds.
+	keyThrottledTime string = "throttled_time"
+)
+
+// CollectThrottling reads CPU throttling counters for the cgroup of a process.
+// Cgroup v1 CPU controller paths are preferred over the unified v2 path because
+// on hybrid hosts the v2 hierarchy does not carry the CPU controller.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - pid: process ID whose cgroup is inspected.
+//
+// Returns:
+//   - domainmetrics.CPUThrottling: the throttling sample.
+//   - error: if the cgroup or its cpu.stat cannot be read.
+func (r *Reader) CollectThrottling(ctx context.Context, pid int) (domainmetrics.CPUThrottling, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.CPUThrottling{}, err
+	}
+
+	m, err := r.resolveMembership(pid)
+	// fail when the cgroup cannot be resolved
+	if err != nil {
+		// return resolution error
+		return domainmetrics.CPUThrottling{}, err
+	}
+
+	path, ok := findFile(append(m.v1CPU, m.v2...), cpuStatFile)
+	// fail when no cpu.stat exists
+	if !ok {
+		// return controller not found
+		return domainmetrics.CPUThrottling{}, fmt.Errorf("%w: pid %d", ErrCPUControllerNotFound, pid)
+	}
+
+	data, err := os.ReadFile(path)
+	// fail when cpu.stat cannot be read
+	if err != nil {
+		// return wrapped read error
+		return domainmetrics.CPUThrottling{}, process.WrapError("read cpu.stat", err)
+	}
+
+	// parse counters with the current timestamp
+	return parseCPUStat(string(data), time.Now()), nil
+}
+
+// parseCPUStat parses throttling counters from cpu.stat content.
+// Unknown keys and malformed lines are ignored.
+//
+// Params:
+//   - content: content of the cpu.stat file.
+//   - now: timestamp of the sample.
+//
+// Returns:
+//   - domainmetrics.CPUThrottling: the parsed throttling sample.
+func parseCPUStat(content string, now time.Time) domainmetrics.CPUThrottling {
+	var periods, throttled uint64
+	var throttledTime time.Duration
+
+	// parse each "key value" line
+	for line := range strings.Lines(content) {
+		key, raw, ok := strings.Cut(strings.TrimSpace(line), " ")
+		// skip lines without a value
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseUint(raw, decimalBase, bitSize64)
+		// skip non-numeric values
+		if err != nil {
+			continue
+		}
+
+		// map key to counter
+		switch key {
+		// elapsed periods
+		case keyPeriods:
+			periods = value
+		// throttled periods
+		case keyThrottled:
+			throttled = value
+		// cgroup v2 throttled time
+		case keyThrottledUsec:
+			throttledTime = time.Duration(value) * time.Microsecond
+		// cgroup v1 throttled time
+		case keyThrottledTime:
+			throttledTime = time.Duration(value)
+		// unrelated counter
+		default:
+			// ignore other keys
+		}
+	}
+
+	// build sample from parsed counters
+	return domainmetrics.NewCPUThrottling(periods, throttled, throttledTime, now)
+}
@@ -0,0 +1,110 @@
+//go:build linux
+
+// Package cgroup_test provides black-box tests for the cgroup package.
+// It tests cpu.stat throttling collection against fixture hierarchies.
+package cgroup_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/cgroup"
+)
+
+// writeFixture writes a fixture file, creating parent directories.
+//
+// Params:
+//   - t: the testing context
+//   - path: the file path
+//   - content: the file content
+func writeFixture(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// TestReader_CollectThrottling tests throttling collection for v1 and v2 hierarchies.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectThrottling(t *testing.T) {
+	tests := []struct {
+		name          string
+		procCgroup    string
+		statPath      string
+		stat          string
+		wantPeriods   uint64
+		wantThrottled uint64
+		wantTime      time.Duration
+	}{
+		{
+			name:          "cgroup v2 unified hierarchy",
+			procCgroup:    "0::/system.slice/app.service\n",
+			statPath:      "system.slice/app.service/cpu.stat",
+			stat:          "usage_usec 100\nnr_periods 40\nnr_throttled 10\nthrottled_usec 250000\n",
+			wantPeriods:   40,
+			wantThrottled: 10,
+			wantTime:      250 * time.Millisecond,
+		},
+		{
+			name:          "cgroup v1 cpu controller",
+			procCgroup:    "4:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n",
+			statPath:      "cpu,cpuacct/docker/abc/cpu.stat",
+			stat:          "nr_periods 8\nnr_throttled 2\nthrottled_time 1500000000\n",
+			wantPeriods:   8,
+			wantThrottled: 2,
+			wantTime:      1500 * time.Millisecond,
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			procRoot := t.TempDir()
+			cgroupRoot := t.TempDir()
+			writeFixture(t, filepath.Join(procRoot, "42", "cgroup"), tt.procCgroup)
+			writeFixture(t, filepath.Join(cgroupRoot, tt.statPath), tt.stat)
+
+			reader := cgroup.NewWithRoots(procRoot, cgroupRoot)
+			sample, err := reader.CollectThrottling(context.Background(), 42)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPeriods, sample.Periods)
+			assert.Equal(t, tt.wantThrottled, sample.ThrottledPeriods)
+			assert.Equal(t, tt.wantTime, sample.ThrottledTime)
+			assert.False(t, sample.Timestamp.IsZero())
+		})
+	}
+}
+
+// TestReader_CollectThrottling_Errors tests error paths of throttling collection.
+//
+// Params:
+//   - t: the testing context
+func TestReader_CollectThrottling_Errors(t *testing.T) {
+	procRoot := t.TempDir()
+	cgroupRoot := t.TempDir()
+	writeFixture(t, filepath.Join(procRoot, "7", "cgroup"), "5:memory:/app\n")
+	reader := cgroup.NewWithRoots(procRoot, cgroupRoot)
+
+	// Missing process.
+	_, err := reader.CollectThrottling(context.Background(), 99)
+	assert.Error(t, err)
+
+	// Process without CPU controller.
+	_, err = reader.CollectThrottling(context.Background(), 7)
+	assert.ErrorIs(t, err, cgroup.ErrCPUControllerNotFound)
+
+	// Cancelled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = reader.CollectThrottling(ctx, 7)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,61 @@
+//go:build linux
+
+// Package cgroup provides internal (white-box) tests for cpu.stat parsing.
+package cgroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_parseCPUStat tests parsing of cpu.stat content.
+//
+// Params:
+//   - t: the testing context
+func Test_parseCPUStat(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		content  string
+		wantTime time.Duration
+		wantNr   uint64
+	}{
+		{
+			name:     "ignores malformed lines",
+			content:  "garbage\nnr_throttled abc\nnr_throttled 3\nthrottled_usec 10\n",
+			wantTime: 10 * time.Microsecond,
+			wantNr:   3,
+		},
+		{
+			name:     "empty content",
+			content:  "",
+			wantTime: 0,
+			wantNr:   0,
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			sample := parseCPUStat(tt.content, now)
+			assert.Equal(t, tt.wantTime, sample.ThrottledTime)
+			assert.Equal(t, tt.wantNr, sample.ThrottledPeriods)
+			assert.Equal(t, now, sample.Timestamp)
+		})
+	}
+}
+
+// Test_hasCPUController tests v1 controller list matching.
+//
+// Params:
+//   - t: the testing context
+func Test_hasCPUController(t *testing.T) {
+	assert.True(t, hasCPUController("cpu,cpuacct"))
+	assert.True(t, hasCPUController("cpu"))
+	assert.False(t, hasCPUController("cpuset"))
+	assert.False(t, hasCPUController(""))
+}
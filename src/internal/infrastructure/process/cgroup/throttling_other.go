@@ -0,0 +1,25 @@
+//go:build !linux
+
+// Package cgroup reads per-process control group statistics.
+package cgroup
+
+import (
+	"context"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// CollectThrottling is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - pid: process ID (unused).
+//
+// Returns:
+//   - domainmetrics.CPUThrottling: empty sample.
+//   - error: process.ErrNotSupported.
+func (r *Reader) CollectThrottling(_ context.Context, _ int) (domainmetrics.CPUThrottling, error) {
+	// cgroups are Linux-only
+	return domainmetrics.CPUThrottling{}, process.ErrNotSupported
+}
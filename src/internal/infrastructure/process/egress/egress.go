@@ -0,0 +1,111 @@
+// Package egress restricts the outbound traffic of supervised processes.
+// Each restricted service is started in its own cgroup v2 (a child of the
+// daemon's cgroup), and nftables rules generated by the daemon match the
+// sockets of that cgroup: only connections to the allowed destinations
+// and replies to inbound connections leave. Rules and cgroup are removed
+// when the process exits.
+package egress
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// defaultProcRoot is the default procfs mount point.
+	defaultProcRoot string = "/proc"
+
+	// defaultCgroupRoot is the default cgroup v2 mount point.
+	defaultCgroupRoot string = "/sys/fs/cgroup"
+
+	// tableName is the nftables table owned by the daemon.
+	tableName string = "supervizio"
+
+	// cgroupPrefix prefixes the per-service cgroup directories.
+	cgroupPrefix string = "svc-"
+
+	// chainPrefix prefixes the per-service nftables chains.
+	chainPrefix string = "svc_"
+
+	// allowSuffix suffixes the chain holding the allow rules.
+	allowSuffix string = "_allow"
+)
+
+var (
+	// ErrCgroupV2Required indicates a host without the unified cgroup v2 hierarchy.
+	ErrCgroupV2Required error = errors.New("egress rules require cgroup v2")
+
+	// ErrNftMissing indicates that the nft command is not installed.
+	ErrNftMissing error = errors.New("egress rules require the nft command")
+)
+
+// Runner loads nftables scripts.
+type Runner interface {
+	// Available reports whether scripts can be loaded.
+	//
+	// Returns:
+	//   - error: why scripts cannot be loaded, nil if they can.
+	Available() error
+
+	// Run loads a script atomically.
+	//
+	// Params:
+	//   - script: the nft script.
+	//
+	// Returns:
+	//   - error: if the script is rejected.
+	Run(script string) error
+}
+
+// Firewall confines supervised processes to their egress rules.
+type Firewall struct {
+	// procRoot is the procfs mount point.
+	procRoot string
+	// cgroupRoot is the cgroup v2 mount point.
+	cgroupRoot string
+	// runner loads the nftables rules.
+	runner Runner
+}
+
+// New creates a firewall using the standard mount points and nft.
+//
+// Returns:
+//   - *Firewall: firewall bound to /proc, /sys/fs/cgroup and nft.
+func New() *Firewall {
+	// use standard mount points
+	return NewWithRoots(defaultProcRoot, defaultCgroupRoot, nftRunner{})
+}
+
+// NewWithRoots creates a firewall with custom mount points and runner.
+// This is primarily used for testing against fixture directories.
+//
+// Params:
+//   - procRoot: the procfs mount point.
+//   - cgroupRoot: the cgroup v2 mount point.
+//   - runner: loads the nftables rules.
+//
+// Returns:
+//   - *Firewall: firewall bound to the given roots.
+func NewWithRoots(procRoot, cgroupRoot string, runner Runner) *Firewall {
+	// return firewall bound to roots
+	return &Firewall{procRoot: procRoot, cgroupRoot: cgroupRoot, runner: runner}
+}
+
+// sanitize maps a service name to a cgroup and nftables identifier.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - string: the name with every character but letters, digits and _ replaced by _.
+func sanitize(service string) string {
+	// keep identifier-safe characters only
+	return strings.Map(func(r rune) rune {
+		// letters, digits and underscore are kept
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		// anything else is replaced
+		return '_'
+	}, service)
+}
@@ -0,0 +1,181 @@
+//go:build linux
+
+// Package egress restricts the outbound traffic of supervised processes.
+package egress
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// cgroupV2Prefix starts the unified hierarchy line of /proc/self/cgroup.
+const cgroupV2Prefix string = "0::"
+
+// Check verifies that egress rules can be enforced on this host.
+//
+// Returns:
+//   - error: ErrCgroupV2Required or ErrNftMissing, nil if rules can be enforced.
+func (f *Firewall) Check() error {
+	// the daemon cgroup must be on the unified hierarchy
+	if _, err := f.ownCgroup(); err != nil {
+		// propagate cgroup error
+		return err
+	}
+	// rules must be loadable
+	return f.runner.Available()
+}
+
+// Start calls start with the process placed in its service cgroup, after
+// the egress rules of that cgroup are loaded. The process is cloned
+// directly into the cgroup, so it never sends a packet unrestricted.
+//
+// Params:
+//   - cmd: the command, started by start.
+//   - service: the service name.
+//   - rules: the allowed destinations; empty calls start directly.
+//   - start: forks and executes the process (e.g. exec.Cmd.Start).
+//
+// Returns:
+//   - func() error: removes the rules and the cgroup once the process exited.
+//   - error: the confinement or start error.
+func (f *Firewall) Start(cmd *exec.Cmd, service string, rules []config.EgressRule, start func() error) (func() error, error) {
+	// nothing to enforce
+	if len(rules) == 0 {
+		// start unconfined
+		return noRelease, start()
+	}
+	own, err := f.ownCgroup()
+	// cgroup v2 unavailable
+	if err != nil {
+		// propagate cgroup error
+		return nil, err
+	}
+	name := sanitize(service)
+	cgroup, dir := f.serviceCgroup(own, name)
+	// a previous run may have left the cgroup behind
+	if err := os.Mkdir(dir, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+		// return error with context
+		return nil, fmt.Errorf("creating egress cgroup: %w", err)
+	}
+	release := func() error {
+		// the cgroup is only removable once its processes are gone
+		return errors.Join(f.runner.Run(teardown(name)), os.Remove(dir))
+	}
+	script, err := ruleset(name, cgroup, rules)
+	// invalid rule
+	if err != nil {
+		_ = os.Remove(dir)
+		// propagate translation error
+		return nil, err
+	}
+	// load the rules before the process exists
+	if err := f.runner.Run(script); err != nil {
+		_ = os.Remove(dir)
+		// return error with context
+		return nil, fmt.Errorf("loading egress rules: %w", err)
+	}
+	fd, err := os.Open(dir)
+	// cgroup not openable
+	if err != nil {
+		_ = release()
+		// return error with context
+		return nil, fmt.Errorf("opening egress cgroup: %w", err)
+	}
+	defer func() { _ = fd.Close() }()
+	// initialize SysProcAttr if not already set
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(fd.Fd())
+	// start the process inside the cgroup
+	if err := start(); err != nil {
+		_ = release()
+		// propagate start error
+		return nil, err
+	}
+	// return cleanup for process exit
+	return release, nil
+}
+
+// Dir returns the cgroup directory Start creates for a service confined by
+// egress rules.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - string: the cgroup directory, empty when rules cannot be enforced.
+func (f *Firewall) Dir(service string) string {
+	own, err := f.ownCgroup()
+	// no unified hierarchy, no cgroup
+	if err != nil {
+		// return no directory
+		return ""
+	}
+	_, dir := f.serviceCgroup(own, sanitize(service))
+	// return service cgroup directory
+	return dir
+}
+
+// serviceCgroup locates the cgroup of a service below the daemon cgroup.
+//
+// Params:
+//   - own: the daemon cgroup, as returned by ownCgroup.
+//   - name: the sanitized service name.
+//
+// Returns:
+//   - string: the cgroup path, relative to the cgroup root.
+//   - string: the cgroup directory.
+func (f *Firewall) serviceCgroup(own, name string) (cgroup, dir string) {
+	cgroup = strings.TrimPrefix(path.Join(own, cgroupPrefix+name), "/")
+	// return relative path and directory
+	return cgroup, filepath.Join(f.cgroupRoot, filepath.FromSlash(cgroup))
+}
+
+// ownCgroup resolves the unified cgroup of the daemon.
+//
+// Returns:
+//   - string: the cgroup path, relative to the cgroup root with a leading /.
+//   - error: ErrCgroupV2Required if the host has no unified hierarchy.
+func (f *Firewall) ownCgroup() (string, error) {
+	// the unified hierarchy must be mounted at the root
+	if _, err := os.Stat(filepath.Join(f.cgroupRoot, "cgroup.controllers")); err != nil {
+		// cgroup v1 or hybrid host
+		return "", ErrCgroupV2Required
+	}
+	data, err := os.ReadFile(filepath.Join(f.procRoot, "self", "cgroup"))
+	// membership unreadable
+	if err != nil {
+		// return error with context
+		return "", fmt.Errorf("%w: %w", ErrCgroupV2Required, err)
+	}
+	// find the unified hierarchy line
+	for line := range strings.Lines(string(data)) {
+		// unified entry "0::/path"
+		if own, ok := strings.CutPrefix(strings.TrimSpace(line), cgroupV2Prefix); ok {
+			// return daemon cgroup
+			return own, nil
+		}
+	}
+	// no unified entry
+	return "", ErrCgroupV2Required
+}
+
+// noRelease is the cleanup of an unconfined process.
+//
+// Returns:
+//   - error: always nil.
+func noRelease() error {
+	// nothing to remove
+	return nil
+}
@@ -0,0 +1,151 @@
+//go:build linux
+
+// Package egress provides internal tests for egress_linux.go.
+package egress
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// recordingRunner records the loaded scripts.
+type recordingRunner struct {
+	scripts []string
+	err     error
+}
+
+// Available reports the runner as usable.
+//
+// Returns:
+//   - error: always nil.
+func (r *recordingRunner) Available() error {
+	// fake nft always present
+	return nil
+}
+
+// Run records the script.
+//
+// Params:
+//   - script: the nft script.
+//
+// Returns:
+//   - error: the configured error.
+func (r *recordingRunner) Run(script string) error {
+	r.scripts = append(r.scripts, script)
+	// return configured error
+	return r.err
+}
+
+// newFixture creates procfs and cgroupfs fixtures with the daemon in /daemon.service.
+//
+// Params:
+//   - t: the testing context.
+//   - v2: whether the cgroup root is a unified hierarchy.
+//
+// Returns:
+//   - string: the procfs root.
+//   - string: the cgroupfs root.
+func newFixture(t *testing.T, v2 bool) (procRoot, cgroupRoot string) {
+	t.Helper()
+	procRoot, cgroupRoot = t.TempDir(), t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(procRoot, "self"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(procRoot, "self", "cgroup"), []byte("0::/daemon.service\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(cgroupRoot, "daemon.service"), 0o755))
+	// unified hierarchies expose cgroup.controllers at the root
+	if v2 {
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), nil, 0o644))
+	}
+	// return fixture roots
+	return procRoot, cgroupRoot
+}
+
+// Test_Firewall_Start tests that a process starts in its cgroup with rules loaded.
+//
+// Params:
+//   - t: the testing context.
+func Test_Firewall_Start(t *testing.T) {
+	procRoot, cgroupRoot := newFixture(t, true)
+	runner := &recordingRunner{}
+	f := NewWithRoots(procRoot, cgroupRoot, runner)
+	dir := filepath.Join(cgroupRoot, "daemon.service", "svc-api")
+	cmd := exec.Command("/bin/true")
+
+	release, err := f.Start(cmd, "api", []config.EgressRule{{CIDR: "10.0.0.0/8"}}, func() error {
+		// The cgroup and rules exist before the fork.
+		assert.DirExists(t, dir)
+		assert.Len(t, runner.scripts, 1)
+		assert.True(t, cmd.SysProcAttr.UseCgroupFD)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, runner.scripts[0], `socket cgroupv2 level 2 "daemon.service/svc-api" jump svc_api_allow`)
+
+	require.NoError(t, release())
+	assert.NoDirExists(t, dir)
+	require.Len(t, runner.scripts, 2)
+	assert.Equal(t, teardown("api"), runner.scripts[1])
+}
+
+// Test_Firewall_Dir tests the location of service cgroups.
+//
+// Params:
+//   - t: the testing context.
+func Test_Firewall_Dir(t *testing.T) {
+	procRoot, cgroupRoot := newFixture(t, true)
+	assert.Equal(t, filepath.Join(cgroupRoot, "daemon.service", "svc-api"), NewWithRoots(procRoot, cgroupRoot, &recordingRunner{}).Dir("api"))
+
+	procRoot, cgroupRoot = newFixture(t, false)
+	assert.Empty(t, NewWithRoots(procRoot, cgroupRoot, &recordingRunner{}).Dir("api"))
+}
+
+// Test_Firewall_Start_errors tests the failure paths.
+//
+// Params:
+//   - t: the testing context.
+func Test_Firewall_Start_errors(t *testing.T) {
+	rules := []config.EgressRule{{CIDR: "10.0.0.0/8"}}
+
+	t.Run("no_rules", func(t *testing.T) {
+		started := false
+		release, err := NewWithRoots(t.TempDir(), t.TempDir(), &recordingRunner{}).Start(exec.Command("/bin/true"), "api", nil, func() error {
+			started = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, started)
+		assert.NoError(t, release())
+	})
+
+	t.Run("cgroup_v1", func(t *testing.T) {
+		procRoot, cgroupRoot := newFixture(t, false)
+		_, err := NewWithRoots(procRoot, cgroupRoot, &recordingRunner{}).Start(exec.Command("/bin/true"), "api", rules, func() error { return nil })
+		assert.ErrorIs(t, err, ErrCgroupV2Required)
+	})
+
+	t.Run("rules_rejected", func(t *testing.T) {
+		procRoot, cgroupRoot := newFixture(t, true)
+		runner := &recordingRunner{err: errors.New("syntax error")}
+		_, err := NewWithRoots(procRoot, cgroupRoot, runner).Start(exec.Command("/bin/true"), "api", rules, func() error { return nil })
+		assert.ErrorContains(t, err, "syntax error")
+		assert.NoDirExists(t, filepath.Join(cgroupRoot, "daemon.service", "svc-api"))
+	})
+
+	t.Run("start_failed", func(t *testing.T) {
+		procRoot, cgroupRoot := newFixture(t, true)
+		runner := &recordingRunner{}
+		startErr := errors.New("exec failed")
+		_, err := NewWithRoots(procRoot, cgroupRoot, runner).Start(exec.Command("/bin/true"), "api", rules, func() error { return startErr })
+		assert.ErrorIs(t, err, startErr)
+		// The rules are removed again.
+		assert.Len(t, runner.scripts, 2)
+		assert.NoDirExists(t, filepath.Join(cgroupRoot, "daemon.service", "svc-api"))
+	})
+}
@@ -0,0 +1,54 @@
+//go:build !linux
+
+// Package egress restricts the outbound traffic of supervised processes.
+package egress
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// Check reports that egress rules cannot be enforced outside Linux.
+//
+// Returns:
+//   - error: process.ErrNotSupported.
+func (f *Firewall) Check() error {
+	// nftables and cgroup v2 are Linux-only
+	return fmt.Errorf("egress rules: %w", process.ErrNotSupported)
+}
+
+// Start calls start when no egress rule is requested.
+//
+// Params:
+//   - cmd: the command (unused).
+//   - service: the service name (unused).
+//   - rules: the allowed destinations.
+//   - start: forks and executes the process.
+//
+// Returns:
+//   - func() error: a no-op cleanup.
+//   - error: process.ErrNotSupported for rules, or the start error.
+func (f *Firewall) Start(_ *exec.Cmd, _ string, rules []config.EgressRule, start func() error) (func() error, error) {
+	// rules cannot be enforced
+	if len(rules) > 0 {
+		// propagate unsupported error
+		return nil, f.Check()
+	}
+	// start unconfined
+	return func() error { return nil }, start()
+}
+
+// Dir returns no directory, as no cgroup is created outside Linux.
+//
+// Params:
+//   - service: the service name (unused).
+//
+// Returns:
+//   - string: always empty.
+func (f *Firewall) Dir(_ string) string {
+	// no cgroup outside Linux
+	return ""
+}
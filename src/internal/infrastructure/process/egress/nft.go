@@ -0,0 +1,58 @@
+// Package egress restricts the outbound traffic of supervised processes.
+package egress
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// nftBinary is the nftables command.
+	nftBinary string = "nft"
+
+	// nftTimeout bounds one script load.
+	nftTimeout time.Duration = 10 * time.Second
+)
+
+// nftRunner loads scripts with the nft command.
+type nftRunner struct{}
+
+// Available reports whether the nft command is installed.
+//
+// Returns:
+//   - error: ErrNftMissing if nft is not in PATH.
+func (nftRunner) Available() error {
+	// nft must be resolvable
+	if _, err := exec.LookPath(nftBinary); err != nil {
+		// return missing command error
+		return ErrNftMissing
+	}
+	// nft installed
+	return nil
+}
+
+// Run loads a script with nft -f -, as a single transaction.
+//
+// Params:
+//   - script: the nft script.
+//
+// Returns:
+//   - error: if nft rejects the script, with its output.
+func (nftRunner) Run(script string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), nftTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, nftBinary, "-f", "-")
+	cmd.Stdin = strings.NewReader(script)
+	out, err := cmd.CombinedOutput()
+	// script rejected or nft failed
+	if err != nil {
+		// return error with nft diagnostics
+		return fmt.Errorf("nft: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	// script loaded
+	return nil
+}
@@ -0,0 +1,119 @@
+// Package egress restricts the outbound traffic of supervised processes.
+package egress
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// ruleset generates the script confining a service cgroup.
+// A base chain hooked on output jumps to the allow chain for the sockets
+// of the cgroup only, so traffic without a socket (kernel replies) and
+// other processes are never affected. The allow chain accepts replies
+// and the allowed destinations, and rejects everything else.
+//
+// Params:
+//   - service: the sanitized service name.
+//   - cgroup: the cgroup path relative to the cgroup root.
+//   - rules: the allowed destinations.
+//
+// Returns:
+//   - string: the nft script.
+//   - error: if a rule cannot be translated.
+func ruleset(service, cgroup string, rules []config.EgressRule) (string, error) {
+	base := chainPrefix + service
+	allow := base + allowSuffix
+	level := strings.Count(cgroup, "/") + 1
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "add table inet %s\n", tableName)
+	fmt.Fprintf(&b, "add chain inet %s %s\n", tableName, allow)
+	fmt.Fprintf(&b, "flush chain inet %s %s\n", tableName, allow)
+	fmt.Fprintf(&b, "add chain inet %s %s { type filter hook output priority filter; policy accept; }\n", tableName, base)
+	fmt.Fprintf(&b, "flush chain inet %s %s\n", tableName, base)
+	fmt.Fprintf(&b, "add rule inet %s %s socket cgroupv2 level %d %q jump %s\n", tableName, base, level, cgroup, allow)
+	fmt.Fprintf(&b, "add rule inet %s %s ct state established,related accept\n", tableName, allow)
+	// allow each destination
+	for i := range rules {
+		match, err := destination(&rules[i])
+		// invalid rule
+		if err != nil {
+			// propagate translation error
+			return "", err
+		}
+		fmt.Fprintf(&b, "add rule inet %s %s %s accept\n", tableName, allow, match)
+	}
+	fmt.Fprintf(&b, "add rule inet %s %s reject\n", tableName, allow)
+	// return the script
+	return b.String(), nil
+}
+
+// teardown generates the script removing the chains of a service.
+//
+// Params:
+//   - service: the sanitized service name.
+//
+// Returns:
+//   - string: the nft script.
+func teardown(service string) string {
+	base := chainPrefix + service
+	allow := base + allowSuffix
+
+	var b strings.Builder
+	// chains must be empty to be deleted, and the jump goes first
+	for _, chain := range []string{base, allow} {
+		fmt.Fprintf(&b, "flush chain inet %s %s\n", tableName, chain)
+		fmt.Fprintf(&b, "delete chain inet %s %s\n", tableName, chain)
+	}
+	// return the script
+	return b.String()
+}
+
+// destination translates a rule into an nft match.
+//
+// Params:
+//   - rule: the egress rule.
+//
+// Returns:
+//   - string: the match expression.
+//   - error: if the CIDR is invalid.
+func destination(rule *config.EgressRule) (string, error) {
+	prefix, err := netip.ParsePrefix(rule.CIDR)
+	// invalid network
+	if err != nil {
+		// return error with context
+		return "", fmt.Errorf("egress cidr %q: %w", rule.CIDR, err)
+	}
+	family := "ip6"
+	// IPv4 destinations use the ip family
+	if prefix.Addr().Is4() {
+		family = "ip"
+	}
+	// nft rejects host bits in a prefix
+	match := fmt.Sprintf("%s daddr %s", family, prefix.Masked())
+
+	proto := rule.Protocol
+	// ports imply a transport
+	if proto == "" && len(rule.Ports) > 0 {
+		proto = "{ tcp, udp }"
+	}
+	// restrict the transport
+	if proto != "" {
+		match += " meta l4proto " + proto
+	}
+	// restrict the destination ports
+	if len(rule.Ports) > 0 {
+		ports := make([]string, 0, len(rule.Ports))
+		// list each port
+		for _, port := range rule.Ports {
+			ports = append(ports, strconv.Itoa(port))
+		}
+		match += " th dport { " + strings.Join(ports, ", ") + " }"
+	}
+	// return the match
+	return match, nil
+}
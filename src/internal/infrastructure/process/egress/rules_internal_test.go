@@ -0,0 +1,88 @@
+// Package egress provides internal tests for rules.go.
+package egress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// Test_destination tests the translation of rules into nft matches.
+//
+// Params:
+//   - t: the testing context.
+func Test_destination(t *testing.T) {
+	tests := []struct {
+		name string
+		rule config.EgressRule
+		want string
+	}{
+		{name: "any_traffic", rule: config.EgressRule{CIDR: "10.0.0.0/8"}, want: "ip daddr 10.0.0.0/8"},
+		{name: "host_bits_masked", rule: config.EgressRule{CIDR: "10.1.2.3/8"}, want: "ip daddr 10.0.0.0/8"},
+		{
+			name: "ports_any_transport",
+			rule: config.EgressRule{CIDR: "192.0.2.10/32", Ports: []int{443, 5432}},
+			want: "ip daddr 192.0.2.10/32 meta l4proto { tcp, udp } th dport { 443, 5432 }",
+		},
+		{
+			name: "udp_ipv6",
+			rule: config.EgressRule{CIDR: "2001:db8::/32", Ports: []int{53}, Protocol: "udp"},
+			want: "ip6 daddr 2001:db8::/32 meta l4proto udp th dport { 53 }",
+		},
+		{name: "protocol_only", rule: config.EgressRule{CIDR: "0.0.0.0/0", Protocol: "tcp"}, want: "ip daddr 0.0.0.0/0 meta l4proto tcp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := destination(&tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Test_ruleset tests the generated script.
+//
+// Params:
+//   - t: the testing context.
+func Test_ruleset(t *testing.T) {
+	script, err := ruleset("api", "daemon.service/svc-api", []config.EgressRule{{CIDR: "10.0.0.0/8", Ports: []int{443}, Protocol: "tcp"}})
+	require.NoError(t, err)
+	assert.Equal(t, `add table inet supervizio
+add chain inet supervizio svc_api_allow
+flush chain inet supervizio svc_api_allow
+add chain inet supervizio svc_api { type filter hook output priority filter; policy accept; }
+flush chain inet supervizio svc_api
+add rule inet supervizio svc_api socket cgroupv2 level 2 "daemon.service/svc-api" jump svc_api_allow
+add rule inet supervizio svc_api_allow ct state established,related accept
+add rule inet supervizio svc_api_allow ip daddr 10.0.0.0/8 meta l4proto tcp th dport { 443 } accept
+add rule inet supervizio svc_api_allow reject
+`, script)
+
+	_, err = ruleset("api", "svc-api", []config.EgressRule{{CIDR: "nope"}})
+	assert.Error(t, err)
+}
+
+// Test_teardown tests the removal script.
+//
+// Params:
+//   - t: the testing context.
+func Test_teardown(t *testing.T) {
+	assert.Equal(t, `flush chain inet supervizio svc_api
+delete chain inet supervizio svc_api
+flush chain inet supervizio svc_api_allow
+delete chain inet supervizio svc_api_allow
+`, teardown("api"))
+}
+
+// Test_sanitize tests identifier mapping of service names.
+//
+// Params:
+//   - t: the testing context.
+func Test_sanitize(t *testing.T) {
+	assert.Equal(t, "web_api_2", sanitize("web-api.2"))
+	assert.Equal(t, "worker_1", sanitize("worker_1"))
+}
@@ -18,6 +18,17 @@ import (
 	"github.com/kodflow/daemon/internal/domain/shared"
 	"github.com/kodflow/daemon/internal/infrastructure/process/control"
 	"github.com/kodflow/daemon/internal/infrastructure/process/credentials"
+	"github.com/kodflow/daemon/internal/infrastructure/process/egress"
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+	"github.com/kodflow/daemon/internal/infrastructure/process/mountns"
+	"github.com/kodflow/daemon/internal/infrastructure/process/pidns"
+)
+
+const (
+	// hostsPath is the hosts file replaced by DNS overrides.
+	hostsPath string = "/etc/hosts"
+	// resolvConfPath is the resolver configuration replaced by DNS overrides.
+	resolvConfPath string = "/etc/resolv.conf"
 )
 
 // Waiter is a minimal interface for waiting on commands.
@@ -45,6 +56,12 @@ type Executor struct {
 	credentials credentials.CredentialManager
 	process     control.ProcessControl
 	findProcess ProcessFinder
+	labeler     *lsm.Labeler
+	firewall    *egress.Firewall
+	// ledger accounts for the exit watch and cgroup of each start.
+	ledger resourceLedger
+	// reactor dispatches the exits of started processes.
+	reactor exitReactor
 }
 
 // NewExecutor returns an Executor with production dependencies.
@@ -53,7 +70,7 @@ type Executor struct {
 //   - *Executor: initialized executor with default credential and process managers
 func NewExecutor() *Executor {
 	// return executor with default dependencies.
-	return &Executor{credentials: credentials.New(), process: control.New(), findProcess: defaultFindProcess}
+	return &Executor{credentials: credentials.New(), process: control.New(), findProcess: defaultFindProcess, labeler: lsm.New(), firewall: egress.New()}
 }
 
 // New returns an Executor with production dependencies.
@@ -62,7 +79,7 @@ func NewExecutor() *Executor {
 //   - *Executor: initialized executor with default credential and process managers
 func New() *Executor {
 	// return executor with default dependencies.
-	return &Executor{credentials: credentials.New(), process: control.New(), findProcess: defaultFindProcess}
+	return &Executor{credentials: credentials.New(), process: control.New(), findProcess: defaultFindProcess, labeler: lsm.New(), firewall: egress.New()}
 }
 
 // NewWithDeps returns an Executor with Wire-injected dependencies.
@@ -75,7 +92,7 @@ func New() *Executor {
 //   - *Executor: initialized executor with provided dependencies
 func NewWithDeps(creds credentials.CredentialManager, proc control.ProcessControl) *Executor {
 	// return executor with injected dependencies.
-	return &Executor{credentials: creds, process: proc, findProcess: defaultFindProcess}
+	return &Executor{credentials: creds, process: proc, findProcess: defaultFindProcess, labeler: lsm.New(), firewall: egress.New()}
 }
 
 // NewWithOptions returns an Executor with custom dependencies for testing.
@@ -89,11 +106,11 @@ func NewWithDeps(creds credentials.CredentialManager, proc control.ProcessContro
 //   - *Executor: initialized executor with all custom dependencies
 func NewWithOptions(creds credentials.CredentialManager, proc control.ProcessControl, finder ProcessFinder) *Executor {
 	// return executor with all custom dependencies.
-	return &Executor{credentials: creds, process: proc, findProcess: finder}
+	return &Executor{credentials: creds, process: proc, findProcess: finder, labeler: lsm.New(), firewall: egress.New()}
 }
 
 // Start spawns a process and returns a channel for exit notification.
-// The background goroutine terminates when the process exits.
+// The exit is reported by the exit reactor once the process exited.
 //
 // Params:
 //   - ctx: context for process cancellation
@@ -115,19 +132,72 @@ func (e *Executor) Start(ctx context.Context, spec domain.Spec) (pid int, wait <
 		// return credential error to caller.
 		return 0, nil, err
 	}
-	// Fork/exec failed.
-	if err := cmd.Start(); err != nil {
+	label := lsm.Label{SELinux: spec.SELinuxContext, AppArmor: spec.AppArmorProfile}
+	mounts := sandboxPaths(&spec)
+	// Sandboxed commands start through the mount namespace helper.
+	if !mounts.IsZero() {
+		// The helper applies the label itself, after mounting.
+		if err := mountns.Wrap(cmd, mounts, label); err != nil {
+			// return sandbox error to caller.
+			return 0, nil, fmt.Errorf("sandboxing process: %w", err)
+		}
+		label = lsm.Label{}
+	}
+	// Namespaced commands start under the PID namespace init.
+	if spec.PIDNamespace {
+		// The init applies the label to the command it starts.
+		if err := pidns.Wrap(cmd, label); err != nil {
+			// return sandbox error to caller.
+			return 0, nil, fmt.Errorf("sandboxing process: %w", err)
+		}
+		label = lsm.Label{}
+	}
+	release, err := e.firewall.Start(cmd, spec.Name, spec.Egress, func() error {
+		// Arm the label on the forking thread.
+		return e.labeler.Start(label, cmd.Start)
+	})
+	// Fork/exec failed, or the label or egress rules could not be applied.
+	if err != nil {
 		// return start error to caller.
 		return 0, nil, fmt.Errorf("starting process: %w", err)
 	}
-	// Buffer of 1 prevents goroutine leak if receiver abandons channel.
+	var cgroup string
+	// Confined processes hold a cgroup until they exit.
+	if len(spec.Egress) > 0 {
+		cgroup = e.firewall.Dir(spec.Name)
+	}
+	seq := e.ledger.started(cmd.Process.Pid, spec.Name, cgroup, release)
+	// Buffer of 1 keeps the reactor from blocking if receiver abandons channel.
 	waitCh := make(chan domain.ExitResult, 1)
-	// collect exit result in background goroutine.
-	go e.waitForProcess(cmd, waitCh)
+	waiter := releasingWaiter{Waiter: cmd, release: release, exited: func(err error) { e.ledger.exited(seq, err) }}
+	// collect exit result once the process exited.
+	e.reactor.watch(cmd.Process.Pid, func() { e.waitForProcess(waiter, waitCh) })
 	// return process ID and exit notification channel.
 	return cmd.Process.Pid, waitCh, nil
 }
 
+// releasingWaiter removes the per-process resources once the process
+// exited, before the exit is reported, so a restart never races the cleanup.
+type releasingWaiter struct {
+	Waiter
+	// release removes the egress rules and cgroup.
+	release func() error
+	// exited settles the accounting of the process with the release error.
+	exited func(releaseErr error)
+}
+
+// Wait waits for the process, then releases its resources.
+//
+// Returns:
+//   - error: the wait error.
+func (w releasingWaiter) Wait() error {
+	err := w.Waiter.Wait()
+	// A cgroup kept busy by escaped children is reused by the next start.
+	w.exited(w.release())
+	// return wait outcome.
+	return err
+}
+
 // waitForProcess collects the exit result and signals completion via channel.
 //
 // Params:
@@ -143,6 +213,10 @@ func (e *Executor) waitForProcess(cmd Waiter, wait chan<- domain.ExitResult) {
 		// Normal exit with non-zero code.
 		if errors.As(err, &exitErr) {
 			result.Code = exitErr.ExitCode()
+			// Record the terminating signal, if any.
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+				result.Signal = int(status.Signal())
+			}
 		} else {
 			// Abnormal termination (signal, resource limit, etc).
 			result.Code = -1
@@ -164,9 +238,12 @@ func (e *Executor) waitForProcess(cmd Waiter, wait chan<- domain.ExitResult) {
 // Returns:
 //   - error: if process cannot be found or signal delivery fails
 //
-// Goroutine lifecycle: Spawns one goroutine to wait for process exit.
+// Processes started by this executor are waited through the exit reactor,
+// so their exit is reaped and reported once.
+//
+// Goroutine lifecycle: Spawns one goroutine to wait for other processes.
 // Termination: Goroutine exits when proc.Wait() returns (process exits or is killed).
-// Cleanup: Done channel is buffered (size 1) to prevent goroutine leak if caller abandons.
+// Cleanup: Done channel is closed once the process exited.
 func (e *Executor) Stop(pid int, timeout time.Duration) error {
 	proc, err := e.findProcess(pid)
 	// Process handle acquisition failed.
@@ -179,17 +256,7 @@ func (e *Executor) Stop(pid int, timeout time.Duration) error {
 		// return signal error to caller.
 		return fmt.Errorf("sending SIGTERM: %w", err)
 	}
-	// Wait for process exit in background.
-	// Goroutine lifecycle: Waits for process termination, sends result to done channel.
-	// Termination guarantee: proc.Wait() always returns when process exits (naturally or killed).
-	done := make(chan error, 1)
-	// wait for process exit in background.
-	go func() {
-		// block until process exits.
-		_, err := proc.Wait()
-		// send wait result to done channel.
-		done <- err
-	}()
+	done := e.awaitExit(pid, proc)
 	// create timeout timer for graceful shutdown window.
 	timer := time.NewTimer(timeout)
 	// ensure timer cleanup on function exit.
@@ -214,6 +281,33 @@ func (e *Executor) Stop(pid int, timeout time.Duration) error {
 	}
 }
 
+// awaitExit returns a channel closed once a process exited.
+//
+// Params:
+//   - pid: process ID to wait for
+//   - proc: process handle, waited for processes the reactor does not watch
+//
+// Returns:
+//   - <-chan struct{}: closed once the process exited
+func (e *Executor) awaitExit(pid int, proc Process) <-chan struct{} {
+	// Started processes are reaped by the reactor only.
+	if done, ok := e.reactor.exited(pid); ok {
+		// return reactor notification.
+		return done
+	}
+	done := make(chan struct{})
+	// Goroutine lifecycle: Waits for process termination, then closes done.
+	// Termination guarantee: proc.Wait() always returns when process exits (naturally or killed).
+	go func() {
+		// block until process exits.
+		_, _ = proc.Wait()
+		// signal exit.
+		close(done)
+	}()
+	// return wait notification.
+	return done
+}
+
 // Signal delivers a signal to the specified process.
 //
 // Params:
@@ -280,6 +374,28 @@ func (e *Executor) buildCommand(ctx context.Context, spec domain.Spec) (*exec.Cm
 	return cmd, nil
 }
 
+// sandboxPaths lists the mount changes of a spec, the DNS overrides
+// rendered as files replacing /etc/hosts and /etc/resolv.conf.
+//
+// Params:
+//   - spec: the process specification.
+//
+// Returns:
+//   - mountns.Paths: the mount changes, zero when the process is not sandboxed.
+func sandboxPaths(spec *domain.Spec) mountns.Paths {
+	paths := mountns.Paths{ReadOnly: spec.ReadOnlyPaths, Masked: spec.MaskedPaths, Tmpfs: spec.TmpfsPaths}
+	// hosts entries replace the hosts file
+	if spec.DNS.OverridesHosts() {
+		paths.Files = append(paths.Files, mountns.File{Path: hostsPath, Content: spec.DNS.HostsFile()})
+	}
+	// nameservers replace the resolver configuration
+	if spec.DNS.OverridesResolvConf() {
+		paths.Files = append(paths.Files, mountns.File{Path: resolvConfPath, Content: spec.DNS.ResolvConf()})
+	}
+	// return mount changes
+	return paths
+}
+
 // configureCredentials applies user/group credentials for privilege drop.
 //
 // Params:
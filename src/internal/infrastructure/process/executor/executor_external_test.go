@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"runtime"
 	"syscall"
 	"testing"
 	"time"
@@ -18,6 +19,7 @@ import (
 	domain "github.com/kodflow/daemon/internal/domain/process"
 	"github.com/kodflow/daemon/internal/domain/shared"
 	"github.com/kodflow/daemon/internal/infrastructure/process/executor"
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
 )
 
 // TestNewExecutor tests the NewExecutor constructor.
@@ -278,6 +280,60 @@ func TestExecutor_Start_Success(t *testing.T) {
 
 			// Verify successful exit.
 			assert.Equal(t, 0, result.Code)
+			// Verify the resources were released before the exit was reported.
+			assert.Empty(t, executor.Resources())
+		})
+	}
+}
+
+// TestExecutor_Start_SecurityLabel tests that a label the host cannot apply
+// fails the start with a clear error.
+//
+// Params:
+//   - t: the testing context
+//
+// Returns:
+//   - (none, test function)
+func TestExecutor_Start_SecurityLabel(t *testing.T) {
+	labeler := lsm.New()
+	// Define test cases for unavailable security modules.
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// spec is the process specification.
+		spec domain.Spec
+		// enabled reports whether the host runs the security module.
+		enabled bool
+		// wantErr is the expected error.
+		wantErr error
+	}{
+		{
+			name:    "selinux disabled",
+			spec:    domain.Spec{Command: "true", SELinuxContext: "system_u:system_r:httpd_t:s0"},
+			enabled: labeler.SELinuxEnabled(),
+			wantErr: lsm.ErrSELinuxDisabled,
+		},
+		{
+			name:    "apparmor disabled",
+			spec:    domain.Spec{Command: "true", AppArmorProfile: "nginx"},
+			enabled: labeler.AppArmorEnabled(),
+			wantErr: lsm.ErrAppArmorDisabled,
+		},
+	}
+
+	// Iterate over test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			// The host must lack the security module.
+			if tt.enabled || runtime.GOOS != "linux" {
+				t.Skip("security module enabled on this host")
+			}
+
+			_, _, err := executor.New().Start(context.Background(), tt.spec)
+
+			// Verify the start is refused with the reason.
+			assert.ErrorIs(t, err, tt.wantErr)
 		})
 	}
 }
@@ -490,6 +546,8 @@ func TestExecutor_Start_NonZeroExit(t *testing.T) {
 		args []string
 		// expectedCode is the expected exit code.
 		expectedCode int
+		// expectedSignal is the expected terminating signal.
+		expectedSignal int
 	}{
 		{
 			name:         "exit code 1",
@@ -503,6 +561,13 @@ func TestExecutor_Start_NonZeroExit(t *testing.T) {
 			args:         []string{"-c", "exit 42"},
 			expectedCode: 42,
 		},
+		{
+			name:           "killed by signal",
+			command:        "sh",
+			args:           []string{"-c", "kill -9 $$"},
+			expectedCode:   -1,
+			expectedSignal: 9,
+		},
 	}
 
 	// Iterate over test cases.
@@ -524,8 +589,9 @@ func TestExecutor_Start_NonZeroExit(t *testing.T) {
 
 			// Wait for process to complete.
 			result := <-wait
-			// Verify exit code matches expected.
+			// Verify exit code and signal match expected.
 			assert.Equal(t, tt.expectedCode, result.Code)
+			assert.Equal(t, tt.expectedSignal, result.Signal)
 		})
 	}
 }
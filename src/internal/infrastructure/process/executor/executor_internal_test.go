@@ -16,10 +16,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kodflow/daemon/internal/domain/config"
 	domain "github.com/kodflow/daemon/internal/domain/process"
 	"github.com/kodflow/daemon/internal/domain/shared"
 	"github.com/kodflow/daemon/internal/infrastructure/process/control"
 	"github.com/kodflow/daemon/internal/infrastructure/process/credentials"
+	"github.com/kodflow/daemon/internal/infrastructure/process/mountns"
 )
 
 // mockCmdWaiter is a mock implementation of cmdWaiter for testing.
@@ -641,3 +643,49 @@ func Test_UnixExecutor_Signal_FindProcessError(t *testing.T) {
 		})
 	}
 }
+
+// Test_sandboxPaths tests the mount changes of a spec.
+//
+// Params:
+//   - t: the testing context.
+func Test_sandboxPaths(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// spec is the process specification.
+		spec domain.Spec
+		// want is the expected mount changes.
+		want mountns.Paths
+	}{
+		{
+			name: "no_sandbox",
+			spec: domain.Spec{Command: "/bin/true"},
+			want: mountns.Paths{},
+		},
+		{
+			name: "paths_only",
+			spec: domain.Spec{ReadOnlyPaths: []string{"/usr"}, TmpfsPaths: []string{"/tmp"}},
+			want: mountns.Paths{ReadOnly: []string{"/usr"}, Tmpfs: []string{"/tmp"}},
+		},
+		{
+			name: "hosts_only",
+			spec: domain.Spec{DNS: config.DNSConfig{Hosts: []config.HostEntry{{IP: "10.0.0.5", Names: []string{"db"}}}}},
+			want: mountns.Paths{Files: []mountns.File{
+				{Path: "/etc/hosts", Content: "127.0.0.1\tlocalhost\n::1\tlocalhost ip6-localhost ip6-loopback\n10.0.0.5\tdb\n"},
+			}},
+		},
+		{
+			name: "resolv_conf_only",
+			spec: domain.Spec{DNS: config.DNSConfig{Nameservers: []string{"10.0.0.53"}}},
+			want: mountns.Paths{Files: []mountns.File{{Path: "/etc/resolv.conf", Content: "nameserver 10.0.0.53\n"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sandboxPaths(&tt.spec)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want.IsZero(), got.IsZero())
+		})
+	}
+}
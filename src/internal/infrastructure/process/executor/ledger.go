@@ -0,0 +1,140 @@
+//go:build unix
+
+// Package executor provides infrastructure adapters for OS process execution.
+package executor
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// ErrResourcesInUse is returned when releasing the resources of a process that still runs.
+var ErrResourcesInUse error = errors.New("process still running")
+
+// resourceLedger accounts for the resources created per process start.
+// The zero value is an empty ledger.
+type resourceLedger struct {
+	// mu protects entries and seq.
+	mu sync.Mutex
+	// entries holds the resources of each start, by start sequence.
+	entries map[uint64]*ledgerEntry
+	// seq numbers the starts.
+	seq uint64
+}
+
+// ledgerEntry is the accounting of one process start.
+type ledgerEntry struct {
+	// resources is the reported view of the entry.
+	resources domain.ProcessResources
+	// release removes the egress rules and cgroup.
+	release func() error
+}
+
+// started records the resources of a started process.
+//
+// Params:
+//   - pid: the process ID.
+//   - service: the service name.
+//   - cgroup: the cgroup directory created for the process, empty if none.
+//   - release: removes the egress rules and cgroup.
+//
+// Returns:
+//   - uint64: the start sequence, passed to exited.
+func (l *resourceLedger) started(pid int, service, cgroup string, release func() error) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Create the map on first use.
+	if l.entries == nil {
+		l.entries = make(map[uint64]*ledgerEntry)
+	}
+	// A new start reuses the cgroup a previous run failed to remove.
+	for seq, entry := range l.entries {
+		// Only exited runs of the same cgroup are taken over.
+		if cgroup != "" && !entry.resources.Waiting && entry.resources.Cgroup == cgroup {
+			delete(l.entries, seq)
+		}
+	}
+	l.seq++
+	l.entries[l.seq] = &ledgerEntry{
+		resources: domain.ProcessResources{PID: pid, Service: service, StartedAt: time.Now(), Waiting: true, Cgroup: cgroup},
+		release:   release,
+	}
+	// return start sequence
+	return l.seq
+}
+
+// exited records that the exit of a start was reaped. The entry is
+// dropped once its cgroup is removed, and kept to be reported otherwise.
+//
+// Params:
+//   - seq: the start sequence.
+//   - releaseErr: the error of the cleanup run at exit.
+func (l *resourceLedger) exited(seq uint64, releaseErr error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry, ok := l.entries[seq]
+	// Nothing left to account for.
+	if !ok || releaseErr == nil || entry.resources.Cgroup == "" {
+		delete(l.entries, seq)
+		// Entry settled.
+		return
+	}
+	entry.resources.Waiting = false
+}
+
+// Resources implements domain.ResourceLedger.
+//
+// Returns:
+//   - []domain.ProcessResources: the resources held, ordered by PID.
+func (e *Executor) Resources() []domain.ProcessResources {
+	e.ledger.mu.Lock()
+	defer e.ledger.mu.Unlock()
+	resources := make([]domain.ProcessResources, 0, len(e.ledger.entries))
+	// Copy each entry.
+	for _, entry := range e.ledger.entries {
+		resources = append(resources, entry.resources)
+	}
+	slices.SortFunc(resources, func(a, b domain.ProcessResources) int { return cmp.Compare(a.PID, b.PID) })
+	// return sorted resources
+	return resources
+}
+
+// Release implements domain.ResourceLedger: it runs again the cleanup of
+// exited processes whose cgroup could not be removed at exit.
+//
+// Params:
+//   - pid: the process ID.
+//
+// Returns:
+//   - error: ErrResourcesInUse while the process runs, or the cleanup error.
+func (e *Executor) Release(pid int) error {
+	e.ledger.mu.Lock()
+	defer e.ledger.mu.Unlock()
+	var errs []error
+	// Release every exited start of the process.
+	for seq, entry := range e.ledger.entries {
+		// Skip other processes.
+		if entry.resources.PID != pid {
+			continue
+		}
+		// A running process still uses its resources.
+		if entry.resources.Waiting {
+			errs = append(errs, fmt.Errorf("releasing pid %d: %w", pid, ErrResourcesInUse))
+			continue
+		}
+		// Drop the entry once its cgroup is gone.
+		if err := entry.release(); err != nil {
+			errs = append(errs, fmt.Errorf("releasing pid %d: %w", pid, err))
+			continue
+		}
+		delete(e.ledger.entries, seq)
+	}
+	// return joined errors
+	return errors.Join(errs...)
+}
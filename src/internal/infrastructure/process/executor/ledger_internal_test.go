@@ -0,0 +1,100 @@
+//go:build unix
+
+// Package executor provides internal white-box tests for the infrastructure executor package.
+// These tests verify the accounting of the resources held per process start.
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_resourceLedger tests the accounting of starts and exits.
+//
+// Params:
+//   - t: the testing context
+func Test_resourceLedger(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// cgroup is the cgroup of the start.
+		cgroup string
+		// exit indicates whether the wait goroutine ended.
+		exit bool
+		// releaseErr is the error of the cleanup at exit.
+		releaseErr error
+		// wantHeld indicates whether the start is still accounted for.
+		wantHeld bool
+		// wantWaiting is the expected Waiting flag of a held start.
+		wantWaiting bool
+	}{
+		{name: "running", cgroup: "/cg/svc-api", wantHeld: true, wantWaiting: true},
+		{name: "exited_and_released", cgroup: "/cg/svc-api", exit: true},
+		{name: "exited_without_cgroup", exit: true, releaseErr: errors.New("no nft")},
+		{name: "cgroup_left_behind", cgroup: "/cg/svc-api", exit: true, releaseErr: errors.New("device busy"), wantHeld: true},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Executor{}
+			seq := e.ledger.started(42, "api", tt.cgroup, func() error { return nil })
+			// End the wait goroutine when requested.
+			if tt.exit {
+				e.ledger.exited(seq, tt.releaseErr)
+			}
+
+			resources := e.Resources()
+
+			// Settled starts are forgotten.
+			if !tt.wantHeld {
+				assert.Empty(t, resources)
+				return
+			}
+			require.Len(t, resources, 1)
+			assert.Equal(t, 42, resources[0].PID)
+			assert.Equal(t, "api", resources[0].Service)
+			assert.Equal(t, tt.cgroup, resources[0].Cgroup)
+			assert.Equal(t, tt.wantWaiting, resources[0].Waiting)
+		})
+	}
+}
+
+// Test_Executor_Release tests the release of leftover cgroups.
+//
+// Params:
+//   - t: the testing context
+func Test_Executor_Release(t *testing.T) {
+	e := &Executor{}
+	releaseErr := errors.New("device busy")
+	released := 0
+	release := func() error {
+		released++
+		return releaseErr
+	}
+	running := e.ledger.started(7, "web", "/cg/svc-web", release)
+	exited := e.ledger.started(8, "api", "/cg/svc-api", release)
+	e.ledger.exited(exited, releaseErr)
+
+	// A running process keeps its resources.
+	require.ErrorIs(t, e.Release(7), ErrResourcesInUse)
+	// A failing cleanup keeps the entry.
+	require.ErrorIs(t, e.Release(8), releaseErr)
+	assert.Len(t, e.Resources(), 2)
+
+	releaseErr = nil
+	require.NoError(t, e.Release(8))
+	assert.Equal(t, 2, released)
+	assert.Len(t, e.Resources(), 1)
+
+	// A new start takes over the cgroup left behind.
+	e.ledger.exited(running, errors.New("device busy"))
+	e.ledger.started(9, "web", "/cg/svc-web", release)
+	resources := e.Resources()
+	require.Len(t, resources, 1)
+	assert.Equal(t, 9, resources[0].PID)
+}
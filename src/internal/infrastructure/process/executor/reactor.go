@@ -0,0 +1,92 @@
+//go:build unix
+
+// Package executor provides infrastructure adapters for OS process execution.
+package executor
+
+import "sync"
+
+// exitReactor dispatches the exits of the started processes. On Linux a
+// single goroutine, woken by SIGCHLD, finds the exited processes without
+// reaping them and dispatches them in start order; elsewhere each process
+// is waited by its own goroutine. The zero value is ready to use.
+type exitReactor struct {
+	// mu protects watches and seq.
+	mu sync.Mutex
+	// watches holds the processes not dispatched yet, by PID.
+	watches map[int]*exitWatch
+	// seq numbers the watches in start order.
+	seq uint64
+	// loop holds the platform state of the reactor.
+	loop reactorLoop
+}
+
+// exitWatch is a started process waiting for its exit to be dispatched.
+type exitWatch struct {
+	// pid is the process ID.
+	pid int
+	// seq orders the exits found together.
+	seq uint64
+	// onExit reaps the process and reports its exit.
+	onExit func()
+	// done is closed once onExit returned.
+	done chan struct{}
+}
+
+// watch registers a started process. onExit is called once the process
+// exited; it must reap it.
+//
+// Params:
+//   - pid: the process ID.
+//   - onExit: reaps the process and reports its exit.
+func (r *exitReactor) watch(pid int, onExit func()) {
+	r.mu.Lock()
+	// Create the map on first use.
+	if r.watches == nil {
+		r.watches = make(map[int]*exitWatch)
+	}
+	r.seq++
+	r.watches[pid] = &exitWatch{pid: pid, seq: r.seq, onExit: onExit, done: make(chan struct{})}
+	r.mu.Unlock()
+	r.arm(pid)
+}
+
+// exited returns a channel closed once the exit of a watched process is
+// dispatched.
+//
+// Params:
+//   - pid: the process ID.
+//
+// Returns:
+//   - <-chan struct{}: closed once the exit is dispatched.
+//   - bool: false when the process is not watched.
+func (r *exitReactor) exited(pid int) (<-chan struct{}, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.watches[pid]
+	// Unknown or already dispatched process.
+	if !ok {
+		// nothing to wait for
+		return nil, false
+	}
+	// return dispatch notification
+	return w.done, true
+}
+
+// dispatch reaps and reports each exit, in order, then forgets the
+// processes. They stay watched until reaped, so Stop waits for the report.
+//
+// Params:
+//   - exited: the exited watches.
+func (r *exitReactor) dispatch(exited []*exitWatch) {
+	// Report each exit.
+	for _, w := range exited {
+		w.onExit()
+		r.mu.Lock()
+		// A reaped PID may already be reused by a new start.
+		if r.watches[w.pid] == w {
+			delete(r.watches, w.pid)
+		}
+		r.mu.Unlock()
+		close(w.done)
+	}
+}
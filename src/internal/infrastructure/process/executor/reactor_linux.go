@@ -0,0 +1,102 @@
+//go:build linux
+
+// Package executor provides infrastructure adapters for OS process execution.
+package executor
+
+import (
+	"cmp"
+	"errors"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// reactorLoop is the SIGCHLD-driven dispatch goroutine, started with the
+// first process.
+type reactorLoop struct {
+	// once starts the goroutine.
+	once sync.Once
+	// wake receives SIGCHLD, and the scans requested by new watches.
+	wake chan os.Signal
+}
+
+// arm starts the dispatch goroutine if needed, then requests a scan, so
+// that a process exiting before it was watched is still dispatched.
+//
+// Params:
+//   - pid: the watched process ID (unused, every watch is scanned).
+func (r *exitReactor) arm(_ int) {
+	r.loop.once.Do(func() {
+		r.loop.wake = make(chan os.Signal, 1)
+		signal.Notify(r.loop.wake, syscall.SIGCHLD)
+		// Goroutine lifecycle: lives as long as the daemon, one for every process started.
+		go r.run()
+	})
+	// A pending scan already covers the new watch.
+	select {
+	case r.loop.wake <- syscall.SIGCHLD:
+	default:
+	}
+}
+
+// run dispatches the exited processes on each SIGCHLD.
+func (r *exitReactor) run() {
+	// Scan on each wake up.
+	for range r.loop.wake {
+		r.dispatch(r.collect(childExited))
+	}
+}
+
+// collect returns the watches whose process exited.
+//
+// Params:
+//   - hasExited: reports whether a process exited, without reaping it.
+//
+// Returns:
+//   - []*exitWatch: the exited watches, in start order.
+func (r *exitReactor) collect(hasExited func(pid int) bool) []*exitWatch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var exited []*exitWatch
+	// Check each watched process.
+	for pid, w := range r.watches {
+		// Collect exited processes.
+		if hasExited(pid) {
+			exited = append(exited, w)
+		}
+	}
+	slices.SortFunc(exited, func(a, b *exitWatch) int { return cmp.Compare(a.seq, b.seq) })
+	// return exits in start order
+	return exited
+}
+
+// childExited reports whether a child exited, leaving it waitable so that
+// its exit status is collected by the wait of its command.
+//
+// Params:
+//   - pid: the child process ID.
+//
+// Returns:
+//   - bool: true when the child exited, or was reaped by someone else.
+func childExited(pid int) bool {
+	// Retry calls interrupted by a signal.
+	for {
+		var info unix.Siginfo
+		err := unix.Waitid(unix.P_PID, pid, &info, unix.WEXITED|unix.WNOHANG|unix.WNOWAIT, nil)
+		// Interrupted before the check.
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		// A child reaped elsewhere is dispatched too; its wait reports the error.
+		if err != nil {
+			// no longer running
+			return errors.Is(err, unix.ECHILD)
+		}
+		// The kernel leaves the info zeroed while the child runs.
+		return info.Signo == int32(unix.SIGCHLD)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build linux
+
+// Package executor provides internal white-box tests for the infrastructure executor package.
+// These tests verify the SIGCHLD-driven dispatch of process exits.
+package executor
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// Test_exitReactor_dispatch tests that exits found together are
+// dispatched in start order, and running processes stay watched.
+//
+// Params:
+//   - t: the testing context
+func Test_exitReactor_dispatch(t *testing.T) {
+	var order []int
+	r := &exitReactor{watches: map[int]*exitWatch{}}
+	// Register the watches in start order, not PID order.
+	for seq, pid := range []int{300, 100, 200, 400} {
+		r.watches[pid] = &exitWatch{pid: pid, seq: uint64(seq + 1), onExit: func() { order = append(order, pid) }, done: make(chan struct{})}
+	}
+	var running <-chan struct{} = r.watches[400].done
+
+	r.dispatch(r.collect(func(pid int) bool { return pid != 400 }))
+
+	assert.Equal(t, []int{300, 100, 200}, order)
+	done, ok := r.exited(400)
+	require.True(t, ok)
+	assert.Equal(t, running, done)
+	_, ok = r.exited(300)
+	assert.False(t, ok)
+}
+
+// Test_childExited tests that exited children are found without being
+// reaped, so their command still collects the exit status.
+//
+// Params:
+//   - t: the testing context
+func Test_childExited(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "exit 3")
+	require.NoError(t, cmd.Start())
+
+	require.Eventually(t, func() bool { return childExited(cmd.Process.Pid) }, 5*time.Second, 10*time.Millisecond)
+	// Still waitable after the check.
+	assert.True(t, childExited(cmd.Process.Pid))
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, cmd.Wait(), &exitErr)
+	assert.Equal(t, 3, exitErr.ExitCode())
+	assert.True(t, childExited(cmd.Process.Pid), "reaped children are no longer waited for")
+}
+
+// Test_Executor_Stop_reportsSignal tests that a stopped process is reaped
+// once, by the reactor, and reported with its terminating signal.
+//
+// Params:
+//   - t: the testing context
+func Test_Executor_Stop_reportsSignal(t *testing.T) {
+	e := New()
+	pid, wait, err := e.Start(context.Background(), domain.Spec{Command: "sleep 60"})
+	require.NoError(t, err)
+
+	require.NoError(t, e.Stop(pid, 5*time.Second))
+
+	select {
+	case result := <-wait:
+		assert.NoError(t, result.Error)
+		assert.Equal(t, int(syscall.SIGTERM), result.Signal)
+	case <-time.After(5 * time.Second):
+		t.Fatal("exit not reported")
+	}
+	_, watched := e.reactor.exited(pid)
+	assert.False(t, watched)
+}
+
+// Test_Executor_Start_concurrentExits tests that every exit is reported, however
+// many processes exit together.
+//
+// Params:
+//   - t: the testing context
+func Test_Executor_Start_concurrentExits(t *testing.T) {
+	e := New()
+	waits := make([]<-chan domain.ExitResult, 0, 5)
+	// Start processes exiting at about the same time.
+	for code := range 5 {
+		_, wait, err := e.Start(context.Background(), domain.Spec{Command: "sh", Args: []string{"-c", "sleep 0.1; exit " + strconv.Itoa(code)}})
+		require.NoError(t, err)
+		waits = append(waits, wait)
+	}
+
+	// Each process reports its own exit code.
+	for code, wait := range waits {
+		select {
+		case result := <-wait:
+			assert.Equal(t, code, result.Code)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("exit %d not reported", code)
+		}
+	}
+}
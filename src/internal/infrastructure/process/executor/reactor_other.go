@@ -0,0 +1,21 @@
+//go:build unix && !linux
+
+// Package executor provides infrastructure adapters for OS process execution.
+package executor
+
+// reactorLoop holds no state: without waitid, each process is waited by
+// its own goroutine.
+type reactorLoop struct{}
+
+// arm dispatches the process from its own goroutine, the wait of its
+// command blocking until it exits.
+//
+// Params:
+//   - pid: the watched process ID.
+func (r *exitReactor) arm(pid int) {
+	r.mu.Lock()
+	w := r.watches[pid]
+	r.mu.Unlock()
+	// Goroutine lifecycle: ends once the process exited and was reaped.
+	go r.dispatch([]*exitWatch{w})
+}
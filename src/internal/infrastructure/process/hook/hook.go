@@ -0,0 +1,194 @@
+// Package hook runs hook commands on behalf of services.
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+)
+
+const (
+	// maxOutput bounds the command output quoted in errors.
+	maxOutput int = 512
+
+	// waitDelay bounds the wait for output pipes held by descendants
+	// once the command exited or was killed.
+	waitDelay time.Duration = time.Second
+)
+
+// eventPayload is the JSON event written to the standard input of event hooks.
+type eventPayload struct {
+	Time     time.Time         `json:"time"`
+	Service  string            `json:"service,omitempty"`
+	Type     string            `json:"type"`
+	PID      int               `json:"pid,omitempty"`
+	ExitCode int               `json:"exit_code,omitempty"`
+	Signal   int               `json:"signal,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+// Runner runs hook commands with the daemon's credentials.
+type Runner struct{}
+
+// New creates a hook runner.
+//
+// Returns:
+//   - *Runner: the runner.
+func New() *Runner {
+	// return stateless runner
+	return &Runner{}
+}
+
+// Run runs a command until it exits or its timeout expires.
+//
+// Params:
+//   - ctx: kills the command when cancelled.
+//   - cmd: the command.
+//
+// Returns:
+//   - error: if the command cannot start, fails or times out, with its output.
+func (r *Runner) Run(ctx context.Context, cmd apphook.Command) error {
+	// bound the run
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+
+	c, err := command(ctx, cmd)
+	// the event cannot be encoded
+	if err != nil {
+		// return encoding error
+		return err
+	}
+	out, err := c.CombinedOutput()
+	// return the outcome with the command output
+	return failure(ctx, cmd, err, out)
+}
+
+// Output runs a command until it exits or its timeout expires, and returns
+// its standard output.
+//
+// Params:
+//   - ctx: kills the command when cancelled.
+//   - cmd: the command.
+//
+// Returns:
+//   - string: the standard output, also when the command failed.
+//   - error: if the command cannot start, fails or times out, with its error output.
+func (r *Runner) Output(ctx context.Context, cmd apphook.Command) (string, error) {
+	// bound the run
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+
+	c, err := command(ctx, cmd)
+	// the event cannot be encoded
+	if err != nil {
+		// return encoding error
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	err = c.Run()
+	quoted := stderr.Bytes()
+	// quote the standard output of silent failures
+	if len(bytes.TrimSpace(quoted)) == 0 {
+		quoted = stdout.Bytes()
+	}
+	// return the output with the outcome
+	return stdout.String(), failure(ctx, cmd, err, quoted)
+}
+
+// command prepares a hook command, with its event on standard input.
+//
+// Params:
+//   - ctx: kills the command when cancelled.
+//   - cmd: the command.
+//
+// Returns:
+//   - *exec.Cmd: the prepared command.
+//   - error: if the event cannot be encoded.
+func command(ctx context.Context, cmd apphook.Command) (*exec.Cmd, error) {
+	c := exec.CommandContext(ctx, cmd.Path, cmd.Args...)
+	c.Env = append(os.Environ(), cmd.Env...)
+	c.WaitDelay = waitDelay
+	// hand the event on standard input
+	if e := cmd.Event; e != nil {
+		data, err := json.Marshal(eventPayload{
+			Time:     e.Time,
+			Service:  e.Service,
+			Type:     e.Type,
+			PID:      e.PID,
+			ExitCode: e.ExitCode,
+			Signal:   e.Signal,
+			Error:    e.Error,
+			Labels:   e.Labels,
+		})
+		// the event cannot be encoded
+		if err != nil {
+			// return wrapped error
+			return nil, fmt.Errorf("hook %s: encoding event: %w", cmd.Path, err)
+		}
+		c.Stdin = bytes.NewReader(append(data, '\n'))
+	}
+	// return prepared command
+	return c, nil
+}
+
+// failure describes the outcome of a hook run.
+//
+// Params:
+//   - ctx: the context of the run.
+//   - cmd: the command.
+//   - err: the run error, nil on success.
+//   - out: the output quoted on failure.
+//
+// Returns:
+//   - error: nil on success, the error with the command output otherwise.
+func failure(ctx context.Context, cmd apphook.Command, err error, out []byte) error {
+	// command succeeded
+	if err == nil {
+		// no failure
+		return nil
+	}
+	// report the timeout rather than the kill
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	// return error with the command output
+	return fmt.Errorf("hook %s: %w%s", cmd.Path, err, excerpt(out))
+}
+
+// excerpt formats the end of a command output for an error message.
+//
+// Params:
+//   - out: the command output.
+//
+// Returns:
+//   - string: ": <output>", empty without output.
+func excerpt(out []byte) string {
+	text := strings.TrimSpace(string(out))
+	// nothing to quote
+	if text == "" {
+		// return empty excerpt
+		return ""
+	}
+	// keep the end, where errors are printed
+	if len(text) > maxOutput {
+		text = "..." + text[len(text)-maxOutput:]
+	}
+	// return quoted output
+	return ": " + text
+}
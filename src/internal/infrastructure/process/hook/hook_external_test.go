@@ -0,0 +1,132 @@
+// Package hook_test provides black-box tests for hook.go.
+package hook_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apphook "github.com/kodflow/daemon/internal/application/hook"
+	"github.com/kodflow/daemon/internal/infrastructure/process/hook"
+)
+
+// TestRunner_Run tests hook command outcomes.
+//
+// Params:
+//   - t: the testing context.
+func TestRunner_Run(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// cmd is the command run.
+		cmd apphook.Command
+		// wantErr lists substrings of the expected error, nil for success.
+		wantErr []string
+	}{
+		{
+			name: "success_with_environment",
+			cmd:  apphook.Command{Path: "/bin/sh", Args: []string{"-c", `test "$HOOK_VAR" = ok`}, Env: []string{"HOOK_VAR=ok"}},
+		},
+		{
+			name:    "failure_quotes_output",
+			cmd:     apphook.Command{Path: "/bin/sh", Args: []string{"-c", "echo broken config >&2; exit 3"}},
+			wantErr: []string{"exit status 3", "broken config"},
+		},
+		{
+			name:    "timeout",
+			cmd:     apphook.Command{Path: "/bin/sh", Args: []string{"-c", "sleep 10"}, Timeout: 50 * time.Millisecond},
+			wantErr: []string{context.DeadlineExceeded.Error()},
+		},
+		{
+			name:    "missing_command",
+			cmd:     apphook.Command{Path: "/nonexistent/hook"},
+			wantErr: []string{"/nonexistent/hook"},
+		},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			err := hook.New().Run(context.Background(), tt.cmd)
+
+			// Check the expected outcome.
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			// Check each expected fragment.
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+// TestRunner_Output tests that the standard output is returned with the outcome.
+//
+// Params:
+//   - t: the testing context.
+func TestRunner_Output(t *testing.T) {
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// cmd is the command run.
+		cmd apphook.Command
+		// want is the expected standard output.
+		want string
+		// wantErr lists substrings of the expected error, nil for success.
+		wantErr []string
+	}{
+		{
+			name: "success",
+			cmd:  apphook.Command{Path: "/bin/sh", Args: []string{"-c", "echo clean; echo noise >&2"}},
+			want: "clean\n",
+		},
+		{
+			name:    "failure_quotes_error_output",
+			cmd:     apphook.Command{Path: "/bin/sh", Args: []string{"-c", "echo degraded; echo disk sdb missing >&2; exit 2"}},
+			want:    "degraded\n",
+			wantErr: []string{"exit status 2", "disk sdb missing"},
+		},
+		{
+			name:    "silent_failure_quotes_output",
+			cmd:     apphook.Command{Path: "/bin/sh", Args: []string{"-c", "echo degraded; exit 1"}},
+			want:    "degraded\n",
+			wantErr: []string{"exit status 1", "degraded"},
+		},
+		{
+			name: "event_on_standard_input",
+			cmd: apphook.Command{Path: "/bin/cat", Event: &apphook.Event{
+				Time:     time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+				Service:  "api",
+				Type:     "failed",
+				PID:      42,
+				ExitCode: 2,
+				Labels:   map[string]string{"team": "payments"},
+			}},
+			want: `{"time":"2026-03-01T12:00:00Z","service":"api","type":"failed","pid":42,"exit_code":2,"labels":{"team":"payments"}}` + "\n",
+		},
+	}
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := hook.New().Output(context.Background(), tt.cmd)
+			assert.Equal(t, tt.want, out)
+			// Check the expected outcome.
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			// Check each expected fragment.
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package hostinfo detects the platform capabilities of the host running
+// the daemon: cgroup version, pressure stall information, active security
+// modules, nftables, init system, container runtime sockets, PID 1 status,
+// privileges and file descriptor limits. The daemon reports them and
+// disables the features the host cannot run.
+package hostinfo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+const (
+	// defaultProcRoot is the default procfs mount point.
+	defaultProcRoot string = "/proc"
+	// defaultSysRoot is the default sysfs mount point.
+	defaultSysRoot string = "/sys"
+	// defaultRunRoot is the default runtime state directory.
+	defaultRunRoot string = "/run"
+
+	// nftCommand is the command loading egress rules.
+	nftCommand string = "nft"
+)
+
+// containerSockets are the API sockets of the known container runtimes,
+// relative to the runtime state directory.
+var containerSockets []domainmetrics.ContainerSocket = []domainmetrics.ContainerSocket{
+	{Runtime: "docker", Path: "docker.sock"},
+	{Runtime: "podman", Path: "podman/podman.sock"},
+	{Runtime: "containerd", Path: "containerd/containerd.sock"},
+	{Runtime: "crio", Path: "crio/crio.sock"},
+}
+
+// lookPathFunc finds a command in PATH.
+type lookPathFunc func(file string) (string, error)
+
+// Detector detects the platform capabilities of the host.
+type Detector struct {
+	// procRoot is the procfs mount point.
+	procRoot string
+	// sysRoot is the sysfs mount point.
+	sysRoot string
+	// runRoot is the runtime state directory holding sockets and the systemd marker.
+	runRoot string
+	// lookPath finds the nft command.
+	lookPath lookPathFunc
+}
+
+// New creates a detector using the standard mount points.
+//
+// Returns:
+//   - *Detector: detector bound to /proc, /sys and /run.
+func New() *Detector {
+	// use standard mount points
+	return NewWithRoots(defaultProcRoot, defaultSysRoot, defaultRunRoot)
+}
+
+// NewWithRoots creates a detector using custom mount points.
+//
+// Params:
+//   - procRoot: the procfs mount point.
+//   - sysRoot: the sysfs mount point.
+//   - runRoot: the runtime state directory.
+//
+// Returns:
+//   - *Detector: detector bound to the given roots.
+func NewWithRoots(procRoot, sysRoot, runRoot string) *Detector {
+	// return detector bound to roots
+	return &Detector{procRoot: procRoot, sysRoot: sysRoot, runRoot: runRoot, lookPath: exec.LookPath}
+}
+
+// CollectHostInventory detects the platform capabilities of the host.
+// Capabilities that cannot be read are reported as missing.
+//
+// Params:
+//   - ctx: context for cancellation.
+//
+// Returns:
+//   - domainmetrics.HostInventory: the detected capabilities.
+//   - error: only on cancellation.
+func (d *Detector) CollectHostInventory(ctx context.Context) (domainmetrics.HostInventory, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.HostInventory{}, err
+	}
+	inventory := domainmetrics.HostInventory{
+		OS:               runtime.GOOS,
+		Architecture:     runtime.GOARCH,
+		ContainerSockets: d.containerSockets(),
+		PID1:             os.Getpid() == 1,
+		Privileged:       os.Geteuid() == 0,
+	}
+	d.detectPlatform(&inventory)
+	// return detected capabilities
+	return inventory, nil
+}
+
+// containerSockets lists the container runtime sockets found.
+//
+// Returns:
+//   - []domainmetrics.ContainerSocket: the sockets found, in a fixed order.
+func (d *Detector) containerSockets() []domainmetrics.ContainerSocket {
+	var found []domainmetrics.ContainerSocket
+	// keep the paths that are sockets
+	for _, socket := range containerSockets {
+		path := filepath.Join(d.runRoot, socket.Path)
+		// a regular file or a missing path is no runtime
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			found = append(found, domainmetrics.ContainerSocket{Runtime: socket.Runtime, Path: path})
+		}
+	}
+	// return found sockets
+	return found
+}
@@ -0,0 +1,95 @@
+//go:build linux
+
+// Package hostinfo detects the platform capabilities of the host running the daemon.
+package hostinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+const (
+	// osReleaseFile is the procfs file holding the kernel release.
+	osReleaseFile string = "sys/kernel/osrelease"
+	// memoryPressureFile is the host memory PSI file, present when the kernel has PSI.
+	memoryPressureFile string = "pressure/memory"
+	// controllersFile lists the controllers of a cgroup v2 hierarchy root.
+	controllersFile string = "fs/cgroup/cgroup.controllers"
+	// memoryControllerDir is the memory controller of a cgroup v1 hierarchy.
+	memoryControllerDir string = "fs/cgroup/memory"
+	// systemdMarker exists when systemd is the init system, as sd_booted checks.
+	systemdMarker string = "systemd/system"
+)
+
+// detectPlatform fills in the Linux capabilities: kernel, cgroup version,
+// PSI, security modules, nft, systemd and file descriptor limits.
+//
+// Params:
+//   - inventory: the inventory to complete.
+func (d *Detector) detectPlatform(inventory *domainmetrics.HostInventory) {
+	// read the kernel release, "6.1.0-18-amd64"
+	if data, err := os.ReadFile(filepath.Join(d.procRoot, osReleaseFile)); err == nil {
+		inventory.KernelVersion = strings.TrimSpace(string(data))
+	}
+	inventory.CgroupVersion = d.cgroupVersion()
+	inventory.PressureStall = exists(filepath.Join(d.procRoot, memoryPressureFile))
+	labeler := lsm.NewWithRoots(d.procRoot, d.sysRoot)
+	// report AppArmor first, as the modules are sorted
+	if labeler.AppArmorEnabled() {
+		inventory.SecurityModules = append(inventory.SecurityModules, "apparmor")
+	}
+	// report SELinux
+	if labeler.SELinuxEnabled() {
+		inventory.SecurityModules = append(inventory.SecurityModules, "selinux")
+	}
+	_, err := d.lookPath(nftCommand)
+	inventory.Nftables = err == nil
+	// systemd creates its marker directory at boot
+	if info, err := os.Stat(filepath.Join(d.runRoot, systemdMarker)); err == nil && info.IsDir() {
+		inventory.Systemd = true
+	}
+	var limit syscall.Rlimit
+	// read the file descriptor limits of the daemon
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err == nil {
+		inventory.MaxFDs = limit.Cur
+		inventory.MaxFDsHard = limit.Max
+	}
+}
+
+// cgroupVersion detects the cgroup hierarchy mounted under sysfs. A hybrid
+// hierarchy, with controllers on v1, is reported as v1.
+//
+// Returns:
+//   - int: 2 for a unified hierarchy, 1 for v1 controllers, 0 without cgroups.
+func (d *Detector) cgroupVersion() int {
+	// only the root of a v2 hierarchy lists its controllers
+	if exists(filepath.Join(d.sysRoot, controllersFile)) {
+		// return unified hierarchy
+		return 2
+	}
+	// v1 mounts one directory per controller
+	if exists(filepath.Join(d.sysRoot, memoryControllerDir)) {
+		// return v1 hierarchy
+		return 1
+	}
+	// return no hierarchy
+	return 0
+}
+
+// exists reports whether a path can be stat'ed.
+//
+// Params:
+//   - path: the path.
+//
+// Returns:
+//   - bool: true if the path exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	// any error counts as missing
+	return err == nil
+}
@@ -0,0 +1,137 @@
+//go:build linux
+
+// Package hostinfo provides internal tests for hostinfo_linux.go.
+package hostinfo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// writeFile creates a file and its parent directories.
+//
+// Params:
+//   - t: the testing context.
+//   - path: the file path.
+//   - content: the file content.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// listenUnix creates a listening unix socket.
+//
+// Params:
+//   - t: the testing context.
+//   - path: the socket path.
+func listenUnix(t *testing.T, path string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+}
+
+// Test_Detector_CollectHostInventory tests the detection from fixture roots.
+//
+// Params:
+//   - t: the testing context.
+func Test_Detector_CollectHostInventory(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, proc, sys, run string)
+		nft   bool
+		check func(t *testing.T, inventory *domainmetrics.HostInventory)
+	}{
+		{
+			name:  "bare",
+			setup: func(*testing.T, string, string, string) {},
+			check: func(t *testing.T, inventory *domainmetrics.HostInventory) {
+				assert.Empty(t, inventory.KernelVersion)
+				assert.Zero(t, inventory.CgroupVersion)
+				assert.False(t, inventory.PressureStall)
+				assert.Empty(t, inventory.SecurityModules)
+				assert.False(t, inventory.Nftables)
+				assert.False(t, inventory.Systemd)
+				assert.Empty(t, inventory.ContainerSockets)
+			},
+		},
+		{
+			name: "systemd host on cgroup v2",
+			setup: func(t *testing.T, proc, sys, run string) {
+				writeFile(t, filepath.Join(proc, "sys", "kernel", "osrelease"), "6.1.0-18-amd64\n")
+				writeFile(t, filepath.Join(proc, "pressure", "memory"), "some avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+				writeFile(t, filepath.Join(sys, "fs", "cgroup", "cgroup.controllers"), "cpu memory io\n")
+				writeFile(t, filepath.Join(sys, "module", "apparmor", "parameters", "enabled"), "Y\n")
+				writeFile(t, filepath.Join(sys, "fs", "selinux", "enforce"), "1\n")
+				require.NoError(t, os.MkdirAll(filepath.Join(run, "systemd", "system"), 0o755))
+				listenUnix(t, filepath.Join(run, "docker.sock"))
+				// A regular file is no runtime socket.
+				writeFile(t, filepath.Join(run, "podman", "podman.sock"), "")
+			},
+			nft: true,
+			check: func(t *testing.T, inventory *domainmetrics.HostInventory) {
+				assert.Equal(t, "6.1.0-18-amd64", inventory.KernelVersion)
+				assert.Equal(t, 2, inventory.CgroupVersion)
+				assert.True(t, inventory.PressureStall)
+				assert.Equal(t, []string{"apparmor", "selinux"}, inventory.SecurityModules)
+				assert.True(t, inventory.Nftables)
+				assert.True(t, inventory.Systemd)
+				require.Len(t, inventory.ContainerSockets, 1)
+				assert.Equal(t, "docker", inventory.ContainerSockets[0].Runtime)
+			},
+		},
+		{
+			name: "cgroup v1",
+			setup: func(t *testing.T, _, sys, _ string) {
+				require.NoError(t, os.MkdirAll(filepath.Join(sys, "fs", "cgroup", "memory"), 0o755))
+			},
+			check: func(t *testing.T, inventory *domainmetrics.HostInventory) {
+				assert.Equal(t, 1, inventory.CgroupVersion)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proc, sys, run := t.TempDir(), t.TempDir(), t.TempDir()
+			tt.setup(t, proc, sys, run)
+			detector := NewWithRoots(proc, sys, run)
+			detector.lookPath = func(string) (string, error) {
+				// Report the nft command as configured by the case.
+				if tt.nft {
+					return "/usr/sbin/nft", nil
+				}
+				return "", errors.New("not found")
+			}
+
+			inventory, err := detector.CollectHostInventory(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "linux", inventory.OS)
+			assert.Equal(t, os.Getpid() == 1, inventory.PID1)
+			assert.NotZero(t, inventory.MaxFDs)
+			tt.check(t, &inventory)
+		})
+	}
+}
+
+// Test_Detector_CollectHostInventory_cancelled tests that a cancelled context is honored.
+//
+// Params:
+//   - t: the testing context.
+func Test_Detector_CollectHostInventory_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := New().CollectHostInventory(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+// Package hostinfo detects the platform capabilities of the host running the daemon.
+package hostinfo
+
+import domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+
+// detectPlatform leaves the Linux capabilities missing outside Linux: no
+// cgroups, PSI, security modules or systemd, and unknown limits.
+//
+// Params:
+//   - inventory: the inventory to complete (unused).
+func (d *Detector) detectPlatform(_ *domainmetrics.HostInventory) {
+	// nothing to detect
+}
@@ -0,0 +1,57 @@
+// Package hostpower gives the daemon advance notice of a host shutdown.
+package hostpower
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// powerButtonEvent is the class of the acpid power button events.
+const powerButtonEvent string = "button/power"
+
+// startACPI listens for power button events on the acpid socket.
+//
+// Params:
+//   - ctx: closes the connection.
+//
+// Returns:
+//   - error: if the socket could not be reached.
+func (w *Watcher) startACPI(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", w.cfg.ACPISocket)
+	// acpid not running
+	if err != nil {
+		// return dial error
+		return fmt.Errorf("listen acpi events: %w", err)
+	}
+	context.AfterFunc(ctx, func() { _ = conn.Close() })
+	go func() {
+		scanner := bufio.NewScanner(conn)
+		// read one event per line
+		for scanner.Scan() {
+			// notify on a power button press
+			if isPowerButton(scanner.Text()) {
+				w.notify(SourceACPI)
+			}
+		}
+	}()
+	// listening
+	return nil
+}
+
+// isPowerButton reports whether an acpid event is a power button press,
+// such as "button/power PBTN 00000080 00000000".
+//
+// Params:
+//   - event: one acpid event line.
+//
+// Returns:
+//   - bool: true for power button events.
+func isPowerButton(event string) bool {
+	fields := strings.Fields(event)
+	// the first field is the event class
+	return len(fields) > 0 && fields[0] == powerButtonEvent
+}
@@ -0,0 +1,119 @@
+// Package hostpower gives the daemon advance notice of a host shutdown.
+// With systemd-logind, the daemon holds a delay inhibitor lock and listens
+// for PrepareForShutdown: logind announces the shutdown, then waits for the
+// lock to be released, up to its InhibitDelayMaxSec. With acpid, a power
+// button press starts the shutdown. Either way, the ordered stop of the
+// services begins before the host kills them.
+package hostpower
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// Source is the origin of a host shutdown notice.
+type Source string
+
+const (
+	// SourceLogind is a PrepareForShutdown signal of systemd-logind.
+	SourceLogind Source = "logind"
+	// SourceACPI is a power button event of acpid.
+	SourceACPI Source = "acpi"
+)
+
+// NoticeHandler is called once, on the first host shutdown notice.
+type NoticeHandler func(source Source)
+
+// commandFunc builds the external commands run by the watcher.
+type commandFunc func(ctx context.Context, name string, args ...string) *exec.Cmd
+
+// Watcher listens for host shutdown notices and holds the logind inhibitor
+// lock until it is released.
+type Watcher struct {
+	// cfg selects the notice sources.
+	cfg config.HostShutdownConfig
+	// command builds the logind commands.
+	command commandFunc
+	// once delivers the first notice only.
+	once sync.Once
+	// handler receives the notice.
+	handler NoticeHandler
+	// mu protects lock.
+	mu sync.Mutex
+	// lock is the held inhibitor lock, nil when none.
+	lock *inhibitor
+}
+
+// New creates a watcher for the configured notice sources.
+//
+// Params:
+//   - cfg: the host shutdown configuration.
+//
+// Returns:
+//   - *Watcher: the watcher, idle until Start.
+func New(cfg *config.HostShutdownConfig) *Watcher {
+	// run the real logind commands
+	return &Watcher{cfg: *cfg, command: exec.CommandContext}
+}
+
+// Start takes the inhibitor lock and listens for notices until ctx is
+// done. The lock outlives ctx: it is held until Release, so the services
+// can stop after the daemon context is cancelled. A source that cannot be
+// used does not prevent the others from starting.
+//
+// Params:
+//   - ctx: stops the listening.
+//   - handler: called on the first notice.
+//
+// Returns:
+//   - error: why a source could not be used, nil if all started.
+func (w *Watcher) Start(ctx context.Context, handler NoticeHandler) error {
+	w.handler = handler
+	var errs []error
+	// hold the lock before listening, so no announcement is missed
+	if w.cfg.Logind {
+		errs = append(errs, w.startLogind(ctx))
+	}
+	// listen to the power button
+	if w.cfg.ACPISocket != "" {
+		errs = append(errs, w.startACPI(ctx))
+	}
+	// return the failures of each source
+	return errors.Join(errs...)
+}
+
+// Release releases the inhibitor lock, letting the host shutdown proceed.
+// It is safe to call when no lock is held, and more than once.
+//
+// Returns:
+//   - error: if the lock holder failed.
+func (w *Watcher) Release() error {
+	w.mu.Lock()
+	lock := w.lock
+	w.lock = nil
+	w.mu.Unlock()
+	// nothing held
+	if lock == nil {
+		// no lock to release
+		return nil
+	}
+	// return release result
+	return lock.release()
+}
+
+// notify delivers the first notice to the handler.
+//
+// Params:
+//   - source: the origin of the notice.
+func (w *Watcher) notify(source Source) {
+	w.once.Do(func() {
+		// forward to the handler when set
+		if w.handler != nil {
+			w.handler(source)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+// Package hostpower provides white-box tests for host shutdown notices.
+package hostpower
+
+import (
+	"context"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// fakeCommands runs shell scripts in place of the logind commands.
+//
+// Params:
+//   - inhibit: the script standing for systemd-inhibit.
+//   - monitor: the script standing for busctl monitor.
+//
+// Returns:
+//   - commandFunc: the command builder.
+func fakeCommands(inhibit, monitor string) commandFunc {
+	return func(ctx context.Context, name string, _ ...string) *exec.Cmd {
+		script := monitor
+		// select the script of the command
+		if name == inhibitBinary {
+			script = inhibit
+		}
+		return exec.CommandContext(ctx, "/bin/sh", "-c", script)
+	}
+}
+
+// Test_shutdownAnnounced tests the recognition of logind announcements.
+//
+// Params:
+//   - t: the testing context.
+func Test_shutdownAnnounced(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		line string
+		want bool
+	}{
+		{name: "shutdown starts", line: `{"type":"signal","member":"PrepareForShutdown","payload":{"type":"b","data":[true]}}`, want: true},
+		{name: "shutdown cancelled", line: `{"type":"signal","member":"PrepareForShutdown","payload":{"type":"b","data":[false]}}`},
+		{name: "other signal", line: `{"type":"signal","member":"PrepareForSleep","payload":{"type":"b","data":[true]}}`},
+		{name: "not json", line: `Monitoring bus message stream.`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, shutdownAnnounced([]byte(tt.line)))
+		})
+	}
+}
+
+// Test_isPowerButton tests the recognition of acpid power button events.
+//
+// Params:
+//   - t: the testing context.
+func Test_isPowerButton(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isPowerButton("button/power PBTN 00000080 00000000"))
+	assert.False(t, isPowerButton("button/lid LID close"))
+	assert.False(t, isPowerButton(""))
+}
+
+// Test_Watcher_logind tests that the lock is held until released and that
+// only the first announcement is delivered.
+//
+// Params:
+//   - t: the testing context.
+func Test_Watcher_logind(t *testing.T) {
+	t.Parallel()
+
+	announce := `{"member":"PrepareForShutdown","payload":{"data":[true]}}`
+	w := New(&config.HostShutdownConfig{Logind: true})
+	w.command = fakeCommands("cat", "echo '"+announce+"'; echo '"+announce+"'")
+
+	notices := make(chan Source, 2)
+	require.NoError(t, w.Start(context.Background(), func(source Source) { notices <- source }))
+
+	select {
+	case source := <-notices:
+		assert.Equal(t, SourceLogind, source)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notice")
+	}
+	require.NoError(t, w.Release())
+	require.NoError(t, w.Release())
+	assert.Empty(t, notices)
+}
+
+// Test_Watcher_inhibitFailure tests that a failing lock holder is reported
+// with its diagnostics on release.
+//
+// Params:
+//   - t: the testing context.
+func Test_Watcher_inhibitFailure(t *testing.T) {
+	t.Parallel()
+
+	w := New(&config.HostShutdownConfig{Logind: true})
+	w.command = fakeCommands("echo 'Failed to inhibit: Access denied' >&2; exit 1", "true")
+	require.NoError(t, w.Start(context.Background(), nil))
+
+	err := w.Release()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Access denied")
+}
+
+// Test_Watcher_acpi tests that a power button press on the acpid socket is
+// delivered, and that an unreachable socket is reported.
+//
+// Params:
+//   - t: the testing context.
+func Test_Watcher_acpi(t *testing.T) {
+	t.Parallel()
+
+	socket := filepath.Join(t.TempDir(), "acpid.socket")
+	w := New(&config.HostShutdownConfig{ACPISocket: socket})
+	require.Error(t, w.Start(context.Background(), nil))
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+	go func() {
+		conn, err := listener.Accept()
+		// listener closed
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("button/lid LID close\nbutton/power PBTN 00000080 00000000\n"))
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notices := make(chan Source, 1)
+	w = New(&config.HostShutdownConfig{ACPISocket: socket})
+	require.NoError(t, w.Start(ctx, func(source Source) { notices <- source }))
+
+	select {
+	case source := <-notices:
+		assert.Equal(t, SourceACPI, source)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notice")
+	}
+}
@@ -0,0 +1,184 @@
+// Package hostpower gives the daemon advance notice of a host shutdown.
+package hostpower
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// inhibitBinary takes logind inhibitor locks.
+	inhibitBinary string = "systemd-inhibit"
+
+	// busctlBinary monitors the system bus.
+	busctlBinary string = "busctl"
+
+	// inhibitWho names the lock holder in systemd-inhibit --list.
+	inhibitWho string = "supervizio"
+
+	// inhibitWhy explains the lock in systemd-inhibit --list.
+	inhibitWhy string = "Stopping supervised services"
+
+	// prepareForShutdownMatch selects the logind shutdown announcements.
+	prepareForShutdownMatch string = "type='signal',sender='org.freedesktop.login1'," +
+		"interface='org.freedesktop.login1.Manager',member='PrepareForShutdown'"
+
+	// prepareForShutdown is the member of the announcement signal.
+	prepareForShutdown string = "PrepareForShutdown"
+
+	// releaseTimeout bounds the wait for the lock holder to exit.
+	releaseTimeout time.Duration = 5 * time.Second
+
+	// maxOutput bounds the holder output quoted in errors.
+	maxOutput int = 512
+)
+
+// inhibitor is a held delay lock: systemd-inhibit runs cat, which exits
+// when its standard input is closed.
+type inhibitor struct {
+	// cmd is the running systemd-inhibit.
+	cmd *exec.Cmd
+	// stdin keeps cat, and so the lock, alive.
+	stdin io.Closer
+	// stderr collects the diagnostics of systemd-inhibit.
+	stderr *bytes.Buffer
+	// done receives the exit of systemd-inhibit.
+	done chan error
+}
+
+// monitorMessage is the part of a busctl JSON message read by the watcher.
+type monitorMessage struct {
+	// Member is the signal name.
+	Member string `json:"member"`
+	// Payload holds the signal arguments.
+	Payload struct {
+		// Data are the argument values.
+		Data []any `json:"data"`
+	} `json:"payload"`
+}
+
+// startLogind holds a delay inhibitor lock, then monitors the logind
+// shutdown announcements.
+//
+// Params:
+//   - ctx: stops the monitor.
+//
+// Returns:
+//   - error: if the lock or the monitor could not be started.
+func (w *Watcher) startLogind(ctx context.Context) error {
+	lock, err := w.inhibit()
+	// logind or systemd-inhibit unavailable
+	if err != nil {
+		// return lock error
+		return err
+	}
+	w.mu.Lock()
+	w.lock = lock
+	w.mu.Unlock()
+
+	cmd := w.command(ctx, busctlBinary, "--system", "--json=short", "monitor", "--match="+prepareForShutdownMatch)
+	stdout, err := cmd.StdoutPipe()
+	// pipe creation failed
+	if err != nil {
+		// return pipe error
+		return fmt.Errorf("monitor logind: %w", err)
+	}
+	// start monitor
+	if err := cmd.Start(); err != nil {
+		// return start error
+		return fmt.Errorf("monitor logind: %w", err)
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		// read one message per line
+		for scanner.Scan() {
+			// notify on a shutdown about to happen
+			if shutdownAnnounced(scanner.Bytes()) {
+				w.notify(SourceLogind)
+			}
+		}
+		_ = cmd.Wait()
+	}()
+	// monitor started
+	return nil
+}
+
+// inhibit takes a delay lock on the host shutdown. The lock does not
+// depend on the daemon context: it is held until released.
+//
+// Returns:
+//   - *inhibitor: the held lock.
+//   - error: if systemd-inhibit could not be started.
+func (w *Watcher) inhibit() (*inhibitor, error) {
+	cmd := w.command(context.Background(), inhibitBinary,
+		"--what=shutdown", "--mode=delay", "--who="+inhibitWho, "--why="+inhibitWhy, "cat")
+	stdin, err := cmd.StdinPipe()
+	// pipe creation failed
+	if err != nil {
+		// return pipe error
+		return nil, fmt.Errorf("inhibit shutdown: %w", err)
+	}
+	lock := &inhibitor{cmd: cmd, stdin: stdin, stderr: &bytes.Buffer{}, done: make(chan error, 1)}
+	cmd.Stderr = lock.stderr
+	// start lock holder
+	if err := cmd.Start(); err != nil {
+		// return start error
+		return nil, fmt.Errorf("inhibit shutdown: %w", err)
+	}
+	go func() { lock.done <- cmd.Wait() }()
+	// return held lock
+	return lock, nil
+}
+
+// release ends the lock holder, killing it when it does not exit in time.
+//
+// Returns:
+//   - error: if the holder failed, with its diagnostics.
+func (l *inhibitor) release() error {
+	_ = l.stdin.Close()
+	var err error
+	select {
+	case err = <-l.done:
+	case <-time.After(releaseTimeout):
+		_ = l.cmd.Process.Kill()
+		err = <-l.done
+	}
+	// holder failed, e.g. logind unavailable
+	if err != nil {
+		out := strings.TrimSpace(l.stderr.String())
+		// keep the end of long diagnostics
+		if len(out) > maxOutput {
+			out = out[len(out)-maxOutput:]
+		}
+		// return error with diagnostics
+		return fmt.Errorf("%s: %w: %s", inhibitBinary, err, out)
+	}
+	// lock released
+	return nil
+}
+
+// shutdownAnnounced reports whether a busctl message announces a shutdown
+// about to happen. PrepareForShutdown(false) cancels an announcement.
+//
+// Params:
+//   - line: one busctl message, as JSON.
+//
+// Returns:
+//   - bool: true for PrepareForShutdown(true).
+func shutdownAnnounced(line []byte) bool {
+	var msg monitorMessage
+	// ignore lines that are not messages
+	if err := json.Unmarshal(line, &msg); err != nil {
+		// not an announcement
+		return false
+	}
+	// the only argument tells whether the shutdown starts
+	return msg.Member == prepareForShutdown && len(msg.Payload.Data) > 0 && msg.Payload.Data[0] == true
+}
@@ -0,0 +1,132 @@
+//go:build linux
+
+// Package inspect reads the kernel-side view of supervised processes.
+// This file reads the CPU times of a process and the clock tick rate.
+package inspect
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// auxvFile is the auxiliary vector the kernel handed to a process.
+	auxvFile string = "self/auxv"
+	// atClockTicks is AT_CLKTCK, the auxiliary vector entry holding CLK_TCK.
+	atClockTicks uint64 = 17
+	// wordBytes is the size of an auxiliary vector word.
+	wordBytes int = strconv.IntSize / 8
+)
+
+// CollectCPU reads the CPU times of a process from /proc/[pid]/stat.
+// The sample carries the start time of the process, telling a reused PID
+// apart, and the clock tick rate of its times.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - pid: the process ID.
+//
+// Returns:
+//   - domainmetrics.ProcessCPU: the CPU times, without usage percentages.
+//   - error: if the process is gone or its stat is malformed.
+func (i *Inspector) CollectCPU(ctx context.Context, pid int) (domainmetrics.ProcessCPU, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.ProcessCPU{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(i.procRoot, strconv.Itoa(pid), statFile))
+	// the process exited
+	if err != nil {
+		// return wrapped read error
+		return domainmetrics.ProcessCPU{}, process.WrapError("read stat", err)
+	}
+	name, fields, ok := splitStat(string(data))
+	// the line ends with the start time or later fields
+	if !ok || len(fields) <= statStartTime {
+		// return malformed stat error
+		return domainmetrics.ProcessCPU{}, ErrMalformedStat
+	}
+	// return the CPU times
+	return domainmetrics.ProcessCPU{
+		Timestamp:      time.Now(),
+		Name:           name,
+		PID:            pid,
+		User:           parseTicks(fields[statUtime]),
+		System:         parseTicks(fields[statStime]),
+		ChildrenUser:   parseTicks(fields[statCutime]),
+		ChildrenSystem: parseTicks(fields[statCstime]),
+		StartTime:      parseTicks(fields[statStartTime]),
+		ClockTicks:     i.clockTicks,
+	}, nil
+}
+
+// parseTicks parses a clock tick counter of /proc/[pid]/stat.
+//
+// Params:
+//   - field: the field.
+//
+// Returns:
+//   - uint64: the counter, zero when malformed.
+func parseTicks(field string) uint64 {
+	ticks, _ := strconv.ParseUint(field, shared.Base10, shared.BitSize64)
+	// return counter
+	return ticks
+}
+
+// detectClockTicks reads CLK_TCK from the auxiliary vector of the daemon,
+// as sysconf(_SC_CLK_TCK) does.
+//
+// Params:
+//   - procRoot: the procfs mount point.
+//
+// Returns:
+//   - uint64: the clock ticks per second, DefaultClockTicks when unreadable.
+func detectClockTicks(procRoot string) uint64 {
+	data, err := os.ReadFile(filepath.Join(procRoot, auxvFile))
+	// the vector is unreadable, fall back to USER_HZ
+	if err != nil {
+		// return default rate
+		return domainmetrics.DefaultClockTicks
+	}
+	// scan the key and value words of each entry
+	for off := 0; off+2*wordBytes <= len(data); off += 2 * wordBytes {
+		key := readWord(data[off:])
+		// the clock tick entry
+		if key == atClockTicks {
+			// a zero rate cannot convert times
+			if value := readWord(data[off+wordBytes:]); value > 0 {
+				// return detected rate
+				return value
+			}
+			break
+		}
+	}
+	// return default rate
+	return domainmetrics.DefaultClockTicks
+}
+
+// readWord reads a native auxiliary vector word.
+//
+// Params:
+//   - b: the bytes, at least one word long.
+//
+// Returns:
+//   - uint64: the word.
+func readWord(b []byte) uint64 {
+	// 32-bit platforms use 4-byte words
+	if wordBytes == 4 {
+		// return the widened word
+		return uint64(binary.NativeEndian.Uint32(b))
+	}
+	// return the word
+	return binary.NativeEndian.Uint64(b)
+}
@@ -0,0 +1,97 @@
+//go:build linux
+
+// Package inspect_test provides black-box tests for the inspect package.
+// It tests CPU time reading against fixture directories.
+package inspect_test
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/process/inspect"
+)
+
+// writeAuxv writes an auxiliary vector fixture holding AT_CLKTCK.
+//
+// Params:
+//   - t: the testing context
+//   - root: the procfs fixture root
+//   - hz: the clock ticks per second
+func writeAuxv(t *testing.T, root string, hz uint64) {
+	t.Helper()
+	word := strconv.IntSize / 8
+	data := make([]byte, 0, 6*word)
+	// AT_PAGESZ, AT_CLKTCK, then AT_NULL
+	for _, v := range []uint64{6, 4096, 17, hz, 0, 0} {
+		// native words of the platform
+		if word == 4 {
+			data = binary.NativeEndian.AppendUint32(data, uint32(v))
+			continue
+		}
+		data = binary.NativeEndian.AppendUint64(data, v)
+	}
+	writeFixture(t, filepath.Join(root, "self", "auxv"), string(data))
+}
+
+// TestInspector_CollectCPU tests that CPU times are read with the detected clock rate.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_CollectCPU(t *testing.T) {
+	root := t.TempDir()
+	writeAuxv(t, root, 250)
+	writeProcess(t, root, 42, "my (worker)", 1, 300, "worker\x00")
+
+	cpu, err := inspect.NewWithRoot(root).CollectCPU(context.Background(), 42)
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, cpu.PID)
+	assert.Equal(t, "my (worker)", cpu.Name)
+	assert.Equal(t, uint64(500), cpu.User)
+	assert.Equal(t, uint64(500), cpu.System)
+	assert.Equal(t, uint64(10000), cpu.StartTime)
+	assert.Equal(t, uint64(250), cpu.ClockTicks)
+	assert.False(t, cpu.Timestamp.IsZero())
+}
+
+// TestInspector_CollectCPU_errors tests missing and malformed processes.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_CollectCPU_errors(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "7", "stat"), "7 (truncated) S 1 7\n")
+	in := inspect.NewWithRoot(root)
+
+	_, err := in.CollectCPU(context.Background(), 99)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = in.CollectCPU(context.Background(), 7)
+	assert.ErrorIs(t, err, inspect.ErrMalformedStat)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = in.CollectCPU(ctx, 7)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestInspector_CollectCPU_Self tests reading the test process against the real procfs.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_CollectCPU_Self(t *testing.T) {
+	cpu, err := inspect.New().CollectCPU(context.Background(), os.Getpid())
+	require.NoError(t, err)
+
+	assert.Equal(t, os.Getpid(), cpu.PID)
+	assert.NotZero(t, cpu.StartTime)
+	assert.Equal(t, domainmetrics.DefaultClockTicks, cpu.TicksPerSecond())
+}
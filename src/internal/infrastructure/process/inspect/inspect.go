@@ -0,0 +1,55 @@
+// Package inspect reads the kernel-side view of supervised processes.
+// It resolves the cgroup of a process, its resource limits, its CPU
+// times, its memory attribution, the processes it spawned and the sockets
+// they hold from procfs.
+package inspect
+
+import (
+	"errors"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+)
+
+// defaultProcRoot is the default procfs mount point.
+const defaultProcRoot string = "/proc"
+
+// ErrMalformedStat indicates a /proc/[pid]/stat line that cannot be parsed.
+var ErrMalformedStat error = errors.New("malformed process stat")
+
+// Compile-time interface checks.
+var (
+	_ domain.Inspector    = (*Inspector)(nil)
+	_ domain.TreeReader   = (*Inspector)(nil)
+	_ domain.SocketReader = (*Inspector)(nil)
+)
+
+// Inspector reads process details from procfs.
+// It implements the domain process Inspector, TreeReader and SocketReader ports.
+type Inspector struct {
+	// procRoot is the procfs mount point.
+	procRoot string
+	// clockTicks is CLK_TCK, the unit of the CPU times in procfs.
+	clockTicks uint64
+}
+
+// New creates an inspector using the standard procfs mount point.
+//
+// Returns:
+//   - *Inspector: inspector bound to /proc.
+func New() *Inspector {
+	// use standard mount point
+	return NewWithRoot(defaultProcRoot)
+}
+
+// NewWithRoot creates an inspector with a custom procfs mount point.
+// This is primarily used for testing against fixture directories.
+//
+// Params:
+//   - procRoot: procfs mount point.
+//
+// Returns:
+//   - *Inspector: inspector bound to the given mount point.
+func NewWithRoot(procRoot string) *Inspector {
+	// construct inspector with provided root
+	return &Inspector{procRoot: procRoot, clockTicks: detectClockTicks(procRoot)}
+}
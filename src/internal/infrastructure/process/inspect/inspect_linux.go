@@ -0,0 +1,141 @@
+//go:build linux
+
+// Package inspect reads the kernel-side view of supervised processes.
+// This file contains the Linux procfs reader.
+package inspect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// cgroupFile is the per-process cgroup membership file.
+	cgroupFile string = "cgroup"
+	// limitsFile is the per-process resource limits file.
+	limitsFile string = "limits"
+
+	// cgroupLineFields is the number of fields in a /proc/[pid]/cgroup line.
+	cgroupLineFields int = 3
+	// cgroupV2HierarchyID is the hierarchy ID of the unified cgroup v2 entry.
+	cgroupV2HierarchyID string = "0"
+
+	// softLimitHeader starts the soft limit column of /proc/[pid]/limits.
+	softLimitHeader string = "Soft Limit"
+	// hardLimitHeader starts the hard limit column of /proc/[pid]/limits.
+	hardLimitHeader string = "Hard Limit"
+	// unitsHeader starts the units column of /proc/[pid]/limits.
+	unitsHeader string = "Units"
+)
+
+// Inspect reads the cgroup and resource limits of a process.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - pid: process ID to inspect.
+//
+// Returns:
+//   - domain.Inspection: the process cgroup and limits.
+//   - error: if either procfs file cannot be read.
+func (i *Inspector) Inspect(ctx context.Context, pid int) (domain.Inspection, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domain.Inspection{}, err
+	}
+	dir := filepath.Join(i.procRoot, strconv.Itoa(pid))
+
+	cgroupData, err := os.ReadFile(filepath.Join(dir, cgroupFile))
+	// fail when the cgroup file is unreadable
+	if err != nil {
+		// return wrapped read error
+		return domain.Inspection{}, process.WrapError("read process cgroup", err)
+	}
+	limitsData, err := os.ReadFile(filepath.Join(dir, limitsFile))
+	// fail when the limits file is unreadable
+	if err != nil {
+		// return wrapped read error
+		return domain.Inspection{}, process.WrapError("read process limits", err)
+	}
+
+	// return parsed details
+	return domain.Inspection{
+		CgroupPath: parseCgroupPath(string(cgroupData)),
+		Limits:     parseLimits(string(limitsData)),
+	}, nil
+}
+
+// parseCgroupPath extracts the cgroup path from /proc/[pid]/cgroup content.
+// The unified v2 entry is preferred; on v1 hosts the first hierarchy is used.
+//
+// Params:
+//   - content: content of /proc/[pid]/cgroup.
+//
+// Returns:
+//   - string: the cgroup path, empty when none is listed.
+func parseCgroupPath(content string) string {
+	var first string
+	// inspect each hierarchy line "id:controllers:path"
+	for line := range strings.Lines(content) {
+		fields := strings.SplitN(strings.TrimSpace(line), ":", cgroupLineFields)
+		// skip malformed lines
+		if len(fields) != cgroupLineFields {
+			continue
+		}
+		// unified hierarchy wins
+		if fields[0] == cgroupV2HierarchyID && fields[1] == "" {
+			// return v2 path
+			return fields[2]
+		}
+		// remember first v1 hierarchy
+		if first == "" {
+			first = fields[2]
+		}
+	}
+	// fall back to v1 path
+	return first
+}
+
+// parseLimits parses /proc/[pid]/limits content.
+// Columns are located from the header line because limit names contain spaces.
+//
+// Params:
+//   - content: content of /proc/[pid]/limits.
+//
+// Returns:
+//   - []domain.ResourceLimit: the parsed limits, nil for unexpected content.
+func parseLimits(content string) []domain.ResourceLimit {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	header := lines[0]
+	soft := strings.Index(header, softLimitHeader)
+	hard := strings.Index(header, hardLimitHeader)
+	units := strings.Index(header, unitsHeader)
+	// reject unknown layouts
+	if soft <= 0 || hard <= soft || units <= hard {
+		// no usable header
+		return nil
+	}
+
+	limits := make([]domain.ResourceLimit, 0, len(lines)-1)
+	// parse each limit row
+	for _, line := range lines[1:] {
+		// skip truncated rows
+		if len(line) <= hard {
+			continue
+		}
+		limits = append(limits, domain.ResourceLimit{
+			Name: strings.TrimSpace(line[:soft]),
+			Soft: strings.TrimSpace(line[soft:hard]),
+			Hard: strings.TrimSpace(line[hard:min(units, len(line))]),
+			Unit: strings.TrimSpace(line[min(units, len(line)):]),
+		})
+	}
+	// return parsed limits
+	return limits
+}
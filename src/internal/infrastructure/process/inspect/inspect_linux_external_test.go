@@ -0,0 +1,102 @@
+//go:build linux
+
+// Package inspect_test provides black-box tests for the inspect package.
+// It tests procfs inspection against fixture directories.
+package inspect_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/process/inspect"
+)
+
+// limitsFixture is a /proc/[pid]/limits excerpt.
+const limitsFixture string = `Limit                     Soft Limit           Hard Limit           Units     
+Max cpu time              unlimited            unlimited            seconds   
+Max open files            1024                 524288               files     
+Max nice priority         0                    0                    
+`
+
+// writeFixture writes a fixture file, creating parent directories.
+//
+// Params:
+//   - t: the testing context
+//   - path: the file path
+//   - content: the file content
+func writeFixture(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// TestInspector_Inspect tests cgroup and limits resolution.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_Inspect(t *testing.T) {
+	tests := []struct {
+		name       string
+		procCgroup string
+		wantCgroup string
+	}{
+		{
+			name:       "cgroup v2 unified hierarchy",
+			procCgroup: "0::/system.slice/app.service\n",
+			wantCgroup: "/system.slice/app.service",
+		},
+		{
+			name:       "cgroup v1 hierarchies",
+			procCgroup: "4:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n",
+			wantCgroup: "/docker/abc",
+		},
+		{
+			name:       "hybrid host prefers v2",
+			procCgroup: "3:cpu,cpuacct:/legacy\n0::/unified\n",
+			wantCgroup: "/unified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFixture(t, filepath.Join(root, "42", "cgroup"), tt.procCgroup)
+			writeFixture(t, filepath.Join(root, "42", "limits"), limitsFixture)
+
+			got, err := inspect.NewWithRoot(root).Inspect(context.Background(), 42)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCgroup, got.CgroupPath)
+			assert.Equal(t, []domain.ResourceLimit{
+				{Name: "Max cpu time", Soft: "unlimited", Hard: "unlimited", Unit: "seconds"},
+				{Name: "Max open files", Soft: "1024", Hard: "524288", Unit: "files"},
+				{Name: "Max nice priority", Soft: "0", Hard: "0", Unit: ""},
+			}, got.Limits)
+		})
+	}
+}
+
+// TestInspector_Inspect_MissingProcess tests the error for an unknown PID.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_Inspect_MissingProcess(t *testing.T) {
+	_, err := inspect.NewWithRoot(t.TempDir()).Inspect(context.Background(), 42)
+	assert.Error(t, err)
+}
+
+// TestInspector_Inspect_Self tests inspection of the test process itself.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_Inspect_Self(t *testing.T) {
+	got, err := inspect.New().Inspect(context.Background(), os.Getpid())
+	require.NoError(t, err)
+	assert.NotEmpty(t, got.Limits)
+}
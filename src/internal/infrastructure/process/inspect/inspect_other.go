@@ -0,0 +1,94 @@
+//go:build !linux
+
+// Package inspect reads the kernel-side view of supervised processes.
+package inspect
+
+import (
+	"context"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// Inspect is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - pid: process ID (unused).
+//
+// Returns:
+//   - domain.Inspection: empty inspection.
+//   - error: process.ErrNotSupported.
+func (i *Inspector) Inspect(_ context.Context, _ int) (domain.Inspection, error) {
+	// procfs is Linux-only
+	return domain.Inspection{}, process.ErrNotSupported
+}
+
+// ProcessTrees is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - roots: the root PIDs (unused).
+//
+// Returns:
+//   - map[int]domain.ProcessNode: nil.
+//   - error: process.ErrNotSupported.
+func (i *Inspector) ProcessTrees(_ context.Context, _ []int) (map[int]domain.ProcessNode, error) {
+	// procfs is Linux-only
+	return nil, process.ErrNotSupported
+}
+
+// Sockets is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - roots: the root PIDs (unused).
+//
+// Returns:
+//   - map[int][]domain.Socket: nil.
+//   - error: process.ErrNotSupported.
+func (i *Inspector) Sockets(_ context.Context, _ []int) (map[int][]domain.Socket, error) {
+	// procfs is Linux-only
+	return nil, process.ErrNotSupported
+}
+
+// CollectCPU is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - pid: process ID (unused).
+//
+// Returns:
+//   - domainmetrics.ProcessCPU: empty sample.
+//   - error: process.ErrNotSupported.
+func (i *Inspector) CollectCPU(_ context.Context, _ int) (domainmetrics.ProcessCPU, error) {
+	// procfs is Linux-only
+	return domainmetrics.ProcessCPU{}, process.ErrNotSupported
+}
+
+// CollectMemoryDetail is not supported outside Linux.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - pid: process ID (unused).
+//
+// Returns:
+//   - domainmetrics.MemoryDetail: empty sample.
+//   - error: process.ErrNotSupported.
+func (i *Inspector) CollectMemoryDetail(_ context.Context, _ int) (domainmetrics.MemoryDetail, error) {
+	// procfs is Linux-only
+	return domainmetrics.MemoryDetail{}, process.ErrNotSupported
+}
+
+// detectClockTicks returns USER_HZ outside Linux, where procfs is not read.
+//
+// Params:
+//   - procRoot: the procfs mount point (unused).
+//
+// Returns:
+//   - uint64: DefaultClockTicks.
+func detectClockTicks(_ string) uint64 {
+	// no procfs times to convert
+	return domainmetrics.DefaultClockTicks
+}
@@ -0,0 +1,117 @@
+//go:build linux
+
+// Package inspect reads the kernel-side view of supervised processes.
+// This file reads the memory attribution of a process.
+package inspect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// smapsRollupFile sums the memory mappings of a process (Linux 4.14+).
+	smapsRollupFile string = "smaps_rollup"
+	// kibibyte converts the kB values of smaps to bytes.
+	kibibyte uint64 = 1024
+)
+
+// CollectMemoryDetail reads the PSS, USS and swap of a process from
+// /proc/[pid]/smaps_rollup and its major page faults from /proc/[pid]/stat.
+// The kernel walks every mapping of the process to answer, so this costs
+// more than reading its RSS.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - pid: the process ID.
+//
+// Returns:
+//   - domainmetrics.MemoryDetail: the memory attribution.
+//   - error: if the process is gone, unreadable or its stat is malformed.
+func (i *Inspector) CollectMemoryDetail(ctx context.Context, pid int) (domainmetrics.MemoryDetail, error) {
+	// honor cancellation before touching the filesystem
+	if err := ctx.Err(); err != nil {
+		// return cancellation error
+		return domainmetrics.MemoryDetail{}, err
+	}
+	dir := filepath.Join(i.procRoot, strconv.Itoa(pid))
+	stat, err := os.ReadFile(filepath.Join(dir, statFile))
+	// the process exited
+	if err != nil {
+		// return wrapped read error
+		return domainmetrics.MemoryDetail{}, process.WrapError("read stat", err)
+	}
+	_, fields, ok := splitStat(string(stat))
+	// the line ends with the start time or later fields
+	if !ok || len(fields) <= statStartTime {
+		// return malformed stat error
+		return domainmetrics.MemoryDetail{}, ErrMalformedStat
+	}
+	rollup, err := os.ReadFile(filepath.Join(dir, smapsRollupFile))
+	// the process exited, or the kernel predates smaps_rollup
+	if err != nil {
+		// return wrapped read error
+		return domainmetrics.MemoryDetail{}, process.WrapError("read smaps_rollup", err)
+	}
+	detail := parseSmapsRollup(rollup)
+	detail.Timestamp = time.Now()
+	detail.PID = pid
+	detail.StartTime = parseTicks(fields[statStartTime])
+	detail.MajorFaults = parseTicks(fields[statMajflt])
+	// return the attribution
+	return detail, nil
+}
+
+// parseSmapsRollup parses the summed mappings of a process. Kernel threads
+// have no mappings and read as zero.
+//
+// Params:
+//   - data: the smaps_rollup content.
+//
+// Returns:
+//   - domainmetrics.MemoryDetail: the PSS, USS and swap in bytes.
+func parseSmapsRollup(data []byte) domainmetrics.MemoryDetail {
+	var detail domainmetrics.MemoryDetail
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// read "Key:   value kB" lines
+	for scanner.Scan() {
+		key, rest, found := strings.Cut(scanner.Text(), ":")
+		// skip the mapping header line
+		if !found {
+			continue
+		}
+		value, _, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		kb, err := strconv.ParseUint(value, shared.Base10, shared.BitSize64)
+		// skip lines without a size
+		if err != nil {
+			continue
+		}
+		// keep the attribution fields
+		switch key {
+		// proportional share of the resident pages
+		case "Pss":
+			detail.PSS = kb * kibibyte
+		// pages mapped by this process only
+		case "Private_Clean", "Private_Dirty":
+			detail.USS += kb * kibibyte
+		// swapped-out pages
+		case "Swap":
+			detail.Swap = kb * kibibyte
+		// proportional share of the swapped-out pages
+		case "SwapPss":
+			detail.SwapPSS = kb * kibibyte
+		}
+	}
+	// return parsed attribution
+	return detail
+}
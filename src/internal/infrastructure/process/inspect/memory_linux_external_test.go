@@ -0,0 +1,100 @@
+//go:build linux
+
+// Package inspect_test provides black-box tests for the inspect package.
+// It tests memory attribution reading against fixture directories.
+package inspect_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/inspect"
+)
+
+// smapsRollup is a smaps_rollup fixture of a process sharing its libraries.
+const smapsRollup string = `55d0c0a00000-7ffd7e5fe000 ---p 00000000 00:00 0                          [rollup]
+Rss:                4096 kB
+Pss:                1536 kB
+Pss_Anon:            512 kB
+Shared_Clean:       3072 kB
+Shared_Dirty:          0 kB
+Private_Clean:       256 kB
+Private_Dirty:       768 kB
+Referenced:         4096 kB
+Anonymous:           768 kB
+Swap:                128 kB
+SwapPss:              64 kB
+Locked:                0 kB
+`
+
+// TestInspector_CollectMemoryDetail tests that PSS, USS, swap and major faults are read.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_CollectMemoryDetail(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "42", "stat"),
+		"42 (my (worker)) S 1 42 42 0 -1 4194560 100 0 37 0 500 500 0 0 20 0 1 0 10000 1000000 300 18446744073709551615\n")
+	writeFixture(t, filepath.Join(root, "42", "smaps_rollup"), smapsRollup)
+
+	detail, err := inspect.NewWithRoot(root).CollectMemoryDetail(context.Background(), 42)
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, detail.PID)
+	assert.Equal(t, uint64(10000), detail.StartTime)
+	assert.Equal(t, uint64(37), detail.MajorFaults)
+	assert.Equal(t, uint64(1536*1024), detail.PSS)
+	assert.Equal(t, uint64((256+768)*1024), detail.USS)
+	assert.Equal(t, uint64(128*1024), detail.Swap)
+	assert.Equal(t, uint64(64*1024), detail.SwapPSS)
+	assert.False(t, detail.Timestamp.IsZero())
+}
+
+// TestInspector_CollectMemoryDetail_errors tests missing and malformed processes.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_CollectMemoryDetail_errors(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "7", "stat"), "7 (truncated) S 1 7\n")
+	writeProcess(t, root, 8, "old-kernel", 1, 10, "")
+	in := inspect.NewWithRoot(root)
+
+	_, err := in.CollectMemoryDetail(context.Background(), 99)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = in.CollectMemoryDetail(context.Background(), 7)
+	assert.ErrorIs(t, err, inspect.ErrMalformedStat)
+
+	_, err = in.CollectMemoryDetail(context.Background(), 8)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = in.CollectMemoryDetail(ctx, 8)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestInspector_CollectMemoryDetail_Self tests reading the test process against the real procfs.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_CollectMemoryDetail_Self(t *testing.T) {
+	// smaps_rollup appeared in Linux 4.14
+	if _, err := os.Stat("/proc/self/smaps_rollup"); err != nil {
+		t.Skip("smaps_rollup not available")
+	}
+
+	detail, err := inspect.New().CollectMemoryDetail(context.Background(), os.Getpid())
+	require.NoError(t, err)
+
+	assert.Equal(t, os.Getpid(), detail.PID)
+	assert.NotZero(t, detail.PSS)
+	assert.NotZero(t, detail.USS)
+	assert.LessOrEqual(t, detail.USS, detail.PSS)
+}
@@ -0,0 +1,356 @@
+//go:build linux
+
+// Package inspect reads the kernel-side view of supervised processes.
+// This file reads the TCP sockets held by supervised process trees.
+package inspect
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// fdDir is the per-process directory of open file descriptors.
+	fdDir string = "fd"
+	// netnsLink is the per-process link to its network namespace.
+	netnsLink string = "ns/net"
+	// socketLinkPrefix starts the fd link of a socket, followed by its inode.
+	socketLinkPrefix string = "socket:["
+	// tcpEstablished is the hex state of an established TCP connection.
+	tcpEstablished string = "01"
+	// tcpListen is the hex state of a listening TCP socket.
+	tcpListen string = "0A"
+	// ipv4HexLen is the hex length of an IPv4 address in the socket tables.
+	ipv4HexLen int = 8
+	// ipv6HexLen is the hex length of an IPv6 address in the socket tables.
+	ipv6HexLen int = 32
+	// addrWordLen is the byte length of each host-endian word of an address.
+	addrWordLen int = 4
+	// hexBase is the base of the ports in the socket tables.
+	hexBase int = 16
+	// portBitSize is the bit size of a port number.
+	portBitSize int = 16
+)
+
+// Fields of a line of /proc/[pid]/net/tcp.
+const (
+	// tcpLocal is the local address and port.
+	tcpLocal int = 1
+	// tcpRemote is the remote address and port.
+	tcpRemote int = 2
+	// tcpState is the connection state.
+	tcpState int = 3
+	// tcpInode is the socket inode.
+	tcpInode int = 9
+)
+
+// tcpTables are the socket tables of a network namespace, under /proc/[pid].
+var tcpTables []string = []string{"net/tcp", "net/tcp6"}
+
+// tcpEntry is a line of a TCP socket table.
+type tcpEntry struct {
+	// socket is the socket of the line.
+	socket domain.Socket
+	// inode is the socket inode.
+	inode uint64
+}
+
+// Sockets reads every process of procfs once and returns the listening
+// and established TCP sockets held by each root and its descendants,
+// following parent PIDs. Sockets are read from the network namespace of
+// each root, so services in their own namespace are covered.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - roots: the PIDs of the supervised processes.
+//
+// Returns:
+//   - map[int][]domain.Socket: the sockets of each root still running.
+//   - error: if procfs cannot be listed or the context is cancelled.
+func (i *Inspector) Sockets(ctx context.Context, roots []int) (map[int][]domain.Socket, error) {
+	entries, err := os.ReadDir(i.procRoot)
+	// fail when procfs is unreadable
+	if err != nil {
+		// return wrapped read error
+		return nil, process.WrapError("list processes", err)
+	}
+	alive := make(map[int]bool, len(entries))
+	children := make(map[int][]int, len(entries))
+	// link each process to its parent
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		// skip non-process entries
+		if err != nil {
+			continue
+		}
+		ppid, ok := i.readPPID(pid)
+		// skip processes gone since the listing
+		if !ok {
+			continue
+		}
+		alive[pid] = true
+		children[ppid] = append(children[ppid], pid)
+	}
+
+	tables := make(map[string][]tcpEntry)
+	sockets := make(map[int][]domain.Socket, len(roots))
+	// collect the sockets of each running root
+	for _, root := range roots {
+		// honor cancellation between services
+		if err := ctx.Err(); err != nil {
+			// return cancellation error
+			return nil, err
+		}
+		// skip roots that exited
+		if !alive[root] {
+			continue
+		}
+		inodes := make(map[uint64]bool)
+		// gather the socket inodes of the whole tree
+		for _, pid := range descendants(root, children) {
+			i.readSocketInodes(pid, inodes)
+		}
+		found := []domain.Socket{}
+		// keep the sockets of the tree
+		for _, entry := range i.namespaceTable(root, tables) {
+			// sockets of other processes
+			if !inodes[entry.inode] {
+				continue
+			}
+			found = append(found, entry.socket)
+		}
+		sockets[root] = found
+	}
+	// return the sockets
+	return sockets, nil
+}
+
+// descendants lists a process and every process under it.
+//
+// Params:
+//   - root: the process ID.
+//   - children: the child PIDs of each process.
+//
+// Returns:
+//   - []int: the root followed by its descendants.
+func descendants(root int, children map[int][]int) []int {
+	seen := map[int]bool{root: true}
+	pids := []int{root}
+	// walk the tree breadth first
+	for next := 0; next < len(pids); next++ {
+		// queue each child not yet seen, guarding against PID reuse loops
+		for _, child := range children[pids[next]] {
+			// skip loops
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			pids = append(pids, child)
+		}
+	}
+	// return the tree
+	return pids
+}
+
+// readPPID reads the parent PID of a process.
+//
+// Params:
+//   - pid: the process ID.
+//
+// Returns:
+//   - int: the parent PID.
+//   - bool: false when the process is gone or its stat is malformed.
+func (i *Inspector) readPPID(pid int) (int, bool) {
+	data, err := os.ReadFile(filepath.Join(i.procRoot, strconv.Itoa(pid), statFile))
+	// the process exited
+	if err != nil {
+		// skip the process
+		return 0, false
+	}
+	line := string(data)
+	end := strings.LastIndexByte(line, ')')
+	// the command name is enclosed in parentheses and may hold any character
+	if end < 0 {
+		// skip the malformed line
+		return 0, false
+	}
+	fields := strings.Fields(line[end+1:])
+	// the line holds the state and the parent PID
+	if len(fields) <= statPPID {
+		// skip the truncated line
+		return 0, false
+	}
+	ppid, err := strconv.Atoi(fields[statPPID])
+	// return parent PID
+	return ppid, err == nil
+}
+
+// readSocketInodes adds the inodes of the sockets a process holds open.
+//
+// Params:
+//   - pid: the process ID.
+//   - inodes: the inodes collected so far.
+func (i *Inspector) readSocketInodes(pid int, inodes map[uint64]bool) {
+	dir := filepath.Join(i.procRoot, strconv.Itoa(pid), fdDir)
+	fds, err := os.ReadDir(dir)
+	// the process exited, or its descriptors are not readable
+	if err != nil {
+		// no sockets
+		return
+	}
+	// resolve each descriptor
+	for _, fd := range fds {
+		link, err := os.Readlink(filepath.Join(dir, fd.Name()))
+		inode, found := strings.CutPrefix(link, socketLinkPrefix)
+		// the descriptor closed or is not a socket
+		if err != nil || !found {
+			continue
+		}
+		// record the socket inode
+		if value, err := strconv.ParseUint(strings.TrimSuffix(inode, "]"), shared.Base10, shared.BitSize64); err == nil {
+			inodes[value] = true
+		}
+	}
+}
+
+// namespaceTable returns the TCP sockets of the network namespace of a
+// process, read once per namespace.
+//
+// Params:
+//   - pid: the process ID.
+//   - tables: the tables read so far, by namespace.
+//
+// Returns:
+//   - []tcpEntry: the listening and established sockets of the namespace.
+func (i *Inspector) namespaceTable(pid int, tables map[string][]tcpEntry) []tcpEntry {
+	dir := filepath.Join(i.procRoot, strconv.Itoa(pid))
+	namespace, err := os.Readlink(filepath.Join(dir, netnsLink))
+	// without a readable namespace link, read the tables of the process
+	if err != nil {
+		namespace = dir
+	}
+	// reuse the tables of the namespace
+	if entries, ok := tables[namespace]; ok {
+		// return cached tables
+		return entries
+	}
+	var entries []tcpEntry
+	// read IPv4 and IPv6 sockets
+	for _, table := range tcpTables {
+		entries = append(entries, readTCPTable(filepath.Join(dir, table))...)
+	}
+	tables[namespace] = entries
+	// return the tables
+	return entries
+}
+
+// readTCPTable reads the listening and established sockets of a socket table.
+//
+// Params:
+//   - path: the socket table.
+//
+// Returns:
+//   - []tcpEntry: the sockets, nil when the table is unreadable.
+func readTCPTable(path string) []tcpEntry {
+	file, err := os.Open(path) // #nosec G304 - path is under procfs
+	// IPv6 may be disabled
+	if err != nil {
+		// no sockets
+		return nil
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []tcpEntry
+	scanner := bufio.NewScanner(file)
+	// skip the header
+	scanner.Scan()
+	// parse each socket
+	for scanner.Scan() {
+		// keep well-formed sockets
+		if entry, ok := parseTCPLine(scanner.Text()); ok {
+			entries = append(entries, entry)
+		}
+	}
+	// return the sockets
+	return entries
+}
+
+// parseTCPLine parses a line of a socket table.
+//
+// Params:
+//   - line: the line.
+//
+// Returns:
+//   - tcpEntry: the socket.
+//   - bool: false for malformed lines and states other than listening or established.
+func parseTCPLine(line string) (tcpEntry, bool) {
+	fields := strings.Fields(line)
+	// the line ends with the inode or later fields
+	if len(fields) <= tcpInode {
+		// skip the truncated line
+		return tcpEntry{}, false
+	}
+	state := fields[tcpState]
+	// only connections and listeners tell who talks to whom
+	if state != tcpEstablished && state != tcpListen {
+		// skip closing sockets
+		return tcpEntry{}, false
+	}
+	local, okLocal := parseSocketAddr(fields[tcpLocal])
+	remote, okRemote := parseSocketAddr(fields[tcpRemote])
+	inode, err := strconv.ParseUint(fields[tcpInode], shared.Base10, shared.BitSize64)
+	// addresses and inode must parse
+	if !okLocal || !okRemote || err != nil {
+		// skip the malformed line
+		return tcpEntry{}, false
+	}
+	socket := domain.Socket{Local: local, Listening: state == tcpListen}
+	// listeners have no peer
+	if !socket.Listening {
+		socket.Remote = remote
+	}
+	// return the socket
+	return tcpEntry{socket: socket, inode: inode}, true
+}
+
+// parseSocketAddr parses an address of a socket table: the address as
+// host-endian 32-bit words, a colon, then the port, in hex.
+//
+// Params:
+//   - field: the address field.
+//
+// Returns:
+//   - netip.AddrPort: the address and port, IPv4-mapped addresses unmapped.
+//   - bool: false when malformed.
+func parseSocketAddr(field string) (netip.AddrPort, bool) {
+	addrHex, portHex, found := strings.Cut(field, ":")
+	// an address is followed by its port
+	if !found || (len(addrHex) != ipv4HexLen && len(addrHex) != ipv6HexLen) {
+		// malformed address
+		return netip.AddrPort{}, false
+	}
+	raw, err := hex.DecodeString(addrHex)
+	port, portErr := strconv.ParseUint(portHex, hexBase, portBitSize)
+	// both parts are hex
+	if err != nil || portErr != nil {
+		// malformed address
+		return netip.AddrPort{}, false
+	}
+	// the kernel writes each word in host order, little-endian here
+	for word := 0; word < len(raw); word += addrWordLen {
+		raw[word], raw[word+1], raw[word+2], raw[word+3] = raw[word+3], raw[word+2], raw[word+1], raw[word]
+	}
+	addr, _ := netip.AddrFromSlice(raw)
+	// return the address, dual-stack sockets reported as IPv4
+	return netip.AddrPortFrom(addr.Unmap(), uint16(port)), true
+}
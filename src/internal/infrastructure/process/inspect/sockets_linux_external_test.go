@@ -0,0 +1,101 @@
+//go:build linux
+
+// Package inspect_test provides black-box tests for the inspect package.
+// It tests socket reading against fixture directories.
+package inspect_test
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/process/inspect"
+)
+
+// tcpHeader is the header line of the socket tables.
+const tcpHeader string = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"
+
+// tcpLine formats a line of a socket table.
+//
+// Params:
+//   - local: the local address, in table format.
+//   - remote: the remote address, in table format.
+//   - state: the hex connection state.
+//   - inode: the socket inode.
+//
+// Returns:
+//   - string: the line.
+func tcpLine(local, remote, state string, inode int) string {
+	return fmt.Sprintf("   0: %s %s %s 00000000:00000000 00:00000000 00000000     0        0 %d 1 0000000000000000 100 0 0 10 0\n",
+		local, remote, state, inode)
+}
+
+// writeLink creates a symbolic link fixture.
+//
+// Params:
+//   - t: the testing context
+//   - path: the link path
+//   - target: the link target
+func writeLink(t *testing.T, path, target string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.Symlink(target, path))
+}
+
+// TestInspector_Sockets tests the sockets collected over each process tree.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_Sockets(t *testing.T) {
+	root := t.TempDir()
+	writeProcess(t, root, 1, "supervizio", 0, 100, "supervizio\x00")
+	writeProcess(t, root, 10, "nginx", 1, 200, "nginx\x00")
+	writeProcess(t, root, 12, "nginx: worker", 10, 50, "nginx: worker process\x00")
+	writeProcess(t, root, 20, "api", 1, 300, "api\x00")
+	writeLink(t, filepath.Join(root, "10", "ns", "net"), "net:[4026531840]")
+	writeLink(t, filepath.Join(root, "20", "ns", "net"), "net:[4026531840]")
+	writeLink(t, filepath.Join(root, "10", "fd", "3"), "socket:[100]")
+	writeLink(t, filepath.Join(root, "12", "fd", "4"), "socket:[101]")
+	writeLink(t, filepath.Join(root, "12", "fd", "5"), "/var/log/nginx/access.log")
+	writeLink(t, filepath.Join(root, "20", "fd", "3"), "socket:[200]")
+	writeLink(t, filepath.Join(root, "20", "fd", "4"), "socket:[201]")
+	writeLink(t, filepath.Join(root, "20", "fd", "5"), "socket:[202]")
+	// The tables of the shared namespace are read through the first root.
+	writeFixture(t, filepath.Join(root, "10", "net", "tcp"), tcpHeader+
+		tcpLine("00000000:0050", "00000000:0000", "0A", 100)+
+		tcpLine("0100007F:0050", "0100007F:9C40", "01", 101)+
+		tcpLine("0100007F:9C40", "0100007F:0050", "01", 200)+
+		tcpLine("0100007F:9C41", "0100007F:0050", "06", 202))
+	writeFixture(t, filepath.Join(root, "10", "net", "tcp6"), tcpHeader+
+		tcpLine("0000000000000000FFFF00000200000A:C350", "0000000000000000FFFF00000500000A:1538", "01", 201))
+
+	sockets, err := inspect.NewWithRoot(root).Sockets(context.Background(), []int{10, 20, 99})
+	require.NoError(t, err)
+	require.Len(t, sockets, 2)
+
+	assert.Equal(t, []domain.Socket{
+		{Local: netip.MustParseAddrPort("0.0.0.0:80"), Listening: true},
+		{Local: netip.MustParseAddrPort("127.0.0.1:80"), Remote: netip.MustParseAddrPort("127.0.0.1:40000")},
+	}, sockets[10])
+	// The closing socket is left out, the dual-stack one is unmapped.
+	assert.Equal(t, []domain.Socket{
+		{Local: netip.MustParseAddrPort("127.0.0.1:40000"), Remote: netip.MustParseAddrPort("127.0.0.1:80")},
+		{Local: netip.MustParseAddrPort("10.0.0.2:50000"), Remote: netip.MustParseAddrPort("10.0.0.5:5432")},
+	}, sockets[20])
+}
+
+// TestInspector_Sockets_unreadable tests that an unreadable procfs is reported.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_Sockets_unreadable(t *testing.T) {
+	_, err := inspect.NewWithRoot(filepath.Join(t.TempDir(), "missing")).Sockets(context.Background(), []int{1})
+	assert.Error(t, err)
+}
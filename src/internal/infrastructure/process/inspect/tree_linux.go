@@ -0,0 +1,268 @@
+//go:build linux
+
+// Package inspect reads the kernel-side view of supervised processes.
+// This file builds the process trees under supervised processes.
+package inspect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	domainmetrics "github.com/kodflow/daemon/internal/domain/metrics"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+const (
+	// statFile is the per-process status line.
+	statFile string = "stat"
+	// cmdlineFile is the per-process command line, NUL-separated.
+	cmdlineFile string = "cmdline"
+	// uptimeFile is the system uptime in seconds.
+	uptimeFile string = "uptime"
+	// percentMultiplier converts a ratio to a percentage.
+	percentMultiplier float64 = 100
+)
+
+// Fields of /proc/[pid]/stat counted after the command name.
+const (
+	// statState is the state letter.
+	statState int = iota
+	// statPPID is the parent PID.
+	statPPID
+)
+
+// Later fields of /proc/[pid]/stat counted after the command name.
+const (
+	// statMajflt is the number of major page faults.
+	statMajflt int = 9
+	// statUtime is the user CPU time in clock ticks.
+	statUtime int = 11
+	// statStime is the system CPU time in clock ticks.
+	statStime int = 12
+	// statCutime is the user CPU time of waited-for children in clock ticks.
+	statCutime int = 13
+	// statCstime is the system CPU time of waited-for children in clock ticks.
+	statCstime int = 14
+	// statStartTime is the start time in clock ticks after boot.
+	statStartTime int = 19
+	// statRSS is the resident set size in pages.
+	statRSS int = 21
+)
+
+// procStat is a process as read from /proc/[pid]/stat.
+type procStat struct {
+	// node holds the fields reported for the process.
+	node domain.ProcessNode
+	// ppid is the parent PID.
+	ppid int
+}
+
+// ProcessTrees reads every process of procfs once and returns the tree
+// under each root, following parent PIDs. Processes that exit during the
+// scan are left out.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - roots: the PIDs of the supervised processes.
+//
+// Returns:
+//   - map[int]domain.ProcessNode: the tree of each root still running.
+//   - error: if procfs cannot be listed or the context is cancelled.
+func (i *Inspector) ProcessTrees(ctx context.Context, roots []int) (map[int]domain.ProcessNode, error) {
+	entries, err := os.ReadDir(i.procRoot)
+	// fail when procfs is unreadable
+	if err != nil {
+		// return wrapped read error
+		return nil, process.WrapError("list processes", err)
+	}
+	uptime := i.readUptime()
+	pageSize := uint64(os.Getpagesize())
+	stats := make(map[int]procStat, len(entries))
+	children := make(map[int][]int, len(entries))
+	// read each process directory
+	for _, entry := range entries {
+		// honor cancellation between processes
+		if err := ctx.Err(); err != nil {
+			// return cancellation error
+			return nil, err
+		}
+		pid, err := strconv.Atoi(entry.Name())
+		// skip non-process entries
+		if err != nil {
+			continue
+		}
+		stat, ok := i.readStat(pid, uptime, pageSize)
+		// skip processes gone since the listing
+		if !ok {
+			continue
+		}
+		stats[pid] = stat
+		children[stat.ppid] = append(children[stat.ppid], pid)
+	}
+
+	trees := make(map[int]domain.ProcessNode, len(roots))
+	// assemble the tree of each running root
+	for _, root := range roots {
+		// skip roots that exited
+		if _, ok := stats[root]; !ok {
+			continue
+		}
+		trees[root] = buildNode(root, stats, children, map[int]bool{})
+	}
+	// return the trees
+	return trees, nil
+}
+
+// buildNode assembles the tree under a process.
+//
+// Params:
+//   - pid: the process ID.
+//   - stats: every process read.
+//   - children: the child PIDs of each process.
+//   - seen: the processes already placed, guarding against PID reuse loops.
+//
+// Returns:
+//   - domain.ProcessNode: the process and its descendants.
+func buildNode(pid int, stats map[int]procStat, children map[int][]int, seen map[int]bool) domain.ProcessNode {
+	seen[pid] = true
+	node := stats[pid].node
+	kids := slices.Clone(children[pid])
+	slices.Sort(kids)
+	// attach each child not yet placed
+	for _, child := range kids {
+		// skip loops
+		if seen[child] {
+			continue
+		}
+		node.Children = append(node.Children, buildNode(child, stats, children, seen))
+	}
+	// return the subtree
+	return node
+}
+
+// readStat reads the status and command line of a process.
+//
+// Params:
+//   - pid: the process ID.
+//   - uptime: the system uptime, zero when unknown.
+//   - pageSize: the memory page size in bytes.
+//
+// Returns:
+//   - procStat: the process.
+//   - bool: false when the process is gone or its stat is malformed.
+func (i *Inspector) readStat(pid int, uptime time.Duration, pageSize uint64) (procStat, bool) {
+	dir := filepath.Join(i.procRoot, strconv.Itoa(pid))
+	data, err := os.ReadFile(filepath.Join(dir, statFile))
+	// the process exited
+	if err != nil {
+		// skip the process
+		return procStat{}, false
+	}
+	name, fields, ok := splitStat(string(data))
+	// the line ends with the resident set size or later fields
+	if !ok || len(fields) <= statRSS {
+		// skip the malformed line
+		return procStat{}, false
+	}
+	ppid, _ := strconv.Atoi(fields[statPPID])
+	utime, _ := strconv.ParseUint(fields[statUtime], shared.Base10, shared.BitSize64)
+	stime, _ := strconv.ParseUint(fields[statStime], shared.Base10, shared.BitSize64)
+	start, _ := strconv.ParseUint(fields[statStartTime], shared.Base10, shared.BitSize64)
+	rss, _ := strconv.ParseUint(fields[statRSS], shared.Base10, shared.BitSize64)
+	cpu := i.ticksToDuration(utime + stime)
+	node := domain.ProcessNode{
+		PID:     pid,
+		Name:    name,
+		Cmdline: readCmdline(filepath.Join(dir, cmdlineFile)),
+		State:   fields[statState],
+		RSS:     rss * pageSize,
+		CPUTime: cpu,
+	}
+	// average the CPU time over the lifetime, like ps
+	if lifetime := uptime - i.ticksToDuration(start); uptime > 0 && lifetime > 0 {
+		node.CPUPercent = cpu.Seconds() / lifetime.Seconds() * percentMultiplier
+	}
+	// return the process
+	return procStat{node: node, ppid: ppid}, true
+}
+
+// splitStat splits a /proc/[pid]/stat line around the command name, which
+// is enclosed in parentheses and may hold any character.
+//
+// Params:
+//   - line: the stat line.
+//
+// Returns:
+//   - string: the command name.
+//   - []string: the fields after the command name, the state first.
+//   - bool: false when the line is malformed.
+func splitStat(line string) (string, []string, bool) {
+	open, end := strings.IndexByte(line, '('), strings.LastIndexByte(line, ')')
+	// the last parenthesis closes the command name
+	if open < 0 || end < open {
+		// malformed line
+		return "", nil, false
+	}
+	// return name and fields
+	return line[open+1 : end], strings.Fields(line[end+1:]), true
+}
+
+// readCmdline reads the command line of a process.
+//
+// Params:
+//   - path: the cmdline file.
+//
+// Returns:
+//   - string: the arguments separated by spaces, empty when unreadable.
+func readCmdline(path string) string {
+	data, err := os.ReadFile(path) // #nosec G304 - path is under procfs
+	// kernel threads and exited processes have none
+	if err != nil {
+		// no command line
+		return ""
+	}
+	// return space-separated arguments
+	return strings.TrimSpace(strings.ReplaceAll(string(data), "\x00", " "))
+}
+
+// readUptime reads the system uptime.
+//
+// Returns:
+//   - time.Duration: the uptime, zero when unreadable.
+func (i *Inspector) readUptime() time.Duration {
+	data, err := os.ReadFile(filepath.Join(i.procRoot, uptimeFile))
+	// uptime is only needed for CPU percentages
+	if err != nil {
+		// unknown uptime
+		return 0
+	}
+	fields := strings.Fields(string(data))
+	// the first field is the uptime in seconds
+	if len(fields) == 0 {
+		// unknown uptime
+		return 0
+	}
+	seconds, _ := strconv.ParseFloat(fields[0], shared.BitSize64)
+	// return uptime
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// ticksToDuration converts clock ticks to a duration.
+//
+// Params:
+//   - ticks: the clock ticks.
+//
+// Returns:
+//   - time.Duration: the duration.
+func (i *Inspector) ticksToDuration(ticks uint64) time.Duration {
+	sample := domainmetrics.ProcessCPU{ClockTicks: i.clockTicks}
+	// return ticks in wall time
+	return sample.TicksToDuration(ticks)
+}
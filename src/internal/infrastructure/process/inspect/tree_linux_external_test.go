@@ -0,0 +1,96 @@
+//go:build linux
+
+// Package inspect_test provides black-box tests for the inspect package.
+// It tests process tree reading against fixture directories.
+package inspect_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/inspect"
+)
+
+// writeProcess writes the stat and cmdline fixtures of a process.
+// The process started 100 seconds after boot and used 5s of user and
+// 5s of system CPU time.
+//
+// Params:
+//   - t: the testing context
+//   - root: the procfs fixture root
+//   - pid: the process ID
+//   - comm: the command name
+//   - ppid: the parent PID
+//   - rssPages: the resident set size in pages
+//   - cmdline: the NUL-separated command line
+func writeProcess(t *testing.T, root string, pid int, comm string, ppid, rssPages int, cmdline string) {
+	t.Helper()
+	stat := fmt.Sprintf("%d (%s) S %d %d %d 0 -1 4194560 100 0 0 0 500 500 0 0 20 0 1 0 10000 1000000 %d 18446744073709551615\n",
+		pid, comm, ppid, pid, pid, rssPages)
+	writeFixture(t, filepath.Join(root, fmt.Sprint(pid), "stat"), stat)
+	writeFixture(t, filepath.Join(root, fmt.Sprint(pid), "cmdline"), cmdline)
+}
+
+// TestInspector_ProcessTrees tests the trees assembled from parent PIDs.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_ProcessTrees(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "uptime"), "200.00 350.00\n")
+	writeFixture(t, filepath.Join(root, "self", "stat"), "")
+	writeProcess(t, root, 1, "supervizio", 0, 100, "supervizio\x00")
+	writeProcess(t, root, 10, "nginx", 1, 200, "nginx\x00-g\x00daemon off;\x00")
+	writeProcess(t, root, 12, "nginx: worker", 10, 50, "nginx: worker process\x00")
+	writeProcess(t, root, 11, "sh", 10, 10, "sh\x00-c\x00sleep 5\x00")
+	writeProcess(t, root, 13, "sleep", 11, 5, "")
+	writeProcess(t, root, 20, "redis (main)", 1, 300, "redis-server\x00")
+
+	trees, err := inspect.NewWithRoot(root).ProcessTrees(context.Background(), []int{10, 20, 99})
+	require.NoError(t, err)
+	require.Len(t, trees, 2)
+
+	nginx := trees[10]
+	page := uint64(os.Getpagesize())
+	assert.Equal(t, "nginx", nginx.Name)
+	assert.Equal(t, "nginx -g daemon off;", nginx.Cmdline)
+	assert.Equal(t, "S", nginx.State)
+	assert.Equal(t, 200*page, nginx.RSS)
+	assert.Equal(t, 10*time.Second, nginx.CPUTime)
+	assert.InDelta(t, 10.0, nginx.CPUPercent, 0.01)
+	assert.Equal(t, 4, nginx.Size())
+	require.Len(t, nginx.Children, 2)
+	assert.Equal(t, 11, nginx.Children[0].PID)
+	assert.Equal(t, 13, nginx.Children[0].Children[0].PID)
+	assert.Empty(t, nginx.Children[0].Children[0].Cmdline)
+	assert.Equal(t, "nginx: worker", nginx.Children[1].Name)
+
+	assert.Equal(t, "redis (main)", trees[20].Name)
+	assert.Empty(t, trees[20].Children)
+}
+
+// TestInspector_ProcessTrees_MissingProcfs tests the error for an unreadable procfs.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_ProcessTrees_MissingProcfs(t *testing.T) {
+	_, err := inspect.NewWithRoot(filepath.Join(t.TempDir(), "missing")).ProcessTrees(context.Background(), []int{1})
+	assert.Error(t, err)
+}
+
+// TestInspector_ProcessTrees_Self tests the tree of the test process itself.
+//
+// Params:
+//   - t: the testing context
+func TestInspector_ProcessTrees_Self(t *testing.T) {
+	trees, err := inspect.New().ProcessTrees(context.Background(), []int{os.Getpid()})
+	require.NoError(t, err)
+	assert.Positive(t, trees[os.Getpid()].RSS)
+}
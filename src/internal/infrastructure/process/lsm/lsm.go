@@ -0,0 +1,76 @@
+// Package lsm assigns Linux Security Module labels to supervised processes.
+// A service may run under an SELinux context (setexeccon) or an AppArmor
+// profile (aa_change_onexec); the label is armed on the thread forking the
+// process and takes effect when the process executes its command.
+package lsm
+
+import "errors"
+
+const (
+	// defaultProcRoot is the default procfs mount point.
+	defaultProcRoot string = "/proc"
+
+	// defaultSysRoot is the default sysfs mount point.
+	defaultSysRoot string = "/sys"
+)
+
+var (
+	// ErrSELinuxDisabled indicates an SELinux context on a host without SELinux.
+	ErrSELinuxDisabled error = errors.New("selinux is not enabled on this host")
+
+	// ErrSELinuxContextInvalid indicates a context unknown to the loaded SELinux policy.
+	ErrSELinuxContextInvalid error = errors.New("selinux context is not valid in the loaded policy")
+
+	// ErrAppArmorDisabled indicates an AppArmor profile on a host without AppArmor.
+	ErrAppArmorDisabled error = errors.New("apparmor is not enabled on this host")
+
+	// ErrAppArmorProfileMissing indicates an AppArmor profile that is not loaded.
+	ErrAppArmorProfileMissing error = errors.New("apparmor profile is not loaded")
+)
+
+// Label is the security label a process executes under.
+type Label struct {
+	// SELinux is the SELinux context (user:role:type[:level]).
+	SELinux string
+	// AppArmor is the AppArmor profile name.
+	AppArmor string
+}
+
+// IsZero reports whether no label is set.
+//
+// Returns:
+//   - bool: true if the process keeps the daemon label.
+func (l Label) IsZero() bool {
+	// no LSM label requested
+	return l.SELinux == "" && l.AppArmor == ""
+}
+
+// Labeler arms security labels before processes are started.
+type Labeler struct {
+	// procRoot is the procfs mount point.
+	procRoot string
+	// sysRoot is the sysfs mount point.
+	sysRoot string
+}
+
+// New creates a labeler using the standard mount points.
+//
+// Returns:
+//   - *Labeler: labeler bound to /proc and /sys.
+func New() *Labeler {
+	// use standard mount points
+	return NewWithRoots(defaultProcRoot, defaultSysRoot)
+}
+
+// NewWithRoots creates a labeler using custom mount points.
+//
+// Params:
+//   - procRoot: the procfs mount point.
+//   - sysRoot: the sysfs mount point.
+//
+// Returns:
+//   - *Labeler: labeler bound to the given roots.
+func NewWithRoots(procRoot, sysRoot string) *Labeler {
+	// return labeler bound to roots
+	return &Labeler{procRoot: procRoot, sysRoot: sysRoot}
+}
@@ -0,0 +1,216 @@
+//go:build linux
+
+// Package lsm assigns Linux Security Module labels to supervised processes.
+package lsm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Check verifies that the LSM of each label is active and knows the label.
+//
+// Params:
+//   - label: the label to check.
+//
+// Returns:
+//   - error: why the label cannot be applied, nil if it can.
+func (l *Labeler) Check(label Label) error {
+	// check the SELinux context
+	if label.SELinux != "" {
+		// SELinux must be enabled
+		if !l.SELinuxEnabled() {
+			// return disabled error
+			return ErrSELinuxDisabled
+		}
+		// the loaded policy must know the context
+		if err := l.checkSELinuxContext(label.SELinux); err != nil {
+			// propagate validation error
+			return err
+		}
+	}
+	// check the AppArmor profile
+	if label.AppArmor != "" {
+		// AppArmor must be enabled
+		if !l.AppArmorEnabled() {
+			// return disabled error
+			return ErrAppArmorDisabled
+		}
+		// the profile must be loaded
+		if loaded, known := l.appArmorProfileLoaded(label.AppArmor); known && !loaded {
+			// return missing profile error
+			return fmt.Errorf("%w: %q", ErrAppArmorProfileMissing, label.AppArmor)
+		}
+	}
+	// label can be applied
+	return nil
+}
+
+// SELinuxEnabled reports whether SELinux is active (selinuxfs mounted).
+//
+// Returns:
+//   - bool: true if SELinux is enabled.
+func (l *Labeler) SELinuxEnabled() bool {
+	_, err := os.Stat(filepath.Join(l.sysRoot, "fs", "selinux", "enforce"))
+	// selinuxfs exposes enforce when SELinux is active
+	return err == nil
+}
+
+// AppArmorEnabled reports whether AppArmor is active.
+//
+// Returns:
+//   - bool: true if AppArmor is enabled.
+func (l *Labeler) AppArmorEnabled() bool {
+	data, err := os.ReadFile(filepath.Join(l.sysRoot, "module", "apparmor", "parameters", "enabled"))
+	// the module reports Y when enabled
+	return err == nil && strings.TrimSpace(string(data)) == "Y"
+}
+
+// Start calls start with the label armed for the next exec. The label is
+// armed on a dedicated OS thread that is discarded afterwards, so no other
+// goroutine ever runs with it.
+//
+// Params:
+//   - label: the label to apply; a zero label calls start directly.
+//   - start: forks and executes the process (e.g. exec.Cmd.Start).
+//
+// Returns:
+//   - error: the labeling or start error.
+func (l *Labeler) Start(label Label, start func() error) error {
+	// nothing to arm
+	if label.IsZero() {
+		// start unlabeled
+		return start()
+	}
+	// fail with a clear reason before touching the thread
+	if err := l.Check(label); err != nil {
+		// propagate check error
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		// The thread is never unlocked: it terminates with the goroutine,
+		// taking the armed label with it.
+		runtime.LockOSThread()
+		// Arm the label, then fork from this thread.
+		if err := l.arm(label); err != nil {
+			done <- err
+			return
+		}
+		done <- start()
+	}()
+	// return start outcome
+	return <-done
+}
+
+// arm writes the label to the exec attribute of the current thread.
+// Must be called with the OS thread locked.
+//
+// Params:
+//   - label: the label to arm.
+//
+// Returns:
+//   - error: if the attribute cannot be written.
+func (l *Labeler) arm(label Label) error {
+	// arm the SELinux exec context
+	if label.SELinux != "" {
+		// setexeccon
+		if err := os.WriteFile(l.threadAttr("exec"), []byte(label.SELinux), 0); err != nil {
+			// return error with context
+			return fmt.Errorf("setting selinux exec context %q: %w", label.SELinux, err)
+		}
+	}
+	// arm the AppArmor exec profile
+	if label.AppArmor != "" {
+		path := l.threadAttr(filepath.Join("apparmor", "exec"))
+		// kernels without LSM stacking only have the shared attribute
+		if _, err := os.Stat(path); err != nil {
+			path = l.threadAttr("exec")
+		}
+		// aa_change_onexec
+		if err := os.WriteFile(path, []byte("exec "+label.AppArmor), 0); err != nil {
+			// a missing profile is rejected by the kernel
+			if errors.Is(err, syscall.ENOENT) {
+				// return missing profile error
+				return fmt.Errorf("%w: %q", ErrAppArmorProfileMissing, label.AppArmor)
+			}
+			// return error with profile
+			return fmt.Errorf("setting apparmor exec profile %q: %w", label.AppArmor, err)
+		}
+	}
+	// label armed
+	return nil
+}
+
+// threadAttr returns an LSM attribute file of the current thread.
+//
+// Params:
+//   - name: the attribute name, relative to attr/.
+//
+// Returns:
+//   - string: the attribute path.
+func (l *Labeler) threadAttr(name string) string {
+	// address the thread itself, not the whole process
+	return filepath.Join(l.procRoot, "self", "task", strconv.Itoa(syscall.Gettid()), "attr", name)
+}
+
+// checkSELinuxContext asks the loaded policy whether a context is valid.
+//
+// Params:
+//   - context: the SELinux context.
+//
+// Returns:
+//   - error: ErrSELinuxContextInvalid if the policy rejects it.
+func (l *Labeler) checkSELinuxContext(context string) error {
+	// security_check_context: the kernel validates contexts written here
+	err := os.WriteFile(filepath.Join(l.sysRoot, "fs", "selinux", "context"), []byte(context), 0)
+	// rejected by the policy
+	if errors.Is(err, syscall.EINVAL) {
+		// return invalid context error
+		return fmt.Errorf("%w: %q", ErrSELinuxContextInvalid, context)
+	}
+	// other failures are left to the exec attribute write
+	return nil
+}
+
+// appArmorProfileLoaded looks up a profile among the loaded ones.
+//
+// Params:
+//   - name: the profile name.
+//
+// Returns:
+//   - bool: true if the profile is loaded.
+//   - bool: false if the loaded profiles cannot be listed.
+func (l *Labeler) appArmorProfileLoaded(name string) (loaded, known bool) {
+	f, err := os.Open(filepath.Join(l.sysRoot, "kernel", "security", "apparmor", "profiles"))
+	// securityfs not mounted or not readable
+	if err != nil {
+		// unknown, the kernel will decide
+		return false, false
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	// each line is "name (mode)"
+	for scanner.Scan() {
+		line := scanner.Text()
+		// strip the mode suffix
+		if i := strings.LastIndex(line, " ("); i >= 0 {
+			line = line[:i]
+		}
+		// matching profile
+		if line == name {
+			// profile loaded
+			return true, true
+		}
+	}
+	// listed but not loaded
+	return false, scanner.Err() == nil
+}
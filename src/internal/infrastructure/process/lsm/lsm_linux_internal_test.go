@@ -0,0 +1,134 @@
+//go:build linux
+
+// Package lsm provides internal tests for lsm_linux.go.
+package lsm
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile creates a file and its parent directories.
+//
+// Params:
+//   - t: the testing context.
+//   - path: the file path.
+//   - content: the file content.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// Test_Labeler_Check tests LSM detection and profile lookup.
+//
+// Params:
+//   - t: the testing context.
+func Test_Labeler_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, sys string)
+		label   Label
+		wantErr error
+	}{
+		{name: "no_label", setup: func(*testing.T, string) {}},
+		{
+			name:    "selinux_disabled",
+			setup:   func(*testing.T, string) {},
+			label:   Label{SELinux: "system_u:system_r:httpd_t:s0"},
+			wantErr: ErrSELinuxDisabled,
+		},
+		{
+			name: "selinux_enabled",
+			setup: func(t *testing.T, sys string) {
+				writeFile(t, filepath.Join(sys, "fs", "selinux", "enforce"), "1")
+				writeFile(t, filepath.Join(sys, "fs", "selinux", "context"), "")
+			},
+			label: Label{SELinux: "system_u:system_r:httpd_t:s0"},
+		},
+		{
+			name: "apparmor_disabled",
+			setup: func(t *testing.T, sys string) {
+				writeFile(t, filepath.Join(sys, "module", "apparmor", "parameters", "enabled"), "N\n")
+			},
+			label:   Label{AppArmor: "nginx"},
+			wantErr: ErrAppArmorDisabled,
+		},
+		{
+			name: "apparmor_profile_missing",
+			setup: func(t *testing.T, sys string) {
+				writeFile(t, filepath.Join(sys, "module", "apparmor", "parameters", "enabled"), "Y\n")
+				writeFile(t, filepath.Join(sys, "kernel", "security", "apparmor", "profiles"), "docker-default (enforce)\n")
+			},
+			label:   Label{AppArmor: "nginx"},
+			wantErr: ErrAppArmorProfileMissing,
+		},
+		{
+			name: "apparmor_profile_loaded",
+			setup: func(t *testing.T, sys string) {
+				writeFile(t, filepath.Join(sys, "module", "apparmor", "parameters", "enabled"), "Y\n")
+				writeFile(t, filepath.Join(sys, "kernel", "security", "apparmor", "profiles"), "docker-default (enforce)\nnginx (complain)\n")
+			},
+			label: Label{AppArmor: "nginx"},
+		},
+		{
+			name: "apparmor_profiles_unlisted",
+			setup: func(t *testing.T, sys string) {
+				writeFile(t, filepath.Join(sys, "module", "apparmor", "parameters", "enabled"), "Y\n")
+			},
+			label: Label{AppArmor: "nginx"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sys := t.TempDir()
+			tt.setup(t, sys)
+			l := NewWithRoots(t.TempDir(), sys)
+
+			err := l.Check(tt.label)
+			// check the expected outcome
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Test_Labeler_Start tests starting with and without labels.
+//
+// Params:
+//   - t: the testing context.
+func Test_Labeler_Start(t *testing.T) {
+	sys := t.TempDir()
+	writeFile(t, filepath.Join(sys, "module", "apparmor", "parameters", "enabled"), "Y\n")
+	l := NewWithRoots(t.TempDir(), sys)
+
+	// An unlabeled start runs directly.
+	started := false
+	require.NoError(t, l.Start(Label{}, func() error {
+		started = true
+		return nil
+	}))
+	assert.True(t, started)
+
+	// A label that cannot be armed aborts the start.
+	started = false
+	err := l.Start(Label{AppArmor: "nginx"}, func() error {
+		started = true
+		return nil
+	})
+	require.Error(t, err)
+	assert.False(t, started)
+
+	// A failed check aborts the start.
+	err = l.Start(Label{SELinux: "system_u:system_r:httpd_t:s0"}, func() error { return errors.New("unexpected") })
+	assert.ErrorIs(t, err, ErrSELinuxDisabled)
+}
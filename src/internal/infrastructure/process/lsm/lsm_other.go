@@ -0,0 +1,63 @@
+//go:build !linux
+
+// Package lsm assigns Linux Security Module labels to supervised processes.
+package lsm
+
+import (
+	"fmt"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+)
+
+// Check rejects every label outside Linux.
+//
+// Params:
+//   - label: the label to check.
+//
+// Returns:
+//   - error: process.ErrNotSupported for a non-zero label.
+func (l *Labeler) Check(label Label) error {
+	// no label requested
+	if label.IsZero() {
+		// nothing to check
+		return nil
+	}
+	// LSM labels are Linux-only
+	return fmt.Errorf("security labels: %w", process.ErrNotSupported)
+}
+
+// SELinuxEnabled reports false outside Linux.
+//
+// Returns:
+//   - bool: always false.
+func (l *Labeler) SELinuxEnabled() bool {
+	// SELinux is Linux-only
+	return false
+}
+
+// AppArmorEnabled reports false outside Linux.
+//
+// Returns:
+//   - bool: always false.
+func (l *Labeler) AppArmorEnabled() bool {
+	// AppArmor is Linux-only
+	return false
+}
+
+// Start calls start when no label is requested.
+//
+// Params:
+//   - label: the label to apply.
+//   - start: forks and executes the process.
+//
+// Returns:
+//   - error: process.ErrNotSupported for a non-zero label, or the start error.
+func (l *Labeler) Start(label Label, start func() error) error {
+	// labels cannot be applied
+	if err := l.Check(label); err != nil {
+		// propagate unsupported error
+		return err
+	}
+	// start unlabeled
+	return start()
+}
@@ -0,0 +1,78 @@
+// Package mountns runs supervised processes in a private mount namespace
+// where paths are made read-only, masked or covered by a tmpfs, and
+// files replaced by rendered content.
+// Go cannot run code between fork and exec, so the daemon re-executes
+// itself as a small helper inside the new namespace: the helper applies
+// the mounts, drops privileges and executes the service command.
+package mountns
+
+import (
+	"errors"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+const (
+	// helperArg0 is the argv[0] identifying the helper re-execution.
+	helperArg0 string = "supervizio-mountns"
+
+	// payloadEnv carries the helper payload through the environment.
+	payloadEnv string = "SUPERVIZIO_MOUNTNS"
+
+	// helperFailureCode is the exit code of a helper that cannot sandbox
+	// or execute the command, like a shell failing to execute one.
+	helperFailureCode int = 127
+)
+
+var (
+	// ErrInvalidPayload indicates a helper started without a valid payload.
+	ErrInvalidPayload error = errors.New("invalid mount namespace payload")
+)
+
+// Paths are the mount changes applied in the namespace.
+type Paths struct {
+	// ReadOnly are bind-mounted read-only over themselves.
+	ReadOnly []string
+	// Masked are hidden: files behind /dev/null, directories behind an
+	// empty read-only tmpfs.
+	Masked []string
+	// Tmpfs are covered by a private writable tmpfs.
+	Tmpfs []string
+	// Files replace existing files by a read-only copy of their content.
+	Files []File
+}
+
+// File is a file whose content is shown in place of an existing one.
+type File struct {
+	// Path is the replaced file; it must exist.
+	Path string
+	// Content is what the namespace reads at Path.
+	Content string
+}
+
+// IsZero reports whether no mount change is requested.
+//
+// Returns:
+//   - bool: true if the process can share the daemon mount namespace.
+func (p Paths) IsZero() bool {
+	// no path in any list
+	return len(p.ReadOnly) == 0 && len(p.Masked) == 0 && len(p.Tmpfs) == 0 && len(p.Files) == 0
+}
+
+// payload is what the daemon hands to the helper.
+type payload struct {
+	// Paths are the mount changes.
+	Paths Paths `json:"paths"`
+	// Path is the command to execute.
+	Path string `json:"path"`
+	// Args is the command argv, including argv[0].
+	Args []string `json:"args"`
+	// Label is the LSM label the command executes under.
+	Label lsm.Label `json:"label"`
+	// Credential reports that UID and GID must be applied.
+	Credential bool `json:"credential,omitempty"`
+	// UID is the user the command runs as.
+	UID uint32 `json:"uid,omitempty"`
+	// GID is the group the command runs as.
+	GID uint32 `json:"gid,omitempty"`
+}
@@ -0,0 +1,349 @@
+//go:build linux
+
+// Package mountns runs supervised processes in a private mount namespace.
+package mountns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+// selfExe is the running daemon binary, still reachable after an upgrade
+// replaced it on disk.
+const selfExe string = "/proc/self/exe"
+
+// fileMode is the mode of replaced files, readable by every user.
+const fileMode fs.FileMode = 0o644
+
+// Wrap rewrites a command to start through the helper in a new mount
+// namespace. The helper takes over the credentials and the label, which
+// must only apply to the final command, not to the mounting helper.
+//
+// Params:
+//   - cmd: the command, with credentials already applied.
+//   - paths: the mount changes; zero leaves the command untouched.
+//   - label: the LSM label the command executes under.
+//
+// Returns:
+//   - error: if the payload cannot be encoded.
+func Wrap(cmd *exec.Cmd, paths Paths, label lsm.Label) error {
+	// nothing to sandbox, or the lookup failed and Start reports it
+	if paths.IsZero() || cmd.Err != nil {
+		// command unchanged
+		return nil
+	}
+	p := payload{Paths: paths, Path: cmd.Path, Args: cmd.Args, Label: label}
+	// initialize SysProcAttr if not already set
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	// the helper mounts as root and drops privileges afterwards
+	if cred := cmd.SysProcAttr.Credential; cred != nil {
+		p.Credential, p.UID, p.GID = true, cred.Uid, cred.Gid
+		cmd.SysProcAttr.Credential = nil
+	}
+	data, err := json.Marshal(p)
+	// payload not encodable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("encoding mount namespace payload: %w", err)
+	}
+	cmd.Path = selfExe
+	cmd.Args = []string{helperArg0}
+	cmd.Env = append(cmd.Environ(), payloadEnv+"="+string(data))
+	cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWNS
+	// command rewritten
+	return nil
+}
+
+// Init runs the helper when the daemon was re-executed by Wrap, and
+// returns immediately otherwise. It must be called first thing in main.
+// The helper never returns: it executes the command or exits with 127.
+func Init() {
+	// regular daemon start
+	if len(os.Args) == 0 || os.Args[0] != helperArg0 {
+		return
+	}
+	err := run(os.Getenv(payloadEnv))
+	// stderr is the service log: report why the command did not start
+	_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", helperArg0, err)
+	os.Exit(helperFailureCode)
+}
+
+// run applies the mounts and executes the command.
+//
+// Params:
+//   - raw: the JSON payload.
+//
+// Returns:
+//   - error: why the command could not be executed; nil is never returned.
+func run(raw string) error {
+	var p payload
+	// decode the payload
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		// return error with decoding details
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	// a payload without command cannot come from Wrap
+	if p.Path == "" || len(p.Args) == 0 {
+		// return invalid payload error
+		return ErrInvalidPayload
+	}
+	mounts, err := plan(p.Paths)
+	// a path could not be inspected
+	if err != nil {
+		// propagate plan error
+		return err
+	}
+	// make the namespace private first
+	if err := apply(mounts[:1]); err != nil {
+		// propagate mount error
+		return err
+	}
+	// replace the files before read-only paths forbid staging them
+	if err := overlay(p.Paths.Files); err != nil {
+		// propagate overlay error
+		return err
+	}
+	// apply the remaining mounts in order
+	if err := apply(mounts[1:]); err != nil {
+		// propagate mount error
+		return err
+	}
+	env := slices.DeleteFunc(os.Environ(), func(kv string) bool {
+		// the payload is not for the command
+		return strings.HasPrefix(kv, payloadEnv+"=")
+	})
+	// arm the label on the executing thread, then drop privileges and exec
+	return lsm.New().Start(p.Label, func() error {
+		// switch to the service user
+		if p.Credential {
+			// apply credentials like exec.Cmd does
+			if err := dropPrivileges(p.UID, p.GID); err != nil {
+				// propagate credential error
+				return err
+			}
+		}
+		// only returns on failure
+		return syscall.Exec(p.Path, p.Args, env)
+	})
+}
+
+// apply performs mounts in order.
+//
+// Params:
+//   - mounts: the mounts.
+//
+// Returns:
+//   - error: if a mount fails.
+func apply(mounts []mount) error {
+	// apply each mount
+	for _, m := range mounts {
+		// mount(2) in the private namespace
+		if err := syscall.Mount(m.source, m.target, m.fstype, m.flags, m.data); err != nil {
+			// return error with the target
+			return fmt.Errorf("mounting %s: %w", m.target, err)
+		}
+	}
+	// mounts applied
+	return nil
+}
+
+// overlay replaces files by their rendered content. The contents are
+// written to a private tmpfs staged in a temporary directory, each bound
+// read-only over its file; the staging mount is then detached, the binds
+// keeping the tmpfs alive while the namespace lives.
+//
+// Params:
+//   - files: the replaced files.
+//
+// Returns:
+//   - error: if a file cannot be staged or mounted.
+func overlay(files []File) error {
+	// nothing to replace
+	if len(files) == 0 {
+		// no staging needed
+		return nil
+	}
+	staging, err := os.MkdirTemp("", helperArg0+"-")
+	// temporary directory unavailable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("creating staging directory: %w", err)
+	}
+	defer func() { _ = os.Remove(staging) }()
+	// keep the contents off the host filesystem
+	if err := syscall.Mount("tmpfs", staging, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, "mode=0755"); err != nil {
+		// return error with the target
+		return fmt.Errorf("mounting %s: %w", staging, err)
+	}
+	// the binds keep their files once the staging mount is gone
+	defer func() { _ = syscall.Unmount(staging, syscall.MNT_DETACH) }()
+	// stage and bind each file
+	for i, f := range files {
+		source := filepath.Join(staging, strconv.Itoa(i))
+		// write the content
+		if err := os.WriteFile(source, []byte(f.Content), fileMode); err != nil {
+			// return error with the file
+			return fmt.Errorf("staging %s: %w", f.Path, err)
+		}
+		// readable by the service whatever the daemon umask
+		if err := os.Chmod(source, fileMode); err != nil {
+			// return error with the file
+			return fmt.Errorf("staging %s: %w", f.Path, err)
+		}
+		err := apply([]mount{
+			{source: source, target: f.Path, flags: syscall.MS_BIND},
+			{target: f.Path, flags: syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY},
+		})
+		// bind failed, the target is likely missing
+		if err != nil {
+			// propagate mount error
+			return err
+		}
+	}
+	// files replaced
+	return nil
+}
+
+// dropPrivileges switches every thread to the service user.
+//
+// Params:
+//   - uid: the user ID.
+//   - gid: the group ID.
+//
+// Returns:
+//   - error: if a credential cannot be applied.
+func dropPrivileges(uid, gid uint32) error {
+	// no supplementary groups, like exec.Cmd with a Credential
+	if err := syscall.Setgroups(nil); err != nil {
+		// return error with context
+		return fmt.Errorf("clearing groups: %w", err)
+	}
+	// group before user, while still privileged
+	if err := syscall.Setgid(int(gid)); err != nil {
+		// return error with context
+		return fmt.Errorf("setting gid %d: %w", gid, err)
+	}
+	// user last
+	if err := syscall.Setuid(int(uid)); err != nil {
+		// return error with context
+		return fmt.Errorf("setting uid %d: %w", uid, err)
+	}
+	// privileges dropped
+	return nil
+}
+
+// mount is one mount(2) call.
+type mount struct {
+	// source is the mounted device or directory.
+	source string
+	// target is the mount point.
+	target string
+	// fstype is the filesystem type, empty for binds.
+	fstype string
+	// flags are the MS_* flags.
+	flags uintptr
+	// data is the filesystem options.
+	data string
+}
+
+// plan lists the mounts applying the paths. The namespace is first made
+// private so nothing propagates back to the host; read-only binds come
+// next, then tmpfs, and masks last so they hide everything below.
+// Read-only and masked paths missing from the host are skipped: there is
+// nothing to protect. Submounts of a read-only path keep their own flags.
+//
+// Params:
+//   - paths: the mount changes.
+//
+// Returns:
+//   - []mount: the mounts, in order.
+//   - error: if a path cannot be inspected.
+func plan(paths Paths) ([]mount, error) {
+	mounts := []mount{{target: "/", flags: syscall.MS_REC | syscall.MS_PRIVATE}}
+	// bind each read-only path over itself, then remount it read-only
+	for _, p := range paths.ReadOnly {
+		_, ok, err := stat(p)
+		// inspection failed
+		if err != nil {
+			// propagate stat error
+			return nil, err
+		}
+		// nothing to protect
+		if !ok {
+			continue
+		}
+		mounts = append(mounts,
+			mount{source: p, target: p, flags: syscall.MS_BIND | syscall.MS_REC},
+			mount{target: p, flags: syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY},
+		)
+	}
+	// cover each tmpfs path; a missing mount point fails at mount time
+	for _, p := range paths.Tmpfs {
+		mounts = append(mounts, mount{
+			source: "tmpfs", target: p, fstype: "tmpfs",
+			flags: syscall.MS_NOSUID | syscall.MS_NODEV, data: "mode=0755",
+		})
+	}
+	// hide each masked path
+	for _, p := range paths.Masked {
+		info, ok, err := stat(p)
+		// inspection failed
+		if err != nil {
+			// propagate stat error
+			return nil, err
+		}
+		// nothing to hide
+		if !ok {
+			continue
+		}
+		// directories become empty and inaccessible
+		if info.IsDir() {
+			mounts = append(mounts, mount{
+				source: "tmpfs", target: p, fstype: "tmpfs",
+				flags: syscall.MS_RDONLY | syscall.MS_NOSUID | syscall.MS_NODEV | syscall.MS_NOEXEC, data: "mode=0000",
+			})
+			continue
+		}
+		mounts = append(mounts, mount{source: os.DevNull, target: p, flags: syscall.MS_BIND})
+	}
+	// return ordered mounts
+	return mounts, nil
+}
+
+// stat inspects a path, following symlinks like mount(2) does.
+//
+// Params:
+//   - path: the path.
+//
+// Returns:
+//   - fs.FileInfo: the file information.
+//   - bool: false if the path does not exist.
+//   - error: if the path cannot be inspected.
+func stat(path string) (fs.FileInfo, bool, error) {
+	info, err := os.Stat(path)
+	// missing paths are not an error
+	if errors.Is(err, fs.ErrNotExist) {
+		// report absence
+		return nil, false, nil
+	}
+	// other stat failure
+	if err != nil {
+		// return error with context
+		return nil, false, fmt.Errorf("inspecting %s: %w", path, err)
+	}
+	// path exists
+	return info, true, nil
+}
@@ -0,0 +1,191 @@
+//go:build linux
+
+// Package mountns provides internal tests for mountns_linux.go.
+package mountns
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+// TestMain lets the test binary act as the helper it is re-executed as.
+//
+// Params:
+//   - m: the test runner.
+func TestMain(m *testing.M) {
+	Init()
+	os.Exit(m.Run())
+}
+
+// decodePayload extracts the helper payload from a wrapped command.
+//
+// Params:
+//   - t: the testing context.
+//   - cmd: the wrapped command.
+//
+// Returns:
+//   - payload: the decoded payload.
+func decodePayload(t *testing.T, cmd *exec.Cmd) payload {
+	t.Helper()
+	var p payload
+	// find the payload variable
+	for _, kv := range cmd.Env {
+		// decode the payload value
+		if value, ok := strings.CutPrefix(kv, payloadEnv+"="); ok {
+			require.NoError(t, json.Unmarshal([]byte(value), &p))
+			return p
+		}
+	}
+	t.Fatal("payload not found in environment")
+	return p
+}
+
+// Test_Wrap tests the command rewrite.
+//
+// Params:
+//   - t: the testing context.
+func Test_Wrap(t *testing.T) {
+	t.Run("zero_paths", func(t *testing.T) {
+		cmd := exec.Command("/bin/true")
+		require.NoError(t, Wrap(cmd, Paths{}, lsm.Label{AppArmor: "api"}))
+		assert.Equal(t, "/bin/true", cmd.Path)
+		assert.Nil(t, cmd.SysProcAttr)
+	})
+
+	t.Run("sandboxed", func(t *testing.T) {
+		cmd := exec.Command("/bin/echo", "hello")
+		cmd.Env = []string{"A=1"}
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Credential: &syscall.Credential{Uid: 1000, Gid: 100}}
+		paths := Paths{
+			ReadOnly: []string{"/usr"}, Masked: []string{"/etc/shadow"}, Tmpfs: []string{"/tmp"},
+			Files: []File{{Path: "/etc/hosts", Content: "10.0.0.5\tdb\n"}},
+		}
+
+		require.NoError(t, Wrap(cmd, paths, lsm.Label{AppArmor: "api"}))
+
+		assert.Equal(t, selfExe, cmd.Path)
+		assert.Equal(t, []string{helperArg0}, cmd.Args)
+		assert.Contains(t, cmd.Env, "A=1")
+		assert.True(t, cmd.SysProcAttr.Setpgid)
+		assert.Nil(t, cmd.SysProcAttr.Credential)
+		assert.NotZero(t, cmd.SysProcAttr.Cloneflags&syscall.CLONE_NEWNS)
+
+		p := decodePayload(t, cmd)
+		assert.Equal(t, paths, p.Paths)
+		assert.Equal(t, "/bin/echo", p.Path)
+		assert.Equal(t, []string{"/bin/echo", "hello"}, p.Args)
+		assert.Equal(t, "api", p.Label.AppArmor)
+		assert.True(t, p.Credential)
+		assert.Equal(t, uint32(1000), p.UID)
+		assert.Equal(t, uint32(100), p.GID)
+	})
+}
+
+// Test_plan tests the mount plan.
+//
+// Params:
+//   - t: the testing context.
+func Test_plan(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(file, []byte("s3cret"), 0o600))
+	missing := filepath.Join(dir, "missing")
+
+	mounts, err := plan(Paths{
+		ReadOnly: []string{dir, missing},
+		Masked:   []string{file, dir, missing},
+		Tmpfs:    []string{"/tmp"},
+	})
+	require.NoError(t, err)
+
+	targets := make([]string, 0, len(mounts))
+	// collect mount targets in order
+	for _, m := range mounts {
+		targets = append(targets, m.target)
+	}
+	assert.Equal(t, []string{"/", dir, dir, "/tmp", file, dir}, targets)
+	assert.Equal(t, uintptr(syscall.MS_REC|syscall.MS_PRIVATE), mounts[0].flags)
+	assert.NotZero(t, mounts[2].flags&syscall.MS_RDONLY)
+	assert.Equal(t, "tmpfs", mounts[3].fstype)
+	assert.Equal(t, os.DevNull, mounts[4].source)
+	assert.Equal(t, "tmpfs", mounts[5].fstype)
+	assert.NotZero(t, mounts[5].flags&syscall.MS_RDONLY)
+}
+
+// Test_run_invalidPayload tests that a malformed payload is rejected.
+//
+// Params:
+//   - t: the testing context.
+func Test_run_invalidPayload(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "empty", raw: ""},
+		{name: "not_json", raw: "{"},
+		{name: "no_command", raw: `{"paths":{"Tmpfs":["/tmp"]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, run(tt.raw), ErrInvalidPayload)
+		})
+	}
+}
+
+// TestInit_sandbox runs a command through the helper.
+//
+// Params:
+//   - t: the testing context.
+func TestInit_sandbox(t *testing.T) {
+	// Mount namespaces need CAP_SYS_ADMIN.
+	if os.Geteuid() != 0 {
+		t.Skip("requires root")
+	}
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cret"), 0o600))
+	readOnly := filepath.Join(dir, "ro")
+	scratch := filepath.Join(dir, "scratch")
+	require.NoError(t, os.Mkdir(readOnly, 0o755))
+	require.NoError(t, os.Mkdir(scratch, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(scratch, "host"), nil, 0o600))
+	hosts := filepath.Join(dir, "hosts")
+	require.NoError(t, os.WriteFile(hosts, []byte("host\n"), 0o644))
+
+	script := "cat " + secret + "; touch " + readOnly + "/x 2>/dev/null && echo writable; ls " + scratch +
+		"; cat " + hosts + "; echo x 2>/dev/null >> " + hosts + " && echo writable; echo done"
+	cmd := exec.Command("/bin/sh", "-c", script)
+	paths := Paths{
+		ReadOnly: []string{readOnly}, Masked: []string{secret}, Tmpfs: []string{scratch},
+		Files: []File{{Path: hosts, Content: "10.0.0.5\tdb\n"}},
+	}
+	require.NoError(t, Wrap(cmd, paths, lsm.Label{}))
+
+	out, err := cmd.CombinedOutput()
+	// Sandboxes such as containers may forbid new mount namespaces.
+	if err != nil && strings.Contains(err.Error(), "operation not permitted") {
+		t.Skipf("mount namespaces unavailable: %v", err)
+	}
+	require.NoError(t, err, string(out))
+	assert.Equal(t, "10.0.0.5\tdb\ndone\n", string(out))
+
+	// The host view is untouched.
+	data, err := os.ReadFile(secret)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", string(data))
+	assert.FileExists(t, filepath.Join(scratch, "host"))
+	data, err = os.ReadFile(hosts)
+	require.NoError(t, err)
+	assert.Equal(t, "host\n", string(data))
+}
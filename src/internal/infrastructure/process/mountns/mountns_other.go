@@ -0,0 +1,34 @@
+//go:build !linux
+
+// Package mountns runs supervised processes in a private mount namespace.
+package mountns
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+// Wrap rejects every mount change outside Linux.
+//
+// Params:
+//   - cmd: the command (unused).
+//   - paths: the mount changes.
+//   - label: the LSM label (unused).
+//
+// Returns:
+//   - error: process.ErrNotSupported for non-zero paths.
+func Wrap(_ *exec.Cmd, paths Paths, _ lsm.Label) error {
+	// no mount change requested
+	if paths.IsZero() {
+		// command unchanged
+		return nil
+	}
+	// mount namespaces are Linux-only
+	return fmt.Errorf("mount namespace: %w", process.ErrNotSupported)
+}
+
+// Init does nothing outside Linux, where Wrap never re-executes the daemon.
+func Init() {}
@@ -0,0 +1,51 @@
+// Package pidns runs supervised processes in their own PID namespace.
+// The daemon re-executes itself as a minimal init, PID 1 of the new
+// namespace: it starts the service command, forwards it the signals the
+// daemon sends, reaps every orphan of the namespace, and exits with the
+// exit code of the command. When it exits, the kernel kills whatever is
+// left in the namespace, so the exit of the service is the exit of the
+// whole process tree.
+package pidns
+
+import (
+	"errors"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+const (
+	// helperArg0 is the argv[0] identifying the helper re-execution.
+	helperArg0 string = "supervizio-pidns"
+
+	// payloadEnv carries the helper payload through the environment.
+	payloadEnv string = "SUPERVIZIO_PIDNS"
+
+	// helperFailureCode is the exit code of a helper that cannot start
+	// the command, like a shell failing to execute one.
+	helperFailureCode int = 127
+
+	// signalExitBase is added to the number of the signal that killed the
+	// command, the exit code shells report for it.
+	signalExitBase int = 128
+)
+
+var (
+	// ErrInvalidPayload indicates a helper started without a valid payload.
+	ErrInvalidPayload error = errors.New("invalid PID namespace payload")
+)
+
+// payload is what the daemon hands to the helper.
+type payload struct {
+	// Path is the command to execute.
+	Path string `json:"path"`
+	// Args is the command argv, including argv[0].
+	Args []string `json:"args"`
+	// Label is the LSM label the command executes under.
+	Label lsm.Label `json:"label"`
+	// Credential reports that UID and GID must be applied.
+	Credential bool `json:"credential,omitempty"`
+	// UID is the user the command runs as.
+	UID uint32 `json:"uid,omitempty"`
+	// GID is the group the command runs as.
+	GID uint32 `json:"gid,omitempty"`
+}
@@ -0,0 +1,251 @@
+//go:build linux
+
+// Package pidns runs supervised processes in their own PID namespace.
+package pidns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+// selfExe is the running daemon binary, still reachable after an upgrade
+// replaced it on disk.
+const selfExe string = "/proc/self/exe"
+
+// forwardedSignals are relayed by the helper to the command. SIGKILL and
+// SIGSTOP cannot be caught: sent to the helper, they end the namespace.
+var forwardedSignals []os.Signal = []os.Signal{
+	syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM,
+	syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGWINCH, syscall.SIGALRM,
+}
+
+// Wrap rewrites a command to start through the helper as PID 1 of a new
+// PID namespace, with a private mount namespace for its own /proc. The
+// helper takes over the credentials and the label, which must only apply
+// to the command, not to the helper. A command already wrapped by mountns
+// is started by the helper as is.
+//
+// Params:
+//   - cmd: the command, with credentials already applied.
+//   - label: the LSM label the command executes under.
+//
+// Returns:
+//   - error: if the payload cannot be encoded.
+func Wrap(cmd *exec.Cmd, label lsm.Label) error {
+	// the lookup failed and Start reports it
+	if cmd.Err != nil {
+		// command unchanged
+		return nil
+	}
+	p := payload{Path: cmd.Path, Args: cmd.Args, Label: label}
+	// initialize SysProcAttr if not already set
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	// the helper mounts /proc as root and starts the command as the user
+	if cred := cmd.SysProcAttr.Credential; cred != nil {
+		p.Credential, p.UID, p.GID = true, cred.Uid, cred.Gid
+		cmd.SysProcAttr.Credential = nil
+	}
+	data, err := json.Marshal(p)
+	// payload not encodable
+	if err != nil {
+		// return error with context
+		return fmt.Errorf("encoding PID namespace payload: %w", err)
+	}
+	cmd.Path = selfExe
+	cmd.Args = []string{helperArg0}
+	cmd.Env = append(cmd.Environ(), payloadEnv+"="+string(data))
+	cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWPID | syscall.CLONE_NEWNS
+	// command rewritten
+	return nil
+}
+
+// Init runs the helper when the daemon was re-executed by Wrap, and
+// returns immediately otherwise. It must be called first thing in main.
+// The helper never returns: it exits with the exit code of the command,
+// or with 127 when the command cannot be started.
+func Init() {
+	// regular daemon start
+	if len(os.Args) == 0 || os.Args[0] != helperArg0 {
+		return
+	}
+	code, err := run(os.Getenv(payloadEnv))
+	// stderr is the service log: report why the command did not run
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", helperArg0, err)
+	}
+	os.Exit(code)
+}
+
+// run mounts /proc, starts the command and reaps the namespace until the
+// command exits.
+//
+// Params:
+//   - raw: the JSON payload.
+//
+// Returns:
+//   - int: the exit code of the command, 127 if it could not run.
+//   - error: why the command could not run or be waited for.
+func run(raw string) (int, error) {
+	var p payload
+	// decode the payload
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		// return error with decoding details
+		return helperFailureCode, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	// a payload without command cannot come from Wrap
+	if p.Path == "" || len(p.Args) == 0 {
+		// return invalid payload error
+		return helperFailureCode, ErrInvalidPayload
+	}
+	// show the processes of the namespace in /proc, not the host's
+	if err := mountProc(); err != nil {
+		// propagate mount error
+		return helperFailureCode, err
+	}
+	env := slices.DeleteFunc(os.Environ(), func(kv string) bool {
+		// the payload is not for the command
+		return strings.HasPrefix(kv, payloadEnv+"=")
+	})
+	// relay signals from the start, so none sent early is lost
+	signals := make(chan os.Signal, len(forwardedSignals))
+	signal.Notify(signals, forwardedSignals...)
+
+	pid, err := start(&p, env)
+	// the command could not be executed
+	if err != nil {
+		// return start error
+		return helperFailureCode, err
+	}
+	go forward(signals, pid)
+	// return the exit of the command once reaped
+	return reap(pid)
+}
+
+// mountProc makes the mount namespace private and mounts a /proc
+// listing the processes of the PID namespace.
+//
+// Returns:
+//   - error: if a mount fails.
+func mountProc() error {
+	// nothing propagates back to the host
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		// return error with context
+		return fmt.Errorf("making mounts private: %w", err)
+	}
+	// a fresh procfs reflects the namespace it is mounted from
+	if err := syscall.Mount("proc", "/proc", "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		// return error with context
+		return fmt.Errorf("mounting /proc: %w", err)
+	}
+	// mounts applied
+	return nil
+}
+
+// start forks the command with its label and credentials.
+//
+// Params:
+//   - p: the payload.
+//   - env: the environment of the command.
+//
+// Returns:
+//   - int: the process ID of the command in the namespace.
+//   - error: if the command cannot be executed.
+func start(p *payload, env []string) (int, error) {
+	attr := &syscall.ProcAttr{Env: env, Files: []uintptr{0, 1, 2}, Sys: &syscall.SysProcAttr{}}
+	// switch to the service user, without supplementary groups
+	if p.Credential {
+		attr.Sys.Credential = &syscall.Credential{Uid: p.UID, Gid: p.GID}
+	}
+	var pid int
+	// arm the label on the forking thread
+	err := lsm.New().Start(p.Label, func() error {
+		var forkErr error
+		pid, forkErr = syscall.ForkExec(p.Path, p.Args, attr)
+		// return fork/exec outcome
+		return forkErr
+	})
+	// the command could not be executed
+	if err != nil {
+		// return error with the command
+		return 0, fmt.Errorf("starting %s: %w", p.Path, err)
+	}
+	// return namespace PID
+	return pid, nil
+}
+
+// forward relays the signals received by the helper to the command.
+//
+// Params:
+//   - signals: the signals received.
+//   - pid: the process ID of the command.
+func forward(signals <-chan os.Signal, pid int) {
+	// relay until the helper exits
+	for sig := range signals {
+		// a command already gone is reaped next
+		if s, ok := sig.(syscall.Signal); ok {
+			_ = syscall.Kill(pid, s)
+		}
+	}
+}
+
+// reap waits for every child of the namespace, orphans included, until
+// the command exits.
+//
+// Params:
+//   - pid: the process ID of the command.
+//
+// Returns:
+//   - int: the exit code of the command, 128+N when killed by signal N.
+//   - error: if waiting fails before the command exited.
+func reap(pid int) (int, error) {
+	// collect exits until the command's
+	for {
+		var status syscall.WaitStatus
+		reaped, err := syscall.Wait4(-1, &status, 0, nil)
+		// interrupted by a forwarded signal
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		// no child left to wait for
+		if err != nil {
+			// return wait error
+			return helperFailureCode, fmt.Errorf("waiting for %d: %w", pid, err)
+		}
+		// orphans are only reaped
+		if reaped != pid {
+			continue
+		}
+		// return the code of the command
+		return exitCode(status), nil
+	}
+}
+
+// exitCode converts a wait status to the exit code of the helper.
+// The helper cannot be killed by the signal that killed the command, as
+// PID 1 of its namespace, so the signal is reported as 128+N.
+//
+// Params:
+//   - status: the wait status of the command.
+//
+// Returns:
+//   - int: the exit code.
+func exitCode(status syscall.WaitStatus) int {
+	// killed by a signal
+	if status.Signaled() {
+		// return shell convention
+		return signalExitBase + int(status.Signal())
+	}
+	// return the exit code
+	return status.ExitStatus()
+}
@@ -0,0 +1,197 @@
+//go:build linux
+
+// Package pidns provides internal tests for pidns_linux.go.
+package pidns
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+// TestMain lets the test binary act as the helper it is re-executed as.
+//
+// Params:
+//   - m: the test runner.
+func TestMain(m *testing.M) {
+	Init()
+	os.Exit(m.Run())
+}
+
+// decodePayload extracts the helper payload from a wrapped command.
+//
+// Params:
+//   - t: the testing context.
+//   - cmd: the wrapped command.
+//
+// Returns:
+//   - payload: the decoded payload.
+func decodePayload(t *testing.T, cmd *exec.Cmd) payload {
+	t.Helper()
+	var p payload
+	// find the payload variable
+	for _, kv := range cmd.Env {
+		// decode the payload value
+		if value, ok := strings.CutPrefix(kv, payloadEnv+"="); ok {
+			require.NoError(t, json.Unmarshal([]byte(value), &p))
+			return p
+		}
+	}
+	t.Fatal("payload not found in environment")
+	return p
+}
+
+// Test_Wrap tests the command rewrite.
+//
+// Params:
+//   - t: the testing context.
+func Test_Wrap(t *testing.T) {
+	cmd := exec.Command("/bin/echo", "hello")
+	cmd.Env = []string{"A=1"}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Credential: &syscall.Credential{Uid: 1000, Gid: 100}}
+
+	require.NoError(t, Wrap(cmd, lsm.Label{AppArmor: "api"}))
+
+	assert.Equal(t, selfExe, cmd.Path)
+	assert.Equal(t, []string{helperArg0}, cmd.Args)
+	assert.Contains(t, cmd.Env, "A=1")
+	assert.True(t, cmd.SysProcAttr.Setpgid)
+	assert.Nil(t, cmd.SysProcAttr.Credential)
+	assert.NotZero(t, cmd.SysProcAttr.Cloneflags&syscall.CLONE_NEWPID)
+	assert.NotZero(t, cmd.SysProcAttr.Cloneflags&syscall.CLONE_NEWNS)
+
+	p := decodePayload(t, cmd)
+	assert.Equal(t, "/bin/echo", p.Path)
+	assert.Equal(t, []string{"/bin/echo", "hello"}, p.Args)
+	assert.Equal(t, "api", p.Label.AppArmor)
+	assert.True(t, p.Credential)
+	assert.Equal(t, uint32(1000), p.UID)
+	assert.Equal(t, uint32(100), p.GID)
+}
+
+// Test_run_invalidPayload tests that a malformed payload is rejected.
+//
+// Params:
+//   - t: the testing context.
+func Test_run_invalidPayload(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "empty", raw: ""},
+		{name: "not_json", raw: "{"},
+		{name: "no_command", raw: `{"args":["x"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := run(tt.raw)
+			assert.ErrorIs(t, err, ErrInvalidPayload)
+			assert.Equal(t, helperFailureCode, code)
+		})
+	}
+}
+
+// Test_exitCode tests the conversion of wait statuses.
+//
+// Params:
+//   - t: the testing context.
+func Test_exitCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		status syscall.WaitStatus
+		want   int
+	}{
+		{name: "success", status: 0, want: 0},
+		{name: "exit_3", status: 3 << 8, want: 3},
+		{name: "sigkill", status: syscall.WaitStatus(syscall.SIGKILL), want: 137},
+		{name: "sigterm", status: syscall.WaitStatus(syscall.SIGTERM), want: 143},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.status))
+		})
+	}
+}
+
+// runWrapped runs a shell script through the helper.
+//
+// Params:
+//   - t: the testing context.
+//   - script: the shell script.
+//   - signal: sent to the helper after 200ms when non-zero.
+//
+// Returns:
+//   - string: the combined output.
+//   - int: the exit code of the helper.
+func runWrapped(t *testing.T, script string, signal syscall.Signal) (string, int) {
+	t.Helper()
+	// PID namespaces need CAP_SYS_ADMIN.
+	if os.Geteuid() != 0 {
+		t.Skip("requires root")
+	}
+	cmd := exec.Command("/bin/sh", "-c", script)
+	var out strings.Builder
+	cmd.Stdout, cmd.Stderr = &out, &out
+	require.NoError(t, Wrap(cmd, lsm.Label{}))
+	err := cmd.Start()
+	// Sandboxes such as containers may forbid new namespaces.
+	if err != nil && strings.Contains(err.Error(), "operation not permitted") {
+		t.Skipf("PID namespaces unavailable: %v", err)
+	}
+	require.NoError(t, err)
+	// signal the helper once the command runs
+	if signal != 0 {
+		time.Sleep(200 * time.Millisecond)
+		require.NoError(t, cmd.Process.Signal(signal))
+	}
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	// a non-zero exit is the result under test
+	if err != nil && !errors.As(err, &exitErr) {
+		require.NoError(t, err)
+	}
+	return out.String(), cmd.ProcessState.ExitCode()
+}
+
+// TestInit_namespace runs commands through the helper.
+//
+// Params:
+//   - t: the testing context.
+func TestInit_namespace(t *testing.T) {
+	t.Run("exit_code", func(t *testing.T) {
+		out, code := runWrapped(t, "tr '\\0' ' ' < /proc/1/cmdline; echo; exit 3", 0)
+		assert.Equal(t, helperArg0+" \n", out, "the helper is PID 1 of the namespace")
+		assert.Equal(t, 3, code)
+	})
+
+	t.Run("orphans_killed", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "survived")
+		_, code := runWrapped(t, "(sleep 0.3; touch "+marker+") & exit 0", 0)
+		assert.Equal(t, 0, code)
+		time.Sleep(500 * time.Millisecond)
+		assert.NoFileExists(t, marker, "the namespace died with the command")
+	})
+
+	t.Run("signal_forwarded", func(t *testing.T) {
+		_, code := runWrapped(t, "trap 'exit 7' TERM; while :; do sleep 0.05; done", syscall.SIGTERM)
+		assert.Equal(t, 7, code)
+	})
+
+	t.Run("killed_by_signal", func(t *testing.T) {
+		_, code := runWrapped(t, "kill -KILL $$", 0)
+		assert.Equal(t, 137, code)
+	})
+}
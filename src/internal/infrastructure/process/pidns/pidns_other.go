@@ -0,0 +1,28 @@
+//go:build !linux
+
+// Package pidns runs supervised processes in their own PID namespace.
+package pidns
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process"
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+// Wrap rejects PID namespaces outside Linux.
+//
+// Params:
+//   - cmd: the command (unused).
+//   - label: the LSM label (unused).
+//
+// Returns:
+//   - error: process.ErrNotSupported.
+func Wrap(_ *exec.Cmd, _ lsm.Label) error {
+	// PID namespaces are Linux-only
+	return fmt.Errorf("PID namespace: %w", process.ErrNotSupported)
+}
+
+// Init does nothing outside Linux, where Wrap never re-executes the daemon.
+func Init() {}
@@ -0,0 +1,375 @@
+// Package preflight checks a configuration against the host before it is applied.
+// It verifies that binaries, working directories, users, groups and security
+// labels exist, that egress rules can be enforced and that listeners do not
+// bind conflicting ports, so a reload can be refused as a whole instead of
+// being partially applied. Service binaries can also be dry-run under their
+// user and group.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/process/credentials"
+	"github.com/kodflow/daemon/internal/infrastructure/process/egress"
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+)
+
+const (
+	// maxOutput bounds the verify command output quoted in failures.
+	maxOutput int = 512
+
+	// waitDelay bounds the wait for output pipes held by descendants
+	// once a verify command exited or was killed.
+	waitDelay time.Duration = time.Second
+)
+
+// errNotDirectory indicates that a working directory path is not a directory.
+var errNotDirectory error = errors.New("not a directory")
+
+// Checker runs pre-flight checks on a configuration.
+// It implements the application config Preflighter port.
+type Checker struct {
+	// creds resolves service users and groups.
+	creds credentials.CredentialManager
+	// labeler checks service security labels.
+	labeler *lsm.Labeler
+	// firewall checks that egress rules can be enforced.
+	firewall *egress.Firewall
+}
+
+// New creates a pre-flight checker.
+//
+// Params:
+//   - creds: credential manager used to resolve users and groups.
+//
+// Returns:
+//   - *Checker: the pre-flight checker.
+func New(creds credentials.CredentialManager) *Checker {
+	// construct checker with credential resolver
+	return &Checker{creds: creds, labeler: lsm.New(), firewall: egress.New()}
+}
+
+// Preflight runs every check and reports all failures at once.
+// Services with a verify command are also dry-run.
+//
+// Params:
+//   - cfg: the configuration to check.
+//
+// Returns:
+//   - error: a *config.PreflightError listing failures, nil if all checks pass.
+func (c *Checker) Preflight(cfg *config.Config) error {
+	var failures []config.PreflightFailure
+
+	// check each service independently
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		failures = append(failures, c.checkService(svc, svc.VerifyCommand != "")...)
+	}
+	failures = append(failures, checkPorts(cfg.Services)...)
+
+	// return the full report
+	return report(failures)
+}
+
+// Verify runs the per-service checks and dry-runs the binary of services,
+// with their verify command or the binary with --version, then --help.
+//
+// Params:
+//   - cfg: the configuration holding the services.
+//   - names: the services to verify, all when empty.
+//
+// Returns:
+//   - error: a *config.PreflightError listing failures, nil if all checks pass.
+func (c *Checker) Verify(cfg *config.Config, names []string) error {
+	var failures []config.PreflightFailure
+
+	// check the selected services
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		// skip services not asked for
+		if len(names) > 0 && !slices.Contains(names, svc.Name) {
+			continue
+		}
+		failures = append(failures, c.checkService(svc, true)...)
+	}
+
+	// return the full report
+	return report(failures)
+}
+
+// report wraps failures into a pre-flight report.
+//
+// Params:
+//   - failures: the failed checks.
+//
+// Returns:
+//   - error: a *config.PreflightError, nil without failures.
+func report(failures []config.PreflightFailure) error {
+	// nothing failed
+	if len(failures) == 0 {
+		// config can be applied
+		return nil
+	}
+
+	// return the full report
+	return &config.PreflightError{Failures: failures}
+}
+
+// checkService runs the per-service checks.
+//
+// Params:
+//   - svc: the service configuration.
+//   - verify: true to dry-run the binary once the other checks pass.
+//
+// Returns:
+//   - []config.PreflightFailure: failed checks for the service.
+func (c *Checker) checkService(svc *config.ServiceConfig, verify bool) []config.PreflightFailure {
+	var failures []config.PreflightFailure
+	fail := func(check string, err error) {
+		failures = append(failures, config.PreflightFailure{Service: svc.Name, Check: check, Detail: err.Error()})
+	}
+
+	// verify the working directory exists
+	if err := checkDirectory(svc.WorkingDirectory); err != nil {
+		fail(config.PreflightCheckWorkingDirectory, err)
+	}
+
+	// verify the binary resolves to an executable
+	if err := checkBinary(svc.Command, svc.WorkingDirectory); err != nil {
+		fail(config.PreflightCheckBinary, err)
+	}
+
+	// verify the user resolves when configured
+	if svc.User != "" {
+		// lookup user by name or UID
+		if _, err := c.creds.LookupUser(svc.User); err != nil {
+			fail(config.PreflightCheckUser, err)
+		}
+	}
+
+	// verify the group resolves when configured
+	if svc.Group != "" {
+		// lookup group by name or GID
+		if _, err := c.creds.LookupGroup(svc.Group); err != nil {
+			fail(config.PreflightCheckGroup, err)
+		}
+	}
+
+	// verify the security module knows the label
+	if err := c.labeler.Check(lsm.Label{SELinux: svc.SELinuxContext, AppArmor: svc.AppArmorProfile}); err != nil {
+		fail(config.PreflightCheckSecurityLabel, err)
+	}
+
+	// verify egress rules can be enforced when configured
+	if len(svc.Egress) > 0 {
+		// cgroup v2 and nft are required
+		if err := c.firewall.Check(); err != nil {
+			fail(config.PreflightCheckEgress, err)
+		}
+	}
+
+	// dry-run only a service that could start
+	if verify && len(failures) == 0 {
+		// run the verify command under the service credentials
+		if err := c.verifyBinary(svc); err != nil {
+			fail(config.PreflightCheckVerify, err)
+		}
+	}
+
+	// return collected failures
+	return failures
+}
+
+// verifyBinary runs the verify commands of a service until one succeeds.
+//
+// Params:
+//   - svc: the service configuration.
+//
+// Returns:
+//   - error: the failure of the first command, nil if any command exits 0.
+func (c *Checker) verifyBinary(svc *config.ServiceConfig) error {
+	var first error
+	// try each command in order
+	for _, fields := range svc.VerifyCommands() {
+		err := c.runVerify(svc, fields)
+		// the binary runs
+		if err == nil {
+			// verification passed
+			return nil
+		}
+		// keep the failure of the primary command
+		if first == nil {
+			first = err
+		}
+	}
+	// return the primary failure
+	return first
+}
+
+// runVerify runs one verify command as the service would run: under its
+// user and group, in its working directory, with its environment.
+//
+// Params:
+//   - svc: the service configuration.
+//   - fields: the program and its arguments.
+//
+// Returns:
+//   - error: if the command cannot start, fails or times out, with its output.
+func (c *Checker) runVerify(svc *config.ServiceConfig, fields []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), svc.EffectiveVerifyTimeout())
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Dir = svc.WorkingDirectory
+	cmd.Env = os.Environ()
+	// merge the service environment
+	for k, v := range svc.Environment {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.WaitDelay = waitDelay
+
+	// drop privileges as the executor does
+	if svc.User != "" || svc.Group != "" {
+		uid, gid, err := c.creds.ResolveCredentials(svc.User, svc.Group)
+		// credentials cannot be resolved
+		if err != nil {
+			// return resolution error
+			return fmt.Errorf("resolving credentials: %w", err)
+		}
+		// credentials cannot be applied
+		if err := c.creds.ApplyCredentials(cmd, uid, gid); err != nil {
+			// return application error
+			return fmt.Errorf("applying credentials: %w", err)
+		}
+	}
+
+	out, err := cmd.CombinedOutput()
+	// command failed
+	if err != nil {
+		// report the timeout rather than the kill
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		// return error with the command output
+		return fmt.Errorf("%s: %w%s", strings.Join(fields, " "), err, excerpt(out))
+	}
+	// command succeeded
+	return nil
+}
+
+// excerpt formats the end of a command output for a failure detail.
+//
+// Params:
+//   - out: the command output.
+//
+// Returns:
+//   - string: ": <output>", empty without output.
+func excerpt(out []byte) string {
+	text := strings.TrimSpace(string(out))
+	// nothing to quote
+	if text == "" {
+		// return empty excerpt
+		return ""
+	}
+	// keep the end, where errors are printed
+	if len(text) > maxOutput {
+		text = "..." + text[len(text)-maxOutput:]
+	}
+	// return quoted output
+	return ": " + text
+}
+
+// checkDirectory verifies that a working directory exists.
+//
+// Params:
+//   - dir: the working directory, empty to inherit the daemon's.
+//
+// Returns:
+//   - error: nil if the directory exists or is unset.
+func checkDirectory(dir string) error {
+	// unset directory inherits the daemon's
+	if dir == "" {
+		// nothing to check
+		return nil
+	}
+
+	info, err := os.Stat(dir)
+	// directory missing or inaccessible
+	if err != nil {
+		// report stat error
+		return err
+	}
+
+	// path exists but is a file
+	if !info.IsDir() {
+		// report wrong file type
+		return fmt.Errorf("%s: %w", dir, errNotDirectory)
+	}
+
+	// directory exists
+	return nil
+}
+
+// checkBinary verifies that the command binary exists and is executable.
+// Relative paths are resolved against the working directory, matching
+// how the executor starts the process.
+//
+// Params:
+//   - command: the service command line.
+//   - dir: the service working directory.
+//
+// Returns:
+//   - error: nil if the binary is executable.
+func checkBinary(command, dir string) error {
+	parts := strings.Fields(command)
+	// validation rejects empty commands; nothing to resolve here
+	if len(parts) == 0 {
+		// skip empty command
+		return nil
+	}
+
+	binary := parts[0]
+	// relative paths are evaluated from the working directory
+	if strings.Contains(binary, "/") && !filepath.IsAbs(binary) && dir != "" {
+		binary = filepath.Join(dir, binary)
+	}
+
+	_, err := exec.LookPath(binary)
+	// return lookup result
+	return err
+}
+
+// checkPorts detects listeners of different services or of the same service
+// binding the same protocol and port on overlapping addresses.
+//
+// Params:
+//   - services: the configured services.
+//
+// Returns:
+//   - []config.PreflightFailure: one failure per conflicting listener.
+func checkPorts(services []config.ServiceConfig) []config.PreflightFailure {
+	conflicts := config.FindListenerConflicts(services)
+	failures := make([]config.PreflightFailure, 0, len(conflicts))
+
+	// report each conflict against the listener declared last
+	for _, c := range conflicts {
+		failures = append(failures, config.PreflightFailure{
+			Service: c.Service,
+			Check:   config.PreflightCheckPort,
+			Detail: fmt.Sprintf("listener %q binds %s/%d which is already bound by service %q listener %q",
+				c.Listener.Name, c.Listener.EffectiveProtocol(), c.Listener.Port, c.OwnerService, c.Owner.Name),
+		})
+	}
+
+	// return collected conflicts
+	return failures
+}
@@ -0,0 +1,336 @@
+// Package preflight_test provides black-box tests for the preflight package.
+package preflight_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/process/credentials"
+	"github.com/kodflow/daemon/internal/infrastructure/process/egress"
+	"github.com/kodflow/daemon/internal/infrastructure/process/lsm"
+	"github.com/kodflow/daemon/internal/infrastructure/process/preflight"
+)
+
+// stubCredentials resolves only the users and groups it knows about.
+type stubCredentials struct {
+	credentials.CredentialManager
+}
+
+// LookupUser resolves the "app" user.
+//
+// Params:
+//   - nameOrID: the username or UID.
+//
+// Returns:
+//   - *credentials.User: the user if known.
+//   - error: ErrUserNotFound otherwise.
+func (s stubCredentials) LookupUser(nameOrID string) (*credentials.User, error) {
+	// only "app" exists
+	if nameOrID != "app" {
+		// unknown user
+		return nil, credentials.ErrUserNotFound
+	}
+	// known user
+	return &credentials.User{Username: nameOrID}, nil
+}
+
+// LookupGroup resolves the "app" group.
+//
+// Params:
+//   - nameOrID: the group name or GID.
+//
+// Returns:
+//   - *credentials.Group: the group if known.
+//   - error: ErrGroupNotFound otherwise.
+func (s stubCredentials) LookupGroup(nameOrID string) (*credentials.Group, error) {
+	// only "app" exists
+	if nameOrID != "app" {
+		// unknown group
+		return nil, credentials.ErrGroupNotFound
+	}
+	// known group
+	return &credentials.Group{Name: nameOrID}, nil
+}
+
+// writeExecutable creates an executable script in dir.
+//
+// Params:
+//   - t: the testing context.
+//   - dir: the target directory.
+//   - name: the file name.
+//
+// Returns:
+//   - string: the absolute path of the script.
+func writeExecutable(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+	// return script path
+	return path
+}
+
+// TestChecker_Preflight_securityLabel tests that labels the host cannot
+// apply are reported.
+func TestChecker_Preflight_securityLabel(t *testing.T) {
+	// The host must lack AppArmor.
+	if lsm.New().AppArmorEnabled() {
+		t.Skip("apparmor enabled on this host")
+	}
+	binary := writeExecutable(t, t.TempDir(), "app")
+
+	err := preflight.New(stubCredentials{}).Preflight(&config.Config{Services: []config.ServiceConfig{
+		{Name: "api", Command: binary, AppArmorProfile: "api"},
+	}})
+
+	var report *config.PreflightError
+	require.ErrorAs(t, err, &report)
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, config.PreflightCheckSecurityLabel, report.Failures[0].Check)
+	assert.Contains(t, report.Failures[0].Detail, "apparmor")
+}
+
+// TestChecker_Preflight_egress tests that egress rules the host cannot
+// enforce are reported.
+func TestChecker_Preflight_egress(t *testing.T) {
+	// The host must lack cgroup v2 or nft.
+	if egress.New().Check() == nil {
+		t.Skip("egress rules enforceable on this host")
+	}
+	binary := writeExecutable(t, t.TempDir(), "app")
+
+	err := preflight.New(stubCredentials{}).Preflight(&config.Config{Services: []config.ServiceConfig{
+		{Name: "api", Command: binary, Egress: []config.EgressRule{{CIDR: "10.0.0.0/8"}}},
+	}})
+
+	var report *config.PreflightError
+	require.ErrorAs(t, err, &report)
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, config.PreflightCheckEgress, report.Failures[0].Check)
+}
+
+// TestChecker_Preflight tests pre-flight checks against the host.
+func TestChecker_Preflight(t *testing.T) {
+	dir := t.TempDir()
+	binary := writeExecutable(t, dir, "app")
+	writeExecutable(t, dir, "relative")
+	notExecutable := filepath.Join(dir, "data.txt")
+	require.NoError(t, os.WriteFile(notExecutable, []byte("x"), 0o644))
+	_, shErr := exec.LookPath("sh")
+	require.NoError(t, shErr)
+
+	tests := []struct {
+		name     string
+		services []config.ServiceConfig
+		want     []string
+	}{
+		{
+			name: "all_checks_pass",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: binary + " --serve", User: "app", Group: "app", WorkingDirectory: dir},
+				{Name: "shell", Command: "sh -c true"},
+				{Name: "rel", Command: "./relative", WorkingDirectory: dir},
+			},
+		},
+		{
+			name: "missing_binary",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: filepath.Join(dir, "missing")},
+			},
+			want: []string{config.PreflightCheckBinary},
+		},
+		{
+			name: "binary_not_executable",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: notExecutable},
+			},
+			want: []string{config.PreflightCheckBinary},
+		},
+		{
+			name: "missing_working_directory",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: binary, WorkingDirectory: filepath.Join(dir, "nope")},
+			},
+			want: []string{config.PreflightCheckWorkingDirectory},
+		},
+		{
+			name: "working_directory_is_file",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: binary, WorkingDirectory: binary},
+			},
+			want: []string{config.PreflightCheckWorkingDirectory},
+		},
+		{
+			name: "unknown_user_and_group",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: binary, User: "ghost", Group: "ghost"},
+			},
+			want: []string{config.PreflightCheckUser, config.PreflightCheckGroup},
+		},
+		{
+			name: "port_conflict_across_services",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: binary, Listeners: []config.ListenerConfig{{Name: "http", Port: 8080}}},
+				{Name: "web", Command: binary, Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Address: "127.0.0.1"}}},
+			},
+			want: []string{config.PreflightCheckPort},
+		},
+		{
+			name: "distinct_addresses_and_protocols",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: binary, Listeners: []config.ListenerConfig{
+					{Name: "http", Port: 8080, Address: "127.0.0.1"},
+					{Name: "dns", Port: 8080, Protocol: "udp"},
+				}},
+				{Name: "web", Command: binary, Listeners: []config.ListenerConfig{{Name: "http", Port: 8080, Address: "10.0.0.1"}}},
+			},
+		},
+	}
+
+	checker := preflight.New(stubCredentials{})
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checker.Preflight(&config.Config{Services: tt.services})
+
+			// Verify a passing config yields no error.
+			if len(tt.want) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var report *config.PreflightError
+			require.ErrorAs(t, err, &report)
+			checks := make([]string, 0, len(report.Failures))
+			// Collect failed check names.
+			for _, f := range report.Failures {
+				checks = append(checks, f.Check)
+			}
+			assert.Equal(t, tt.want, checks)
+		})
+	}
+}
+
+// writeScript creates an executable shell script in dir.
+//
+// Params:
+//   - t: the testing context.
+//   - dir: the target directory.
+//   - name: the file name.
+//   - body: the script body.
+//
+// Returns:
+//   - string: the absolute path of the script.
+func writeScript(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755))
+	// return script path
+	return path
+}
+
+// TestChecker_Preflight_verify tests that reloads dry-run only services
+// with a verify command.
+func TestChecker_Preflight_verify(t *testing.T) {
+	dir := t.TempDir()
+	broken := writeScript(t, dir, "broken", "echo missing library >&2; exit 127")
+
+	err := preflight.New(stubCredentials{}).Preflight(&config.Config{Services: []config.ServiceConfig{
+		{Name: "api", Command: broken},
+		{Name: "web", Command: broken, VerifyCommand: broken + " --check"},
+	}})
+
+	var report *config.PreflightError
+	require.ErrorAs(t, err, &report)
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, "web", report.Failures[0].Service)
+	assert.Equal(t, config.PreflightCheckVerify, report.Failures[0].Check)
+	assert.Contains(t, report.Failures[0].Detail, "missing library")
+}
+
+// TestChecker_Verify tests dry runs of service binaries.
+func TestChecker_Verify(t *testing.T) {
+	dir := t.TempDir()
+	versioned := writeScript(t, dir, "versioned", `[ "$1" = --version ]`)
+	helpOnly := writeScript(t, dir, "help-only", `[ "$1" = --help ]`)
+	broken := writeScript(t, dir, "broken", "echo missing library >&2; exit 127")
+	envCheck := writeScript(t, dir, "env-check", `[ "$MODE" = verify ] && [ "$(pwd)" = "`+dir+`" ]`)
+	slow := writeScript(t, dir, "slow", "exec sleep 5")
+
+	tests := []struct {
+		name     string
+		services []config.ServiceConfig
+		names    []string
+		want     []string
+	}{
+		{
+			name:     "version_flag",
+			services: []config.ServiceConfig{{Name: "api", Command: versioned + " --serve"}},
+		},
+		{
+			name:     "help_fallback",
+			services: []config.ServiceConfig{{Name: "api", Command: helpOnly}},
+		},
+		{
+			name:     "binary_fails",
+			services: []config.ServiceConfig{{Name: "api", Command: broken}},
+			want:     []string{config.PreflightCheckVerify},
+		},
+		{
+			name: "service_environment_and_directory",
+			services: []config.ServiceConfig{{
+				Name: "api", Command: broken, VerifyCommand: envCheck,
+				WorkingDirectory: dir, Environment: map[string]string{"MODE": "verify"},
+			}},
+		},
+		{
+			name: "timeout",
+			services: []config.ServiceConfig{{
+				Name: "api", Command: broken, VerifyCommand: slow, VerifyTimeout: shared.Duration(50 * time.Millisecond),
+			}},
+			want: []string{config.PreflightCheckVerify},
+		},
+		{
+			name:     "spec_failure_skips_dry_run",
+			services: []config.ServiceConfig{{Name: "api", Command: filepath.Join(dir, "missing")}},
+			want:     []string{config.PreflightCheckBinary},
+		},
+		{
+			name: "selected_services_only",
+			services: []config.ServiceConfig{
+				{Name: "api", Command: versioned},
+				{Name: "web", Command: broken},
+			},
+			names: []string{"api"},
+		},
+	}
+
+	checker := preflight.New(stubCredentials{})
+	// Iterate through test cases.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checker.Verify(&config.Config{Services: tt.services}, tt.names)
+			// Verify a passing service yields no error.
+			if len(tt.want) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var report *config.PreflightError
+			require.ErrorAs(t, err, &report)
+			checks := make([]string, 0, len(report.Failures))
+			// Collect failed check names.
+			for _, f := range report.Failures {
+				checks = append(checks, f.Check)
+			}
+			assert.Equal(t, tt.want, checks)
+		})
+	}
+}
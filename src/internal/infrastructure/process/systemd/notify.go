@@ -0,0 +1,220 @@
+// Package systemd integrates the daemon with the systemd service manager
+// it runs under. The daemon reports its state over the notification socket
+// (READY, RELOADING, STOPPING, STATUS), pets the service watchdog, and
+// tells whether its standard error is connected to the journal.
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Environment passed by systemd to the services it starts.
+const (
+	// notifySocketEnv is the notification socket, set with Type=notify or NotifyAccess.
+	notifySocketEnv string = "NOTIFY_SOCKET"
+	// watchdogUsecEnv is the watchdog timeout in microseconds, set with WatchdogSec.
+	watchdogUsecEnv string = "WATCHDOG_USEC"
+	// watchdogPIDEnv is the process the watchdog applies to.
+	watchdogPIDEnv string = "WATCHDOG_PID"
+)
+
+// Notification states.
+const (
+	// stateReady reports that the service finished starting or reloading.
+	stateReady string = "READY=1"
+	// stateReloading reports that the service is reloading its configuration.
+	stateReloading string = "RELOADING=1"
+	// stateStopping reports that the service is shutting down.
+	stateStopping string = "STOPPING=1"
+	// stateWatchdog pets the service watchdog.
+	stateWatchdog string = "WATCHDOG=1"
+	// statusPrefix starts the free-form status shown by systemctl status.
+	statusPrefix string = "STATUS="
+	// monotonicPrefix starts the monotonic time of a reload, in microseconds.
+	monotonicPrefix string = "MONOTONIC_USEC="
+)
+
+// Notifier sends state notifications to systemd. Without a notification
+// socket, every notification is a no-op.
+type Notifier struct {
+	// socket is the notification socket, empty when not run by systemd.
+	socket string
+	// watchdog is the watchdog timeout, zero when disabled.
+	watchdog time.Duration
+}
+
+// New reads the notification socket and the watchdog timeout systemd
+// passed to the daemon, then removes them from the environment so the
+// supervised services do not inherit them. The watchdog is ignored when
+// systemd meant it for another process.
+//
+// Returns:
+//   - *Notifier: the notifier, disabled when not run by systemd.
+func New() *Notifier {
+	notifier := &Notifier{
+		socket:   os.Getenv(notifySocketEnv),
+		watchdog: parseWatchdog(os.Getenv(watchdogUsecEnv), os.Getenv(watchdogPIDEnv), os.Getpid()),
+	}
+	_ = os.Unsetenv(notifySocketEnv)
+	_ = os.Unsetenv(watchdogUsecEnv)
+	_ = os.Unsetenv(watchdogPIDEnv)
+	// return the notifier
+	return notifier
+}
+
+// parseWatchdog parses the watchdog timeout passed by systemd.
+//
+// Params:
+//   - usec: the WATCHDOG_USEC value.
+//   - pid: the WATCHDOG_PID value, empty for the main process.
+//   - self: the PID of the daemon.
+//
+// Returns:
+//   - time.Duration: the watchdog timeout, zero when disabled or meant for another process.
+func parseWatchdog(usec, pid string, self int) time.Duration {
+	// the watchdog targets another process
+	if pid != "" && pid != strconv.Itoa(self) {
+		// not ours to pet
+		return 0
+	}
+	value, err := strconv.ParseInt(usec, shared.Base10, shared.BitSize64)
+	// the watchdog is disabled or malformed
+	if err != nil || value <= 0 {
+		// no watchdog
+		return 0
+	}
+	// return the timeout
+	return time.Duration(value) * time.Microsecond
+}
+
+// Enabled reports whether the daemon was started by systemd with a
+// notification socket.
+//
+// Returns:
+//   - bool: true when notifications are delivered.
+func (n *Notifier) Enabled() bool {
+	// return socket presence
+	return n.socket != ""
+}
+
+// WatchdogInterval returns the watchdog timeout set with WatchdogSec.
+// The watchdog must be petted well within it.
+//
+// Returns:
+//   - time.Duration: the timeout, zero when the watchdog is disabled.
+func (n *Notifier) WatchdogInterval() time.Duration {
+	// return the timeout
+	return n.watchdog
+}
+
+// Ready reports that the daemon finished starting, or reloading.
+//
+// Params:
+//   - status: the status shown by systemctl status.
+//
+// Returns:
+//   - error: if the notification cannot be sent.
+func (n *Notifier) Ready(status string) error {
+	// send ready with its status
+	return n.notify(stateReady, statusPrefix+singleLine(status))
+}
+
+// Reloading reports that the daemon is reloading its configuration, with
+// the monotonic time systemd requires for Type=notify-reload.
+//
+// Params:
+//   - status: the status shown by systemctl status.
+//
+// Returns:
+//   - error: if the notification cannot be sent.
+func (n *Notifier) Reloading(status string) error {
+	states := []string{stateReloading}
+	// systemd matches the reload to the request by its time
+	if usec, ok := monotonicUsec(); ok {
+		states = append(states, monotonicPrefix+strconv.FormatInt(usec, shared.Base10))
+	}
+	// send reloading with its status
+	return n.notify(append(states, statusPrefix+singleLine(status))...)
+}
+
+// Stopping reports that the daemon is shutting down.
+//
+// Params:
+//   - status: the status shown by systemctl status.
+//
+// Returns:
+//   - error: if the notification cannot be sent.
+func (n *Notifier) Stopping(status string) error {
+	// send stopping with its status
+	return n.notify(stateStopping, statusPrefix+singleLine(status))
+}
+
+// Status updates the status shown by systemctl status.
+//
+// Params:
+//   - status: the status.
+//
+// Returns:
+//   - error: if the notification cannot be sent.
+func (n *Notifier) Status(status string) error {
+	// send the status alone
+	return n.notify(statusPrefix + singleLine(status))
+}
+
+// Watchdog pets the service watchdog.
+//
+// Returns:
+//   - error: if the notification cannot be sent.
+func (n *Notifier) Watchdog() error {
+	// send the keep-alive
+	return n.notify(stateWatchdog)
+}
+
+// notify sends one datagram holding the states, one per line. A socket
+// starting with @ is in the abstract namespace.
+//
+// Params:
+//   - states: the VARIABLE=value states.
+//
+// Returns:
+//   - error: if the socket cannot be reached.
+func (n *Notifier) notify(states ...string) error {
+	// not run by systemd
+	if n.socket == "" {
+		// nothing to notify
+		return nil
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: n.socket, Net: "unixgram"})
+	// the socket is gone
+	if err != nil {
+		// return the dial error
+		return fmt.Errorf("notify systemd: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	// send the datagram
+	if _, err := conn.Write([]byte(strings.Join(states, "\n") + "\n")); err != nil {
+		// return the write error
+		return fmt.Errorf("notify systemd: %w", err)
+	}
+	// notification sent
+	return nil
+}
+
+// singleLine keeps a status on one line, as each state takes one line.
+//
+// Params:
+//   - status: the status.
+//
+// Returns:
+//   - string: the status with line breaks replaced by spaces.
+func singleLine(status string) string {
+	// return the flattened status
+	return strings.ReplaceAll(status, "\n", " ")
+}
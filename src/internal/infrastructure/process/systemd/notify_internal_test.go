@@ -0,0 +1,127 @@
+// Package systemd provides internal tests for notify.go.
+// It tests the notifications using white-box testing.
+package systemd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenNotify opens a datagram socket standing in for systemd.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - string: the socket path.
+//   - func() string: reads the next notification.
+func listenNotify(t *testing.T) (string, func() string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return path, func() string {
+		buf := make([]byte, 1024)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+}
+
+// Test_parseWatchdog tests the watchdog timeout and its target process.
+//
+// Params:
+//   - t: the testing context.
+func Test_parseWatchdog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		// name is the test case name.
+		name string
+		// usec is WATCHDOG_USEC.
+		usec string
+		// pid is WATCHDOG_PID.
+		pid string
+		// want is the expected timeout.
+		want time.Duration
+	}{
+		{name: "main_process", usec: "30000000", want: 30 * time.Second},
+		{name: "this_process", usec: "30000000", pid: "42", want: 30 * time.Second},
+		{name: "other_process", usec: "30000000", pid: "43"},
+		{name: "disabled"},
+		{name: "malformed", usec: "soon"},
+	}
+
+	// Iterate through all test cases.
+	for _, tt := range tests {
+		// Run each test case as a subtest.
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, parseWatchdog(tt.usec, tt.pid, 42))
+		})
+	}
+}
+
+// TestNew tests the environment is read, then removed.
+//
+// Params:
+//   - t: the testing context.
+func TestNew(t *testing.T) {
+	t.Setenv(notifySocketEnv, "/run/systemd/notify")
+	t.Setenv(watchdogUsecEnv, "10000000")
+	t.Setenv(watchdogPIDEnv, strconv.Itoa(os.Getpid()))
+
+	notifier := New()
+
+	assert.True(t, notifier.Enabled())
+	assert.Equal(t, 10*time.Second, notifier.WatchdogInterval())
+	// Supervised services do not inherit the variables.
+	for _, name := range []string{notifySocketEnv, watchdogUsecEnv, watchdogPIDEnv} {
+		_, found := os.LookupEnv(name)
+		assert.False(t, found, name)
+	}
+}
+
+// TestNotifier_notify tests the datagram of each notification.
+//
+// Params:
+//   - t: the testing context.
+func TestNotifier_notify(t *testing.T) {
+	t.Parallel()
+
+	path, next := listenNotify(t)
+	notifier := &Notifier{socket: path}
+
+	require.NoError(t, notifier.Ready("Boot completed"))
+	assert.Equal(t, "READY=1\nSTATUS=Boot completed\n", next())
+
+	require.NoError(t, notifier.Reloading("Reloading\nconfiguration"))
+	reloading := next()
+	assert.True(t, strings.HasPrefix(reloading, "RELOADING=1\n"), reloading)
+	assert.True(t, strings.HasSuffix(reloading, "STATUS=Reloading configuration\n"), reloading)
+
+	require.NoError(t, notifier.Stopping("Stopping services"))
+	assert.Equal(t, "STOPPING=1\nSTATUS=Stopping services\n", next())
+
+	require.NoError(t, notifier.Status("3 services running"))
+	assert.Equal(t, "STATUS=3 services running\n", next())
+
+	require.NoError(t, notifier.Watchdog())
+	assert.Equal(t, "WATCHDOG=1\n", next())
+
+	// A vanished socket is reported.
+	assert.Error(t, (&Notifier{socket: filepath.Join(t.TempDir(), "gone.sock")}).Watchdog())
+	// Without systemd, notifications are no-ops.
+	assert.NoError(t, (&Notifier{}).Ready("Boot completed"))
+	assert.False(t, (&Notifier{}).Enabled())
+}
@@ -0,0 +1,49 @@
+//go:build linux
+
+// Package systemd integrates the daemon with the systemd service manager it runs under.
+package systemd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// journalStreamEnv is the device and inode of the journal stream systemd
+// connected standard output and error to.
+const journalStreamEnv string = "JOURNAL_STREAM"
+
+// monotonicUsec reads the monotonic clock systemd stamps reloads with.
+//
+// Returns:
+//   - int64: the clock in microseconds.
+//   - bool: false when the clock cannot be read.
+func monotonicUsec() (int64, bool) {
+	var ts unix.Timespec
+	// read the clock shared with systemd
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		// clock unavailable
+		return 0, false
+	}
+	// return microseconds
+	return ts.Nano() / int64(time.Microsecond), true
+}
+
+// JournalStream reports whether standard error is the journal stream
+// systemd opened for the daemon, rather than a terminal or a redirection
+// made later. Logs are then better sent to the journal with their fields.
+//
+// Returns:
+//   - bool: true when standard error is connected to the journal.
+func JournalStream() bool {
+	var st unix.Stat_t
+	// a closed standard error is no stream
+	if err := unix.Fstat(int(os.Stderr.Fd()), &st); err != nil {
+		// not the journal
+		return false
+	}
+	// compare with the stream systemd announced
+	return os.Getenv(journalStreamEnv) == fmt.Sprintf("%d:%d", st.Dev, st.Ino)
+}
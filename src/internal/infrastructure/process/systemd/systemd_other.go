@@ -0,0 +1,23 @@
+//go:build !linux
+
+// Package systemd integrates the daemon with the systemd service manager it runs under.
+package systemd
+
+// monotonicUsec has no clock shared with systemd outside Linux.
+//
+// Returns:
+//   - int64: always zero.
+//   - bool: always false.
+func monotonicUsec() (int64, bool) {
+	// no systemd
+	return 0, false
+}
+
+// JournalStream reports no journal outside Linux.
+//
+// Returns:
+//   - bool: always false.
+func JournalStream() bool {
+	// no systemd
+	return false
+}
@@ -0,0 +1,102 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// ServiceBatcher applies lifecycle actions to several services at once.
+type ServiceBatcher interface {
+	// RunBatch applies an action to the services of a request and returns
+	// the outcome of each.
+	RunBatch(req *lifecycle.BatchRequest) ([]lifecycle.BatchResult, error)
+}
+
+// serviceActions maps protobuf actions to domain service actions.
+var serviceActions map[daemonpb.ServiceAction]lifecycle.ServiceAction = map[daemonpb.ServiceAction]lifecycle.ServiceAction{
+	daemonpb.ServiceAction_SERVICE_ACTION_START:   lifecycle.ActionStart,
+	daemonpb.ServiceAction_SERVICE_ACTION_STOP:    lifecycle.ActionStop,
+	daemonpb.ServiceAction_SERVICE_ACTION_RESTART: lifecycle.ActionRestart,
+}
+
+// SetServiceBatcher sets the target of batch requests.
+// Without a batcher, BatchServices returns Unimplemented.
+//
+// Params:
+//   - batcher: the service batcher.
+func (s *Server) SetServiceBatcher(batcher ServiceBatcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store service batcher
+	s.batcher = batcher
+}
+
+// BatchServices implements DaemonService.BatchServices.
+// Failures of single services are reported in their result, not as the
+// error of the call.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the action and the target services.
+//
+// Returns:
+//   - *daemonpb.BatchServicesResponse: the outcome of each service.
+//   - error: if batches are not configured, or the action or targets are
+//     invalid.
+func (s *Server) BatchServices(_ context.Context, req *daemonpb.BatchServicesRequest) (*daemonpb.BatchServicesResponse, error) {
+	s.mu.Lock()
+	batcher := s.batcher
+	s.mu.Unlock()
+
+	// Check if batches are configured.
+	if batcher == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "service batches not configured")
+	}
+	action, ok := serviceActions[req.Action]
+	// Check if the action is set.
+	if !ok {
+		// Report invalid action.
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported service action %s", req.Action)
+	}
+
+	results, err := batcher.RunBatch(&lifecycle.BatchRequest{
+		Action:      action,
+		Services:    req.ServiceNames,
+		Selector:    req.LabelSelector,
+		MaxParallel: int(req.MaxParallel),
+	})
+	// Check if the batch was refused.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("batch services: %w", err)
+	}
+
+	resp := &daemonpb.BatchServicesResponse{
+		Results: make([]*daemonpb.ServiceActionResult, 0, len(results)),
+		Failed:  safeInt32(lifecycle.BatchFailures(results)),
+	}
+	// Convert each result.
+	for i := range results {
+		result := &daemonpb.ServiceActionResult{
+			ServiceName: results[i].Service,
+			Snapshot:    s.convertServiceSnapshot(&results[i].Snapshot),
+		}
+		// Report the error of failed services.
+		if results[i].Err != nil {
+			result.Error = results[i].Err.Error()
+			result.ErrorCode = string(shared.CodeOf(results[i].Err))
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	// Return converted results.
+	return resp, nil
+}
@@ -0,0 +1,126 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockServiceBatcher records the batch request and fails unknown services.
+type mockServiceBatcher struct {
+	known map[string]bool
+	req   *lifecycle.BatchRequest
+	err   error
+}
+
+func (m *mockServiceBatcher) RunBatch(req *lifecycle.BatchRequest) ([]lifecycle.BatchResult, error) {
+	m.req = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	results := make([]lifecycle.BatchResult, 0, len(req.Services))
+	for _, name := range req.Services {
+		result := lifecycle.BatchResult{Service: name, Snapshot: process.ServiceSnapshot{Name: name}}
+		if m.known[name] {
+			result.Snapshot.State, result.Snapshot.PID = process.StateRunning, 42
+		} else {
+			result.Err = shared.NewCodedError(shared.CodeServiceNotFound, "service not found: "+name)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// TestServer_BatchServices verifies batch requests and per-service results.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_BatchServices(t *testing.T) {
+	t.Parallel()
+
+	batcher := &mockServiceBatcher{known: map[string]bool{"web": true}}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetServiceBatcher(batcher)
+
+	resp, err := server.BatchServices(context.Background(), &daemonpb.BatchServicesRequest{
+		Action:        daemonpb.ServiceAction_SERVICE_ACTION_RESTART,
+		ServiceNames:  []string{"web", "missing"},
+		LabelSelector: map[string]string{"team": "payments"},
+		MaxParallel:   2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &lifecycle.BatchRequest{
+		Action:      lifecycle.ActionRestart,
+		Services:    []string{"web", "missing"},
+		Selector:    map[string]string{"team": "payments"},
+		MaxParallel: 2,
+	}, batcher.req)
+
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, int32(1), resp.Failed)
+	assert.Equal(t, "web", resp.Results[0].ServiceName)
+	assert.Empty(t, resp.Results[0].Error)
+	assert.Equal(t, daemonpb.ProcessState_PROCESS_STATE_RUNNING, resp.Results[0].Snapshot.State)
+	assert.Equal(t, int32(42), resp.Results[0].Snapshot.Pid)
+	assert.Equal(t, "missing", resp.Results[1].ServiceName)
+	assert.Equal(t, string(shared.CodeServiceNotFound), resp.Results[1].ErrorCode)
+	assert.Contains(t, resp.Results[1].Error, "missing")
+}
+
+// TestServer_BatchServices_Errors verifies refused batches.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_BatchServices_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		batcher  grpc.ServiceBatcher
+		action   daemonpb.ServiceAction
+		wantCode codes.Code
+	}{
+		{name: "not configured", action: daemonpb.ServiceAction_SERVICE_ACTION_START, wantCode: codes.Unimplemented},
+		{name: "unspecified action", batcher: &mockServiceBatcher{}, wantCode: codes.InvalidArgument},
+		{
+			name:     "refused batch",
+			batcher:  &mockServiceBatcher{err: shared.NewCodedError(shared.CodeInvalidArgument, "no target")},
+			action:   daemonpb.ServiceAction_SERVICE_ACTION_STOP,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			if tt.batcher != nil {
+				server.SetServiceBatcher(tt.batcher)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, err := server.BatchServices(ctx, &daemonpb.BatchServicesRequest{Action: tt.action})
+			require.Error(t, err)
+			// Handler errors are converted by the server interceptor.
+			if _, ok := status.FromError(err); !ok {
+				assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+				return
+			}
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+// BootReporter provides the outcome of the initial startup.
+type BootReporter interface {
+	// BootReport returns the boot report so far.
+	BootReport() lifecycle.BootReport
+}
+
+// bootStatuses maps domain boot statuses to protobuf values.
+var bootStatuses map[lifecycle.BootStatus]daemonpb.BootStatus = map[lifecycle.BootStatus]daemonpb.BootStatus{
+	lifecycle.BootPending: daemonpb.BootStatus_BOOT_STATUS_PENDING,
+	lifecycle.BootReady:   daemonpb.BootStatus_BOOT_STATUS_READY,
+	lifecycle.BootFailed:  daemonpb.BootStatus_BOOT_STATUS_FAILED,
+	lifecycle.BootSkipped: daemonpb.BootStatus_BOOT_STATUS_SKIPPED,
+}
+
+// SetBootReporter sets the source of the boot report.
+// Without a reporter, GetBootReport returns Unimplemented.
+//
+// Params:
+//   - reporter: the boot reporter.
+func (s *Server) SetBootReporter(reporter BootReporter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store boot reporter
+	s.bootReporter = reporter
+}
+
+// GetBootReport implements DaemonService.GetBootReport.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.BootReport: the boot report.
+//   - error: if boot reporting is not configured.
+func (s *Server) GetBootReport(_ context.Context, _ *emptypb.Empty) (*daemonpb.BootReport, error) {
+	s.mu.Lock()
+	reporter := s.bootReporter
+	s.mu.Unlock()
+
+	// Check if boot reporting is configured.
+	if reporter == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "boot report not configured")
+	}
+
+	report := reporter.BootReport()
+	// Return converted report.
+	return convertBootReport(&report), nil
+}
+
+// convertBootReport converts a boot report to protobuf format.
+//
+// Params:
+//   - report: the boot report.
+//
+// Returns:
+//   - *daemonpb.BootReport: protobuf boot report.
+func convertBootReport(report *lifecycle.BootReport) *daemonpb.BootReport {
+	services := make([]*daemonpb.ServiceBoot, 0, len(report.Services))
+	// Convert each service outcome.
+	for i := range report.Services {
+		svc := &report.Services[i]
+		services = append(services, &daemonpb.ServiceBoot{
+			ServiceName: svc.Name,
+			Critical:    svc.Critical,
+			Status:      bootStatuses[svc.Status],
+			Duration:    durationpb.New(svc.Duration),
+			Error:       svc.Error,
+		})
+	}
+	pb := &daemonpb.BootReport{
+		StartedAt: timestamppb.New(report.StartedAt),
+		Aborted:   report.Aborted,
+		Services:  services,
+	}
+	// Set completion time only once the boot is over.
+	if report.Complete() {
+		pb.CompletedAt = timestamppb.New(report.CompletedAt)
+	}
+	// Return converted report.
+	return pb
+}
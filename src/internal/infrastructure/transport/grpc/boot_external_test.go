@@ -0,0 +1,96 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockBootReporter returns a fixed boot report.
+type mockBootReporter struct {
+	report lifecycle.BootReport
+}
+
+func (m *mockBootReporter) BootReport() lifecycle.BootReport {
+	return m.report
+}
+
+// TestServer_GetBootReport verifies boot report conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetBootReport(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	tests := []struct {
+		name          string
+		report        lifecycle.BootReport
+		wantCompleted bool
+		wantStatuses  []daemonpb.BootStatus
+	}{
+		{
+			name: "booting",
+			report: lifecycle.BootReport{StartedAt: started, Services: []lifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: lifecycle.BootReady, Duration: time.Second},
+				{Name: "db", Critical: true, Status: lifecycle.BootPending},
+			}},
+			wantStatuses: []daemonpb.BootStatus{daemonpb.BootStatus_BOOT_STATUS_READY, daemonpb.BootStatus_BOOT_STATUS_PENDING},
+		},
+		{
+			name: "aborted",
+			report: lifecycle.BootReport{StartedAt: started, CompletedAt: started.Add(time.Second), Aborted: true, Services: []lifecycle.ServiceBoot{
+				{Name: "api", Critical: true, Status: lifecycle.BootFailed, Error: "exited with code 1"},
+			}},
+			wantCompleted: true,
+			wantStatuses:  []daemonpb.BootStatus{daemonpb.BootStatus_BOOT_STATUS_FAILED},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetBootReporter(&mockBootReporter{report: tt.report})
+
+			report, err := server.GetBootReport(context.Background(), &emptypb.Empty{})
+			require.NoError(t, err)
+
+			assert.True(t, report.StartedAt.AsTime().Equal(started))
+			assert.Equal(t, tt.wantCompleted, report.CompletedAt != nil)
+			assert.Equal(t, tt.report.Aborted, report.Aborted)
+			require.Len(t, report.Services, len(tt.wantStatuses))
+			for i, svc := range report.Services {
+				assert.Equal(t, tt.report.Services[i].Name, svc.ServiceName)
+				assert.Equal(t, tt.wantStatuses[i], svc.Status)
+				assert.Equal(t, tt.report.Services[i].Duration, svc.Duration.AsDuration())
+				assert.Equal(t, tt.report.Services[i].Error, svc.Error)
+			}
+		})
+	}
+}
+
+// TestServer_GetBootReport_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetBootReport_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetBootReport(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
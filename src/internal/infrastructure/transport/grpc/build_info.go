@@ -0,0 +1,71 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// SetBuildInfo sets the build of the daemon binary.
+// Without build info, GetBuildInfo returns Unimplemented.
+//
+// Params:
+//   - info: the build of the daemon.
+func (s *Server) SetBuildInfo(info *metrics.BuildInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store build info
+	s.buildInfo = info
+}
+
+// GetBuildInfo implements DaemonService.GetBuildInfo.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.BuildInfo: the build of the daemon.
+//   - error: if build info is not configured.
+func (s *Server) GetBuildInfo(_ context.Context, _ *emptypb.Empty) (*daemonpb.BuildInfo, error) {
+	s.mu.Lock()
+	info := s.buildInfo
+	s.mu.Unlock()
+
+	// Check if build info is configured.
+	if info == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "build info not configured")
+	}
+	// Return converted build info.
+	return convertBuildInfo(info), nil
+}
+
+// convertBuildInfo converts the build of the daemon to protobuf format.
+//
+// Params:
+//   - info: the build of the daemon.
+//
+// Returns:
+//   - *daemonpb.BuildInfo: protobuf build info.
+func convertBuildInfo(info *metrics.BuildInfo) *daemonpb.BuildInfo {
+	pb := &daemonpb.BuildInfo{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		GoVersion: info.GoVersion,
+		Features:  info.Features,
+	}
+	// Leave unknown dates unset.
+	if !info.BuildDate.IsZero() {
+		pb.BuildDate = timestamppb.New(info.BuildDate)
+	}
+	// Return converted build info.
+	return pb
+}
@@ -0,0 +1,76 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// TestServer_GetBuildInfo verifies build info conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	built := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		info metrics.BuildInfo
+	}{
+		{
+			name: "release build",
+			info: metrics.BuildInfo{Version: "1.4.0", Commit: "4f2a9c1", BuildDate: built, GoVersion: "go1.25.6", Features: []string{"cgroups", "probe"}},
+		},
+		{
+			name: "unknown commit and date",
+			info: metrics.BuildInfo{Version: "dev", GoVersion: "go1.25.6"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetBuildInfo(&tt.info)
+
+			resp, err := server.GetBuildInfo(context.Background(), &emptypb.Empty{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.info.Version, resp.Version)
+			assert.Equal(t, tt.info.Commit, resp.Commit)
+			assert.Equal(t, tt.info.GoVersion, resp.GoVersion)
+			assert.Equal(t, tt.info.Features, resp.Features)
+			// unknown dates are left unset
+			if tt.info.BuildDate.IsZero() {
+				assert.Nil(t, resp.BuildDate)
+				return
+			}
+			assert.Equal(t, tt.info.BuildDate, resp.BuildDate.AsTime())
+		})
+	}
+}
+
+// TestServer_GetBuildInfo_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetBuildInfo_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetBuildInfo(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
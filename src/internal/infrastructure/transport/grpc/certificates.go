@@ -0,0 +1,107 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// CertificateProvider provides the checked certificates of tls listeners.
+type CertificateProvider interface {
+	// Certificates returns the checked certificates of a service, in listener order.
+	Certificates(name string) ([]health.CertificateStatus, error)
+}
+
+// SetCertificateProvider sets the source of listener certificates.
+// Without a provider, GetCertificates returns Unimplemented.
+//
+// Params:
+//   - provider: the certificate provider.
+func (s *Server) SetCertificateProvider(provider CertificateProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store certificate provider
+	s.certificates = provider
+}
+
+// GetCertificates implements DaemonService.GetCertificates.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service name.
+//
+// Returns:
+//   - *daemonpb.ServiceCertificates: the checked certificates.
+//   - error: if certificates are not configured or the service is unknown.
+func (s *Server) GetCertificates(_ context.Context, req *daemonpb.GetCertificatesRequest) (*daemonpb.ServiceCertificates, error) {
+	s.mu.Lock()
+	provider := s.certificates
+	s.mu.Unlock()
+
+	// Check if certificates are configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "certificates not configured")
+	}
+
+	statuses, err := provider.Certificates(req.ServiceName)
+	// Check if the lookup failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get certificates: %w", err)
+	}
+
+	resp := &daemonpb.ServiceCertificates{
+		ServiceName:  req.ServiceName,
+		Certificates: make([]*daemonpb.CertificateStatus, 0, len(statuses)),
+	}
+	// Convert each listener certificate.
+	for i := range statuses {
+		resp.Certificates = append(resp.Certificates, convertCertificateStatus(&statuses[i]))
+	}
+	// Return converted certificates.
+	return resp, nil
+}
+
+// convertCertificateStatus converts a certificate status to protobuf format.
+//
+// Params:
+//   - cs: the certificate status.
+//
+// Returns:
+//   - *daemonpb.CertificateStatus: protobuf certificate status.
+func convertCertificateStatus(cs *health.CertificateStatus) *daemonpb.CertificateStatus {
+	pb := &daemonpb.CertificateStatus{
+		Listener: cs.Listener,
+		Address:  cs.Address,
+		Error:    cs.Error,
+	}
+	// Set certificate fields only once a check succeeded.
+	if cs.Checked() {
+		cert := &cs.Certificate
+		pb.Subject = cert.Subject
+		pb.Issuer = cert.Issuer
+		pb.DnsNames = cert.DNSNames
+		pb.SerialNumber = cert.SerialNumber
+		pb.NotBefore = timestamppb.New(cert.NotBefore)
+		pb.NotAfter = timestamppb.New(cert.NotAfter)
+		pb.Fingerprint = cert.Fingerprint
+	}
+	// Check time is unset before the first check.
+	if !cs.LastCheck.IsZero() {
+		pb.LastCheck = timestamppb.New(cs.LastCheck)
+	}
+	// Renewal time is unset until a renewal succeeded.
+	if !cs.RenewedAt.IsZero() {
+		pb.RenewedAt = timestamppb.New(cs.RenewedAt)
+	}
+	// Return converted status.
+	return pb
+}
@@ -0,0 +1,93 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockCertificateProvider returns fixed certificates for one service.
+type mockCertificateProvider struct {
+	service  string
+	statuses []health.CertificateStatus
+}
+
+func (m *mockCertificateProvider) Certificates(name string) ([]health.CertificateStatus, error) {
+	if name != m.service {
+		return nil, errUnknownService
+	}
+	return m.statuses, nil
+}
+
+// TestServer_GetCertificates verifies certificate requests reach the provider.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetCertificates(t *testing.T) {
+	t.Parallel()
+
+	checked := time.Unix(1700000000, 0)
+	provider := &mockCertificateProvider{service: "api", statuses: []health.CertificateStatus{
+		{
+			Listener: "https",
+			Address:  "localhost:8443",
+			Certificate: health.Certificate{
+				Subject:      "CN=api.example.com",
+				Issuer:       "CN=Example CA",
+				DNSNames:     []string{"api.example.com"},
+				SerialNumber: "42",
+				NotBefore:    checked.Add(-24 * time.Hour),
+				NotAfter:     checked.Add(30 * 24 * time.Hour),
+				Fingerprint:  "ab12",
+			},
+			LastCheck: checked,
+			RenewedAt: checked.Add(-time.Hour),
+		},
+		{Listener: "admin", Address: "localhost:9443", LastCheck: checked, Error: "connection refused"},
+	}}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetCertificateProvider(provider)
+
+	resp, err := server.GetCertificates(context.Background(), &daemonpb.GetCertificatesRequest{ServiceName: "api"})
+	require.NoError(t, err)
+	assert.Equal(t, "api", resp.ServiceName)
+	require.Len(t, resp.Certificates, 2)
+	https := resp.Certificates[0]
+	assert.Equal(t, "https", https.Listener)
+	assert.Equal(t, "CN=Example CA", https.Issuer)
+	assert.Equal(t, []string{"api.example.com"}, https.DnsNames)
+	assert.Equal(t, "ab12", https.Fingerprint)
+	assert.Equal(t, checked.Add(30*24*time.Hour).Unix(), https.NotAfter.AsTime().Unix())
+	assert.Equal(t, checked.Add(-time.Hour).Unix(), https.RenewedAt.AsTime().Unix())
+	admin := resp.Certificates[1]
+	assert.Equal(t, "connection refused", admin.Error)
+	assert.Nil(t, admin.NotAfter)
+	assert.Nil(t, admin.RenewedAt)
+	assert.Equal(t, checked.Unix(), admin.LastCheck.AsTime().Unix())
+
+	_, err = server.GetCertificates(context.Background(), &daemonpb.GetCertificatesRequest{ServiceName: "missing"})
+	assert.ErrorIs(t, err, errUnknownService)
+}
+
+// TestServer_GetCertificates_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetCertificates_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetCertificates(context.Background(), &daemonpb.GetCertificatesRequest{ServiceName: "api"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,785 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+// processStates maps protobuf process states to the domain.
+var processStates map[daemonpb.ProcessState]process.State = map[daemonpb.ProcessState]process.State{
+	daemonpb.ProcessState_PROCESS_STATE_STOPPED:  process.StateStopped,
+	daemonpb.ProcessState_PROCESS_STATE_STARTING: process.StateStarting,
+	daemonpb.ProcessState_PROCESS_STATE_RUNNING:  process.StateRunning,
+	daemonpb.ProcessState_PROCESS_STATE_STOPPING: process.StateStopping,
+	daemonpb.ProcessState_PROCESS_STATE_FAILED:   process.StateFailed,
+	daemonpb.ProcessState_PROCESS_STATE_SKIPPED:  process.StateSkipped,
+}
+
+// ProcessQuery filters, sorts and pages the processes listed by ListProcesses.
+// The zero value lists every process, sorted by service name.
+type ProcessQuery struct {
+	// Labels are the labels a service must carry, each with the given value.
+	Labels map[string]string
+	// States are the states a process must be in; empty matches all.
+	States []process.State
+	// OrderBy is the sort key, optionally followed by " desc".
+	OrderBy string
+	// PageSize is the largest number of processes to return; zero returns them all.
+	PageSize int
+	// PageToken is the NextPageToken of the previous page; empty for the first page.
+	PageToken string
+	// Fields are the ProcessMetrics proto fields to fill; empty fills them all.
+	Fields []string
+}
+
+// ProcessPage is a page of the processes listed by ListProcesses.
+type ProcessPage struct {
+	// Processes are the metrics of the processes of the page.
+	Processes []metrics.ProcessMetrics
+	// NextPageToken is the token of the next page, empty on the last page.
+	NextPageToken string
+	// Total is the number of processes matching the query, across all pages.
+	Total int
+}
+
+// Client calls the API of a running daemon.
+type Client struct {
+	conn   *grpc.ClientConn
+	daemon daemonpb.DaemonServiceClient
+}
+
+// NewClient creates a client of the daemon API at address. The connection
+// is established on the first call.
+//
+// Params:
+//   - address: network address of the daemon API (e.g., "localhost:50051").
+//
+// Returns:
+//   - *Client: the client.
+//   - error: if the address is malformed.
+func NewClient(address string) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Check if the target is malformed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("connect %s: %w", address, err)
+	}
+	// Return connected client.
+	return &Client{conn: conn, daemon: daemonpb.NewDaemonServiceClient(conn)}, nil
+}
+
+// Close closes the connection to the daemon.
+//
+// Returns:
+//   - error: if closing fails.
+func (c *Client) Close() error {
+	// Return close result.
+	return c.conn.Close()
+}
+
+// PlanReload asks the daemon what reloading a configuration would do.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - config: the configuration YAML, includes merged.
+//
+// Returns:
+//   - lifecycle.ReloadPlan: what the reload would do.
+//   - error: the daemon error, with its error code.
+func (c *Client) PlanReload(ctx context.Context, config []byte) (lifecycle.ReloadPlan, error) {
+	resp, err := c.daemon.PlanReload(ctx, &daemonpb.PlanReloadRequest{Config: config})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return lifecycle.ReloadPlan{}, fromStatus(err)
+	}
+
+	plan := lifecycle.ReloadPlan{Services: make([]lifecycle.ServicePlan, 0, len(resp.Services))}
+	// Convert each service plan.
+	for _, svc := range resp.Services {
+		plan.Services = append(plan.Services, lifecycle.ServicePlan{
+			Name:    svc.ServiceName,
+			Action:  convertReloadAction(svc.Action),
+			Changes: svc.Changes,
+		})
+	}
+	// Return converted plan.
+	return plan, nil
+}
+
+// ReadLogs reads the lines of a service from its log files, oldest first.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - service: the service name.
+//   - since: the time of the oldest line; zero reads every line.
+//   - pattern: the regular expression lines must match; empty matches all.
+//   - send: the callback receiving each line; an error stops the read.
+//
+// Returns:
+//   - error: the daemon error, with its error code, or the send error.
+func (c *Client) ReadLogs(ctx context.Context, service string, since time.Time, pattern string, send func(*logging.OutputLine) error) error {
+	req := &daemonpb.ReadLogsRequest{ServiceName: service, Pattern: pattern}
+	// Limit the read to recent lines if requested.
+	if !since.IsZero() {
+		req.Since = timestamppb.New(since)
+	}
+	stream, err := c.daemon.ReadLogs(ctx, req)
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return fromStatus(err)
+	}
+
+	// Receive lines until the daemon ends the stream.
+	for {
+		msg, err := stream.Recv()
+		// Check if every line was received.
+		if errors.Is(err, io.EOF) {
+			// Read complete.
+			return nil
+		}
+		// Check if the read failed.
+		if err != nil {
+			// Return the daemon error.
+			return fromStatus(err)
+		}
+		line := logging.OutputLine{
+			Service:   msg.Service,
+			Stream:    msg.Stream,
+			Timestamp: msg.Timestamp.AsTime(),
+			Message:   msg.Message,
+		}
+		// Unknown levels read as info.
+		line.Level, _ = logging.ParseLevel(msg.Level)
+		// Check if the receiver stopped.
+		if err := send(&line); err != nil {
+			// Return send error.
+			return err
+		}
+	}
+}
+
+// StreamProcesses receives the metrics of every supervised process, once
+// immediately and then at each interval, until the context is done.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - interval: the time between two snapshots.
+//   - send: the callback receiving each snapshot; an error stops the stream.
+//
+// Returns:
+//   - error: the daemon error, with its error code, or the send error.
+func (c *Client) StreamProcesses(ctx context.Context, interval time.Duration, send func([]metrics.ProcessMetrics) error) error {
+	stream, err := c.daemon.StreamState(ctx, &daemonpb.StreamStateRequest{Interval: durationpb.New(interval)})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return fromStatus(err)
+	}
+
+	// Receive snapshots until the stream ends.
+	for {
+		state, err := stream.Recv()
+		// Check if the receive failed.
+		if err != nil {
+			// Return the daemon error.
+			return fromStatus(err)
+		}
+		processes := make([]metrics.ProcessMetrics, 0, len(state.Processes))
+		// Convert each process.
+		for _, proc := range state.Processes {
+			processes = append(processes, convertProcess(proc))
+		}
+		// Check if the receiver stopped.
+		if err := send(processes); err != nil {
+			// Return send error.
+			return err
+		}
+	}
+}
+
+// ListProcesses returns a page of the supervised processes matching a query.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - query: the filters, sort key, page and fields.
+//
+// Returns:
+//   - ProcessPage: the page of processes.
+//   - error: the daemon error, with its error code.
+func (c *Client) ListProcesses(ctx context.Context, query *ProcessQuery) (ProcessPage, error) {
+	req := &daemonpb.ListProcessesRequest{
+		LabelSelector: query.Labels,
+		OrderBy:       query.OrderBy,
+		PageSize:      safeInt32(query.PageSize),
+		PageToken:     query.PageToken,
+		Fields:        query.Fields,
+	}
+	// Convert each state.
+	for _, state := range query.States {
+		req.States = append(req.States, convertState(state))
+	}
+	resp, err := c.daemon.ListProcesses(ctx, req)
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return ProcessPage{}, fromStatus(err)
+	}
+
+	page := ProcessPage{
+		Processes:     make([]metrics.ProcessMetrics, 0, len(resp.Processes)),
+		NextPageToken: resp.NextPageToken,
+		Total:         int(resp.TotalSize),
+	}
+	// Convert each process.
+	for _, proc := range resp.Processes {
+		page.Processes = append(page.Processes, convertProcess(proc))
+	}
+	// Return converted page.
+	return page, nil
+}
+
+// convertState converts a domain process state to protobuf.
+//
+// Params:
+//   - state: the domain state.
+//
+// Returns:
+//   - daemonpb.ProcessState: the protobuf state, unspecified if unknown.
+func convertState(state process.State) daemonpb.ProcessState {
+	// Look up the protobuf state.
+	for pb, domainState := range processStates {
+		// Return the matching state.
+		if domainState == state {
+			return pb
+		}
+	}
+	// Unknown states match no process.
+	return daemonpb.ProcessState_PROCESS_STATE_UNSPECIFIED
+}
+
+// convertProcess converts protobuf process metrics to the domain.
+//
+// Params:
+//   - proc: the protobuf process metrics.
+//
+// Returns:
+//   - metrics.ProcessMetrics: the domain metrics.
+func convertProcess(proc *daemonpb.ProcessMetrics) metrics.ProcessMetrics {
+	// Return converted metrics.
+	return metrics.ProcessMetrics{
+		ServiceName: proc.ServiceName,
+		PID:         int(proc.Pid),
+		State:       processStates[proc.State],
+		Healthy:     proc.Healthy,
+		CPU: metrics.ProcessCPU{
+			UsagePercent:      proc.GetCpu().GetUsagePercent(),
+			PerCorePercent:    proc.GetCpu().GetPerCorePercent(),
+			NormalizedPercent: proc.GetCpu().GetNormalizedPercent(),
+		},
+		Memory: metrics.ProcessMemory{
+			RSS:            proc.GetMemory().GetRssBytes(),
+			Swap:           proc.GetMemory().GetSwapBytes(),
+			PSS:            proc.GetMemory().GetPssBytes(),
+			USS:            proc.GetMemory().GetUssBytes(),
+			MajorFaultRate: proc.GetMemory().GetMajorFaultRate(),
+		},
+		NumFDs:       proc.NumFds,
+		Uptime:       proc.GetUptime().AsDuration(),
+		RestartCount: int(proc.RestartCount),
+		LastError:    proc.LastError,
+	}
+}
+
+// ProcessTrees returns the process tree of a running service, or of every
+// running service when service is empty.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - service: the service name, empty for every running service.
+//
+// Returns:
+//   - []process.ServiceTree: the process tree of each service.
+//   - error: the daemon error, with its error code.
+func (c *Client) ProcessTrees(ctx context.Context, service string) ([]process.ServiceTree, error) {
+	resp, err := c.daemon.GetProcessTree(ctx, &daemonpb.GetProcessTreeRequest{ServiceName: service})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return nil, fromStatus(err)
+	}
+
+	trees := make([]process.ServiceTree, 0, len(resp.Services))
+	// Convert each service tree.
+	for _, svc := range resp.Services {
+		trees = append(trees, process.ServiceTree{Service: svc.ServiceName, Root: convertNode(svc.GetRoot())})
+	}
+	// Return converted trees.
+	return trees, nil
+}
+
+// Timeline is the timeline of a service returned by ServiceTimeline.
+type Timeline struct {
+	// Events are the events of the range, oldest first.
+	Events []storage.EventRecord
+	// Truncated reports that the limit left out later events of the range.
+	Truncated bool
+}
+
+// ServiceTimeline returns the persisted events of a service over a time range.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - service: the service name.
+//   - from: the start of the range, zero for the oldest retained event.
+//   - to: the end of the range, excluded, zero for now.
+//   - limit: the maximum number of events, zero for the daemon default.
+//
+// Returns:
+//   - Timeline: the events of the range, oldest first.
+//   - error: the daemon error, with its error code.
+func (c *Client) ServiceTimeline(ctx context.Context, service string, from, to time.Time, limit int) (Timeline, error) {
+	req := &daemonpb.GetServiceTimelineRequest{ServiceName: service, Limit: safeInt32(limit)}
+	// Bound the start of the range if requested.
+	if !from.IsZero() {
+		req.From = timestamppb.New(from)
+	}
+	// Bound the end of the range if requested.
+	if !to.IsZero() {
+		req.To = timestamppb.New(to)
+	}
+	resp, err := c.daemon.GetServiceTimeline(ctx, req)
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return Timeline{}, fromStatus(err)
+	}
+
+	timeline := Timeline{Events: make([]storage.EventRecord, 0, len(resp.Entries)), Truncated: resp.Truncated}
+	// Convert each entry.
+	for _, entry := range resp.Entries {
+		timeline.Events = append(timeline.Events, storage.EventRecord{
+			Seq:       entry.Seq,
+			Timestamp: entry.Timestamp.AsTime(),
+			Service:   resp.ServiceName,
+			Type:      entry.Type,
+			PID:       int(entry.Pid),
+			ExitCode:  int(entry.ExitCode),
+			Error:     entry.Error,
+		})
+	}
+	// Return converted timeline.
+	return timeline, nil
+}
+
+// convertNode converts a protobuf process node and its descendants to the domain.
+//
+// Params:
+//   - node: the protobuf process node.
+//
+// Returns:
+//   - process.ProcessNode: the domain process node.
+func convertNode(node *daemonpb.ProcessNode) process.ProcessNode {
+	result := process.ProcessNode{
+		PID:        int(node.GetPid()),
+		Name:       node.GetName(),
+		Cmdline:    node.GetCmdline(),
+		State:      node.GetState(),
+		RSS:        node.GetRssBytes(),
+		CPUTime:    node.GetCpuTime().AsDuration(),
+		CPUPercent: node.GetCpuPercent(),
+	}
+	// Convert each child.
+	for _, child := range node.GetChildren() {
+		result.Children = append(result.Children, convertNode(child))
+	}
+	// Return converted node.
+	return result
+}
+
+// BatchServices applies a lifecycle action to several services in one call.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - req: the action and its target services.
+//
+// Returns:
+//   - []lifecycle.BatchResult: the outcome of each service, errors carrying
+//     their error code.
+//   - error: the daemon error when the whole batch was refused.
+func (c *Client) BatchServices(ctx context.Context, req *lifecycle.BatchRequest) ([]lifecycle.BatchResult, error) {
+	resp, err := c.daemon.BatchServices(ctx, &daemonpb.BatchServicesRequest{
+		Action:        convertServiceAction(req.Action),
+		ServiceNames:  req.Services,
+		LabelSelector: req.Selector,
+		MaxParallel:   safeInt32(req.MaxParallel),
+	})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return nil, fromStatus(err)
+	}
+
+	results := make([]lifecycle.BatchResult, 0, len(resp.Results))
+	// Convert each result.
+	for _, item := range resp.Results {
+		result := lifecycle.BatchResult{Service: item.ServiceName, Snapshot: convertSnapshot(item.GetSnapshot())}
+		// Restore the error of failed services with its code.
+		if item.Error != "" {
+			result.Err = shared.WithCode(shared.Code(item.ErrorCode), errors.New(item.Error))
+		}
+		results = append(results, result)
+	}
+	// Return converted results.
+	return results, nil
+}
+
+// SignalService sends a signal to the process of a service.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - service: the service name.
+//   - signal: the signal name.
+//
+// Returns:
+//   - process.ServiceSnapshot: the state of the service once the signal is sent.
+//   - error: the daemon error.
+func (c *Client) SignalService(ctx context.Context, service, signal string) (process.ServiceSnapshot, error) {
+	resp, err := c.daemon.SignalService(ctx, &daemonpb.SignalServiceRequest{ServiceName: service, Signal: signal})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return process.ServiceSnapshot{}, fromStatus(err)
+	}
+	// Return converted snapshot.
+	return convertSnapshot(resp), nil
+}
+
+// ReloadService makes a service reload its own configuration in place.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - service: the service name.
+//
+// Returns:
+//   - process.ServiceSnapshot: the state of the service once the reload is
+//     verified.
+//   - error: the daemon error.
+func (c *Client) ReloadService(ctx context.Context, service string) (process.ServiceSnapshot, error) {
+	resp, err := c.daemon.ReloadService(ctx, &daemonpb.ReloadServiceRequest{ServiceName: service})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return process.ServiceSnapshot{}, fromStatus(err)
+	}
+	// Return converted snapshot.
+	return convertSnapshot(resp), nil
+}
+
+// PauseProbes pauses the probes of a listener of a service, or of all its
+// listeners, for the TTL of the spec.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - spec: the service, listener and TTL of the pause; zero TTL uses the
+//     daemon default.
+//
+// Returns:
+//   - []health.ProbePause: the active pauses of the service.
+//   - error: the daemon error, with its error code.
+func (c *Client) PauseProbes(ctx context.Context, spec *health.ProbePauseSpec) ([]health.ProbePause, error) {
+	req := &daemonpb.PauseProbesRequest{ServiceName: spec.Service, Listener: spec.Listener}
+	// Keep the daemon default unless a TTL is requested.
+	if spec.TTL != 0 {
+		req.Ttl = durationpb.New(spec.TTL)
+	}
+	resp, err := c.daemon.PauseProbes(ctx, req)
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return nil, fromStatus(err)
+	}
+	// Return converted pauses.
+	return convertPauses(resp), nil
+}
+
+// ResumeProbes lifts the probe pause of a listener of a service, or every
+// probe pause of the service when listener is empty.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - service: the service name.
+//   - listener: the listener name, empty for every pause of the service.
+//
+// Returns:
+//   - []health.ProbePause: the pauses of the service still active.
+//   - error: the daemon error, with its error code.
+func (c *Client) ResumeProbes(ctx context.Context, service, listener string) ([]health.ProbePause, error) {
+	resp, err := c.daemon.ResumeProbes(ctx, &daemonpb.ResumeProbesRequest{ServiceName: service, Listener: listener})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return nil, fromStatus(err)
+	}
+	// Return converted pauses.
+	return convertPauses(resp), nil
+}
+
+// HostInventory returns the platform capabilities detected on the host of
+// the daemon. The support of each feature is derived again from them by
+// metrics.HostInventory.Features.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//
+// Returns:
+//   - metrics.HostInventory: the host inventory.
+//   - error: the daemon error, with its error code.
+func (c *Client) HostInventory(ctx context.Context) (metrics.HostInventory, error) {
+	resp, err := c.daemon.GetHostInventory(ctx, &emptypb.Empty{})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return metrics.HostInventory{}, fromStatus(err)
+	}
+	inventory := metrics.HostInventory{
+		OS:              resp.GetOs(),
+		Architecture:    resp.GetArchitecture(),
+		KernelVersion:   resp.GetKernelVersion(),
+		CgroupVersion:   int(resp.GetCgroupVersion()),
+		PressureStall:   resp.GetPressureStall(),
+		SecurityModules: resp.GetSecurityModules(),
+		Nftables:        resp.GetNftables(),
+		Systemd:         resp.GetSystemd(),
+		PID1:            resp.GetPid1(),
+		Privileged:      resp.GetPrivileged(),
+		MaxFDs:          resp.GetMaxFds(),
+		MaxFDsHard:      resp.GetMaxFdsHard(),
+	}
+	// Convert each runtime socket.
+	for _, socket := range resp.GetContainerSockets() {
+		inventory.ContainerSockets = append(inventory.ContainerSockets, metrics.ContainerSocket{Runtime: socket.GetRuntime(), Path: socket.GetPath()})
+	}
+	// Return converted inventory.
+	return inventory, nil
+}
+
+// DependencyMap returns the connections sampled from a service, or from
+// every service when service is empty.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - service: the service name, empty for every service.
+//   - undeclaredOnly: keep only the edges the configuration does not declare.
+//
+// Returns:
+//   - []process.TrafficEdge: the sampled edges.
+//   - error: the daemon error, with its error code.
+func (c *Client) DependencyMap(ctx context.Context, service string, undeclaredOnly bool) ([]process.TrafficEdge, error) {
+	resp, err := c.daemon.GetDependencyMap(ctx, &daemonpb.GetDependencyMapRequest{ServiceName: service, UndeclaredOnly: undeclaredOnly})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return nil, fromStatus(err)
+	}
+	edges := make([]process.TrafficEdge, 0, len(resp.GetEdges()))
+	// Convert each edge.
+	for _, edge := range resp.GetEdges() {
+		edges = append(edges, process.TrafficEdge{
+			Service:   edge.GetServiceName(),
+			Peer:      edge.GetPeer(),
+			Endpoint:  edge.GetEndpoint(),
+			Declared:  edge.GetDeclared(),
+			Samples:   int(edge.GetSamples()),
+			FirstSeen: edge.GetFirstSeen().AsTime(),
+			LastSeen:  edge.GetLastSeen().AsTime(),
+		})
+	}
+	// Return converted edges.
+	return edges, nil
+}
+
+// SafeMode returns the safe mode of the daemon and the services whose
+// restart it holds.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//
+// Returns:
+//   - process.SafeMode: the safe mode.
+//   - error: the daemon error, with its error code.
+func (c *Client) SafeMode(ctx context.Context) (process.SafeMode, error) {
+	resp, err := c.daemon.GetSafeMode(ctx, &emptypb.Empty{})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return process.SafeMode{}, fromStatus(err)
+	}
+	// Return converted safe mode.
+	return convertSafeModeState(resp), nil
+}
+
+// SetSafeMode enters or exits safe mode. Exiting releases the held restarts.
+//
+// Params:
+//   - ctx: context for cancellation and deadline.
+//   - on: true to enter safe mode, false to exit it.
+//
+// Returns:
+//   - process.SafeMode: the safe mode after the request.
+//   - error: the daemon error, with its error code.
+func (c *Client) SetSafeMode(ctx context.Context, on bool) (process.SafeMode, error) {
+	resp, err := c.daemon.SetSafeMode(ctx, &daemonpb.SetSafeModeRequest{Enabled: on})
+	// Check if the call failed.
+	if err != nil {
+		// Return the daemon error.
+		return process.SafeMode{}, fromStatus(err)
+	}
+	// Return converted safe mode.
+	return convertSafeModeState(resp), nil
+}
+
+// convertSafeModeState converts a protobuf safe mode to the domain.
+//
+// Params:
+//   - state: the protobuf safe mode.
+//
+// Returns:
+//   - process.SafeMode: the domain safe mode.
+func convertSafeModeState(state *daemonpb.SafeModeState) process.SafeMode {
+	mode := process.SafeMode{
+		Active:   state.GetActive(),
+		Manual:   state.GetManual(),
+		Restarts: int(state.GetRestarts()),
+		Window:   state.GetWindow().AsDuration(),
+		Held:     state.GetHeld(),
+	}
+	// Keep the zero time outside safe mode.
+	if state.GetSince() != nil {
+		mode.Since = state.GetSince().AsTime()
+	}
+	// Return converted safe mode.
+	return mode
+}
+
+// convertPauses converts protobuf probe pauses to the domain.
+//
+// Params:
+//   - pauses: the protobuf probe pauses.
+//
+// Returns:
+//   - []health.ProbePause: the domain probe pauses.
+func convertPauses(pauses *daemonpb.ProbePauses) []health.ProbePause {
+	result := make([]health.ProbePause, 0, len(pauses.GetPauses()))
+	// Convert each pause.
+	for _, pause := range pauses.GetPauses() {
+		result = append(result, health.ProbePause{Listener: pause.GetListener(), Until: pause.GetUntil().AsTime()})
+	}
+	// Return converted pauses.
+	return result
+}
+
+// convertSnapshot converts a protobuf service snapshot to the domain.
+//
+// Params:
+//   - snapshot: the protobuf snapshot, nil for none.
+//
+// Returns:
+//   - process.ServiceSnapshot: the domain snapshot.
+func convertSnapshot(snapshot *daemonpb.ServiceSnapshot) process.ServiceSnapshot {
+	// Return converted snapshot.
+	return process.ServiceSnapshot{
+		Name:        snapshot.GetServiceName(),
+		State:       processStates[snapshot.GetState()],
+		PID:         int(snapshot.GetPid()),
+		OperationID: snapshot.GetOperationId(),
+		Sequence:    snapshot.GetSequence(),
+	}
+}
+
+// convertServiceAction converts a domain service action to protobuf.
+//
+// Params:
+//   - action: the domain action.
+//
+// Returns:
+//   - daemonpb.ServiceAction: the protobuf action, unspecified if unknown.
+func convertServiceAction(action lifecycle.ServiceAction) daemonpb.ServiceAction {
+	// Look up the protobuf action.
+	for pb, domainAction := range serviceActions {
+		// Return the matching action.
+		if domainAction == action {
+			return pb
+		}
+	}
+	// Unknown actions are refused by the daemon.
+	return daemonpb.ServiceAction_SERVICE_ACTION_UNSPECIFIED
+}
+
+// convertReloadAction converts a protobuf reload action to the domain.
+//
+// Params:
+//   - action: the protobuf reload action.
+//
+// Returns:
+//   - lifecycle.ReloadAction: the domain action, ReloadRestart if unknown.
+func convertReloadAction(action daemonpb.ReloadAction) lifecycle.ReloadAction {
+	// Look up the domain action.
+	for domainAction, pb := range reloadActions {
+		// Return the matching action.
+		if pb == action {
+			return domainAction
+		}
+	}
+	// Unknown actions restart, as every reload does.
+	return lifecycle.ReloadRestart
+}
+
+// fromStatus converts a status error into the daemon error, carrying the
+// error code of its ErrorInfo reason. Other errors are returned unchanged.
+//
+// Params:
+//   - err: the error returned by a call.
+//
+// Returns:
+//   - error: the error, coded when the daemon sent a code.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	// Keep errors without status.
+	if !ok {
+		// Return unchanged error.
+		return err
+	}
+	// Look for the error code of the daemon.
+	for _, detail := range st.Details() {
+		info, isInfo := detail.(*errdetails.ErrorInfo)
+		// Return the daemon message with its code.
+		if isInfo && info.Domain == errorDomain {
+			return shared.WithCode(shared.Code(info.Reason), errors.New(st.Message()))
+		}
+	}
+	// Return uncoded error.
+	return err
+}
@@ -0,0 +1,419 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/storage"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// TestClient_PlanReload verifies plans and errors round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_PlanReload(t *testing.T) {
+	t.Parallel()
+
+	planner := &mockReloadPlanner{plan: lifecycle.ReloadPlan{Services: []lifecycle.ServicePlan{
+		{Name: "web", Action: lifecycle.ReloadRestart, Changes: []string{"command"}},
+		{Name: "api", Action: lifecycle.ReloadAdd},
+		{Name: "old", Action: lifecycle.ReloadRemove},
+	}}}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetReloadPlanner(planner, &mockConfigParser{})
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan, err := client.PlanReload(ctx, []byte("services: []"))
+	require.NoError(t, err)
+	assert.Equal(t, planner.plan, plan)
+
+	planner.err = shared.NewCodedError(shared.CodeConfigPreflightFailed, "reload refused")
+	_, err = client.PlanReload(ctx, []byte("services: []"))
+	require.Error(t, err)
+	assert.Equal(t, shared.CodeConfigPreflightFailed, shared.CodeOf(err))
+	assert.Contains(t, err.Error(), "reload refused")
+}
+
+// TestClient_ReadLogs verifies log lines and errors round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_ReadLogs(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	history := newMockOutputHistory(start)
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetLogHistory(history)
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var lines []domainlogging.OutputLine
+	err = client.ReadLogs(ctx, "api", start.Add(time.Minute), "", func(line *domainlogging.OutputLine) error {
+		lines = append(lines, *line)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "ERROR db down", lines[0].Message)
+	assert.Equal(t, domainlogging.StreamStderr, lines[0].Stream)
+	assert.Equal(t, domainlogging.LevelError, lines[0].Level)
+	assert.True(t, start.Add(time.Minute).Equal(lines[0].Timestamp))
+	assert.Equal(t, "ready", lines[1].Message)
+
+	err = client.ReadLogs(ctx, "api", time.Time{}, "(", func(*domainlogging.OutputLine) error { return nil })
+	require.Error(t, err)
+	assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+}
+
+// TestClient_ProcessTrees verifies process trees and errors round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_ProcessTrees(t *testing.T) {
+	t.Parallel()
+
+	provider := newMockProcessTreeProvider()
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetProcessTreeProvider(provider)
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	trees, err := client.ProcessTrees(ctx, "web")
+	require.NoError(t, err)
+	assert.Equal(t, []process.ServiceTree{{Service: "web", Root: provider.root}}, trees)
+
+	provider.err = process.ErrNotRunning
+	_, err = client.ProcessTrees(ctx, "web")
+	require.Error(t, err)
+	assert.Equal(t, shared.CodeServiceNotRunning, shared.CodeOf(err))
+}
+
+// TestClient_ServiceTimeline verifies timelines and errors round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_ServiceTimeline(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetEventHistory(newMockEventHistory(start))
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	timeline, err := client.ServiceTimeline(ctx, "api", start.Add(time.Minute), time.Time{}, 2)
+	require.NoError(t, err)
+	assert.True(t, timeline.Truncated)
+	require.Len(t, timeline.Events, 2)
+	assert.Equal(t, storage.EventRecord{
+		Seq: 3, Timestamp: start.Add(2 * time.Minute), Service: "api", Type: "unhealthy", PID: 10, Error: "connection refused",
+	}, timeline.Events[0])
+	assert.Equal(t, "failed", timeline.Events[1].Type)
+
+	_, err = client.ServiceTimeline(ctx, "", time.Time{}, time.Time{}, 0)
+	require.Error(t, err)
+	assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+}
+
+// TestClient_ProbePauses verifies probe pauses and errors round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_ProbePauses(t *testing.T) {
+	t.Parallel()
+
+	pauser := &mockProbePauser{service: "api"}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetProbePauser(pauser)
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pauses, err := client.PauseProbes(ctx, &health.ProbePauseSpec{Service: "api", Listener: "http", TTL: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, []health.ProbePauseSpec{{Service: "api", Listener: "http", TTL: time.Minute}}, pauser.specs)
+	assert.Equal(t, []health.ProbePause{{Listener: "http", Until: time.Unix(1700000000, 0).UTC()}}, pauses)
+
+	pauses, err = client.ResumeProbes(ctx, "api", "")
+	require.NoError(t, err)
+	assert.Empty(t, pauses)
+	assert.Equal(t, []string{""}, pauser.resumed)
+
+	_, err = client.PauseProbes(ctx, &health.ProbePauseSpec{Service: "missing"})
+	require.Error(t, err)
+	assert.Equal(t, shared.CodeUnknown, shared.CodeOf(err))
+}
+
+// TestClient_HostInventory verifies the host inventory round-trips through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_HostInventory(t *testing.T) {
+	t.Parallel()
+
+	want := metrics.HostInventory{
+		OS:               "linux",
+		Architecture:     "amd64",
+		KernelVersion:    "6.1.0-18-amd64",
+		CgroupVersion:    2,
+		PressureStall:    true,
+		SecurityModules:  []string{"apparmor"},
+		Systemd:          true,
+		ContainerSockets: []metrics.ContainerSocket{{Runtime: "podman", Path: "/run/podman/podman.sock"}},
+		Privileged:       true,
+		MaxFDs:           1024,
+		MaxFDsHard:       524288,
+	}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetHostInventoryProvider(&mockHostInventoryProvider{inventory: want})
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inventory, err := client.HostInventory(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, inventory)
+}
+
+// TestClient_SafeMode verifies the safe mode round-trips through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_SafeMode(t *testing.T) {
+	t.Parallel()
+
+	controller := &mockSafeModeController{mode: stormSafeMode()}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetSafeModeController(controller)
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	mode, err := client.SafeMode(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, stormSafeMode(), mode)
+
+	mode, err = client.SetSafeMode(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false}, controller.requests)
+	assert.False(t, mode.Active)
+	assert.True(t, mode.Since.IsZero())
+}
+
+// TestClient_StreamProcesses verifies process snapshots round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_StreamProcesses(t *testing.T) {
+	t.Parallel()
+
+	stator := &mockGetStator{state: lifecycle.DaemonState{Processes: []metrics.ProcessMetrics{
+		{
+			ServiceName:  "api",
+			PID:          1234,
+			State:        process.StateRunning,
+			Healthy:      true,
+			CPU:          metrics.ProcessCPU{UsagePercent: 12.5},
+			Memory:       metrics.ProcessMemory{RSS: 64 << 20},
+			NumFDs:       42,
+			Uptime:       time.Hour,
+			RestartCount: 2,
+		},
+		{ServiceName: "worker", State: process.StateFailed, LastError: "exit code 1"},
+	}}}
+	server := grpc.NewServer(&mockMetricsProvider{}, stator)
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var snapshots [][]metrics.ProcessMetrics
+	err = client.StreamProcesses(ctx, 10*time.Millisecond, func(processes []metrics.ProcessMetrics) error {
+		snapshots = append(snapshots, processes)
+		if len(snapshots) == 2 {
+			return context.Canceled
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, stator.state.Processes, snapshots[1])
+}
+
+// TestClient_ListProcesses verifies queries and pages round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_ListProcesses(t *testing.T) {
+	t.Parallel()
+
+	server := newListTestServer()
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	page, err := client.ListProcesses(ctx, &grpc.ProcessQuery{
+		Labels:   map[string]string{"team": "edge"},
+		States:   []process.State{process.StateRunning},
+		OrderBy:  "cpu desc",
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Processes, 1)
+	assert.Equal(t, "api", page.Processes[0].ServiceName)
+	assert.Equal(t, process.StateRunning, page.Processes[0].State)
+	assert.Equal(t, 2, page.Total)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page, err = client.ListProcesses(ctx, &grpc.ProcessQuery{
+		Labels:    map[string]string{"team": "edge"},
+		OrderBy:   "cpu desc",
+		PageSize:  1,
+		PageToken: page.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Processes, 1)
+	assert.Equal(t, "web", page.Processes[0].ServiceName)
+	assert.Empty(t, page.NextPageToken)
+
+	_, err = client.ListProcesses(ctx, &grpc.ProcessQuery{OrderBy: "color"})
+	require.Error(t, err)
+	assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+}
+
+// TestClient_BatchServices verifies batch results and their error codes
+// round-trip through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_BatchServices(t *testing.T) {
+	t.Parallel()
+
+	batcher := &mockServiceBatcher{known: map[string]bool{"web": true}}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetServiceBatcher(batcher)
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := client.BatchServices(ctx, &lifecycle.BatchRequest{Action: lifecycle.ActionStop, Services: []string{"web", "missing"}})
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.ActionStop, batcher.req.Action)
+	require.Len(t, results, 2)
+	assert.Equal(t, "web", results[0].Service)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, process.ServiceSnapshot{Name: "web", State: process.StateRunning, PID: 42}, results[0].Snapshot)
+	assert.Equal(t, "missing", results[1].Service)
+	assert.Equal(t, shared.CodeServiceNotFound, shared.CodeOf(results[1].Err))
+
+	batcher.err = shared.NewCodedError(shared.CodeInvalidArgument, "no target")
+	_, err = client.BatchServices(ctx, &lifecycle.BatchRequest{Action: lifecycle.ActionStart})
+	assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+}
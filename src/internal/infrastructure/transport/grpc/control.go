@@ -0,0 +1,73 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// mutatingMethods lists the methods changing the daemon, refused while the
+// control plane is read-only. SignalService is not listed: signals keep
+// working, within the allowed_signals of each service. Neither is
+// ResumeProbes, which only restores the probing of the configuration.
+var mutatingMethods map[string]bool = map[string]bool{
+	daemonpb.DaemonService_RequestReload_FullMethodName:       true,
+	daemonpb.DaemonService_ReloadService_FullMethodName:       true,
+	daemonpb.DaemonService_BatchServices_FullMethodName:       true,
+	daemonpb.DaemonService_SpawnDebugService_FullMethodName:   true,
+	daemonpb.DaemonService_SetDaemonParameters_FullMethodName: true,
+	daemonpb.DaemonService_PauseProbes_FullMethodName:         true,
+	daemonpb.DaemonService_SetSafeMode_FullMethodName:         true,
+}
+
+// ControlPolicy reports whether the control plane accepts changes.
+type ControlPolicy interface {
+	// ReadOnly reports whether mutating requests are refused.
+	ReadOnly() bool
+}
+
+// SetControlPolicy sets the policy consulted before mutating requests.
+// Without a policy, every request is accepted.
+//
+// Params:
+//   - policy: the control policy.
+func (s *Server) SetControlPolicy(policy ControlPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store control policy
+	s.control = policy
+}
+
+// readOnlyInterceptor refuses mutating requests while the control plane is
+// read-only, before their handler runs.
+//
+// Params:
+//   - ctx: request context.
+//   - req: the request message.
+//   - info: the method information.
+//   - handler: the method handler.
+//
+// Returns:
+//   - any: the response message.
+//   - error: config.ErrReadOnly for refused requests, or the handler error.
+func (s *Server) readOnlyInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	// read requests are always served
+	if !mutatingMethods[info.FullMethod] {
+		// run handler
+		return handler(ctx, req)
+	}
+	s.mu.Lock()
+	policy := s.control
+	s.mu.Unlock()
+	// refuse changes on a read-only control plane
+	if policy != nil && policy.ReadOnly() {
+		// return refusal
+		return nil, config.ErrReadOnly
+	}
+	// run handler
+	return handler(ctx, req)
+}
@@ -0,0 +1,78 @@
+// Package grpc provides internal tests for control.go.
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// staticPolicy is a ControlPolicy with a fixed mode.
+type staticPolicy bool
+
+func (p staticPolicy) ReadOnly() bool { return bool(p) }
+
+// Test_Server_readOnlyInterceptor verifies mutating requests are refused on a read-only control plane.
+//
+// Params:
+//   - t: testing context.
+func Test_Server_readOnlyInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ControlPolicy
+		method   string
+		wantDeny bool
+	}{
+		{name: "no policy", method: daemonpb.DaemonService_RequestReload_FullMethodName},
+		{name: "writable", policy: staticPolicy(false), method: daemonpb.DaemonService_SetDaemonParameters_FullMethodName},
+		{name: "reload refused", policy: staticPolicy(true), method: daemonpb.DaemonService_RequestReload_FullMethodName, wantDeny: true},
+		{name: "service reload refused", policy: staticPolicy(true), method: daemonpb.DaemonService_ReloadService_FullMethodName, wantDeny: true},
+		{name: "batch refused", policy: staticPolicy(true), method: daemonpb.DaemonService_BatchServices_FullMethodName, wantDeny: true},
+		{name: "debug service refused", policy: staticPolicy(true), method: daemonpb.DaemonService_SpawnDebugService_FullMethodName, wantDeny: true},
+		{name: "parameters refused", policy: staticPolicy(true), method: daemonpb.DaemonService_SetDaemonParameters_FullMethodName, wantDeny: true},
+		{name: "probe pause refused", policy: staticPolicy(true), method: daemonpb.DaemonService_PauseProbes_FullMethodName, wantDeny: true},
+		{name: "safe mode refused", policy: staticPolicy(true), method: daemonpb.DaemonService_SetSafeMode_FullMethodName, wantDeny: true},
+		{name: "signal served", policy: staticPolicy(true), method: daemonpb.DaemonService_SignalService_FullMethodName},
+		{name: "probe resume served", policy: staticPolicy(true), method: daemonpb.DaemonService_ResumeProbes_FullMethodName},
+		{name: "read served", policy: staticPolicy(true), method: daemonpb.DaemonService_GetDaemonParameters_FullMethodName},
+		{name: "plan served", policy: staticPolicy(true), method: daemonpb.DaemonService_PlanReload_FullMethodName},
+		{name: "safe mode state served", policy: staticPolicy(true), method: daemonpb.DaemonService_GetSafeMode_FullMethodName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(nil, nil)
+			// Keep the nil interface when no policy is set.
+			if tt.policy != nil {
+				s.SetControlPolicy(tt.policy)
+			}
+			called := false
+			handler := func(context.Context, any) (any, error) {
+				called = true
+				return "ok", nil
+			}
+
+			resp, err := s.readOnlyInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			// Refused requests never reach their handler.
+			if tt.wantDeny {
+				require.ErrorIs(t, err, config.ErrReadOnly)
+				assert.False(t, called)
+				st, _ := status.FromError(toStatus(err))
+				assert.Equal(t, codes.PermissionDenied, st.Code())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, "ok", resp)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// DaemonInfoProvider provides the resource usage of the daemon itself.
+type DaemonInfoProvider interface {
+	// DaemonUsage returns the daemon process, runtime, queue and loop usage.
+	DaemonUsage(ctx context.Context) metrics.DaemonUsage
+}
+
+// SetDaemonInfoProvider sets the source of the daemon usage.
+// Without a provider, GetDaemonInfo returns Unimplemented.
+//
+// Params:
+//   - provider: the daemon info provider.
+func (s *Server) SetDaemonInfoProvider(provider DaemonInfoProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store daemon info provider
+	s.daemonInfo = provider
+}
+
+// GetDaemonInfo implements DaemonService.GetDaemonInfo.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.DaemonInfo: the daemon usage.
+//   - error: if daemon info is not configured.
+func (s *Server) GetDaemonInfo(ctx context.Context, _ *emptypb.Empty) (*daemonpb.DaemonInfo, error) {
+	s.mu.Lock()
+	provider := s.daemonInfo
+	s.mu.Unlock()
+
+	// Check if daemon info is configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "daemon info not configured")
+	}
+	// Return converted usage.
+	return convertDaemonUsage(provider.DaemonUsage(ctx)), nil
+}
+
+// convertDaemonUsage converts the daemon usage to protobuf format.
+//
+// Params:
+//   - usage: the daemon usage.
+//
+// Returns:
+//   - *daemonpb.DaemonInfo: protobuf daemon info.
+func convertDaemonUsage(usage metrics.DaemonUsage) *daemonpb.DaemonInfo {
+	info := &daemonpb.DaemonInfo{
+		RssBytes:       usage.RSS,
+		OpenFds:        usage.OpenFDs,
+		Goroutines:     uint32(usage.Goroutines),
+		HeapAllocBytes: usage.HeapAlloc,
+		GcCycles:       usage.GCCycles,
+		GcPauseTotal:   durationpb.New(usage.GCPauseTotal),
+		LastGcPause:    durationpb.New(usage.LastGCPause),
+		Queues:         make([]*daemonpb.QueueDepth, 0, len(usage.Queues)),
+		Loops:          make([]*daemonpb.LoopLatency, 0, len(usage.Loops)),
+	}
+	// Convert each queue.
+	for _, q := range usage.Queues {
+		info.Queues = append(info.Queues, &daemonpb.QueueDepth{Name: q.Name, Depth: uint32(q.Depth), Capacity: uint32(q.Capacity)})
+	}
+	// Convert each loop.
+	for _, l := range usage.Loops {
+		info.Loops = append(info.Loops, &daemonpb.LoopLatency{Name: l.Name, Latency: durationpb.New(l.Latency)})
+	}
+	// Return converted info.
+	return info
+}
@@ -0,0 +1,77 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockDaemonInfo returns a fixed daemon usage.
+type mockDaemonInfo struct {
+	usage metrics.DaemonUsage
+}
+
+func (m *mockDaemonInfo) DaemonUsage(_ context.Context) metrics.DaemonUsage {
+	return m.usage
+}
+
+// TestServer_GetDaemonInfo verifies daemon usage conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetDaemonInfo(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetDaemonInfoProvider(&mockDaemonInfo{usage: metrics.DaemonUsage{
+		SelfUsage:    metrics.SelfUsage{RSS: 20 << 20, OpenFDs: 12},
+		Goroutines:   42,
+		HeapAlloc:    8 << 20,
+		GCCycles:     7,
+		GCPauseTotal: 3 * time.Millisecond,
+		LastGCPause:  time.Millisecond,
+		Queues:       []metrics.QueueDepth{{Name: "events/api", Depth: 2, Capacity: 16}},
+		Loops:        []metrics.LoopLatency{{Name: "metrics", Latency: 15 * time.Millisecond}},
+	}})
+
+	resp, err := server.GetDaemonInfo(context.Background(), &emptypb.Empty{})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20<<20), resp.RssBytes)
+	assert.Equal(t, uint32(12), resp.OpenFds)
+	assert.Equal(t, uint32(42), resp.Goroutines)
+	assert.Equal(t, uint64(8<<20), resp.HeapAllocBytes)
+	assert.Equal(t, uint32(7), resp.GcCycles)
+	assert.Equal(t, 3*time.Millisecond, resp.GcPauseTotal.AsDuration())
+	assert.Equal(t, time.Millisecond, resp.LastGcPause.AsDuration())
+	require.Len(t, resp.Queues, 1)
+	assert.Equal(t, "events/api", resp.Queues[0].Name)
+	assert.Equal(t, uint32(2), resp.Queues[0].Depth)
+	assert.Equal(t, uint32(16), resp.Queues[0].Capacity)
+	require.Len(t, resp.Loops, 1)
+	assert.Equal(t, "metrics", resp.Loops[0].Name)
+	assert.Equal(t, 15*time.Millisecond, resp.Loops[0].Latency.AsDuration())
+}
+
+// TestServer_GetDaemonInfo_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetDaemonInfo_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetDaemonInfo(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,76 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// DebugSpawner runs transient services for remote diagnostics.
+type DebugSpawner interface {
+	// SpawnEphemeral runs a command once as a transient service.
+	SpawnEphemeral(spec *process.EphemeralSpec) (process.EphemeralService, error)
+}
+
+// SetDebugSpawner sets the target of debug service requests.
+// Without a spawner, SpawnDebugService returns Unimplemented.
+//
+// Params:
+//   - spawner: the debug service spawner.
+func (s *Server) SetDebugSpawner(spawner DebugSpawner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store debug spawner
+	s.debugSpawner = spawner
+}
+
+// SpawnDebugService implements DaemonService.SpawnDebugService.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the command, environment, TTL and sandbox service.
+//
+// Returns:
+//   - *daemonpb.DebugService: the generated service name and expiry.
+//   - error: if spawning is not configured, the spec is invalid, the
+//     sandbox service is unknown, or the supervisor is not running.
+func (s *Server) SpawnDebugService(_ context.Context, req *daemonpb.SpawnDebugServiceRequest) (*daemonpb.DebugService, error) {
+	s.mu.Lock()
+	spawner := s.debugSpawner
+	s.mu.Unlock()
+
+	// Check if spawning is configured.
+	if spawner == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "debug services not configured")
+	}
+
+	spec := &process.EphemeralSpec{
+		Command:     req.Command,
+		Args:        req.Args,
+		Env:         req.Env,
+		SandboxFrom: req.SandboxFrom,
+	}
+	// Keep the default TTL when none is requested.
+	if req.Ttl != nil {
+		spec.TTL = req.Ttl.AsDuration()
+	}
+	spawned, err := spawner.SpawnEphemeral(spec)
+	// Check if the service was spawned.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("spawn debug service: %w", err)
+	}
+	// Return the spawned service.
+	return &daemonpb.DebugService{
+		ServiceName: spawned.Name,
+		ExpiresAt:   timestamppb.New(spawned.ExpiresAt),
+	}, nil
+}
@@ -0,0 +1,103 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockDebugSpawner records the spawned specs, sandboxed by one service only.
+type mockDebugSpawner struct {
+	service string
+	specs   []process.EphemeralSpec
+}
+
+func (m *mockDebugSpawner) SpawnEphemeral(spec *process.EphemeralSpec) (process.EphemeralService, error) {
+	if spec.SandboxFrom != "" && spec.SandboxFrom != m.service {
+		return process.EphemeralService{}, errUnknownService
+	}
+	m.specs = append(m.specs, *spec)
+	return process.EphemeralService{Name: "debug-0a1b2c3d", ExpiresAt: time.Unix(1700000000, 0)}, nil
+}
+
+// TestServer_SpawnDebugService verifies debug service requests reach the spawner.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SpawnDebugService(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *daemonpb.SpawnDebugServiceRequest
+		want    process.EphemeralSpec
+		wantErr bool
+	}{
+		{
+			name: "sandboxed",
+			req: &daemonpb.SpawnDebugServiceRequest{
+				Command: "/usr/bin/ss", Args: []string{"-tlnp"}, Env: map[string]string{"MODE": "debug"},
+				Ttl: durationpb.New(time.Minute), SandboxFrom: "api",
+			},
+			want: process.EphemeralSpec{
+				Command: "/usr/bin/ss", Args: []string{"-tlnp"}, Env: map[string]string{"MODE": "debug"},
+				TTL: time.Minute, SandboxFrom: "api",
+			},
+		},
+		{
+			name: "default ttl",
+			req:  &daemonpb.SpawnDebugServiceRequest{Command: "/bin/ps"},
+			want: process.EphemeralSpec{Command: "/bin/ps"},
+		},
+		{
+			name:    "unknown sandbox",
+			req:     &daemonpb.SpawnDebugServiceRequest{Command: "/bin/ps", SandboxFrom: "missing"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spawner := &mockDebugSpawner{service: "api"}
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetDebugSpawner(spawner)
+
+			resp, err := server.SpawnDebugService(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				assert.Empty(t, spawner.specs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []process.EphemeralSpec{tt.want}, spawner.specs)
+			assert.Equal(t, "debug-0a1b2c3d", resp.ServiceName)
+			assert.Equal(t, int64(1700000000), resp.ExpiresAt.AsTime().Unix())
+		})
+	}
+}
+
+// TestServer_SpawnDebugService_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SpawnDebugService_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.SpawnDebugService(context.Background(), &daemonpb.SpawnDebugServiceRequest{Command: "/bin/ps"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
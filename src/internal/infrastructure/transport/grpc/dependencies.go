@@ -0,0 +1,97 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// DependencyProvider provides the probed state of external dependencies.
+type DependencyProvider interface {
+	// Dependencies returns the external dependencies of a service.
+	Dependencies(name string) ([]health.DependencyStatus, error)
+}
+
+// SetDependencyProvider sets the source of dependency states.
+// Without a provider, GetDependencies returns Unimplemented.
+//
+// Params:
+//   - provider: the dependency provider.
+func (s *Server) SetDependencyProvider(provider DependencyProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store dependency provider
+	s.dependencies = provider
+}
+
+// GetDependencies implements DaemonService.GetDependencies.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service name.
+//
+// Returns:
+//   - *daemonpb.ServiceDependencies: the dependency states.
+//   - error: if dependencies are not configured or the service is unknown.
+func (s *Server) GetDependencies(_ context.Context, req *daemonpb.GetDependenciesRequest) (*daemonpb.ServiceDependencies, error) {
+	s.mu.Lock()
+	provider := s.dependencies
+	s.mu.Unlock()
+
+	// Check if dependency reporting is configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "dependencies not configured")
+	}
+
+	deps, err := provider.Dependencies(req.ServiceName)
+	// Check if the lookup failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get dependencies: %w", err)
+	}
+
+	resp := &daemonpb.ServiceDependencies{
+		ServiceName:  req.ServiceName,
+		Dependencies: make([]*daemonpb.DependencyStatus, 0, len(deps)),
+	}
+	// Convert each dependency.
+	for i := range deps {
+		resp.Dependencies = append(resp.Dependencies, convertDependencyStatus(&deps[i]))
+	}
+	// Return converted dependencies.
+	return resp, nil
+}
+
+// convertDependencyStatus converts a dependency status to protobuf format.
+//
+// Params:
+//   - dep: the dependency status.
+//
+// Returns:
+//   - *daemonpb.DependencyStatus: protobuf dependency status.
+func convertDependencyStatus(dep *health.DependencyStatus) *daemonpb.DependencyStatus {
+	pb := &daemonpb.DependencyStatus{
+		Name:        dep.Name,
+		Type:        dep.Type,
+		Address:     dep.Address,
+		GateRestart: dep.GateRestart,
+		Healthy:     dep.Healthy,
+		Latency:     durationpb.New(dep.Latency),
+		Error:       dep.Error,
+	}
+	// Leave the check time unset until the first probe.
+	if dep.Checked() {
+		pb.LastCheck = timestamppb.New(dep.LastCheck)
+	}
+	// Return converted status.
+	return pb
+}
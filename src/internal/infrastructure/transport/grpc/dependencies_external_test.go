@@ -0,0 +1,98 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockDependencyProvider returns fixed dependencies for one service.
+type mockDependencyProvider struct {
+	service string
+	deps    []health.DependencyStatus
+}
+
+func (m *mockDependencyProvider) Dependencies(name string) ([]health.DependencyStatus, error) {
+	if name != m.service {
+		return nil, errUnknownService
+	}
+	return m.deps, nil
+}
+
+// TestServer_GetDependencies verifies dependency status conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetDependencies(t *testing.T) {
+	t.Parallel()
+
+	at := time.Now()
+	provider := &mockDependencyProvider{service: "api", deps: []health.DependencyStatus{
+		{Name: "db", Type: "tcp", Address: "db.internal:5432", GateRestart: true, LastCheck: at, Latency: time.Millisecond, Error: "connection refused"},
+		{Name: "billing", Type: "http", Address: "https://billing.example.com"},
+	}}
+
+	tests := []struct {
+		name     string
+		service  string
+		wantErr  bool
+		wantDeps int
+	}{
+		{name: "service with dependencies", service: "api", wantDeps: 2},
+		{name: "unknown service", service: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetDependencyProvider(provider)
+
+			resp, err := server.GetDependencies(context.Background(), &daemonpb.GetDependenciesRequest{ServiceName: tt.service})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.service, resp.ServiceName)
+			require.Len(t, resp.Dependencies, tt.wantDeps)
+
+			db := resp.Dependencies[0]
+			assert.Equal(t, "db", db.Name)
+			assert.Equal(t, "tcp", db.Type)
+			assert.Equal(t, "db.internal:5432", db.Address)
+			assert.True(t, db.GateRestart)
+			assert.False(t, db.Healthy)
+			assert.True(t, db.LastCheck.AsTime().Equal(at))
+			assert.Equal(t, time.Millisecond, db.Latency.AsDuration())
+			assert.Equal(t, "connection refused", db.Error)
+
+			// Dependencies not probed yet have no check time.
+			assert.Nil(t, resp.Dependencies[1].LastCheck)
+		})
+	}
+}
+
+// TestServer_GetDependencies_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetDependencies_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetDependencies(context.Background(), &daemonpb.GetDependenciesRequest{ServiceName: "api"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,76 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// DependencyMapProvider provides the connections sampled from the services.
+type DependencyMapProvider interface {
+	// DependencyMap returns the sampled edges of a service, or of every service when name is empty.
+	DependencyMap(name string, undeclaredOnly bool) ([]process.TrafficEdge, error)
+}
+
+// SetDependencyMapProvider sets the source of the sampled dependency map.
+// Without a provider, GetDependencyMap returns Unimplemented.
+//
+// Params:
+//   - provider: the dependency map provider.
+func (s *Server) SetDependencyMapProvider(provider DependencyMapProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store dependency map provider
+	s.dependencyMap = provider
+}
+
+// GetDependencyMap implements DaemonService.GetDependencyMap.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service name and the undeclared filter.
+//
+// Returns:
+//   - *daemonpb.DependencyMap: the sampled edges.
+//   - error: if the map is not configured, sampling is disabled or the service is unknown.
+func (s *Server) GetDependencyMap(_ context.Context, req *daemonpb.GetDependencyMapRequest) (*daemonpb.DependencyMap, error) {
+	s.mu.Lock()
+	provider := s.dependencyMap
+	s.mu.Unlock()
+
+	// Check if the dependency map is configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "dependency map not configured")
+	}
+
+	edges, err := provider.DependencyMap(req.ServiceName, req.UndeclaredOnly)
+	// Check if reading the map failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get dependency map: %w", err)
+	}
+
+	resp := &daemonpb.DependencyMap{Edges: make([]*daemonpb.TrafficEdge, 0, len(edges))}
+	// Convert each edge.
+	for i := range edges {
+		resp.Edges = append(resp.Edges, &daemonpb.TrafficEdge{
+			ServiceName: edges[i].Service,
+			Peer:        edges[i].Peer,
+			Endpoint:    edges[i].Endpoint,
+			Declared:    edges[i].Declared,
+			Samples:     int64(edges[i].Samples),
+			FirstSeen:   timestamppb.New(edges[i].FirstSeen),
+			LastSeen:    timestamppb.New(edges[i].LastSeen),
+		})
+	}
+	// Return converted edges.
+	return resp, nil
+}
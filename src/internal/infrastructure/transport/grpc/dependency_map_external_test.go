@@ -0,0 +1,97 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockDependencyMapProvider returns fixed edges and records the request.
+type mockDependencyMapProvider struct {
+	edges          []process.TrafficEdge
+	err            error
+	name           string
+	undeclaredOnly bool
+}
+
+func (m *mockDependencyMapProvider) DependencyMap(name string, undeclaredOnly bool) ([]process.TrafficEdge, error) {
+	m.name, m.undeclaredOnly = name, undeclaredOnly
+	return m.edges, m.err
+}
+
+// testTrafficEdges returns a declared peer edge and an undeclared external endpoint.
+func testTrafficEdges() []process.TrafficEdge {
+	first, last := time.Unix(1000, 0).UTC(), time.Unix(1300, 0).UTC()
+	return []process.TrafficEdge{
+		{Service: "web", Peer: "api", Endpoint: "127.0.0.1:8080", Declared: true, Samples: 10, FirstSeen: first, LastSeen: last},
+		{Service: "web", Endpoint: "10.0.0.6:6379", Samples: 2, FirstSeen: first, LastSeen: last},
+	}
+}
+
+// TestServer_GetDependencyMap verifies edge conversion and the request filters.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetDependencyMap(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	_, err := server.GetDependencyMap(context.Background(), &daemonpb.GetDependencyMapRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	provider := &mockDependencyMapProvider{edges: testTrafficEdges()}
+	server.SetDependencyMapProvider(provider)
+	resp, err := server.GetDependencyMap(context.Background(), &daemonpb.GetDependencyMapRequest{ServiceName: "web", UndeclaredOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, "web", provider.name)
+	assert.True(t, provider.undeclaredOnly)
+	require.Len(t, resp.Edges, 2)
+	assert.Equal(t, "api", resp.Edges[0].Peer)
+	assert.True(t, resp.Edges[0].Declared)
+	assert.Equal(t, int64(10), resp.Edges[0].Samples)
+	assert.Equal(t, time.Unix(1300, 0).UTC(), resp.Edges[0].LastSeen.AsTime())
+	assert.Empty(t, resp.Edges[1].Peer)
+	assert.Equal(t, "10.0.0.6:6379", resp.Edges[1].Endpoint)
+
+	provider.err = errors.New("traffic sampling disabled")
+	_, err = server.GetDependencyMap(context.Background(), &daemonpb.GetDependencyMapRequest{})
+	assert.ErrorContains(t, err, "traffic sampling disabled")
+}
+
+// TestClient_DependencyMap verifies the dependency map round-trips through a server.
+//
+// Params:
+//   - t: testing context for assertions
+func TestClient_DependencyMap(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetDependencyMapProvider(&mockDependencyMapProvider{edges: testTrafficEdges()})
+	// Goroutine lifecycle: Starts server, terminated by server.Stop() in cleanup.
+	go func() {
+		_ = server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	t.Cleanup(server.Stop)
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 5*time.Millisecond)
+
+	client, err := grpc.NewClient(server.Address())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	edges, err := client.DependencyMap(ctx, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, testTrafficEdges(), edges)
+}
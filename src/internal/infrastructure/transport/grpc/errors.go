@@ -0,0 +1,125 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// errorDomain is the ErrorInfo domain attached to coded errors.
+const errorDomain string = "supervizio.daemon"
+
+// statusCodes maps domain error codes to gRPC status codes.
+var statusCodes map[shared.Code]codes.Code = map[shared.Code]codes.Code{
+	shared.CodeInvalidArgument:          codes.InvalidArgument,
+	shared.CodeServiceNotFound:          codes.NotFound,
+	shared.CodeServiceAlreadyRunning:    codes.FailedPrecondition,
+	shared.CodeServiceNotRunning:        codes.FailedPrecondition,
+	shared.CodeServiceInvalidTransition: codes.FailedPrecondition,
+	shared.CodeServiceFailed:            codes.Aborted,
+	shared.CodeServiceRetriesExhausted:  codes.FailedPrecondition,
+	shared.CodeServiceUnhealthy:         codes.Unavailable,
+	shared.CodeServiceStartTimeout:      codes.DeadlineExceeded,
+	shared.CodeServiceMaxRuntime:        codes.DeadlineExceeded,
+	shared.CodeSignalNotAllowed:         codes.PermissionDenied,
+	shared.CodeAdmissionRefused:         codes.ResourceExhausted,
+	shared.CodeConditionsUnmet:          codes.FailedPrecondition,
+	shared.CodeSupervisorAlreadyRunning: codes.FailedPrecondition,
+	shared.CodeSupervisorNotRunning:     codes.Unavailable,
+	shared.CodeBootFailed:               codes.Aborted,
+	shared.CodeConfigInvalid:            codes.InvalidArgument,
+	shared.CodeConfigUnreadable:         codes.FailedPrecondition,
+	shared.CodeConfigPreflightFailed:    codes.FailedPrecondition,
+	shared.CodeOperationConflict:        codes.AlreadyExists,
+	shared.CodeEventStoreUnavailable:    codes.Unavailable,
+	shared.CodeReadOnly:                 codes.PermissionDenied,
+}
+
+// toStatus converts an error into a gRPC status error.
+// Coded errors keep their message and carry the domain code as the
+// ErrorInfo reason, so clients can match on it. Status errors pass
+// through unchanged; context errors map to Canceled/DeadlineExceeded.
+//
+// Params:
+//   - err: the error returned by a handler.
+//
+// Returns:
+//   - error: the status error, or nil when err is nil.
+func toStatus(err error) error {
+	// nothing to convert
+	if err == nil {
+		// propagate success
+		return nil
+	}
+
+	// keep errors already carrying a status
+	if _, ok := status.FromError(err); ok {
+		// already converted
+		return err
+	}
+
+	// map cancellation and deadlines
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// context status
+		return status.FromContextError(err).Err()
+	}
+
+	code := shared.CodeOf(err)
+	grpcCode, ok := statusCodes[code]
+	// uncoded errors are reported as unknown
+	if !ok {
+		grpcCode = codes.Unknown
+	}
+
+	st := status.New(grpcCode, err.Error())
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(code),
+		Domain: errorDomain,
+	})
+	// fall back to the bare status if details cannot be attached
+	if detailErr != nil {
+		// status without details
+		return st.Err()
+	}
+	// status with error code details
+	return detailed.Err()
+}
+
+// unaryErrorInterceptor converts unary handler errors into status errors.
+//
+// Params:
+//   - ctx: request context.
+//   - req: the request message.
+//   - _: the method information (unused).
+//   - handler: the method handler.
+//
+// Returns:
+//   - any: the response message.
+//   - error: the converted status error.
+func unaryErrorInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	// convert handler error
+	return resp, toStatus(err)
+}
+
+// streamErrorInterceptor converts stream handler errors into status errors.
+//
+// Params:
+//   - srv: the service implementation.
+//   - ss: the server stream.
+//   - _: the stream information (unused).
+//   - handler: the stream handler.
+//
+// Returns:
+//   - error: the converted status error.
+func streamErrorInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	// convert handler error
+	return toStatus(handler(srv, ss))
+}
@@ -0,0 +1,60 @@
+// Package grpc provides internal tests for errors.go.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// Test_toStatus verifies mapping of domain errors to gRPC status errors.
+//
+// Params:
+//   - t: testing context.
+func Test_toStatus(t *testing.T) {
+	notFound := shared.NewCodedError(shared.CodeServiceNotFound, "service not found")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantMsg    string
+		wantReason string
+	}{
+		{name: "coded", err: fmt.Errorf("%w: api", notFound), wantCode: codes.NotFound, wantMsg: "service not found: api", wantReason: "SVC_NOT_FOUND"},
+		{name: "config", err: shared.WithCode(shared.CodeConfigInvalid, errors.New("bad")), wantCode: codes.InvalidArgument, wantMsg: "bad", wantReason: "CFG_INVALID"},
+		{name: "uncoded", err: errors.New("boom"), wantCode: codes.Unknown, wantMsg: "boom", wantReason: "UNKNOWN"},
+		{name: "canceled", err: context.Canceled, wantCode: codes.Canceled, wantMsg: "context canceled"},
+		{name: "status", err: status.Error(codes.PermissionDenied, "no"), wantCode: codes.PermissionDenied, wantMsg: "no"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(toStatus(tt.err))
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCode, st.Code())
+			assert.Equal(t, tt.wantMsg, st.Message())
+
+			// Coded errors carry the domain code as ErrorInfo reason.
+			if tt.wantReason == "" {
+				return
+			}
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantReason, info.Reason)
+			assert.Equal(t, errorDomain, info.Domain)
+		})
+	}
+
+	assert.NoError(t, toStatus(nil))
+}
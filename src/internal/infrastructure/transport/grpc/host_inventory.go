@@ -0,0 +1,101 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// HostInventoryProvider detects the platform capabilities of the host.
+type HostInventoryProvider interface {
+	// HostInventory returns the platform capabilities of the host.
+	HostInventory(ctx context.Context) (metrics.HostInventory, error)
+}
+
+// SetHostInventoryProvider sets the source of the host inventory.
+// Without a provider, GetHostInventory returns Unimplemented.
+//
+// Params:
+//   - provider: the host inventory provider.
+func (s *Server) SetHostInventoryProvider(provider HostInventoryProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store host inventory provider
+	s.hostInventory = provider
+}
+
+// GetHostInventory implements DaemonService.GetHostInventory.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.HostInventory: the platform capabilities and feature support of the host.
+//   - error: if the inventory is not configured or cannot be detected.
+func (s *Server) GetHostInventory(ctx context.Context, _ *emptypb.Empty) (*daemonpb.HostInventory, error) {
+	s.mu.Lock()
+	provider := s.hostInventory
+	s.mu.Unlock()
+
+	// Check if the inventory is configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "host inventory not configured")
+	}
+
+	inventory, err := provider.HostInventory(ctx)
+	// Check if the host was detected.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get host inventory: %w", err)
+	}
+	// Return converted inventory.
+	return convertHostInventory(&inventory), nil
+}
+
+// convertHostInventory converts the host inventory to protobuf format,
+// with the support derived for each feature.
+//
+// Params:
+//   - inventory: the host inventory.
+//
+// Returns:
+//   - *daemonpb.HostInventory: protobuf host inventory.
+func convertHostInventory(inventory *metrics.HostInventory) *daemonpb.HostInventory {
+	pb := &daemonpb.HostInventory{
+		Os:              inventory.OS,
+		Architecture:    inventory.Architecture,
+		KernelVersion:   inventory.KernelVersion,
+		CgroupVersion:   int32(inventory.CgroupVersion),
+		PressureStall:   inventory.PressureStall,
+		SecurityModules: inventory.SecurityModules,
+		Nftables:        inventory.Nftables,
+		Systemd:         inventory.Systemd,
+		Pid1:            inventory.PID1,
+		Privileged:      inventory.Privileged,
+		MaxFds:          inventory.MaxFDs,
+		MaxFdsHard:      inventory.MaxFDsHard,
+	}
+	// Convert each runtime socket.
+	for _, socket := range inventory.ContainerSockets {
+		pb.ContainerSockets = append(pb.ContainerSockets, &daemonpb.ContainerSocket{Runtime: socket.Runtime, Path: socket.Path})
+	}
+	// Convert the support of each feature.
+	for _, support := range inventory.Features() {
+		pb.Features = append(pb.Features, &daemonpb.FeatureSupport{
+			Name:      support.Name,
+			Supported: support.Supported,
+			Reason:    support.Reason,
+		})
+	}
+	// Return converted inventory.
+	return pb
+}
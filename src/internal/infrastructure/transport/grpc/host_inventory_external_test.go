@@ -0,0 +1,62 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockHostInventoryProvider returns a fixed host inventory.
+type mockHostInventoryProvider struct {
+	inventory metrics.HostInventory
+	err       error
+}
+
+func (m *mockHostInventoryProvider) HostInventory(_ context.Context) (metrics.HostInventory, error) {
+	return m.inventory, m.err
+}
+
+// TestServer_GetHostInventory verifies the host inventory and its derived features.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetHostInventory(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	_, err := server.GetHostInventory(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	server.SetHostInventoryProvider(&mockHostInventoryProvider{inventory: metrics.HostInventory{
+		OS:               "linux",
+		CgroupVersion:    1,
+		ContainerSockets: []metrics.ContainerSocket{{Runtime: "docker", Path: "/run/docker.sock"}},
+		MaxFDs:           1024,
+	}})
+	resp, err := server.GetHostInventory(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.CgroupVersion)
+	assert.Equal(t, uint64(1024), resp.MaxFds)
+	require.Len(t, resp.ContainerSockets, 1)
+	assert.Equal(t, "docker", resp.ContainerSockets[0].Runtime)
+	require.Len(t, resp.Features, 9)
+	assert.Equal(t, metrics.FeatureCgroupMetrics, resp.Features[0].Name)
+	assert.True(t, resp.Features[0].Supported)
+	assert.Equal(t, metrics.FeatureCgroupPressure, resp.Features[1].Name)
+	assert.Equal(t, "needs a cgroup v2 hierarchy", resp.Features[1].Reason)
+
+	failing := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	failing.SetHostInventoryProvider(&mockHostInventoryProvider{err: errors.New("no procfs")})
+	_, err = failing.GetHostInventory(context.Background(), &emptypb.Empty{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// JobHistoryProvider provides the last runs of oneshot services.
+type JobHistoryProvider interface {
+	// JobRuns returns the last runs of a oneshot service, most recent first.
+	JobRuns(name string) ([]process.JobRun, error)
+}
+
+// SetJobHistoryProvider sets the source of job runs.
+// Without a provider, GetJobHistory returns Unimplemented.
+//
+// Params:
+//   - provider: the job history provider.
+func (s *Server) SetJobHistoryProvider(provider JobHistoryProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store job history provider
+	s.jobHistory = provider
+}
+
+// GetJobHistory implements DaemonService.GetJobHistory.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service name.
+//
+// Returns:
+//   - *daemonpb.JobHistory: the last runs.
+//   - error: if job history is not configured or the service is unknown.
+func (s *Server) GetJobHistory(_ context.Context, req *daemonpb.GetJobHistoryRequest) (*daemonpb.JobHistory, error) {
+	s.mu.Lock()
+	provider := s.jobHistory
+	s.mu.Unlock()
+
+	// Check if job history is configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "job history not configured")
+	}
+
+	runs, err := provider.JobRuns(req.ServiceName)
+	// Check if the lookup failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get job history: %w", err)
+	}
+
+	history := &daemonpb.JobHistory{
+		ServiceName: req.ServiceName,
+		Runs:        make([]*daemonpb.JobRun, 0, len(runs)),
+	}
+	// Convert each run.
+	for i := range runs {
+		history.Runs = append(history.Runs, convertJobRun(&runs[i]))
+	}
+	// Return converted history.
+	return history, nil
+}
+
+// convertJobRun converts a job run to protobuf format.
+//
+// Params:
+//   - run: the job run.
+//
+// Returns:
+//   - *daemonpb.JobRun: protobuf job run.
+func convertJobRun(run *process.JobRun) *daemonpb.JobRun {
+	// Return converted run.
+	return &daemonpb.JobRun{
+		StartedAt: timestamppb.New(run.StartedAt),
+		Duration:  durationpb.New(run.Duration),
+		ExitCode:  int32(run.ExitCode),
+		Signal:    int32(run.Signal),
+		Succeeded: run.Succeeded,
+		Error:     run.Error,
+		Output:    run.Output,
+	}
+}
@@ -0,0 +1,92 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockJobHistory returns fixed runs for one service.
+type mockJobHistory struct {
+	service string
+	runs    []process.JobRun
+}
+
+func (m *mockJobHistory) JobRuns(name string) ([]process.JobRun, error) {
+	if name != m.service {
+		return nil, errUnknownService
+	}
+	return m.runs, nil
+}
+
+// TestServer_GetJobHistory verifies job run conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetJobHistory(t *testing.T) {
+	t.Parallel()
+
+	at := time.Now()
+	provider := &mockJobHistory{service: "backup", runs: []process.JobRun{
+		{Service: "backup", StartedAt: at, Duration: time.Minute, ExitCode: 2, Error: "exit code 2", Output: []string{"disk full"}},
+		{Service: "backup", StartedAt: at.Add(-time.Hour), Duration: 2 * time.Minute, Succeeded: true},
+	}}
+
+	tests := []struct {
+		name     string
+		service  string
+		wantErr  bool
+		wantRuns int
+	}{
+		{name: "oneshot service", service: "backup", wantRuns: 2},
+		{name: "unknown service", service: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetJobHistoryProvider(provider)
+
+			resp, err := server.GetJobHistory(context.Background(), &daemonpb.GetJobHistoryRequest{ServiceName: tt.service})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "backup", resp.ServiceName)
+			require.Len(t, resp.Runs, tt.wantRuns)
+			assert.Equal(t, int32(2), resp.Runs[0].ExitCode)
+			assert.False(t, resp.Runs[0].Succeeded)
+			assert.Equal(t, "exit code 2", resp.Runs[0].Error)
+			assert.Equal(t, []string{"disk full"}, resp.Runs[0].Output)
+			assert.Equal(t, time.Minute, resp.Runs[0].Duration.AsDuration())
+			assert.True(t, at.Equal(resp.Runs[0].StartedAt.AsTime()))
+			assert.True(t, resp.Runs[1].Succeeded)
+		})
+	}
+}
+
+// TestServer_GetJobHistory_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetJobHistory_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetJobHistory(context.Background(), &daemonpb.GetJobHistoryRequest{ServiceName: "backup"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
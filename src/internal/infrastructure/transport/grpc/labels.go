@@ -0,0 +1,41 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+// LabelProvider provides the labels of services.
+type LabelProvider interface {
+	// ServiceLabels returns the labels of a service, nil when it has none.
+	ServiceLabels(name string) map[string]string
+}
+
+// SetLabelProvider sets the source of service labels.
+// Without a provider, process metrics carry no labels.
+//
+// Params:
+//   - provider: the label provider.
+func (s *Server) SetLabelProvider(provider LabelProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store label provider
+	s.labels = provider
+}
+
+// serviceLabels returns the labels of a service for process metrics.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - map[string]string: the labels, nil without a provider.
+func (s *Server) serviceLabels(name string) map[string]string {
+	s.mu.Lock()
+	provider := s.labels
+	s.mu.Unlock()
+
+	// Check if labels are configured.
+	if provider == nil {
+		// No labels to report.
+		return nil
+	}
+	// Return the labels of the service.
+	return provider.ServiceLabels(name)
+}
@@ -0,0 +1,46 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockLabelProvider returns fixed labels per service.
+type mockLabelProvider struct {
+	labels map[string]map[string]string
+}
+
+// ServiceLabels returns the labels of the service.
+func (m *mockLabelProvider) ServiceLabels(name string) map[string]string {
+	return m.labels[name]
+}
+
+// TestServer_GetProcess_Labels verifies process metrics carry the service labels.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetProcess_Labels(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockMetricsProvider{processMetrics: metrics.ProcessMetrics{ServiceName: "nginx"}}
+	server := grpc.NewServer(provider, &mockGetStator{})
+
+	resp, err := server.GetProcess(context.Background(), &daemonpb.GetProcessRequest{ServiceName: "nginx"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Labels)
+
+	server.SetLabelProvider(&mockLabelProvider{labels: map[string]map[string]string{
+		"nginx": {"team": "edge", "tier": "frontend"},
+	}})
+	resp, err = server.GetProcess(context.Background(), &daemonpb.GetProcessRequest{ServiceName: "nginx"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "edge", "tier": "frontend"}, resp.Labels)
+}
@@ -0,0 +1,278 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"cmp"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+const (
+	// defaultProcessOrder is the sort key of requests without order_by.
+	defaultProcessOrder string = "name"
+	// descendingSuffix reverses the sort key of order_by.
+	descendingSuffix string = " desc"
+)
+
+// errMalformedPageToken indicates a page token not issued by ListProcesses.
+var errMalformedPageToken error = errors.New("malformed page token")
+
+// processOrders compares processes by each ListProcesses sort key.
+var processOrders map[string]func(a, b *metrics.ProcessMetrics) int = map[string]func(a, b *metrics.ProcessMetrics) int{
+	"name":     func(a, b *metrics.ProcessMetrics) int { return cmp.Compare(a.ServiceName, b.ServiceName) },
+	"state":    func(a, b *metrics.ProcessMetrics) int { return cmp.Compare(a.State, b.State) },
+	"cpu":      func(a, b *metrics.ProcessMetrics) int { return cmp.Compare(a.CPU.UsagePercent, b.CPU.UsagePercent) },
+	"memory":   func(a, b *metrics.ProcessMetrics) int { return cmp.Compare(a.Memory.RSS, b.Memory.RSS) },
+	"restarts": func(a, b *metrics.ProcessMetrics) int { return cmp.Compare(a.RestartCount, b.RestartCount) },
+	"uptime":   func(a, b *metrics.ProcessMetrics) int { return cmp.Compare(a.Uptime, b.Uptime) },
+}
+
+// processQuery is a validated ListProcesses request.
+type processQuery struct {
+	// labels are the labels a service must carry.
+	labels map[string]string
+	// states are the accepted states, empty for all.
+	states map[daemonpb.ProcessState]bool
+	// order compares two processes by the sort key.
+	order func(a, b *metrics.ProcessMetrics) int
+	// descending reverses the order.
+	descending bool
+	// offset is the index of the first process of the page.
+	offset int
+	// size is the page size, zero for all.
+	size int
+	// fields are the fields to keep, empty for all.
+	fields map[protoreflect.Name]bool
+}
+
+// parseProcessQuery validates a ListProcesses request.
+//
+// Params:
+//   - req: the request.
+//
+// Returns:
+//   - processQuery: the validated query.
+//   - error: a coded invalid argument error for a bad sort key, page or field.
+func parseProcessQuery(req *daemonpb.ListProcessesRequest) (processQuery, error) {
+	query := processQuery{labels: req.LabelSelector, size: int(req.PageSize)}
+
+	key, descending := strings.CutSuffix(strings.TrimSpace(req.OrderBy), descendingSuffix)
+	// Sort by name unless asked otherwise.
+	if key == "" {
+		key = defaultProcessOrder
+	}
+	order, ok := processOrders[key]
+	// Check if the sort key is known.
+	if !ok {
+		// Return invalid argument error.
+		return query, shared.WithCode(shared.CodeInvalidArgument, fmt.Errorf("order_by %q: unknown sort key", req.OrderBy))
+	}
+	query.order, query.descending = order, descending
+
+	// Check if the page size is valid.
+	if req.PageSize < 0 {
+		// Return invalid argument error.
+		return query, shared.WithCode(shared.CodeInvalidArgument, fmt.Errorf("page_size %d: must not be negative", req.PageSize))
+	}
+	offset, err := decodePageToken(req.PageToken)
+	// Check if the page token is valid.
+	if err != nil {
+		// Return invalid argument error.
+		return query, shared.WithCode(shared.CodeInvalidArgument, fmt.Errorf("page_token %q: %w", req.PageToken, err))
+	}
+	query.offset = offset
+
+	// Accept the requested states.
+	if len(req.States) > 0 {
+		query.states = make(map[daemonpb.ProcessState]bool, len(req.States))
+		// Record each state.
+		for _, state := range req.States {
+			query.states[state] = true
+		}
+	}
+
+	// Keep every field unless some are selected.
+	if len(req.Fields) > 0 {
+		descriptor := (&daemonpb.ProcessMetrics{}).ProtoReflect().Descriptor().Fields()
+		query.fields = map[protoreflect.Name]bool{"service_name": true}
+		// Check each selected field.
+		for _, field := range req.Fields {
+			name := protoreflect.Name(field)
+			// Check if the field exists.
+			if descriptor.ByName(name) == nil {
+				// Return invalid argument error.
+				return query, shared.WithCode(shared.CodeInvalidArgument, fmt.Errorf("fields: unknown field %q", field))
+			}
+			query.fields[name] = true
+		}
+	}
+	// Return validated query.
+	return query, nil
+}
+
+// filterProcesses returns the processes matching the labels and states of a query.
+//
+// Params:
+//   - all: the metrics of every process.
+//   - query: the validated query.
+//
+// Returns:
+//   - []metrics.ProcessMetrics: the matching processes.
+func (s *Server) filterProcesses(all []metrics.ProcessMetrics, query *processQuery) []metrics.ProcessMetrics {
+	matching := make([]metrics.ProcessMetrics, 0, len(all))
+	// Keep the matching processes.
+	for i := range all {
+		// Skip processes in other states.
+		if query.states != nil && !query.states[s.convertProcessState(all[i].State)] {
+			continue
+		}
+		// Skip services missing a label.
+		if len(query.labels) > 0 && !labelsMatch(s.serviceLabels(all[i].ServiceName), query.labels) {
+			continue
+		}
+		matching = append(matching, all[i])
+	}
+	// Return matching processes.
+	return matching
+}
+
+// labelsMatch reports whether labels carry every label of a selector.
+//
+// Params:
+//   - labels: the labels of a service.
+//   - selector: the labels to match.
+//
+// Returns:
+//   - bool: true when each selector label has the same value.
+func labelsMatch(labels, selector map[string]string) bool {
+	// Compare each selector label.
+	for key, value := range selector {
+		// Check if the label is missing or differs.
+		if got, ok := labels[key]; !ok || got != value {
+			// Label not matched.
+			return false
+		}
+	}
+	// Every label matched.
+	return true
+}
+
+// sortProcesses sorts processes by the key of a query, then by name.
+//
+// Params:
+//   - processes: the processes to sort in place.
+//   - query: the validated query.
+func sortProcesses(processes []metrics.ProcessMetrics, query *processQuery) {
+	slices.SortStableFunc(processes, func(a, b metrics.ProcessMetrics) int {
+		order := query.order(&a, &b)
+		// Reverse descending orders.
+		if query.descending {
+			order = -order
+		}
+		// Break ties by name, so pages are stable.
+		return cmp.Or(order, cmp.Compare(a.ServiceName, b.ServiceName))
+	})
+}
+
+// page returns the page of a query and the token of the next one.
+//
+// Params:
+//   - processes: the sorted matching processes.
+//
+// Returns:
+//   - []metrics.ProcessMetrics: the processes of the page.
+//   - string: the token of the next page, empty on the last page.
+func (q *processQuery) page(processes []metrics.ProcessMetrics) ([]metrics.ProcessMetrics, string) {
+	start := min(q.offset, len(processes))
+	// Return the rest without page size.
+	if q.size == 0 {
+		// Last page.
+		return processes[start:], ""
+	}
+	end := min(start+q.size, len(processes))
+	// Check if processes follow the page.
+	if end < len(processes) {
+		// Return page with the next token.
+		return processes[start:end], encodePageToken(end)
+	}
+	// Last page.
+	return processes[start:end], ""
+}
+
+// prune clears the fields of a process not selected by the query.
+//
+// Params:
+//   - pb: the protobuf process metrics.
+//
+// Returns:
+//   - *daemonpb.ProcessMetrics: the pruned metrics.
+func (q *processQuery) prune(pb *daemonpb.ProcessMetrics) *daemonpb.ProcessMetrics {
+	// Keep every field without selection.
+	if q.fields == nil {
+		// Return unchanged metrics.
+		return pb
+	}
+	msg := pb.ProtoReflect()
+	fields := msg.Descriptor().Fields()
+	// Clear the unselected fields.
+	for i := range fields.Len() {
+		// Check if the field is selected.
+		if field := fields.Get(i); !q.fields[field.Name()] {
+			msg.Clear(field)
+		}
+	}
+	// Return pruned metrics.
+	return pb
+}
+
+// encodePageToken returns the opaque token of the page starting at offset.
+//
+// Params:
+//   - offset: the index of the first process of the page.
+//
+// Returns:
+//   - string: the page token.
+func encodePageToken(offset int) string {
+	// Return encoded offset.
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodePageToken returns the offset of a page token.
+//
+// Params:
+//   - token: the page token, empty for the first page.
+//
+// Returns:
+//   - int: the index of the first process of the page.
+//   - error: if the token is malformed.
+func decodePageToken(token string) (int, error) {
+	// Start at the first page without token.
+	if token == "" {
+		// Return first offset.
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	// Check if the token is encoded.
+	if err != nil {
+		// Return decoding error.
+		return 0, fmt.Errorf("%w: %w", errMalformedPageToken, err)
+	}
+	offset, err := strconv.Atoi(string(raw))
+	// Check if the token holds an offset.
+	if err != nil || offset < 0 {
+		// Return malformed token error.
+		return 0, errMalformedPageToken
+	}
+	// Return decoded offset.
+	return offset, nil
+}
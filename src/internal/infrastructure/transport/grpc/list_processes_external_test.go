@@ -0,0 +1,211 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// newListTestServer returns a server supervising four labeled services.
+//
+// Returns:
+//   - *grpc.Server: the server.
+func newListTestServer() *grpc.Server {
+	server := grpc.NewServer(&mockMetricsProvider{allProcessMetrics: []metrics.ProcessMetrics{
+		{ServiceName: "web", State: process.StateRunning, CPU: metrics.ProcessCPU{UsagePercent: 40}, RestartCount: 1},
+		{ServiceName: "api", State: process.StateRunning, CPU: metrics.ProcessCPU{UsagePercent: 80}, RestartCount: 3},
+		{ServiceName: "cron", State: process.StateStopped},
+		{ServiceName: "worker", State: process.StateFailed, RestartCount: 3},
+	}}, &mockGetStator{})
+	server.SetLabelProvider(&mockLabelProvider{labels: map[string]map[string]string{
+		"web":    {"team": "edge", "tier": "frontend"},
+		"api":    {"team": "edge", "tier": "backend"},
+		"worker": {"team": "data", "tier": "backend"},
+	}})
+	// Return configured server.
+	return server
+}
+
+// listedNames returns the service names of a ListProcesses response.
+//
+// Params:
+//   - resp: the response.
+//
+// Returns:
+//   - []string: the names, in order.
+func listedNames(resp *daemonpb.ListProcessesResponse) []string {
+	names := make([]string, 0, len(resp.Processes))
+	// Collect each name.
+	for _, proc := range resp.Processes {
+		names = append(names, proc.ServiceName)
+	}
+	// Return names.
+	return names
+}
+
+// TestServer_ListProcesses_Query verifies filters, sort keys and pages.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ListProcesses_Query(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		req       *daemonpb.ListProcessesRequest
+		wantNames []string
+		wantTotal int32
+		wantNext  bool
+	}{
+		{
+			name:      "sorted by name",
+			req:       &daemonpb.ListProcessesRequest{},
+			wantNames: []string{"api", "cron", "web", "worker"},
+			wantTotal: 4,
+		},
+		{
+			name:      "label selector",
+			req:       &daemonpb.ListProcessesRequest{LabelSelector: map[string]string{"tier": "backend"}},
+			wantNames: []string{"api", "worker"},
+			wantTotal: 2,
+		},
+		{
+			name: "every label must match",
+			req: &daemonpb.ListProcessesRequest{
+				LabelSelector: map[string]string{"tier": "backend", "team": "edge"},
+			},
+			wantNames: []string{"api"},
+			wantTotal: 1,
+		},
+		{
+			name: "states",
+			req: &daemonpb.ListProcessesRequest{States: []daemonpb.ProcessState{
+				daemonpb.ProcessState_PROCESS_STATE_STOPPED, daemonpb.ProcessState_PROCESS_STATE_FAILED,
+			}},
+			wantNames: []string{"cron", "worker"},
+			wantTotal: 2,
+		},
+		{
+			name:      "cpu descending",
+			req:       &daemonpb.ListProcessesRequest{OrderBy: "cpu desc"},
+			wantNames: []string{"api", "web", "cron", "worker"},
+			wantTotal: 4,
+		},
+		{
+			name:      "ties broken by name",
+			req:       &daemonpb.ListProcessesRequest{OrderBy: "restarts desc"},
+			wantNames: []string{"api", "worker", "web", "cron"},
+			wantTotal: 4,
+		},
+		{
+			name:      "first page",
+			req:       &daemonpb.ListProcessesRequest{PageSize: 3},
+			wantNames: []string{"api", "cron", "web"},
+			wantTotal: 4,
+			wantNext:  true,
+		},
+		{
+			name:      "page larger than the list",
+			req:       &daemonpb.ListProcessesRequest{PageSize: 10},
+			wantNames: []string{"api", "cron", "web", "worker"},
+			wantTotal: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := newListTestServer().ListProcesses(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNames, listedNames(resp))
+			assert.Equal(t, tt.wantTotal, resp.TotalSize)
+			assert.Equal(t, tt.wantNext, resp.NextPageToken != "")
+		})
+	}
+}
+
+// TestServer_ListProcesses_Pages verifies that following next_page_token
+// lists every process once.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ListProcesses_Pages(t *testing.T) {
+	t.Parallel()
+
+	server := newListTestServer()
+	req := &daemonpb.ListProcessesRequest{PageSize: 3, OrderBy: "cpu desc"}
+
+	first, err := server.ListProcesses(context.Background(), req)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextPageToken)
+
+	req.PageToken = first.NextPageToken
+	second, err := server.ListProcesses(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, second.NextPageToken)
+	assert.Equal(t, []string{"api", "web", "cron"}, listedNames(first))
+	assert.Equal(t, []string{"worker"}, listedNames(second))
+}
+
+// TestServer_ListProcesses_Fields verifies that only the selected fields are filled.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ListProcesses_Fields(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{allProcessMetrics: []metrics.ProcessMetrics{
+		{ServiceName: "web", PID: 42, State: process.StateRunning, RestartCount: 2, Uptime: time.Minute},
+	}}, &mockGetStator{})
+
+	resp, err := server.ListProcesses(context.Background(), &daemonpb.ListProcessesRequest{Fields: []string{"pid", "state"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Processes, 1)
+	proc := resp.Processes[0]
+	assert.Equal(t, "web", proc.ServiceName)
+	assert.Equal(t, int32(42), proc.Pid)
+	assert.Equal(t, daemonpb.ProcessState_PROCESS_STATE_RUNNING, proc.State)
+	assert.Zero(t, proc.RestartCount)
+	assert.Nil(t, proc.Uptime)
+	assert.Nil(t, proc.Cpu)
+}
+
+// TestServer_ListProcesses_InvalidArgument verifies malformed requests are refused.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ListProcesses_InvalidArgument(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		req  *daemonpb.ListProcessesRequest
+	}{
+		{name: "unknown sort key", req: &daemonpb.ListProcessesRequest{OrderBy: "color"}},
+		{name: "negative page size", req: &daemonpb.ListProcessesRequest{PageSize: -1}},
+		{name: "malformed page token", req: &daemonpb.ListProcessesRequest{PageToken: "!!"}},
+		{name: "page token without offset", req: &daemonpb.ListProcessesRequest{PageToken: "YWJj"}},
+		{name: "unknown field", req: &daemonpb.ListProcessesRequest{Fields: []string{"color"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newListTestServer().ListProcesses(context.Background(), tt.req)
+			require.Error(t, err)
+			assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+		})
+	}
+}
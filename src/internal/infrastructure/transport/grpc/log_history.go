@@ -0,0 +1,72 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/logging"
+)
+
+// SetLogHistory sets the reader of service log files for ReadLogs.
+// Without a reader, ReadLogs returns Unimplemented.
+//
+// Params:
+//   - history: the log file reader.
+func (s *Server) SetLogHistory(history logging.OutputHistory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store log history
+	s.logHistory = history
+}
+
+// ReadLogs implements DaemonService.ReadLogs.
+// It sends the matching lines of the service log files, oldest first, then
+// ends the stream.
+//
+// Params:
+//   - req: service, since, and filter settings.
+//   - stream: server stream for log lines.
+//
+// Returns:
+//   - error: if the request is invalid, reading is disabled, the service is
+//     unknown, or reading or sending fails.
+func (s *Server) ReadLogs(req *daemonpb.ReadLogsRequest, stream daemonpb.DaemonService_ReadLogsServer) error {
+	s.mu.Lock()
+	history := s.logHistory
+	s.mu.Unlock()
+
+	// Check if log reading is configured.
+	if history == nil {
+		// Report disabled feature.
+		return status.Error(codes.Unimplemented, "log history not configured")
+	}
+
+	filter, err := logFilter(nil, req.MinLevel, req.Pattern)
+	// Check if filter is valid.
+	if err != nil {
+		// Return invalid argument error.
+		return err
+	}
+	var since time.Time
+	// Start from the requested time if provided.
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+
+	err = history.Read(stream.Context(), req.ServiceName, since, filter, func(line *logging.OutputLine) error {
+		// Forward each line.
+		return stream.Send(convertLogLine(line, 0))
+	})
+	// Check if the read failed.
+	if err != nil {
+		// Return wrapped error.
+		return fmt.Errorf("read logs: %w", err)
+	}
+	// Lines sent.
+	return nil
+}
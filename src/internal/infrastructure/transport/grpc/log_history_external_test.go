@@ -0,0 +1,122 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockOutputHistory sends the lines of the "api" service that match the
+// filter and were written since the requested time.
+type mockOutputHistory struct {
+	lines []domainlogging.OutputLine
+}
+
+func (m *mockOutputHistory) Read(_ context.Context, service string, since time.Time, filter domainlogging.OutputFilter, send func(*domainlogging.OutputLine) error) error {
+	if service != "api" {
+		return errUnknownService
+	}
+	for i := range m.lines {
+		if m.lines[i].Timestamp.Before(since) || !filter.Matches(&m.lines[i]) {
+			continue
+		}
+		if err := send(&m.lines[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newMockOutputHistory returns a history of three lines a minute apart.
+func newMockOutputHistory(start time.Time) *mockOutputHistory {
+	history := &mockOutputHistory{lines: []domainlogging.OutputLine{
+		domainlogging.NewOutputLine("api", domainlogging.StreamStdout, "starting"),
+		domainlogging.NewOutputLine("api", domainlogging.StreamStderr, "ERROR db down"),
+		domainlogging.NewOutputLine("api", domainlogging.StreamStdout, "ready"),
+	}}
+	for i := range history.lines {
+		history.lines[i].Timestamp = start.Add(time.Duration(i) * time.Minute)
+	}
+	return history
+}
+
+// TestServer_ReadLogs verifies read requests reach the history.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ReadLogs(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	history := newMockOutputHistory(start)
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetLogHistory(history)
+
+	tests := []struct {
+		name     string
+		req      *daemonpb.ReadLogsRequest
+		expected []string
+	}{
+		{
+			name:     "all lines",
+			req:      &daemonpb.ReadLogsRequest{ServiceName: "api"},
+			expected: []string{"starting", "ERROR db down", "ready"},
+		},
+		{
+			name:     "since",
+			req:      &daemonpb.ReadLogsRequest{ServiceName: "api", Since: timestamppb.New(start.Add(time.Minute))},
+			expected: []string{"ERROR db down", "ready"},
+		},
+		{
+			name:     "pattern",
+			req:      &daemonpb.ReadLogsRequest{ServiceName: "api", Pattern: "ERROR"},
+			expected: []string{"ERROR db down"},
+		},
+		{
+			name:     "minimum level",
+			req:      &daemonpb.ReadLogsRequest{ServiceName: "api", MinLevel: "error"},
+			expected: []string{"ERROR db down"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stream := &mockStreamLogsServer{ctx: context.Background()}
+			require.NoError(t, server.ReadLogs(tt.req, stream))
+			assert.Equal(t, tt.expected, stream.messages())
+		})
+	}
+
+	stream := &mockStreamLogsServer{ctx: context.Background()}
+	err := server.ReadLogs(&daemonpb.ReadLogsRequest{ServiceName: "web"}, stream)
+	assert.ErrorIs(t, err, errUnknownService)
+
+	err = server.ReadLogs(&daemonpb.ReadLogsRequest{ServiceName: "api", Pattern: "("}, stream)
+	assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+	assert.Empty(t, stream.messages())
+}
+
+// TestServer_ReadLogs_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ReadLogs_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	err := server.ReadLogs(&daemonpb.ReadLogsRequest{ServiceName: "api"}, &mockStreamLogsServer{ctx: context.Background()})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
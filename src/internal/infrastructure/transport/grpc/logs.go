@@ -0,0 +1,160 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"fmt"
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// SetLogStreamer sets the source of captured service output for StreamLogs.
+// Without a streamer, StreamLogs returns Unimplemented.
+//
+// Params:
+//   - streamer: the captured output source.
+func (s *Server) SetLogStreamer(streamer logging.OutputStreamer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store log streamer
+	s.logStreamer = streamer
+}
+
+// StreamLogs implements DaemonService.StreamLogs.
+// It sends the last requested lines, then with follow keeps sending new
+// lines until the client disconnects. A client that reads too slowly loses
+// lines; the loss is reported in the dropped field of the next message.
+//
+// Params:
+//   - req: services, tail, follow, and filter settings.
+//   - stream: server stream for log lines.
+//
+// Returns:
+//   - error: if the request is invalid, streaming is disabled, or sending fails.
+func (s *Server) StreamLogs(req *daemonpb.StreamLogsRequest, stream daemonpb.DaemonService_StreamLogsServer) error {
+	s.mu.Lock()
+	streamer := s.logStreamer
+	s.mu.Unlock()
+
+	// Check if log streaming is configured.
+	if streamer == nil {
+		// Report disabled feature.
+		return status.Error(codes.Unimplemented, "log streaming not configured")
+	}
+
+	filter, err := logFilter(req.Services, req.MinLevel, req.Pattern)
+	// Check if filter is valid.
+	if err != nil {
+		// Return invalid argument error.
+		return err
+	}
+	tail := max(int(req.Tail), 0)
+
+	// Send backlog only without follow.
+	if !req.Follow {
+		// Send each recent line.
+		for _, line := range streamer.Tail(filter, tail) {
+			// Check if send failed.
+			if err := stream.Send(convertLogLine(&line, 0)); err != nil {
+				// Return send error.
+				return err
+			}
+		}
+		// Backlog sent.
+		return nil
+	}
+
+	sub := streamer.Subscribe(filter, tail)
+	defer sub.Close()
+	// Track drops already reported to the client.
+	var reported uint64
+
+	// Loop until client disconnects.
+	for {
+		select {
+		// Check if client disconnected.
+		case <-stream.Context().Done():
+			// Return context error.
+			return stream.Context().Err()
+		// Forward next line.
+		case line, ok := <-sub.Lines():
+			// Check if subscription ended.
+			if !ok {
+				// Stream finished.
+				return nil
+			}
+			dropped := sub.Dropped()
+			// Check if send failed.
+			if err := stream.Send(convertLogLine(&line, dropped-reported)); err != nil {
+				// Return send error.
+				return err
+			}
+			reported = dropped
+		}
+	}
+}
+
+// logFilter builds the output filter of a log request.
+//
+// Params:
+//   - services: the services to send; empty sends all.
+//   - minLevel: the lowest level to send; empty sends all.
+//   - pattern: the regular expression lines must match; empty matches all.
+//
+// Returns:
+//   - logging.OutputFilter: the filter.
+//   - error: a coded invalid argument error for a bad level or pattern.
+func logFilter(services []string, minLevel, pattern string) (logging.OutputFilter, error) {
+	filter := logging.OutputFilter{Services: services, MinLevel: logging.LevelDebug}
+
+	// Parse minimum level if provided.
+	if minLevel != "" {
+		level, err := logging.ParseLevel(minLevel)
+		// Check if level is valid.
+		if err != nil {
+			// Return invalid argument error.
+			return filter, shared.WithCode(shared.CodeInvalidArgument, fmt.Errorf("min_level %q: %w", minLevel, err))
+		}
+		filter.MinLevel = level
+	}
+
+	// Compile pattern if provided.
+	if pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		// Check if pattern is valid.
+		if err != nil {
+			// Return invalid argument error.
+			return filter, shared.WithCode(shared.CodeInvalidArgument, fmt.Errorf("pattern: %w", err))
+		}
+		filter.Pattern = compiled
+	}
+
+	// Return built filter.
+	return filter, nil
+}
+
+// convertLogLine converts a captured line to protobuf format.
+//
+// Params:
+//   - line: the captured line.
+//   - dropped: lines dropped since the previous message.
+//
+// Returns:
+//   - *daemonpb.LogLine: protobuf log line.
+func convertLogLine(line *logging.OutputLine, dropped uint64) *daemonpb.LogLine {
+	// Return converted line.
+	return &daemonpb.LogLine{
+		Service:   line.Service,
+		Stream:    line.Stream,
+		Timestamp: timestamppb.New(line.Timestamp),
+		Level:     line.Level.String(),
+		Message:   line.Message,
+		Dropped:   dropped,
+	}
+}
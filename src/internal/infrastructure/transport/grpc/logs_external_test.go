@@ -0,0 +1,163 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	domainlogging "github.com/kodflow/daemon/internal/domain/logging"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/logging"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockStreamLogsServer mocks the gRPC stream for StreamLogs.
+type mockStreamLogsServer struct {
+	daemonpb.DaemonService_StreamLogsServer
+	ctx  context.Context
+	sent []*daemonpb.LogLine
+	mu   sync.Mutex
+}
+
+func (m *mockStreamLogsServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockStreamLogsServer) Send(line *daemonpb.LogLine) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, line)
+	return nil
+}
+
+func (m *mockStreamLogsServer) messages() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []string
+	for _, line := range m.sent {
+		result = append(result, line.Message)
+	}
+	return result
+}
+
+// newLogsTestServer creates a server streaming from a hub with sample lines.
+func newLogsTestServer() (*grpc.Server, *logging.Hub) {
+	hub := logging.NewHub(0)
+	hub.Publish(domainlogging.NewOutputLine("api", domainlogging.StreamStdout, "api started"))
+	hub.Publish(domainlogging.NewOutputLine("web", domainlogging.StreamStderr, "ERROR web crashed"))
+	hub.Publish(domainlogging.NewOutputLine("api", domainlogging.StreamStderr, "WARN api slow"))
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetLogStreamer(hub)
+	return server, hub
+}
+
+// TestServer_StreamLogs verifies backlog filtering without follow.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_StreamLogs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      *daemonpb.StreamLogsRequest
+		expected []string
+	}{
+		{
+			name:     "all services",
+			req:      &daemonpb.StreamLogsRequest{Tail: 10},
+			expected: []string{"api started", "ERROR web crashed", "WARN api slow"},
+		},
+		{
+			name:     "one service",
+			req:      &daemonpb.StreamLogsRequest{Services: []string{"api"}, Tail: 10},
+			expected: []string{"api started", "WARN api slow"},
+		},
+		{
+			name:     "minimum level",
+			req:      &daemonpb.StreamLogsRequest{MinLevel: "warn", Tail: 10},
+			expected: []string{"ERROR web crashed", "WARN api slow"},
+		},
+		{
+			name:     "pattern",
+			req:      &daemonpb.StreamLogsRequest{Pattern: "crash|start", Tail: 10},
+			expected: []string{"api started", "ERROR web crashed"},
+		},
+		{
+			name:     "last line only",
+			req:      &daemonpb.StreamLogsRequest{Tail: 1},
+			expected: []string{"WARN api slow"},
+		},
+		{
+			name:     "no tail",
+			req:      &daemonpb.StreamLogsRequest{},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, _ := newLogsTestServer()
+			stream := &mockStreamLogsServer{ctx: context.Background()}
+
+			err := server.StreamLogs(tt.req, stream)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stream.messages())
+		})
+	}
+}
+
+// TestServer_StreamLogs_Follow verifies live lines are streamed until the client leaves.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_StreamLogs_Follow(t *testing.T) {
+	t.Parallel()
+
+	server, hub := newLogsTestServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &mockStreamLogsServer{ctx: ctx}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.StreamLogs(&daemonpb.StreamLogsRequest{Services: []string{"api"}, Follow: true, Tail: 1}, stream)
+	}()
+
+	require.Eventually(t, func() bool { return len(stream.messages()) == 1 }, time.Second, 5*time.Millisecond)
+	hub.Publish(domainlogging.NewOutputLine("api", domainlogging.StreamStdout, "api ready"))
+	require.Eventually(t, func() bool { return len(stream.messages()) == 2 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, []string{"WARN api slow", "api ready"}, stream.messages())
+}
+
+// TestServer_StreamLogs_Errors verifies invalid requests and disabled streaming.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_StreamLogs_Errors(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newLogsTestServer()
+	stream := &mockStreamLogsServer{ctx: context.Background()}
+
+	err := server.StreamLogs(&daemonpb.StreamLogsRequest{Pattern: "("}, stream)
+	assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+	err = server.StreamLogs(&daemonpb.StreamLogsRequest{MinLevel: "loud"}, stream)
+	assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+
+	disabled := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	err = disabled.StreamLogs(&daemonpb.StreamLogsRequest{}, stream)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,126 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// ParametersController reads and changes the daemon parameters.
+type ParametersController interface {
+	// Parameters returns the applied parameters.
+	Parameters() config.DaemonParameters
+	// SetParameters replaces, saves and applies the parameters.
+	SetParameters(params *config.DaemonParameters) error
+}
+
+// SetParametersController sets the target of daemon parameter requests.
+// Without a controller, GetDaemonParameters and SetDaemonParameters return
+// Unimplemented.
+//
+// Params:
+//   - controller: the parameters controller.
+func (s *Server) SetParametersController(controller ParametersController) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store parameters controller
+	s.parameters = controller
+}
+
+// GetDaemonParameters implements DaemonService.GetDaemonParameters.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.DaemonParameters: the applied parameters.
+//   - error: if parameters are not configured.
+func (s *Server) GetDaemonParameters(_ context.Context, _ *emptypb.Empty) (*daemonpb.DaemonParameters, error) {
+	s.mu.Lock()
+	controller := s.parameters
+	s.mu.Unlock()
+
+	// Check if parameters are configured.
+	if controller == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "daemon parameters not configured")
+	}
+	params := controller.Parameters()
+	// Return converted parameters.
+	return convertParameters(&params), nil
+}
+
+// SetDaemonParameters implements DaemonService.SetDaemonParameters.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: the new parameters.
+//
+// Returns:
+//   - *daemonpb.DaemonParameters: the applied parameters.
+//   - error: if parameters are not configured, invalid or cannot be saved.
+func (s *Server) SetDaemonParameters(_ context.Context, req *daemonpb.DaemonParameters) (*daemonpb.DaemonParameters, error) {
+	s.mu.Lock()
+	controller := s.parameters
+	s.mu.Unlock()
+
+	// Check if parameters are configured.
+	if controller == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "daemon parameters not configured")
+	}
+	params := config.DaemonParameters{
+		LogLevel:           req.LogLevel,
+		NotificationsMuted: req.NotificationsMuted,
+	}
+	// Keep the default interval when unset.
+	if req.ProbeInterval != nil {
+		params.ProbeInterval = shared.FromTimeDuration(req.ProbeInterval.AsDuration())
+	}
+	// Keep the default timeout when unset.
+	if req.ProbeTimeout != nil {
+		params.ProbeTimeout = shared.FromTimeDuration(req.ProbeTimeout.AsDuration())
+	}
+	// Check if the change was refused.
+	if err := controller.SetParameters(&params); err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("set daemon parameters: %w", err)
+	}
+	applied := controller.Parameters()
+	// Return converted parameters.
+	return convertParameters(&applied), nil
+}
+
+// convertParameters converts daemon parameters to protobuf.
+//
+// Params:
+//   - params: the daemon parameters.
+//
+// Returns:
+//   - *daemonpb.DaemonParameters: the protobuf parameters.
+func convertParameters(params *config.DaemonParameters) *daemonpb.DaemonParameters {
+	resp := &daemonpb.DaemonParameters{
+		LogLevel:           params.LogLevel,
+		NotificationsMuted: params.NotificationsMuted,
+	}
+	// Leave the default interval unset.
+	if params.ProbeInterval > 0 {
+		resp.ProbeInterval = durationpb.New(params.ProbeInterval.Duration())
+	}
+	// Leave the default timeout unset.
+	if params.ProbeTimeout > 0 {
+		resp.ProbeTimeout = durationpb.New(params.ProbeTimeout.Duration())
+	}
+	// Return converted parameters.
+	return resp
+}
@@ -0,0 +1,123 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockParametersController validates and keeps the parameters.
+type mockParametersController struct {
+	params config.DaemonParameters
+}
+
+func (m *mockParametersController) Parameters() config.DaemonParameters {
+	return m.params
+}
+
+func (m *mockParametersController) SetParameters(params *config.DaemonParameters) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	m.params = *params
+	return nil
+}
+
+// TestServer_SetDaemonParameters verifies parameter changes reach the controller.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SetDaemonParameters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *daemonpb.DaemonParameters
+		wantErr error
+		want    config.DaemonParameters
+	}{
+		{
+			name: "every parameter",
+			req: &daemonpb.DaemonParameters{
+				LogLevel:           "debug",
+				ProbeInterval:      durationpb.New(30 * time.Second),
+				ProbeTimeout:       durationpb.New(2 * time.Second),
+				NotificationsMuted: true,
+			},
+			want: config.DaemonParameters{
+				LogLevel:           "debug",
+				ProbeInterval:      shared.Seconds(30),
+				ProbeTimeout:       shared.Seconds(2),
+				NotificationsMuted: true,
+			},
+		},
+		{
+			name: "defaults kept",
+			req:  &daemonpb.DaemonParameters{NotificationsMuted: true},
+			want: config.DaemonParameters{NotificationsMuted: true},
+		},
+		{
+			name:    "invalid level",
+			req:     &daemonpb.DaemonParameters{LogLevel: "verbose"},
+			wantErr: config.ErrInvalidParameterLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := &mockParametersController{}
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetParametersController(controller)
+
+			resp, err := server.SetDaemonParameters(context.Background(), tt.req)
+
+			// refused changes leave the parameters untouched
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, config.DaemonParameters{}, controller.params)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, controller.params)
+			assert.Equal(t, tt.req.LogLevel, resp.LogLevel)
+			assert.Equal(t, tt.req.NotificationsMuted, resp.NotificationsMuted)
+			assert.Equal(t, tt.req.ProbeInterval.AsDuration(), resp.ProbeInterval.AsDuration())
+			assert.Equal(t, tt.req.ProbeTimeout.AsDuration(), resp.ProbeTimeout.AsDuration())
+
+			got, err := server.GetDaemonParameters(context.Background(), &emptypb.Empty{})
+			require.NoError(t, err)
+			assert.Equal(t, resp.LogLevel, got.LogLevel)
+			assert.Equal(t, resp.ProbeInterval.AsDuration(), got.ProbeInterval.AsDuration())
+		})
+	}
+}
+
+// TestServer_DaemonParameters_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_DaemonParameters_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetDaemonParameters(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = server.SetDaemonParameters(context.Background(), &daemonpb.DaemonParameters{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
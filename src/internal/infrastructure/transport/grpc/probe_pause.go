@@ -0,0 +1,124 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// ProbePauser pauses and resumes the listener probes of services.
+type ProbePauser interface {
+	// PauseProbes pauses the probes of a service or listener for a TTL.
+	PauseProbes(spec *health.ProbePauseSpec) ([]health.ProbePause, error)
+	// ResumeProbes lifts the probe pause of a listener, or of every listener.
+	ResumeProbes(name, listener string) ([]health.ProbePause, error)
+}
+
+// SetProbePauser sets the target of probe pause requests.
+// Without a pauser, PauseProbes and ResumeProbes return Unimplemented.
+//
+// Params:
+//   - pauser: the probe pauser.
+func (s *Server) SetProbePauser(pauser ProbePauser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store probe pauser
+	s.probePauser = pauser
+}
+
+// PauseProbes implements DaemonService.PauseProbes.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service, the listener and the TTL.
+//
+// Returns:
+//   - *daemonpb.ProbePauses: the active pauses of the service.
+//   - error: if pausing is not configured, the TTL is out of range, or the
+//     service or listener is unknown or has no probe.
+func (s *Server) PauseProbes(_ context.Context, req *daemonpb.PauseProbesRequest) (*daemonpb.ProbePauses, error) {
+	s.mu.Lock()
+	pauser := s.probePauser
+	s.mu.Unlock()
+
+	// Check if pausing is configured.
+	if pauser == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "probe pauses not configured")
+	}
+
+	spec := &health.ProbePauseSpec{Service: req.ServiceName, Listener: req.Listener}
+	// Keep the default TTL when none is requested.
+	if req.Ttl != nil {
+		spec.TTL = req.Ttl.AsDuration()
+	}
+	pauses, err := pauser.PauseProbes(spec)
+	// Check if the probes were paused.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("pause probes: %w", err)
+	}
+	// Return the active pauses.
+	return convertProbePauses(req.ServiceName, pauses), nil
+}
+
+// ResumeProbes implements DaemonService.ResumeProbes.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service and the listener.
+//
+// Returns:
+//   - *daemonpb.ProbePauses: the pauses of the service still active.
+//   - error: if pausing is not configured or the service is unknown.
+func (s *Server) ResumeProbes(_ context.Context, req *daemonpb.ResumeProbesRequest) (*daemonpb.ProbePauses, error) {
+	s.mu.Lock()
+	pauser := s.probePauser
+	s.mu.Unlock()
+
+	// Check if pausing is configured.
+	if pauser == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "probe pauses not configured")
+	}
+
+	pauses, err := pauser.ResumeProbes(req.ServiceName, req.Listener)
+	// Check if the probes were resumed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("resume probes: %w", err)
+	}
+	// Return the remaining pauses.
+	return convertProbePauses(req.ServiceName, pauses), nil
+}
+
+// convertProbePauses converts the probe pauses of a service to protobuf format.
+//
+// Params:
+//   - service: the service name.
+//   - pauses: the active pauses.
+//
+// Returns:
+//   - *daemonpb.ProbePauses: protobuf probe pauses.
+func convertProbePauses(service string, pauses []health.ProbePause) *daemonpb.ProbePauses {
+	resp := &daemonpb.ProbePauses{
+		ServiceName: service,
+		Pauses:      make([]*daemonpb.ProbePause, 0, len(pauses)),
+	}
+	// Convert each pause.
+	for _, pause := range pauses {
+		resp.Pauses = append(resp.Pauses, &daemonpb.ProbePause{
+			Listener: pause.Listener,
+			Until:    timestamppb.New(pause.Until),
+		})
+	}
+	// Return converted pauses.
+	return resp
+}
@@ -0,0 +1,131 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockProbePauser records the pause requests of one service.
+type mockProbePauser struct {
+	service string
+	specs   []health.ProbePauseSpec
+	resumed []string
+}
+
+func (m *mockProbePauser) PauseProbes(spec *health.ProbePauseSpec) ([]health.ProbePause, error) {
+	if spec.Service != m.service {
+		return nil, errUnknownService
+	}
+	m.specs = append(m.specs, *spec)
+	return []health.ProbePause{{Listener: spec.Listener, Until: time.Unix(1700000000, 0)}}, nil
+}
+
+func (m *mockProbePauser) ResumeProbes(name, listener string) ([]health.ProbePause, error) {
+	if name != m.service {
+		return nil, errUnknownService
+	}
+	m.resumed = append(m.resumed, listener)
+	return nil, nil
+}
+
+// TestServer_PauseProbes verifies probe pause requests reach the pauser.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_PauseProbes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *daemonpb.PauseProbesRequest
+		want    health.ProbePauseSpec
+		wantErr bool
+	}{
+		{
+			name: "listener",
+			req:  &daemonpb.PauseProbesRequest{ServiceName: "api", Listener: "http", Ttl: durationpb.New(time.Minute)},
+			want: health.ProbePauseSpec{Service: "api", Listener: "http", TTL: time.Minute},
+		},
+		{
+			name: "default ttl",
+			req:  &daemonpb.PauseProbesRequest{ServiceName: "api"},
+			want: health.ProbePauseSpec{Service: "api"},
+		},
+		{
+			name:    "unknown service",
+			req:     &daemonpb.PauseProbesRequest{ServiceName: "missing"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pauser := &mockProbePauser{service: "api"}
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetProbePauser(pauser)
+
+			resp, err := server.PauseProbes(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				assert.Empty(t, pauser.specs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []health.ProbePauseSpec{tt.want}, pauser.specs)
+			assert.Equal(t, "api", resp.ServiceName)
+			require.Len(t, resp.Pauses, 1)
+			assert.Equal(t, tt.want.Listener, resp.Pauses[0].Listener)
+			assert.Equal(t, int64(1700000000), resp.Pauses[0].Until.AsTime().Unix())
+		})
+	}
+}
+
+// TestServer_ResumeProbes verifies probe resume requests reach the pauser.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ResumeProbes(t *testing.T) {
+	t.Parallel()
+
+	pauser := &mockProbePauser{service: "api"}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetProbePauser(pauser)
+
+	resp, err := server.ResumeProbes(context.Background(), &daemonpb.ResumeProbesRequest{ServiceName: "api", Listener: "http"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http"}, pauser.resumed)
+	assert.Equal(t, "api", resp.ServiceName)
+	assert.Empty(t, resp.Pauses)
+
+	_, err = server.ResumeProbes(context.Background(), &daemonpb.ResumeProbesRequest{ServiceName: "missing"})
+	assert.ErrorIs(t, err, errUnknownService)
+}
+
+// TestServer_PauseProbes_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_PauseProbes_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.PauseProbes(context.Background(), &daemonpb.PauseProbesRequest{ServiceName: "api"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = server.ResumeProbes(context.Background(), &daemonpb.ResumeProbesRequest{ServiceName: "api"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,93 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+)
+
+// ProbeTracer provides the recent attempts of debug probes.
+type ProbeTracer interface {
+	// ProbeTrace returns the traced probe attempts of a service.
+	ProbeTrace(name string) ([]health.ProbeAttempt, error)
+}
+
+// SetProbeTracer sets the source of probe traces.
+// Without a tracer, GetProbeTrace returns Unimplemented.
+//
+// Params:
+//   - tracer: the probe tracer.
+func (s *Server) SetProbeTracer(tracer ProbeTracer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store probe tracer
+	s.probeTracer = tracer
+}
+
+// GetProbeTrace implements DaemonService.GetProbeTrace.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service name.
+//
+// Returns:
+//   - *daemonpb.ProbeTrace: the traced attempts.
+//   - error: if tracing is not configured or the service is unknown.
+func (s *Server) GetProbeTrace(_ context.Context, req *daemonpb.GetProbeTraceRequest) (*daemonpb.ProbeTrace, error) {
+	s.mu.Lock()
+	tracer := s.probeTracer
+	s.mu.Unlock()
+
+	// Check if probe tracing is configured.
+	if tracer == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "probe trace not configured")
+	}
+
+	attempts, err := tracer.ProbeTrace(req.ServiceName)
+	// Check if the lookup failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get probe trace: %w", err)
+	}
+
+	trace := &daemonpb.ProbeTrace{
+		ServiceName: req.ServiceName,
+		Attempts:    make([]*daemonpb.ProbeAttempt, 0, len(attempts)),
+	}
+	// Convert each attempt.
+	for i := range attempts {
+		trace.Attempts = append(trace.Attempts, convertProbeAttempt(&attempts[i]))
+	}
+	// Return converted trace.
+	return trace, nil
+}
+
+// convertProbeAttempt converts a probe attempt to protobuf format.
+//
+// Params:
+//   - attempt: the probe attempt.
+//
+// Returns:
+//   - *daemonpb.ProbeAttempt: protobuf probe attempt.
+func convertProbeAttempt(attempt *health.ProbeAttempt) *daemonpb.ProbeAttempt {
+	// Return converted attempt.
+	return &daemonpb.ProbeAttempt{
+		ListenerName: attempt.Listener,
+		Type:         attempt.Type,
+		Target:       attempt.Target,
+		Timestamp:    timestamppb.New(attempt.Timestamp),
+		Latency:      durationpb.New(attempt.Latency),
+		Success:      attempt.Success,
+		Output:       attempt.Output,
+		Error:        attempt.Error,
+	}
+}
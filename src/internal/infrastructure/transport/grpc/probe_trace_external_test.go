@@ -0,0 +1,100 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// errUnknownService is returned by mockProbeTracer for unknown services.
+var errUnknownService error = errors.New("unknown service")
+
+// mockProbeTracer returns fixed attempts for one service.
+type mockProbeTracer struct {
+	service  string
+	attempts []health.ProbeAttempt
+}
+
+func (m *mockProbeTracer) ProbeTrace(name string) ([]health.ProbeAttempt, error) {
+	if name != m.service {
+		return nil, errUnknownService
+	}
+	return m.attempts, nil
+}
+
+// TestServer_GetProbeTrace verifies probe attempt conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetProbeTrace(t *testing.T) {
+	t.Parallel()
+
+	at := time.Now()
+	tracer := &mockProbeTracer{service: "api", attempts: []health.ProbeAttempt{
+		{Listener: "http", Type: "http", Target: "127.0.0.1:8080/health", Timestamp: at, Latency: 3 * time.Millisecond, Success: true, Output: "200 OK"},
+		{Listener: "http", Type: "http", Target: "127.0.0.1:8080/health", Timestamp: at.Add(time.Second), Latency: time.Second, Error: "context deadline exceeded"},
+	}}
+
+	tests := []struct {
+		name         string
+		service      string
+		wantErr      bool
+		wantAttempts int
+	}{
+		{name: "traced service", service: "api", wantAttempts: 2},
+		{name: "unknown service", service: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetProbeTracer(tracer)
+
+			trace, err := server.GetProbeTrace(context.Background(), &daemonpb.GetProbeTraceRequest{ServiceName: tt.service})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.service, trace.ServiceName)
+			require.Len(t, trace.Attempts, tt.wantAttempts)
+			for i, attempt := range trace.Attempts {
+				want := tracer.attempts[i]
+				assert.Equal(t, want.Listener, attempt.ListenerName)
+				assert.Equal(t, want.Type, attempt.Type)
+				assert.Equal(t, want.Target, attempt.Target)
+				assert.True(t, attempt.Timestamp.AsTime().Equal(want.Timestamp))
+				assert.Equal(t, want.Latency, attempt.Latency.AsDuration())
+				assert.Equal(t, want.Success, attempt.Success)
+				assert.Equal(t, want.Output, attempt.Output)
+				assert.Equal(t, want.Error, attempt.Error)
+			}
+		})
+	}
+}
+
+// TestServer_GetProbeTrace_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetProbeTrace_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetProbeTrace(context.Background(), &daemonpb.GetProbeTraceRequest{ServiceName: "api"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
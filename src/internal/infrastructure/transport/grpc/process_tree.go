@@ -0,0 +1,97 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// ProcessTreeProvider provides the processes spawned by running services.
+type ProcessTreeProvider interface {
+	// ProcessTrees returns the process tree of a running service, or of every running service when name is empty.
+	ProcessTrees(ctx context.Context, name string) ([]process.ServiceTree, error)
+}
+
+// SetProcessTreeProvider sets the source of service process trees.
+// Without a provider, GetProcessTree returns Unimplemented.
+//
+// Params:
+//   - provider: the process tree provider.
+func (s *Server) SetProcessTreeProvider(provider ProcessTreeProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store process tree provider
+	s.processTrees = provider
+}
+
+// GetProcessTree implements DaemonService.GetProcessTree.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - req: request with the service name, empty for every service.
+//
+// Returns:
+//   - *daemonpb.ProcessTrees: the process trees.
+//   - error: if process trees are not configured or the service is unknown or not running.
+func (s *Server) GetProcessTree(ctx context.Context, req *daemonpb.GetProcessTreeRequest) (*daemonpb.ProcessTrees, error) {
+	s.mu.Lock()
+	provider := s.processTrees
+	s.mu.Unlock()
+
+	// Check if process trees are configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "process trees not configured")
+	}
+
+	trees, err := provider.ProcessTrees(ctx, req.ServiceName)
+	// Check if reading the trees failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get process tree: %w", err)
+	}
+
+	resp := &daemonpb.ProcessTrees{Services: make([]*daemonpb.ServiceProcessTree, 0, len(trees))}
+	// Convert each service tree.
+	for i := range trees {
+		resp.Services = append(resp.Services, &daemonpb.ServiceProcessTree{
+			ServiceName: trees[i].Service,
+			Root:        convertProcessNode(&trees[i].Root),
+		})
+	}
+	// Return converted trees.
+	return resp, nil
+}
+
+// convertProcessNode converts a process and its descendants to protobuf format.
+//
+// Params:
+//   - node: the process node.
+//
+// Returns:
+//   - *daemonpb.ProcessNode: protobuf process node.
+func convertProcessNode(node *process.ProcessNode) *daemonpb.ProcessNode {
+	pb := &daemonpb.ProcessNode{
+		Pid:        int32(node.PID),
+		Name:       node.Name,
+		Cmdline:    node.Cmdline,
+		State:      node.State,
+		RssBytes:   node.RSS,
+		CpuTime:    durationpb.New(node.CPUTime),
+		CpuPercent: node.CPUPercent,
+		Children:   make([]*daemonpb.ProcessNode, 0, len(node.Children)),
+	}
+	// Convert each child.
+	for i := range node.Children {
+		pb.Children = append(pb.Children, convertProcessNode(&node.Children[i]))
+	}
+	// Return converted node.
+	return pb
+}
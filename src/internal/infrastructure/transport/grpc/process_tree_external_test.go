@@ -0,0 +1,105 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockProcessTreeProvider returns a fixed tree for one service.
+type mockProcessTreeProvider struct {
+	service string
+	root    process.ProcessNode
+	err     error
+}
+
+func (m *mockProcessTreeProvider) ProcessTrees(_ context.Context, name string) ([]process.ServiceTree, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if name != "" && name != m.service {
+		return nil, errUnknownService
+	}
+	return []process.ServiceTree{{Service: m.service, Root: m.root}}, nil
+}
+
+// newMockProcessTreeProvider returns a provider with an nginx master and two workers.
+func newMockProcessTreeProvider() *mockProcessTreeProvider {
+	return &mockProcessTreeProvider{service: "web", root: process.ProcessNode{
+		PID: 10, Name: "nginx", Cmdline: "nginx -g daemon off;", State: "S",
+		RSS: 8 << 20, CPUTime: 3 * time.Second, CPUPercent: 1.5,
+		Children: []process.ProcessNode{
+			{PID: 11, Name: "nginx", State: "S", RSS: 4 << 20},
+			{PID: 12, Name: "nginx", State: "R", RSS: 2 << 20},
+		},
+	}}
+}
+
+// TestServer_GetProcessTree verifies process tree conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetProcessTree(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		wantErr bool
+	}{
+		{name: "every service"},
+		{name: "named service", service: "web"},
+		{name: "unknown service", service: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetProcessTreeProvider(newMockProcessTreeProvider())
+
+			resp, err := server.GetProcessTree(context.Background(), &daemonpb.GetProcessTreeRequest{ServiceName: tt.service})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Services, 1)
+			assert.Equal(t, "web", resp.Services[0].ServiceName)
+
+			root := resp.Services[0].Root
+			assert.Equal(t, int32(10), root.Pid)
+			assert.Equal(t, "nginx -g daemon off;", root.Cmdline)
+			assert.Equal(t, uint64(8<<20), root.RssBytes)
+			assert.Equal(t, 3*time.Second, root.CpuTime.AsDuration())
+			assert.InDelta(t, 1.5, root.CpuPercent, 0.001)
+			require.Len(t, root.Children, 2)
+			assert.Equal(t, int32(12), root.Children[1].Pid)
+			assert.Equal(t, "R", root.Children[1].State)
+		})
+	}
+}
+
+// TestServer_GetProcessTree_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetProcessTree_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetProcessTree(context.Background(), &daemonpb.GetProcessTreeRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,134 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+// ReloadController queues configuration reloads and reports their progress.
+type ReloadController interface {
+	// RequestReload queues a reload and returns the run serving it with a
+	// channel receiving its result.
+	RequestReload() (uint64, <-chan error)
+	// ReloadStatus returns the progress and last result of reloads.
+	ReloadStatus() lifecycle.ReloadStatus
+}
+
+// SetReloadController sets the target of reload requests.
+// Without a controller, RequestReload and GetReloadStatus return Unimplemented.
+//
+// Params:
+//   - controller: the reload controller.
+func (s *Server) SetReloadController(controller ReloadController) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store reload controller
+	s.reloader = controller
+}
+
+// RequestReload implements DaemonService.RequestReload.
+// The reload runs in the background; clients poll GetReloadStatus until
+// completed reaches the returned run.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.ReloadStatus: the status, with the run serving this request.
+//   - error: if reloading is not configured.
+func (s *Server) RequestReload(_ context.Context, _ *emptypb.Empty) (*daemonpb.ReloadStatus, error) {
+	controller, err := s.reloadController()
+	// Check if reloading is configured.
+	if err != nil {
+		// Report disabled feature.
+		return nil, err
+	}
+
+	// The result channel is buffered, so it can be dropped.
+	run, _ := controller.RequestReload()
+	reloadStatus := controller.ReloadStatus()
+	pb := convertReloadStatus(&reloadStatus)
+	pb.Run = run
+	// Return status with the run number.
+	return pb, nil
+}
+
+// GetReloadStatus implements DaemonService.GetReloadStatus.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.ReloadStatus: the reload status.
+//   - error: if reloading is not configured.
+func (s *Server) GetReloadStatus(_ context.Context, _ *emptypb.Empty) (*daemonpb.ReloadStatus, error) {
+	controller, err := s.reloadController()
+	// Check if reloading is configured.
+	if err != nil {
+		// Report disabled feature.
+		return nil, err
+	}
+
+	reloadStatus := controller.ReloadStatus()
+	// Return converted status.
+	return convertReloadStatus(&reloadStatus), nil
+}
+
+// reloadController returns the configured reload controller.
+//
+// Returns:
+//   - ReloadController: the controller.
+//   - error: Unimplemented if none is configured.
+func (s *Server) reloadController() (ReloadController, error) {
+	s.mu.Lock()
+	controller := s.reloader
+	s.mu.Unlock()
+
+	// Check if reloading is configured.
+	if controller == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "reload not configured")
+	}
+	// Return controller.
+	return controller, nil
+}
+
+// convertReloadStatus converts a reload status to protobuf format.
+//
+// Params:
+//   - rs: the reload status.
+//
+// Returns:
+//   - *daemonpb.ReloadStatus: protobuf reload status.
+func convertReloadStatus(rs *lifecycle.ReloadStatus) *daemonpb.ReloadStatus {
+	pb := &daemonpb.ReloadStatus{
+		Running:       rs.Running,
+		Pending:       rs.Pending,
+		Requested:     rs.Requested,
+		Coalesced:     rs.Coalesced,
+		Started:       rs.Started,
+		Completed:     rs.Completed,
+		LastError:     rs.LastError,
+		LastErrorCode: string(rs.LastErrorCode),
+	}
+	// Set times only once a run started or finished.
+	if !rs.LastStartedAt.IsZero() {
+		pb.LastStartedAt = timestamppb.New(rs.LastStartedAt)
+	}
+	// Finish time is unset while the first run is in progress.
+	if !rs.LastFinishedAt.IsZero() {
+		pb.LastFinishedAt = timestamppb.New(rs.LastFinishedAt)
+	}
+	// Return converted status.
+	return pb
+}
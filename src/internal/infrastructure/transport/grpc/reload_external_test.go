@@ -0,0 +1,122 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockReloadController queues reloads without running them.
+type mockReloadController struct {
+	status lifecycle.ReloadStatus
+}
+
+func (m *mockReloadController) RequestReload() (uint64, <-chan error) {
+	m.status.Requested++
+	m.status.Pending = true
+	return m.status.Started + 1, make(chan error, 1)
+}
+
+func (m *mockReloadController) ReloadStatus() lifecycle.ReloadStatus {
+	return m.status
+}
+
+// TestServer_RequestReload verifies the returned run and status.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_RequestReload(t *testing.T) {
+	t.Parallel()
+
+	controller := &mockReloadController{status: lifecycle.ReloadStatus{Running: true, Requested: 1, Started: 1}}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetReloadController(controller)
+
+	got, err := server.RequestReload(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), got.Run)
+	assert.Equal(t, uint64(2), got.Requested)
+	assert.True(t, got.Running)
+	assert.True(t, got.Pending)
+}
+
+// TestServer_GetReloadStatus verifies reload status conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetReloadStatus(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	tests := []struct {
+		name         string
+		status       lifecycle.ReloadStatus
+		wantStarted  bool
+		wantFinished bool
+	}{
+		{name: "never reloaded"},
+		{
+			name:        "first run in progress",
+			status:      lifecycle.ReloadStatus{Running: true, Requested: 1, Started: 1, LastStartedAt: started},
+			wantStarted: true,
+		},
+		{
+			name: "last run failed",
+			status: lifecycle.ReloadStatus{
+				Requested: 3, Coalesced: 1, Started: 2, Completed: 2,
+				LastStartedAt: started, LastFinishedAt: started.Add(time.Second),
+				LastError: "invalid config", LastErrorCode: shared.CodeConfigInvalid,
+			},
+			wantStarted:  true,
+			wantFinished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetReloadController(&mockReloadController{status: tt.status})
+
+			got, err := server.GetReloadStatus(context.Background(), &emptypb.Empty{})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.status.Running, got.Running)
+			assert.Equal(t, tt.status.Requested, got.Requested)
+			assert.Equal(t, tt.status.Coalesced, got.Coalesced)
+			assert.Equal(t, tt.status.Completed, got.Completed)
+			assert.Equal(t, tt.status.LastError, got.LastError)
+			assert.Equal(t, string(tt.status.LastErrorCode), got.LastErrorCode)
+			assert.Equal(t, tt.wantStarted, got.LastStartedAt != nil)
+			assert.Equal(t, tt.wantFinished, got.LastFinishedAt != nil)
+			assert.Zero(t, got.Run)
+		})
+	}
+}
+
+// TestServer_Reload_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_Reload_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.RequestReload(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = server.GetReloadStatus(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,95 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+)
+
+// ReloadPlanner computes what a configuration reload would do.
+type ReloadPlanner interface {
+	// PlanReload returns the plan of a reload, without applying it.
+	PlanReload(cfg *config.Config) (lifecycle.ReloadPlan, error)
+}
+
+// ConfigParser parses and validates a configuration.
+type ConfigParser interface {
+	// Parse decodes and validates configuration YAML.
+	Parse(data []byte) (*config.Config, error)
+}
+
+// reloadActions maps domain reload actions to protobuf values.
+var reloadActions map[lifecycle.ReloadAction]daemonpb.ReloadAction = map[lifecycle.ReloadAction]daemonpb.ReloadAction{
+	lifecycle.ReloadAdd:     daemonpb.ReloadAction_RELOAD_ACTION_ADD,
+	lifecycle.ReloadRemove:  daemonpb.ReloadAction_RELOAD_ACTION_REMOVE,
+	lifecycle.ReloadRestart: daemonpb.ReloadAction_RELOAD_ACTION_RESTART,
+}
+
+// SetReloadPlanner sets the planner of reloads and the parser of the
+// planned configurations. Without them, PlanReload returns Unimplemented.
+//
+// Params:
+//   - planner: the reload planner.
+//   - parser: the configuration parser.
+func (s *Server) SetReloadPlanner(planner ReloadPlanner, parser ConfigParser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store reload planner and parser
+	s.planner = planner
+	s.parser = parser
+}
+
+// PlanReload implements DaemonService.PlanReload.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the configuration YAML.
+//
+// Returns:
+//   - *daemonpb.ReloadPlan: what the reload would do.
+//   - error: if planning is not configured, the configuration is invalid or
+//     the reload would be refused.
+func (s *Server) PlanReload(_ context.Context, req *daemonpb.PlanReloadRequest) (*daemonpb.ReloadPlan, error) {
+	s.mu.Lock()
+	planner, parser := s.planner, s.parser
+	s.mu.Unlock()
+
+	// Check if planning is configured.
+	if planner == nil || parser == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "reload planning not configured")
+	}
+
+	cfg, err := parser.Parse(req.Config)
+	// Check if the configuration is invalid.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	plan, err := planner.PlanReload(cfg)
+	// Check if the reload would be refused.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("plan reload: %w", err)
+	}
+
+	resp := &daemonpb.ReloadPlan{Services: make([]*daemonpb.ServicePlan, 0, len(plan.Services))}
+	// Convert each service plan.
+	for i := range plan.Services {
+		svc := &plan.Services[i]
+		resp.Services = append(resp.Services, &daemonpb.ServicePlan{
+			ServiceName: svc.Name,
+			Action:      reloadActions[svc.Action],
+			Changes:     svc.Changes,
+		})
+	}
+	// Return converted plan.
+	return resp, nil
+}
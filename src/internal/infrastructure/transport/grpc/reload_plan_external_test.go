@@ -0,0 +1,86 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// errInvalidConfig is returned by mockConfigParser for unparsable input.
+var errInvalidConfig error = errors.New("invalid config")
+
+// mockConfigParser parses any input but "invalid" into an empty configuration.
+type mockConfigParser struct{}
+
+func (m *mockConfigParser) Parse(data []byte) (*config.Config, error) {
+	if string(data) == "invalid" {
+		return nil, errInvalidConfig
+	}
+	return &config.Config{}, nil
+}
+
+// mockReloadPlanner returns a fixed plan.
+type mockReloadPlanner struct {
+	plan lifecycle.ReloadPlan
+	err  error
+}
+
+func (m *mockReloadPlanner) PlanReload(_ *config.Config) (lifecycle.ReloadPlan, error) {
+	return m.plan, m.err
+}
+
+// TestServer_PlanReload verifies plan requests reach the planner.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_PlanReload(t *testing.T) {
+	t.Parallel()
+
+	planner := &mockReloadPlanner{plan: lifecycle.ReloadPlan{Services: []lifecycle.ServicePlan{
+		{Name: "web", Action: lifecycle.ReloadRestart, Changes: []string{"command"}},
+		{Name: "api", Action: lifecycle.ReloadAdd},
+		{Name: "old", Action: lifecycle.ReloadRemove},
+	}}}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetReloadPlanner(planner, &mockConfigParser{})
+
+	resp, err := server.PlanReload(context.Background(), &daemonpb.PlanReloadRequest{Config: []byte("services: []")})
+	require.NoError(t, err)
+	require.Len(t, resp.Services, 3)
+	assert.Equal(t, "web", resp.Services[0].ServiceName)
+	assert.Equal(t, daemonpb.ReloadAction_RELOAD_ACTION_RESTART, resp.Services[0].Action)
+	assert.Equal(t, []string{"command"}, resp.Services[0].Changes)
+	assert.Equal(t, daemonpb.ReloadAction_RELOAD_ACTION_ADD, resp.Services[1].Action)
+	assert.Equal(t, daemonpb.ReloadAction_RELOAD_ACTION_REMOVE, resp.Services[2].Action)
+
+	_, err = server.PlanReload(context.Background(), &daemonpb.PlanReloadRequest{Config: []byte("invalid")})
+	assert.ErrorIs(t, err, errInvalidConfig)
+
+	planner.err = errUnknownService
+	_, err = server.PlanReload(context.Background(), &daemonpb.PlanReloadRequest{Config: []byte("services: []")})
+	assert.ErrorIs(t, err, errUnknownService)
+}
+
+// TestServer_PlanReload_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_PlanReload_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.PlanReload(context.Background(), &daemonpb.PlanReloadRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
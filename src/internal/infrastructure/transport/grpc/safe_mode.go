@@ -0,0 +1,109 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// SafeModeController reports and changes the safe mode of the daemon.
+type SafeModeController interface {
+	// SafeMode returns the safe mode and the services whose restart it holds.
+	SafeMode() process.SafeMode
+	// SetSafeMode enters or exits safe mode.
+	SetSafeMode(on bool) process.SafeMode
+}
+
+// SetSafeModeController sets the target of safe mode requests.
+// Without a controller, GetSafeMode and SetSafeMode return Unimplemented.
+//
+// Params:
+//   - controller: the safe mode controller.
+func (s *Server) SetSafeModeController(controller SafeModeController) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store safe mode controller
+	s.safeMode = controller
+}
+
+// GetSafeMode implements DaemonService.GetSafeMode.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: empty request.
+//
+// Returns:
+//   - *daemonpb.SafeModeState: the safe mode of the daemon.
+//   - error: if safe mode is not configured.
+func (s *Server) GetSafeMode(_ context.Context, _ *emptypb.Empty) (*daemonpb.SafeModeState, error) {
+	s.mu.Lock()
+	controller := s.safeMode
+	s.mu.Unlock()
+
+	// Check if safe mode is configured.
+	if controller == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "safe mode not configured")
+	}
+	mode := controller.SafeMode()
+	// Return converted safe mode.
+	return convertSafeMode(&mode), nil
+}
+
+// SetSafeMode implements DaemonService.SetSafeMode.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request entering or exiting safe mode.
+//
+// Returns:
+//   - *daemonpb.SafeModeState: the safe mode after the request.
+//   - error: if safe mode is not configured.
+func (s *Server) SetSafeMode(_ context.Context, req *daemonpb.SetSafeModeRequest) (*daemonpb.SafeModeState, error) {
+	s.mu.Lock()
+	controller := s.safeMode
+	s.mu.Unlock()
+
+	// Check if safe mode is configured.
+	if controller == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "safe mode not configured")
+	}
+	mode := controller.SetSafeMode(req.Enabled)
+	// Return converted safe mode.
+	return convertSafeMode(&mode), nil
+}
+
+// convertSafeMode converts the safe mode to protobuf format.
+//
+// Params:
+//   - mode: the safe mode.
+//
+// Returns:
+//   - *daemonpb.SafeModeState: protobuf safe mode.
+func convertSafeMode(mode *process.SafeMode) *daemonpb.SafeModeState {
+	resp := &daemonpb.SafeModeState{
+		Active:   mode.Active,
+		Manual:   mode.Manual,
+		Restarts: int32(mode.Restarts),
+		Held:     mode.Held,
+	}
+	// Leave since unset outside safe mode.
+	if !mode.Since.IsZero() {
+		resp.Since = timestamppb.New(mode.Since)
+	}
+	// Only a storm sets the window.
+	if mode.Window > 0 {
+		resp.Window = durationpb.New(mode.Window)
+	}
+	// Return converted safe mode.
+	return resp
+}
@@ -0,0 +1,105 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockSafeModeController holds a safe mode entered by a storm.
+type mockSafeModeController struct {
+	mode     process.SafeMode
+	requests []bool
+}
+
+func (m *mockSafeModeController) SafeMode() process.SafeMode {
+	return m.mode
+}
+
+func (m *mockSafeModeController) SetSafeMode(on bool) process.SafeMode {
+	m.requests = append(m.requests, on)
+	// Exiting clears the mode.
+	if !on {
+		m.mode = process.SafeMode{}
+	}
+	return m.mode
+}
+
+// stormSafeMode returns a safe mode entered by a restart storm.
+//
+// Returns:
+//   - process.SafeMode: the safe mode.
+func stormSafeMode() process.SafeMode {
+	return process.SafeMode{
+		Active:   true,
+		Since:    time.Unix(1700000000, 0).UTC(),
+		Restarts: 21,
+		Window:   time.Minute,
+		Held:     []string{"api", "web"},
+	}
+}
+
+// TestServer_GetSafeMode verifies the safe mode is reported.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetSafeMode(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetSafeModeController(&mockSafeModeController{mode: stormSafeMode()})
+
+	resp, err := server.GetSafeMode(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.True(t, resp.Active)
+	assert.False(t, resp.Manual)
+	assert.Equal(t, int64(1700000000), resp.Since.AsTime().Unix())
+	assert.Equal(t, int32(21), resp.Restarts)
+	assert.Equal(t, time.Minute, resp.Window.AsDuration())
+	assert.Equal(t, []string{"api", "web"}, resp.Held)
+}
+
+// TestServer_SetSafeMode verifies safe mode requests reach the controller.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SetSafeMode(t *testing.T) {
+	t.Parallel()
+
+	controller := &mockSafeModeController{mode: stormSafeMode()}
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetSafeModeController(controller)
+
+	resp, err := server.SetSafeMode(context.Background(), &daemonpb.SetSafeModeRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false}, controller.requests)
+	assert.False(t, resp.Active)
+	assert.Nil(t, resp.Since)
+	assert.Nil(t, resp.Window)
+}
+
+// TestServer_SafeMode_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SafeMode_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetSafeMode(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = server.SetSafeMode(context.Background(), &daemonpb.SetSafeModeRequest{Enabled: true})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -19,8 +19,10 @@ import (
 
 	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
 	"github.com/kodflow/daemon/internal/domain/lifecycle"
+	"github.com/kodflow/daemon/internal/domain/logging"
 	"github.com/kodflow/daemon/internal/domain/metrics"
 	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/storage"
 )
 
 // DefaultStreamInterval is the default interval for streaming updates.
@@ -132,6 +134,35 @@ type Server struct {
 	healthServer    *health.Server
 	metricsProvider MetricsProvider
 	stateProvider   GetStator
+	logStreamer     logging.OutputStreamer
+	logHistory      logging.OutputHistory
+	eventHistory    storage.EventReader
+	specProvider    SpecProvider
+	bootReporter    BootReporter
+	reloader        ReloadController
+	probeTracer     ProbeTracer
+	dependencies    DependencyProvider
+	signaler        ServiceSignaler
+	serviceReloader ServiceReloader
+	batcher         ServiceBatcher
+	snapshots       SnapshotProvider
+	statsProvider   StatsProvider
+	labels          LabelProvider
+	jobHistory      JobHistoryProvider
+	verifier        ServiceVerifier
+	daemonInfo      DaemonInfoProvider
+	debugSpawner    DebugSpawner
+	certificates    CertificateProvider
+	planner         ReloadPlanner
+	parser          ConfigParser
+	parameters      ParametersController
+	control         ControlPolicy
+	processTrees    ProcessTreeProvider
+	probePauser     ProbePauser
+	hostInventory   HostInventoryProvider
+	dependencyMap   DependencyMapProvider
+	safeMode        SafeModeController
+	buildInfo       *metrics.BuildInfo
 	listener        net.Listener
 	mu              sync.Mutex
 	running         bool
@@ -146,15 +177,17 @@ type Server struct {
 // Returns:
 //   - *Server: configured gRPC server.
 func NewServer(metricsProvider MetricsProvider, stateProvider GetStator) *Server {
-	grpcServer := grpc.NewServer()
 	healthServer := health.NewServer()
-
 	s := &Server{
-		grpcServer:      grpcServer,
 		healthServer:    healthServer,
 		metricsProvider: metricsProvider,
 		stateProvider:   stateProvider,
 	}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryErrorInterceptor, s.readOnlyInterceptor),
+		grpc.ChainStreamInterceptor(streamErrorInterceptor),
+	)
+	s.grpcServer = grpcServer
 
 	// Register services.
 	daemonpb.RegisterDaemonServiceServer(grpcServer, s)
@@ -307,12 +340,12 @@ func (s *Server) StreamState(req *daemonpb.StreamStateRequest, stream daemonpb.D
 //
 // Params:
 //   - ctx: request context for cancellation.
-//   - req: empty request (required by gRPC interface).
+//   - req: request with the filters, sort key, page and fields.
 //
 // Returns:
-//   - *daemonpb.ListProcessesResponse: list of all processes.
-//   - error: context error if cancelled.
-func (s *Server) ListProcesses(ctx context.Context, req *emptypb.Empty) (*daemonpb.ListProcessesResponse, error) {
+//   - *daemonpb.ListProcessesResponse: the page of matching processes.
+//   - error: context error if cancelled, or invalid argument error.
+func (s *Server) ListProcesses(ctx context.Context, req *daemonpb.ListProcessesRequest) (*daemonpb.ListProcessesResponse, error) {
 	// Check request is valid (interface requirement).
 	if req == nil {
 		// Handle nil request gracefully.
@@ -323,19 +356,29 @@ func (s *Server) ListProcesses(ctx context.Context, req *emptypb.Empty) (*daemon
 		// Return context error.
 		return nil, ctx.Err()
 	}
-	allMetrics := s.metricsProvider.GetAllProcessMetrics()
+	query, err := parseProcessQuery(req)
+	// Check if the query is valid.
+	if err != nil {
+		// Return invalid argument error.
+		return nil, err
+	}
+	matching := s.filterProcesses(s.metricsProvider.GetAllProcessMetrics(), &query)
+	sortProcesses(matching, &query)
+	page, next := query.page(matching)
 	// Pre-allocate with capacity to avoid reallocation.
-	processes := make([]*daemonpb.ProcessMetrics, 0, len(allMetrics))
+	processes := make([]*daemonpb.ProcessMetrics, 0, len(page))
 
-	// Convert all process metrics.
-	for i := range allMetrics {
-		// Append converted metrics.
-		processes = append(processes, s.convertProcessMetrics(&allMetrics[i]))
+	// Convert the process metrics of the page.
+	for i := range page {
+		// Append converted metrics, keeping the selected fields.
+		processes = append(processes, query.prune(s.convertProcessMetrics(&page[i])))
 	}
 
-	// Return process list.
+	// Return process page.
 	return &daemonpb.ListProcessesResponse{
-		Processes: processes,
+		Processes:     processes,
+		NextPageToken: next,
+		TotalSize:     safeInt32(len(matching)),
 	}, nil
 }
 
@@ -522,6 +565,7 @@ func (s *Server) convertDaemonState(ds *lifecycle.DaemonState) *daemonpb.DaemonS
 		System:     s.convertSystemMetrics(ds),
 		Host:       s.convertHostInfo(&ds.Host),
 		Kubernetes: s.convertKubernetesInfo(ds.Kubernetes),
+		Sequence:   ds.Sequence,
 	}
 }
 
@@ -535,17 +579,22 @@ func (s *Server) convertDaemonState(ds *lifecycle.DaemonState) *daemonpb.DaemonS
 func (s *Server) convertProcessMetrics(m *metrics.ProcessMetrics) *daemonpb.ProcessMetrics {
 	// Return protobuf metrics.
 	return &daemonpb.ProcessMetrics{
-		ServiceName:  m.ServiceName,
-		Pid:          safeInt32(m.PID),
-		State:        s.convertProcessState(m.State),
-		Healthy:      m.Healthy,
-		Cpu:          s.convertProcessCPU(&m.CPU),
-		Memory:       s.convertProcessMemory(&m.Memory),
-		StartTime:    timestamppb.New(m.StartTime),
-		Uptime:       durationpb.New(m.Uptime),
-		RestartCount: safeInt32(m.RestartCount),
-		LastError:    m.LastError,
-		Timestamp:    timestamppb.New(m.Timestamp),
+		ServiceName:      m.ServiceName,
+		Pid:              safeInt32(m.PID),
+		State:            s.convertProcessState(m.State),
+		Healthy:          m.Healthy,
+		Cpu:              s.convertProcessCPU(&m.CPU),
+		Memory:           s.convertProcessMemory(&m.Memory),
+		StartTime:        timestamppb.New(m.StartTime),
+		Uptime:           durationpb.New(m.Uptime),
+		RestartCount:     safeInt32(m.RestartCount),
+		LastError:        m.LastError,
+		Timestamp:        timestamppb.New(m.Timestamp),
+		ThrottledPercent: m.ThrottledPercent,
+		Pressure:         s.convertResourcePressure(&m.Pressure),
+		Availability:     s.serviceAvailability(m.ServiceName),
+		Labels:           s.serviceLabels(m.ServiceName),
+		NumFds:           m.NumFDs,
 	}
 }
 
@@ -579,6 +628,10 @@ func (s *Server) convertProcessState(ps process.State) daemonpb.ProcessState {
 	case process.StateFailed:
 		// Return failed state.
 		return daemonpb.ProcessState_PROCESS_STATE_FAILED
+	// Process skipped for unmet start conditions.
+	case process.StateSkipped:
+		// Return skipped state.
+		return daemonpb.ProcessState_PROCESS_STATE_SKIPPED
 	// Unknown state.
 	default:
 		// Return unspecified state.
@@ -586,7 +639,8 @@ func (s *Server) convertProcessState(ps process.State) daemonpb.ProcessState {
 	}
 }
 
-// convertProcessCPU converts domain CPU metrics to protobuf.
+// convertProcessCPU converts domain CPU metrics to protobuf, the jiffies
+// converted to nanoseconds at the clock tick rate of the sample.
 //
 // Params:
 //   - cpu: domain process CPU metrics.
@@ -596,9 +650,12 @@ func (s *Server) convertProcessState(ps process.State) daemonpb.ProcessState {
 func (s *Server) convertProcessCPU(cpu *metrics.ProcessCPU) *daemonpb.ProcessCPU {
 	// Return protobuf CPU metrics.
 	return &daemonpb.ProcessCPU{
-		UserTimeNs:   cpu.User,
-		SystemTimeNs: cpu.System,
-		TotalTimeNs:  cpu.User + cpu.System,
+		UserTimeNs:        uint64(cpu.TicksToDuration(cpu.User)),
+		SystemTimeNs:      uint64(cpu.TicksToDuration(cpu.System)),
+		TotalTimeNs:       uint64(cpu.TicksToDuration(cpu.Total())),
+		UsagePercent:      cpu.UsagePercent,
+		PerCorePercent:    cpu.PerCorePercent,
+		NormalizedPercent: cpu.NormalizedPercent,
 	}
 }
 
@@ -612,12 +669,17 @@ func (s *Server) convertProcessCPU(cpu *metrics.ProcessCPU) *daemonpb.ProcessCPU
 func (s *Server) convertProcessMemory(mem *metrics.ProcessMemory) *daemonpb.ProcessMemory {
 	// Return protobuf memory metrics.
 	return &daemonpb.ProcessMemory{
-		RssBytes:    mem.RSS,
-		VmsBytes:    mem.VMS,
-		SwapBytes:   mem.Swap,
-		SharedBytes: mem.Shared,
-		DataBytes:   mem.Data,
-		StackBytes:  mem.Stack,
+		RssBytes:       mem.RSS,
+		VmsBytes:       mem.VMS,
+		SwapBytes:      mem.Swap,
+		SharedBytes:    mem.Shared,
+		DataBytes:      mem.Data,
+		StackBytes:     mem.Stack,
+		PssBytes:       mem.PSS,
+		UssBytes:       mem.USS,
+		SwapPssBytes:   mem.SwapPSS,
+		MajorFaults:    mem.MajorFaults,
+		MajorFaultRate: mem.MajorFaultRate,
 	}
 }
 
@@ -654,6 +716,48 @@ func (s *Server) convertSystemMetrics(ds *lifecycle.DaemonState) *daemonpb.Syste
 			SwapFreeBytes:  ds.System.Memory.SwapFree,
 		},
 		Timestamp: timestamppb.New(ds.Timestamp),
+		Pressure:  s.convertResourcePressure(&ds.System.Pressure),
+	}
+}
+
+// convertResourcePressure converts domain PSI to protobuf.
+//
+// Params:
+//   - rp: domain resource pressure.
+//
+// Returns:
+//   - *daemonpb.ResourcePressure: protobuf resource pressure, nil if not collected.
+func (s *Server) convertResourcePressure(rp *metrics.ResourcePressure) *daemonpb.ResourcePressure {
+	// Return nil when PSI was not collected.
+	if !rp.IsCollected() {
+		// No pressure data.
+		return nil
+	}
+
+	// Return protobuf resource pressure.
+	return &daemonpb.ResourcePressure{
+		Cpu:    s.convertPressure(&rp.CPU),
+		Memory: s.convertPressure(&rp.Memory),
+		Io:     s.convertPressure(&rp.IO),
+	}
+}
+
+// convertPressure converts domain pressure of a single resource to protobuf.
+//
+// Params:
+//   - p: domain pressure.
+//
+// Returns:
+//   - *daemonpb.Pressure: protobuf pressure.
+func (s *Server) convertPressure(p *metrics.Pressure) *daemonpb.Pressure {
+	// Return protobuf pressure.
+	return &daemonpb.Pressure{
+		SomeAvg10:  p.SomeAvg10,
+		SomeAvg60:  p.SomeAvg60,
+		SomeAvg300: p.SomeAvg300,
+		FullAvg10:  p.FullAvg10,
+		FullAvg60:  p.FullAvg60,
+		FullAvg300: p.FullAvg300,
 	}
 }
 
@@ -355,7 +355,7 @@ func TestServer_ListProcesses(t *testing.T) {
 			metricsProvider := &mockMetricsProvider{allProcessMetrics: tt.allProcessMetrics}
 			server := grpc.NewServer(metricsProvider, &mockGetStator{})
 
-			resp, err := server.ListProcesses(context.Background(), &emptypb.Empty{})
+			resp, err := server.ListProcesses(context.Background(), &daemonpb.ListProcessesRequest{})
 			require.NoError(t, err)
 			require.NotNil(t, resp)
 			assert.Len(t, resp.Processes, tt.expectedCount)
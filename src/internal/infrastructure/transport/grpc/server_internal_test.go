@@ -357,6 +357,7 @@ func Test_Server_convertProcessMetrics(t *testing.T) {
 		expectedPID     int32
 		expectedHealthy bool
 		expectedRestart int32
+		expectedFDs     uint32
 	}{
 		{
 			name: "healthy running process",
@@ -371,12 +372,14 @@ func Test_Server_convertProcessMetrics(t *testing.T) {
 				Uptime:       time.Hour,
 				RestartCount: 3,
 				LastError:    "none",
+				NumFDs:       42,
 				Timestamp:    timestamp,
 			},
 			expectedName:    "test-service",
 			expectedPID:     1234,
 			expectedHealthy: true,
 			expectedRestart: 3,
+			expectedFDs:     42,
 		},
 		{
 			name: "unhealthy failed process",
@@ -406,6 +409,7 @@ func Test_Server_convertProcessMetrics(t *testing.T) {
 			assert.Equal(t, tt.expectedPID, result.Pid)
 			assert.Equal(t, tt.expectedHealthy, result.Healthy)
 			assert.Equal(t, tt.expectedRestart, result.RestartCount)
+			assert.Equal(t, tt.expectedFDs, result.NumFds)
 		})
 	}
 }
@@ -427,13 +431,22 @@ func Test_Server_convertProcessCPU(t *testing.T) {
 		expectedUser   uint64
 		expectedSystem uint64
 		expectedTotal  uint64
+		expectedUsage  float64
 	}{
 		{
 			name:           "normal values",
-			cpu:            &metrics.ProcessCPU{User: 1000, System: 2000},
-			expectedUser:   1000,
-			expectedSystem: 2000,
-			expectedTotal:  3000,
+			cpu:            &metrics.ProcessCPU{User: 1000, System: 2000, UsagePercent: 12.5, PerCorePercent: 12.5, NormalizedPercent: 3.125},
+			expectedUser:   uint64(10 * time.Second),
+			expectedSystem: uint64(20 * time.Second),
+			expectedTotal:  uint64(30 * time.Second),
+			expectedUsage:  12.5,
+		},
+		{
+			name:           "detected clock rate",
+			cpu:            &metrics.ProcessCPU{User: 1000, System: 2000, ClockTicks: 1000},
+			expectedUser:   uint64(time.Second),
+			expectedSystem: uint64(2 * time.Second),
+			expectedTotal:  uint64(3 * time.Second),
 		},
 		{
 			name:           "zero values",
@@ -453,6 +466,9 @@ func Test_Server_convertProcessCPU(t *testing.T) {
 			assert.Equal(t, tt.expectedUser, result.UserTimeNs)
 			assert.Equal(t, tt.expectedSystem, result.SystemTimeNs)
 			assert.Equal(t, tt.expectedTotal, result.TotalTimeNs)
+			assert.Equal(t, tt.expectedUsage, result.UsagePercent)
+			assert.Equal(t, tt.cpu.PerCorePercent, result.PerCorePercent)
+			assert.Equal(t, tt.cpu.NormalizedPercent, result.NormalizedPercent)
 		})
 	}
 }
@@ -473,6 +489,8 @@ func Test_Server_convertProcessMemory(t *testing.T) {
 		mem         *metrics.ProcessMemory
 		expectedRSS uint64
 		expectedVMS uint64
+		expectedPSS uint64
+		expectedUSS uint64
 	}{
 		{
 			name: "all fields populated",
@@ -487,6 +505,20 @@ func Test_Server_convertProcessMemory(t *testing.T) {
 			expectedRSS: 1024,
 			expectedVMS: 2048,
 		},
+		{
+			name: "smaps attribution",
+			mem: &metrics.ProcessMemory{
+				RSS:            1024,
+				PSS:            768,
+				USS:            512,
+				SwapPSS:        32,
+				MajorFaults:    9,
+				MajorFaultRate: 1.5,
+			},
+			expectedRSS: 1024,
+			expectedPSS: 768,
+			expectedUSS: 512,
+		},
 		{
 			name:        "zero values",
 			mem:         &metrics.ProcessMemory{},
@@ -503,6 +535,11 @@ func Test_Server_convertProcessMemory(t *testing.T) {
 
 			assert.Equal(t, tt.expectedRSS, result.RssBytes)
 			assert.Equal(t, tt.expectedVMS, result.VmsBytes)
+			assert.Equal(t, tt.expectedPSS, result.PssBytes)
+			assert.Equal(t, tt.expectedUSS, result.UssBytes)
+			assert.Equal(t, tt.mem.SwapPSS, result.SwapPssBytes)
+			assert.Equal(t, tt.mem.MajorFaults, result.MajorFaults)
+			assert.InDelta(t, tt.mem.MajorFaultRate, result.MajorFaultRate, 0.001)
 		})
 	}
 }
@@ -573,6 +610,55 @@ func Test_Server_convertSystemMetrics(t *testing.T) {
 	}
 }
 
+// Test_Server_convertResourcePressure verifies that convertResourcePressure converts PSI.
+//
+// Params:
+//   - t: testing context for assertions
+func Test_Server_convertResourcePressure(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	tests := []struct {
+		name     string
+		pressure *metrics.ResourcePressure
+		wantNil  bool
+		wantIO   float64
+	}{
+		{
+			name: "collected pressure",
+			pressure: &metrics.ResourcePressure{
+				Timestamp: time.Now(),
+				IO:        metrics.Pressure{SomeAvg10: 12.5, FullAvg300: 3},
+			},
+			wantIO: 12.5,
+		},
+		{
+			name:     "not collected",
+			pressure: &metrics.ResourcePressure{},
+			wantNil:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := server.convertResourcePressure(tt.pressure)
+
+			// Verify nil for missing PSI.
+			if tt.wantNil {
+				assert.Nil(t, result)
+				return
+			}
+			assert.InDelta(t, tt.wantIO, result.Io.SomeAvg10, 0.001)
+			assert.InDelta(t, tt.pressure.IO.FullAvg300, result.Io.FullAvg300, 0.001)
+			assert.NotNil(t, result.Cpu)
+			assert.NotNil(t, result.Memory)
+		})
+	}
+}
+
 // Test_Server_convertHostInfo verifies that convertHostInfo correctly converts host information.
 //
 // Params:
@@ -819,7 +905,7 @@ func Test_Server_RequestHandling(t *testing.T) {
 				if tt.testType == "nil" {
 					result, err = server.ListProcesses(ctx, nil)
 				} else {
-					result, err = server.ListProcesses(ctx, &emptypb.Empty{})
+					result, err = server.ListProcesses(ctx, &daemonpb.ListProcessesRequest{})
 				}
 			case "GetSystemMetrics":
 				if tt.testType == "nil" {
@@ -0,0 +1,62 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+)
+
+// ServiceReloader reloads supervised services in place.
+type ServiceReloader interface {
+	// ReloadService makes a service reload its own configuration.
+	ReloadService(name string) error
+}
+
+// SetServiceReloader sets the target of in-place reload requests.
+// Without a reloader, ReloadService returns Unimplemented.
+//
+// Params:
+//   - reloader: the service reloader.
+func (s *Server) SetServiceReloader(reloader ServiceReloader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store service reloader
+	s.serviceReloader = reloader
+}
+
+// ReloadService implements DaemonService.ReloadService.
+// It returns once the service reloaded and its probes pass again.
+//
+// Params:
+//   - ctx: bounds the wait for the resulting state.
+//   - req: request with the service name.
+//
+// Returns:
+//   - *daemonpb.ServiceSnapshot: the state of the service once the reload
+//     is verified.
+//   - error: if reloading is not configured, the service is unknown or not
+//     running, the reload failed, or the state was not settled in time.
+func (s *Server) ReloadService(ctx context.Context, req *daemonpb.ReloadServiceRequest) (*daemonpb.ServiceSnapshot, error) {
+	s.mu.Lock()
+	reloader := s.serviceReloader
+	s.mu.Unlock()
+
+	// Check if in-place reloads are configured.
+	if reloader == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "service reload not configured")
+	}
+
+	// Check if the reload succeeded.
+	if err := reloader.ReloadService(req.ServiceName); err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("reload service: %w", err)
+	}
+	// Return the resulting state.
+	return s.serviceSnapshot(ctx, req.ServiceName)
+}
@@ -0,0 +1,78 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockServiceReloader records the reloads of one service.
+type mockServiceReloader struct {
+	service string
+	reloads int
+}
+
+func (m *mockServiceReloader) ReloadService(name string) error {
+	if name != m.service {
+		return errUnknownService
+	}
+	m.reloads++
+	return nil
+}
+
+// TestServer_ReloadService verifies reload requests reach the reloader.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ReloadService(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		wantErr bool
+	}{
+		{name: "known service", service: "nginx"},
+		{name: "unknown service", service: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reloader := &mockServiceReloader{service: "nginx"}
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetServiceReloader(reloader)
+
+			_, err := server.ReloadService(context.Background(), &daemonpb.ReloadServiceRequest{ServiceName: tt.service})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				assert.Zero(t, reloader.reloads)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, reloader.reloads)
+		})
+	}
+}
+
+// TestServer_ReloadService_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ReloadService_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.ReloadService(context.Background(), &daemonpb.ReloadServiceRequest{ServiceName: "nginx"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,180 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// StatsProvider provides the lifetime statistics of services.
+type StatsProvider interface {
+	// ServiceStats returns the lifetime statistics of a service.
+	ServiceStats(name string) (metrics.ServiceStats, error)
+}
+
+// SetStatsProvider sets the source of service statistics.
+// Without a provider, GetServiceStats returns Unimplemented and process
+// metrics carry no availability.
+//
+// Params:
+//   - provider: the statistics provider.
+func (s *Server) SetStatsProvider(provider StatsProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store statistics provider
+	s.statsProvider = provider
+}
+
+// GetServiceStats implements DaemonService.GetServiceStats.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service name.
+//
+// Returns:
+//   - *daemonpb.ServiceStats: the lifetime statistics.
+//   - error: if statistics are not configured or the service is unknown.
+func (s *Server) GetServiceStats(_ context.Context, req *daemonpb.GetServiceStatsRequest) (*daemonpb.ServiceStats, error) {
+	provider := s.getStatsProvider()
+
+	// Check if statistics are configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "service stats not configured")
+	}
+
+	stats, err := provider.ServiceStats(req.ServiceName)
+	// Check if the lookup failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get service stats: %w", err)
+	}
+
+	// Return converted statistics.
+	return &daemonpb.ServiceStats{
+		ServiceName:  stats.ServiceName,
+		StartCount:   int64(stats.StartCount),
+		StopCount:    int64(stats.StopCount),
+		FailCount:    int64(stats.FailCount),
+		RestartCount: int64(stats.RestartCount),
+		Uptime:       durationpb.New(stats.Uptime),
+		Downtime:     durationpb.New(stats.Downtime),
+		Availability: convertAvailability(&stats.Availability),
+		Slo:          convertSLOStatus(stats.SLO),
+		TopExits:     convertExitCounts(stats.TopExits),
+	}, nil
+}
+
+// serviceAvailability returns the availability of a service for process metrics.
+//
+// Params:
+//   - name: the service name.
+//
+// Returns:
+//   - *daemonpb.ServiceAvailability: the availability, nil without statistics.
+func (s *Server) serviceAvailability(name string) *daemonpb.ServiceAvailability {
+	provider := s.getStatsProvider()
+	// Check if statistics are configured.
+	if provider == nil {
+		// No availability to report.
+		return nil
+	}
+	stats, err := provider.ServiceStats(name)
+	// Check if the service has statistics.
+	if err != nil {
+		// No availability to report.
+		return nil
+	}
+	// Return converted availability.
+	return convertAvailability(&stats.Availability)
+}
+
+// getStatsProvider returns the configured statistics provider.
+//
+// Returns:
+//   - StatsProvider: the provider, or nil when not configured.
+func (s *Server) getStatsProvider() StatsProvider {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// return statistics provider
+	return s.statsProvider
+}
+
+// convertAvailability converts a service availability to protobuf format.
+//
+// Params:
+//   - a: the availability.
+//
+// Returns:
+//   - *daemonpb.ServiceAvailability: protobuf availability.
+func convertAvailability(a *metrics.Availability) *daemonpb.ServiceAvailability {
+	// Return converted availability.
+	return &daemonpb.ServiceAvailability{
+		Day:   a.Day,
+		Week:  a.Week,
+		Month: a.Month,
+	}
+}
+
+// convertExitCounts converts the failure modes of a service to protobuf format.
+//
+// Params:
+//   - counts: the failures of each exit cause.
+//
+// Returns:
+//   - []*daemonpb.ExitCount: protobuf exit counts.
+func convertExitCounts(counts []metrics.ExitCount) []*daemonpb.ExitCount {
+	result := make([]*daemonpb.ExitCount, 0, len(counts))
+	// Convert each cause.
+	for _, c := range counts {
+		result = append(result, &daemonpb.ExitCount{
+			ExitCode: int32(c.Cause.Code),
+			Signal:   c.Cause.Signal,
+			Count:    int64(c.Count),
+			Last:     timestamppb.New(c.Last),
+		})
+	}
+	// Return converted counts.
+	return result
+}
+
+// convertSLOStatus converts an SLO status to protobuf format.
+//
+// Params:
+//   - slo: the SLO status, nil without objective.
+//
+// Returns:
+//   - *daemonpb.SLOStatus: protobuf status, nil without objective.
+func convertSLOStatus(slo *metrics.SLOStatus) *daemonpb.SLOStatus {
+	// No objective declared.
+	if slo == nil {
+		// Leave the field unset.
+		return nil
+	}
+	burns := make([]*daemonpb.BurnRate, 0, len(slo.BurnRates))
+	// Convert each burn rate.
+	for _, b := range slo.BurnRates {
+		burns = append(burns, &daemonpb.BurnRate{
+			Window:    durationpb.New(b.Window),
+			Threshold: b.Threshold,
+			Rate:      b.Rate,
+			Alerting:  b.Alerting,
+		})
+	}
+	// Return converted status.
+	return &daemonpb.SLOStatus{
+		Target:          slo.Target,
+		Window:          durationpb.New(slo.Window),
+		Availability:    slo.Availability,
+		BudgetRemaining: slo.BudgetRemaining,
+		BurnRates:       burns,
+	}
+}
@@ -0,0 +1,135 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockStatsProvider returns fixed statistics for one service.
+type mockStatsProvider struct {
+	stats metrics.ServiceStats
+}
+
+func (m *mockStatsProvider) ServiceStats(name string) (metrics.ServiceStats, error) {
+	if name != m.stats.ServiceName {
+		return metrics.ServiceStats{}, errUnknownService
+	}
+	return m.stats, nil
+}
+
+// TestServer_GetServiceStats verifies statistics are converted to protobuf.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetServiceStats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		wantErr bool
+	}{
+		{name: "known service", service: "nginx"},
+		{name: "unknown service", service: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetStatsProvider(&mockStatsProvider{stats: metrics.ServiceStats{
+				ServiceName:  "nginx",
+				StartCount:   4,
+				FailCount:    1,
+				RestartCount: 3,
+				Uptime:       time.Hour,
+				Downtime:     time.Minute,
+				Availability: metrics.Availability{Day: 98.5, Week: 99.5, Month: 99.9},
+				SLO: &metrics.SLOStatus{
+					Target:          99.9,
+					Window:          720 * time.Hour,
+					BudgetRemaining: 40,
+					BurnRates:       []metrics.BurnRate{{Window: time.Hour, Threshold: 14.4, Rate: 20, Alerting: true}},
+				},
+				TopExits: []metrics.ExitCount{
+					{Cause: metrics.ExitCause{Code: 2}, Count: 3, Last: time.Unix(1700000000, 0)},
+					{Cause: metrics.ExitCause{Signal: "SIGSEGV"}, Count: 1, Last: time.Unix(1700000000, 0)},
+				},
+			}})
+
+			resp, err := server.GetServiceStats(context.Background(), &daemonpb.GetServiceStatsRequest{ServiceName: tt.service})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "nginx", resp.ServiceName)
+			assert.Equal(t, int64(4), resp.StartCount)
+			assert.Equal(t, int64(1), resp.FailCount)
+			assert.Equal(t, int64(3), resp.RestartCount)
+			assert.Equal(t, time.Hour, resp.Uptime.AsDuration())
+			assert.Equal(t, time.Minute, resp.Downtime.AsDuration())
+			assert.InDelta(t, 98.5, resp.Availability.Day, 0.001)
+			assert.InDelta(t, 99.9, resp.Availability.Month, 0.001)
+			require.NotNil(t, resp.Slo)
+			assert.InDelta(t, 40.0, resp.Slo.BudgetRemaining, 0.001)
+			require.Len(t, resp.Slo.BurnRates, 1)
+			assert.True(t, resp.Slo.BurnRates[0].Alerting)
+			assert.Equal(t, time.Hour, resp.Slo.BurnRates[0].Window.AsDuration())
+			require.Len(t, resp.TopExits, 2)
+			assert.Equal(t, int32(2), resp.TopExits[0].ExitCode)
+			assert.Equal(t, int64(3), resp.TopExits[0].Count)
+			assert.Equal(t, "SIGSEGV", resp.TopExits[1].Signal)
+			assert.Equal(t, int64(1700000000), resp.TopExits[1].Last.AsTime().Unix())
+		})
+	}
+}
+
+// TestServer_GetServiceStats_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetServiceStats_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetServiceStats(context.Background(), &daemonpb.GetServiceStatsRequest{ServiceName: "nginx"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+// TestServer_GetProcess_Availability verifies process metrics carry the availability.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetProcess_Availability(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockMetricsProvider{processMetrics: metrics.ProcessMetrics{ServiceName: "nginx"}}
+	server := grpc.NewServer(provider, &mockGetStator{})
+
+	resp, err := server.GetProcess(context.Background(), &daemonpb.GetProcessRequest{ServiceName: "nginx"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Availability)
+
+	server.SetStatsProvider(&mockStatsProvider{stats: metrics.ServiceStats{
+		ServiceName:  "nginx",
+		Availability: metrics.Availability{Day: 50, Week: 75, Month: 90},
+	}})
+	resp, err = server.GetProcess(context.Background(), &daemonpb.GetProcessRequest{ServiceName: "nginx"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Availability)
+	assert.InDelta(t, 75.0, resp.Availability.Week, 0.001)
+}
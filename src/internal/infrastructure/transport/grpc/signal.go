@@ -0,0 +1,62 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+)
+
+// ServiceSignaler sends signals to supervised services.
+type ServiceSignaler interface {
+	// SignalService sends a signal to the process of a service.
+	SignalService(name, signal string) error
+}
+
+// SetServiceSignaler sets the target of signal requests.
+// Without a signaler, SignalService returns Unimplemented.
+//
+// Params:
+//   - signaler: the service signaler.
+func (s *Server) SetServiceSignaler(signaler ServiceSignaler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store service signaler
+	s.signaler = signaler
+}
+
+// SignalService implements DaemonService.SignalService.
+//
+// Params:
+//   - ctx: bounds the wait for the resulting state.
+//   - req: request with the service name and signal.
+//
+// Returns:
+//   - *daemonpb.ServiceSnapshot: the state of the service once the signal
+//     is sent.
+//   - error: if signaling is not configured, the service is unknown, or
+//     the signal is unknown or not allowed, or the state was not settled
+//     in time.
+func (s *Server) SignalService(ctx context.Context, req *daemonpb.SignalServiceRequest) (*daemonpb.ServiceSnapshot, error) {
+	s.mu.Lock()
+	signaler := s.signaler
+	s.mu.Unlock()
+
+	// Check if signaling is configured.
+	if signaler == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "signals not configured")
+	}
+
+	// Check if the signal was delivered.
+	if err := signaler.SignalService(req.ServiceName, req.Signal); err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("signal service: %w", err)
+	}
+	// Return the resulting state.
+	return s.serviceSnapshot(ctx, req.ServiceName)
+}
@@ -0,0 +1,78 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockServiceSignaler records the signals sent to one service.
+type mockServiceSignaler struct {
+	service string
+	signals []string
+}
+
+func (m *mockServiceSignaler) SignalService(name, signal string) error {
+	if name != m.service {
+		return errUnknownService
+	}
+	m.signals = append(m.signals, signal)
+	return nil
+}
+
+// TestServer_SignalService verifies signal requests reach the signaler.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SignalService(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		wantErr bool
+	}{
+		{name: "known service", service: "api"},
+		{name: "unknown service", service: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			signaler := &mockServiceSignaler{service: "api"}
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetServiceSignaler(signaler)
+
+			_, err := server.SignalService(context.Background(), &daemonpb.SignalServiceRequest{ServiceName: tt.service, Signal: "usr1"})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				assert.Empty(t, signaler.signals)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"usr1"}, signaler.signals)
+		})
+	}
+}
+
+// TestServer_SignalService_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SignalService_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.SignalService(context.Background(), &daemonpb.SignalServiceRequest{ServiceName: "api", Signal: "SIGHUP"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,77 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// SnapshotProvider reports the state of a service once the events it
+// reported so far are applied, so the reply of a control request reflects
+// the request.
+type SnapshotProvider interface {
+	// ServiceSnapshot returns the state of a service after its pending events.
+	ServiceSnapshot(ctx context.Context, name string) (process.ServiceSnapshot, error)
+}
+
+// SetSnapshotProvider sets the source of the snapshots returned by control
+// requests. Without a provider, they only carry the service name.
+//
+// Params:
+//   - provider: the snapshot provider.
+func (s *Server) SetSnapshotProvider(provider SnapshotProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store snapshot provider
+	s.snapshots = provider
+}
+
+// serviceSnapshot returns the state of a service after a control request.
+//
+// Params:
+//   - ctx: bounds the wait for pending events.
+//   - name: the service name.
+//
+// Returns:
+//   - *daemonpb.ServiceSnapshot: the state of the service.
+//   - error: if the pending events were not applied in time.
+func (s *Server) serviceSnapshot(ctx context.Context, name string) (*daemonpb.ServiceSnapshot, error) {
+	s.mu.Lock()
+	provider := s.snapshots
+	s.mu.Unlock()
+
+	// Check if snapshots are configured.
+	if provider == nil {
+		// Return the name only.
+		return &daemonpb.ServiceSnapshot{ServiceName: name}, nil
+	}
+	snapshot, err := provider.ServiceSnapshot(ctx, name)
+	// Check if the events were applied.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("service snapshot: %w", err)
+	}
+	// Return converted snapshot.
+	return s.convertServiceSnapshot(&snapshot), nil
+}
+
+// convertServiceSnapshot converts a domain service snapshot to protobuf.
+//
+// Params:
+//   - snapshot: domain service snapshot.
+//
+// Returns:
+//   - *daemonpb.ServiceSnapshot: protobuf service snapshot.
+func (s *Server) convertServiceSnapshot(snapshot *process.ServiceSnapshot) *daemonpb.ServiceSnapshot {
+	// Return protobuf snapshot.
+	return &daemonpb.ServiceSnapshot{
+		ServiceName: snapshot.Name,
+		State:       s.convertProcessState(snapshot.State),
+		Pid:         safeInt32(snapshot.PID),
+		OperationId: snapshot.OperationID,
+		Sequence:    snapshot.Sequence,
+	}
+}
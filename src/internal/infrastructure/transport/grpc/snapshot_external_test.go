@@ -0,0 +1,53 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockSnapshotProvider reports a fixed state for one service.
+type mockSnapshotProvider struct {
+	snapshot process.ServiceSnapshot
+}
+
+func (m *mockSnapshotProvider) ServiceSnapshot(_ context.Context, name string) (process.ServiceSnapshot, error) {
+	if name != m.snapshot.Name {
+		return process.ServiceSnapshot{}, errUnknownService
+	}
+	return m.snapshot, nil
+}
+
+// TestServer_SignalService_Snapshot verifies control replies carry the
+// state of the service once its events are applied.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_SignalService_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetServiceSignaler(&mockServiceSignaler{service: "api"})
+
+	// Without a provider, only the name is reported.
+	resp, err := server.SignalService(context.Background(), &daemonpb.SignalServiceRequest{ServiceName: "api", Signal: "usr1"})
+	require.NoError(t, err)
+	assert.Equal(t, "api", resp.ServiceName)
+	assert.Zero(t, resp.Sequence)
+
+	server.SetSnapshotProvider(&mockSnapshotProvider{snapshot: process.ServiceSnapshot{
+		Name: "api", State: process.StateRunning, PID: 42, Sequence: 7,
+	}})
+	resp, err = server.SignalService(context.Background(), &daemonpb.SignalServiceRequest{ServiceName: "api", Signal: "usr1"})
+	require.NoError(t, err)
+	assert.Equal(t, daemonpb.ProcessState_PROCESS_STATE_RUNNING, resp.State)
+	assert.Equal(t, int32(42), resp.Pid)
+	assert.Equal(t, uint64(7), resp.Sequence)
+}
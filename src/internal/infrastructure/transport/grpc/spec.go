@@ -0,0 +1,104 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+)
+
+// SpecProvider provides the resolved launch specification of services.
+type SpecProvider interface {
+	// ServiceSpec returns the specification a running service was launched with.
+	ServiceSpec(ctx context.Context, name string) (process.ResolvedSpec, error)
+}
+
+// SetSpecProvider sets the source of resolved service specifications.
+// Without a provider, GetServiceSpec returns Unimplemented.
+//
+// Params:
+//   - provider: the spec provider.
+func (s *Server) SetSpecProvider(provider SpecProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store spec provider
+	s.specProvider = provider
+}
+
+// GetServiceSpec implements DaemonService.GetServiceSpec.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - req: request with the service name.
+//
+// Returns:
+//   - *daemonpb.ServiceSpec: the resolved specification.
+//   - error: if inspection is disabled or the service is unknown or not running.
+func (s *Server) GetServiceSpec(ctx context.Context, req *daemonpb.GetServiceSpecRequest) (*daemonpb.ServiceSpec, error) {
+	s.mu.Lock()
+	provider := s.specProvider
+	s.mu.Unlock()
+
+	// Check if spec inspection is configured.
+	if provider == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "spec inspection not configured")
+	}
+
+	spec, err := provider.ServiceSpec(ctx, req.ServiceName)
+	// Check if inspection failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get service spec: %w", err)
+	}
+	// Return converted spec.
+	return convertServiceSpec(&spec), nil
+}
+
+// convertServiceSpec converts a resolved spec to protobuf format.
+//
+// Params:
+//   - spec: the resolved spec.
+//
+// Returns:
+//   - *daemonpb.ServiceSpec: protobuf spec.
+func convertServiceSpec(spec *process.ResolvedSpec) *daemonpb.ServiceSpec {
+	limits := make([]*daemonpb.ResourceLimit, 0, len(spec.Limits))
+	// Convert each limit.
+	for _, l := range spec.Limits {
+		limits = append(limits, &daemonpb.ResourceLimit{Name: l.Name, Soft: l.Soft, Hard: l.Hard, Unit: l.Unit})
+	}
+	listeners := make([]*daemonpb.ListenerSpec, 0, len(spec.Listeners))
+	// Convert each listener.
+	for i := range spec.Listeners {
+		l := &spec.Listeners[i]
+		listeners = append(listeners, &daemonpb.ListenerSpec{
+			Name:     l.Name,
+			Protocol: l.Protocol,
+			Address:  l.Address,
+			Port:     safeInt32(l.Port),
+			Exposed:  l.Exposed,
+		})
+	}
+	// Return converted spec.
+	return &daemonpb.ServiceSpec{
+		ServiceName:      spec.Service,
+		Pid:              safeInt32(spec.PID),
+		StartedAt:        timestamppb.New(spec.StartedAt),
+		Command:          spec.Command,
+		Args:             spec.Args,
+		WorkingDirectory: spec.Dir,
+		Env:              spec.Env,
+		User:             spec.User,
+		Group:            spec.Group,
+		CgroupPath:       spec.CgroupPath,
+		Limits:           limits,
+		Listeners:        listeners,
+	}
+}
@@ -0,0 +1,88 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockSpecProvider returns a fixed spec for one service.
+type mockSpecProvider struct {
+	spec process.ResolvedSpec
+}
+
+func (m *mockSpecProvider) ServiceSpec(_ context.Context, name string) (process.ResolvedSpec, error) {
+	if name != m.spec.Service {
+		return process.ResolvedSpec{}, process.ErrNotRunning
+	}
+	return m.spec, nil
+}
+
+// TestServer_GetServiceSpec verifies spec conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetServiceSpec(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetSpecProvider(&mockSpecProvider{spec: process.ResolvedSpec{
+		Service:    "api",
+		PID:        42,
+		StartedAt:  started,
+		Command:    "/bin/api",
+		Args:       []string{"--port", "8080"},
+		Dir:        "/srv",
+		Env:        map[string]string{"API_TOKEN": process.RedactedValue},
+		User:       "app",
+		Group:      "app",
+		CgroupPath: "/system.slice/api.service",
+		Limits:     []process.ResourceLimit{{Name: "Max open files", Soft: "1024", Hard: "4096", Unit: "files"}},
+		Listeners:  []config.ListenerConfig{{Name: "http", Protocol: "tcp", Port: 8080, Exposed: true}},
+	}})
+
+	spec, err := server.GetServiceSpec(context.Background(), &daemonpb.GetServiceSpecRequest{ServiceName: "api"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "api", spec.ServiceName)
+	assert.Equal(t, int32(42), spec.Pid)
+	assert.True(t, spec.StartedAt.AsTime().Equal(started))
+	assert.Equal(t, "/bin/api", spec.Command)
+	assert.Equal(t, []string{"--port", "8080"}, spec.Args)
+	assert.Equal(t, "/srv", spec.WorkingDirectory)
+	assert.Equal(t, process.RedactedValue, spec.Env["API_TOKEN"])
+	assert.Equal(t, "/system.slice/api.service", spec.CgroupPath)
+	require.Len(t, spec.Limits, 1)
+	assert.Equal(t, "4096", spec.Limits[0].Hard)
+	require.Len(t, spec.Listeners, 1)
+	assert.Equal(t, int32(8080), spec.Listeners[0].Port)
+	assert.True(t, spec.Listeners[0].Exposed)
+
+	_, err = server.GetServiceSpec(context.Background(), &daemonpb.GetServiceSpecRequest{ServiceName: "web"})
+	assert.ErrorIs(t, err, process.ErrNotRunning)
+}
+
+// TestServer_GetServiceSpec_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetServiceSpec_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetServiceSpec(context.Background(), &daemonpb.GetServiceSpecRequest{ServiceName: "api"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
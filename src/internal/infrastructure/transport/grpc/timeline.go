@@ -0,0 +1,156 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/storage"
+)
+
+const (
+	// defaultTimelineLimit is the number of entries returned when the request sets none.
+	defaultTimelineLimit int = 1000
+
+	// maxTimelineLimit bounds the number of entries of one timeline.
+	maxTimelineLimit int = 10000
+)
+
+// SetEventHistory sets the reader of persisted events for GetServiceTimeline.
+// Without a reader, GetServiceTimeline returns Unimplemented.
+//
+// Params:
+//   - history: the event store reader.
+func (s *Server) SetEventHistory(history storage.EventReader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store event history
+	s.eventHistory = history
+}
+
+// GetServiceTimeline implements DaemonService.GetServiceTimeline.
+// It returns the persisted events of a service over a time range, oldest
+// first, each with its category.
+//
+// Params:
+//   - ctx: context for cancellation.
+//   - req: service name, time range and entry limit.
+//
+// Returns:
+//   - *daemonpb.ServiceTimeline: the events of the range.
+//   - error: if the request is invalid, the timeline is disabled, or the store read fails.
+func (s *Server) GetServiceTimeline(ctx context.Context, req *daemonpb.GetServiceTimelineRequest) (*daemonpb.ServiceTimeline, error) {
+	s.mu.Lock()
+	history := s.eventHistory
+	s.mu.Unlock()
+
+	// Check if event history is configured.
+	if history == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "event history not configured")
+	}
+
+	filter, err := timelineFilter(req)
+	// Check if the request is valid.
+	if err != nil {
+		// Return invalid argument error.
+		return nil, err
+	}
+	limit := filter.Limit
+	// Read one more event to detect truncation.
+	filter.Limit++
+
+	records, err := history.ListEvents(ctx, 0, filter)
+	// Check if the read failed.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("get service timeline: %w", err)
+	}
+
+	timeline := &daemonpb.ServiceTimeline{ServiceName: req.ServiceName}
+	// Leave out the extra event.
+	if len(records) > limit {
+		records = records[:limit]
+		timeline.Truncated = true
+	}
+	timeline.Entries = make([]*daemonpb.TimelineEntry, 0, len(records))
+	// Convert each event.
+	for i := range records {
+		timeline.Entries = append(timeline.Entries, convertTimelineEntry(&records[i]))
+	}
+	// Return converted timeline.
+	return timeline, nil
+}
+
+// timelineFilter builds the event filter of a timeline request.
+//
+// Params:
+//   - req: the timeline request.
+//
+// Returns:
+//   - storage.EventFilter: the events of the service in the range.
+//   - error: an invalid argument error for a missing service, a negative
+//     limit, or a range ending before it starts.
+func timelineFilter(req *daemonpb.GetServiceTimelineRequest) (storage.EventFilter, error) {
+	// Require a service.
+	if req.ServiceName == "" {
+		// Return invalid argument error.
+		return storage.EventFilter{}, shared.WithCode(shared.CodeInvalidArgument, errors.New("service_name: required"))
+	}
+	// Reject negative limits.
+	if req.Limit < 0 {
+		// Return invalid argument error.
+		return storage.EventFilter{}, shared.WithCode(shared.CodeInvalidArgument, fmt.Errorf("limit %d: must not be negative", req.Limit))
+	}
+
+	filter := storage.EventFilter{Services: []string{req.ServiceName}, Limit: min(int(req.Limit), maxTimelineLimit)}
+	// Apply the default limit.
+	if filter.Limit == 0 {
+		filter.Limit = defaultTimelineLimit
+	}
+	// Start from the requested time if provided.
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	// End at the requested time if provided.
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+	// Reject ranges ending before they start.
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		// Return invalid argument error.
+		return storage.EventFilter{}, shared.WithCode(shared.CodeInvalidArgument,
+			fmt.Errorf("to %s: before from %s", filter.To.Format(time.RFC3339), filter.From.Format(time.RFC3339)))
+	}
+	// Return filter.
+	return filter, nil
+}
+
+// convertTimelineEntry converts a persisted event to a timeline entry.
+//
+// Params:
+//   - rec: the persisted event.
+//
+// Returns:
+//   - *daemonpb.TimelineEntry: protobuf timeline entry.
+func convertTimelineEntry(rec *storage.EventRecord) *daemonpb.TimelineEntry {
+	// Return converted entry.
+	return &daemonpb.TimelineEntry{
+		Timestamp: timestamppb.New(rec.Timestamp),
+		Type:      rec.Type,
+		Category:  string(process.CategoryOf(rec.Type)),
+		Pid:       safeInt32(rec.PID),
+		ExitCode:  safeInt32(rec.ExitCode),
+		Error:     rec.Error,
+		Seq:       rec.Seq,
+	}
+}
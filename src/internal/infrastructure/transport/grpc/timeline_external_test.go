@@ -0,0 +1,144 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/storage"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockEventHistory filters fixed records like an event store.
+type mockEventHistory struct {
+	records []storage.EventRecord
+}
+
+func (m *mockEventHistory) ListEvents(_ context.Context, since uint64, filter storage.EventFilter) ([]storage.EventRecord, error) {
+	var result []storage.EventRecord
+	for i := range m.records {
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+		if m.records[i].Seq > since && filter.Matches(&m.records[i]) {
+			result = append(result, m.records[i])
+		}
+	}
+	return result, nil
+}
+
+// newMockEventHistory returns the events of an api service restarting after
+// a failure, and one event of another service.
+//
+// Params:
+//   - start: the time of the first event.
+//
+// Returns:
+//   - *mockEventHistory: the event history.
+func newMockEventHistory(start time.Time) *mockEventHistory {
+	return &mockEventHistory{records: []storage.EventRecord{
+		{Seq: 1, Timestamp: start, Service: "api", Type: "started", PID: 10},
+		{Seq: 2, Timestamp: start.Add(time.Minute), Service: "web", Type: "started", PID: 11},
+		{Seq: 3, Timestamp: start.Add(2 * time.Minute), Service: "api", Type: "unhealthy", PID: 10, Error: "connection refused"},
+		{Seq: 4, Timestamp: start.Add(3 * time.Minute), Service: "api", Type: "failed", PID: 10, ExitCode: 2},
+		{Seq: 5, Timestamp: start.Add(4 * time.Minute), Service: "api", Type: "started", PID: 12},
+	}}
+}
+
+// TestServer_GetServiceTimeline verifies time range, limit and category handling.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetServiceTimeline(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		req           *daemonpb.GetServiceTimelineRequest
+		wantTypes     []string
+		wantTruncated bool
+	}{
+		{name: "whole history", req: &daemonpb.GetServiceTimelineRequest{ServiceName: "api"}, wantTypes: []string{"started", "unhealthy", "failed", "started"}},
+		{
+			name:      "time range",
+			req:       &daemonpb.GetServiceTimelineRequest{ServiceName: "api", From: timestamppb.New(start.Add(time.Minute)), To: timestamppb.New(start.Add(4 * time.Minute))},
+			wantTypes: []string{"unhealthy", "failed"},
+		},
+		{name: "limited", req: &daemonpb.GetServiceTimelineRequest{ServiceName: "api", Limit: 2}, wantTypes: []string{"started", "unhealthy"}, wantTruncated: true},
+		{name: "limit reached exactly", req: &daemonpb.GetServiceTimelineRequest{ServiceName: "api", Limit: 4}, wantTypes: []string{"started", "unhealthy", "failed", "started"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetEventHistory(newMockEventHistory(start))
+
+			resp, err := server.GetServiceTimeline(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, "api", resp.ServiceName)
+			assert.Equal(t, tt.wantTruncated, resp.Truncated)
+			types := make([]string, 0, len(resp.Entries))
+			for _, entry := range resp.Entries {
+				types = append(types, entry.Type)
+			}
+			assert.Equal(t, tt.wantTypes, types)
+		})
+	}
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+	server.SetEventHistory(newMockEventHistory(start))
+	resp, err := server.GetServiceTimeline(context.Background(), &daemonpb.GetServiceTimelineRequest{ServiceName: "api", Limit: 3})
+	require.NoError(t, err)
+	unhealthy, failed := resp.Entries[1], resp.Entries[2]
+	assert.Equal(t, "health", unhealthy.Category)
+	assert.Equal(t, "connection refused", unhealthy.Error)
+	assert.True(t, start.Add(2*time.Minute).Equal(unhealthy.Timestamp.AsTime()))
+	assert.Equal(t, "lifecycle", failed.Category)
+	assert.Equal(t, int32(2), failed.ExitCode)
+	assert.Equal(t, uint64(4), failed.Seq)
+}
+
+// TestServer_GetServiceTimeline_errors verifies invalid requests and a missing event store.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_GetServiceTimeline_errors(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.GetServiceTimeline(context.Background(), &daemonpb.GetServiceTimelineRequest{ServiceName: "api"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	server.SetEventHistory(newMockEventHistory(start))
+	tests := []struct {
+		name string
+		req  *daemonpb.GetServiceTimelineRequest
+	}{
+		{name: "no service", req: &daemonpb.GetServiceTimelineRequest{}},
+		{name: "negative limit", req: &daemonpb.GetServiceTimelineRequest{ServiceName: "api", Limit: -1}},
+		{name: "reversed range", req: &daemonpb.GetServiceTimelineRequest{ServiceName: "api", From: timestamppb.New(start), To: timestamppb.New(start.Add(-time.Hour))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := server.GetServiceTimeline(context.Background(), tt.req)
+			assert.Equal(t, shared.CodeInvalidArgument, shared.CodeOf(err))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Package grpc provides gRPC server implementation for the daemon API.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+)
+
+// ServiceVerifier dry-runs service binaries without starting the services.
+type ServiceVerifier interface {
+	// VerifyServices checks the named services, all when none is named.
+	// It returns a *config.PreflightError listing every failed check.
+	VerifyServices(names ...string) error
+}
+
+// SetServiceVerifier sets the target of verification requests.
+// Without a verifier, VerifyServices returns Unimplemented.
+//
+// Params:
+//   - verifier: the service verifier.
+func (s *Server) SetServiceVerifier(verifier ServiceVerifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store service verifier
+	s.verifier = verifier
+}
+
+// VerifyServices implements DaemonService.VerifyServices.
+// Failed checks are reported in the response, not as an error.
+//
+// Params:
+//   - ctx: context for cancellation (unused).
+//   - req: request with the service names.
+//
+// Returns:
+//   - *daemonpb.VerifyReport: the passed flag and failed checks.
+//   - error: if verification is not configured or a service is unknown.
+func (s *Server) VerifyServices(_ context.Context, req *daemonpb.VerifyServicesRequest) (*daemonpb.VerifyReport, error) {
+	s.mu.Lock()
+	verifier := s.verifier
+	s.mu.Unlock()
+
+	// Check if verification is configured.
+	if verifier == nil {
+		// Report disabled feature.
+		return nil, status.Error(codes.Unimplemented, "service verification not configured")
+	}
+
+	err := verifier.VerifyServices(req.ServiceNames...)
+	var report *config.PreflightError
+	// Report failed checks as the verification outcome.
+	if errors.As(err, &report) {
+		// Return converted failures.
+		return convertVerifyReport(report.Failures), nil
+	}
+	// Check if the verification could not run.
+	if err != nil {
+		// Return wrapped error.
+		return nil, fmt.Errorf("verify services: %w", err)
+	}
+	// Return passed report.
+	return convertVerifyReport(nil), nil
+}
+
+// convertVerifyReport converts pre-flight failures to protobuf format.
+//
+// Params:
+//   - failures: the failed checks.
+//
+// Returns:
+//   - *daemonpb.VerifyReport: protobuf report, passed without failures.
+func convertVerifyReport(failures []config.PreflightFailure) *daemonpb.VerifyReport {
+	report := &daemonpb.VerifyReport{
+		Passed:   len(failures) == 0,
+		Failures: make([]*daemonpb.PreflightFailure, 0, len(failures)),
+	}
+	// Convert each failure.
+	for _, f := range failures {
+		report.Failures = append(report.Failures, &daemonpb.PreflightFailure{
+			ServiceName: f.Service,
+			Check:       f.Check,
+			Detail:      f.Detail,
+		})
+	}
+	// Return converted report.
+	return report
+}
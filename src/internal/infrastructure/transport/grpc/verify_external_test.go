@@ -0,0 +1,92 @@
+// Package grpc_test provides black-box tests for the grpc package.
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "github.com/kodflow/daemon/api/proto/v1/daemon"
+	"github.com/kodflow/daemon/internal/domain/config"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/grpc"
+)
+
+// mockServiceVerifier fails the verify check of one service.
+type mockServiceVerifier struct {
+	broken string
+}
+
+func (m *mockServiceVerifier) VerifyServices(names ...string) error {
+	for _, name := range names {
+		if name != "api" && name != m.broken {
+			return errUnknownService
+		}
+	}
+	if len(names) == 1 && names[0] == "api" {
+		return nil
+	}
+	return fmt.Errorf("wrapped: %w", &config.PreflightError{Failures: []config.PreflightFailure{
+		{Service: m.broken, Check: config.PreflightCheckVerify, Detail: "exit status 127: libssl.so.3 not found"},
+	}})
+}
+
+// TestServer_VerifyServices verifies verification report conversion.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_VerifyServices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		services     []string
+		wantErr      bool
+		wantPassed   bool
+		wantFailures int
+	}{
+		{name: "passing service", services: []string{"api"}, wantPassed: true},
+		{name: "all services", wantFailures: 1},
+		{name: "unknown service", services: []string{"missing"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+			server.SetServiceVerifier(&mockServiceVerifier{broken: "web"})
+
+			resp, err := server.VerifyServices(context.Background(), &daemonpb.VerifyServicesRequest{ServiceNames: tt.services})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnknownService)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPassed, resp.Passed)
+			require.Len(t, resp.Failures, tt.wantFailures)
+			if tt.wantFailures > 0 {
+				assert.Equal(t, "web", resp.Failures[0].ServiceName)
+				assert.Equal(t, config.PreflightCheckVerify, resp.Failures[0].Check)
+				assert.Contains(t, resp.Failures[0].Detail, "libssl")
+			}
+		})
+	}
+}
+
+// TestServer_VerifyServices_NotConfigured verifies the disabled feature error.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_VerifyServices_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer(&mockMetricsProvider{}, &mockGetStator{})
+
+	_, err := server.VerifyServices(context.Background(), &daemonpb.VerifyServicesRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
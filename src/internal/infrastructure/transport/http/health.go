@@ -0,0 +1,157 @@
+// Package http provides the HTTP adapter of the daemon API, for clients
+// that only speak plain HTTP such as external load balancers.
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/shared"
+)
+
+// HealthProvider provides the aggregated health of services.
+type HealthProvider interface {
+	// ServiceHealth returns the aggregated health of a service.
+	ServiceHealth(name string) (*health.AggregatedHealth, error)
+}
+
+// ServiceHealth is the JSON body of the service health endpoint.
+type ServiceHealth struct {
+	// Service is the service name.
+	Service string `json:"service"`
+	// Status is healthy, degraded, unhealthy or unknown.
+	Status string `json:"status"`
+	// ProcessState is the state of the service process.
+	ProcessState string `json:"process_state"`
+	// CustomStatus is the status set by the service, if any.
+	CustomStatus string `json:"custom_status,omitempty"`
+	// LastCheck is the time of the last health update.
+	LastCheck time.Time `json:"last_check"`
+	// LatencyMs is the latest probe latency, in milliseconds.
+	LatencyMs float64 `json:"latency_ms"`
+	// Subjects are the probed subjects, whatever the probe type.
+	Subjects []SubjectHealth `json:"subjects"`
+}
+
+// SubjectHealth is the health of a probed subject.
+type SubjectHealth struct {
+	// Name is the subject name.
+	Name string `json:"name"`
+	// State is the subject state (ready, listening, closed, ...).
+	State string `json:"state"`
+	// ConsecutiveSuccesses is the count of consecutive successful probes.
+	ConsecutiveSuccesses int `json:"consecutive_successes"`
+	// ConsecutiveFailures is the count of consecutive failed probes.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// LastProbe is the last probe result, absent before the first probe.
+	LastProbe *ProbeResult `json:"last_probe,omitempty"`
+}
+
+// ProbeResult is the outcome of a probe.
+type ProbeResult struct {
+	// Status is the probe outcome.
+	Status string `json:"status"`
+	// Message describes the outcome.
+	Message string `json:"message,omitempty"`
+	// Error is the probe error, if any.
+	Error string `json:"error,omitempty"`
+	// DurationMs is the probe duration, in milliseconds.
+	DurationMs float64 `json:"duration_ms"`
+	// Timestamp is when the probe ran.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// handleServiceHealth serves GET /services/{name}/health. It answers 200
+// when the service is healthy, 503 otherwise, with the health as JSON; 404
+// for unknown services.
+//
+// Params:
+//   - w: the response writer.
+//   - r: the request.
+func (s *Server) handleServiceHealth(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	aggregated, err := s.health.ServiceHealth(name)
+	// Check if the lookup failed.
+	if err != nil {
+		code := http.StatusInternalServerError
+		// unknown services are not found
+		if shared.CodeOf(err) == shared.CodeServiceNotFound {
+			code = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), code)
+		// Lookup failed.
+		return
+	}
+
+	body := convertServiceHealth(name, aggregated)
+	code := http.StatusOK
+	// Load balancers only look at the status code.
+	if !aggregated.IsHealthy() {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	// The client may be gone; nothing to do then.
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// convertServiceHealth converts an aggregated health to its JSON body.
+//
+// Params:
+//   - name: the service name.
+//   - h: the aggregated health.
+//
+// Returns:
+//   - *ServiceHealth: the JSON body.
+func convertServiceHealth(name string, h *health.AggregatedHealth) *ServiceHealth {
+	body := &ServiceHealth{
+		Service:      name,
+		Status:       h.Status().String(),
+		ProcessState: h.ProcessState.String(),
+		CustomStatus: h.CustomStatus,
+		LastCheck:    h.LastCheck,
+		LatencyMs:    milliseconds(h.Latency),
+		Subjects:     make([]SubjectHealth, 0, len(h.Subjects)),
+	}
+	// Convert each subject.
+	for i := range h.Subjects {
+		subject := &h.Subjects[i]
+		sh := SubjectHealth{
+			Name:                 subject.Name,
+			State:                string(subject.State),
+			ConsecutiveSuccesses: subject.ConsecutiveSuccesses,
+			ConsecutiveFailures:  subject.ConsecutiveFailures,
+		}
+		// Set the last probe once one ran.
+		if result := subject.LastProbeResult; result != nil {
+			sh.LastProbe = &ProbeResult{
+				Status:     result.Status.String(),
+				Message:    result.Message,
+				DurationMs: milliseconds(result.Duration),
+				Timestamp:  result.Timestamp,
+			}
+			// Errors are reported as text.
+			if result.Error != nil {
+				sh.LastProbe.Error = result.Error.Error()
+			}
+		}
+		body.Subjects = append(body.Subjects, sh)
+	}
+	// Return converted health.
+	return body
+}
+
+// milliseconds converts a duration to fractional milliseconds.
+//
+// Params:
+//   - d: the duration.
+//
+// Returns:
+//   - float64: the duration in milliseconds.
+func milliseconds(d time.Duration) float64 {
+	// Return fractional milliseconds.
+	return float64(d) / float64(time.Millisecond)
+}
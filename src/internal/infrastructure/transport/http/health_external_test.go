@@ -0,0 +1,157 @@
+// Package http_test provides black-box tests for the http package.
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	daemonhttp "github.com/kodflow/daemon/internal/infrastructure/transport/http"
+)
+
+// errServiceNotFound mimics the supervisor unknown service error.
+var errServiceNotFound error = shared.NewCodedError(shared.CodeServiceNotFound, "service not found")
+
+// mockHealthProvider returns fixed health per service.
+type mockHealthProvider struct {
+	services map[string]*health.AggregatedHealth
+	err      error
+}
+
+func (m *mockHealthProvider) ServiceHealth(name string) (*health.AggregatedHealth, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	h, ok := m.services[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errServiceNotFound, name)
+	}
+	return h, nil
+}
+
+// TestServer_ServiceHealth verifies the status code and body of the service health endpoint.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ServiceHealth(t *testing.T) {
+	t.Parallel()
+
+	probed := time.Unix(1700000000, 0).UTC()
+	failed := health.NewUnhealthyResult("exit status 1", 20*time.Millisecond, errors.New("exit status 1"))
+	provider := &mockHealthProvider{services: map[string]*health.AggregatedHealth{
+		"api": {ProcessState: process.StateRunning},
+		"dns": {
+			ProcessState: process.StateRunning,
+			LastCheck:    probed,
+			Latency:      1500 * time.Microsecond,
+			Subjects: []health.SubjectStatus{{
+				Name:                "udp",
+				State:               health.SubjectClosed,
+				LastProbeResult:     &failed,
+				ConsecutiveFailures: 3,
+			}},
+		},
+		"worker": {ProcessState: process.StateStopped},
+	}}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{name: "healthy without probes", path: "/services/api/health", wantCode: nethttp.StatusOK, wantBody: "healthy"},
+		{name: "failing exec probe", path: "/services/dns/health", wantCode: nethttp.StatusServiceUnavailable, wantBody: "unhealthy"},
+		{name: "stopped process", path: "/services/worker/health", wantCode: nethttp.StatusServiceUnavailable, wantBody: "unhealthy"},
+		{name: "unknown service", path: "/services/db/health", wantCode: nethttp.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := daemonhttp.NewServer(provider)
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, tt.path, nethttp.NoBody))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantBody == "" {
+				return
+			}
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var body daemonhttp.ServiceHealth
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantBody, body.Status)
+		})
+	}
+}
+
+// TestServer_ServiceHealth_Subjects verifies probed subjects are reported.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ServiceHealth_Subjects(t *testing.T) {
+	t.Parallel()
+
+	probed := time.Unix(1700000000, 0).UTC()
+	result := health.NewUnhealthyResultAt("no answer", 20*time.Millisecond, errors.New("timeout"), probed)
+	provider := &mockHealthProvider{services: map[string]*health.AggregatedHealth{
+		"dns": {
+			ProcessState: process.StateRunning,
+			LastCheck:    probed,
+			Latency:      1500 * time.Microsecond,
+			Subjects: []health.SubjectStatus{
+				{Name: "udp", State: health.SubjectClosed, LastProbeResult: &result, ConsecutiveFailures: 3},
+				{Name: "admin", State: health.SubjectUnknown},
+			},
+		},
+	}}
+	server := daemonhttp.NewServer(provider)
+	rec := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/services/dns/health", nethttp.NoBody))
+
+	var body daemonhttp.ServiceHealth
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "dns", body.Service)
+	assert.Equal(t, "running", body.ProcessState)
+	assert.InDelta(t, 1.5, body.LatencyMs, 0.001)
+	require.Len(t, body.Subjects, 2)
+	udp := body.Subjects[0]
+	assert.Equal(t, "udp", udp.Name)
+	assert.Equal(t, "closed", udp.State)
+	assert.Equal(t, 3, udp.ConsecutiveFailures)
+	require.NotNil(t, udp.LastProbe)
+	assert.Equal(t, "unhealthy", udp.LastProbe.Status)
+	assert.Equal(t, "timeout", udp.LastProbe.Error)
+	assert.Equal(t, probed, udp.LastProbe.Timestamp)
+	assert.Nil(t, body.Subjects[1].LastProbe)
+}
+
+// TestServer_ServiceHealth_Errors verifies lookup failures and other methods.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_ServiceHealth_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := daemonhttp.NewServer(&mockHealthProvider{err: errors.New("boom")})
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/services/api/health", nethttp.NoBody))
+	assert.Equal(t, nethttp.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(nethttp.MethodPost, "/services/api/health", nethttp.NoBody))
+	assert.Equal(t, nethttp.StatusMethodNotAllowed, rec.Code)
+}
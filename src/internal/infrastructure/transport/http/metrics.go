@@ -0,0 +1,125 @@
+// Package http provides the HTTP adapter of the daemon API, for clients
+// that only speak plain HTTP such as external load balancers.
+package http
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// metricsContentType is the Prometheus text exposition format.
+const metricsContentType string = "text/plain; version=0.0.4; charset=utf-8"
+
+// labelEscaper escapes Prometheus label values.
+var labelEscaper *strings.Replacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ExitStatsProvider counts the failures of services by exit cause.
+type ExitStatsProvider interface {
+	// ServiceExits returns the failures of each service over the last 30 days.
+	ServiceExits() map[string][]metrics.ExitCount
+}
+
+// SetExitStatsProvider sets the source of the supervizio_service_failures
+// gauge. Without a provider, the gauge is not exported.
+//
+// Params:
+//   - provider: the exit statistics provider.
+func (s *Server) SetExitStatsProvider(provider ExitStatsProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store exit statistics provider
+	s.exits = provider
+}
+
+// SetBuildInfo sets the build of the daemon, exported as the
+// supervizio_build_info gauge. Without build info, /metrics answers 404.
+//
+// Params:
+//   - info: the build of the daemon.
+func (s *Server) SetBuildInfo(info *metrics.BuildInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// store build info
+	s.buildInfo = info
+}
+
+// handleMetrics serves GET /metrics in the Prometheus text format.
+//
+// Params:
+//   - w: the response writer.
+//   - r: the request.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	info := s.buildInfo
+	exits := s.exits
+	s.mu.Unlock()
+
+	// Check if build info is configured.
+	if info == nil {
+		http.NotFound(w, r)
+		// Nothing to export.
+		return
+	}
+	w.Header().Set("Content-Type", metricsContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	body := formatBuildInfo(info)
+	// export the failure modes of the services
+	if exits != nil {
+		body += formatServiceFailures(exits.ServiceExits())
+	}
+	// The client may be gone; nothing to do then.
+	_, _ = fmt.Fprint(w, body)
+}
+
+// formatBuildInfo formats the build of the daemon as a gauge always at 1,
+// identified by its labels, so it can be joined with other series.
+//
+// Params:
+//   - info: the build of the daemon.
+//
+// Returns:
+//   - string: the metric family in the Prometheus text format.
+func formatBuildInfo(info *metrics.BuildInfo) string {
+	date := ""
+	// unknown dates are left empty
+	if !info.BuildDate.IsZero() {
+		date = info.BuildDate.UTC().Format(time.RFC3339)
+	}
+	// Return metric family.
+	return "# HELP supervizio_build_info Build of the daemon binary, always 1.\n" +
+		"# TYPE supervizio_build_info gauge\n" +
+		fmt.Sprintf("supervizio_build_info{version=\"%s\",commit=\"%s\",build_date=\"%s\",go_version=\"%s\",features=\"%s\"} 1\n",
+			labelEscaper.Replace(info.Version), labelEscaper.Replace(info.Commit), date,
+			labelEscaper.Replace(info.GoVersion), labelEscaper.Replace(strings.Join(info.Features, ",")))
+}
+
+// formatServiceFailures formats the failures of each service over the last
+// 30 days as a gauge per exit cause: exit 2 for a configuration error,
+// SIGSEGV for a crash.
+//
+// Params:
+//   - exits: the failures of each service by exit cause.
+//
+// Returns:
+//   - string: the metric family in the Prometheus text format.
+func formatServiceFailures(exits map[string][]metrics.ExitCount) string {
+	var b strings.Builder
+	b.WriteString("# HELP supervizio_service_failures Failures of a service over the last 30 days, by exit code or signal.\n" +
+		"# TYPE supervizio_service_failures gauge\n")
+	// one series per service and cause, services in order
+	for _, service := range slices.Sorted(maps.Keys(exits)) {
+		// causes come most frequent first
+		for _, c := range exits[service] {
+			fmt.Fprintf(&b, "supervizio_service_failures{service=\"%s\",cause=\"%s\"} %d\n",
+				labelEscaper.Replace(service), labelEscaper.Replace(c.Cause.String()), c.Count)
+		}
+	}
+	// Return metric family.
+	return b.String()
+}
@@ -0,0 +1,111 @@
+// Package http_test provides black-box tests for the http package.
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+	daemonhttp "github.com/kodflow/daemon/internal/infrastructure/transport/http"
+)
+
+// TestServer_Metrics verifies the build info gauge of the metrics endpoint.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		info     *metrics.BuildInfo
+		wantCode int
+		wantLine string
+	}{
+		{
+			name: "release build",
+			info: &metrics.BuildInfo{
+				Version:   "1.4.0",
+				Commit:    "4f2a9c1",
+				BuildDate: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+				GoVersion: "go1.25.6",
+				Features:  []string{"cgroups", "probe"},
+			},
+			wantCode: nethttp.StatusOK,
+			wantLine: `supervizio_build_info{version="1.4.0",commit="4f2a9c1",build_date="2026-03-01T12:00:00Z",go_version="go1.25.6",features="cgroups,probe"} 1`,
+		},
+		{
+			name:     "escaped labels",
+			info:     &metrics.BuildInfo{Version: `v"1"\dev`, GoVersion: "go1.25.6"},
+			wantCode: nethttp.StatusOK,
+			wantLine: `supervizio_build_info{version="v\"1\"\\dev",commit="",build_date="",go_version="go1.25.6",features=""} 1`,
+		},
+		{
+			name:     "not configured",
+			wantCode: nethttp.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := daemonhttp.NewServer(&mockHealthProvider{})
+			// only configured servers export metrics
+			if tt.info != nil {
+				server.SetBuildInfo(tt.info)
+			}
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/metrics", nethttp.NoBody))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantLine == "" {
+				return
+			}
+			assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+			assert.Contains(t, rec.Body.String(), "# TYPE supervizio_build_info gauge\n")
+			assert.Contains(t, rec.Body.String(), tt.wantLine+"\n")
+		})
+	}
+}
+
+// mockExitStatsProvider returns fixed failure modes.
+type mockExitStatsProvider struct {
+	exits map[string][]metrics.ExitCount
+}
+
+// ServiceExits returns the configured failure modes.
+//
+// Returns:
+//   - map[string][]metrics.ExitCount: failure modes by service
+func (m *mockExitStatsProvider) ServiceExits() map[string][]metrics.ExitCount {
+	return m.exits
+}
+
+// TestServer_Metrics_ServiceFailures verifies the failure gauge of the metrics endpoint.
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_Metrics_ServiceFailures(t *testing.T) {
+	t.Parallel()
+
+	server := daemonhttp.NewServer(&mockHealthProvider{})
+	server.SetBuildInfo(&metrics.BuildInfo{Version: "1.4.0"})
+	server.SetExitStatsProvider(&mockExitStatsProvider{exits: map[string][]metrics.ExitCount{
+		"web": {{Cause: metrics.ExitCause{Signal: "SIGSEGV"}, Count: 1}},
+		"api": {{Cause: metrics.ExitCause{Code: 2}, Count: 3}, {Cause: metrics.ExitCause{Signal: "SIGKILL"}, Count: 1}},
+		"db":  nil,
+	}})
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/metrics", nethttp.NoBody))
+
+	assert.Equal(t, nethttp.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "# TYPE supervizio_service_failures gauge\n"+
+		`supervizio_service_failures{service="api",cause="exit 2"} 3`+"\n"+
+		`supervizio_service_failures{service="api",cause="SIGKILL"} 1`+"\n"+
+		`supervizio_service_failures{service="web",cause="SIGSEGV"} 1`+"\n")
+}
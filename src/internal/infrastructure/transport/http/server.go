@@ -0,0 +1,141 @@
+// Package http provides the HTTP adapter of the daemon API, for clients
+// that only speak plain HTTP such as external load balancers.
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kodflow/daemon/internal/domain/metrics"
+)
+
+// readHeaderTimeout bounds the reading of request headers.
+const readHeaderTimeout time.Duration = 5 * time.Second
+
+// ErrServerAlreadyRunning indicates the server is already running.
+var ErrServerAlreadyRunning error = errors.New("server already running")
+
+// Server serves the HTTP endpoints of the daemon.
+type Server struct {
+	httpServer *http.Server
+	health     HealthProvider
+	buildInfo  *metrics.BuildInfo
+	exits      ExitStatsProvider
+	listener   net.Listener
+	mu         sync.Mutex
+	running    bool
+}
+
+// NewServer creates a new HTTP server.
+//
+// Params:
+//   - health: provider of service health.
+//
+// Returns:
+//   - *Server: configured HTTP server.
+func NewServer(health HealthProvider) *Server {
+	s := &Server{health: health}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /services/{name}/health", s.handleServiceHealth)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	// Return configured server.
+	return s
+}
+
+// Handler returns the handler routing the daemon endpoints.
+//
+// Returns:
+//   - http.Handler: the request router.
+func (s *Server) Handler() http.Handler {
+	// Return router.
+	return s.httpServer.Handler
+}
+
+// Serve starts the HTTP server on the specified address.
+// The provided context controls cancellation during listener setup.
+//
+// Params:
+//   - ctx: context for cancellation and timeout control during listener setup.
+//   - address: network address to listen on (e.g., ":8080").
+//
+// Returns:
+//   - error: if the server fails to start; nil once stopped.
+func (s *Server) Serve(ctx context.Context, address string) error {
+	s.mu.Lock()
+	// Check if server is already running.
+	if s.running {
+		s.mu.Unlock()
+		// Return sentinel error for already running server.
+		return fmt.Errorf("serve: %w", ErrServerAlreadyRunning)
+	}
+
+	lc := net.ListenConfig{}
+	listener, err := lc.Listen(ctx, "tcp", address)
+	// Check if listen failed.
+	if err != nil {
+		s.mu.Unlock()
+		// Return wrapped error.
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	s.listener = listener
+	s.running = true
+	s.mu.Unlock()
+
+	err = s.httpServer.Serve(listener)
+	// A stopped server is no failure.
+	if errors.Is(err, http.ErrServerClosed) {
+		// Return clean stop.
+		return nil
+	}
+	// Return serve error.
+	return err
+}
+
+// Stop gracefully stops the HTTP server, waiting for in-flight requests
+// until ctx is done.
+//
+// Params:
+//   - ctx: bound of the graceful shutdown.
+//
+// Returns:
+//   - error: if in-flight requests did not finish in time.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if server is not running.
+	if !s.running {
+		// Nothing to stop.
+		return nil
+	}
+	s.running = false
+	// Return shutdown result.
+	return s.httpServer.Shutdown(ctx)
+}
+
+// Address returns the server's listening address.
+//
+// Returns:
+//   - string: listening address, or empty if not running.
+func (s *Server) Address() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if listener exists.
+	if s.listener == nil {
+		// Return empty string for no listener.
+		return ""
+	}
+	// Return listener address.
+	return s.listener.Addr().String()
+}
@@ -0,0 +1,58 @@
+// Package http_test provides black-box tests for the http package.
+package http_test
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/internal/domain/health"
+	"github.com/kodflow/daemon/internal/domain/process"
+	daemonhttp "github.com/kodflow/daemon/internal/infrastructure/transport/http"
+)
+
+// TestServer_Serve verifies the server serves until stopped.
+//
+// Goroutine lifecycle: Test launches a server goroutine terminated by
+// server.Stop().
+//
+// Params:
+//   - t: testing context for assertions
+func TestServer_Serve(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockHealthProvider{services: map[string]*health.AggregatedHealth{"api": {ProcessState: process.StateRunning}}}
+	server := daemonhttp.NewServer(provider)
+	assert.Empty(t, server.Address())
+
+	errCh := make(chan error, 1)
+	// Goroutine lifecycle: Starts server, terminated by server.Stop().
+	go func() {
+		errCh <- server.Serve(context.Background(), "127.0.0.1:0")
+	}()
+	require.Eventually(t, func() bool { return server.Address() != "" }, time.Second, 10*time.Millisecond)
+
+	// A running server refuses a second Serve.
+	assert.ErrorIs(t, server.Serve(context.Background(), "127.0.0.1:0"), daemonhttp.ErrServerAlreadyRunning)
+
+	req, err := nethttp.NewRequestWithContext(context.Background(), nethttp.MethodGet, "http://"+server.Address()+"/services/api/health", nethttp.NoBody)
+	require.NoError(t, err)
+	resp, err := nethttp.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+
+	require.NoError(t, server.Stop(context.Background()))
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Serve did not return after Stop")
+	}
+	// Stopping twice is a no-op.
+	assert.NoError(t, server.Stop(context.Background()))
+}
@@ -0,0 +1,71 @@
+// Package proxy provides the TCP relay adapter for supervisor-owned listener proxies.
+// It binds the public endpoint of exposed listeners and forwards connections
+// to the service's local endpoint.
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
+)
+
+// defaultNetwork is the stream network used when a route does not set one.
+const defaultNetwork string = "tcp"
+
+// defaultDialTimeout bounds connection attempts to the service endpoint.
+const defaultDialTimeout time.Duration = 5 * time.Second
+
+// Opener binds TCP relays for proxied listeners.
+// It implements the application proxy.Opener port.
+type Opener struct {
+	// dialTimeout bounds connection attempts to the service endpoint.
+	dialTimeout time.Duration
+}
+
+// New creates a new relay opener.
+//
+// Returns:
+//   - *Opener: the opener with the default dial timeout.
+func New() *Opener {
+	// use default dial timeout
+	return &Opener{dialTimeout: defaultDialTimeout}
+}
+
+// Open binds the route public endpoint and starts forwarding connections.
+//
+// Params:
+//   - route: the proxied listener.
+//
+// Returns:
+//   - appproxy.Relay: the running relay.
+//   - error: if the public endpoint cannot be bound.
+//
+// Goroutine lifecycle:
+//   - Spawns an accept goroutine and one goroutine pair per connection.
+//   - All goroutines terminate once Close is called on the relay.
+func (o *Opener) Open(route appproxy.Route) (appproxy.Relay, error) {
+	// apply default network
+	if route.Network == "" {
+		route.Network = defaultNetwork
+	}
+
+	listener, err := net.Listen(route.Network, route.ListenAddress)
+	// handle bind failure
+	if err != nil {
+		// return error with route context
+		return nil, fmt.Errorf("proxy %s/%s: %w", route.Service, route.Listener, err)
+	}
+
+	relay := newRelay(listener, route, o.dialTimeout)
+	go relay.serve()
+	// return running relay
+	return relay, nil
+}
+
+// Compile-time interface checks.
+var (
+	_ appproxy.Opener = (*Opener)(nil)
+	_ appproxy.Relay  = (*Relay)(nil)
+)
@@ -0,0 +1,206 @@
+// Package proxy_test provides black-box tests for the proxy package.
+package proxy_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
+	"github.com/kodflow/daemon/internal/infrastructure/transport/proxy"
+)
+
+// startEchoServer starts a TCP server echoing each line back.
+//
+// Params:
+//   - t: the testing context.
+//
+// Returns:
+//   - string: the server address.
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	// Echo every connection until the listener closes.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// roundTrip sends a line through the relay and reads the reply.
+//
+// Params:
+//   - addr: the relay address.
+//
+// Returns:
+//   - string: the reply line.
+//   - error: if the exchange fails.
+func roundTrip(addr string) (string, error) {
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	if _, err := conn.Write([]byte("ping\n")); err != nil {
+		return "", err
+	}
+	return bufio.NewReader(conn).ReadString('\n')
+}
+
+// TestOpener_Open tests forwarding and gating through a relay.
+func TestOpener_Open(t *testing.T) {
+	tests := []struct {
+		name      string
+		gate      appproxy.Gate
+		wantReply string
+		wantErr   bool
+	}{
+		{
+			name:      "forwards_without_gate",
+			wantReply: "ping\n",
+		},
+		{
+			name:      "forwards_with_open_gate",
+			gate:      func() bool { return true },
+			wantReply: "ping\n",
+		},
+		{
+			name:    "refuses_with_closed_gate",
+			gate:    func() bool { return false },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := startEchoServer(t)
+			relay, err := proxy.New().Open(appproxy.Route{
+				Service:       "api",
+				Listener:      "http",
+				ListenAddress: "127.0.0.1:0",
+				TargetAddress: target,
+				Gate:          tt.gate,
+			})
+			require.NoError(t, err)
+			defer func() { _ = relay.Close() }()
+
+			reply, err := roundTrip(relay.Addr())
+
+			// Verify the exchange outcome.
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReply, reply)
+		})
+	}
+}
+
+// TestOpener_Open_BindFailure tests that an occupied public endpoint is reported.
+func TestOpener_Open_BindFailure(t *testing.T) {
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = occupied.Close() }()
+
+	_, err = proxy.New().Open(appproxy.Route{
+		Service:       "api",
+		Listener:      "http",
+		ListenAddress: occupied.Addr().String(),
+	})
+
+	// Verify the error names the route.
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "proxy api/http")
+}
+
+// TestRelay_Close tests that a closed relay stops accepting connections.
+func TestRelay_Close(t *testing.T) {
+	relay, err := proxy.New().Open(appproxy.Route{
+		ListenAddress: "127.0.0.1:0",
+		TargetAddress: startEchoServer(t),
+	})
+	require.NoError(t, err)
+	addr := relay.Addr()
+
+	// First close succeeds, second is a no-op.
+	require.NoError(t, relay.Close())
+	require.NoError(t, relay.Close())
+
+	_, err = net.DialTimeout("tcp", addr, time.Second)
+	assert.Error(t, err)
+}
+
+// TestRelay_Drain tests that a draining relay refuses new connections while
+// in-flight ones keep working, and admits traffic again after Resume.
+func TestRelay_Drain(t *testing.T) {
+	relay, err := proxy.New().Open(appproxy.Route{
+		ListenAddress: "127.0.0.1:0",
+		TargetAddress: startEchoServer(t),
+		DrainTimeout:  time.Minute,
+	})
+	require.NoError(t, err)
+	defer func() { _ = relay.Close() }()
+
+	// Establish an in-flight connection before draining.
+	inflight, err := net.DialTimeout("tcp", relay.Addr(), time.Second)
+	require.NoError(t, err)
+	reader := bufio.NewReader(inflight)
+	_ = inflight.SetDeadline(time.Now().Add(2 * time.Second))
+	_, err = inflight.Write([]byte("ping\n"))
+	require.NoError(t, err)
+	_, err = reader.ReadString('\n')
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		relay.Drain()
+		close(done)
+	}()
+
+	// New connections are refused.
+	assert.Eventually(t, func() bool {
+		_, err := roundTrip(relay.Addr())
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// The in-flight connection keeps working.
+	_, err = inflight.Write([]byte("pong\n"))
+	require.NoError(t, err)
+	reply, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "pong\n", reply)
+
+	// Closing it completes the drain.
+	_ = inflight.Close()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("drain did not complete")
+	}
+
+	// Resume admits new connections.
+	relay.Resume()
+	reply, err = roundTrip(relay.Addr())
+	require.NoError(t, err)
+	assert.Equal(t, "ping\n", reply)
+}
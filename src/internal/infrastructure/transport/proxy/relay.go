@@ -0,0 +1,274 @@
+// Package proxy provides the TCP relay adapter for supervisor-owned listener proxies.
+package proxy
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
+)
+
+// acceptRetryDelay is the pause after a transient accept failure.
+const acceptRetryDelay time.Duration = 100 * time.Millisecond
+
+// Relay forwards connections accepted on a public endpoint to a service.
+type Relay struct {
+	// listener is the bound public endpoint.
+	listener net.Listener
+	// route describes the proxied listener.
+	route appproxy.Route
+	// dialer connects to the service endpoint.
+	dialer *net.Dialer
+	// mu protects conns, closed, draining and idle.
+	mu sync.Mutex
+	// conns tracks open client and upstream connections.
+	conns map[net.Conn]struct{}
+	// closed indicates Close was called.
+	closed bool
+	// draining indicates new connections are refused until Resume.
+	draining bool
+	// idle is closed once the last tracked connection ends during a drain.
+	idle chan struct{}
+}
+
+// newRelay creates a relay over a bound listener.
+//
+// Params:
+//   - listener: the bound public endpoint.
+//   - route: the proxied listener.
+//   - dialTimeout: timeout for service connections.
+//
+// Returns:
+//   - *Relay: the relay, not yet serving.
+func newRelay(listener net.Listener, route appproxy.Route, dialTimeout time.Duration) *Relay {
+	// construct relay with empty connection set
+	return &Relay{
+		listener: listener,
+		route:    route,
+		dialer:   &net.Dialer{Timeout: dialTimeout},
+		conns:    make(map[net.Conn]struct{}),
+	}
+}
+
+// Addr returns the bound public address.
+//
+// Returns:
+//   - string: the address in host:port format.
+func (r *Relay) Addr() string {
+	// report listener address
+	return r.listener.Addr().String()
+}
+
+// Drain refuses new connections and waits for in-flight ones to finish.
+// Connections still open when the route drain timeout elapses are closed.
+// The public endpoint stays bound so Resume can admit traffic again.
+func (r *Relay) Drain() {
+	r.mu.Lock()
+	r.draining = true
+	idle := r.idle
+	// join a drain already in progress or start a new one
+	if idle == nil {
+		idle = make(chan struct{})
+		// nothing in flight: drained immediately
+		if len(r.conns) == 0 {
+			close(idle)
+		} else {
+			r.idle = idle
+		}
+	}
+	r.mu.Unlock()
+
+	timer := time.NewTimer(r.route.DrainTimeout)
+	defer timer.Stop()
+	// wait for in-flight connections or the drain timeout
+	select {
+	case <-idle:
+		// drained gracefully
+		return
+	case <-timer.C:
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// cut connections that outlived the drain window
+	for conn := range r.conns {
+		_ = conn.Close()
+	}
+}
+
+// Resume admits new connections again after Drain.
+func (r *Relay) Resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// reopen admission
+	r.draining = false
+}
+
+// Close stops accepting connections and closes forwarded connections.
+//
+// Returns:
+//   - error: if the listener cannot be closed.
+func (r *Relay) Close() error {
+	err := r.listener.Close()
+
+	r.mu.Lock()
+	r.closed = true
+	// close every forwarded connection
+	for conn := range r.conns {
+		_ = conn.Close()
+	}
+	clear(r.conns)
+	// release a drain waiting on the closed connections
+	if r.idle != nil {
+		close(r.idle)
+		r.idle = nil
+	}
+	r.mu.Unlock()
+
+	// ignore double close
+	if errors.Is(err, net.ErrClosed) {
+		// already closed
+		return nil
+	}
+	// return listener close result
+	return err
+}
+
+// serve accepts connections until the listener is closed.
+func (r *Relay) serve() {
+	// accept loop
+	for {
+		conn, err := r.listener.Accept()
+		// handle accept failure
+		if err != nil {
+			// stop once the listener is closed
+			if errors.Is(err, net.ErrClosed) {
+				// relay closed
+				return
+			}
+			// back off on transient failures such as fd exhaustion
+			time.Sleep(acceptRetryDelay)
+			continue
+		}
+		go r.handle(conn)
+	}
+}
+
+// handle forwards a single client connection to the service.
+//
+// Params:
+//   - client: the accepted public connection.
+func (r *Relay) handle(client net.Conn) {
+	// refuse traffic while draining or while the gate is closed
+	if !r.admitting() || !r.route.Admits() {
+		_ = client.Close()
+		// connection refused by gate
+		return
+	}
+
+	upstream, err := r.dialer.Dial(r.route.Network, r.route.TargetAddress)
+	// drop the client when the service is unreachable
+	if err != nil {
+		_ = client.Close()
+		// service unreachable
+		return
+	}
+
+	// register both ends so Close and Drain can interrupt them
+	if !r.track(client, upstream) {
+		_ = client.Close()
+		_ = upstream.Close()
+		// relay closed or draining meanwhile
+		return
+	}
+	defer r.untrack(client, upstream)
+
+	pipe(client, upstream)
+}
+
+// admitting reports whether the relay accepts new connections.
+//
+// Returns:
+//   - bool: false while the relay is draining or closed.
+func (r *Relay) admitting() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// refuse while draining or closed
+	return !r.closed && !r.draining
+}
+
+// track registers connections unless the relay is closed or draining.
+//
+// Params:
+//   - conns: the connections to register.
+//
+// Returns:
+//   - bool: false if the relay is closed or draining.
+func (r *Relay) track(conns ...net.Conn) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// refuse registration after close or during a drain
+	if r.closed || r.draining {
+		// relay not admitting
+		return false
+	}
+	// register each connection
+	for _, conn := range conns {
+		r.conns[conn] = struct{}{}
+	}
+	// registered
+	return true
+}
+
+// untrack removes connections once forwarding is done and signals a
+// pending drain when the last one ends.
+//
+// Params:
+//   - conns: the connections to remove.
+func (r *Relay) untrack(conns ...net.Conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// remove each connection
+	for _, conn := range conns {
+		delete(r.conns, conn)
+	}
+	// wake a drain waiting for in-flight connections
+	if r.idle != nil && len(r.conns) == 0 {
+		close(r.idle)
+		r.idle = nil
+	}
+}
+
+// pipe copies data in both directions until both sides are done, then
+// closes both connections.
+//
+// Params:
+//   - a: the first connection.
+//   - b: the second connection.
+func pipe(a, b net.Conn) {
+	var wg sync.WaitGroup
+	wg.Go(func() { copyHalf(a, b) })
+	wg.Go(func() { copyHalf(b, a) })
+	wg.Wait()
+	_ = a.Close()
+	_ = b.Close()
+}
+
+// copyHalf copies src to dst and propagates end of stream to dst.
+//
+// Params:
+//   - dst: the destination connection.
+//   - src: the source connection.
+func copyHalf(dst, src net.Conn) {
+	_, _ = io.Copy(dst, src)
+	// half-close so the peer sees end of stream
+	if tcp, ok := dst.(*net.TCPConn); ok {
+		_ = tcp.CloseWrite()
+		// peer notified
+		return
+	}
+	_ = dst.Close()
+}
@@ -0,0 +1,97 @@
+// Package proxy provides internal tests for relay.go.
+package proxy
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appproxy "github.com/kodflow/daemon/internal/application/proxy"
+)
+
+// Test_Relay_track tests connection registration before and after close.
+func Test_Relay_track(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to create listener: %v", err)
+	}
+	relay := newRelay(listener, appproxy.Route{}, defaultDialTimeout)
+	client, server := net.Pipe()
+	defer func() { _ = server.Close() }()
+
+	// Registration succeeds while open.
+	assert.True(t, relay.track(client))
+	assert.Len(t, relay.conns, 1)
+	relay.untrack(client)
+	assert.Empty(t, relay.conns)
+
+	// Registration is refused once closed.
+	_ = relay.Close()
+	assert.False(t, relay.track(client))
+}
+
+// Test_Relay_Drain tests that a drain refuses new connections and waits for in-flight ones.
+func Test_Relay_Drain(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to create listener: %v", err)
+	}
+	relay := newRelay(listener, appproxy.Route{DrainTimeout: time.Minute}, defaultDialTimeout)
+	defer func() { _ = relay.Close() }()
+	client, server := net.Pipe()
+	defer func() { _ = server.Close() }()
+	assert.True(t, relay.track(client))
+
+	done := make(chan struct{})
+	go func() {
+		relay.Drain()
+		close(done)
+	}()
+
+	// New connections are refused while in-flight ones keep the drain open.
+	assert.Eventually(t, func() bool { return !relay.admitting() }, time.Second, 10*time.Millisecond)
+	assert.False(t, relay.track(server))
+	select {
+	case <-done:
+		t.Fatal("drain returned with a connection in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The drain completes once the last connection ends.
+	relay.untrack(client)
+	assert.Eventually(t, func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	// Resume admits connections again.
+	relay.Resume()
+	assert.True(t, relay.admitting())
+	assert.True(t, relay.track(client))
+}
+
+// Test_Relay_Drain_Timeout tests that connections outliving the drain timeout are closed.
+func Test_Relay_Drain_Timeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to create listener: %v", err)
+	}
+	relay := newRelay(listener, appproxy.Route{DrainTimeout: 50 * time.Millisecond}, defaultDialTimeout)
+	defer func() { _ = relay.Close() }()
+	client, server := net.Pipe()
+	defer func() { _ = server.Close() }()
+	assert.True(t, relay.track(client))
+
+	relay.Drain()
+
+	// The lingering connection was closed by the drain.
+	_, err = client.Write([]byte("x"))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+}
@@ -509,6 +509,10 @@ func stateColorAndText(state process.State) (colorFn func(*ansi.Theme) string, t
 	case process.StateStopping:
 		// Return warning color for pending shutdown.
 		return func(th *ansi.Theme) string { return th.Warning }, "stopping"
+	// Handle skipped state with muted color.
+	case process.StateSkipped:
+		// Return muted color for services left stopped by their conditions.
+		return func(th *ansi.Theme) string { return th.Muted }, "skipped"
 	// Handle unknown or future states.
 	default:
 		// Return muted color for unknown states.
@@ -56,6 +56,10 @@ func (s *StatusIndicator) ProcessState(state process.State) string {
 	case process.StateFailed:
 		// Return red error icon.
 		return s.Theme.Error + s.Icons.Failed + ansi.Reset
+	// Skipped state.
+	case process.StateSkipped:
+		// Return muted stopped icon.
+		return s.Theme.Muted + s.Icons.Stopped + ansi.Reset
 	// Unknown state.
 	default:
 		// Return muted unknown icon.
@@ -105,6 +109,10 @@ func stateColorText(state process.State) (colorFn func(*ansi.Theme) string, text
 	case process.StateFailed:
 		// Return error color for failed processes.
 		return func(th *ansi.Theme) string { return th.Error }, "failed", "fail"
+	// Handle skipped state with muted color.
+	case process.StateSkipped:
+		// Return muted color for services left stopped by their conditions.
+		return func(th *ansi.Theme) string { return th.Muted }, "skipped", "skip"
 	// Handle unknown or future states.
 	default:
 		// Return muted color for unknown states.
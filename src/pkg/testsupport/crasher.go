@@ -0,0 +1,183 @@
+// Package testsupport runs a supervisor inside a Go test, without a daemon.
+package testsupport
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kodflow/daemon/internal/infrastructure/process/mountns"
+	"github.com/kodflow/daemon/internal/infrastructure/process/pidns"
+)
+
+// crasherName is the name the test binary answers to as the crasher.
+const crasherName string = "supervizio-crasher"
+
+// Main runs the tests of a package using a Harness. Call it from TestMain:
+//
+//	func TestMain(m *testing.M) { testsupport.Main(m) }
+//
+// When the test binary is started as the crasher, it behaves as configured
+// by its arguments instead of running the tests. Started as the mount or
+// PID namespace helper of a sandboxed service, it acts as the daemon does. The crasher is a service
+// command for tests, referenced as {{crasher}} in a harness configuration:
+//
+//	--exit CODE        exit code (default 0)
+//	--delay DURATION   run this long before exiting (default 0, exit at once)
+//	--port PORT        listen for TCP connections on this port
+//	--http             serve GET /health on the port
+//	--unhealthy        answer /health with 503
+//	--ignore-term      ignore SIGTERM
+//	--term-delay D     exit D after SIGTERM (slow shutdown)
+//
+// Params:
+//   - m: the tests of the package.
+func Main(m *testing.M) {
+	// sandboxed services start through the test binary too
+	mountns.Init()
+	pidns.Init()
+	// run as the crasher when started through its link
+	if filepath.Base(os.Args[0]) == crasherName {
+		os.Exit(runCrasher(os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+// linkCrasher links the crasher name to the test binary in a directory.
+//
+// Params:
+//   - dir: the directory of the link.
+//
+// Returns:
+//   - string: the path of the crasher.
+//   - error: the lookup or link error.
+func linkCrasher(dir string) (string, error) {
+	exe, err := os.Executable()
+	// the test binary must be found
+	if err != nil {
+		// return lookup error
+		return "", err
+	}
+	path := filepath.Join(dir, crasherName)
+	// return path with link result
+	return path, os.Symlink(exe, path)
+}
+
+// runCrasher behaves as requested by the crasher arguments.
+//
+// Params:
+//   - args: the crasher arguments.
+//
+// Returns:
+//   - int: the exit code.
+func runCrasher(args []string) int {
+	flags := flag.NewFlagSet(crasherName, flag.ContinueOnError)
+	exitCode := flags.Int("exit", 0, "exit code")
+	delay := flags.Duration("delay", 0, "run this long before exiting")
+	port := flags.Int("port", 0, "TCP port to listen on")
+	serveHTTP := flags.Bool("http", false, "serve GET /health on the port")
+	unhealthy := flags.Bool("unhealthy", false, "answer /health with 503")
+	ignoreTerm := flags.Bool("ignore-term", false, "ignore SIGTERM")
+	termDelay := flags.Duration("term-delay", 0, "exit this long after SIGTERM")
+	// malformed arguments are a test bug
+	if err := flags.Parse(args); err != nil {
+		// return usage failure
+		return 2
+	}
+
+	// answer SIGTERM as configured
+	if *ignoreTerm {
+		signal.Ignore(syscall.SIGTERM)
+	} else {
+		go exitOnSignal(*termDelay)
+	}
+	// listen when a port is given
+	if *port > 0 {
+		// a busy port fails the crasher
+		if err := listen(*port, *serveHTTP, *unhealthy); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", crasherName, err)
+			// return listen failure
+			return 1
+		}
+	}
+	time.Sleep(*delay)
+	// return requested exit code
+	return *exitCode
+}
+
+// exitOnSignal exits the crasher on SIGTERM or SIGINT.
+//
+// Params:
+//   - delay: how long to keep running after the signal.
+func exitOnSignal(delay time.Duration) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	<-signals
+	time.Sleep(delay)
+	os.Exit(0)
+}
+
+// listen accepts connections on a port, answering /health over HTTP if asked.
+//
+// Params:
+//   - port: the TCP port.
+//   - serveHTTP: whether to serve HTTP.
+//   - unhealthy: whether /health answers 503.
+//
+// Returns:
+//   - error: the listen error.
+func listen(port int, serveHTTP, unhealthy bool) error {
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	// the port must be free
+	if err != nil {
+		// return listen error
+		return err
+	}
+	// accept raw connections without HTTP
+	if !serveHTTP {
+		go acceptAndClose(ln)
+		// return listening
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		// report the configured health
+		if unhealthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second}
+	go func() {
+		// serve until the crasher exits
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", crasherName, err)
+		}
+	}()
+	// return listening
+	return nil
+}
+
+// acceptAndClose accepts connections and closes them at once.
+//
+// Params:
+//   - ln: the listener.
+func acceptAndClose(ln net.Listener) {
+	// accept until the crasher exits
+	for {
+		conn, err := ln.Accept()
+		// the listener is closed
+		if err != nil {
+			// stop accepting
+			return
+		}
+		_ = conn.Close()
+	}
+}
@@ -0,0 +1,335 @@
+// Package testsupport runs a supervisor inside a Go test, without a daemon.
+// A Harness loads a configuration into a temporary directory, starts the
+// services as real processes and drives the restart backoff with a fake
+// clock, so integration tests observe the behavior of the daemon without
+// sleeping through its delays.
+package testsupport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kodflow/daemon/internal/application/supervisor"
+	domain "github.com/kodflow/daemon/internal/domain/process"
+	"github.com/kodflow/daemon/internal/domain/shared"
+	"github.com/kodflow/daemon/internal/domain/storage"
+	"github.com/kodflow/daemon/internal/infrastructure/observability/healthcheck"
+	"github.com/kodflow/daemon/internal/infrastructure/persistence/config/yaml"
+	"github.com/kodflow/daemon/internal/infrastructure/process/control"
+	"github.com/kodflow/daemon/internal/infrastructure/process/credentials"
+	"github.com/kodflow/daemon/internal/infrastructure/process/executor"
+)
+
+const (
+	// configFile is the name of the configuration written to the directory.
+	configFile string = "supervizio.yaml"
+
+	// logDir is the directory of the service logs, inside the harness directory.
+	logDir string = "logs"
+
+	// probeTimeout is the timeout of probes configuring none.
+	probeTimeout time.Duration = 5 * time.Second
+
+	// pollInterval is how often Wait methods check the supervisor.
+	pollInterval time.Duration = 10 * time.Millisecond
+)
+
+// Event is an event emitted by a supervised service.
+type Event = storage.EventRecord
+
+// Clock is the fake clock timing the restart backoff and the health probes.
+// Advance moves it forward and fires the timers that became due.
+type Clock = shared.FakeClock
+
+// Harness is a supervisor running in the test process.
+// Create it with New, then Start it; it is stopped when the test ends.
+type Harness struct {
+	// t is the test owning the harness.
+	t testing.TB
+	// dir is the temporary directory of the configuration and logs.
+	dir string
+	// clock drives the supervisor timers.
+	clock *Clock
+	// sup is the supervisor under test.
+	sup *supervisor.Supervisor
+
+	// mu protects events.
+	mu sync.Mutex
+	// events holds the emitted events, oldest first.
+	events []Event
+}
+
+// New loads a configuration into a fresh temporary directory and builds a
+// supervisor for it, not started. In the configuration, {{dir}} is replaced
+// by the directory and {{crasher}} by the path of the crasher binary (see
+// Main). Service logs are written under the directory. The test fails if
+// the configuration does not load.
+//
+// Params:
+//   - t: the test owning the harness.
+//   - config: the YAML configuration.
+//
+// Returns:
+//   - *Harness: the harness, stopped.
+func New(t testing.TB, config string) *Harness {
+	t.Helper()
+	dir := t.TempDir()
+	crasher, err := linkCrasher(dir)
+	// the crasher is reached through the test binary
+	if err != nil {
+		t.Fatalf("testsupport: linking crasher: %v", err)
+	}
+	config = strings.NewReplacer("{{dir}}", dir, "{{crasher}}", crasher).Replace(config)
+	path := filepath.Join(dir, configFile)
+	// write the configuration where reloads read it
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("testsupport: writing config: %v", err)
+	}
+
+	loader := yaml.NewLoader()
+	cfg, err := loader.Load(path)
+	// the configuration must be valid
+	if err != nil {
+		t.Fatalf("testsupport: loading config: %v", err)
+	}
+	cfg.Logging.BaseDir = filepath.Join(dir, logDir)
+
+	sup, err := supervisor.NewSupervisor(cfg, loader, executor.NewWithDeps(credentials.New(), control.New()), nil)
+	// the supervisor refuses what validation cannot check
+	if err != nil {
+		t.Fatalf("testsupport: creating supervisor: %v", err)
+	}
+	h := &Harness{t: t, dir: dir, clock: shared.NewFakeClock(time.Now()), sup: sup}
+	sup.SetClock(h.clock)
+	sup.SetProberFactory(healthcheck.NewFactory(probeTimeout))
+	sup.SetEventHandler(h.record)
+	// return stopped harness
+	return h
+}
+
+// Start starts the supervisor and its services. The supervisor is stopped
+// when the test ends. The test fails if a service cannot start.
+func (h *Harness) Start() {
+	h.t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	// stop the services before the directory is removed
+	h.t.Cleanup(func() {
+		_ = h.sup.Stop()
+		cancel()
+	})
+	// services failing to start fail the test
+	if err := h.sup.Start(ctx); err != nil {
+		h.t.Fatalf("testsupport: starting supervisor: %v", err)
+	}
+}
+
+// Dir returns the temporary directory holding the configuration and logs.
+//
+// Returns:
+//   - string: the directory, removed when the test ends.
+func (h *Harness) Dir() string {
+	// return harness directory
+	return h.dir
+}
+
+// Clock returns the fake clock of the supervisor.
+//
+// Returns:
+//   - *Clock: the clock, stopped at the creation of the harness.
+func (h *Harness) Clock() *Clock {
+	// return fake clock
+	return h.clock
+}
+
+// State returns the state of a service: stopped, starting, running,
+// stopping, failed or skipped.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - string: the state, empty for unknown services.
+func (h *Harness) State(service string) string {
+	info, ok := h.sup.Services()[service]
+	// unknown service
+	if !ok {
+		// return no state
+		return ""
+	}
+	// return state name
+	return info.State.String()
+}
+
+// PID returns the process ID of a service.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - int: the process ID, zero when the service has no process.
+func (h *Harness) PID(service string) int {
+	// return process ID of the service
+	return h.sup.Services()[service].PID
+}
+
+// Events returns the events emitted by a service so far.
+//
+// Params:
+//   - service: the service name, empty for the events of every service.
+//
+// Returns:
+//   - []Event: the events, oldest first.
+func (h *Harness) Events(service string) []Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var events []Event
+	// keep the events of the service
+	for i := range h.events {
+		// match every service when none is given
+		if service == "" || h.events[i].Service == service {
+			events = append(events, h.events[i])
+		}
+	}
+	// return copied events
+	return events
+}
+
+// WaitState waits until a service reaches a state. The test fails if it
+// does not within the timeout, measured in real time.
+//
+// Params:
+//   - service: the service name.
+//   - state: the state, as returned by State.
+//   - timeout: how long to wait.
+func (h *Harness) WaitState(service, state string, timeout time.Duration) {
+	h.t.Helper()
+	// poll the supervisor status
+	if !h.poll(timeout, func() bool { return h.State(service) == state }) {
+		h.t.Fatalf("testsupport: service %s is %s, not %s, after %v", service, h.State(service), state, timeout)
+	}
+}
+
+// WaitEvent waits until a service has emitted count events of a type,
+// counting from the start. The test fails if it has not within the timeout,
+// measured in real time.
+//
+// Params:
+//   - service: the service name.
+//   - eventType: the event type, such as started, failed or restarting.
+//   - count: the number of events to wait for.
+//   - timeout: how long to wait.
+//
+// Returns:
+//   - Event: the last awaited event.
+func (h *Harness) WaitEvent(service, eventType string, count int, timeout time.Duration) Event {
+	h.t.Helper()
+	var found []Event
+	match := func() bool {
+		found = found[:0]
+		events := h.Events(service)
+		// keep the events of the type
+		for i := range events {
+			// compare type names
+			if events[i].Type == eventType {
+				found = append(found, events[i])
+			}
+		}
+		// return whether enough were emitted
+		return len(found) >= count
+	}
+	// poll the recorded events
+	if !h.poll(timeout, match) {
+		h.t.Fatalf("testsupport: service %s emitted %d %s events, not %d, after %v", service, len(found), eventType, count, timeout)
+	}
+	// return the last awaited event
+	return found[count-1]
+}
+
+// StartService starts a stopped service.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - error: an error if the service is unknown or fails to start.
+func (h *Harness) StartService(service string) error {
+	// delegate to supervisor
+	return h.sup.StartService(service)
+}
+
+// StopService stops a service.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - error: an error if the service is unknown or fails to stop.
+func (h *Harness) StopService(service string) error {
+	// delegate to supervisor
+	return h.sup.StopService(service)
+}
+
+// RestartService restarts a service.
+//
+// Params:
+//   - service: the service name.
+//
+// Returns:
+//   - error: an error if the service is unknown or fails to restart.
+func (h *Harness) RestartService(service string) error {
+	// delegate to supervisor
+	return h.sup.RestartService(service)
+}
+
+// record keeps an event emitted by the supervisor.
+//
+// Params:
+//   - service: the service name.
+//   - event: the event.
+//   - _: the service statistics, unused.
+func (h *Harness) record(service string, event *domain.Event, _ *supervisor.ServiceStatsSnapshot) {
+	rec := Event{
+		Timestamp: event.Timestamp,
+		Service:   service,
+		Type:      event.Name(),
+		PID:       event.PID,
+		ExitCode:  event.ExitCode,
+		Labels:    event.Labels,
+	}
+	// keep the error text only
+	if event.Error != nil {
+		rec.Error = event.Error.Error()
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	rec.Seq = uint64(len(h.events)) + 1
+	h.events = append(h.events, rec)
+}
+
+// poll checks a condition until it holds or the timeout expires.
+//
+// Params:
+//   - timeout: how long to wait, in real time.
+//   - cond: the condition.
+//
+// Returns:
+//   - bool: true if the condition held in time.
+func (h *Harness) poll(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	// check until the deadline
+	for !cond() {
+		// give up at the deadline
+		if time.Now().After(deadline) {
+			// return timeout
+			return false
+		}
+		time.Sleep(pollInterval)
+	}
+	// return condition met
+	return true
+}
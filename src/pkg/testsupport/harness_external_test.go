@@ -0,0 +1,108 @@
+// Package testsupport_test provides black-box tests for the testsupport package.
+package testsupport_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/daemon/pkg/testsupport"
+)
+
+// TestMain runs the tests, or the crasher when started as it.
+//
+// Params:
+//   - m: the tests of the package.
+func TestMain(m *testing.M) {
+	testsupport.Main(m)
+}
+
+// TestHarness_restartAfterBackoff verifies that a failing service is
+// restarted only once the fake clock passes the restart delay.
+//
+// Params:
+//   - t: the testing context.
+func TestHarness_restartAfterBackoff(t *testing.T) {
+	h := testsupport.New(t, `version: "1"
+services:
+  - name: worker
+    command: "{{crasher}}"
+    args: ["--exit", "3"]
+    restart:
+      policy: on-failure
+      max_retries: 5
+      delay: 1m
+      delay_max: 1m
+`)
+	h.Start()
+
+	failed := h.WaitEvent("worker", "failed", 1, 5*time.Second)
+	assert.Equal(t, 3, failed.ExitCode)
+	assert.Len(t, h.Events("worker"), len(h.Events("")))
+
+	// The restart waits for the delay on the fake clock.
+	h.WaitEvent("worker", "restarting", 1, 5*time.Second)
+	h.Clock().BlockUntil(1)
+	h.Clock().Advance(59 * time.Second)
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, h.Events("worker"), 3)
+	h.Clock().Advance(time.Second)
+	h.WaitEvent("worker", "started", 2, 5*time.Second)
+}
+
+// TestHarness_services verifies the state, control and directory of services.
+//
+// Params:
+//   - t: the testing context.
+func TestHarness_services(t *testing.T) {
+	h := testsupport.New(t, `version: "1"
+services:
+  - name: api
+    command: "{{crasher}}"
+    args: ["--delay", "1h"]
+    working_dir: "{{dir}}"
+`)
+	assert.Equal(t, "stopped", h.State("api"))
+	assert.Empty(t, h.State("unknown"))
+	h.Start()
+
+	h.WaitState("api", "running", 5*time.Second)
+	pid := h.PID("api")
+	assert.Positive(t, pid)
+	require.NoError(t, h.RestartService("api"))
+	h.WaitEvent("api", "started", 2, 5*time.Second)
+	assert.NotEqual(t, pid, h.PID("api"))
+	assert.Error(t, h.StopService("unknown"))
+
+	_, err := os.Stat(filepath.Join(h.Dir(), "supervizio.yaml"))
+	assert.NoError(t, err)
+}
+
+// TestHarness_pidNamespace verifies that a service in its own PID namespace
+// reports the exit code of its command, through the namespace init.
+//
+// Params:
+//   - t: the testing context.
+func TestHarness_pidNamespace(t *testing.T) {
+	// PID namespaces need CAP_SYS_ADMIN.
+	if os.Geteuid() != 0 {
+		t.Skip("requires root")
+	}
+	h := testsupport.New(t, `version: "1"
+services:
+  - name: worker
+    command: "{{crasher}}"
+    args: ["--exit", "3"]
+    pid_namespace: true
+    restart:
+      policy: never
+`)
+	h.Start()
+
+	failed := h.WaitEvent("worker", "failed", 1, 5*time.Second)
+	assert.Equal(t, 3, failed.ExitCode)
+}